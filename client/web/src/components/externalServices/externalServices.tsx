@@ -22,6 +22,7 @@ import azureDevOpsSchemaJSON from '../../../../../schema/azuredevops.schema.json
 import bitbucketCloudSchemaJSON from '../../../../../schema/bitbucket_cloud.schema.json'
 import bitbucketServerSchemaJSON from '../../../../../schema/bitbucket_server.schema.json'
 import gerritSchemaJSON from '../../../../../schema/gerrit.schema.json'
+import giteaSchemaJSON from '../../../../../schema/gitea.schema.json'
 import githubSchemaJSON from '../../../../../schema/github.schema.json'
 import gitlabSchemaJSON from '../../../../../schema/gitlab.schema.json'
 import gitoliteSchemaJSON from '../../../../../schema/gitolite.schema.json'
@@ -1389,6 +1390,38 @@ const GERRIT: AddExternalServiceOptions = {
     status: 'beta',
 }
 
+const GITEA: AddExternalServiceOptions = {
+    kind: ExternalServiceKind.GITEA,
+    title: 'Gitea / Forgejo',
+    icon: GitIcon,
+    jsonSchema: giteaSchemaJSON,
+    defaultDisplayName: 'Gitea',
+    defaultConfig: `{
+  "url": "https://gitea.example.com",
+  "token": "<access token>",
+  "orgs": []
+}`,
+    Instructions: () => (
+        <div>
+            <ol>
+                <li>
+                    In the configuration below, set <Field>url</Field> to the URL of your Gitea or Forgejo instance.
+                </li>
+                <li>
+                    Create an access token in <strong>Settings &gt; Applications</strong> with the{' '}
+                    <Code>read:repository</Code>, <Code>read:organization</Code> and <Code>read:user</Code> scopes
+                    (add <Code>write:repository</Code> to create pull requests with batch changes), and set it as the
+                    value of <Field>token</Field>.
+                </li>
+                <li>
+                    Use <Field>orgs</Field> and <Field>repos</Field> to select the repositories to sync.
+                </li>
+            </ol>
+        </div>
+    ),
+    editorActions: [],
+}
+
 const AZUREDEVOPS: AddExternalServiceOptions = {
     kind: ExternalServiceKind.AZUREDEVOPS,
     title: 'Azure DevOps',
@@ -1604,6 +1637,7 @@ export const codeHostExternalServices: Record<string, AddExternalServiceOptions>
     gitolite: GITOLITE,
     git: GENERIC_GIT,
     gerrit: GERRIT,
+    gitea: GITEA,
     azuredevops: AZUREDEVOPS,
     phabricator: PHABRICATOR_SERVICE,
     ...(window.context?.experimentalFeatures?.perforce !== 'disabled' ? { perforce: PERFORCE } : {}),
@@ -1637,6 +1671,7 @@ export const defaultExternalServices: Record<ExternalServiceKind, AddExternalSer
     [ExternalServiceKind.AWSCODECOMMIT]: AWS_CODE_COMMIT,
     [ExternalServiceKind.PERFORCE]: PERFORCE,
     [ExternalServiceKind.GERRIT]: GERRIT,
+    [ExternalServiceKind.GITEA]: GITEA,
     [ExternalServiceKind.PAGURE]: PAGURE,
     [ExternalServiceKind.GOMODULES]: GO_MODULES,
     [ExternalServiceKind.JVMPACKAGES]: JVM_PACKAGES,
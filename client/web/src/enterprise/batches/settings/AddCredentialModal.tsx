@@ -60,6 +60,11 @@ const scopeRequirements: Record<ExternalServiceKind, JSX.Element> = {
         </span>
     ),
     [ExternalServiceKind.GERRIT]: <span />,
+    [ExternalServiceKind.GITEA]: (
+        <span>
+            with <Code>read:user</Code> and <Code>write:repository</Code> scopes.
+        </span>
+    ),
     [ExternalServiceKind.PERFORCE]: <span>with the ability to shelve changelists.</span>,
     // These are just for type completeness and serve as placeholders for a bright future.
     [ExternalServiceKind.GITOLITE]: <span>Unsupported</span>,
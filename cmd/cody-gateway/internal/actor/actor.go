@@ -200,6 +200,49 @@ func (a *Actor) Limiter(
 	}, true
 }
 
+// TokenBudgets returns the limiters of the token budgets that apply to the
+// actor's requests to the given model for the feature, if any.
+func (a *Actor) TokenBudgets(
+	redis limiter.RedisStore,
+	feature codygateway.Feature,
+	model string,
+	budgets []TokenBudget,
+	tokenBudgetNotifier notify.TokenBudgetNotifier,
+) limiter.TokenBudgets {
+	if a == nil {
+		// Not logged in, no budget applicable.
+		return nil
+	}
+
+	var limiters limiter.TokenBudgets
+	for _, budget := range budgets {
+		if !budget.AppliesTo(feature, model) {
+			continue
+		}
+
+		// Budgets of the same feature and model with different intervals split
+		// their windows into different buckets, so they need separate counters.
+		budgetModel := budget.Model
+		budgetPrefix := fmt.Sprintf("tokens:%s:%s:%s:", feature, budgetModel, budget.Interval)
+		if budgetModel == "" {
+			budgetPrefix = fmt.Sprintf("tokens:%s:*:%s:", feature, budget.Interval)
+		}
+
+		limiters = append(limiters, limiter.TokenLimiter{
+			LimiterName: "actor.TokenBudget",
+			Identifier:  a.ID,
+			Redis:       limiter.NewPrefixRedisStore(budgetPrefix, redis),
+			Limit:       budget.Limit,
+			Interval:    budget.Interval,
+			NowFunc:     time.Now,
+			BudgetAlerter: func(ctx context.Context, usageRatio float32, ttl time.Duration) {
+				tokenBudgetNotifier(ctx, a, feature, budgetModel, usageRatio, ttl)
+			},
+		})
+	}
+	return limiters
+}
+
 // ErrAccessTokenDenied is returned when the access token is denied due to the
 // reason.
 type ErrAccessTokenDenied struct {
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/sourcegraph/log"
@@ -58,6 +59,67 @@ func (r *RateLimit) IsValid() bool {
 	return r != nil && r.Interval > 0 && r.Limit > 0 && len(r.AllowedModels) > 0
 }
 
+// TokenBudget is a budget of tokens that each actor may consume over a rolling
+// window for a feature. Tokens consumed by both prompts and completions count
+// towards the budget.
+type TokenBudget struct {
+	Feature codygateway.Feature
+	// Model optionally restricts the budget to a model in Cody Gateway's model
+	// configuration format, "$PROVIDER/$MODEL_NAME". If empty, the budget
+	// applies to all models of the feature combined.
+	Model string
+
+	Limit    int64
+	Interval time.Duration
+}
+
+// AppliesTo returns true if requests to the given model for the given feature
+// count towards the budget.
+func (b TokenBudget) AppliesTo(feature codygateway.Feature, model string) bool {
+	return b.Feature == feature && (b.Model == "" || strings.EqualFold(b.Model, model))
+}
+
+// ParseTokenBudgets parses a comma-separated list of token budgets of the form
+// "feature[:model]=limit/interval", for example
+// "chat_completions=2000000/24h,code_completions:fireworks/starcoder=500000/1h".
+func ParseTokenBudgets(s string) ([]TokenBudget, error) {
+	var budgets []TokenBudget
+	for _, spec := range strings.Split(s, ",") {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+
+		scope, limit, ok := strings.Cut(spec, "=")
+		if !ok {
+			return nil, errors.Newf("invalid token budget %q: expected feature[:model]=limit/interval", spec)
+		}
+
+		var budget TokenBudget
+		feature, model, _ := strings.Cut(scope, ":")
+		budget.Feature = codygateway.Feature(feature)
+		if !budget.Feature.IsValid() {
+			return nil, errors.Newf("invalid token budget %q: unknown feature %q", spec, feature)
+		}
+		budget.Model = model
+
+		limit, interval, ok := strings.Cut(limit, "/")
+		if !ok {
+			return nil, errors.Newf("invalid token budget %q: expected limit/interval", spec)
+		}
+		var err error
+		if budget.Limit, err = strconv.ParseInt(limit, 10, 64); err != nil || budget.Limit <= 0 {
+			return nil, errors.Newf("invalid token budget %q: limit must be a positive integer", spec)
+		}
+		if budget.Interval, err = time.ParseDuration(interval); err != nil || budget.Interval <= 0 {
+			return nil, errors.Newf("invalid token budget %q: interval must be a positive duration", spec)
+		}
+
+		budgets = append(budgets, budget)
+	}
+	return budgets, nil
+}
+
 type concurrencyLimiter struct {
 	logger  log.Logger
 	actor   *Actor
@@ -174,3 +174,41 @@ func TestAsErrConcurrencyLimitExceeded(t *testing.T) {
 	assert.True(t, errors.As(err, &ErrConcurrencyLimitExceeded{}))
 	assert.True(t, errors.As(errors.Wrap(err, "foo"), &ErrConcurrencyLimitExceeded{}))
 }
+
+func TestParseTokenBudgets(t *testing.T) {
+	budgets, err := ParseTokenBudgets("chat_completions=2000000/24h, code_completions:fireworks/accounts/fireworks/models/starcoder-7b-w8a16=500000/1h,")
+	require.NoError(t, err)
+	assert.Equal(t, []TokenBudget{
+		{
+			Feature:  codygateway.FeatureChatCompletions,
+			Limit:    2000000,
+			Interval: 24 * time.Hour,
+		},
+		{
+			Feature:  codygateway.FeatureCodeCompletions,
+			Model:    "fireworks/accounts/fireworks/models/starcoder-7b-w8a16",
+			Limit:    500000,
+			Interval: time.Hour,
+		},
+	}, budgets)
+
+	assert.True(t, budgets[0].AppliesTo(codygateway.FeatureChatCompletions, "anthropic/claude-2"))
+	assert.False(t, budgets[0].AppliesTo(codygateway.FeatureCodeCompletions, "anthropic/claude-2"))
+	assert.True(t, budgets[1].AppliesTo(codygateway.FeatureCodeCompletions, "fireworks/accounts/fireworks/models/starcoder-7b-w8a16"))
+	assert.False(t, budgets[1].AppliesTo(codygateway.FeatureCodeCompletions, "anthropic/claude-instant-1"))
+
+	budgets, err = ParseTokenBudgets("")
+	require.NoError(t, err)
+	assert.Empty(t, budgets)
+
+	for _, invalid := range []string{
+		"chat_completions",
+		"unknown=100/1h",
+		"chat_completions=100",
+		"chat_completions=-100/1h",
+		"chat_completions=100/forever",
+	} {
+		_, err := ParseTokenBudgets(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
@@ -83,6 +83,8 @@ func NewAnthropicHandler(
 	eventLogger events.Logger,
 	rs limiter.RedisStore,
 	rateLimitNotifier notify.RateLimitNotifier,
+	tokenBudgets []actor.TokenBudget,
+	tokenBudgetNotifier notify.TokenBudgetNotifier,
 	httpClient httpcli.Doer,
 	accessToken string,
 	allowedModels []string,
@@ -104,6 +106,8 @@ func NewAnthropicHandler(
 		eventLogger,
 		rs,
 		rateLimitNotifier,
+		tokenBudgets,
+		tokenBudgetNotifier,
 		httpClient,
 		string(conftypes.CompletionsProviderNameAnthropic),
 		anthropicAPIURL,
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"strings"
 
 	"github.com/sourcegraph/log"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/cody-gateway/internal/events"
 	"github.com/sourcegraph/sourcegraph/cmd/cody-gateway/internal/limiter"
 	"github.com/sourcegraph/sourcegraph/cmd/cody-gateway/internal/notify"
+	"github.com/sourcegraph/sourcegraph/cmd/cody-gateway/internal/tokenizer"
 	"github.com/sourcegraph/sourcegraph/internal/codygateway"
 	"github.com/sourcegraph/sourcegraph/internal/completions/client/fireworks"
 	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
@@ -27,15 +29,26 @@ func NewFireworksHandler(
 	eventLogger events.Logger,
 	rs limiter.RedisStore,
 	rateLimitNotifier notify.RateLimitNotifier,
+	tokenBudgets []actor.TokenBudget,
+	tokenBudgetNotifier notify.TokenBudgetNotifier,
 	httpClient httpcli.Doer,
 	accessToken string,
 	allowedModels []string,
-) http.Handler {
+) (http.Handler, error) {
+	// Fireworks does not report usage of streaming requests, so we approximate
+	// it with the Claude tokenizer. The tokenizer only needs to be initialized
+	// once, and can be shared globally.
+	estimationTokenizer, err := tokenizer.NewAnthropicClaudeTokenizer()
+	if err != nil {
+		return nil, err
+	}
 	return makeUpstreamHandler(
 		baseLogger,
 		eventLogger,
 		rs,
 		rateLimitNotifier,
+		tokenBudgets,
+		tokenBudgetNotifier,
 		httpClient,
 		string(conftypes.CompletionsProviderNameFireworks),
 		fireworksAPIURL,
@@ -89,11 +102,11 @@ func NewFireworksHandler(
 				//
 				// TODO: Does fireworks streaming include usage data?
 				// Unclear in the API currently: https://readme.fireworks.ai/reference/createcompletion
-				// For now, just count character usage, and set token counts to
-				// -1 as sentinel values.
-				promptUsage.tokens = -1
-				completionUsage.tokens = -1
+				// For now, estimate token counts by tokenizing the prompt and
+				// the streamed completion ourselves.
+				promptUsage.estimateTokens(logger, estimationTokenizer, reqBody.Prompt)
 
+				var completion strings.Builder
 				dec := fireworks.NewDecoder(r)
 				// Consume all the messages, but we only care about the last completion data.
 				for dec.Scan() {
@@ -111,13 +124,16 @@ func NewFireworksHandler(
 					}
 
 					if len(event.Choices) > 0 {
-						completionUsage.characters += len(event.Choices[0].Text)
+						completion.WriteString(event.Choices[0].Text)
 					}
 				}
 				if err := dec.Err(); err != nil {
 					logger.Error("failed to decode Fireworks streaming response", log.Error(err))
 				}
 
+				completionUsage.characters = completion.Len()
+				completionUsage.estimateTokens(logger, estimationTokenizer, completion.String())
+
 				return promptUsage, completionUsage
 			},
 		},
@@ -125,7 +141,7 @@ func NewFireworksHandler(
 		// Setting to a valuer higher than SRC_HTTP_CLI_EXTERNAL_RETRY_AFTER_MAX_DURATION to not
 		// do any retries
 		30, // seconds
-	)
+	), nil
 }
 
 // fireworksRequest captures all known fields from https://fireworksai.readme.io/reference/createcompletion.
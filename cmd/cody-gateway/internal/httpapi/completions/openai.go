@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"strings"
 
 	"github.com/sourcegraph/log"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/cody-gateway/internal/events"
 	"github.com/sourcegraph/sourcegraph/cmd/cody-gateway/internal/limiter"
 	"github.com/sourcegraph/sourcegraph/cmd/cody-gateway/internal/notify"
+	"github.com/sourcegraph/sourcegraph/cmd/cody-gateway/internal/tokenizer"
 	"github.com/sourcegraph/sourcegraph/internal/codygateway"
 	"github.com/sourcegraph/sourcegraph/internal/completions/client/openai"
 	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
@@ -27,16 +29,27 @@ func NewOpenAIHandler(
 	eventLogger events.Logger,
 	rs limiter.RedisStore,
 	rateLimitNotifier notify.RateLimitNotifier,
+	tokenBudgets []actor.TokenBudget,
+	tokenBudgetNotifier notify.TokenBudgetNotifier,
 	httpClient httpcli.Doer,
 	accessToken string,
 	orgID string,
 	allowedModels []string,
-) http.Handler {
+) (http.Handler, error) {
+	// OpenAI does not report usage of streaming requests, so we approximate it
+	// with the Claude tokenizer. The tokenizer only needs to be initialized
+	// once, and can be shared globally.
+	estimationTokenizer, err := tokenizer.NewAnthropicClaudeTokenizer()
+	if err != nil {
+		return nil, err
+	}
 	return makeUpstreamHandler(
 		baseLogger,
 		eventLogger,
 		rs,
 		rateLimitNotifier,
+		tokenBudgets,
+		tokenBudgetNotifier,
 		httpClient,
 		string(conftypes.CompletionsProviderNameOpenAI),
 		openAIURL,
@@ -95,13 +108,17 @@ func NewOpenAIHandler(
 
 				// Otherwise, we have to parse the event stream.
 				//
-				// Currently, OpenAI only reports usage on non-streaming requests
-				// Until we can tokenize the response ourselves, just count
-				// character usage, and set token counts to -1 as sentinel values.
+				// Currently, OpenAI only reports usage on non-streaming requests,
+				// so we estimate token counts by tokenizing the prompt and the
+				// streamed completion ourselves.
 				// TODO: https://github.com/sourcegraph/sourcegraph/issues/56590
-				promptUsage.tokens = -1
-				completionUsage.tokens = -1
+				prompt := make([]string, 0, len(body.Messages))
+				for _, m := range body.Messages {
+					prompt = append(prompt, m.Content)
+				}
+				promptUsage.estimateTokens(logger, estimationTokenizer, strings.Join(prompt, "\n"))
 
+				var completion strings.Builder
 				dec := openai.NewDecoder(r)
 				// Consume all the messages, but we only care about the last completion data.
 				for dec.Scan() {
@@ -118,13 +135,16 @@ func NewOpenAIHandler(
 						continue
 					}
 					if len(event.Choices) > 0 {
-						completionUsage.characters += len(event.Choices[0].Delta.Content)
+						completion.WriteString(event.Choices[0].Delta.Content)
 					}
 				}
 				if err := dec.Err(); err != nil {
 					logger.Error("failed to decode OpenAI streaming response", log.Error(err))
 				}
 
+				completionUsage.characters = completion.Len()
+				completionUsage.estimateTokens(logger, estimationTokenizer, completion.String())
+
 				return promptUsage, completionUsage
 			},
 		},
@@ -134,7 +154,7 @@ func NewOpenAIHandler(
 		// clients from retrying at all since retries are probably not going to
 		// help in a minute-long rate limit window.
 		30, // seconds
-	)
+	), nil
 }
 
 type openaiRequestMessage struct {
@@ -23,6 +23,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/cody-gateway/internal/limiter"
 	"github.com/sourcegraph/sourcegraph/cmd/cody-gateway/internal/notify"
 	"github.com/sourcegraph/sourcegraph/cmd/cody-gateway/internal/response"
+	"github.com/sourcegraph/sourcegraph/cmd/cody-gateway/internal/tokenizer"
 	"github.com/sourcegraph/sourcegraph/internal/codygateway"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/requestclient"
@@ -35,6 +36,35 @@ type usageStats struct {
 	characters int
 	// tokens is the number of tokens consumed in the input or response.
 	tokens int
+	// tokensEstimated is true if tokens was counted with a tokenizer that may
+	// not exactly match the upstream model's, because upstream did not report
+	// usage.
+	tokensEstimated bool
+}
+
+// estimateTokens sets the token count of the usage to the number of tokens of
+// the text according to the given tokenizer, for upstreams that do not report
+// usage themselves.
+func (u *usageStats) estimateTokens(logger log.Logger, tk *tokenizer.Tokenizer, text string) {
+	tokens, err := tk.Tokenize(text)
+	if err != nil {
+		logger.Warn("failed to estimate token count", log.Error(err))
+		u.tokens = -1
+		return
+	}
+	u.tokens = len(tokens)
+	u.tokensEstimated = true
+}
+
+// budgetTokens returns the number of tokens that count towards token budgets,
+// ignoring usage that is unknown.
+func budgetTokens(usages ...usageStats) (tokens int) {
+	for _, u := range usages {
+		if u.tokens > 0 {
+			tokens += u.tokens
+		}
+	}
+	return tokens
 }
 
 // upstreamHandlerMethods declares a set of methods that are used throughout the
@@ -66,7 +96,9 @@ type upstreamHandlerMethods[ReqT UpstreamRequest] struct {
 	// upstream as well as overall usage for tracking purposes.
 	//
 	// If data is unavailable, implementations should set relevant usage fields
-	// to -1 as a sentinel value.
+	// to -1 as a sentinel value. Token counts are consumed from token budgets,
+	// so if upstream does not report them, implementations should estimate them
+	// with a tokenizer where possible and set tokensEstimated.
 	parseResponseAndUsage func(log.Logger, ReqT, io.Reader) (promptUsage, completionUsage usageStats)
 }
 
@@ -77,6 +109,8 @@ func makeUpstreamHandler[ReqT UpstreamRequest](
 	eventLogger events.Logger,
 	rs limiter.RedisStore,
 	rateLimitNotifier notify.RateLimitNotifier,
+	tokenBudgets []actor.TokenBudget,
+	tokenBudgetNotifier notify.TokenBudgetNotifier,
 	httpClient httpcli.Doer,
 
 	// upstreamName is the name of the upstream provider. It MUST match the
@@ -204,6 +238,41 @@ func makeUpstreamHandler[ReqT UpstreamRequest](
 				return
 			}
 
+			// Check the token budgets of the model before sending the request
+			// upstream. The number of tokens consumed is only known once the
+			// response completes, so they are committed at the end.
+			budgets := act.TokenBudgets(rs, feature, gatewayModel, tokenBudgets, tokenBudgetNotifier)
+			commitTokens, budgetUsage, err := budgets.TryAcquire(r.Context())
+			if err != nil {
+				var budgetExceeded limiter.TokenBudgetExceededError
+				if !errors.As(err, &budgetExceeded) {
+					response.JSONError(logger, w, http.StatusInternalServerError, errors.Wrap(err, "failed to check token budgets"))
+					return
+				}
+
+				if loggerErr := eventLogger.LogEvent(
+					r.Context(),
+					events.Event{
+						Name:       codygateway.EventNameRateLimited,
+						Source:     act.Source.Name(),
+						Identifier: act.ID,
+						Metadata: map[string]any{
+							"error": err.Error(),
+							codygateway.CompletionsEventFeatureMetadataField: feature,
+							"model": gatewayModel,
+							"cause": "token_budget",
+						},
+					},
+				); loggerErr != nil {
+					logger.Error("failed to log event", log.Error(loggerErr))
+				}
+				budgetExceeded.WriteResponse(w)
+				return
+			}
+			if len(budgets) > 0 {
+				budgetUsage.WriteHeaders(w)
+			}
+
 			var (
 				upstreamStarted        = time.Now()
 				upstreamStatusCode int = -1
@@ -237,6 +306,9 @@ func makeUpstreamHandler[ReqT UpstreamRequest](
 						delete(usageData, k)
 					}
 				}
+				if promptUsage.tokensEstimated || completionUsage.tokensEstimated {
+					usageData["token_count_estimated"] = true
+				}
 				err := eventLogger.LogEvent(
 					r.Context(),
 					events.Event{
@@ -333,6 +405,12 @@ func makeUpstreamHandler[ReqT UpstreamRequest](
 			if upstreamStatusCode >= 200 && upstreamStatusCode < 300 {
 				// Pass reader to response transformer to capture token counts.
 				promptUsage, completionUsage = methods.parseResponseAndUsage(logger, body, &responseBuf)
+
+				// Now that the response is complete, consume the tokens of both
+				// the prompt and the completion from the token budgets.
+				if err := commitTokens(r.Context(), budgetTokens(promptUsage, completionUsage)); err != nil {
+					logger.Error("failed to commit token budget consumption", log.Error(err))
+				}
 			} else if upstreamStatusCode >= 500 {
 				logger.Error("error from upstream",
 					log.Int("status_code", upstreamStatusCode))
@@ -11,6 +11,7 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 
+	"github.com/sourcegraph/sourcegraph/cmd/cody-gateway/internal/actor"
 	"github.com/sourcegraph/sourcegraph/cmd/cody-gateway/internal/auth"
 	"github.com/sourcegraph/sourcegraph/cmd/cody-gateway/internal/events"
 	"github.com/sourcegraph/sourcegraph/cmd/cody-gateway/internal/httpapi/completions"
@@ -26,6 +27,8 @@ import (
 
 type Config struct {
 	RateLimitNotifier              notify.RateLimitNotifier
+	TokenBudgets                   []actor.TokenBudget
+	TokenBudgetNotifier            notify.TokenBudgetNotifier
 	AnthropicAccessToken           string
 	AnthropicAllowedModels         []string
 	AnthropicAllowedPromptPatterns []string
@@ -76,6 +79,8 @@ func NewHandler(
 			eventLogger,
 			rs,
 			config.RateLimitNotifier,
+			config.TokenBudgets,
+			config.TokenBudgetNotifier,
 			httpClient,
 			config.AnthropicAccessToken,
 			config.AnthropicAllowedModels,
@@ -104,6 +109,22 @@ func NewHandler(
 		)
 	}
 	if config.OpenAIAccessToken != "" {
+		openAIHandler, err := completions.NewOpenAIHandler(
+			logger,
+			eventLogger,
+			rs,
+			config.RateLimitNotifier,
+			config.TokenBudgets,
+			config.TokenBudgetNotifier,
+			httpClient,
+			config.OpenAIAccessToken,
+			config.OpenAIOrgID,
+			config.OpenAIAllowedModels,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "init OpenAI handler")
+		}
+
 		v1router.Path("/completions/openai").Methods(http.MethodPost).Handler(
 			instrumentation.HTTPMiddleware("v1.completions.openai",
 				gaugeHandler(
@@ -112,16 +133,7 @@ func NewHandler(
 					authr.Middleware(
 						requestlogger.Middleware(
 							logger,
-							completions.NewOpenAIHandler(
-								logger,
-								eventLogger,
-								rs,
-								config.RateLimitNotifier,
-								httpClient,
-								config.OpenAIAccessToken,
-								config.OpenAIOrgID,
-								config.OpenAIAllowedModels,
-							),
+							openAIHandler,
 						),
 					),
 				),
@@ -171,6 +183,21 @@ func NewHandler(
 		)
 	}
 	if config.FireworksAccessToken != "" {
+		fireworksHandler, err := completions.NewFireworksHandler(
+			logger,
+			eventLogger,
+			rs,
+			config.RateLimitNotifier,
+			config.TokenBudgets,
+			config.TokenBudgetNotifier,
+			httpClient,
+			config.FireworksAccessToken,
+			config.FireworksAllowedModels,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "init Fireworks handler")
+		}
+
 		v1router.Path("/completions/fireworks").Methods(http.MethodPost).Handler(
 			instrumentation.HTTPMiddleware("v1.completions.fireworks",
 				gaugeHandler(
@@ -179,15 +206,7 @@ func NewHandler(
 					authr.Middleware(
 						requestlogger.Middleware(
 							logger,
-							completions.NewFireworksHandler(
-								logger,
-								eventLogger,
-								rs,
-								config.RateLimitNotifier,
-								httpClient,
-								config.FireworksAccessToken,
-								config.FireworksAllowedModels,
-							),
+							fireworksHandler,
 						),
 					),
 				),
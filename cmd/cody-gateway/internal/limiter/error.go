@@ -30,6 +30,28 @@ func (e RateLimitExceededError) WriteResponse(w http.ResponseWriter) {
 	http.Error(w, e.Summary(), http.StatusTooManyRequests)
 }
 
+type TokenBudgetExceededError struct {
+	Limit      int64
+	RetryAfter time.Time
+}
+
+// Error generates a simple string that is fairly static for use in logging.
+// This helps with categorizing errors. For more detailed output use Summary().
+func (e TokenBudgetExceededError) Error() string { return "token budget exceeded" }
+
+func (e TokenBudgetExceededError) Summary() string {
+	return fmt.Sprintf("you have exceeded the budget of %d tokens. Retry after %s",
+		e.Limit, e.RetryAfter.Truncate(time.Second))
+}
+
+func (e TokenBudgetExceededError) WriteResponse(w http.ResponseWriter) {
+	// Budget exceeded, write well known headers and return correct status code.
+	TokenBudgetUsage{Limit: e.Limit, Remaining: 0}.WriteHeaders(w)
+	w.Header().Set("retry-after", e.RetryAfter.Format(time.RFC1123))
+	// Use Summary instead of Error for more informative text
+	http.Error(w, e.Summary(), http.StatusTooManyRequests)
+}
+
 type NoAccessError struct{}
 
 func (e NoAccessError) Error() string {
@@ -16,6 +16,10 @@ func (s *prefixRedisStore) Incrby(key string, val int) (int, error) {
 	return s.store.Incrby(s.prefix+key, val)
 }
 
+func (s *prefixRedisStore) IncrbyWithExpire(key string, val int, ttlSeconds int) (int, error) {
+	return s.store.IncrbyWithExpire(s.prefix+key, val, ttlSeconds)
+}
+
 func (s *prefixRedisStore) GetInt(key string) (int, error) {
 	return s.store.GetInt(s.prefix + key)
 }
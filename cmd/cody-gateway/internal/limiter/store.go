@@ -8,6 +8,9 @@ import (
 type RedisStore interface {
 	// Incrby increments a key's value, or initializes it to 1 if it does not exist
 	Incrby(key string, val int) (int, error)
+	// IncrbyWithExpire atomically increments a key's value, and sets its TTL if
+	// it does not have one yet
+	IncrbyWithExpire(key string, val int, ttlSeconds int) (int, error)
 	// Get retrieves a key's value
 	GetInt(key string) (int, error)
 	// TTL provides seconds TTL on an existing key
@@ -35,6 +38,16 @@ func (m MockRedisStore) Incrby(key string, val int) (int, error) {
 	return entry.Value, nil
 }
 
+func (m MockRedisStore) IncrbyWithExpire(key string, val int, ttlSeconds int) (int, error) {
+	entry := m[key]
+	entry.Value += val
+	if entry.TTL <= 0 {
+		entry.TTL = ttlSeconds
+	}
+	m[key] = entry
+	return entry.Value, nil
+}
+
 func (m MockRedisStore) GetInt(key string) (int, error) {
 	entry, ok := m[key]
 	if !ok {
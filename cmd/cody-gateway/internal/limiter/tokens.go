@@ -0,0 +1,280 @@
+package limiter
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// tokenBudgetBuckets is the number of buckets a token budget window is split
+// into. More buckets make the window roll more smoothly, at the cost of more
+// counters to read on each request.
+const tokenBudgetBuckets = 10
+
+// TokenLimiter enforces a budget of tokens over a rolling window. Unlike
+// StaticLimiter, usage does not reset all at once at the end of a fixed window:
+// the window is split into buckets that count the tokens consumed during a
+// fraction of the interval, and tokens only stop counting towards the budget
+// once their bucket falls out of the window.
+type TokenLimiter struct {
+	// LimiterName optionally identifies the limiter for instrumentation. If not
+	// provided, 'TokenLimiter' is used.
+	LimiterName string
+
+	// Identifier is the prefix of the keys of the bucket counters.
+	Identifier string
+
+	Redis    RedisStore
+	Limit    int64
+	Interval time.Duration
+
+	NowFunc func() time.Time
+
+	// BudgetAlerter is always called with usageRatio whenever tokens are
+	// committed, and when the budget is exhausted.
+	BudgetAlerter func(ctx context.Context, usageRatio float32, ttl time.Duration)
+}
+
+var _ Limiter = TokenLimiter{}
+
+// tokenWindow is the usage of the buckets of a rolling window, from the oldest
+// to the current bucket.
+type tokenWindow struct {
+	// firstBucket is the index of the oldest bucket of the window.
+	firstBucket   int64
+	bucketSeconds int64
+	usage         []int
+	total         int
+}
+
+// bucketKey returns the key of the counter of the given bucket.
+func (l TokenLimiter) bucketKey(bucket int64) string {
+	return fmt.Sprintf("%s:%d", l.Identifier, bucket)
+}
+
+func (l TokenLimiter) window() (tokenWindow, error) {
+	// Round up so that the window is never shorter than the interval when the
+	// interval is not a multiple of tokenBudgetBuckets seconds.
+	bucketSeconds := (int64(l.Interval/time.Second) + tokenBudgetBuckets - 1) / tokenBudgetBuckets
+	if bucketSeconds < 1 {
+		bucketSeconds = 1
+	}
+	currentBucket := l.NowFunc().Unix() / bucketSeconds
+
+	w := tokenWindow{
+		firstBucket:   currentBucket - tokenBudgetBuckets + 1,
+		bucketSeconds: bucketSeconds,
+		usage:         make([]int, tokenBudgetBuckets),
+	}
+	for i := range w.usage {
+		// If no record exists, redis will return 0.
+		usage, err := l.Redis.GetInt(l.bucketKey(w.firstBucket + int64(i)))
+		if err != nil {
+			return tokenWindow{}, errors.Wrap(err, "failed to read token budget counter")
+		}
+		w.usage[i] = usage
+		w.total += usage
+	}
+	return w, nil
+}
+
+// currentBucket returns the index of the bucket that tokens are committed to.
+func (w tokenWindow) currentBucket() int64 {
+	return w.firstBucket + tokenBudgetBuckets - 1
+}
+
+// until returns the time by which enough buckets have fallen out of the window
+// for the usage to drop below the given limit, or the zero time if the usage is
+// already below the limit.
+func (w tokenWindow) until(limit int64) time.Time {
+	remaining := int64(w.total)
+	if remaining < limit {
+		return time.Time{}
+	}
+	for i, usage := range w.usage {
+		remaining -= int64(usage)
+		// Bucket i leaves the window when the bucket tokenBudgetBuckets after it
+		// starts.
+		if remaining < limit {
+			return time.Unix((w.firstBucket+int64(i)+tokenBudgetBuckets)*w.bucketSeconds, 0)
+		}
+	}
+	return time.Time{}
+}
+
+func (l TokenLimiter) TryAcquire(ctx context.Context) (func(context.Context, int) error, error) {
+	commit, _, err := l.tryAcquire(ctx)
+	return commit, err
+}
+
+// tryAcquire is like TryAcquire, but also returns the number of tokens left in
+// the budget before this request.
+func (l TokenLimiter) tryAcquire(ctx context.Context) (_ func(context.Context, int) error, remaining int64, err error) {
+	if l.LimiterName == "" {
+		l.LimiterName = "TokenLimiter"
+	}
+	var currentUsage int
+	var span trace.Span
+	ctx, span = tracer.Start(ctx, l.LimiterName+".TryAcquire",
+		trace.WithAttributes(
+			attribute.Int64("limit", l.Limit),
+			attribute.Float64("intervalSeconds", l.Interval.Seconds())))
+	defer func() {
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.SetAttributes(attribute.Int("currentUsage", currentUsage))
+		span.End()
+	}()
+
+	// Zero values implies no access - this is a fallback check, callers should
+	// be checking independently if a budget applies.
+	if l.Identifier == "" || l.Limit <= 0 || l.Interval <= 0 {
+		return nil, 0, NoAccessError{}
+	}
+
+	w, err := l.window()
+	if err != nil {
+		return nil, 0, err
+	}
+	currentUsage = w.total
+
+	if int64(currentUsage) >= l.Limit {
+		retryAfter := w.until(l.Limit)
+		if l.BudgetAlerter != nil {
+			// Call with usage 1 for 100% (budget exhausted)
+			go l.BudgetAlerter(backgroundContextWithSpan(ctx), 1, retryAfter.Sub(l.NowFunc()))
+		}
+
+		return nil, 0, TokenBudgetExceededError{
+			Limit:      l.Limit,
+			RetryAfter: retryAfter,
+		}
+	}
+
+	// As with StaticLimiter, reading the usage and committing tokens are not
+	// atomic, so concurrent requests may slightly overrun the budget. Since the
+	// number of tokens is only known once the response completes, a single
+	// request may also overrun the budget by the size of its response.
+	return func(ctx context.Context, tokens int) (err error) {
+		// NOTE: This is to make sure we still commit usage even if the context was canceled.
+		ctx = backgroundContextWithSpan(ctx)
+
+		bucket := w.currentBucket()
+		var incrementedTo int
+		_, span = tracer.Start(ctx, l.LimiterName+".commit",
+			trace.WithAttributes(
+				attribute.Int("tokens", tokens),
+				attribute.Int64("bucket", bucket)))
+		defer func() {
+			span.SetAttributes(attribute.Int("incrementedTo", incrementedTo))
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, "failed to commit token usage")
+			}
+			span.End()
+		}()
+
+		if tokens <= 0 {
+			return nil
+		}
+
+		// Buckets only need to be kept for as long as they are part of the
+		// window. The expiry is set together with the increment so that a
+		// failure in between cannot leave a counter that never expires.
+		if incrementedTo, err = l.Redis.IncrbyWithExpire(l.bucketKey(bucket), tokens, int(tokenBudgetBuckets*w.bucketSeconds)); err != nil {
+			return errors.Wrap(err, "failed to increment token budget counter")
+		}
+
+		if l.BudgetAlerter != nil {
+			// Usage is measured over the whole window, so alerts are only
+			// repeated once a full window has passed.
+			go l.BudgetAlerter(ctx, float32(currentUsage+tokens)/float32(l.Limit), l.Interval)
+		}
+
+		return nil
+	}, l.Limit - int64(currentUsage), nil
+}
+
+// Usage returns the number of tokens consumed in the current window, and the
+// time by which all of them will have fallen out of the window.
+func (l TokenLimiter) Usage(ctx context.Context) (_ int, _ time.Time, err error) {
+	if l.LimiterName == "" {
+		l.LimiterName = "TokenLimiter"
+	}
+
+	_, span := tracer.Start(ctx, l.LimiterName+".Usage",
+		trace.WithAttributes(
+			attribute.Int64("limit", l.Limit),
+		))
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	// Zero values implies no access.
+	if l.Identifier == "" || l.Limit <= 0 || l.Interval <= 0 {
+		return 0, time.Time{}, NoAccessError{}
+	}
+
+	w, err := l.window()
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	if w.total == 0 {
+		return 0, time.Time{}, nil
+	}
+
+	// All tokens have fallen out of the window once the usage drops below 1.
+	return w.total, w.until(1), nil
+}
+
+// TokenBudgets are token budgets that all apply to the same requests.
+type TokenBudgets []TokenLimiter
+
+// TokenBudgetUsage describes the most constrained of a set of token budgets.
+type TokenBudgetUsage struct {
+	Limit     int64
+	Remaining int64
+}
+
+// WriteHeaders sets the well known headers that tell clients about their
+// remaining token budget.
+func (u TokenBudgetUsage) WriteHeaders(w http.ResponseWriter) {
+	w.Header().Set("x-token-budget-limit", strconv.FormatInt(u.Limit, 10))
+	w.Header().Set("x-token-budget-remaining", strconv.FormatInt(u.Remaining, 10))
+}
+
+// TryAcquire checks that none of the budgets are exhausted and returns no error
+// if the request can proceed, along with the usage of the budget with the
+// fewest tokens left. The commit callback consumes the given number of tokens
+// from all budgets.
+func (b TokenBudgets) TryAcquire(ctx context.Context) (commit func(context.Context, int) error, usage TokenBudgetUsage, err error) {
+	commits := make([]func(context.Context, int) error, 0, len(b))
+	for i, l := range b {
+		commit, remaining, err := l.tryAcquire(ctx)
+		if err != nil {
+			return nil, TokenBudgetUsage{}, err
+		}
+		commits = append(commits, commit)
+		if i == 0 || remaining < usage.Remaining {
+			usage = TokenBudgetUsage{Limit: l.Limit, Remaining: remaining}
+		}
+	}
+
+	return func(ctx context.Context, tokens int) error {
+		var errs error
+		for _, commit := range commits {
+			errs = errors.Append(errs, commit(ctx, tokens))
+		}
+		return errs
+	}, usage, nil
+}
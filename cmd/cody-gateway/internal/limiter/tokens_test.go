@@ -0,0 +1,135 @@
+package limiter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenLimiter(t *testing.T) {
+	// Stable time for testing, aligned with the one-hour buckets of a ten-hour
+	// window.
+	now := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	currentBucket := now.Unix() / 3600
+
+	newLimiter := func(store MockRedisStore) TokenLimiter {
+		return TokenLimiter{
+			Identifier: "foobar",
+			Redis:      store,
+			Limit:      100,
+			Interval:   10 * time.Hour,
+			NowFunc:    func() time.Time { return now },
+		}
+	}
+	key := func(bucket int64) string {
+		return newLimiter(nil).bucketKey(bucket)
+	}
+
+	t.Run("no budget set", func(t *testing.T) {
+		_, err := TokenLimiter{Redis: MockRedisStore{}, NowFunc: time.Now}.TryAcquire(context.Background())
+		assert.ErrorAs(t, err, &NoAccessError{})
+	})
+
+	t.Run("commits to the current bucket", func(t *testing.T) {
+		store := MockRedisStore{
+			key(currentBucket - 9): {Value: 20},
+			key(currentBucket - 1): {Value: 30},
+		}
+		commit, remaining, err := newLimiter(store).tryAcquire(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, int64(50), remaining)
+
+		require.NoError(t, commit(context.Background(), 15))
+		assert.Equal(t, 15, store[key(currentBucket)].Value)
+		// The bucket expires once it falls out of the window.
+		assert.Equal(t, 36000, store[key(currentBucket)].TTL)
+
+		usage, _, err := newLimiter(store).Usage(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 65, usage)
+	})
+
+	t.Run("buckets out of the window do not count", func(t *testing.T) {
+		store := MockRedisStore{
+			key(currentBucket - 10): {Value: 100},
+			key(currentBucket):      {Value: 10},
+		}
+		_, remaining, err := newLimiter(store).tryAcquire(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, int64(90), remaining)
+	})
+
+	t.Run("budget exceeded", func(t *testing.T) {
+		store := MockRedisStore{
+			key(currentBucket - 8): {Value: 30},
+			key(currentBucket - 5): {Value: 50},
+			key(currentBucket):     {Value: 40},
+		}
+		_, err := newLimiter(store).TryAcquire(context.Background())
+		var exceeded TokenBudgetExceededError
+		require.ErrorAs(t, err, &exceeded)
+		assert.Equal(t, int64(100), exceeded.Limit)
+		// Usage drops below the limit once the oldest bucket leaves the window.
+		assert.Equal(t, now.Add(2*time.Hour), exceeded.RetryAfter.UTC())
+
+		// All tokens leave the window with the current bucket.
+		usage, until, err := newLimiter(store).Usage(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 120, usage)
+		assert.Equal(t, now.Add(10*time.Hour), until.UTC())
+	})
+
+	t.Run("window is not shorter than an interval that is not a multiple of the buckets", func(t *testing.T) {
+		store := MockRedisStore{}
+		l := newLimiter(store)
+		l.Interval = 15 * time.Second
+
+		commit, err := l.TryAcquire(context.Background())
+		require.NoError(t, err)
+		require.NoError(t, commit(context.Background(), 10))
+		// Buckets of 2 seconds make a 20 second window.
+		assert.Equal(t, 20, store[l.bucketKey(now.Unix()/2)].TTL)
+	})
+
+	t.Run("alerts with usage ratio", func(t *testing.T) {
+		alerts := make(chan float32, 1)
+		l := newLimiter(MockRedisStore{key(currentBucket): {Value: 40}})
+		l.BudgetAlerter = func(_ context.Context, usageRatio float32, _ time.Duration) {
+			alerts <- usageRatio
+		}
+
+		commit, err := l.TryAcquire(context.Background())
+		require.NoError(t, err)
+		require.NoError(t, commit(context.Background(), 40))
+		assert.Equal(t, float32(0.8), <-alerts)
+	})
+}
+
+func TestTokenBudgets(t *testing.T) {
+	now := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := MockRedisStore{
+		"daily:" + TokenLimiter{Identifier: "foobar"}.bucketKey(now.Unix()/8640): {Value: 500},
+		"hourly:" + TokenLimiter{Identifier: "foobar"}.bucketKey(now.Unix()/360): {Value: 50},
+	}
+	budgets := TokenBudgets{
+		{Identifier: "foobar", Redis: NewPrefixRedisStore("daily:", store), Limit: 1000, Interval: 24 * time.Hour, NowFunc: func() time.Time { return now }},
+		{Identifier: "foobar", Redis: NewPrefixRedisStore("hourly:", store), Limit: 100, Interval: time.Hour, NowFunc: func() time.Time { return now }},
+	}
+
+	commit, usage, err := budgets.TryAcquire(context.Background())
+	require.NoError(t, err)
+	// The hourly budget has fewer tokens left.
+	assert.Equal(t, TokenBudgetUsage{Limit: 100, Remaining: 50}, usage)
+
+	// Tokens count towards all budgets.
+	require.NoError(t, commit(context.Background(), 60))
+	_, _, err = budgets.TryAcquire(context.Background())
+	assert.ErrorAs(t, err, &TokenBudgetExceededError{})
+
+	dailyUsage, _, err := budgets[0].Usage(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 560, dailyUsage)
+}
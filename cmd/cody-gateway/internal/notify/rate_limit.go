@@ -53,9 +53,33 @@ func NewSlackRateLimitNotifier(
 	slackWebhookURL string,
 	slackSender func(ctx context.Context, url string, msg *slack.WebhookMessage) error,
 ) RateLimitNotifier {
-	baseLogger = baseLogger.Scoped("slackRateLimitNotifier", "notifications for usage rate limit approaching thresholds")
+	notify := newSlackNotifier(
+		baseLogger.Scoped("slackRateLimitNotifier", "notifications for usage rate limit approaching thresholds"),
+		"slackRateLimitNotification",
+		rs, dotcomURL, actorSourceThresholds, slackWebhookURL, slackSender,
+	)
 
 	return func(ctx context.Context, actor codygateway.Actor, feature codygateway.Feature, usageRatio float32, ttl time.Duration) {
+		notify(ctx, actor, quota{
+			key:         fmt.Sprintf("rate_limit:%s", feature),
+			description: fmt.Sprintf("rate limit quota for `%s`", feature),
+			feature:     feature,
+		}, usageRatio, ttl)
+	}
+}
+
+// newSlackNotifier returns a function that sends Slack notifications when the
+// usage of a quota hits given thresholds.
+func newSlackNotifier(
+	baseLogger log.Logger,
+	spanName string,
+	rs redispool.KeyValue,
+	dotcomURL string,
+	actorSourceThresholds Thresholds,
+	slackWebhookURL string,
+	slackSender func(ctx context.Context, url string, msg *slack.WebhookMessage) error,
+) func(ctx context.Context, actor codygateway.Actor, q quota, usageRatio float32, ttl time.Duration) {
+	return func(ctx context.Context, actor codygateway.Actor, q quota, usageRatio float32, ttl time.Duration) {
 		thresholds := actorSourceThresholds.Get(actor.GetSource())
 		if len(thresholds) == 0 {
 			return
@@ -67,13 +91,13 @@ func NewSlackRateLimitNotifier(
 		}
 
 		var span trace.Span
-		ctx, span = tracer.Start(ctx, "slackRateLimitNotification",
+		ctx, span = tracer.Start(ctx, spanName,
 			trace.WithAttributes(
 				attribute.Float64("usagePercentage", float64(usageRatio)),
 				attribute.Float64("alert.ttlSeconds", ttl.Seconds())))
 		logger := sgtrace.Logger(ctx, baseLogger)
 
-		if err := handleNotify(ctx, logger, rs, dotcomURL, thresholds, slackWebhookURL, slackSender, actor, feature, usagePercentage, ttl); err != nil {
+		if err := handleNotify(ctx, logger, rs, dotcomURL, thresholds, slackWebhookURL, slackSender, actor, q, usagePercentage, ttl); err != nil {
 			span.RecordError(err)
 			logger.Error("failed to notification", log.Error(err))
 		}
@@ -82,6 +106,16 @@ func NewSlackRateLimitNotifier(
 	}
 }
 
+// quota identifies the usage that notifications are sent about.
+type quota struct {
+	// key is the prefix of the Redis keys that track notifications for the
+	// quota.
+	key string
+	// description describes the quota in notifications.
+	description string
+	feature     codygateway.Feature
+}
+
 func handleNotify(
 	ctx context.Context,
 	logger log.Logger,
@@ -93,13 +127,13 @@ func handleNotify(
 	slackSender func(ctx context.Context, url string, msg *slack.WebhookMessage) error,
 
 	actor codygateway.Actor,
-	feature codygateway.Feature,
+	q quota,
 	usagePercentage int,
 	ttl time.Duration,
 ) error {
 	span := trace.SpanFromContext(ctx)
 
-	lockKey := fmt.Sprintf("rate_limit:%s:alert:lock:%s", feature, actor.GetID())
+	lockKey := fmt.Sprintf("%s:alert:lock:%s", q.key, actor.GetID())
 	acquired, release, err := redislock.TryAcquire(rs, lockKey, 30*time.Second)
 	span.SetAttributes(attribute.Bool("lock.acquired", acquired))
 	if err != nil {
@@ -118,7 +152,7 @@ func handleNotify(
 	}
 	span.SetAttributes(attribute.Int("bucket", bucket))
 
-	key := fmt.Sprintf("rate_limit:%s:alert:%s", feature, actor.GetID())
+	key := fmt.Sprintf("%s:alert:%s", q.key, actor.GetID())
 	lastBucket, err := rs.Get(key).Int()
 	if err != nil && err != redis.ErrNil {
 		return errors.Wrap(err, "failed to get last alert bucket")
@@ -142,7 +176,8 @@ func handleNotify(
 				log.String("id", actor.GetID()),
 				log.String("source", string(actor.GetSource())),
 			),
-			log.String("feature", string(feature)),
+			log.String("feature", string(q.feature)),
+			log.String("quota", q.key),
 			log.Int("usagePercentage", usagePercentage),
 		)
 		return nil
@@ -159,8 +194,8 @@ func handleNotify(
 		attribute.String("actor.link", actorLink),
 		attribute.Bool("sendToSlack", true))
 
-	text := fmt.Sprintf("The actor %s from %q has exceeded *%d%%* of its rate limit quota for `%s`. The quota will reset in `%s` at `%s`.",
-		actorLink, actor.GetSource(), usagePercentage, feature, ttl.String(), time.Now().Add(ttl).Format(time.RFC3339))
+	text := fmt.Sprintf("The actor %s from %q has exceeded *%d%%* of its %s. The quota will reset in `%s` at `%s`.",
+		actorLink, actor.GetSource(), usagePercentage, q.description, ttl.String(), time.Now().Add(ttl).Format(time.RFC3339))
 
 	// NOTE: The context timeout must below the lock timeout we set above (30 seconds
 	// ) to make sure the lock doesn't expire when we release it, i.e. avoid
@@ -0,0 +1,49 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/codygateway"
+	"github.com/sourcegraph/sourcegraph/internal/redispool"
+)
+
+// TokenBudgetNotifier is a function that sends notifications when the usage of
+// a token budget hits given thresholds. Model is empty for budgets that apply
+// to all models of a feature. Like RateLimitNotifier, at most one notification
+// will be sent per actor per budget per threshold until the TTL is reached.
+type TokenBudgetNotifier func(ctx context.Context, actor codygateway.Actor, feature codygateway.Feature, model string, usageRatio float32, ttl time.Duration)
+
+// NewSlackTokenBudgetNotifier returns a TokenBudgetNotifier that sends Slack
+// notifications when token budget usage hits given thresholds.
+func NewSlackTokenBudgetNotifier(
+	baseLogger log.Logger,
+	rs redispool.KeyValue,
+	dotcomURL string,
+	actorSourceThresholds Thresholds,
+	slackWebhookURL string,
+	slackSender func(ctx context.Context, url string, msg *slack.WebhookMessage) error,
+) TokenBudgetNotifier {
+	notify := newSlackNotifier(
+		baseLogger.Scoped("slackTokenBudgetNotifier", "notifications for token budget usage approaching thresholds"),
+		"slackTokenBudgetNotification",
+		rs, dotcomURL, actorSourceThresholds, slackWebhookURL, slackSender,
+	)
+
+	return func(ctx context.Context, actor codygateway.Actor, feature codygateway.Feature, model string, usageRatio float32, ttl time.Duration) {
+		q := quota{
+			key:         fmt.Sprintf("token_budget:%s:*", feature),
+			description: fmt.Sprintf("token budget for `%s`", feature),
+			feature:     feature,
+		}
+		if model != "" {
+			q.key = fmt.Sprintf("token_budget:%s:%s", feature, model)
+			q.description = fmt.Sprintf("token budget for `%s` with `%s`", feature, model)
+		}
+		notify(ctx, actor, q, usageRatio, ttl)
+	}
+}
@@ -0,0 +1,69 @@
+package notify
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/codygateway"
+	"github.com/sourcegraph/sourcegraph/internal/redispool"
+)
+
+func TestSlackTokenBudgetNotifier(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		model    string
+		wantKey  string
+		wantText string
+	}{
+		{
+			name:     "budget of a feature",
+			wantKey:  "token_budget:chat_completions:*:alert:foobar",
+			wantText: "has exceeded *95%* of its token budget for `chat_completions`.",
+		},
+		{
+			name:     "budget of a model",
+			model:    "anthropic/claude-2",
+			wantKey:  "token_budget:chat_completions:anthropic/claude-2:alert:foobar",
+			wantText: "has exceeded *95%* of its token budget for `chat_completions` with `anthropic/claude-2`.",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rs := redispool.NewMockKeyValue()
+			rs.SetNxFunc.SetDefaultReturn(true, nil)
+
+			var text string
+			notifier := NewSlackTokenBudgetNotifier(
+				logtest.NoOp(t),
+				rs,
+				"https://sourcegraph.com/",
+				Thresholds{codygateway.ActorSourceProductSubscription: []int{50, 90}},
+				"https://hooks.slack.com",
+				func(ctx context.Context, url string, msg *slack.WebhookMessage) error {
+					text = msg.Blocks.BlockSet[0].(*slack.SectionBlock).Text.Text
+					return nil
+				},
+			)
+
+			notifier(context.Background(),
+				&mockActor{
+					id:     "foobar",
+					name:   "alice",
+					source: codygateway.ActorSourceProductSubscription,
+				},
+				codygateway.FeatureChatCompletions,
+				tc.model,
+				0.95,
+				time.Hour)
+
+			assert.Contains(t, text, tc.wantText)
+			require.NotEmpty(t, rs.SetExFunc.History())
+			assert.Equal(t, tc.wantKey, rs.SetExFunc.History()[0].Arg0)
+		})
+	}
+}
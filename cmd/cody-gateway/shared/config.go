@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/sourcegraph/sourcegraph/cmd/cody-gateway/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/codygateway"
 	"github.com/sourcegraph/sourcegraph/internal/env"
 	"github.com/sourcegraph/sourcegraph/internal/trace/policy"
@@ -65,6 +66,7 @@ type Config struct {
 
 	ActorConcurrencyLimit codygateway.ActorConcurrencyLimitConfig
 	ActorRateLimitNotify  codygateway.ActorRateLimitNotifyConfig
+	ActorTokenBudgets     []actor.TokenBudget
 }
 
 type OpenTelemetryConfig struct {
@@ -171,6 +173,13 @@ func (c *Config) Load() {
 	c.ActorConcurrencyLimit.Interval = c.GetInterval("CODY_GATEWAY_ACTOR_CONCURRENCY_LIMIT_INTERVAL", "10s", "The interval at which to check the concurrent requests limit from an actor.")
 
 	c.ActorRateLimitNotify.SlackWebhookURL = c.GetOptional("CODY_GATEWAY_ACTOR_RATE_LIMIT_NOTIFY_SLACK_WEBHOOK_URL", "The Slack webhook URL to send notifications to.")
+
+	var err error
+	c.ActorTokenBudgets, err = actor.ParseTokenBudgets(c.GetOptional("CODY_GATEWAY_ACTOR_TOKEN_BUDGETS",
+		"Comma-separated rolling-window token budgets applied to each actor, of the form 'feature[:provider/model]=limit/interval', e.g. 'chat_completions=2000000/24h'."))
+	if err != nil {
+		c.AddError(errors.Wrap(err, "invalid CODY_GATEWAY_ACTOR_TOKEN_BUDGETS"))
+	}
 }
 
 // splitMaybe splits on commas, but only returns at least one element if the input
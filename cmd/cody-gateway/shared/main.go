@@ -139,6 +139,23 @@ func Main(ctx context.Context, obctx *observation.Context, ready service.ReadyFu
 		},
 	)
 
+	tokenBudgetNotifier := notify.NewSlackTokenBudgetNotifier(
+		obctx.Logger,
+		redispool.Cache,
+		dotcomURL.String(),
+		notify.Thresholds{
+			// Soft limits ahead of exhausted budgets for product subscriptions
+			// and individual dotcom users, since budgets are meant to catch
+			// heavy users.
+			codygateway.ActorSourceProductSubscription: []int{75, 90, 100},
+			codygateway.ActorSourceDotcomUser:          []int{90},
+		},
+		config.ActorRateLimitNotify.SlackWebhookURL,
+		func(ctx context.Context, url string, msg *slack.WebhookMessage) error {
+			return slack.PostWebhookCustomHTTPContext(ctx, url, otelhttp.DefaultClient, msg)
+		},
+	)
+
 	// Set up our handler chain, which is run from the bottom up. Application handlers
 	// come last.
 	handler, err := httpapi.NewHandler(obctx.Logger, eventLogger, rs, httpClient, authr,
@@ -150,6 +167,8 @@ func Main(ctx context.Context, obctx *observation.Context, ready service.ReadyFu
 		},
 		&httpapi.Config{
 			RateLimitNotifier:              rateLimitNotifier,
+			TokenBudgets:                   config.ActorTokenBudgets,
+			TokenBudgetNotifier:            tokenBudgetNotifier,
 			AnthropicAccessToken:           config.Anthropic.AccessToken,
 			AnthropicAllowedModels:         config.Anthropic.AllowedModels,
 			AnthropicMaxTokensToSample:     config.Anthropic.MaxTokensToSample,
@@ -231,6 +250,27 @@ func (s *redisStore) Incrby(key string, val int) (int, error) {
 	return s.store.Incrby(key, val)
 }
 
+// incrbyWithExpireScript increments the counter at KEYS[1] by ARGV[1], and
+// sets its expiry to ARGV[2] seconds if it does not have one yet.
+var incrbyWithExpireScript = redis.NewScript(1, `
+local value = redis.call('INCRBY', KEYS[1], ARGV[1])
+if redis.call('TTL', KEYS[1]) < 0 then
+	redis.call('EXPIRE', KEYS[1], ARGV[2])
+end
+return value
+`)
+
+func (s *redisStore) IncrbyWithExpire(key string, val int, ttlSeconds int) (int, error) {
+	pool, ok := s.store.Pool()
+	if !ok {
+		return 0, errors.New("redis is not available")
+	}
+	c := pool.Get()
+	defer c.Close()
+
+	return redis.Int(incrbyWithExpireScript.Do(c, key, val, ttlSeconds))
+}
+
 func (s *redisStore) GetInt(key string) (int, error) {
 	i, err := s.store.Get(key).Int()
 	if err != nil && err != redis.ErrNil {
@@ -24,7 +24,10 @@ type Client struct {
 }
 
 // Compile time validation.
-var _ files.Store = &Client{}
+var (
+	_ files.Store         = &Client{}
+	_ files.ArtifactStore = &Client{}
+)
 
 // New creates a new Client based on the provided Options.
 func New(observationCtx *observation.Context, options apiclient.BaseClientOptions) (*Client, error) {
@@ -82,3 +85,18 @@ func (c *Client) Get(ctx context.Context, job types.Job, bucket string, key stri
 	}
 	return body, nil
 }
+
+func (c *Client) UploadArtifact(ctx context.Context, job types.Job, name string, content io.Reader) (err error) {
+	ctx, _, endObservation := c.operations.uploadArtifact.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.String("queue", job.Queue),
+		attribute.String("name", name),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	req, err := c.client.NewRequest(job.ID, job.Token, http.MethodPost, fmt.Sprintf("artifacts/%s/%s", job.Queue, name), content)
+	if err != nil {
+		return err
+	}
+
+	return c.client.DoAndDrop(ctx, req)
+}
@@ -8,8 +8,9 @@ import (
 )
 
 type operations struct {
-	exists *observation.Operation
-	get    *observation.Operation
+	exists         *observation.Operation
+	get            *observation.Operation
+	uploadArtifact *observation.Operation
 }
 
 func newOperations(observationCtx *observation.Context) *operations {
@@ -29,7 +30,8 @@ func newOperations(observationCtx *observation.Context) *operations {
 	}
 
 	return &operations{
-		exists: op("Exists"),
-		get:    op("Get"),
+		exists:         op("Exists"),
+		get:            op("Get"),
+		uploadArtifact: op("UploadArtifact"),
 	}
 }
@@ -69,6 +69,7 @@ func (c *Client) Dequeue(ctx context.Context, workerHostname string, extraArgume
 		NumCPUs:      c.options.ResourceOptions.NumCPUs,
 		Memory:       c.options.ResourceOptions.Memory,
 		DiskSpace:    c.options.ResourceOptions.DiskSpace,
+		Labels:       c.options.Labels,
 	}
 
 	if len(c.options.QueueNames) > 0 {
@@ -191,6 +192,10 @@ func (c *Client) Heartbeat(ctx context.Context, jobIDs []string) (knownIDs, canc
 			ExecutorName:      c.options.ExecutorName,
 			QueueNames:        c.options.QueueNames,
 			JobIDsByQueue:     queueJobIDs,
+			Labels:            c.options.Labels,
+			NumCPUs:           c.options.ResourceOptions.NumCPUs,
+			Memory:            c.options.ResourceOptions.Memory,
+			DiskSpace:         c.options.ResourceOptions.DiskSpace,
 			OS:                c.options.TelemetryOptions.OS,
 			Architecture:      c.options.TelemetryOptions.Architecture,
 			DockerVersion:     c.options.TelemetryOptions.DockerVersion,
@@ -223,6 +228,11 @@ func (c *Client) Heartbeat(ctx context.Context, jobIDs []string) (knownIDs, canc
 			ExecutorName: c.options.ExecutorName,
 			JobIDs:       jobIDsInt,
 
+			Labels:    c.options.Labels,
+			NumCPUs:   c.options.ResourceOptions.NumCPUs,
+			Memory:    c.options.ResourceOptions.Memory,
+			DiskSpace: c.options.ResourceOptions.DiskSpace,
+
 			OS:              c.options.TelemetryOptions.OS,
 			Architecture:    c.options.TelemetryOptions.Architecture,
 			DockerVersion:   c.options.TelemetryOptions.DockerVersion,
@@ -14,6 +14,10 @@ type Options struct {
 	// QueueNames are the names of the queues being processed. Only one of QueueNames and QueueName can be set.
 	QueueNames []string
 
+	// Labels are the capabilities advertised by the executor. Only jobs whose required labels
+	// are all advertised are handed out to the executor.
+	Labels []string
+
 	// BaseClientOptions are the underlying HTTP client options.
 	BaseClientOptions apiclient.BaseClientOptions
 
@@ -3,6 +3,7 @@ package config
 import (
 	"encoding/json"
 	"net/url"
+	"os"
 	"path/filepath"
 	"runtime"
 	"strconv"
@@ -30,6 +31,8 @@ type Config struct {
 	QueueName                                      string
 	QueueNamesStr                                  string
 	QueueNames                                     []string
+	LabelsStr                                      string
+	Labels                                         []string
 	QueuePollInterval                              time.Duration
 	MaximumNumJobs                                 int
 	FirecrackerImage                               string
@@ -40,6 +43,11 @@ type Config struct {
 	KeepWorkspaces                                 bool
 	DockerHostMountPath                            string
 	UseFirecracker                                 bool
+	UseSandbox                                     bool
+	SandboxImageDir                                string
+	SandboxCgroupRoot                              string
+	SandboxNetwork                                 bool
+	SandboxImageMaxSize                            string
 	JobNumCPUs                                     int
 	JobMemory                                      string
 	FirecrackerDiskSpace                           string
@@ -47,6 +55,8 @@ type Config struct {
 	FirecrackerBandwidthEgress                     int
 	MaximumRuntimePerJob                           time.Duration
 	CleanupTaskInterval                            time.Duration
+	RepoCacheDir                                   string
+	RepoCacheMaxSize                               string
 	NumTotalJobs                                   int
 	MaxActiveTime                                  time.Duration
 	NodeExporterURL                                string
@@ -122,9 +132,15 @@ func (c *Config) Load() {
 	c.FrontendAuthorizationToken = c.Get("EXECUTOR_FRONTEND_PASSWORD", c.defaultFrontendPassword, "The authorization token supplied to the frontend.")
 	c.QueueName = c.GetOptional("EXECUTOR_QUEUE_NAME", "The name of the queue to listen to.")
 	c.QueueNamesStr = c.GetOptional("EXECUTOR_QUEUE_NAMES", "The names of multiple queues to listen to, comma-separated.")
+	c.LabelsStr = c.GetOptional("EXECUTOR_LABELS", "The labels advertised by this executor, comma-separated. Only jobs whose required labels are all advertised are handed out to this executor.")
 	c.QueuePollInterval = c.GetInterval("EXECUTOR_QUEUE_POLL_INTERVAL", "1s", "Interval between dequeue requests.")
 	c.MaximumNumJobs = c.GetInt("EXECUTOR_MAXIMUM_NUM_JOBS", "1", "Number of virtual machines or containers that can be running at once.")
-	c.UseFirecracker = c.GetBool("EXECUTOR_USE_FIRECRACKER", strconv.FormatBool(runtime.GOOS == "linux" && !IsKubernetes()), "Whether to isolate commands in virtual machines. Requires ignite and firecracker. Linux hosts only. Kubernetes is not supported.")
+	c.UseSandbox = c.GetBool("EXECUTOR_USE_SANDBOX", "false", "Whether to isolate commands in rootless namespace sandboxes, which requires neither Docker nor KVM. Linux hosts only. Cannot be combined with Firecracker.")
+	c.SandboxImageDir = c.GetOptional("EXECUTOR_SANDBOX_IMAGE_DIR", "A directory on the host to unpack the images of sandboxes into. Defaults to a directory in the system temp directory.")
+	c.SandboxCgroupRoot = c.GetOptional("EXECUTOR_SANDBOX_CGROUP_ROOT", "A cgroup v2 directory writable by the executor with the cpu and memory controllers enabled for its children. Sandboxes are created in it to enforce EXECUTOR_JOB_NUM_CPUS and EXECUTOR_JOB_MEMORY. Resource limits are not enforced if unset.")
+	c.SandboxImageMaxSize = c.Get("EXECUTOR_SANDBOX_IMAGE_MAX_SIZE", "20G", "The size the sandbox image directory is trimmed to by evicting the least recently used images.")
+	c.SandboxNetwork = c.GetBool("EXECUTOR_SANDBOX_NETWORK", "false", "Whether sandboxes share the network of the host. If false, sandboxes only have a loopback interface.")
+	c.UseFirecracker = c.GetBool("EXECUTOR_USE_FIRECRACKER", strconv.FormatBool(runtime.GOOS == "linux" && !IsKubernetes() && !c.UseSandbox), "Whether to isolate commands in virtual machines. Requires ignite and firecracker. Linux hosts only. Kubernetes is not supported.")
 	c.FirecrackerImage = c.Get("EXECUTOR_FIRECRACKER_IMAGE", DefaultFirecrackerImage, "The base image to use for virtual machines.")
 	c.FirecrackerKernelImage = c.Get("EXECUTOR_FIRECRACKER_KERNEL_IMAGE", DefaultFirecrackerKernelImage, "The base image containing the kernel binary to use for virtual machines.")
 	c.FirecrackerSandboxImage = c.Get("EXECUTOR_FIRECRACKER_SANDBOX_IMAGE", DefaultFirecrackerSandboxImage, "The OCI image for the ignite VM sandbox.")
@@ -139,6 +155,8 @@ func (c *Config) Load() {
 	c.FirecrackerBandwidthEgress = c.GetInt("EXECUTOR_FIRECRACKER_BANDWIDTH_EGRESS", "524288000", "How much bandwidth to allow for egress packets to the VM in bytes/s.")
 	c.MaximumRuntimePerJob = c.GetInterval("EXECUTOR_MAXIMUM_RUNTIME_PER_JOB", "30m", "The maximum wall time that can be spent on a single job.")
 	c.CleanupTaskInterval = c.GetInterval("EXECUTOR_CLEANUP_TASK_INTERVAL", "1m", "The frequency with which to run periodic cleanup tasks.")
+	c.RepoCacheDir = c.GetOptional("EXECUTOR_REPO_CACHE_DIR", "A directory on the host to cache bare repositories in, which workspaces borrow git objects from when cloning. The cache is disabled if unset. Kubernetes is not supported.")
+	c.RepoCacheMaxSize = c.Get("EXECUTOR_REPO_CACHE_MAX_SIZE", "50G", "The size the repository cache is trimmed to by evicting the least recently used repositories.")
 	c.NumTotalJobs = c.GetInt("EXECUTOR_NUM_TOTAL_JOBS", "0", "The maximum number of jobs that will be dequeued by the worker.")
 	c.NodeExporterURL = c.GetOptional("NODE_EXPORTER_URL", "The URL of the node_exporter instance, without the /metrics path.")
 	c.DockerRegistryNodeExporterURL = c.GetOptional("DOCKER_REGISTRY_NODE_EXPORTER_URL", "The URL of the Docker Registry instance's node_exporter, without the /metrics path.")
@@ -180,6 +198,12 @@ func (c *Config) Load() {
 		c.QueueNames = strings.Split(c.QueueNamesStr, ",")
 	}
 
+	for _, label := range strings.Split(c.LabelsStr, ",") {
+		if label = strings.TrimSpace(label); label != "" {
+			c.Labels = append(c.Labels, label)
+		}
+	}
+
 	if c.dockerAuthConfigStr != "" {
 		c.dockerAuthConfigUnmarshalError = json.Unmarshal([]byte(c.dockerAuthConfigStr), &c.DockerAuthConfig)
 	}
@@ -216,6 +240,10 @@ func (c *Config) Load() {
 		c.KubernetesConfigPath = getKubeConfigPath()
 	}
 
+	if c.SandboxImageDir == "" {
+		c.SandboxImageDir = filepath.Join(os.TempDir(), "executor-sandbox-images")
+	}
+
 	hn := hostname.Get()
 	// Be unique but also descriptive.
 	c.WorkerHostname = hn + "-" + uuid.New().String()
@@ -338,6 +366,38 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if c.UseSandbox {
+		// Validate that sandboxes can work on this host.
+		if runtime.GOOS != "linux" {
+			c.AddError(errors.New("EXECUTOR_USE_SANDBOX is only supported on linux hosts."))
+		}
+		if c.UseFirecracker {
+			c.AddError(errors.New("EXECUTOR_USE_SANDBOX and EXECUTOR_USE_FIRECRACKER cannot both be enabled."))
+		}
+		if IsKubernetes() {
+			c.AddError(errors.New("EXECUTOR_USE_SANDBOX is not supported on Kubernetes."))
+		}
+
+		// Make sure the image directory size is a valid datasize string.
+		if _, err := datasize.ParseString(c.SandboxImageMaxSize); err != nil {
+			c.AddError(errors.Wrapf(err, "invalid size provided for EXECUTOR_SANDBOX_IMAGE_MAX_SIZE: %q", c.SandboxImageMaxSize))
+		}
+	}
+
+	if c.RepoCacheDir != "" {
+		if IsKubernetes() {
+			c.AddError(errors.New("EXECUTOR_REPO_CACHE_DIR is not supported on Kubernetes."))
+		}
+		if runtime.GOOS == "windows" {
+			c.AddError(errors.New("EXECUTOR_REPO_CACHE_DIR is not supported on windows hosts."))
+		}
+
+		// Make sure the cache size is a valid datasize string.
+		if _, err := datasize.ParseString(c.RepoCacheMaxSize); err != nil {
+			c.AddError(errors.Wrapf(err, "invalid size provided for EXECUTOR_REPO_CACHE_MAX_SIZE: %q", c.RepoCacheMaxSize))
+		}
+	}
+
 	if len(c.KubernetesNodeSelector) > 0 {
 		nodeSelectorValues := strings.Split(c.KubernetesNodeSelector, ",")
 		for _, value := range nodeSelectorValues {
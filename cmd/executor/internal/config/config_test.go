@@ -1,6 +1,8 @@
 package config_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -74,6 +76,16 @@ func TestConfig_Load(t *testing.T) {
 			return `{"foo": "bar", "faz": "baz"}`
 		case "KUBERNETES_IMAGE_PULL_SECRETS":
 			return "foo,bar"
+		case "EXECUTOR_LABELS":
+			return "gpu, network:internal"
+		case "EXECUTOR_REPO_CACHE_MAX_SIZE":
+			return "10G"
+		case "EXECUTOR_USE_SANDBOX":
+			return "true"
+		case "EXECUTOR_SANDBOX_NETWORK":
+			return "true"
+		case "EXECUTOR_SANDBOX_IMAGE_MAX_SIZE":
+			return "5G"
 		default:
 			return name
 		}
@@ -84,6 +96,7 @@ func TestConfig_Load(t *testing.T) {
 	assert.Equal(t, "EXECUTOR_FRONTEND_PASSWORD", cfg.FrontendAuthorizationToken)
 	assert.Equal(t, "EXECUTOR_QUEUE_NAME", cfg.QueueName)
 	assert.Equal(t, "EXECUTOR_QUEUE_NAMES", cfg.QueueNamesStr)
+	assert.Equal(t, []string{"gpu", "network:internal"}, cfg.Labels)
 	assert.Equal(t, 10*time.Second, cfg.QueuePollInterval)
 	assert.Equal(t, 10, cfg.MaximumNumJobs)
 	assert.True(t, cfg.UseFirecracker)
@@ -101,6 +114,13 @@ func TestConfig_Load(t *testing.T) {
 	assert.Equal(t, 100, cfg.FirecrackerBandwidthEgress)
 	assert.Equal(t, 1*time.Minute, cfg.MaximumRuntimePerJob)
 	assert.Equal(t, 10*time.Minute, cfg.CleanupTaskInterval)
+	assert.Equal(t, "EXECUTOR_REPO_CACHE_DIR", cfg.RepoCacheDir)
+	assert.Equal(t, "10G", cfg.RepoCacheMaxSize)
+	assert.True(t, cfg.UseSandbox)
+	assert.Equal(t, "EXECUTOR_SANDBOX_IMAGE_DIR", cfg.SandboxImageDir)
+	assert.Equal(t, "EXECUTOR_SANDBOX_CGROUP_ROOT", cfg.SandboxCgroupRoot)
+	assert.True(t, cfg.SandboxNetwork)
+	assert.Equal(t, "5G", cfg.SandboxImageMaxSize)
 	assert.Equal(t, 10, cfg.NumTotalJobs)
 	assert.Equal(t, "NODE_EXPORTER_URL", cfg.NodeExporterURL)
 	assert.Equal(t, "DOCKER_REGISTRY_NODE_EXPORTER_URL", cfg.DockerRegistryNodeExporterURL)
@@ -194,6 +214,7 @@ func TestConfig_Load_Defaults(t *testing.T) {
 	assert.Empty(t, cfg.FrontendAuthorizationToken)
 	assert.Empty(t, cfg.QueueName)
 	assert.Empty(t, cfg.QueueNamesStr)
+	assert.Empty(t, cfg.Labels)
 	assert.Equal(t, time.Second, cfg.QueuePollInterval)
 	assert.Equal(t, 1, cfg.MaximumNumJobs)
 	assert.Equal(t, "sourcegraph/executor-vm:insiders", cfg.FirecrackerImage)
@@ -210,6 +231,13 @@ func TestConfig_Load_Defaults(t *testing.T) {
 	assert.Equal(t, 524288000, cfg.FirecrackerBandwidthEgress)
 	assert.Equal(t, 30*time.Minute, cfg.MaximumRuntimePerJob)
 	assert.Equal(t, 1*time.Minute, cfg.CleanupTaskInterval)
+	assert.Empty(t, cfg.RepoCacheDir)
+	assert.Equal(t, "50G", cfg.RepoCacheMaxSize)
+	assert.False(t, cfg.UseSandbox)
+	assert.Equal(t, filepath.Join(os.TempDir(), "executor-sandbox-images"), cfg.SandboxImageDir)
+	assert.Empty(t, cfg.SandboxCgroupRoot)
+	assert.False(t, cfg.SandboxNetwork)
+	assert.Equal(t, "20G", cfg.SandboxImageMaxSize)
 	assert.Zero(t, cfg.NumTotalJobs)
 	assert.Empty(t, cfg.NodeExporterURL)
 	assert.Empty(t, cfg.DockerRegistryNodeExporterURL)
@@ -348,6 +376,46 @@ func TestConfig_Validate(t *testing.T) {
 			},
 			expectedErr: errors.New("EXECUTOR_QUEUE_NAMES contains invalid queue name 'batches;codeintel', valid names are 'batches, codeintel' and should be comma-separated"),
 		},
+		{
+			name: "Invalid EXECUTOR_REPO_CACHE_MAX_SIZE",
+			getterFunc: func(name string, defaultValue, description string) string {
+				switch name {
+				case "EXECUTOR_QUEUE_NAME":
+					return "batches"
+				case "EXECUTOR_FRONTEND_URL":
+					return "http://some-url.com"
+				case "EXECUTOR_FRONTEND_PASSWORD":
+					return "some-password"
+				case "EXECUTOR_REPO_CACHE_DIR":
+					return "/var/cache/executor-repos"
+				case "EXECUTOR_REPO_CACHE_MAX_SIZE":
+					return "lots"
+				default:
+					return defaultValue
+				}
+			},
+			expectedErr: errors.New("invalid size provided for EXECUTOR_REPO_CACHE_MAX_SIZE: \"lots\": strconv.UnmarshalText: parsing \"lots\": invalid syntax"),
+		},
+		{
+			name: "Sandbox and Firecracker",
+			getterFunc: func(name string, defaultValue, description string) string {
+				switch name {
+				case "EXECUTOR_QUEUE_NAME":
+					return "batches"
+				case "EXECUTOR_FRONTEND_URL":
+					return "http://some-url.com"
+				case "EXECUTOR_FRONTEND_PASSWORD":
+					return "some-password"
+				case "EXECUTOR_USE_SANDBOX":
+					return "true"
+				case "EXECUTOR_USE_FIRECRACKER":
+					return "true"
+				default:
+					return defaultValue
+				}
+			},
+			expectedErr: errors.New("EXECUTOR_USE_SANDBOX and EXECUTOR_USE_FIRECRACKER cannot both be enabled."),
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -0,0 +1,90 @@
+//go:build !windows
+// +build !windows
+
+// Package filelock provides advisory file locks that are shared with other processes on
+// the host, such as other executors sharing a cache directory.
+package filelock
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// RetryInterval is the time between two attempts to acquire a held lock.
+const RetryInterval = 100 * time.Millisecond
+
+// Lock is an advisory lock on a file, shared with other processes on the host.
+type Lock struct {
+	f *os.File
+}
+
+// Acquire acquires a lock on the file at the given path, creating it if needed. It
+// blocks until the lock is acquired or the context is canceled.
+func Acquire(ctx context.Context, path string, exclusive bool) (*Lock, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &Lock{f: f}
+	if err := l.lock(ctx, exclusive); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return l, nil
+}
+
+// TryAcquire acquires an exclusive lock on the file at the given path, creating it if
+// needed. It returns false without blocking if the lock is held by someone else.
+func TryAcquire(path string) (*Lock, bool, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		_ = f.Close()
+		if errors.Is(err, syscall.EWOULDBLOCK) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	return &Lock{f: f}, true, nil
+}
+
+// Downgrade converts the exclusive lock into a shared lock.
+func (l *Lock) Downgrade(ctx context.Context) error {
+	return l.lock(ctx, false)
+}
+
+// Unlock releases the lock.
+func (l *Lock) Unlock() error {
+	return l.f.Close()
+}
+
+func (l *Lock) lock(ctx context.Context, exclusive bool) error {
+	how := syscall.LOCK_SH
+	if exclusive {
+		how = syscall.LOCK_EX
+	}
+
+	for {
+		err := syscall.Flock(int(l.f.Fd()), how|syscall.LOCK_NB)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, syscall.EWOULDBLOCK) && !errors.Is(err, syscall.EINTR) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(RetryInterval):
+		}
+	}
+}
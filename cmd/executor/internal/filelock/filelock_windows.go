@@ -0,0 +1,28 @@
+package filelock
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ErrUnsupported is returned by all locking functions on Windows.
+var ErrUnsupported = errors.New("file locks are not supported on Windows")
+
+type Lock struct{}
+
+func Acquire(ctx context.Context, path string, exclusive bool) (*Lock, error) {
+	return nil, ErrUnsupported
+}
+
+func TryAcquire(path string) (*Lock, bool, error) {
+	return nil, false, ErrUnsupported
+}
+
+func (l *Lock) Downgrade(ctx context.Context) error {
+	return ErrUnsupported
+}
+
+func (l *Lock) Unlock() error {
+	return nil
+}
@@ -7,8 +7,10 @@ import (
 )
 
 type metrics struct {
-	numVMsRemoved prometheus.Counter
-	numErrors     prometheus.Counter
+	numVMsRemoved           prometheus.Counter
+	numCachedReposEvicted   prometheus.Counter
+	numSandboxImagesEvicted prometheus.Counter
+	numErrors               prometheus.Counter
 }
 
 var NewMetrics = newMetrics
@@ -28,13 +30,23 @@ func newMetrics(observationCtx *observation.Context) *metrics {
 		"src_executor_orphaned_vms_removed_total",
 		"The number of orphaned virtual machines removed from the host.",
 	)
+	numCachedReposEvicted := counter(
+		"src_executor_cached_repos_evicted_total",
+		"The number of repositories evicted from the repository cache on the host.",
+	)
+	numSandboxImagesEvicted := counter(
+		"src_executor_sandbox_images_evicted_total",
+		"The number of images evicted from the sandbox image directory on the host.",
+	)
 	numErrors := counter(
 		"src_executor_janitor_errors_total",
 		"The number of errors that occur during the janitor job.",
 	)
 
 	return &metrics{
-		numVMsRemoved: numVMsRemoved,
-		numErrors:     numErrors,
+		numVMsRemoved:           numVMsRemoved,
+		numCachedReposEvicted:   numCachedReposEvicted,
+		numSandboxImagesEvicted: numSandboxImagesEvicted,
+		numErrors:               numErrors,
 	}
 }
@@ -0,0 +1,64 @@
+package janitor
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+)
+
+// RepoCache is a cache of repositories on the host that can be trimmed to its maximum
+// size.
+type RepoCache interface {
+	// Evict removes the least recently used repositories from the cache until it fits
+	// its maximum size, and returns the number of removed repositories.
+	Evict(ctx context.Context) (int, error)
+}
+
+type repoCacheJanitor struct {
+	logger  log.Logger
+	cache   RepoCache
+	metrics *metrics
+}
+
+var (
+	_ goroutine.Handler      = &repoCacheJanitor{}
+	_ goroutine.ErrorHandler = &repoCacheJanitor{}
+)
+
+// NewRepoCacheJanitor returns a background routine that periodically evicts the least
+// recently used repositories from the repository cache on the host.
+func NewRepoCacheJanitor(
+	logger log.Logger,
+	cache RepoCache,
+	interval time.Duration,
+	metrics *metrics,
+) goroutine.BackgroundRoutine {
+	return goroutine.NewPeriodicGoroutine(
+		context.Background(),
+		&repoCacheJanitor{
+			logger:  logger,
+			cache:   cache,
+			metrics: metrics,
+		},
+		goroutine.WithName("executors.repo-cache-janitor"),
+		goroutine.WithDescription("evicts the least recently used repositories from the repository cache"),
+		goroutine.WithInterval(interval),
+	)
+}
+
+func (j *repoCacheJanitor) Handle(ctx context.Context) error {
+	evicted, err := j.cache.Evict(ctx)
+	if evicted > 0 {
+		j.logger.Info("Evicted cached repositories", log.Int("count", evicted))
+		j.metrics.numCachedReposEvicted.Add(float64(evicted))
+	}
+	return err
+}
+
+func (j *repoCacheJanitor) HandleError(err error) {
+	j.metrics.numErrors.Inc()
+	j.logger.Error("Failed to evict cached repositories", log.Error(err))
+}
@@ -0,0 +1,64 @@
+package janitor
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+)
+
+// SandboxImages is a directory of unpacked sandbox images on the host that can be
+// trimmed to its maximum size.
+type SandboxImages interface {
+	// Evict removes the least recently used images from the directory until it fits its
+	// maximum size, and returns the number of removed images.
+	Evict(ctx context.Context) (int, error)
+}
+
+type sandboxImageJanitor struct {
+	logger  log.Logger
+	images  SandboxImages
+	metrics *metrics
+}
+
+var (
+	_ goroutine.Handler      = &sandboxImageJanitor{}
+	_ goroutine.ErrorHandler = &sandboxImageJanitor{}
+)
+
+// NewSandboxImageJanitor returns a background routine that periodically evicts the least
+// recently used images unpacked for sandboxes on the host.
+func NewSandboxImageJanitor(
+	logger log.Logger,
+	images SandboxImages,
+	interval time.Duration,
+	metrics *metrics,
+) goroutine.BackgroundRoutine {
+	return goroutine.NewPeriodicGoroutine(
+		context.Background(),
+		&sandboxImageJanitor{
+			logger:  logger,
+			images:  images,
+			metrics: metrics,
+		},
+		goroutine.WithName("executors.sandbox-image-janitor"),
+		goroutine.WithDescription("evicts the least recently used images from the sandbox image directory"),
+		goroutine.WithInterval(interval),
+	)
+}
+
+func (j *sandboxImageJanitor) Handle(ctx context.Context) error {
+	evicted, err := j.images.Evict(ctx)
+	if evicted > 0 {
+		j.logger.Info("Evicted sandbox images", log.Int("count", evicted))
+		j.metrics.numSandboxImagesEvicted.Add(float64(evicted))
+	}
+	return err
+}
+
+func (j *sandboxImageJanitor) HandleError(err error) {
+	j.metrics.numErrors.Inc()
+	j.logger.Error("Failed to evict sandbox images", log.Error(err))
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/c2h5oh/datasize"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sourcegraph/log"
 	"github.com/urfave/cli/v2"
@@ -14,8 +15,10 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/config"
 	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/ignite"
 	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/janitor"
+	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/sandbox"
 	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/util"
 	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/worker"
+	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/worker/workspace"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -49,8 +52,13 @@ func StandaloneRun(ctx context.Context, runner util.CmdRunner, logger log.Logger
 
 	// TODO: This is too similar to the RunValidate func. Make it share even more code.
 	if runVerifyChecks {
-		// Then, validate all tools that are required are installed.
-		if err := util.ValidateRequiredTools(runner, cfg.UseFirecracker); err != nil {
+		// Then, validate all tools that are required are installed. Sandboxes need
+		// neither docker nor src-cli.
+		if cfg.UseSandbox {
+			if err := sandbox.Validate(cfg.SandboxCgroupRoot); err != nil {
+				return err
+			}
+		} else if err := util.ValidateRequiredTools(runner, cfg.UseFirecracker); err != nil {
 			return err
 		}
 
@@ -62,27 +70,29 @@ func StandaloneRun(ctx context.Context, runner util.CmdRunner, logger log.Logger
 		// TODO: Validate access token.
 		// Validate src-cli is of a good version, rely on the connected instance to tell
 		// us what "good" means.
-		client, err := apiclient.NewBaseClient(logger, opts.QueueOptions.BaseClientOptions)
-		if err != nil {
-			return err
-		}
-		if err = util.ValidateSrcCLIVersion(ctx, runner, client, opts.QueueOptions.BaseClientOptions.EndpointOptions); err != nil {
-			if errors.Is(err, util.ErrSrcPatchBehind) {
-				// This is ok. The patch just doesn't match but still works.
-				logger.Warn("A newer patch release version of src-cli is available, consider running executor install src-cli to upgrade", log.Error(err))
-			} else {
+		if !cfg.UseSandbox {
+			client, err := apiclient.NewBaseClient(logger, opts.QueueOptions.BaseClientOptions)
+			if err != nil {
 				return err
 			}
+			if err = util.ValidateSrcCLIVersion(ctx, runner, client, opts.QueueOptions.BaseClientOptions.EndpointOptions); err != nil {
+				if errors.Is(err, util.ErrSrcPatchBehind) {
+					// This is ok. The patch just doesn't match but still works.
+					logger.Warn("A newer patch release version of src-cli is available, consider running executor install src-cli to upgrade", log.Error(err))
+				} else {
+					return err
+				}
+			}
 		}
 
 		if cfg.UseFirecracker {
 			// Validate ignite is installed.
-			if err = util.ValidateIgniteInstalled(ctx, runner); err != nil {
+			if err := util.ValidateIgniteInstalled(ctx, runner); err != nil {
 				return err
 			}
 
 			// Validate all required CNI plugins are installed.
-			if err = util.ValidateCNIInstalled(runner); err != nil {
+			if err := util.ValidateCNIInstalled(runner); err != nil {
 				return err
 			}
 
@@ -91,6 +101,38 @@ func StandaloneRun(ctx context.Context, runner util.CmdRunner, logger log.Logger
 		}
 	}
 
+	if cfg.RepoCacheDir != "" {
+		maxSize, err := datasize.ParseString(cfg.RepoCacheMaxSize)
+		if err != nil {
+			return errors.Wrap(err, "parsing EXECUTOR_REPO_CACHE_MAX_SIZE")
+		}
+		opts.RepoCache, err = workspace.NewRepoCache(cfg.RepoCacheDir, int64(maxSize.Bytes()))
+		if err != nil {
+			return err
+		}
+	}
+
+	var images *sandbox.ImageStore
+	if cfg.UseSandbox {
+		if cfg.SandboxCgroupRoot == "" && (cfg.JobNumCPUs != 0 || !isZeroSize(cfg.JobMemory)) {
+			logger.Warn(
+				"EXECUTOR_JOB_NUM_CPUS and EXECUTOR_JOB_MEMORY are not enforced in sandboxes without EXECUTOR_SANDBOX_CGROUP_ROOT",
+				log.Int("numCPUs", cfg.JobNumCPUs),
+				log.String("memory", cfg.JobMemory),
+			)
+		}
+
+		maxSize, err := datasize.ParseString(cfg.SandboxImageMaxSize)
+		if err != nil {
+			return errors.Wrap(err, "parsing EXECUTOR_SANDBOX_IMAGE_MAX_SIZE")
+		}
+		opts.RunnerOptions.SandboxOptions.ImageMaxSize = int64(maxSize.Bytes())
+		images, err = sandbox.NewImageStore(cfg.SandboxImageDir, int64(maxSize.Bytes()))
+		if err != nil {
+			return err
+		}
+	}
+
 	nameSet := janitor.NewNameSet()
 	ctx, cancel := context.WithCancel(ctx)
 	wrk, err := worker.NewWorker(observationCtx, nameSet, opts)
@@ -100,6 +142,7 @@ func StandaloneRun(ctx context.Context, runner util.CmdRunner, logger log.Logger
 	}
 
 	routines := []goroutine.BackgroundRoutine{wrk}
+	janitorMetrics := janitor.NewMetrics(observationCtx)
 
 	if cfg.UseFirecracker {
 		routines = append(routines, janitor.NewOrphanedVMJanitor(
@@ -107,13 +150,31 @@ func StandaloneRun(ctx context.Context, runner util.CmdRunner, logger log.Logger
 			cfg.VMPrefix,
 			nameSet,
 			cfg.CleanupTaskInterval,
-			janitor.NewMetrics(observationCtx),
+			janitorMetrics,
 			runner,
 		))
 
 		mustRegisterVMCountMetric(observationCtx, runner, logger, cfg.VMPrefix)
 	}
 
+	if opts.RepoCache != nil {
+		routines = append(routines, janitor.NewRepoCacheJanitor(
+			log.Scoped("repo-cache-janitor", "evicts the least recently used repositories from the repository cache"),
+			opts.RepoCache,
+			cfg.CleanupTaskInterval,
+			janitorMetrics,
+		))
+	}
+
+	if images != nil {
+		routines = append(routines, janitor.NewSandboxImageJanitor(
+			log.Scoped("sandbox-image-janitor", "evicts the least recently used images from the sandbox image directory"),
+			images,
+			cfg.CleanupTaskInterval,
+			janitorMetrics,
+		))
+	}
+
 	go func() {
 		// Block until the worker has exited. The executor worker is unique
 		// in that we want a maximum runtime and/or number of jobs to be
@@ -131,6 +192,12 @@ func StandaloneRun(ctx context.Context, runner util.CmdRunner, logger log.Logger
 	return nil
 }
 
+// isZeroSize returns true if the given size, like the memory of jobs, sets no bound.
+func isZeroSize(size string) bool {
+	parsed, err := datasize.ParseString(size)
+	return size == "" || (err == nil && parsed == 0)
+}
+
 func mustRegisterVMCountMetric(observationCtx *observation.Context, runner util.CmdRunner, logger log.Logger, prefix string) {
 	observationCtx.Registerer.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
 		Name: "src_executor_vms_total",
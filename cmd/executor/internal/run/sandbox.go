@@ -0,0 +1,28 @@
+package run
+
+import (
+	"github.com/sourcegraph/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/config"
+	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/sandbox"
+	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/util"
+)
+
+// Sandbox runs a command in a new sandbox and exits with its exit code. The sandbox
+// runtime invokes it for every step, it is not meant to be run by hand.
+func Sandbox(cliCtx *cli.Context, runner util.CmdRunner, logger log.Logger, cfg *config.Config) error {
+	opts, err := sandbox.ParseArgs(cliCtx.Args().Slice())
+	if err != nil {
+		return err
+	}
+
+	exitCode, err := sandbox.Run(cliCtx.Context, opts)
+	if err != nil {
+		return err
+	}
+	if exitCode != 0 {
+		return cli.Exit("", exitCode)
+	}
+	return nil
+}
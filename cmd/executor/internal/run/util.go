@@ -72,6 +72,7 @@ func apiWorkerOptions(c *config.Config, queueTelemetryOptions queue.TelemetryOpt
 			DockerOptions:      dockerOptions(c),
 			FirecrackerOptions: firecrackerOptions(c),
 			KubernetesOptions:  kubernetesOptions(c),
+			SandboxOptions:     sandboxOptions(c),
 		},
 		GitServicePath: "/.executors/git",
 		QueueOptions:   queueOptions(c, queueTelemetryOptions),
@@ -127,6 +128,19 @@ func firecrackerOptions(c *config.Config) runner.FirecrackerOptions {
 	}
 }
 
+func sandboxOptions(c *config.Config) runner.SandboxOptions {
+	return runner.SandboxOptions{
+		Enabled:  c.UseSandbox,
+		ImageDir: c.SandboxImageDir,
+		SandboxOptions: command.SandboxOptions{
+			CgroupRoot: c.SandboxCgroupRoot,
+			Network:    c.SandboxNetwork,
+			Resources:  resourceOptions(c),
+		},
+		DockerAuthConfig: c.DockerAuthConfig,
+	}
+}
+
 func resourceOptions(c *config.Config) command.ResourceOptions {
 	return command.ResourceOptions{
 		NumCPUs:             c.JobNumCPUs,
@@ -143,6 +157,7 @@ func queueOptions(c *config.Config, telemetryOptions queue.TelemetryOptions) que
 		ExecutorName:      c.WorkerHostname,
 		QueueName:         c.QueueName,
 		QueueNames:        c.QueueNames,
+		Labels:            c.Labels,
 		BaseClientOptions: baseClientOptions(c, "/.executors/queue"),
 		TelemetryOptions:  telemetryOptions,
 		ResourceOptions: queue.ResourceOptions{
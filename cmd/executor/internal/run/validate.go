@@ -10,6 +10,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/apiclient"
 	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/config"
+	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/sandbox"
 	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/util"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -32,7 +33,12 @@ func Validate(cliCtx *cli.Context, runner util.CmdRunner, logger log.Logger, con
 		return err
 	}
 
-	if !config.IsKubernetes() {
+	if conf.UseSandbox {
+		// Sandboxes need neither docker nor src-cli.
+		if err = sandbox.Validate(conf.SandboxCgroupRoot); err != nil {
+			return err
+		}
+	} else if !config.IsKubernetes() {
 		// Then, validate all tools that are required are installed.
 		if err = util.ValidateRequiredTools(runner, conf.UseFirecracker); err != nil {
 			return err
@@ -0,0 +1,125 @@
+package sandbox
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/c2h5oh/datasize"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// cpuPeriod is the cgroup scheduling period in microseconds that CPU quotas are
+// relative to.
+const cpuPeriod = 100000
+
+// cgroup is a cgroup v2 created for a single sandbox.
+type cgroup struct {
+	path string
+}
+
+// newCgroup creates a cgroup for a sandbox below the given root, limited to the given
+// number of CPUs and memory. Zero or empty limits are not enforced.
+func newCgroup(root string, numCPUs int, memory string) (*cgroup, error) {
+	path, err := os.MkdirTemp(root, "sandbox-")
+	if err != nil {
+		return nil, err
+	}
+	cg := &cgroup{path: path}
+
+	if err := cg.setLimits(numCPUs, memory); err != nil {
+		return nil, errors.Append(err, cg.remove())
+	}
+	return cg, nil
+}
+
+func (cg *cgroup) setLimits(numCPUs int, memory string) error {
+	if numCPUs > 0 {
+		if err := cg.write("cpu.max", strconv.Itoa(numCPUs*cpuPeriod)+" "+strconv.Itoa(cpuPeriod)); err != nil {
+			return err
+		}
+	}
+
+	if memory != "" && memory != "0" {
+		size, err := datasize.ParseString(memory)
+		if err != nil {
+			return errors.Wrapf(err, "invalid memory limit %q", memory)
+		}
+		if err := cg.write("memory.max", strconv.FormatUint(size.Bytes(), 10)); err != nil {
+			return err
+		}
+		// Like Docker with equal memory and swap limits, don't let the sandbox swap. Not
+		// every host has swap accounting enabled.
+		_ = cg.write("memory.swap.max", "0")
+	}
+
+	return nil
+}
+
+// add moves the process with the given PID into the cgroup. Children of the process are
+// in the cgroup as well.
+func (cg *cgroup) add(pid int) error {
+	return cg.write("cgroup.procs", strconv.Itoa(pid))
+}
+
+// remove kills the processes left in the cgroup and removes it.
+func (cg *cgroup) remove() error {
+	// cgroup.kill is only available since Linux 5.14. The sandbox is normally empty
+	// already, as its init process is gone.
+	_ = cg.write("cgroup.kill", "1")
+
+	// The cgroup cannot be removed until the killed processes have exited.
+	var err error
+	for i := 0; i < 50; i++ {
+		if err = os.Remove(cg.path); err == nil || os.IsNotExist(err) {
+			return nil
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return errors.Wrapf(err, "removing cgroup %s", cg.path)
+}
+
+func (cg *cgroup) write(file, value string) error {
+	if err := os.WriteFile(filepath.Join(cg.path, file), []byte(value), 0); err != nil {
+		return errors.Wrapf(err, "writing %s", file)
+	}
+	return nil
+}
+
+// validateCgroupRoot checks that cgroups limiting CPU and memory can be created below
+// the given cgroup v2 directory.
+func validateCgroupRoot(root string) error {
+	b, err := os.ReadFile(filepath.Join(root, "cgroup.subtree_control"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return errors.Newf("%s is not a cgroup v2 directory", root)
+		}
+		return err
+	}
+
+	controllers := strings.Fields(string(b))
+	for _, controller := range []string{"cpu", "memory"} {
+		if !contains(controllers, controller) {
+			return errors.Newf("the %s controller is not enabled for the children of %s, add it to cgroup.subtree_control", controller, root)
+		}
+	}
+
+	// The executor needs to be able to create cgroups in the root.
+	dir, err := os.MkdirTemp(root, "sandbox-")
+	if err != nil {
+		return err
+	}
+	return os.Remove(dir)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
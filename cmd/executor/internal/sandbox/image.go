@@ -0,0 +1,293 @@
+package sandbox
+
+import (
+	"context"
+	"encoding/json"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/filelock"
+	"github.com/sourcegraph/sourcegraph/internal/executor/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Image is an image unpacked on the host.
+type Image struct {
+	// Rootfs is the directory containing the root filesystem of the image.
+	Rootfs string
+	// Env are the environment variables set by the image, in the form KEY=value.
+	Env []string
+}
+
+// ImageStore pulls images from registries and unpacks them on the host. Images are
+// stored by the digest of their manifest, so every image is only unpacked once. Images
+// are not modified after they have been unpacked and can be shared between sandboxes.
+//
+// Like the repository cache, each unpacked image is guarded by a lock file next to it,
+// so that an image directory can be shared by multiple executors on the same host. The
+// modification time of the lock file records when the image was last pulled, which
+// drives the eviction of the least recently used images.
+type ImageStore struct {
+	dir     string
+	maxSize int64
+	client  *http.Client
+}
+
+// NewImageStore returns an image store that unpacks images into the given directory.
+// The store grows beyond maxSize bytes only until the next call to Evict.
+func NewImageStore(dir string, maxSize int64) (*ImageStore, error) {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, err
+	}
+	return &ImageStore{dir: dir, maxSize: maxSize, client: http.DefaultClient}, nil
+}
+
+const (
+	// imageConfigFile is the file next to the root filesystem of an unpacked image that
+	// contains its config.
+	imageConfigFile = "config.json"
+
+	// imageLockSuffix is the suffix of the lock file next to each unpacked image.
+	imageLockSuffix = ".lock"
+)
+
+// Pull pulls the given image for the platform of the executor, unless it is unpacked
+// already, and returns it. The credentials of the given docker CLI config are used to
+// authenticate to the registry. The image is not evicted until the returned release
+// function is called.
+func (s *ImageStore) Pull(ctx context.Context, image string, authConfig types.DockerAuthConfig) (_ Image, release func() error, err error) {
+	ref, err := parseReference(image)
+	if err != nil {
+		return Image{}, nil, err
+	}
+
+	client := newRegistryClient(s.client, ref, authConfig)
+	m, digest, err := client.resolveManifest(ctx)
+	if err != nil {
+		return Image{}, nil, errors.Wrapf(err, "resolving image %s", image)
+	}
+
+	dir := filepath.Join(s.dir, strings.Replace(digest, ":", "-", 1))
+	lock, err := filelock.Acquire(ctx, dir+imageLockSuffix, false)
+	if err != nil {
+		return Image{}, nil, errors.Wrap(err, "locking image")
+	}
+	defer func() {
+		if err != nil {
+			_ = lock.Unlock()
+		}
+	}()
+
+	now := time.Now()
+	if err := os.Chtimes(dir+imageLockSuffix, now, now); err != nil {
+		return Image{}, nil, errors.Wrap(err, "marking image as used")
+	}
+
+	img, err := s.unpackOnce(ctx, client, m, image, dir)
+	if err != nil {
+		return Image{}, nil, err
+	}
+	return img, lock.Unlock, nil
+}
+
+// unpackOnce unpacks the image with the given manifest into the given directory, unless
+// it is unpacked already.
+func (s *ImageStore) unpackOnce(ctx context.Context, client *registryClient, m manifest, image, dir string) (Image, error) {
+	if img, err := readImage(dir); err == nil {
+		return img, nil
+	} else if !os.IsNotExist(err) {
+		return Image{}, err
+	}
+
+	// Unpack into a temporary directory first, so that images that failed to unpack are
+	// never used.
+	tmpDir, err := os.MkdirTemp(s.dir, ".pull-")
+	if err != nil {
+		return Image{}, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if err := unpack(ctx, client, m, tmpDir); err != nil {
+		return Image{}, errors.Wrapf(err, "unpacking image %s", image)
+	}
+	if err := os.Rename(tmpDir, dir); err != nil {
+		// Another job might have unpacked the same image concurrently.
+		if img, readErr := readImage(dir); readErr == nil {
+			return img, nil
+		}
+		return Image{}, err
+	}
+
+	return readImage(dir)
+}
+
+func unpack(ctx context.Context, client *registryClient, m manifest, dir string) error {
+	config, err := client.fetchConfig(ctx, m.Config)
+	if err != nil {
+		return err
+	}
+
+	rootfs := filepath.Join(dir, "rootfs")
+	if err := os.Mkdir(rootfs, 0o755); err != nil {
+		return err
+	}
+	for _, layer := range m.Layers {
+		if err := pullLayer(ctx, client, layer, rootfs); err != nil {
+			return errors.Wrapf(err, "layer %s", layer.Digest)
+		}
+	}
+
+	b, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, imageConfigFile), b, 0o644)
+}
+
+func pullLayer(ctx context.Context, client *registryClient, layer descriptor, rootfs string) error {
+	if strings.HasSuffix(layer.MediaType, "+zstd") {
+		return errors.Newf("unsupported layer type %q", layer.MediaType)
+	}
+
+	blob, err := client.fetchBlob(ctx, layer.Digest)
+	if err != nil {
+		return err
+	}
+	defer blob.Close()
+
+	if err := applyLayer(rootfs, blob); err != nil {
+		return err
+	}
+	return blob.verify()
+}
+
+func readImage(dir string) (Image, error) {
+	b, err := os.ReadFile(filepath.Join(dir, imageConfigFile))
+	if err != nil {
+		return Image{}, err
+	}
+	var config imageConfig
+	if err := json.Unmarshal(b, &config); err != nil {
+		return Image{}, err
+	}
+	return Image{Rootfs: filepath.Join(dir, "rootfs"), Env: config.Config.Env}, nil
+}
+
+// Evict removes the least recently used images until the total size of the store is at
+// most its maximum size. Images that are in use by a sandbox are skipped. It returns the
+// number of removed images.
+func (s *ImageStore) Evict(ctx context.Context) (int, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return 0, errors.Wrap(err, "reading image directory")
+	}
+
+	type storedImage struct {
+		dir      string
+		size     int64
+		lastUsed time.Time
+	}
+
+	var images []storedImage
+	var totalSize int64
+	for _, entry := range entries {
+		// Skip the lock files, and the temporary directories of images being unpacked.
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		dir := filepath.Join(s.dir, entry.Name())
+
+		size, err := dirSize(dir)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				// Removed concurrently.
+				continue
+			}
+			return 0, errors.Wrapf(err, "computing size of image %q", entry.Name())
+		}
+
+		// Images without a lock file are treated as the least recently used ones.
+		var lastUsed time.Time
+		if info, err := os.Stat(dir + imageLockSuffix); err == nil {
+			lastUsed = info.ModTime()
+		}
+
+		images = append(images, storedImage{dir: dir, size: size, lastUsed: lastUsed})
+		totalSize += size
+	}
+
+	sort.Slice(images, func(i, j int) bool {
+		return images[i].lastUsed.Before(images[j].lastUsed)
+	})
+
+	evicted := 0
+	for _, image := range images {
+		if totalSize <= s.maxSize {
+			break
+		}
+		if err := ctx.Err(); err != nil {
+			return evicted, err
+		}
+
+		removed, err := removeImage(image.dir)
+		if err != nil {
+			return evicted, err
+		}
+		if removed {
+			totalSize -= image.size
+			evicted++
+		}
+	}
+
+	return evicted, nil
+}
+
+// removeImage deletes the unpacked image in the given directory, unless it is in use.
+// The lock file is kept, as jobs may be waiting on it.
+func removeImage(dir string) (bool, error) {
+	l, ok, err := filelock.TryAcquire(dir + imageLockSuffix)
+	if err != nil {
+		return false, errors.Wrapf(err, "locking image %q", filepath.Base(dir))
+	}
+	if !ok {
+		return false, nil
+	}
+	defer l.Unlock()
+
+	// Directories of images may not be writable, which prevents removing their contents.
+	_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && d.IsDir() {
+			_ = os.Chmod(path, 0o755)
+		}
+		return nil
+	})
+	if err := os.RemoveAll(dir); err != nil {
+		return false, errors.Wrapf(err, "removing image %q", filepath.Base(dir))
+	}
+	return true, nil
+}
+
+// dirSize returns the total size of the regular files below the given directory.
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		size += info.Size()
+		return nil
+	})
+	return size, err
+}
@@ -0,0 +1,217 @@
+package sandbox
+
+import (
+	"archive/tar"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/executor/types"
+)
+
+func TestImageStore_Pull(t *testing.T) {
+	registry := newTestRegistry(t)
+
+	store, err := NewImageStore(t.TempDir(), 0)
+	require.NoError(t, err)
+
+	authConfig := types.DockerAuthConfig{Auths: types.DockerAuthConfigAuths{
+		"http://" + registry.host + "/v1/": {Auth: []byte("user:pass")},
+	}}
+	image := registry.host + "/org/image:1.0"
+
+	img, release, err := store.Pull(context.Background(), image, authConfig)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"PATH=/usr/bin:/bin", "LANG=C.UTF-8"}, img.Env)
+	content, err := os.ReadFile(filepath.Join(img.Rootfs, "etc/os-release"))
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(content))
+	assert.Equal(t, int32(1), registry.layerPulls.Load())
+
+	// The image is only unpacked once.
+	cached, releaseCached, err := store.Pull(context.Background(), image, authConfig)
+	require.NoError(t, err)
+	assert.Equal(t, img, cached)
+	assert.Equal(t, int32(1), registry.layerPulls.Load())
+
+	// Images in use are not evicted.
+	require.NoError(t, release())
+	evicted, err := store.Evict(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, evicted)
+	assert.DirExists(t, img.Rootfs)
+
+	// Unused images are evicted once the store is over its maximum size, and pulled
+	// again when they are needed.
+	require.NoError(t, releaseCached())
+	evicted, err = store.Evict(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, evicted)
+	assert.NoDirExists(t, img.Rootfs)
+
+	_, release, err = store.Pull(context.Background(), image, authConfig)
+	require.NoError(t, err)
+	require.NoError(t, release())
+	assert.Equal(t, int32(2), registry.layerPulls.Load())
+
+	// Pulling requires credentials.
+	_, _, err = store.Pull(context.Background(), image, types.DockerAuthConfig{})
+	assert.ErrorContains(t, err, "unexpected status 401 fetching token")
+}
+
+func TestImageStore_Evict(t *testing.T) {
+	store, err := NewImageStore(t.TempDir(), 10)
+	require.NoError(t, err)
+
+	// Images a and c are the least recently used ones.
+	now := time.Now()
+	for i, name := range []string{"sha256-a", "sha256-b", "sha256-c"} {
+		dir := filepath.Join(store.dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "rootfs/bin"), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "rootfs/bin/sh"), []byte("12345"), 0o755))
+		// Directories of images may not be writable.
+		require.NoError(t, os.Chmod(filepath.Join(dir, "rootfs/bin"), 0o555))
+
+		lastUsed := now.Add(time.Duration(i) * time.Minute)
+		if name == "sha256-c" {
+			lastUsed = now.Add(-time.Hour)
+		}
+		require.NoError(t, os.WriteFile(dir+imageLockSuffix, nil, 0o644))
+		require.NoError(t, os.Chtimes(dir+imageLockSuffix, lastUsed, lastUsed))
+	}
+
+	evicted, err := store.Evict(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, evicted)
+	assert.NoDirExists(t, filepath.Join(store.dir, "sha256-c"))
+	assert.DirExists(t, filepath.Join(store.dir, "sha256-a"))
+	assert.DirExists(t, filepath.Join(store.dir, "sha256-b"))
+}
+
+func TestImageStore_Pull_DigestMismatch(t *testing.T) {
+	registry := newTestRegistry(t)
+	registry.corruptLayer = true
+
+	store, err := NewImageStore(t.TempDir(), 0)
+	require.NoError(t, err)
+
+	authConfig := types.DockerAuthConfig{Auths: types.DockerAuthConfigAuths{
+		registry.host: {Auth: []byte("user:pass")},
+	}}
+	_, _, err = store.Pull(context.Background(), registry.host+"/org/image:1.0", authConfig)
+	assert.ErrorContains(t, err, "does not match its digest")
+
+	// Nothing but the lock file of the image is left in the store.
+	entries, err := os.ReadDir(store.dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.True(t, strings.HasSuffix(entries[0].Name(), imageLockSuffix))
+}
+
+func TestParseReference(t *testing.T) {
+	tests := map[string]reference{
+		"alpine":                        {registry: "docker.io", repository: "library/alpine", reference: "latest"},
+		"sourcegraph/src-cli:5.0":       {registry: "docker.io", repository: "sourcegraph/src-cli", reference: "5.0"},
+		"ghcr.io/org/image@sha256:abcd": {registry: "ghcr.io", repository: "org/image", reference: "sha256:abcd"},
+		"localhost:5000/image:tag":      {registry: "localhost:5000", repository: "image", reference: "tag"},
+	}
+	for image, expected := range tests {
+		ref, err := parseReference(image)
+		require.NoError(t, err)
+		assert.Equal(t, expected, ref, image)
+	}
+
+	_, err := parseReference("alpine@latest")
+	assert.Error(t, err)
+}
+
+type testRegistry struct {
+	host         string
+	corruptLayer bool
+	layerPulls   atomic.Int32
+}
+
+// newTestRegistry starts a registry serving a multi-platform org/image:1.0 image, that
+// requires a bearer token.
+func newTestRegistry(t *testing.T) *testRegistry {
+	t.Helper()
+
+	layer := newLayer(t, true, []tar.Header{
+		{Name: "etc/os-release", Typeflag: tar.TypeReg, Mode: 0o644, Size: 5},
+	}).Bytes()
+	config := []byte(`{"architecture":"amd64","os":"linux","config":{"Env":["PATH=/usr/bin:/bin","LANG=C.UTF-8"]}}`)
+	manifest := []byte(fmt.Sprintf(
+		`{"schemaVersion":2,"mediaType":%q,"config":{"mediaType":"application/vnd.oci.image.config.v1+json","digest":%q},"layers":[{"mediaType":"application/vnd.oci.image.layer.v1.tar+gzip","digest":%q}]}`,
+		mediaTypeOCIManifest, sha256Digest(config), sha256Digest(layer),
+	))
+	index := []byte(fmt.Sprintf(
+		`{"schemaVersion":2,"mediaType":%q,"manifests":[{"digest":"sha256:0000","platform":{"architecture":"s390x","os":"linux"}},{"digest":%q,"platform":{"architecture":%q,"os":"linux"}}]}`,
+		mediaTypeOCIIndex, sha256Digest(manifest), runtime.GOARCH,
+	))
+
+	registry := &testRegistry{}
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			if user, pass, ok := r.BasicAuth(); !ok || user != "user" || pass != "pass" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			if r.URL.Query().Get("scope") != "repository:org/image:pull" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]string{"token": "secret"})
+			return
+		}
+
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry.test"`, server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		var body []byte
+		switch r.URL.Path {
+		case "/v2/org/image/manifests/1.0":
+			body = index
+		case "/v2/org/image/manifests/" + sha256Digest(manifest):
+			body = manifest
+		case "/v2/org/image/blobs/" + sha256Digest(config):
+			body = config
+		case "/v2/org/image/blobs/" + sha256Digest(layer):
+			registry.layerPulls.Add(1)
+			body = layer
+			if registry.corruptLayer {
+				body = newLayer(t, false, []tar.Header{{Name: "evil", Typeflag: tar.TypeReg, Mode: 0o644, Size: 5}}).Bytes()
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+
+	registry.host = strings.TrimPrefix(server.URL, "http://")
+	return registry
+}
+
+func sha256Digest(b []byte) string {
+	sum := sha256.Sum256(b)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
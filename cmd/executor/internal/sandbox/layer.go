@@ -0,0 +1,224 @@
+package sandbox
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	// whiteoutPrefix marks a file of a lower layer as deleted.
+	whiteoutPrefix = ".wh."
+	// whiteoutOpaque marks all the files of a directory in lower layers as deleted.
+	whiteoutOpaque = whiteoutPrefix + whiteoutPrefix + ".opq"
+)
+
+// applyLayer extracts an image layer, a tar archive that is optionally gzipped, on top
+// of the root filesystem at the given directory. Ownership of the files is not kept, as
+// the root filesystem is owned by the user running the executor. Device nodes are
+// skipped, as the sandbox only sees those of the host that are mounted in /dev.
+func applyLayer(root string, r io.Reader) error {
+	br := bufio.NewReader(r)
+	if magic, err := br.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gr, err := gzip.NewReader(br)
+		if err != nil {
+			return err
+		}
+		defer gr.Close()
+		r = gr
+	} else {
+		r = br
+	}
+
+	// Opaque whiteouts only hide the files of lower layers, not those added by this one.
+	added := map[string]struct{}{}
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.Wrap(err, "reading layer")
+		}
+
+		name := path.Clean("/" + hdr.Name)
+		if name == "/" {
+			continue
+		}
+		dir, base := path.Split(name)
+
+		parent, err := resolveInRoot(root, dir)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case base == whiteoutOpaque:
+			if err := removeChildren(parent, added); err != nil {
+				return err
+			}
+			continue
+		case strings.HasPrefix(base, whiteoutPrefix):
+			if err := os.RemoveAll(filepath.Join(parent, strings.TrimPrefix(base, whiteoutPrefix))); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := os.MkdirAll(parent, 0o755); err != nil {
+			return err
+		}
+		target := filepath.Join(parent, base)
+		added[target] = struct{}{}
+
+		if err := extractEntry(root, target, hdr, tr); err != nil {
+			return errors.Wrapf(err, "extracting %s", name)
+		}
+	}
+
+	return nil
+}
+
+func extractEntry(root, target string, hdr *tar.Header, r io.Reader) error {
+	mode := hdr.FileInfo().Mode()
+
+	// Replace what lower layers have at the path, unless both are directories.
+	if info, err := os.Lstat(target); err == nil {
+		if !(info.IsDir() && hdr.Typeflag == tar.TypeDir) {
+			if err := os.RemoveAll(target); err != nil {
+				return err
+			}
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	switch hdr.Typeflag {
+	case tar.TypeDir:
+		if err := os.MkdirAll(target, 0o755); err != nil {
+			return err
+		}
+		// The executor must be able to remove the root filesystem again.
+		return os.Chmod(target, mode.Perm()|0o700)
+
+	case tar.TypeReg:
+		f, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode.Perm())
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(f, r); err != nil {
+			_ = f.Close()
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+		if err := os.Chmod(target, mode&(os.ModePerm|os.ModeSetuid|os.ModeSetgid|os.ModeSticky)); err != nil {
+			return err
+		}
+		_ = os.Chtimes(target, hdr.ModTime, hdr.ModTime)
+		return nil
+
+	case tar.TypeSymlink:
+		return os.Symlink(hdr.Linkname, target)
+
+	case tar.TypeLink:
+		linkName := path.Clean("/" + hdr.Linkname)
+		dir, base := path.Split(linkName)
+		parent, err := resolveInRoot(root, dir)
+		if err != nil {
+			return err
+		}
+		return os.Link(filepath.Join(parent, base), target)
+
+	default:
+		// Device nodes and FIFOs cannot be created without privileges.
+		return nil
+	}
+}
+
+// removeChildren removes the entries of the given directory that were not added by the
+// current layer.
+func removeChildren(dir string, added map[string]struct{}) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	for _, entry := range entries {
+		p := filepath.Join(dir, entry.Name())
+		if _, ok := added[p]; ok {
+			continue
+		}
+		if err := os.RemoveAll(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// maxSymlinks is the maximum number of symlinks followed when resolving a path, like
+// the limit of the Linux kernel.
+const maxSymlinks = 40
+
+// resolveInRoot resolves the given path of the image to a path on the host, following
+// symlinks as if root was the root directory. The result never points outside root.
+// Components that don't exist yet are kept as they are.
+func resolveInRoot(root, name string) (string, error) {
+	var (
+		resolved  = "/"
+		remaining = strings.Split(path.Clean("/"+name), "/")
+		followed  = 0
+	)
+	for len(remaining) > 0 {
+		component := remaining[0]
+		remaining = remaining[1:]
+		if component == "" || component == "." {
+			continue
+		}
+		if component == ".." {
+			resolved = path.Dir(resolved)
+			continue
+		}
+
+		next := path.Join(resolved, component)
+		info, err := os.Lstat(filepath.Join(root, next))
+		if err != nil {
+			if os.IsNotExist(err) {
+				resolved = next
+				continue
+			}
+			return "", err
+		}
+		if info.Mode()&os.ModeSymlink == 0 {
+			resolved = next
+			continue
+		}
+
+		followed++
+		if followed > maxSymlinks {
+			return "", errors.Newf("too many levels of symbolic links in %s", name)
+		}
+		link, err := os.Readlink(filepath.Join(root, next))
+		if err != nil {
+			return "", err
+		}
+		if path.IsAbs(link) {
+			resolved = "/"
+		}
+		remaining = append(strings.Split(link, "/"), remaining...)
+	}
+
+	return filepath.Join(root, resolved), nil
+}
@@ -0,0 +1,115 @@
+package sandbox
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyLayer(t *testing.T) {
+	root := t.TempDir()
+
+	require.NoError(t, applyLayer(root, newLayer(t, true, []tar.Header{
+		{Name: "etc/", Typeflag: tar.TypeDir, Mode: 0o755},
+		{Name: "etc/os-release", Typeflag: tar.TypeReg, Mode: 0o644, Size: 5},
+		{Name: "etc/shadow", Typeflag: tar.TypeReg, Mode: 0o640, Size: 5},
+		{Name: "usr/bin/sh", Typeflag: tar.TypeReg, Mode: 0o755, Size: 5},
+		{Name: "usr/bin/bash", Typeflag: tar.TypeLink, Linkname: "usr/bin/sh"},
+		{Name: "bin", Typeflag: tar.TypeSymlink, Linkname: "usr/bin"},
+		{Name: "opt/app/", Typeflag: tar.TypeDir, Mode: 0o500},
+		{Name: "opt/app/old", Typeflag: tar.TypeReg, Mode: 0o644, Size: 5},
+		{Name: "dev/null", Typeflag: tar.TypeChar, Mode: 0o666},
+	})))
+
+	// The second layer is not compressed.
+	require.NoError(t, applyLayer(root, newLayer(t, false, []tar.Header{
+		{Name: "etc/.wh.shadow", Typeflag: tar.TypeReg},
+		{Name: "opt/app/new", Typeflag: tar.TypeReg, Mode: 0o644, Size: 5},
+		{Name: "opt/app/.wh..wh..opq", Typeflag: tar.TypeReg},
+		// Parents are resolved through symlinks within the root filesystem.
+		{Name: "bin/ls", Typeflag: tar.TypeReg, Mode: 0o755, Size: 5},
+		{Name: "escape", Typeflag: tar.TypeSymlink, Linkname: "../../../.."},
+		{Name: "escape/file", Typeflag: tar.TypeReg, Mode: 0o644, Size: 5},
+	})))
+
+	assert.FileExists(t, filepath.Join(root, "etc/os-release"))
+	assert.NoFileExists(t, filepath.Join(root, "etc/shadow"))
+	assert.NoFileExists(t, filepath.Join(root, "dev/null"))
+
+	info, err := os.Stat(filepath.Join(root, "usr/bin/sh"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o755), info.Mode().Perm())
+	linked, err := os.Stat(filepath.Join(root, "usr/bin/bash"))
+	require.NoError(t, err)
+	assert.True(t, os.SameFile(info, linked))
+
+	assert.FileExists(t, filepath.Join(root, "usr/bin/ls"))
+	assert.FileExists(t, filepath.Join(root, "file"))
+
+	info, err = os.Stat(filepath.Join(root, "opt/app"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o700), info.Mode().Perm())
+	assert.NoFileExists(t, filepath.Join(root, "opt/app/old"))
+	assert.FileExists(t, filepath.Join(root, "opt/app/new"))
+}
+
+func TestResolveInRoot(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "usr/lib"), os.ModePerm))
+	require.NoError(t, os.Symlink("usr/lib", filepath.Join(root, "lib")))
+	require.NoError(t, os.Symlink("/usr", filepath.Join(root, "usr/lib/absolute")))
+	require.NoError(t, os.Symlink("../../..", filepath.Join(root, "usr/lib/up")))
+	require.NoError(t, os.Symlink("loop", filepath.Join(root, "loop")))
+
+	tests := map[string]string{
+		"/":                    root,
+		"lib":                  filepath.Join(root, "usr/lib"),
+		"/lib/missing/file":    filepath.Join(root, "usr/lib/missing/file"),
+		"/lib/absolute/lib":    filepath.Join(root, "usr/lib"),
+		"/lib/up/etc":          filepath.Join(root, "etc"),
+		"/../../../etc/passwd": filepath.Join(root, "etc/passwd"),
+	}
+	for name, expected := range tests {
+		resolved, err := resolveInRoot(root, name)
+		require.NoError(t, err)
+		assert.Equal(t, expected, resolved, name)
+	}
+
+	_, err := resolveInRoot(root, "/loop/file")
+	assert.Error(t, err)
+}
+
+// newLayer returns a layer with the given entries. Regular files contain "hello".
+func newLayer(t *testing.T, compress bool, headers []tar.Header) *bytes.Buffer {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := &buf
+	var gw *gzip.Writer
+	tw := tar.NewWriter(w)
+	if compress {
+		gw = gzip.NewWriter(w)
+		tw = tar.NewWriter(gw)
+	}
+
+	for _, hdr := range headers {
+		hdr := hdr
+		require.NoError(t, tw.WriteHeader(&hdr))
+		if hdr.Typeflag == tar.TypeReg && hdr.Size > 0 {
+			_, err := tw.Write([]byte("hello"))
+			require.NoError(t, err)
+		}
+	}
+	require.NoError(t, tw.Close())
+	if gw != nil {
+		require.NoError(t, gw.Close())
+	}
+
+	return &buf
+}
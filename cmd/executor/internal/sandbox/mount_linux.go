@@ -0,0 +1,253 @@
+package sandbox
+
+import (
+	"os"
+	"path/filepath"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// devices are the device nodes of the host that are bind-mounted into the /dev of the
+// sandbox. New device nodes cannot be created in a user namespace.
+var devices = []string{"null", "zero", "full", "random", "urandom", "tty"}
+
+// setupMounts builds the mount tree of the sandbox and pivots into it. It must be
+// called in the new mount namespace of the sandbox.
+func setupMounts(opts Options) error {
+	// Don't propagate any of the following mounts back to the host.
+	if err := unix.Mount("", "/", "", unix.MS_REC|unix.MS_PRIVATE, ""); err != nil {
+		return errors.Wrap(err, "making mounts private")
+	}
+
+	root, writable, err := mountRootfs(opts)
+	if err != nil {
+		return err
+	}
+
+	// Mount points at the top level of the root filesystem can always be created, those
+	// nested in the image only if the root filesystem is writable.
+	if err := mount("proc", filepath.Join(root, "proc"), "proc", unix.MS_NOSUID|unix.MS_NODEV|unix.MS_NOEXEC, "", true); err != nil {
+		return err
+	}
+	if err := setupDev(filepath.Join(root, "dev")); err != nil {
+		return err
+	}
+	// /sys is read-only, and only needed by tools inspecting the cgroup limits.
+	if err := bindMount("/sys", filepath.Join(root, "sys"), true, true); err != nil {
+		return err
+	}
+	if !writable {
+		for _, dir := range []string{"tmp", "run"} {
+			if err := mount("tmpfs", filepath.Join(root, dir), "tmpfs", unix.MS_NOSUID|unix.MS_NODEV, "mode=1777", true); err != nil {
+				return err
+			}
+		}
+	}
+	if opts.Network {
+		// Use the DNS configuration of the host, like Docker does.
+		for _, file := range []string{"/etc/resolv.conf", "/etc/hosts"} {
+			if err := bindMount(file, filepath.Join(root, file), true, writable); err != nil {
+				return err
+			}
+		}
+	}
+	if opts.Workspace != "" {
+		if err := bindMount(opts.Workspace, filepath.Join(root, "data"), false, true); err != nil {
+			return err
+		}
+	}
+
+	// Pivot into the new root, and detach the old one stacked below it.
+	if err := os.Chdir(root); err != nil {
+		return err
+	}
+	if err := unix.PivotRoot(".", "."); err != nil {
+		return errors.Wrap(err, "pivoting root")
+	}
+	if err := unix.Unmount(".", unix.MNT_DETACH); err != nil {
+		return errors.Wrap(err, "detaching old root")
+	}
+
+	return os.Chdir("/")
+}
+
+// mountRootfs mounts the root filesystem of the sandbox in its scratch directory, and
+// returns where. The root filesystem is writable if the kernel supports overlay mounts
+// in user namespaces, which it does since Linux 5.11.
+func mountRootfs(opts Options) (string, bool, error) {
+	root := filepath.Join(opts.ScratchDir, "root")
+	upper := filepath.Join(opts.ScratchDir, "upper")
+	work := filepath.Join(opts.ScratchDir, "work")
+	for _, dir := range []string{root, upper, work} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return "", false, err
+		}
+	}
+
+	data := "lowerdir=" + opts.Rootfs + ",upperdir=" + upper + ",workdir=" + work
+	if err := unix.Mount("overlay", root, "overlay", 0, data); err == nil {
+		return root, true, nil
+	}
+
+	// Otherwise, bind-mount the top level entries of the root filesystem read-only into
+	// a tmpfs, so that mount points can still be created at the top level.
+	if err := mount("tmpfs", root, "tmpfs", 0, "mode=755", false); err != nil {
+		return "", false, err
+	}
+	entries, err := os.ReadDir(opts.Rootfs)
+	if err != nil {
+		return "", false, errors.Wrap(err, "reading root filesystem")
+	}
+	for _, entry := range entries {
+		source := filepath.Join(opts.Rootfs, entry.Name())
+		target := filepath.Join(root, entry.Name())
+
+		if entry.Type()&os.ModeSymlink != 0 {
+			link, err := os.Readlink(source)
+			if err != nil {
+				return "", false, err
+			}
+			if err := os.Symlink(link, target); err != nil {
+				return "", false, err
+			}
+			continue
+		}
+		if err := bindMount(source, target, true, true); err != nil {
+			return "", false, err
+		}
+	}
+
+	return root, false, nil
+}
+
+// setupDev mounts a minimal /dev at the given path.
+func setupDev(dev string) error {
+	if err := mount("tmpfs", dev, "tmpfs", unix.MS_NOSUID|unix.MS_NOEXEC, "mode=755", true); err != nil {
+		return err
+	}
+	for _, device := range devices {
+		if err := bindMount(filepath.Join("/dev", device), filepath.Join(dev, device), false, true); err != nil {
+			return err
+		}
+	}
+	for _, dir := range []string{"pts", "shm"} {
+		if err := os.Mkdir(filepath.Join(dev, dir), 0o755); err != nil {
+			return err
+		}
+	}
+	if err := mount("tmpfs", filepath.Join(dev, "shm"), "tmpfs", unix.MS_NOSUID|unix.MS_NODEV, "mode=1777", false); err != nil {
+		return err
+	}
+	for link, target := range map[string]string{
+		"fd":     "/proc/self/fd",
+		"stdin":  "/proc/self/fd/0",
+		"stdout": "/proc/self/fd/1",
+		"stderr": "/proc/self/fd/2",
+	} {
+		if err := os.Symlink(target, filepath.Join(dev, link)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mount mounts a filesystem at the given target, creating the target directory first if
+// create is true. Missing targets are skipped otherwise.
+func mount(source, target, fstype string, flags uintptr, data string, create bool) error {
+	if create {
+		if err := os.MkdirAll(target, 0o755); err != nil {
+			return err
+		}
+	} else if _, err := os.Stat(target); os.IsNotExist(err) {
+		return nil
+	}
+	if err := unix.Mount(source, target, fstype, flags, data); err != nil {
+		return errors.Wrapf(err, "mounting %s at %s", fstype, target)
+	}
+	return nil
+}
+
+// bindMount bind-mounts the given source at the given target, creating the target first
+// if create is true. Missing targets are skipped otherwise.
+func bindMount(source, target string, readOnly, create bool) error {
+	info, err := os.Stat(source)
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(target); os.IsNotExist(err) {
+		if !create {
+			return nil
+		}
+		if info.IsDir() {
+			err = os.MkdirAll(target, 0o755)
+		} else {
+			if err = os.MkdirAll(filepath.Dir(target), 0o755); err == nil {
+				err = os.WriteFile(target, nil, 0o644)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+
+	if err := unix.Mount(source, target, "", unix.MS_BIND|unix.MS_REC, ""); err != nil {
+		return errors.Wrapf(err, "bind-mounting %s at %s", source, target)
+	}
+	if readOnly {
+		return remountReadOnly(target)
+	}
+	return nil
+}
+
+// remountReadOnly makes the bind mount at the given path read-only. In a user namespace,
+// the flags locked by the mount of the host must be kept as they are.
+func remountReadOnly(path string) error {
+	var st unix.Statfs_t
+	if err := unix.Statfs(path, &st); err != nil {
+		return err
+	}
+
+	flags := uintptr(unix.MS_BIND | unix.MS_REMOUNT | unix.MS_RDONLY)
+	for stFlag, msFlag := range map[int64]uintptr{
+		unix.ST_NOSUID:      unix.MS_NOSUID,
+		unix.ST_NODEV:       unix.MS_NODEV,
+		unix.ST_NOEXEC:      unix.MS_NOEXEC,
+		unix.ST_NOATIME:     unix.MS_NOATIME,
+		unix.ST_NODIRATIME:  unix.MS_NODIRATIME,
+		unix.ST_RELATIME:    unix.MS_RELATIME,
+		unix.ST_SYNCHRONOUS: unix.MS_SYNCHRONOUS,
+	} {
+		if int64(st.Flags)&stFlag != 0 {
+			flags |= msFlag
+		}
+	}
+
+	if err := unix.Mount("", path, "", flags, ""); err != nil {
+		return errors.Wrapf(err, "remounting %s read-only", path)
+	}
+	return nil
+}
+
+// setLoopbackUp brings up the loopback interface of a new network namespace.
+func setLoopbackUp() error {
+	fd, err := unix.Socket(unix.AF_INET, unix.SOCK_DGRAM|unix.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return err
+	}
+	defer unix.Close(fd)
+
+	ifr, err := unix.NewIfreq("lo")
+	if err != nil {
+		return err
+	}
+	if err := unix.IoctlIfreq(fd, unix.SIOCGIFFLAGS, ifr); err != nil {
+		return err
+	}
+	ifr.SetUint16(ifr.Uint16() | unix.IFF_UP | unix.IFF_RUNNING)
+	return unix.IoctlIfreq(fd, unix.SIOCSIFFLAGS, ifr)
+}
@@ -0,0 +1,414 @@
+package sandbox
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"runtime"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/executor/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	mediaTypeOCIIndex        = "application/vnd.oci.image.index.v1+json"
+	mediaTypeOCIManifest     = "application/vnd.oci.image.manifest.v1+json"
+	mediaTypeDockerList      = "application/vnd.docker.distribution.manifest.list.v2+json"
+	mediaTypeDockerManifest  = "application/vnd.docker.distribution.manifest.v2+json"
+	dockerHubRegistry        = "docker.io"
+	dockerHubRegistryAddress = "registry-1.docker.io"
+)
+
+// maxManifestSize is the maximum size of a manifest or image config read from a registry.
+const maxManifestSize = 4 << 20
+
+// reference is a parsed image reference, like alpine:3 or ghcr.io/org/image@sha256:....
+type reference struct {
+	// registry is the host of the registry, as used in the docker CLI config.
+	registry string
+	// repository is the path of the image in the registry.
+	repository string
+	// reference is the tag or digest of the image.
+	reference string
+}
+
+// parseReference parses an image reference the way the docker CLI does.
+func parseReference(image string) (reference, error) {
+	name, ref := image, "latest"
+	if i := strings.Index(name, "@"); i >= 0 {
+		name, ref = name[:i], name[i+1:]
+		if _, _, err := parseDigest(ref); err != nil {
+			return reference{}, err
+		}
+	} else if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name, ref = name[:i], name[i+1:]
+	}
+	if name == "" || ref == "" {
+		return reference{}, errors.Newf("invalid image reference %q", image)
+	}
+
+	registry, repository := dockerHubRegistry, name
+	if i := strings.Index(name, "/"); i >= 0 {
+		if host := name[:i]; strings.ContainsAny(host, ".:") || host == "localhost" {
+			registry, repository = host, name[i+1:]
+		}
+	}
+	if registry == dockerHubRegistry && !strings.Contains(repository, "/") {
+		repository = "library/" + repository
+	}
+
+	return reference{registry: registry, repository: repository, reference: ref}, nil
+}
+
+func (r reference) String() string {
+	if strings.Contains(r.reference, ":") {
+		return r.registry + "/" + r.repository + "@" + r.reference
+	}
+	return r.registry + "/" + r.repository + ":" + r.reference
+}
+
+// baseURL is the URL of the registry API. Registries on the local host are assumed to
+// serve plain HTTP, like the docker daemon does.
+func (r reference) baseURL() string {
+	host := r.registry
+	if host == dockerHubRegistry {
+		host = dockerHubRegistryAddress
+	}
+	scheme := "https"
+	if h := strings.Split(host, ":")[0]; h == "localhost" || h == "127.0.0.1" {
+		scheme = "http"
+	}
+	return scheme + "://" + host + "/v2/" + r.repository
+}
+
+// registryClient is a minimal client of the OCI distribution API, for pulling images.
+type registryClient struct {
+	client *http.Client
+	ref    reference
+	creds  *types.DockerAuthConfigAuth
+	// authorization is the Authorization header sent with requests, once the registry
+	// asked for it.
+	authorization string
+}
+
+func newRegistryClient(client *http.Client, ref reference, authConfig types.DockerAuthConfig) *registryClient {
+	return &registryClient{
+		client: client,
+		ref:    ref,
+		creds:  findCredentials(authConfig, ref.registry),
+	}
+}
+
+// findCredentials returns the credentials of the docker CLI config for the given
+// registry, if any. Keys of the config can be hosts or URLs.
+func findCredentials(authConfig types.DockerAuthConfig, registry string) *types.DockerAuthConfigAuth {
+	normalize := func(key string) string {
+		if u, err := url.Parse(key); err == nil && u.Host != "" {
+			key = u.Host
+		}
+		key = strings.TrimSuffix(key, "/")
+		switch key {
+		case "index.docker.io", dockerHubRegistryAddress:
+			return dockerHubRegistry
+		}
+		return key
+	}
+
+	for key, auth := range authConfig.Auths {
+		if normalize(key) == registry {
+			auth := auth
+			return &auth
+		}
+	}
+	return nil
+}
+
+// manifest is an OCI image manifest, or a Docker v2 schema 2 manifest.
+type manifest struct {
+	MediaType string       `json:"mediaType"`
+	Config    descriptor   `json:"config"`
+	Layers    []descriptor `json:"layers"`
+	Manifests []descriptor `json:"manifests"`
+}
+
+type descriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+	Platform  *struct {
+		Architecture string `json:"architecture"`
+		OS           string `json:"os"`
+	} `json:"platform,omitempty"`
+}
+
+// imageConfig is the part of the OCI image config that is used by sandboxes.
+type imageConfig struct {
+	Config struct {
+		Env []string `json:"Env"`
+	} `json:"config"`
+}
+
+// resolveManifest fetches the manifest of the image for the platform of the executor,
+// and returns it with its digest.
+func (c *registryClient) resolveManifest(ctx context.Context) (manifest, string, error) {
+	m, digest, err := c.fetchManifest(ctx, c.ref.reference)
+	if err != nil {
+		return manifest{}, "", err
+	}
+
+	switch m.MediaType {
+	case mediaTypeOCIIndex, mediaTypeDockerList:
+		for _, d := range m.Manifests {
+			if d.Platform != nil && d.Platform.OS == "linux" && d.Platform.Architecture == runtime.GOARCH {
+				return c.fetchManifest(ctx, d.Digest)
+			}
+		}
+		return manifest{}, "", errors.Newf("image %s has no manifest for linux/%s", c.ref, runtime.GOARCH)
+	case mediaTypeOCIManifest, mediaTypeDockerManifest:
+		return m, digest, nil
+	default:
+		return manifest{}, "", errors.Newf("unsupported manifest type %q of image %s", m.MediaType, c.ref)
+	}
+}
+
+func (c *registryClient) fetchManifest(ctx context.Context, ref string) (manifest, string, error) {
+	resp, err := c.get(ctx, "/manifests/"+ref, strings.Join([]string{
+		mediaTypeOCIIndex, mediaTypeOCIManifest, mediaTypeDockerList, mediaTypeDockerManifest,
+	}, ", "))
+	if err != nil {
+		return manifest{}, "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxManifestSize))
+	if err != nil {
+		return manifest{}, "", err
+	}
+	sum := sha256.Sum256(body)
+	digest := "sha256:" + hex.EncodeToString(sum[:])
+	if strings.Contains(ref, ":") && ref != digest {
+		return manifest{}, "", errors.Newf("manifest of image %s does not match digest %s", c.ref, ref)
+	}
+
+	var m manifest
+	if err := json.Unmarshal(body, &m); err != nil {
+		return manifest{}, "", errors.Wrapf(err, "parsing manifest of image %s", c.ref)
+	}
+	// The media type is optional in OCI manifests.
+	if m.MediaType == "" {
+		if len(m.Manifests) > 0 {
+			m.MediaType = mediaTypeOCIIndex
+		} else {
+			m.MediaType = mediaTypeOCIManifest
+		}
+	}
+	return m, digest, nil
+}
+
+// fetchConfig fetches the config of the image with the given descriptor.
+func (c *registryClient) fetchConfig(ctx context.Context, d descriptor) (imageConfig, error) {
+	blob, err := c.fetchBlob(ctx, d.Digest)
+	if err != nil {
+		return imageConfig{}, err
+	}
+	defer blob.Close()
+
+	body, err := io.ReadAll(io.LimitReader(blob, maxManifestSize))
+	if err != nil {
+		return imageConfig{}, err
+	}
+	if err := blob.verify(); err != nil {
+		return imageConfig{}, err
+	}
+
+	var config imageConfig
+	if err := json.Unmarshal(body, &config); err != nil {
+		return imageConfig{}, errors.Wrapf(err, "parsing config of image %s", c.ref)
+	}
+	return config, nil
+}
+
+// fetchBlob fetches the blob with the given digest. The content must be verified once
+// it has been read completely.
+func (c *registryClient) fetchBlob(ctx context.Context, digest string) (*verifiedBlob, error) {
+	algorithm, expected, err := parseDigest(digest)
+	if err != nil {
+		return nil, err
+	}
+	if algorithm != "sha256" {
+		return nil, errors.Newf("unsupported digest algorithm %q", algorithm)
+	}
+
+	resp, err := c.get(ctx, "/blobs/"+digest, "")
+	if err != nil {
+		return nil, err
+	}
+	return &verifiedBlob{body: resp.Body, hash: sha256.New(), digest: digest, expected: expected}, nil
+}
+
+// get sends a GET request to the API of the repository, authenticating as the registry
+// asks for.
+func (c *registryClient) get(ctx context.Context, path, accept string) (*http.Response, error) {
+	resp, err := c.do(ctx, path, accept)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized && c.authorization == "" {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+
+		if c.authorization, err = c.authenticate(ctx, challenge); err != nil {
+			return nil, errors.Wrapf(err, "authenticating to %s", c.ref.registry)
+		}
+		if resp, err = c.do(ctx, path, accept); err != nil {
+			return nil, err
+		}
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, errors.Newf("unexpected status %d fetching %s of image %s", resp.StatusCode, strings.TrimPrefix(path, "/"), c.ref)
+	}
+	return resp, nil
+}
+
+func (c *registryClient) do(ctx context.Context, path, accept string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.ref.baseURL()+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	if c.authorization != "" {
+		req.Header.Set("Authorization", c.authorization)
+	}
+	return c.client.Do(req)
+}
+
+// authenticate answers a WWW-Authenticate challenge of the registry, and returns the
+// Authorization header to send with further requests.
+func (c *registryClient) authenticate(ctx context.Context, challenge string) (string, error) {
+	scheme, params := parseChallenge(challenge)
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if c.creds == nil {
+			return "", errors.New("registry requires credentials, but none are configured")
+		}
+		return "Basic " + basicAuth(c.creds.Auth), nil
+
+	case "bearer":
+		u, err := url.Parse(params["realm"])
+		if err != nil || u.Host == "" {
+			return "", errors.Newf("invalid token realm %q", params["realm"])
+		}
+		q := u.Query()
+		if service := params["service"]; service != "" {
+			q.Set("service", service)
+		}
+		q.Set("scope", "repository:"+c.ref.repository+":pull")
+		u.RawQuery = q.Encode()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return "", err
+		}
+		if c.creds != nil {
+			req.Header.Set("Authorization", "Basic "+basicAuth(c.creds.Auth))
+		}
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return "", errors.Newf("unexpected status %d fetching token", resp.StatusCode)
+		}
+
+		var token struct {
+			Token       string `json:"token"`
+			AccessToken string `json:"access_token"`
+		}
+		if err := json.NewDecoder(io.LimitReader(resp.Body, maxManifestSize)).Decode(&token); err != nil {
+			return "", errors.Wrap(err, "parsing token")
+		}
+		if token.Token == "" {
+			token.Token = token.AccessToken
+		}
+		return "Bearer " + token.Token, nil
+
+	default:
+		return "", errors.Newf("unsupported authentication scheme %q", scheme)
+	}
+}
+
+// parseChallenge parses a WWW-Authenticate header like
+// Bearer realm="https://auth.docker.io/token",service="registry.docker.io".
+func parseChallenge(challenge string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(challenge), " ")
+	params := map[string]string{}
+	for rest != "" {
+		var key, value string
+		key, rest, _ = strings.Cut(strings.TrimLeft(rest, ", "), "=")
+		if strings.HasPrefix(rest, `"`) {
+			value, rest, _ = strings.Cut(rest[1:], `"`)
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+		}
+		params[strings.ToLower(strings.TrimSpace(key))] = value
+	}
+	return scheme, params
+}
+
+// basicAuth encodes the user:password credentials of the docker CLI config.
+func basicAuth(auth []byte) string {
+	return base64.StdEncoding.EncodeToString(auth)
+}
+
+// parseDigest splits a digest like sha256:abc... into its algorithm and hex encoded
+// value.
+func parseDigest(digest string) (string, string, error) {
+	algorithm, value, ok := strings.Cut(digest, ":")
+	if !ok || algorithm == "" || value == "" {
+		return "", "", errors.Newf("invalid digest %q", digest)
+	}
+	if _, err := hex.DecodeString(value); err != nil {
+		return "", "", errors.Newf("invalid digest %q", digest)
+	}
+	return algorithm, value, nil
+}
+
+// verifiedBlob hashes the content of a blob while it is read.
+type verifiedBlob struct {
+	body     io.ReadCloser
+	hash     hash.Hash
+	digest   string
+	expected string
+}
+
+func (b *verifiedBlob) Read(p []byte) (int, error) {
+	n, err := b.body.Read(p)
+	b.hash.Write(p[:n])
+	return n, err
+}
+
+func (b *verifiedBlob) Close() error {
+	return b.body.Close()
+}
+
+// verify reads the rest of the blob and checks that its content matches the digest.
+func (b *verifiedBlob) verify() error {
+	if _, err := io.Copy(io.Discard, b); err != nil {
+		return err
+	}
+	if actual := hex.EncodeToString(b.hash.Sum(nil)); actual != b.expected {
+		return errors.Newf("content of blob %s does not match its digest, got sha256:%s", b.digest, actual)
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+// Package sandbox runs commands in rootless Linux namespace sandboxes, similar to
+// bubblewrap. The root filesystem of a sandbox is an OCI image unpacked on the host.
+// The command is isolated from the host with user, mount, PID, UTS, IPC and optionally
+// network namespaces, and its resources are limited with a cgroup.
+//
+// Sandboxes are set up in two stages, both running the executor binary. Run starts the
+// second stage in new namespaces and moves it into the cgroup of the sandbox. The second
+// stage, Init, then builds the mount tree of the sandbox, pivots into it, and runs the
+// command.
+package sandbox
+
+import (
+	"flag"
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// InitCommand is the first argument the executor binary is re-executed with to run the
+// second stage of a sandbox. The main function must call Init when it sees it.
+const InitCommand = "sandbox-init"
+
+// ErrUnsupported is returned when sandboxes are not supported on the host.
+var ErrUnsupported = errors.New("sandboxes are only supported on Linux hosts")
+
+// Options configure a sandbox and the command run in it.
+type Options struct {
+	// Rootfs is the directory on the host containing the root filesystem of the sandbox.
+	// It is never modified.
+	Rootfs string
+	// ScratchDir is an empty directory on the host that the mount tree of the sandbox and
+	// the writes to the root filesystem are kept in. If the kernel does not support overlay
+	// mounts in user namespaces, the root filesystem is read-only.
+	ScratchDir string
+	// Workspace is the directory on the host that is mounted at /data.
+	Workspace string
+	// Dir is the working directory of the command, inside the sandbox.
+	Dir string
+	// Env are the environment variables of the command, in the form KEY=value.
+	Env []string
+	// Network shares the network namespace of the host with the sandbox. If false, the
+	// sandbox only has a loopback interface.
+	Network bool
+	// CgroupRoot is a cgroup v2 directory in which a cgroup is created for the sandbox.
+	// Resource limits are not enforced if unset.
+	CgroupRoot string
+	// NumCPUs is the number of CPUs the sandbox can use. Zero means no limit.
+	NumCPUs int
+	// Memory is the maximum amount of memory the sandbox can use, for example 12G. Empty
+	// or zero means no limit.
+	Memory string
+	// Command is the command to run and its arguments.
+	Command []string
+}
+
+// Args formats the options as command line arguments that ParseArgs understands.
+func (o Options) Args() []string {
+	args := []string{
+		"--rootfs", o.Rootfs,
+		"--scratch-dir", o.ScratchDir,
+		"--workspace", o.Workspace,
+		"--dir", o.Dir,
+	}
+	for _, env := range o.Env {
+		args = append(args, "--env", env)
+	}
+	if o.Network {
+		args = append(args, "--network")
+	}
+	if o.CgroupRoot != "" {
+		args = append(args, "--cgroup-root", o.CgroupRoot)
+	}
+	if o.NumCPUs != 0 {
+		args = append(args, "--cpus", strconv.Itoa(o.NumCPUs))
+	}
+	if o.Memory != "" && o.Memory != "0" {
+		args = append(args, "--memory", o.Memory)
+	}
+	return append(append(args, "--"), o.Command...)
+}
+
+// ParseArgs parses the command line arguments produced by Options.Args.
+func ParseArgs(args []string) (Options, error) {
+	var opts Options
+
+	fs := flag.NewFlagSet("sandbox", flag.ContinueOnError)
+	fs.StringVar(&opts.Rootfs, "rootfs", "", "")
+	fs.StringVar(&opts.ScratchDir, "scratch-dir", "", "")
+	fs.StringVar(&opts.Workspace, "workspace", "", "")
+	fs.StringVar(&opts.Dir, "dir", "/", "")
+	fs.Var((*stringsValue)(&opts.Env), "env", "")
+	fs.BoolVar(&opts.Network, "network", false, "")
+	fs.StringVar(&opts.CgroupRoot, "cgroup-root", "", "")
+	fs.IntVar(&opts.NumCPUs, "cpus", 0, "")
+	fs.StringVar(&opts.Memory, "memory", "", "")
+	if err := fs.Parse(args); err != nil {
+		return Options{}, err
+	}
+	opts.Command = fs.Args()
+
+	if opts.Rootfs == "" {
+		return Options{}, errors.New("no root filesystem given")
+	}
+	if opts.ScratchDir == "" {
+		return Options{}, errors.New("no scratch directory given")
+	}
+	if len(opts.Command) == 0 {
+		return Options{}, errors.New("no command given")
+	}
+
+	return opts, nil
+}
+
+// stringsValue is a flag that can be given multiple times. Unlike a comma-separated
+// flag, it keeps values containing commas intact.
+type stringsValue []string
+
+func (v *stringsValue) String() string {
+	return strings.Join(*v, " ")
+}
+
+func (v *stringsValue) Set(s string) error {
+	*v = append(*v, s)
+	return nil
+}
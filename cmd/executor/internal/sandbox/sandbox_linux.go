@@ -0,0 +1,224 @@
+package sandbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// initFailedExitCode is the exit code of the second stage when the sandbox could not be
+// set up, like the exit code of `docker run` when the container fails to start.
+const initFailedExitCode = 125
+
+// Validate checks that the host supports sandboxes, and that the given cgroup root, if
+// any, can be used to limit the resources of sandboxes.
+func Validate(cgroupRoot string) error {
+	if os.Getuid() != 0 {
+		// Some distributions disable user namespaces for unprivileged users by default.
+		if b, err := os.ReadFile("/proc/sys/kernel/unprivileged_userns_clone"); err == nil && strings.TrimSpace(string(b)) == "0" {
+			return errors.New("unprivileged user namespaces are disabled, set the kernel.unprivileged_userns_clone sysctl to 1")
+		}
+	}
+	if b, err := os.ReadFile("/proc/sys/user/max_user_namespaces"); err == nil && strings.TrimSpace(string(b)) == "0" {
+		return errors.New("user namespaces are disabled, set the user.max_user_namespaces sysctl to a positive value")
+	}
+
+	if cgroupRoot != "" {
+		if err := validateCgroupRoot(cgroupRoot); err != nil {
+			return errors.Wrap(err, "invalid cgroup root")
+		}
+	}
+
+	return nil
+}
+
+// Run runs the command of the given options in a new sandbox and returns its exit code.
+// The standard output and error streams of the command are those of the calling process.
+func Run(ctx context.Context, opts Options) (exitCode int, err error) {
+	// The options are sent to the second stage over a pipe once it has been moved into
+	// the cgroup of the sandbox, which also prevents it from running anything before.
+	r, w, err := os.Pipe()
+	if err != nil {
+		return 0, err
+	}
+	defer r.Close()
+	defer w.Close()
+
+	cloneflags := syscall.CLONE_NEWUSER | syscall.CLONE_NEWNS | syscall.CLONE_NEWPID | syscall.CLONE_NEWUTS | syscall.CLONE_NEWIPC
+	if !opts.Network {
+		cloneflags |= syscall.CLONE_NEWNET
+	}
+
+	cmd := exec.CommandContext(ctx, "/proc/self/exe", InitCommand)
+	// The environment of the command is passed in the options, nothing of the host
+	// environment is inherited.
+	cmd.Env = []string{}
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.ExtraFiles = []*os.File{r}
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Cloneflags: uintptr(cloneflags),
+		// Root in the sandbox is the user running the executor on the host.
+		UidMappings:                []syscall.SysProcIDMap{{ContainerID: 0, HostID: os.Getuid(), Size: 1}},
+		GidMappings:                []syscall.SysProcIDMap{{ContainerID: 0, HostID: os.Getgid(), Size: 1}},
+		GidMappingsEnableSetgroups: false,
+		Pdeathsig:                  syscall.SIGKILL,
+	}
+
+	var cg *cgroup
+	if opts.CgroupRoot != "" {
+		if cg, err = newCgroup(opts.CgroupRoot, opts.NumCPUs, opts.Memory); err != nil {
+			return 0, errors.Wrap(err, "creating cgroup")
+		}
+		defer func() {
+			err = errors.Append(err, cg.remove())
+		}()
+	}
+
+	if err := cmd.Start(); err != nil {
+		return 0, errors.Wrap(err, "starting sandbox")
+	}
+
+	if err := start(cmd, cg, w, opts); err != nil {
+		// The second stage exits once the pipe is closed without options.
+		_ = w.Close()
+		_ = cmd.Wait()
+		return 0, err
+	}
+
+	if err := cmd.Wait(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return exitErr.ExitCode(), ctxErr
+			}
+			return exitErr.ExitCode(), nil
+		}
+		return 0, err
+	}
+
+	return 0, nil
+}
+
+// start moves the second stage into the cgroup of the sandbox and sends it the options.
+func start(cmd *exec.Cmd, cg *cgroup, w *os.File, opts Options) error {
+	if cg != nil {
+		if err := cg.add(cmd.Process.Pid); err != nil {
+			return errors.Wrap(err, "moving sandbox into cgroup")
+		}
+	}
+
+	if err := json.NewEncoder(w).Encode(opts); err != nil {
+		return errors.Wrap(err, "sending sandbox options")
+	}
+	return w.Close()
+}
+
+// Init runs the second stage of a sandbox. It never returns.
+func Init() {
+	exitCode, err := initSandbox()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "sandbox: %s\n", err)
+		os.Exit(initFailedExitCode)
+	}
+	os.Exit(exitCode)
+}
+
+func initSandbox() (int, error) {
+	var opts Options
+	if err := json.NewDecoder(os.NewFile(3, "options")).Decode(&opts); err != nil {
+		return 0, errors.Wrap(err, "reading sandbox options")
+	}
+
+	if err := setupMounts(opts); err != nil {
+		return 0, err
+	}
+	if err := syscall.Sethostname([]byte("sandbox")); err != nil {
+		return 0, errors.Wrap(err, "setting hostname")
+	}
+	if !opts.Network {
+		if err := setLoopbackUp(); err != nil {
+			return 0, errors.Wrap(err, "setting up loopback interface")
+		}
+	}
+	// The sandbox is set up, it must not change its mounts and namespaces from here on.
+	if err := restrictSyscalls(); err != nil {
+		return 0, errors.Wrap(err, "restricting system calls")
+	}
+
+	return runCommand(opts)
+}
+
+// runCommand runs the command of the sandbox as a child of the init process of the
+// sandbox, so that signals sent to the sandbox are forwarded to it.
+func runCommand(opts Options) (int, error) {
+	// Commands are looked up in the PATH of the sandbox, which defaults to the one of
+	// Docker containers.
+	env := opts.Env
+	path := ""
+	for _, e := range env {
+		if strings.HasPrefix(e, "PATH=") {
+			path = strings.TrimPrefix(e, "PATH=")
+		}
+	}
+	if path == "" {
+		path = defaultPath
+		env = append([]string{"PATH=" + path}, env...)
+	}
+	if err := os.Setenv("PATH", path); err != nil {
+		return 0, err
+	}
+
+	cmd := exec.Command(opts.Command[0], opts.Command[1:]...)
+	cmd.Env = env
+	cmd.Dir = opts.Dir
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
+		// Like shells and Docker, report commands that cannot be run with 126 and 127.
+		fmt.Fprintf(os.Stderr, "sandbox: %s\n", err)
+		if errors.Is(err, fs.ErrNotExist) {
+			return 127, nil
+		}
+		return 126, nil
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT)
+	go func() {
+		for sig := range signals {
+			_ = cmd.Process.Signal(sig)
+		}
+	}()
+
+	err := cmd.Wait()
+	signal.Stop(signals)
+	close(signals)
+
+	if err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return 0, err
+		}
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+			// Like shells, report commands killed by a signal with 128+n.
+			return 128 + int(status.Signal()), nil
+		}
+		return exitErr.ExitCode(), nil
+	}
+
+	return 0, nil
+}
+
+// defaultPath is the PATH of commands in the sandbox if none is configured, which is
+// the default of Docker.
+const defaultPath = "/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin"
@@ -0,0 +1,28 @@
+//go:build !linux
+// +build !linux
+
+package sandbox
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+// Validate checks that the host supports sandboxes. Sandboxes are only supported on
+// Linux.
+func Validate(cgroupRoot string) error {
+	return ErrUnsupported
+}
+
+// Run runs the command of the given options in a new sandbox. Sandboxes are only
+// supported on Linux.
+func Run(ctx context.Context, opts Options) (int, error) {
+	return 0, ErrUnsupported
+}
+
+// Init runs the second stage of a sandbox. Sandboxes are only supported on Linux.
+func Init() {
+	fmt.Fprintf(os.Stderr, "sandbox: %s\n", ErrUnsupported)
+	os.Exit(125)
+}
@@ -0,0 +1,89 @@
+package sandbox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptions_Args(t *testing.T) {
+	opts := Options{
+		Rootfs:     "/images/sha256-abc/rootfs",
+		ScratchDir: "/tmp/scratch",
+		Workspace:  "/tmp/workspace",
+		Dir:        "/data/repository",
+		Env:        []string{"FOO=bar,baz", "EMPTY="},
+		Network:    true,
+		CgroupRoot: "/sys/fs/cgroup/executor",
+		NumCPUs:    4,
+		Memory:     "12G",
+		Command:    []string{"/bin/sh", "-c", "echo --not-a-flag"},
+	}
+
+	assert.Equal(t, []string{
+		"--rootfs", "/images/sha256-abc/rootfs",
+		"--scratch-dir", "/tmp/scratch",
+		"--workspace", "/tmp/workspace",
+		"--dir", "/data/repository",
+		"--env", "FOO=bar,baz",
+		"--env", "EMPTY=",
+		"--network",
+		"--cgroup-root", "/sys/fs/cgroup/executor",
+		"--cpus", "4",
+		"--memory", "12G",
+		"--",
+		"/bin/sh", "-c", "echo --not-a-flag",
+	}, opts.Args())
+
+	parsed, err := ParseArgs(opts.Args())
+	require.NoError(t, err)
+	assert.Equal(t, opts, parsed)
+}
+
+func TestParseArgs(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		expected      Options
+		expectedError string
+	}{
+		{
+			name: "Defaults",
+			args: []string{"--rootfs", "/rootfs", "--scratch-dir", "/scratch", "--", "true"},
+			expected: Options{
+				Rootfs:     "/rootfs",
+				ScratchDir: "/scratch",
+				Dir:        "/",
+				Command:    []string{"true"},
+			},
+		},
+		{
+			name:          "No root filesystem",
+			args:          []string{"--scratch-dir", "/scratch", "--", "true"},
+			expectedError: "no root filesystem given",
+		},
+		{
+			name:          "No scratch directory",
+			args:          []string{"--rootfs", "/rootfs", "--", "true"},
+			expectedError: "no scratch directory given",
+		},
+		{
+			name:          "No command",
+			args:          []string{"--rootfs", "/rootfs", "--scratch-dir", "/scratch", "--"},
+			expectedError: "no command given",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			opts, err := ParseArgs(test.args)
+			if test.expectedError != "" {
+				require.Error(t, err)
+				assert.EqualError(t, err, test.expectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.expected, opts)
+			}
+		})
+	}
+}
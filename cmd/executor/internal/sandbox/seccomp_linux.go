@@ -0,0 +1,155 @@
+package sandbox
+
+import (
+	"runtime"
+	"unsafe"
+
+	"golang.org/x/net/bpf"
+	"golang.org/x/sys/unix"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Constants of seccomp(2) that are missing from golang.org/x/sys/unix.
+const (
+	seccompSetModeFilter   = 1
+	seccompFilterFlagTsync = 1
+
+	seccompRetKillProcess = 0x80000000
+	seccompRetErrno       = 0x00050000
+	seccompRetAllow       = 0x7fff0000
+)
+
+// Offsets of the fields of struct seccomp_data that filters inspect. The lower 32 bits
+// of the first argument come first on little-endian architectures.
+const (
+	seccompDataNR   = 0
+	seccompDataArch = 4
+	seccompDataArg0 = 16
+)
+
+// namespaceCloneFlags are the flags of clone(2) that create new namespaces.
+const namespaceCloneFlags = unix.CLONE_NEWNS | unix.CLONE_NEWCGROUP | unix.CLONE_NEWUTS | unix.CLONE_NEWIPC |
+	unix.CLONE_NEWUSER | unix.CLONE_NEWPID | unix.CLONE_NEWNET
+
+// deniedSyscalls are the system calls that fail with EPERM in sandboxes on all
+// architectures, like in the default seccomp profile of Docker. They administer the host,
+// load code into the kernel, or change the namespaces and mounts of the sandbox.
+var deniedSyscalls = []uint32{
+	unix.SYS_ACCT,
+	unix.SYS_ADD_KEY,
+	unix.SYS_BPF,
+	unix.SYS_CLOCK_ADJTIME,
+	unix.SYS_CLOCK_SETTIME,
+	unix.SYS_DELETE_MODULE,
+	unix.SYS_FINIT_MODULE,
+	unix.SYS_FSCONFIG,
+	unix.SYS_FSMOUNT,
+	unix.SYS_FSOPEN,
+	unix.SYS_FSPICK,
+	unix.SYS_INIT_MODULE,
+	unix.SYS_KEXEC_LOAD,
+	unix.SYS_KEYCTL,
+	unix.SYS_LOOKUP_DCOOKIE,
+	unix.SYS_MOUNT,
+	unix.SYS_MOUNT_SETATTR,
+	unix.SYS_MOVE_MOUNT,
+	unix.SYS_NFSSERVCTL,
+	unix.SYS_OPEN_BY_HANDLE_AT,
+	unix.SYS_OPEN_TREE,
+	unix.SYS_PERF_EVENT_OPEN,
+	unix.SYS_PIVOT_ROOT,
+	unix.SYS_QUOTACTL,
+	unix.SYS_REBOOT,
+	unix.SYS_REQUEST_KEY,
+	unix.SYS_SETNS,
+	unix.SYS_SETTIMEOFDAY,
+	unix.SYS_SWAPOFF,
+	unix.SYS_SWAPON,
+	unix.SYS_SYSLOG,
+	unix.SYS_UMOUNT2,
+	unix.SYS_UNSHARE,
+	unix.SYS_USERFAULTFD,
+}
+
+// seccompPolicy is the seccomp filter of sandboxes for an architecture.
+type seccompPolicy struct {
+	// arch is the AUDIT_ARCH_* value of the architecture. System calls of other
+	// architectures kill the sandbox.
+	arch uint32
+	// maxNR, if non-zero, is the first system call number that is denied, which rules
+	// out alternative ABIs like x32.
+	maxNR uint32
+	// denied are the system calls that fail with EPERM.
+	denied []uint32
+	// clone and clone3 are the numbers of the clone system calls. clone fails with EPERM
+	// if it is asked to create namespaces. The flags of clone3 are behind a pointer that
+	// filters cannot inspect, so it fails with ENOSYS, which makes libc fall back to
+	// clone.
+	clone, clone3 uint32
+}
+
+// program returns the classic BPF program of the policy.
+func (p *seccompPolicy) program() []bpf.Instruction {
+	deny := bpf.RetConstant{Val: seccompRetErrno | uint32(unix.EPERM)}
+
+	prog := []bpf.Instruction{
+		bpf.LoadAbsolute{Off: seccompDataArch, Size: 4},
+		bpf.JumpIf{Cond: bpf.JumpEqual, Val: p.arch, SkipTrue: 1},
+		bpf.RetConstant{Val: seccompRetKillProcess},
+		bpf.LoadAbsolute{Off: seccompDataNR, Size: 4},
+	}
+	if p.maxNR != 0 {
+		prog = append(prog, bpf.JumpIf{Cond: bpf.JumpGreaterOrEqual, Val: p.maxNR, SkipFalse: 1}, deny)
+	}
+	for _, nr := range p.denied {
+		prog = append(prog, bpf.JumpIf{Cond: bpf.JumpNotEqual, Val: nr, SkipTrue: 1}, deny)
+	}
+	return append(prog,
+		bpf.JumpIf{Cond: bpf.JumpNotEqual, Val: p.clone3, SkipTrue: 1},
+		bpf.RetConstant{Val: seccompRetErrno | uint32(unix.ENOSYS)},
+		bpf.JumpIf{Cond: bpf.JumpNotEqual, Val: p.clone, SkipTrue: 3},
+		bpf.LoadAbsolute{Off: seccompDataArg0, Size: 4},
+		bpf.JumpIf{Cond: bpf.JumpBitsSet, Val: namespaceCloneFlags, SkipFalse: 1},
+		deny,
+		bpf.RetConstant{Val: seccompRetAllow},
+	)
+}
+
+// restrictSyscalls prevents the sandbox from gaining privileges through executables and
+// installs the seccomp filter of the architecture, if any, on all threads of the process.
+// Children of the process inherit both.
+func restrictSyscalls() error {
+	// no_new_privs is a property of the thread, and must be set on the thread that
+	// installs the filter.
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	if err := unix.Prctl(unix.PR_SET_NO_NEW_PRIVS, 1, 0, 0, 0); err != nil {
+		return errors.Wrap(err, "setting no_new_privs")
+	}
+	if syscallPolicy == nil {
+		return nil
+	}
+
+	raw, err := bpf.Assemble(syscallPolicy.program())
+	if err != nil {
+		return errors.Wrap(err, "assembling seccomp filter")
+	}
+	filter := make([]unix.SockFilter, len(raw))
+	for i, ins := range raw {
+		filter[i] = unix.SockFilter{Code: ins.Op, Jt: ins.Jt, Jf: ins.Jf, K: ins.K}
+	}
+	fprog := unix.SockFprog{Len: uint16(len(filter)), Filter: &filter[0]}
+
+	// With TSYNC, a positive return value is the ID of a thread the filter could not be
+	// installed on.
+	r1, _, errno := unix.Syscall(unix.SYS_SECCOMP, seccompSetModeFilter, seccompFilterFlagTsync, uintptr(unsafe.Pointer(&fprog)))
+	if errno != 0 {
+		return errors.Wrap(errno, "installing seccomp filter")
+	}
+	if r1 != 0 {
+		return errors.Newf("installing seccomp filter: failed to synchronize thread %d", r1)
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package sandbox
+
+import "golang.org/x/sys/unix"
+
+var syscallPolicy = &seccompPolicy{
+	arch: unix.AUDIT_ARCH_X86_64,
+	// System calls of the x32 ABI have bit 30 set.
+	maxNR: 0x40000000,
+	denied: append([]uint32{
+		unix.SYS_CREATE_MODULE,
+		unix.SYS_GET_KERNEL_SYMS,
+		unix.SYS_IOPERM,
+		unix.SYS_IOPL,
+		unix.SYS_KEXEC_FILE_LOAD,
+		unix.SYS_QUERY_MODULE,
+		unix.SYS_SYSFS,
+		unix.SYS_USELIB,
+		unix.SYS_USTAT,
+		unix.SYS__SYSCTL,
+	}, deniedSyscalls...),
+	clone:  unix.SYS_CLONE,
+	clone3: unix.SYS_CLONE3,
+}
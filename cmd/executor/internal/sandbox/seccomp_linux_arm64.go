@@ -0,0 +1,12 @@
+package sandbox
+
+import "golang.org/x/sys/unix"
+
+var syscallPolicy = &seccompPolicy{
+	arch: unix.AUDIT_ARCH_AARCH64,
+	denied: append([]uint32{
+		unix.SYS_KEXEC_FILE_LOAD,
+	}, deniedSyscalls...),
+	clone:  unix.SYS_CLONE,
+	clone3: unix.SYS_CLONE3,
+}
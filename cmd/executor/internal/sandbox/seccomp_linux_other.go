@@ -0,0 +1,7 @@
+//go:build linux && !amd64 && !arm64
+
+package sandbox
+
+// syscallPolicy is nil on architectures without a seccomp filter, where sandboxes only
+// set no_new_privs.
+var syscallPolicy *seccompPolicy
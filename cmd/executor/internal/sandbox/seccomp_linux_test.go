@@ -0,0 +1,56 @@
+package sandbox
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/bpf"
+	"golang.org/x/sys/unix"
+)
+
+func TestSeccompPolicy(t *testing.T) {
+	if syscallPolicy == nil {
+		t.Skip("no seccomp filter on this architecture")
+	}
+
+	vm, err := bpf.NewVM(syscallPolicy.program())
+	require.NoError(t, err)
+
+	// run returns the action of the filter for the given system call. The VM loads
+	// words in network byte order, while the kernel loads them in the byte order of the
+	// host.
+	run := func(arch, nr, arg0 uint32) uint32 {
+		data := make([]byte, 64)
+		binary.BigEndian.PutUint32(data[seccompDataNR:], nr)
+		binary.BigEndian.PutUint32(data[seccompDataArch:], arch)
+		binary.BigEndian.PutUint32(data[seccompDataArg0:], arg0)
+		action, err := vm.Run(data)
+		require.NoError(t, err)
+		return uint32(action)
+	}
+
+	const (
+		allow = seccompRetAllow
+		eperm = seccompRetErrno | uint32(unix.EPERM)
+	)
+	arch := syscallPolicy.arch
+
+	assert.Equal(t, uint32(allow), run(arch, unix.SYS_READ, 0))
+	assert.Equal(t, uint32(allow), run(arch, unix.SYS_EXECVE, 0))
+	assert.Equal(t, eperm, run(arch, unix.SYS_MOUNT, 0))
+	assert.Equal(t, eperm, run(arch, unix.SYS_UNSHARE, unix.CLONE_NEWUSER))
+	assert.Equal(t, eperm, run(arch, unix.SYS_BPF, 0))
+
+	// Threads and processes can be created, but not namespaces.
+	assert.Equal(t, uint32(allow), run(arch, unix.SYS_CLONE, unix.CLONE_VM|unix.CLONE_THREAD|unix.CLONE_SIGHAND))
+	assert.Equal(t, eperm, run(arch, unix.SYS_CLONE, unix.CLONE_NEWUSER|unix.CLONE_NEWNS))
+	assert.Equal(t, seccompRetErrno|uint32(unix.ENOSYS), run(arch, unix.SYS_CLONE3, 0))
+
+	// System calls of other architectures kill the sandbox.
+	assert.Equal(t, uint32(seccompRetKillProcess), run(arch^1, unix.SYS_READ, 0))
+	if syscallPolicy.maxNR != 0 {
+		assert.Equal(t, eperm, run(arch, syscallPolicy.maxNR|unix.SYS_READ, 0))
+	}
+}
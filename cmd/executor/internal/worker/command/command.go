@@ -30,6 +30,12 @@ func init() {
 	if util.HasShellBuildTag() {
 		allowedBinaries = append(allowedBinaries, "/bin/sh")
 	}
+
+	// The sandbox runtime runs the executor binary itself to set up sandboxes.
+	if executable, err := os.Executable(); err == nil {
+		executorBinary = executable
+		allowedBinaries = append(allowedBinaries, executable)
+	}
 }
 
 type Command interface {
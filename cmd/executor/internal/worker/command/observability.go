@@ -18,6 +18,10 @@ type Operations struct {
 	SetupGitSparseCheckoutSet    *observation.Operation
 	SetupGitCheckout             *observation.Operation
 	SetupGitSetRemoteUrl         *observation.Operation
+	SetupGitCacheInit            *observation.Operation
+	SetupGitCacheDisableGC       *observation.Operation
+	SetupGitCacheFetch           *observation.Operation
+	SetupGitDissociate           *observation.Operation
 	SetupStartupScript           *observation.Operation
 
 	SetupFirecrackerStart     *observation.Operation
@@ -75,6 +79,10 @@ func NewOperations(observationCtx *observation.Context) *Operations {
 		SetupGitSparseCheckoutSet:    op("setup.git.sparse-checkout-set"),
 		SetupGitCheckout:             op("setup.git.checkout"),
 		SetupGitSetRemoteUrl:         op("setup.git.set-remote"),
+		SetupGitCacheInit:            op("setup.git.cache-init"),
+		SetupGitCacheDisableGC:       op("setup.git.cache-disable-gc"),
+		SetupGitCacheFetch:           op("setup.git.cache-fetch"),
+		SetupGitDissociate:           op("setup.git.dissociate"),
 		SetupStartupScript:           op("setup.startup-script"),
 
 		SetupFirecrackerStart:     op("setup.firecracker.start"),
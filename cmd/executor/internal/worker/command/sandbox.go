@@ -0,0 +1,47 @@
+package command
+
+import (
+	"path/filepath"
+
+	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/sandbox"
+	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/worker/files"
+)
+
+// SandboxOptions are the options that are specific to running a sandbox.
+type SandboxOptions struct {
+	// CgroupRoot is the cgroup v2 directory that sandboxes are created in to enforce the
+	// resource limits. Resource limits are not enforced if unset.
+	CgroupRoot string
+	// Network shares the network of the host with sandboxes.
+	Network   bool
+	Resources ResourceOptions
+}
+
+// executorBinary is the path of the running executor binary, which sets up sandboxes
+// when invoked with the sandbox command.
+var executorBinary string
+
+// NewSandboxSpec constructs the command to run on the host in order to invoke the given
+// spec in a sandbox with the root filesystem of the given image. Writes to the root
+// filesystem are kept in the given scratch directory.
+func NewSandboxSpec(workingDir string, image sandbox.Image, scratchDir string, scriptPath string, spec Spec, options SandboxOptions) Spec {
+	opts := sandbox.Options{
+		Rootfs:     image.Rootfs,
+		ScratchDir: scratchDir,
+		Workspace:  workingDir,
+		Dir:        filepath.Join("/data", spec.Dir),
+		// Like Docker, variables of the step override those of the image.
+		Env:        append(append([]string{"HOME=/root"}, image.Env...), spec.Env...),
+		Network:    options.Network,
+		CgroupRoot: options.CgroupRoot,
+		NumCPUs:    options.Resources.NumCPUs,
+		Memory:     options.Resources.Memory,
+		Command:    []string{"/bin/sh", filepath.Join("/data", files.ScriptsPath, scriptPath)},
+	}
+
+	return Spec{
+		Key:       spec.Key,
+		Command:   Flatten(executorBinary, "sandbox", opts.Args()),
+		Operation: spec.Operation,
+	}
+}
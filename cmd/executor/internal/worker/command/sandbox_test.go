@@ -0,0 +1,97 @@
+package command_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/sandbox"
+	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/worker/command"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestNewSandboxSpec(t *testing.T) {
+	executable, err := os.Executable()
+	require.NoError(t, err)
+
+	image := sandbox.Image{
+		Rootfs: "/images/sha256-abc/rootfs",
+		Env:    []string{"PATH=/usr/local/bin:/usr/bin:/bin", "FOO=image"},
+	}
+
+	tests := []struct {
+		name         string
+		spec         command.Spec
+		options      command.SandboxOptions
+		expectedSpec command.Spec
+	}{
+		{
+			name: "Default",
+			spec: command.Spec{
+				Key:     "some-key",
+				Command: []string{"some", "command"},
+				Dir:     "some/dir",
+				Env:     []string{"FOO=BAR"},
+			},
+			expectedSpec: command.Spec{
+				Key: "some-key",
+				Command: []string{
+					executable, "sandbox",
+					"--rootfs", "/images/sha256-abc/rootfs",
+					"--scratch-dir", "/tmp/scratch",
+					"--workspace", "/workingDirectory",
+					"--dir", "/data/some/dir",
+					"--env", "HOME=/root",
+					"--env", "PATH=/usr/local/bin:/usr/bin:/bin",
+					"--env", "FOO=image",
+					"--env", "FOO=BAR",
+					"--",
+					"/bin/sh", "/data/.sourcegraph-executor/some/path",
+				},
+				Operation: (*observation.Operation)(nil),
+			},
+		},
+		{
+			name: "Network and resources",
+			spec: command.Spec{
+				Key: "some-key",
+			},
+			options: command.SandboxOptions{
+				CgroupRoot: "/sys/fs/cgroup/executor",
+				Network:    true,
+				Resources: command.ResourceOptions{
+					NumCPUs: 4,
+					Memory:  "12G",
+				},
+			},
+			expectedSpec: command.Spec{
+				Key: "some-key",
+				Command: []string{
+					executable, "sandbox",
+					"--rootfs", "/images/sha256-abc/rootfs",
+					"--scratch-dir", "/tmp/scratch",
+					"--workspace", "/workingDirectory",
+					"--dir", "/data",
+					"--env", "HOME=/root",
+					"--env", "PATH=/usr/local/bin:/usr/bin:/bin",
+					"--env", "FOO=image",
+					"--network",
+					"--cgroup-root", "/sys/fs/cgroup/executor",
+					"--cpus", "4",
+					"--memory", "12G",
+					"--",
+					"/bin/sh", "/data/.sourcegraph-executor/some/path",
+				},
+				Operation: (*observation.Operation)(nil),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := command.NewSandboxSpec("/workingDirectory", image, "/tmp/scratch", "some/path", test.spec, test.options)
+			assert.Equal(t, test.expectedSpec, actual)
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package files
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gobwas/glob"
+
+	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/worker/cmdlogger"
+	"github.com/sourcegraph/sourcegraph/internal/executor/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ArtifactStore handles uploading the artifacts of jobs.
+type ArtifactStore interface {
+	// UploadArtifact uploads the content of the artifact with the given name.
+	UploadArtifact(ctx context.Context, job types.Job, name string, content io.Reader) error
+}
+
+// UploadArtifacts uploads the regular files below the given directory that match the
+// artifact patterns of the job. The content of each file is redacted with the given
+// replacements before it is uploaded. Files that are too large, or beyond the maximum
+// number of artifacts per job, are skipped. Symbolic links are never followed, so that
+// a job cannot upload files from outside its workspace.
+func UploadArtifacts(
+	ctx context.Context,
+	store ArtifactStore,
+	job types.Job,
+	dir string,
+	replacements map[string]string,
+	logger cmdlogger.Logger,
+) (err error) {
+	handle := logger.LogEntry("teardown.artifacts", nil)
+	defer func() {
+		if err != nil {
+			fmt.Fprint(handle, err.Error())
+			handle.Finalize(1)
+		} else {
+			handle.Finalize(0)
+		}
+		handle.Close()
+	}()
+
+	names, err := matchArtifacts(dir, job.Artifacts)
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		fmt.Fprintf(handle, "No files match the artifact patterns %s\n", strings.Join(job.Artifacts, ", "))
+		return nil
+	}
+	if len(names) > types.MaxArtifactsPerJob {
+		fmt.Fprintf(handle, "Skipping %d files beyond the limit of %d artifacts per job\n", len(names)-types.MaxArtifactsPerJob, types.MaxArtifactsPerJob)
+		names = names[:types.MaxArtifactsPerJob]
+	}
+
+	oldnew := make([]string, 0, len(replacements)*2)
+	for k, v := range replacements {
+		oldnew = append(oldnew, k, v)
+	}
+	replacer := strings.NewReplacer(oldnew...)
+
+	var errs error
+	for _, name := range names {
+		content, err := readArtifact(filepath.Join(dir, filepath.FromSlash(name)))
+		if err != nil {
+			if errors.Is(err, errArtifactTooLarge) {
+				fmt.Fprintf(handle, "Skipping %s: larger than %d bytes\n", name, types.MaxArtifactSize)
+				continue
+			}
+			errs = errors.Append(errs, errors.Wrapf(err, "reading artifact %q", name))
+			continue
+		}
+
+		// 🚨 SECURITY: Artifacts are redacted like the command output, as they may contain
+		// the same sensitive values.
+		content = []byte(replacer.Replace(string(content)))
+
+		if err := store.UploadArtifact(ctx, job, name, bytes.NewReader(content)); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "uploading artifact %q", name))
+			continue
+		}
+		fmt.Fprintf(handle, "Uploaded %s (%d bytes)\n", name, len(content))
+	}
+
+	return errs
+}
+
+var errArtifactTooLarge = errors.New("artifact too large")
+
+// matchArtifacts returns the slash-separated paths of the regular files below the given
+// directory that match one of the given patterns, in lexical order.
+func matchArtifacts(dir string, patterns []string) ([]string, error) {
+	globs := make([]glob.Glob, 0, len(patterns))
+	for _, pattern := range patterns {
+		g, err := glob.Compile(pattern, '/')
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid artifact pattern %q", pattern)
+		}
+		globs = append(globs, g)
+	}
+
+	var names []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" || d.Name() == ScriptsPath {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		for _, g := range globs {
+			if g.Match(name) {
+				names = append(names, name)
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(names)
+	return names, nil
+}
+
+func readArtifact(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	content, err := io.ReadAll(io.LimitReader(f, types.MaxArtifactSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(content) > types.MaxArtifactSize {
+		return nil, errArtifactTooLarge
+	}
+	return content, nil
+}
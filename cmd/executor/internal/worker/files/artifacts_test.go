@@ -0,0 +1,143 @@
+package files_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/worker/files"
+	"github.com/sourcegraph/sourcegraph/internal/executor/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestUploadArtifacts(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "reports/unit.xml", "<testsuites token=\"secret-token\"/>")
+	writeFile(t, dir, "reports/nested/integration.xml", "<testsuites/>")
+	writeFile(t, dir, "reports/summary.txt", "all good")
+	writeFile(t, dir, "index.scip", "scip")
+	writeFile(t, dir, ".git/config", "[core]")
+	require.NoError(t, os.Symlink("/etc/hostname", filepath.Join(dir, "reports", "host.xml")))
+
+	tests := []struct {
+		name              string
+		artifacts         []string
+		mockFunc          func(store *files.MockArtifactStore)
+		expectedArtifacts map[string]string
+		expectedExitCode  int
+		expectedErr       error
+	}{
+		{
+			name:      "Matching files",
+			artifacts: []string{"reports/**.xml", "*.scip"},
+			expectedArtifacts: map[string]string{
+				"index.scip":                     "scip",
+				"reports/nested/integration.xml": "<testsuites/>",
+				"reports/unit.xml":               "<testsuites token=\"REDACTED\"/>",
+			},
+		},
+		{
+			name:              "No matching files",
+			artifacts:         []string{"coverage/*"},
+			expectedArtifacts: map[string]string{},
+		},
+		{
+			name:      "Git directory and symbolic links are skipped",
+			artifacts: []string{"**"},
+			expectedArtifacts: map[string]string{
+				"index.scip":                     "scip",
+				"reports/nested/integration.xml": "<testsuites/>",
+				"reports/summary.txt":            "all good",
+				"reports/unit.xml":               "<testsuites token=\"REDACTED\"/>",
+			},
+		},
+		{
+			name:      "Upload failure",
+			artifacts: []string{"*.scip"},
+			mockFunc: func(store *files.MockArtifactStore) {
+				store.UploadArtifactFunc.SetDefaultReturn(errors.New("failed"))
+			},
+			expectedArtifacts: map[string]string{},
+			expectedExitCode:  1,
+			expectedErr:       errors.New("uploading artifact \"index.scip\": failed"),
+		},
+		{
+			name:             "Invalid pattern",
+			artifacts:        []string{"[a-"},
+			expectedExitCode: 1,
+			expectedErr:      errors.New("invalid artifact pattern \"[a-\""),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			store := files.NewMockArtifactStore()
+			uploaded := map[string]string{}
+			store.UploadArtifactFunc.SetDefaultHook(func(ctx context.Context, job types.Job, name string, content io.Reader) error {
+				b, err := io.ReadAll(content)
+				require.NoError(t, err)
+				uploaded[name] = string(b)
+				return nil
+			})
+			if test.mockFunc != nil {
+				test.mockFunc(store)
+			}
+
+			logger := files.NewMockLogger()
+			logEntry := files.NewMockLogEntry()
+			logger.LogEntryFunc.SetDefaultReturn(logEntry)
+
+			job := types.Job{ID: 42, Queue: "codeintel", Artifacts: test.artifacts}
+			err := files.UploadArtifacts(context.Background(), store, job, dir, map[string]string{"secret-token": "REDACTED"}, logger)
+			if test.expectedErr != nil {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.expectedErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+
+			if test.expectedArtifacts != nil {
+				assert.Equal(t, test.expectedArtifacts, uploaded)
+			}
+			require.Len(t, logger.LogEntryFunc.History(), 1)
+			assert.Equal(t, "teardown.artifacts", logger.LogEntryFunc.History()[0].Arg0)
+			require.Len(t, logEntry.FinalizeFunc.History(), 1)
+			assert.Equal(t, test.expectedExitCode, logEntry.FinalizeFunc.History()[0].Arg0)
+		})
+	}
+}
+
+func TestUploadArtifacts_TooLarge(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "small.log", "small")
+	writeFile(t, dir, "large.log", strings.Repeat("a", types.MaxArtifactSize+1))
+
+	store := files.NewMockArtifactStore()
+	logger := files.NewMockLogger()
+	logEntry := files.NewMockLogEntry()
+	logger.LogEntryFunc.SetDefaultReturn(logEntry)
+
+	var output bytes.Buffer
+	logEntry.WriteFunc.SetDefaultHook(output.Write)
+
+	job := types.Job{ID: 42, Artifacts: []string{"*.log"}}
+	require.NoError(t, files.UploadArtifacts(context.Background(), store, job, dir, nil, logger))
+
+	require.Len(t, store.UploadArtifactFunc.History(), 1)
+	assert.Equal(t, "small.log", store.UploadArtifactFunc.History()[0].Arg2)
+	assert.Contains(t, output.String(), "Skipping large.log")
+}
+
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+	require.NoError(t, os.WriteFile(path, []byte(content), os.ModePerm))
+}
@@ -3,6 +3,7 @@ package worker
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"time"
 
 	"github.com/google/uuid"
@@ -26,15 +27,16 @@ import (
 )
 
 type handler struct {
-	nameSet      *janitor.NameSet
-	cmdRunner    util.CmdRunner
-	cmd          command.Command
-	logStore     cmdlogger.ExecutionLogEntryStore
-	filesStore   files.Store
-	options      Options
-	cloneOptions workspace.CloneOptions
-	operations   *command.Operations
-	jobRuntime   runtime.Runtime
+	nameSet       *janitor.NameSet
+	cmdRunner     util.CmdRunner
+	cmd           command.Command
+	logStore      cmdlogger.ExecutionLogEntryStore
+	filesStore    files.Store
+	artifactStore files.ArtifactStore
+	options       Options
+	cloneOptions  workspace.CloneOptions
+	operations    *command.Operations
+	jobRuntime    runtime.Runtime
 }
 
 var (
@@ -99,6 +101,9 @@ func (h *handler) Handle(ctx context.Context, logger log.Logger, job types.Job)
 	// src-cli steps do not work in the new runtime environment.
 	// Remove this when native SSBC is complete.
 	if len(job.CliSteps) > 0 {
+		if h.options.RunnerOptions.SandboxOptions.Enabled {
+			return errors.New("src-cli steps are not supported by the sandbox runtime")
+		}
 		logger.Debug("Handling src-cli steps")
 		return h.handle(ctx, logger, commandLogger, job)
 	}
@@ -144,6 +149,9 @@ func (h *handler) Handle(ctx context.Context, logger log.Logger, job types.Job)
 		}
 	}()
 
+	// Upload the artifacts of the job once its commands have run, whether or not they succeeded.
+	defer h.uploadArtifacts(ctx, logger, commandLogger, job, ws, h.jobRuntime.Name())
+
 	// Get the commands we will execute.
 	logger.Info("Creating commands")
 	job.Queue = h.options.QueueName
@@ -249,6 +257,13 @@ func (h *handler) handle(ctx context.Context, logger log.Logger, commandLogger c
 		}
 	}()
 
+	// Upload the artifacts of the job once its steps have run, whether or not they succeeded.
+	runtimeName := runtime.NameDocker
+	if h.options.RunnerOptions.FirecrackerOptions.Enabled {
+		runtimeName = runtime.NameFirecracker
+	}
+	defer h.uploadArtifacts(ctx, logger, commandLogger, job, ws, runtimeName)
+
 	// Invoke each docker step sequentially
 	for i, dockerStep := range job.DockerSteps {
 		var key string
@@ -346,6 +361,41 @@ func (h *handler) prepareWorkspace(
 	)
 }
 
+// uploadArtifacts uploads the files of the workspace that match the artifact patterns of
+// the job. Failing to upload artifacts does not fail the job, failures are reported in
+// the execution logs of the job instead.
+func (h *handler) uploadArtifacts(
+	ctx context.Context,
+	logger log.Logger,
+	commandLogger cmdlogger.Logger,
+	job types.Job,
+	ws workspace.Workspace,
+	runtimeName runtime.Name,
+) {
+	if len(job.Artifacts) == 0 || h.artifactStore == nil {
+		return
+	}
+	if job.Queue == "" {
+		job.Queue = h.options.QueueName
+	}
+
+	// The workspace of Firecracker and Kubernetes jobs is not accessible from the host
+	// once their commands have run.
+	if runtimeName != runtime.NameDocker && runtimeName != runtime.NameShell && runtimeName != runtime.NameSandbox {
+		handle := commandLogger.LogEntry("teardown.artifacts", nil)
+		fmt.Fprintf(handle, "Artifacts are not supported by the %s runtime\n", runtimeName)
+		handle.Finalize(0)
+		handle.Close()
+		return
+	}
+
+	// 🚨 SECURITY: Artifacts are redacted with the same values as the command output.
+	dir := filepath.Join(ws.WorkingDirectory(), job.RepositoryDirectory)
+	if err := files.UploadArtifacts(ctx, h.artifactStore, job, dir, union(h.options.RedactedValues, job.RedactedValues), commandLogger); err != nil {
+		logger.Warn("Failed to upload artifacts", log.Error(err))
+	}
+}
+
 func newVMName(vmPrefix string) string {
 	vmNameSuffix := uuid.NewString()
 
@@ -2,6 +2,9 @@ package worker
 
 import (
 	"context"
+	"io"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/sourcegraph/log/logtest"
@@ -11,6 +14,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/janitor"
 	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/worker/command"
 	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/worker/runner"
+	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/worker/runtime"
 	"github.com/sourcegraph/sourcegraph/internal/executor/types"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
@@ -341,6 +345,29 @@ func TestHandler_Handle_Legacy(t *testing.T) {
 			},
 			expectedErr: errors.New("failed to perform src-cli step: failed"),
 		},
+		{
+			name: "srcCli steps in sandbox",
+			options: Options{
+				RunnerOptions: runner.Options{
+					SandboxOptions: runner.SandboxOptions{Enabled: true},
+				},
+			},
+			job: types.Job{
+				ID:             42,
+				RepositoryName: "my-repo",
+				Commit:         "cool-commit",
+				CliSteps: []types.CliStep{
+					{
+						Key:      "some-step",
+						Commands: []string{"echo", "hello"},
+					},
+				},
+			},
+			assertMockFunc: func(t *testing.T, cmdRunner *MockCmdRunner, cmd *MockCommand, logStore *MockExecutionLogEntryStore, filesStore *MockStore) {
+				require.Len(t, cmd.RunFunc.History(), 0)
+			},
+			expectedErr: errors.New("src-cli steps are not supported by the sandbox runtime"),
+		},
 		{
 			name:    "failed with docker steps",
 			options: Options{},
@@ -627,3 +654,97 @@ func TestHandler_Handle(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_Handle_Artifacts(t *testing.T) {
+	internalLogger := logtest.Scoped(t)
+	operations := command.NewOperations(&observation.TestContext)
+
+	workingDirectory := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(workingDirectory, "repository", "reports"), os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(workingDirectory, "repository", "reports", "unit.xml"), []byte("token=hunter2"), os.ModePerm))
+
+	tests := []struct {
+		name              string
+		runtimeName       runtime.Name
+		runErr            error
+		expectedErr       error
+		expectedArtifacts []string
+	}{
+		{
+			name:              "Uploaded after success",
+			runtimeName:       runtime.NameDocker,
+			expectedArtifacts: []string{"reports/unit.xml"},
+		},
+		{
+			name:              "Uploaded after failure",
+			runtimeName:       runtime.NameShell,
+			runErr:            errors.New("failed"),
+			expectedErr:       errors.New("running command \"my-key\": failed"),
+			expectedArtifacts: []string{"reports/unit.xml"},
+		},
+		{
+			name:              "Uploaded from sandbox",
+			runtimeName:       runtime.NameSandbox,
+			expectedArtifacts: []string{"reports/unit.xml"},
+		},
+		{
+			name:        "Unsupported runtime",
+			runtimeName: runtime.NameFirecracker,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			jobRuntime := NewMockRuntime()
+			jobRunner := NewMockRunner()
+			jobWorkspace := NewMockWorkspace()
+			artifactStore := NewMockArtifactStore()
+
+			jobRuntime.NameFunc.SetDefaultReturn(test.runtimeName)
+			jobRuntime.PrepareWorkspaceFunc.PushReturn(jobWorkspace, nil)
+			jobRuntime.NewRunnerFunc.PushReturn(jobRunner, nil)
+			jobRuntime.NewRunnerSpecsFunc.PushReturn([]runner.Spec{
+				{CommandSpecs: []command.Spec{{Key: "my-key", Command: []string{"make", "test"}, Operation: operations.Exec}}},
+			}, nil)
+			jobRunner.RunFunc.PushReturn(test.runErr)
+			jobWorkspace.WorkingDirectoryFunc.SetDefaultReturn(workingDirectory)
+
+			var uploaded []string
+			artifactStore.UploadArtifactFunc.SetDefaultHook(func(ctx context.Context, job types.Job, name string, content io.Reader) error {
+				assert.Equal(t, "codeintel", job.Queue)
+				b, err := io.ReadAll(content)
+				require.NoError(t, err)
+				assert.Equal(t, "token=REDACTED", string(b))
+				uploaded = append(uploaded, name)
+				return nil
+			})
+
+			h := &handler{
+				nameSet:       janitor.NewNameSet(),
+				jobRuntime:    jobRuntime,
+				logStore:      NewMockExecutionLogEntryStore(),
+				artifactStore: artifactStore,
+				options:       Options{QueueName: "codeintel"},
+				operations:    operations,
+			}
+
+			job := types.Job{
+				ID:                  42,
+				RepositoryName:      "my-repo",
+				RepositoryDirectory: "repository",
+				Commit:              "cool-commit",
+				Artifacts:           []string{"reports/*.xml"},
+				RedactedValues:      map[string]string{"hunter2": "REDACTED"},
+			}
+			err := h.Handle(context.Background(), internalLogger, job)
+			if test.expectedErr != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, test.expectedErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, test.expectedArtifacts, uploaded)
+			require.Len(t, jobRunner.TeardownFunc.History(), 1)
+		})
+	}
+}
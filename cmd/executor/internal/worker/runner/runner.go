@@ -43,6 +43,7 @@ type Options struct {
 	DockerOptions      command.DockerOptions
 	FirecrackerOptions FirecrackerOptions
 	KubernetesOptions  KubernetesOptions
+	SandboxOptions     SandboxOptions
 }
 
 // NewRunner creates a new runner with the given options.
@@ -0,0 +1,135 @@
+package runner
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/sandbox"
+	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/worker/cmdlogger"
+	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/worker/command"
+	"github.com/sourcegraph/sourcegraph/internal/executor/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// SandboxOptions are the options for running commands in rootless sandboxes.
+type SandboxOptions struct {
+	// Enabled determines if commands will be run in sandboxes.
+	Enabled bool
+	// ImageDir is the directory on the host that images are unpacked into. Images are
+	// shared between jobs.
+	ImageDir string
+	// ImageMaxSize is the size in bytes above which the least recently used images are
+	// evicted by the janitor.
+	ImageMaxSize int64
+	// SandboxOptions are the options of the sandboxes.
+	SandboxOptions command.SandboxOptions
+	// DockerAuthConfig is the docker CLI config used to authenticate to registries.
+	DockerAuthConfig types.DockerAuthConfig
+}
+
+type sandboxRunner struct {
+	cmd              command.Command
+	dir              string
+	internalLogger   log.Logger
+	commandLogger    cmdlogger.Logger
+	options          SandboxOptions
+	dockerAuthConfig types.DockerAuthConfig
+	images           *sandbox.ImageStore
+	// tmpDir is used to store the writes of the sandboxes to their root filesystem.
+	tmpDir string
+}
+
+var _ Runner = &sandboxRunner{}
+
+// NewSandboxRunner creates a new runner that runs commands in rootless sandboxes.
+func NewSandboxRunner(
+	cmd command.Command,
+	logger cmdlogger.Logger,
+	dir string,
+	options SandboxOptions,
+	dockerAuthConfig types.DockerAuthConfig,
+) Runner {
+	// Use the option configuration unless the user has provided a custom configuration.
+	actualDockerAuthConfig := options.DockerAuthConfig
+	if len(dockerAuthConfig.Auths) > 0 {
+		actualDockerAuthConfig = dockerAuthConfig
+	}
+
+	return &sandboxRunner{
+		cmd:              cmd,
+		dir:              dir,
+		internalLogger:   log.Scoped("sandbox-runner", ""),
+		commandLogger:    logger,
+		options:          options,
+		dockerAuthConfig: actualDockerAuthConfig,
+	}
+}
+
+func (r *sandboxRunner) TempDir() string {
+	return r.tmpDir
+}
+
+func (r *sandboxRunner) Setup(ctx context.Context) error {
+	images, err := sandbox.NewImageStore(r.options.ImageDir, r.options.ImageMaxSize)
+	if err != nil {
+		return errors.Wrap(err, "failed to create image dir for sandbox runner")
+	}
+	r.images = images
+
+	dir, err := os.MkdirTemp("", "executor-sandbox-runner")
+	if err != nil {
+		return errors.Wrap(err, "failed to create tmp dir for sandbox runner")
+	}
+	r.tmpDir = dir
+
+	return nil
+}
+
+func (r *sandboxRunner) Teardown(ctx context.Context) error {
+	// The kernel leaves the work directories of overlay mounts without any permissions.
+	_ = filepath.WalkDir(r.tmpDir, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && d.IsDir() {
+			_ = os.Chmod(path, 0o700)
+		}
+		return nil
+	})
+
+	if err := os.RemoveAll(r.tmpDir); err != nil {
+		r.internalLogger.Error(
+			"Failed to remove sandbox state tmp dir",
+			log.String("tmpDir", r.tmpDir),
+			log.Error(err),
+		)
+	}
+
+	return nil
+}
+
+func (r *sandboxRunner) Run(ctx context.Context, spec Spec) error {
+	if spec.Image == "" {
+		return errors.New("commands without an image are not supported by the sandbox runtime")
+	}
+
+	image, release, err := r.images.Pull(ctx, spec.Image, r.dockerAuthConfig)
+	if err != nil {
+		return errors.Wrap(err, "failed to pull image")
+	}
+	defer func() {
+		if err := release(); err != nil {
+			r.internalLogger.Error("Failed to release sandbox image", log.String("image", spec.Image), log.Error(err))
+		}
+	}()
+
+	// Every step starts with a pristine root filesystem, like a new container.
+	scratchDir, err := os.MkdirTemp(r.tmpDir, "step")
+	if err != nil {
+		return errors.Wrap(err, "failed to create scratch dir for sandbox")
+	}
+
+	sandboxSpec := command.NewSandboxSpec(r.dir, image, scratchDir, spec.ScriptPath, spec.CommandSpecs[0], r.options.SandboxOptions)
+	return r.cmd.Run(ctx, r.commandLogger, sandboxSpec)
+}
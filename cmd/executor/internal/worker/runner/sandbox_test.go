@@ -0,0 +1,73 @@
+package runner_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/worker/command"
+	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/worker/runner"
+	"github.com/sourcegraph/sourcegraph/internal/executor/types"
+)
+
+func TestSandboxRunner_Setup(t *testing.T) {
+	imageDir := filepath.Join(t.TempDir(), "images")
+	sandboxRunner := runner.NewSandboxRunner(nil, nil, "", runner.SandboxOptions{ImageDir: imageDir}, types.DockerAuthConfig{})
+
+	ctx := context.Background()
+	err := sandboxRunner.Setup(ctx)
+	defer sandboxRunner.Teardown(ctx)
+	require.NoError(t, err)
+
+	assert.DirExists(t, imageDir)
+	entries, err := os.ReadDir(sandboxRunner.TempDir())
+	require.NoError(t, err)
+	assert.Len(t, entries, 0)
+}
+
+func TestSandboxRunner_Teardown(t *testing.T) {
+	sandboxRunner := runner.NewSandboxRunner(nil, nil, "", runner.SandboxOptions{ImageDir: t.TempDir()}, types.DockerAuthConfig{})
+	ctx := context.Background()
+	err := sandboxRunner.Setup(ctx)
+	require.NoError(t, err)
+
+	dir := sandboxRunner.TempDir()
+
+	// Overlay work directories have no permissions.
+	workDir := filepath.Join(dir, "step1", "work", "work")
+	require.NoError(t, os.MkdirAll(filepath.Join(workDir, "index"), os.ModePerm))
+	require.NoError(t, os.Chmod(workDir, 0))
+
+	err = sandboxRunner.Teardown(ctx)
+	require.NoError(t, err)
+
+	_, err = os.Stat(dir)
+	require.Error(t, err)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestSandboxRunner_Run_NoImage(t *testing.T) {
+	cmd := runner.NewMockCommand()
+	logger := runner.NewMockLogger()
+	spec := runner.Spec{
+		CommandSpecs: []command.Spec{
+			{
+				Key:     "some-key",
+				Command: []string{"src", "batch", "exec"},
+			},
+		},
+	}
+
+	sandboxRunner := runner.NewSandboxRunner(cmd, logger, "/some/dir", runner.SandboxOptions{ImageDir: t.TempDir()}, types.DockerAuthConfig{})
+	ctx := context.Background()
+	require.NoError(t, sandboxRunner.Setup(ctx))
+	defer sandboxRunner.Teardown(ctx)
+
+	err := sandboxRunner.Run(ctx, spec)
+	assert.EqualError(t, err, "commands without an image are not supported by the sandbox runtime")
+	assert.Empty(t, cmd.RunFunc.History())
+}
@@ -8,6 +8,7 @@ import (
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 
+	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/sandbox"
 	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/util"
 	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/worker/cmdlogger"
 	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/worker/command"
@@ -89,6 +90,29 @@ func New(
 		}
 	}
 
+	if runnerOpts.SandboxOptions.Enabled {
+		// We explicitly want a sandbox runtime. So validation must pass.
+		if err := sandbox.Validate(runnerOpts.SandboxOptions.SandboxOptions.CgroupRoot); err != nil {
+			logger.Error("runtime 'sandbox' is not supported", log.Error(err))
+			return nil, err
+		} else if found, err := util.ExistsPath(runner, "git"); err != nil {
+			logger.Error("failed to determine if git is installed", log.Error(err))
+			return nil, err
+		} else if !found {
+			logger.Error("runtime 'sandbox' is not supported: missing required tools", log.Strings("tools", []string{"git"}))
+			return nil, &util.ErrMissingTools{Tools: []string{"git"}}
+		} else {
+			logger.Info("using runtime 'sandbox'")
+			return &sandboxRuntime{
+				cmd:          cmd,
+				operations:   ops,
+				filesStore:   filesStore,
+				cloneOptions: cloneOpts,
+				sandboxOpts:  runnerOpts.SandboxOptions,
+			}, nil
+		}
+	}
+
 	if runnerOpts.KubernetesOptions.Enabled {
 		configPath := runnerOpts.KubernetesOptions.ConfigPath
 		kubeConfig, err := clientcmd.BuildConfigFromFlags("", configPath)
@@ -150,6 +174,7 @@ const (
 	NameFirecracker Name = "firecracker"
 	NameKubernetes  Name = "kubernetes"
 	NameShell       Name = "shell"
+	NameSandbox     Name = "sandbox"
 )
 
 // CommandKey returns the fully formatted key for the command.
@@ -158,7 +183,7 @@ func CommandKey(name Name, rawStepKey string, index int) string {
 	case NameKubernetes:
 		return kubernetesKey(rawStepKey, index)
 	default:
-		// shell, docker, and firecracker all use the same key format.
+		// shell, docker, firecracker, and sandbox all use the same key format.
 		return dockerKey(rawStepKey, index)
 	}
 }
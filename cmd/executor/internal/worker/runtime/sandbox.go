@@ -0,0 +1,70 @@
+package runtime
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/worker/cmdlogger"
+	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/worker/command"
+	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/worker/files"
+	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/worker/runner"
+	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/worker/workspace"
+	"github.com/sourcegraph/sourcegraph/internal/executor/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type sandboxRuntime struct {
+	cmd          command.Command
+	operations   *command.Operations
+	filesStore   files.Store
+	cloneOptions workspace.CloneOptions
+	sandboxOpts  runner.SandboxOptions
+}
+
+var _ Runtime = &sandboxRuntime{}
+
+func (r *sandboxRuntime) Name() Name {
+	return NameSandbox
+}
+
+func (r *sandboxRuntime) PrepareWorkspace(ctx context.Context, logger cmdlogger.Logger, job types.Job) (workspace.Workspace, error) {
+	// The workspace is mounted into sandboxes like into docker containers.
+	return workspace.NewDockerWorkspace(
+		ctx,
+		r.filesStore,
+		job,
+		r.cmd,
+		logger,
+		r.cloneOptions,
+		r.operations,
+	)
+}
+
+func (r *sandboxRuntime) NewRunner(ctx context.Context, logger cmdlogger.Logger, filesStore files.Store, options RunnerOptions) (runner.Runner, error) {
+	run := runner.NewSandboxRunner(r.cmd, logger, options.Path, r.sandboxOpts, options.DockerAuthConfig)
+	if err := run.Setup(ctx); err != nil {
+		return nil, errors.Wrap(err, "failed to setup sandbox runner")
+	}
+	return run, nil
+}
+
+func (r *sandboxRuntime) NewRunnerSpecs(ws workspace.Workspace, job types.Job) ([]runner.Spec, error) {
+	runnerSpecs := make([]runner.Spec, len(job.DockerSteps))
+	for i, step := range job.DockerSteps {
+		runnerSpecs[i] = runner.Spec{
+			Job: job,
+			CommandSpecs: []command.Spec{
+				{
+					Key:       dockerKey(step.Key, i),
+					Command:   nil,
+					Dir:       step.Dir,
+					Env:       step.Env,
+					Operation: r.operations.Exec,
+				},
+			},
+			Image:      step.Image,
+			ScriptPath: ws.ScriptFilenames()[i],
+		}
+	}
+
+	return runnerSpecs, nil
+}
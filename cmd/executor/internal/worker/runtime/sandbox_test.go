@@ -0,0 +1,58 @@
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/worker/command"
+	"github.com/sourcegraph/sourcegraph/internal/executor/types"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestSandboxRuntime_Name(t *testing.T) {
+	r := sandboxRuntime{}
+	assert.Equal(t, "sandbox", string(r.Name()))
+}
+
+func TestSandboxRuntime_NewRunnerSpecs(t *testing.T) {
+	operations := command.NewOperations(&observation.TestContext)
+
+	ws := NewMockWorkspace()
+	ws.ScriptFilenamesFunc.SetDefaultReturn([]string{"script1.sh", "script2.sh"})
+
+	job := types.Job{
+		DockerSteps: []types.DockerStep{
+			{
+				Key:      "key-1",
+				Image:    "my-image",
+				Commands: []string{"echo", "hello"},
+				Dir:      ".",
+				Env:      []string{"FOO=bar"},
+			},
+			{
+				Image:    "my-other-image",
+				Commands: []string{"echo", "world"},
+			},
+		},
+	}
+
+	r := &sandboxRuntime{operations: operations}
+	actual, err := r.NewRunnerSpecs(ws, job)
+	require.NoError(t, err)
+	require.Len(t, actual, 2)
+
+	assert.Equal(t, "my-image", actual[0].Image)
+	assert.Equal(t, "script1.sh", actual[0].ScriptPath)
+	assert.Equal(t, command.Spec{
+		Key:       "step.docker.key-1",
+		Dir:       ".",
+		Env:       []string{"FOO=bar"},
+		Operation: operations.Exec,
+	}, actual[0].CommandSpecs[0])
+
+	assert.Equal(t, "my-other-image", actual[1].Image)
+	assert.Equal(t, "script2.sh", actual[1].ScriptPath)
+	assert.Equal(t, "step.docker.1", actual[1].CommandSpecs[0].Key)
+}
@@ -73,6 +73,10 @@ type Options struct {
 	// DockerRegistryNodeExporterEndpoint is the URL of the intermediary caching docker registry,
 	// for scraping and forwarding metrics.
 	DockerRegistryNodeExporterEndpoint string
+
+	// RepoCache is the repository cache on the host that workspaces borrow git objects
+	// from when cloning. It is nil if the repository cache is disabled.
+	RepoCache *workspace.RepoCache
 }
 
 // NewWorker creates a worker that polls a remote job queue API for work.
@@ -100,6 +104,7 @@ func NewWorker(observationCtx *observation.Context, nameSet *janitor.NameSet, op
 		EndpointURL:    options.QueueOptions.BaseClientOptions.EndpointOptions.URL,
 		GitServicePath: options.GitServicePath,
 		ExecutorToken:  options.QueueOptions.BaseClientOptions.EndpointOptions.Token,
+		RepoCache:      options.RepoCache,
 	}
 
 	cmdRunner := &util.RealCmdRunner{}
@@ -115,15 +120,16 @@ func NewWorker(observationCtx *observation.Context, nameSet *janitor.NameSet, op
 	}
 
 	h := &handler{
-		nameSet:      nameSet,
-		cmdRunner:    cmdRunner,
-		cmd:          cmd,
-		logStore:     queueClient,
-		filesStore:   filesClient,
-		options:      options,
-		cloneOptions: cloneOptions,
-		operations:   commandOps,
-		jobRuntime:   jobRuntime,
+		nameSet:       nameSet,
+		cmdRunner:     cmdRunner,
+		cmd:           cmd,
+		logStore:      queueClient,
+		filesStore:    filesClient,
+		artifactStore: filesClient,
+		options:       options,
+		cloneOptions:  cloneOptions,
+		operations:    commandOps,
+		jobRuntime:    jobRuntime,
 	}
 
 	return workerutil.NewWorker[types.Job](context.Background(), queueClient, h, options.WorkerOptions), nil
@@ -63,6 +63,25 @@ func cloneRepo(
 		return err
 	}
 
+	// When the repository cache is enabled, the job commit is fetched into the cached
+	// repository first. The fetch into the workspace then borrows its objects, so that
+	// nothing but the refs has to be transferred over the network.
+	fetchEnv := gitStdEnv
+	cached := options.RepoCache != nil
+	if cached {
+		var cachePath string
+		var unlock func() error
+		cachePath, unlock, err = updateRepoCache(ctx, options.RepoCache, job, cloneURL, cmd, logger, operations)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			err = errors.Append(err, unlock())
+		}()
+
+		fetchEnv = append(append([]string{}, gitStdEnv...), "GIT_ALTERNATE_OBJECT_DIRECTORIES="+filepath.Join(cachePath, "objects"))
+	}
+
 	fetchCommand := []string{
 		"git",
 		"-C", repoPath,
@@ -85,7 +104,10 @@ func cloneRepo(
 		appendFetchArg("--tags")
 	}
 
-	if job.ShallowClone {
+	// Borrowing objects from the repository cache only works for complete fetches. As
+	// the objects are copied from the local disk, a shallow or partial fetch would not
+	// save anything anyway.
+	if job.ShallowClone && !cached {
 		if !job.FetchTags {
 			appendFetchArg("--no-tags")
 		}
@@ -93,7 +115,7 @@ func cloneRepo(
 	}
 
 	// For a sparse checkout, we want to add a blob filter so we only fetch the minimum set of files initially.
-	if len(job.SparseCheckout) > 0 {
+	if len(job.SparseCheckout) > 0 && !cached {
 		appendFetchArg("--filter=blob:none")
 	}
 
@@ -102,7 +124,19 @@ func cloneRepo(
 		{Key: "setup.git.add-remote", Env: gitStdEnv, Command: []string{"git", "-C", repoPath, "remote", "add", "origin", cloneURL.String()}, Operation: operations.SetupAddRemote},
 		// Disable gc, this can improve performance and should never run for executor clones.
 		{Key: "setup.git.disable-gc", Env: gitStdEnv, Command: []string{"git", "-C", repoPath, "config", "--local", "gc.auto", "0"}, Operation: operations.SetupGitDisableGC},
-		{Key: "setup.git.fetch", Env: gitStdEnv, Command: fetchCommand, Operation: operations.SetupGitFetch},
+		{Key: "setup.git.fetch", Env: fetchEnv, Command: fetchCommand, Operation: operations.SetupGitFetch},
+	}
+
+	if cached {
+		// Copy the borrowed objects into the workspace, like `git clone --dissociate`
+		// does. The cached repository is not visible to the job, and may be evicted
+		// while the workspace is still in use.
+		gitCommands = append(gitCommands, command.Spec{
+			Key:       "setup.git.dissociate",
+			Env:       fetchEnv,
+			Command:   []string{"git", "-C", repoPath, "repack", "-a", "-d", "-q"},
+			Operation: operations.SetupGitDissociate,
+		})
 	}
 
 	if len(job.SparseCheckout) > 0 {
@@ -175,6 +209,82 @@ func cloneRepo(
 	return nil
 }
 
+// updateRepoCache fetches the job commit into the cached repository of the job,
+// creating the cached repository if needed. On success, the cached repository stays
+// locked for reading, so that it is neither updated nor evicted while the workspace
+// borrows its objects, until the returned function is called.
+func updateRepoCache(
+	ctx context.Context,
+	cache *RepoCache,
+	job types.Job,
+	cloneURL *url.URL,
+	cmd command.Command,
+	logger cmdlogger.Logger,
+	operations *command.Operations,
+) (_ string, _ func() error, err error) {
+	cachePath, lock, err := cache.lock(ctx, job.RepositoryName)
+	if err != nil {
+		return "", nil, err
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Append(err, lock.Unlock())
+		}
+	}()
+
+	var gitCommands []command.Spec
+
+	_, err = os.Stat(cachePath)
+	created := os.IsNotExist(err)
+	if err != nil && !created {
+		return "", nil, errors.Wrap(err, "checking cached repository")
+	}
+	if created {
+		gitCommands = append(gitCommands,
+			command.Spec{Key: "setup.git.cache-init", Env: gitStdEnv, Command: []string{"git", "init", "--bare", "--quiet", cachePath}, Operation: operations.SetupGitCacheInit},
+			// The fetched commits are not referenced by any ref, so gc must never run
+			// as it would prune them.
+			command.Spec{Key: "setup.git.cache-disable-gc", Env: gitStdEnv, Command: []string{"git", "-C", cachePath, "config", "--local", "gc.auto", "0"}, Operation: operations.SetupGitCacheDisableGC},
+		)
+	}
+
+	fetchCommand := []string{
+		"git",
+		"-C", cachePath,
+		"-c", "protocol.version=2",
+		"fetch",
+		"--progress",
+		"--no-recurse-submodules",
+	}
+	if job.FetchTags {
+		fetchCommand = append(fetchCommand, "--tags")
+	}
+	fetchCommand = append(fetchCommand, cloneURL.String(), job.Commit)
+
+	gitCommands = append(gitCommands, command.Spec{
+		Key:       "setup.git.cache-fetch",
+		Env:       gitStdEnv,
+		Command:   fetchCommand,
+		Operation: operations.SetupGitCacheFetch,
+	})
+
+	for _, spec := range gitCommands {
+		if err = cmd.Run(ctx, logger, spec); err != nil {
+			if created {
+				// Don't leave a partially initialized repository behind.
+				err = errors.Append(err, os.RemoveAll(cachePath))
+			}
+			return "", nil, errors.Wrap(err, fmt.Sprintf("failed %s", spec.Key))
+		}
+	}
+
+	if err = lock.Downgrade(ctx); err != nil {
+		return "", nil, errors.Wrap(err, "locking cached repository")
+	}
+
+	return cachePath, lock.Unlock, nil
+}
+
 // newGitProxyServer creates a new HTTP proxy to the Sourcegraph instance on a random port.
 // It handles authentication and additional headers required. The cleanup function
 // should be called after the clone operations are done and _before_ the job is started.
@@ -21,6 +21,9 @@ import (
 
 func TestNewDockerWorkspace(t *testing.T) {
 	operations := command.NewOperations(&observation.TestContext)
+	repoCacheDir := t.TempDir()
+	repoCache, err := workspace.NewRepoCache(repoCacheDir, 1024)
+	require.NoError(t, err)
 
 	tests := []struct {
 		name                   string
@@ -295,6 +298,68 @@ func TestNewDockerWorkspace(t *testing.T) {
 				}, cmd.RunFunc.History()[3].Arg2.Command)
 			},
 		},
+		{
+			name: "Clone repository with repository cache",
+			job: types.Job{
+				ID:             42,
+				Token:          "token",
+				Commit:         "commit",
+				RepositoryName: "my-repo",
+				ShallowClone:   true,
+			},
+			cloneOptions: workspace.CloneOptions{RepoCache: repoCache},
+			mockFunc: func(logger *workspace.MockLogger, filesStore *workspace.MockStore, cmd *workspace.MockCommand) {
+				logger.LogEntryFunc.SetDefaultReturn(workspace.NewMockLogEntry())
+				cmd.RunFunc.SetDefaultReturn(nil)
+			},
+			assertMockFunc: func(t *testing.T, logger *workspace.MockLogger, filesStore *workspace.MockStore, cmd *workspace.MockCommand, tempDir string) {
+				require.Len(t, filesStore.GetFunc.History(), 0)
+				require.Len(t, cmd.RunFunc.History(), 10)
+				// Init cached repository
+				assert.Equal(t, "setup.git.cache-init", cmd.RunFunc.History()[0].Arg2.Key)
+				cachePath := cmd.RunFunc.History()[0].Arg2.Command[4]
+				assert.Equal(t, []string{"git", "init", "--bare", "--quiet", cachePath}, cmd.RunFunc.History()[0].Arg2.Command)
+				assert.True(t, strings.HasPrefix(cachePath, repoCacheDir))
+				assert.Equal(t, "setup.git.cache-disable-gc", cmd.RunFunc.History()[1].Arg2.Key)
+				// Fetch into cached repository
+				assert.Equal(t, "setup.git.cache-fetch", cmd.RunFunc.History()[2].Arg2.Key)
+				assert.Equal(t, expectedGitEnv, cmd.RunFunc.History()[2].Arg2.Env)
+				assert.Equal(t, []string{
+					"git",
+					"-C",
+					cachePath,
+					"-c",
+					"protocol.version=2",
+					"fetch",
+					"--progress",
+					"--no-recurse-submodules",
+				}, cmd.RunFunc.History()[2].Arg2.Command[:8])
+				assert.Regexp(t, "^http://127.0.0.1:[0-9]+/my-repo$", cmd.RunFunc.History()[2].Arg2.Command[8])
+				assert.Equal(t, "commit", cmd.RunFunc.History()[2].Arg2.Command[9])
+				// Fetch borrowing from the cached repository, never shallow
+				expectedCacheEnv := append(append([]string{}, expectedGitEnv...), "GIT_ALTERNATE_OBJECT_DIRECTORIES="+path.Join(cachePath, "objects"))
+				assert.Equal(t, "setup.git.fetch", cmd.RunFunc.History()[6].Arg2.Key)
+				assert.Equal(t, expectedCacheEnv, cmd.RunFunc.History()[6].Arg2.Env)
+				assert.Equal(t, []string{
+					"git",
+					"-C",
+					tempDir,
+					"-c",
+					"protocol.version=2",
+					"fetch",
+					"--progress",
+					"--no-recurse-submodules",
+					"origin",
+					"commit",
+				}, cmd.RunFunc.History()[6].Arg2.Command)
+				// Dissociate
+				assert.Equal(t, "setup.git.dissociate", cmd.RunFunc.History()[7].Arg2.Key)
+				assert.Equal(t, expectedCacheEnv, cmd.RunFunc.History()[7].Arg2.Env)
+				assert.Equal(t, []string{"git", "-C", tempDir, "repack", "-a", "-d", "-q"}, cmd.RunFunc.History()[7].Arg2.Command)
+				assert.Equal(t, operations.SetupGitDissociate, cmd.RunFunc.History()[7].Arg2.Operation)
+				assert.Equal(t, "setup.git.checkout", cmd.RunFunc.History()[8].Arg2.Key)
+			},
+		},
 		{
 			name: "Sparse checkout",
 			job: types.Job{
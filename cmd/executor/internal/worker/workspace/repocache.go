@@ -0,0 +1,184 @@
+package workspace
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/filelock"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	repoCacheRepoSuffix = ".git"
+	repoCacheLockSuffix = ".lock"
+)
+
+// RepoCache is a cache of bare repositories on the host. Workspaces borrow the git
+// objects of the cached repository when cloning, so that jobs repeatedly running on
+// the same repository don't fetch its whole history over the network every time.
+//
+// Each cached repository is guarded by a lock file next to it, so that a cache
+// directory can be shared by concurrent jobs and by multiple executors on the same
+// host. The modification time of the lock file records when the repository was last
+// used, which drives the eviction of the least recently used repositories.
+type RepoCache struct {
+	dir     string
+	maxSize int64
+}
+
+// NewRepoCache creates a repository cache in the given directory. The cache grows
+// beyond maxSize bytes only until the next call to Evict.
+func NewRepoCache(dir string, maxSize int64) (*RepoCache, error) {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, errors.Wrap(err, "creating repository cache directory")
+	}
+	return &RepoCache{dir: dir, maxSize: maxSize}, nil
+}
+
+// lock acquires an exclusive lock on the cached repository of the given repository and
+// marks it as recently used. The returned path of the cached repository may not exist
+// yet. The caller must release the lock once done with the repository.
+func (c *RepoCache) lock(ctx context.Context, repositoryName string) (string, *filelock.Lock, error) {
+	key := repoCacheKey(repositoryName)
+
+	l, err := filelock.Acquire(ctx, c.lockPath(key), true)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "locking cached repository")
+	}
+
+	now := time.Now()
+	if err := os.Chtimes(c.lockPath(key), now, now); err != nil {
+		_ = l.Unlock()
+		return "", nil, errors.Wrap(err, "marking cached repository as used")
+	}
+
+	return c.repoPath(key), l, nil
+}
+
+// Evict removes the least recently used repositories from the cache until the total
+// size of the cache is at most its maximum size. Repositories that are in use by a job
+// are skipped. It returns the number of removed repositories.
+func (c *RepoCache) Evict(ctx context.Context) (int, error) {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return 0, errors.Wrap(err, "reading repository cache directory")
+	}
+
+	type cachedRepo struct {
+		key      string
+		size     int64
+		lastUsed time.Time
+	}
+
+	var repos []cachedRepo
+	var totalSize int64
+	for _, entry := range entries {
+		if !entry.IsDir() || !strings.HasSuffix(entry.Name(), repoCacheRepoSuffix) {
+			continue
+		}
+		key := strings.TrimSuffix(entry.Name(), repoCacheRepoSuffix)
+
+		size, err := dirSize(c.repoPath(key))
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				// Removed concurrently.
+				continue
+			}
+			return 0, errors.Wrapf(err, "computing size of cached repository %q", key)
+		}
+
+		// Repositories without a lock file are treated as the least recently used ones.
+		var lastUsed time.Time
+		if info, err := os.Stat(c.lockPath(key)); err == nil {
+			lastUsed = info.ModTime()
+		}
+
+		repos = append(repos, cachedRepo{key: key, size: size, lastUsed: lastUsed})
+		totalSize += size
+	}
+
+	sort.Slice(repos, func(i, j int) bool {
+		return repos[i].lastUsed.Before(repos[j].lastUsed)
+	})
+
+	evicted := 0
+	for _, repo := range repos {
+		if totalSize <= c.maxSize {
+			break
+		}
+		if err := ctx.Err(); err != nil {
+			return evicted, err
+		}
+
+		removed, err := c.remove(repo.key)
+		if err != nil {
+			return evicted, err
+		}
+		if removed {
+			totalSize -= repo.size
+			evicted++
+		}
+	}
+
+	return evicted, nil
+}
+
+// remove deletes the cached repository with the given key, unless it is in use. The
+// lock file is kept, as jobs may be waiting on it.
+func (c *RepoCache) remove(key string) (bool, error) {
+	l, ok, err := filelock.TryAcquire(c.lockPath(key))
+	if err != nil {
+		return false, errors.Wrapf(err, "locking cached repository %q", key)
+	}
+	if !ok {
+		return false, nil
+	}
+	defer l.Unlock()
+
+	if err := os.RemoveAll(c.repoPath(key)); err != nil {
+		return false, errors.Wrapf(err, "removing cached repository %q", key)
+	}
+	return true, nil
+}
+
+func (c *RepoCache) repoPath(key string) string {
+	return filepath.Join(c.dir, key+repoCacheRepoSuffix)
+}
+
+func (c *RepoCache) lockPath(key string) string {
+	return filepath.Join(c.dir, key+repoCacheLockSuffix)
+}
+
+// repoCacheKey returns the name of the cached repository of the given repository, which
+// is safe to use as a file name.
+func repoCacheKey(repositoryName string) string {
+	sum := sha256.Sum256([]byte(repositoryName))
+	return hex.EncodeToString(sum[:])
+}
+
+// dirSize returns the total size of the regular files below the given directory.
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		size += info.Size()
+		return nil
+	})
+	return size, err
+}
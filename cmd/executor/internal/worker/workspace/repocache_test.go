@@ -0,0 +1,82 @@
+//go:build !windows
+// +build !windows
+
+package workspace
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/filelock"
+)
+
+func TestRepoCache_Lock(t *testing.T) {
+	cache, err := NewRepoCache(t.TempDir(), 1024)
+	require.NoError(t, err)
+
+	path, l, err := cache.lock(context.Background(), "github.com/sourcegraph/sourcegraph")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(cache.dir, repoCacheKey("github.com/sourcegraph/sourcegraph")+".git"), path)
+
+	// The lock is exclusive until downgraded.
+	ctx, cancel := context.WithTimeout(context.Background(), 3*filelock.RetryInterval)
+	defer cancel()
+	_, _, err = cache.lock(ctx, "github.com/sourcegraph/sourcegraph")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// Other repositories are not affected.
+	_, other, err := cache.lock(context.Background(), "github.com/sourcegraph/other")
+	require.NoError(t, err)
+	require.NoError(t, other.Unlock())
+
+	// A repository that is read from cannot be removed.
+	require.NoError(t, l.Downgrade(context.Background()))
+	removed, err := cache.remove(repoCacheKey("github.com/sourcegraph/sourcegraph"))
+	require.NoError(t, err)
+	assert.False(t, removed)
+
+	require.NoError(t, l.Unlock())
+	removed, err = cache.remove(repoCacheKey("github.com/sourcegraph/sourcegraph"))
+	require.NoError(t, err)
+	assert.True(t, removed)
+}
+
+func TestRepoCache_Evict(t *testing.T) {
+	cache, err := NewRepoCache(t.TempDir(), 10)
+	require.NoError(t, err)
+
+	now := time.Now()
+	for i, name := range []string{"a", "b", "c"} {
+		key := repoCacheKey(name)
+		require.NoError(t, os.MkdirAll(filepath.Join(cache.repoPath(key), "objects"), os.ModePerm))
+		require.NoError(t, os.WriteFile(filepath.Join(cache.repoPath(key), "objects", "pack"), []byte("12345678"), os.ModePerm))
+
+		lastUsed := now.Add(time.Duration(i-3) * time.Hour)
+		require.NoError(t, os.WriteFile(cache.lockPath(key), nil, os.ModePerm))
+		require.NoError(t, os.Chtimes(cache.lockPath(key), lastUsed, lastUsed))
+	}
+
+	// b is in use by a job.
+	l, err := filelock.Acquire(context.Background(), cache.lockPath(repoCacheKey("b")), false)
+	require.NoError(t, err)
+	defer l.Unlock()
+
+	evicted, err := cache.Evict(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, evicted)
+
+	assert.NoDirExists(t, cache.repoPath(repoCacheKey("a")))
+	assert.DirExists(t, cache.repoPath(repoCacheKey("b")))
+	assert.NoDirExists(t, cache.repoPath(repoCacheKey("c")))
+
+	// The cache fits its maximum size now.
+	evicted, err = cache.Evict(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, evicted)
+}
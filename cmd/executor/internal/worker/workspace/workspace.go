@@ -8,6 +8,10 @@ type CloneOptions struct {
 	EndpointURL    string
 	GitServicePath string
 	ExecutorToken  string
+
+	// RepoCache is the repository cache on the host that clones borrow git objects
+	// from. It is nil if the repository cache is disabled.
+	RepoCache *RepoCache
 }
 
 // Workspace represents a workspace that can be used to execute a job.
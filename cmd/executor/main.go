@@ -10,6 +10,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/config"
 	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/run"
+	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/sandbox"
 	"github.com/sourcegraph/sourcegraph/cmd/executor/internal/util"
 	"github.com/sourcegraph/sourcegraph/internal/env"
 	"github.com/sourcegraph/sourcegraph/internal/hostname"
@@ -19,6 +20,12 @@ import (
 )
 
 func main() {
+	// The second stage of a sandbox is the executor binary itself, run in the new
+	// namespaces of the sandbox. It must not do anything else.
+	if len(os.Args) > 1 && os.Args[1] == sandbox.InitCommand {
+		sandbox.Init()
+	}
+
 	sanitycheck.Pass()
 	cfg := &config.Config{}
 	cfg.Load()
@@ -157,6 +164,13 @@ func main() {
 				},
 				Action: makeActionHandler(run.TestVM),
 			},
+			{
+				Name:            "sandbox",
+				Usage:           "Runs a command in a rootless sandbox. Used internally by the sandbox runtime.",
+				Hidden:          true,
+				SkipFlagParsing: true,
+				Action:          makeActionHandler(run.Sandbox),
+			},
 		},
 	}
 
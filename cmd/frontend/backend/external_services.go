@@ -16,6 +16,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitolite"
@@ -176,6 +177,11 @@ func addRepoToExclude(ctx context.Context, logger log.Logger, externalService *t
 		if !schemaContainsExclusion(c.Exclude, exclusion) {
 			c.Exclude = append(c.Exclude, &schema.ExcludedBitbucketServerRepo{Name: excludableName})
 		}
+	case *schema.GiteaConnection:
+		exclusion := &schema.ExcludedGiteaRepo{Name: excludableName}
+		if !schemaContainsExclusion(c.Exclude, exclusion) {
+			c.Exclude = append(c.Exclude, &schema.ExcludedGiteaRepo{Name: excludableName})
+		}
 	case *schema.GitHubConnection:
 		exclusion := &schema.ExcludedGitHubRepo{Name: excludableName}
 		if !schemaContainsExclusion(c.Exclude, exclusion) {
@@ -226,6 +232,12 @@ func ExcludableRepoName(repository *types.Repo, logger log.Logger) (name string)
 		} else {
 			logger.Error("invalid repo metadata schema", log.String("extSvcType", extsvc.TypeBitbucketServer))
 		}
+	case extsvc.TypeGitea:
+		if repo, ok := repository.Metadata.(*gitea.Repo); ok {
+			name = repo.FullName
+		} else {
+			logger.Error("invalid repo metadata schema", log.String("extSvcType", extsvc.TypeGitea))
+		}
 	case extsvc.TypeGitHub:
 		if repo, ok := repository.Metadata.(*github.Repository); ok {
 			name = repo.NameWithOwner
@@ -96,7 +96,7 @@ func (ws *webhookService) UpdateWebhook(ctx context.Context, id int32, name, cod
 
 func validateCodeHostKindAndSecret(codeHostKind string, secret *string) error {
 	switch codeHostKind {
-	case extsvc.KindGitHub, extsvc.KindGitLab, extsvc.KindBitbucketServer:
+	case extsvc.KindGitHub, extsvc.KindGitLab, extsvc.KindBitbucketServer, extsvc.KindGitea:
 		return nil
 	case extsvc.KindBitbucketCloud, extsvc.KindAzureDevOps:
 		if secret != nil {
@@ -25,6 +25,7 @@ type Services struct {
 	BatchesBitbucketServerWebhook   webhooks.RegistererHandler
 	BatchesBitbucketCloudWebhook    webhooks.RegistererHandler
 	BatchesAzureDevOpsWebhook       webhooks.Registerer
+	BatchesGiteaWebhook             webhooks.Registerer
 	BatchesChangesFileGetHandler    http.Handler
 	BatchesChangesFileExistsHandler http.Handler
 	BatchesChangesFileUploadHandler http.Handler
@@ -34,16 +35,25 @@ type Services struct {
 	ReposGitLabWebhook          webhooks.Registerer
 	ReposBitbucketServerWebhook webhooks.Registerer
 	ReposBitbucketCloudWebhook  webhooks.Registerer
+	ReposGiteaWebhook           webhooks.Registerer
 
 	SCIMHandler http.Handler
 
 	// Handler for exporting code insights data.
 	CodeInsightsDataExportHandler http.Handler
 
+	// Handlers for exposing code insights series as metrics.
+	CodeInsightsMetricsHandler           http.Handler
+	CodeInsightsMetricsQueryRangeHandler http.Handler
+
 	// Handler for exporting search jobs data.
 	SearchJobsDataExportHandler http.Handler
 	SearchJobsLogsHandler       http.Handler
 
+	// Handlers for listing and downloading the artifacts of executor jobs.
+	ExecutorJobArtifactsHandler        http.Handler
+	ExecutorJobArtifactDownloadHandler http.Handler
+
 	// Handler for completions stream.
 	NewChatCompletionsStreamHandler NewChatCompletionsStreamHandler
 
@@ -53,6 +63,12 @@ type Services struct {
 	// Handler for license v2 check.
 	NewDotcomLicenseCheckHandler NewDotcomLicenseCheckHandler
 
+	// Handler for exporting SCIP indexes reconstructed from processed uploads.
+	CodeIntelSCIPExportHandler http.Handler
+
+	// Handler for downloading the dead code report computed by the ranking pipeline.
+	CodeIntelDeadCodeReportHandler http.Handler
+
 	PermissionsGitHubWebhook  webhooks.Registerer
 	NewCodeIntelUploadHandler NewCodeIntelUploadHandler
 	RankingService            RankingService
@@ -97,31 +113,39 @@ type NewDotcomLicenseCheckHandler func() http.Handler
 // DefaultServices creates a new Services value that has default implementations for all services.
 func DefaultServices() Services {
 	return Services{
-		ReposGithubWebhook:              &emptyWebhookHandler{name: "github sync webhook"},
-		ReposGitLabWebhook:              &emptyWebhookHandler{name: "gitlab sync webhook"},
-		ReposBitbucketServerWebhook:     &emptyWebhookHandler{name: "bitbucket server sync webhook"},
-		ReposBitbucketCloudWebhook:      &emptyWebhookHandler{name: "bitbucket cloud sync webhook"},
-		PermissionsGitHubWebhook:        &emptyWebhookHandler{name: "permissions github webhook"},
-		BatchesGitHubWebhook:            &emptyWebhookHandler{name: "batches github webhook"},
-		BatchesGitLabWebhook:            &emptyWebhookHandler{name: "batches gitlab webhook"},
-		BatchesBitbucketServerWebhook:   &emptyWebhookHandler{name: "batches bitbucket server webhook"},
-		BatchesBitbucketCloudWebhook:    &emptyWebhookHandler{name: "batches bitbucket cloud webhook"},
-		BatchesAzureDevOpsWebhook:       &emptyWebhookHandler{name: "batches azure devops webhook"},
-		BatchesChangesFileGetHandler:    makeNotFoundHandler("batches file get handler"),
-		BatchesChangesFileExistsHandler: makeNotFoundHandler("batches file exists handler"),
-		BatchesChangesFileUploadHandler: makeNotFoundHandler("batches file upload handler"),
-		SCIMHandler:                     makeNotFoundHandler("SCIM handler"),
-		NewCodeIntelUploadHandler:       func(_ bool) http.Handler { return makeNotFoundHandler("code intel upload") },
-		RankingService:                  stubRankingService{},
-		NewExecutorProxyHandler:         func() http.Handler { return makeNotFoundHandler("executor proxy") },
-		NewGitHubAppSetupHandler:        func() http.Handler { return makeNotFoundHandler("Sourcegraph GitHub App setup") },
-		NewComputeStreamHandler:         func() http.Handler { return makeNotFoundHandler("compute streaming endpoint") },
-		CodeInsightsDataExportHandler:   makeNotFoundHandler("code insights data export handler"),
-		NewDotcomLicenseCheckHandler:    func() http.Handler { return makeNotFoundHandler("dotcom license check handler") },
-		NewChatCompletionsStreamHandler: func() http.Handler { return makeNotFoundHandler("chat completions streaming endpoint") },
-		NewCodeCompletionsHandler:       func() http.Handler { return makeNotFoundHandler("code completions streaming endpoint") },
-		SearchJobsDataExportHandler:     makeNotFoundHandler("search jobs data export handler"),
-		SearchJobsLogsHandler:           makeNotFoundHandler("search jobs logs handler"),
+		ReposGithubWebhook:                   &emptyWebhookHandler{name: "github sync webhook"},
+		ReposGitLabWebhook:                   &emptyWebhookHandler{name: "gitlab sync webhook"},
+		ReposBitbucketServerWebhook:          &emptyWebhookHandler{name: "bitbucket server sync webhook"},
+		ReposBitbucketCloudWebhook:           &emptyWebhookHandler{name: "bitbucket cloud sync webhook"},
+		ReposGiteaWebhook:                    &emptyWebhookHandler{name: "gitea sync webhook"},
+		PermissionsGitHubWebhook:             &emptyWebhookHandler{name: "permissions github webhook"},
+		BatchesGitHubWebhook:                 &emptyWebhookHandler{name: "batches github webhook"},
+		BatchesGitLabWebhook:                 &emptyWebhookHandler{name: "batches gitlab webhook"},
+		BatchesBitbucketServerWebhook:        &emptyWebhookHandler{name: "batches bitbucket server webhook"},
+		BatchesBitbucketCloudWebhook:         &emptyWebhookHandler{name: "batches bitbucket cloud webhook"},
+		BatchesAzureDevOpsWebhook:            &emptyWebhookHandler{name: "batches azure devops webhook"},
+		BatchesGiteaWebhook:                  &emptyWebhookHandler{name: "batches gitea webhook"},
+		BatchesChangesFileGetHandler:         makeNotFoundHandler("batches file get handler"),
+		BatchesChangesFileExistsHandler:      makeNotFoundHandler("batches file exists handler"),
+		BatchesChangesFileUploadHandler:      makeNotFoundHandler("batches file upload handler"),
+		SCIMHandler:                          makeNotFoundHandler("SCIM handler"),
+		NewCodeIntelUploadHandler:            func(_ bool) http.Handler { return makeNotFoundHandler("code intel upload") },
+		CodeIntelSCIPExportHandler:           makeNotFoundHandler("code intel SCIP export"),
+		CodeIntelDeadCodeReportHandler:       makeNotFoundHandler("code intel dead code report"),
+		RankingService:                       stubRankingService{},
+		NewExecutorProxyHandler:              func() http.Handler { return makeNotFoundHandler("executor proxy") },
+		NewGitHubAppSetupHandler:             func() http.Handler { return makeNotFoundHandler("Sourcegraph GitHub App setup") },
+		NewComputeStreamHandler:              func() http.Handler { return makeNotFoundHandler("compute streaming endpoint") },
+		CodeInsightsDataExportHandler:        makeNotFoundHandler("code insights data export handler"),
+		CodeInsightsMetricsHandler:           makeNotFoundHandler("code insights metrics handler"),
+		CodeInsightsMetricsQueryRangeHandler: makeNotFoundHandler("code insights metrics query range handler"),
+		NewDotcomLicenseCheckHandler:         func() http.Handler { return makeNotFoundHandler("dotcom license check handler") },
+		NewChatCompletionsStreamHandler:      func() http.Handler { return makeNotFoundHandler("chat completions streaming endpoint") },
+		NewCodeCompletionsHandler:            func() http.Handler { return makeNotFoundHandler("code completions streaming endpoint") },
+		SearchJobsDataExportHandler:          makeNotFoundHandler("search jobs data export handler"),
+		SearchJobsLogsHandler:                makeNotFoundHandler("search jobs logs handler"),
+		ExecutorJobArtifactsHandler:          makeNotFoundHandler("executor job artifacts handler"),
+		ExecutorJobArtifactDownloadHandler:   makeNotFoundHandler("executor job artifact download handler"),
 	}
 }
 
@@ -50,6 +50,16 @@ type MoveBatchChangeArgs struct {
 	NewNamespace *graphql.ID
 }
 
+type SetBatchChangeAutoRebaseArgs struct {
+	BatchChange graphql.ID
+	Enabled     bool
+}
+
+type SetBatchChangeRolloutPausedArgs struct {
+	BatchChange graphql.ID
+	Paused      bool
+}
+
 type DeleteBatchChangeArgs struct {
 	BatchChange graphql.ID
 }
@@ -108,6 +118,31 @@ type ReplaceBatchSpecInputArgs struct {
 
 type UpsertBatchSpecInputArgs = CreateBatchSpecFromRawArgs
 
+type CreateBatchSpecTemplateArgs struct {
+	Template  string
+	Namespace *graphql.ID
+}
+
+type DeleteBatchSpecTemplateArgs struct {
+	BatchSpecTemplate graphql.ID
+}
+
+type BatchSpecTemplateInput struct {
+	Name  string
+	Value string
+}
+
+type CreateBatchSpecFromTemplateArgs struct {
+	BatchSpecTemplate graphql.ID
+	Version           *int32
+	Inputs            *[]BatchSpecTemplateInput
+	AllowIgnored      bool
+	AllowUnsupported  bool
+	NoCache           bool
+	Namespace         graphql.ID
+	BatchChange       graphql.ID
+}
+
 type DeleteBatchSpecArgs struct {
 	BatchSpec graphql.ID
 }
@@ -266,10 +301,15 @@ type BatchChangesResolver interface {
 	RetryBatchSpecExecution(ctx context.Context, args *RetryBatchSpecExecutionArgs) (BatchSpecResolver, error)
 	EnqueueBatchSpecWorkspaceExecution(ctx context.Context, args *EnqueueBatchSpecWorkspaceExecutionArgs) (*EmptyResponse, error)
 	ToggleBatchSpecAutoApply(ctx context.Context, args *ToggleBatchSpecAutoApplyArgs) (BatchSpecResolver, error)
+	CreateBatchSpecTemplate(ctx context.Context, args *CreateBatchSpecTemplateArgs) (BatchSpecTemplateResolver, error)
+	DeleteBatchSpecTemplate(ctx context.Context, args *DeleteBatchSpecTemplateArgs) (*EmptyResponse, error)
+	CreateBatchSpecFromTemplate(ctx context.Context, args *CreateBatchSpecFromTemplateArgs) (BatchSpecResolver, error)
 
 	ApplyBatchChange(ctx context.Context, args *ApplyBatchChangeArgs) (BatchChangeResolver, error)
 	CloseBatchChange(ctx context.Context, args *CloseBatchChangeArgs) (BatchChangeResolver, error)
 	MoveBatchChange(ctx context.Context, args *MoveBatchChangeArgs) (BatchChangeResolver, error)
+	SetBatchChangeAutoRebase(ctx context.Context, args *SetBatchChangeAutoRebaseArgs) (BatchChangeResolver, error)
+	SetBatchChangeRolloutPaused(ctx context.Context, args *SetBatchChangeRolloutPausedArgs) (BatchChangeResolver, error)
 	DeleteBatchChange(ctx context.Context, args *DeleteBatchChangeArgs) (*EmptyResponse, error)
 	CreateBatchChangesCredential(ctx context.Context, args *CreateBatchChangesCredentialArgs) (BatchChangesCredentialResolver, error)
 	DeleteBatchChangesCredential(ctx context.Context, args *DeleteBatchChangesCredentialArgs) (*EmptyResponse, error)
@@ -296,6 +336,7 @@ type BatchChangesResolver interface {
 	RepoDiffStat(ctx context.Context, repo *graphql.ID) (*DiffStat, error)
 
 	BatchSpecs(cx context.Context, args *ListBatchSpecArgs) (BatchSpecConnectionResolver, error)
+	BatchSpecTemplates(ctx context.Context, args *ListBatchSpecTemplatesArgs) (BatchSpecTemplateConnectionResolver, error)
 	AvailableBulkOperations(ctx context.Context, args *AvailableBulkOperationsArgs) ([]string, error)
 
 	ResolveWorkspacesForBatchSpec(ctx context.Context, args *ResolveWorkspacesForBatchSpecArgs) ([]ResolvedBatchSpecWorkspaceResolver, error)
@@ -375,6 +416,45 @@ type BatchSpecResolver interface {
 	Source() string
 
 	Files(ctx context.Context, args *ListBatchSpecWorkspaceFilesArgs) (BatchSpecWorkspaceFileConnectionResolver, error)
+
+	TemplateVersion(ctx context.Context) (BatchSpecTemplateVersionResolver, error)
+}
+
+type BatchSpecTemplateResolver interface {
+	ID() graphql.ID
+	Name() string
+	Description() string
+	Namespace(ctx context.Context) (*NamespaceResolver, error)
+	Creator(ctx context.Context) (*UserResolver, error)
+	LatestVersion(ctx context.Context) (BatchSpecTemplateVersionResolver, error)
+	Versions(ctx context.Context) ([]BatchSpecTemplateVersionResolver, error)
+	ViewerCanAdminister(ctx context.Context) (bool, error)
+	CreatedAt() gqlutil.DateTime
+	UpdatedAt() gqlutil.DateTime
+}
+
+type BatchSpecTemplateVersionResolver interface {
+	Template(ctx context.Context) (BatchSpecTemplateResolver, error)
+	Version() int32
+	OriginalInput() string
+	Parameters() ([]BatchSpecTemplateParameterResolver, error)
+	Creator(ctx context.Context) (*UserResolver, error)
+	CreatedAt() gqlutil.DateTime
+}
+
+type BatchSpecTemplateParameterResolver interface {
+	Name() string
+	Type() string
+	Description() *string
+	Required() bool
+	DefaultValue() *string
+	Values() *[]string
+}
+
+type BatchSpecTemplateConnectionResolver interface {
+	Nodes(ctx context.Context) ([]BatchSpecTemplateResolver, error)
+	TotalCount(ctx context.Context) (int32, error)
+	PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error)
 }
 
 type BatchChangeDescriptionResolver interface {
@@ -604,6 +684,13 @@ type ListChangesetsArgs struct {
 	Repo         *graphql.ID
 }
 
+type ListBatchSpecTemplatesArgs struct {
+	First      int32
+	After      *string
+	Namespace  *graphql.ID
+	OnlyGlobal bool
+}
+
 type ListBatchSpecArgs struct {
 	First                       int32
 	After                       *string
@@ -665,12 +752,21 @@ type BatchChangeResolver interface {
 	Changesets(ctx context.Context, args *ListChangesetsArgs) (ChangesetsConnectionResolver, error)
 	ChangesetCountsOverTime(ctx context.Context, args *ChangesetCountsArgs) ([]ChangesetCountsResolver, error)
 	ClosedAt() *gqlutil.DateTime
+	AutoRebaseEnabled() bool
+	Rollout(ctx context.Context) (BatchChangeRolloutResolver, error)
 	DiffStat(ctx context.Context) (*DiffStat, error)
 	CurrentSpec(ctx context.Context) (BatchSpecResolver, error)
 	BulkOperations(ctx context.Context, args *ListBatchChangeBulkOperationArgs) (BulkOperationConnectionResolver, error)
 	BatchSpecs(ctx context.Context, args *ListBatchSpecArgs) (BatchSpecConnectionResolver, error)
 }
 
+type BatchChangeRolloutResolver interface {
+	CurrentWave() int32
+	Waves() int32
+	Paused() bool
+	PauseReason() *string
+}
+
 type BatchChangesConnectionResolver interface {
 	Nodes(ctx context.Context) ([]BatchChangeResolver, error)
 	TotalCount(ctx context.Context) (int32, error)
@@ -817,6 +913,17 @@ type ExternalChangesetResolver interface {
 	ScheduleEstimateAt(ctx context.Context) (*gqlutil.DateTime, error)
 
 	CurrentSpec(ctx context.Context) (VisibleChangesetSpecResolver, error)
+
+	AutoRebases(ctx context.Context) ([]ChangesetAutoRebaseResolver, error)
+}
+
+type ChangesetAutoRebaseResolver interface {
+	// State returns a value of type btypes.ChangesetRebaseState.
+	State() string
+	BaseRev() *string
+	FailureMessage() *string
+	CreatedAt() gqlutil.DateTime
+	UpdatedAt() gqlutil.DateTime
 }
 
 // Only GitHubApps are supported for commit signing for now.
@@ -41,6 +41,12 @@ func (e *ExecutorResolver) QueueName() *string {
 func (e *ExecutorResolver) QueueNames() *[]string {
 	return &e.executor.QueueNames
 }
+func (e *ExecutorResolver) Labels() []string {
+	if e.executor.Labels == nil {
+		return []string{}
+	}
+	return e.executor.Labels
+}
 func (e *ExecutorResolver) Active() bool {
 	// TODO: Read the value of the executor worker heartbeat interval in here.
 	heartbeatInterval := 5 * time.Second
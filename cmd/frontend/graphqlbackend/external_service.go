@@ -64,6 +64,7 @@ var availabilityCheck = map[string]bool{
 	extsvc.KindBitbucketServer: true,
 	extsvc.KindBitbucketCloud:  true,
 	extsvc.KindAzureDevOps:     true,
+	extsvc.KindGitea:           true,
 	extsvc.KindPerforce:        true,
 }
 
@@ -26,6 +26,8 @@ type InsightsResolver interface {
 	ValidateScopedInsightQuery(ctx context.Context, args ValidateScopedInsightQueryArgs) (ScopedInsightQueryPayloadResolver, error)
 	PreviewRepositoriesFromQuery(ctx context.Context, args PreviewRepositoriesFromQueryArgs) (RepositoryPreviewPayloadResolver, error)
 
+	InsightSeriesAlerts(ctx context.Context, args InsightSeriesAlertsArgs) ([]InsightSeriesAlertResolver, error)
+
 	// Mutations
 	CreateInsightsDashboard(ctx context.Context, args *CreateInsightsDashboardArgs) (InsightsDashboardPayloadResolver, error)
 	UpdateInsightsDashboard(ctx context.Context, args *UpdateInsightsDashboardArgs) (InsightsDashboardPayloadResolver, error)
@@ -41,6 +43,9 @@ type InsightsResolver interface {
 	DeleteInsightView(ctx context.Context, args *DeleteInsightViewArgs) (*EmptyResponse, error)
 	SaveInsightAsNewView(ctx context.Context, args SaveInsightAsNewViewArgs) (InsightViewPayloadResolver, error)
 
+	CreateInsightSeriesAlert(ctx context.Context, args *CreateInsightSeriesAlertArgs) (InsightSeriesAlertResolver, error)
+	DeleteInsightSeriesAlert(ctx context.Context, args *DeleteInsightSeriesAlertArgs) (*EmptyResponse, error)
+
 	// Admin Management
 	InsightSeriesQueryStatus(ctx context.Context) ([]InsightSeriesQueryStatusResolver, error)
 	InsightViewDebug(ctx context.Context, args InsightViewDebugArgs) (InsightViewDebugResolver, error)
@@ -120,6 +125,26 @@ type InsightSeriesResolver interface {
 	Label() string
 	Points(ctx context.Context, args *InsightsPointsArgs) ([]InsightsDataPointResolver, error)
 	Status(ctx context.Context) (InsightStatusResolver, error)
+	Forecast(ctx context.Context, args *InsightsForecastArgs) (InsightSeriesForecastResolver, error)
+}
+
+type InsightsForecastArgs struct {
+	Method    string
+	Intervals int32
+	Target    *float64
+}
+
+type InsightSeriesForecastResolver interface {
+	Method() string
+	Points() []InsightForecastPointResolver
+	TargetDateTime() *gqlutil.DateTime
+}
+
+type InsightForecastPointResolver interface {
+	DateTime() gqlutil.DateTime
+	Value() float64
+	LowerBound() float64
+	UpperBound() float64
 }
 
 type InsightResolver interface {
@@ -237,6 +262,7 @@ type SearchInsightDataSeriesDefinitionResolver interface {
 	RepositoryDefinition(ctx context.Context) (InsightRepositoryDefinition, error)
 	TimeScope(ctx context.Context) (InsightTimeScope, error)
 	GeneratedFromCaptureGroups() (bool, error)
+	GeneratedFromScript() (bool, error)
 	IsCalculated() (bool, error)
 	GroupBy() (*string, error)
 }
@@ -428,6 +454,7 @@ type LineChartSearchInsightDataSeriesInput struct {
 	RepositoryScope            *RepositoryScopeInput
 	Options                    LineChartDataSeriesOptionsInput
 	GeneratedFromCaptureGroups *bool
+	GeneratedFromScript        *bool
 	GroupBy                    *string
 }
 
@@ -587,3 +614,62 @@ type AdminBackfillQueueArgs struct {
 	States     *[]string
 	TextSearch *string
 }
+
+type InsightSeriesAlertsArgs struct {
+	InsightViewId graphql.ID
+	SeriesId      string
+}
+
+type CreateInsightSeriesAlertArgs struct {
+	Input CreateInsightSeriesAlertInput
+}
+
+type CreateInsightSeriesAlertInput struct {
+	InsightViewId graphql.ID
+	SeriesId      string
+	Condition     string
+	Threshold     float64
+	Lookback      *int32
+	Description   *string
+	Actions       []InsightSeriesAlertActionInput
+}
+
+type InsightSeriesAlertActionInput struct {
+	Type string
+	Url  *string
+}
+
+type DeleteInsightSeriesAlertArgs struct {
+	Id graphql.ID
+}
+
+type InsightSeriesAlertHistoryArgs struct {
+	First *int32
+}
+
+type InsightSeriesAlertResolver interface {
+	ID() graphql.ID
+	SeriesId() string
+	Condition() string
+	Threshold() float64
+	Lookback() int32
+	Description() string
+	Enabled() bool
+	Actions() []InsightSeriesAlertActionResolver
+	CreatedAt() gqlutil.DateTime
+	History(ctx context.Context, args *InsightSeriesAlertHistoryArgs) ([]InsightSeriesAlertEventResolver, error)
+}
+
+type InsightSeriesAlertActionResolver interface {
+	Type() string
+	Url() *string
+}
+
+type InsightSeriesAlertEventResolver interface {
+	Time() gqlutil.DateTime
+	Capture() *string
+	Value() float64
+	Baseline() *float64
+	Message() string
+	CreatedAt() gqlutil.DateTime
+}
@@ -256,6 +256,11 @@ func (r *NodeResolver) ToOutOfBandMigration() (*outOfBandMigrationResolver, bool
 	return n, ok
 }
 
+func (r *NodeResolver) ToBatchSpecTemplate() (BatchSpecTemplateResolver, bool) {
+	n, ok := r.Node.(BatchSpecTemplateResolver)
+	return n, ok
+}
+
 func (r *NodeResolver) ToBulkOperation() (BulkOperationResolver, bool) {
 	n, ok := r.Node.(BulkOperationResolver)
 	return n, ok
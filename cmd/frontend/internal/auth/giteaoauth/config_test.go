@@ -0,0 +1,203 @@
+package giteaoauth
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/oauth2"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/auth/oauth"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestParseConfig(t *testing.T) {
+	db := dbmocks.NewMockDB()
+
+	type args struct {
+		cfg *conf.Unified
+	}
+	tests := []struct {
+		name          string
+		args          args
+		wantProviders []Provider
+		wantProblems  []string
+	}{
+		{
+			name:          "No configs",
+			args:          args{cfg: &conf.Unified{}},
+			wantProviders: []Provider(nil),
+		},
+		{
+			name: "1 Gitea config",
+			args: args{cfg: &conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+				AuthProviders: []schema.AuthProviders{{
+					Gitea: &schema.GiteaAuthProvider{
+						ClientID:     "myclientid",
+						ClientSecret: "myclientsecret",
+						DisplayName:  "Gitea",
+						Type:         extsvc.TypeGitea,
+						Url:          "https://gitea.example.com",
+					},
+				}},
+			}}},
+			wantProviders: []Provider{
+				{
+					GiteaAuthProvider: &schema.GiteaAuthProvider{
+						ClientID:     "myclientid",
+						ClientSecret: "myclientsecret",
+						DisplayName:  "Gitea",
+						Type:         extsvc.TypeGitea,
+						Url:          "https://gitea.example.com",
+					},
+					Provider: provider("https://gitea.example.com/", oauth2.Config{
+						ClientID:     "myclientid",
+						ClientSecret: "myclientsecret",
+						Endpoint: oauth2.Endpoint{
+							AuthURL:  "https://gitea.example.com/login/oauth/authorize",
+							TokenURL: "https://gitea.example.com/login/oauth/access_token",
+						},
+					}),
+				},
+			},
+		},
+		{
+			name: "1 Forgejo config served from a subpath",
+			args: args{cfg: &conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+				AuthProviders: []schema.AuthProviders{{
+					Gitea: &schema.GiteaAuthProvider{
+						ClientID:     "myclientid",
+						ClientSecret: "myclientsecret",
+						Type:         extsvc.TypeGitea,
+						Url:          "https://example.com/forgejo",
+					},
+				}},
+			}}},
+			wantProviders: []Provider{
+				{
+					GiteaAuthProvider: &schema.GiteaAuthProvider{
+						ClientID:     "myclientid",
+						ClientSecret: "myclientsecret",
+						Type:         extsvc.TypeGitea,
+						Url:          "https://example.com/forgejo",
+					},
+					Provider: provider("https://example.com/forgejo/", oauth2.Config{
+						ClientID:     "myclientid",
+						ClientSecret: "myclientsecret",
+						Endpoint: oauth2.Endpoint{
+							AuthURL:  "https://example.com/forgejo/login/oauth/authorize",
+							TokenURL: "https://example.com/forgejo/login/oauth/access_token",
+						},
+					}),
+				},
+			},
+		},
+		{
+			name: "2 Gitea configs with the same Url and client IDs",
+			args: args{cfg: &conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+				AuthProviders: []schema.AuthProviders{{
+					Gitea: &schema.GiteaAuthProvider{
+						ClientID:     "myclientid",
+						ClientSecret: "myclientsecret",
+						DisplayName:  "Gitea",
+						Type:         extsvc.TypeGitea,
+						Url:          "https://gitea.example.com",
+					},
+				}, {
+					Gitea: &schema.GiteaAuthProvider{
+						ClientID:     "myclientid",
+						ClientSecret: "myclientsecret2",
+						DisplayName:  "Gitea Duplicate",
+						Type:         extsvc.TypeGitea,
+						Url:          "https://gitea.example.com",
+					},
+				}},
+			}}},
+			wantProviders: []Provider{
+				{
+					GiteaAuthProvider: &schema.GiteaAuthProvider{
+						ClientID:     "myclientid",
+						ClientSecret: "myclientsecret",
+						DisplayName:  "Gitea",
+						Type:         extsvc.TypeGitea,
+						Url:          "https://gitea.example.com",
+					},
+					Provider: provider("https://gitea.example.com/", oauth2.Config{
+						ClientID:     "myclientid",
+						ClientSecret: "myclientsecret",
+						Endpoint: oauth2.Endpoint{
+							AuthURL:  "https://gitea.example.com/login/oauth/authorize",
+							TokenURL: "https://gitea.example.com/login/oauth/access_token",
+						},
+					}),
+				},
+			},
+			wantProblems: []string{
+				`Cannot have more than one Gitea auth provider with url "https://gitea.example.com/" and client ID "myclientid", only the first one will be used`,
+			},
+		},
+		{
+			name: "Invalid Url",
+			args: args{cfg: &conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+				AuthProviders: []schema.AuthProviders{{
+					Gitea: &schema.GiteaAuthProvider{
+						ClientID:     "myclientid",
+						ClientSecret: "myclientsecret",
+						Type:         extsvc.TypeGitea,
+						Url:          "http://[::1]:namedport",
+					},
+				}},
+			}}},
+			wantProviders: []Provider(nil),
+			wantProblems: []string{
+				`Could not parse Gitea URL "http://[::1]:namedport". You will not be able to login via Gitea.`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotProviders, gotProblems := parseConfig(logtest.Scoped(t), tt.args.cfg, db)
+			gotConfigs := make([]oauth2.Config, len(gotProviders))
+			for k, p := range gotProviders {
+				if p, ok := p.Provider.(*oauth.Provider); ok {
+					p.Login, p.Callback = nil, nil
+					gotConfigs[k] = p.OAuth2Config()
+					p.OAuth2Config = nil
+					p.ProviderOp.Login, p.ProviderOp.Callback = nil, nil
+				}
+			}
+			wantConfigs := make([]oauth2.Config, len(tt.wantProviders))
+			for k, p := range tt.wantProviders {
+				if q, ok := p.Provider.(*oauth.Provider); ok {
+					q.SourceConfig = schema.AuthProviders{Gitea: p.GiteaAuthProvider}
+					wantConfigs[k] = q.OAuth2Config()
+					q.OAuth2Config = nil
+				}
+			}
+			if diff := cmp.Diff(tt.wantProviders, gotProviders); diff != "" {
+				t.Errorf("providers: %s", diff)
+			}
+			if diff := cmp.Diff(tt.wantProblems, gotProblems.Messages()); diff != "" {
+				t.Errorf("problems: %s", diff)
+			}
+			if diff := cmp.Diff(wantConfigs, gotConfigs); diff != "" {
+				t.Errorf("configs: %s", diff)
+			}
+		})
+	}
+}
+
+func provider(serviceID string, oauth2Config oauth2.Config) *oauth.Provider {
+	op := oauth.ProviderOp{
+		AuthPrefix:   authPrefix,
+		OAuth2Config: func() oauth2.Config { return oauth2Config },
+		StateConfig:  oauth.GetStateConfig(stateCookie),
+		ServiceID:    serviceID,
+		ServiceType:  extsvc.TypeGitea,
+	}
+	return &oauth.Provider{ProviderOp: op}
+}
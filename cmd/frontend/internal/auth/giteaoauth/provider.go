@@ -0,0 +1,95 @@
+package giteaoauth
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/dghubble/gologin"
+	goauth2 "github.com/dghubble/gologin/oauth2"
+	"golang.org/x/oauth2"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/auth/oauth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const (
+	sessionKey  = "giteaoauth@0"
+	stateCookie = "gitea-state-cookie"
+)
+
+func parseProvider(logger log.Logger, p *schema.GiteaAuthProvider, db database.DB, sourceCfg schema.AuthProviders) (provider *oauth.Provider, messages []string) {
+	parsedURL, err := url.Parse(p.Url)
+	if err != nil {
+		messages = append(messages, fmt.Sprintf("Could not parse Gitea URL %q. You will not be able to login via Gitea.", p.Url))
+		return nil, messages
+	}
+	parsedURL = extsvc.NormalizeBaseURL(parsedURL)
+
+	allowOrgs := make(map[string]struct{}, len(p.AllowOrgs))
+	for _, org := range p.AllowOrgs {
+		allowOrgs[org] = struct{}{}
+	}
+
+	return oauth.NewProvider(oauth.ProviderOp{
+		AuthPrefix: authPrefix,
+		OAuth2Config: func() oauth2.Config {
+			return oauth2.Config{
+				ClientID:     p.ClientID,
+				ClientSecret: p.ClientSecret,
+				Endpoint: oauth2.Endpoint{
+					AuthURL:  parsedURL.ResolveReference(&url.URL{Path: "login/oauth/authorize"}).String(),
+					TokenURL: parsedURL.ResolveReference(&url.URL{Path: "login/oauth/access_token"}).String(),
+				},
+			}
+		},
+		SourceConfig: sourceCfg,
+		StateConfig:  oauth.GetStateConfig(stateCookie),
+		ServiceID:    parsedURL.String(),
+		ServiceType:  extsvc.TypeGitea,
+		Login: func(oauth2Cfg oauth2.Config) http.Handler {
+			return goauth2.LoginHandler(&oauth2Cfg, nil)
+		},
+		Callback: func(oauth2Cfg oauth2.Config) http.Handler {
+			return goauth2.CallbackHandler(
+				&oauth2Cfg,
+				oauth.SessionIssuer(logger, db, &sessionIssuerHelper{
+					baseURL:     parsedURL,
+					db:          db,
+					clientID:    p.ClientID,
+					allowOrgs:   allowOrgs,
+					allowSignup: p.AllowSignup,
+				}, sessionKey),
+				http.HandlerFunc(failureHandler),
+			)
+		},
+	}), messages
+}
+
+func failureHandler(w http.ResponseWriter, r *http.Request) {
+	// As a special case we want to handle `access_denied` errors by redirecting
+	// back. This case arises when the user decides not to proceed by clicking `cancel`.
+	if err := r.URL.Query().Get("error"); err != "access_denied" {
+		// Fall back to default failure handler
+		gologin.DefaultFailureHandler.ServeHTTP(w, r)
+		return
+	}
+
+	ctx := r.Context()
+	encodedState, err := goauth2.StateFromContext(ctx)
+	if err != nil {
+		http.Error(w, "Authentication failed. Try signing in again (and clearing cookies for the current site). The error was: could not get OAuth state from context.", http.StatusInternalServerError)
+		return
+	}
+	state, err := oauth.DecodeState(encodedState)
+	if err != nil {
+		http.Error(w, "Authentication failed. Try signing in again (and clearing cookies for the current site). The error was: could not get decode OAuth state.", http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, auth.SafeRedirectURL(state.Redirect), http.StatusFound)
+}
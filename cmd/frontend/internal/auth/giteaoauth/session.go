@@ -0,0 +1,212 @@
+package giteaoauth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"golang.org/x/oauth2"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/hubspot"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/hubspot/hubspotutil"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/auth/oauth"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/auth/providers"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	esauth "github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+type sessionIssuerHelper struct {
+	baseURL     *url.URL
+	clientID    string
+	db          database.DB
+	allowOrgs   map[string]struct{}
+	allowSignup *bool
+	client      gitea.Client
+}
+
+func (s *sessionIssuerHelper) AuthSucceededEventName() database.SecurityEventName {
+	return database.SecurityEventGiteaAuthSucceeded
+}
+
+func (s *sessionIssuerHelper) AuthFailedEventName() database.SecurityEventName {
+	return database.SecurityEventGiteaAuthFailed
+}
+
+func (s *sessionIssuerHelper) GetOrCreateUser(ctx context.Context, token *oauth2.Token, anonymousUserID, firstSourceURL, lastSourceURL string) (actr *actor.Actor, safeErrMsg string, err error) {
+	client := s.client
+	if client == nil {
+		conf := &schema.GiteaConnection{
+			Url: s.baseURL.String(),
+		}
+		client, err = gitea.NewClient(s.baseURL.String(), conf, nil)
+		if err != nil {
+			return nil, "Could not initialize Gitea client", err
+		}
+	}
+
+	client, err = client.WithAuthenticator(&esauth.OAuthBearerToken{Token: token.AccessToken})
+	if err != nil {
+		return nil, "Could not initialize Gitea client", err
+	}
+
+	user, err := client.CurrentUser(ctx)
+	if err != nil {
+		return nil, "Could not read Gitea user from callback request.", errors.Wrap(err, "could not read user from gitea")
+	}
+
+	if allow, err := s.verifyAllowOrgs(ctx, client); err != nil {
+		return nil, "Error in verifying authorized user organizations.", err
+	} else if !allow {
+		msg := "User does not belong to any org from the allowed list of organizations. Please contact your site admin."
+		return nil, msg, errors.Newf("%s Must be in one of %v", msg, s.allowOrgs)
+	}
+
+	var data extsvc.AccountData
+	if err := gitea.SetExternalAccountData(&data, user, token); err != nil {
+		return nil, "", err
+	}
+
+	emails, err := client.ListCurrentUserEmails(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// allowSignup is true by default in the config schema.
+	signupAllowed := s.allowSignup == nil || *s.allowSignup
+
+	attempts, err := buildUserFetchAttempts(emails, signupAllowed)
+	if err != nil {
+		return nil, "Could not find verified email address for Gitea user.", err
+	}
+
+	var (
+		firstSafeErrMsg string
+		firstErr        error
+	)
+
+	for i, attempt := range attempts {
+		userID, safeErrMsg, err := auth.GetAndSaveUser(ctx, s.db, auth.GetAndSaveUserOp{
+			UserProps: database.NewUser{
+				Username:        user.Login,
+				Email:           attempt.email,
+				EmailIsVerified: true,
+				DisplayName:     user.FullName,
+				AvatarURL:       user.AvatarURL,
+			},
+			ExternalAccount: extsvc.AccountSpec{
+				ServiceType: extsvc.TypeGitea,
+				ServiceID:   s.baseURL.String(),
+				ClientID:    s.clientID,
+				AccountID:   strconv.FormatInt(user.ID, 10),
+			},
+			ExternalAccountData: data,
+			CreateIfNotExist:    attempt.createIfNotExist,
+		})
+		if err == nil {
+			go hubspotutil.SyncUser(attempt.email, hubspotutil.SignupEventID, &hubspot.ContactProperties{
+				AnonymousUserID: anonymousUserID,
+				FirstSourceURL:  firstSourceURL,
+				LastSourceURL:   lastSourceURL,
+			})
+			return actor.FromUser(userID), "", nil
+		}
+		if i == 0 {
+			firstSafeErrMsg, firstErr = safeErrMsg, err
+		}
+	}
+
+	// On failure, return the first error
+	verifiedEmails := make([]string, 0, len(attempts))
+	for _, attempt := range attempts {
+		verifiedEmails = append(verifiedEmails, attempt.email)
+	}
+	return nil, fmt.Sprintf("No Sourcegraph user exists matching any of the verified emails: %s.\n\nFirst error was: %s", strings.Join(verifiedEmails, ", "), firstSafeErrMsg), firstErr
+}
+
+type attempt struct {
+	email            string
+	createIfNotExist bool
+}
+
+func buildUserFetchAttempts(emails []*gitea.Email, allowSignup bool) ([]attempt, error) {
+	attempts := []attempt{}
+	for _, email := range emails {
+		if !email.Verified {
+			continue
+		}
+		a := attempt{email: email.Email}
+		// Try the primary address before any others.
+		if email.Primary {
+			attempts = append([]attempt{a}, attempts...)
+		} else {
+			attempts = append(attempts, a)
+		}
+	}
+	if len(attempts) == 0 {
+		return nil, errors.New("no verified email")
+	}
+	// If allowSignup is true, we will create an account using the first verified
+	// email address from Gitea, which is their primary address if it is
+	// verified. Note that the order of attempts is important. If we manage to
+	// connect with an existing account we return early and don't attempt to
+	// create a new account.
+	if allowSignup {
+		attempts = append(attempts, attempt{
+			email:            attempts[0].email,
+			createIfNotExist: true,
+		})
+	}
+
+	return attempts, nil
+}
+
+// verifyAllowOrgs returns true if the user is a member of at least one of the
+// allowed organizations, or if no organizations are configured.
+func (s *sessionIssuerHelper) verifyAllowOrgs(ctx context.Context, client gitea.Client) (bool, error) {
+	if len(s.allowOrgs) == 0 {
+		return true, nil
+	}
+
+	for page, hasNextPage := 1, true; hasNextPage; page++ {
+		var orgs []*gitea.Organization
+		var err error
+		orgs, hasNextPage, err = client.ListCurrentUserOrgs(ctx, page)
+		if err != nil {
+			return false, errors.Wrap(err, "failed to list organizations of user")
+		}
+
+		for _, org := range orgs {
+			if _, ok := s.allowOrgs[org.Name]; ok {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+func (s *sessionIssuerHelper) DeleteStateCookie(w http.ResponseWriter) {
+	stateConfig := oauth.GetStateConfig(stateCookie)
+	stateConfig.MaxAge = -1
+	http.SetCookie(w, oauth.NewCookie(stateConfig, ""))
+}
+
+func (s *sessionIssuerHelper) SessionData(token *oauth2.Token) oauth.SessionData {
+	return oauth.SessionData{
+		ID: providers.ConfigID{
+			ID:   s.baseURL.String(),
+			Type: extsvc.TypeGitea,
+		},
+		AccessToken: token.AccessToken,
+		TokenType:   token.Type(),
+	}
+}
@@ -0,0 +1,279 @@
+package giteaoauth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/oauth2"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+type testServerData struct {
+	user   *gitea.User
+	emails []*gitea.Email
+	orgs   []*gitea.Organization
+}
+
+func createTestServer(t *testing.T, data *testServerData) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var resp any
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/user"):
+			if data.user == nil {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			resp = data.user
+		case strings.HasSuffix(r.URL.Path, "/user/emails"):
+			resp = data.emails
+		case strings.HasSuffix(r.URL.Path, "/user/orgs"):
+			resp = data.orgs
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestSessionIssuerHelper_GetOrCreateUser(t *testing.T) {
+	ratelimit.SetupForTest(t)
+
+	clientID := "client-id"
+
+	// authSaveableUsers that will be accepted by auth.GetAndSaveUser
+	authSaveableUsers := map[string]int32{
+		"alice": 1,
+	}
+
+	alice := &gitea.User{ID: 1234, Login: "alice", FullName: "Alice"}
+
+	cases := []struct {
+		description   string
+		data          testServerData
+		allowOrgs     []string
+		allowSignup   *bool
+		expActor      *actor.Actor
+		expErr        bool
+		expAuthUserOp *auth.GetAndSaveUserOp
+	}{
+		{
+			description: "user, verified email -> session created",
+			data: testServerData{
+				user:   alice,
+				emails: []*gitea.Email{{Email: "alice@example.com", Verified: true, Primary: true}},
+			},
+			allowSignup: pointers.Ptr(false),
+			expActor:    &actor.Actor{UID: 1},
+			expAuthUserOp: &auth.GetAndSaveUserOp{
+				UserProps:       u("alice", "alice@example.com", "Alice"),
+				ExternalAccount: acct(clientID, "1234"),
+			},
+		},
+		{
+			description: "user, primary email not verified but another is -> session created",
+			data: testServerData{
+				user: alice,
+				emails: []*gitea.Email{
+					{Email: "alice@example1.com", Primary: true},
+					{Email: "alice@example2.com", Verified: true},
+				},
+			},
+			allowSignup: pointers.Ptr(false),
+			expActor:    &actor.Actor{UID: 1},
+			expAuthUserOp: &auth.GetAndSaveUserOp{
+				UserProps:       u("alice", "alice@example2.com", "Alice"),
+				ExternalAccount: acct(clientID, "1234"),
+			},
+		},
+		{
+			description: "user in allowed org -> session created",
+			data: testServerData{
+				user:   alice,
+				emails: []*gitea.Email{{Email: "alice@example.com", Verified: true, Primary: true}},
+				orgs:   []*gitea.Organization{{Name: "other"}, {Name: "sourcegraph"}},
+			},
+			allowOrgs:   []string{"sourcegraph"},
+			allowSignup: pointers.Ptr(false),
+			expActor:    &actor.Actor{UID: 1},
+			expAuthUserOp: &auth.GetAndSaveUserOp{
+				UserProps:       u("alice", "alice@example.com", "Alice"),
+				ExternalAccount: acct(clientID, "1234"),
+			},
+		},
+		{
+			description: "user not in allowed org -> no session created",
+			data: testServerData{
+				user:   alice,
+				emails: []*gitea.Email{{Email: "alice@example.com", Verified: true, Primary: true}},
+				orgs:   []*gitea.Organization{{Name: "other"}},
+			},
+			allowOrgs: []string{"sourcegraph"},
+			expErr:    true,
+		},
+		{
+			description: "user, no verified emails -> no session created",
+			data: testServerData{
+				user:   alice,
+				emails: []*gitea.Email{{Email: "alice@example.com", Primary: true}},
+			},
+			expErr: true,
+		},
+		{
+			description: "no user -> no session created",
+			expErr:      true,
+		},
+		{
+			description: "user, verified email, unsaveable -> no session created",
+			data: testServerData{
+				user:   &gitea.User{ID: 5678, Login: "bob"},
+				emails: []*gitea.Email{{Email: "bob@example.com", Verified: true, Primary: true}},
+			},
+			allowSignup: pointers.Ptr(false),
+			expErr:      true,
+			expAuthUserOp: &auth.GetAndSaveUserOp{
+				UserProps:       u("bob", "bob@example.com", ""),
+				ExternalAccount: acct(clientID, "5678"),
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.description, func(t *testing.T) {
+			server := createTestServer(t, &c.data)
+			baseURL, _ := url.Parse(server.URL)
+			baseURL = extsvc.NormalizeBaseURL(baseURL)
+
+			var gotAuthUserOp *auth.GetAndSaveUserOp
+			auth.MockGetAndSaveUser = func(ctx context.Context, op auth.GetAndSaveUserOp) (userID int32, safeErrMsg string, err error) {
+				if gotAuthUserOp != nil {
+					t.Fatal("GetAndSaveUser called more than once")
+				}
+				op.ExternalAccountData = extsvc.AccountData{} // ignore AccountData value
+				gotAuthUserOp = &op
+
+				if uid, ok := authSaveableUsers[op.UserProps.Username]; ok {
+					return uid, "", nil
+				}
+				return 0, "safeErr", errors.New("auth.GetAndSaveUser error")
+			}
+			t.Cleanup(func() { auth.MockGetAndSaveUser = nil })
+
+			client, err := gitea.NewClient(server.URL, &schema.GiteaConnection{Url: server.URL}, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			allowOrgs := make(map[string]struct{}, len(c.allowOrgs))
+			for _, org := range c.allowOrgs {
+				allowOrgs[org] = struct{}{}
+			}
+
+			s := &sessionIssuerHelper{
+				baseURL:     baseURL,
+				clientID:    clientID,
+				allowOrgs:   allowOrgs,
+				allowSignup: c.allowSignup,
+				client:      client,
+			}
+
+			tok := &oauth2.Token{AccessToken: "dummy-value-that-isnt-relevant-to-unit-correctness"}
+			actr, _, err := s.GetOrCreateUser(context.Background(), tok, "", "", "")
+			if c.expErr && err == nil {
+				t.Errorf("expected err %v, but was nil", c.expErr)
+			} else if !c.expErr && err != nil {
+				t.Errorf("expected no error, but was %v", err)
+			}
+
+			if got, exp := actr, c.expActor; !reflect.DeepEqual(got, exp) {
+				t.Errorf("expected actor %v, got %v", exp, got)
+			}
+
+			if c.expAuthUserOp != nil {
+				c.expAuthUserOp.ExternalAccount.ServiceID = baseURL.String()
+			}
+			if got, exp := gotAuthUserOp, c.expAuthUserOp; !reflect.DeepEqual(got, exp) {
+				t.Error(cmp.Diff(got, exp))
+			}
+		})
+	}
+}
+
+func TestBuildUserFetchAttempts(t *testing.T) {
+	emails := []*gitea.Email{
+		{Email: "secondary@example.com", Verified: true},
+		{Email: "unverified@example.com"},
+		{Email: "primary@example.com", Verified: true, Primary: true},
+	}
+
+	t.Run("signup not allowed", func(t *testing.T) {
+		attempts, err := buildUserFetchAttempts(emails, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []attempt{
+			{email: "primary@example.com"},
+			{email: "secondary@example.com"},
+		}
+		if diff := cmp.Diff(want, attempts, cmp.AllowUnexported(attempt{})); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("signup allowed", func(t *testing.T) {
+		attempts, err := buildUserFetchAttempts(emails, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []attempt{
+			{email: "primary@example.com"},
+			{email: "secondary@example.com"},
+			{email: "primary@example.com", createIfNotExist: true},
+		}
+		if diff := cmp.Diff(want, attempts, cmp.AllowUnexported(attempt{})); diff != "" {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("no verified emails", func(t *testing.T) {
+		if _, err := buildUserFetchAttempts(emails[1:2], true); err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+}
+
+func u(username, email, displayName string) database.NewUser {
+	return database.NewUser{
+		Username:        username,
+		Email:           email,
+		EmailIsVerified: true,
+		DisplayName:     displayName,
+	}
+}
+
+func acct(clientID, accountID string) extsvc.AccountSpec {
+	return extsvc.AccountSpec{
+		ServiceType: extsvc.TypeGitea,
+		ClientID:    clientID,
+		AccountID:   accountID,
+	}
+}
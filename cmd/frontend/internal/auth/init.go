@@ -17,6 +17,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/auth/confauth"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/auth/gerrit"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/auth/giteaoauth"
 	githubapp "github.com/sourcegraph/sourcegraph/cmd/frontend/internal/auth/githubappauth"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/auth/githuboauth"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/auth/gitlaboauth"
@@ -36,6 +37,7 @@ func Init(logger log.Logger, db database.DB) {
 	azureoauth.Init(logger, db)
 	bitbucketcloudoauth.Init(logger, db)
 	gerrit.Init()
+	giteaoauth.Init(logger, db)
 	githuboauth.Init(logger, db)
 	gitlaboauth.Init(logger, db)
 	httpheader.Init()
@@ -53,6 +55,7 @@ func Init(logger log.Logger, db database.DB) {
 		gitlaboauth.Middleware(db),
 		bitbucketcloudoauth.Middleware(db),
 		azureoauth.Middleware(db),
+		giteaoauth.Middleware(db),
 		githubapp.Middleware(db),
 		confauth.Middleware(),
 	)
@@ -88,6 +91,8 @@ func Init(logger log.Logger, db database.DB) {
 				name = "Bitbucket Cloud OAuth"
 			case p.AzureDevOps != nil:
 				name = "Azure DevOps"
+			case p.Gitea != nil:
+				name = "Gitea OAuth"
 			case p.HttpHeader != nil:
 				name = "HTTP header"
 			case p.Openidconnect != nil:
@@ -19,6 +19,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -66,6 +67,8 @@ func (p *Provider) CachedInfo() *providers.Info {
 		displayName = p.SourceConfig.Gitlab.DisplayName
 	case p.SourceConfig.Bitbucketcloud != nil && p.SourceConfig.Bitbucketcloud.DisplayName != "":
 		displayName = p.SourceConfig.Bitbucketcloud.DisplayName
+	case p.SourceConfig.Gitea != nil && p.SourceConfig.Gitea.DisplayName != "":
+		displayName = p.SourceConfig.Gitea.DisplayName
 	}
 	return &providers.Info{
 		ServiceID:   p.ServiceID,
@@ -92,9 +95,11 @@ func (p *Provider) ExternalAccountInfo(ctx context.Context, account extsvc.Accou
 		return bitbucketcloud.GetPublicExternalAccountData(ctx, &account.AccountData)
 	case extsvc.TypeAzureDevOps:
 		return azuredevops.GetPublicExternalAccountData(ctx, &account.AccountData)
+	case extsvc.TypeGitea:
+		return gitea.GetPublicExternalAccountData(ctx, &account.AccountData)
 	}
 
-	return nil, errors.Errorf("Sourcegraph currently only supports Azure DevOps, Bitbucket Cloud, GitHub, GitLab as OAuth providers")
+	return nil, errors.Errorf("Sourcegraph currently only supports Azure DevOps, Bitbucket Cloud, Gitea, GitHub, GitLab as OAuth providers")
 }
 
 type ProviderOp struct {
@@ -183,6 +183,21 @@ func (r *batchChangeResolver) ClosedAt() *gqlutil.DateTime {
 	return &gqlutil.DateTime{Time: r.batchChange.ClosedAt}
 }
 
+func (r *batchChangeResolver) AutoRebaseEnabled() bool {
+	return r.batchChange.AutoRebase
+}
+
+func (r *batchChangeResolver) Rollout(ctx context.Context) (graphqlbackend.BatchChangeRolloutResolver, error) {
+	batchSpec, err := r.computeBatchSpec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if batchSpec.Spec == nil || batchSpec.Spec.ChangesetTemplate == nil || batchSpec.Spec.ChangesetTemplate.Rollout == nil {
+		return nil, nil
+	}
+	return &batchChangeRolloutResolver{batchChange: r.batchChange, policy: batchSpec.Spec.ChangesetTemplate.Rollout}, nil
+}
+
 func (r *batchChangeResolver) ChangesetsStats(ctx context.Context) (graphqlbackend.ChangesetsStatsResolver, error) {
 	stats, err := r.store.GetChangesetsStats(ctx, r.batchChange.ID)
 	if err != nil {
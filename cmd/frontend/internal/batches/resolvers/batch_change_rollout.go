@@ -0,0 +1,33 @@
+package resolvers
+
+import (
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+var _ graphqlbackend.BatchChangeRolloutResolver = &batchChangeRolloutResolver{}
+
+type batchChangeRolloutResolver struct {
+	batchChange *btypes.BatchChange
+	policy      *batcheslib.RolloutPolicy
+}
+
+func (r *batchChangeRolloutResolver) CurrentWave() int32 {
+	return r.batchChange.RolloutWave
+}
+
+func (r *batchChangeRolloutResolver) Waves() int32 {
+	return int32(len(r.policy.Waves))
+}
+
+func (r *batchChangeRolloutResolver) Paused() bool {
+	return r.batchChange.RolloutPaused
+}
+
+func (r *batchChangeRolloutResolver) PauseReason() *string {
+	if r.batchChange.RolloutPauseReason == "" {
+		return nil
+	}
+	return &r.batchChange.RolloutPauseReason
+}
@@ -655,3 +655,34 @@ func (r *batchSpecResolver) Files(ctx context.Context, args *graphqlbackend.List
 
 	return &batchSpecWorkspaceFileConnectionResolver{store: r.store, opts: opts}, nil
 }
+
+func (r *batchSpecResolver) TemplateVersion(ctx context.Context) (graphqlbackend.BatchSpecTemplateVersionResolver, error) {
+	if r.batchSpec.TemplateVersionID == 0 {
+		return nil, nil
+	}
+
+	version, err := r.store.GetBatchSpecTemplateVersion(ctx, store.GetBatchSpecTemplateVersionOpts{ID: r.batchSpec.TemplateVersionID})
+	if err != nil {
+		if err == store.ErrNoResults {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	template, err := r.store.GetBatchSpecTemplate(ctx, store.GetBatchSpecTemplateOpts{ID: version.TemplateID})
+	if err != nil {
+		if err == store.ErrNoResults {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	// 🚨 SECURITY: The batch spec can be viewed by everyone who has its ID, but
+	// the template it was created from might live in a namespace the viewer
+	// can't access.
+	if err := service.New(r.store).CheckViewerCanAccessBatchSpecTemplate(ctx, template); err != nil {
+		return nil, nil
+	}
+
+	return &batchSpecTemplateVersionResolver{store: r.store, logger: r.logger, version: version, template: template}, nil
+}
@@ -0,0 +1,262 @@
+package resolvers
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/batches/service"
+	"github.com/sourcegraph/sourcegraph/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const batchSpecTemplateIDKind = "BatchSpecTemplate"
+
+func marshalBatchSpecTemplateID(id int64) graphql.ID {
+	return relay.MarshalID(batchSpecTemplateIDKind, id)
+}
+
+func unmarshalBatchSpecTemplateID(id graphql.ID) (templateID int64, err error) {
+	err = relay.UnmarshalSpec(id, &templateID)
+	return
+}
+
+var _ graphqlbackend.BatchSpecTemplateResolver = &batchSpecTemplateResolver{}
+
+type batchSpecTemplateResolver struct {
+	store  *store.Store
+	logger log.Logger
+
+	template *btypes.BatchSpecTemplate
+}
+
+func (r *batchSpecTemplateResolver) ID() graphql.ID {
+	return marshalBatchSpecTemplateID(r.template.ID)
+}
+
+func (r *batchSpecTemplateResolver) Name() string {
+	return r.template.Name
+}
+
+func (r *batchSpecTemplateResolver) Description() string {
+	return r.template.Description
+}
+
+func (r *batchSpecTemplateResolver) Namespace(ctx context.Context) (*graphqlbackend.NamespaceResolver, error) {
+	if r.template.IsGlobal() {
+		return nil, nil
+	}
+
+	var (
+		err error
+		n   = &graphqlbackend.NamespaceResolver{}
+	)
+	if r.template.NamespaceUserID != 0 {
+		n.Namespace, err = graphqlbackend.UserByIDInt32(ctx, r.store.DatabaseDB(), r.template.NamespaceUserID)
+	} else {
+		n.Namespace, err = graphqlbackend.OrgByIDInt32(ctx, r.store.DatabaseDB(), r.template.NamespaceOrgID)
+	}
+	if errcode.IsNotFound(err) {
+		return nil, errors.New("namespace of batch spec template has been deleted")
+	}
+	return n, err
+}
+
+func (r *batchSpecTemplateResolver) Creator(ctx context.Context) (*graphqlbackend.UserResolver, error) {
+	user, err := graphqlbackend.UserByIDInt32(ctx, r.store.DatabaseDB(), r.template.CreatorID)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *batchSpecTemplateResolver) LatestVersion(ctx context.Context) (graphqlbackend.BatchSpecTemplateVersionResolver, error) {
+	version, err := r.store.GetBatchSpecTemplateVersion(ctx, store.GetBatchSpecTemplateVersionOpts{TemplateID: r.template.ID})
+	if err != nil {
+		return nil, err
+	}
+	return &batchSpecTemplateVersionResolver{store: r.store, logger: r.logger, version: version, template: r.template}, nil
+}
+
+func (r *batchSpecTemplateResolver) Versions(ctx context.Context) ([]graphqlbackend.BatchSpecTemplateVersionResolver, error) {
+	versions, err := r.store.ListBatchSpecTemplateVersions(ctx, r.template.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.BatchSpecTemplateVersionResolver, 0, len(versions))
+	for _, v := range versions {
+		resolvers = append(resolvers, &batchSpecTemplateVersionResolver{store: r.store, logger: r.logger, version: v, template: r.template})
+	}
+	return resolvers, nil
+}
+
+func (r *batchSpecTemplateResolver) ViewerCanAdminister(ctx context.Context) (bool, error) {
+	if r.template.IsGlobal() {
+		err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.store.DatabaseDB())
+		if err == auth.ErrMustBeSiteAdmin || err == auth.ErrNotAuthenticated {
+			return false, nil
+		}
+		return err == nil, err
+	}
+	return service.New(r.store).CheckViewerCanAdminister(ctx, r.template.NamespaceUserID, r.template.NamespaceOrgID)
+}
+
+func (r *batchSpecTemplateResolver) CreatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.template.CreatedAt}
+}
+
+func (r *batchSpecTemplateResolver) UpdatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.template.UpdatedAt}
+}
+
+var _ graphqlbackend.BatchSpecTemplateVersionResolver = &batchSpecTemplateVersionResolver{}
+
+type batchSpecTemplateVersionResolver struct {
+	store  *store.Store
+	logger log.Logger
+
+	version  *btypes.BatchSpecTemplateVersion
+	template *btypes.BatchSpecTemplate
+}
+
+func (r *batchSpecTemplateVersionResolver) Template(ctx context.Context) (graphqlbackend.BatchSpecTemplateResolver, error) {
+	return &batchSpecTemplateResolver{store: r.store, logger: r.logger, template: r.template}, nil
+}
+
+func (r *batchSpecTemplateVersionResolver) Version() int32 {
+	return r.version.Version
+}
+
+func (r *batchSpecTemplateVersionResolver) OriginalInput() string {
+	return r.version.RawTemplate
+}
+
+func (r *batchSpecTemplateVersionResolver) Parameters() ([]graphqlbackend.BatchSpecTemplateParameterResolver, error) {
+	tmpl, err := r.version.Template()
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.BatchSpecTemplateParameterResolver, 0, len(tmpl.Parameters))
+	for _, p := range tmpl.Parameters {
+		resolvers = append(resolvers, &batchSpecTemplateParameterResolver{parameter: p})
+	}
+	return resolvers, nil
+}
+
+func (r *batchSpecTemplateVersionResolver) Creator(ctx context.Context) (*graphqlbackend.UserResolver, error) {
+	user, err := graphqlbackend.UserByIDInt32(ctx, r.store.DatabaseDB(), r.version.CreatorID)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *batchSpecTemplateVersionResolver) CreatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.version.CreatedAt}
+}
+
+var _ graphqlbackend.BatchSpecTemplateParameterResolver = &batchSpecTemplateParameterResolver{}
+
+type batchSpecTemplateParameterResolver struct {
+	parameter batcheslib.TemplateParameter
+}
+
+func (r *batchSpecTemplateParameterResolver) Name() string {
+	return r.parameter.Name
+}
+
+func (r *batchSpecTemplateParameterResolver) Type() string {
+	switch r.parameter.Type {
+	case batcheslib.TemplateParameterTypeRepositoryQuery:
+		return "REPOSITORY_QUERY"
+	case batcheslib.TemplateParameterTypeEnum:
+		return "ENUM"
+	default:
+		return "STRING"
+	}
+}
+
+func (r *batchSpecTemplateParameterResolver) Description() *string {
+	if r.parameter.Description == "" {
+		return nil
+	}
+	return &r.parameter.Description
+}
+
+func (r *batchSpecTemplateParameterResolver) Required() bool {
+	return r.parameter.IsRequired()
+}
+
+func (r *batchSpecTemplateParameterResolver) DefaultValue() *string {
+	return r.parameter.Default
+}
+
+func (r *batchSpecTemplateParameterResolver) Values() *[]string {
+	if len(r.parameter.Values) == 0 {
+		return nil
+	}
+	return &r.parameter.Values
+}
+
+var _ graphqlbackend.BatchSpecTemplateConnectionResolver = &batchSpecTemplateConnectionResolver{}
+
+type batchSpecTemplateConnectionResolver struct {
+	store  *store.Store
+	logger log.Logger
+	opts   store.ListBatchSpecTemplatesOpts
+
+	// Cache results because they are used by multiple fields.
+	once      sync.Once
+	templates []*btypes.BatchSpecTemplate
+	next      int64
+	err       error
+}
+
+func (r *batchSpecTemplateConnectionResolver) Nodes(ctx context.Context) ([]graphqlbackend.BatchSpecTemplateResolver, error) {
+	nodes, _, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]graphqlbackend.BatchSpecTemplateResolver, 0, len(nodes))
+	for _, t := range nodes {
+		resolvers = append(resolvers, &batchSpecTemplateResolver{store: r.store, logger: r.logger, template: t})
+	}
+	return resolvers, nil
+}
+
+func (r *batchSpecTemplateConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	count, err := r.store.CountBatchSpecTemplates(ctx, r.opts)
+	return int32(count), err
+}
+
+func (r *batchSpecTemplateConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	_, next, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if next != 0 {
+		return graphqlutil.NextPageCursor(strconv.Itoa(int(next))), nil
+	}
+	return graphqlutil.HasNextPage(false), nil
+}
+
+func (r *batchSpecTemplateConnectionResolver) compute(ctx context.Context) ([]*btypes.BatchSpecTemplate, int64, error) {
+	r.once.Do(func() {
+		r.templates, r.next, r.err = r.store.ListBatchSpecTemplates(ctx, r.opts)
+	})
+	return r.templates, r.next, r.err
+}
@@ -434,6 +434,23 @@ func (r *changesetResolver) CurrentSpec(ctx context.Context) (graphqlbackend.Vis
 	return NewChangesetSpecResolverWithRepo(r.store, r.repo, spec), nil
 }
 
+func (r *changesetResolver) AutoRebases(ctx context.Context) ([]graphqlbackend.ChangesetAutoRebaseResolver, error) {
+	if r.changeset.OwnedByBatchChangeID == 0 {
+		return []graphqlbackend.ChangesetAutoRebaseResolver{}, nil
+	}
+
+	rebases, _, err := r.store.ListChangesetRebases(ctx, store.ListChangesetRebasesOpts{ChangesetID: r.changeset.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.ChangesetAutoRebaseResolver, 0, len(rebases))
+	for _, rb := range rebases {
+		resolvers = append(resolvers, &changesetAutoRebaseResolver{rebase: rb})
+	}
+	return resolvers, nil
+}
+
 func (r *changesetResolver) Labels(ctx context.Context) ([]graphqlbackend.ChangesetLabelResolver, error) {
 	if !r.changeset.Published() {
 		return []graphqlbackend.ChangesetLabelResolver{}, nil
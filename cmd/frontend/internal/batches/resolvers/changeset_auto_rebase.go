@@ -0,0 +1,36 @@
+package resolvers
+
+import (
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+)
+
+var _ graphqlbackend.ChangesetAutoRebaseResolver = &changesetAutoRebaseResolver{}
+
+type changesetAutoRebaseResolver struct {
+	rebase *btypes.ChangesetRebase
+}
+
+func (r *changesetAutoRebaseResolver) State() string {
+	return string(r.rebase.State)
+}
+
+func (r *changesetAutoRebaseResolver) BaseRev() *string {
+	if r.rebase.BaseRev == "" {
+		return nil
+	}
+	return &r.rebase.BaseRev
+}
+
+func (r *changesetAutoRebaseResolver) FailureMessage() *string {
+	return r.rebase.FailureMessage
+}
+
+func (r *changesetAutoRebaseResolver) CreatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.rebase.CreatedAt}
+}
+
+func (r *changesetAutoRebaseResolver) UpdatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.rebase.UpdatedAt}
+}
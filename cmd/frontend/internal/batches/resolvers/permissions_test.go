@@ -925,6 +925,18 @@ query($includeLocallyExecutedSpecs: Boolean) {
 					return fmt.Sprintf(`mutation { moveBatchChange(batchChange: %q, newName: "foobar") { id } }`, batchChangeID)
 				},
 			},
+			{
+				name: "setBatchChangeAutoRebase",
+				mutationFunc: func(userID, batchChangeID, changesetID, batchSpecID string) string {
+					return fmt.Sprintf(`mutation { setBatchChangeAutoRebase(batchChange: %q, enabled: true) { id } }`, batchChangeID)
+				},
+			},
+			{
+				name: "setBatchChangeRolloutPaused",
+				mutationFunc: func(userID, batchChangeID, changesetID, batchSpecID string) string {
+					return fmt.Sprintf(`mutation { setBatchChangeRolloutPaused(batchChange: %q, paused: true) { id } }`, batchChangeID)
+				},
+			},
 			{
 				name: "createChangesetComments",
 				mutationFunc: func(userID, batchChangeID, changesetID, batchSpecID string) string {
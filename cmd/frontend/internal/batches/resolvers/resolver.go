@@ -125,6 +125,9 @@ func (r *Resolver) NodeResolvers() map[string]graphqlbackend.NodeByIDFunc {
 		workspaceFileIDKind: func(ctx context.Context, id graphql.ID) (graphqlbackend.Node, error) {
 			return r.batchSpecWorkspaceFileByID(ctx, id)
 		},
+		batchSpecTemplateIDKind: func(ctx context.Context, id graphql.ID) (graphqlbackend.Node, error) {
+			return r.batchSpecTemplateByID(ctx, id)
+		},
 	}
 }
 
@@ -717,6 +720,72 @@ func (r *Resolver) MoveBatchChange(ctx context.Context, args *graphqlbackend.Mov
 	return &batchChangeResolver{store: r.store, gitserverClient: r.gitserverClient, batchChange: batchChange, logger: r.logger}, nil
 }
 
+func (r *Resolver) SetBatchChangeAutoRebase(ctx context.Context, args *graphqlbackend.SetBatchChangeAutoRebaseArgs) (_ graphqlbackend.BatchChangeResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.SetBatchChangeAutoRebase",
+		attribute.String("batchChange", string(args.BatchChange)),
+		attribute.Bool("enabled", args.Enabled))
+	defer tr.EndWithErr(&err)
+
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission); err != nil {
+		return nil, err
+	}
+
+	batchChangeID, err := unmarshalBatchChangeID(args.BatchChange)
+	if err != nil {
+		return nil, err
+	}
+
+	if batchChangeID == 0 {
+		return nil, ErrIDIsZero{}
+	}
+
+	svc := service.New(r.store)
+	// 🚨 SECURITY: SetBatchChangeAutoRebase checks whether the current user is authorized.
+	batchChange, err := svc.SetBatchChangeAutoRebase(ctx, batchChangeID, args.Enabled)
+	if err != nil {
+		return nil, err
+	}
+
+	return &batchChangeResolver{store: r.store, gitserverClient: r.gitserverClient, batchChange: batchChange, logger: r.logger}, nil
+}
+
+func (r *Resolver) SetBatchChangeRolloutPaused(ctx context.Context, args *graphqlbackend.SetBatchChangeRolloutPausedArgs) (_ graphqlbackend.BatchChangeResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.SetBatchChangeRolloutPaused",
+		attribute.String("batchChange", string(args.BatchChange)),
+		attribute.Bool("paused", args.Paused))
+	defer tr.EndWithErr(&err)
+
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission); err != nil {
+		return nil, err
+	}
+
+	batchChangeID, err := unmarshalBatchChangeID(args.BatchChange)
+	if err != nil {
+		return nil, err
+	}
+
+	if batchChangeID == 0 {
+		return nil, ErrIDIsZero{}
+	}
+
+	svc := service.New(r.store)
+	// 🚨 SECURITY: SetBatchChangeRolloutPaused checks whether the current user is authorized.
+	batchChange, err := svc.SetBatchChangeRolloutPaused(ctx, batchChangeID, args.Paused)
+	if err != nil {
+		return nil, err
+	}
+
+	return &batchChangeResolver{store: r.store, gitserverClient: r.gitserverClient, batchChange: batchChange, logger: r.logger}, nil
+}
+
 func (r *Resolver) DeleteBatchChange(ctx context.Context, args *graphqlbackend.DeleteBatchChangeArgs) (_ *graphqlbackend.EmptyResponse, err error) {
 	tr, ctx := trace.New(ctx, "Resolver.DeleteBatchChange", attribute.String("batchChange", string(args.BatchChange)))
 	defer tr.EndWithErr(&err)
@@ -1974,6 +2043,214 @@ func (r *Resolver) DeleteBatchSpec(ctx context.Context, args *graphqlbackend.Del
 	return nil, errors.New("not implemented yet")
 }
 
+func (r *Resolver) batchSpecTemplateByID(ctx context.Context, id graphql.ID) (graphqlbackend.BatchSpecTemplateResolver, error) {
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	templateID, err := unmarshalBatchSpecTemplateID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if templateID == 0 {
+		return nil, ErrIDIsZero{}
+	}
+
+	template, err := r.store.GetBatchSpecTemplate(ctx, store.GetBatchSpecTemplateOpts{ID: templateID})
+	if err != nil {
+		if err == store.ErrNoResults {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only return templates that are global or live in a
+	// namespace the viewer has access to.
+	if err := service.New(r.store).CheckViewerCanAccessBatchSpecTemplate(ctx, template); err != nil {
+		return nil, err
+	}
+
+	return &batchSpecTemplateResolver{store: r.store, logger: r.logger, template: template}, nil
+}
+
+func (r *Resolver) BatchSpecTemplates(ctx context.Context, args *graphqlbackend.ListBatchSpecTemplatesArgs) (_ graphqlbackend.BatchSpecTemplateConnectionResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.BatchSpecTemplates",
+		attribute.Int("first", int(args.First)),
+		attribute.String("after", fmt.Sprintf("%v", args.After)))
+	defer tr.EndWithErr(&err)
+
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	if err := validateFirstParamDefaults(args.First); err != nil {
+		return nil, err
+	}
+
+	opts := store.ListBatchSpecTemplatesOpts{
+		LimitOpts: store.LimitOpts{
+			Limit: int(args.First),
+		},
+		OnlyGlobal: args.OnlyGlobal,
+	}
+
+	svc := service.New(r.store)
+
+	if args.Namespace != nil {
+		if err := graphqlbackend.UnmarshalNamespaceID(*args.Namespace, &opts.NamespaceUserID, &opts.NamespaceOrgID); err != nil {
+			return nil, err
+		}
+		// 🚨 SECURITY: Only list the templates of namespaces the viewer has
+		// access to.
+		if err := svc.CheckNamespaceAccess(ctx, opts.NamespaceUserID, opts.NamespaceOrgID); err != nil {
+			return nil, err
+		}
+	} else {
+		// 🚨 SECURITY: If the user is not an admin, we only want to include
+		// templates that are global or in a namespace the user has access to.
+		authErr := auth.CheckCurrentUserIsSiteAdmin(ctx, r.store.DatabaseDB())
+		if authErr != nil && authErr != auth.ErrMustBeSiteAdmin {
+			return nil, authErr
+		}
+		if authErr == auth.ErrMustBeSiteAdmin {
+			opts.VisibleToUserID = sgactor.FromContext(ctx).UID
+		}
+	}
+
+	if args.After != nil {
+		id, err := strconv.Atoi(*args.After)
+		if err != nil {
+			return nil, err
+		}
+		opts.Cursor = int64(id)
+	}
+
+	return &batchSpecTemplateConnectionResolver{store: r.store, logger: r.logger, opts: opts}, nil
+}
+
+func (r *Resolver) CreateBatchSpecTemplate(ctx context.Context, args *graphqlbackend.CreateBatchSpecTemplateArgs) (_ graphqlbackend.BatchSpecTemplateResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.CreateBatchSpecTemplate",
+		attribute.String("namespace", fmt.Sprintf("%v", args.Namespace)))
+	defer tr.EndWithErr(&err)
+
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission); err != nil {
+		return nil, err
+	}
+
+	opts := service.CreateBatchSpecTemplateOpts{RawTemplate: args.Template}
+	if args.Namespace != nil {
+		if err := graphqlbackend.UnmarshalNamespaceID(*args.Namespace, &opts.NamespaceUserID, &opts.NamespaceOrgID); err != nil {
+			return nil, err
+		}
+	}
+
+	// 🚨 SECURITY: CreateBatchSpecTemplate checks whether the current user has
+	// access to the namespace, or is a site admin for global templates.
+	template, _, err := service.New(r.store).CreateBatchSpecTemplate(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &batchSpecTemplateResolver{store: r.store, logger: r.logger, template: template}, nil
+}
+
+func (r *Resolver) DeleteBatchSpecTemplate(ctx context.Context, args *graphqlbackend.DeleteBatchSpecTemplateArgs) (_ *graphqlbackend.EmptyResponse, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.DeleteBatchSpecTemplate",
+		attribute.String("batchSpecTemplate", string(args.BatchSpecTemplate)))
+	defer tr.EndWithErr(&err)
+
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission); err != nil {
+		return nil, err
+	}
+
+	templateID, err := unmarshalBatchSpecTemplateID(args.BatchSpecTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	if templateID == 0 {
+		return nil, ErrIDIsZero{}
+	}
+
+	// 🚨 SECURITY: DeleteBatchSpecTemplate checks whether current user is authorized.
+	if err := service.New(r.store).DeleteBatchSpecTemplate(ctx, templateID); err != nil {
+		return nil, err
+	}
+
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
+func (r *Resolver) CreateBatchSpecFromTemplate(ctx context.Context, args *graphqlbackend.CreateBatchSpecFromTemplateArgs) (_ graphqlbackend.BatchSpecResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.CreateBatchSpecFromTemplate",
+		attribute.String("batchSpecTemplate", string(args.BatchSpecTemplate)),
+		attribute.String("namespace", string(args.Namespace)))
+	defer tr.EndWithErr(&err)
+
+	if err := batchChangesCreateAccess(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	if err := rbac.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission); err != nil {
+		return nil, err
+	}
+
+	templateID, err := unmarshalBatchSpecTemplateID(args.BatchSpecTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	if templateID == 0 {
+		return nil, ErrIDIsZero{}
+	}
+
+	var uid, oid int32
+	if err := graphqlbackend.UnmarshalNamespaceID(args.Namespace, &uid, &oid); err != nil {
+		return nil, err
+	}
+
+	bid, err := unmarshalBatchChangeID(args.BatchChange)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := service.CreateBatchSpecFromTemplateOpts{
+		TemplateID:       templateID,
+		Inputs:           map[string]string{},
+		NamespaceUserID:  uid,
+		NamespaceOrgID:   oid,
+		AllowIgnored:     args.AllowIgnored,
+		AllowUnsupported: args.AllowUnsupported,
+		NoCache:          args.NoCache,
+		BatchChange:      bid,
+	}
+	if args.Version != nil {
+		opts.Version = *args.Version
+	}
+	if args.Inputs != nil {
+		for _, input := range *args.Inputs {
+			opts.Inputs[input.Name] = input.Value
+		}
+	}
+
+	// 🚨 SECURITY: CreateBatchSpecFromTemplate checks whether the current user
+	// has access to the template and to the namespace of the batch spec.
+	batchSpec, err := service.New(r.store).CreateBatchSpecFromTemplate(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &batchSpecResolver{store: r.store, logger: r.logger, batchSpec: batchSpec}, nil
+}
+
 func (r *Resolver) batchSpecWorkspaceFileByID(ctx context.Context, gqlID graphql.ID) (_ graphqlbackend.BatchWorkspaceFileResolver, err error) {
 	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
 		return nil, err
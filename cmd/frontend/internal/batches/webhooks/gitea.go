@@ -0,0 +1,125 @@
+package webhooks
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/sourcegraph/log"
+
+	fewebhooks "github.com/sourcegraph/sourcegraph/cmd/frontend/webhooks"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/batches/store"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var giteaEvents = []string{
+	gitea.EventPullRequest,
+	gitea.EventPullRequestReviewApproved,
+	gitea.EventPullRequestReviewRejected,
+	gitea.EventPullRequestReviewComment,
+	gitea.EventPullRequestComment,
+}
+
+type GiteaWebhook struct {
+	*webhook
+}
+
+func NewGiteaWebhook(store *store.Store, gitserverClient gitserver.Client, logger log.Logger) *GiteaWebhook {
+	return &GiteaWebhook{
+		webhook: &webhook{store, gitserverClient, logger, extsvc.TypeGitea},
+	}
+}
+
+func (h *GiteaWebhook) Register(router *fewebhooks.Router) {
+	router.Register(
+		h.handleEvent,
+		extsvc.KindGitea,
+		giteaEvents...,
+	)
+}
+
+func (h *GiteaWebhook) handleEvent(ctx context.Context, db database.DB, codeHostURN extsvc.CodeHostBaseURL, event any) error {
+	ctx = actor.WithInternalActor(ctx)
+
+	pr, err := giteaEventToPR(event)
+	if err != nil {
+		return err
+	}
+	if pr == (PR{}) {
+		h.logger.Debug("Dropping Gitea webhook event that is not for a pull request")
+		return nil
+	}
+
+	// Gitea webhook payloads don't include reviews or commit statuses, so
+	// rather than deriving the changeset state from the event we enqueue a
+	// sync of the changeset, which fetches everything we need.
+	if err := h.enqueueChangesetSync(ctx, codeHostURN, pr); err != nil {
+		return &httpError{
+			code: http.StatusInternalServerError,
+			err:  err,
+		}
+	}
+	return nil
+}
+
+func giteaEventToPR(event any) (PR, error) {
+	switch e := event.(type) {
+	case *gitea.PullRequestEvent:
+		return giteaToPR(e.Repository, e.Index), nil
+	case *gitea.PullRequestReviewEvent:
+		return giteaToPR(e.Repository, e.Index), nil
+	case *gitea.PullRequestCommentEvent:
+		// Comment events are sent for issues as well as pull requests.
+		if !e.IsPull || e.Issue == nil {
+			return PR{}, nil
+		}
+		return giteaToPR(e.Repository, e.Issue.Index), nil
+	default:
+		return PR{}, errors.Newf("unknown event type: %T", event)
+	}
+}
+
+func giteaToPR(repo *gitea.Repo, index int64) PR {
+	if repo == nil {
+		return PR{}
+	}
+	return PR{
+		ID:             index,
+		RepoExternalID: strconv.FormatInt(repo.ID, 10),
+	}
+}
+
+// enqueueChangesetSync enqueues a sync request for the changeset matching the
+// given pull request in repo-updater.
+func (h *GiteaWebhook) enqueueChangesetSync(ctx context.Context, esID extsvc.CodeHostBaseURL, pr PR) error {
+	repo, err := h.getRepoForPR(ctx, h.Store, pr, esID)
+	if err != nil {
+		h.logger.Warn("Webhook event could not be matched to repo", log.Error(err))
+		return nil
+	}
+
+	c, err := h.Store.GetChangeset(ctx, store.GetChangesetOpts{
+		RepoID:              repo.ID,
+		ExternalID:          strconv.FormatInt(pr.ID, 10),
+		ExternalServiceType: h.ServiceType,
+	})
+	if err != nil {
+		if err == store.ErrNoResults {
+			// Not a changeset we're tracking.
+			return nil
+		}
+		return errors.Wrap(err, "getting changeset")
+	}
+
+	if err := repoupdater.DefaultClient.EnqueueChangesetSync(ctx, []int64{c.ID}); err != nil {
+		return errors.Wrap(err, "enqueuing changeset sync")
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package webhooks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+)
+
+func TestGiteaEventToPR(t *testing.T) {
+	repo := &gitea.Repo{ID: 42, FullName: "sourcegraph/test"}
+
+	for name, tc := range map[string]struct {
+		event any
+		want  PR
+	}{
+		"pull request": {
+			event: &gitea.PullRequestEvent{Action: "closed", Index: 3, Repository: repo},
+			want:  PR{ID: 3, RepoExternalID: "42"},
+		},
+		"pull request review": {
+			event: &gitea.PullRequestReviewEvent{
+				PullRequestEvent: gitea.PullRequestEvent{Action: "reviewed", Index: 5, Repository: repo},
+			},
+			want: PR{ID: 5, RepoExternalID: "42"},
+		},
+		"pull request comment": {
+			event: &gitea.PullRequestCommentEvent{Action: "created", Issue: &gitea.Issue{Index: 7}, IsPull: true, Repository: repo},
+			want:  PR{ID: 7, RepoExternalID: "42"},
+		},
+		"issue comment": {
+			event: &gitea.PullRequestCommentEvent{Action: "created", Issue: &gitea.Issue{Index: 8}, IsPull: false, Repository: repo},
+			want:  PR{},
+		},
+		"missing repository": {
+			event: &gitea.PullRequestEvent{Action: "opened", Index: 1},
+			want:  PR{},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			have, err := giteaEventToPR(tc.event)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, have)
+		})
+	}
+
+	t.Run("unknown event", func(t *testing.T) {
+		_, err := giteaEventToPR(&gitea.PushEvent{})
+		assert.Error(t, err)
+	})
+}
@@ -274,28 +274,36 @@ func makeExternalAPI(db database.DB, logger sglog.Logger, schema *graphql.Schema
 		schema,
 		rateLimiter,
 		&httpapi.Handlers{
-			GitHubSyncWebhook:               enterprise.ReposGithubWebhook,
-			GitLabSyncWebhook:               enterprise.ReposGitLabWebhook,
-			BitbucketServerSyncWebhook:      enterprise.ReposBitbucketServerWebhook,
-			BitbucketCloudSyncWebhook:       enterprise.ReposBitbucketCloudWebhook,
-			PermissionsGitHubWebhook:        enterprise.PermissionsGitHubWebhook,
-			BatchesGitHubWebhook:            enterprise.BatchesGitHubWebhook,
-			BatchesGitLabWebhook:            enterprise.BatchesGitLabWebhook,
-			BatchesBitbucketServerWebhook:   enterprise.BatchesBitbucketServerWebhook,
-			BatchesBitbucketCloudWebhook:    enterprise.BatchesBitbucketCloudWebhook,
-			BatchesAzureDevOpsWebhook:       enterprise.BatchesAzureDevOpsWebhook,
-			BatchesChangesFileGetHandler:    enterprise.BatchesChangesFileGetHandler,
-			BatchesChangesFileExistsHandler: enterprise.BatchesChangesFileExistsHandler,
-			BatchesChangesFileUploadHandler: enterprise.BatchesChangesFileUploadHandler,
-			SCIMHandler:                     enterprise.SCIMHandler,
-			NewCodeIntelUploadHandler:       enterprise.NewCodeIntelUploadHandler,
-			NewComputeStreamHandler:         enterprise.NewComputeStreamHandler,
-			CodeInsightsDataExportHandler:   enterprise.CodeInsightsDataExportHandler,
-			SearchJobsDataExportHandler:     enterprise.SearchJobsDataExportHandler,
-			SearchJobsLogsHandler:           enterprise.SearchJobsLogsHandler,
-			NewDotcomLicenseCheckHandler:    enterprise.NewDotcomLicenseCheckHandler,
-			NewChatCompletionsStreamHandler: enterprise.NewChatCompletionsStreamHandler,
-			NewCodeCompletionsHandler:       enterprise.NewCodeCompletionsHandler,
+			GitHubSyncWebhook:                    enterprise.ReposGithubWebhook,
+			GitLabSyncWebhook:                    enterprise.ReposGitLabWebhook,
+			BitbucketServerSyncWebhook:           enterprise.ReposBitbucketServerWebhook,
+			BitbucketCloudSyncWebhook:            enterprise.ReposBitbucketCloudWebhook,
+			GiteaSyncWebhook:                     enterprise.ReposGiteaWebhook,
+			PermissionsGitHubWebhook:             enterprise.PermissionsGitHubWebhook,
+			BatchesGitHubWebhook:                 enterprise.BatchesGitHubWebhook,
+			BatchesGitLabWebhook:                 enterprise.BatchesGitLabWebhook,
+			BatchesBitbucketServerWebhook:        enterprise.BatchesBitbucketServerWebhook,
+			BatchesBitbucketCloudWebhook:         enterprise.BatchesBitbucketCloudWebhook,
+			BatchesAzureDevOpsWebhook:            enterprise.BatchesAzureDevOpsWebhook,
+			BatchesGiteaWebhook:                  enterprise.BatchesGiteaWebhook,
+			BatchesChangesFileGetHandler:         enterprise.BatchesChangesFileGetHandler,
+			BatchesChangesFileExistsHandler:      enterprise.BatchesChangesFileExistsHandler,
+			BatchesChangesFileUploadHandler:      enterprise.BatchesChangesFileUploadHandler,
+			SCIMHandler:                          enterprise.SCIMHandler,
+			NewCodeIntelUploadHandler:            enterprise.NewCodeIntelUploadHandler,
+			CodeIntelSCIPExportHandler:           enterprise.CodeIntelSCIPExportHandler,
+			CodeIntelDeadCodeReportHandler:       enterprise.CodeIntelDeadCodeReportHandler,
+			NewComputeStreamHandler:              enterprise.NewComputeStreamHandler,
+			CodeInsightsDataExportHandler:        enterprise.CodeInsightsDataExportHandler,
+			CodeInsightsMetricsHandler:           enterprise.CodeInsightsMetricsHandler,
+			CodeInsightsMetricsQueryRangeHandler: enterprise.CodeInsightsMetricsQueryRangeHandler,
+			SearchJobsDataExportHandler:          enterprise.SearchJobsDataExportHandler,
+			SearchJobsLogsHandler:                enterprise.SearchJobsLogsHandler,
+			ExecutorJobArtifactsHandler:          enterprise.ExecutorJobArtifactsHandler,
+			ExecutorJobArtifactDownloadHandler:   enterprise.ExecutorJobArtifactDownloadHandler,
+			NewDotcomLicenseCheckHandler:         enterprise.NewDotcomLicenseCheckHandler,
+			NewChatCompletionsStreamHandler:      enterprise.NewChatCompletionsStreamHandler,
+			NewCodeCompletionsHandler:            enterprise.NewCodeCompletionsHandler,
 		},
 		enterprise.NewExecutorProxyHandler,
 		enterprise.NewGitHubAppSetupHandler,
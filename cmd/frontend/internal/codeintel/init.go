@@ -13,6 +13,7 @@ import (
 	codenavgraphql "github.com/sourcegraph/sourcegraph/internal/codeintel/codenav/transport/graphql"
 	policiesgraphql "github.com/sourcegraph/sourcegraph/internal/codeintel/policies/transport/graphql"
 	rankinggraphql "github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/transport/graphql"
+	rankinghttp "github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/transport/http"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/resolvers"
 	sentinelgraphql "github.com/sourcegraph/sourcegraph/internal/codeintel/sentinel/transport/graphql"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/shared/lsifuploadstore"
@@ -133,6 +134,8 @@ func Init(
 		rankingRootResolver,
 	))
 	enterpriseServices.NewCodeIntelUploadHandler = newUploadHandler
+	enterpriseServices.CodeIntelSCIPExportHandler = uploadshttp.GetExportHandler(codeIntelServices.UploadsService, db, codeIntelServices.GitserverClient)
+	enterpriseServices.CodeIntelDeadCodeReportHandler = rankinghttp.NewDeadCodeReportHandler(codeIntelServices.RankingService)
 	enterpriseServices.RankingService = codeIntelServices.RankingService
 	return nil
 }
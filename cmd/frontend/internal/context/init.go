@@ -11,10 +11,20 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/embeddings"
 	vdb "github.com/sourcegraph/sourcegraph/internal/embeddings/db"
+	"github.com/sourcegraph/sourcegraph/internal/env"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/search/client"
 )
 
+// fusionOptions configure how the results of embeddings, keyword and symbol search are merged when
+// the cody-context-hybrid feature flag is enabled. Weights are relative to each other.
+var fusionOptions = codycontext.FusionOptions{
+	K:                env.MustGetInt("CODY_CONTEXT_FUSION_K", codycontext.DefaultFusionOptions.K, "The constant of the reciprocal rank fusion of Cody context results. Larger values give lower-ranked results more weight relative to top-ranked results."),
+	EmbeddingsWeight: env.MustGetFloat("CODY_CONTEXT_FUSION_EMBEDDINGS_WEIGHT", codycontext.DefaultFusionOptions.EmbeddingsWeight, "The weight of embeddings search results in the reciprocal rank fusion of Cody context results. Set to 0 to ignore them."),
+	KeywordWeight:    env.MustGetFloat("CODY_CONTEXT_FUSION_KEYWORD_WEIGHT", codycontext.DefaultFusionOptions.KeywordWeight, "The weight of keyword search results in the reciprocal rank fusion of Cody context results. Set to 0 to ignore them."),
+	SymbolWeight:     env.MustGetFloat("CODY_CONTEXT_FUSION_SYMBOL_WEIGHT", codycontext.DefaultFusionOptions.SymbolWeight, "The weight of symbol search results in the reciprocal rank fusion of Cody context results. Set to 0 to ignore them."),
+}
+
 func Init(
 	_ context.Context,
 	observationCtx *observation.Context,
@@ -34,6 +44,7 @@ func Init(
 		embeddingsClient,
 		searchClient,
 		getQdrantSearcher,
+		fusionOptions,
 	)
 	enterpriseServices.CodyContextResolver = resolvers.NewResolver(
 		db,
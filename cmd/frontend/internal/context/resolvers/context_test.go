@@ -131,6 +131,7 @@ func TestContextResolver(t *testing.T) {
 		mockEmbeddingsClient,
 		mockSearchClient,
 		nil,
+		codycontext.DefaultFusionOptions,
 	)
 
 	resolver := NewResolver(
@@ -0,0 +1,149 @@
+package artifacts
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/sourcegraph/log"
+	"golang.org/x/exp/slices"
+
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/executor/artifacts"
+	"github.com/sourcegraph/sourcegraph/internal/executor/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ServeUpload returns the handler executors use to upload the artifacts of the jobs they
+// run. The job ID is read from the X-Sourcegraph-Job-ID header, and the queue and the name
+// of the artifact from the route.
+//
+// 🚨 SECURITY: The caller MUST ensure that the request is authenticated for the job.
+func ServeUpload(logger log.Logger, svc *artifacts.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		queue := mux.Vars(r)["queueName"]
+		name := mux.Vars(r)["name"]
+		if !slices.Contains(types.ValidQueueNames, queue) {
+			http.Error(w, fmt.Sprintf("unknown queue %q", queue), http.StatusNotFound)
+			return
+		}
+		if err := types.ValidateArtifactName(name); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		jobID, err := strconv.Atoi(r.Header.Get("X-Sourcegraph-Job-ID"))
+		if err != nil {
+			http.Error(w, "invalid job ID in header 'X-Sourcegraph-Job-ID'", http.StatusBadRequest)
+			return
+		}
+
+		if _, err := svc.Upload(r.Context(), queue, jobID, name, r.Body); err != nil {
+			if errors.Is(err, artifacts.ErrTooLarge) {
+				http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+				return
+			}
+			logger.Error("failed to upload artifact",
+				log.String("queue", queue),
+				log.Int("jobID", jobID),
+				log.String("name", name),
+				log.Error(err))
+			http.Error(w, "failed to upload artifact", http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// Artifact is the JSON representation of an artifact returned by ServeList.
+type Artifact struct {
+	Name      string    `json:"name"`
+	Size      int64     `json:"size"`
+	CreatedAt time.Time `json:"createdAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// ServeList returns the handler listing the unexpired artifacts of a job.
+func ServeList(db database.DB, svc *artifacts.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		queue, jobID, ok := parseJob(w, r, db)
+		if !ok {
+			return
+		}
+
+		list, err := svc.List(r.Context(), queue, jobID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		resp := make([]Artifact, 0, len(list))
+		for _, artifact := range list {
+			resp = append(resp, Artifact{
+				Name:      artifact.Name,
+				Size:      artifact.Size,
+				CreatedAt: artifact.CreatedAt,
+				ExpiresAt: artifact.ExpiresAt,
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}
+}
+
+// ServeDownload returns the handler downloading the content of an unexpired artifact of a job.
+func ServeDownload(db database.DB, svc *artifacts.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		queue, jobID, ok := parseJob(w, r, db)
+		if !ok {
+			return
+		}
+
+		artifact, rc, ok, err := svc.Open(r.Context(), queue, jobID, mux.Vars(r)["name"])
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if !ok {
+			http.Error(w, "artifact not found", http.StatusNotFound)
+			return
+		}
+		defer rc.Close()
+
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Length", strconv.FormatInt(artifact.Size, 10))
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", path.Base(artifact.Name)))
+		_, _ = io.Copy(w, rc)
+	}
+}
+
+// parseJob returns the queue and the ID of the job of the request. It writes an error
+// response and returns false if they are invalid, or if the current user is not a site
+// admin.
+func parseJob(w http.ResponseWriter, r *http.Request, db database.DB) (string, int, bool) {
+	// 🚨 SECURITY: Artifacts may contain data of any repository, so only site admins can
+	// access them, like the execution logs of jobs.
+	if err := auth.CheckCurrentUserIsSiteAdmin(r.Context(), db); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return "", 0, false
+	}
+
+	queue := mux.Vars(r)["queueName"]
+	if !slices.Contains(types.ValidQueueNames, queue) {
+		http.Error(w, fmt.Sprintf("unknown queue %q", queue), http.StatusNotFound)
+		return "", 0, false
+	}
+	jobID, err := strconv.Atoi(mux.Vars(r)["jobID"])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return "", 0, false
+	}
+	return queue, jobID, true
+}
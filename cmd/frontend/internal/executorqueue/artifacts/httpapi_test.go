@@ -0,0 +1,172 @@
+package artifacts
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	"github.com/sourcegraph/sourcegraph/internal/executor/artifacts"
+	"github.com/sourcegraph/sourcegraph/internal/executor/store"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	uploadstoremocks "github.com/sourcegraph/sourcegraph/internal/uploadstore/mocks"
+)
+
+func TestServeUpload(t *testing.T) {
+	tests := []struct {
+		name               string
+		path               string
+		jobID              string
+		expectedStatusCode int
+		expectedKey        string
+	}{
+		{
+			name:               "Uploaded",
+			path:               "/artifacts/batches/reports/unit.xml",
+			jobID:              "42",
+			expectedStatusCode: http.StatusNoContent,
+			expectedKey:        "batches/42/reports/unit.xml",
+		},
+		{
+			name:               "Unknown queue",
+			path:               "/artifacts/unknown/unit.xml",
+			jobID:              "42",
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:               "Invalid name",
+			path:               "/artifacts/batches/reports%5Cunit.xml",
+			jobID:              "42",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "Missing job ID",
+			path:               "/artifacts/batches/unit.xml",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			artifactStore := store.NewMockArtifactStore()
+			uploadStore := uploadstoremocks.NewMockStore()
+			uploadStore.UploadFunc.SetDefaultHook(func(ctx context.Context, key string, r io.Reader) (int64, error) {
+				return io.Copy(io.Discard, r)
+			})
+
+			router := mux.NewRouter()
+			router.Path("/artifacts/{queueName}/{name:.*}").Handler(ServeUpload(logtest.Scoped(t), artifacts.NewService(artifactStore, uploadStore)))
+
+			req := httptest.NewRequest(http.MethodPost, test.path, strings.NewReader("<testsuites/>"))
+			req.Header.Set("X-Sourcegraph-Job-ID", test.jobID)
+			rw := httptest.NewRecorder()
+			router.ServeHTTP(rw, req)
+
+			assert.Equal(t, test.expectedStatusCode, rw.Code)
+			if test.expectedKey != "" {
+				require.Len(t, uploadStore.UploadFunc.History(), 1)
+				assert.Equal(t, test.expectedKey, uploadStore.UploadFunc.History()[0].Arg1)
+			} else {
+				assert.Empty(t, uploadStore.UploadFunc.History())
+			}
+		})
+	}
+}
+
+func TestServeList(t *testing.T) {
+	createdAt := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	artifactStore := store.NewMockArtifactStore()
+	artifactStore.ListFunc.SetDefaultReturn([]store.Artifact{
+		{Name: "reports/unit.xml", Size: 13, CreatedAt: createdAt, ExpiresAt: createdAt.Add(7 * 24 * time.Hour)},
+	}, nil)
+	svc := artifacts.NewService(artifactStore, uploadstoremocks.NewMockStore())
+
+	tests := []struct {
+		name                 string
+		siteAdmin            bool
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:                 "Site admin",
+			siteAdmin:            true,
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `[{"name":"reports/unit.xml","size":13,"createdAt":"2023-10-01T12:00:00Z","expiresAt":"2023-10-08T12:00:00Z"}]` + "\n",
+		},
+		{
+			name:                 "Not a site admin",
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: "must be site admin\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			users := dbmocks.NewMockUserStore()
+			users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{SiteAdmin: test.siteAdmin}, nil)
+			db := dbmocks.NewMockDB()
+			db.UsersFunc.SetDefaultReturn(users)
+
+			router := mux.NewRouter()
+			router.Path("/jobs/{queueName}/{jobID}/artifacts").Handler(ServeList(db, svc))
+
+			req := httptest.NewRequest(http.MethodGet, "/jobs/batches/42/artifacts", nil)
+			req = req.WithContext(actor.WithActor(req.Context(), &actor.Actor{UID: 1}))
+			rw := httptest.NewRecorder()
+			router.ServeHTTP(rw, req)
+
+			assert.Equal(t, test.expectedStatusCode, rw.Code)
+			assert.Equal(t, test.expectedResponseBody, rw.Body.String())
+		})
+	}
+}
+
+func TestServeDownload(t *testing.T) {
+	users := dbmocks.NewMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{SiteAdmin: true}, nil)
+	db := dbmocks.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+
+	artifactStore := store.NewMockArtifactStore()
+	artifactStore.GetFunc.SetDefaultHook(func(ctx context.Context, queue string, jobID int, name string) (store.Artifact, bool, error) {
+		if name != "reports/unit.xml" {
+			return store.Artifact{}, false, nil
+		}
+		return store.Artifact{Name: name, ObjectKey: "batches/42/reports/unit.xml", Size: 13}, true, nil
+	})
+	uploadStore := uploadstoremocks.NewMockStore()
+	uploadStore.GetFunc.SetDefaultReturn(io.NopCloser(strings.NewReader("<testsuites/>")), nil)
+
+	router := mux.NewRouter()
+	router.Path("/jobs/{queueName}/{jobID}/artifacts/{name:.*}").Handler(ServeDownload(db, artifacts.NewService(artifactStore, uploadStore)))
+
+	t.Run("Found", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/jobs/batches/42/artifacts/reports/unit.xml", nil)
+		req = req.WithContext(actor.WithActor(req.Context(), &actor.Actor{UID: 1}))
+		rw := httptest.NewRecorder()
+		router.ServeHTTP(rw, req)
+
+		assert.Equal(t, http.StatusOK, rw.Code)
+		assert.Equal(t, `attachment; filename="unit.xml"`, rw.Header().Get("Content-Disposition"))
+		assert.Equal(t, "<testsuites/>", rw.Body.String())
+		require.Len(t, uploadStore.GetFunc.History(), 1)
+		assert.Equal(t, "batches/42/reports/unit.xml", uploadStore.GetFunc.History()[0].Arg1)
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/jobs/batches/42/artifacts/missing.xml", nil)
+		req = req.WithContext(actor.WithActor(req.Context(), &actor.Actor{UID: 1}))
+		rw := httptest.NewRecorder()
+		router.ServeHTTP(rw, req)
+
+		assert.Equal(t, http.StatusNotFound, rw.Code)
+	})
+}
@@ -65,11 +65,21 @@ type QueueHandler[T workerutil.Record] struct {
 	// RecordTransformer is a required hook for each registered queue that transforms a generic
 	// record from that queue into the job to be given to an executor.
 	RecordTransformer TransformerFunc[T]
+	// JobRequirements is an optional hook that returns the name of the repository of a record and the
+	// requirements the record declares, so that records the requesting executor cannot run are released
+	// before they are transformed. If not set, records declare no requirements, and only the rules of the
+	// site configuration that apply to all repositories of the queue route them.
+	JobRequirements JobRequirementsFunc[T]
 }
 
 // TransformerFunc is the function to transform a workerutil.Record into an executor.Job.
 type TransformerFunc[T workerutil.Record] func(ctx context.Context, version string, record T, resourceMetadata ResourceMetadata) (executortypes.Job, error)
 
+// JobRequirementsFunc is the function that returns the name of the repository of a workerutil.Record and the
+// requirements it declares. It should be cheap and free of side effects, as it runs for records the requesting
+// executor may not run.
+type JobRequirementsFunc[T workerutil.Record] func(ctx context.Context, record T) (repositoryName string, requirements executortypes.JobRequirements, err error)
+
 // NewHandler creates a new ExecutorHandler.
 func NewHandler[T workerutil.Record](
 	executorStore database.ExecutorStore,
@@ -100,6 +110,7 @@ func (h *handler[T]) HandleDequeue(w http.ResponseWriter, r *http.Request) {
 		job, dequeued, err := h.dequeue(r.Context(), mux.Vars(r)["queueName"], executorMetadata{
 			name:    payload.ExecutorName,
 			version: payload.Version,
+			labels:  payload.Labels,
 			resources: ResourceMetadata{
 				NumCPUs:   payload.NumCPUs,
 				Memory:    payload.Memory,
@@ -131,16 +142,36 @@ func (h *handler[T]) dequeue(ctx context.Context, queueName string, metadata exe
 		}
 	}
 
-	// executorName is supposed to be unique.
-	record, dequeued, err := h.queueHandler.Store.Dequeue(ctx, metadata.name, nil)
-	if err != nil {
-		return executortypes.Job{}, false, errors.Wrap(err, "dbworkerstore.Dequeue")
-	}
-	if !dequeued {
-		return executortypes.Job{}, false, nil
+	logger := log.Scoped("dequeue", "Select a job record from the database.")
+
+	var record T
+	var released []int
+	for attempt := 1; ; attempt++ {
+		// executorName is supposed to be unique.
+		var dequeued bool
+		var err error
+		record, dequeued, err = h.queueHandler.Store.Dequeue(ctx, metadata.name, excludeReleased(released))
+		if err != nil {
+			return executortypes.Job{}, false, errors.Wrap(err, "dbworkerstore.Dequeue")
+		}
+		if !dequeued {
+			return executortypes.Job{}, false, nil
+		}
+
+		// Hand the record back if this executor cannot run it, and try the next one.
+		isReleased, err := releaseIfUnrunnable(ctx, logger, h.queueHandler, h.executorStore, metadata, record)
+		if err != nil {
+			return executortypes.Job{}, false, err
+		}
+		if !isReleased {
+			break
+		}
+		released = append(released, record.RecordID())
+		if attempt == maxDequeueAttempts {
+			return executortypes.Job{}, false, nil
+		}
 	}
 
-	logger := log.Scoped("dequeue", "Select a job record from the database.")
 	job, err := h.queueHandler.RecordTransformer(ctx, metadata.version, record, metadata.resources)
 	if err != nil {
 		if _, err := h.queueHandler.Store.MarkFailed(ctx, record.RecordID(), fmt.Sprintf("failed to transform record: %s", err), store.MarkFinalOptions{}); err != nil {
@@ -178,6 +209,7 @@ func (h *handler[T]) dequeue(ctx context.Context, queueName string, metadata exe
 type executorMetadata struct {
 	name      string
 	version   string
+	labels    []string
 	resources ResourceMetadata
 }
 
@@ -355,6 +387,10 @@ func (h *handler[T]) HandleHeartbeat(w http.ResponseWriter, r *http.Request) {
 			GitVersion:      payload.GitVersion,
 			IgniteVersion:   payload.IgniteVersion,
 			SrcCliVersion:   payload.SrcCliVersion,
+			Labels:          payload.Labels,
+			NumCPUs:         payload.NumCPUs,
+			Memory:          payload.Memory,
+			DiskSpace:       payload.DiskSpace,
 		}
 
 		// Handle metrics in the background, this should not delay the heartbeat response being
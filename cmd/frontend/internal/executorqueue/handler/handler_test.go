@@ -13,21 +13,25 @@ import (
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/lib/pq"
 	dto "github.com/prometheus/client_model/go"
 	"github.com/prometheus/common/expfmt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/executorqueue/handler"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
 	internalexecutor "github.com/sourcegraph/sourcegraph/internal/executor"
 	executorstore "github.com/sourcegraph/sourcegraph/internal/executor/store"
 	executortypes "github.com/sourcegraph/sourcegraph/internal/executor/types"
 	metricsstore "github.com/sourcegraph/sourcegraph/internal/metrics/store"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
 	dbworkerstoremocks "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store/mocks"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 	"github.com/sourcegraph/sourcegraph/lib/pointers"
+	"github.com/sourcegraph/sourcegraph/schema"
 )
 
 func TestHandler_Name(t *testing.T) {
@@ -269,6 +273,119 @@ func TestHandler_HandleDequeue(t *testing.T) {
 	}
 }
 
+func TestHandler_HandleDequeue_Requirements(t *testing.T) {
+	var transformed []int
+	transformerFunc := func(ctx context.Context, version string, record testRecord, resourceMetadata handler.ResourceMetadata) (executortypes.Job, error) {
+		transformed = append(transformed, record.RecordID())
+		return executortypes.Job{ID: record.RecordID()}, nil
+	}
+	jobRequirementsFunc := func(ctx context.Context, record testRecord) (string, executortypes.JobRequirements, error) {
+		var requirements executortypes.JobRequirements
+		if record.id == 1 {
+			requirements = executortypes.JobRequirements{Labels: []string{"gpu"}}
+		}
+		return fmt.Sprintf("github.com/sourcegraph/repo-%d", record.RecordID()), requirements, nil
+	}
+
+	tests := []struct {
+		name               string
+		body               string
+		liveExecutors      []types.Executor
+		rules              []*schema.ExecutorJobRequirements
+		expectedStatusCode int
+		expectedJobID      int
+		expectedReleased   []int
+		expectedHeldBack   bool
+	}{
+		{
+			name:               "Executor satisfies requirements",
+			body:               `{"executorName": "test-executor", "labels": ["gpu"]}`,
+			expectedStatusCode: http.StatusOK,
+			expectedJobID:      1,
+		},
+		{
+			name:               "Executor does not satisfy requirements",
+			body:               `{"executorName": "test-executor"}`,
+			liveExecutors:      []types.Executor{{Hostname: "gpu-executor", QueueName: "test", Labels: []string{"gpu"}}},
+			expectedStatusCode: http.StatusOK,
+			expectedJobID:      2,
+			expectedReleased:   []int{1},
+		},
+		{
+			name:               "No live executor satisfies requirements",
+			body:               `{"executorName": "test-executor"}`,
+			liveExecutors:      []types.Executor{{Hostname: "other-executor", QueueName: "test"}},
+			expectedStatusCode: http.StatusOK,
+			expectedJobID:      2,
+			expectedReleased:   []int{1},
+			expectedHeldBack:   true,
+		},
+		{
+			name:               "Site configuration requirements",
+			body:               `{"executorName": "test-executor", "labels": ["gpu"], "memory": "8G"}`,
+			liveExecutors:      []types.Executor{{Hostname: "big-executor", QueueName: "test", Labels: []string{"gpu"}, Memory: "64G"}},
+			rules:              []*schema.ExecutorJobRequirements{{Queue: "test", Repositories: []string{"github.com/*/repo-1"}, Memory: "32G"}},
+			expectedStatusCode: http.StatusOK,
+			expectedJobID:      2,
+			expectedReleased:   []int{1},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{ExecutorsJobRequirements: test.rules}})
+			t.Cleanup(func() { conf.Mock(nil) })
+			transformed = nil
+
+			mockStore := dbworkerstoremocks.NewMockStore[testRecord]()
+			mockStore.DequeueFunc.PushReturn(testRecord{id: 1}, true, nil)
+			mockStore.DequeueFunc.PushReturn(testRecord{id: 2}, true, nil)
+			executorStore := dbmocks.NewMockExecutorStore()
+			executorStore.ListFunc.SetDefaultReturn(test.liveExecutors, nil)
+			jobTokenStore := executorstore.NewMockJobTokenStore()
+			jobTokenStore.CreateFunc.SetDefaultReturn("sometoken", nil)
+
+			h := handler.NewHandler(
+				executorStore,
+				jobTokenStore,
+				metricsstore.NewMockDistributedStore(),
+				handler.QueueHandler[testRecord]{Name: "test", Store: mockStore, RecordTransformer: transformerFunc, JobRequirements: jobRequirementsFunc},
+			)
+
+			router := mux.NewRouter()
+			router.HandleFunc("/{queueName}", h.HandleDequeue)
+
+			req, err := http.NewRequest(http.MethodPost, "/test", strings.NewReader(test.body))
+			require.NoError(t, err)
+
+			rw := httptest.NewRecorder()
+			router.ServeHTTP(rw, req)
+
+			assert.Equal(t, test.expectedStatusCode, rw.Code)
+			require.Len(t, jobTokenStore.CreateFunc.History(), 1)
+			assert.Equal(t, test.expectedJobID, jobTokenStore.CreateFunc.History()[0].Arg1)
+
+			assert.Empty(t, mockStore.RequeueFunc.History())
+			var released []int
+			for _, call := range mockStore.ReleaseFunc.History() {
+				released = append(released, call.Arg1)
+				assert.Equal(t, test.expectedHeldBack, !call.Arg2.IsZero())
+			}
+			assert.Equal(t, test.expectedReleased, released)
+			// Released records are not transformed.
+			assert.Equal(t, []int{test.expectedJobID}, transformed)
+
+			// Released records are skipped by the following dequeue attempts.
+			dequeues := mockStore.DequeueFunc.History()
+			require.Len(t, dequeues, len(test.expectedReleased)+1)
+			assert.Empty(t, dequeues[0].Arg2)
+			if len(test.expectedReleased) > 0 {
+				require.Len(t, dequeues[1].Arg2, 1)
+				assert.Equal(t, []any{pq.Array(test.expectedReleased)}, dequeues[1].Arg2[0].Args())
+			}
+		})
+	}
+}
+
 func TestHandler_HandleAddExecutionLogEntry(t *testing.T) {
 	startTime := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
 
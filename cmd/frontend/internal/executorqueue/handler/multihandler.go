@@ -143,49 +143,26 @@ func (m *MultiHandler) dequeue(ctx context.Context, req executortypes.DequeueReq
 		DiskSpace: req.DiskSpace,
 	}
 
+	metadata := executorMetadata{
+		name:      req.ExecutorName,
+		version:   req.Version,
+		labels:    req.Labels,
+		resources: resourceMetadata,
+	}
+
 	logger := m.logger.Scoped("dequeue", "Pick a job record from the database.")
 	var job executortypes.Job
+	var dequeued bool
 	switch selectedQueue {
 	case m.BatchesQueueHandler.Name:
-		record, dequeued, err := m.BatchesQueueHandler.Store.Dequeue(ctx, req.ExecutorName, nil)
-		if err != nil {
-			err = errors.Wrapf(err, "dbworkerstore.Dequeue %s", selectedQueue)
-			logger.Error("Failed to dequeue", log.String("queue", selectedQueue), log.Error(err))
-			return executortypes.Job{}, false, err
-		}
-		if !dequeued {
-			// no batches job to dequeue. Even though the queue was populated before, another executor
-			// instance could have dequeued in the meantime
-			return executortypes.Job{}, false, nil
-		}
-
-		job, err = m.BatchesQueueHandler.RecordTransformer(ctx, req.Version, record, resourceMetadata)
-		if err != nil {
-			markErr := markRecordAsFailed(ctx, m.BatchesQueueHandler.Store, record.RecordID(), err, logger)
-			err = errors.Wrapf(errors.Append(err, markErr), "RecordTransformer %s", selectedQueue)
-			logger.Error("Failed to transform record", log.String("queue", selectedQueue), log.Error(err))
-			return executortypes.Job{}, false, err
-		}
+		job, dequeued, err = dequeueRunnable(ctx, logger, m.BatchesQueueHandler, m.executorStore, metadata)
 	case m.CodeIntelQueueHandler.Name:
-		record, dequeued, err := m.CodeIntelQueueHandler.Store.Dequeue(ctx, req.ExecutorName, nil)
-		if err != nil {
-			err = errors.Wrapf(err, "dbworkerstore.Dequeue %s", selectedQueue)
-			logger.Error("Failed to dequeue", log.String("queue", selectedQueue), log.Error(err))
-			return executortypes.Job{}, false, err
-		}
-		if !dequeued {
-			// no codeintel job to dequeue. Even though the queue was populated before, another executor
-			// instance could have dequeued in the meantime
-			return executortypes.Job{}, false, nil
-		}
-
-		job, err = m.CodeIntelQueueHandler.RecordTransformer(ctx, req.Version, record, resourceMetadata)
-		if err != nil {
-			markErr := markRecordAsFailed(ctx, m.CodeIntelQueueHandler.Store, record.RecordID(), err, logger)
-			err = errors.Wrapf(errors.Append(err, markErr), "RecordTransformer %s", selectedQueue)
-			logger.Error("Failed to transform record", log.String("queue", selectedQueue), log.Error(err))
-			return executortypes.Job{}, false, err
-		}
+		job, dequeued, err = dequeueRunnable(ctx, logger, m.CodeIntelQueueHandler, m.executorStore, metadata)
+	}
+	if err != nil || !dequeued {
+		// Even though the queue was populated before, another executor instance could have dequeued in
+		// the meantime, or this executor cannot run any of the queued jobs.
+		return executortypes.Job{}, false, err
 	}
 	job.Queue = selectedQueue
 
@@ -314,6 +291,10 @@ func (m *MultiHandler) HandleHeartbeat(w http.ResponseWriter, r *http.Request) {
 			GitVersion:      payload.GitVersion,
 			IgniteVersion:   payload.IgniteVersion,
 			SrcCliVersion:   payload.SrcCliVersion,
+			Labels:          payload.Labels,
+			NumCPUs:         payload.NumCPUs,
+			Memory:          payload.Memory,
+			DiskSpace:       payload.DiskSpace,
 		}
 
 		// Handle metrics in the background, this should not delay the heartbeat response being
@@ -415,6 +396,43 @@ func (m *MultiHandler) validateQueues(queues []string) []string {
 	return invalidQueues
 }
 
+// dequeueRunnable dequeues the next record of the given queue that the executor can run and transforms it into
+// a job. Records the executor cannot run are released, so that other executors can pick them up.
+func dequeueRunnable[T workerutil.Record](ctx context.Context, logger log.Logger, queueHandler QueueHandler[T], executorStore database.ExecutorStore, metadata executorMetadata) (executortypes.Job, bool, error) {
+	var released []int
+	for attempt := 1; attempt <= maxDequeueAttempts; attempt++ {
+		record, dequeued, err := queueHandler.Store.Dequeue(ctx, metadata.name, excludeReleased(released))
+		if err != nil {
+			err = errors.Wrapf(err, "dbworkerstore.Dequeue %s", queueHandler.Name)
+			logger.Error("Failed to dequeue", log.String("queue", queueHandler.Name), log.Error(err))
+			return executortypes.Job{}, false, err
+		}
+		if !dequeued {
+			return executortypes.Job{}, false, nil
+		}
+
+		isReleased, err := releaseIfUnrunnable(ctx, logger, queueHandler, executorStore, metadata, record)
+		if err != nil {
+			logger.Error("Failed to release record", log.String("queue", queueHandler.Name), log.Error(err))
+			return executortypes.Job{}, false, err
+		}
+		if isReleased {
+			released = append(released, record.RecordID())
+			continue
+		}
+
+		job, err := queueHandler.RecordTransformer(ctx, metadata.version, record, metadata.resources)
+		if err != nil {
+			markErr := markRecordAsFailed(ctx, queueHandler.Store, record.RecordID(), err, logger)
+			err = errors.Wrapf(errors.Append(err, markErr), "RecordTransformer %s", queueHandler.Name)
+			logger.Error("Failed to transform record", log.String("queue", queueHandler.Name), log.Error(err))
+			return executortypes.Job{}, false, err
+		}
+		return job, true, nil
+	}
+	return executortypes.Job{}, false, nil
+}
+
 func markRecordAsFailed[T workerutil.Record](context context.Context, store dbworkerstore.Store[T], recordID int, err error, logger log.Logger) error {
 	_, markErr := store.MarkFailed(context, recordID, fmt.Sprintf("failed to transform record: %s", err), dbworkerstore.MarkFinalOptions{})
 	if markErr != nil {
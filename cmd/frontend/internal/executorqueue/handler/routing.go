@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"context"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sourcegraph/log"
+	"golang.org/x/exp/slices"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	executortypes "github.com/sourcegraph/sourcegraph/internal/executor/types"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const (
+	// maxDequeueAttempts is the number of records a single dequeue request may dequeue before giving up when
+	// the requesting executor cannot run any of them. Released records keep their position at the front of the
+	// queue, so an executor that cannot run the first maxDequeueAttempts records of the queue is not handed any
+	// record until executors that can run them dequeue them, or they are held back because no live executor
+	// can run them.
+	maxDequeueAttempts = 5
+
+	// unschedulableRequeueDelay is how long a record that no live executor can run is held back.
+	unschedulableRequeueDelay = time.Minute
+
+	// maxLiveExecutors bounds the number of live executors considered when checking whether a job can run
+	// anywhere.
+	maxLiveExecutors = 1000
+)
+
+var unschedulableJobsCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "src_executor_queue_unschedulable_jobs_total",
+	Help: "The number of times a dequeued job was held back because no live executor satisfies its requirements.",
+}, []string{"queue"})
+
+// capabilities returns the capabilities advertised by the requesting executor.
+func (m executorMetadata) capabilities() executortypes.ExecutorCapabilities {
+	return executortypes.ExecutorCapabilities{
+		Labels:    m.labels,
+		NumCPUs:   m.resources.NumCPUs,
+		Memory:    m.resources.Memory,
+		DiskSpace: m.resources.DiskSpace,
+	}
+}
+
+// executorCapabilities returns the capabilities an executor advertised on its last heartbeat.
+func executorCapabilities(executor types.Executor) executortypes.ExecutorCapabilities {
+	return executortypes.ExecutorCapabilities{
+		Labels:    executor.Labels,
+		NumCPUs:   executor.NumCPUs,
+		Memory:    executor.Memory,
+		DiskSpace: executor.DiskSpace,
+	}
+}
+
+// jobRequirements returns the requirements of a job of the given repository: the ones declared by the job itself,
+// combined with those of every rule of the site configuration that matches the job.
+func jobRequirements(queueName, repositoryName string, declared executortypes.JobRequirements, rules []*schema.ExecutorJobRequirements) executortypes.JobRequirements {
+	requirements := declared
+	for _, rule := range rules {
+		if rule.Queue != queueName || !matchesRepository(rule.Repositories, repositoryName) {
+			continue
+		}
+		requirements = requirements.Merge(executortypes.JobRequirements{
+			Labels:    rule.Labels,
+			NumCPUs:   rule.Cpus,
+			Memory:    rule.Memory,
+			DiskSpace: rule.DiskSpace,
+		})
+	}
+	return requirements
+}
+
+// matchesRepository returns true if the repository name matches one of the given glob patterns, or if no
+// patterns are given. Patterns are matched with path.Match, so a `*` does not match across a `/`: use
+// `github.com/sourcegraph/*` to match the repositories of an organization, and `*/sourcegraph/*` to match them
+// on any code host.
+func matchesRepository(patterns []string, repositoryName string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, repositoryName); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// releaseIfUnrunnable releases the given record if the requesting executor does not satisfy the requirements of
+// its job, so that it can be handed out to another executor instead. The record keeps its position in the queue.
+// If no live executor of the queue satisfies the requirements either, the job is reported as unschedulable and
+// held back. It returns true if the record was released. It runs before the record is transformed into a job.
+func releaseIfUnrunnable[T workerutil.Record](
+	ctx context.Context,
+	logger log.Logger,
+	queueHandler QueueHandler[T],
+	executorStore database.ExecutorStore,
+	metadata executorMetadata,
+	record T,
+) (bool, error) {
+	var repositoryName string
+	var declared executortypes.JobRequirements
+	if queueHandler.JobRequirements != nil {
+		var err error
+		repositoryName, declared, err = queueHandler.JobRequirements(ctx, record)
+		if err != nil {
+			return false, errors.Wrap(err, "JobRequirements")
+		}
+	}
+
+	queueName := queueHandler.Name
+	requirements := jobRequirements(queueName, repositoryName, declared, conf.Get().ExecutorsJobRequirements)
+	unsatisfied := requirements.Unsatisfied(metadata.capabilities())
+	if len(unsatisfied) == 0 {
+		return false, nil
+	}
+
+	var after time.Time
+	schedulable, err := canRunAnywhere(ctx, executorStore, queueName, requirements)
+	if err != nil {
+		return false, err
+	}
+	if !schedulable {
+		unschedulableJobsCounter.WithLabelValues(queueName).Inc()
+		logger.Warn("No live executor satisfies the requirements of job",
+			log.String("queue", queueName),
+			log.Int("jobID", record.RecordID()),
+			log.String("repositoryName", repositoryName),
+			log.String("requirements", strings.Join(unsatisfied, ", ")))
+		after = time.Now().Add(unschedulableRequeueDelay)
+	}
+
+	if err := queueHandler.Store.Release(ctx, record.RecordID(), after); err != nil {
+		return false, errors.Wrap(err, "dbworkerstore.Release")
+	}
+	return true, nil
+}
+
+// excludeReleased returns the dequeue conditions that skip the records released by the current dequeue request,
+// as they keep their position at the front of the queue.
+func excludeReleased(released []int) []*sqlf.Query {
+	if len(released) == 0 {
+		return nil
+	}
+	return []*sqlf.Query{sqlf.Sprintf("id != ALL(%s)", pq.Array(released))}
+}
+
+// canRunAnywhere returns true if a live executor processing the given queue satisfies the given requirements.
+func canRunAnywhere(ctx context.Context, executorStore database.ExecutorStore, queueName string, requirements executortypes.JobRequirements) (bool, error) {
+	executors, err := executorStore.List(ctx, database.ExecutorStoreListOptions{Active: true, Limit: maxLiveExecutors})
+	if err != nil {
+		return false, errors.Wrap(err, "executorStore.List")
+	}
+	for _, executor := range executors {
+		if executor.QueueName != queueName && !slices.Contains(executor.QueueNames, queueName) {
+			continue
+		}
+		if requirements.SatisfiedBy(executorCapabilities(executor)) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
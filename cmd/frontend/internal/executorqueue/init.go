@@ -1,17 +1,27 @@
 package executorqueue
 
 import (
+	"context"
+
 	"github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/internal/conf/confdefaults"
 	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
 	"github.com/sourcegraph/sourcegraph/internal/conf/deploy"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/executor/artifacts"
+	"github.com/sourcegraph/sourcegraph/internal/executor/store"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/enterprise"
+	artifactshttpapi "github.com/sourcegraph/sourcegraph/cmd/frontend/internal/executorqueue/artifacts"
 )
 
+// LoadConfig loads the configuration of the upload store of executor job artifacts.
+func LoadConfig() {
+	artifacts.ConfigInst.Load()
+}
+
 // Init initializes the executor endpoints required for use with the executor service.
 func Init(
 	observationCtx *observation.Context,
@@ -32,6 +42,12 @@ func Init(
 
 	logger := log.Scoped("executorqueue", "")
 
+	artifactUploadStore, err := artifacts.NewUploadStore(context.Background(), observationCtx, artifacts.ConfigInst)
+	if err != nil {
+		return err
+	}
+	artifactsSvc := artifacts.NewService(store.NewArtifactStore(db), artifactUploadStore)
+
 	queueHandler := newExecutorQueuesHandler(
 		observationCtx,
 		db,
@@ -40,8 +56,11 @@ func Init(
 		codeintelUploadHandler,
 		batchesWorkspaceFileGetHandler,
 		batchesWorkspaceFileExistsHandler,
+		artifactshttpapi.ServeUpload(logger, artifactsSvc),
 	)
 
 	enterpriseServices.NewExecutorProxyHandler = queueHandler
+	enterpriseServices.ExecutorJobArtifactsHandler = artifactshttpapi.ServeList(db, artifactsSvc)
+	enterpriseServices.ExecutorJobArtifactDownloadHandler = artifactshttpapi.ServeDownload(db, artifactsSvc)
 	return nil
 }
@@ -31,6 +31,7 @@ func newExecutorQueuesHandler(
 	uploadHandler http.Handler,
 	batchesWorkspaceFileGetHandler http.Handler,
 	batchesWorkspaceFileExistsHandler http.Handler,
+	artifactUploadHandler http.Handler,
 ) func() http.Handler {
 	metricsStore := metricsstore.NewDistributedStore("executors:")
 	executorStore := db.Executors()
@@ -110,6 +111,14 @@ func newExecutorQueuesHandler(
 		// The scip route are treated as an internal actor and require the executor access token to authenticate.
 		scipRouter.Use(withInternalActor, executorAuth)
 
+		// Upload the artifacts of jobs. This route is registered before the files route, as the
+		// artifacts are authenticated for the queue of the job rather than the batches queue.
+		artifactsRouter := base.PathPrefix("/files/artifacts/{queueName}").Name("executor-artifacts").Subrouter()
+		artifactsRouter.Path("/{name:.*}").Methods(http.MethodPost).Handler(artifactUploadHandler)
+		// The artifacts route are treated as an internal actor. Additionally, each job comes with a short-lived
+		// token that is checked by jobAuthMiddleware.
+		artifactsRouter.Use(withInternalActor, jobAuthMiddleware(logger, routeArtifacts, jobTokenStore, executorStore))
+
 		filesRouter := base.PathPrefix("/files").Name("executor-files").Subrouter()
 		batchChangesRouter := filesRouter.PathPrefix("/batch-changes").Subrouter()
 		batchChangesRouter.Path("/{spec}/{file}").Methods(http.MethodGet).Handler(batchesWorkspaceFileGetHandler)
@@ -126,9 +135,10 @@ func newExecutorQueuesHandler(
 type routeName string
 
 const (
-	routeFiles = "files"
-	routeGit   = "git"
-	routeQueue = "queue"
+	routeArtifacts = "artifacts"
+	routeFiles     = "files"
+	routeGit       = "git"
+	routeQueue     = "queue"
 )
 
 // withInternalActor ensures that the request handling is running as an internal actor.
@@ -265,7 +275,7 @@ func validateJobRequest(
 		queue = "batches"
 	case routeGit:
 		repo = mux.Vars(r)["RepoName"]
-	case routeQueue:
+	case routeArtifacts, routeQueue:
 		queue = mux.Vars(r)["queueName"]
 	default:
 		logger.Error("unsupported route", log.String("route", string(routeName)))
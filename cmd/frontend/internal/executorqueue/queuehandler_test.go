@@ -155,6 +155,34 @@ func TestJobAuthMiddleware(t *testing.T) {
 				require.Len(t, executorStore.GetByHostnameFunc.History(), 0)
 			},
 		},
+		{
+			name:      "Artifacts Authorized",
+			routeName: routeArtifacts,
+			header: map[string]string{
+				"Authorization":               "Bearer somejobtoken",
+				"X-Sourcegraph-Job-ID":        "42",
+				"X-Sourcegraph-Executor-Name": "test-executor",
+			},
+			mockFunc: func(executorStore *dbmocks.MockExecutorStore, jobTokenStore *executorstore.MockJobTokenStore) {
+				jobTokenStore.GetByTokenFunc.PushReturn(executorstore.JobToken{JobID: 42, Queue: "test"}, nil)
+				executorStore.GetByHostnameFunc.PushReturn(types.Executor{}, true, nil)
+			},
+			expectedStatusCode: http.StatusTeapot,
+		},
+		{
+			name:      "Artifacts queue mismatch",
+			routeName: routeArtifacts,
+			header: map[string]string{
+				"Authorization":               "Bearer somejobtoken",
+				"X-Sourcegraph-Job-ID":        "42",
+				"X-Sourcegraph-Executor-Name": "test-executor",
+			},
+			mockFunc: func(executorStore *dbmocks.MockExecutorStore, jobTokenStore *executorstore.MockJobTokenStore) {
+				jobTokenStore.GetByTokenFunc.PushReturn(executorstore.JobToken{JobID: 42, Queue: "batches"}, nil)
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: "invalid token\n",
+		},
 		{
 			name:      "Files Authorized",
 			routeName: routeFiles,
@@ -20,10 +20,16 @@ func QueueHandler(observationCtx *observation.Context, db database.DB, _ func()
 		return transformRecord(ctx, logger, batchesStore, record, version)
 	}
 
+	jobRequirementsFunc := func(ctx context.Context, record *btypes.BatchSpecWorkspaceExecutionJob) (string, apiclient.JobRequirements, error) {
+		batchesStore := bstore.New(db, observationCtx, nil)
+		return jobRequirements(ctx, batchesStore, record)
+	}
+
 	store := bstore.NewBatchSpecWorkspaceExecutionWorkerStore(observationCtx, db.Handle())
 	return handler.QueueHandler[*btypes.BatchSpecWorkspaceExecutionJob]{
 		Name:              "batches",
 		Store:             store,
 		RecordTransformer: recordTransformer,
+		JobRequirements:   jobRequirementsFunc,
 	}
 }
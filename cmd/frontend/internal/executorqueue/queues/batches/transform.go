@@ -44,6 +44,46 @@ type BatchesStore interface {
 
 const fileStoreBucket = "batch-changes"
 
+// jobRequirements returns the name of the repository of the job and the requirements declared by its batch
+// spec. Unlike transformRecord, it does not read the secrets of the batch spec, so that executors that cannot run
+// the job don't leave entries in their access logs.
+func jobRequirements(ctx context.Context, s BatchesStore, job *btypes.BatchSpecWorkspaceExecutionJob) (string, apiclient.JobRequirements, error) {
+	workspace, err := s.GetBatchSpecWorkspace(ctx, store.GetBatchSpecWorkspaceOpts{ID: job.BatchSpecWorkspaceID})
+	if err != nil {
+		return "", apiclient.JobRequirements{}, errors.Wrapf(err, "fetching workspace %d", job.BatchSpecWorkspaceID)
+	}
+
+	batchSpec, err := s.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: workspace.BatchSpecID})
+	if err != nil {
+		return "", apiclient.JobRequirements{}, errors.Wrap(err, "fetching batch spec")
+	}
+
+	// 🚨 SECURITY: Set the actor on the context so we check for permissions
+	// when loading the repository.
+	ctx = actor.WithActor(ctx, actor.FromUser(job.UserID))
+
+	repo, err := s.DatabaseDB().Repos().Get(ctx, workspace.RepoID)
+	if err != nil {
+		return "", apiclient.JobRequirements{}, errors.Wrap(err, "fetching repo")
+	}
+
+	return string(repo.Name), executorRequirements(batchSpec), nil
+}
+
+// executorRequirements returns the requirements declared in the executor property of the batch spec.
+func executorRequirements(batchSpec *btypes.BatchSpec) apiclient.JobRequirements {
+	executor := batchSpec.Spec.Executor
+	if executor == nil {
+		return apiclient.JobRequirements{}
+	}
+	return apiclient.JobRequirements{
+		Labels:    executor.Labels,
+		NumCPUs:   executor.CPUs,
+		Memory:    executor.Memory,
+		DiskSpace: executor.DiskSpace,
+	}
+}
+
 // transformRecord transforms a *btypes.BatchSpecWorkspaceExecutionJob into an apiclient.Job.
 func transformRecord(ctx context.Context, logger log.Logger, s BatchesStore, job *btypes.BatchSpecWorkspaceExecutionJob, version string) (apiclient.Job, error) {
 	workspace, err := s.GetBatchSpecWorkspace(ctx, store.GetBatchSpecWorkspaceOpts{ID: job.BatchSpecWorkspaceID})
@@ -192,6 +232,9 @@ func transformRecord(ctx context.Context, logger log.Logger, s BatchesStore, job
 		RedactedValues: redactedEnvVars,
 	}
 
+	aj.Requirements = executorRequirements(batchSpec)
+	aj.Artifacts = batchSpec.Spec.Artifacts
+
 	if job.Version == 2 {
 		helperImage := fmt.Sprintf("%s:%s", conf.ExecutorsBatcheshelperImage(), conf.ExecutorsBatcheshelperImageTag())
 
@@ -397,3 +397,41 @@ steps:
 		mockassert.CalledN(t, sal.CreateFunc, 5)
 	})
 }
+
+func TestJobRequirements(t *testing.T) {
+	db := dbmocks.NewMockDB()
+	repos := dbmocks.NewMockRepoStore()
+	repos.GetFunc.SetDefaultHook(func(ctx context.Context, id api.RepoID) (*types.Repo, error) {
+		return &types.Repo{ID: id, Name: "github.com/sourcegraph/sourcegraph"}, nil
+	})
+	db.ReposFunc.SetDefaultReturn(repos)
+	secs := dbmocks.NewMockExecutorSecretStore()
+	db.ExecutorSecretsFunc.SetDefaultReturn(secs)
+
+	batchSpec := &btypes.BatchSpec{
+		UserID: 123,
+		Spec: &batcheslib.BatchSpec{
+			Executor: &batcheslib.ExecutorRequirements{Labels: []string{"gpu"}, Memory: "32G"},
+		},
+	}
+	workspace := &btypes.BatchSpecWorkspace{BatchSpecID: batchSpec.ID, RepoID: 5678}
+
+	store := NewMockBatchesStore()
+	store.GetBatchSpecFunc.SetDefaultReturn(batchSpec, nil)
+	store.GetBatchSpecWorkspaceFunc.SetDefaultReturn(workspace, nil)
+	store.DatabaseDBFunc.SetDefaultReturn(db)
+
+	repositoryName, requirements, err := jobRequirements(context.Background(), store, &btypes.BatchSpecWorkspaceExecutionJob{ID: 42, UserID: 123})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if repositoryName != "github.com/sourcegraph/sourcegraph" {
+		t.Errorf("unexpected repository name %q", repositoryName)
+	}
+	if diff := cmp.Diff(apiclient.JobRequirements{Labels: []string{"gpu"}, Memory: "32G"}, requirements); diff != "" {
+		t.Errorf("unexpected requirements (-want +got):\n%s", diff)
+	}
+
+	// The secrets of the batch spec are only read once the job is transformed.
+	mockassert.NotCalled(t, secs.ListFunc)
+}
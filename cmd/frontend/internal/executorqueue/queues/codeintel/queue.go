@@ -19,9 +19,15 @@ func QueueHandler(observationCtx *observation.Context, db database.DB, accessTok
 
 	store := dbworkerstore.New(observationCtx, db.Handle(), autoindexing.IndexWorkerStoreOptions)
 
+	// Indexes don't declare requirements of their own, but may be routed by the site configuration.
+	jobRequirements := func(_ context.Context, record uploadsshared.Index) (string, apiclient.JobRequirements, error) {
+		return record.RepositoryName, apiclient.JobRequirements{}, nil
+	}
+
 	return handler.QueueHandler[uploadsshared.Index]{
 		Name:              "codeintel",
 		Store:             store,
 		RecordTransformer: recordTransformer,
+		JobRequirements:   jobRequirements,
 	}
 }
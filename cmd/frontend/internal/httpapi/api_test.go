@@ -40,11 +40,15 @@ func newTest(t *testing.T) *httptestutil.Client {
 			GitLabSyncWebhook:               enterpriseServices.ReposGitLabWebhook,
 			BitbucketServerSyncWebhook:      enterpriseServices.ReposBitbucketServerWebhook,
 			BitbucketCloudSyncWebhook:       enterpriseServices.ReposBitbucketCloudWebhook,
+			GiteaSyncWebhook:                enterpriseServices.ReposGiteaWebhook,
 			BatchesBitbucketServerWebhook:   enterpriseServices.BatchesBitbucketServerWebhook,
 			BatchesBitbucketCloudWebhook:    enterpriseServices.BatchesBitbucketCloudWebhook,
 			BatchesAzureDevOpsWebhook:       enterpriseServices.BatchesAzureDevOpsWebhook,
+			BatchesGiteaWebhook:             enterpriseServices.BatchesGiteaWebhook,
 			SCIMHandler:                     enterpriseServices.SCIMHandler,
 			NewCodeIntelUploadHandler:       enterpriseServices.NewCodeIntelUploadHandler,
+			CodeIntelSCIPExportHandler:      enterpriseServices.CodeIntelSCIPExportHandler,
+			CodeIntelDeadCodeReportHandler:  enterpriseServices.CodeIntelDeadCodeReportHandler,
 			NewComputeStreamHandler:         enterpriseServices.NewComputeStreamHandler,
 			PermissionsGitHubWebhook:        enterpriseServices.PermissionsGitHubWebhook,
 			NewChatCompletionsStreamHandler: enterpriseServices.NewChatCompletionsStreamHandler,
@@ -48,6 +48,7 @@ type Handlers struct {
 	GitLabSyncWebhook          webhooks.Registerer
 	BitbucketServerSyncWebhook webhooks.Registerer
 	BitbucketCloudSyncWebhook  webhooks.Registerer
+	GiteaSyncWebhook           webhooks.Registerer
 
 	// Permissions
 	PermissionsGitHubWebhook webhooks.Registerer
@@ -58,6 +59,7 @@ type Handlers struct {
 	BatchesBitbucketServerWebhook   webhooks.RegistererHandler
 	BatchesBitbucketCloudWebhook    webhooks.RegistererHandler
 	BatchesAzureDevOpsWebhook       webhooks.Registerer
+	BatchesGiteaWebhook             webhooks.Registerer
 	BatchesChangesFileGetHandler    http.Handler
 	BatchesChangesFileExistsHandler http.Handler
 	BatchesChangesFileUploadHandler http.Handler
@@ -66,18 +68,26 @@ type Handlers struct {
 	SCIMHandler http.Handler
 
 	// Code intel
-	NewCodeIntelUploadHandler enterprise.NewCodeIntelUploadHandler
+	NewCodeIntelUploadHandler      enterprise.NewCodeIntelUploadHandler
+	CodeIntelSCIPExportHandler     http.Handler
+	CodeIntelDeadCodeReportHandler http.Handler
 
 	// Compute
 	NewComputeStreamHandler enterprise.NewComputeStreamHandler
 
 	// Code Insights
-	CodeInsightsDataExportHandler http.Handler
+	CodeInsightsDataExportHandler        http.Handler
+	CodeInsightsMetricsHandler           http.Handler
+	CodeInsightsMetricsQueryRangeHandler http.Handler
 
 	// Search jobs
 	SearchJobsDataExportHandler http.Handler
 	SearchJobsLogsHandler       http.Handler
 
+	// Executor job artifacts
+	ExecutorJobArtifactsHandler        http.Handler
+	ExecutorJobArtifactDownloadHandler http.Handler
+
 	// Dotcom license check
 	NewDotcomLicenseCheckHandler enterprise.NewDotcomLicenseCheckHandler
 
@@ -137,6 +147,8 @@ func NewHandler(
 	handlers.GitLabSyncWebhook.Register(&wh)
 	handlers.PermissionsGitHubWebhook.Register(&wh)
 	handlers.BatchesAzureDevOpsWebhook.Register(&wh)
+	handlers.GiteaSyncWebhook.Register(&wh)
+	handlers.BatchesGiteaWebhook.Register(&wh)
 	// 🚨 SECURITY: This handler implements its own secret-based auth
 	webhookHandler := webhooks.NewHandler(logger, db, &wh)
 
@@ -157,11 +169,15 @@ func NewHandler(
 	m.Get(apirouter.LSIFUpload).Handler(trace.Route(lsifDeprecationHandler))
 	m.Get(apirouter.SCIPUpload).Handler(trace.Route(handlers.NewCodeIntelUploadHandler(true)))
 	m.Get(apirouter.SCIPUploadExists).Handler(trace.Route(noopHandler))
+	m.Get(apirouter.SCIPExport).Handler(trace.Route(handlers.CodeIntelSCIPExportHandler))
+	m.Get(apirouter.CodeIntelDeadCodeReport).Handler(trace.Route(handlers.CodeIntelDeadCodeReportHandler))
 	m.Get(apirouter.ComputeStream).Handler(trace.Route(handlers.NewComputeStreamHandler()))
 	m.Get(apirouter.ChatCompletionsStream).Handler(trace.Route(handlers.NewChatCompletionsStreamHandler()))
 	m.Get(apirouter.CodeCompletions).Handler(trace.Route(handlers.NewCodeCompletionsHandler()))
 
 	m.Get(apirouter.CodeInsightsDataExport).Handler(trace.Route(handlers.CodeInsightsDataExportHandler))
+	m.Get(apirouter.CodeInsightsMetrics).Handler(trace.Route(handlers.CodeInsightsMetricsHandler))
+	m.Get(apirouter.CodeInsightsMetricsQueryRange).Handler(trace.Route(handlers.CodeInsightsMetricsQueryRangeHandler))
 
 	if envvar.SourcegraphDotComMode() {
 		m.Path("/app/check/update").Name(codyapp.RouteAppUpdateCheck).Handler(trace.Route(codyapp.AppUpdateHandler(logger)))
@@ -185,6 +201,9 @@ func NewHandler(
 	m.Get(apirouter.SearchJobResults).Handler(trace.Route(handlers.SearchJobsDataExportHandler))
 	m.Get(apirouter.SearchJobLogs).Handler(trace.Route(handlers.SearchJobsLogsHandler))
 
+	m.Get(apirouter.ExecutorJobArtifacts).Handler(trace.Route(handlers.ExecutorJobArtifactsHandler))
+	m.Get(apirouter.ExecutorJobArtifactDownload).Handler(trace.Route(handlers.ExecutorJobArtifactDownloadHandler))
+
 	// Return the minimum src-cli version that's compatible with this instance
 	m.Get(apirouter.SrcCli).Handler(trace.Route(newSrcCliVersionHandler(logger)))
 
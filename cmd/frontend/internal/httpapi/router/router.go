@@ -13,6 +13,9 @@ const (
 	LSIFUpload       = "lsif.upload"
 	SCIPUpload       = "scip.upload"
 	SCIPUploadExists = "scip.upload.exists"
+	SCIPExport       = "scip.export"
+
+	CodeIntelDeadCodeReport = "codeintel.dead-code-report"
 
 	SearchStream          = "search.stream"
 	SearchJobResults      = "search.job.results"
@@ -42,7 +45,12 @@ const (
 	BatchesFileExists = "batches.file.exists"
 	BatchesFileUpload = "batches.file.upload"
 
-	CodeInsightsDataExport = "insights.data.export"
+	CodeInsightsDataExport        = "insights.data.export"
+	CodeInsightsMetrics           = "insights.metrics"
+	CodeInsightsMetricsQueryRange = "insights.metrics.query-range"
+
+	ExecutorJobArtifacts        = "executors.job.artifacts"
+	ExecutorJobArtifactDownload = "executors.job.artifact.download"
 
 	GitInfoRefs         = "internal.git.info-refs"
 	GitUploadPack       = "internal.git.upload-pack"
@@ -78,6 +86,8 @@ func New(base *mux.Router) *mux.Router {
 	base.Path("/lsif/upload").Methods("POST").Name(LSIFUpload)
 	base.Path("/scip/upload").Methods("POST").Name(SCIPUpload)
 	base.Path("/scip/upload").Methods("HEAD").Name(SCIPUploadExists)
+	base.Path("/scip/export").Methods("GET").Name(SCIPExport)
+	base.Path("/codeintel/dead-code-report").Methods("GET").Name(CodeIntelDeadCodeReport)
 	base.Path("/search/stream").Methods("GET").Name(SearchStream)
 	base.Path("/search/export/{id}.csv").Methods("GET").Name(SearchJobResults)
 	base.Path("/search/export/{id}.log").Methods("GET").Name(SearchJobLogs)
@@ -86,6 +96,10 @@ func New(base *mux.Router) *mux.Router {
 	base.Path("/src-cli/versions/{rest:.*}").Methods("GET", "POST").Name(SrcCliVersionCache)
 	base.Path("/src-cli/{rest:.*}").Methods("GET").Name(SrcCli)
 	base.Path("/insights/export/{id}").Methods("GET").Name(CodeInsightsDataExport)
+	base.Path("/insights/metrics").Methods("GET").Name(CodeInsightsMetrics)
+	base.Path("/insights/metrics/query_range").Methods("GET").Name(CodeInsightsMetricsQueryRange)
+	base.Path("/executors/jobs/{queueName}/{jobID:[0-9]+}/artifacts").Methods("GET").Name(ExecutorJobArtifacts)
+	base.Path("/executors/jobs/{queueName}/{jobID:[0-9]+}/artifacts/{name:.*}").Methods("GET").Name(ExecutorJobArtifactDownload)
 	base.Path("/completions/stream").Methods("POST").Name(ChatCompletionsStream)
 	base.Path("/completions/code").Methods("POST").Name(CodeCompletions)
 
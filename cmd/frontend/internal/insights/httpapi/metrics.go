@@ -0,0 +1,356 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	edb "github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/licensing"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	// seriesMetricName is the name of the metric insight series points are exposed as.
+	seriesMetricName = "src_insights_series_value"
+
+	// seriesRecordingTimeMetricName is the name of the metric the recording times of the exposed points are
+	// exposed as.
+	seriesRecordingTimeMetricName = "src_insights_series_recording_timestamp_seconds"
+
+	// defaultRangeDuration is the range of the range API if no start is given.
+	defaultRangeDuration = 30 * 24 * time.Hour
+
+	// insightKind is the kind of the GraphQL IDs of insight views.
+	insightKind = "insight_view"
+)
+
+// MetricsHandler exposes code insights series in the Prometheus and OpenMetrics text formats, so they can be
+// scraped by Prometheus or queried from dashboards such as Grafana.
+type MetricsHandler struct {
+	seriesStore          *store.Store
+	permStore            *store.InsightPermStore
+	insightStore         *store.InsightStore
+	searchContextHandler *store.SearchContextHandler
+}
+
+func NewMetricsHandler(db database.DB, insightsDB edb.InsightsDB) *MetricsHandler {
+	insightPermStore := store.NewInsightPermissionStore(db)
+	return &MetricsHandler{
+		seriesStore:          store.New(insightsDB, insightPermStore),
+		permStore:            insightPermStore,
+		insightStore:         store.NewInsightStore(insightsDB),
+		searchContextHandler: store.NewSearchContextHandler(db),
+	}
+}
+
+// MetricsFunc serves the latest point of every series of the insights visible to the user. The insights can be
+// restricted with one or more `insight` query parameters.
+func (h *MetricsHandler) MetricsFunc() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		points, err := h.seriesPoints(r.Context(), r.URL.Query()["insight"], store.MetricsOpts{LatestOnly: true})
+		if err != nil {
+			writeMetricsError(w, err)
+			return
+		}
+
+		format := expfmt.NegotiateIncludingOpenMetrics(r.Header)
+		w.Header().Set("Content-Type", string(format))
+		encoder := expfmt.NewEncoder(w, format)
+		for _, family := range seriesMetricFamilies(points) {
+			if err := encoder.Encode(family); err != nil {
+				http.Error(w, fmt.Sprintf("failed to write metrics: %v", err), http.StatusInternalServerError)
+				return
+			}
+		}
+		if closer, ok := encoder.(expfmt.Closer); ok {
+			_ = closer.Close()
+		}
+	}
+}
+
+// QueryRangeFunc serves the points of every series of the insights visible to the user that were recorded
+// between the `start` and `end` query parameters. The response has the same shape as a range query of the
+// Prometheus HTTP API, with a sample per recording time.
+func (h *MetricsHandler) QueryRangeFunc() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		now := time.Now()
+
+		end, err := parseMetricsTime(query.Get("end"), now)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid end: %v", err), http.StatusBadRequest)
+			return
+		}
+		start, err := parseMetricsTime(query.Get("start"), end.Add(-defaultRangeDuration))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid start: %v", err), http.StatusBadRequest)
+			return
+		}
+		if end.Before(start) {
+			http.Error(w, "end must not be before start", http.StatusBadRequest)
+			return
+		}
+
+		points, err := h.seriesPoints(r.Context(), query["insight"], store.MetricsOpts{From: &start, To: &end})
+		if err != nil {
+			writeMetricsError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(rangeQueryResponse{
+			Status: "success",
+			Data: rangeQueryData{
+				ResultType: "matrix",
+				Result:     seriesMatrix(points),
+			},
+		}); err != nil {
+			http.Error(w, fmt.Sprintf("failed to write data: %v", err), http.StatusInternalServerError)
+		}
+	}
+}
+
+func writeMetricsError(w http.ResponseWriter, err error) {
+	if errors.Is(err, notFoundError) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+	} else if errors.Is(err, authenticationError) {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+	} else if errors.Is(err, invalidLicenseError) {
+		http.Error(w, err.Error(), http.StatusForbidden)
+	} else {
+		http.Error(w, fmt.Sprintf("failed to fetch metrics: %v", err), http.StatusInternalServerError)
+	}
+}
+
+// seriesPoints returns the points of the series of the given insight views, or of all insight views if none
+// are given, that are visible to the current user.
+func (h *MetricsHandler) seriesPoints(ctx context.Context, ids []string, opts store.MetricsOpts) ([]store.SeriesPointForMetrics, error) {
+	if !actor.FromContext(ctx).IsAuthenticated() {
+		return nil, authenticationError
+	}
+	userIDs, orgIDs, err := h.permStore.GetUserPermissions(ctx)
+	if err != nil {
+		return nil, authenticationError
+	}
+	if err := licensing.Check(licensing.FeatureCodeInsights); err != nil {
+		return nil, invalidLicenseError
+	}
+
+	uniqueIDs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		var insightViewID string
+		if err := relay.UnmarshalSpec(graphql.ID(id), &insightViewID); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal insight view ID")
+		}
+		uniqueIDs = append(uniqueIDs, insightViewID)
+	}
+
+	isFrozen := false
+	visibleViewSeries, err := h.insightStore.GetAll(ctx, store.InsightQueryArgs{
+		UniqueIDs:            uniqueIDs,
+		UserIDs:              userIDs,
+		OrgIDs:               orgIDs,
+		IsFrozen:             &isFrozen,
+		WithoutAuthorization: false,
+	})
+	if err != nil {
+		return nil, errors.New("could not fetch insight information")
+	}
+	// 🚨 SECURITY: if the user context doesn't get any response here that means they should not be able to access
+	// the requested insights.
+	if len(uniqueIDs) > 0 && len(visibleViewSeries) == 0 {
+		return nil, notFoundError
+	}
+
+	var points []store.SeriesPointForMetrics
+	for _, view := range uniqueViews(visibleViewSeries) {
+		viewOpts := opts
+		viewOpts.InsightViewUniqueID = view.UniqueID
+		if view.DefaultFilterIncludeRepoRegex != nil {
+			viewOpts.IncludeRepoRegex = append(viewOpts.IncludeRepoRegex, *view.DefaultFilterIncludeRepoRegex)
+		}
+		if view.DefaultFilterExcludeRepoRegex != nil {
+			viewOpts.ExcludeRepoRegex = append(viewOpts.ExcludeRepoRegex, *view.DefaultFilterExcludeRepoRegex)
+		}
+		inc, exc, err := h.searchContextHandler.UnwrapSearchContexts(ctx, view.DefaultFilterSearchContexts)
+		if err != nil {
+			return nil, errors.Wrap(err, "search context error")
+		}
+		viewOpts.IncludeRepoRegex = append(viewOpts.IncludeRepoRegex, inc...)
+		viewOpts.ExcludeRepoRegex = append(viewOpts.ExcludeRepoRegex, exc...)
+
+		viewPoints, err := h.seriesStore.GetMetricsDataForInsightViewID(ctx, viewOpts)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to fetch data for insight")
+		}
+		points = append(points, viewPoints...)
+	}
+	return points, nil
+}
+
+// uniqueViews returns the first series of each insight view, which holds the view's default filters.
+func uniqueViews(viewSeries []types.InsightViewSeries) []types.InsightViewSeries {
+	seen := make(map[string]struct{}, len(viewSeries))
+	views := make([]types.InsightViewSeries, 0, len(viewSeries))
+	for _, series := range viewSeries {
+		if _, ok := seen[series.UniqueID]; ok {
+			continue
+		}
+		seen[series.UniqueID] = struct{}{}
+		views = append(views, series)
+	}
+	return views
+}
+
+// seriesLabels returns the labels a point is exposed with. The repository and capture labels are omitted for
+// points that have none.
+func seriesLabels(point store.SeriesPointForMetrics) map[string]string {
+	labels := map[string]string{
+		"insight_id":    string(relay.MarshalID(insightKind, point.InsightViewUniqueID)),
+		"insight_title": point.InsightViewTitle,
+		"series_id":     point.SeriesID,
+		"series_label":  point.SeriesLabel,
+	}
+	if point.RepoName != nil {
+		labels["repo"] = *point.RepoName
+	}
+	if point.Capture != nil {
+		labels["capture"] = *point.Capture
+	}
+	return labels
+}
+
+// seriesMetricFamilies returns the families of the value and the recording time of the latest point of each
+// label set. The samples carry no timestamp, as Prometheus drops samples with timestamps older than the
+// ones it has already ingested, and points are only recorded at the interval of their insight.
+func seriesMetricFamilies(points []store.SeriesPointForMetrics) []*dto.MetricFamily {
+	latest := latestSeriesPoints(points)
+
+	values := make([]*dto.Metric, 0, len(latest))
+	recordingTimes := make([]*dto.Metric, 0, len(latest))
+	for _, point := range latest {
+		labelPairs := seriesLabelPairs(point)
+		value := point.Value
+		recordingTime := float64(point.RecordingTime.UnixMilli()) / 1000
+		values = append(values, &dto.Metric{Label: labelPairs, Gauge: &dto.Gauge{Value: &value}})
+		recordingTimes = append(recordingTimes, &dto.Metric{Label: labelPairs, Gauge: &dto.Gauge{Value: &recordingTime}})
+	}
+
+	return []*dto.MetricFamily{
+		gaugeFamily(seriesMetricName, "The latest recorded value of a code insights series, per repository and capture group value.", values),
+		gaugeFamily(seriesRecordingTimeMetricName, "The time the latest value of a code insights series was recorded at, per repository and capture group value.", recordingTimes),
+	}
+}
+
+// latestSeriesPoints returns the latest point of each label set, in the order the label sets first appear in.
+func latestSeriesPoints(points []store.SeriesPointForMetrics) []store.SeriesPointForMetrics {
+	latest := make([]store.SeriesPointForMetrics, 0, len(points))
+	index := map[string]int{}
+	for _, point := range points {
+		key := fmt.Sprint(seriesLabels(point))
+		i, ok := index[key]
+		if !ok {
+			index[key] = len(latest)
+			latest = append(latest, point)
+			continue
+		}
+		if point.RecordingTime.After(latest[i].RecordingTime) {
+			latest[i] = point
+		}
+	}
+	return latest
+}
+
+// seriesLabelPairs returns the labels of a point sorted by name.
+func seriesLabelPairs(point store.SeriesPointForMetrics) []*dto.LabelPair {
+	labels := seriesLabels(point)
+	labelPairs := make([]*dto.LabelPair, 0, len(labels))
+	for key, value := range labels {
+		key, value := key, value
+		labelPairs = append(labelPairs, &dto.LabelPair{Name: &key, Value: &value})
+	}
+	sort.Slice(labelPairs, func(i, j int) bool { return *labelPairs[i].Name < *labelPairs[j].Name })
+	return labelPairs
+}
+
+func gaugeFamily(name, help string, metrics []*dto.Metric) *dto.MetricFamily {
+	metricType := dto.MetricType_GAUGE
+	return &dto.MetricFamily{
+		Name:   &name,
+		Help:   &help,
+		Type:   &metricType,
+		Metric: metrics,
+	}
+}
+
+type rangeQueryResponse struct {
+	Status string         `json:"status"`
+	Data   rangeQueryData `json:"data"`
+}
+
+type rangeQueryData struct {
+	ResultType string             `json:"resultType"`
+	Result     []rangeQuerySeries `json:"result"`
+}
+
+type rangeQuerySeries struct {
+	Metric map[string]string `json:"metric"`
+	// Values are pairs of a unix timestamp in seconds and a value formatted as a string.
+	Values [][2]any `json:"values"`
+}
+
+// seriesMatrix groups the given points, which must be ordered by recording time within each series, by their
+// labels.
+func seriesMatrix(points []store.SeriesPointForMetrics) []rangeQuerySeries {
+	result := []rangeQuerySeries{}
+	index := map[string]int{}
+	for _, point := range points {
+		labels := seriesLabels(point)
+		labels["__name__"] = seriesMetricName
+
+		key := fmt.Sprint(labels)
+		i, ok := index[key]
+		if !ok {
+			i = len(result)
+			index[key] = i
+			result = append(result, rangeQuerySeries{Metric: labels})
+		}
+		result[i].Values = append(result[i].Values, [2]any{
+			float64(point.RecordingTime.UnixMilli()) / 1000,
+			strconv.FormatFloat(point.Value, 'f', -1, 64),
+		})
+	}
+	return result
+}
+
+// parseMetricsTime parses a time given as an RFC 3339 timestamp or as a unix timestamp in seconds, like the
+// Prometheus HTTP API does.
+func parseMetricsTime(value string, defaultTime time.Time) (time.Time, error) {
+	if value == "" {
+		return defaultTime, nil
+	}
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		whole, fraction := math.Modf(seconds)
+		return time.Unix(int64(whole), int64(fraction*float64(time.Second))).UTC(), nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, errors.Newf("cannot parse %q as a unix or RFC 3339 timestamp", value)
+	}
+	return t, nil
+}
@@ -0,0 +1,77 @@
+package httpapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/common/expfmt"
+
+	"github.com/sourcegraph/sourcegraph/internal/insights/store"
+)
+
+func TestSeriesMetrics(t *testing.T) {
+	repo := "github.com/sourcegraph/sourcegraph"
+	capture := "1.20"
+	first := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	second := first.Add(time.Hour)
+	points := []store.SeriesPointForMetrics{
+		{InsightViewUniqueID: "view1", InsightViewTitle: "Go versions", SeriesID: "s1", SeriesLabel: "versions", RecordingTime: first, RepoName: &repo, Capture: &capture, Value: 2},
+		{InsightViewUniqueID: "view1", InsightViewTitle: "Go versions", SeriesID: "s1", SeriesLabel: "versions", RecordingTime: second, RepoName: &repo, Capture: &capture, Value: 3.5},
+	}
+
+	t.Run("text format", func(t *testing.T) {
+		var buf bytes.Buffer
+		encoder := expfmt.NewEncoder(&buf, expfmt.FmtText)
+		// Only the latest point of a label set is exposed, regardless of the order of the points.
+		for _, family := range seriesMetricFamilies([]store.SeriesPointForMetrics{points[1], points[0]}) {
+			if err := encoder.Encode(family); err != nil {
+				t.Fatal(err)
+			}
+		}
+		want := `# HELP src_insights_series_value The latest recorded value of a code insights series, per repository and capture group value.
+# TYPE src_insights_series_value gauge
+src_insights_series_value{capture="1.20",insight_id="aW5zaWdodF92aWV3OiJ2aWV3MSI=",insight_title="Go versions",repo="github.com/sourcegraph/sourcegraph",series_id="s1",series_label="versions"} 3.5
+# HELP src_insights_series_recording_timestamp_seconds The time the latest value of a code insights series was recorded at, per repository and capture group value.
+# TYPE src_insights_series_recording_timestamp_seconds gauge
+src_insights_series_recording_timestamp_seconds{capture="1.20",insight_id="aW5zaWdodF92aWV3OiJ2aWV3MSI=",insight_title="Go versions",repo="github.com/sourcegraph/sourcegraph",series_id="s1",series_label="versions"} 1.6725348e+09
+`
+		if diff := cmp.Diff(want, buf.String()); diff != "" {
+			t.Errorf("unexpected metrics (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("range matrix", func(t *testing.T) {
+		got, err := json.Marshal(seriesMatrix(points))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := `[{"metric":{"__name__":"src_insights_series_value","capture":"1.20","insight_id":"aW5zaWdodF92aWV3OiJ2aWV3MSI=","insight_title":"Go versions","repo":"github.com/sourcegraph/sourcegraph","series_id":"s1","series_label":"versions"},"values":[[1672531200,"2"],[1672534800,"3.5"]]}]`
+		if diff := cmp.Diff(want, string(got)); diff != "" {
+			t.Errorf("unexpected matrix (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestParseMetricsTime(t *testing.T) {
+	defaultTime := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	for input, want := range map[string]time.Time{
+		"":                     defaultTime,
+		"1672534800":           time.Date(2023, 1, 1, 1, 0, 0, 0, time.UTC),
+		"1672534800.5":         time.Date(2023, 1, 1, 1, 0, 0, int(500*time.Millisecond), time.UTC),
+		"2023-01-02T00:00:00Z": time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+	} {
+		got, err := parseMetricsTime(input, defaultTime)
+		if err != nil {
+			t.Fatalf("unexpected error for %q: %s", input, err)
+		}
+		if !got.Equal(want) {
+			t.Errorf("unexpected time for %q: want %s got %s", input, want, got)
+		}
+	}
+	if _, err := parseMetricsTime("yesterday", defaultTime); err == nil {
+		t.Error("expected error")
+	}
+}
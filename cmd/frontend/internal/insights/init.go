@@ -40,6 +40,9 @@ func Init(
 	}
 	enterpriseServices.InsightsResolver = resolvers.New(rawInsightsDB, db)
 	enterpriseServices.CodeInsightsDataExportHandler = httpapi.NewExportHandler(db, rawInsightsDB).ExportFunc()
+	metricsHandler := httpapi.NewMetricsHandler(db, rawInsightsDB)
+	enterpriseServices.CodeInsightsMetricsHandler = metricsHandler.MetricsFunc()
+	enterpriseServices.CodeInsightsMetricsQueryRangeHandler = metricsHandler.QueryRangeFunc()
 
 	return nil
 }
@@ -0,0 +1,266 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/insights/background/alerts"
+	"github.com/sourcegraph/sourcegraph/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const insightSeriesAlertKind = "InsightSeriesAlert"
+
+var _ graphqlbackend.InsightSeriesAlertResolver = &insightSeriesAlertResolver{}
+var _ graphqlbackend.InsightSeriesAlertActionResolver = &insightSeriesAlertActionResolver{}
+var _ graphqlbackend.InsightSeriesAlertEventResolver = &insightSeriesAlertEventResolver{}
+
+func (r *Resolver) InsightSeriesAlerts(ctx context.Context, args graphqlbackend.InsightSeriesAlertsArgs) ([]graphqlbackend.InsightSeriesAlertResolver, error) {
+	series, err := r.seriesForAlerts(ctx, args.InsightViewId, args.SeriesId)
+	if err != nil {
+		return nil, err
+	}
+
+	alertStore := store.NewAlertStoreWith(r.insightStore)
+	rules, err := alertStore.ListAlertRules(ctx, store.AlertRuleQueryArgs{SeriesID: series.InsightSeriesID})
+	if err != nil {
+		return nil, errors.Wrap(err, "ListAlertRules")
+	}
+
+	// 🚨 SECURITY: Alerts are evaluated with the repository permissions of their creator, so their history is only
+	// visible to the creator.
+	uid := actor.FromContext(ctx).UID
+	resolvers := make([]graphqlbackend.InsightSeriesAlertResolver, 0, len(rules))
+	for _, rule := range rules {
+		if rule.CreatedBy != uid {
+			continue
+		}
+		resolvers = append(resolvers, &insightSeriesAlertResolver{alertStore: alertStore, rule: rule, seriesID: series.SeriesID})
+	}
+	return resolvers, nil
+}
+
+func (r *Resolver) CreateInsightSeriesAlert(ctx context.Context, args *graphqlbackend.CreateInsightSeriesAlertArgs) (graphqlbackend.InsightSeriesAlertResolver, error) {
+	input := args.Input
+	series, err := r.seriesForAlerts(ctx, input.InsightViewId, input.SeriesId)
+	if err != nil {
+		return nil, err
+	}
+
+	uid := actor.FromContext(ctx).UID
+	rule := types.SeriesAlertRule{
+		SeriesID:  series.InsightSeriesID,
+		Condition: types.AlertCondition(input.Condition),
+		Threshold: input.Threshold,
+		Lookback:  1,
+		Enabled:   true,
+		CreatedBy: uid,
+	}
+	if input.Lookback != nil {
+		rule.Lookback = int(*input.Lookback)
+	}
+	if input.Description != nil {
+		rule.Description = *input.Description
+	}
+	if err := alerts.ValidateRule(rule); err != nil {
+		return nil, err
+	}
+
+	if len(input.Actions) == 0 {
+		return nil, errors.New("at least one action is required")
+	}
+	for _, a := range input.Actions {
+		action := types.SeriesAlertAction{Type: types.AlertActionType(a.Type)}
+		switch action.Type {
+		case types.AlertActionEmail:
+			action.RecipientUserID = uid
+		case types.AlertActionWebhook, types.AlertActionSlackWebhook:
+			if a.Url == nil || *a.Url == "" {
+				return nil, errors.Newf("a URL is required for %s actions", a.Type)
+			}
+			action.URL = *a.Url
+		default:
+			return nil, errors.Newf("unknown action type %q", a.Type)
+		}
+		rule.Actions = append(rule.Actions, action)
+	}
+
+	alertStore := store.NewAlertStoreWith(r.insightStore)
+	created, err := alertStore.CreateAlertRule(ctx, rule)
+	if err != nil {
+		return nil, errors.Wrap(err, "CreateAlertRule")
+	}
+	return &insightSeriesAlertResolver{alertStore: alertStore, rule: created, seriesID: series.SeriesID}, nil
+}
+
+func (r *Resolver) DeleteInsightSeriesAlert(ctx context.Context, args *graphqlbackend.DeleteInsightSeriesAlertArgs) (*graphqlbackend.EmptyResponse, error) {
+	var id int
+	if err := relay.UnmarshalSpec(args.Id, &id); err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling the insight series alert id")
+	}
+
+	alertStore := store.NewAlertStoreWith(r.insightStore)
+	rule, err := alertStore.GetAlertRule(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "GetAlertRule")
+	}
+	if rule == nil {
+		return nil, errors.New("Insight series alert not found.")
+	}
+
+	// 🚨 SECURITY: Only the creator of an alert or a site admin may delete it.
+	actr := actor.FromContext(ctx)
+	if rule.CreatedBy != actr.UID {
+		if err := auth.CheckUserIsSiteAdmin(ctx, r.postgresDB, actr.UID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := alertStore.DeleteAlertRule(ctx, id); err != nil {
+		return nil, errors.Wrap(err, "DeleteAlertRule")
+	}
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
+// seriesForAlerts returns the series with the given unique ID if it belongs to the given insight view, and the
+// user has access to the view.
+func (r *Resolver) seriesForAlerts(ctx context.Context, insightViewID graphql.ID, seriesID string) (*types.InsightViewSeries, error) {
+	if actor.FromContext(ctx).UID == 0 {
+		return nil, errors.New("must be authenticated")
+	}
+
+	var viewID string
+	if err := relay.UnmarshalSpec(insightViewID, &viewID); err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling the insight view id")
+	}
+	permissionsValidator := PermissionsValidatorFromBase(&r.baseInsightResolver)
+	if err := permissionsValidator.validateUserAccessForView(ctx, viewID); err != nil {
+		return nil, err
+	}
+
+	insights, err := r.insightStore.GetMapped(ctx, store.InsightQueryArgs{WithoutAuthorization: true, UniqueID: viewID})
+	if err != nil {
+		return nil, errors.Wrap(err, "GetMapped")
+	}
+	if len(insights) != 1 {
+		return nil, errors.New("Insight not found.")
+	}
+	for _, series := range insights[0].Series {
+		if series.SeriesID == seriesID {
+			return &series, nil
+		}
+	}
+	return nil, errors.New("Series not found in insight.")
+}
+
+type insightSeriesAlertResolver struct {
+	alertStore *store.AlertStore
+	rule       *types.SeriesAlertRule
+	seriesID   string
+}
+
+func (r *insightSeriesAlertResolver) ID() graphql.ID {
+	return relay.MarshalID(insightSeriesAlertKind, r.rule.ID)
+}
+
+func (r *insightSeriesAlertResolver) SeriesId() string {
+	return r.seriesID
+}
+
+func (r *insightSeriesAlertResolver) Condition() string {
+	return string(r.rule.Condition)
+}
+
+func (r *insightSeriesAlertResolver) Threshold() float64 {
+	return r.rule.Threshold
+}
+
+func (r *insightSeriesAlertResolver) Lookback() int32 {
+	return int32(r.rule.Lookback)
+}
+
+func (r *insightSeriesAlertResolver) Description() string {
+	return r.rule.Description
+}
+
+func (r *insightSeriesAlertResolver) Enabled() bool {
+	return r.rule.Enabled
+}
+
+func (r *insightSeriesAlertResolver) Actions() []graphqlbackend.InsightSeriesAlertActionResolver {
+	resolvers := make([]graphqlbackend.InsightSeriesAlertActionResolver, 0, len(r.rule.Actions))
+	for _, action := range r.rule.Actions {
+		resolvers = append(resolvers, &insightSeriesAlertActionResolver{action: action})
+	}
+	return resolvers
+}
+
+func (r *insightSeriesAlertResolver) CreatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.rule.CreatedAt}
+}
+
+func (r *insightSeriesAlertResolver) History(ctx context.Context, args *graphqlbackend.InsightSeriesAlertHistoryArgs) ([]graphqlbackend.InsightSeriesAlertEventResolver, error) {
+	limit := 50
+	if args.First != nil {
+		limit = int(*args.First)
+	}
+	events, err := r.alertStore.ListAlertEvents(ctx, store.AlertEventQueryArgs{RuleID: r.rule.ID, Limit: limit})
+	if err != nil {
+		return nil, errors.Wrap(err, "ListAlertEvents")
+	}
+	resolvers := make([]graphqlbackend.InsightSeriesAlertEventResolver, 0, len(events))
+	for _, event := range events {
+		resolvers = append(resolvers, &insightSeriesAlertEventResolver{event: event})
+	}
+	return resolvers, nil
+}
+
+type insightSeriesAlertActionResolver struct {
+	action types.SeriesAlertAction
+}
+
+func (r *insightSeriesAlertActionResolver) Type() string {
+	return string(r.action.Type)
+}
+
+func (r *insightSeriesAlertActionResolver) Url() *string {
+	if r.action.URL == "" {
+		return nil
+	}
+	return &r.action.URL
+}
+
+type insightSeriesAlertEventResolver struct {
+	event *types.SeriesAlertEvent
+}
+
+func (r *insightSeriesAlertEventResolver) Time() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.event.PointTime}
+}
+
+func (r *insightSeriesAlertEventResolver) Capture() *string {
+	return r.event.Capture
+}
+
+func (r *insightSeriesAlertEventResolver) Value() float64 {
+	return r.event.Value
+}
+
+func (r *insightSeriesAlertEventResolver) Baseline() *float64 {
+	return r.event.Baseline
+}
+
+func (r *insightSeriesAlertEventResolver) Message() string {
+	return r.event.Message
+}
+
+func (r *insightSeriesAlertEventResolver) CreatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.event.CreatedAt}
+}
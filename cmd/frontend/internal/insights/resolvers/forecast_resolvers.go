@@ -0,0 +1,83 @@
+package resolvers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/insights/timeseries"
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// maxForecastIntervals matches the maximum number of samples a series displays.
+const maxForecastIntervals = 90
+
+var _ graphqlbackend.InsightSeriesForecastResolver = &insightSeriesForecastResolver{}
+var _ graphqlbackend.InsightForecastPointResolver = insightForecastPointResolver{}
+
+// Forecast projects the points already loaded for the series. The projection is computed on every request and
+// is never written to the insights database.
+func (p *precalculatedInsightSeriesResolver) Forecast(ctx context.Context, args *graphqlbackend.InsightsForecastArgs) (graphqlbackend.InsightSeriesForecastResolver, error) {
+	if args.Intervals < 0 || args.Intervals > maxForecastIntervals {
+		return nil, errors.Newf("intervals must be between 0 and %d", maxForecastIntervals)
+	}
+
+	points := removeClosePoints(p.points, p.series)
+	observations := make([]timeseries.Observation, 0, len(points))
+	for _, point := range points {
+		observations = append(observations, timeseries.Observation{Time: point.Time, Value: point.Value})
+	}
+
+	forecast, err := timeseries.MakeForecast(observations, timeseries.ForecastOptions{
+		Method: timeseries.ForecastMethod(strings.ToLower(args.Method)),
+		Interval: timeseries.TimeInterval{
+			Unit:  types.IntervalUnit(p.series.SampleIntervalUnit),
+			Value: p.series.SampleIntervalValue,
+		},
+		Intervals: int(args.Intervals),
+		Target:    args.Target,
+	})
+	if err != nil {
+		if errors.Is(err, timeseries.ErrInsufficientData) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "MakeForecast")
+	}
+	return &insightSeriesForecastResolver{forecast: forecast}, nil
+}
+
+type insightSeriesForecastResolver struct {
+	forecast *timeseries.Forecast
+}
+
+func (r *insightSeriesForecastResolver) Method() string {
+	return strings.ToUpper(string(r.forecast.Method))
+}
+
+func (r *insightSeriesForecastResolver) Points() []graphqlbackend.InsightForecastPointResolver {
+	resolvers := make([]graphqlbackend.InsightForecastPointResolver, 0, len(r.forecast.Points))
+	for _, point := range r.forecast.Points {
+		resolvers = append(resolvers, insightForecastPointResolver{p: point})
+	}
+	return resolvers
+}
+
+func (r *insightSeriesForecastResolver) TargetDateTime() *gqlutil.DateTime {
+	return gqlutil.DateTimeOrNil(r.forecast.TargetTime)
+}
+
+type insightForecastPointResolver struct {
+	p timeseries.ForecastPoint
+}
+
+func (i insightForecastPointResolver) DateTime() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: i.p.Time}
+}
+
+func (i insightForecastPointResolver) Value() float64 { return i.p.Value }
+
+func (i insightForecastPointResolver) LowerBound() float64 { return i.p.Lower }
+
+func (i insightForecastPointResolver) UpperBound() float64 { return i.p.Upper }
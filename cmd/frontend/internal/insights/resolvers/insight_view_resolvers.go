@@ -22,6 +22,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/insights/background"
 	"github.com/sourcegraph/sourcegraph/internal/insights/query/querybuilder"
 	"github.com/sourcegraph/sourcegraph/internal/insights/scheduler"
+	"github.com/sourcegraph/sourcegraph/internal/insights/script"
 	"github.com/sourcegraph/sourcegraph/internal/insights/store"
 	"github.com/sourcegraph/sourcegraph/internal/insights/types"
 	"github.com/sourcegraph/sourcegraph/internal/licensing"
@@ -340,6 +341,10 @@ func (s *searchInsightDataSeriesDefinitionResolver) GeneratedFromCaptureGroups()
 	return s.series.GeneratedFromCaptureGroups, nil
 }
 
+func (s *searchInsightDataSeriesDefinitionResolver) GeneratedFromScript() (bool, error) {
+	return s.series.GenerationMethod == types.Script, nil
+}
+
 func (s *searchInsightDataSeriesDefinitionResolver) GroupBy() (*string, error) {
 	if s.series.GroupBy != nil {
 		groupBy := strings.ToUpper(*s.series.GroupBy)
@@ -612,7 +617,7 @@ func (r *Resolver) UpdateLineChartSearchInsight(ctx context.Context, args *graph
 	// Capture group insight only have 1 associated insight series at most.
 	captureGroupInsight := false
 	for _, newSeries := range args.Input.DataSeries {
-		if isCaptureGroupSeries(newSeries.GeneratedFromCaptureGroups) {
+		if isCaptureGroupSeries(newSeries.GeneratedFromCaptureGroups) || isScriptSeries(newSeries) {
 			captureGroupInsight = true
 			break
 		}
@@ -666,6 +671,10 @@ func isCaptureGroupSeries(generatedFromCaptureGroups *bool) bool {
 	return *generatedFromCaptureGroups
 }
 
+func isScriptSeries(series graphqlbackend.LineChartSearchInsightDataSeriesInput) bool {
+	return series.GeneratedFromScript != nil && *series.GeneratedFromScript
+}
+
 func updateCaptureGroupInsight(ctx context.Context, input graphqlbackend.LineChartSearchInsightDataSeriesInput, existingSeries []types.InsightViewSeries, view types.InsightView, tx *store.InsightStore, seriesFillStrategy fillSeriesStrategy) error {
 	if len(existingSeries) == 0 {
 		// This should not happen, but if we somehow have no existing series for an insight, create one.
@@ -744,6 +753,9 @@ func existingSeriesHasChanged(new graphqlbackend.LineChartSearchInsightDataSerie
 	if new.Query != existing.Query {
 		return true
 	}
+	if isScriptSeries(new) != (existing.GenerationMethod == types.Script) {
+		return true
+	}
 	if new.TimeScope.StepInterval.Unit != existing.SampleIntervalUnit {
 		return true
 	}
@@ -1316,7 +1328,14 @@ func createAndAttachSeries(ctx context.Context, tx *store.InsightStore, startSer
 	var err error
 	var dynamic bool
 	// Validate the query before creating anything; we don't want faulty insights running pointlessly.
-	if series.GroupBy != nil || series.GeneratedFromCaptureGroups != nil {
+	if isScriptSeries(series) {
+		if series.GroupBy != nil {
+			return errors.New("script series cannot be grouped")
+		}
+		if err := script.Validate(series.Query); err != nil {
+			return errors.Wrap(err, "script validation")
+		}
+	} else if series.GroupBy != nil || series.GeneratedFromCaptureGroups != nil {
 		if _, err := querybuilder.ParseComputeQuery(series.Query, gitserver.NewClient()); err != nil {
 			return errors.Wrap(err, "query validation")
 		}
@@ -1329,6 +1348,10 @@ func createAndAttachSeries(ctx context.Context, tx *store.InsightStore, startSer
 	if series.GeneratedFromCaptureGroups != nil {
 		dynamic = *series.GeneratedFromCaptureGroups
 	}
+	if isScriptSeries(series) {
+		// Each label returned by a script is recorded like a capture group value.
+		dynamic = true
+	}
 
 	groupBy := lowercaseGroupBy(series.GroupBy)
 	var nextRecordingAfter time.Time
@@ -1399,6 +1422,9 @@ func createAndAttachSeries(ctx context.Context, tx *store.InsightStore, startSer
 }
 
 func searchGenerationMethod(series graphqlbackend.LineChartSearchInsightDataSeriesInput) types.GenerationMethod {
+	if isScriptSeries(series) {
+		return types.Script
+	}
 	if series.GeneratedFromCaptureGroups != nil && *series.GeneratedFromCaptureGroups {
 		if series.GroupBy != nil {
 			return types.MappingCompute
@@ -1627,6 +1653,9 @@ func isValidSeriesInput(seriesInput graphqlbackend.LineChartSearchInsightDataSer
 	if !repoListSpecified && seriesInput.GroupBy != nil {
 		return errors.New("group by series require a list of repositories to be specified.")
 	}
+	if !repoListSpecified && isScriptSeries(seriesInput) {
+		return errors.New("script series require a list of repositories to be specified.")
+	}
 
 	if repoCriteriaSpecified {
 		plan, err := querybuilder.ParseQuery(*seriesInput.RepositoryScope.RepositoryCriteria, "literal")
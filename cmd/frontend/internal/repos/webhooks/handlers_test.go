@@ -30,6 +30,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
 	gitlabwebhooks "github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab/webhooks"
 	internalgrpc "github.com/sourcegraph/sourcegraph/internal/grpc"
 	"github.com/sourcegraph/sourcegraph/internal/grpc/defaults"
@@ -302,3 +303,39 @@ func TestBitbucketCloudHandler(t *testing.T) {
 	}
 	assert.Equal(t, repoName, updateQueued)
 }
+
+func TestGiteaHandler(t *testing.T) {
+	repoName := "gitea.sgdev.org/sgtest/go-jsonschema"
+
+	db := dbmocks.NewMockDB()
+	repositories := dbmocks.NewMockRepoStore()
+	repositories.GetFirstRepoNameByCloneURLFunc.SetDefaultHook(func(ctx context.Context, s string) (api.RepoName, error) {
+		return "gitea.sgdev.org/sgtest/go-jsonschema", nil
+	})
+	db.ReposFunc.SetDefaultReturn(repositories)
+
+	handler := NewGiteaHandler()
+	data, err := os.ReadFile("testdata/gitea-push.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var payload gitea.PushEvent
+	if err := json.Unmarshal(data, &payload); err != nil {
+		t.Fatal(err)
+	}
+
+	var updateQueued string
+	repoupdater.MockEnqueueRepoUpdate = func(ctx context.Context, repo api.RepoName) (*protocol.RepoUpdateResponse, error) {
+		updateQueued = string(repo)
+		return &protocol.RepoUpdateResponse{
+			ID:   1,
+			Name: string(repo),
+		}, nil
+	}
+	t.Cleanup(func() { repoupdater.MockEnqueueRepoUpdate = nil })
+
+	if err := handler.handlePushEvent(context.Background(), db, &payload); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, repoName, updateQueued)
+}
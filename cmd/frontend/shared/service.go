@@ -6,6 +6,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/cli"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/codeintel"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/executorqueue"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/debugserver"
 	"github.com/sourcegraph/sourcegraph/internal/env"
@@ -25,6 +26,7 @@ func (svc) Configure() (env.Config, []debugserver.Endpoint) {
 	CLILoadConfig()
 	codeintel.LoadConfig()
 	search.LoadConfig()
+	executorqueue.LoadConfig()
 	return nil, CreateDebugServerEndpoints()
 }
 
@@ -0,0 +1,76 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func (wr *Router) HandleGiteaWebhook(ctx context.Context, logger log.Logger, w http.ResponseWriter, codeHostURN extsvc.CodeHostBaseURL, eventType string, payload []byte) {
+	// 🚨 SECURITY: now that the shared secret has been validated, we can use an
+	// internal actor on the context.
+	ctx = actor.WithInternalActor(ctx)
+
+	event, err := gitea.ParseWebhookEvent(eventType, payload)
+	if err != nil {
+		var unknown gitea.UnknownWebhookEventType
+		if errors.As(err, &unknown) {
+			// We don't want to return a non-2XX status code and have Gitea
+			// retry the webhook, so we'll log that we don't know what to do
+			// and return 204.
+			logger.Debug("unknown event type", log.Error(err))
+
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			w.WriteHeader(http.StatusNoContent)
+			fmt.Fprintf(w, "%v", err)
+		} else {
+			http.Error(w, errors.Wrap(err, "unmarshalling webhook payload").Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// Route the request based on the event type.
+	err = wr.Dispatch(ctx, eventType, extsvc.KindGitea, codeHostURN, event)
+	if err != nil {
+		logger.Error("Error handling Gitea webhook event", log.Error(err))
+		if errcode.IsNotFound(err) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (wr *Router) handleGiteaWebhook(logger log.Logger, w http.ResponseWriter, r *http.Request, urn extsvc.CodeHostBaseURL, secret string) {
+	payload, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "Error while reading request body.", http.StatusInternalServerError)
+		return
+	}
+	if err := r.Body.Close(); err != nil {
+		http.Error(w, "Closing body", http.StatusInternalServerError)
+		return
+	}
+
+	if secret != "" && !gitea.ValidateSignature(r, payload, secret) {
+		http.Error(w, "Could not validate payload with secret.", http.StatusBadRequest)
+		return
+	}
+
+	eventType := gitea.WebhookEventType(r)
+	if eventType == "" {
+		http.Error(w, "Missing event type header.", http.StatusBadRequest)
+		return
+	}
+
+	wr.HandleGiteaWebhook(r.Context(), logger, w, urn, eventType, payload)
+}
@@ -142,6 +142,9 @@ func handler(logger log.Logger, db database.DB, wh *Router) http.HandlerFunc {
 		case extsvc.KindAzureDevOps:
 			wh.HandleAzureDevOpsWebhook(logger, w, r, webhook.CodeHostURN)
 			return
+		case extsvc.KindGitea:
+			wh.handleGiteaWebhook(logger, w, r, webhook.CodeHostURN, secret)
+			return
 		}
 
 		http.Error(w, fmt.Sprintf("webhooks not implemented for code host kind %q", webhook.CodeHostKind), http.StatusNotImplemented)
@@ -5,6 +5,7 @@ import (
 	"context"
 	"crypto/hmac"
 	"crypto/sha1"
+	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -27,6 +28,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab/webhooks"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 )
@@ -100,6 +102,16 @@ func TestWebhooksHandler(t *testing.T) {
 	)
 	require.NoError(t, err)
 
+	giteaWH, err := dbWebhooks.Create(
+		context.Background(),
+		"gitea webhook",
+		extsvc.KindGitea,
+		"https://gitea.example.com",
+		u.ID,
+		types.NewUnencryptedSecret("giteasecret"),
+	)
+	require.NoError(t, err)
+
 	wr := Router{Logger: logger, DB: db}
 	gwh := GitHubWebhook{Router: &wr}
 
@@ -457,6 +469,74 @@ func TestWebhooksHandler(t *testing.T) {
 
 		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
 	})
+
+	t.Run("Gitea with correct secret returns 200", func(t *testing.T) {
+		requestURL := fmt.Sprintf("%s/.api/webhooks/%v", srv.URL, giteaWH.UUID)
+
+		event := gitea.PushEvent{Ref: "refs/heads/main"}
+		payload, err := json.Marshal(event)
+		require.NoError(t, err)
+		wh := &fakeWebhookHandler{}
+		wr.handlers = map[string]eventHandlers{
+			extsvc.KindGitea: {
+				gitea.EventPush: []Handler{wh.handleEvent},
+			},
+		}
+
+		h := hmac.New(sha256.New, []byte("giteasecret"))
+		h.Write(payload)
+
+		req, err := http.NewRequest("POST", requestURL, bytes.NewBuffer(payload))
+		require.NoError(t, err)
+		req.Header.Set(gitea.EventHeader, gitea.EventPush)
+		req.Header.Set(gitea.SignatureHeader, hex.EncodeToString(h.Sum(nil)))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, giteaWH.CodeHostURN, wh.codeHostURNReceived)
+		assert.Equal(t, &event, wh.eventReceived)
+	})
+
+	t.Run("incorrect Gitea secret returns 400", func(t *testing.T) {
+		requestURL := fmt.Sprintf("%s/.api/webhooks/%v", srv.URL, giteaWH.UUID)
+
+		payload := []byte(`{"ref": "refs/heads/main"}`)
+		h := hmac.New(sha256.New, []byte("wrongsecret"))
+		h.Write(payload)
+
+		req, err := http.NewRequest("POST", requestURL, bytes.NewBuffer(payload))
+		require.NoError(t, err)
+		req.Header.Set(gitea.EventHeader, gitea.EventPush)
+		req.Header.Set(gitea.SignatureHeader, hex.EncodeToString(h.Sum(nil)))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("Gitea returns 204 if webhook event type unknown", func(t *testing.T) {
+		requestURL := fmt.Sprintf("%s/.api/webhooks/%v", srv.URL, giteaWH.UUID)
+
+		payload := []byte(`{}`)
+		h := hmac.New(sha256.New, []byte("giteasecret"))
+		h.Write(payload)
+
+		req, err := http.NewRequest("POST", requestURL, bytes.NewBuffer(payload))
+		require.NoError(t, err)
+		req.Header.Set(gitea.EventHeader, "wiki")
+		req.Header.Set(gitea.SignatureHeader, hex.EncodeToString(h.Sum(nil)))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	})
 }
 
 type fakeWebhookHandler struct {
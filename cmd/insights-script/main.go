@@ -0,0 +1,17 @@
+// insights-script runs a single Lua script of a scripted code insight series, and is started by the
+// worker for every repository and commit the series is recorded at. It reads the script from stdin and
+// reaches the repository through the worker, so that the CPU time and memory the script uses can be
+// bounded without affecting the worker.
+package main // import "github.com/sourcegraph/sourcegraph/cmd/insights-script"
+
+import (
+	"os"
+
+	"github.com/sourcegraph/sourcegraph/internal/insights/script/helper"
+	"github.com/sourcegraph/sourcegraph/internal/sanitycheck"
+)
+
+func main() {
+	sanitycheck.Pass()
+	os.Exit(helper.Run(os.Stdin, os.Stdout, os.Stderr))
+}
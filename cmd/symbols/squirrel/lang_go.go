@@ -0,0 +1,1095 @@
+package squirrel
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/grafana/regexp"
+	sitter "github.com/smacker/go-tree-sitter"
+
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func (s *SquirrelService) getDefGo(ctx context.Context, node Node) (ret *Node, err error) {
+	defer s.onCall(node, String(node.Type()), lazyNodeStringer(&ret))()
+
+	switch node.Type() {
+	case "identifier":
+		fallthrough
+	case "type_identifier":
+		ident := node.Content(node.Contents)
+
+		cur := node.Node
+
+		for {
+			prev := cur
+			cur = cur.Parent()
+			if cur == nil {
+				s.breadcrumb(node, "getDefGo: ran out of parents")
+				return nil, nil
+			}
+
+			switch cur.Type() {
+
+			case "source_file":
+				return s.getDefInFileOrPackageGo(ctx, swapNode(node, cur), ident)
+
+			case "qualified_type":
+				//     vvvv type_identifier
+				// pkg.Type
+				name := cur.ChildByFieldName("name")
+				pkg := cur.ChildByFieldName("package")
+				if name == nil || pkg == nil || nodeId(name) != nodeId(prev) {
+					continue
+				}
+				return s.getDefInImportedPackageGo(ctx, swapNode(node, getRoot(cur)), pkg.Content(node.Contents), ident)
+
+			// Check nodes that might have bindings:
+			case "block":
+				fallthrough
+			case "statement_list":
+				for blockChild := prev.PrevNamedSibling(); blockChild != nil; blockChild = blockChild.PrevNamedSibling() {
+					if found := findDeclInStatementGo(swapNode(node, blockChild), ident); found != nil {
+						return found, nil
+					}
+				}
+				continue
+
+			case "function_declaration":
+				fallthrough
+			case "method_declaration":
+				fallthrough
+			case "func_literal":
+				for _, field := range []string{"receiver", "type_parameters", "parameters", "result"} {
+					if found := findParameterGo(swapNode(node, cur.ChildByFieldName(field)), ident); found != nil {
+						return found, nil
+					}
+				}
+				continue
+
+			case "type_declaration":
+				// Type parameters of generic types
+				for _, spec := range children(cur) {
+					if spec.Type() != "type_spec" {
+						continue
+					}
+					if found := findParameterGo(swapNode(node, spec.ChildByFieldName("type_parameters")), ident); found != nil {
+						return found, nil
+					}
+				}
+				continue
+
+			case "if_statement":
+				fallthrough
+			case "expression_switch_statement":
+				fallthrough
+			case "type_switch_statement":
+				if found := findDeclInStatementGo(swapNode(node, cur.ChildByFieldName("initializer")), ident); found != nil {
+					return found, nil
+				}
+				if cur.Type() == "type_switch_statement" {
+					//        v alias
+					// switch x := y.(type) { ... }
+					if found := findIdentInListGo(swapNode(node, cur.ChildByFieldName("alias")), ident); found != nil {
+						return found, nil
+					}
+				}
+				continue
+
+			case "for_statement":
+				for _, child := range children(cur) {
+					switch child.Type() {
+					case "for_clause":
+						if found := findDeclInStatementGo(swapNode(node, child.ChildByFieldName("initializer")), ident); found != nil {
+							return found, nil
+						}
+					case "range_clause":
+						if found := findDeclInStatementGo(swapNode(node, child), ident); found != nil {
+							return found, nil
+						}
+					}
+				}
+				continue
+
+			case "communication_case":
+				if found := findDeclInStatementGo(swapNode(node, cur.ChildByFieldName("communication")), ident); found != nil {
+					return found, nil
+				}
+				continue
+
+			// Skip all other nodes
+			default:
+				continue
+			}
+		}
+
+	case "field_identifier":
+		parent := node.Parent()
+		if parent == nil {
+			return nil, nil
+		}
+
+		switch parent.Type() {
+		case "selector_expression":
+			operand := parent.ChildByFieldName("operand")
+			if operand == nil {
+				return nil, nil
+			}
+			return s.getFieldGo(ctx, swapNode(node, operand), node.Content(node.Contents))
+
+		case "keyed_element":
+			//     vvvvv field_identifier
+			// T{Field: ...}
+			literalValue := parent.Parent()
+			if literalValue == nil || literalValue.Type() != "literal_value" {
+				return nil, nil
+			}
+			compositeLiteral := literalValue.Parent()
+			if compositeLiteral == nil || compositeLiteral.Type() != "composite_literal" {
+				return nil, nil
+			}
+			ty := compositeLiteral.ChildByFieldName("type")
+			if ty == nil {
+				return nil, nil
+			}
+			found, err := s.getTypeDefGo(ctx, swapNode(node, ty))
+			if err != nil {
+				return nil, err
+			}
+			if found == nil {
+				return nil, nil
+			}
+			return s.lookupFieldGo(ctx, found, node.Content(node.Contents))
+
+		// Fields and methods are their own definitions
+		case "field_declaration":
+			fallthrough
+		case "method_declaration":
+			fallthrough
+		case "method_spec":
+			fallthrough
+		case "method_elem":
+			return &node, nil
+
+		default:
+			return nil, nil
+		}
+
+	case "package_identifier":
+		parent := node.Parent()
+		if parent == nil {
+			return nil, nil
+		}
+		if parent.Type() == "import_spec" {
+			return s.resolveImportSpecGo(ctx, swapNode(node, parent))
+		}
+		return s.getDefInImportsGo(ctx, swapNode(node, getRoot(node.Node)), node.Content(node.Contents))
+
+	// No other nodes have a definition
+	default:
+		return nil, nil
+	}
+}
+
+// getDefInFileOrPackageGo looks for a package-level declaration in the given file, then for an
+// import with the given name, and then for a package-level declaration in another file of the
+// same package.
+func (s *SquirrelService) getDefInFileOrPackageGo(ctx context.Context, program Node, ident string) (ret *Node, err error) {
+	defer s.onCall(program, &Tuple{String(program.Type()), String(ident)}, lazyNodeStringer(&ret))()
+
+	if found := findTopLevelDeclGo(program, ident); found != nil {
+		return found, nil
+	}
+
+	found, err := s.getDefInImportsGo(ctx, program, ident)
+	if err != nil {
+		return nil, err
+	}
+	if found != nil {
+		return found, nil
+	}
+
+	return s.getDefInPackageGo(ctx, program, dirGo(program.RepoCommitPath.Path), ident)
+}
+
+// getDefInImportsGo returns the directory of the package imported under the given name, or a
+// package-level declaration of a dot-imported package.
+func (s *SquirrelService) getDefInImportsGo(ctx context.Context, program Node, ident string) (ret *Node, err error) {
+	defer s.onCall(program, &Tuple{String(program.Type()), String(ident)}, lazyNodeStringer(&ret))()
+
+	dotImports := []Node{}
+	for _, spec := range allCaptures("(import_spec) @spec", program) {
+		name := spec.ChildByFieldName("name")
+		if name != nil && name.Type() == "dot" {
+			dotImports = append(dotImports, spec)
+			continue
+		}
+		if importSpecNameGo(spec) != ident {
+			continue
+		}
+		return s.resolveImportSpecGo(ctx, spec)
+	}
+
+	for _, spec := range dotImports {
+		dir, err := s.resolveImportSpecGo(ctx, spec)
+		if err != nil {
+			return nil, err
+		}
+		if dir == nil {
+			continue
+		}
+		found, err := s.getDefInPackageGo(ctx, program, dir.RepoCommitPath.Path, ident)
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			return found, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// getDefInImportedPackageGo looks up a package-level declaration in the package imported under the
+// given name.
+func (s *SquirrelService) getDefInImportedPackageGo(ctx context.Context, program Node, pkg string, ident string) (ret *Node, err error) {
+	defer s.onCall(program, &Tuple{String(pkg), String(ident)}, lazyNodeStringer(&ret))()
+
+	dir, err := s.getDefInImportsGo(ctx, program, pkg)
+	if err != nil {
+		return nil, err
+	}
+	if dir == nil || dir.Node != nil {
+		return nil, nil
+	}
+	return s.getDefInPackageGo(ctx, program, dir.RepoCommitPath.Path, ident)
+}
+
+// getDefInPackageGo looks up a package-level declaration in the package in the given directory.
+func (s *SquirrelService) getDefInPackageGo(ctx context.Context, program Node, dir string, ident string) (*Node, error) {
+	candidates, err := s.symbolSearchN(
+		ctx,
+		program.RepoCommitPath.Repo,
+		program.RepoCommitPath.Commit,
+		[]string{packageFilesPatternGo(dir)},
+		ident,
+		maxSymbolCandidatesGo,
+	)
+	if err != nil {
+		return nil, err
+	}
+	for _, candidate := range candidates {
+		if isTopLevelDeclGo(candidate) {
+			return &candidate, nil
+		}
+	}
+	return nil, nil
+}
+
+// resolveImportSpecGo returns the directory of the imported package if it lives in the same
+// repository, and nil otherwise.
+func (s *SquirrelService) resolveImportSpecGo(ctx context.Context, spec Node) (*Node, error) {
+	path := spec.ChildByFieldName("path")
+	if path == nil {
+		return nil, nil
+	}
+	importPath := strings.Trim(path.Content(spec.Contents), "\"`")
+
+	modRoot, modPath, err := s.findModuleGo(ctx, spec.RepoCommitPath)
+	if err != nil {
+		return nil, err
+	}
+	if modPath == "" {
+		return nil, nil
+	}
+
+	var dir string
+	if importPath == modPath {
+		dir = modRoot
+	} else if strings.HasPrefix(importPath, modPath+"/") {
+		dir = filepath.Join(modRoot, strings.TrimPrefix(importPath, modPath+"/"))
+	} else {
+		s.breadcrumb(spec, fmt.Sprintf("resolveImportSpecGo: %q is outside of module %q", importPath, modPath))
+		return nil, nil
+	}
+
+	return &Node{
+		RepoCommitPath: types.RepoCommitPath{
+			Repo:   spec.RepoCommitPath.Repo,
+			Commit: spec.RepoCommitPath.Commit,
+			Path:   dir,
+		},
+		Node:     nil,
+		Contents: spec.Contents,
+		LangSpec: spec.LangSpec,
+	}, nil
+}
+
+var modulePathRegexGo = regexp.MustCompile(`(?m)^module\s+"?([^"\s]+)"?`)
+
+// findModuleGo returns the directory and path of the Go module that contains the given file by
+// reading the nearest go.mod file.
+func (s *SquirrelService) findModuleGo(ctx context.Context, file types.RepoCommitPath) (root string, modPath string, err error) {
+	dir := dirGo(file.Path)
+	for {
+		contents, err := s.readFile(ctx, types.RepoCommitPath{
+			Repo:   file.Repo,
+			Commit: file.Commit,
+			Path:   filepath.Join(dir, "go.mod"),
+		})
+		if err != nil && ctx.Err() != nil {
+			return "", "", ctx.Err()
+		}
+		if err == nil {
+			if matches := modulePathRegexGo.FindSubmatch(contents); matches != nil {
+				return dir, string(matches[1]), nil
+			}
+		}
+
+		if dir == "" {
+			return "", "", nil
+		}
+		dir = dirGo(dir)
+	}
+}
+
+func (s *SquirrelService) getFieldGo(ctx context.Context, operand Node, field string) (ret *Node, err error) {
+	defer s.onCall(operand, &Tuple{String(operand.Type()), String(field)}, lazyNodeStringer(&ret))()
+
+	// Selectors on imported packages, e.g. pkg.Func
+	if operand.Type() == "identifier" {
+		found, err := s.getDefGo(ctx, operand)
+		if err != nil {
+			return nil, err
+		}
+		if found != nil && found.Node == nil {
+			return s.getDefInPackageGo(ctx, operand, found.RepoCommitPath.Path, field)
+		}
+	}
+
+	ty, err := s.getTypeDefGo(ctx, operand)
+	if err != nil {
+		return nil, err
+	}
+	if ty == nil {
+		return nil, nil
+	}
+	return s.lookupFieldGo(ctx, ty, field)
+}
+
+func (s *SquirrelService) lookupFieldGo(ctx context.Context, ty TypeGo, field string) (ret *Node, err error) {
+	defer s.onCall(ty.node(), &Tuple{String(ty.variant()), String(field)}, lazyNodeStringer(&ret))()
+
+	switch ty2 := ty.(type) {
+	case NamedTypeGo:
+		underlying := ty2.spec.ChildByFieldName("type")
+		if underlying != nil {
+			found, embedded := findFieldInTypeLiteralGo(swapNode(ty2.spec, underlying), field)
+			if found != nil {
+				return found, nil
+			}
+
+			found, err := s.lookupMethodGo(ctx, ty2, field)
+			if err != nil {
+				return nil, err
+			}
+			if found != nil {
+				return found, nil
+			}
+
+			return s.lookupPromotedFieldGo(ctx, embedded, field)
+		}
+		return s.lookupMethodGo(ctx, ty2, field)
+	case LiteralTypeGo:
+		found, embedded := findFieldInTypeLiteralGo(ty2.def, field)
+		if found != nil {
+			return found, nil
+		}
+		return s.lookupPromotedFieldGo(ctx, embedded, field)
+	case FnTypeGo:
+		s.breadcrumb(ty.node(), fmt.Sprintf("lookupFieldGo: unexpected object type %s", ty.variant()))
+		return nil, nil
+	default:
+		s.breadcrumb(ty.node(), fmt.Sprintf("lookupFieldGo: unrecognized type variant %q", ty.variant()))
+		return nil, nil
+	}
+}
+
+// lookupPromotedFieldGo looks up fields and methods promoted from embedded fields.
+func (s *SquirrelService) lookupPromotedFieldGo(ctx context.Context, embedded []Node, field string) (*Node, error) {
+	for _, embeddedType := range embedded {
+		ty, err := s.getTypeDefGo(ctx, embeddedType)
+		if err != nil {
+			return nil, err
+		}
+		if ty == nil {
+			continue
+		}
+		found, err := s.lookupFieldGo(ctx, ty, field)
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			return found, nil
+		}
+	}
+	return nil, nil
+}
+
+// lookupMethodGo finds a method declared on the given named type, first in the file that declares
+// the type and then in the rest of its package.
+func (s *SquirrelService) lookupMethodGo(ctx context.Context, ty NamedTypeGo, method string) (ret *Node, err error) {
+	defer s.onCall(ty.spec, &Tuple{String(ty.variant()), String(method)}, lazyNodeStringer(&ret))()
+
+	name := ty.spec.ChildByFieldName("name")
+	if name == nil {
+		return nil, nil
+	}
+	typeName := name.Content(ty.spec.Contents)
+
+	matches := func(candidate Node) bool {
+		decl := candidate.Parent()
+		if decl == nil || decl.Type() != "method_declaration" {
+			return false
+		}
+		declName := decl.ChildByFieldName("name")
+		if declName == nil || nodeId(declName) != nodeId(candidate.Node) {
+			return false
+		}
+		return receiverTypeNameGo(swapNode(candidate, decl)) == typeName
+	}
+
+	program := swapNode(ty.spec, getRoot(ty.spec.Node))
+	for _, decl := range children(program.Node) {
+		if decl.Type() != "method_declaration" {
+			continue
+		}
+		declName := decl.ChildByFieldName("name")
+		if declName == nil || declName.Content(program.Contents) != method {
+			continue
+		}
+		if candidate := swapNode(program, declName); matches(candidate) {
+			return &candidate, nil
+		}
+	}
+
+	candidates, err := s.symbolSearchN(
+		ctx,
+		ty.spec.RepoCommitPath.Repo,
+		ty.spec.RepoCommitPath.Commit,
+		[]string{packageFilesPatternGo(dirGo(ty.spec.RepoCommitPath.Path))},
+		method,
+		maxSymbolCandidatesGo,
+	)
+	if err != nil {
+		return nil, err
+	}
+	for _, candidate := range candidates {
+		if matches(candidate) {
+			return &candidate, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (s *SquirrelService) getTypeDefGo(ctx context.Context, node Node) (ret TypeGo, err error) {
+	defer s.onCall(node, String(node.Type()), lazyTypeGoStringer(&ret))()
+
+	switch node.Type() {
+	case "identifier":
+		fallthrough
+	case "type_identifier":
+		fallthrough
+	case "field_identifier":
+		found, err := s.getDefGo(ctx, node)
+		if err != nil {
+			return nil, err
+		}
+		if found == nil || found.Node == nil {
+			return nil, nil
+		}
+		if isRecursiveDefinitionGo(node, *found) {
+			return nil, nil
+		}
+		return s.defToTypeGo(ctx, *found)
+	case "qualified_type":
+		name := node.ChildByFieldName("name")
+		if name == nil {
+			return nil, nil
+		}
+		return s.getTypeDefGo(ctx, swapNode(node, name))
+	case "generic_type":
+		ty := node.ChildByFieldName("type")
+		if ty == nil {
+			return nil, nil
+		}
+		return s.getTypeDefGo(ctx, swapNode(node, ty))
+	case "pointer_type":
+		fallthrough
+	case "parenthesized_type":
+		fallthrough
+	case "parenthesized_expression":
+		if node.NamedChildCount() == 0 {
+			return nil, nil
+		}
+		return s.getTypeDefGo(ctx, swapNode(node, node.NamedChild(0)))
+	case "unary_expression":
+		// &x and *x have the same fields and methods as x
+		operand := node.ChildByFieldName("operand")
+		if operand == nil {
+			return nil, nil
+		}
+		return s.getTypeDefGo(ctx, swapNode(node, operand))
+	case "selector_expression":
+		operand := node.ChildByFieldName("operand")
+		if operand == nil {
+			return nil, nil
+		}
+		field := node.ChildByFieldName("field")
+		if field == nil {
+			return nil, nil
+		}
+		found, err := s.getFieldGo(ctx, swapNode(node, operand), field.Content(node.Contents))
+		if err != nil {
+			return nil, err
+		}
+		if found == nil {
+			return nil, nil
+		}
+		return s.defToTypeGo(ctx, *found)
+	case "call_expression":
+		fn := node.ChildByFieldName("function")
+		if fn == nil {
+			return nil, nil
+		}
+		ty, err := s.getTypeDefGo(ctx, swapNode(node, fn))
+		if err != nil {
+			return nil, err
+		}
+		if ty == nil {
+			return nil, nil
+		}
+		switch ty2 := ty.(type) {
+		case FnTypeGo:
+			return ty2.ret, nil
+		case NamedTypeGo:
+			// Conversion, e.g. T(x)
+			return ty2, nil
+		default:
+			s.breadcrumb(ty.node(), fmt.Sprintf("getTypeDefGo: expected function, got %q", ty.variant()))
+			return nil, nil
+		}
+	case "composite_literal":
+		fallthrough
+	case "type_assertion_expression":
+		ty := node.ChildByFieldName("type")
+		if ty == nil {
+			return nil, nil
+		}
+		return s.getTypeDefGo(ctx, swapNode(node, ty))
+	case "struct_type":
+		fallthrough
+	case "interface_type":
+		return LiteralTypeGo{def: node}, nil
+	default:
+		s.breadcrumb(node, fmt.Sprintf("getTypeDefGo: unrecognized node type %q", node.Type()))
+		return nil, nil
+	}
+}
+
+func (s *SquirrelService) defToTypeGo(ctx context.Context, def Node) (TypeGo, error) {
+	parent := def.Node.Parent()
+	if parent == nil {
+		return nil, nil
+	}
+
+	switch parent.Type() {
+	case "type_spec":
+		return (TypeGo)(NamedTypeGo{spec: swapNode(def, parent)}), nil
+	case "type_alias":
+		ty := parent.ChildByFieldName("type")
+		if ty == nil {
+			return nil, nil
+		}
+		return s.getTypeDefGo(ctx, swapNode(def, ty))
+	case "function_declaration":
+		fallthrough
+	case "method_declaration":
+		fallthrough
+	case "method_spec":
+		fallthrough
+	case "method_elem":
+		retTy, err := s.resultTypeGo(ctx, swapNode(def, parent))
+		if err != nil {
+			return nil, err
+		}
+		return (TypeGo)(FnTypeGo{
+			ret:  retTy,
+			noad: swapNode(def, parent),
+		}), nil
+	case "parameter_declaration":
+		fallthrough
+	case "field_declaration":
+		ty := parent.ChildByFieldName("type")
+		if ty == nil {
+			s.breadcrumb(swapNode(def, parent), "defToTypeGo: could not find type")
+			return nil, nil
+		}
+		return s.getTypeDefGo(ctx, swapNode(def, ty))
+	case "var_spec":
+		fallthrough
+	case "const_spec":
+		if ty := parent.ChildByFieldName("type"); ty != nil {
+			return s.getTypeDefGo(ctx, swapNode(def, ty))
+		}
+		value := nthExpressionGo(parent.ChildByFieldName("value"), nameIndexGo(parent, def.Node))
+		if value == nil {
+			return nil, nil
+		}
+		return s.getTypeDefGo(ctx, swapNode(def, value))
+	case "expression_list":
+		decl := parent.Parent()
+		if decl == nil || decl.Type() != "short_var_declaration" {
+			// Range and receive statements would require element types
+			return nil, nil
+		}
+		value := nthExpressionGo(decl.ChildByFieldName("right"), nameIndexGo(parent, def.Node))
+		if value == nil {
+			return nil, nil
+		}
+		return s.getTypeDefGo(ctx, swapNode(def, value))
+	default:
+		s.breadcrumb(swapNode(def, parent), fmt.Sprintf("unrecognized def parent %q", parent.Type()))
+		return nil, nil
+	}
+}
+
+// resultTypeGo returns the type of the first result of the given function or method.
+func (s *SquirrelService) resultTypeGo(ctx context.Context, fn Node) (TypeGo, error) {
+	result := fn.ChildByFieldName("result")
+	if result == nil {
+		return nil, nil
+	}
+	if result.Type() == "parameter_list" {
+		for _, param := range children(result) {
+			if param.Type() != "parameter_declaration" {
+				continue
+			}
+			ty := param.ChildByFieldName("type")
+			if ty == nil {
+				return nil, nil
+			}
+			return s.getTypeDefGo(ctx, swapNode(fn, ty))
+		}
+		return nil, nil
+	}
+	return s.getTypeDefGo(ctx, swapNode(fn, result))
+}
+
+// findTopLevelDeclGo finds a package-level declaration in the given file. Methods are not in scope
+// by name, so they are skipped.
+func findTopLevelDeclGo(program Node, ident string) *Node {
+	for _, decl := range children(program.Node) {
+		if decl.Type() == "function_declaration" {
+			name := decl.ChildByFieldName("name")
+			if name != nil && name.Content(program.Contents) == ident {
+				return swapNodePtr(program, name)
+			}
+			continue
+		}
+		if found := findDeclInStatementGo(swapNode(program, decl), ident); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// findDeclInStatementGo finds a binding of the given identifier introduced by the given statement.
+func findDeclInStatementGo(stmt Node, ident string) *Node {
+	if stmt.Node == nil {
+		return nil
+	}
+
+	switch stmt.Type() {
+	case "short_var_declaration":
+		return findIdentInListGo(swapNode(stmt, stmt.ChildByFieldName("left")), ident)
+	case "range_clause":
+		return findIdentInListGo(swapNode(stmt, stmt.ChildByFieldName("left")), ident)
+	case "receive_statement":
+		return findIdentInListGo(swapNode(stmt, stmt.ChildByFieldName("left")), ident)
+	case "var_declaration":
+		fallthrough
+	case "var_spec_list":
+		fallthrough
+	case "const_declaration":
+		fallthrough
+	case "type_declaration":
+		for _, spec := range children(stmt.Node) {
+			switch spec.Type() {
+			case "var_spec":
+				fallthrough
+			case "const_spec":
+				for _, name := range children(spec) {
+					if name.Type() == "identifier" && name.Content(stmt.Contents) == ident {
+						return swapNodePtr(stmt, name)
+					}
+				}
+			case "type_spec":
+				fallthrough
+			case "type_alias":
+				name := spec.ChildByFieldName("name")
+				if name != nil && name.Content(stmt.Contents) == ident {
+					return swapNodePtr(stmt, name)
+				}
+			case "var_spec_list":
+				if found := findDeclInStatementGo(swapNode(stmt, spec), ident); found != nil {
+					return found
+				}
+			}
+		}
+		return nil
+	default:
+		return nil
+	}
+}
+
+// findIdentInListGo finds the given identifier in an expression_list such as the left-hand side of
+// a short variable declaration.
+func findIdentInListGo(list Node, ident string) *Node {
+	if list.Node == nil {
+		return nil
+	}
+	if list.Type() == "identifier" {
+		if list.Content(list.Contents) == ident {
+			return &list
+		}
+		return nil
+	}
+	for _, child := range children(list.Node) {
+		if child.Type() == "identifier" && child.Content(list.Contents) == ident {
+			return swapNodePtr(list, child)
+		}
+	}
+	return nil
+}
+
+// findParameterGo finds the given identifier in a parameter_list (parameters, results, receivers
+// and type parameters).
+func findParameterGo(params Node, ident string) *Node {
+	if params.Node == nil {
+		return nil
+	}
+	for _, param := range children(params.Node) {
+		switch param.Type() {
+		case "parameter_declaration":
+			fallthrough
+		case "variadic_parameter_declaration":
+			fallthrough
+		case "type_parameter_declaration":
+			for _, name := range children(param) {
+				if name.Type() == "identifier" && name.Content(params.Contents) == ident {
+					return swapNodePtr(params, name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// findFieldInTypeLiteralGo finds a field of a struct type or a method of an interface type, and
+// returns the embedded types in which to look for promoted fields otherwise.
+func findFieldInTypeLiteralGo(literal Node, field string) (*Node, []Node) {
+	embedded := []Node{}
+
+	switch literal.Type() {
+	case "struct_type":
+		for _, list := range children(literal.Node) {
+			if list.Type() != "field_declaration_list" {
+				continue
+			}
+			for _, decl := range children(list) {
+				if decl.Type() != "field_declaration" {
+					continue
+				}
+
+				hasNames := false
+				for _, name := range children(decl) {
+					if name.Type() != "field_identifier" {
+						continue
+					}
+					hasNames = true
+					if name.Content(literal.Contents) == field {
+						return swapNodePtr(literal, name), nil
+					}
+				}
+				if hasNames {
+					continue
+				}
+
+				// Embedded fields are named after their type
+				ty := decl.ChildByFieldName("type")
+				if ty == nil {
+					continue
+				}
+				if name := embeddedTypeNameGo(ty); name != nil && name.Content(literal.Contents) == field {
+					return swapNodePtr(literal, name), nil
+				}
+				embedded = append(embedded, swapNode(literal, ty))
+			}
+		}
+	case "interface_type":
+		for _, elem := range children(literal.Node) {
+			switch elem.Type() {
+			case "method_spec":
+				fallthrough
+			case "method_elem":
+				name := elem.ChildByFieldName("name")
+				if name != nil && name.Content(literal.Contents) == field {
+					return swapNodePtr(literal, name), nil
+				}
+			case "type_identifier":
+				fallthrough
+			case "qualified_type":
+				embedded = append(embedded, swapNode(literal, elem))
+			case "interface_type_name":
+				fallthrough
+			case "constraint_elem":
+				fallthrough
+			case "type_elem":
+				for _, ty := range children(elem) {
+					embedded = append(embedded, swapNode(literal, ty))
+				}
+			}
+		}
+	}
+
+	return nil, embedded
+}
+
+// embeddedTypeNameGo returns the type_identifier that names an embedded field.
+func embeddedTypeNameGo(ty *sitter.Node) *sitter.Node {
+	switch ty.Type() {
+	case "type_identifier":
+		return ty
+	case "qualified_type":
+		return ty.ChildByFieldName("name")
+	case "pointer_type":
+		fallthrough
+	case "generic_type":
+		if ty.NamedChildCount() == 0 {
+			return nil
+		}
+		return embeddedTypeNameGo(ty.NamedChild(0))
+	default:
+		return nil
+	}
+}
+
+// receiverTypeNameGo returns the name of the receiver type of the given method declaration.
+func receiverTypeNameGo(method Node) string {
+	receiver := method.ChildByFieldName("receiver")
+	if receiver == nil {
+		return ""
+	}
+	for _, param := range children(receiver) {
+		if param.Type() != "parameter_declaration" {
+			continue
+		}
+		ty := param.ChildByFieldName("type")
+		if ty == nil {
+			return ""
+		}
+		name := embeddedTypeNameGo(ty)
+		if name == nil {
+			return ""
+		}
+		return name.Content(method.Contents)
+	}
+	return ""
+}
+
+// importSpecNameGo returns the name under which the given import_spec is visible in the file.
+func importSpecNameGo(spec Node) string {
+	if name := spec.ChildByFieldName("name"); name != nil {
+		return name.Content(spec.Contents)
+	}
+	path := spec.ChildByFieldName("path")
+	if path == nil {
+		return ""
+	}
+	components := strings.Split(strings.Trim(path.Content(spec.Contents), "\"`"), "/")
+	last := components[len(components)-1]
+	// Major version suffixes such as example.com/foo/v2 are not part of the package name
+	if len(components) > 1 && majorVersionRegexGo.MatchString(last) {
+		last = components[len(components)-2]
+	}
+	return last
+}
+
+var majorVersionRegexGo = regexp.MustCompile(`^v[0-9]+$`)
+
+// isTopLevelDeclGo returns true if the given node names a package-level declaration.
+func isTopLevelDeclGo(node Node) bool {
+	parent := node.Parent()
+	if parent == nil {
+		return false
+	}
+	switch parent.Type() {
+	case "function_declaration":
+		return true
+	case "type_spec":
+		fallthrough
+	case "type_alias":
+		fallthrough
+	case "var_spec":
+		fallthrough
+	case "const_spec":
+		for cur := parent.Parent(); cur != nil; cur = cur.Parent() {
+			switch cur.Type() {
+			case "source_file":
+				return true
+			case "block":
+				return false
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+// nameIndexGo returns the position of the given name among the identifiers of a declaration.
+func nameIndexGo(decl *sitter.Node, name *sitter.Node) int {
+	i := 0
+	for _, child := range children(decl) {
+		if child.Type() != "identifier" {
+			continue
+		}
+		if nodeId(child) == nodeId(name) {
+			return i
+		}
+		i++
+	}
+	return -1
+}
+
+// nthExpressionGo returns the nth expression of an expression_list.
+func nthExpressionGo(list *sitter.Node, n int) *sitter.Node {
+	if list == nil || n < 0 {
+		return nil
+	}
+	if list.Type() != "expression_list" {
+		if n == 0 {
+			return list
+		}
+		return nil
+	}
+	if n >= int(list.NamedChildCount()) {
+		return nil
+	}
+	return list.NamedChild(n)
+}
+
+// dirGo returns the directory of the given path, using an empty string for the repository root.
+func dirGo(path string) string {
+	dir := filepath.Dir(path)
+	if dir == "." || dir == "/" {
+		return ""
+	}
+	return dir
+}
+
+// packageFilesPatternGo returns a symbol search include pattern that matches the files of the
+// package in the given directory (but not its subpackages).
+func packageFilesPatternGo(dir string) string {
+	if dir == "" {
+		return `^[^/]+\.go$`
+	}
+	return fmt.Sprintf(`^%s/[^/]+\.go$`, regexp.QuoteMeta(dir))
+}
+
+// Symbol search may return methods and package-level declarations of other types with the same
+// name, so a handful of candidates are considered.
+const maxSymbolCandidatesGo = 25
+
+// isRecursiveDefinitionGo detects cases like `x := x.foo` where the definition of x is being
+// resolved in order to determine the type of x.
+func isRecursiveDefinitionGo(node Node, def Node) bool {
+	if node.RepoCommitPath != def.RepoCommitPath {
+		return false
+	}
+	parent := def.Parent()
+	if parent == nil {
+		return false
+	}
+	decl := parent
+	if parent.Type() == "expression_list" {
+		decl = parent.Parent()
+	}
+	if decl == nil || (decl.Type() != "short_var_declaration" && decl.Type() != "var_spec") {
+		return false
+	}
+	for nodeAncestor := node.Parent(); nodeAncestor != nil; nodeAncestor = nodeAncestor.Parent() {
+		if nodeId(nodeAncestor) == nodeId(decl) {
+			return true
+		}
+	}
+	return false
+}
+
+type TypeGo interface {
+	variant() string
+	node() Node
+}
+
+// NamedTypeGo is a type declared with a type_spec.
+type NamedTypeGo struct {
+	spec Node
+}
+
+func (t NamedTypeGo) variant() string {
+	return "named"
+}
+
+func (t NamedTypeGo) node() Node {
+	return t.spec
+}
+
+// LiteralTypeGo is an anonymous struct or interface type.
+type LiteralTypeGo struct {
+	def Node
+}
+
+func (t LiteralTypeGo) variant() string {
+	return "literal"
+}
+
+func (t LiteralTypeGo) node() Node {
+	return t.def
+}
+
+type FnTypeGo struct {
+	ret  TypeGo
+	noad Node
+}
+
+func (t FnTypeGo) variant() string {
+	return "fn"
+}
+
+func (t FnTypeGo) node() Node {
+	return t.noad
+}
+
+func lazyTypeGoStringer(ty *TypeGo) func() fmt.Stringer {
+	return func() fmt.Stringer {
+		if ty != nil && *ty != nil {
+			return String((*ty).variant())
+		} else {
+			return String("<nil>")
+		}
+	}
+}
@@ -0,0 +1,976 @@
+package squirrel
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func (s *SquirrelService) getDefTypeScript(ctx context.Context, node Node) (ret *Node, err error) {
+	defer s.onCall(node, String(node.Type()), lazyNodeStringer(&ret))()
+
+	switch node.Type() {
+	case "identifier":
+		fallthrough
+	case "type_identifier":
+		ident := node.Content(node.Contents)
+
+		cur := node.Node
+
+		for {
+			prev := cur
+			cur = cur.Parent()
+			if cur == nil {
+				s.breadcrumb(node, "getDefTypeScript: ran out of parents")
+				return nil, nil
+			}
+
+			switch cur.Type() {
+
+			case "program":
+				found := findInScopeTypeScript(swapNode(node, cur), ident)
+				if found == nil {
+					return nil, nil
+				}
+				return s.followImportTypeScript(ctx, *found)
+
+			case "nested_type_identifier":
+				//        vvvv type_identifier
+				// module.Type
+				name := cur.ChildByFieldName("name")
+				module := cur.ChildByFieldName("module")
+				if name == nil || module == nil || nodeId(name) != nodeId(prev) {
+					continue
+				}
+				return s.getFieldTypeScript(ctx, swapNode(node, module), ident)
+
+			// Check nodes that might have bindings:
+			case "statement_block":
+				found := findInScopeTypeScript(swapNode(node, cur), ident)
+				if found != nil {
+					return found, nil
+				}
+				continue
+
+			case "function_declaration":
+				fallthrough
+			case "function":
+				fallthrough
+			case "generator_function_declaration":
+				fallthrough
+			case "generator_function":
+				fallthrough
+			case "method_definition":
+				fallthrough
+			case "arrow_function":
+				if param := cur.ChildByFieldName("parameter"); param != nil && param.Type() == "identifier" {
+					if param.Content(node.Contents) == ident {
+						return swapNodePtr(node, param), nil
+					}
+				}
+				if found := findParameterTypeScript(swapNode(node, cur.ChildByFieldName("parameters")), ident); found != nil {
+					return found, nil
+				}
+				if found := findTypeParameterTypeScript(swapNode(node, cur), ident); found != nil {
+					return found, nil
+				}
+				continue
+
+			case "class_declaration":
+				fallthrough
+			case "abstract_class_declaration":
+				fallthrough
+			case "class":
+				fallthrough
+			case "interface_declaration":
+				fallthrough
+			case "type_alias_declaration":
+				if found := findTypeParameterTypeScript(swapNode(node, cur), ident); found != nil {
+					return found, nil
+				}
+				continue
+
+			case "for_in_statement":
+				left := cur.ChildByFieldName("left")
+				if left != nil && left.Type() == "identifier" && left.Content(node.Contents) == ident {
+					return swapNodePtr(node, left), nil
+				}
+				continue
+
+			case "for_statement":
+				if found := findInDeclarationTypeScript(swapNode(node, cur.ChildByFieldName("initializer")), ident); found != nil {
+					return found, nil
+				}
+				continue
+
+			case "catch_clause":
+				param := cur.ChildByFieldName("parameter")
+				if param != nil && param.Type() == "identifier" && param.Content(node.Contents) == ident {
+					return swapNodePtr(node, param), nil
+				}
+				continue
+
+			// Skip all other nodes
+			default:
+				continue
+			}
+		}
+
+	case "property_identifier":
+		parent := node.Parent()
+		if parent == nil {
+			return nil, nil
+		}
+
+		switch parent.Type() {
+		case "member_expression":
+			object := parent.ChildByFieldName("object")
+			if object == nil {
+				return nil, nil
+			}
+			return s.getFieldTypeScript(ctx, swapNode(node, object), node.Content(node.Contents))
+
+		// Members are their own definitions
+		case "method_definition":
+			fallthrough
+		case "method_signature":
+			fallthrough
+		case "abstract_method_signature":
+			fallthrough
+		case "public_field_definition":
+			fallthrough
+		case "property_signature":
+			return &node, nil
+
+		default:
+			return nil, nil
+		}
+
+	case "this":
+		for cur := node.Parent(); cur != nil; cur = cur.Parent() {
+			switch cur.Type() {
+			case "class_declaration":
+				fallthrough
+			case "abstract_class_declaration":
+				fallthrough
+			case "class":
+				name := cur.ChildByFieldName("name")
+				if name == nil {
+					return nil, nil
+				}
+				return swapNodePtr(node, name), nil
+			}
+		}
+		return nil, nil
+
+	// No other nodes have a definition
+	default:
+		return nil, nil
+	}
+}
+
+// followImportTypeScript resolves a binding introduced by an import statement to the exported
+// declaration in the imported module. Bindings that can't be followed (e.g. imports of packages
+// outside of the repository) are returned as-is.
+func (s *SquirrelService) followImportTypeScript(ctx context.Context, binding Node) (ret *Node, err error) {
+	parent := binding.Parent()
+	if parent == nil {
+		return &binding, nil
+	}
+	switch parent.Type() {
+	case "import_specifier":
+	case "import_clause":
+	default:
+		// Not an import, and namespace imports are definitions of the module object
+		return &binding, nil
+	}
+
+	defer s.onCall(binding, String(binding.Type()), lazyNodeStringer(&ret))()
+
+	module, err := s.resolveImportedModuleTypeScript(ctx, binding)
+	if err != nil {
+		return nil, err
+	}
+	if module == nil {
+		return &binding, nil
+	}
+
+	var found *Node
+	if parent.Type() == "import_clause" {
+		// import Foo from './foo'
+		found, err = s.findDefaultExportTypeScript(ctx, *module)
+	} else {
+		// import { Foo } from './foo'
+		// import { Foo as Bar } from './foo'
+		name := parent.ChildByFieldName("name")
+		if name == nil {
+			return &binding, nil
+		}
+		found, err = s.findExportTypeScript(ctx, *module, name.Content(binding.Contents))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if found == nil {
+		return &binding, nil
+	}
+	return found, nil
+}
+
+// resolveImportedModuleTypeScript parses the module imported by the import statement that contains
+// the given node.
+func (s *SquirrelService) resolveImportedModuleTypeScript(ctx context.Context, node Node) (*Node, error) {
+	for cur := node.Node; cur != nil; cur = cur.Parent() {
+		switch cur.Type() {
+		case "import_statement":
+			fallthrough
+		case "export_statement":
+			source := cur.ChildByFieldName("source")
+			if source == nil {
+				return nil, nil
+			}
+			return s.resolveModuleTypeScript(ctx, node.RepoCommitPath, getStringContentsTypeScript(swapNode(node, source)))
+		}
+	}
+	return nil, nil
+}
+
+// resolveModuleTypeScript parses the module with the given specifier relative to the given file.
+// Only relative specifiers are supported.
+func (s *SquirrelService) resolveModuleTypeScript(ctx context.Context, from types.RepoCommitPath, specifier string) (*Node, error) {
+	if !strings.HasPrefix(specifier, "./") && !strings.HasPrefix(specifier, "../") {
+		return nil, nil
+	}
+
+	base := filepath.Join(filepath.Dir(from.Path), specifier)
+	candidates := []string{}
+	switch filepath.Ext(base) {
+	case ".ts", ".tsx":
+		candidates = append(candidates, base)
+	case ".js", ".jsx":
+		// TypeScript resolves ESM-style imports of the compiled output to the sources
+		trimmed := strings.TrimSuffix(base, filepath.Ext(base))
+		candidates = append(candidates, trimmed+".ts", trimmed+".tsx")
+	}
+	for _, suffix := range typeScriptModuleSuffixes {
+		candidates = append(candidates, base+suffix)
+	}
+
+	for _, candidate := range candidates {
+		module, err := s.parse(ctx, types.RepoCommitPath{
+			Repo:   from.Repo,
+			Commit: from.Commit,
+			Path:   candidate,
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			// Most candidates don't exist
+			continue
+		}
+		return module, nil
+	}
+
+	return nil, nil
+}
+
+var typeScriptModuleSuffixes = []string{".ts", ".tsx", ".d.ts", "/index.ts", "/index.tsx"}
+
+// findExportTypeScript finds the declaration exported under the given name by the given module.
+func (s *SquirrelService) findExportTypeScript(ctx context.Context, module Node, name string) (ret *Node, err error) {
+	defer s.onCall(module, &Tuple{String(module.Type()), String(name)}, lazyNodeStringer(&ret))()
+
+	starExports := []Node{}
+	for _, stmt := range children(module.Node) {
+		if stmt.Type() != "export_statement" {
+			continue
+		}
+		exportStmt := swapNode(module, stmt)
+
+		// export function f() { ... }
+		// export class C { ... }
+		if decl := stmt.ChildByFieldName("declaration"); decl != nil {
+			if found := findInDeclarationTypeScript(swapNode(module, decl), name); found != nil {
+				return found, nil
+			}
+			continue
+		}
+
+		source := stmt.ChildByFieldName("source")
+
+		var clause *sitter.Node
+		for _, child := range children(stmt) {
+			if child.Type() == "export_clause" {
+				clause = child
+			}
+		}
+		if clause == nil {
+			if source != nil {
+				// export * from './other'
+				starExports = append(starExports, exportStmt)
+			}
+			continue
+		}
+
+		// export { a, b as c }
+		// export { a, b as c } from './other'
+		for _, specifier := range children(clause) {
+			if specifier.Type() != "export_specifier" {
+				continue
+			}
+			local := specifier.ChildByFieldName("name")
+			if local == nil {
+				continue
+			}
+			exported := local
+			if alias := specifier.ChildByFieldName("alias"); alias != nil {
+				exported = alias
+			}
+			if exported.Content(module.Contents) != name {
+				continue
+			}
+
+			if source == nil {
+				found := findInScopeTypeScript(module, local.Content(module.Contents))
+				if found == nil {
+					return nil, nil
+				}
+				return s.followImportTypeScript(ctx, *found)
+			}
+
+			other, err := s.resolveImportedModuleTypeScript(ctx, exportStmt)
+			if err != nil {
+				return nil, err
+			}
+			if other == nil {
+				return nil, nil
+			}
+			return s.findExportTypeScript(ctx, *other, local.Content(module.Contents))
+		}
+	}
+
+	for _, exportStmt := range starExports {
+		other, err := s.resolveImportedModuleTypeScript(ctx, exportStmt)
+		if err != nil {
+			return nil, err
+		}
+		if other == nil {
+			continue
+		}
+		found, err := s.findExportTypeScript(ctx, *other, name)
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			return found, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// findDefaultExportTypeScript finds the default export of the given module.
+func (s *SquirrelService) findDefaultExportTypeScript(ctx context.Context, module Node) (ret *Node, err error) {
+	defer s.onCall(module, String(module.Type()), lazyNodeStringer(&ret))()
+
+	for _, stmt := range children(module.Node) {
+		if stmt.Type() != "export_statement" {
+			continue
+		}
+
+		isDefault := false
+		for i := 0; i < int(stmt.ChildCount()); i++ {
+			if stmt.Child(i).Type() == "default" {
+				isDefault = true
+			}
+		}
+		if !isDefault {
+			continue
+		}
+
+		// export default class C { ... }
+		if decl := stmt.ChildByFieldName("declaration"); decl != nil {
+			name := decl.ChildByFieldName("name")
+			if name == nil {
+				return nil, nil
+			}
+			return swapNodePtr(module, name), nil
+		}
+
+		// export default c
+		value := stmt.ChildByFieldName("value")
+		if value == nil || value.Type() != "identifier" {
+			return nil, nil
+		}
+		found := findInScopeTypeScript(module, value.Content(module.Contents))
+		if found == nil {
+			return nil, nil
+		}
+		return s.followImportTypeScript(ctx, *found)
+	}
+
+	return nil, nil
+}
+
+func (s *SquirrelService) getFieldTypeScript(ctx context.Context, object Node, field string) (ret *Node, err error) {
+	defer s.onCall(object, &Tuple{String(object.Type()), String(field)}, lazyNodeStringer(&ret))()
+
+	ty, err := s.getTypeDefTypeScript(ctx, object)
+	if err != nil {
+		return nil, err
+	}
+	if ty == nil {
+		return nil, nil
+	}
+	return s.lookupFieldTypeScript(ctx, ty, field)
+}
+
+func (s *SquirrelService) lookupFieldTypeScript(ctx context.Context, ty TypeTypeScript, field string) (ret *Node, err error) {
+	defer s.onCall(ty.node(), &Tuple{String(ty.variant()), String(field)}, lazyNodeStringer(&ret))()
+
+	switch ty2 := ty.(type) {
+	case ModuleTypeTypeScript:
+		return s.findExportTypeScript(ctx, ty2.module, field)
+	case ClassTypeTypeScript:
+		body := ty2.def.Node
+		if ty2.def.Type() != "object_type" {
+			body = ty2.def.ChildByFieldName("body")
+		}
+		if body == nil {
+			return nil, nil
+		}
+		for _, member := range children(body) {
+			switch member.Type() {
+			case "method_definition":
+				name := member.ChildByFieldName("name")
+				if name == nil {
+					continue
+				}
+				if name.Content(ty2.def.Contents) == field {
+					return swapNodePtr(ty2.def, name), nil
+				}
+				if name.Content(ty2.def.Contents) == "constructor" {
+					// Parameter properties, e.g. constructor(private readonly x: X) { ... }
+					if found := findParameterPropertyTypeScript(swapNode(ty2.def, member.ChildByFieldName("parameters")), field); found != nil {
+						return found, nil
+					}
+				}
+			case "method_signature":
+				fallthrough
+			case "abstract_method_signature":
+				fallthrough
+			case "public_field_definition":
+				fallthrough
+			case "property_signature":
+				name := member.ChildByFieldName("name")
+				if name != nil && name.Content(ty2.def.Contents) == field {
+					return swapNodePtr(ty2.def, name), nil
+				}
+			}
+		}
+		for _, super := range getSupertypesTypeScript(ty2.def) {
+			found, err := s.getFieldTypeScript(ctx, super, field)
+			if err != nil {
+				return nil, err
+			}
+			if found != nil {
+				return found, nil
+			}
+		}
+		return nil, nil
+	case FnTypeTypeScript:
+		s.breadcrumb(ty.node(), fmt.Sprintf("lookupFieldTypeScript: unexpected object type %s", ty.variant()))
+		return nil, nil
+	default:
+		s.breadcrumb(ty.node(), fmt.Sprintf("lookupFieldTypeScript: unrecognized type variant %q", ty.variant()))
+		return nil, nil
+	}
+}
+
+func (s *SquirrelService) getTypeDefTypeScript(ctx context.Context, node Node) (ret TypeTypeScript, err error) {
+	defer s.onCall(node, String(node.Type()), lazyTypeTypeScriptStringer(&ret))()
+
+	switch node.Type() {
+	case "identifier":
+		fallthrough
+	case "type_identifier":
+		fallthrough
+	case "this":
+		found, err := s.getDefTypeScript(ctx, node)
+		if err != nil {
+			return nil, err
+		}
+		if found == nil {
+			return nil, nil
+		}
+		if isRecursiveDefinitionTypeScript(node, *found) {
+			return nil, nil
+		}
+		return s.defToTypeTypeScript(ctx, *found)
+	case "nested_type_identifier":
+		name := node.ChildByFieldName("name")
+		if name == nil {
+			return nil, nil
+		}
+		return s.getTypeDefTypeScript(ctx, swapNode(node, name))
+	case "generic_type":
+		name := node.ChildByFieldName("name")
+		if name == nil {
+			return nil, nil
+		}
+		return s.getTypeDefTypeScript(ctx, swapNode(node, name))
+	case "member_expression":
+		object := node.ChildByFieldName("object")
+		if object == nil {
+			return nil, nil
+		}
+		property := node.ChildByFieldName("property")
+		if property == nil {
+			return nil, nil
+		}
+		found, err := s.getFieldTypeScript(ctx, swapNode(node, object), property.Content(node.Contents))
+		if err != nil {
+			return nil, err
+		}
+		if found == nil {
+			return nil, nil
+		}
+		return s.defToTypeTypeScript(ctx, *found)
+	case "call_expression":
+		fn := node.ChildByFieldName("function")
+		if fn == nil {
+			return nil, nil
+		}
+		ty, err := s.getTypeDefTypeScript(ctx, swapNode(node, fn))
+		if err != nil {
+			return nil, err
+		}
+		if ty == nil {
+			return nil, nil
+		}
+		switch ty2 := ty.(type) {
+		case FnTypeTypeScript:
+			return ty2.ret, nil
+		default:
+			s.breadcrumb(ty.node(), fmt.Sprintf("getTypeDefTypeScript: expected function, got %q", ty.variant()))
+			return nil, nil
+		}
+	case "new_expression":
+		constructor := node.ChildByFieldName("constructor")
+		if constructor == nil {
+			return nil, nil
+		}
+		return s.getTypeDefTypeScript(ctx, swapNode(node, constructor))
+	case "type_annotation":
+		fallthrough
+	case "parenthesized_type":
+		fallthrough
+	case "parenthesized_expression":
+		fallthrough
+	case "non_null_expression":
+		if node.NamedChildCount() == 0 {
+			return nil, nil
+		}
+		return s.getTypeDefTypeScript(ctx, swapNode(node, node.NamedChild(0)))
+	case "as_expression":
+		// x as T
+		if node.NamedChildCount() < 2 {
+			return nil, nil
+		}
+		return s.getTypeDefTypeScript(ctx, swapNode(node, node.NamedChild(int(node.NamedChildCount())-1)))
+	case "object_type":
+		return ClassTypeTypeScript{def: node}, nil
+	default:
+		s.breadcrumb(node, fmt.Sprintf("getTypeDefTypeScript: unrecognized node type %q", node.Type()))
+		return nil, nil
+	}
+}
+
+func (s *SquirrelService) defToTypeTypeScript(ctx context.Context, def Node) (TypeTypeScript, error) {
+	parent := def.Node.Parent()
+	if parent == nil {
+		return nil, nil
+	}
+
+	switch parent.Type() {
+	case "class_declaration":
+		fallthrough
+	case "abstract_class_declaration":
+		fallthrough
+	case "class":
+		fallthrough
+	case "interface_declaration":
+		return (TypeTypeScript)(ClassTypeTypeScript{def: swapNode(def, parent)}), nil
+	case "type_alias_declaration":
+		value := parent.ChildByFieldName("value")
+		if value == nil {
+			return nil, nil
+		}
+		return s.getTypeDefTypeScript(ctx, swapNode(def, value))
+	case "function_declaration":
+		fallthrough
+	case "generator_function_declaration":
+		fallthrough
+	case "method_definition":
+		fallthrough
+	case "method_signature":
+		fallthrough
+	case "abstract_method_signature":
+		return s.fnTypeTypeScript(ctx, swapNode(def, parent))
+	case "variable_declarator":
+		fallthrough
+	case "public_field_definition":
+		fallthrough
+	case "property_signature":
+		if ty := parent.ChildByFieldName("type"); ty != nil {
+			return s.getTypeDefTypeScript(ctx, swapNode(def, ty))
+		}
+		value := parent.ChildByFieldName("value")
+		if value == nil {
+			return nil, nil
+		}
+		switch value.Type() {
+		case "arrow_function":
+			fallthrough
+		case "function":
+			return s.fnTypeTypeScript(ctx, swapNode(def, value))
+		default:
+			return s.getTypeDefTypeScript(ctx, swapNode(def, value))
+		}
+	case "required_parameter":
+		fallthrough
+	case "optional_parameter":
+		ty := parent.ChildByFieldName("type")
+		if ty == nil {
+			s.breadcrumb(swapNode(def, parent), "defToTypeTypeScript: could not find type")
+			return nil, nil
+		}
+		return s.getTypeDefTypeScript(ctx, swapNode(def, ty))
+	case "namespace_import":
+		module, err := s.resolveImportedModuleTypeScript(ctx, def)
+		if err != nil {
+			return nil, err
+		}
+		if module == nil {
+			return nil, nil
+		}
+		return (TypeTypeScript)(ModuleTypeTypeScript{module: *module}), nil
+	default:
+		s.breadcrumb(swapNode(def, parent), fmt.Sprintf("unrecognized def parent %q", parent.Type()))
+		return nil, nil
+	}
+}
+
+func (s *SquirrelService) fnTypeTypeScript(ctx context.Context, fn Node) (TypeTypeScript, error) {
+	retTyNode := fn.ChildByFieldName("return_type")
+	if retTyNode == nil {
+		s.breadcrumb(fn, "fnTypeTypeScript: could not find return type")
+		return (TypeTypeScript)(FnTypeTypeScript{
+			ret:  nil,
+			noad: fn,
+		}), nil
+	}
+	retTy, err := s.getTypeDefTypeScript(ctx, swapNode(fn, retTyNode))
+	if err != nil {
+		return nil, err
+	}
+	return (TypeTypeScript)(FnTypeTypeScript{
+		ret:  retTy,
+		noad: fn,
+	}), nil
+}
+
+// findInScopeTypeScript finds a declaration of the given identifier among the statements of a
+// program or block. Declarations are hoisted, so every statement in the scope is considered.
+func findInScopeTypeScript(scope Node, ident string) *Node {
+	for _, stmt := range children(scope.Node) {
+		switch stmt.Type() {
+		case "export_statement":
+			decl := stmt.ChildByFieldName("declaration")
+			if decl == nil {
+				continue
+			}
+			if found := findInDeclarationTypeScript(swapNode(scope, decl), ident); found != nil {
+				return found
+			}
+		case "import_statement":
+			if found := findInImportTypeScript(swapNode(scope, stmt), ident); found != nil {
+				return found
+			}
+		default:
+			if found := findInDeclarationTypeScript(swapNode(scope, stmt), ident); found != nil {
+				return found
+			}
+		}
+	}
+	return nil
+}
+
+// findInDeclarationTypeScript returns the name of the given declaration if it declares the given
+// identifier.
+func findInDeclarationTypeScript(decl Node, ident string) *Node {
+	if decl.Node == nil {
+		return nil
+	}
+
+	switch decl.Type() {
+	case "lexical_declaration":
+		fallthrough
+	case "variable_declaration":
+		for _, declarator := range children(decl.Node) {
+			if declarator.Type() != "variable_declarator" {
+				continue
+			}
+			name := declarator.ChildByFieldName("name")
+			if name != nil && name.Type() == "identifier" && name.Content(decl.Contents) == ident {
+				return swapNodePtr(decl, name)
+			}
+		}
+		return nil
+	case "function_declaration":
+		fallthrough
+	case "generator_function_declaration":
+		fallthrough
+	case "function_signature":
+		fallthrough
+	case "class_declaration":
+		fallthrough
+	case "abstract_class_declaration":
+		fallthrough
+	case "interface_declaration":
+		fallthrough
+	case "type_alias_declaration":
+		fallthrough
+	case "enum_declaration":
+		name := decl.ChildByFieldName("name")
+		if name != nil && name.Content(decl.Contents) == ident {
+			return swapNodePtr(decl, name)
+		}
+		return nil
+	default:
+		return nil
+	}
+}
+
+// findInImportTypeScript finds the binding of the given identifier introduced by an import
+// statement.
+func findInImportTypeScript(stmt Node, ident string) *Node {
+	for _, clause := range children(stmt.Node) {
+		if clause.Type() != "import_clause" {
+			continue
+		}
+		for _, child := range children(clause) {
+			switch child.Type() {
+			case "identifier":
+				// import Foo from './foo'
+				if child.Content(stmt.Contents) == ident {
+					return swapNodePtr(stmt, child)
+				}
+			case "namespace_import":
+				// import * as foo from './foo'
+				for _, name := range children(child) {
+					if name.Type() == "identifier" && name.Content(stmt.Contents) == ident {
+						return swapNodePtr(stmt, name)
+					}
+				}
+			case "named_imports":
+				// import { Foo, Bar as Baz } from './foo'
+				for _, specifier := range children(child) {
+					if specifier.Type() != "import_specifier" {
+						continue
+					}
+					local := specifier.ChildByFieldName("alias")
+					if local == nil {
+						local = specifier.ChildByFieldName("name")
+					}
+					if local != nil && local.Content(stmt.Contents) == ident {
+						return swapNodePtr(stmt, local)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// findParameterTypeScript finds the given identifier in formal_parameters.
+func findParameterTypeScript(params Node, ident string) *Node {
+	if params.Node == nil {
+		return nil
+	}
+	for _, param := range children(params.Node) {
+		switch param.Type() {
+		case "required_parameter":
+			fallthrough
+		case "optional_parameter":
+			pattern := param.ChildByFieldName("pattern")
+			if pattern == nil {
+				continue
+			}
+			if pattern.Type() == "rest_pattern" && pattern.NamedChildCount() > 0 {
+				pattern = pattern.NamedChild(0)
+			}
+			if pattern.Type() == "identifier" && pattern.Content(params.Contents) == ident {
+				return swapNodePtr(params, pattern)
+			}
+		}
+	}
+	return nil
+}
+
+// findParameterPropertyTypeScript finds a constructor parameter that declares a class property,
+// i.e. one with an accessibility or readonly modifier.
+func findParameterPropertyTypeScript(params Node, field string) *Node {
+	if params.Node == nil {
+		return nil
+	}
+	for _, param := range children(params.Node) {
+		isProperty := false
+		for i := 0; i < int(param.ChildCount()); i++ {
+			switch param.Child(i).Type() {
+			case "accessibility_modifier", "readonly":
+				isProperty = true
+			}
+		}
+		if !isProperty {
+			continue
+		}
+		pattern := param.ChildByFieldName("pattern")
+		if pattern != nil && pattern.Type() == "identifier" && pattern.Content(params.Contents) == field {
+			return swapNodePtr(params, pattern)
+		}
+	}
+	return nil
+}
+
+// findTypeParameterTypeScript finds the given identifier among the type parameters of a generic
+// declaration.
+func findTypeParameterTypeScript(decl Node, ident string) *Node {
+	typeParams := decl.ChildByFieldName("type_parameters")
+	if typeParams == nil {
+		return nil
+	}
+	for _, param := range children(typeParams) {
+		if param.Type() != "type_parameter" {
+			continue
+		}
+		name := param.ChildByFieldName("name")
+		if name != nil && name.Content(decl.Contents) == ident {
+			return swapNodePtr(decl, name)
+		}
+	}
+	return nil
+}
+
+// getSupertypesTypeScript returns the extended classes and interfaces of the given class or
+// interface declaration.
+func getSupertypesTypeScript(decl Node) []Node {
+	supers := []Node{}
+	for _, child := range children(decl.Node) {
+		switch child.Type() {
+		case "class_heritage":
+			for _, clause := range children(child) {
+				if clause.Type() != "extends_clause" {
+					continue
+				}
+				for _, super := range children(clause) {
+					if super.Type() == "type_arguments" {
+						continue
+					}
+					supers = append(supers, swapNode(decl, super))
+				}
+			}
+		case "extends_clause":
+			fallthrough
+		case "extends_type_clause":
+			for _, super := range children(child) {
+				if super.Type() == "type_arguments" {
+					continue
+				}
+				supers = append(supers, swapNode(decl, super))
+			}
+		}
+	}
+	return supers
+}
+
+func getStringContentsTypeScript(node Node) string {
+	return strings.Trim(node.Content(node.Contents), "\"'`")
+}
+
+// isRecursiveDefinitionTypeScript detects cases like `const x = x.foo` that would cause infinite
+// recursion when attempting to determine the type of `x`.
+func isRecursiveDefinitionTypeScript(node Node, def Node) bool {
+	if node.RepoCommitPath != def.RepoCommitPath {
+		return false
+	}
+	declarator := def.Parent()
+	if declarator == nil || declarator.Type() != "variable_declarator" {
+		return false
+	}
+	for nodeAncestor := node.Parent(); nodeAncestor != nil; nodeAncestor = nodeAncestor.Parent() {
+		if nodeId(nodeAncestor) == nodeId(declarator) {
+			return true
+		}
+	}
+	return false
+}
+
+type TypeTypeScript interface {
+	variant() string
+	node() Node
+}
+
+type FnTypeTypeScript struct {
+	ret  TypeTypeScript
+	noad Node
+}
+
+func (t FnTypeTypeScript) variant() string {
+	return "fn"
+}
+
+func (t FnTypeTypeScript) node() Node {
+	return t.noad
+}
+
+// ClassTypeTypeScript is a class, an interface or an object type literal.
+type ClassTypeTypeScript struct {
+	def Node
+}
+
+func (t ClassTypeTypeScript) variant() string {
+	return "class"
+}
+
+func (t ClassTypeTypeScript) node() Node {
+	return t.def
+}
+
+type ModuleTypeTypeScript struct {
+	module Node
+}
+
+func (t ModuleTypeTypeScript) variant() string {
+	return "module"
+}
+
+func (t ModuleTypeTypeScript) node() Node {
+	return t.module
+}
+
+func lazyTypeTypeScriptStringer(ty *TypeTypeScript) func() fmt.Stringer {
+	return func() fmt.Stringer {
+		if ty != nil && *ty != nil {
+			return String((*ty).variant())
+		} else {
+			return String("<nil>")
+		}
+	}
+}
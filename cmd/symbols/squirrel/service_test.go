@@ -14,6 +14,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 func init() {
@@ -60,6 +61,10 @@ func TestNonLocalDefinition(t *testing.T) {
 			annotations = append(annotations, collectAnnotations(repoCommitPath, string(contents))...)
 
 			symbols, err := tempSquirrel.getSymbols(context.Background(), repoCommitPath)
+			if errors.Is(err, unrecognizedFileExtensionError) {
+				// e.g. go.mod
+				return nil
+			}
 			fatalIfErrorLabel(t, err, "getSymbols")
 			allSymbols = append(allSymbols, symbols...)
 
@@ -0,0 +1,18 @@
+package geom
+
+//   vvvvv go.Point def
+type Point struct {
+	X int // < "X" go.Point.X def
+	Y int // < "Y" go.Point.Y def
+}
+
+//             vvvv go.Point.Move def
+func (p Point) Move(dx int) Point {
+	//                       v go.Point.Y ref
+	return Point{p.X + dx, p.Y}
+}
+
+//   vvvvvv go.Origin def
+func Origin() Point {
+	return Point{}
+}
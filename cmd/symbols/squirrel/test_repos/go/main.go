@@ -0,0 +1,44 @@
+package main
+
+import (
+	"example.com/shapes/geom"
+)
+
+func main() {
+	//        vvvvvvvvv go.NewSquare ref
+	square := NewSquare(2) // < "square" go.main.square def
+
+	//          vvvvvv go.Square.Corner ref
+	//                 v go.Point.X ref
+	x := square.Corner.X
+
+	//                     vvvv go.Point.Move ref
+	moved := square.Corner.Move(x)
+
+	//         vvvv geom path
+	//              vvvvv go.Point ref
+	var origin geom.Point = moved
+
+	//  vvvvvv go.main.square ref
+	//         vvvv go.Square.Area ref
+	//                         v go.Point.X ref
+	_ = square.Area() + origin.X + double(1)
+
+	//               vvvv go.Square.Area ref
+	_ = NewSquare(3).Area()
+
+	//        vvvvv go.Shape ref
+	var shape Shape = square
+	//        vvvv go.Shape.Area ref
+	_ = shape.Area()
+
+	labeled := Labeled{Label: "unit"}
+	//          vvvv go.Square.Area ref
+	_ = labeled.Area()
+
+	//            vvvvvv go.double ref
+	if doubled := double(x); doubled > 0 { // < "doubled" go.main.doubled def
+		//  vvvvvvv go.main.doubled ref
+		_ = doubled
+	}
+}
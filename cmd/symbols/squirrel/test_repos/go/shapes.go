@@ -0,0 +1,35 @@
+package main
+
+import (
+	"example.com/shapes/geom"
+)
+
+//   vvvvv go.Shape def
+type Shape interface {
+	Area() int // < "Area" go.Shape.Area def
+}
+
+//   vvvvvv go.Square def
+type Square struct {
+	Corner geom.Point // < "Corner" go.Square.Corner def
+	Side   int        // < "Side" go.Square.Side def
+}
+
+//   vvvvvvvvv go.NewSquare def
+func NewSquare(side int) *Square {
+	//                          vvvvvv go.Origin ref
+	return &Square{Corner: geom.Origin(), Side: side}
+}
+
+//               vvvv go.Square.Area def
+func (s *Square) Area() int {
+	//       vvvv go.Square.Side ref
+	//                vvvv go.Square.Side ref
+	return s.Side * s.Side
+}
+
+//   vvvvvvv go.Labeled def
+type Labeled struct {
+	Square
+	Label string
+}
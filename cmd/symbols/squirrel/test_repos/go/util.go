@@ -0,0 +1,6 @@
+package main
+
+//   vvvvvv go.double def
+func double(n int) int {
+	return n * 2
+}
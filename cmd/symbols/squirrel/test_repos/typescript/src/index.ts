@@ -0,0 +1,37 @@
+//     vvvv ts.unit ref
+//             vvvvvv ts.Square ref
+import unit, { Square } from './shapes'
+//          vvvvvv ts.shapes def
+import * as shapes from './shapes'
+//                 vvvvv ts.double ref
+import { double as twice } from './util/math'
+
+//                 vvvvvv ts.Square ref
+const square = new Square(2) // < "square" ts.square def
+
+//     vvvv ts.Square.grow ref
+//             vvvv ts.Square.area ref
+square.grow(1).area()
+
+//     vvvv ts.Square.side ref
+unit().side
+
+//  vvvvvv ts.shapes ref
+//         vvvvvv ts.Square ref
+new shapes.Square(3)
+
+//                  vvvvv ts.Shape ref
+//                          vvvvvv ts.square ref
+const shape: shapes.Shape = square
+//    vvvv ts.Shape.area ref
+shape.area()
+
+twice(2) // < "twice" ts.double ref
+
+//                          vvvvv ts.Shape ref
+function describe(s: shapes.Shape): number {
+  //       vvvv ts.Shape.area ref
+  return s.area()
+}
+
+describe(square)
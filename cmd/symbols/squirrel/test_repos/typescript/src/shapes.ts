@@ -0,0 +1,27 @@
+//               vvvvv ts.Shape def
+export interface Shape {
+  area(): number // < "area" ts.Shape.area def
+}
+
+//           vvvvvv ts.Square def
+export class Square implements Shape {
+  constructor(public side: number) {} // < "side" ts.Square.side def
+
+  area(): number { // < "area" ts.Square.area def
+    //          vvvv ts.Square.side ref
+    return this.side * this.side
+  }
+
+  grow(by: number): Square { // < "grow" ts.Square.grow def
+    //         vvvvvv ts.Square ref
+    //                     vvvv ts.Square.side ref
+    return new Square(this.side + by)
+  }
+}
+
+//       vvvv ts.unit def
+function unit(): Square {
+  return new Square(1)
+}
+
+export default unit
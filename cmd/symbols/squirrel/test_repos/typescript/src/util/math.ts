@@ -0,0 +1,4 @@
+//              vvvvvv ts.double def
+export function double(n: number): number {
+  return n * 2
+}
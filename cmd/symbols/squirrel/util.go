@@ -387,6 +387,19 @@ func lazyNodeStringer(node **Node) func() fmt.Stringer {
 }
 
 func (s *SquirrelService) symbolSearchOne(ctx context.Context, repo string, commit string, include []string, ident string) (*Node, error) {
+	nodes, err := s.symbolSearchN(ctx, repo, commit, include, ident, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, nil
+	}
+	return &nodes[0], nil
+}
+
+// symbolSearchN returns the nodes of up to first symbols with the given name. Symbols in files that
+// can't be parsed are skipped.
+func (s *SquirrelService) symbolSearchN(ctx context.Context, repo string, commit string, include []string, ident string, first int) ([]Node, error) {
 	symbols, err := s.symbolSearch(ctx, search.SymbolsParameters{
 		Repo:            api.RepoName(repo),
 		CommitID:        api.CommitID(commit),
@@ -395,34 +408,34 @@ func (s *SquirrelService) symbolSearchOne(ctx context.Context, repo string, comm
 		IsCaseSensitive: true,
 		IncludePatterns: include,
 		ExcludePattern:  "",
-		First:           1,
+		First:           first,
 	})
 	if err != nil {
 		return nil, err
 	}
-	if len(symbols) == 0 {
-		return nil, nil
-	}
-	symbol := symbols[0]
-	file, err := s.parse(ctx, types.RepoCommitPath{
-		Repo:   repo,
-		Commit: commit,
-		Path:   symbol.Path,
-	})
-	if errors.Is(err, UnsupportedLanguageError) || errors.Is(err, unrecognizedFileExtensionError) {
-		return nil, nil
-	}
-	if err != nil {
-		return nil, err
-	}
-	point := sitter.Point{
-		Row:    uint32(symbol.Line),
-		Column: uint32(symbol.Character),
-	}
-	symbolNode := file.NamedDescendantForPointRange(point, point)
-	if symbolNode == nil {
-		return nil, nil
+
+	nodes := []Node{}
+	for _, symbol := range symbols {
+		file, err := s.parse(ctx, types.RepoCommitPath{
+			Repo:   repo,
+			Commit: commit,
+			Path:   symbol.Path,
+		})
+		if errors.Is(err, UnsupportedLanguageError) || errors.Is(err, unrecognizedFileExtensionError) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		point := sitter.Point{
+			Row:    uint32(symbol.Line),
+			Column: uint32(symbol.Character),
+		}
+		symbolNode := file.NamedDescendantForPointRange(point, point)
+		if symbolNode == nil {
+			continue
+		}
+		nodes = append(nodes, swapNode(*file, symbolNode))
 	}
-	ret := swapNode(*file, symbolNode)
-	return &ret, nil
+	return nodes, nil
 }
@@ -0,0 +1,31 @@
+package shared
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/sourcegraph/log"
+
+	workerdb "github.com/sourcegraph/sourcegraph/cmd/worker/shared/init/db"
+	"github.com/sourcegraph/sourcegraph/internal/debugserver"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
+)
+
+func CreateDebugServerEndpoints() []debugserver.Endpoint {
+	return []debugserver.Endpoint{
+		{
+			Name: "Job Dependencies",
+			Path: "/job-dependencies",
+			Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				db, err := workerdb.InitDB(observation.NewContext(log.Scoped("job-dependencies", "debug endpoint serving the dependency graphs of jobs")))
+				if err != nil {
+					http.Error(w, fmt.Sprintf("failed to connect to database: %q", err.Error()), http.StatusInternalServerError)
+					return
+				}
+
+				dbworker.NewDependencyGraphHandler(db.Handle()).ServeHTTP(w, r)
+			}),
+		},
+	}
+}
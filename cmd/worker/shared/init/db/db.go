@@ -21,11 +21,16 @@ func InitDB(observationCtx *observation.Context) (database.DB, error) {
 	return database.NewDB(observationCtx.Logger, rawDB), nil
 }
 
-var initDatabaseMemo = memo.NewMemoizedConstructorWithArg(func(observationCtx *observation.Context) (*sql.DB, error) {
-	dsn := conf.GetServiceConnectionValueAndRestartOnChange(func(serviceConnections conftypes.ServiceConnections) string {
+// DataSource returns the data source name of the frontend database, for connections that must not
+// be shared with other users of the database, like the ones of dbworker.Listener.
+func DataSource() string {
+	return conf.GetServiceConnectionValueAndRestartOnChange(func(serviceConnections conftypes.ServiceConnections) string {
 		return serviceConnections.PostgresDSN
 	})
-	db, err := connections.EnsureNewFrontendDB(observationCtx, dsn, "worker")
+}
+
+var initDatabaseMemo = memo.NewMemoizedConstructorWithArg(func(observationCtx *observation.Context) (*sql.DB, error) {
+	db, err := connections.EnsureNewFrontendDB(observationCtx, DataSource(), "worker")
 	if err != nil {
 		return nil, errors.Errorf("failed to connect to frontend database: %s", err)
 	}
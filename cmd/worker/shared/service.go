@@ -14,7 +14,7 @@ type svc struct{}
 func (svc) Name() string { return "worker" }
 
 func (svc) Configure() (env.Config, []debugserver.Endpoint) {
-	return LoadConfig(nil, nil), nil
+	return LoadConfig(nil, nil), CreateDebugServerEndpoints()
 }
 
 func (svc) Start(ctx context.Context, observationCtx *observation.Context, ready service.ReadyFunc, config env.Config) error {
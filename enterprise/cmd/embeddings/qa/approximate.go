@@ -0,0 +1,88 @@
+package qa
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/embeddings"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ApproximateRecall is the recall of approximate searches with a number of candidates.
+type ApproximateRecall struct {
+	Ef     int
+	Recall float64
+	// Latency is the mean latency of approximate searches.
+	Latency time.Duration
+}
+
+// RunApproximate builds the HNSW graph of the index with the given parameters and measures the
+// recall of approximate searches of the index for each of the given numbers of candidates. Recall is
+// the share of the results of exhaustive searches that are also found by approximate searches.
+func RunApproximate(index *embeddings.EmbeddingIndex, queries [][]float32, numResults int, params embeddings.HNSWParameters, efs []int) ([]ApproximateRecall, error) {
+	if len(queries) == 0 {
+		return nil, errors.New("no queries")
+	}
+
+	// Build the graph regardless of the size of the index.
+	params.MinRows = 0
+	start := time.Now()
+	index.BuildGraph(params)
+	if index.Graph == nil {
+		return nil, errors.New("failed to build graph")
+	}
+	fmt.Printf("Built graph of %d rows in %s\n", len(index.RowMetadata), time.Since(start))
+
+	quantizedQueries := make([][]int8, 0, len(queries))
+	exactResults := make([]map[string]struct{}, 0, len(queries))
+	var exactLatency time.Duration
+	for _, query := range queries {
+		quantizedQuery := embeddings.Quantize(query, nil)
+		quantizedQueries = append(quantizedQueries, quantizedQuery)
+
+		start := time.Now()
+		results := index.SimilaritySearch(quantizedQuery, numResults, embeddings.WorkerOptions{NumWorkers: 1}, embeddings.SearchOptions{}, "", "")
+		exactLatency += time.Since(start)
+
+		exactResults = append(exactResults, resultKeys(results))
+	}
+
+	fmt.Println()
+	fmt.Printf("Exhaustive search: latency %s\n", exactLatency/time.Duration(len(queries)))
+
+	recalls := make([]ApproximateRecall, 0, len(efs))
+	for _, ef := range efs {
+		found, total := 0, 0
+		var latency time.Duration
+		for i, query := range quantizedQueries {
+			start := time.Now()
+			results := index.SimilaritySearch(query, numResults, embeddings.WorkerOptions{NumWorkers: 1}, embeddings.SearchOptions{ApproximateSearchEf: ef}, "", "")
+			latency += time.Since(start)
+
+			for key := range resultKeys(results) {
+				if _, ok := exactResults[i][key]; ok {
+					found++
+				}
+			}
+			total += len(exactResults[i])
+		}
+
+		recall := ApproximateRecall{
+			Ef:      ef,
+			Recall:  float64(found) / float64(total),
+			Latency: latency / time.Duration(len(queries)),
+		}
+		fmt.Printf("Approximate search (ef=%d): recall %f, latency %s\n", recall.Ef, recall.Recall, recall.Latency)
+		recalls = append(recalls, recall)
+	}
+
+	return recalls, nil
+}
+
+func resultKeys(results []embeddings.EmbeddingSearchResult) map[string]struct{} {
+	keys := make(map[string]struct{}, len(results))
+	for _, result := range results {
+		keys[fmt.Sprintf("%s:%d-%d", result.FileName, result.StartLine, result.EndLine)] = struct{}{}
+	}
+	return keys
+}
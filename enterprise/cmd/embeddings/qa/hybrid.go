@@ -0,0 +1,79 @@
+package qa
+
+import (
+	"fmt"
+
+	codycontext "github.com/sourcegraph/sourcegraph/internal/codycontext"
+	"github.com/sourcegraph/sourcegraph/internal/embeddings"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type hybridSearcher struct {
+	embeddingsSearcher embeddingsSearcher
+	keywordSearcher    embeddingsSearcher
+	options            codycontext.FusionOptions
+}
+
+// NewHybridSearcher returns a searcher that merges the results of embeddings search and keyword
+// search the same way Cody context is merged in hybrid mode, so that the recall of different
+// fusion options can be measured with Run.
+func NewHybridSearcher(embeddingsSearcher, keywordSearcher embeddingsSearcher, options codycontext.FusionOptions) *hybridSearcher {
+	return &hybridSearcher{
+		embeddingsSearcher: embeddingsSearcher,
+		keywordSearcher:    keywordSearcher,
+		options:            options,
+	}
+}
+
+func (s *hybridSearcher) Search(args embeddings.EmbeddingsSearchParameters) (*embeddings.EmbeddingCombinedSearchResults, error) {
+	embeddingsResults, err := s.embeddingsSearcher.Search(args)
+	if err != nil {
+		return nil, errors.Wrap(err, "embeddings search failed")
+	}
+	keywordResults, err := s.keywordSearcher.Search(args)
+	if err != nil {
+		return nil, errors.Wrap(err, "keyword search failed")
+	}
+
+	return &embeddings.EmbeddingCombinedSearchResults{
+		CodeResults: s.fuse(args.CodeResultsCount, embeddingsResults.CodeResults, keywordResults.CodeResults),
+		TextResults: s.fuse(args.TextResultsCount, embeddingsResults.TextResults, keywordResults.TextResults),
+	}, nil
+}
+
+func (s *hybridSearcher) fuse(limit int, embeddingsResults, keywordResults []embeddings.EmbeddingSearchResult) []embeddings.EmbeddingSearchResult {
+	// Fused chunks keep the lines of one of the chunks they were merged from, so the original
+	// results can be looked up by their lines.
+	originals := map[string]embeddings.EmbeddingSearchResult{}
+	toChunks := func(results []embeddings.EmbeddingSearchResult) []codycontext.FileChunkContext {
+		chunks := make([]codycontext.FileChunkContext, 0, len(results))
+		for _, result := range results {
+			chunk := codycontext.FileChunkContext{
+				RepoName:  result.RepoName,
+				Path:      result.FileName,
+				StartLine: result.StartLine,
+				EndLine:   result.EndLine,
+			}
+			if _, ok := originals[chunkKey(chunk)]; !ok {
+				originals[chunkKey(chunk)] = result
+			}
+			chunks = append(chunks, chunk)
+		}
+		return chunks
+	}
+
+	fused := codycontext.FuseResults(s.options.K, limit,
+		codycontext.RankedResults{Weight: s.options.EmbeddingsWeight, Results: toChunks(embeddingsResults)},
+		codycontext.RankedResults{Weight: s.options.KeywordWeight, Results: toChunks(keywordResults)},
+	)
+
+	results := make([]embeddings.EmbeddingSearchResult, 0, len(fused))
+	for _, chunk := range fused {
+		results = append(results, originals[chunkKey(chunk)])
+	}
+	return results
+}
+
+func chunkKey(chunk codycontext.FileChunkContext) string {
+	return fmt.Sprintf("%s/%s:%d-%d", chunk.RepoName, chunk.Path, chunk.StartLine, chunk.EndLine)
+}
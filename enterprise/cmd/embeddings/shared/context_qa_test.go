@@ -11,10 +11,14 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"testing"
+	"unicode"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/embeddings/qa"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	codycontext "github.com/sourcegraph/sourcegraph/internal/codycontext"
 	"github.com/sourcegraph/sourcegraph/internal/embeddings"
 	uploadstoremocks "github.com/sourcegraph/sourcegraph/internal/uploadstore/mocks"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -31,6 +35,92 @@ func TestRecall(t *testing.T) {
 		t.Skip("Cannot run this test outside of Bazel")
 	}
 
+	recall, err := qa.Run(newQAEmbeddingsSearcher(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	epsilon := 0.0001
+	wantMinRecall := 0.4285
+
+	if d := wantMinRecall - recall; d > epsilon {
+		t.Fatalf("Recall decreased: want %f, got %f", wantMinRecall, recall)
+	}
+}
+
+// TestHybridRecall measures the recall of embeddings search results merged with the results of a
+// keyword search over file paths, which stands in for keyword search as the contents of the
+// indexed files are not available.
+func TestHybridRecall(t *testing.T) {
+	if os.Getenv("BAZEL_TEST") != "1" {
+		t.Skip("Cannot run this test outside of Bazel")
+	}
+
+	index, err := embeddings.DownloadRepoEmbeddingIndex(context.Background(), newQAUploadStore(), 0, "github.com/sourcegraph/sourcegraph")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	embeddingsSearcher := newQAEmbeddingsSearcher(t)
+	embeddingsRecall, err := qa.Run(embeddingsSearcher)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	searcher := qa.NewHybridSearcher(embeddingsSearcher, &pathKeywordSearcher{index: index}, codycontext.DefaultFusionOptions)
+	recall, err := qa.Run(searcher)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	epsilon := 0.0001
+	// Hybrid search must be at least as good as the floor of embeddings search.
+	wantMinRecall := 0.4285
+
+	if d := wantMinRecall - recall; d > epsilon {
+		t.Fatalf("Hybrid recall decreased: want %f, got %f", wantMinRecall, recall)
+	}
+	if d := embeddingsRecall - recall; d > epsilon {
+		t.Fatalf("Hybrid recall is lower than embeddings recall: want at least %f, got %f", embeddingsRecall, recall)
+	}
+}
+
+func TestApproximateRecall(t *testing.T) {
+	if os.Getenv("BAZEL_TEST") != "1" {
+		t.Skip("Cannot run this test outside of Bazel")
+	}
+
+	queryEmbeddings, err := loadQueryEmbeddings(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	queries := make([][]float32, 0, len(queryEmbeddings))
+	for _, embedding := range queryEmbeddings {
+		queries = append(queries, embedding)
+	}
+
+	index, err := embeddings.DownloadRepoEmbeddingIndex(context.Background(), newQAUploadStore(), 0, "github.com/sourcegraph/sourcegraph")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recalls, err := qa.RunApproximate(&index.CodeIndex, queries, 20, embeddings.DefaultHNSWParameters, []int{50, 100, approximateSearchEf, 400})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantMinRecall := 0.9
+	for _, recall := range recalls {
+		if recall.Ef == approximateSearchEf && recall.Recall < wantMinRecall {
+			t.Fatalf("Approximate recall with ef=%d too low: want at least %f, got %f", recall.Ef, wantMinRecall, recall.Recall)
+		}
+	}
+}
+
+// newQAEmbeddingsSearcher returns a searcher of the embedding index of the test data.
+func newQAEmbeddingsSearcher(t *testing.T) embeddingsSearcherFunc {
+	t.Helper()
+
 	ctx := context.Background()
 
 	// Set up mock functions
@@ -43,15 +133,7 @@ func TestRecall(t *testing.T) {
 		return queryEmbeddings[query], "openai/text-embedding-ada-002", nil
 	}
 
-	mockStore := uploadstoremocks.NewMockStore()
-	mockStore.GetFunc.SetDefaultHook(func(ctx context.Context, key string) (io.ReadCloser, error) {
-		b, err := fs.ReadFile(filepath.Join("testdata", key))
-		if err != nil {
-			return nil, err
-		}
-
-		return io.NopCloser(bytes.NewReader(b)), nil
-	})
+	mockStore := newQAUploadStore()
 	getRepoEmbeddingIndex := func(ctx context.Context, repoID api.RepoID, repoName api.RepoName) (*embeddings.RepoEmbeddingIndex, error) {
 		return embeddings.DownloadRepoEmbeddingIndex(context.Background(), mockStore, repoID, repoName)
 	}
@@ -59,7 +141,7 @@ func TestRecall(t *testing.T) {
 	// Weaviate is disabled per default. We don't need it for this test.
 	weaviate := &weaviateClient{}
 
-	searcher := func(args embeddings.EmbeddingsSearchParameters) (*embeddings.EmbeddingCombinedSearchResults, error) {
+	return func(args embeddings.EmbeddingsSearchParameters) (*embeddings.EmbeddingCombinedSearchResults, error) {
 		return searchRepoEmbeddingIndexes(
 			ctx,
 			args,
@@ -68,18 +150,20 @@ func TestRecall(t *testing.T) {
 			weaviate,
 		)
 	}
+}
 
-	recall, err := qa.Run(embeddingsSearcherFunc(searcher))
-	if err != nil {
-		t.Fatal(err)
-	}
-
-	epsilon := 0.0001
-	wantMinRecall := 0.4285
+// newQAUploadStore returns an upload store that serves the test data.
+func newQAUploadStore() *uploadstoremocks.MockStore {
+	mockStore := uploadstoremocks.NewMockStore()
+	mockStore.GetFunc.SetDefaultHook(func(ctx context.Context, key string) (io.ReadCloser, error) {
+		b, err := fs.ReadFile(filepath.Join("testdata", key))
+		if err != nil {
+			return nil, err
+		}
 
-	if d := wantMinRecall - recall; d > epsilon {
-		t.Fatalf("Recall decreased: want %f, got %f", wantMinRecall, recall)
-	}
+		return io.NopCloser(bytes.NewReader(b)), nil
+	})
+	return mockStore
 }
 
 // loadQueryEmbeddings loads the query embeddings from the
@@ -115,3 +199,61 @@ type embeddingsSearcherFunc func(args embeddings.EmbeddingsSearchParameters) (*e
 func (f embeddingsSearcherFunc) Search(args embeddings.EmbeddingsSearchParameters) (*embeddings.EmbeddingCombinedSearchResults, error) {
 	return f(args)
 }
+
+// pathKeywordSearcher ranks the chunks of an embedding index by the number of words of the query
+// that appear in their file path.
+type pathKeywordSearcher struct {
+	index *embeddings.RepoEmbeddingIndex
+}
+
+func (s *pathKeywordSearcher) Search(args embeddings.EmbeddingsSearchParameters) (*embeddings.EmbeddingCombinedSearchResults, error) {
+	var words []string
+	for _, word := range strings.FieldsFunc(strings.ToLower(args.Query), func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+		// Skip short words, which are mostly stop words.
+		if len(word) > 3 {
+			words = append(words, word)
+		}
+	}
+
+	search := func(index *embeddings.EmbeddingIndex, limit int) []embeddings.EmbeddingSearchResult {
+		type match struct {
+			row   int
+			score int
+		}
+		var matches []match
+		for row, metadata := range index.RowMetadata {
+			path := strings.ToLower(metadata.FileName)
+			score := 0
+			for _, word := range words {
+				if strings.Contains(path, word) {
+					score++
+				}
+			}
+			if score > 0 {
+				matches = append(matches, match{row: row, score: score})
+			}
+		}
+		sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+
+		results := make([]embeddings.EmbeddingSearchResult, 0, limit)
+		for _, m := range matches {
+			if len(results) == limit {
+				break
+			}
+			metadata := index.RowMetadata[m.row]
+			results = append(results, embeddings.EmbeddingSearchResult{
+				RepoName:  s.index.RepoName,
+				Revision:  s.index.Revision,
+				FileName:  metadata.FileName,
+				StartLine: metadata.StartLine,
+				EndLine:   metadata.EndLine,
+			})
+		}
+		return results
+	}
+
+	return &embeddings.EmbeddingCombinedSearchResults{
+		CodeResults: search(&s.index.CodeIndex, args.CodeResultsCount),
+		TextResults: search(&s.index.TextIndex, args.TextResultsCount),
+	}, nil
+}
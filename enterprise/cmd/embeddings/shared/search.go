@@ -8,6 +8,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/embeddings"
+	"github.com/sourcegraph/sourcegraph/internal/env"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -17,6 +18,8 @@ const (
 	queryEmbeddingRetries          = 3
 )
 
+var approximateSearchEf = env.MustGetInt("EMBEDDINGS_APPROXIMATE_SEARCH_EF", 200, "The number of candidates considered when searching embedding indexes that have an HNSW graph. Larger values trade latency for recall. Set to 0 to always search embedding indexes exhaustively.")
+
 type (
 	getRepoEmbeddingIndexFn func(ctx context.Context, repoID api.RepoID, repoName api.RepoName) (*embeddings.RepoEmbeddingIndex, error)
 	getQueryEmbeddingFn     func(ctx context.Context, model string) ([]float32, string, error)
@@ -44,7 +47,8 @@ func searchRepoEmbeddingIndexes(
 	}
 
 	searchOpts := embeddings.SearchOptions{
-		UseDocumentRanks: params.UseDocumentRanks,
+		UseDocumentRanks:    params.UseDocumentRanks,
+		ApproximateSearchEf: approximateSearchEf,
 	}
 
 	searchRepo := func(repoID api.RepoID, repoName api.RepoName) (codeResults, textResults []embeddings.EmbeddingSearchResult, err error) {
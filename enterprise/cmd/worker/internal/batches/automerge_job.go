@@ -0,0 +1,51 @@
+package batches
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/batches/automerge"
+	"github.com/sourcegraph/sourcegraph/internal/batches/sources"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+type autoMergeJob struct{}
+
+func NewAutoMergeJob() job.Job {
+	return &autoMergeJob{}
+}
+
+func (j *autoMergeJob) Description() string {
+	return "Merges changesets that satisfy the auto-merge policy of their batch change."
+}
+
+func (j *autoMergeJob) Config() []env.Config {
+	return []env.Config{}
+}
+
+func (j *autoMergeJob) Routines(_ context.Context, observationCtx *observation.Context) ([]goroutine.BackgroundRoutine, error) {
+	observationCtx = observation.NewContext(observationCtx.Logger.Scoped("routines", "auto-merge job routines"))
+	workCtx := actor.WithInternalActor(context.Background())
+
+	bstore, err := InitStore()
+	if err != nil {
+		return nil, err
+	}
+
+	routines := []goroutine.BackgroundRoutine{
+		automerge.NewMerger(
+			workCtx,
+			observationCtx,
+			bstore,
+			sources.NewSourcer(httpcli.NewExternalClientFactory(
+				httpcli.NewLoggingMiddleware(observationCtx.Logger.Scoped("sourcer", "batches sourcer")),
+			)),
+		),
+	}
+
+	return routines, nil
+}
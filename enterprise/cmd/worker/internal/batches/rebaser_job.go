@@ -0,0 +1,42 @@
+package batches
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/batches/rebaser"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+type rebaserJob struct{}
+
+func NewRebaserJob() job.Job {
+	return &rebaserJob{}
+}
+
+func (j *rebaserJob) Description() string {
+	return ""
+}
+
+func (j *rebaserJob) Config() []env.Config {
+	return []env.Config{}
+}
+
+func (j *rebaserJob) Routines(_ context.Context, observationCtx *observation.Context) ([]goroutine.BackgroundRoutine, error) {
+	observationCtx = observation.NewContext(observationCtx.Logger.Scoped("routines", "rebaser job routines"))
+	workCtx := actor.WithInternalActor(context.Background())
+
+	bstore, err := InitStore()
+	if err != nil {
+		return nil, err
+	}
+
+	routines := []goroutine.BackgroundRoutine{
+		rebaser.NewRebaser(workCtx, observationCtx, bstore),
+	}
+
+	return routines, nil
+}
@@ -0,0 +1,42 @@
+package batches
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/batches/rollout"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+type rolloutJob struct{}
+
+func NewRolloutJob() job.Job {
+	return &rolloutJob{}
+}
+
+func (j *rolloutJob) Description() string {
+	return ""
+}
+
+func (j *rolloutJob) Config() []env.Config {
+	return []env.Config{}
+}
+
+func (j *rolloutJob) Routines(_ context.Context, observationCtx *observation.Context) ([]goroutine.BackgroundRoutine, error) {
+	observationCtx = observation.NewContext(observationCtx.Logger.Scoped("routines", "rollout job routines"))
+	workCtx := actor.WithInternalActor(context.Background())
+
+	bstore, err := InitStore()
+	if err != nil {
+		return nil, err
+	}
+
+	routines := []goroutine.BackgroundRoutine{
+		rollout.NewAdvancer(workCtx, observationCtx, bstore),
+	}
+
+	return routines, nil
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/batches/store"
 	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
@@ -20,11 +21,13 @@ func NewBatchSpecResolutionWorker(
 	ctx context.Context,
 	observationCtx *observation.Context,
 	s *store.Store,
+	gitserverClient gitserver.Client,
 	workerStore dbworkerstore.Store[*btypes.BatchSpecResolutionJob],
 ) *workerutil.Worker[*btypes.BatchSpecResolutionJob] {
 	e := &batchSpecWorkspaceCreator{
-		store:  s,
-		logger: log.Scoped("batch-spec-workspace-creator", "The background worker running workspace resolutions for batch changes"),
+		store:           s,
+		gitserverClient: gitserverClient,
+		logger:          log.Scoped("batch-spec-workspace-creator", "The background worker running workspace resolutions for batch changes"),
 	}
 
 	options := workerutil.WorkerOptions{
@@ -14,12 +14,14 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/batches/rewrite"
 	"github.com/sourcegraph/sourcegraph/internal/batches/service"
 	"github.com/sourcegraph/sourcegraph/internal/batches/store"
 	"github.com/sourcegraph/sourcegraph/internal/batches/store/author"
 	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
 	"github.com/sourcegraph/sourcegraph/lib/batches/execution"
@@ -31,8 +33,9 @@ import (
 // batchSpecWorkspaceCreator takes in BatchSpecs, resolves them into
 // RepoWorkspaces and then persists those as pending BatchSpecWorkspaces.
 type batchSpecWorkspaceCreator struct {
-	store  *store.Store
-	logger log.Logger
+	store           *store.Store
+	gitserverClient gitserver.Client
+	logger          log.Logger
 }
 
 // HandlerFunc returns a workerutil.HandlerFunc that can be passed to a
@@ -275,8 +278,16 @@ func (r *batchSpecWorkspaceCreator) process(
 		// execution step result.
 		res, found := workspace.dbWorkspace.StepCacheResult(latestStepIdx + 1)
 		if !found {
-			// There is no cache result available, proceed.
-			continue
+			if !spec.Spec.HasOnlyRewriteSteps() {
+				// There is no cache result available, proceed.
+				continue
+			}
+			// Rewrite steps don't need an executor, so we run them right away
+			// and treat their results like cached results.
+			res, err = r.runRewriteSteps(ctx, spec.Spec, workspace, latestStepIdx)
+			if err != nil {
+				return err
+			}
 		}
 
 		workspace.dbWorkspace.CachedResultFound = true
@@ -336,6 +347,34 @@ func (r *batchSpecWorkspaceCreator) process(
 	return tx.CreateBatchSpecWorkspace(ctx, ws...)
 }
 
+// runRewriteSteps runs the rewrite steps of the batch spec in the workspace,
+// stores the step results on the workspace and returns the result of the
+// latest step.
+func (r *batchSpecWorkspaceCreator) runRewriteSteps(ctx context.Context, spec *batcheslib.BatchSpec, workspace workspaceCacheKey, latestStepIdx int) (btypes.StepCacheResult, error) {
+	results, err := rewrite.Run(ctx, r.gitserverClient, spec, rewrite.Workspace{
+		Repo: workspace.repo,
+		Path: workspace.dbWorkspace.Path,
+	}, workspace.skippedSteps)
+	if err != nil {
+		return btypes.StepCacheResult{}, errors.Wrapf(err, "running rewrite steps in %s", workspace.repo.Name)
+	}
+
+	keys := make(map[int]string, len(workspace.stepCacheKeys))
+	for _, ck := range workspace.stepCacheKeys {
+		keys[ck.index] = ck.key
+	}
+	for i := range results {
+		res := results[i]
+		workspace.dbWorkspace.SetStepCacheResult(res.StepIndex+1, btypes.StepCacheResult{Key: keys[res.StepIndex], Value: &res})
+	}
+
+	res, found := workspace.dbWorkspace.StepCacheResult(latestStepIdx + 1)
+	if !found {
+		return btypes.StepCacheResult{}, errors.Newf("no result for step %d", latestStepIdx+1)
+	}
+	return res, nil
+}
+
 func listBatchSpecMounts(ctx context.Context, s *store.Store, batchSpecID int64) ([]*btypes.BatchSpecWorkspaceFile, error) {
 	mounts, _, err := s.ListBatchSpecWorkspaceFiles(ctx, store.ListBatchSpecWorkspaceFileOpts{BatchSpecID: batchSpecID})
 	if err != nil {
@@ -21,6 +21,7 @@ import (
 	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
 	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
@@ -997,6 +998,93 @@ func TestBatchSpecWorkspaceCreatorProcess_Secrets(t *testing.T) {
 	}
 }
 
+func TestBatchSpecWorkspaceCreatorProcess_RewriteSteps(t *testing.T) {
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+
+	repos, _ := bt.CreateTestRepos(t, ctx, db, 1)
+
+	user := bt.CreateTestUser(t, db, true)
+
+	s := store.New(db, &observation.TestContext, nil)
+
+	testSpecYAML := `
+name: my-unique-name
+on:
+  - repository: ` + string(repos[0].Name) + `
+steps:
+  - rewrite:
+      match: foo
+      replace: bar
+      patternType: regexp
+changesetTemplate:
+  title: Rewrite
+  body: Rewrite foo
+  branch: rewrite
+  commit:
+    message: Rewrite foo
+  published: false
+`
+
+	batchSpec, err := btypes.NewBatchSpecFromRaw(testSpecYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	batchSpec.UserID = user.ID
+	batchSpec.NamespaceUserID = user.ID
+	if err := s.CreateBatchSpec(ctx, batchSpec); err != nil {
+		t.Fatal(err)
+	}
+
+	job := &btypes.BatchSpecResolutionJob{BatchSpecID: batchSpec.ID}
+
+	resolver := &dummyWorkspaceResolver{
+		workspaces: []*service.RepoWorkspace{
+			{
+				RepoRevision: &service.RepoRevision{
+					Repo:        repos[0],
+					Branch:      "refs/heads/main",
+					Commit:      "d34db33f",
+					FileMatches: []string{"a/b/c.txt"},
+				},
+				Path: "",
+			},
+		},
+	}
+
+	gitserverClient := gitserver.NewMockClient()
+	gitserverClient.ReadFileFunc.SetDefaultReturn([]byte("foo\n"), nil)
+
+	creator := &batchSpecWorkspaceCreator{store: s, gitserverClient: gitserverClient, logger: logtest.Scoped(t)}
+	if err := creator.process(ctx, resolver.DummyBuilder, job); err != nil {
+		t.Fatalf("proces failed: %s", err)
+	}
+
+	have, _, err := s.ListBatchSpecWorkspaces(ctx, store.ListBatchSpecWorkspacesOpts{BatchSpecID: batchSpec.ID})
+	if err != nil {
+		t.Fatalf("listing workspaces failed: %s", err)
+	}
+	if len(have) != 1 {
+		t.Fatalf("wrong number of workspaces. want=1, have=%d", len(have))
+	}
+	if !have[0].CachedResultFound {
+		t.Fatal("workspace of rewrite steps is not marked as having a result")
+	}
+	if len(have[0].ChangesetSpecIDs) != 1 {
+		t.Fatalf("wrong number of changeset specs. want=1, have=%d", len(have[0].ChangesetSpecIDs))
+	}
+
+	changesetSpec, err := s.GetChangesetSpecByID(ctx, have[0].ChangesetSpecIDs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantDiff := "diff --git a/b/c.txt a/b/c.txt\n--- a/b/c.txt\n+++ a/b/c.txt\n@@ -1 +1 @@\n-foo\n+bar\n"
+	if diff := cmp.Diff(wantDiff, string(changesetSpec.Diff)); diff != "" {
+		t.Fatalf("wrong diff (-want +got):\n%s", diff)
+	}
+}
+
 type dummyWorkspaceResolver struct {
 	workspaces []*service.RepoWorkspace
 	err        error
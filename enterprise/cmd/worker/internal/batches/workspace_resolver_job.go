@@ -7,6 +7,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/worker/internal/batches/workers"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 )
@@ -43,6 +44,7 @@ func (j *workspaceResolverJob) Routines(_ context.Context, observationCtx *obser
 		workCtx,
 		observationCtx,
 		bstore,
+		gitserver.NewClient(),
 		resStore,
 	)
 
@@ -47,5 +47,6 @@ func (j *autoindexingDependencyScheduler) Routines(_ context.Context, observatio
 		services.DependenciesService,
 		services.AutoIndexingService,
 		repoupdater.DefaultClient,
+		workerdb.DataSource(),
 	), nil
 }
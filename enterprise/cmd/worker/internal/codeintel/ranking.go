@@ -28,6 +28,7 @@ func (j *rankingJob) Config() []env.Config {
 		ranking.MapperConfigInst,
 		ranking.ReducerConfigInst,
 		ranking.JanitorConfigInst,
+		ranking.DeadCodeConfigInst,
 	}
 }
 
@@ -43,5 +44,6 @@ func (j *rankingJob) Routines(_ context.Context, observationCtx *observation.Con
 	routines = append(routines, ranking.NewMapper(observationCtx, services.RankingService)...)
 	routines = append(routines, ranking.NewReducer(observationCtx, services.RankingService))
 	routines = append(routines, ranking.NewSymbolJanitor(observationCtx, services.RankingService)...)
+	routines = append(routines, ranking.NewDeadCodeReporter(observationCtx, services.RankingService))
 	return routines, nil
 }
@@ -16,6 +16,7 @@ import (
 	bgrepo "github.com/sourcegraph/sourcegraph/internal/embeddings/background/repo"
 	"github.com/sourcegraph/sourcegraph/internal/embeddings/db"
 	"github.com/sourcegraph/sourcegraph/internal/embeddings/embed"
+	"github.com/sourcegraph/sourcegraph/internal/env"
 	"github.com/sourcegraph/sourcegraph/internal/featureflag"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/paths"
@@ -44,6 +45,14 @@ const (
 	embeddingsBatchSize                     = 512
 )
 
+// hnswParameters configure the HNSW graphs built alongside the embeddings of large repositories
+// for approximate similarity searches.
+var hnswParameters = embeddings.HNSWParameters{
+	M:              env.MustGetInt("EMBEDDINGS_HNSW_M", embeddings.DefaultHNSWParameters.M, "The number of neighbors of each embedding in the HNSW graphs of embedding indexes. Set to 0 to disable HNSW graphs."),
+	EfConstruction: env.MustGetInt("EMBEDDINGS_HNSW_EF_CONSTRUCTION", embeddings.DefaultHNSWParameters.EfConstruction, "The number of candidates considered when linking an embedding to its neighbors in the HNSW graphs of embedding indexes."),
+	MinRows:        env.MustGetInt("EMBEDDINGS_HNSW_MIN_ROWS", embeddings.DefaultHNSWParameters.MinRows, "The minimum number of embeddings of an embedding index for an HNSW graph to be built."),
+}
+
 var splitOptions = codeintelContext.SplitOptions{
 	NoSplitTokensThreshold:         embedEntireFileTokensThreshold,
 	ChunkTokensThreshold:           embeddingChunkTokensThreshold,
@@ -204,8 +213,9 @@ func (h *handler) Handle(ctx context.Context, logger log.Logger, record *bgrepo.
 
 	indexName := string(embeddings.GetRepoEmbeddingIndexName(repo.ID))
 	if stats.IsIncremental {
-		return embeddings.UpdateRepoEmbeddingIndex(ctx, h.uploadStore, indexName, previousIndex, repoEmbeddingIndex, toRemove, ranks)
+		return embeddings.UpdateRepoEmbeddingIndex(ctx, h.uploadStore, indexName, previousIndex, repoEmbeddingIndex, toRemove, ranks, hnswParameters)
 	} else {
+		repoEmbeddingIndex.BuildGraphs(hnswParameters)
 		return embeddings.UploadRepoEmbeddingIndex(ctx, h.uploadStore, indexName, repoEmbeddingIndex)
 	}
 }
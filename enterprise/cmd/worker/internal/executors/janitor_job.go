@@ -6,6 +6,8 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
 	workerdb "github.com/sourcegraph/sourcegraph/cmd/worker/shared/init/db"
 	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/executor/artifacts"
+	executorstore "github.com/sourcegraph/sourcegraph/internal/executor/store"
 	executortypes "github.com/sourcegraph/sourcegraph/internal/executor/types"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
@@ -23,7 +25,7 @@ func (j *janitorJob) Description() string {
 }
 
 func (j *janitorJob) Config() []env.Config {
-	return []env.Config{janitorConfigInst}
+	return []env.Config{janitorConfigInst, artifacts.ConfigInst}
 }
 
 func (j *janitorJob) Routines(_ context.Context, observationCtx *observation.Context) ([]goroutine.BackgroundRoutine, error) {
@@ -34,6 +36,12 @@ func (j *janitorJob) Routines(_ context.Context, observationCtx *observation.Con
 
 	dequeueCache := rcache.New(executortypes.DequeueCachePrefix)
 
+	uploadStore, err := artifacts.NewUploadStore(context.Background(), observationCtx, artifacts.ConfigInst)
+	if err != nil {
+		return nil, err
+	}
+	artifactsSvc := artifacts.NewService(executorstore.NewArtifactStore(db), uploadStore)
+
 	routines := []goroutine.BackgroundRoutine{
 		goroutine.NewPeriodicGoroutine(
 			context.Background(),
@@ -45,6 +53,7 @@ func (j *janitorJob) Routines(_ context.Context, observationCtx *observation.Con
 			goroutine.WithInterval(janitorConfigInst.CleanupTaskInterval),
 		),
 		NewMultiqueueCacheCleaner(executortypes.ValidQueueNames, dequeueCache, janitorConfigInst.CacheDequeueTtl, janitorConfigInst.CacheCleanupInterval),
+		artifacts.NewExpirer(context.Background(), observationCtx.Logger, artifactsSvc, janitorConfigInst.CleanupTaskInterval),
 	}
 
 	return routines, nil
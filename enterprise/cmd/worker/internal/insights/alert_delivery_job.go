@@ -0,0 +1,50 @@
+package insights
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	workerdb "github.com/sourcegraph/sourcegraph/cmd/worker/shared/init/db"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/insights"
+	"github.com/sourcegraph/sourcegraph/internal/insights/background"
+	insightsdb "github.com/sourcegraph/sourcegraph/internal/insights/database"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+type insightsAlertDeliveryJob struct {
+	env.BaseConfig
+}
+
+func (s *insightsAlertDeliveryJob) Description() string {
+	return "delivers code insights alerts by email, webhook and Slack"
+}
+
+func (s *insightsAlertDeliveryJob) Config() []env.Config {
+	return nil
+}
+
+func (s *insightsAlertDeliveryJob) Routines(_ context.Context, observationCtx *observation.Context) ([]goroutine.BackgroundRoutine, error) {
+	if !insights.IsEnabled() {
+		observationCtx.Logger.Debug("Code Insights disabled. Disabling insights alert delivery job.")
+		return []goroutine.BackgroundRoutine{}, nil
+	}
+	observationCtx.Logger.Debug("Code Insights enabled. Enabling insights alert delivery job.")
+
+	db, err := workerdb.InitDB(observationCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	insightsDB, err := insightsdb.InitializeCodeInsightsDB(observationCtx, "insights-alert-delivery")
+	if err != nil {
+		return nil, err
+	}
+
+	return background.GetBackgroundAlertDeliveryJob(context.Background(), observationCtx, db, insightsDB), nil
+}
+
+func NewInsightsAlertDeliveryJob() job.Job {
+	return &insightsAlertDeliveryJob{}
+}
@@ -17,7 +17,7 @@ type svc struct{}
 func (svc) Name() string { return "worker" }
 
 func (svc) Configure() (env.Config, []debugserver.Endpoint) {
-	return shared.LoadConfig(additionalJobs, register.RegisterEnterpriseMigrators), nil
+	return shared.LoadConfig(additionalJobs, register.RegisterEnterpriseMigrators), shared.CreateDebugServerEndpoints()
 }
 
 func (svc) Start(ctx context.Context, observationCtx *observation.Context, ready service.ReadyFunc, config env.Config) error {
@@ -38,8 +38,12 @@ var additionalJobs = map[string]job.Job{
 	"insights-job":                          workerinsights.NewInsightsJob(),
 	"insights-query-runner-job":             workerinsights.NewInsightsQueryRunnerJob(),
 	"insights-data-retention-job":           workerinsights.NewInsightsDataRetentionJob(),
+	"insights-alert-delivery-job":           workerinsights.NewInsightsAlertDeliveryJob(),
 	"batches-janitor":                       batches.NewJanitorJob(),
 	"batches-scheduler":                     batches.NewSchedulerJob(),
+	"batches-automerge":                     batches.NewAutoMergeJob(),
+	"batches-rebaser":                       batches.NewRebaserJob(),
+	"batches-rollout":                       batches.NewRolloutJob(),
 	"batches-reconciler":                    batches.NewReconcilerJob(),
 	"batches-bulk-processor":                batches.NewBulkOperationProcessorJob(),
 	"batches-workspace-resolver":            batches.NewWorkspaceResolverJob(),
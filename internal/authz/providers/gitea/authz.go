@@ -0,0 +1,82 @@
+package gitea
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	atypes "github.com/sourcegraph/sourcegraph/internal/authz/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/licensing"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// NewAuthzProviders returns the set of Gitea authz providers derived from the connections.
+//
+// It also returns any simple validation problems with the config, separating these into "serious problems"
+// and "warnings". "Serious problems" are those that should make Sourcegraph set authz.allowAccessByDefault
+// to false. "Warnings" are all other validation problems.
+//
+// This constructor does not and should not directly check connectivity to external services - if
+// desired, callers should use `(*Provider).ValidateConnection` directly to get warnings related
+// to connection issues.
+func NewAuthzProviders(db database.DB, conns []*types.GiteaConnection, authProviders []schema.AuthProviders) *atypes.ProviderInitResult {
+	initResults := &atypes.ProviderInitResult{}
+	giteaAuthProviders := make(map[string]*schema.GiteaAuthProvider)
+	for _, p := range authProviders {
+		if p.Gitea == nil {
+			continue
+		}
+
+		var id string
+		giteaURL, err := url.Parse(p.Gitea.Url)
+		if err != nil {
+			// error reporting for this should happen elsewhere, for now just use what is given
+			id = p.Gitea.Url
+		} else {
+			// use codehost normalized URL as ID
+			ch := extsvc.NewCodeHost(giteaURL, p.Gitea.Type)
+			id = ch.ServiceID
+		}
+		giteaAuthProviders[id] = p.Gitea
+	}
+
+	for _, c := range conns {
+		p, err := newAuthzProvider(db, c)
+		if err != nil {
+			initResults.InvalidConnections = append(initResults.InvalidConnections, extsvc.TypeGitea)
+			initResults.Problems = append(initResults.Problems, err.Error())
+		}
+		if p == nil {
+			continue
+		}
+
+		if _, exists := giteaAuthProviders[p.ServiceID()]; !exists {
+			initResults.Warnings = append(initResults.Warnings,
+				fmt.Sprintf("Gitea config for %[1]s has `authorization` enabled, "+
+					"but no authentication provider matching %[1]q was found. "+
+					"Check the [**site configuration**](/site-admin/configuration) to "+
+					"verify an entry in [`auth.providers`](https://docs.sourcegraph.com/admin/auth) exists for %[1]s.",
+					p.ServiceID()))
+		}
+
+		initResults.Providers = append(initResults.Providers, p)
+	}
+
+	return initResults
+}
+
+func newAuthzProvider(db database.DB, c *types.GiteaConnection) (authz.Provider, error) {
+	// If authorization is not set for this connection, we do not need an
+	// authz provider.
+	if c.Authorization == nil {
+		return nil, nil
+	}
+	if err := licensing.Check(licensing.FeatureACLs); err != nil {
+		return nil, err
+	}
+
+	return NewProvider(db, c, ProviderOptions{})
+}
@@ -0,0 +1,106 @@
+package gitea
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/licensing"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestNewAuthzProviders(t *testing.T) {
+	db := dbmocks.NewMockDB()
+
+	t.Run("no authorization", func(t *testing.T) {
+		initResults := NewAuthzProviders(
+			db,
+			[]*types.GiteaConnection{{
+				GiteaConnection: &schema.GiteaConnection{
+					Url: "https://gitea.example.com",
+				},
+			}},
+			[]schema.AuthProviders{},
+		)
+
+		assertion := assert.New(t)
+
+		assertion.Len(initResults.Providers, 0, "unexpected a providers: %+v", initResults.Providers)
+		assertion.Len(initResults.Problems, 0, "unexpected problems: %+v", initResults.Problems)
+		assertion.Len(initResults.Warnings, 0, "unexpected warnings: %+v", initResults.Warnings)
+		assertion.Len(initResults.InvalidConnections, 0, "unexpected invalidConnections: %+v", initResults.InvalidConnections)
+	})
+
+	t.Run("no matching auth provider", func(t *testing.T) {
+		t.Cleanup(licensing.TestingSkipFeatureChecks())
+		initResults := NewAuthzProviders(
+			db,
+			[]*types.GiteaConnection{{
+				GiteaConnection: &schema.GiteaConnection{
+					Url:           "https://gitea.example.com",
+					Authorization: &schema.GiteaAuthorization{},
+				},
+			}},
+			[]schema.AuthProviders{{Gitea: &schema.GiteaAuthProvider{
+				Type: extsvc.TypeGitea,
+				Url:  "https://codeberg.org",
+			}}},
+		)
+
+		require.Len(t, initResults.Providers, 1, "expect exactly one provider")
+		assert.NotNil(t, initResults.Providers[0])
+
+		assert.Empty(t, initResults.Problems)
+		assert.Empty(t, initResults.InvalidConnections)
+
+		require.Len(t, initResults.Warnings, 1, "expect exactly one warning")
+		assert.Contains(t, initResults.Warnings[0], "no authentication provider")
+	})
+
+	t.Run("matching auth provider found", func(t *testing.T) {
+		t.Cleanup(licensing.TestingSkipFeatureChecks())
+		initResults := NewAuthzProviders(
+			db,
+			[]*types.GiteaConnection{{
+				GiteaConnection: &schema.GiteaConnection{
+					Url:           "https://gitea.example.com",
+					Authorization: &schema.GiteaAuthorization{},
+				},
+			}},
+			[]schema.AuthProviders{{Gitea: &schema.GiteaAuthProvider{
+				Type: extsvc.TypeGitea,
+				Url:  "https://gitea.example.com/",
+			}}},
+		)
+
+		require.Len(t, initResults.Providers, 1, "expect exactly one provider")
+		assert.NotNil(t, initResults.Providers[0])
+
+		assert.Empty(t, initResults.Problems)
+		assert.Empty(t, initResults.Warnings)
+		assert.Empty(t, initResults.InvalidConnections)
+	})
+
+	t.Run("license does not allow ACLs", func(t *testing.T) {
+		t.Cleanup(licensing.MockCheckFeatureError("failed"))
+
+		initResults := NewAuthzProviders(
+			db,
+			[]*types.GiteaConnection{{
+				GiteaConnection: &schema.GiteaConnection{
+					Url:           "https://gitea.example.com",
+					Authorization: &schema.GiteaAuthorization{},
+				},
+			}},
+			[]schema.AuthProviders{},
+		)
+
+		assert.Empty(t, initResults.Providers)
+		assert.Equal(t, []string{extsvc.TypeGitea}, initResults.InvalidConnections)
+		require.Len(t, initResults.Problems, 1)
+	})
+}
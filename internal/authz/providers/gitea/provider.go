@@ -0,0 +1,152 @@
+// Package gitea contains an authorization provider for Gitea and Forgejo.
+package gitea
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/oauthtoken"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Provider is an implementation of AuthzProvider that provides repository
+// permissions as determined from Gitea.
+type Provider struct {
+	urn      string
+	codeHost *extsvc.CodeHost
+	client   gitea.Client
+	db       database.DB
+}
+
+type ProviderOptions struct {
+	GiteaClient gitea.Client
+}
+
+var _ authz.Provider = (*Provider)(nil)
+
+// NewProvider returns a new Gitea authorization provider that uses the given
+// gitea.Client to talk to the Gitea API that is the source of truth for
+// permissions. Sourcegraph users will need a valid Gitea external account for
+// permissions to sync correctly.
+func NewProvider(db database.DB, conn *types.GiteaConnection, opts ProviderOptions) (*Provider, error) {
+	baseURL, err := url.Parse(conn.Url)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.GiteaClient == nil {
+		opts.GiteaClient, err = gitea.NewClient(conn.URN, conn.GiteaConnection, httpcli.ExternalDoer)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &Provider{
+		urn:      conn.URN,
+		codeHost: extsvc.NewCodeHost(baseURL, extsvc.TypeGitea),
+		client:   opts.GiteaClient,
+		db:       db,
+	}, nil
+}
+
+// ValidateConnection validates that the Provider has access to the Gitea API
+// with the credentials it was configured with.
+func (p *Provider) ValidateConnection(ctx context.Context) error {
+	_, err := p.client.CurrentUser(ctx)
+	return err
+}
+
+func (p *Provider) URN() string {
+	return p.urn
+}
+
+// ServiceID returns the absolute URL that identifies the Gitea instance this
+// provider is configured with.
+func (p *Provider) ServiceID() string { return p.codeHost.ServiceID }
+
+// ServiceType returns the type of this Provider, namely, "gitea".
+func (p *Provider) ServiceType() string { return p.codeHost.ServiceType }
+
+// FetchAccount satisfies the authz.Provider interface.
+func (p *Provider) FetchAccount(context.Context, *types.User, []*extsvc.Account, []string) (*extsvc.Account, error) {
+	return nil, nil
+}
+
+// FetchUserPerms returns a list of repository IDs (on code host) that the given
+// account has read access on the code host. The repository ID has the same
+// value as it would be used as api.ExternalRepoSpec.ID.
+//
+// Gitea lists every repository the authenticated user can access, whether
+// owned, shared with them as a collaborator, or granted through an organization
+// team, so a single paginated listing with the user's OAuth token suffices.
+//
+// This method may return partial but valid results in case of error, and it is up to
+// callers to decide whether to discard.
+func (p *Provider) FetchUserPerms(ctx context.Context, account *extsvc.Account, _ authz.FetchPermsOptions) (*authz.ExternalUserPermissions, error) {
+	switch {
+	case account == nil:
+		return nil, errors.New("no account provided")
+	case !extsvc.IsHostOfAccount(p.codeHost, account):
+		return nil, errors.Errorf("not a code host of the account: want %q but have %q",
+			p.codeHost.ServiceID, account.AccountSpec.ServiceID)
+	case account.Data == nil:
+		return nil, errors.New("no account data provided")
+	}
+
+	_, tok, err := gitea.GetExternalAccountData(ctx, &account.AccountData)
+	if err != nil {
+		return nil, err
+	}
+	if tok == nil {
+		return nil, errors.New("no token found in the external account data")
+	}
+
+	oauthToken := &auth.OAuthBearerToken{
+		Token:              tok.AccessToken,
+		RefreshToken:       tok.RefreshToken,
+		Expiry:             tok.Expiry,
+		NeedsRefreshBuffer: 5,
+	}
+	// Gitea access tokens expire after an hour by default, so we need to be
+	// able to refresh them through the matching auth provider.
+	if oauthContext := gitea.GetOAuthContext(p.codeHost.BaseURL.String()); oauthContext != nil {
+		oauthToken.RefreshFunc = oauthtoken.GetAccountRefreshAndStoreOAuthTokenFunc(p.db.UserExternalAccounts(), account.ID, oauthContext)
+	}
+
+	client, err := p.client.WithAuthenticator(oauthToken)
+	if err != nil {
+		return nil, err
+	}
+
+	var extIDs []extsvc.RepoID
+	for page, hasNextPage := 1, true; hasNextPage; page++ {
+		var repos []*gitea.Repo
+		repos, hasNextPage, err = client.ListCurrentUserRepos(ctx, page)
+		if err != nil {
+			return &authz.ExternalUserPermissions{Exacts: extIDs}, err
+		}
+
+		for _, r := range repos {
+			extIDs = append(extIDs, extsvc.RepoID(strconv.FormatInt(r.ID, 10)))
+		}
+	}
+
+	return &authz.ExternalUserPermissions{
+		Exacts: extIDs,
+	}, nil
+}
+
+// FetchRepoPerms is unimplemented for Gitea: listing the users with access to
+// a repository requires admin access to it, so permissions are only synced
+// from the user's side.
+func (p *Provider) FetchRepoPerms(context.Context, *extsvc.Repository, authz.FetchPermsOptions) ([]extsvc.AccountID, error) {
+	return nil, authz.ErrUnimplemented{Feature: "gitea.FetchRepoPerms"}
+}
@@ -0,0 +1,164 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func mustURL(t *testing.T, u string) *url.URL {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parsed
+}
+
+// createTestServer serves two pages of repositories from /user/repos, and
+// records the Authorization header it was called with.
+func createTestServer(t *testing.T, gotAuthorization *string) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/user/repos" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		*gotAuthorization = r.Header.Get("Authorization")
+
+		var repos []*gitea.Repo
+		switch r.URL.Query().Get("page") {
+		case "1":
+			for i := 1; i <= gitea.DefaultPageSize; i++ {
+				repos = append(repos, &gitea.Repo{ID: int64(i)})
+			}
+		case "2":
+			repos = []*gitea.Repo{{ID: 100}}
+		}
+		w.Header().Set("X-Total-Count", fmt.Sprint(gitea.DefaultPageSize+1))
+		json.NewEncoder(w).Encode(repos)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestProvider_FetchUserPerms(t *testing.T) {
+	ratelimit.SetupForTest(t)
+
+	db := dbmocks.NewMockDB()
+	conn := &types.GiteaConnection{
+		GiteaConnection: &schema.GiteaConnection{
+			Url: "https://gitea.example.com",
+		},
+	}
+
+	t.Run("nil account", func(t *testing.T) {
+		p, err := NewProvider(db, conn, ProviderOptions{})
+		require.NoError(t, err)
+
+		_, err = p.FetchUserPerms(context.Background(), nil, authz.FetchPermsOptions{})
+		assert.EqualError(t, err, "no account provided")
+	})
+
+	t.Run("not the code host of the account", func(t *testing.T) {
+		p, err := NewProvider(db, conn, ProviderOptions{})
+		require.NoError(t, err)
+
+		_, err = p.FetchUserPerms(context.Background(),
+			&extsvc.Account{
+				AccountSpec: extsvc.AccountSpec{
+					ServiceType: extsvc.TypeGitHub,
+					ServiceID:   "https://github.com/",
+				},
+			},
+			authz.FetchPermsOptions{},
+		)
+		assert.EqualError(t, err, `not a code host of the account: want "https://gitea.example.com/" but have "https://github.com/"`)
+	})
+
+	t.Run("no account data provided", func(t *testing.T) {
+		p, err := NewProvider(db, conn, ProviderOptions{})
+		require.NoError(t, err)
+
+		_, err = p.FetchUserPerms(context.Background(),
+			&extsvc.Account{
+				AccountSpec: extsvc.AccountSpec{
+					ServiceType: extsvc.TypeGitea,
+					ServiceID:   "https://gitea.example.com/",
+				},
+			},
+			authz.FetchPermsOptions{},
+		)
+		assert.EqualError(t, err, "no account data provided")
+	})
+
+	t.Run("fetch user permissions", func(t *testing.T) {
+		var gotAuthorization string
+		server := createTestServer(t, &gotAuthorization)
+
+		serverConn := &types.GiteaConnection{
+			GiteaConnection: &schema.GiteaConnection{
+				Url:   server.URL,
+				Token: "site-token",
+			},
+		}
+		client, err := gitea.NewClient(server.URL, serverConn.GiteaConnection, http.DefaultClient)
+		require.NoError(t, err)
+
+		p, err := NewProvider(db, serverConn, ProviderOptions{GiteaClient: client})
+		require.NoError(t, err)
+
+		var acctData extsvc.AccountData
+		err = gitea.SetExternalAccountData(&acctData, &gitea.User{Login: "alice"}, &oauth2.Token{AccessToken: "my-access-token"})
+		require.NoError(t, err)
+
+		account := &extsvc.Account{
+			AccountSpec: extsvc.AccountSpec{
+				ServiceType: extsvc.TypeGitea,
+				ServiceID:   extsvc.NormalizeBaseURL(mustURL(t, server.URL)).String(),
+			},
+			AccountData: acctData,
+		}
+		userPerms, err := p.FetchUserPerms(context.Background(), account, authz.FetchPermsOptions{})
+		require.NoError(t, err)
+
+		// The user's token must be used rather than the one of the connection.
+		assert.Equal(t, "Bearer my-access-token", gotAuthorization)
+
+		want := make([]extsvc.RepoID, 0, gitea.DefaultPageSize+1)
+		for i := 1; i <= gitea.DefaultPageSize; i++ {
+			want = append(want, extsvc.RepoID(fmt.Sprint(i)))
+		}
+		want = append(want, "100")
+		if diff := cmp.Diff(want, userPerms.Exacts); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+}
+
+func TestProvider_FetchRepoPerms(t *testing.T) {
+	p, err := NewProvider(dbmocks.NewMockDB(), &types.GiteaConnection{
+		GiteaConnection: &schema.GiteaConnection{Url: "https://gitea.example.com"},
+	}, ProviderOptions{})
+	require.NoError(t, err)
+
+	_, err = p.FetchRepoPerms(context.Background(), &extsvc.Repository{}, authz.FetchPermsOptions{})
+	assert.ErrorIs(t, err, &authz.ErrUnimplemented{})
+}
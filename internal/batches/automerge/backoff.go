@@ -0,0 +1,19 @@
+package automerge
+
+import "time"
+
+// nextBackoff returns how long to wait before attempting another merge after
+// the code host reported a changeset as not mergeable, given the previous
+// backoff of that changeset. The delay starts at notMergeableBackoffInit and
+// doubles with each consecutive refusal, up to notMergeableBackoffLimit.
+func nextBackoff(previous time.Duration) time.Duration {
+	if previous <= 0 {
+		return notMergeableBackoffInit
+	}
+
+	next := previous * 2
+	if next > notMergeableBackoffLimit {
+		next = notMergeableBackoffLimit
+	}
+	return next
+}
@@ -0,0 +1,23 @@
+package automerge
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextBackoff(t *testing.T) {
+	for _, tc := range []struct {
+		previous time.Duration
+		want     time.Duration
+	}{
+		{previous: 0, want: notMergeableBackoffInit},
+		{previous: notMergeableBackoffInit, want: 2 * notMergeableBackoffInit},
+		{previous: 2 * notMergeableBackoffInit, want: 4 * notMergeableBackoffInit},
+		{previous: notMergeableBackoffLimit - time.Minute, want: notMergeableBackoffLimit},
+		{previous: notMergeableBackoffLimit, want: notMergeableBackoffLimit},
+	} {
+		if have := nextBackoff(tc.previous); have != tc.want {
+			t.Errorf("unexpected backoff after %s: have=%s want=%s", tc.previous, have, tc.want)
+		}
+	}
+}
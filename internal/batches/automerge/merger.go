@@ -0,0 +1,300 @@
+package automerge
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	bgql "github.com/sourcegraph/sourcegraph/internal/batches/graphql"
+	"github.com/sourcegraph/sourcegraph/internal/batches/sources"
+	"github.com/sourcegraph/sourcegraph/internal/batches/state"
+	"github.com/sourcegraph/sourcegraph/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/batches/types/scheduler/config"
+	"github.com/sourcegraph/sourcegraph/internal/batches/webhooks"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	mergerInterval = 1 * time.Minute
+	pageSize       = 100
+
+	// When the code host reports that a changeset can't be merged, we back off
+	// from that changeset, starting at notMergeableBackoffInit and doubling up
+	// to notMergeableBackoffLimit.
+	notMergeableBackoffInit  = 5 * time.Minute
+	notMergeableBackoffLimit = 6 * time.Hour
+)
+
+// NewMerger creates a new goroutine.PeriodicGoroutine that evaluates the
+// auto-merge policies of open batch changes against the synced state of their
+// changesets, and merges changesets that satisfy the policy of their batch
+// change.
+func NewMerger(ctx context.Context, observationCtx *observation.Context, s *store.Store, sourcer sources.Sourcer) goroutine.BackgroundRoutine {
+	m := &merger{
+		store:           s,
+		sourcer:         sourcer,
+		gitserverClient: gitserver.NewClient(),
+		logger:          observationCtx.Logger.Scoped("automerge", "auto-merges changesets according to batch spec policies"),
+	}
+
+	return goroutine.NewPeriodicGoroutine(
+		ctx,
+		goroutine.HandlerFunc(m.Handle),
+		goroutine.WithName("batchchanges.auto-merger"),
+		goroutine.WithDescription("merges changesets that satisfy the auto-merge policy of their batch change"),
+		goroutine.WithInterval(mergerInterval),
+	)
+}
+
+type merger struct {
+	store           *store.Store
+	sourcer         sources.Sourcer
+	gitserverClient gitserver.Client
+	logger          log.Logger
+}
+
+func (m *merger) Handle(ctx context.Context) error {
+	opts := store.ListBatchChangesOpts{
+		LimitOpts: store.LimitOpts{Limit: pageSize},
+		States:    []btypes.BatchChangeState{btypes.BatchChangeStateOpen},
+	}
+
+	for {
+		batchChanges, next, err := m.store.ListBatchChanges(ctx, opts)
+		if err != nil {
+			return errors.Wrap(err, "listing batch changes")
+		}
+
+		for _, batchChange := range batchChanges {
+			if err := m.handleBatchChange(ctx, batchChange); err != nil {
+				m.logger.Error("failed to auto-merge changesets of batch change", log.Int64("batchChangeID", batchChange.ID), log.Error(err))
+			}
+		}
+
+		if next == 0 {
+			break
+		}
+		opts.Cursor = next
+	}
+
+	// Forget the auto-merge state of changesets that have been merged, closed
+	// or are otherwise no longer candidates.
+	if err := m.store.DeleteStaleChangesetAutoMergeStates(ctx); err != nil {
+		return errors.Wrap(err, "deleting stale auto-merge states")
+	}
+	return nil
+}
+
+func (m *merger) handleBatchChange(ctx context.Context, batchChange *btypes.BatchChange) error {
+	batchSpec, err := m.store.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: batchChange.BatchSpecID})
+	if err != nil {
+		return errors.Wrap(err, "loading batch spec")
+	}
+	if batchSpec.Spec == nil || batchSpec.Spec.ChangesetTemplate == nil || batchSpec.Spec.ChangesetTemplate.AutoMerge == nil {
+		return nil
+	}
+	policy := batchSpec.Spec.ChangesetTemplate.AutoMerge
+
+	publicationState := btypes.ChangesetPublicationStatePublished
+	opts := store.ListChangesetsOpts{
+		LimitOpts:            store.LimitOpts{Limit: pageSize},
+		BatchChangeID:        batchChange.ID,
+		OwnedByBatchChangeID: batchChange.ID,
+		PublicationState:     &publicationState,
+		ExternalStates:       []btypes.ChangesetExternalState{btypes.ChangesetExternalStateOpen},
+		ReconcilerStates:     []btypes.ReconcilerState{btypes.ReconcilerStateCompleted},
+	}
+
+	for {
+		changesets, next, err := m.store.ListChangesets(ctx, opts)
+		if err != nil {
+			return errors.Wrap(err, "listing changesets")
+		}
+
+		eventsByChangeset, err := m.listReviewEvents(ctx, changesets)
+		if err != nil {
+			return err
+		}
+
+		statesByChangeset, err := m.listAutoMergeStates(ctx, changesets)
+		if err != nil {
+			return err
+		}
+
+		for _, ch := range changesets {
+			if err := m.handleChangeset(ctx, policy, ch, eventsByChangeset[ch.ID], statesByChangeset[ch.ID]); err != nil {
+				m.logger.Error("failed to auto-merge changeset", log.Int64("changesetID", ch.ID), log.Error(err))
+			}
+		}
+
+		if next == 0 {
+			return nil
+		}
+		opts.Cursor = next
+	}
+}
+
+func (m *merger) listReviewEvents(ctx context.Context, changesets btypes.Changesets) (map[int64]state.ChangesetEvents, error) {
+	if len(changesets) == 0 {
+		return nil, nil
+	}
+
+	events, _, err := m.store.ListChangesetEvents(ctx, store.ListChangesetEventsOpts{ChangesetIDs: changesets.IDs()})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing changeset events")
+	}
+
+	eventsByChangeset := make(map[int64]state.ChangesetEvents, len(changesets))
+	for _, e := range events {
+		eventsByChangeset[e.ChangesetID] = append(eventsByChangeset[e.ChangesetID], e)
+	}
+
+	return eventsByChangeset, nil
+}
+
+func (m *merger) listAutoMergeStates(ctx context.Context, changesets btypes.Changesets) (map[int64]*btypes.ChangesetAutoMergeState, error) {
+	if len(changesets) == 0 {
+		return nil, nil
+	}
+
+	states, err := m.store.ListChangesetAutoMergeStates(ctx, changesets.IDs())
+	if err != nil {
+		return nil, errors.Wrap(err, "listing auto-merge states")
+	}
+
+	statesByChangeset := make(map[int64]*btypes.ChangesetAutoMergeState, len(states))
+	for _, st := range states {
+		statesByChangeset[st.ChangesetID] = st
+	}
+
+	return statesByChangeset, nil
+}
+
+// handleChangeset evaluates the policy against the changeset and merges it if
+// the policy is satisfied. prev is the persisted auto-merge state of the
+// changeset, or nil if it has none.
+//
+// The reason a changeset is not merged is persisted as its auto-merge state so
+// that it survives restarts and can be inspected, and is logged whenever it
+// changes.
+func (m *merger) handleChangeset(ctx context.Context, policy *batcheslib.AutoMergePolicy, ch *btypes.Changeset, events state.ChangesetEvents, prev *btypes.ChangesetAutoMergeState) error {
+	logger := m.logger.With(log.Int64("changesetID", ch.ID), log.Int64("batchChangeID", ch.OwnedByBatchChangeID))
+	now := m.store.Clock()()
+
+	if prev != nil && now.Before(prev.RetryAfter) {
+		logger.Debug("not auto-merging changeset yet", log.String("reason", prev.Reason), log.Time("retryAfter", prev.RetryAfter))
+		return nil
+	}
+
+	approvals, err := state.CountApprovals(events)
+	if err != nil {
+		return errors.Wrap(err, "counting approvals")
+	}
+
+	result := evaluate(policy, ch, approvals, config.ActiveWindow().IsOpen(now))
+	if !result.Merge {
+		if prev != nil && prev.Reason == result.Reason {
+			return nil
+		}
+		logger.Info("not auto-merging changeset yet", log.String("reason", result.Reason))
+
+		next := &btypes.ChangesetAutoMergeState{ChangesetID: ch.ID, Reason: result.Reason}
+		if prev != nil {
+			// Keep the backoff, so that it keeps growing if the code host
+			// refuses to merge the changeset again once the policy is met.
+			next.Backoff = prev.Backoff
+		}
+		return errors.Wrap(m.store.UpsertChangesetAutoMergeState(ctx, next), "updating auto-merge state")
+	}
+
+	logger.Info("auto-merging changeset", log.String("reason", result.Reason), log.Bool("squash", policy.Squash))
+
+	if err := m.merge(ctx, ch, policy.Squash); err != nil {
+		if isNotMergeable(err) {
+			var backoff time.Duration
+			if prev != nil {
+				backoff = prev.Backoff
+			}
+			next := &btypes.ChangesetAutoMergeState{
+				ChangesetID: ch.ID,
+				Reason:      "code host reported the changeset as not mergeable: " + err.Error(),
+				Backoff:     nextBackoff(backoff),
+			}
+			next.RetryAfter = now.Add(next.Backoff)
+
+			logger.Warn("code host reported changeset as not mergeable, backing off", log.Time("retryAfter", next.RetryAfter), log.Error(err))
+			return errors.Wrap(m.store.UpsertChangesetAutoMergeState(ctx, next), "updating auto-merge state")
+		}
+		return err
+	}
+
+	if prev != nil {
+		return errors.Wrap(m.store.DeleteChangesetAutoMergeState(ctx, ch.ID), "deleting auto-merge state")
+	}
+	return nil
+}
+
+// merge merges the changeset on the code host and persists its updated state,
+// in the same way a merge bulk operation does.
+func (m *merger) merge(ctx context.Context, ch *btypes.Changeset, squash bool) (err error) {
+	tx, err := m.store.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	repo, err := tx.Repos().Get(ctx, ch.RepoID)
+	if err != nil {
+		return errors.Wrap(err, "loading repo")
+	}
+
+	css, err := m.sourcer.ForChangeset(ctx, tx, ch, sources.AuthenticationStrategyUserCredential)
+	if err != nil {
+		return errors.Wrap(err, "loading ChangesetSource")
+	}
+
+	remoteRepo, err := sources.GetRemoteRepo(ctx, css, repo, ch, nil)
+	if err != nil {
+		return errors.Wrap(err, "loading remote repo")
+	}
+
+	cs := &sources.Changeset{
+		Changeset:  ch,
+		TargetRepo: repo,
+		RemoteRepo: remoteRepo,
+	}
+	if err := css.MergeChangeset(ctx, cs, squash); err != nil {
+		return err
+	}
+
+	events, err := cs.Changeset.Events()
+	if err != nil {
+		return errors.Wrap(err, "computing changeset events")
+	}
+	state.SetDerivedState(ctx, tx.Repos(), m.gitserverClient, cs.Changeset, events)
+
+	if err := tx.UpsertChangesetEvents(ctx, events...); err != nil {
+		return errors.Wrap(err, "upserting changeset events")
+	}
+	if err := tx.UpdateChangesetCodeHostState(ctx, cs.Changeset); err != nil {
+		return errors.Wrap(err, "updating changeset")
+	}
+
+	webhooks.EnqueueChangeset(ctx, m.logger, tx, webhooks.ChangesetClose, bgql.MarshalChangesetID(ch.ID))
+	return nil
+}
+
+// isNotMergeable returns true if the code host refused to merge the changeset
+// in its current state. Sources return this error both by value and by
+// reference.
+func isNotMergeable(err error) bool {
+	var e sources.ChangesetNotMergeableError
+	var pe *sources.ChangesetNotMergeableError
+	return errors.As(err, &e) || errors.As(err, &pe)
+}
@@ -0,0 +1,192 @@
+package automerge
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/batches/sources"
+	stesting "github.com/sourcegraph/sourcegraph/internal/batches/sources/testing"
+	"github.com/sourcegraph/sourcegraph/internal/batches/store"
+	bt "github.com/sourcegraph/sourcegraph/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/timeutil"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestMergerHandleChangeset(t *testing.T) {
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+
+	sqlDB := dbtest.NewDB(logger, t)
+	db := database.NewDB(logger, sqlDB)
+	clock := &bt.TestClock{Time: timeutil.Now()}
+	bstore := store.NewWithClock(database.NewDBWith(logger, basestore.NewWithHandle(basestore.NewHandleWithTx(dbtest.NewTx(t, sqlDB), sql.TxOptions{}))), &observation.TestContext, nil, clock.Now)
+
+	user := bt.CreateTestUser(t, db, true)
+	repo, _ := bt.CreateTestRepo(t, ctx, db)
+	batchSpec := bt.CreateBatchSpec(t, ctx, bstore, "test-automerge", user.ID, 0)
+	batchChange := bt.CreateBatchChange(t, ctx, bstore, "test-automerge", user.ID, batchSpec.ID)
+
+	createChangeset := func(t *testing.T, checkState btypes.ChangesetCheckState) *btypes.Changeset {
+		return bt.CreateChangeset(t, ctx, bstore, bt.TestChangesetOpts{
+			Repo:                repo.ID,
+			BatchChanges:        []btypes.BatchChangeAssoc{{BatchChangeID: batchChange.ID}},
+			OwnedByBatchChange:  batchChange.ID,
+			Metadata:            &github.PullRequest{},
+			ExternalServiceType: extsvc.TypeGitHub,
+			ExternalState:       btypes.ChangesetExternalStateOpen,
+			ExternalCheckState:  checkState,
+			PublicationState:    btypes.ChangesetPublicationStatePublished,
+		})
+	}
+
+	newMerger := func(fake *stesting.FakeChangesetSource) *merger {
+		return &merger{
+			store:           bstore,
+			sourcer:         stesting.NewFakeSourcer(nil, fake),
+			gitserverClient: gitserver.NewMockClient(),
+			logger:          logtest.Scoped(t),
+		}
+	}
+
+	loadState := func(t *testing.T, changesetID int64) *btypes.ChangesetAutoMergeState {
+		t.Helper()
+
+		states, err := bstore.ListChangesetAutoMergeStates(ctx, []int64{changesetID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(states) == 0 {
+			return nil
+		}
+		return states[0]
+	}
+
+	policy := &batcheslib.AutoMergePolicy{}
+
+	t.Run("policy not met", func(t *testing.T) {
+		ch := createChangeset(t, btypes.ChangesetCheckStatePending)
+		fake := &stesting.FakeChangesetSource{}
+
+		if err := newMerger(fake).handleChangeset(ctx, policy, ch, nil, nil); err != nil {
+			t.Fatal(err)
+		}
+		if fake.MergeChangesetCalled {
+			t.Fatal("unexpected call to MergeChangeset")
+		}
+
+		have := loadState(t, ch.ID)
+		if have == nil {
+			t.Fatal("expected auto-merge state to be persisted")
+		}
+		if want := "checks have not passed (check state is PENDING)"; have.Reason != want {
+			t.Errorf("unexpected reason. want=%q have=%q", want, have.Reason)
+		}
+		if !have.RetryAfter.IsZero() {
+			t.Errorf("unexpected retry after: %s", have.RetryAfter)
+		}
+	})
+
+	t.Run("policy met", func(t *testing.T) {
+		ch := createChangeset(t, btypes.ChangesetCheckStatePassed)
+		prev := &btypes.ChangesetAutoMergeState{ChangesetID: ch.ID, Reason: "checks have not passed (check state is PENDING)"}
+		if err := bstore.UpsertChangesetAutoMergeState(ctx, prev); err != nil {
+			t.Fatal(err)
+		}
+		fake := &stesting.FakeChangesetSource{}
+
+		if err := newMerger(fake).handleChangeset(ctx, policy, ch, nil, prev); err != nil {
+			t.Fatal(err)
+		}
+		if !fake.MergeChangesetCalled {
+			t.Fatal("expected MergeChangeset to be called but wasn't")
+		}
+		if have := loadState(t, ch.ID); have != nil {
+			t.Fatalf("unexpected auto-merge state after merge: %+v", have)
+		}
+	})
+
+	t.Run("not mergeable", func(t *testing.T) {
+		ch := createChangeset(t, btypes.ChangesetCheckStatePassed)
+		fake := &stesting.FakeChangesetSource{Err: sources.ChangesetNotMergeableError{ErrorMsg: "merge conflict"}}
+		m := newMerger(fake)
+
+		if err := m.handleChangeset(ctx, policy, ch, nil, nil); err != nil {
+			t.Fatal(err)
+		}
+		state := loadState(t, ch.ID)
+		if state == nil {
+			t.Fatal("expected auto-merge state to be persisted")
+		}
+		if state.Backoff != notMergeableBackoffInit {
+			t.Errorf("unexpected backoff. want=%s have=%s", notMergeableBackoffInit, state.Backoff)
+		}
+		if want := clock.Now().Add(notMergeableBackoffInit); !state.RetryAfter.Equal(want) {
+			t.Errorf("unexpected retry after. want=%s have=%s", want, state.RetryAfter)
+		}
+
+		// While backing off, no merge is attempted.
+		fake.MergeChangesetCalled = false
+		if err := m.handleChangeset(ctx, policy, ch, nil, state); err != nil {
+			t.Fatal(err)
+		}
+		if fake.MergeChangesetCalled {
+			t.Fatal("unexpected call to MergeChangeset while backing off")
+		}
+
+		// Once the backoff has passed, the next refusal doubles it.
+		clock.Add(notMergeableBackoffInit)
+		if err := m.handleChangeset(ctx, policy, ch, nil, state); err != nil {
+			t.Fatal(err)
+		}
+		if !fake.MergeChangesetCalled {
+			t.Fatal("expected MergeChangeset to be called but wasn't")
+		}
+		state = loadState(t, ch.ID)
+		if want := 2 * notMergeableBackoffInit; state.Backoff != want {
+			t.Errorf("unexpected backoff. want=%s have=%s", want, state.Backoff)
+		}
+	})
+
+	t.Run("merge error", func(t *testing.T) {
+		ch := createChangeset(t, btypes.ChangesetCheckStatePassed)
+		fake := &stesting.FakeChangesetSource{Err: errors.New("code host unavailable")}
+
+		if err := newMerger(fake).handleChangeset(ctx, policy, ch, nil, nil); err == nil {
+			t.Fatal("expected error but got none")
+		}
+		if have := loadState(t, ch.ID); have != nil {
+			t.Fatalf("unexpected auto-merge state after failed merge: %+v", have)
+		}
+	})
+
+	t.Run("stale states are deleted", func(t *testing.T) {
+		ch := createChangeset(t, btypes.ChangesetCheckStatePending)
+		if err := bstore.UpsertChangesetAutoMergeState(ctx, &btypes.ChangesetAutoMergeState{ChangesetID: ch.ID, Reason: "pending"}); err != nil {
+			t.Fatal(err)
+		}
+		ch.ExternalState = btypes.ChangesetExternalStateClosed
+		if err := bstore.UpdateChangeset(ctx, ch); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := newMerger(&stesting.FakeChangesetSource{}).Handle(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if have := loadState(t, ch.ID); have != nil {
+			t.Fatalf("unexpected auto-merge state for closed changeset: %+v", have)
+		}
+	})
+
+}
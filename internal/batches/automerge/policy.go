@@ -0,0 +1,44 @@
+package automerge
+
+import (
+	"fmt"
+
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+// evaluation is the result of evaluating an auto-merge policy against a
+// changeset.
+type evaluation struct {
+	// Merge is true if the changeset satisfies the policy and should be merged.
+	Merge bool
+	// Reason describes why the changeset should or should not be merged yet.
+	Reason string
+}
+
+// evaluate checks whether the given changeset satisfies the given auto-merge
+// policy. approvals is the number of distinct reviewers currently approving the
+// changeset, and windowOpen is whether a rollout window is currently open.
+func evaluate(policy *batcheslib.AutoMergePolicy, ch *btypes.Changeset, approvals int, windowOpen bool) evaluation {
+	if ch.ExternalState != btypes.ChangesetExternalStateOpen {
+		return evaluation{Reason: fmt.Sprintf("changeset is %s, not open", ch.ExternalState)}
+	}
+
+	if policy.OnlyInRolloutWindows && !windowOpen {
+		return evaluation{Reason: "no rollout window is currently open"}
+	}
+
+	if policy.RequiresPassingChecks() && ch.ExternalCheckState != btypes.ChangesetCheckStatePassed {
+		return evaluation{Reason: fmt.Sprintf("checks have not passed (check state is %s)", ch.ExternalCheckState)}
+	}
+
+	if ch.ExternalReviewState == btypes.ChangesetReviewStateChangesRequested {
+		return evaluation{Reason: "changes have been requested by a reviewer"}
+	}
+
+	if approvals < policy.RequiredApprovals {
+		return evaluation{Reason: fmt.Sprintf("changeset has %d of %d required approvals", approvals, policy.RequiredApprovals)}
+	}
+
+	return evaluation{Merge: true, Reason: "all conditions of the auto-merge policy are met"}
+}
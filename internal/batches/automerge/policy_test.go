@@ -0,0 +1,92 @@
+package automerge
+
+import (
+	"testing"
+
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
+)
+
+func TestEvaluate(t *testing.T) {
+	mergeable := func() *btypes.Changeset {
+		return &btypes.Changeset{
+			ExternalState:       btypes.ChangesetExternalStateOpen,
+			ExternalCheckState:  btypes.ChangesetCheckStatePassed,
+			ExternalReviewState: btypes.ChangesetReviewStateApproved,
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		policy     batcheslib.AutoMergePolicy
+		changeset  func(ch *btypes.Changeset)
+		approvals  int
+		windowOpen bool
+		want       bool
+	}{
+		"all conditions met": {
+			policy:     batcheslib.AutoMergePolicy{RequiredApprovals: 2, OnlyInRolloutWindows: true},
+			approvals:  2,
+			windowOpen: true,
+			want:       true,
+		},
+		"draft changeset": {
+			changeset:  func(ch *btypes.Changeset) { ch.ExternalState = btypes.ChangesetExternalStateDraft },
+			windowOpen: true,
+			want:       false,
+		},
+		"outside rollout window": {
+			policy:     batcheslib.AutoMergePolicy{OnlyInRolloutWindows: true},
+			windowOpen: false,
+			want:       false,
+		},
+		"rollout windows ignored": {
+			policy:     batcheslib.AutoMergePolicy{},
+			windowOpen: false,
+			want:       true,
+		},
+		"pending checks": {
+			changeset:  func(ch *btypes.Changeset) { ch.ExternalCheckState = btypes.ChangesetCheckStatePending },
+			windowOpen: true,
+			want:       false,
+		},
+		"unknown checks": {
+			changeset:  func(ch *btypes.Changeset) { ch.ExternalCheckState = btypes.ChangesetCheckStateUnknown },
+			windowOpen: true,
+			want:       false,
+		},
+		"checks not required": {
+			policy:     batcheslib.AutoMergePolicy{RequirePassingChecks: pointers.Ptr(false)},
+			changeset:  func(ch *btypes.Changeset) { ch.ExternalCheckState = btypes.ChangesetCheckStateFailed },
+			windowOpen: true,
+			want:       true,
+		},
+		"changes requested": {
+			changeset:  func(ch *btypes.Changeset) { ch.ExternalReviewState = btypes.ChangesetReviewStateChangesRequested },
+			approvals:  3,
+			windowOpen: true,
+			want:       false,
+		},
+		"not enough approvals": {
+			policy:     batcheslib.AutoMergePolicy{RequiredApprovals: 2},
+			approvals:  1,
+			windowOpen: true,
+			want:       false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ch := mergeable()
+			if tc.changeset != nil {
+				tc.changeset(ch)
+			}
+
+			result := evaluate(&tc.policy, ch, tc.approvals, tc.windowOpen)
+			if result.Merge != tc.want {
+				t.Errorf("unexpected result: have=%v want=%v (reason: %s)", result.Merge, tc.want, result.Reason)
+			}
+			if result.Reason == "" {
+				t.Error("expected a reason")
+			}
+		})
+	}
+}
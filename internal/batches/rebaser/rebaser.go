@@ -0,0 +1,258 @@
+package rebaser
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/batches/global"
+	"github.com/sourcegraph/sourcegraph/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	rebaserInterval = 1 * time.Minute
+	pageSize        = 100
+
+	// The code host can report a conflict before gitserver has fetched the
+	// new base commit. Queued rebases wait for the base branch to move in
+	// gitserver for at most baseBranchTimeout.
+	baseBranchTimeout = 24 * time.Hour
+)
+
+// NewRebaser creates a new goroutine.PeriodicGoroutine that processes queued
+// changeset rebases: it re-executes the workspace that produced the
+// changeset against the new head of the base branch and, once the execution
+// completes, enqueues the changeset with the regenerated changeset spec so
+// that the reconciler force-pushes the new commit to the same branch.
+func NewRebaser(ctx context.Context, observationCtx *observation.Context, s *store.Store) goroutine.BackgroundRoutine {
+	r := &rebaser{
+		store:           s,
+		gitserverClient: gitserver.NewClient(),
+		logger:          observationCtx.Logger.Scoped("rebaser", "re-executes changesets that conflict with their base branch"),
+	}
+
+	return goroutine.NewPeriodicGoroutine(
+		ctx,
+		goroutine.HandlerFunc(r.Handle),
+		goroutine.WithName("batchchanges.rebaser"),
+		goroutine.WithDescription("re-executes and force-pushes changesets that conflict with their base branch"),
+		goroutine.WithInterval(rebaserInterval),
+	)
+}
+
+type rebaser struct {
+	store           *store.Store
+	gitserverClient gitserver.Client
+	logger          log.Logger
+}
+
+func (r *rebaser) Handle(ctx context.Context) error {
+	opts := store.ListChangesetRebasesOpts{
+		LimitOpts: store.LimitOpts{Limit: pageSize},
+		States:    []btypes.ChangesetRebaseState{btypes.ChangesetRebaseStateQueued, btypes.ChangesetRebaseStateExecuting},
+	}
+
+	for {
+		rebases, next, err := r.store.ListChangesetRebases(ctx, opts)
+		if err != nil {
+			return errors.Wrap(err, "listing changeset rebases")
+		}
+
+		for _, rb := range rebases {
+			if err := r.handleRebase(ctx, rb); err != nil {
+				r.logger.Error("failed to process changeset rebase", log.Int64("rebaseID", rb.ID), log.Int64("changesetID", rb.ChangesetID), log.Error(err))
+			}
+		}
+
+		if next == 0 {
+			return nil
+		}
+		opts.Cursor = next
+	}
+}
+
+func (r *rebaser) handleRebase(ctx context.Context, rb *btypes.ChangesetRebase) (err error) {
+	tx, err := r.store.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	var reason string
+	switch rb.State {
+	case btypes.ChangesetRebaseStateQueued:
+		reason, err = r.start(ctx, tx, rb)
+	case btypes.ChangesetRebaseStateExecuting:
+		reason, err = r.finish(ctx, tx, rb)
+	default:
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if reason != "" {
+		r.logger.Info("changeset rebase failed", log.Int64("rebaseID", rb.ID), log.Int64("changesetID", rb.ChangesetID), log.String("reason", reason))
+		rb.State = btypes.ChangesetRebaseStateFailed
+		rb.FailureMessage = &reason
+		return tx.UpdateChangesetRebase(ctx, rb)
+	}
+
+	return nil
+}
+
+// start queues a re-execution of the workspace that produced the changeset's
+// current spec against the new head of the base branch. It returns a non-empty
+// reason if the rebase can't be performed.
+//
+// The re-executed workspace is marked as a rebase workspace, so that neither
+// it nor the changeset spec it produces changes the stats, state or changeset
+// specs of the applied batch spec.
+func (r *rebaser) start(ctx context.Context, tx *store.Store, rb *btypes.ChangesetRebase) (string, error) {
+	ch, err := tx.GetChangeset(ctx, store.GetChangesetOpts{ID: rb.ChangesetID})
+	if err != nil {
+		return "", errors.Wrap(err, "loading changeset")
+	}
+	if ch.CurrentSpecID != rb.PreviousChangesetSpecID {
+		return "changeset has been updated since the conflict was detected", nil
+	}
+
+	ws, err := tx.GetBatchSpecWorkspace(ctx, store.GetBatchSpecWorkspaceOpts{ChangesetSpecID: rb.PreviousChangesetSpecID})
+	if err != nil {
+		if err == store.ErrNoResults {
+			return "changeset was not created by server-side execution and can't be re-executed", nil
+		}
+		return "", errors.Wrap(err, "loading batch spec workspace")
+	}
+
+	repo, err := tx.Repos().Get(ctx, ws.RepoID)
+	if err != nil {
+		return "", errors.Wrap(err, "loading repo")
+	}
+
+	commit, err := r.gitserverClient.ResolveRevision(ctx, repo.Name, ws.Branch, gitserver.ResolveRevisionOptions{NoEnsureRevision: true})
+	if err != nil {
+		return "", errors.Wrap(err, "resolving base branch")
+	}
+	if string(commit) == ws.Commit {
+		if time.Since(rb.CreatedAt) > baseBranchTimeout {
+			return "base branch has not moved since the changeset was created", nil
+		}
+		// gitserver may not have fetched the new base commit yet; try again
+		// later.
+		return "", nil
+	}
+
+	rebaseWs := &btypes.BatchSpecWorkspace{
+		BatchSpecID:        ws.BatchSpecID,
+		RepoID:             ws.RepoID,
+		Branch:             ws.Branch,
+		Commit:             string(commit),
+		Path:               ws.Path,
+		FileMatches:        ws.FileMatches,
+		OnlyFetchWorkspace: ws.OnlyFetchWorkspace,
+		Unsupported:        ws.Unsupported,
+		Ignored:            ws.Ignored,
+		Rebase:             true,
+	}
+	if err := tx.CreateBatchSpecWorkspace(ctx, rebaseWs); err != nil {
+		return "", errors.Wrap(err, "creating batch spec workspace")
+	}
+	if err := tx.CreateBatchSpecWorkspaceExecutionJobsForWorkspaces(ctx, []int64{rebaseWs.ID}); err != nil {
+		return "", errors.Wrap(err, "creating batch spec workspace execution job")
+	}
+
+	rb.BatchSpecWorkspaceID = rebaseWs.ID
+	rb.BaseRev = string(commit)
+	rb.State = btypes.ChangesetRebaseStateExecuting
+	return "", tx.UpdateChangesetRebase(ctx, rb)
+}
+
+// finish checks whether the re-execution of the workspace has finished and, if
+// so, enqueues the changeset with the changeset spec it produced. It returns a
+// non-empty reason if the rebase failed.
+func (r *rebaser) finish(ctx context.Context, tx *store.Store, rb *btypes.ChangesetRebase) (string, error) {
+	if rb.BatchSpecWorkspaceID == 0 {
+		return "batch spec workspace has been deleted", nil
+	}
+
+	job, err := tx.GetBatchSpecWorkspaceExecutionJob(ctx, store.GetBatchSpecWorkspaceExecutionJobOpts{
+		BatchSpecWorkspaceID: rb.BatchSpecWorkspaceID,
+		ExcludeRank:          true,
+	})
+	if err != nil {
+		if err == store.ErrNoResults {
+			return "batch spec workspace execution job has been deleted", nil
+		}
+		return "", errors.Wrap(err, "loading batch spec workspace execution job")
+	}
+
+	switch job.State {
+	case btypes.BatchSpecWorkspaceExecutionJobStateCompleted:
+	case btypes.BatchSpecWorkspaceExecutionJobStateFailed:
+		if job.FailureMessage != nil {
+			return "re-execution failed: " + *job.FailureMessage, nil
+		}
+		return "re-execution failed", nil
+	case btypes.BatchSpecWorkspaceExecutionJobStateCanceled:
+		return "re-execution was canceled", nil
+	default:
+		// Still running.
+		return "", nil
+	}
+
+	ws, err := tx.GetBatchSpecWorkspace(ctx, store.GetBatchSpecWorkspaceOpts{ID: rb.BatchSpecWorkspaceID})
+	if err != nil {
+		return "", errors.Wrap(err, "loading batch spec workspace")
+	}
+
+	previousSpec, err := tx.GetChangesetSpec(ctx, store.GetChangesetSpecOpts{ID: rb.PreviousChangesetSpecID})
+	if err != nil {
+		return "", errors.Wrap(err, "loading previous changeset spec")
+	}
+
+	var spec *btypes.ChangesetSpec
+	if len(ws.ChangesetSpecIDs) > 0 {
+		specs, _, err := tx.ListChangesetSpecs(ctx, store.ListChangesetSpecsOpts{IDs: ws.ChangesetSpecIDs})
+		if err != nil {
+			return "", errors.Wrap(err, "listing changeset specs")
+		}
+		for _, s := range specs {
+			if s.HeadRef == previousSpec.HeadRef {
+				spec = s
+				break
+			}
+		}
+	}
+	if spec == nil {
+		return "re-execution did not produce changes for the changeset's branch", nil
+	}
+
+	ch, err := tx.GetChangeset(ctx, store.GetChangesetOpts{ID: rb.ChangesetID})
+	if err != nil {
+		return "", errors.Wrap(err, "loading changeset")
+	}
+	if ch.CurrentSpecID != rb.PreviousChangesetSpecID {
+		return "changeset has been updated while it was re-executed", nil
+	}
+
+	// Swap in the regenerated changeset spec and enqueue the changeset, in the
+	// same way the rewirer does when a batch spec is applied. The reconciler
+	// then pushes the new commit to the changeset's branch.
+	ch.PreviousSpecID = ch.CurrentSpecID
+	ch.SetCurrentSpec(spec)
+	ch.ResetReconcilerState(global.DefaultReconcilerEnqueueState())
+	if err := tx.UpdateChangeset(ctx, ch); err != nil {
+		return "", errors.Wrap(err, "updating changeset")
+	}
+
+	rb.ChangesetSpecID = spec.ID
+	rb.State = btypes.ChangesetRebaseStateCompleted
+	return "", tx.UpdateChangesetRebase(ctx, rb)
+}
@@ -0,0 +1,541 @@
+package rebaser
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/keegancsmith/sqlf"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/batches/store"
+	bt "github.com/sourcegraph/sourcegraph/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
+)
+
+const (
+	headRef    = "refs/heads/my-branch"
+	baseBranch = "refs/heads/main"
+	oldBaseRev = "0000000000000000000000000000000000000001"
+	newBaseRev = "0000000000000000000000000000000000000002"
+)
+
+func TestRebaserStart(t *testing.T) {
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+
+	sqlDB := dbtest.NewDB(logger, t)
+	db := database.NewDB(logger, sqlDB)
+	user := bt.CreateTestUser(t, db, true)
+	repo, _ := bt.CreateTestRepo(t, ctx, db)
+
+	for _, tc := range []struct {
+		name string
+		// setup modifies the fixture before the rebase is handled.
+		setup       func(t *testing.T, f *fixture)
+		resolvedRev string
+		resolveErr  error
+
+		wantErr            bool
+		wantState          btypes.ChangesetRebaseState
+		wantFailureMessage string
+	}{
+		{
+			name:        "base branch moved",
+			resolvedRev: newBaseRev,
+			wantState:   btypes.ChangesetRebaseStateExecuting,
+		},
+		{
+			name:        "base branch not fetched yet",
+			resolvedRev: oldBaseRev,
+			wantState:   btypes.ChangesetRebaseStateQueued,
+		},
+		{
+			name: "base branch not moved before timeout",
+			setup: func(t *testing.T, f *fixture) {
+				f.rebase.CreatedAt = time.Now().Add(-baseBranchTimeout - time.Hour)
+			},
+			resolvedRev:        oldBaseRev,
+			wantState:          btypes.ChangesetRebaseStateFailed,
+			wantFailureMessage: "base branch has not moved since the changeset was created",
+		},
+		{
+			name: "changeset updated",
+			setup: func(t *testing.T, f *fixture) {
+				f.rebase.PreviousChangesetSpecID = f.createChangesetSpec(t).ID
+			},
+			resolvedRev:        newBaseRev,
+			wantState:          btypes.ChangesetRebaseStateFailed,
+			wantFailureMessage: "changeset has been updated since the conflict was detected",
+		},
+		{
+			name: "changeset not created by server-side execution",
+			setup: func(t *testing.T, f *fixture) {
+				// The changeset's current spec was not produced by any
+				// workspace.
+				spec := f.createChangesetSpec(t)
+				f.changeset.CurrentSpecID = spec.ID
+				if err := f.store.UpdateChangeset(ctx, f.changeset); err != nil {
+					t.Fatal(err)
+				}
+				f.rebase.PreviousChangesetSpecID = spec.ID
+			},
+			resolvedRev:        newBaseRev,
+			wantState:          btypes.ChangesetRebaseStateFailed,
+			wantFailureMessage: "changeset was not created by server-side execution and can't be re-executed",
+		},
+		{
+			name:       "resolving base branch fails",
+			resolveErr: errors.New("gitserver unavailable"),
+			wantErr:    true,
+			wantState:  btypes.ChangesetRebaseStateQueued,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newFixture(t, ctx, sqlDB, user, repo)
+			if tc.setup != nil {
+				tc.setup(t, f)
+			}
+			f.createRebase(t)
+
+			gitserverClient := gitserver.NewMockClient()
+			gitserverClient.ResolveRevisionFunc.SetDefaultReturn(api.CommitID(tc.resolvedRev), tc.resolveErr)
+			r := &rebaser{store: f.store, gitserverClient: gitserverClient, logger: logtest.Scoped(t)}
+
+			if err := r.handleRebase(ctx, f.rebase); (err != nil) != tc.wantErr {
+				t.Fatalf("unexpected error. want error=%t have=%v", tc.wantErr, err)
+			}
+
+			have := f.reloadRebase(t)
+			assertRebase(t, have, tc.wantState, tc.wantFailureMessage)
+
+			if tc.wantState != btypes.ChangesetRebaseStateExecuting {
+				if have.BatchSpecWorkspaceID != 0 {
+					t.Errorf("unexpected batch spec workspace %d", have.BatchSpecWorkspaceID)
+				}
+				return
+			}
+
+			if have.BaseRev != newBaseRev {
+				t.Errorf("unexpected base rev. want=%q have=%q", newBaseRev, have.BaseRev)
+			}
+			ws, err := f.store.GetBatchSpecWorkspace(ctx, store.GetBatchSpecWorkspaceOpts{ID: have.BatchSpecWorkspaceID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ws.Commit != newBaseRev || ws.Branch != baseBranch || ws.BatchSpecID != f.workspace.BatchSpecID || !ws.Rebase {
+				t.Errorf("unexpected rebase workspace: %+v", ws)
+			}
+			if _, err := f.store.GetBatchSpecWorkspaceExecutionJob(ctx, store.GetBatchSpecWorkspaceExecutionJobOpts{
+				BatchSpecWorkspaceID: ws.ID,
+				ExcludeRank:          true,
+			}); err != nil {
+				t.Fatalf("expected execution job for rebase workspace: %s", err)
+			}
+		})
+	}
+}
+
+func TestRebaserFinish(t *testing.T) {
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+
+	sqlDB := dbtest.NewDB(logger, t)
+	db := database.NewDB(logger, sqlDB)
+	user := bt.CreateTestUser(t, db, true)
+	repo, _ := bt.CreateTestRepo(t, ctx, db)
+
+	for _, tc := range []struct {
+		name string
+		// jobState is the state of the execution job of the rebase workspace.
+		jobState          btypes.BatchSpecWorkspaceExecutionJobState
+		jobFailureMessage *string
+		// setup modifies the fixture before the rebase is handled.
+		setup func(t *testing.T, f *fixture)
+
+		wantState          btypes.ChangesetRebaseState
+		wantFailureMessage string
+	}{
+		{
+			name:      "completed",
+			jobState:  btypes.BatchSpecWorkspaceExecutionJobStateCompleted,
+			wantState: btypes.ChangesetRebaseStateCompleted,
+		},
+		{
+			name:      "still running",
+			jobState:  btypes.BatchSpecWorkspaceExecutionJobStateProcessing,
+			wantState: btypes.ChangesetRebaseStateExecuting,
+		},
+		{
+			name:               "failed",
+			jobState:           btypes.BatchSpecWorkspaceExecutionJobStateFailed,
+			jobFailureMessage:  pointers.Ptr("step 1 failed"),
+			wantState:          btypes.ChangesetRebaseStateFailed,
+			wantFailureMessage: "re-execution failed: step 1 failed",
+		},
+		{
+			name:               "failed without message",
+			jobState:           btypes.BatchSpecWorkspaceExecutionJobStateFailed,
+			wantState:          btypes.ChangesetRebaseStateFailed,
+			wantFailureMessage: "re-execution failed",
+		},
+		{
+			name:               "canceled",
+			jobState:           btypes.BatchSpecWorkspaceExecutionJobStateCanceled,
+			wantState:          btypes.ChangesetRebaseStateFailed,
+			wantFailureMessage: "re-execution was canceled",
+		},
+		{
+			name:     "no changes for branch",
+			jobState: btypes.BatchSpecWorkspaceExecutionJobStateCompleted,
+			setup: func(t *testing.T, f *fixture) {
+				if err := f.store.Exec(ctx, sqlf.Sprintf("UPDATE changeset_specs SET head_ref = %s WHERE id = %s", "refs/heads/other-branch", f.rebaseSpec.ID)); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantState:          btypes.ChangesetRebaseStateFailed,
+			wantFailureMessage: "re-execution did not produce changes for the changeset's branch",
+		},
+		{
+			name:     "changeset updated while re-executing",
+			jobState: btypes.BatchSpecWorkspaceExecutionJobStateCompleted,
+			setup: func(t *testing.T, f *fixture) {
+				f.changeset.CurrentSpecID = f.createChangesetSpec(t).ID
+				if err := f.store.UpdateChangeset(ctx, f.changeset); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantState:          btypes.ChangesetRebaseStateFailed,
+			wantFailureMessage: "changeset has been updated while it was re-executed",
+		},
+		{
+			name:     "workspace deleted",
+			jobState: btypes.BatchSpecWorkspaceExecutionJobStateCompleted,
+			setup: func(t *testing.T, f *fixture) {
+				f.rebase.BatchSpecWorkspaceID = 0
+			},
+			wantState:          btypes.ChangesetRebaseStateFailed,
+			wantFailureMessage: "batch spec workspace has been deleted",
+		},
+		{
+			name:     "execution job deleted",
+			jobState: btypes.BatchSpecWorkspaceExecutionJobStateCompleted,
+			setup: func(t *testing.T, f *fixture) {
+				if err := f.store.Exec(ctx, sqlf.Sprintf("DELETE FROM batch_spec_workspace_execution_jobs WHERE batch_spec_workspace_id = %s", f.rebase.BatchSpecWorkspaceID)); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantState:          btypes.ChangesetRebaseStateFailed,
+			wantFailureMessage: "batch spec workspace execution job has been deleted",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newFixture(t, ctx, sqlDB, user, repo)
+			f.createRebase(t)
+			f.startRebase(t, tc.jobState, tc.jobFailureMessage)
+			if tc.setup != nil {
+				tc.setup(t, f)
+			}
+
+			r := &rebaser{store: f.store, gitserverClient: gitserver.NewMockClient(), logger: logtest.Scoped(t)}
+			if err := r.handleRebase(ctx, f.rebase); err != nil {
+				t.Fatal(err)
+			}
+
+			have := f.reloadRebase(t)
+			assertRebase(t, have, tc.wantState, tc.wantFailureMessage)
+
+			ch, err := f.store.GetChangeset(ctx, store.GetChangesetOpts{ID: f.changeset.ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.wantState != btypes.ChangesetRebaseStateCompleted {
+				if ch.ReconcilerState != f.changeset.ReconcilerState {
+					t.Errorf("unexpected reconciler state. want=%s have=%s", f.changeset.ReconcilerState, ch.ReconcilerState)
+				}
+				return
+			}
+
+			if have.ChangesetSpecID != f.rebaseSpec.ID {
+				t.Errorf("unexpected changeset spec. want=%d have=%d", f.rebaseSpec.ID, have.ChangesetSpecID)
+			}
+			if ch.CurrentSpecID != f.rebaseSpec.ID || ch.PreviousSpecID != f.spec.ID {
+				t.Errorf("unexpected changeset specs. want current=%d previous=%d, have current=%d previous=%d", f.rebaseSpec.ID, f.spec.ID, ch.CurrentSpecID, ch.PreviousSpecID)
+			}
+			if ch.ReconcilerState != btypes.ReconcilerStateQueued {
+				t.Errorf("unexpected reconciler state. want=%s have=%s", btypes.ReconcilerStateQueued, ch.ReconcilerState)
+			}
+		})
+	}
+}
+
+func TestRebaserLeavesAppliedBatchSpecUnchanged(t *testing.T) {
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+
+	sqlDB := dbtest.NewDB(logger, t)
+	db := database.NewDB(logger, sqlDB)
+	user := bt.CreateTestUser(t, db, true)
+	repo, _ := bt.CreateTestRepo(t, ctx, db)
+
+	f := newFixture(t, ctx, sqlDB, user, repo)
+	f.createRebase(t)
+	before := f.batchSpecSnapshot(t)
+
+	gitserverClient := gitserver.NewMockClient()
+	gitserverClient.ResolveRevisionFunc.SetDefaultReturn(api.CommitID(newBaseRev), nil)
+	r := &rebaser{store: f.store, gitserverClient: gitserverClient, logger: logtest.Scoped(t)}
+
+	// Start the rebase, and check the applied batch spec while the rebase
+	// workspace is queued.
+	if err := r.handleRebase(ctx, f.rebase); err != nil {
+		t.Fatal(err)
+	}
+	f.rebase = f.reloadRebase(t)
+	assertRebase(t, f.rebase, btypes.ChangesetRebaseStateExecuting, "")
+	if diff := cmp.Diff(before, f.batchSpecSnapshot(t)); diff != "" {
+		t.Errorf("unexpected applied batch spec after starting the rebase (-want +got):\n%s", diff)
+	}
+
+	// Complete the execution of the rebase workspace in the same way the
+	// execution worker store does, and finish the rebase.
+	rebaseSpec := bt.CreateChangesetSpec(t, ctx, f.store, bt.TestSpecOpts{
+		User:    user.ID,
+		Repo:    repo.ID,
+		HeadRef: headRef,
+		Typ:     btypes.ChangesetSpecTypeBranch,
+	})
+	if err := f.store.Exec(ctx, sqlf.Sprintf("UPDATE batch_spec_workspaces SET changeset_spec_ids = %s WHERE id = %s", fmt.Sprintf(`{"%d": {}}`, rebaseSpec.ID), f.rebase.BatchSpecWorkspaceID)); err != nil {
+		t.Fatal(err)
+	}
+	job, err := f.store.GetBatchSpecWorkspaceExecutionJob(ctx, store.GetBatchSpecWorkspaceExecutionJobOpts{BatchSpecWorkspaceID: f.rebase.BatchSpecWorkspaceID, ExcludeRank: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	job.State = btypes.BatchSpecWorkspaceExecutionJobStateCompleted
+	bt.UpdateJobState(t, ctx, f.store, job)
+
+	if err := r.handleRebase(ctx, f.rebase); err != nil {
+		t.Fatal(err)
+	}
+	assertRebase(t, f.reloadRebase(t), btypes.ChangesetRebaseStateCompleted, "")
+	if diff := cmp.Diff(before, f.batchSpecSnapshot(t)); diff != "" {
+		t.Errorf("unexpected applied batch spec after finishing the rebase (-want +got):\n%s", diff)
+	}
+}
+
+// batchSpecSnapshot is what the fixture's batch spec looks like to users and
+// to the rewirer when the batch spec is applied again.
+type batchSpecSnapshot struct {
+	State            btypes.BatchSpecState
+	Stats            btypes.BatchSpecStats
+	WorkspaceIDs     []int64
+	JobIDs           []int64
+	ChangesetSpecIDs []int64
+}
+
+func (f *fixture) batchSpecSnapshot(t *testing.T) batchSpecSnapshot {
+	t.Helper()
+
+	stats, err := f.store.GetBatchSpecStats(f.ctx, []int64{f.batchSpec.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshot := batchSpecSnapshot{
+		State: btypes.ComputeBatchSpecState(f.batchSpec, stats[f.batchSpec.ID]),
+		Stats: stats[f.batchSpec.ID],
+	}
+
+	workspaces, _, err := f.store.ListBatchSpecWorkspaces(f.ctx, store.ListBatchSpecWorkspacesOpts{BatchSpecID: f.batchSpec.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ws := range workspaces {
+		snapshot.WorkspaceIDs = append(snapshot.WorkspaceIDs, ws.ID)
+	}
+
+	jobs, err := f.store.ListBatchSpecWorkspaceExecutionJobs(f.ctx, store.ListBatchSpecWorkspaceExecutionJobsOpts{BatchSpecID: f.batchSpec.ID, ExcludeRank: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, job := range jobs {
+		snapshot.JobIDs = append(snapshot.JobIDs, job.ID)
+	}
+
+	specs, _, err := f.store.ListChangesetSpecs(f.ctx, store.ListChangesetSpecsOpts{BatchSpecID: f.batchSpec.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, spec := range specs {
+		snapshot.ChangesetSpecIDs = append(snapshot.ChangesetSpecIDs, spec.ID)
+	}
+
+	return snapshot
+}
+
+// fixture is a changeset created by server-side execution, together with the
+// workspace that produced it.
+type fixture struct {
+	ctx   context.Context
+	store *store.Store
+	user  *types.User
+	repo  *types.Repo
+
+	batchSpec *btypes.BatchSpec
+	spec      *btypes.ChangesetSpec
+	changeset *btypes.Changeset
+	workspace *btypes.BatchSpecWorkspace
+	rebase    *btypes.ChangesetRebase
+
+	// rebaseSpec is the changeset spec produced by re-executing the workspace,
+	// set by startRebase.
+	rebaseSpec *btypes.ChangesetSpec
+}
+
+func newFixture(t *testing.T, ctx context.Context, sqlDB *sql.DB, user *types.User, repo *types.Repo) *fixture {
+	t.Helper()
+
+	// Every fixture lives in its own transaction, so that the fixtures of
+	// different test cases don't interfere.
+	tx := store.New(database.NewDBWith(logtest.Scoped(t), basestore.NewWithHandle(basestore.NewHandleWithTx(dbtest.NewTx(t, sqlDB), sql.TxOptions{}))), &observation.TestContext, nil)
+
+	f := &fixture{ctx: ctx, store: tx, user: user, repo: repo}
+	f.batchSpec = bt.CreateBatchSpec(t, ctx, tx, "test-rebase", user.ID, 0)
+	batchChange := bt.CreateBatchChange(t, ctx, tx, "test-rebase", user.ID, f.batchSpec.ID)
+	f.spec = f.createChangesetSpec(t)
+	f.changeset = bt.CreateChangeset(t, ctx, tx, bt.TestChangesetOpts{
+		Repo:               repo.ID,
+		BatchChanges:       []btypes.BatchChangeAssoc{{BatchChangeID: batchChange.ID}},
+		OwnedByBatchChange: batchChange.ID,
+		CurrentSpec:        f.spec.ID,
+		PublicationState:   btypes.ChangesetPublicationStatePublished,
+		ReconcilerState:    btypes.ReconcilerStateCompleted,
+		ExternalState:      btypes.ChangesetExternalStateOpen,
+	})
+
+	f.workspace = &btypes.BatchSpecWorkspace{
+		BatchSpecID:      f.batchSpec.ID,
+		ChangesetSpecIDs: []int64{f.spec.ID},
+		RepoID:           repo.ID,
+		Branch:           baseBranch,
+		Commit:           oldBaseRev,
+		FileMatches:      []string{},
+	}
+	if err := tx.CreateBatchSpecWorkspace(ctx, f.workspace); err != nil {
+		t.Fatal(err)
+	}
+
+	f.rebase = &btypes.ChangesetRebase{
+		ChangesetID:             f.changeset.ID,
+		BatchChangeID:           batchChange.ID,
+		PreviousChangesetSpecID: f.spec.ID,
+	}
+	return f
+}
+
+func (f *fixture) createChangesetSpec(t *testing.T) *btypes.ChangesetSpec {
+	t.Helper()
+
+	return bt.CreateChangesetSpec(t, f.ctx, f.store, bt.TestSpecOpts{
+		User:      f.user.ID,
+		Repo:      f.repo.ID,
+		BatchSpec: f.batchSpec.ID,
+		HeadRef:   headRef,
+		Typ:       btypes.ChangesetSpecTypeBranch,
+	})
+}
+
+func (f *fixture) createRebase(t *testing.T) {
+	t.Helper()
+
+	if err := f.store.CreateChangesetRebase(f.ctx, f.rebase); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// startRebase moves the rebase to the executing state with a rebase workspace
+// whose execution job is in the given state and that produced a changeset spec
+// for the changeset's branch.
+func (f *fixture) startRebase(t *testing.T, jobState btypes.BatchSpecWorkspaceExecutionJobState, failureMessage *string) {
+	t.Helper()
+
+	// The changeset specs produced by rebase workspaces aren't attached to
+	// the batch spec.
+	f.rebaseSpec = bt.CreateChangesetSpec(t, f.ctx, f.store, bt.TestSpecOpts{
+		User:    f.user.ID,
+		Repo:    f.repo.ID,
+		HeadRef: headRef,
+		Typ:     btypes.ChangesetSpecTypeBranch,
+	})
+	ws := &btypes.BatchSpecWorkspace{
+		BatchSpecID:      f.batchSpec.ID,
+		ChangesetSpecIDs: []int64{f.rebaseSpec.ID},
+		RepoID:           f.repo.ID,
+		Branch:           baseBranch,
+		Commit:           newBaseRev,
+		FileMatches:      []string{},
+		Rebase:           true,
+	}
+	if err := f.store.CreateBatchSpecWorkspace(f.ctx, ws); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.store.CreateBatchSpecWorkspaceExecutionJobsForWorkspaces(f.ctx, []int64{ws.ID}); err != nil {
+		t.Fatal(err)
+	}
+
+	job, err := f.store.GetBatchSpecWorkspaceExecutionJob(f.ctx, store.GetBatchSpecWorkspaceExecutionJobOpts{BatchSpecWorkspaceID: ws.ID, ExcludeRank: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	job.State = jobState
+	job.FailureMessage = failureMessage
+	bt.UpdateJobState(t, f.ctx, f.store, job)
+
+	f.rebase.BatchSpecWorkspaceID = ws.ID
+	f.rebase.BaseRev = newBaseRev
+	f.rebase.State = btypes.ChangesetRebaseStateExecuting
+	if err := f.store.UpdateChangesetRebase(f.ctx, f.rebase); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func (f *fixture) reloadRebase(t *testing.T) *btypes.ChangesetRebase {
+	t.Helper()
+
+	rebases, _, err := f.store.ListChangesetRebases(f.ctx, store.ListChangesetRebasesOpts{ChangesetID: f.changeset.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rebases) != 1 {
+		t.Fatalf("unexpected number of rebases. want=%d have=%d", 1, len(rebases))
+	}
+	return rebases[0]
+}
+
+func assertRebase(t *testing.T, rb *btypes.ChangesetRebase, wantState btypes.ChangesetRebaseState, wantFailureMessage string) {
+	t.Helper()
+
+	if rb.State != wantState {
+		t.Errorf("unexpected state. want=%s have=%s", wantState, rb.State)
+	}
+
+	var haveFailureMessage string
+	if rb.FailureMessage != nil {
+		haveFailureMessage = *rb.FailureMessage
+	}
+	if haveFailureMessage != wantFailureMessage {
+		t.Errorf("unexpected failure message. want=%q have=%q", wantFailureMessage, haveFailureMessage)
+	}
+}
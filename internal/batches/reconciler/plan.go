@@ -186,6 +186,12 @@ func DeterminePlan(previousSpec, currentSpec *btypes.ChangesetSpec, currentChang
 	}
 
 	delta := compareChangesetSpecs(previousSpec, currentSpec, wantedChangeset.UiPublicationState)
+	// The commit is created on top of the spec's base revision. A changed base
+	// revision alone only requires a new commit when the existing one conflicts
+	// with the base branch, which is the case for automatic rebases.
+	if previousSpec != nil && previousSpec.BaseRev != currentSpec.BaseRev && wantedChangeset.HasMergeConflict() {
+		delta.BaseRevChanged = true
+	}
 	pl.Delta = delta
 
 	switch wantedChangeset.PublicationState {
@@ -344,6 +350,7 @@ type ChangesetSpecDelta struct {
 	BodyChanged          bool
 	Undraft              bool
 	BaseRefChanged       bool
+	BaseRevChanged       bool
 	DiffChanged          bool
 	CommitMessageChanged bool
 	AuthorNameChanged    bool
@@ -353,7 +360,7 @@ type ChangesetSpecDelta struct {
 func (d *ChangesetSpecDelta) String() string { return fmt.Sprintf("%#v", d) }
 
 func (d *ChangesetSpecDelta) NeedCommitUpdate() bool {
-	return d.DiffChanged || d.CommitMessageChanged || d.AuthorNameChanged || d.AuthorEmailChanged
+	return d.DiffChanged || d.BaseRevChanged || d.CommitMessageChanged || d.AuthorNameChanged || d.AuthorEmailChanged
 }
 
 func (d *ChangesetSpecDelta) NeedCodeHostUpdate() bool {
@@ -6,6 +6,7 @@ import (
 	bt "github.com/sourcegraph/sourcegraph/internal/batches/testing"
 	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/lib/pointers"
 )
 
@@ -256,6 +257,30 @@ func TestDetermineReconcilerPlan(t *testing.T) {
 				btypes.ReconcilerOperationSync,
 			},
 		},
+		{
+			name:         "base rev changed on published changeset",
+			previousSpec: &bt.TestSpecOpts{Published: true, BaseRev: "d34db33f"},
+			currentSpec:  &bt.TestSpecOpts{Published: true, BaseRev: "f00b4r"},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				Metadata:         &github.PullRequest{Mergeable: "MERGEABLE"},
+			},
+			wantOperations: Operations{},
+		},
+		{
+			name:         "base rev changed on conflicting changeset",
+			previousSpec: &bt.TestSpecOpts{Published: true, BaseRev: "d34db33f"},
+			currentSpec:  &bt.TestSpecOpts{Published: true, BaseRev: "f00b4r"},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				Metadata:         &github.PullRequest{Mergeable: "CONFLICTING"},
+			},
+			wantOperations: Operations{
+				btypes.ReconcilerOperationPush,
+				btypes.ReconcilerOperationSleep,
+				btypes.ReconcilerOperationSync,
+			},
+		},
 		{
 			name:         "commit diff changed on merge changeset",
 			previousSpec: &bt.TestSpecOpts{Published: true, CommitDiff: []byte("testDiff")},
@@ -6,6 +6,7 @@ import (
 
 	"github.com/sourcegraph/log"
 
+	"github.com/sourcegraph/sourcegraph/internal/batches/rollout"
 	"github.com/sourcegraph/sourcegraph/internal/batches/sources"
 	"github.com/sourcegraph/sourcegraph/internal/batches/store"
 	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
@@ -97,6 +98,20 @@ func (r *Reconciler) process(ctx context.Context, logger log.Logger, tx *store.S
 		return nil, err
 	}
 
+	// Changesets of batch changes with a rollout policy are only published
+	// once the rollout reaches their wave. The rollout advancer enqueues them
+	// again when that happens.
+	if plan.Ops.Contains(btypes.ReconcilerOperationPublish) || plan.Ops.Contains(btypes.ReconcilerOperationPublishDraft) {
+		held, err := rollout.Hold(ctx, tx, ch)
+		if err != nil {
+			return nil, err
+		}
+		if held {
+			logger.Info("Reconciler holding back changeset until its rollout wave is reached", log.Int64("changeset", ch.ID))
+			plan.Ops = withoutPublishing(plan.Ops)
+		}
+	}
+
 	logger.Info("Reconciler processing changeset", log.Int64("changeset", ch.ID), log.String("operations", fmt.Sprintf("%+v", plan.Ops)))
 
 	return executePlan(
@@ -125,3 +140,17 @@ func loadChangesetSpecs(ctx context.Context, tx *store.Store, ch *btypes.Changes
 	}
 	return
 }
+
+// withoutPublishing returns the given operations without those that push and
+// publish a changeset.
+func withoutPublishing(ops Operations) Operations {
+	filtered := Operations{}
+	for _, op := range ops {
+		switch op {
+		case btypes.ReconcilerOperationPush, btypes.ReconcilerOperationPublish, btypes.ReconcilerOperationPublishDraft:
+			continue
+		}
+		filtered = append(filtered, op)
+	}
+	return filtered
+}
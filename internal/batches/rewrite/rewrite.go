@@ -0,0 +1,335 @@
+// Package rewrite executes batch specs that only consist of rewrite steps.
+// Rewrite steps don't need a container and an executor: they are applied
+// directly to the file contents read from gitserver while the workspaces of a
+// batch spec are resolved, and they produce the same step results as a
+// container execution.
+package rewrite
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/go-enry/go-enry/v2"
+	"github.com/grafana/regexp"
+	"github.com/hexops/gotextdiff"
+	"github.com/hexops/gotextdiff/myers"
+	"github.com/hexops/gotextdiff/span"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/comby"
+	"github.com/sourcegraph/sourcegraph/internal/compute"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/batches/execution"
+	"github.com/sourcegraph/sourcegraph/lib/batches/git"
+	"github.com/sourcegraph/sourcegraph/lib/batches/template"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	// maxFiles is the maximum number of files in a workspace that rewrite
+	// steps are applied to.
+	maxFiles = 10_000
+	// maxFileSize is the size above which files are not rewritten.
+	maxFileSize = 1 << 20
+)
+
+// Workspace is a workspace in a repository that rewrite steps are applied to.
+type Workspace struct {
+	Repo batcheslib.Repository
+	// Path is the path of the workspace in the repository. It is empty for the
+	// repository root.
+	Path string
+}
+
+// Run applies the steps of the batch spec to the workspace. It returns a
+// result for every step that is not in skippedSteps, in order. Like the
+// results of a container execution, the diff of each result is the cumulative
+// diff of all steps up to and including that step.
+func Run(
+	ctx context.Context,
+	client gitserver.Client,
+	spec *batcheslib.BatchSpec,
+	workspace Workspace,
+	skippedSteps map[int]struct{},
+) ([]execution.AfterStepResult, error) {
+	if !spec.HasOnlyRewriteSteps() {
+		return nil, errors.New("batch spec contains steps that require a container")
+	}
+
+	paths, err := workspaceFiles(ctx, client, workspace)
+	if err != nil {
+		return nil, err
+	}
+
+	files := &fileSet{
+		client:   client,
+		repo:     api.RepoName(workspace.Repo.Name),
+		commit:   api.CommitID(workspace.Repo.BaseRev),
+		original: make(map[string]string),
+		current:  make(map[string]string),
+	}
+
+	var (
+		results  []execution.AfterStepResult
+		previous execution.AfterStepResult
+		outputs  = make(map[string]any)
+	)
+	for i, step := range spec.Steps {
+		if _, ok := skippedSteps[i]; ok {
+			continue
+		}
+
+		changes, err := git.ChangesInDiff(previous.Diff)
+		if err != nil {
+			return nil, errors.Wrap(err, "getting changes in diff")
+		}
+		stepCtx := template.StepContext{
+			BatchChange: template.BatchChangeAttributes{
+				Name:        spec.Name,
+				Description: spec.Description,
+			},
+			Repository: template.Repository{
+				Name:        workspace.Repo.Name,
+				Branch:      workspace.Repo.BaseRef,
+				FileMatches: workspace.Repo.FileMatches,
+			},
+			Outputs: outputs,
+			Steps: template.StepsContext{
+				Path:    workspace.Path,
+				Changes: changes,
+			},
+			PreviousStep: previous,
+		}
+
+		cond, err := template.EvalStepCondition(step.IfCondition(), &stepCtx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "evaluating condition of step %d", i+1)
+		}
+		if !cond {
+			result := execution.AfterStepResult{
+				Version:   2,
+				StepIndex: i,
+				Diff:      previous.Diff,
+				Outputs:   copyOutputs(outputs),
+				Skipped:   true,
+			}
+			results = append(results, result)
+			previous = result
+			continue
+		}
+
+		rewritten, err := files.apply(ctx, step.Rewrite, paths)
+		if err != nil {
+			return nil, errors.Wrapf(err, "running step %d", i+1)
+		}
+
+		result := execution.AfterStepResult{
+			Version:   2,
+			StepIndex: i,
+			// The rewritten files are the stdout of a rewrite step, so they can
+			// be referenced in outputs through step.stdout.
+			Stdout: strings.Join(rewritten, "\n"),
+			Diff:   files.diff(),
+		}
+		stepCtx.Step = result
+		if err := batcheslib.SetOutputs(step.Outputs, outputs, &stepCtx); err != nil {
+			return nil, errors.Wrapf(err, "setting outputs of step %d", i+1)
+		}
+		result.Outputs = copyOutputs(outputs)
+		if result.ChangedFiles, err = git.ChangesInDiff(result.Diff); err != nil {
+			return nil, errors.Wrap(err, "getting changes in diff")
+		}
+
+		results = append(results, result)
+		previous = result
+	}
+
+	return results, nil
+}
+
+// workspaceFiles returns the paths of the files that rewrite steps are applied
+// to. These are the file matches of the search query inside the workspace or,
+// if the workspace has no file matches, all files in the workspace.
+func workspaceFiles(ctx context.Context, client gitserver.Client, workspace Workspace) ([]string, error) {
+	var paths []string
+	if len(workspace.Repo.FileMatches) > 0 {
+		for _, p := range workspace.Repo.FileMatches {
+			if inWorkspace(workspace.Path, p) {
+				paths = append(paths, p)
+			}
+		}
+	} else {
+		entries, err := client.ReadDir(ctx, authz.DefaultSubRepoPermsChecker, api.RepoName(workspace.Repo.Name), api.CommitID(workspace.Repo.BaseRev), workspace.Path, true)
+		if err != nil {
+			return nil, errors.Wrap(err, "listing workspace files")
+		}
+		for _, entry := range entries {
+			if !entry.Mode().IsRegular() || entry.Size() > maxFileSize {
+				continue
+			}
+			paths = append(paths, entry.Name())
+		}
+	}
+
+	if len(paths) > maxFiles {
+		return nil, errors.Newf("workspace contains more than %d files, use a search query that returns file matches to narrow it down", maxFiles)
+	}
+
+	sort.Strings(paths)
+	return paths, nil
+}
+
+func inWorkspace(workspacePath, file string) bool {
+	if workspacePath == "" || workspacePath == "." {
+		return true
+	}
+	return strings.HasPrefix(file, strings.TrimSuffix(workspacePath, "/")+"/")
+}
+
+// fileSet tracks the original and rewritten contents of the files in a
+// workspace across all steps.
+type fileSet struct {
+	client gitserver.Client
+	repo   api.RepoName
+	commit api.CommitID
+
+	// original holds the contents of the files that have been read. Binary
+	// and oversized files are not rewritten and are not added.
+	original map[string]string
+	// current holds the contents after the steps applied so far.
+	current map[string]string
+	// ignored holds the files that can't be rewritten.
+	ignored map[string]struct{}
+}
+
+// apply applies the rewrite to all paths that match its language and returns
+// the paths of the files that were changed.
+func (f *fileSet) apply(ctx context.Context, rewrite *batcheslib.RewriteStep, paths []string) ([]string, error) {
+	matchPattern, err := toMatchPattern(rewrite)
+	if err != nil {
+		return nil, err
+	}
+
+	language := ""
+	if rewrite.Language != "" {
+		lang, ok := enry.GetLanguageByAlias(rewrite.Language)
+		if !ok {
+			return nil, errors.Newf("unknown language %q", rewrite.Language)
+		}
+		language = lang
+	}
+
+	var rewritten []string
+	for _, p := range paths {
+		if language != "" && !hasLanguage(p, language) {
+			continue
+		}
+
+		content, ok, err := f.read(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		newContent, err := compute.ReplaceContent(ctx, []byte(content), matchPattern, rewrite.Replace, language, p)
+		if err != nil {
+			return nil, errors.Wrapf(err, "rewriting %s", p)
+		}
+		if newContent != content {
+			f.current[p] = newContent
+			rewritten = append(rewritten, p)
+		}
+	}
+
+	return rewritten, nil
+}
+
+// read returns the current content of the file. It returns false if the file
+// can't be rewritten.
+func (f *fileSet) read(ctx context.Context, p string) (string, bool, error) {
+	if content, ok := f.current[p]; ok {
+		return content, true, nil
+	}
+	if _, ok := f.ignored[p]; ok {
+		return "", false, nil
+	}
+
+	content, err := f.client.ReadFile(ctx, authz.DefaultSubRepoPermsChecker, f.repo, f.commit, p)
+	if err != nil {
+		return "", false, errors.Wrapf(err, "reading %s", p)
+	}
+	if len(content) > maxFileSize || enry.IsBinary(content) {
+		if f.ignored == nil {
+			f.ignored = make(map[string]struct{})
+		}
+		f.ignored[p] = struct{}{}
+		return "", false, nil
+	}
+
+	f.original[p] = string(content)
+	f.current[p] = string(content)
+	return string(content), true, nil
+}
+
+// diff returns the diff between the original and the current contents of all
+// files, in the format of `git diff --no-prefix`.
+func (f *fileSet) diff() []byte {
+	paths := make([]string, 0, len(f.current))
+	for p, content := range f.current {
+		if content != f.original[p] {
+			paths = append(paths, p)
+		}
+	}
+	sort.Strings(paths)
+
+	var b strings.Builder
+	for _, p := range paths {
+		edits := myers.ComputeEdits(span.URIFromPath(p), f.original[p], f.current[p])
+		fmt.Fprintf(&b, "diff --git %s %s\n", p, p)
+		fmt.Fprint(&b, gotextdiff.ToUnified(p, p, f.original[p], edits))
+	}
+	return []byte(b.String())
+}
+
+func toMatchPattern(rewrite *batcheslib.RewriteStep) (compute.MatchPattern, error) {
+	if rewrite.IsRegexp() {
+		re, err := regexp.Compile(rewrite.Match)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid regular expression")
+		}
+		return &compute.Regexp{Value: re}, nil
+	}
+	if !comby.Exists() {
+		return nil, errors.New("structural rewrites require comby to be installed, use patternType regexp instead")
+	}
+	return &compute.Comby{Value: rewrite.Match}, nil
+}
+
+func hasLanguage(p, language string) bool {
+	for _, lang := range enry.GetLanguagesByExtension(path.Base(p), nil, nil) {
+		if lang == language {
+			return true
+		}
+	}
+	for _, lang := range enry.GetLanguagesByFilename(path.Base(p), nil, nil) {
+		if lang == language {
+			return true
+		}
+	}
+	return false
+}
+
+func copyOutputs(outputs map[string]any) map[string]any {
+	c := make(map[string]any, len(outputs))
+	for k, v := range outputs {
+		c[k] = v
+	}
+	return c
+}
@@ -0,0 +1,196 @@
+package rewrite
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/comby"
+	"github.com/sourcegraph/sourcegraph/internal/fileutil"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/batches/git"
+)
+
+func TestRun(t *testing.T) {
+	ctx := context.Background()
+
+	contents := map[string]string{
+		"README.md":     "# foo(bar)\n",
+		"sub/main.go":   "package main\nfunc main() {\n\tfoo(bar)\n}\n",
+		"sub/other.go":  "package main\n",
+		"sub/script.sh": "foo(baz)",
+	}
+
+	client := gitserver.NewMockClient()
+	client.ReadDirFunc.SetDefaultHook(func(_ context.Context, _ authz.SubRepoPermissionChecker, _ api.RepoName, _ api.CommitID, path string, _ bool) ([]fs.FileInfo, error) {
+		var fis []fs.FileInfo
+		for name, content := range contents {
+			if inWorkspace(path, name) {
+				fis = append(fis, &fileutil.FileInfo{Name_: name, Mode_: os.ModePerm, Size_: int64(len(content))})
+			}
+		}
+		return fis, nil
+	})
+	client.ReadFileFunc.SetDefaultHook(func(_ context.Context, _ authz.SubRepoPermissionChecker, _ api.RepoName, _ api.CommitID, name string) ([]byte, error) {
+		return []byte(contents[name]), nil
+	})
+
+	workspace := Workspace{
+		Repo: batcheslib.Repository{Name: "github.com/sourcegraph/test", BaseRef: "refs/heads/main", BaseRev: "deadbeef"},
+		Path: "sub",
+	}
+
+	spec := &batcheslib.BatchSpec{
+		Name: "test",
+		Steps: []batcheslib.Step{
+			{
+				Rewrite: &batcheslib.RewriteStep{Match: `foo\((\w+)\)`, Replace: "qux($1)", Language: "go", PatternType: batcheslib.RewritePatternTypeRegexp},
+				Outputs: batcheslib.Outputs{"rewritten": batcheslib.Output{Value: "${{ step.stdout }}"}},
+			},
+			{
+				Rewrite: &batcheslib.RewriteStep{Match: `never`, Replace: "matched", PatternType: batcheslib.RewritePatternTypeRegexp},
+				If:      "${{ eq outputs.rewritten \"nothing\" }}",
+			},
+			{
+				Rewrite: &batcheslib.RewriteStep{Match: `baz`, Replace: "quux", PatternType: batcheslib.RewritePatternTypeRegexp},
+			},
+		},
+	}
+
+	results, err := Run(ctx, client, spec, workspace, map[int]struct{}{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("wrong number of results. want=3, have=%d", len(results))
+	}
+
+	wantFirstDiff := `diff --git sub/main.go sub/main.go
+--- sub/main.go
++++ sub/main.go
+@@ -1,4 +1,4 @@
+ package main
+ func main() {
+-	foo(bar)
++	qux(bar)
+ }
+`
+	if diff := cmp.Diff(wantFirstDiff, string(results[0].Diff)); diff != "" {
+		t.Fatalf("wrong diff for first step (-want +got):\n%s", diff)
+	}
+	if have, want := results[0].Outputs["rewritten"], "sub/main.go"; have != want {
+		t.Fatalf("wrong output. want=%q, have=%q", want, have)
+	}
+	if diff := cmp.Diff(git.Changes{Modified: []string{"sub/main.go"}}, results[0].ChangedFiles); diff != "" {
+		t.Fatalf("wrong changed files (-want +got):\n%s", diff)
+	}
+
+	if !results[1].Skipped {
+		t.Fatal("second step was not skipped")
+	}
+	if diff := cmp.Diff(results[0].Diff, results[1].Diff); diff != "" {
+		t.Fatalf("skipped step changed the diff (-want +got):\n%s", diff)
+	}
+
+	wantLastDiff := wantFirstDiff + `diff --git sub/script.sh sub/script.sh
+--- sub/script.sh
++++ sub/script.sh
+@@ -1 +1 @@
+-foo(baz)
+\ No newline at end of file
++foo(quux)
+\ No newline at end of file
+`
+	if diff := cmp.Diff(wantLastDiff, string(results[2].Diff)); diff != "" {
+		t.Fatalf("wrong diff for last step (-want +got):\n%s", diff)
+	}
+	if have, want := results[2].StepIndex, 2; have != want {
+		t.Fatalf("wrong step index. want=%d, have=%d", want, have)
+	}
+}
+
+func TestRun_FileMatches(t *testing.T) {
+	client := gitserver.NewMockClient()
+	client.ReadFileFunc.SetDefaultHook(func(_ context.Context, _ authz.SubRepoPermissionChecker, _ api.RepoName, _ api.CommitID, name string) ([]byte, error) {
+		return []byte("foo\n"), nil
+	})
+
+	spec := &batcheslib.BatchSpec{
+		Steps: []batcheslib.Step{
+			{Rewrite: &batcheslib.RewriteStep{Match: "foo", Replace: "bar", PatternType: batcheslib.RewritePatternTypeRegexp}},
+		},
+	}
+	workspace := Workspace{
+		Repo: batcheslib.Repository{Name: "github.com/sourcegraph/test", FileMatches: []string{"a/1.txt", "b/2.txt"}},
+		Path: "a",
+	}
+
+	results, err := Run(context.Background(), client, spec, workspace, map[int]struct{}{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(git.Changes{Modified: []string{"a/1.txt"}}, results[0].ChangedFiles); diff != "" {
+		t.Fatalf("wrong changed files (-want +got):\n%s", diff)
+	}
+	if len(client.ReadDirFunc.History()) != 0 {
+		t.Fatal("ReadDir called for workspace with file matches")
+	}
+}
+
+func TestRun_Structural(t *testing.T) {
+	// If we are not on CI skip the test if comby is not installed.
+	if os.Getenv("CI") == "" && !comby.Exists() {
+		t.Skip("comby is not installed on the PATH. Try running 'bash <(curl -sL get.comby.dev)'.")
+	}
+
+	contents := map[string]string{
+		"main.go":   "package main\n\nfunc main() {\n\tfoo(bar(1, 2), baz)\n}\n",
+		"script.py": "foo(bar, baz)\n",
+	}
+
+	client := gitserver.NewMockClient()
+	client.ReadFileFunc.SetDefaultHook(func(_ context.Context, _ authz.SubRepoPermissionChecker, _ api.RepoName, _ api.CommitID, name string) ([]byte, error) {
+		return []byte(contents[name]), nil
+	})
+
+	spec := &batcheslib.BatchSpec{
+		Steps: []batcheslib.Step{
+			{Rewrite: &batcheslib.RewriteStep{Match: "foo(:[x], :[y])", Replace: "foo(:[y], :[x])", Language: "go"}},
+		},
+	}
+	workspace := Workspace{
+		Repo: batcheslib.Repository{Name: "github.com/sourcegraph/test", FileMatches: []string{"main.go", "script.py"}},
+	}
+
+	results, err := Run(context.Background(), client, spec, workspace, map[int]struct{}{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Structural patterns match balanced parentheses, so the nested call is
+	// a single hole. Files in other languages are not rewritten.
+	wantDiff := `diff --git main.go main.go
+--- main.go
++++ main.go
+@@ -1,5 +1,5 @@
+ package main
+ 
+ func main() {
+-	foo(bar(1, 2), baz)
++	foo(baz, bar(1, 2))
+ }
+`
+	if diff := cmp.Diff(wantDiff, string(results[0].Diff)); diff != "" {
+		t.Fatalf("wrong diff (-want +got):\n%s", diff)
+	}
+	if have, want := results[0].Stdout, "main.go"; have != want {
+		t.Fatalf("wrong stdout. want=%q, have=%q", want, have)
+	}
+}
@@ -0,0 +1,190 @@
+package rollout
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/batches/global"
+	"github.com/sourcegraph/sourcegraph/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
+)
+
+const advancerInterval = 1 * time.Minute
+
+// NewAdvancer creates a new goroutine.PeriodicGoroutine that evaluates the
+// current rollout wave of open batch changes with a rollout policy, moves on
+// to the next wave once the success criteria are met, and enqueues the
+// changesets that were held back until their wave was reached.
+func NewAdvancer(ctx context.Context, observationCtx *observation.Context, s *store.Store) goroutine.BackgroundRoutine {
+	a := &advancer{
+		store:  s,
+		logger: observationCtx.Logger.Scoped("rollout", "advances the rollout waves of batch changes"),
+	}
+
+	return goroutine.NewPeriodicGoroutine(
+		ctx,
+		goroutine.HandlerFunc(a.Handle),
+		goroutine.WithName("batchchanges.rollout-advancer"),
+		goroutine.WithDescription("publishes the next rollout wave of batch changes once the current wave succeeded"),
+		goroutine.WithInterval(advancerInterval),
+	)
+}
+
+type advancer struct {
+	store  *store.Store
+	logger log.Logger
+}
+
+func (a *advancer) Handle(ctx context.Context) error {
+	opts := store.ListBatchChangesOpts{
+		LimitOpts: store.LimitOpts{Limit: pageSize},
+		States:    []btypes.BatchChangeState{btypes.BatchChangeStateOpen},
+	}
+
+	for {
+		batchChanges, next, err := a.store.ListBatchChanges(ctx, opts)
+		if err != nil {
+			return errors.Wrap(err, "listing batch changes")
+		}
+
+		for _, batchChange := range batchChanges {
+			if err := a.handleBatchChange(ctx, batchChange); err != nil {
+				a.logger.Error("failed to advance rollout of batch change", log.Int64("batchChangeID", batchChange.ID), log.Error(err))
+			}
+		}
+
+		if next == 0 {
+			return nil
+		}
+		opts.Cursor = next
+	}
+}
+
+func (a *advancer) handleBatchChange(ctx context.Context, batchChange *btypes.BatchChange) (err error) {
+	if batchChange.RolloutPaused {
+		return nil
+	}
+
+	policy, err := loadPolicy(ctx, a.store, batchChange)
+	if err != nil || policy == nil {
+		return err
+	}
+
+	tx, err := a.store.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	changesets, err := listOwnedChangesets(ctx, tx, batchChange)
+	if err != nil {
+		return err
+	}
+	if err := assignChangesetsFrom(ctx, tx, batchChange, policy, changesets); err != nil {
+		return err
+	}
+
+	waves, err := tx.ListChangesetRolloutWaves(ctx, store.ListChangesetRolloutWavesOpts{BatchChangeID: batchChange.ID})
+	if err != nil {
+		return errors.Wrap(err, "listing rollout waves")
+	}
+
+	if a.advance(batchChange, policy, changesets, waves) {
+		if err := tx.UpdateBatchChange(ctx, batchChange); err != nil {
+			return errors.Wrap(err, "updating batch change")
+		}
+	}
+	if batchChange.RolloutPaused {
+		return nil
+	}
+
+	return release(ctx, tx, batchChange, changesets)
+}
+
+// advance moves the rollout of the batch change forward as long as the
+// current wave is complete, and pauses it if the current wave failed. It
+// returns true if the batch change was changed.
+func (a *advancer) advance(batchChange *btypes.BatchChange, policy *batcheslib.RolloutPolicy, changesets btypes.Changesets, waves []*btypes.ChangesetRolloutWave) bool {
+	byID := make(map[int64]*btypes.Changeset, len(changesets))
+	for _, ch := range changesets {
+		byID[ch.ID] = ch
+	}
+
+	changed := false
+	for int(batchChange.RolloutWave) < len(policy.Waves) {
+		var current btypes.Changesets
+		held := map[int64]struct{}{}
+		for _, w := range waves {
+			if w.Wave != batchChange.RolloutWave {
+				continue
+			}
+			if ch, ok := byID[w.ChangesetID]; ok {
+				current = append(current, ch)
+			}
+			if w.Held {
+				held[w.ChangesetID] = struct{}{}
+			}
+		}
+
+		eval := evaluateWave(policy, int(batchChange.RolloutWave), current, held)
+		if eval.Pause {
+			a.logger.Info("pausing rollout", log.Int64("batchChangeID", batchChange.ID), log.String("reason", eval.Reason))
+			batchChange.RolloutPaused = true
+			batchChange.RolloutPauseReason = eval.Reason
+			return true
+		}
+		if !eval.Advance {
+			return changed
+		}
+
+		batchChange.RolloutWave++
+		changed = true
+		a.logger.Info("advancing rollout", log.Int64("batchChangeID", batchChange.ID), log.Int32("wave", batchChange.RolloutWave))
+	}
+
+	return changed
+}
+
+// release enqueues the changesets that were held back by the reconciler and
+// whose wave has been reached. They are enqueued like any other changeset, so
+// rollout windows still apply to them.
+func release(ctx context.Context, tx *store.Store, batchChange *btypes.BatchChange, changesets btypes.Changesets) error {
+	byID := make(map[int64]*btypes.Changeset, len(changesets))
+	for _, ch := range changesets {
+		byID[ch.ID] = ch
+	}
+
+	held, err := tx.ListChangesetRolloutWaves(ctx, store.ListChangesetRolloutWavesOpts{
+		BatchChangeID: batchChange.ID,
+		Held:          pointers.Ptr(true),
+		MaxWave:       pointers.Ptr(batchChange.RolloutWave),
+	})
+	if err != nil {
+		return errors.Wrap(err, "listing held changesets")
+	}
+
+	for _, w := range held {
+		if err := tx.SetChangesetRolloutWaveHeld(ctx, w.ChangesetID, false); err != nil {
+			return errors.Wrap(err, "releasing changeset")
+		}
+
+		// Changesets that are not completed are going to be processed by the
+		// reconciler anyway.
+		ch, ok := byID[w.ChangesetID]
+		if !ok || ch.ReconcilerState != btypes.ReconcilerStateCompleted {
+			continue
+		}
+		if err := tx.EnqueueChangeset(ctx, ch, global.DefaultReconcilerEnqueueState(), btypes.ReconcilerStateCompleted); err != nil {
+			return errors.Wrap(err, "enqueueing changeset")
+		}
+	}
+
+	return nil
+}
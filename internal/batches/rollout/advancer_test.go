@@ -0,0 +1,180 @@
+package rollout
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
+)
+
+func TestAdvancer(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("waits for the success criteria of the current wave", func(t *testing.T) {
+		f := newFixture(t, canaryPolicy(false))
+		f.holdRest(t)
+		f.publish(t, f.canary, btypes.ChangesetExternalStateOpen)
+
+		a := &advancer{store: f.store, logger: logtest.Scoped(t)}
+		if err := a.handleBatchChange(ctx, f.batchChange); err != nil {
+			t.Fatal(err)
+		}
+
+		f.assertBatchChange(t, 0, false, "")
+		f.assertHeld(t, f.rest.ID)
+		f.assertReconcilerState(t, f.rest, btypes.ReconcilerStateCompleted)
+	})
+
+	t.Run("advances and releases held changesets", func(t *testing.T) {
+		f := newFixture(t, canaryPolicy(false))
+		f.holdRest(t)
+		f.publish(t, f.canary, btypes.ChangesetExternalStateMerged)
+
+		a := &advancer{store: f.store, logger: logtest.Scoped(t)}
+		if err := a.handleBatchChange(ctx, f.batchChange); err != nil {
+			t.Fatal(err)
+		}
+
+		f.assertBatchChange(t, 1, false, "")
+		f.assertHeld(t)
+		f.assertReconcilerState(t, f.rest, btypes.ReconcilerStateQueued)
+	})
+
+	t.Run("pauses on failure", func(t *testing.T) {
+		f := newFixture(t, canaryPolicy(true))
+		f.holdRest(t)
+		f.publish(t, f.canary, btypes.ChangesetExternalStateClosed)
+
+		a := &advancer{store: f.store, logger: logtest.Scoped(t)}
+		if err := a.handleBatchChange(ctx, f.batchChange); err != nil {
+			t.Fatal(err)
+		}
+
+		f.assertBatchChange(t, 0, true, fmt.Sprintf("wave 1 failed: changeset %d was closed without being merged", f.canary.ID))
+		f.assertHeld(t, f.rest.ID)
+		f.assertReconcilerState(t, f.rest, btypes.ReconcilerStateCompleted)
+	})
+
+	t.Run("does not advance while paused", func(t *testing.T) {
+		f := newFixture(t, canaryPolicy(false))
+		f.holdRest(t)
+		f.publish(t, f.canary, btypes.ChangesetExternalStateMerged)
+		f.batchChange.RolloutPaused = true
+		f.updateBatchChange(t)
+
+		a := &advancer{store: f.store, logger: logtest.Scoped(t)}
+		if err := a.handleBatchChange(ctx, f.batchChange); err != nil {
+			t.Fatal(err)
+		}
+
+		f.assertBatchChange(t, 0, true, "")
+		f.assertHeld(t, f.rest.ID)
+	})
+
+	t.Run("resumes after the rollout is unpaused", func(t *testing.T) {
+		f := newFixture(t, canaryPolicy(true))
+		f.holdRest(t)
+		f.publish(t, f.canary, btypes.ChangesetExternalStateClosed)
+
+		a := &advancer{store: f.store, logger: logtest.Scoped(t)}
+		if err := a.handleBatchChange(ctx, f.batchChange); err != nil {
+			t.Fatal(err)
+		}
+		f.assertBatchChange(t, 0, true, fmt.Sprintf("wave 1 failed: changeset %d was closed without being merged", f.canary.ID))
+
+		// The canary changeset is reopened and merged, and the rollout is
+		// resumed in the same way SetBatchChangeRolloutPaused does.
+		f.publish(t, f.canary, btypes.ChangesetExternalStateMerged)
+		f.batchChange.RolloutPaused = false
+		f.batchChange.RolloutPauseReason = ""
+		f.updateBatchChange(t)
+
+		if err := a.handleBatchChange(ctx, f.batchChange); err != nil {
+			t.Fatal(err)
+		}
+
+		f.assertBatchChange(t, 1, false, "")
+		f.assertHeld(t)
+		f.assertReconcilerState(t, f.rest, btypes.ReconcilerStateQueued)
+	})
+
+	t.Run("batch change without rollout policy", func(t *testing.T) {
+		f := newFixture(t, nil)
+
+		a := &advancer{store: f.store, logger: logtest.Scoped(t)}
+		if err := a.handleBatchChange(ctx, f.batchChange); err != nil {
+			t.Fatal(err)
+		}
+
+		f.assertBatchChange(t, 0, false, "")
+		if waves := f.listWaves(t, nil); len(waves) != 0 {
+			t.Fatalf("unexpected rollout waves: %+v", waves)
+		}
+	})
+}
+
+// holdRest holds back the changeset outside of the canary repository, as the
+// reconciler does when it is about to publish it.
+func (f *fixture) holdRest(t *testing.T) {
+	t.Helper()
+
+	assertHold(t, f.ctx, f, f.rest, true)
+}
+
+// publish marks the changeset as published with the given external state.
+func (f *fixture) publish(t *testing.T, ch *btypes.Changeset, state btypes.ChangesetExternalState) {
+	t.Helper()
+
+	ch.PublicationState = btypes.ChangesetPublicationStatePublished
+	ch.ExternalState = state
+	if err := f.store.UpdateChangeset(f.ctx, ch); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func (f *fixture) assertBatchChange(t *testing.T, wantWave int32, wantPaused bool, wantReason string) {
+	t.Helper()
+
+	batchChange, err := f.store.GetBatchChange(f.ctx, store.GetBatchChangeOpts{ID: f.batchChange.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if batchChange.RolloutWave != wantWave {
+		t.Errorf("unexpected rollout wave. want=%d have=%d", wantWave, batchChange.RolloutWave)
+	}
+	if batchChange.RolloutPaused != wantPaused {
+		t.Errorf("unexpected rollout paused. want=%t have=%t", wantPaused, batchChange.RolloutPaused)
+	}
+	if batchChange.RolloutPauseReason != wantReason {
+		t.Errorf("unexpected rollout pause reason. want=%q have=%q", wantReason, batchChange.RolloutPauseReason)
+	}
+}
+
+func (f *fixture) assertHeld(t *testing.T, wantChangesetIDs ...int64) {
+	t.Helper()
+
+	var have []int64
+	for _, w := range f.listWaves(t, pointers.Ptr(true)) {
+		have = append(have, w.ChangesetID)
+	}
+	if fmt.Sprint(have) != fmt.Sprint(wantChangesetIDs) {
+		t.Errorf("unexpected held changesets. want=%v have=%v", wantChangesetIDs, have)
+	}
+}
+
+func (f *fixture) assertReconcilerState(t *testing.T, ch *btypes.Changeset, want btypes.ReconcilerState) {
+	t.Helper()
+
+	have, err := f.store.GetChangeset(f.ctx, store.GetChangesetOpts{ID: ch.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have.ReconcilerState != want {
+		t.Errorf("unexpected reconciler state of changeset %d. want=%s have=%s", ch.ID, want, have.ReconcilerState)
+	}
+}
@@ -0,0 +1,160 @@
+package rollout
+
+import (
+	"fmt"
+	"sort"
+
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+// changesetRepo is a changeset and the name of the repository it belongs to.
+type changesetRepo struct {
+	ID   int64
+	Repo string
+}
+
+// assignWaves assigns the given changesets to the waves of the policy.
+// Changesets in repositories matching the patterns of a wave are assigned to
+// the first such wave. The remaining changesets are then assigned, in order of
+// their repository names, to the percentage waves: once a percentage wave is
+// reached, that percentage of all changesets is published. Changesets that
+// don't end up in any wave are assigned to an implicit final wave, whose index
+// is the number of waves in the policy.
+//
+// The assignment only depends on the set of changesets, so it is stable across
+// calls.
+func assignWaves(policy *batcheslib.RolloutPolicy, changesets []changesetRepo) map[int64]int32 {
+	sorted := make([]changesetRepo, len(changesets))
+	copy(sorted, changesets)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Repo != sorted[j].Repo {
+			return sorted[i].Repo < sorted[j].Repo
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	waves := make(map[int64]int32, len(sorted))
+	for _, ch := range sorted {
+		for i := range policy.Waves {
+			if policy.Waves[i].MatchesRepository(ch.Repo) {
+				waves[ch.ID] = int32(i)
+				break
+			}
+		}
+	}
+
+	total := len(sorted)
+	for i, wave := range policy.Waves {
+		if wave.Percentage == 0 {
+			continue
+		}
+
+		// The number of changesets that should be published once this wave is
+		// reached, rounded up so that a small percentage of a small batch
+		// change still publishes at least one changeset.
+		target := (wave.Percentage*total + 99) / 100
+		assigned := 0
+		for _, w := range waves {
+			if w <= int32(i) {
+				assigned++
+			}
+		}
+
+		for _, ch := range sorted {
+			if assigned >= target {
+				break
+			}
+			if _, ok := waves[ch.ID]; ok {
+				continue
+			}
+			waves[ch.ID] = int32(i)
+			assigned++
+		}
+	}
+
+	for _, ch := range sorted {
+		if _, ok := waves[ch.ID]; !ok {
+			waves[ch.ID] = int32(len(policy.Waves))
+		}
+	}
+
+	return waves
+}
+
+// evaluation is the result of evaluating the current wave of a rollout.
+type evaluation struct {
+	// Advance is true if the wave is complete and the next wave can be
+	// published.
+	Advance bool
+	// Pause is true if the rollout should be paused because the wave failed.
+	Pause bool
+	// Reason describes why the rollout should or should not advance.
+	Reason string
+}
+
+// evaluateWave checks whether the changesets of the given wave meet its
+// success criteria. held contains the IDs of changesets that the reconciler
+// hasn't published yet because their wave hadn't been reached.
+func evaluateWave(policy *batcheslib.RolloutPolicy, wave int, changesets []*btypes.Changeset, held map[int64]struct{}) evaluation {
+	var failures []string
+	var published, merged, pendingChecks int
+	for _, ch := range changesets {
+		if _, ok := held[ch.ID]; ok {
+			return evaluation{Reason: fmt.Sprintf("changeset %d has not been published yet", ch.ID)}
+		}
+
+		switch ch.ReconcilerState {
+		case btypes.ReconcilerStateFailed:
+			failures = append(failures, fmt.Sprintf("changeset %d failed to publish", ch.ID))
+			continue
+		case btypes.ReconcilerStateCompleted:
+		default:
+			return evaluation{Reason: fmt.Sprintf("changeset %d is still being processed", ch.ID)}
+		}
+
+		if !ch.Published() {
+			continue
+		}
+		published++
+
+		switch ch.ExternalState {
+		case btypes.ChangesetExternalStateMerged:
+			merged++
+		case btypes.ChangesetExternalStateClosed:
+			failures = append(failures, fmt.Sprintf("changeset %d was closed without being merged", ch.ID))
+		case btypes.ChangesetExternalStateOpen, btypes.ChangesetExternalStateDraft:
+			switch ch.ExternalCheckState {
+			case btypes.ChangesetCheckStateFailed:
+				failures = append(failures, fmt.Sprintf("checks of changeset %d failed", ch.ID))
+			case btypes.ChangesetCheckStatePending:
+				pendingChecks++
+			}
+		}
+	}
+
+	if len(failures) > 0 && policy.PauseOnFailure {
+		return evaluation{Pause: true, Reason: fmt.Sprintf("wave %d failed: %s", wave+1, failures[0])}
+	}
+
+	// The implicit final wave has no success criteria.
+	if wave >= len(policy.Waves) || policy.Waves[wave].SuccessCriteria == nil {
+		return evaluation{Advance: true, Reason: "all changesets of the wave have been published"}
+	}
+	criteria := policy.Waves[wave].SuccessCriteria
+
+	if criteria.NoFailingChecks {
+		if len(failures) > 0 {
+			return evaluation{Reason: failures[0]}
+		}
+		if pendingChecks > 0 {
+			return evaluation{Reason: fmt.Sprintf("checks of %d changesets are still pending", pendingChecks)}
+		}
+	}
+
+	if criteria.MergedPercentage > 0 && merged*100 < criteria.MergedPercentage*published {
+		return evaluation{Reason: fmt.Sprintf("%d of %d published changesets are merged, %d%% required", merged, published, criteria.MergedPercentage)}
+	}
+
+	return evaluation{Advance: true, Reason: "the success criteria of the wave are met"}
+}
@@ -0,0 +1,176 @@
+package rollout
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+func TestAssignWaves(t *testing.T) {
+	changesets := []changesetRepo{
+		{ID: 1, Repo: "github.com/sourcegraph/e"},
+		{ID: 2, Repo: "github.com/sourcegraph/canary"},
+		{ID: 3, Repo: "github.com/sourcegraph/a"},
+		{ID: 4, Repo: "github.com/sourcegraph/d"},
+		{ID: 5, Repo: "github.com/sourcegraph/c"},
+		{ID: 6, Repo: "github.com/sourcegraph/b"},
+	}
+
+	for _, tc := range []struct {
+		name   string
+		policy *batcheslib.RolloutPolicy
+		want   map[int64]int32
+	}{
+		{
+			name: "repository patterns",
+			policy: &batcheslib.RolloutPolicy{Waves: []batcheslib.RolloutWave{
+				{Repositories: []string{"github.com/sourcegraph/canary"}},
+				{Repositories: []string{"github.com/sourcegraph/[ab]"}},
+			}},
+			want: map[int64]int32{1: 2, 2: 0, 3: 1, 4: 2, 5: 2, 6: 1},
+		},
+		{
+			name: "percentages",
+			policy: &batcheslib.RolloutPolicy{Waves: []batcheslib.RolloutWave{
+				{Percentage: 10},
+				{Percentage: 50},
+			}},
+			// 10% of 6 changesets is rounded up to one changeset, 50% are three.
+			want: map[int64]int32{3: 0, 6: 1, 5: 1, 4: 2, 1: 2, 2: 2},
+		},
+		{
+			name: "patterns and percentages",
+			policy: &batcheslib.RolloutPolicy{Waves: []batcheslib.RolloutWave{
+				{Repositories: []string{"github.com/sourcegraph/canary"}},
+				{Percentage: 50},
+				{Percentage: 100},
+			}},
+			want: map[int64]int32{2: 0, 3: 1, 6: 1, 5: 2, 4: 2, 1: 2},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			have := assignWaves(tc.policy, changesets)
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Fatalf("wrong waves (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEvaluateWave(t *testing.T) {
+	published := func(id int64, external btypes.ChangesetExternalState, checks btypes.ChangesetCheckState) *btypes.Changeset {
+		return &btypes.Changeset{
+			ID:                 id,
+			ReconcilerState:    btypes.ReconcilerStateCompleted,
+			PublicationState:   btypes.ChangesetPublicationStatePublished,
+			ExternalState:      external,
+			ExternalCheckState: checks,
+		}
+	}
+
+	policy := func(pauseOnFailure bool, criteria *batcheslib.RolloutSuccessCriteria) *batcheslib.RolloutPolicy {
+		return &batcheslib.RolloutPolicy{
+			PauseOnFailure: pauseOnFailure,
+			Waves:          []batcheslib.RolloutWave{{Percentage: 10, SuccessCriteria: criteria}},
+		}
+	}
+
+	for _, tc := range []struct {
+		name       string
+		policy     *batcheslib.RolloutPolicy
+		changesets []*btypes.Changeset
+		held       map[int64]struct{}
+		want       evaluation
+	}{
+		{
+			name:   "empty wave",
+			policy: policy(false, nil),
+			want:   evaluation{Advance: true, Reason: "all changesets of the wave have been published"},
+		},
+		{
+			name:       "held changeset",
+			policy:     policy(false, nil),
+			changesets: []*btypes.Changeset{published(1, btypes.ChangesetExternalStateOpen, btypes.ChangesetCheckStateUnknown)},
+			held:       map[int64]struct{}{1: {}},
+			want:       evaluation{Reason: "changeset 1 has not been published yet"},
+		},
+		{
+			name:       "changeset being processed",
+			policy:     policy(false, nil),
+			changesets: []*btypes.Changeset{{ID: 1, ReconcilerState: btypes.ReconcilerStateQueued}},
+			want:       evaluation{Reason: "changeset 1 is still being processed"},
+		},
+		{
+			name:       "published without criteria",
+			policy:     policy(false, nil),
+			changesets: []*btypes.Changeset{published(1, btypes.ChangesetExternalStateOpen, btypes.ChangesetCheckStateFailed)},
+			want:       evaluation{Advance: true, Reason: "all changesets of the wave have been published"},
+		},
+		{
+			name:       "failing checks pause",
+			policy:     policy(true, nil),
+			changesets: []*btypes.Changeset{published(1, btypes.ChangesetExternalStateOpen, btypes.ChangesetCheckStateFailed)},
+			want:       evaluation{Pause: true, Reason: "wave 1 failed: checks of changeset 1 failed"},
+		},
+		{
+			name:   "closed changeset pauses",
+			policy: policy(true, nil),
+			changesets: []*btypes.Changeset{
+				published(1, btypes.ChangesetExternalStateMerged, btypes.ChangesetCheckStatePassed),
+				published(2, btypes.ChangesetExternalStateClosed, btypes.ChangesetCheckStatePassed),
+			},
+			want: evaluation{Pause: true, Reason: "wave 1 failed: changeset 2 was closed without being merged"},
+		},
+		{
+			name:       "failed to publish pauses",
+			policy:     policy(true, nil),
+			changesets: []*btypes.Changeset{{ID: 1, ReconcilerState: btypes.ReconcilerStateFailed}},
+			want:       evaluation{Pause: true, Reason: "wave 1 failed: changeset 1 failed to publish"},
+		},
+		{
+			name:       "pending checks",
+			policy:     policy(false, &batcheslib.RolloutSuccessCriteria{NoFailingChecks: true}),
+			changesets: []*btypes.Changeset{published(1, btypes.ChangesetExternalStateOpen, btypes.ChangesetCheckStatePending)},
+			want:       evaluation{Reason: "checks of 1 changesets are still pending"},
+		},
+		{
+			name:   "no failing checks",
+			policy: policy(false, &batcheslib.RolloutSuccessCriteria{NoFailingChecks: true}),
+			changesets: []*btypes.Changeset{
+				published(1, btypes.ChangesetExternalStateOpen, btypes.ChangesetCheckStatePassed),
+				published(2, btypes.ChangesetExternalStateOpen, btypes.ChangesetCheckStateUnknown),
+			},
+			want: evaluation{Advance: true, Reason: "the success criteria of the wave are met"},
+		},
+		{
+			name:   "not enough merged",
+			policy: policy(false, &batcheslib.RolloutSuccessCriteria{MergedPercentage: 50}),
+			changesets: []*btypes.Changeset{
+				published(1, btypes.ChangesetExternalStateMerged, btypes.ChangesetCheckStatePassed),
+				published(2, btypes.ChangesetExternalStateOpen, btypes.ChangesetCheckStatePassed),
+				published(3, btypes.ChangesetExternalStateOpen, btypes.ChangesetCheckStatePassed),
+			},
+			want: evaluation{Reason: "1 of 3 published changesets are merged, 50% required"},
+		},
+		{
+			name:   "enough merged",
+			policy: policy(false, &batcheslib.RolloutSuccessCriteria{MergedPercentage: 50}),
+			changesets: []*btypes.Changeset{
+				published(1, btypes.ChangesetExternalStateMerged, btypes.ChangesetCheckStatePassed),
+				published(2, btypes.ChangesetExternalStateOpen, btypes.ChangesetCheckStatePassed),
+				{ID: 3, ReconcilerState: btypes.ReconcilerStateCompleted, PublicationState: btypes.ChangesetPublicationStateUnpublished},
+			},
+			want: evaluation{Advance: true, Reason: "the success criteria of the wave are met"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			have := evaluateWave(tc.policy, 0, tc.changesets, tc.held)
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Fatalf("wrong evaluation (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
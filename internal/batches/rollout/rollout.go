@@ -0,0 +1,142 @@
+// Package rollout implements staged rollouts of batch changes: the changesets
+// of a batch change with a rollout policy are published in waves, and the
+// next wave is only published once the changesets of the current wave meet
+// the success criteria of the wave.
+package rollout
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const pageSize = 100
+
+// Hold returns true if publishing the given changeset has to be held back
+// because the rollout of the batch change that owns it hasn't reached the
+// wave of the changeset yet, or because the rollout is paused. Held
+// changesets are recorded, so that they can be enqueued again once their wave
+// is reached.
+func Hold(ctx context.Context, tx *store.Store, ch *btypes.Changeset) (bool, error) {
+	if ch.OwnedByBatchChangeID == 0 {
+		return false, nil
+	}
+
+	batchChange, err := tx.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: ch.OwnedByBatchChangeID})
+	if err != nil {
+		return false, errors.Wrap(err, "loading batch change")
+	}
+	policy, err := loadPolicy(ctx, tx, batchChange)
+	if err != nil || policy == nil {
+		return false, err
+	}
+
+	waves, err := tx.ListChangesetRolloutWaves(ctx, store.ListChangesetRolloutWavesOpts{ChangesetIDs: []int64{ch.ID}})
+	if err != nil {
+		return false, errors.Wrap(err, "loading rollout wave")
+	}
+	if len(waves) == 0 {
+		if err := assignChangesets(ctx, tx, batchChange, policy); err != nil {
+			return false, err
+		}
+		if waves, err = tx.ListChangesetRolloutWaves(ctx, store.ListChangesetRolloutWavesOpts{ChangesetIDs: []int64{ch.ID}}); err != nil {
+			return false, errors.Wrap(err, "loading rollout wave")
+		}
+		if len(waves) == 0 {
+			return false, errors.Newf("changeset %d was not assigned to a rollout wave", ch.ID)
+		}
+	}
+
+	if !batchChange.RolloutPaused && waves[0].Wave <= batchChange.RolloutWave {
+		return false, nil
+	}
+
+	if !waves[0].Held {
+		if err := tx.SetChangesetRolloutWaveHeld(ctx, ch.ID, true); err != nil {
+			return false, errors.Wrap(err, "holding changeset")
+		}
+	}
+	return true, nil
+}
+
+// loadPolicy returns the rollout policy of the batch spec the batch change was
+// last applied with, or nil if it has none.
+func loadPolicy(ctx context.Context, s *store.Store, batchChange *btypes.BatchChange) (*batcheslib.RolloutPolicy, error) {
+	batchSpec, err := s.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: batchChange.BatchSpecID})
+	if err != nil {
+		return nil, errors.Wrap(err, "loading batch spec")
+	}
+	if batchSpec.Spec == nil || batchSpec.Spec.ChangesetTemplate == nil || batchSpec.Spec.ChangesetTemplate.Rollout == nil {
+		return nil, nil
+	}
+	return batchSpec.Spec.ChangesetTemplate.Rollout, nil
+}
+
+// listOwnedChangesets returns all changesets owned by the batch change.
+func listOwnedChangesets(ctx context.Context, s *store.Store, batchChange *btypes.BatchChange) (btypes.Changesets, error) {
+	opts := store.ListChangesetsOpts{
+		LimitOpts:            store.LimitOpts{Limit: pageSize},
+		BatchChangeID:        batchChange.ID,
+		OwnedByBatchChangeID: batchChange.ID,
+	}
+
+	var all btypes.Changesets
+	for {
+		changesets, next, err := s.ListChangesets(ctx, opts)
+		if err != nil {
+			return nil, errors.Wrap(err, "listing changesets")
+		}
+		all = append(all, changesets...)
+
+		if next == 0 {
+			return all, nil
+		}
+		opts.Cursor = next
+	}
+}
+
+// assignChangesets assigns the changesets of the batch change that don't have
+// a rollout wave yet to the waves of the policy.
+func assignChangesets(ctx context.Context, s *store.Store, batchChange *btypes.BatchChange, policy *batcheslib.RolloutPolicy) error {
+	changesets, err := listOwnedChangesets(ctx, s, batchChange)
+	if err != nil {
+		return err
+	}
+	return assignChangesetsFrom(ctx, s, batchChange, policy, changesets)
+}
+
+func assignChangesetsFrom(ctx context.Context, s *store.Store, batchChange *btypes.BatchChange, policy *batcheslib.RolloutPolicy, changesets btypes.Changesets) error {
+	if len(changesets) == 0 {
+		return nil
+	}
+
+	repos, err := s.Repos().GetReposSetByIDs(ctx, changesets.RepoIDs()...)
+	if err != nil {
+		return errors.Wrap(err, "loading repositories")
+	}
+
+	crs := make([]changesetRepo, 0, len(changesets))
+	for _, ch := range changesets {
+		var name api.RepoName
+		if repo, ok := repos[ch.RepoID]; ok {
+			name = repo.Name
+		}
+		crs = append(crs, changesetRepo{ID: ch.ID, Repo: string(name)})
+	}
+
+	assignments := assignWaves(policy, crs)
+	ws := make([]*btypes.ChangesetRolloutWave, 0, len(assignments))
+	for _, ch := range changesets {
+		ws = append(ws, &btypes.ChangesetRolloutWave{
+			ChangesetID:   ch.ID,
+			BatchChangeID: batchChange.ID,
+			Wave:          assignments[ch.ID],
+		})
+	}
+
+	return errors.Wrap(s.CreateChangesetRolloutWaves(ctx, ws...), "creating rollout waves")
+}
@@ -0,0 +1,185 @@
+package rollout
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/batches/store"
+	bt "github.com/sourcegraph/sourcegraph/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+func TestHold(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("changeset not owned by a batch change", func(t *testing.T) {
+		f := newFixture(t, canaryPolicy(false))
+
+		f.canary.OwnedByBatchChangeID = 0
+		assertHold(t, ctx, f, f.canary, false)
+	})
+
+	t.Run("batch change without rollout policy", func(t *testing.T) {
+		f := newFixture(t, nil)
+
+		assertHold(t, ctx, f, f.canary, false)
+		assertHold(t, ctx, f, f.rest, false)
+		if waves := f.listWaves(t, nil); len(waves) != 0 {
+			t.Fatalf("unexpected rollout waves: %+v", waves)
+		}
+	})
+
+	t.Run("holds changesets of later waves", func(t *testing.T) {
+		f := newFixture(t, canaryPolicy(false))
+
+		assertHold(t, ctx, f, f.canary, false)
+		assertHold(t, ctx, f, f.rest, true)
+		// Holding a changeset again keeps it held.
+		assertHold(t, ctx, f, f.rest, true)
+
+		waves := f.listWaves(t, nil)
+		if len(waves) != 2 {
+			t.Fatalf("unexpected number of rollout waves. want=%d have=%d", 2, len(waves))
+		}
+		for _, w := range waves {
+			wantWave, wantHeld := int32(0), false
+			if w.ChangesetID == f.rest.ID {
+				wantWave, wantHeld = 1, true
+			}
+			if w.Wave != wantWave || w.Held != wantHeld {
+				t.Errorf("unexpected rollout wave for changeset %d. want wave=%d held=%t, have wave=%d held=%t", w.ChangesetID, wantWave, wantHeld, w.Wave, w.Held)
+			}
+		}
+	})
+
+	t.Run("holds all changesets while paused", func(t *testing.T) {
+		f := newFixture(t, canaryPolicy(false))
+		f.batchChange.RolloutPaused = true
+		f.updateBatchChange(t)
+
+		assertHold(t, ctx, f, f.canary, true)
+		assertHold(t, ctx, f, f.rest, true)
+	})
+
+	t.Run("releases changesets once their wave is reached", func(t *testing.T) {
+		f := newFixture(t, canaryPolicy(false))
+
+		assertHold(t, ctx, f, f.rest, true)
+
+		f.batchChange.RolloutWave = 1
+		f.updateBatchChange(t)
+		assertHold(t, ctx, f, f.rest, false)
+	})
+}
+
+func assertHold(t *testing.T, ctx context.Context, f *fixture, ch *btypes.Changeset, want bool) {
+	t.Helper()
+
+	have, err := Hold(ctx, f.store, ch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have != want {
+		t.Fatalf("unexpected hold for changeset %d. want=%t have=%t", ch.ID, want, have)
+	}
+}
+
+// canaryPolicy returns a rollout policy that publishes the changeset in the
+// canary repository first, and the other changeset once the canary changeset
+// has been merged.
+func canaryPolicy(pauseOnFailure bool) func(repos []string) *batcheslib.RolloutPolicy {
+	return func(repos []string) *batcheslib.RolloutPolicy {
+		return &batcheslib.RolloutPolicy{
+			Waves: []batcheslib.RolloutWave{
+				{
+					Repositories:    []string{repos[0]},
+					SuccessCriteria: &batcheslib.RolloutSuccessCriteria{MergedPercentage: 100},
+				},
+			},
+			PauseOnFailure: pauseOnFailure,
+		}
+	}
+}
+
+// fixture is a batch change with a changeset in a canary repository and a
+// changeset in another repository.
+type fixture struct {
+	ctx         context.Context
+	store       *store.Store
+	batchChange *btypes.BatchChange
+	canary      *btypes.Changeset
+	rest        *btypes.Changeset
+}
+
+func newFixture(t *testing.T, policy func(repos []string) *batcheslib.RolloutPolicy) *fixture {
+	t.Helper()
+
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+
+	sqlDB := dbtest.NewDB(logger, t)
+	s := store.New(database.NewDBWith(logger, basestore.NewWithHandle(basestore.NewHandleWithTx(dbtest.NewTx(t, sqlDB), sql.TxOptions{}))), &observation.TestContext, nil)
+
+	user := bt.CreateTestUser(t, s.DatabaseDB(), true)
+	repos, _ := bt.CreateTestRepos(t, ctx, s.DatabaseDB(), 2)
+
+	batchSpec := &btypes.BatchSpec{
+		UserID:          user.ID,
+		NamespaceUserID: user.ID,
+		Spec: &batcheslib.BatchSpec{
+			Name:              "test-rollout",
+			ChangesetTemplate: &batcheslib.ChangesetTemplate{Branch: "branch-name"},
+		},
+	}
+	if policy != nil {
+		batchSpec.Spec.ChangesetTemplate.Rollout = policy([]string{string(repos[0].Name), string(repos[1].Name)})
+	}
+	if err := s.CreateBatchSpec(ctx, batchSpec); err != nil {
+		t.Fatal(err)
+	}
+	batchChange := bt.CreateBatchChange(t, ctx, s, "test-rollout", user.ID, batchSpec.ID)
+
+	createChangeset := func(repo int) *btypes.Changeset {
+		return bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
+			Repo:               repos[repo].ID,
+			BatchChanges:       []btypes.BatchChangeAssoc{{BatchChangeID: batchChange.ID}},
+			OwnedByBatchChange: batchChange.ID,
+			PublicationState:   btypes.ChangesetPublicationStateUnpublished,
+			ReconcilerState:    btypes.ReconcilerStateCompleted,
+		})
+	}
+
+	return &fixture{
+		ctx:         ctx,
+		store:       s,
+		batchChange: batchChange,
+		canary:      createChangeset(0),
+		rest:        createChangeset(1),
+	}
+}
+
+func (f *fixture) updateBatchChange(t *testing.T) {
+	t.Helper()
+
+	if err := f.store.UpdateBatchChange(f.ctx, f.batchChange); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func (f *fixture) listWaves(t *testing.T, held *bool) []*btypes.ChangesetRolloutWave {
+	t.Helper()
+
+	waves, err := f.store.ListChangesetRolloutWaves(f.ctx, store.ListChangesetRolloutWavesOpts{BatchChangeID: f.batchChange.ID, Held: held})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return waves
+}
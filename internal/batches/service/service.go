@@ -84,6 +84,11 @@ type operations struct {
 	getBatchChangeMatchingBatchSpec      *observation.Operation
 	getNewestBatchSpec                   *observation.Operation
 	moveBatchChange                      *observation.Operation
+	setBatchChangeAutoRebase             *observation.Operation
+	setBatchChangeRolloutPaused          *observation.Operation
+	createBatchSpecTemplate              *observation.Operation
+	deleteBatchSpecTemplate              *observation.Operation
+	createBatchSpecFromTemplate          *observation.Operation
 	closeBatchChange                     *observation.Operation
 	deleteBatchChange                    *observation.Operation
 	enqueueChangesetSync                 *observation.Operation
@@ -134,6 +139,11 @@ func newOperations(observationCtx *observation.Context) *operations {
 			getBatchChangeMatchingBatchSpec:      op("GetBatchChangeMatchingBatchSpec"),
 			getNewestBatchSpec:                   op("GetNewestBatchSpec"),
 			moveBatchChange:                      op("MoveBatchChange"),
+			setBatchChangeAutoRebase:             op("SetBatchChangeAutoRebase"),
+			setBatchChangeRolloutPaused:          op("SetBatchChangeRolloutPaused"),
+			createBatchSpecTemplate:              op("CreateBatchSpecTemplate"),
+			deleteBatchSpecTemplate:              op("DeleteBatchSpecTemplate"),
+			createBatchSpecFromTemplate:          op("CreateBatchSpecFromTemplate"),
 			closeBatchChange:                     op("CloseBatchChange"),
 			deleteBatchChange:                    op("DeleteBatchChange"),
 			enqueueChangesetSync:                 op("EnqueueChangesetSync"),
@@ -471,6 +481,10 @@ type CreateBatchSpecFromRawOpts struct {
 	NoCache          bool
 
 	BatchChange int64
+
+	// TemplateVersionID is the ID of the batch spec template version the raw
+	// spec was instantiated from, if any.
+	TemplateVersionID int64
 }
 
 // CreateBatchSpecFromRaw creates the BatchSpec.
@@ -499,6 +513,7 @@ func (s *Service) CreateBatchSpecFromRaw(ctx context.Context, opts CreateBatchSp
 	spec.UserID = a.UID
 
 	spec.BatchChangeID = opts.BatchChange
+	spec.TemplateVersionID = opts.TemplateVersionID
 
 	tx, err := s.store.Transact(ctx)
 	if err != nil {
@@ -1036,6 +1051,63 @@ func (s *Service) MoveBatchChange(ctx context.Context, opts MoveBatchChangeOpts)
 	return batchChange, tx.UpdateBatchChange(ctx, batchChange)
 }
 
+// SetBatchChangeAutoRebase enables or disables automatic rebasing of the
+// changesets of the BatchChange with the given ID that conflict with their
+// base branch.
+func (s *Service) SetBatchChangeAutoRebase(ctx context.Context, id int64, enabled bool) (batchChange *btypes.BatchChange, err error) {
+	ctx, _, endObservation := s.operations.setBatchChangeAutoRebase.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	batchChange, err = s.store.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: id})
+	if err != nil {
+		return nil, errors.Wrap(err, "getting batch change")
+	}
+
+	// 🚨 SECURITY: Only the Author of the batch change can change its settings.
+	// If the batch change belongs to an org namespace, org members will be able to access it if
+	// the `orgs.allMembersBatchChangesAdmin` setting is true.
+	if err := s.checkViewerCanAdminister(ctx, batchChange.NamespaceOrgID, batchChange.CreatorID, false); err != nil {
+		return nil, err
+	}
+
+	if batchChange.AutoRebase == enabled {
+		return batchChange, nil
+	}
+
+	batchChange.AutoRebase = enabled
+	return batchChange, s.store.UpdateBatchChange(ctx, batchChange)
+}
+
+// SetBatchChangeRolloutPaused pauses or resumes the rollout of the
+// BatchChange with the given ID. Resuming a rollout that was paused
+// automatically clears the reason it was paused for.
+func (s *Service) SetBatchChangeRolloutPaused(ctx context.Context, id int64, paused bool) (batchChange *btypes.BatchChange, err error) {
+	ctx, _, endObservation := s.operations.setBatchChangeRolloutPaused.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	batchChange, err = s.store.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: id})
+	if err != nil {
+		return nil, errors.Wrap(err, "getting batch change")
+	}
+
+	// 🚨 SECURITY: Only the Author of the batch change can change its settings.
+	// If the batch change belongs to an org namespace, org members will be able to access it if
+	// the `orgs.allMembersBatchChangesAdmin` setting is true.
+	if err := s.checkViewerCanAdminister(ctx, batchChange.NamespaceOrgID, batchChange.CreatorID, false); err != nil {
+		return nil, err
+	}
+
+	if batchChange.RolloutPaused == paused {
+		return batchChange, nil
+	}
+
+	batchChange.RolloutPaused = paused
+	if !paused {
+		batchChange.RolloutPauseReason = ""
+	}
+	return batchChange, s.store.UpdateBatchChange(ctx, batchChange)
+}
+
 // CloseBatchChange closes the BatchChange with the given ID if it has not been closed yet.
 func (s *Service) CloseBatchChange(ctx context.Context, id int64, closeChangesets bool) (batchChange *btypes.BatchChange, err error) {
 	ctx, _, endObservation := s.operations.closeBatchChange.With(ctx, &err, observation.Args{})
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	sgactor "github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type CreateBatchSpecTemplateOpts struct {
+	RawTemplate string
+
+	// NamespaceUserID and NamespaceOrgID are the namespace of the template. If
+	// neither is set, the template is global.
+	NamespaceUserID int32
+	NamespaceOrgID  int32
+}
+
+// CreateBatchSpecTemplate parses and validates the given raw template and
+// stores it as the first version of a new batch spec template. If a template
+// with the same name already exists in the namespace, the raw template is
+// stored as a new version of it instead, so that batch specs created from
+// previous versions keep referencing the version they were created from.
+func (s *Service) CreateBatchSpecTemplate(ctx context.Context, opts CreateBatchSpecTemplateOpts) (tmpl *btypes.BatchSpecTemplate, version *btypes.BatchSpecTemplateVersion, err error) {
+	ctx, _, endObservation := s.operations.createBatchSpecTemplate.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("namespaceUserID", int(opts.NamespaceUserID)),
+		attribute.Int("namespaceOrgID", int(opts.NamespaceOrgID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	// 🚨 SECURITY: Only site admins can manage global templates, everyone
+	// else needs access to the namespace.
+	if err := s.checkBatchSpecTemplateNamespaceAccess(ctx, opts.NamespaceUserID, opts.NamespaceOrgID, true); err != nil {
+		return nil, nil, err
+	}
+
+	version = &btypes.BatchSpecTemplateVersion{RawTemplate: opts.RawTemplate}
+	parsed, err := version.Template()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Actor is guaranteed to be set here, because the namespace access check
+	// above enforces it.
+	a := sgactor.FromContext(ctx)
+
+	tx, err := s.store.Transact(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	tmpl, err = tx.GetBatchSpecTemplate(ctx, store.GetBatchSpecTemplateOpts{
+		Name:            parsed.Name,
+		NamespaceUserID: opts.NamespaceUserID,
+		NamespaceOrgID:  opts.NamespaceOrgID,
+	})
+	switch {
+	case err == store.ErrNoResults:
+		tmpl = &btypes.BatchSpecTemplate{
+			Name:            parsed.Name,
+			Description:     parsed.Description,
+			NamespaceUserID: opts.NamespaceUserID,
+			NamespaceOrgID:  opts.NamespaceOrgID,
+			CreatorID:       a.UID,
+		}
+		if err := tx.CreateBatchSpecTemplate(ctx, tmpl); err != nil {
+			return nil, nil, err
+		}
+	case err != nil:
+		return nil, nil, err
+	default:
+		tmpl.Description = parsed.Description
+		if err := tx.UpdateBatchSpecTemplate(ctx, tmpl); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	version.TemplateID = tmpl.ID
+	version.CreatorID = a.UID
+	if err := tx.CreateBatchSpecTemplateVersion(ctx, version); err != nil {
+		return nil, nil, err
+	}
+
+	return tmpl, version, nil
+}
+
+// DeleteBatchSpecTemplate deletes the batch spec template with the given ID
+// and all of its versions.
+func (s *Service) DeleteBatchSpecTemplate(ctx context.Context, id int64) (err error) {
+	ctx, _, endObservation := s.operations.deleteBatchSpecTemplate.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("ID", int(id)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	tmpl, err := s.store.GetBatchSpecTemplate(ctx, store.GetBatchSpecTemplateOpts{ID: id})
+	if err != nil {
+		return errors.Wrap(err, "getting batch spec template")
+	}
+
+	// 🚨 SECURITY: Only site admins can manage global templates, everyone
+	// else needs access to the namespace.
+	if err := s.checkBatchSpecTemplateNamespaceAccess(ctx, tmpl.NamespaceUserID, tmpl.NamespaceOrgID, true); err != nil {
+		return err
+	}
+
+	return s.store.DeleteBatchSpecTemplate(ctx, id)
+}
+
+// CheckViewerCanAccessBatchSpecTemplate returns an error if the current user
+// can't view and instantiate the given template. Global templates can be
+// accessed by all users.
+func (s *Service) CheckViewerCanAccessBatchSpecTemplate(ctx context.Context, tmpl *btypes.BatchSpecTemplate) error {
+	return s.checkBatchSpecTemplateNamespaceAccess(ctx, tmpl.NamespaceUserID, tmpl.NamespaceOrgID, false)
+}
+
+func (s *Service) checkBatchSpecTemplateNamespaceAccess(ctx context.Context, namespaceUserID, namespaceOrgID int32, write bool) error {
+	if namespaceUserID != 0 || namespaceOrgID != 0 {
+		return s.CheckNamespaceAccess(ctx, namespaceUserID, namespaceOrgID)
+	}
+	if write {
+		return auth.CheckCurrentUserIsSiteAdmin(ctx, s.store.DatabaseDB())
+	}
+	if !sgactor.FromContext(ctx).IsAuthenticated() {
+		return auth.ErrNotAuthenticated
+	}
+	return nil
+}
+
+type CreateBatchSpecFromTemplateOpts struct {
+	TemplateID int64
+	// Version is the version of the template to instantiate. If it's not set,
+	// the latest version is used.
+	Version int32
+	Inputs  map[string]string
+
+	NamespaceUserID int32
+	NamespaceOrgID  int32
+
+	AllowIgnored     bool
+	AllowUnsupported bool
+	NoCache          bool
+
+	BatchChange int64
+}
+
+// CreateBatchSpecFromTemplate instantiates the given version of a batch spec
+// template with the given inputs and creates a BatchSpec for server-side
+// execution from the result, just like CreateBatchSpecFromRaw. The BatchSpec
+// records the template version it was created from.
+func (s *Service) CreateBatchSpecFromTemplate(ctx context.Context, opts CreateBatchSpecFromTemplateOpts) (spec *btypes.BatchSpec, err error) {
+	ctx, _, endObservation := s.operations.createBatchSpecFromTemplate.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("templateID", int(opts.TemplateID)),
+		attribute.Int("version", int(opts.Version)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	tmpl, err := s.store.GetBatchSpecTemplate(ctx, store.GetBatchSpecTemplateOpts{ID: opts.TemplateID})
+	if err != nil {
+		return nil, errors.Wrap(err, "getting batch spec template")
+	}
+
+	// 🚨 SECURITY: Check whether the current user has access to the template.
+	// CreateBatchSpecFromRaw checks access to the namespace of the batch spec.
+	if err := s.CheckViewerCanAccessBatchSpecTemplate(ctx, tmpl); err != nil {
+		return nil, err
+	}
+
+	version, err := s.store.GetBatchSpecTemplateVersion(ctx, store.GetBatchSpecTemplateVersionOpts{
+		TemplateID: tmpl.ID,
+		Version:    opts.Version,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "getting batch spec template version")
+	}
+
+	parsed, err := version.Template()
+	if err != nil {
+		return nil, err
+	}
+	rawSpec, err := parsed.Instantiate(opts.Inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.CreateBatchSpecFromRaw(ctx, CreateBatchSpecFromRawOpts{
+		RawSpec:           rawSpec,
+		NamespaceUserID:   opts.NamespaceUserID,
+		NamespaceOrgID:    opts.NamespaceOrgID,
+		AllowIgnored:      opts.AllowIgnored,
+		AllowUnsupported:  opts.AllowUnsupported,
+		NoCache:           opts.NoCache,
+		BatchChange:       opts.BatchChange,
+		TemplateVersionID: version.ID,
+	})
+}
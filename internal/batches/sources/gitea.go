@@ -0,0 +1,432 @@
+package sources
+
+import (
+	"context"
+	"strconv"
+
+	gtcs "github.com/sourcegraph/sourcegraph/internal/batches/sources/gitea"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+type GiteaSource struct {
+	client gitea.Client
+}
+
+var (
+	_ ForkableChangesetSource = GiteaSource{}
+	_ DraftChangesetSource    = GiteaSource{}
+)
+
+func NewGiteaSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*GiteaSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.GiteaConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d", svc.ID)
+	}
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	opts := httpClientCertificateOptions(nil, c.Certificate)
+
+	cli, err := cf.Doer(opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating external client")
+	}
+
+	client, err := gitea.NewClient(svc.URN(), &c, cli)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating Gitea client")
+	}
+
+	return &GiteaSource{client: client}, nil
+}
+
+// GitserverPushConfig returns an authenticated push config used for pushing
+// commits to the code host.
+func (s GiteaSource) GitserverPushConfig(repo *types.Repo) (*protocol.PushConfig, error) {
+	return GitserverPushConfig(repo, s.client.Authenticator())
+}
+
+// WithAuthenticator returns a copy of the original Source configured to use the
+// given authenticator, provided that authenticator type is supported by the
+// code host.
+func (s GiteaSource) WithAuthenticator(a auth.Authenticator) (ChangesetSource, error) {
+	switch a.(type) {
+	case *auth.OAuthBearerToken,
+		*auth.OAuthBearerTokenWithSSH,
+		*auth.BasicAuth,
+		*auth.BasicAuthWithSSH:
+		break
+
+	default:
+		return nil, newUnsupportedAuthenticatorError("GiteaSource", a)
+	}
+
+	client, err := s.client.WithAuthenticator(a)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GiteaSource{client: client}, nil
+}
+
+// ValidateAuthenticator validates the currently set authenticator is usable.
+// Returns an error, when validating the Authenticator yielded an error.
+func (s GiteaSource) ValidateAuthenticator(ctx context.Context) error {
+	_, err := s.client.CurrentUser(ctx)
+	return err
+}
+
+// LoadChangeset loads the given Changeset from the source and updates it. If
+// the Changeset could not be found on the source, a ChangesetNotFoundError is
+// returned.
+func (s GiteaSource) LoadChangeset(ctx context.Context, cs *Changeset) error {
+	repo := cs.TargetRepo.Metadata.(*gitea.Repo)
+	index, err := strconv.ParseInt(cs.ExternalID, 10, 64)
+	if err != nil {
+		return errors.Wrapf(err, "converting external ID %q", cs.ExternalID)
+	}
+
+	pr, err := s.client.GetPullRequest(ctx, repo.Owner.Login, repo.Name, index)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return ChangesetNotFoundError{Changeset: cs}
+		}
+		return errors.Wrap(err, "getting pull request")
+	}
+
+	return s.setChangesetMetadata(ctx, repo, pr, cs)
+}
+
+// CreateChangeset will create the Changeset on the source. If it already
+// exists, *Changeset will be populated and the return value will be true.
+func (s GiteaSource) CreateChangeset(ctx context.Context, cs *Changeset) (bool, error) {
+	targetRepo := cs.TargetRepo.Metadata.(*gitea.Repo)
+
+	exists := false
+	pr, err := s.client.CreatePullRequest(ctx, targetRepo.Owner.Login, targetRepo.Name, s.changesetToPullRequestInput(cs))
+	if err != nil {
+		// Gitea responds with 409 Conflict if there is already an open pull
+		// request for the same head and base branches.
+		if !gitea.IsConflict(err) {
+			return false, errors.Wrap(err, "creating pull request")
+		}
+
+		pr, err = s.findOpenPullRequest(ctx, cs)
+		if err != nil {
+			return false, errors.Wrap(err, "fetching existing pull request")
+		}
+		exists = true
+	}
+
+	if err := s.setChangesetMetadata(ctx, targetRepo, pr, cs); err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// CreateDraftChangeset creates the Changeset on the source as a work in
+// progress. Gitea has no native draft pull requests, so this is done by
+// prefixing the title with "WIP:". If the pull request already exists,
+// *Changeset will be populated and the return value will be true.
+func (s GiteaSource) CreateDraftChangeset(ctx context.Context, cs *Changeset) (bool, error) {
+	cs.Title = gitea.SetWIPPrefix(cs.Title)
+
+	exists, err := s.CreateChangeset(ctx, cs)
+	if err != nil {
+		return exists, err
+	}
+
+	// If it already exists, but is not a draft, we need to update the title.
+	if pr := cs.Metadata.(*gtcs.AnnotatedPullRequest); exists && !pr.Draft() {
+		if err := s.UpdateChangeset(ctx, cs); err != nil {
+			return exists, err
+		}
+	}
+	return exists, nil
+}
+
+// UndraftChangeset marks the pull request as ready for review by removing the
+// work in progress prefix from its title.
+func (s GiteaSource) UndraftChangeset(ctx context.Context, cs *Changeset) error {
+	repo := cs.TargetRepo.Metadata.(*gitea.Repo)
+	pr := cs.Metadata.(*gtcs.AnnotatedPullRequest)
+
+	title := gitea.TrimWIPPrefix(cs.Title)
+	updated, err := s.client.EditPullRequest(ctx, repo.Owner.Login, repo.Name, pr.Index, gitea.EditPullRequestInput{
+		Title: &title,
+	})
+	if err != nil {
+		return errors.Wrap(err, "updating pull request")
+	}
+	cs.Title = title
+
+	return s.setChangesetMetadata(ctx, repo, updated, cs)
+}
+
+// CloseChangeset will close the Changeset on the source, where "close"
+// means the appropriate final state on the codehost (e.g. "declined" on
+// Bitbucket Server).
+func (s GiteaSource) CloseChangeset(ctx context.Context, cs *Changeset) error {
+	return s.setState(ctx, cs, gitea.PullRequestStateClosed)
+}
+
+// UpdateChangeset can update Changesets.
+func (s GiteaSource) UpdateChangeset(ctx context.Context, cs *Changeset) error {
+	repo := cs.TargetRepo.Metadata.(*gitea.Repo)
+	pr := cs.Metadata.(*gtcs.AnnotatedPullRequest)
+
+	// Avoid accidentally undrafting the changeset by retaining the work in
+	// progress prefix if the pull request is currently a draft.
+	title := cs.Title
+	if pr.Draft() {
+		title = gitea.SetWIPPrefix(title)
+	}
+	base := gitdomain.AbbreviateRef(cs.BaseRef)
+
+	updated, err := s.client.EditPullRequest(ctx, repo.Owner.Login, repo.Name, pr.Index, gitea.EditPullRequestInput{
+		Title: &title,
+		Body:  &cs.Body,
+		Base:  &base,
+	})
+	if err != nil {
+		return errors.Wrap(err, "updating pull request")
+	}
+
+	return s.setChangesetMetadata(ctx, repo, updated, cs)
+}
+
+// ReopenChangeset will reopen the Changeset on the source, if it's closed.
+// If not, it's a noop.
+func (s GiteaSource) ReopenChangeset(ctx context.Context, cs *Changeset) error {
+	return s.setState(ctx, cs, gitea.PullRequestStateOpen)
+}
+
+// CreateComment posts a comment on the Changeset.
+func (s GiteaSource) CreateComment(ctx context.Context, cs *Changeset, comment string) error {
+	repo := cs.TargetRepo.Metadata.(*gitea.Repo)
+	pr := cs.Metadata.(*gtcs.AnnotatedPullRequest)
+
+	_, err := s.client.CreatePullRequestComment(ctx, repo.Owner.Login, repo.Name, pr.Index, comment)
+	return err
+}
+
+// MergeChangeset merges a Changeset on the code host, if in a mergeable state.
+// If squash is true, and the code host supports squash merges, the source
+// must attempt a squash merge. Otherwise, it is expected to perform a regular
+// merge. If the changeset cannot be merged, because it is in an unmergeable
+// state, ChangesetNotMergeableError must be returned.
+func (s GiteaSource) MergeChangeset(ctx context.Context, cs *Changeset, squash bool) error {
+	repo := cs.TargetRepo.Metadata.(*gitea.Repo)
+	pr := cs.Metadata.(*gtcs.AnnotatedPullRequest)
+
+	style := gitea.MergeStyleMerge
+	if squash {
+		style = gitea.MergeStyleSquash
+	}
+
+	err := s.client.MergePullRequest(ctx, repo.Owner.Login, repo.Name, pr.Index, gitea.MergePullRequestInput{
+		Do:                     style,
+		DeleteBranchAfterMerge: conf.Get().BatchChangesAutoDeleteBranch,
+	})
+	if err != nil {
+		if gitea.IsNotMergeable(err) {
+			return ChangesetNotMergeableError{ErrorMsg: err.Error()}
+		}
+		return errors.Wrap(err, "merging pull request")
+	}
+
+	// The merge endpoint doesn't return the pull request, so we have to
+	// reload it.
+	updated, err := s.client.GetPullRequest(ctx, repo.Owner.Login, repo.Name, pr.Index)
+	if err != nil {
+		return errors.Wrap(err, "getting pull request")
+	}
+
+	return s.setChangesetMetadata(ctx, repo, updated, cs)
+}
+
+// GetFork returns a repo pointing to a fork of the target repo, ensuring that the fork
+// exists and creating it if it doesn't. If namespace is not provided, the fork will be in
+// the currently authenticated user's namespace. If name is not provided, the fork will be
+// named with the default Sourcegraph convention: "${original-namespace}-${original-name}"
+func (s GiteaSource) GetFork(ctx context.Context, targetRepo *types.Repo, ns, n *string) (*types.Repo, error) {
+	tr := targetRepo.Metadata.(*gitea.Repo)
+
+	var namespace string
+	if ns != nil {
+		namespace = *ns
+	} else {
+		user, err := s.client.CurrentUser(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "getting the current user")
+		}
+		namespace = user.Login
+	}
+
+	var name string
+	if n != nil {
+		name = *n
+	} else {
+		name = DefaultForkName(tr.Owner.Login, tr.Name)
+	}
+
+	// Figure out if we already have a fork of the repo in the given namespace.
+	if fork, err := s.client.GetRepo(ctx, namespace, name); err == nil {
+		return s.checkAndCopy(targetRepo, fork)
+	} else if !errcode.IsNotFound(err) {
+		return nil, errors.Wrap(err, "checking for fork existence")
+	}
+
+	// Gitea forks into the authenticated user's namespace unless an
+	// organization is given.
+	input := gitea.ForkRepoInput{Name: &name}
+	if ns != nil {
+		input.Organization = ns
+	}
+
+	fork, err := s.client.ForkRepo(ctx, tr.Owner.Login, tr.Name, input)
+	if err != nil {
+		return nil, errors.Wrap(err, "forking repository")
+	}
+
+	return s.checkAndCopy(targetRepo, fork)
+}
+
+func (s GiteaSource) BuildCommitOpts(repo *types.Repo, _ *btypes.Changeset, spec *btypes.ChangesetSpec, pushOpts *protocol.PushConfig) protocol.CreateCommitFromPatchRequest {
+	return BuildCommitOptsCommon(repo, spec, pushOpts)
+}
+
+func (s GiteaSource) checkAndCopy(targetRepo *types.Repo, fork *gitea.Repo) (*types.Repo, error) {
+	tr := targetRepo.Metadata.(*gitea.Repo)
+
+	if fork.Parent == nil {
+		return nil, errors.New("repo is not a fork")
+	} else if fork.Parent.ID != tr.ID {
+		return nil, errors.New("repo was not forked from the given parent")
+	}
+
+	// Now we make a copy of targetRepo, but with its sources and metadata updated to
+	// point to the fork
+	forkRepo, err := CopyRepoAsFork(targetRepo, fork, tr.FullName, fork.FullName)
+	if err != nil {
+		return nil, errors.Wrap(err, "updating target repo sources and metadata")
+	}
+
+	return forkRepo, nil
+}
+
+func (s GiteaSource) setState(ctx context.Context, cs *Changeset, state gitea.PullRequestState) error {
+	repo := cs.TargetRepo.Metadata.(*gitea.Repo)
+	pr := cs.Metadata.(*gtcs.AnnotatedPullRequest)
+
+	updated, err := s.client.EditPullRequest(ctx, repo.Owner.Login, repo.Name, pr.Index, gitea.EditPullRequestInput{
+		State: &state,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "setting pull request state to %q", state)
+	}
+
+	return s.setChangesetMetadata(ctx, repo, updated, cs)
+}
+
+// findOpenPullRequest returns the open pull request for the head and base
+// branches of the given changeset.
+func (s GiteaSource) findOpenPullRequest(ctx context.Context, cs *Changeset) (*gitea.PullRequest, error) {
+	targetRepo := cs.TargetRepo.Metadata.(*gitea.Repo)
+	remoteRepo := cs.RemoteRepo.Metadata.(*gitea.Repo)
+	head := gitdomain.AbbreviateRef(cs.HeadRef)
+	base := gitdomain.AbbreviateRef(cs.BaseRef)
+
+	for page, hasNextPage := 1, true; hasNextPage; page++ {
+		var prs []*gitea.PullRequest
+		var err error
+		prs, hasNextPage, err = s.client.ListOpenPullRequests(ctx, targetRepo.Owner.Login, targetRepo.Name, page)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pr := range prs {
+			if pr.Head == nil || pr.Base == nil {
+				continue
+			}
+			if pr.Head.Ref == head && pr.Base.Ref == base && pr.Head.RepoID == remoteRepo.ID {
+				return pr, nil
+			}
+		}
+	}
+
+	return nil, errors.Errorf("no open pull request found for %q", head)
+}
+
+func (s GiteaSource) annotatePullRequest(ctx context.Context, repo *gitea.Repo, pr *gitea.PullRequest) (*gtcs.AnnotatedPullRequest, error) {
+	reviews, err := s.client.ListPullRequestReviews(ctx, repo.Owner.Login, repo.Name, pr.Index)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting pull request reviews")
+	}
+
+	apr := &gtcs.AnnotatedPullRequest{
+		PullRequest: pr,
+		Reviews:     reviews,
+	}
+
+	// Statuses have to be looked up by the head commit, since the head branch
+	// may live in a fork.
+	if pr.Head != nil && pr.Head.Sha != "" {
+		status, err := s.client.GetCombinedStatus(ctx, repo.Owner.Login, repo.Name, pr.Head.Sha)
+		if err != nil {
+			return nil, errors.Wrap(err, "getting combined commit status")
+		}
+		apr.Status = status
+	}
+
+	return apr, nil
+}
+
+func (s GiteaSource) setChangesetMetadata(ctx context.Context, repo *gitea.Repo, pr *gitea.PullRequest, cs *Changeset) error {
+	apr, err := s.annotatePullRequest(ctx, repo, pr)
+	if err != nil {
+		return errors.Wrap(err, "annotating pull request")
+	}
+
+	if err := cs.SetMetadata(apr); err != nil {
+		return errors.Wrap(err, "setting changeset metadata")
+	}
+
+	return nil
+}
+
+func (s GiteaSource) changesetToPullRequestInput(cs *Changeset) gitea.CreatePullRequestInput {
+	head := gitdomain.AbbreviateRef(cs.HeadRef)
+
+	// If we're forking, then the head branch has to be qualified with the
+	// owner of the fork.
+	if cs.RemoteRepo != cs.TargetRepo {
+		fork := cs.RemoteRepo.Metadata.(*gitea.Repo)
+		head = fork.Owner.Login + ":" + head
+	}
+
+	return gitea.CreatePullRequestInput{
+		Head:  head,
+		Base:  gitdomain.AbbreviateRef(cs.BaseRef),
+		Title: cs.Title,
+		Body:  cs.Body,
+	}
+}
@@ -0,0 +1,12 @@
+package gitea
+
+import "github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+
+// AnnotatedPullRequest adds metadata we need that lives outside the main
+// PullRequest type returned by the Gitea API alongside the pull request. This
+// type is used as the primary metadata type for Gitea changesets.
+type AnnotatedPullRequest struct {
+	*gitea.PullRequest
+	Reviews []*gitea.PullRequestReview
+	Status  *gitea.CombinedStatus
+}
@@ -0,0 +1,735 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	gtcs "github.com/sourcegraph/sourcegraph/internal/batches/sources/gitea"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestNewGiteaSource(t *testing.T) {
+	t.Run("invalid", func(t *testing.T) {
+		for name, input := range map[string]string{
+			"invalid JSON":   "invalid JSON",
+			"invalid schema": `{"token": ["not a string"]}`,
+			"bad URL":        `{"url": "http://[::1]:namedport"}`,
+		} {
+			t.Run(name, func(t *testing.T) {
+				ctx := context.Background()
+				s, err := NewGiteaSource(ctx, &types.ExternalService{
+					Config: extsvc.NewUnencryptedConfig(input),
+				}, nil)
+				assert.Nil(t, s)
+				assert.NotNil(t, err)
+			})
+		}
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		ctx := context.Background()
+		s, err := NewGiteaSource(ctx, &types.ExternalService{
+			Config: extsvc.NewUnencryptedConfig(`{"url": "https://gitea.example.com", "token": "abc"}`),
+		}, nil)
+		assert.NotNil(t, s)
+		assert.Nil(t, err)
+	})
+}
+
+func TestGiteaSource_GitserverPushConfig(t *testing.T) {
+	au := auth.OAuthBearerToken{Token: "token"}
+	s, client := mockGiteaSource()
+	client.AuthenticatorFunc.SetDefaultReturn(&au)
+
+	repo := &types.Repo{
+		ExternalRepo: api.ExternalRepoSpec{
+			ServiceType: extsvc.TypeGitea,
+		},
+		Metadata: &gitea.Repo{
+			CloneURL: "https://gitea.example.com/org/repo.git",
+		},
+		Sources: map[string]*types.SourceInfo{
+			"1": {
+				ID:       "extsvc:gitea:1",
+				CloneURL: "https://gitea.example.com/org/repo.git",
+			},
+		},
+	}
+
+	pushConfig, err := s.GitserverPushConfig(repo)
+	assert.Nil(t, err)
+	assert.NotNil(t, pushConfig)
+	assert.Equal(t, "https://token@gitea.example.com/org/repo.git", pushConfig.RemoteURL)
+}
+
+func TestGiteaSource_WithAuthenticator(t *testing.T) {
+	t.Run("unsupported types", func(t *testing.T) {
+		s, _ := mockGiteaSource()
+
+		for _, au := range []auth.Authenticator{
+			&auth.OAuthClient{},
+		} {
+			t.Run(fmt.Sprintf("%T", au), func(t *testing.T) {
+				newSource, err := s.WithAuthenticator(au)
+				assert.Nil(t, newSource)
+				assert.NotNil(t, err)
+				assert.ErrorAs(t, err, &UnsupportedAuthenticatorError{})
+			})
+		}
+	})
+
+	t.Run("supported types", func(t *testing.T) {
+		for _, au := range []auth.Authenticator{
+			&auth.OAuthBearerToken{},
+			&auth.OAuthBearerTokenWithSSH{},
+			&auth.BasicAuth{},
+			&auth.BasicAuthWithSSH{},
+		} {
+			t.Run(fmt.Sprintf("%T", au), func(t *testing.T) {
+				newClient := NewStrictMockGiteaClient()
+
+				s, client := mockGiteaSource()
+				client.WithAuthenticatorFunc.SetDefaultHook(func(a auth.Authenticator) (gitea.Client, error) {
+					assert.Same(t, au, a)
+					return newClient, nil
+				})
+
+				newSource, err := s.WithAuthenticator(au)
+				assert.Nil(t, err)
+				assert.Same(t, newClient, newSource.(*GiteaSource).client)
+			})
+		}
+	})
+}
+
+func TestGiteaSource_ValidateAuthenticator(t *testing.T) {
+	ctx := context.Background()
+
+	for name, want := range map[string]error{
+		"nil":   nil,
+		"error": errors.New("error"),
+	} {
+		t.Run(name, func(t *testing.T) {
+			s, client := mockGiteaSource()
+			client.CurrentUserFunc.SetDefaultReturn(&gitea.User{Login: "user"}, want)
+
+			assert.Equal(t, want, s.ValidateAuthenticator(ctx))
+		})
+	}
+}
+
+func TestGiteaSource_LoadChangeset(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid external ID", func(t *testing.T) {
+		s, _ := mockGiteaSource()
+
+		cs, _, _ := mockGiteaChangeset()
+		cs.ExternalID = "not a number"
+
+		err := s.LoadChangeset(ctx, cs)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("error getting pull request", func(t *testing.T) {
+		cs, _, _ := mockGiteaChangeset()
+		s, client := mockGiteaSource()
+		want := errors.New("error")
+		client.GetPullRequestFunc.SetDefaultHook(func(ctx context.Context, owner, name string, index int64) (*gitea.PullRequest, error) {
+			assert.Equal(t, "org", owner)
+			assert.Equal(t, "repo", name)
+			assert.EqualValues(t, 42, index)
+			return nil, want
+		})
+
+		err := s.LoadChangeset(ctx, cs)
+		assert.NotNil(t, err)
+		assert.ErrorIs(t, err, want)
+	})
+
+	t.Run("pull request not found", func(t *testing.T) {
+		cs, _, _ := mockGiteaChangeset()
+		s, client := mockGiteaSource()
+		client.GetPullRequestFunc.SetDefaultReturn(nil, &notFoundError{})
+
+		err := s.LoadChangeset(ctx, cs)
+		assert.NotNil(t, err)
+		target := ChangesetNotFoundError{}
+		assert.ErrorAs(t, err, &target)
+		assert.Same(t, target.Changeset, cs)
+	})
+
+	t.Run("error setting changeset metadata", func(t *testing.T) {
+		cs, _, gtRepo := mockGiteaChangeset()
+		s, client := mockGiteaSource()
+		want := mockGiteaAnnotatePullRequestError(client)
+
+		client.GetPullRequestFunc.SetDefaultReturn(mockGiteaPullRequest(gtRepo), nil)
+
+		err := s.LoadChangeset(ctx, cs)
+		assert.NotNil(t, err)
+		assert.ErrorIs(t, err, want)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		cs, _, gtRepo := mockGiteaChangeset()
+		s, client := mockGiteaSource()
+		mockGiteaAnnotatePullRequestSuccess(client)
+
+		pr := mockGiteaPullRequest(gtRepo)
+		client.GetPullRequestFunc.SetDefaultReturn(pr, nil)
+
+		err := s.LoadChangeset(ctx, cs)
+		assert.Nil(t, err)
+		assertGiteaChangesetMatchesPullRequest(t, cs, pr)
+	})
+}
+
+func TestGiteaSource_CreateChangeset(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("error creating pull request", func(t *testing.T) {
+		cs, _, _ := mockGiteaChangeset()
+		s, client := mockGiteaSource()
+
+		want := errors.New("error")
+		client.CreatePullRequestFunc.SetDefaultReturn(nil, want)
+
+		exists, err := s.CreateChangeset(ctx, cs)
+		assert.False(t, exists)
+		assert.NotNil(t, err)
+		assert.ErrorIs(t, err, want)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		cs, _, gtRepo := mockGiteaChangeset()
+		s, client := mockGiteaSource()
+		mockGiteaAnnotatePullRequestSuccess(client)
+
+		pr := mockGiteaPullRequest(gtRepo)
+		client.CreatePullRequestFunc.SetDefaultHook(func(ctx context.Context, owner, name string, input gitea.CreatePullRequestInput) (*gitea.PullRequest, error) {
+			assert.Equal(t, "org", owner)
+			assert.Equal(t, "repo", name)
+			assert.Equal(t, gitea.CreatePullRequestInput{
+				Head:  "branch",
+				Base:  "main",
+				Title: cs.Title,
+				Body:  cs.Body,
+			}, input)
+			return pr, nil
+		})
+
+		exists, err := s.CreateChangeset(ctx, cs)
+		assert.False(t, exists)
+		assert.Nil(t, err)
+		assertGiteaChangesetMatchesPullRequest(t, cs, pr)
+	})
+
+	t.Run("success with fork", func(t *testing.T) {
+		cs, _, gtRepo := mockGiteaChangeset()
+		s, client := mockGiteaSource()
+		mockGiteaAnnotatePullRequestSuccess(client)
+
+		cs.RemoteRepo = &types.Repo{
+			Metadata: &gitea.Repo{
+				ID:       2,
+				Name:     "repo",
+				FullName: "fork/repo",
+				Owner:    &gitea.User{Login: "fork"},
+			},
+		}
+
+		pr := mockGiteaPullRequest(gtRepo)
+		client.CreatePullRequestFunc.SetDefaultHook(func(ctx context.Context, owner, name string, input gitea.CreatePullRequestInput) (*gitea.PullRequest, error) {
+			assert.Equal(t, "org", owner)
+			assert.Equal(t, "fork:branch", input.Head)
+			return pr, nil
+		})
+
+		exists, err := s.CreateChangeset(ctx, cs)
+		assert.False(t, exists)
+		assert.Nil(t, err)
+	})
+
+	t.Run("already exists", func(t *testing.T) {
+		// Gitea responds with a 409 when a pull request already exists, which
+		// we can only produce through a real client.
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusConflict)
+		}))
+		t.Cleanup(srv.Close)
+
+		httpClient, err := gitea.NewClient("gitea", &schema.GiteaConnection{Url: srv.URL}, srv.Client())
+		require.NoError(t, err)
+		_, conflict := httpClient.CreatePullRequest(ctx, "org", "repo", gitea.CreatePullRequestInput{})
+		require.True(t, gitea.IsConflict(conflict))
+
+		cs, _, gtRepo := mockGiteaChangeset()
+		s, client := mockGiteaSource()
+		mockGiteaAnnotatePullRequestSuccess(client)
+
+		pr := mockGiteaPullRequest(gtRepo)
+		client.CreatePullRequestFunc.SetDefaultReturn(nil, conflict)
+		client.ListOpenPullRequestsFunc.SetDefaultHook(func(ctx context.Context, owner, name string, page int) ([]*gitea.PullRequest, bool, error) {
+			assert.Equal(t, 1, page)
+			other := mockGiteaPullRequest(gtRepo)
+			other.Index = 1
+			other.Head.Ref = "other-branch"
+			return []*gitea.PullRequest{other, pr}, false, nil
+		})
+
+		exists, err := s.CreateChangeset(ctx, cs)
+		assert.True(t, exists)
+		assert.Nil(t, err)
+		assertGiteaChangesetMatchesPullRequest(t, cs, pr)
+	})
+}
+
+func TestGiteaSource_CreateDraftChangeset(t *testing.T) {
+	ctx := context.Background()
+
+	cs, _, gtRepo := mockGiteaChangeset()
+	s, client := mockGiteaSource()
+	mockGiteaAnnotatePullRequestSuccess(client)
+
+	client.CreatePullRequestFunc.SetDefaultHook(func(ctx context.Context, owner, name string, input gitea.CreatePullRequestInput) (*gitea.PullRequest, error) {
+		assert.Equal(t, "WIP: title", input.Title)
+		pr := mockGiteaPullRequest(gtRepo)
+		pr.Title = input.Title
+		return pr, nil
+	})
+
+	exists, err := s.CreateDraftChangeset(ctx, cs)
+	assert.False(t, exists)
+	assert.Nil(t, err)
+	assert.True(t, cs.Metadata.(*gtcs.AnnotatedPullRequest).Draft())
+}
+
+func TestGiteaSource_UndraftChangeset(t *testing.T) {
+	ctx := context.Background()
+
+	cs, _, gtRepo := mockGiteaChangeset()
+	cs.Title = "WIP: title"
+	s, client := mockGiteaSource()
+	mockGiteaAnnotatePullRequestSuccess(client)
+
+	client.EditPullRequestFunc.SetDefaultHook(func(ctx context.Context, owner, name string, index int64, input gitea.EditPullRequestInput) (*gitea.PullRequest, error) {
+		assert.EqualValues(t, 42, index)
+		assert.Equal(t, pointers.Ptr("title"), input.Title)
+		pr := mockGiteaPullRequest(gtRepo)
+		pr.Title = *input.Title
+		return pr, nil
+	})
+
+	err := s.UndraftChangeset(ctx, cs)
+	assert.Nil(t, err)
+	assert.Equal(t, "title", cs.Title)
+	assert.False(t, cs.Metadata.(*gtcs.AnnotatedPullRequest).Draft())
+}
+
+func TestGiteaSource_CloseChangeset(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("error closing pull request", func(t *testing.T) {
+		cs, _, _ := mockGiteaChangeset()
+		s, client := mockGiteaSource()
+
+		want := errors.New("error")
+		client.EditPullRequestFunc.SetDefaultReturn(nil, want)
+
+		err := s.CloseChangeset(ctx, cs)
+		assert.NotNil(t, err)
+		assert.ErrorIs(t, err, want)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		cs, _, gtRepo := mockGiteaChangeset()
+		s, client := mockGiteaSource()
+		mockGiteaAnnotatePullRequestSuccess(client)
+
+		pr := mockGiteaPullRequest(gtRepo)
+		pr.State = gitea.PullRequestStateClosed
+		client.EditPullRequestFunc.SetDefaultHook(func(ctx context.Context, owner, name string, index int64, input gitea.EditPullRequestInput) (*gitea.PullRequest, error) {
+			assert.EqualValues(t, 42, index)
+			assert.Equal(t, gitea.EditPullRequestInput{State: pointers.Ptr(gitea.PullRequestStateClosed)}, input)
+			return pr, nil
+		})
+
+		err := s.CloseChangeset(ctx, cs)
+		assert.Nil(t, err)
+		assertGiteaChangesetMatchesPullRequest(t, cs, pr)
+	})
+}
+
+func TestGiteaSource_ReopenChangeset(t *testing.T) {
+	ctx := context.Background()
+
+	cs, _, gtRepo := mockGiteaChangeset()
+	s, client := mockGiteaSource()
+	mockGiteaAnnotatePullRequestSuccess(client)
+
+	pr := mockGiteaPullRequest(gtRepo)
+	client.EditPullRequestFunc.SetDefaultHook(func(ctx context.Context, owner, name string, index int64, input gitea.EditPullRequestInput) (*gitea.PullRequest, error) {
+		assert.Equal(t, gitea.EditPullRequestInput{State: pointers.Ptr(gitea.PullRequestStateOpen)}, input)
+		return pr, nil
+	})
+
+	err := s.ReopenChangeset(ctx, cs)
+	assert.Nil(t, err)
+	assertGiteaChangesetMatchesPullRequest(t, cs, pr)
+}
+
+func TestGiteaSource_UpdateChangeset(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("error updating pull request", func(t *testing.T) {
+		cs, _, _ := mockGiteaChangeset()
+		s, client := mockGiteaSource()
+
+		want := errors.New("error")
+		client.EditPullRequestFunc.SetDefaultReturn(nil, want)
+
+		err := s.UpdateChangeset(ctx, cs)
+		assert.NotNil(t, err)
+		assert.ErrorIs(t, err, want)
+	})
+
+	for name, tc := range map[string]struct {
+		currentTitle string
+		wantTitle    string
+	}{
+		"not a draft": {currentTitle: "old title", wantTitle: "title"},
+		"draft":       {currentTitle: "WIP: old title", wantTitle: "WIP: title"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cs, _, gtRepo := mockGiteaChangeset()
+			cs.Metadata.(*gtcs.AnnotatedPullRequest).Title = tc.currentTitle
+			s, client := mockGiteaSource()
+			mockGiteaAnnotatePullRequestSuccess(client)
+
+			pr := mockGiteaPullRequest(gtRepo)
+			client.EditPullRequestFunc.SetDefaultHook(func(ctx context.Context, owner, name string, index int64, input gitea.EditPullRequestInput) (*gitea.PullRequest, error) {
+				assert.EqualValues(t, 42, index)
+				assert.Equal(t, gitea.EditPullRequestInput{
+					Title: pointers.Ptr(tc.wantTitle),
+					Body:  pointers.Ptr(cs.Body),
+					Base:  pointers.Ptr("main"),
+				}, input)
+				return pr, nil
+			})
+
+			err := s.UpdateChangeset(ctx, cs)
+			assert.Nil(t, err)
+			assertGiteaChangesetMatchesPullRequest(t, cs, pr)
+		})
+	}
+}
+
+func TestGiteaSource_CreateComment(t *testing.T) {
+	ctx := context.Background()
+
+	for name, want := range map[string]error{
+		"nil":   nil,
+		"error": errors.New("error"),
+	} {
+		t.Run(name, func(t *testing.T) {
+			cs, _, _ := mockGiteaChangeset()
+			s, client := mockGiteaSource()
+
+			client.CreatePullRequestCommentFunc.SetDefaultHook(func(ctx context.Context, owner, name string, index int64, body string) (*gitea.Comment, error) {
+				assert.EqualValues(t, 42, index)
+				assert.Equal(t, "comment", body)
+				return &gitea.Comment{}, want
+			})
+
+			assert.Equal(t, want, s.CreateComment(ctx, cs, "comment"))
+		})
+	}
+}
+
+func TestGiteaSource_MergeChangeset(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("not mergeable", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}))
+		t.Cleanup(srv.Close)
+
+		httpClient, err := gitea.NewClient("gitea", &schema.GiteaConnection{Url: srv.URL}, srv.Client())
+		require.NoError(t, err)
+		notMergeable := httpClient.MergePullRequest(ctx, "org", "repo", 42, gitea.MergePullRequestInput{})
+		require.True(t, gitea.IsNotMergeable(notMergeable))
+
+		cs, _, _ := mockGiteaChangeset()
+		s, client := mockGiteaSource()
+		client.MergePullRequestFunc.SetDefaultReturn(notMergeable)
+
+		err = s.MergeChangeset(ctx, cs, false)
+		assert.NotNil(t, err)
+		target := ChangesetNotMergeableError{}
+		assert.ErrorAs(t, err, &target)
+	})
+
+	t.Run("other error", func(t *testing.T) {
+		cs, _, _ := mockGiteaChangeset()
+		s, client := mockGiteaSource()
+
+		want := errors.New("error")
+		client.MergePullRequestFunc.SetDefaultReturn(want)
+
+		err := s.MergeChangeset(ctx, cs, false)
+		assert.NotNil(t, err)
+		assert.ErrorIs(t, err, want)
+		assert.False(t, errors.HasType(err, ChangesetNotMergeableError{}))
+	})
+
+	for name, tc := range map[string]struct {
+		squash bool
+		want   gitea.MergeStyle
+	}{
+		"merge":  {squash: false, want: gitea.MergeStyleMerge},
+		"squash": {squash: true, want: gitea.MergeStyleSquash},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cs, _, gtRepo := mockGiteaChangeset()
+			s, client := mockGiteaSource()
+			mockGiteaAnnotatePullRequestSuccess(client)
+
+			client.MergePullRequestFunc.SetDefaultHook(func(ctx context.Context, owner, name string, index int64, input gitea.MergePullRequestInput) error {
+				assert.EqualValues(t, 42, index)
+				assert.Equal(t, tc.want, input.Do)
+				return nil
+			})
+			pr := mockGiteaPullRequest(gtRepo)
+			pr.State = gitea.PullRequestStateClosed
+			pr.HasMerged = true
+			client.GetPullRequestFunc.SetDefaultReturn(pr, nil)
+
+			err := s.MergeChangeset(ctx, cs, tc.squash)
+			assert.Nil(t, err)
+			assertGiteaChangesetMatchesPullRequest(t, cs, pr)
+		})
+	}
+}
+
+func TestGiteaSource_GetFork(t *testing.T) {
+	ctx := context.Background()
+
+	upstream := &gitea.Repo{
+		ID:       1,
+		Name:     "repo",
+		FullName: "org/repo",
+		Owner:    &gitea.User{Login: "org"},
+	}
+	targetRepo := &types.Repo{
+		Metadata: upstream,
+		Sources: map[string]*types.SourceInfo{
+			"1": {
+				ID:       "extsvc:gitea:1",
+				CloneURL: "https://gitea.example.com/org/repo.git",
+			},
+		},
+	}
+
+	newFork := func(namespace, name string) *gitea.Repo {
+		return &gitea.Repo{
+			ID:       2,
+			Name:     name,
+			FullName: namespace + "/" + name,
+			Owner:    &gitea.User{Login: namespace},
+			Fork:     true,
+			Parent:   upstream,
+		}
+	}
+
+	t.Run("error getting current user", func(t *testing.T) {
+		s, client := mockGiteaSource()
+		want := errors.New("error")
+		client.CurrentUserFunc.SetDefaultReturn(nil, want)
+
+		fork, err := s.GetFork(ctx, targetRepo, nil, nil)
+		assert.Nil(t, fork)
+		assert.ErrorIs(t, err, want)
+	})
+
+	t.Run("existing fork", func(t *testing.T) {
+		s, client := mockGiteaSource()
+		client.CurrentUserFunc.SetDefaultReturn(&gitea.User{Login: "user"}, nil)
+		client.GetRepoFunc.SetDefaultHook(func(ctx context.Context, owner, name string) (*gitea.Repo, error) {
+			assert.Equal(t, "user", owner)
+			assert.Equal(t, "org-repo", name)
+			return newFork(owner, name), nil
+		})
+
+		fork, err := s.GetFork(ctx, targetRepo, nil, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, "user/org-repo", fork.Metadata.(*gitea.Repo).FullName)
+		assert.Equal(t, "https://gitea.example.com/user/org-repo.git", fork.Sources["1"].CloneURL)
+	})
+
+	t.Run("existing repo is not a fork", func(t *testing.T) {
+		s, client := mockGiteaSource()
+		client.GetRepoFunc.SetDefaultHook(func(ctx context.Context, owner, name string) (*gitea.Repo, error) {
+			fork := newFork(owner, name)
+			fork.Parent = nil
+			return fork, nil
+		})
+
+		fork, err := s.GetFork(ctx, targetRepo, pointers.Ptr("team"), pointers.Ptr("repo"))
+		assert.Nil(t, fork)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("new fork in organization", func(t *testing.T) {
+		s, client := mockGiteaSource()
+		client.GetRepoFunc.SetDefaultReturn(nil, &notFoundError{})
+		client.ForkRepoFunc.SetDefaultHook(func(ctx context.Context, owner, name string, input gitea.ForkRepoInput) (*gitea.Repo, error) {
+			assert.Equal(t, "org", owner)
+			assert.Equal(t, "repo", name)
+			assert.Equal(t, gitea.ForkRepoInput{
+				Organization: pointers.Ptr("team"),
+				Name:         pointers.Ptr("custom"),
+			}, input)
+			return newFork("team", "custom"), nil
+		})
+
+		fork, err := s.GetFork(ctx, targetRepo, pointers.Ptr("team"), pointers.Ptr("custom"))
+		assert.Nil(t, err)
+		assert.Equal(t, "team/custom", fork.Metadata.(*gitea.Repo).FullName)
+	})
+}
+
+func TestGiteaSource_annotatePullRequest(t *testing.T) {
+	ctx := context.Background()
+	_, _, gtRepo := mockGiteaChangeset()
+	pr := mockGiteaPullRequest(gtRepo)
+
+	t.Run("error getting reviews", func(t *testing.T) {
+		s, client := mockGiteaSource()
+		want := mockGiteaAnnotatePullRequestError(client)
+
+		apr, err := s.annotatePullRequest(ctx, gtRepo, pr)
+		assert.Nil(t, apr)
+		assert.ErrorIs(t, err, want)
+	})
+
+	t.Run("error getting status", func(t *testing.T) {
+		s, client := mockGiteaSource()
+		want := errors.New("error")
+		client.ListPullRequestReviewsFunc.SetDefaultReturn(nil, nil)
+		client.GetCombinedStatusFunc.SetDefaultReturn(nil, want)
+
+		apr, err := s.annotatePullRequest(ctx, gtRepo, pr)
+		assert.Nil(t, apr)
+		assert.ErrorIs(t, err, want)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		s, client := mockGiteaSource()
+		reviews := []*gitea.PullRequestReview{{ID: 1, State: gitea.ReviewStateApproved}}
+		status := &gitea.CombinedStatus{State: gitea.StatusStateSuccess}
+		client.ListPullRequestReviewsFunc.SetDefaultReturn(reviews, nil)
+		client.GetCombinedStatusFunc.SetDefaultHook(func(ctx context.Context, owner, name, ref string) (*gitea.CombinedStatus, error) {
+			// Statuses are looked up by the head commit, not the branch.
+			assert.Equal(t, pr.Head.Sha, ref)
+			return status, nil
+		})
+
+		apr, err := s.annotatePullRequest(ctx, gtRepo, pr)
+		assert.Nil(t, err)
+		assert.Same(t, pr, apr.PullRequest)
+		assert.Equal(t, reviews, apr.Reviews)
+		assert.Same(t, status, apr.Status)
+	})
+}
+
+func assertGiteaChangesetMatchesPullRequest(t *testing.T, cs *Changeset, pr *gitea.PullRequest) {
+	t.Helper()
+
+	// We're not thoroughly testing setChangesetMetadata() et al in this
+	// assertion, but we do want to ensure that the PR was used to populate
+	// fields on the Changeset.
+	assert.Equal(t, strconv.FormatInt(pr.Index, 10), cs.ExternalID)
+	assert.Equal(t, "refs/heads/"+pr.Head.Ref, cs.ExternalBranch)
+	assert.Same(t, pr, cs.Metadata.(*gtcs.AnnotatedPullRequest).PullRequest)
+}
+
+// mockGiteaChangeset creates a plausible non-forked changeset, repo, and Gitea
+// specific repo.
+func mockGiteaChangeset() (*Changeset, *types.Repo, *gitea.Repo) {
+	gtRepo := &gitea.Repo{
+		ID:       1,
+		Name:     "repo",
+		FullName: "org/repo",
+		Owner:    &gitea.User{Login: "org"},
+	}
+	repo := &types.Repo{Metadata: gtRepo}
+	cs := &Changeset{
+		Title:   "title",
+		Body:    "body",
+		HeadRef: "refs/heads/branch",
+		BaseRef: "refs/heads/main",
+		Changeset: &btypes.Changeset{
+			ExternalID: "42",
+			Metadata: &gtcs.AnnotatedPullRequest{
+				PullRequest: &gitea.PullRequest{Index: 42, Title: "title"},
+			},
+		},
+		RemoteRepo: repo,
+		TargetRepo: repo,
+	}
+
+	return cs, repo, gtRepo
+}
+
+// mockGiteaPullRequest returns a plausible pull request that would be returned
+// from Gitea for a non-forked changeset.
+func mockGiteaPullRequest(repo *gitea.Repo) *gitea.PullRequest {
+	return &gitea.PullRequest{
+		ID:    4200,
+		Index: 42,
+		Title: "title",
+		State: gitea.PullRequestStateOpen,
+		Head:  &gitea.PRBranchInfo{Ref: "branch", Sha: "0123456789abcdef", RepoID: repo.ID, Repo: repo},
+		Base:  &gitea.PRBranchInfo{Ref: "main", Sha: "fedcba9876543210", RepoID: repo.ID, Repo: repo},
+	}
+}
+
+func mockGiteaSource() (*GiteaSource, *MockGiteaClient) {
+	client := NewStrictMockGiteaClient()
+	s := &GiteaSource{client: client}
+
+	return s, client
+}
+
+// mockGiteaAnnotatePullRequestError configures the mock client to return an
+// error when ListPullRequestReviews is invoked by annotatePullRequest.
+func mockGiteaAnnotatePullRequestError(client *MockGiteaClient) error {
+	err := errors.New("error")
+	client.ListPullRequestReviewsFunc.SetDefaultReturn(nil, err)
+
+	return err
+}
+
+// mockGiteaAnnotatePullRequestSuccess configures the mock client to return no
+// reviews and an empty combined status.
+func mockGiteaAnnotatePullRequestSuccess(client *MockGiteaClient) {
+	client.ListPullRequestReviewsFunc.SetDefaultReturn([]*gitea.PullRequestReview{}, nil)
+	client.GetCombinedStatusFunc.SetDefaultReturn(&gitea.CombinedStatus{}, nil)
+}
@@ -25,6 +25,7 @@ import (
 	azuredevops "github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 	bitbucketcloud "github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	gerrit "github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	gitea "github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
 	store1 "github.com/sourcegraph/sourcegraph/internal/github_apps/store"
 	gitserver "github.com/sourcegraph/sourcegraph/internal/gitserver"
 	gitdomain "github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
@@ -10726,6 +10727,2223 @@ func (c GerritClientWriteReviewCommentFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// MockGiteaClient is a mock implementation of the Client interface (from
+// the package github.com/sourcegraph/sourcegraph/internal/extsvc/gitea)
+// used for unit testing.
+type MockGiteaClient struct {
+	// AuthenticatorFunc is an instance of a mock function object
+	// controlling the behavior of the method Authenticator.
+	AuthenticatorFunc *GiteaAuthenticatorFunc
+	// CreatePullRequestFunc is an instance of a mock function object
+	// controlling the behavior of the method CreatePullRequest.
+	CreatePullRequestFunc *GiteaCreatePullRequestFunc
+	// CreatePullRequestCommentFunc is an instance of a mock function object
+	// controlling the behavior of the method CreatePullRequestComment.
+	CreatePullRequestCommentFunc *GiteaCreatePullRequestCommentFunc
+	// CurrentUserFunc is an instance of a mock function object controlling
+	// the behavior of the method CurrentUser.
+	CurrentUserFunc *GiteaCurrentUserFunc
+	// EditPullRequestFunc is an instance of a mock function object
+	// controlling the behavior of the method EditPullRequest.
+	EditPullRequestFunc *GiteaEditPullRequestFunc
+	// ForkRepoFunc is an instance of a mock function object controlling the
+	// behavior of the method ForkRepo.
+	ForkRepoFunc *GiteaForkRepoFunc
+	// GetCombinedStatusFunc is an instance of a mock function object
+	// controlling the behavior of the method GetCombinedStatus.
+	GetCombinedStatusFunc *GiteaGetCombinedStatusFunc
+	// GetPullRequestFunc is an instance of a mock function object
+	// controlling the behavior of the method GetPullRequest.
+	GetPullRequestFunc *GiteaGetPullRequestFunc
+	// GetRepoFunc is an instance of a mock function object controlling the
+	// behavior of the method GetRepo.
+	GetRepoFunc *GiteaGetRepoFunc
+	// ListCurrentUserEmailsFunc is an instance of a mock function object
+	// controlling the behavior of the method ListCurrentUserEmails.
+	ListCurrentUserEmailsFunc *GiteaListCurrentUserEmailsFunc
+	// ListCurrentUserOrgsFunc is an instance of a mock function object
+	// controlling the behavior of the method ListCurrentUserOrgs.
+	ListCurrentUserOrgsFunc *GiteaListCurrentUserOrgsFunc
+	// ListCurrentUserReposFunc is an instance of a mock function object
+	// controlling the behavior of the method ListCurrentUserRepos.
+	ListCurrentUserReposFunc *GiteaListCurrentUserReposFunc
+	// ListOpenPullRequestsFunc is an instance of a mock function object
+	// controlling the behavior of the method ListOpenPullRequests.
+	ListOpenPullRequestsFunc *GiteaListOpenPullRequestsFunc
+	// ListOrgReposFunc is an instance of a mock function object controlling
+	// the behavior of the method ListOrgRepos.
+	ListOrgReposFunc *GiteaListOrgReposFunc
+	// ListPullRequestReviewsFunc is an instance of a mock function object
+	// controlling the behavior of the method ListPullRequestReviews.
+	ListPullRequestReviewsFunc *GiteaListPullRequestReviewsFunc
+	// MergePullRequestFunc is an instance of a mock function object
+	// controlling the behavior of the method MergePullRequest.
+	MergePullRequestFunc *GiteaMergePullRequestFunc
+	// WithAuthenticatorFunc is an instance of a mock function object
+	// controlling the behavior of the method WithAuthenticator.
+	WithAuthenticatorFunc *GiteaWithAuthenticatorFunc
+}
+
+// NewMockGiteaClient creates a new mock of the Client interface. All
+// methods return zero values for all results, unless overwritten.
+func NewMockGiteaClient() *MockGiteaClient {
+	return &MockGiteaClient{
+		AuthenticatorFunc: &GiteaAuthenticatorFunc{
+			defaultHook: func() (r0 auth.Authenticator) {
+				return
+			},
+		},
+		CreatePullRequestFunc: &GiteaCreatePullRequestFunc{
+			defaultHook: func(context.Context, string, string, gitea.CreatePullRequestInput) (r0 *gitea.PullRequest, r1 error) {
+				return
+			},
+		},
+		CreatePullRequestCommentFunc: &GiteaCreatePullRequestCommentFunc{
+			defaultHook: func(context.Context, string, string, int64, string) (r0 *gitea.Comment, r1 error) {
+				return
+			},
+		},
+		CurrentUserFunc: &GiteaCurrentUserFunc{
+			defaultHook: func(context.Context) (r0 *gitea.User, r1 error) {
+				return
+			},
+		},
+		EditPullRequestFunc: &GiteaEditPullRequestFunc{
+			defaultHook: func(context.Context, string, string, int64, gitea.EditPullRequestInput) (r0 *gitea.PullRequest, r1 error) {
+				return
+			},
+		},
+		ForkRepoFunc: &GiteaForkRepoFunc{
+			defaultHook: func(context.Context, string, string, gitea.ForkRepoInput) (r0 *gitea.Repo, r1 error) {
+				return
+			},
+		},
+		GetCombinedStatusFunc: &GiteaGetCombinedStatusFunc{
+			defaultHook: func(context.Context, string, string, string) (r0 *gitea.CombinedStatus, r1 error) {
+				return
+			},
+		},
+		GetPullRequestFunc: &GiteaGetPullRequestFunc{
+			defaultHook: func(context.Context, string, string, int64) (r0 *gitea.PullRequest, r1 error) {
+				return
+			},
+		},
+		GetRepoFunc: &GiteaGetRepoFunc{
+			defaultHook: func(context.Context, string, string) (r0 *gitea.Repo, r1 error) {
+				return
+			},
+		},
+		ListCurrentUserEmailsFunc: &GiteaListCurrentUserEmailsFunc{
+			defaultHook: func(context.Context) (r0 []*gitea.Email, r1 error) {
+				return
+			},
+		},
+		ListCurrentUserOrgsFunc: &GiteaListCurrentUserOrgsFunc{
+			defaultHook: func(context.Context, int) (r0 []*gitea.Organization, r1 bool, r2 error) {
+				return
+			},
+		},
+		ListCurrentUserReposFunc: &GiteaListCurrentUserReposFunc{
+			defaultHook: func(context.Context, int) (r0 []*gitea.Repo, r1 bool, r2 error) {
+				return
+			},
+		},
+		ListOpenPullRequestsFunc: &GiteaListOpenPullRequestsFunc{
+			defaultHook: func(context.Context, string, string, int) (r0 []*gitea.PullRequest, r1 bool, r2 error) {
+				return
+			},
+		},
+		ListOrgReposFunc: &GiteaListOrgReposFunc{
+			defaultHook: func(context.Context, string, int) (r0 []*gitea.Repo, r1 bool, r2 error) {
+				return
+			},
+		},
+		ListPullRequestReviewsFunc: &GiteaListPullRequestReviewsFunc{
+			defaultHook: func(context.Context, string, string, int64) (r0 []*gitea.PullRequestReview, r1 error) {
+				return
+			},
+		},
+		MergePullRequestFunc: &GiteaMergePullRequestFunc{
+			defaultHook: func(context.Context, string, string, int64, gitea.MergePullRequestInput) (r0 error) {
+				return
+			},
+		},
+		WithAuthenticatorFunc: &GiteaWithAuthenticatorFunc{
+			defaultHook: func(auth.Authenticator) (r0 gitea.Client, r1 error) {
+				return
+			},
+		},
+	}
+}
+
+// NewStrictMockGiteaClient creates a new mock of the Client interface. All
+// methods panic on invocation, unless overwritten.
+func NewStrictMockGiteaClient() *MockGiteaClient {
+	return &MockGiteaClient{
+		AuthenticatorFunc: &GiteaAuthenticatorFunc{
+			defaultHook: func() auth.Authenticator {
+				panic("unexpected invocation of MockGiteaClient.Authenticator")
+			},
+		},
+		CreatePullRequestFunc: &GiteaCreatePullRequestFunc{
+			defaultHook: func(context.Context, string, string, gitea.CreatePullRequestInput) (*gitea.PullRequest, error) {
+				panic("unexpected invocation of MockGiteaClient.CreatePullRequest")
+			},
+		},
+		CreatePullRequestCommentFunc: &GiteaCreatePullRequestCommentFunc{
+			defaultHook: func(context.Context, string, string, int64, string) (*gitea.Comment, error) {
+				panic("unexpected invocation of MockGiteaClient.CreatePullRequestComment")
+			},
+		},
+		CurrentUserFunc: &GiteaCurrentUserFunc{
+			defaultHook: func(context.Context) (*gitea.User, error) {
+				panic("unexpected invocation of MockGiteaClient.CurrentUser")
+			},
+		},
+		EditPullRequestFunc: &GiteaEditPullRequestFunc{
+			defaultHook: func(context.Context, string, string, int64, gitea.EditPullRequestInput) (*gitea.PullRequest, error) {
+				panic("unexpected invocation of MockGiteaClient.EditPullRequest")
+			},
+		},
+		ForkRepoFunc: &GiteaForkRepoFunc{
+			defaultHook: func(context.Context, string, string, gitea.ForkRepoInput) (*gitea.Repo, error) {
+				panic("unexpected invocation of MockGiteaClient.ForkRepo")
+			},
+		},
+		GetCombinedStatusFunc: &GiteaGetCombinedStatusFunc{
+			defaultHook: func(context.Context, string, string, string) (*gitea.CombinedStatus, error) {
+				panic("unexpected invocation of MockGiteaClient.GetCombinedStatus")
+			},
+		},
+		GetPullRequestFunc: &GiteaGetPullRequestFunc{
+			defaultHook: func(context.Context, string, string, int64) (*gitea.PullRequest, error) {
+				panic("unexpected invocation of MockGiteaClient.GetPullRequest")
+			},
+		},
+		GetRepoFunc: &GiteaGetRepoFunc{
+			defaultHook: func(context.Context, string, string) (*gitea.Repo, error) {
+				panic("unexpected invocation of MockGiteaClient.GetRepo")
+			},
+		},
+		ListCurrentUserEmailsFunc: &GiteaListCurrentUserEmailsFunc{
+			defaultHook: func(context.Context) ([]*gitea.Email, error) {
+				panic("unexpected invocation of MockGiteaClient.ListCurrentUserEmails")
+			},
+		},
+		ListCurrentUserOrgsFunc: &GiteaListCurrentUserOrgsFunc{
+			defaultHook: func(context.Context, int) ([]*gitea.Organization, bool, error) {
+				panic("unexpected invocation of MockGiteaClient.ListCurrentUserOrgs")
+			},
+		},
+		ListCurrentUserReposFunc: &GiteaListCurrentUserReposFunc{
+			defaultHook: func(context.Context, int) ([]*gitea.Repo, bool, error) {
+				panic("unexpected invocation of MockGiteaClient.ListCurrentUserRepos")
+			},
+		},
+		ListOpenPullRequestsFunc: &GiteaListOpenPullRequestsFunc{
+			defaultHook: func(context.Context, string, string, int) ([]*gitea.PullRequest, bool, error) {
+				panic("unexpected invocation of MockGiteaClient.ListOpenPullRequests")
+			},
+		},
+		ListOrgReposFunc: &GiteaListOrgReposFunc{
+			defaultHook: func(context.Context, string, int) ([]*gitea.Repo, bool, error) {
+				panic("unexpected invocation of MockGiteaClient.ListOrgRepos")
+			},
+		},
+		ListPullRequestReviewsFunc: &GiteaListPullRequestReviewsFunc{
+			defaultHook: func(context.Context, string, string, int64) ([]*gitea.PullRequestReview, error) {
+				panic("unexpected invocation of MockGiteaClient.ListPullRequestReviews")
+			},
+		},
+		MergePullRequestFunc: &GiteaMergePullRequestFunc{
+			defaultHook: func(context.Context, string, string, int64, gitea.MergePullRequestInput) error {
+				panic("unexpected invocation of MockGiteaClient.MergePullRequest")
+			},
+		},
+		WithAuthenticatorFunc: &GiteaWithAuthenticatorFunc{
+			defaultHook: func(auth.Authenticator) (gitea.Client, error) {
+				panic("unexpected invocation of MockGiteaClient.WithAuthenticator")
+			},
+		},
+	}
+}
+
+// NewMockGiteaClientFrom creates a new mock of the MockGiteaClient
+// interface. All methods delegate to the given implementation, unless
+// overwritten.
+func NewMockGiteaClientFrom(i gitea.Client) *MockGiteaClient {
+	return &MockGiteaClient{
+		AuthenticatorFunc: &GiteaAuthenticatorFunc{
+			defaultHook: i.Authenticator,
+		},
+		CreatePullRequestFunc: &GiteaCreatePullRequestFunc{
+			defaultHook: i.CreatePullRequest,
+		},
+		CreatePullRequestCommentFunc: &GiteaCreatePullRequestCommentFunc{
+			defaultHook: i.CreatePullRequestComment,
+		},
+		CurrentUserFunc: &GiteaCurrentUserFunc{
+			defaultHook: i.CurrentUser,
+		},
+		EditPullRequestFunc: &GiteaEditPullRequestFunc{
+			defaultHook: i.EditPullRequest,
+		},
+		ForkRepoFunc: &GiteaForkRepoFunc{
+			defaultHook: i.ForkRepo,
+		},
+		GetCombinedStatusFunc: &GiteaGetCombinedStatusFunc{
+			defaultHook: i.GetCombinedStatus,
+		},
+		GetPullRequestFunc: &GiteaGetPullRequestFunc{
+			defaultHook: i.GetPullRequest,
+		},
+		GetRepoFunc: &GiteaGetRepoFunc{
+			defaultHook: i.GetRepo,
+		},
+		ListCurrentUserEmailsFunc: &GiteaListCurrentUserEmailsFunc{
+			defaultHook: i.ListCurrentUserEmails,
+		},
+		ListCurrentUserOrgsFunc: &GiteaListCurrentUserOrgsFunc{
+			defaultHook: i.ListCurrentUserOrgs,
+		},
+		ListCurrentUserReposFunc: &GiteaListCurrentUserReposFunc{
+			defaultHook: i.ListCurrentUserRepos,
+		},
+		ListOpenPullRequestsFunc: &GiteaListOpenPullRequestsFunc{
+			defaultHook: i.ListOpenPullRequests,
+		},
+		ListOrgReposFunc: &GiteaListOrgReposFunc{
+			defaultHook: i.ListOrgRepos,
+		},
+		ListPullRequestReviewsFunc: &GiteaListPullRequestReviewsFunc{
+			defaultHook: i.ListPullRequestReviews,
+		},
+		MergePullRequestFunc: &GiteaMergePullRequestFunc{
+			defaultHook: i.MergePullRequest,
+		},
+		WithAuthenticatorFunc: &GiteaWithAuthenticatorFunc{
+			defaultHook: i.WithAuthenticator,
+		},
+	}
+}
+
+// GiteaAuthenticatorFunc describes the behavior when the Authenticator
+// method of the parent MockGiteaClient instance is invoked.
+type GiteaAuthenticatorFunc struct {
+	defaultHook func() auth.Authenticator
+	hooks       []func() auth.Authenticator
+	history     []GiteaAuthenticatorFuncCall
+	mutex       sync.Mutex
+}
+
+// Authenticator delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockGiteaClient) Authenticator() auth.Authenticator {
+	r0 := m.AuthenticatorFunc.nextHook()()
+	m.AuthenticatorFunc.appendCall(GiteaAuthenticatorFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Authenticator method
+// of the parent MockGiteaClient instance is invoked and the hook queue is
+// empty.
+func (f *GiteaAuthenticatorFunc) SetDefaultHook(hook func() auth.Authenticator) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Authenticator method of the parent MockGiteaClient instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *GiteaAuthenticatorFunc) PushHook(hook func() auth.Authenticator) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GiteaAuthenticatorFunc) SetDefaultReturn(r0 auth.Authenticator) {
+	f.SetDefaultHook(func() auth.Authenticator {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GiteaAuthenticatorFunc) PushReturn(r0 auth.Authenticator) {
+	f.PushHook(func() auth.Authenticator {
+		return r0
+	})
+}
+
+func (f *GiteaAuthenticatorFunc) nextHook() func() auth.Authenticator {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GiteaAuthenticatorFunc) appendCall(r0 GiteaAuthenticatorFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GiteaAuthenticatorFuncCall objects
+// describing the invocations of this function.
+func (f *GiteaAuthenticatorFunc) History() []GiteaAuthenticatorFuncCall {
+	f.mutex.Lock()
+	history := make([]GiteaAuthenticatorFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GiteaAuthenticatorFuncCall is an object that describes an invocation of
+// method Authenticator on an instance of MockGiteaClient.
+type GiteaAuthenticatorFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 auth.Authenticator
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GiteaAuthenticatorFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GiteaAuthenticatorFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// GiteaCreatePullRequestFunc describes the behavior when the
+// CreatePullRequest method of the parent MockGiteaClient instance is
+// invoked.
+type GiteaCreatePullRequestFunc struct {
+	defaultHook func(context.Context, string, string, gitea.CreatePullRequestInput) (*gitea.PullRequest, error)
+	hooks       []func(context.Context, string, string, gitea.CreatePullRequestInput) (*gitea.PullRequest, error)
+	history     []GiteaCreatePullRequestFuncCall
+	mutex       sync.Mutex
+}
+
+// CreatePullRequest delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockGiteaClient) CreatePullRequest(v0 context.Context, v1 string, v2 string, v3 gitea.CreatePullRequestInput) (*gitea.PullRequest, error) {
+	r0, r1 := m.CreatePullRequestFunc.nextHook()(v0, v1, v2, v3)
+	m.CreatePullRequestFunc.appendCall(GiteaCreatePullRequestFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the CreatePullRequest
+// method of the parent MockGiteaClient instance is invoked and the hook
+// queue is empty.
+func (f *GiteaCreatePullRequestFunc) SetDefaultHook(hook func(context.Context, string, string, gitea.CreatePullRequestInput) (*gitea.PullRequest, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CreatePullRequest method of the parent MockGiteaClient instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *GiteaCreatePullRequestFunc) PushHook(hook func(context.Context, string, string, gitea.CreatePullRequestInput) (*gitea.PullRequest, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GiteaCreatePullRequestFunc) SetDefaultReturn(r0 *gitea.PullRequest, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, string, gitea.CreatePullRequestInput) (*gitea.PullRequest, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GiteaCreatePullRequestFunc) PushReturn(r0 *gitea.PullRequest, r1 error) {
+	f.PushHook(func(context.Context, string, string, gitea.CreatePullRequestInput) (*gitea.PullRequest, error) {
+		return r0, r1
+	})
+}
+
+func (f *GiteaCreatePullRequestFunc) nextHook() func(context.Context, string, string, gitea.CreatePullRequestInput) (*gitea.PullRequest, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GiteaCreatePullRequestFunc) appendCall(r0 GiteaCreatePullRequestFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GiteaCreatePullRequestFuncCall objects
+// describing the invocations of this function.
+func (f *GiteaCreatePullRequestFunc) History() []GiteaCreatePullRequestFuncCall {
+	f.mutex.Lock()
+	history := make([]GiteaCreatePullRequestFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GiteaCreatePullRequestFuncCall is an object that describes an invocation
+// of method CreatePullRequest on an instance of MockGiteaClient.
+type GiteaCreatePullRequestFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 gitea.CreatePullRequestInput
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *gitea.PullRequest
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GiteaCreatePullRequestFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GiteaCreatePullRequestFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// GiteaCreatePullRequestCommentFunc describes the behavior when the
+// CreatePullRequestComment method of the parent MockGiteaClient instance is
+// invoked.
+type GiteaCreatePullRequestCommentFunc struct {
+	defaultHook func(context.Context, string, string, int64, string) (*gitea.Comment, error)
+	hooks       []func(context.Context, string, string, int64, string) (*gitea.Comment, error)
+	history     []GiteaCreatePullRequestCommentFuncCall
+	mutex       sync.Mutex
+}
+
+// CreatePullRequestComment delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockGiteaClient) CreatePullRequestComment(v0 context.Context, v1 string, v2 string, v3 int64, v4 string) (*gitea.Comment, error) {
+	r0, r1 := m.CreatePullRequestCommentFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.CreatePullRequestCommentFunc.appendCall(GiteaCreatePullRequestCommentFuncCall{v0, v1, v2, v3, v4, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// CreatePullRequestComment method of the parent MockGiteaClient instance is
+// invoked and the hook queue is empty.
+func (f *GiteaCreatePullRequestCommentFunc) SetDefaultHook(hook func(context.Context, string, string, int64, string) (*gitea.Comment, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CreatePullRequestComment method of the parent MockGiteaClient instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *GiteaCreatePullRequestCommentFunc) PushHook(hook func(context.Context, string, string, int64, string) (*gitea.Comment, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GiteaCreatePullRequestCommentFunc) SetDefaultReturn(r0 *gitea.Comment, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, string, int64, string) (*gitea.Comment, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GiteaCreatePullRequestCommentFunc) PushReturn(r0 *gitea.Comment, r1 error) {
+	f.PushHook(func(context.Context, string, string, int64, string) (*gitea.Comment, error) {
+		return r0, r1
+	})
+}
+
+func (f *GiteaCreatePullRequestCommentFunc) nextHook() func(context.Context, string, string, int64, string) (*gitea.Comment, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GiteaCreatePullRequestCommentFunc) appendCall(r0 GiteaCreatePullRequestCommentFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GiteaCreatePullRequestCommentFuncCall
+// objects describing the invocations of this function.
+func (f *GiteaCreatePullRequestCommentFunc) History() []GiteaCreatePullRequestCommentFuncCall {
+	f.mutex.Lock()
+	history := make([]GiteaCreatePullRequestCommentFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GiteaCreatePullRequestCommentFuncCall is an object that describes an
+// invocation of method CreatePullRequestComment on an instance of
+// MockGiteaClient.
+type GiteaCreatePullRequestCommentFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int64
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *gitea.Comment
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GiteaCreatePullRequestCommentFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GiteaCreatePullRequestCommentFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// GiteaCurrentUserFunc describes the behavior when the CurrentUser method
+// of the parent MockGiteaClient instance is invoked.
+type GiteaCurrentUserFunc struct {
+	defaultHook func(context.Context) (*gitea.User, error)
+	hooks       []func(context.Context) (*gitea.User, error)
+	history     []GiteaCurrentUserFuncCall
+	mutex       sync.Mutex
+}
+
+// CurrentUser delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockGiteaClient) CurrentUser(v0 context.Context) (*gitea.User, error) {
+	r0, r1 := m.CurrentUserFunc.nextHook()(v0)
+	m.CurrentUserFunc.appendCall(GiteaCurrentUserFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the CurrentUser method
+// of the parent MockGiteaClient instance is invoked and the hook queue is
+// empty.
+func (f *GiteaCurrentUserFunc) SetDefaultHook(hook func(context.Context) (*gitea.User, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CurrentUser method of the parent MockGiteaClient instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *GiteaCurrentUserFunc) PushHook(hook func(context.Context) (*gitea.User, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GiteaCurrentUserFunc) SetDefaultReturn(r0 *gitea.User, r1 error) {
+	f.SetDefaultHook(func(context.Context) (*gitea.User, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GiteaCurrentUserFunc) PushReturn(r0 *gitea.User, r1 error) {
+	f.PushHook(func(context.Context) (*gitea.User, error) {
+		return r0, r1
+	})
+}
+
+func (f *GiteaCurrentUserFunc) nextHook() func(context.Context) (*gitea.User, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GiteaCurrentUserFunc) appendCall(r0 GiteaCurrentUserFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GiteaCurrentUserFuncCall objects describing
+// the invocations of this function.
+func (f *GiteaCurrentUserFunc) History() []GiteaCurrentUserFuncCall {
+	f.mutex.Lock()
+	history := make([]GiteaCurrentUserFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GiteaCurrentUserFuncCall is an object that describes an invocation of
+// method CurrentUser on an instance of MockGiteaClient.
+type GiteaCurrentUserFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *gitea.User
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GiteaCurrentUserFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GiteaCurrentUserFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// GiteaEditPullRequestFunc describes the behavior when the EditPullRequest
+// method of the parent MockGiteaClient instance is invoked.
+type GiteaEditPullRequestFunc struct {
+	defaultHook func(context.Context, string, string, int64, gitea.EditPullRequestInput) (*gitea.PullRequest, error)
+	hooks       []func(context.Context, string, string, int64, gitea.EditPullRequestInput) (*gitea.PullRequest, error)
+	history     []GiteaEditPullRequestFuncCall
+	mutex       sync.Mutex
+}
+
+// EditPullRequest delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockGiteaClient) EditPullRequest(v0 context.Context, v1 string, v2 string, v3 int64, v4 gitea.EditPullRequestInput) (*gitea.PullRequest, error) {
+	r0, r1 := m.EditPullRequestFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.EditPullRequestFunc.appendCall(GiteaEditPullRequestFuncCall{v0, v1, v2, v3, v4, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the EditPullRequest
+// method of the parent MockGiteaClient instance is invoked and the hook
+// queue is empty.
+func (f *GiteaEditPullRequestFunc) SetDefaultHook(hook func(context.Context, string, string, int64, gitea.EditPullRequestInput) (*gitea.PullRequest, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// EditPullRequest method of the parent MockGiteaClient instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *GiteaEditPullRequestFunc) PushHook(hook func(context.Context, string, string, int64, gitea.EditPullRequestInput) (*gitea.PullRequest, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GiteaEditPullRequestFunc) SetDefaultReturn(r0 *gitea.PullRequest, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, string, int64, gitea.EditPullRequestInput) (*gitea.PullRequest, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GiteaEditPullRequestFunc) PushReturn(r0 *gitea.PullRequest, r1 error) {
+	f.PushHook(func(context.Context, string, string, int64, gitea.EditPullRequestInput) (*gitea.PullRequest, error) {
+		return r0, r1
+	})
+}
+
+func (f *GiteaEditPullRequestFunc) nextHook() func(context.Context, string, string, int64, gitea.EditPullRequestInput) (*gitea.PullRequest, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GiteaEditPullRequestFunc) appendCall(r0 GiteaEditPullRequestFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GiteaEditPullRequestFuncCall objects
+// describing the invocations of this function.
+func (f *GiteaEditPullRequestFunc) History() []GiteaEditPullRequestFuncCall {
+	f.mutex.Lock()
+	history := make([]GiteaEditPullRequestFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GiteaEditPullRequestFuncCall is an object that describes an invocation of
+// method EditPullRequest on an instance of MockGiteaClient.
+type GiteaEditPullRequestFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int64
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 gitea.EditPullRequestInput
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *gitea.PullRequest
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GiteaEditPullRequestFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GiteaEditPullRequestFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// GiteaForkRepoFunc describes the behavior when the ForkRepo method of the
+// parent MockGiteaClient instance is invoked.
+type GiteaForkRepoFunc struct {
+	defaultHook func(context.Context, string, string, gitea.ForkRepoInput) (*gitea.Repo, error)
+	hooks       []func(context.Context, string, string, gitea.ForkRepoInput) (*gitea.Repo, error)
+	history     []GiteaForkRepoFuncCall
+	mutex       sync.Mutex
+}
+
+// ForkRepo delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockGiteaClient) ForkRepo(v0 context.Context, v1 string, v2 string, v3 gitea.ForkRepoInput) (*gitea.Repo, error) {
+	r0, r1 := m.ForkRepoFunc.nextHook()(v0, v1, v2, v3)
+	m.ForkRepoFunc.appendCall(GiteaForkRepoFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ForkRepo method of
+// the parent MockGiteaClient instance is invoked and the hook queue is
+// empty.
+func (f *GiteaForkRepoFunc) SetDefaultHook(hook func(context.Context, string, string, gitea.ForkRepoInput) (*gitea.Repo, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ForkRepo method of the parent MockGiteaClient instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *GiteaForkRepoFunc) PushHook(hook func(context.Context, string, string, gitea.ForkRepoInput) (*gitea.Repo, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GiteaForkRepoFunc) SetDefaultReturn(r0 *gitea.Repo, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, string, gitea.ForkRepoInput) (*gitea.Repo, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GiteaForkRepoFunc) PushReturn(r0 *gitea.Repo, r1 error) {
+	f.PushHook(func(context.Context, string, string, gitea.ForkRepoInput) (*gitea.Repo, error) {
+		return r0, r1
+	})
+}
+
+func (f *GiteaForkRepoFunc) nextHook() func(context.Context, string, string, gitea.ForkRepoInput) (*gitea.Repo, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GiteaForkRepoFunc) appendCall(r0 GiteaForkRepoFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GiteaForkRepoFuncCall objects describing
+// the invocations of this function.
+func (f *GiteaForkRepoFunc) History() []GiteaForkRepoFuncCall {
+	f.mutex.Lock()
+	history := make([]GiteaForkRepoFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GiteaForkRepoFuncCall is an object that describes an invocation of method
+// ForkRepo on an instance of MockGiteaClient.
+type GiteaForkRepoFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 gitea.ForkRepoInput
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *gitea.Repo
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GiteaForkRepoFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GiteaForkRepoFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// GiteaGetCombinedStatusFunc describes the behavior when the
+// GetCombinedStatus method of the parent MockGiteaClient instance is
+// invoked.
+type GiteaGetCombinedStatusFunc struct {
+	defaultHook func(context.Context, string, string, string) (*gitea.CombinedStatus, error)
+	hooks       []func(context.Context, string, string, string) (*gitea.CombinedStatus, error)
+	history     []GiteaGetCombinedStatusFuncCall
+	mutex       sync.Mutex
+}
+
+// GetCombinedStatus delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockGiteaClient) GetCombinedStatus(v0 context.Context, v1 string, v2 string, v3 string) (*gitea.CombinedStatus, error) {
+	r0, r1 := m.GetCombinedStatusFunc.nextHook()(v0, v1, v2, v3)
+	m.GetCombinedStatusFunc.appendCall(GiteaGetCombinedStatusFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetCombinedStatus
+// method of the parent MockGiteaClient instance is invoked and the hook
+// queue is empty.
+func (f *GiteaGetCombinedStatusFunc) SetDefaultHook(hook func(context.Context, string, string, string) (*gitea.CombinedStatus, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetCombinedStatus method of the parent MockGiteaClient instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *GiteaGetCombinedStatusFunc) PushHook(hook func(context.Context, string, string, string) (*gitea.CombinedStatus, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GiteaGetCombinedStatusFunc) SetDefaultReturn(r0 *gitea.CombinedStatus, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, string, string) (*gitea.CombinedStatus, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GiteaGetCombinedStatusFunc) PushReturn(r0 *gitea.CombinedStatus, r1 error) {
+	f.PushHook(func(context.Context, string, string, string) (*gitea.CombinedStatus, error) {
+		return r0, r1
+	})
+}
+
+func (f *GiteaGetCombinedStatusFunc) nextHook() func(context.Context, string, string, string) (*gitea.CombinedStatus, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GiteaGetCombinedStatusFunc) appendCall(r0 GiteaGetCombinedStatusFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GiteaGetCombinedStatusFuncCall objects
+// describing the invocations of this function.
+func (f *GiteaGetCombinedStatusFunc) History() []GiteaGetCombinedStatusFuncCall {
+	f.mutex.Lock()
+	history := make([]GiteaGetCombinedStatusFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GiteaGetCombinedStatusFuncCall is an object that describes an invocation
+// of method GetCombinedStatus on an instance of MockGiteaClient.
+type GiteaGetCombinedStatusFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *gitea.CombinedStatus
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GiteaGetCombinedStatusFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GiteaGetCombinedStatusFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// GiteaGetPullRequestFunc describes the behavior when the GetPullRequest
+// method of the parent MockGiteaClient instance is invoked.
+type GiteaGetPullRequestFunc struct {
+	defaultHook func(context.Context, string, string, int64) (*gitea.PullRequest, error)
+	hooks       []func(context.Context, string, string, int64) (*gitea.PullRequest, error)
+	history     []GiteaGetPullRequestFuncCall
+	mutex       sync.Mutex
+}
+
+// GetPullRequest delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockGiteaClient) GetPullRequest(v0 context.Context, v1 string, v2 string, v3 int64) (*gitea.PullRequest, error) {
+	r0, r1 := m.GetPullRequestFunc.nextHook()(v0, v1, v2, v3)
+	m.GetPullRequestFunc.appendCall(GiteaGetPullRequestFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetPullRequest
+// method of the parent MockGiteaClient instance is invoked and the hook
+// queue is empty.
+func (f *GiteaGetPullRequestFunc) SetDefaultHook(hook func(context.Context, string, string, int64) (*gitea.PullRequest, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetPullRequest method of the parent MockGiteaClient instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *GiteaGetPullRequestFunc) PushHook(hook func(context.Context, string, string, int64) (*gitea.PullRequest, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GiteaGetPullRequestFunc) SetDefaultReturn(r0 *gitea.PullRequest, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, string, int64) (*gitea.PullRequest, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GiteaGetPullRequestFunc) PushReturn(r0 *gitea.PullRequest, r1 error) {
+	f.PushHook(func(context.Context, string, string, int64) (*gitea.PullRequest, error) {
+		return r0, r1
+	})
+}
+
+func (f *GiteaGetPullRequestFunc) nextHook() func(context.Context, string, string, int64) (*gitea.PullRequest, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GiteaGetPullRequestFunc) appendCall(r0 GiteaGetPullRequestFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GiteaGetPullRequestFuncCall objects
+// describing the invocations of this function.
+func (f *GiteaGetPullRequestFunc) History() []GiteaGetPullRequestFuncCall {
+	f.mutex.Lock()
+	history := make([]GiteaGetPullRequestFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GiteaGetPullRequestFuncCall is an object that describes an invocation of
+// method GetPullRequest on an instance of MockGiteaClient.
+type GiteaGetPullRequestFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *gitea.PullRequest
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GiteaGetPullRequestFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GiteaGetPullRequestFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// GiteaGetRepoFunc describes the behavior when the GetRepo method of the
+// parent MockGiteaClient instance is invoked.
+type GiteaGetRepoFunc struct {
+	defaultHook func(context.Context, string, string) (*gitea.Repo, error)
+	hooks       []func(context.Context, string, string) (*gitea.Repo, error)
+	history     []GiteaGetRepoFuncCall
+	mutex       sync.Mutex
+}
+
+// GetRepo delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockGiteaClient) GetRepo(v0 context.Context, v1 string, v2 string) (*gitea.Repo, error) {
+	r0, r1 := m.GetRepoFunc.nextHook()(v0, v1, v2)
+	m.GetRepoFunc.appendCall(GiteaGetRepoFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetRepo method of
+// the parent MockGiteaClient instance is invoked and the hook queue is
+// empty.
+func (f *GiteaGetRepoFunc) SetDefaultHook(hook func(context.Context, string, string) (*gitea.Repo, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetRepo method of the parent MockGiteaClient instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *GiteaGetRepoFunc) PushHook(hook func(context.Context, string, string) (*gitea.Repo, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GiteaGetRepoFunc) SetDefaultReturn(r0 *gitea.Repo, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, string) (*gitea.Repo, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GiteaGetRepoFunc) PushReturn(r0 *gitea.Repo, r1 error) {
+	f.PushHook(func(context.Context, string, string) (*gitea.Repo, error) {
+		return r0, r1
+	})
+}
+
+func (f *GiteaGetRepoFunc) nextHook() func(context.Context, string, string) (*gitea.Repo, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GiteaGetRepoFunc) appendCall(r0 GiteaGetRepoFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GiteaGetRepoFuncCall objects describing the
+// invocations of this function.
+func (f *GiteaGetRepoFunc) History() []GiteaGetRepoFuncCall {
+	f.mutex.Lock()
+	history := make([]GiteaGetRepoFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GiteaGetRepoFuncCall is an object that describes an invocation of method
+// GetRepo on an instance of MockGiteaClient.
+type GiteaGetRepoFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *gitea.Repo
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GiteaGetRepoFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GiteaGetRepoFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// GiteaListCurrentUserEmailsFunc describes the behavior when the
+// ListCurrentUserEmails method of the parent MockGiteaClient instance is
+// invoked.
+type GiteaListCurrentUserEmailsFunc struct {
+	defaultHook func(context.Context) ([]*gitea.Email, error)
+	hooks       []func(context.Context) ([]*gitea.Email, error)
+	history     []GiteaListCurrentUserEmailsFuncCall
+	mutex       sync.Mutex
+}
+
+// ListCurrentUserEmails delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockGiteaClient) ListCurrentUserEmails(v0 context.Context) ([]*gitea.Email, error) {
+	r0, r1 := m.ListCurrentUserEmailsFunc.nextHook()(v0)
+	m.ListCurrentUserEmailsFunc.appendCall(GiteaListCurrentUserEmailsFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// ListCurrentUserEmails method of the parent MockGiteaClient instance is
+// invoked and the hook queue is empty.
+func (f *GiteaListCurrentUserEmailsFunc) SetDefaultHook(hook func(context.Context) ([]*gitea.Email, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListCurrentUserEmails method of the parent MockGiteaClient instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *GiteaListCurrentUserEmailsFunc) PushHook(hook func(context.Context) ([]*gitea.Email, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GiteaListCurrentUserEmailsFunc) SetDefaultReturn(r0 []*gitea.Email, r1 error) {
+	f.SetDefaultHook(func(context.Context) ([]*gitea.Email, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GiteaListCurrentUserEmailsFunc) PushReturn(r0 []*gitea.Email, r1 error) {
+	f.PushHook(func(context.Context) ([]*gitea.Email, error) {
+		return r0, r1
+	})
+}
+
+func (f *GiteaListCurrentUserEmailsFunc) nextHook() func(context.Context) ([]*gitea.Email, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GiteaListCurrentUserEmailsFunc) appendCall(r0 GiteaListCurrentUserEmailsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GiteaListCurrentUserEmailsFuncCall objects
+// describing the invocations of this function.
+func (f *GiteaListCurrentUserEmailsFunc) History() []GiteaListCurrentUserEmailsFuncCall {
+	f.mutex.Lock()
+	history := make([]GiteaListCurrentUserEmailsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GiteaListCurrentUserEmailsFuncCall is an object that describes an
+// invocation of method ListCurrentUserEmails on an instance of
+// MockGiteaClient.
+type GiteaListCurrentUserEmailsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*gitea.Email
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GiteaListCurrentUserEmailsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GiteaListCurrentUserEmailsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// GiteaListCurrentUserOrgsFunc describes the behavior when the
+// ListCurrentUserOrgs method of the parent MockGiteaClient instance is
+// invoked.
+type GiteaListCurrentUserOrgsFunc struct {
+	defaultHook func(context.Context, int) ([]*gitea.Organization, bool, error)
+	hooks       []func(context.Context, int) ([]*gitea.Organization, bool, error)
+	history     []GiteaListCurrentUserOrgsFuncCall
+	mutex       sync.Mutex
+}
+
+// ListCurrentUserOrgs delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockGiteaClient) ListCurrentUserOrgs(v0 context.Context, v1 int) ([]*gitea.Organization, bool, error) {
+	r0, r1, r2 := m.ListCurrentUserOrgsFunc.nextHook()(v0, v1)
+	m.ListCurrentUserOrgsFunc.appendCall(GiteaListCurrentUserOrgsFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the ListCurrentUserOrgs
+// method of the parent MockGiteaClient instance is invoked and the hook
+// queue is empty.
+func (f *GiteaListCurrentUserOrgsFunc) SetDefaultHook(hook func(context.Context, int) ([]*gitea.Organization, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListCurrentUserOrgs method of the parent MockGiteaClient instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *GiteaListCurrentUserOrgsFunc) PushHook(hook func(context.Context, int) ([]*gitea.Organization, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GiteaListCurrentUserOrgsFunc) SetDefaultReturn(r0 []*gitea.Organization, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, int) ([]*gitea.Organization, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GiteaListCurrentUserOrgsFunc) PushReturn(r0 []*gitea.Organization, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, int) ([]*gitea.Organization, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *GiteaListCurrentUserOrgsFunc) nextHook() func(context.Context, int) ([]*gitea.Organization, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GiteaListCurrentUserOrgsFunc) appendCall(r0 GiteaListCurrentUserOrgsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GiteaListCurrentUserOrgsFuncCall objects
+// describing the invocations of this function.
+func (f *GiteaListCurrentUserOrgsFunc) History() []GiteaListCurrentUserOrgsFuncCall {
+	f.mutex.Lock()
+	history := make([]GiteaListCurrentUserOrgsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GiteaListCurrentUserOrgsFuncCall is an object that describes an
+// invocation of method ListCurrentUserOrgs on an instance of
+// MockGiteaClient.
+type GiteaListCurrentUserOrgsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*gitea.Organization
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GiteaListCurrentUserOrgsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GiteaListCurrentUserOrgsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// GiteaListCurrentUserReposFunc describes the behavior when the
+// ListCurrentUserRepos method of the parent MockGiteaClient instance is
+// invoked.
+type GiteaListCurrentUserReposFunc struct {
+	defaultHook func(context.Context, int) ([]*gitea.Repo, bool, error)
+	hooks       []func(context.Context, int) ([]*gitea.Repo, bool, error)
+	history     []GiteaListCurrentUserReposFuncCall
+	mutex       sync.Mutex
+}
+
+// ListCurrentUserRepos delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockGiteaClient) ListCurrentUserRepos(v0 context.Context, v1 int) ([]*gitea.Repo, bool, error) {
+	r0, r1, r2 := m.ListCurrentUserReposFunc.nextHook()(v0, v1)
+	m.ListCurrentUserReposFunc.appendCall(GiteaListCurrentUserReposFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the ListCurrentUserRepos
+// method of the parent MockGiteaClient instance is invoked and the hook
+// queue is empty.
+func (f *GiteaListCurrentUserReposFunc) SetDefaultHook(hook func(context.Context, int) ([]*gitea.Repo, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListCurrentUserRepos method of the parent MockGiteaClient instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *GiteaListCurrentUserReposFunc) PushHook(hook func(context.Context, int) ([]*gitea.Repo, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GiteaListCurrentUserReposFunc) SetDefaultReturn(r0 []*gitea.Repo, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, int) ([]*gitea.Repo, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GiteaListCurrentUserReposFunc) PushReturn(r0 []*gitea.Repo, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, int) ([]*gitea.Repo, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *GiteaListCurrentUserReposFunc) nextHook() func(context.Context, int) ([]*gitea.Repo, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GiteaListCurrentUserReposFunc) appendCall(r0 GiteaListCurrentUserReposFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GiteaListCurrentUserReposFuncCall objects
+// describing the invocations of this function.
+func (f *GiteaListCurrentUserReposFunc) History() []GiteaListCurrentUserReposFuncCall {
+	f.mutex.Lock()
+	history := make([]GiteaListCurrentUserReposFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GiteaListCurrentUserReposFuncCall is an object that describes an
+// invocation of method ListCurrentUserRepos on an instance of
+// MockGiteaClient.
+type GiteaListCurrentUserReposFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*gitea.Repo
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GiteaListCurrentUserReposFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GiteaListCurrentUserReposFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// GiteaListOpenPullRequestsFunc describes the behavior when the
+// ListOpenPullRequests method of the parent MockGiteaClient instance is
+// invoked.
+type GiteaListOpenPullRequestsFunc struct {
+	defaultHook func(context.Context, string, string, int) ([]*gitea.PullRequest, bool, error)
+	hooks       []func(context.Context, string, string, int) ([]*gitea.PullRequest, bool, error)
+	history     []GiteaListOpenPullRequestsFuncCall
+	mutex       sync.Mutex
+}
+
+// ListOpenPullRequests delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockGiteaClient) ListOpenPullRequests(v0 context.Context, v1 string, v2 string, v3 int) ([]*gitea.PullRequest, bool, error) {
+	r0, r1, r2 := m.ListOpenPullRequestsFunc.nextHook()(v0, v1, v2, v3)
+	m.ListOpenPullRequestsFunc.appendCall(GiteaListOpenPullRequestsFuncCall{v0, v1, v2, v3, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the ListOpenPullRequests
+// method of the parent MockGiteaClient instance is invoked and the hook
+// queue is empty.
+func (f *GiteaListOpenPullRequestsFunc) SetDefaultHook(hook func(context.Context, string, string, int) ([]*gitea.PullRequest, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListOpenPullRequests method of the parent MockGiteaClient instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *GiteaListOpenPullRequestsFunc) PushHook(hook func(context.Context, string, string, int) ([]*gitea.PullRequest, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GiteaListOpenPullRequestsFunc) SetDefaultReturn(r0 []*gitea.PullRequest, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, string, string, int) ([]*gitea.PullRequest, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GiteaListOpenPullRequestsFunc) PushReturn(r0 []*gitea.PullRequest, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, string, string, int) ([]*gitea.PullRequest, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *GiteaListOpenPullRequestsFunc) nextHook() func(context.Context, string, string, int) ([]*gitea.PullRequest, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GiteaListOpenPullRequestsFunc) appendCall(r0 GiteaListOpenPullRequestsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GiteaListOpenPullRequestsFuncCall objects
+// describing the invocations of this function.
+func (f *GiteaListOpenPullRequestsFunc) History() []GiteaListOpenPullRequestsFuncCall {
+	f.mutex.Lock()
+	history := make([]GiteaListOpenPullRequestsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GiteaListOpenPullRequestsFuncCall is an object that describes an
+// invocation of method ListOpenPullRequests on an instance of
+// MockGiteaClient.
+type GiteaListOpenPullRequestsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*gitea.PullRequest
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GiteaListOpenPullRequestsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GiteaListOpenPullRequestsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// GiteaListOrgReposFunc describes the behavior when the ListOrgRepos method
+// of the parent MockGiteaClient instance is invoked.
+type GiteaListOrgReposFunc struct {
+	defaultHook func(context.Context, string, int) ([]*gitea.Repo, bool, error)
+	hooks       []func(context.Context, string, int) ([]*gitea.Repo, bool, error)
+	history     []GiteaListOrgReposFuncCall
+	mutex       sync.Mutex
+}
+
+// ListOrgRepos delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockGiteaClient) ListOrgRepos(v0 context.Context, v1 string, v2 int) ([]*gitea.Repo, bool, error) {
+	r0, r1, r2 := m.ListOrgReposFunc.nextHook()(v0, v1, v2)
+	m.ListOrgReposFunc.appendCall(GiteaListOrgReposFuncCall{v0, v1, v2, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the ListOrgRepos method
+// of the parent MockGiteaClient instance is invoked and the hook queue is
+// empty.
+func (f *GiteaListOrgReposFunc) SetDefaultHook(hook func(context.Context, string, int) ([]*gitea.Repo, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListOrgRepos method of the parent MockGiteaClient instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *GiteaListOrgReposFunc) PushHook(hook func(context.Context, string, int) ([]*gitea.Repo, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GiteaListOrgReposFunc) SetDefaultReturn(r0 []*gitea.Repo, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, string, int) ([]*gitea.Repo, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GiteaListOrgReposFunc) PushReturn(r0 []*gitea.Repo, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, string, int) ([]*gitea.Repo, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *GiteaListOrgReposFunc) nextHook() func(context.Context, string, int) ([]*gitea.Repo, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GiteaListOrgReposFunc) appendCall(r0 GiteaListOrgReposFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GiteaListOrgReposFuncCall objects
+// describing the invocations of this function.
+func (f *GiteaListOrgReposFunc) History() []GiteaListOrgReposFuncCall {
+	f.mutex.Lock()
+	history := make([]GiteaListOrgReposFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GiteaListOrgReposFuncCall is an object that describes an invocation of
+// method ListOrgRepos on an instance of MockGiteaClient.
+type GiteaListOrgReposFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*gitea.Repo
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GiteaListOrgReposFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GiteaListOrgReposFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// GiteaListPullRequestReviewsFunc describes the behavior when the
+// ListPullRequestReviews method of the parent MockGiteaClient instance is
+// invoked.
+type GiteaListPullRequestReviewsFunc struct {
+	defaultHook func(context.Context, string, string, int64) ([]*gitea.PullRequestReview, error)
+	hooks       []func(context.Context, string, string, int64) ([]*gitea.PullRequestReview, error)
+	history     []GiteaListPullRequestReviewsFuncCall
+	mutex       sync.Mutex
+}
+
+// ListPullRequestReviews delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockGiteaClient) ListPullRequestReviews(v0 context.Context, v1 string, v2 string, v3 int64) ([]*gitea.PullRequestReview, error) {
+	r0, r1 := m.ListPullRequestReviewsFunc.nextHook()(v0, v1, v2, v3)
+	m.ListPullRequestReviewsFunc.appendCall(GiteaListPullRequestReviewsFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// ListPullRequestReviews method of the parent MockGiteaClient instance is
+// invoked and the hook queue is empty.
+func (f *GiteaListPullRequestReviewsFunc) SetDefaultHook(hook func(context.Context, string, string, int64) ([]*gitea.PullRequestReview, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListPullRequestReviews method of the parent MockGiteaClient instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *GiteaListPullRequestReviewsFunc) PushHook(hook func(context.Context, string, string, int64) ([]*gitea.PullRequestReview, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GiteaListPullRequestReviewsFunc) SetDefaultReturn(r0 []*gitea.PullRequestReview, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, string, int64) ([]*gitea.PullRequestReview, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GiteaListPullRequestReviewsFunc) PushReturn(r0 []*gitea.PullRequestReview, r1 error) {
+	f.PushHook(func(context.Context, string, string, int64) ([]*gitea.PullRequestReview, error) {
+		return r0, r1
+	})
+}
+
+func (f *GiteaListPullRequestReviewsFunc) nextHook() func(context.Context, string, string, int64) ([]*gitea.PullRequestReview, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GiteaListPullRequestReviewsFunc) appendCall(r0 GiteaListPullRequestReviewsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GiteaListPullRequestReviewsFuncCall objects
+// describing the invocations of this function.
+func (f *GiteaListPullRequestReviewsFunc) History() []GiteaListPullRequestReviewsFuncCall {
+	f.mutex.Lock()
+	history := make([]GiteaListPullRequestReviewsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GiteaListPullRequestReviewsFuncCall is an object that describes an
+// invocation of method ListPullRequestReviews on an instance of
+// MockGiteaClient.
+type GiteaListPullRequestReviewsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*gitea.PullRequestReview
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GiteaListPullRequestReviewsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GiteaListPullRequestReviewsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// GiteaMergePullRequestFunc describes the behavior when the
+// MergePullRequest method of the parent MockGiteaClient instance is
+// invoked.
+type GiteaMergePullRequestFunc struct {
+	defaultHook func(context.Context, string, string, int64, gitea.MergePullRequestInput) error
+	hooks       []func(context.Context, string, string, int64, gitea.MergePullRequestInput) error
+	history     []GiteaMergePullRequestFuncCall
+	mutex       sync.Mutex
+}
+
+// MergePullRequest delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockGiteaClient) MergePullRequest(v0 context.Context, v1 string, v2 string, v3 int64, v4 gitea.MergePullRequestInput) error {
+	r0 := m.MergePullRequestFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.MergePullRequestFunc.appendCall(GiteaMergePullRequestFuncCall{v0, v1, v2, v3, v4, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the MergePullRequest
+// method of the parent MockGiteaClient instance is invoked and the hook
+// queue is empty.
+func (f *GiteaMergePullRequestFunc) SetDefaultHook(hook func(context.Context, string, string, int64, gitea.MergePullRequestInput) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// MergePullRequest method of the parent MockGiteaClient instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *GiteaMergePullRequestFunc) PushHook(hook func(context.Context, string, string, int64, gitea.MergePullRequestInput) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GiteaMergePullRequestFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, string, string, int64, gitea.MergePullRequestInput) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GiteaMergePullRequestFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, string, string, int64, gitea.MergePullRequestInput) error {
+		return r0
+	})
+}
+
+func (f *GiteaMergePullRequestFunc) nextHook() func(context.Context, string, string, int64, gitea.MergePullRequestInput) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GiteaMergePullRequestFunc) appendCall(r0 GiteaMergePullRequestFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GiteaMergePullRequestFuncCall objects
+// describing the invocations of this function.
+func (f *GiteaMergePullRequestFunc) History() []GiteaMergePullRequestFuncCall {
+	f.mutex.Lock()
+	history := make([]GiteaMergePullRequestFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GiteaMergePullRequestFuncCall is an object that describes an invocation
+// of method MergePullRequest on an instance of MockGiteaClient.
+type GiteaMergePullRequestFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int64
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 gitea.MergePullRequestInput
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GiteaMergePullRequestFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GiteaMergePullRequestFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// GiteaWithAuthenticatorFunc describes the behavior when the
+// WithAuthenticator method of the parent MockGiteaClient instance is
+// invoked.
+type GiteaWithAuthenticatorFunc struct {
+	defaultHook func(auth.Authenticator) (gitea.Client, error)
+	hooks       []func(auth.Authenticator) (gitea.Client, error)
+	history     []GiteaWithAuthenticatorFuncCall
+	mutex       sync.Mutex
+}
+
+// WithAuthenticator delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockGiteaClient) WithAuthenticator(v0 auth.Authenticator) (gitea.Client, error) {
+	r0, r1 := m.WithAuthenticatorFunc.nextHook()(v0)
+	m.WithAuthenticatorFunc.appendCall(GiteaWithAuthenticatorFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the WithAuthenticator
+// method of the parent MockGiteaClient instance is invoked and the hook
+// queue is empty.
+func (f *GiteaWithAuthenticatorFunc) SetDefaultHook(hook func(auth.Authenticator) (gitea.Client, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// WithAuthenticator method of the parent MockGiteaClient instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *GiteaWithAuthenticatorFunc) PushHook(hook func(auth.Authenticator) (gitea.Client, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GiteaWithAuthenticatorFunc) SetDefaultReturn(r0 gitea.Client, r1 error) {
+	f.SetDefaultHook(func(auth.Authenticator) (gitea.Client, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GiteaWithAuthenticatorFunc) PushReturn(r0 gitea.Client, r1 error) {
+	f.PushHook(func(auth.Authenticator) (gitea.Client, error) {
+		return r0, r1
+	})
+}
+
+func (f *GiteaWithAuthenticatorFunc) nextHook() func(auth.Authenticator) (gitea.Client, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GiteaWithAuthenticatorFunc) appendCall(r0 GiteaWithAuthenticatorFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GiteaWithAuthenticatorFuncCall objects
+// describing the invocations of this function.
+func (f *GiteaWithAuthenticatorFunc) History() []GiteaWithAuthenticatorFuncCall {
+	f.mutex.Lock()
+	history := make([]GiteaWithAuthenticatorFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GiteaWithAuthenticatorFuncCall is an object that describes an invocation
+// of method WithAuthenticator on an instance of MockGiteaClient.
+type GiteaWithAuthenticatorFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 auth.Authenticator
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 gitea.Client
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GiteaWithAuthenticatorFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GiteaWithAuthenticatorFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // MockGitserverClient is a mock implementation of the Client interface
 // (from the package github.com/sourcegraph/sourcegraph/internal/gitserver)
 // used for unit testing.
@@ -427,6 +427,7 @@ func loadExternalService(ctx context.Context, s database.ExternalServiceStore, o
 			*schema.BitbucketCloudConnection,
 			*schema.AzureDevOpsConnection,
 			*schema.GerritConnection,
+			*schema.GiteaConnection,
 			*schema.PerforceConnection:
 			return e, nil
 		}
@@ -451,6 +452,8 @@ func buildChangesetSource(ctx context.Context, tx SourcerStore, cf *httpcli.Fact
 		return NewAzureDevOpsSource(ctx, externalService, cf)
 	case extsvc.KindGerrit:
 		return NewGerritSource(ctx, externalService, cf)
+	case extsvc.KindGitea:
+		return NewGiteaSource(ctx, externalService, cf)
 	case extsvc.KindPerforce:
 		return NewPerforceSource(ctx, gitserver.NewClient(), externalService, cf)
 	default:
@@ -493,7 +496,7 @@ func loadSiteCredential(ctx context.Context, s SourcerStore, opts store.GetSiteC
 // with the specific quirks per code host.
 func setOAuthTokenAuth(u *vcs.URL, extSvcType, token string) error {
 	switch extSvcType {
-	case extsvc.TypeGitHub:
+	case extsvc.TypeGitHub, extsvc.TypeGitea:
 		u.User = url.User(token)
 
 	case extsvc.TypeGitLab:
@@ -514,7 +517,7 @@ func setBasicAuth(u *vcs.URL, extSvcType, username, password string) error {
 	switch extSvcType {
 	case extsvc.TypeGitHub, extsvc.TypeGitLab:
 		return errors.New("need token to push commits to " + extSvcType)
-	case extsvc.TypeBitbucketServer, extsvc.TypeBitbucketCloud, extsvc.TypeAzureDevOps, extsvc.TypeGerrit:
+	case extsvc.TypeBitbucketServer, extsvc.TypeBitbucketCloud, extsvc.TypeAzureDevOps, extsvc.TypeGerrit, extsvc.TypeGitea:
 		u.User = url.UserPassword(username, password)
 
 	default:
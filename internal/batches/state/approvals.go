@@ -0,0 +1,71 @@
+package state
+
+import (
+	"sort"
+
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+)
+
+// CountApprovals returns the number of distinct reviewers whose most recent
+// review of a changeset is an approval, based on the given changeset events.
+func CountApprovals(ce ChangesetEvents) (int, error) {
+	if !sort.IsSorted(ce) {
+		ce = append(ChangesetEvents(nil), ce...)
+		sort.Sort(ce)
+	}
+
+	lastReviewByAuthor := map[string]btypes.ChangesetReviewState{}
+
+	for _, e := range ce {
+		author := e.ReviewAuthor()
+		// If the user has been deleted, skip their reviews, as they don't count towards the final state anymore.
+		if author == "" {
+			continue
+		}
+
+		switch e.Kind {
+		case btypes.ChangesetEventKindGitHubReviewed,
+			btypes.ChangesetEventKindBitbucketServerApproved,
+			btypes.ChangesetEventKindBitbucketServerReviewed,
+			btypes.ChangesetEventKindGitLabApproved,
+			btypes.ChangesetEventKindBitbucketCloudApproved,
+			btypes.ChangesetEventKindBitbucketCloudPullRequestApproved,
+			btypes.ChangesetEventKindAzureDevOpsPullRequestApproved,
+			btypes.ChangesetEventKindGiteaApproved,
+			btypes.ChangesetEventKindGiteaChangesRequested:
+			s, err := e.ReviewState()
+			if err != nil {
+				return 0, err
+			}
+
+			switch s {
+			case btypes.ChangesetReviewStateApproved, btypes.ChangesetReviewStateChangesRequested:
+				lastReviewByAuthor[author] = s
+			case btypes.ChangesetReviewStateDismissed:
+				delete(lastReviewByAuthor, author)
+			}
+
+		case btypes.ChangesetEventKindGitHubReviewDismissed,
+			btypes.ChangesetEventKindBitbucketServerUnapproved,
+			btypes.ChangesetEventKindBitbucketServerDismissed,
+			btypes.ChangesetEventKindGitLabUnapproved,
+			btypes.ChangesetEventKindBitbucketCloudPullRequestChangesRequestRemoved,
+			btypes.ChangesetEventKindBitbucketCloudPullRequestUnapproved:
+			delete(lastReviewByAuthor, author)
+
+		case btypes.ChangesetEventKindAzureDevOpsPullRequestRejected,
+			btypes.ChangesetEventKindAzureDevOpsPullRequestApprovedWithSuggestions,
+			btypes.ChangesetEventKindAzureDevOpsPullRequestWaitingForAuthor:
+			lastReviewByAuthor[author] = btypes.ChangesetReviewStateChangesRequested
+		}
+	}
+
+	approvals := 0
+	for _, s := range lastReviewByAuthor {
+		if s == btypes.ChangesetReviewStateApproved {
+			approvals++
+		}
+	}
+
+	return approvals, nil
+}
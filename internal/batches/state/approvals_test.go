@@ -0,0 +1,99 @@
+package state
+
+import (
+	"testing"
+	"time"
+
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+)
+
+func TestCountApprovals(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	review := func(minutes int, author, state string) *btypes.ChangesetEvent {
+		return &btypes.ChangesetEvent{
+			Kind: btypes.ChangesetEventKindGitHubReviewed,
+			Metadata: &github.PullRequestReview{
+				Author:    github.Actor{Login: author},
+				State:     state,
+				UpdatedAt: now.Add(time.Duration(minutes) * time.Minute),
+			},
+		}
+	}
+	dismissed := func(minutes int, author string) *btypes.ChangesetEvent {
+		return &btypes.ChangesetEvent{
+			Kind: btypes.ChangesetEventKindGitHubReviewDismissed,
+			Metadata: &github.ReviewDismissedEvent{
+				Review:    github.PullRequestReview{Author: github.Actor{Login: author}},
+				CreatedAt: now.Add(time.Duration(minutes) * time.Minute),
+			},
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		events ChangesetEvents
+		want   int
+	}{
+		"no events": {
+			events: nil,
+			want:   0,
+		},
+		"single approval": {
+			events: ChangesetEvents{review(1, "alice", "APPROVED")},
+			want:   1,
+		},
+		"approvals by distinct authors": {
+			events: ChangesetEvents{
+				review(1, "alice", "APPROVED"),
+				review(2, "bob", "APPROVED"),
+				review(3, "alice", "APPROVED"),
+			},
+			want: 2,
+		},
+		"comments do not override approvals": {
+			events: ChangesetEvents{
+				review(1, "alice", "APPROVED"),
+				review(2, "alice", "COMMENTED"),
+			},
+			want: 1,
+		},
+		"changes requested after approval": {
+			events: ChangesetEvents{
+				review(1, "alice", "APPROVED"),
+				review(2, "bob", "APPROVED"),
+				review(3, "alice", "CHANGES_REQUESTED"),
+			},
+			want: 1,
+		},
+		"dismissed approval": {
+			events: ChangesetEvents{
+				review(1, "alice", "APPROVED"),
+				dismissed(2, "alice"),
+			},
+			want: 0,
+		},
+		"unsorted events": {
+			events: ChangesetEvents{
+				review(3, "alice", "APPROVED"),
+				review(1, "alice", "CHANGES_REQUESTED"),
+			},
+			want: 1,
+		},
+		"deleted authors": {
+			events: ChangesetEvents{review(1, "", "APPROVED")},
+			want:   0,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			have, err := CountApprovals(tc.events)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if have != tc.want {
+				t.Errorf("unexpected number of approvals: have=%d want=%d", have, tc.want)
+			}
+		})
+	}
+}
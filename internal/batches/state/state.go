@@ -10,9 +10,11 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/batches/sources/azuredevops"
 	gerritbatches "github.com/sourcegraph/sourcegraph/internal/batches/sources/gerrit"
+	giteabatches "github.com/sourcegraph/sourcegraph/internal/batches/sources/gitea"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	adobatches "github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 
 	"github.com/sourcegraph/go-diff/diff"
@@ -124,6 +126,8 @@ func computeCheckState(c *btypes.Changeset, events ChangesetEvents) btypes.Chang
 		return computeAzureDevOpsBuildState(m)
 	case *gerritbatches.AnnotatedChange:
 		return computeGerritBuildState(m)
+	case *giteabatches.AnnotatedPullRequest:
+		return computeGiteaBuildState(m)
 	case *protocol.PerforceChangelistState:
 		// Perforce doesn't have builds built-in, its better to be explicit by still
 		// including this case for clarity.
@@ -563,6 +567,38 @@ func parseGitLabPipelineStatus(status gitlab.PipelineStatus) btypes.ChangesetChe
 	}
 }
 
+func computeGiteaBuildState(apr *giteabatches.AnnotatedPullRequest) btypes.ChangesetCheckState {
+	if apr.Status == nil {
+		return btypes.ChangesetCheckStateUnknown
+	}
+
+	stateMap := make(map[string]btypes.ChangesetCheckState)
+
+	// States from last sync.
+	for _, status := range apr.Status.Statuses {
+		stateMap[status.Key()] = parseGiteaBuildState(status.State)
+	}
+
+	states := make([]btypes.ChangesetCheckState, 0, len(stateMap))
+	for _, v := range stateMap {
+		states = append(states, v)
+	}
+	return combineCheckStates(states)
+}
+
+func parseGiteaBuildState(s gitea.StatusState) btypes.ChangesetCheckState {
+	switch s {
+	case gitea.StatusStateError, gitea.StatusStateFailure:
+		return btypes.ChangesetCheckStateFailed
+	case gitea.StatusStatePending:
+		return btypes.ChangesetCheckStatePending
+	case gitea.StatusStateSuccess, gitea.StatusStateWarning:
+		return btypes.ChangesetCheckStatePassed
+	default:
+		return btypes.ChangesetCheckStateUnknown
+	}
+}
+
 // computeSingleChangesetExternalState of a Changeset based on the metadata.
 // It does NOT reflect the final calculated state, use `ExternalState` instead.
 func computeSingleChangesetExternalState(c *btypes.Changeset) (s btypes.ChangesetExternalState, err error) {
@@ -639,6 +675,25 @@ func computeSingleChangesetExternalState(c *btypes.Changeset) (s btypes.Changese
 		default:
 			return "", errors.Errorf("unknown Gerrit Change state: %s", m.Change.Status)
 		}
+	case *giteabatches.AnnotatedPullRequest:
+		switch m.State {
+		case gitea.PullRequestStateClosed:
+			// Gitea doesn't have a separate merged state, merged pull requests
+			// are closed.
+			if m.HasMerged {
+				s = btypes.ChangesetExternalStateMerged
+			} else {
+				s = btypes.ChangesetExternalStateClosed
+			}
+		case gitea.PullRequestStateOpen:
+			if m.Draft() {
+				s = btypes.ChangesetExternalStateDraft
+			} else {
+				s = btypes.ChangesetExternalStateOpen
+			}
+		default:
+			return "", errors.Errorf("unknown Gitea pull request state: %s", m.State)
+		}
 	case *protocol.PerforceChangelist:
 		switch m.State {
 		case protocol.PerforceChangelistStateClosed:
@@ -758,6 +813,29 @@ func computeSingleChangesetReviewState(c *btypes.Changeset) (s btypes.ChangesetR
 			}
 
 		}
+	case *giteabatches.AnnotatedPullRequest:
+		// Reviewers can submit several reviews on the same pull request, so
+		// only the latest approval or change request of each reviewer counts.
+		latest := map[int64]gitea.ReviewState{}
+		for _, review := range m.Reviews {
+			if review.Dismissed || review.Reviewer == nil {
+				continue
+			}
+			switch review.State {
+			case gitea.ReviewStateApproved, gitea.ReviewStateRequestChanges:
+				latest[review.Reviewer.ID] = review.State
+			}
+		}
+		if len(latest) == 0 {
+			states[btypes.ChangesetReviewStatePending] = true
+		}
+		for _, state := range latest {
+			if state == gitea.ReviewStateApproved {
+				states[btypes.ChangesetReviewStateApproved] = true
+			} else {
+				states[btypes.ChangesetReviewStateChangesRequested] = true
+			}
+		}
 	case *protocol.PerforceChangelist:
 		states[btypes.ChangesetReviewStatePending] = true
 	default:
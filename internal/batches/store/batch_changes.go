@@ -37,6 +37,10 @@ var batchChangeColumns = []*sqlf.Query{
 	sqlf.Sprintf("batch_changes.updated_at"),
 	sqlf.Sprintf("batch_changes.closed_at"),
 	sqlf.Sprintf("batch_changes.batch_spec_id"),
+	sqlf.Sprintf("batch_changes.auto_rebase"),
+	sqlf.Sprintf("batch_changes.rollout_wave"),
+	sqlf.Sprintf("batch_changes.rollout_paused"),
+	sqlf.Sprintf("batch_changes.rollout_pause_reason"),
 }
 
 // batchChangeInsertColumns is the list of batch changes columns that are
@@ -54,6 +58,10 @@ var batchChangeInsertColumns = []*sqlf.Query{
 	sqlf.Sprintf("updated_at"),
 	sqlf.Sprintf("closed_at"),
 	sqlf.Sprintf("batch_spec_id"),
+	sqlf.Sprintf("auto_rebase"),
+	sqlf.Sprintf("rollout_wave"),
+	sqlf.Sprintf("rollout_paused"),
+	sqlf.Sprintf("rollout_pause_reason"),
 }
 
 func (s *Store) UpsertBatchChange(ctx context.Context, c *btypes.BatchChange) (err error) {
@@ -76,10 +84,10 @@ func (s *Store) UpsertBatchChange(ctx context.Context, c *btypes.BatchChange) (e
 
 var upsertBatchChangeQueryFmtstr = `
 INSERT INTO batch_changes (%s)
-VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 ON CONFLICT (%s) WHERE %s
 DO UPDATE SET
-(%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+(%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 RETURNING %s
 `
 
@@ -119,6 +127,10 @@ func (s *Store) upsertBatchChangeQuery(c *btypes.BatchChange) *sqlf.Query {
 		c.UpdatedAt,
 		dbutil.NullTimeColumn(c.ClosedAt),
 		c.BatchSpecID,
+		c.AutoRebase,
+		c.RolloutWave,
+		c.RolloutPaused,
+		dbutil.NewNullString(c.RolloutPauseReason),
 		sqlf.Join(conflictTarget, ", "),
 		predicate,
 		sqlf.Join(batchChangeInsertColumns, ", "),
@@ -133,6 +145,10 @@ func (s *Store) upsertBatchChangeQuery(c *btypes.BatchChange) *sqlf.Query {
 		c.UpdatedAt,
 		dbutil.NullTimeColumn(c.ClosedAt),
 		c.BatchSpecID,
+		c.AutoRebase,
+		c.RolloutWave,
+		c.RolloutPaused,
+		dbutil.NewNullString(c.RolloutPauseReason),
 		sqlf.Join(batchChangeColumns, ", "),
 	)
 }
@@ -158,7 +174,7 @@ func (s *Store) CreateBatchChange(ctx context.Context, c *btypes.BatchChange) (e
 
 var createBatchChangeQueryFmtstr = `
 INSERT INTO batch_changes (%s)
-VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 RETURNING %s
 `
 
@@ -185,6 +201,10 @@ func (s *Store) createBatchChangeQuery(c *btypes.BatchChange) *sqlf.Query {
 		c.UpdatedAt,
 		dbutil.NullTimeColumn(c.ClosedAt),
 		c.BatchSpecID,
+		c.AutoRebase,
+		c.RolloutWave,
+		c.RolloutPaused,
+		dbutil.NewNullString(c.RolloutPauseReason),
 		sqlf.Join(batchChangeColumns, ", "),
 	)
 }
@@ -210,7 +230,7 @@ func (s *Store) UpdateBatchChange(ctx context.Context, c *btypes.BatchChange) (e
 
 var updateBatchChangeQueryFmtstr = `
 UPDATE batch_changes
-SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 WHERE id = %s
 RETURNING %s
 `
@@ -232,6 +252,10 @@ func (s *Store) updateBatchChangeQuery(c *btypes.BatchChange) *sqlf.Query {
 		c.UpdatedAt,
 		dbutil.NullTimeColumn(c.ClosedAt),
 		c.BatchSpecID,
+		c.AutoRebase,
+		c.RolloutWave,
+		c.RolloutPaused,
+		dbutil.NewNullString(c.RolloutPauseReason),
 		c.ID,
 		sqlf.Join(batchChangeColumns, ", "),
 	)
@@ -402,6 +426,10 @@ func (s *Store) GetBatchChange(ctx context.Context, opts GetBatchChangeOpts) (bc
 			&c.UpdatedAt,
 			&dbutil.NullTime{Time: &c.ClosedAt},
 			&c.BatchSpecID,
+			&c.AutoRebase,
+			&c.RolloutWave,
+			&c.RolloutPaused,
+			&dbutil.NullString{S: &c.RolloutPauseReason},
 			// Namespace deleted values
 			&dbutil.NullTime{Time: &userDeletedAt},
 			&dbutil.NullTime{Time: &orgDeletedAt},
@@ -710,6 +738,10 @@ func scanBatchChange(c *btypes.BatchChange, s dbutil.Scanner) error {
 		&c.UpdatedAt,
 		&dbutil.NullTime{Time: &c.ClosedAt},
 		&c.BatchSpecID,
+		&c.AutoRebase,
+		&c.RolloutWave,
+		&c.RolloutPaused,
+		&dbutil.NullString{S: &c.RolloutPauseReason},
 	)
 }
 
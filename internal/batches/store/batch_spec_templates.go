@@ -0,0 +1,458 @@
+package store
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"go.opentelemetry.io/otel/attribute"
+
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ErrBatchSpecTemplateNameNotUnique is returned when a batch spec template is
+// created with a name that is already taken in its namespace.
+var ErrBatchSpecTemplateNameNotUnique = errors.New("a batch spec template with this name already exists in this namespace")
+
+// batchSpecTemplateColumns are used by the batch spec template related Store
+// methods to query and create batch spec templates.
+var batchSpecTemplateColumns = []*sqlf.Query{
+	sqlf.Sprintf("batch_spec_templates.id"),
+	sqlf.Sprintf("batch_spec_templates.name"),
+	sqlf.Sprintf("batch_spec_templates.description"),
+	sqlf.Sprintf("batch_spec_templates.namespace_user_id"),
+	sqlf.Sprintf("batch_spec_templates.namespace_org_id"),
+	sqlf.Sprintf("batch_spec_templates.creator_id"),
+	sqlf.Sprintf("batch_spec_templates.created_at"),
+	sqlf.Sprintf("batch_spec_templates.updated_at"),
+}
+
+// batchSpecTemplateVersionColumns are used by the batch spec template version
+// related Store methods to query and create batch spec template versions.
+var batchSpecTemplateVersionColumns = []*sqlf.Query{
+	sqlf.Sprintf("batch_spec_template_versions.id"),
+	sqlf.Sprintf("batch_spec_template_versions.template_id"),
+	sqlf.Sprintf("batch_spec_template_versions.version"),
+	sqlf.Sprintf("batch_spec_template_versions.raw_template"),
+	sqlf.Sprintf("batch_spec_template_versions.creator_id"),
+	sqlf.Sprintf("batch_spec_template_versions.created_at"),
+}
+
+// CreateBatchSpecTemplate creates the given batch spec template.
+func (s *Store) CreateBatchSpecTemplate(ctx context.Context, t *btypes.BatchSpecTemplate) (err error) {
+	ctx, _, endObservation := s.operations.createBatchSpecTemplate.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	err = s.query(ctx, s.createBatchSpecTemplateQuery(t), func(sc dbutil.Scanner) error {
+		return scanBatchSpecTemplate(t, sc)
+	})
+	if isUniqueConstraintViolation(err, "batch_spec_templates_unique_user_id") ||
+		isUniqueConstraintViolation(err, "batch_spec_templates_unique_org_id") ||
+		isUniqueConstraintViolation(err, "batch_spec_templates_unique_global") {
+		return ErrBatchSpecTemplateNameNotUnique
+	}
+	return err
+}
+
+var createBatchSpecTemplateQueryFmtstr = `
+INSERT INTO batch_spec_templates (name, description, namespace_user_id, namespace_org_id, creator_id, created_at, updated_at)
+VALUES (%s, %s, %s, %s, %s, %s, %s)
+RETURNING %s
+`
+
+func (s *Store) createBatchSpecTemplateQuery(t *btypes.BatchSpecTemplate) *sqlf.Query {
+	if t.CreatedAt.IsZero() {
+		t.CreatedAt = s.now()
+	}
+
+	if t.UpdatedAt.IsZero() {
+		t.UpdatedAt = t.CreatedAt
+	}
+
+	return sqlf.Sprintf(
+		createBatchSpecTemplateQueryFmtstr,
+		t.Name,
+		t.Description,
+		dbutil.NullInt32Column(t.NamespaceUserID),
+		dbutil.NullInt32Column(t.NamespaceOrgID),
+		dbutil.NullInt32Column(t.CreatorID),
+		t.CreatedAt,
+		t.UpdatedAt,
+		sqlf.Join(batchSpecTemplateColumns, ", "),
+	)
+}
+
+// UpdateBatchSpecTemplate updates the description of the given batch spec
+// template and bumps its updated_at.
+func (s *Store) UpdateBatchSpecTemplate(ctx context.Context, t *btypes.BatchSpecTemplate) (err error) {
+	ctx, _, endObservation := s.operations.updateBatchSpecTemplate.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("ID", int(t.ID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	t.UpdatedAt = s.now()
+
+	return s.query(ctx, sqlf.Sprintf(
+		updateBatchSpecTemplateQueryFmtstr,
+		t.Description,
+		t.UpdatedAt,
+		t.ID,
+		sqlf.Join(batchSpecTemplateColumns, ", "),
+	), func(sc dbutil.Scanner) error {
+		return scanBatchSpecTemplate(t, sc)
+	})
+}
+
+var updateBatchSpecTemplateQueryFmtstr = `
+UPDATE batch_spec_templates
+SET description = %s, updated_at = %s
+WHERE id = %s
+RETURNING %s
+`
+
+// DeleteBatchSpecTemplate deletes the batch spec template with the given ID
+// and all of its versions. Batch specs that were instantiated from one of its
+// versions are kept.
+func (s *Store) DeleteBatchSpecTemplate(ctx context.Context, id int64) (err error) {
+	ctx, _, endObservation := s.operations.deleteBatchSpecTemplate.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("ID", int(id)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.Exec(ctx, sqlf.Sprintf(deleteBatchSpecTemplateQueryFmtstr, id))
+}
+
+var deleteBatchSpecTemplateQueryFmtstr = `
+DELETE FROM batch_spec_templates WHERE id = %s
+`
+
+// GetBatchSpecTemplateOpts captures the query options needed for getting a
+// batch spec template.
+type GetBatchSpecTemplateOpts struct {
+	ID int64
+
+	// Name, if set, gets the template with the given name in the namespace
+	// given by NamespaceUserID and NamespaceOrgID. If neither is set, the
+	// global template with the given name is returned.
+	Name            string
+	NamespaceUserID int32
+	NamespaceOrgID  int32
+}
+
+// GetBatchSpecTemplate gets a batch spec template matching the given options.
+func (s *Store) GetBatchSpecTemplate(ctx context.Context, opts GetBatchSpecTemplateOpts) (t *btypes.BatchSpecTemplate, err error) {
+	ctx, _, endObservation := s.operations.getBatchSpecTemplate.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("ID", int(opts.ID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	var tmpl btypes.BatchSpecTemplate
+	err = s.query(ctx, getBatchSpecTemplateQuery(&opts), func(sc dbutil.Scanner) error {
+		return scanBatchSpecTemplate(&tmpl, sc)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if tmpl.ID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &tmpl, nil
+}
+
+var getBatchSpecTemplateQueryFmtstr = `
+SELECT %s FROM batch_spec_templates
+WHERE %s
+LIMIT 1
+`
+
+func getBatchSpecTemplateQuery(opts *GetBatchSpecTemplateOpts) *sqlf.Query {
+	var preds []*sqlf.Query
+	if opts.ID != 0 {
+		preds = append(preds, sqlf.Sprintf("batch_spec_templates.id = %s", opts.ID))
+	}
+
+	if opts.Name != "" {
+		preds = append(preds, sqlf.Sprintf("batch_spec_templates.name = %s", opts.Name))
+		preds = append(preds, namespacePred("batch_spec_templates", opts.NamespaceUserID, opts.NamespaceOrgID))
+	}
+
+	if len(preds) == 0 {
+		preds = append(preds, sqlf.Sprintf("TRUE"))
+	}
+
+	return sqlf.Sprintf(
+		getBatchSpecTemplateQueryFmtstr,
+		sqlf.Join(batchSpecTemplateColumns, ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+}
+
+// ListBatchSpecTemplatesOpts captures the query options needed for listing
+// batch spec templates.
+type ListBatchSpecTemplatesOpts struct {
+	LimitOpts
+	Cursor int64
+
+	// NamespaceUserID and NamespaceOrgID, if set, only list the templates in
+	// the given namespace.
+	NamespaceUserID int32
+	NamespaceOrgID  int32
+	// OnlyGlobal only lists the templates that don't belong to a namespace.
+	OnlyGlobal bool
+
+	// VisibleToUserID, if set, only lists the templates that are global, in
+	// the namespace of the given user, or in an org the user is a member of.
+	VisibleToUserID int32
+}
+
+// ListBatchSpecTemplates lists batch spec templates with the given filters.
+func (s *Store) ListBatchSpecTemplates(ctx context.Context, opts ListBatchSpecTemplatesOpts) (ts []*btypes.BatchSpecTemplate, next int64, err error) {
+	ctx, _, endObservation := s.operations.listBatchSpecTemplates.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	ts = make([]*btypes.BatchSpecTemplate, 0, opts.DBLimit())
+	err = s.query(ctx, listBatchSpecTemplatesQuery(&opts), func(sc dbutil.Scanner) error {
+		var t btypes.BatchSpecTemplate
+		if err := scanBatchSpecTemplate(&t, sc); err != nil {
+			return err
+		}
+		ts = append(ts, &t)
+		return nil
+	})
+
+	if opts.Limit != 0 && len(ts) == opts.DBLimit() {
+		next = ts[len(ts)-1].ID
+		ts = ts[:len(ts)-1]
+	}
+
+	return ts, next, err
+}
+
+var listBatchSpecTemplatesQueryFmtstr = `
+SELECT %s FROM batch_spec_templates
+WHERE %s
+ORDER BY id DESC
+`
+
+func listBatchSpecTemplatesQuery(opts *ListBatchSpecTemplatesOpts) *sqlf.Query {
+	preds := listBatchSpecTemplatesPreds(opts.NamespaceUserID, opts.NamespaceOrgID, opts.OnlyGlobal, opts.VisibleToUserID)
+
+	if opts.Cursor != 0 {
+		preds = append(preds, sqlf.Sprintf("batch_spec_templates.id <= %s", opts.Cursor))
+	}
+
+	return sqlf.Sprintf(
+		listBatchSpecTemplatesQueryFmtstr+opts.LimitOpts.ToDB(),
+		sqlf.Join(batchSpecTemplateColumns, ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+}
+
+// CountBatchSpecTemplates returns the number of batch spec templates that
+// match the filters of the given options. Cursor and limit are ignored.
+func (s *Store) CountBatchSpecTemplates(ctx context.Context, opts ListBatchSpecTemplatesOpts) (count int, err error) {
+	ctx, _, endObservation := s.operations.countBatchSpecTemplates.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	preds := listBatchSpecTemplatesPreds(opts.NamespaceUserID, opts.NamespaceOrgID, opts.OnlyGlobal, opts.VisibleToUserID)
+
+	return s.queryCount(ctx, sqlf.Sprintf(countBatchSpecTemplatesQueryFmtstr, sqlf.Join(preds, "\n AND ")))
+}
+
+var countBatchSpecTemplatesQueryFmtstr = `
+SELECT COUNT(*) FROM batch_spec_templates
+WHERE %s
+`
+
+func listBatchSpecTemplatesPreds(namespaceUserID, namespaceOrgID int32, onlyGlobal bool, visibleToUserID int32) []*sqlf.Query {
+	preds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+
+	if namespaceUserID != 0 || namespaceOrgID != 0 || onlyGlobal {
+		preds = append(preds, namespacePred("batch_spec_templates", namespaceUserID, namespaceOrgID))
+	}
+
+	if visibleToUserID != 0 {
+		preds = append(preds, sqlf.Sprintf(`(
+	(batch_spec_templates.namespace_user_id IS NULL AND batch_spec_templates.namespace_org_id IS NULL)
+	OR batch_spec_templates.namespace_user_id = %s
+	OR EXISTS (SELECT 1 FROM org_members WHERE org_members.org_id = batch_spec_templates.namespace_org_id AND org_members.user_id = %s)
+)`, visibleToUserID, visibleToUserID))
+	}
+
+	return preds
+}
+
+// namespacePred returns a predicate that matches the rows of the given table
+// that belong to the given namespace, or to no namespace if neither ID is set.
+func namespacePred(table string, namespaceUserID, namespaceOrgID int32) *sqlf.Query {
+	switch {
+	case namespaceUserID != 0:
+		return sqlf.Sprintf(table+".namespace_user_id = %s", namespaceUserID)
+	case namespaceOrgID != 0:
+		return sqlf.Sprintf(table+".namespace_org_id = %s", namespaceOrgID)
+	default:
+		return sqlf.Sprintf(table + ".namespace_user_id IS NULL AND " + table + ".namespace_org_id IS NULL")
+	}
+}
+
+// CreateBatchSpecTemplateVersion creates the given batch spec template
+// version. The version number is assigned by the database and is one higher
+// than the latest version of the template.
+func (s *Store) CreateBatchSpecTemplateVersion(ctx context.Context, v *btypes.BatchSpecTemplateVersion) (err error) {
+	ctx, _, endObservation := s.operations.createBatchSpecTemplateVersion.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("templateID", int(v.TemplateID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if v.CreatedAt.IsZero() {
+		v.CreatedAt = s.now()
+	}
+
+	q := sqlf.Sprintf(
+		createBatchSpecTemplateVersionQueryFmtstr,
+		v.TemplateID,
+		v.RawTemplate,
+		dbutil.NullInt32Column(v.CreatorID),
+		v.CreatedAt,
+		v.TemplateID,
+		sqlf.Join(batchSpecTemplateVersionColumns, ", "),
+	)
+	return s.query(ctx, q, func(sc dbutil.Scanner) error {
+		return scanBatchSpecTemplateVersion(v, sc)
+	})
+}
+
+var createBatchSpecTemplateVersionQueryFmtstr = `
+INSERT INTO batch_spec_template_versions (template_id, version, raw_template, creator_id, created_at)
+SELECT %s, COALESCE(MAX(version), 0) + 1, %s, %s, %s
+FROM batch_spec_template_versions
+WHERE template_id = %s
+RETURNING %s
+`
+
+// GetBatchSpecTemplateVersionOpts captures the query options needed for
+// getting a batch spec template version.
+type GetBatchSpecTemplateVersionOpts struct {
+	ID int64
+
+	// TemplateID gets a version of the given template. Unless Version is set,
+	// the latest version is returned.
+	TemplateID int64
+	Version    int32
+}
+
+// GetBatchSpecTemplateVersion gets a batch spec template version matching the
+// given options.
+func (s *Store) GetBatchSpecTemplateVersion(ctx context.Context, opts GetBatchSpecTemplateVersionOpts) (v *btypes.BatchSpecTemplateVersion, err error) {
+	ctx, _, endObservation := s.operations.getBatchSpecTemplateVersion.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("ID", int(opts.ID)),
+		attribute.Int("templateID", int(opts.TemplateID)),
+		attribute.Int("version", int(opts.Version)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	var version btypes.BatchSpecTemplateVersion
+	err = s.query(ctx, getBatchSpecTemplateVersionQuery(&opts), func(sc dbutil.Scanner) error {
+		return scanBatchSpecTemplateVersion(&version, sc)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if version.ID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &version, nil
+}
+
+var getBatchSpecTemplateVersionQueryFmtstr = `
+SELECT %s FROM batch_spec_template_versions
+WHERE %s
+ORDER BY version DESC
+LIMIT 1
+`
+
+func getBatchSpecTemplateVersionQuery(opts *GetBatchSpecTemplateVersionOpts) *sqlf.Query {
+	var preds []*sqlf.Query
+	if opts.ID != 0 {
+		preds = append(preds, sqlf.Sprintf("batch_spec_template_versions.id = %s", opts.ID))
+	}
+
+	if opts.TemplateID != 0 {
+		preds = append(preds, sqlf.Sprintf("batch_spec_template_versions.template_id = %s", opts.TemplateID))
+	}
+
+	if opts.Version != 0 {
+		preds = append(preds, sqlf.Sprintf("batch_spec_template_versions.version = %s", opts.Version))
+	}
+
+	if len(preds) == 0 {
+		preds = append(preds, sqlf.Sprintf("TRUE"))
+	}
+
+	return sqlf.Sprintf(
+		getBatchSpecTemplateVersionQueryFmtstr,
+		sqlf.Join(batchSpecTemplateVersionColumns, ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+}
+
+// ListBatchSpecTemplateVersions lists all versions of the given batch spec
+// template, newest first.
+func (s *Store) ListBatchSpecTemplateVersions(ctx context.Context, templateID int64) (vs []*btypes.BatchSpecTemplateVersion, err error) {
+	ctx, _, endObservation := s.operations.listBatchSpecTemplateVersions.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("templateID", int(templateID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(
+		listBatchSpecTemplateVersionsQueryFmtstr,
+		sqlf.Join(batchSpecTemplateVersionColumns, ", "),
+		templateID,
+	)
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		var v btypes.BatchSpecTemplateVersion
+		if err := scanBatchSpecTemplateVersion(&v, sc); err != nil {
+			return err
+		}
+		vs = append(vs, &v)
+		return nil
+	})
+
+	return vs, err
+}
+
+var listBatchSpecTemplateVersionsQueryFmtstr = `
+SELECT %s FROM batch_spec_template_versions
+WHERE template_id = %s
+ORDER BY version DESC
+`
+
+func scanBatchSpecTemplate(t *btypes.BatchSpecTemplate, s dbutil.Scanner) error {
+	return s.Scan(
+		&t.ID,
+		&t.Name,
+		&t.Description,
+		&dbutil.NullInt32{N: &t.NamespaceUserID},
+		&dbutil.NullInt32{N: &t.NamespaceOrgID},
+		&dbutil.NullInt32{N: &t.CreatorID},
+		&t.CreatedAt,
+		&t.UpdatedAt,
+	)
+}
+
+func scanBatchSpecTemplateVersion(v *btypes.BatchSpecTemplateVersion, s dbutil.Scanner) error {
+	return s.Scan(
+		&v.ID,
+		&v.TemplateID,
+		&v.Version,
+		&v.RawTemplate,
+		&dbutil.NullInt32{N: &v.CreatorID},
+		&v.CreatedAt,
+	)
+}
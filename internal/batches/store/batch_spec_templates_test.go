@@ -0,0 +1,184 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	bt "github.com/sourcegraph/sourcegraph/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+)
+
+const testRawBatchSpecTemplate = `
+name: hello
+parameters:
+  - name: query
+    type: repositoryQuery
+spec: |
+  name: hello
+  on:
+    - repositoriesMatchingQuery: ${{ inputs.query }}
+`
+
+func testStoreBatchSpecTemplates(t *testing.T, ctx context.Context, s *Store, clock bt.Clock) {
+	user := bt.CreateTestUser(t, s.DatabaseDB(), false)
+	otherUser := bt.CreateTestUser(t, s.DatabaseDB(), false)
+
+	global := &btypes.BatchSpecTemplate{Name: "hello", Description: "global", CreatorID: user.ID}
+	userTemplate := &btypes.BatchSpecTemplate{Name: "hello", Description: "user", NamespaceUserID: user.ID, CreatorID: user.ID}
+	otherUserTemplate := &btypes.BatchSpecTemplate{Name: "hello", NamespaceUserID: otherUser.ID, CreatorID: otherUser.ID}
+
+	t.Run("Create", func(t *testing.T) {
+		for _, tmpl := range []*btypes.BatchSpecTemplate{global, userTemplate, otherUserTemplate} {
+			if err := s.CreateBatchSpecTemplate(ctx, tmpl); err != nil {
+				t.Fatal(err)
+			}
+			if tmpl.ID == 0 {
+				t.Fatal("ID should not be zero")
+			}
+			if have, want := tmpl.CreatedAt, clock.Now(); !have.Equal(want) {
+				t.Fatalf("wrong CreatedAt. want=%s, have=%s", want, have)
+			}
+		}
+	})
+
+	t.Run("Create duplicate name", func(t *testing.T) {
+		err := s.CreateBatchSpecTemplate(ctx, &btypes.BatchSpecTemplate{Name: "hello", NamespaceUserID: user.ID})
+		if err != ErrBatchSpecTemplateNameNotUnique {
+			t.Fatalf("wrong error. want=%s, have=%v", ErrBatchSpecTemplateNameNotUnique, err)
+		}
+
+		err = s.CreateBatchSpecTemplate(ctx, &btypes.BatchSpecTemplate{Name: "hello"})
+		if err != ErrBatchSpecTemplateNameNotUnique {
+			t.Fatalf("wrong error. want=%s, have=%v", ErrBatchSpecTemplateNameNotUnique, err)
+		}
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		have, err := s.GetBatchSpecTemplate(ctx, GetBatchSpecTemplateOpts{ID: userTemplate.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(userTemplate, have); diff != "" {
+			t.Fatal(diff)
+		}
+
+		have, err = s.GetBatchSpecTemplate(ctx, GetBatchSpecTemplateOpts{Name: "hello"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(global, have); diff != "" {
+			t.Fatal(diff)
+		}
+
+		_, err = s.GetBatchSpecTemplate(ctx, GetBatchSpecTemplateOpts{ID: otherUserTemplate.ID + 1000})
+		if err != ErrNoResults {
+			t.Fatalf("wrong error. want=%s, have=%v", ErrNoResults, err)
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		have, _, err := s.ListBatchSpecTemplates(ctx, ListBatchSpecTemplatesOpts{VisibleToUserID: user.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]*btypes.BatchSpecTemplate{userTemplate, global}, have); diff != "" {
+			t.Fatal(diff)
+		}
+
+		have, _, err = s.ListBatchSpecTemplates(ctx, ListBatchSpecTemplatesOpts{OnlyGlobal: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]*btypes.BatchSpecTemplate{global}, have); diff != "" {
+			t.Fatal(diff)
+		}
+
+		have, next, err := s.ListBatchSpecTemplates(ctx, ListBatchSpecTemplatesOpts{LimitOpts: LimitOpts{Limit: 1}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]*btypes.BatchSpecTemplate{otherUserTemplate}, have); diff != "" {
+			t.Fatal(diff)
+		}
+		if have, want := next, userTemplate.ID; have != want {
+			t.Fatalf("wrong cursor. want=%d, have=%d", want, have)
+		}
+
+		count, err := s.CountBatchSpecTemplates(ctx, ListBatchSpecTemplatesOpts{NamespaceUserID: otherUser.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count != 1 {
+			t.Fatalf("wrong count. want=1, have=%d", count)
+		}
+	})
+
+	t.Run("Versions", func(t *testing.T) {
+		var versions []*btypes.BatchSpecTemplateVersion
+		for i := 0; i < 2; i++ {
+			v := &btypes.BatchSpecTemplateVersion{TemplateID: userTemplate.ID, RawTemplate: testRawBatchSpecTemplate, CreatorID: user.ID}
+			if err := s.CreateBatchSpecTemplateVersion(ctx, v); err != nil {
+				t.Fatal(err)
+			}
+			if have, want := v.Version, int32(i+1); have != want {
+				t.Fatalf("wrong version. want=%d, have=%d", want, have)
+			}
+			versions = append(versions, v)
+		}
+
+		latest, err := s.GetBatchSpecTemplateVersion(ctx, GetBatchSpecTemplateVersionOpts{TemplateID: userTemplate.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(versions[1], latest); diff != "" {
+			t.Fatal(diff)
+		}
+
+		first, err := s.GetBatchSpecTemplateVersion(ctx, GetBatchSpecTemplateVersionOpts{TemplateID: userTemplate.ID, Version: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(versions[0], first); diff != "" {
+			t.Fatal(diff)
+		}
+
+		have, err := s.ListBatchSpecTemplateVersions(ctx, userTemplate.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]*btypes.BatchSpecTemplateVersion{versions[1], versions[0]}, have); diff != "" {
+			t.Fatal(diff)
+		}
+
+		batchSpec := bt.CreateBatchSpec(t, ctx, s, "from-template", user.ID, 0)
+		batchSpec.TemplateVersionID = versions[0].ID
+		if err := s.UpdateBatchSpec(ctx, batchSpec); err != nil {
+			t.Fatal(err)
+		}
+		reloaded, err := s.GetBatchSpec(ctx, GetBatchSpecOpts{ID: batchSpec.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := reloaded.TemplateVersionID, versions[0].ID; have != want {
+			t.Fatalf("wrong template version. want=%d, have=%d", want, have)
+		}
+
+		// Deleting the template keeps the batch spec, but drops the
+		// reference to the deleted version.
+		if err := s.DeleteBatchSpecTemplate(ctx, userTemplate.ID); err != nil {
+			t.Fatal(err)
+		}
+		reloaded, err = s.GetBatchSpec(ctx, GetBatchSpecOpts{ID: batchSpec.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if reloaded.TemplateVersionID != 0 {
+			t.Fatalf("template version was not unset: %d", reloaded.TemplateVersionID)
+		}
+		if _, err := s.GetBatchSpecTemplateVersion(ctx, GetBatchSpecTemplateVersionOpts{ID: versions[0].ID}); err != ErrNoResults {
+			t.Fatalf("wrong error. want=%s, have=%v", ErrNoResults, err)
+		}
+	})
+}
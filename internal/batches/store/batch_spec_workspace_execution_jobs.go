@@ -351,6 +351,7 @@ func listBatchSpecWorkspaceExecutionJobsQuery(opts ListBatchSpecWorkspaceExecuti
 	if opts.BatchSpecID != 0 {
 		joins = append(joins, sqlf.Sprintf("JOIN batch_spec_workspaces ON batch_spec_workspace_execution_jobs.batch_spec_workspace_id = batch_spec_workspaces.id"))
 		preds = append(preds, sqlf.Sprintf("batch_spec_workspaces.batch_spec_id = %d", opts.BatchSpecID))
+		preds = append(preds, sqlf.Sprintf("NOT batch_spec_workspaces.rebase"))
 	}
 
 	if len(preds) == 0 {
@@ -455,6 +456,7 @@ func (s *Store) cancelBatchSpecWorkspaceExecutionJobQuery(opts CancelBatchSpecWo
 	if opts.BatchSpecID != 0 {
 		joins = append(joins, sqlf.Sprintf("JOIN batch_spec_workspaces ON batch_spec_workspaces.id = batch_spec_workspace_execution_jobs.batch_spec_workspace_id"))
 		preds = append(preds, sqlf.Sprintf("batch_spec_workspaces.batch_spec_id = %s", opts.BatchSpecID))
+		preds = append(preds, sqlf.Sprintf("NOT batch_spec_workspaces.rebase"))
 	}
 
 	return sqlf.Sprintf(
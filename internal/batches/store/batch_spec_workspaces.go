@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"sort"
+	"strconv"
 
 	"github.com/keegancsmith/sqlf"
 	"github.com/lib/pq"
@@ -37,6 +38,7 @@ var batchSpecWorkspaceInsertColumns = []string{
 	"skipped",
 	"cached_result_found",
 	"step_cache_results",
+	"rebase",
 
 	"created_at",
 	"updated_at",
@@ -61,6 +63,7 @@ var BatchSpecWorkspaceColums = SQLColumns{
 	"batch_spec_workspaces.skipped",
 	"batch_spec_workspaces.cached_result_found",
 	"batch_spec_workspaces.step_cache_results",
+	"batch_spec_workspaces.rebase",
 
 	"batch_spec_workspaces.created_at",
 	"batch_spec_workspaces.updated_at",
@@ -117,6 +120,7 @@ func (s *Store) CreateBatchSpecWorkspace(ctx context.Context, ws ...*btypes.Batc
 				wj.Skipped,
 				wj.CachedResultFound,
 				marshaledStepCacheResults,
+				wj.Rebase,
 				wj.CreatedAt,
 				wj.UpdatedAt,
 			); err != nil {
@@ -146,6 +150,9 @@ func (s *Store) CreateBatchSpecWorkspace(ctx context.Context, ws ...*btypes.Batc
 // GetBatchSpecWorkspaceOpts captures the query options needed for getting a BatchSpecWorkspace
 type GetBatchSpecWorkspaceOpts struct {
 	ID int64
+	// ChangesetSpecID selects the workspace whose execution produced the
+	// given changeset spec.
+	ChangesetSpecID int64
 }
 
 // GetBatchSpecWorkspace gets a BatchSpecWorkspace matching the given options.
@@ -181,7 +188,14 @@ LIMIT 1
 func getBatchSpecWorkspaceQuery(opts *GetBatchSpecWorkspaceOpts) *sqlf.Query {
 	preds := []*sqlf.Query{
 		sqlf.Sprintf("repo.deleted_at IS NULL"),
-		sqlf.Sprintf("batch_spec_workspaces.id = %s", opts.ID),
+	}
+
+	if opts.ID != 0 || opts.ChangesetSpecID == 0 {
+		preds = append(preds, sqlf.Sprintf("batch_spec_workspaces.id = %s", opts.ID))
+	}
+
+	if opts.ChangesetSpecID != 0 {
+		preds = append(preds, sqlf.Sprintf("batch_spec_workspaces.changeset_spec_ids ? %s", strconv.FormatInt(opts.ChangesetSpecID, 10)))
 	}
 
 	return sqlf.Sprintf(
@@ -195,7 +209,9 @@ func getBatchSpecWorkspaceQuery(opts *GetBatchSpecWorkspaceOpts) *sqlf.Query {
 // listing batch spec workspace jobs.
 type ListBatchSpecWorkspacesOpts struct {
 	LimitOpts
-	Cursor      int64
+	Cursor int64
+	// BatchSpecID selects the workspaces of the execution of the given batch
+	// spec, which doesn't include its rebase workspaces.
 	BatchSpecID int64
 	IDs         []int64
 
@@ -219,6 +235,7 @@ func (opts ListBatchSpecWorkspacesOpts) SQLConds(ctx context.Context, db databas
 
 	if opts.BatchSpecID != 0 {
 		preds = append(preds, sqlf.Sprintf("batch_spec_workspaces.batch_spec_id = %d", opts.BatchSpecID))
+		preds = append(preds, sqlf.Sprintf("NOT batch_spec_workspaces.rebase"))
 	}
 
 	if !forCount && opts.Cursor > 0 {
@@ -427,6 +444,7 @@ func getListRetryBatchSpecWorkspacesQuery(opts *ListRetryBatchSpecWorkspacesOpts
 	preds := []*sqlf.Query{
 		sqlf.Sprintf("repo.deleted_at IS NULL"),
 		sqlf.Sprintf("batch_spec_workspaces.batch_spec_id = %s", opts.BatchSpecID),
+		sqlf.Sprintf("NOT batch_spec_workspaces.rebase"),
 	}
 
 	if !opts.IncludeCompleted {
@@ -466,6 +484,7 @@ candidate_batch_spec_workspaces AS (
 		batch_spec_workspaces
 	WHERE
 		batch_spec_workspaces.batch_spec_id = (SELECT id FROM batch_spec)
+		AND NOT batch_spec_workspaces.rebase
 	ORDER BY id
 ),
 removable_changeset_specs AS (
@@ -521,6 +540,7 @@ func scanBatchSpecWorkspace(wj *btypes.BatchSpecWorkspace, s dbutil.Scanner) err
 		&wj.Skipped,
 		&wj.CachedResultFound,
 		&stepCacheResults,
+		&wj.Rebase,
 		&wj.CreatedAt,
 		&wj.UpdatedAt,
 	); err != nil {
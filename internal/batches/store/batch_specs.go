@@ -32,6 +32,7 @@ var batchSpecColumns = []*sqlf.Query{
 	sqlf.Sprintf("batch_specs.allow_ignored"),
 	sqlf.Sprintf("batch_specs.no_cache"),
 	sqlf.Sprintf("batch_specs.batch_change_id"),
+	sqlf.Sprintf("batch_specs.batch_spec_template_version_id"),
 	sqlf.Sprintf("batch_specs.created_at"),
 	sqlf.Sprintf("batch_specs.updated_at"),
 }
@@ -50,11 +51,12 @@ var batchSpecInsertColumns = []*sqlf.Query{
 	sqlf.Sprintf("allow_ignored"),
 	sqlf.Sprintf("no_cache"),
 	sqlf.Sprintf("batch_change_id"),
+	sqlf.Sprintf("batch_spec_template_version_id"),
 	sqlf.Sprintf("created_at"),
 	sqlf.Sprintf("updated_at"),
 }
 
-const batchSpecInsertColsFmt = `(%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)`
+const batchSpecInsertColsFmt = `(%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)`
 
 // CreateBatchSpec creates the given BatchSpec.
 func (s *Store) CreateBatchSpec(ctx context.Context, c *btypes.BatchSpec) (err error) {
@@ -107,6 +109,7 @@ func (s *Store) createBatchSpecQuery(c *btypes.BatchSpec) (*sqlf.Query, error) {
 		c.AllowIgnored,
 		c.NoCache,
 		dbutil.NullInt64Column(c.BatchChangeID),
+		dbutil.NullInt64Column(c.TemplateVersionID),
 		c.CreatedAt,
 		c.UpdatedAt,
 		sqlf.Join(batchSpecColumns, ", "),
@@ -158,6 +161,7 @@ func (s *Store) updateBatchSpecQuery(c *btypes.BatchSpec) (*sqlf.Query, error) {
 		c.AllowIgnored,
 		c.NoCache,
 		dbutil.NullInt64Column(c.BatchChangeID),
+		dbutil.NullInt64Column(c.TemplateVersionID),
 		c.CreatedAt,
 		c.UpdatedAt,
 		c.ID,
@@ -599,7 +603,8 @@ SELECT
 	COUNT(jobs.id) FILTER (WHERE jobs.state = 'processing' AND jobs.cancel = TRUE) AS canceling
 FROM batch_specs
 LEFT JOIN batch_spec_resolution_jobs res_job ON res_job.batch_spec_id = batch_specs.id
-LEFT JOIN batch_spec_workspaces ws ON ws.batch_spec_id = batch_specs.id
+-- Rebase workspaces are not part of the execution of the batch spec.
+LEFT JOIN batch_spec_workspaces ws ON ws.batch_spec_id = batch_specs.id AND NOT ws.rebase
 LEFT JOIN batch_spec_workspace_execution_jobs jobs ON jobs.batch_spec_workspace_id = ws.id
 WHERE
 	%s
@@ -669,6 +674,7 @@ func scanBatchSpec(c *btypes.BatchSpec, s dbutil.Scanner) error {
 		&c.AllowIgnored,
 		&c.NoCache,
 		&dbutil.NullInt64{N: &c.BatchChangeID},
+		&dbutil.NullInt64{N: &c.TemplateVersionID},
 		&c.CreatedAt,
 		&c.UpdatedAt,
 	)
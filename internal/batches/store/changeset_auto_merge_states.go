@@ -0,0 +1,139 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel/attribute"
+
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// changesetAutoMergeStateColumns are used by the changeset auto-merge state
+// related Store methods to query and upsert changeset auto-merge states.
+var changesetAutoMergeStateColumns = []*sqlf.Query{
+	sqlf.Sprintf("changeset_auto_merge_states.changeset_id"),
+	sqlf.Sprintf("changeset_auto_merge_states.reason"),
+	sqlf.Sprintf("changeset_auto_merge_states.backoff_seconds"),
+	sqlf.Sprintf("changeset_auto_merge_states.retry_after"),
+	sqlf.Sprintf("changeset_auto_merge_states.updated_at"),
+}
+
+// UpsertChangesetAutoMergeState creates or replaces the auto-merge state of the
+// changeset.
+func (s *Store) UpsertChangesetAutoMergeState(ctx context.Context, st *btypes.ChangesetAutoMergeState) (err error) {
+	ctx, _, endObservation := s.operations.upsertChangesetAutoMergeState.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("changesetID", int(st.ChangesetID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	st.UpdatedAt = s.now()
+
+	q := sqlf.Sprintf(
+		upsertChangesetAutoMergeStateQueryFmtstr,
+		st.ChangesetID,
+		st.Reason,
+		int64(st.Backoff/time.Second),
+		dbutil.NullTimeColumn(st.RetryAfter),
+		st.UpdatedAt,
+	)
+	return s.Exec(ctx, q)
+}
+
+var upsertChangesetAutoMergeStateQueryFmtstr = `
+INSERT INTO changeset_auto_merge_states (changeset_id, reason, backoff_seconds, retry_after, updated_at)
+VALUES (%s, %s, %s, %s, %s)
+ON CONFLICT (changeset_id) DO UPDATE SET
+	reason = EXCLUDED.reason,
+	backoff_seconds = EXCLUDED.backoff_seconds,
+	retry_after = EXCLUDED.retry_after,
+	updated_at = EXCLUDED.updated_at
+`
+
+// DeleteChangesetAutoMergeState deletes the auto-merge state of the changeset,
+// if any.
+func (s *Store) DeleteChangesetAutoMergeState(ctx context.Context, changesetID int64) (err error) {
+	ctx, _, endObservation := s.operations.deleteChangesetAutoMergeState.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("changesetID", int(changesetID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.Exec(ctx, sqlf.Sprintf(deleteChangesetAutoMergeStateQueryFmtstr, changesetID))
+}
+
+var deleteChangesetAutoMergeStateQueryFmtstr = `
+DELETE FROM changeset_auto_merge_states WHERE changeset_id = %s
+`
+
+// DeleteStaleChangesetAutoMergeStates deletes the auto-merge states of
+// changesets that are no longer open, and will therefore not be merged by the
+// auto-merger.
+func (s *Store) DeleteStaleChangesetAutoMergeStates(ctx context.Context) (err error) {
+	ctx, _, endObservation := s.operations.deleteStaleChangesetAutoMergeStates.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.Exec(ctx, sqlf.Sprintf(deleteStaleChangesetAutoMergeStatesQueryFmtstr, btypes.ChangesetExternalStateOpen))
+}
+
+var deleteStaleChangesetAutoMergeStatesQueryFmtstr = `
+DELETE FROM changeset_auto_merge_states
+USING changesets
+WHERE
+	changesets.id = changeset_auto_merge_states.changeset_id AND
+	changesets.external_state IS DISTINCT FROM %s
+`
+
+// ListChangesetAutoMergeStates lists the auto-merge states of the given
+// changesets. Changesets without a state are omitted.
+func (s *Store) ListChangesetAutoMergeStates(ctx context.Context, changesetIDs []int64) (sts []*btypes.ChangesetAutoMergeState, err error) {
+	ctx, _, endObservation := s.operations.listChangesetAutoMergeStates.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("count", len(changesetIDs)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if len(changesetIDs) == 0 {
+		return nil, nil
+	}
+
+	q := sqlf.Sprintf(
+		listChangesetAutoMergeStatesQueryFmtstr,
+		sqlf.Join(changesetAutoMergeStateColumns, ", "),
+		pq.Array(changesetIDs),
+	)
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		var st btypes.ChangesetAutoMergeState
+		if err := scanChangesetAutoMergeState(&st, sc); err != nil {
+			return err
+		}
+		sts = append(sts, &st)
+		return nil
+	})
+
+	return sts, err
+}
+
+var listChangesetAutoMergeStatesQueryFmtstr = `
+SELECT %s FROM changeset_auto_merge_states
+WHERE changeset_id = ANY (%s)
+ORDER BY changeset_id ASC
+`
+
+func scanChangesetAutoMergeState(st *btypes.ChangesetAutoMergeState, s dbutil.Scanner) error {
+	var backoffSeconds int64
+	if err := s.Scan(
+		&st.ChangesetID,
+		&st.Reason,
+		&backoffSeconds,
+		&dbutil.NullTime{Time: &st.RetryAfter},
+		&st.UpdatedAt,
+	); err != nil {
+		return err
+	}
+
+	st.Backoff = time.Duration(backoffSeconds) * time.Second
+	return nil
+}
@@ -0,0 +1,106 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/log/logtest"
+
+	bt "github.com/sourcegraph/sourcegraph/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+)
+
+func testStoreChangesetAutoMergeStates(t *testing.T, ctx context.Context, s *Store, clock bt.Clock) {
+	logger := logtest.Scoped(t)
+	repoStore := database.ReposWith(logger, s)
+	esStore := database.ExternalServicesWith(logger, s)
+
+	repo := bt.TestRepo(t, esStore, extsvc.KindGitHub)
+	if err := repoStore.Create(ctx, repo); err != nil {
+		t.Fatal(err)
+	}
+
+	user := bt.CreateTestUser(t, s.DatabaseDB(), false)
+	batchSpec := bt.CreateBatchSpec(t, ctx, s, "automerge", user.ID, 0)
+	batchChange := bt.CreateBatchChange(t, ctx, s, "automerge", user.ID, batchSpec.ID)
+	open := bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{Repo: repo.ID, OwnedByBatchChange: batchChange.ID, ExternalState: btypes.ChangesetExternalStateOpen})
+	merged := bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{Repo: repo.ID, OwnedByBatchChange: batchChange.ID, ExternalState: btypes.ChangesetExternalStateMerged})
+
+	retryAfter := clock.Now().Add(10 * time.Minute)
+
+	t.Run("Upsert", func(t *testing.T) {
+		for _, st := range []*btypes.ChangesetAutoMergeState{
+			{ChangesetID: open.ID, Reason: "checks have not passed"},
+			{ChangesetID: merged.ID, Reason: "not mergeable", Backoff: 5 * time.Minute, RetryAfter: clock.Now()},
+		} {
+			if err := s.UpsertChangesetAutoMergeState(ctx, st); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		// Upserting again replaces the existing state.
+		if err := s.UpsertChangesetAutoMergeState(ctx, &btypes.ChangesetAutoMergeState{
+			ChangesetID: open.ID,
+			Reason:      "not mergeable",
+			Backoff:     10 * time.Minute,
+			RetryAfter:  retryAfter,
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		have, err := s.ListChangesetAutoMergeStates(ctx, []int64{open.ID, merged.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []*btypes.ChangesetAutoMergeState{
+			{ChangesetID: open.ID, Reason: "not mergeable", Backoff: 10 * time.Minute, RetryAfter: retryAfter, UpdatedAt: clock.Now()},
+			{ChangesetID: merged.ID, Reason: "not mergeable", Backoff: 5 * time.Minute, RetryAfter: clock.Now(), UpdatedAt: clock.Now()},
+		}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("List without IDs", func(t *testing.T) {
+		have, err := s.ListChangesetAutoMergeStates(ctx, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(have) != 0 {
+			t.Fatalf("unexpected states: %+v", have)
+		}
+	})
+
+	t.Run("DeleteStale", func(t *testing.T) {
+		if err := s.DeleteStaleChangesetAutoMergeStates(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		have, err := s.ListChangesetAutoMergeStates(ctx, []int64{open.ID, merged.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(have) != 1 || have[0].ChangesetID != open.ID {
+			t.Fatalf("unexpected states after deleting stale states: %+v", have)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		if err := s.DeleteChangesetAutoMergeState(ctx, open.ID); err != nil {
+			t.Fatal(err)
+		}
+
+		have, err := s.ListChangesetAutoMergeStates(ctx, []int64{open.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(have) != 0 {
+			t.Fatalf("unexpected states after delete: %+v", have)
+		}
+	})
+}
@@ -0,0 +1,223 @@
+package store
+
+import (
+	"context"
+	"strings"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel/attribute"
+
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// changesetRebaseInsertColumns is the list of changeset_rebases columns that
+// are modified in CreateChangesetRebase and UpdateChangesetRebase.
+var changesetRebaseInsertColumns = []*sqlf.Query{
+	sqlf.Sprintf("changeset_id"),
+	sqlf.Sprintf("batch_change_id"),
+	sqlf.Sprintf("previous_changeset_spec_id"),
+	sqlf.Sprintf("batch_spec_workspace_id"),
+	sqlf.Sprintf("changeset_spec_id"),
+	sqlf.Sprintf("base_rev"),
+	sqlf.Sprintf("state"),
+	sqlf.Sprintf("failure_message"),
+	sqlf.Sprintf("created_at"),
+	sqlf.Sprintf("updated_at"),
+}
+
+// changesetRebaseColumns are used by the changeset rebase related Store
+// methods to query and create changeset rebases.
+var changesetRebaseColumns = []*sqlf.Query{
+	sqlf.Sprintf("changeset_rebases.id"),
+	sqlf.Sprintf("changeset_rebases.changeset_id"),
+	sqlf.Sprintf("changeset_rebases.batch_change_id"),
+	sqlf.Sprintf("changeset_rebases.previous_changeset_spec_id"),
+	sqlf.Sprintf("changeset_rebases.batch_spec_workspace_id"),
+	sqlf.Sprintf("changeset_rebases.changeset_spec_id"),
+	sqlf.Sprintf("changeset_rebases.base_rev"),
+	sqlf.Sprintf("changeset_rebases.state"),
+	sqlf.Sprintf("changeset_rebases.failure_message"),
+	sqlf.Sprintf("changeset_rebases.created_at"),
+	sqlf.Sprintf("changeset_rebases.updated_at"),
+}
+
+// CreateChangesetRebase creates the given changeset rebase. Only one rebase is
+// created per changeset and previous changeset spec: if a rebase already
+// exists, nothing is created and r.ID remains 0.
+func (s *Store) CreateChangesetRebase(ctx context.Context, r *btypes.ChangesetRebase) (err error) {
+	ctx, _, endObservation := s.operations.createChangesetRebase.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("changesetID", int(r.ChangesetID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.query(ctx, s.createChangesetRebaseQuery(r), func(sc dbutil.Scanner) error {
+		return scanChangesetRebase(r, sc)
+	})
+}
+
+var createChangesetRebaseQueryFmtstr = `
+INSERT INTO changeset_rebases (%s)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+ON CONFLICT (changeset_id, previous_changeset_spec_id) DO NOTHING
+RETURNING %s
+`
+
+func (s *Store) createChangesetRebaseQuery(r *btypes.ChangesetRebase) *sqlf.Query {
+	if r.CreatedAt.IsZero() {
+		r.CreatedAt = s.now()
+	}
+
+	if r.UpdatedAt.IsZero() {
+		r.UpdatedAt = r.CreatedAt
+	}
+
+	if r.State == "" {
+		r.State = btypes.ChangesetRebaseStateQueued
+	}
+
+	return sqlf.Sprintf(
+		createChangesetRebaseQueryFmtstr,
+		sqlf.Join(changesetRebaseInsertColumns, ", "),
+		r.ChangesetID,
+		r.BatchChangeID,
+		r.PreviousChangesetSpecID,
+		dbutil.NullInt64Column(r.BatchSpecWorkspaceID),
+		dbutil.NullInt64Column(r.ChangesetSpecID),
+		r.BaseRev,
+		r.State.ToDB(),
+		r.FailureMessage,
+		r.CreatedAt,
+		r.UpdatedAt,
+		sqlf.Join(changesetRebaseColumns, ", "),
+	)
+}
+
+// UpdateChangesetRebase updates the given changeset rebase.
+func (s *Store) UpdateChangesetRebase(ctx context.Context, r *btypes.ChangesetRebase) (err error) {
+	ctx, _, endObservation := s.operations.updateChangesetRebase.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("ID", int(r.ID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.query(ctx, s.updateChangesetRebaseQuery(r), func(sc dbutil.Scanner) error {
+		return scanChangesetRebase(r, sc)
+	})
+}
+
+var updateChangesetRebaseQueryFmtstr = `
+UPDATE changeset_rebases
+SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+WHERE id = %s
+RETURNING %s
+`
+
+func (s *Store) updateChangesetRebaseQuery(r *btypes.ChangesetRebase) *sqlf.Query {
+	r.UpdatedAt = s.now()
+
+	return sqlf.Sprintf(
+		updateChangesetRebaseQueryFmtstr,
+		sqlf.Join(changesetRebaseInsertColumns, ", "),
+		r.ChangesetID,
+		r.BatchChangeID,
+		r.PreviousChangesetSpecID,
+		dbutil.NullInt64Column(r.BatchSpecWorkspaceID),
+		dbutil.NullInt64Column(r.ChangesetSpecID),
+		r.BaseRev,
+		r.State.ToDB(),
+		r.FailureMessage,
+		r.CreatedAt,
+		r.UpdatedAt,
+		r.ID,
+		sqlf.Join(changesetRebaseColumns, ", "),
+	)
+}
+
+// ListChangesetRebasesOpts captures the query options needed for listing
+// changeset rebases.
+type ListChangesetRebasesOpts struct {
+	LimitOpts
+	Cursor int64
+
+	ChangesetID int64
+	States      []btypes.ChangesetRebaseState
+}
+
+// ListChangesetRebases lists changeset rebases with the given filters.
+func (s *Store) ListChangesetRebases(ctx context.Context, opts ListChangesetRebasesOpts) (rs []*btypes.ChangesetRebase, next int64, err error) {
+	ctx, _, endObservation := s.operations.listChangesetRebases.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	q := listChangesetRebasesQuery(&opts)
+
+	rs = make([]*btypes.ChangesetRebase, 0, opts.DBLimit())
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		var r btypes.ChangesetRebase
+		if err := scanChangesetRebase(&r, sc); err != nil {
+			return err
+		}
+		rs = append(rs, &r)
+		return nil
+	})
+
+	if opts.Limit != 0 && len(rs) == opts.DBLimit() {
+		next = rs[len(rs)-1].ID
+		rs = rs[:len(rs)-1]
+	}
+
+	return rs, next, err
+}
+
+var listChangesetRebasesQueryFmtstr = `
+SELECT %s FROM changeset_rebases
+WHERE %s
+ORDER BY id ASC
+`
+
+func listChangesetRebasesQuery(opts *ListChangesetRebasesOpts) *sqlf.Query {
+	preds := []*sqlf.Query{
+		sqlf.Sprintf("changeset_rebases.id >= %s", opts.Cursor),
+	}
+
+	if opts.ChangesetID != 0 {
+		preds = append(preds, sqlf.Sprintf("changeset_rebases.changeset_id = %s", opts.ChangesetID))
+	}
+
+	if len(opts.States) > 0 {
+		states := make([]string, 0, len(opts.States))
+		for _, state := range opts.States {
+			states = append(states, state.ToDB())
+		}
+		preds = append(preds, sqlf.Sprintf("changeset_rebases.state = ANY (%s)", pq.Array(states)))
+	}
+
+	return sqlf.Sprintf(
+		listChangesetRebasesQueryFmtstr+opts.LimitOpts.ToDB(),
+		sqlf.Join(changesetRebaseColumns, ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+}
+
+func scanChangesetRebase(r *btypes.ChangesetRebase, s dbutil.Scanner) error {
+	var state string
+	if err := s.Scan(
+		&r.ID,
+		&r.ChangesetID,
+		&r.BatchChangeID,
+		&r.PreviousChangesetSpecID,
+		&dbutil.NullInt64{N: &r.BatchSpecWorkspaceID},
+		&dbutil.NullInt64{N: &r.ChangesetSpecID},
+		&r.BaseRev,
+		&state,
+		&r.FailureMessage,
+		&r.CreatedAt,
+		&r.UpdatedAt,
+	); err != nil {
+		return err
+	}
+
+	r.State = btypes.ChangesetRebaseState(strings.ToUpper(state))
+	return nil
+}
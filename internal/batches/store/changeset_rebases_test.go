@@ -0,0 +1,145 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/log/logtest"
+
+	bt "github.com/sourcegraph/sourcegraph/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+)
+
+func testStoreChangesetRebases(t *testing.T, ctx context.Context, s *Store, clock bt.Clock) {
+	logger := logtest.Scoped(t)
+	repoStore := database.ReposWith(logger, s)
+	esStore := database.ExternalServicesWith(logger, s)
+
+	repo := bt.TestRepo(t, esStore, extsvc.KindGitHub)
+	if err := repoStore.Create(ctx, repo); err != nil {
+		t.Fatal(err)
+	}
+
+	user := bt.CreateTestUser(t, s.DatabaseDB(), false)
+	batchSpec := bt.CreateBatchSpec(t, ctx, s, "rebases", user.ID, 0)
+	batchChange := bt.CreateBatchChange(t, ctx, s, "rebases", user.ID, batchSpec.ID)
+	changeset := bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{Repo: repo.ID, OwnedByBatchChange: batchChange.ID})
+	otherChangeset := bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{Repo: repo.ID, OwnedByBatchChange: batchChange.ID})
+
+	rebase := &btypes.ChangesetRebase{
+		ChangesetID:             changeset.ID,
+		BatchChangeID:           batchChange.ID,
+		PreviousChangesetSpecID: 1234,
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		if err := s.CreateChangesetRebase(ctx, rebase); err != nil {
+			t.Fatal(err)
+		}
+		if rebase.ID == 0 {
+			t.Fatal("ID should not be zero")
+		}
+
+		want := &btypes.ChangesetRebase{
+			ID:                      rebase.ID,
+			ChangesetID:             changeset.ID,
+			BatchChangeID:           batchChange.ID,
+			PreviousChangesetSpecID: 1234,
+			State:                   btypes.ChangesetRebaseStateQueued,
+			CreatedAt:               clock.Now(),
+			UpdatedAt:               clock.Now(),
+		}
+		if diff := cmp.Diff(want, rebase); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("Create duplicate", func(t *testing.T) {
+		duplicate := &btypes.ChangesetRebase{
+			ChangesetID:             changeset.ID,
+			BatchChangeID:           batchChange.ID,
+			PreviousChangesetSpecID: 1234,
+		}
+		if err := s.CreateChangesetRebase(ctx, duplicate); err != nil {
+			t.Fatal(err)
+		}
+		if duplicate.ID != 0 {
+			t.Fatalf("unexpected rebase created for the same changeset spec: %d", duplicate.ID)
+		}
+	})
+
+	otherRebase := &btypes.ChangesetRebase{
+		ChangesetID:             otherChangeset.ID,
+		BatchChangeID:           batchChange.ID,
+		PreviousChangesetSpecID: 5678,
+	}
+	if err := s.CreateChangesetRebase(ctx, otherRebase); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Update", func(t *testing.T) {
+		clock.Add(1 * time.Second)
+		message := "re-execution failed"
+		rebase.BaseRev = "d34db33f"
+		rebase.State = btypes.ChangesetRebaseStateFailed
+		rebase.FailureMessage = &message
+
+		if err := s.UpdateChangesetRebase(ctx, rebase); err != nil {
+			t.Fatal(err)
+		}
+		if rebase.UpdatedAt != clock.Now() {
+			t.Fatalf("unexpected updated at: %s", rebase.UpdatedAt)
+		}
+		if rebase.State != btypes.ChangesetRebaseStateFailed || *rebase.FailureMessage != message {
+			t.Fatalf("unexpected rebase: %+v", rebase)
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		for name, tc := range map[string]struct {
+			opts ListChangesetRebasesOpts
+			want []int64
+		}{
+			"all": {
+				want: []int64{rebase.ID, otherRebase.ID},
+			},
+			"by changeset": {
+				opts: ListChangesetRebasesOpts{ChangesetID: otherChangeset.ID},
+				want: []int64{otherRebase.ID},
+			},
+			"by state": {
+				opts: ListChangesetRebasesOpts{States: []btypes.ChangesetRebaseState{btypes.ChangesetRebaseStateQueued}},
+				want: []int64{otherRebase.ID},
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				have, _, err := s.ListChangesetRebases(ctx, tc.opts)
+				if err != nil {
+					t.Fatal(err)
+				}
+				haveIDs := make([]int64, 0, len(have))
+				for _, r := range have {
+					haveIDs = append(haveIDs, r.ID)
+				}
+				if diff := cmp.Diff(tc.want, haveIDs); diff != "" {
+					t.Fatal(diff)
+				}
+			})
+		}
+
+		t.Run("paginated", func(t *testing.T) {
+			have, next, err := s.ListChangesetRebases(ctx, ListChangesetRebasesOpts{LimitOpts: LimitOpts{Limit: 1}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(have) != 1 || have[0].ID != rebase.ID || next != otherRebase.ID {
+				t.Fatalf("unexpected page: %v, next=%d", have, next)
+			}
+		})
+	})
+}
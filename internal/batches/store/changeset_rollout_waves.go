@@ -0,0 +1,166 @@
+package store
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel/attribute"
+
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// changesetRolloutWaveColumns are used by the changeset rollout wave related
+// Store methods to query and create changeset rollout waves.
+var changesetRolloutWaveColumns = []*sqlf.Query{
+	sqlf.Sprintf("changeset_rollout_waves.changeset_id"),
+	sqlf.Sprintf("changeset_rollout_waves.batch_change_id"),
+	sqlf.Sprintf("changeset_rollout_waves.wave"),
+	sqlf.Sprintf("changeset_rollout_waves.held"),
+	sqlf.Sprintf("changeset_rollout_waves.created_at"),
+	sqlf.Sprintf("changeset_rollout_waves.updated_at"),
+}
+
+// CreateChangesetRolloutWaves creates the given changeset rollout waves. A
+// changeset keeps the wave it was first assigned to: waves of changesets that
+// already have one are not changed.
+func (s *Store) CreateChangesetRolloutWaves(ctx context.Context, ws ...*btypes.ChangesetRolloutWave) (err error) {
+	ctx, _, endObservation := s.operations.createChangesetRolloutWaves.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("count", len(ws)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if len(ws) == 0 {
+		return nil
+	}
+
+	return s.Exec(ctx, s.createChangesetRolloutWavesQuery(ws))
+}
+
+var createChangesetRolloutWavesQueryFmtstr = `
+INSERT INTO changeset_rollout_waves (changeset_id, batch_change_id, wave, held, created_at, updated_at)
+VALUES %s
+ON CONFLICT (changeset_id) DO NOTHING
+`
+
+func (s *Store) createChangesetRolloutWavesQuery(ws []*btypes.ChangesetRolloutWave) *sqlf.Query {
+	now := s.now()
+
+	values := make([]*sqlf.Query, 0, len(ws))
+	for _, w := range ws {
+		if w.CreatedAt.IsZero() {
+			w.CreatedAt = now
+		}
+		if w.UpdatedAt.IsZero() {
+			w.UpdatedAt = w.CreatedAt
+		}
+		values = append(values, sqlf.Sprintf(
+			"(%s, %s, %s, %s, %s, %s)",
+			w.ChangesetID,
+			w.BatchChangeID,
+			w.Wave,
+			w.Held,
+			w.CreatedAt,
+			w.UpdatedAt,
+		))
+	}
+
+	return sqlf.Sprintf(createChangesetRolloutWavesQueryFmtstr, sqlf.Join(values, ", "))
+}
+
+// SetChangesetRolloutWaveHeld marks whether publishing the given changeset is
+// currently held back by the rollout of its batch change.
+func (s *Store) SetChangesetRolloutWaveHeld(ctx context.Context, changesetID int64, held bool) (err error) {
+	ctx, _, endObservation := s.operations.setChangesetRolloutWaveHeld.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("changesetID", int(changesetID)),
+		attribute.Bool("held", held),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.Exec(ctx, sqlf.Sprintf(setChangesetRolloutWaveHeldQueryFmtstr, held, s.now(), changesetID))
+}
+
+var setChangesetRolloutWaveHeldQueryFmtstr = `
+UPDATE changeset_rollout_waves
+SET held = %s, updated_at = %s
+WHERE changeset_id = %s
+`
+
+// ListChangesetRolloutWavesOpts captures the query options needed for listing
+// changeset rollout waves.
+type ListChangesetRolloutWavesOpts struct {
+	BatchChangeID int64
+	ChangesetIDs  []int64
+	Held          *bool
+	// MaxWave, if set, only lists changesets in waves up to and including it.
+	MaxWave *int32
+}
+
+// ListChangesetRolloutWaves lists changeset rollout waves with the given
+// filters.
+func (s *Store) ListChangesetRolloutWaves(ctx context.Context, opts ListChangesetRolloutWavesOpts) (ws []*btypes.ChangesetRolloutWave, err error) {
+	ctx, _, endObservation := s.operations.listChangesetRolloutWaves.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("batchChangeID", int(opts.BatchChangeID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	err = s.query(ctx, listChangesetRolloutWavesQuery(&opts), func(sc dbutil.Scanner) error {
+		var w btypes.ChangesetRolloutWave
+		if err := scanChangesetRolloutWave(&w, sc); err != nil {
+			return err
+		}
+		ws = append(ws, &w)
+		return nil
+	})
+
+	return ws, err
+}
+
+var listChangesetRolloutWavesQueryFmtstr = `
+SELECT %s FROM changeset_rollout_waves
+WHERE %s
+ORDER BY changeset_id ASC
+`
+
+func listChangesetRolloutWavesQuery(opts *ListChangesetRolloutWavesOpts) *sqlf.Query {
+	preds := []*sqlf.Query{}
+
+	if opts.BatchChangeID != 0 {
+		preds = append(preds, sqlf.Sprintf("changeset_rollout_waves.batch_change_id = %s", opts.BatchChangeID))
+	}
+
+	if len(opts.ChangesetIDs) > 0 {
+		preds = append(preds, sqlf.Sprintf("changeset_rollout_waves.changeset_id = ANY (%s)", pq.Array(opts.ChangesetIDs)))
+	}
+
+	if opts.Held != nil {
+		preds = append(preds, sqlf.Sprintf("changeset_rollout_waves.held = %s", *opts.Held))
+	}
+
+	if opts.MaxWave != nil {
+		preds = append(preds, sqlf.Sprintf("changeset_rollout_waves.wave <= %s", *opts.MaxWave))
+	}
+
+	if len(preds) == 0 {
+		preds = append(preds, sqlf.Sprintf("TRUE"))
+	}
+
+	return sqlf.Sprintf(
+		listChangesetRolloutWavesQueryFmtstr,
+		sqlf.Join(changesetRolloutWaveColumns, ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+}
+
+func scanChangesetRolloutWave(w *btypes.ChangesetRolloutWave, s dbutil.Scanner) error {
+	return s.Scan(
+		&w.ChangesetID,
+		&w.BatchChangeID,
+		&w.Wave,
+		&w.Held,
+		&w.CreatedAt,
+		&w.UpdatedAt,
+	)
+}
@@ -0,0 +1,92 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/log/logtest"
+
+	bt "github.com/sourcegraph/sourcegraph/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
+)
+
+func testStoreChangesetRolloutWaves(t *testing.T, ctx context.Context, s *Store, clock bt.Clock) {
+	logger := logtest.Scoped(t)
+	repoStore := database.ReposWith(logger, s)
+	esStore := database.ExternalServicesWith(logger, s)
+
+	repo := bt.TestRepo(t, esStore, extsvc.KindGitHub)
+	if err := repoStore.Create(ctx, repo); err != nil {
+		t.Fatal(err)
+	}
+
+	user := bt.CreateTestUser(t, s.DatabaseDB(), false)
+	batchSpec := bt.CreateBatchSpec(t, ctx, s, "rollout", user.ID, 0)
+	batchChange := bt.CreateBatchChange(t, ctx, s, "rollout", user.ID, batchSpec.ID)
+	canary := bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{Repo: repo.ID, OwnedByBatchChange: batchChange.ID})
+	rest := bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{Repo: repo.ID, OwnedByBatchChange: batchChange.ID})
+
+	t.Run("Create", func(t *testing.T) {
+		if err := s.CreateChangesetRolloutWaves(ctx,
+			&btypes.ChangesetRolloutWave{ChangesetID: canary.ID, BatchChangeID: batchChange.ID, Wave: 0},
+			&btypes.ChangesetRolloutWave{ChangesetID: rest.ID, BatchChangeID: batchChange.ID, Wave: 1},
+		); err != nil {
+			t.Fatal(err)
+		}
+
+		have, err := s.ListChangesetRolloutWaves(ctx, ListChangesetRolloutWavesOpts{BatchChangeID: batchChange.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []*btypes.ChangesetRolloutWave{
+			{ChangesetID: canary.ID, BatchChangeID: batchChange.ID, Wave: 0, CreatedAt: clock.Now(), UpdatedAt: clock.Now()},
+			{ChangesetID: rest.ID, BatchChangeID: batchChange.ID, Wave: 1, CreatedAt: clock.Now(), UpdatedAt: clock.Now()},
+		}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("Create keeps existing waves", func(t *testing.T) {
+		if err := s.CreateChangesetRolloutWaves(ctx, &btypes.ChangesetRolloutWave{ChangesetID: canary.ID, BatchChangeID: batchChange.ID, Wave: 3}); err != nil {
+			t.Fatal(err)
+		}
+
+		have, err := s.ListChangesetRolloutWaves(ctx, ListChangesetRolloutWavesOpts{ChangesetIDs: []int64{canary.ID}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(have) != 1 || have[0].Wave != 0 {
+			t.Fatalf("wave of changeset was changed: %+v", have)
+		}
+	})
+
+	t.Run("SetChangesetRolloutWaveHeld", func(t *testing.T) {
+		if err := s.SetChangesetRolloutWaveHeld(ctx, rest.ID, true); err != nil {
+			t.Fatal(err)
+		}
+
+		have, err := s.ListChangesetRolloutWaves(ctx, ListChangesetRolloutWavesOpts{BatchChangeID: batchChange.ID, Held: pointers.Ptr(true)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(have) != 1 || have[0].ChangesetID != rest.ID {
+			t.Fatalf("wrong held changesets: %+v", have)
+		}
+	})
+
+	t.Run("List with MaxWave", func(t *testing.T) {
+		have, err := s.ListChangesetRolloutWaves(ctx, ListChangesetRolloutWavesOpts{BatchChangeID: batchChange.ID, MaxWave: pointers.Ptr(int32(0))})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(have) != 1 || have[0].ChangesetID != canary.ID {
+			t.Fatalf("wrong changesets: %+v", have)
+		}
+	})
+}
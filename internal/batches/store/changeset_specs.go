@@ -440,7 +440,7 @@ func (s *Store) ListChangesetSpecsWithConflictingHeadRef(ctx context.Context, ba
 }
 
 // DeleteUnattachedExpiredChangesetSpecs deletes each ChangesetSpec that has not been
-// attached to a BatchSpec within ChangesetSpecTTL.
+// attached to a BatchSpec within ChangesetSpecTTL and isn't used by a Changeset.
 func (s *Store) DeleteUnattachedExpiredChangesetSpecs(ctx context.Context) (err error) {
 	ctx, _, endObservation := s.operations.deleteUnattachedExpiredChangesetSpecs.With(ctx, &err, observation.Args{})
 	defer endObservation(1, observation.Args{})
@@ -459,6 +459,12 @@ WHERE
   AND
   -- and it was never attached to a batch_spec
   batch_spec_id IS NULL
+  AND
+  -- and it isn't the spec of a changeset, as the specs produced by rebases are
+  NOT EXISTS (
+    SELECT 1 FROM changesets
+    WHERE changesets.current_spec_id = changeset_specs.id OR changesets.previous_spec_id = changeset_specs.id
+  )
 `
 
 // DeleteExpiredChangesetSpecs deletes each ChangesetSpec that is attached
@@ -464,8 +464,9 @@ func testStoreChangesetSpecs(t *testing.T, ctx context.Context, s *Store, clock
 		overTTL := clock.Now().Add(-btypes.ChangesetSpecTTL - 24*time.Hour)
 
 		type testCase struct {
-			createdAt   time.Time
-			wantDeleted bool
+			createdAt     time.Time
+			isCurrentSpec bool
+			wantDeleted   bool
 		}
 
 		printTestCase := func(tc testCase) string {
@@ -474,13 +475,16 @@ func testStoreChangesetSpecs(t *testing.T, ctx context.Context, s *Store, clock
 				tooOld = true
 			}
 
-			return fmt.Sprintf("[tooOld=%t]", tooOld)
+			return fmt.Sprintf("[tooOld=%t, isCurrentSpec=%t]", tooOld, tc.isCurrentSpec)
 		}
 
 		tests := []testCase{
 			// ChangesetSpec was created but never attached to a BatchSpec
 			{createdAt: underTTL, wantDeleted: false},
 			{createdAt: overTTL, wantDeleted: true},
+
+			// ChangesetSpec was produced by a rebase and is used by a Changeset
+			{createdAt: overTTL, isCurrentSpec: true, wantDeleted: false},
 		}
 
 		for _, tc := range tests {
@@ -497,6 +501,17 @@ func testStoreChangesetSpecs(t *testing.T, ctx context.Context, s *Store, clock
 				t.Fatal(err)
 			}
 
+			if tc.isCurrentSpec {
+				changeset := &btypes.Changeset{
+					ExternalServiceType: "github",
+					RepoID:              1,
+					CurrentSpecID:       changesetSpec.ID,
+				}
+				if err := s.CreateChangeset(ctx, changeset); err != nil {
+					t.Fatal(err)
+				}
+			}
+
 			if err := s.DeleteUnattachedExpiredChangesetSpecs(ctx); err != nil {
 				t.Fatal(err)
 			}
@@ -14,8 +14,10 @@ import (
 
 	adobatches "github.com/sourcegraph/sourcegraph/internal/batches/sources/azuredevops"
 	gerritbatches "github.com/sourcegraph/sourcegraph/internal/batches/sources/gerrit"
+	giteabatches "github.com/sourcegraph/sourcegraph/internal/batches/sources/gitea"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 
 	"github.com/keegancsmith/sqlf"
@@ -1526,6 +1528,11 @@ func ScanChangeset(t *btypes.Changeset, s dbutil.Scanner) error {
 		m := new(gerritbatches.AnnotatedChange)
 		m.Change = &gerrit.Change{}
 		t.Metadata = m
+	case extsvc.TypeGitea:
+		m := new(giteabatches.AnnotatedPullRequest)
+		// Ensure the inner PR is initialized, it should never be nil.
+		m.PullRequest = &gitea.PullRequest{}
+		t.Metadata = m
 	case extsvc.TypePerforce:
 		t.Metadata = new(protocol.PerforceChangelist)
 	case extsvc.TypeGerrit:
@@ -222,6 +222,29 @@ type operations struct {
 	createChangesetJob *observation.Operation
 	getChangesetJob    *observation.Operation
 
+	createChangesetRebase *observation.Operation
+	updateChangesetRebase *observation.Operation
+	listChangesetRebases  *observation.Operation
+
+	upsertChangesetAutoMergeState       *observation.Operation
+	deleteChangesetAutoMergeState       *observation.Operation
+	deleteStaleChangesetAutoMergeStates *observation.Operation
+	listChangesetAutoMergeStates        *observation.Operation
+
+	createChangesetRolloutWaves *observation.Operation
+	setChangesetRolloutWaveHeld *observation.Operation
+	listChangesetRolloutWaves   *observation.Operation
+
+	createBatchSpecTemplate        *observation.Operation
+	updateBatchSpecTemplate        *observation.Operation
+	deleteBatchSpecTemplate        *observation.Operation
+	getBatchSpecTemplate           *observation.Operation
+	listBatchSpecTemplates         *observation.Operation
+	countBatchSpecTemplates        *observation.Operation
+	createBatchSpecTemplateVersion *observation.Operation
+	getBatchSpecTemplateVersion    *observation.Operation
+	listBatchSpecTemplateVersions  *observation.Operation
+
 	createChangesetSpec                      *observation.Operation
 	updateChangesetSpecBatchSpecID           *observation.Operation
 	deleteChangesetSpec                      *observation.Operation
@@ -367,6 +390,29 @@ func newOperations(observationCtx *observation.Context) *operations {
 			createChangesetJob: op("CreateChangesetJob"),
 			getChangesetJob:    op("GetChangesetJob"),
 
+			createChangesetRebase: op("CreateChangesetRebase"),
+			updateChangesetRebase: op("UpdateChangesetRebase"),
+			listChangesetRebases:  op("ListChangesetRebases"),
+
+			upsertChangesetAutoMergeState:       op("UpsertChangesetAutoMergeState"),
+			deleteChangesetAutoMergeState:       op("DeleteChangesetAutoMergeState"),
+			deleteStaleChangesetAutoMergeStates: op("DeleteStaleChangesetAutoMergeStates"),
+			listChangesetAutoMergeStates:        op("ListChangesetAutoMergeStates"),
+
+			createChangesetRolloutWaves: op("CreateChangesetRolloutWaves"),
+			setChangesetRolloutWaveHeld: op("SetChangesetRolloutWaveHeld"),
+			listChangesetRolloutWaves:   op("ListChangesetRolloutWaves"),
+
+			createBatchSpecTemplate:        op("CreateBatchSpecTemplate"),
+			updateBatchSpecTemplate:        op("UpdateBatchSpecTemplate"),
+			deleteBatchSpecTemplate:        op("DeleteBatchSpecTemplate"),
+			getBatchSpecTemplate:           op("GetBatchSpecTemplate"),
+			listBatchSpecTemplates:         op("ListBatchSpecTemplates"),
+			countBatchSpecTemplates:        op("CountBatchSpecTemplates"),
+			createBatchSpecTemplateVersion: op("CreateBatchSpecTemplateVersion"),
+			getBatchSpecTemplateVersion:    op("GetBatchSpecTemplateVersion"),
+			listBatchSpecTemplateVersions:  op("ListBatchSpecTemplateVersions"),
+
 			createChangesetSpec:                      op("CreateChangesetSpec"),
 			updateChangesetSpecBatchSpecID:           op("UpdateChangesetSpecBatchSpecID"),
 			deleteChangesetSpec:                      op("DeleteChangesetSpec"),
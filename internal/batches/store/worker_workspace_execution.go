@@ -241,7 +241,12 @@ func (s *batchSpecWorkspaceExecutionWorkerStore) MarkComplete(ctx context.Contex
 		if err != nil {
 			return false, errors.Wrap(err, "failed to build db changeset specs")
 		}
-		changesetSpec.BatchSpecID = batchSpec.ID
+		// The changeset specs of a rebase workspace replace the current spec
+		// of a single changeset, and must not show up among the specs of the
+		// batch spec.
+		if !workspace.Rebase {
+			changesetSpec.BatchSpecID = batchSpec.ID
+		}
 		changesetSpec.BaseRepoID = repo.ID
 		changesetSpec.UserID = batchSpec.UserID
 
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/keegancsmith/sqlf"
 
 	"github.com/sourcegraph/log/logtest"
 
@@ -198,6 +199,46 @@ stdout: {"operation":"CACHE_AFTER_STEP_RESULT","timestamp":"2021-11-04T12:43:19.
 		}
 	})
 
+	t.Run("rebase workspace", func(t *testing.T) {
+		job, workspace := setupEntities(t)
+		if err := s.Exec(ctx, sqlf.Sprintf("UPDATE batch_spec_workspaces SET rebase = TRUE WHERE id = %s", workspace.ID)); err != nil {
+			t.Fatal(err)
+		}
+		setProcessing(t, job)
+
+		ok, err := executionStore.MarkComplete(context.Background(), int(job.ID), opts)
+		if !ok || err != nil {
+			t.Fatalf("MarkComplete failed. ok=%t, err=%s", ok, err)
+		}
+
+		assertJobState(t, job, btypes.BatchSpecWorkspaceExecutionJobStateCompleted)
+
+		// The changeset spec is attached to the rebase workspace, but not to
+		// the batch spec.
+		assertNoChangesetSpecsCreated(t)
+
+		reloadedWorkspace, err := s.GetBatchSpecWorkspace(ctx, GetBatchSpecWorkspaceOpts{ID: workspace.ID})
+		if err != nil {
+			t.Fatalf("failed to reload workspace: %s", err)
+		}
+		if have, want := len(reloadedWorkspace.ChangesetSpecIDs), 1; have != want {
+			t.Fatalf("invalid number of changeset specs created: have=%d want=%d", have, want)
+		}
+		specs, _, err := s.ListChangesetSpecs(ctx, ListChangesetSpecsOpts{IDs: reloadedWorkspace.ChangesetSpecIDs})
+		if err != nil {
+			t.Fatalf("failed to load changeset specs: %s", err)
+		}
+		if have, want := len(specs), 1; have != want {
+			t.Fatalf("invalid number of changeset specs loaded: have=%d want=%d", have, want)
+		}
+		if specs[0].BatchSpecID != 0 {
+			t.Fatalf("changeset spec of rebase workspace is attached to batch spec %d", specs[0].BatchSpecID)
+		}
+		if err := s.DeleteChangesetSpecs(ctx, DeleteChangesetSpecsOpts{IDs: reloadedWorkspace.ChangesetSpecIDs}); err != nil {
+			t.Fatal(err)
+		}
+	})
+
 	t.Run("worker hostname mismatch", func(t *testing.T) {
 		job, _ := setupEntities(t)
 		setProcessing(t, job)
@@ -538,5 +538,50 @@ func SyncChangeset(ctx context.Context, syncStore SyncStore, client gitserver.Cl
 		return err
 	}
 
-	return tx.UpsertChangesetEvents(ctx, events...)
+	if err := tx.UpsertChangesetEvents(ctx, events...); err != nil {
+		return err
+	}
+
+	return queueRebaseOnConflict(ctx, tx, c)
+}
+
+// queueRebaseOnConflict queues an automatic rebase of the given changeset if
+// the code host reports that it conflicts with its base branch and the batch
+// change owning it has automatic rebasing enabled. At most one rebase is queued
+// per changeset spec, so a rebase that doesn't resolve the conflict isn't
+// retried until the changeset is updated otherwise.
+func queueRebaseOnConflict(ctx context.Context, tx *store.Store, c *btypes.Changeset) error {
+	if !needsRebase(c) {
+		return nil
+	}
+
+	batchChange, err := tx.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: c.OwnedByBatchChangeID})
+	if err != nil {
+		if err == store.ErrNoResults || errors.Is(err, store.ErrDeletedNamespace) {
+			return nil
+		}
+		return errors.Wrap(err, "loading batch change")
+	}
+	if !batchChange.AutoRebase || batchChange.Closed() {
+		return nil
+	}
+
+	return tx.CreateChangesetRebase(ctx, &btypes.ChangesetRebase{
+		ChangesetID:             c.ID,
+		BatchChangeID:           batchChange.ID,
+		PreviousChangesetSpecID: c.CurrentSpecID,
+		State:                   btypes.ChangesetRebaseStateQueued,
+	})
+}
+
+// needsRebase returns true if the changeset was published by a batch change
+// from a changeset spec, is still open and conflicts with its base branch.
+func needsRebase(c *btypes.Changeset) bool {
+	if c.OwnedByBatchChangeID == 0 || c.CurrentSpecID == 0 || !c.Published() {
+		return false
+	}
+	if c.ExternalState != btypes.ChangesetExternalStateOpen && c.ExternalState != btypes.ChangesetExternalStateDraft {
+		return false
+	}
+	return c.HasMergeConflict()
 }
@@ -17,6 +17,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
 	"github.com/sourcegraph/sourcegraph/internal/types"
@@ -390,3 +391,56 @@ func TestSyncRegistry_EnqueueChangesetSyncsForRepos(t *testing.T) {
 		assert.ElementsMatch(t, []int64{1, 2}, <-s.priorityNotify)
 	})
 }
+
+func TestNeedsRebase(t *testing.T) {
+	conflicting := func() *btypes.Changeset {
+		return &btypes.Changeset{
+			OwnedByBatchChangeID: 1,
+			CurrentSpecID:        2,
+			PublicationState:     btypes.ChangesetPublicationStatePublished,
+			ExternalState:        btypes.ChangesetExternalStateOpen,
+			Metadata:             &github.PullRequest{Mergeable: "CONFLICTING"},
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		changeset func(c *btypes.Changeset)
+		want      bool
+	}{
+		"conflicting": {
+			want: true,
+		},
+		"conflicting draft": {
+			changeset: func(c *btypes.Changeset) { c.ExternalState = btypes.ChangesetExternalStateDraft },
+			want:      true,
+		},
+		"mergeable": {
+			changeset: func(c *btypes.Changeset) { c.Metadata = &github.PullRequest{Mergeable: "MERGEABLE"} },
+			want:      false,
+		},
+		"imported": {
+			changeset: func(c *btypes.Changeset) { c.OwnedByBatchChangeID = 0 },
+			want:      false,
+		},
+		"no changeset spec": {
+			changeset: func(c *btypes.Changeset) { c.CurrentSpecID = 0 },
+			want:      false,
+		},
+		"unpublished": {
+			changeset: func(c *btypes.Changeset) { c.PublicationState = btypes.ChangesetPublicationStateUnpublished },
+			want:      false,
+		},
+		"merged": {
+			changeset: func(c *btypes.Changeset) { c.ExternalState = btypes.ChangesetExternalStateMerged },
+			want:      false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := conflicting()
+			if tc.changeset != nil {
+				tc.changeset(c)
+			}
+			assert.Equal(t, tc.want, needsRebase(c))
+		})
+	}
+}
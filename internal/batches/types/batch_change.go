@@ -36,6 +36,20 @@ type BatchChange struct {
 
 	ClosedAt time.Time
 
+	// AutoRebase is true when changesets of the batch change should be
+	// re-executed against the new base commit and force-pushed when they
+	// conflict with their base branch.
+	AutoRebase bool
+
+	// RolloutWave is the wave of the rollout policy up to which changesets
+	// may be published.
+	RolloutWave int32
+	// RolloutPaused is true when the rollout has been paused, either by a
+	// user or automatically because a wave failed.
+	RolloutPaused bool
+	// RolloutPauseReason explains why the rollout was paused automatically.
+	RolloutPauseReason string
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
@@ -35,6 +35,10 @@ type BatchSpec struct {
 	// executed server-side.
 	CreatedFromRaw bool
 
+	// TemplateVersionID is the ID of the BatchSpecTemplateVersion the
+	// BatchSpec was instantiated from, if any.
+	TemplateVersionID int64
+
 	AllowUnsupported bool
 	AllowIgnored     bool
 	NoCache          bool
@@ -0,0 +1,57 @@
+package types
+
+import (
+	"time"
+
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+// A BatchSpecTemplate is a named batch spec template in a namespace. Templates
+// without a namespace are global and visible to all users.
+type BatchSpecTemplate struct {
+	ID          int64
+	Name        string
+	Description string
+
+	NamespaceUserID int32
+	NamespaceOrgID  int32
+
+	CreatorID int32
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Clone returns a clone of a BatchSpecTemplate.
+func (t *BatchSpecTemplate) Clone() *BatchSpecTemplate {
+	tt := *t
+	return &tt
+}
+
+// IsGlobal returns true if the template doesn't belong to a namespace.
+func (t *BatchSpecTemplate) IsGlobal() bool {
+	return t.NamespaceUserID == 0 && t.NamespaceOrgID == 0
+}
+
+// A BatchSpecTemplateVersion is an immutable version of a BatchSpecTemplate.
+// Batch specs instantiated from a template reference the version they were
+// instantiated from.
+type BatchSpecTemplateVersion struct {
+	ID         int64
+	TemplateID int64
+
+	// Version is the number of the version, starting at 1 for the first
+	// version of a template.
+	Version int32
+
+	RawTemplate string
+
+	CreatorID int32
+
+	CreatedAt time.Time
+}
+
+// Template parses and returns the template of the version.
+func (v *BatchSpecTemplateVersion) Template() (*batcheslib.BatchSpecTemplate, error) {
+	return batcheslib.ParseBatchSpecTemplate([]byte(v.RawTemplate))
+}
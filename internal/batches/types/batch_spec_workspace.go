@@ -39,6 +39,11 @@ type BatchSpecWorkspace struct {
 	// and used for creating the attached changeset specs.
 	CachedResultFound bool
 
+	// Rebase is true if this workspace re-executes a changeset against a new
+	// base commit. Rebase workspaces are not part of the execution of their
+	// batch spec, and the changeset specs they produce aren't attached to it.
+	Rebase bool
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 
 	gerritbatches "github.com/sourcegraph/sourcegraph/internal/batches/sources/gerrit"
+	giteabatches "github.com/sourcegraph/sourcegraph/internal/batches/sources/gitea"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
@@ -23,6 +24,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
@@ -470,6 +472,24 @@ func (c *Changeset) SetMetadata(meta any) error {
 		c.ExternalServiceType = extsvc.TypeGerrit
 		c.ExternalBranch = gitdomain.EnsureRefPrefix(pr.Change.Branch)
 		c.ExternalUpdatedAt = pr.Change.Updated
+	case *giteabatches.AnnotatedPullRequest:
+		c.Metadata = pr
+		c.ExternalID = strconv.FormatInt(pr.Index, 10)
+		c.ExternalServiceType = extsvc.TypeGitea
+		if pr.Head != nil {
+			c.ExternalBranch = gitdomain.EnsureRefPrefix(pr.Head.Ref)
+		}
+		if pr.Updated != nil {
+			c.ExternalUpdatedAt = *pr.Updated
+		}
+
+		if pr.Head != nil && pr.Base != nil && pr.Head.Repo != nil && pr.Head.RepoID != pr.Base.RepoID {
+			c.ExternalForkNamespace = pr.Head.Repo.Owner.Login
+			c.ExternalForkName = pr.Head.Repo.Name
+		} else {
+			c.ExternalForkNamespace = ""
+			c.ExternalForkName = ""
+		}
 	case *protocol.PerforceChangelist:
 		c.Metadata = pr
 		c.ExternalID = pr.ID
@@ -510,6 +530,8 @@ func (c *Changeset) Title() (string, error) {
 		// Remove extra quotes added by the commit message
 		title = strings.TrimPrefix(strings.TrimSuffix(title, "\""), "\"")
 		return title, nil
+	case *giteabatches.AnnotatedPullRequest:
+		return m.Title, nil
 	case *protocol.PerforceChangelist:
 		return m.Title, nil
 	default:
@@ -537,6 +559,11 @@ func (c *Changeset) AuthorName() (string, error) {
 		return m.CreatedBy.UniqueName, nil
 	case *gerritbatches.AnnotatedChange:
 		return m.Change.Owner.Name, nil
+	case *giteabatches.AnnotatedPullRequest:
+		if m.User == nil {
+			return "", nil
+		}
+		return m.User.Login, nil
 	case *protocol.PerforceChangelist:
 		return m.Author, nil
 	default:
@@ -572,6 +599,11 @@ func (c *Changeset) AuthorEmail() (string, error) {
 		return m.CreatedBy.UniqueName, nil
 	case *gerritbatches.AnnotatedChange:
 		return m.Change.Owner.Email, nil
+	case *giteabatches.AnnotatedPullRequest:
+		if m.User == nil {
+			return "", nil
+		}
+		return m.User.Email, nil
 	case *protocol.PerforceChangelist:
 		return "", nil
 	default:
@@ -596,6 +628,11 @@ func (c *Changeset) ExternalCreatedAt() time.Time {
 		return m.CreationDate
 	case *gerritbatches.AnnotatedChange:
 		return m.Change.Created
+	case *giteabatches.AnnotatedPullRequest:
+		if m.Created == nil {
+			return time.Time{}
+		}
+		return *m.Created
 	case *protocol.PerforceChangelist:
 		return m.CreationDate
 	default:
@@ -619,6 +656,8 @@ func (c *Changeset) Body() (string, error) {
 	case *gerritbatches.AnnotatedChange:
 		// Gerrit doesn't really differentiate between title/description.
 		return m.Change.Subject, nil
+	case *giteabatches.AnnotatedPullRequest:
+		return m.Body, nil
 	case *protocol.PerforceChangelist:
 		return "", nil
 	default:
@@ -687,6 +726,8 @@ func (c *Changeset) URL() (s string, err error) {
 		return returnURL.String(), nil
 	case *gerritbatches.AnnotatedChange:
 		return m.CodeHostURL.JoinPath("c", url.PathEscape(m.Change.Project), "+", url.PathEscape(strconv.Itoa(m.Change.ChangeNumber))).String(), nil
+	case *giteabatches.AnnotatedPullRequest:
+		return m.HTMLURL, nil
 	case *protocol.PerforceChangelist:
 		return "", nil
 	default:
@@ -907,6 +948,42 @@ func (c *Changeset) Events() (events []*ChangesetEvent, err error) {
 				Metadata:    reviewer,
 			})
 		}
+	case *giteabatches.AnnotatedPullRequest:
+		// There are two types of event that we create from an annotated pull
+		// request: review events, based on the submitted reviews, and check
+		// events, based on the commit statuses of the head commit.
+		var kind ChangesetEventKind
+
+		for _, review := range m.Reviews {
+			// Pending reviews haven't been submitted yet, and review requests
+			// aren't reviews at all.
+			if review.State == gitea.ReviewStatePending || review.State == gitea.ReviewStateRequestReview {
+				continue
+			}
+			if kind, err = ChangesetEventKindFor(review); err != nil {
+				return
+			}
+			appendEvent(&ChangesetEvent{
+				ChangesetID: c.ID,
+				Key:         review.Key(),
+				Kind:        kind,
+				Metadata:    review,
+			})
+		}
+
+		if m.Status != nil {
+			for _, status := range m.Status.Statuses {
+				if kind, err = ChangesetEventKindFor(status); err != nil {
+					return
+				}
+				appendEvent(&ChangesetEvent{
+					ChangesetID: c.ID,
+					Key:         status.Key(),
+					Kind:        kind,
+					Metadata:    status,
+				})
+			}
+		}
 	case *protocol.PerforceChangelist:
 		// We don't have any events we care about right now
 		break
@@ -932,6 +1009,11 @@ func (c *Changeset) HeadRefOid() (string, error) {
 		return "", nil
 	case *gerritbatches.AnnotatedChange:
 		return "", nil
+	case *giteabatches.AnnotatedPullRequest:
+		if m.Head == nil {
+			return "", nil
+		}
+		return m.Head.Sha, nil
 	case *protocol.PerforceChangelist:
 		return "", nil
 	default:
@@ -955,6 +1037,11 @@ func (c *Changeset) HeadRef() (string, error) {
 		return m.SourceRefName, nil
 	case *gerritbatches.AnnotatedChange:
 		return "", nil
+	case *giteabatches.AnnotatedPullRequest:
+		if m.Head == nil {
+			return "", nil
+		}
+		return "refs/heads/" + m.Head.Ref, nil
 	case *protocol.PerforceChangelist:
 		return "", nil
 	default:
@@ -979,6 +1066,11 @@ func (c *Changeset) BaseRefOid() (string, error) {
 		return "", nil
 	case *gerritbatches.AnnotatedChange:
 		return "", nil
+	case *giteabatches.AnnotatedPullRequest:
+		if m.Base == nil {
+			return "", nil
+		}
+		return m.Base.Sha, nil
 	case *protocol.PerforceChangelist:
 		return "", nil
 	default:
@@ -1002,6 +1094,11 @@ func (c *Changeset) BaseRef() (string, error) {
 		return m.TargetRefName, nil
 	case *gerritbatches.AnnotatedChange:
 		return "refs/heads/" + m.Change.Branch, nil
+	case *giteabatches.AnnotatedPullRequest:
+		if m.Base == nil {
+			return "", nil
+		}
+		return "refs/heads/" + m.Base.Ref, nil
 	case *protocol.PerforceChangelist:
 		// TODO: @peterguy we may need to change this to something.
 		return "", nil
@@ -1010,6 +1107,20 @@ func (c *Changeset) BaseRef() (string, error) {
 	}
 }
 
+// HasMergeConflict returns true if the codehost reported that the changeset
+// can't be merged into its base branch because of conflicting changes. Code
+// hosts that don't report mergeability always return false.
+func (c *Changeset) HasMergeConflict() bool {
+	switch m := c.Metadata.(type) {
+	case *github.PullRequest:
+		return m.Mergeable == "CONFLICTING"
+	case *gitlab.MergeRequest:
+		return m.HasConflicts
+	default:
+		return false
+	}
+}
+
 // AttachedTo returns true if the changeset is currently attached to the batch
 // change with the given batchChangeID.
 func (c *Changeset) AttachedTo(batchChangeID int64) bool {
@@ -1121,6 +1232,16 @@ func (c *Changeset) Labels() []ChangesetLabel {
 			labels[i] = ChangesetLabel{Name: l, Color: "000000"}
 		}
 		return labels
+	case *giteabatches.AnnotatedPullRequest:
+		labels := make([]ChangesetLabel, len(m.Labels))
+		for i, l := range m.Labels {
+			labels[i] = ChangesetLabel{
+				Name:        l.Name,
+				Color:       strings.TrimPrefix(l.Color, "#"),
+				Description: l.Description,
+			}
+		}
+		return labels
 	default:
 		return []ChangesetLabel{}
 	}
@@ -1399,6 +1520,17 @@ func ChangesetEventKindFor(e any) (ChangesetEventKind, error) {
 				}
 			}
 		}
+	case *gitea.PullRequestReview:
+		switch e.State {
+		case gitea.ReviewStateApproved:
+			return ChangesetEventKindGiteaApproved, nil
+		case gitea.ReviewStateRequestChanges:
+			return ChangesetEventKindGiteaChangesRequested, nil
+		default:
+			return ChangesetEventKindGiteaReviewed, nil
+		}
+	case *gitea.CommitStatus:
+		return ChangesetEventKindGiteaCommitStatus, nil
 	}
 
 	return ChangesetEventKindInvalid, errors.Errorf("changeset eventkindfor unknown changeset event kind for %T", e)
@@ -1548,6 +1680,15 @@ func NewChangesetEventMetadata(k ChangesetEventKind) (any, error) {
 			ChangesetEventKindGerritChangeBuildSucceeded:
 			return new(gerrit.Reviewer), nil
 		}
+	case strings.HasPrefix(string(k), "gitea"):
+		switch k {
+		case ChangesetEventKindGiteaApproved,
+			ChangesetEventKindGiteaChangesRequested,
+			ChangesetEventKindGiteaReviewed:
+			return new(gitea.PullRequestReview), nil
+		case ChangesetEventKindGiteaCommitStatus:
+			return new(gitea.CommitStatus), nil
+		}
 	}
 	return nil, errors.Errorf("changeset event metadata unknown changeset event kind %q", k)
 }
@@ -0,0 +1,22 @@
+package types
+
+import "time"
+
+// A ChangesetAutoMergeState records why the auto-merger has not merged an open
+// changeset yet, and how long it backs off from merging it after the code host
+// reported the changeset as not mergeable.
+type ChangesetAutoMergeState struct {
+	ChangesetID int64
+
+	// Reason describes why the changeset was not merged when the auto-merge
+	// policy of its batch change was last evaluated.
+	Reason string
+	// Backoff is the delay after the most recent attempt that the code host
+	// refused to merge. It doubles with each consecutive refusal.
+	Backoff time.Duration
+	// RetryAfter is the time before which no further merge is attempted. It is
+	// zero if the code host hasn't refused to merge the changeset.
+	RetryAfter time.Time
+
+	UpdatedAt time.Time
+}
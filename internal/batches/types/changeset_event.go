@@ -12,6 +12,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	gitlabwebhooks "github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab/webhooks"
@@ -125,6 +126,14 @@ const (
 	ChangesetEventKindGerritChangeBuildFailed             ChangesetEventKind = "gerrit:change:build_failed"
 	ChangesetEventKindGerritChangeBuildPending            ChangesetEventKind = "gerrit:change:build_pending"
 
+	// These changeset events are created as the result of regular syncs with
+	// Gitea. Webhooks received from Gitea enqueue a sync instead of creating
+	// events directly.
+	ChangesetEventKindGiteaApproved         ChangesetEventKind = "gitea:approved"
+	ChangesetEventKindGiteaChangesRequested ChangesetEventKind = "gitea:changes_requested"
+	ChangesetEventKindGiteaReviewed         ChangesetEventKind = "gitea:reviewed"
+	ChangesetEventKindGiteaCommitStatus     ChangesetEventKind = "gitea:commit_status"
+
 	ChangesetEventKindInvalid ChangesetEventKind = "invalid"
 )
 
@@ -180,6 +189,12 @@ func (e *ChangesetEvent) ReviewAuthor() string {
 	case *bitbucketcloud.PullRequestChangesRequestRemovedEvent:
 		return meta.ChangesRequest.User.UUID
 
+	case *gitea.PullRequestReview:
+		if meta.Reviewer == nil {
+			return ""
+		}
+		return meta.Reviewer.Login
+
 	case *azuredevops.PullRequestApprovedEvent:
 		if len(meta.PullRequest.Reviewers) == 0 {
 			return meta.PullRequest.CreatedBy.UniqueName
@@ -214,7 +229,8 @@ func (e *ChangesetEvent) ReviewState() (ChangesetReviewState, error) {
 		ChangesetEventKindGitLabApproved,
 		ChangesetEventKindBitbucketCloudApproved,
 		ChangesetEventKindBitbucketCloudPullRequestApproved,
-		ChangesetEventKindAzureDevOpsPullRequestApproved:
+		ChangesetEventKindAzureDevOpsPullRequestApproved,
+		ChangesetEventKindGiteaApproved:
 		return ChangesetReviewStateApproved, nil
 
 	// BitbucketServer's "REVIEWED" activity is created when someone clicks
@@ -223,7 +239,8 @@ func (e *ChangesetEvent) ReviewState() (ChangesetReviewState, error) {
 		ChangesetEventKindBitbucketCloudChangesRequested,
 		ChangesetEventKindBitbucketCloudPullRequestChangesRequestCreated,
 		ChangesetEventKindAzureDevOpsPullRequestWaitingForAuthor,
-		ChangesetEventKindAzureDevOpsPullRequestApprovedWithSuggestions:
+		ChangesetEventKindAzureDevOpsPullRequestApprovedWithSuggestions,
+		ChangesetEventKindGiteaChangesRequested:
 		return ChangesetReviewStateChangesRequested, nil
 
 	case ChangesetEventKindGitHubReviewed:
@@ -370,6 +387,10 @@ func (e *ChangesetEvent) Timestamp() time.Time {
 		t = ev.CreatedDate
 	case *azuredevops.PullRequestMergedEvent:
 		t = ev.CreatedDate
+	case *gitea.PullRequestReview:
+		t = ev.SubmittedAt
+	case *gitea.CommitStatus:
+		t = ev.UpdatedAt
 	}
 
 	return t
@@ -913,6 +934,13 @@ func (e *ChangesetEvent) Update(o *ChangesetEvent) error {
 	case *azuredevops.PullRequestRejectedEvent:
 		o := o.Metadata.(*azuredevops.PullRequestRejectedEvent)
 		*e = *o
+
+	case *gitea.PullRequestReview:
+		o := o.Metadata.(*gitea.PullRequestReview)
+		*e = *o
+	case *gitea.CommitStatus:
+		o := o.Metadata.(*gitea.CommitStatus)
+		*e = *o
 	default:
 		return errors.Errorf("unknown changeset event metadata %T", e)
 	}
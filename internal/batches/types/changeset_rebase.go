@@ -0,0 +1,68 @@
+package types
+
+import (
+	"strings"
+	"time"
+)
+
+// ChangesetRebaseState defines the possible states of an automatic rebase of a
+// changeset.
+type ChangesetRebaseState string
+
+// ChangesetRebaseState constants.
+const (
+	ChangesetRebaseStateQueued    ChangesetRebaseState = "QUEUED"
+	ChangesetRebaseStateExecuting ChangesetRebaseState = "EXECUTING"
+	ChangesetRebaseStateCompleted ChangesetRebaseState = "COMPLETED"
+	ChangesetRebaseStateFailed    ChangesetRebaseState = "FAILED"
+)
+
+// Valid returns true if the given ChangesetRebaseState is valid.
+func (s ChangesetRebaseState) Valid() bool {
+	switch s {
+	case ChangesetRebaseStateQueued,
+		ChangesetRebaseStateExecuting,
+		ChangesetRebaseStateCompleted,
+		ChangesetRebaseStateFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+// ToDB returns the database representation of the rebase state.
+func (s ChangesetRebaseState) ToDB() string { return strings.ToLower(string(s)) }
+
+// A ChangesetRebase is an automatic re-execution of the steps that produced a
+// changeset, triggered by the changeset conflicting with its base branch. The
+// diff produced by re-executing the workspace against the new base commit is
+// force-pushed to the changeset's branch.
+type ChangesetRebase struct {
+	ID            int64
+	ChangesetID   int64
+	BatchChangeID int64
+
+	// PreviousChangesetSpecID is the changeset spec that was current when the
+	// conflict was detected.
+	PreviousChangesetSpecID int64
+	// BatchSpecWorkspaceID is the workspace created to re-execute the steps
+	// against BaseRev.
+	BatchSpecWorkspaceID int64
+	// ChangesetSpecID is the changeset spec produced by the re-execution.
+	ChangesetSpecID int64
+	// BaseRev is the commit of the base branch the steps are re-executed
+	// against.
+	BaseRev string
+
+	State          ChangesetRebaseState
+	FailureMessage *string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Clone returns a clone of a ChangesetRebase.
+func (r *ChangesetRebase) Clone() *ChangesetRebase {
+	rr := *r
+	return &rr
+}
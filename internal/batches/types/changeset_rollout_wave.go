@@ -0,0 +1,22 @@
+package types
+
+import "time"
+
+// A ChangesetRolloutWave records which wave of a batch change's rollout policy
+// a changeset belongs to. The reconciler doesn't publish a changeset before
+// the batch change's rollout has reached its wave.
+type ChangesetRolloutWave struct {
+	ChangesetID   int64
+	BatchChangeID int64
+
+	// Wave is the index of the wave in the rollout policy. Changesets that
+	// don't match any wave are assigned to an implicit final wave, whose index
+	// is the number of waves in the policy.
+	Wave int32
+	// Held is true when the reconciler held back publishing the changeset
+	// because the rollout hadn't reached its wave yet.
+	Held bool
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
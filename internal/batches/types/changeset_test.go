@@ -731,6 +731,49 @@ func TestChangeset_BaseRef(t *testing.T) {
 	})
 }
 
+func TestChangeset_HasMergeConflict(t *testing.T) {
+	for name, tc := range map[string]struct {
+		meta any
+		want bool
+	}{
+		"bitbucketserver": {
+			meta: &bitbucketserver.PullRequest{},
+			want: false,
+		},
+		"GitHub conflicting": {
+			meta: &github.PullRequest{Mergeable: "CONFLICTING"},
+			want: true,
+		},
+		"GitHub mergeable": {
+			meta: &github.PullRequest{Mergeable: "MERGEABLE"},
+			want: false,
+		},
+		"GitHub unknown": {
+			meta: &github.PullRequest{Mergeable: "UNKNOWN"},
+			want: false,
+		},
+		"GitLab conflicting": {
+			meta: &gitlab.MergeRequest{HasConflicts: true},
+			want: true,
+		},
+		"GitLab mergeable": {
+			meta: &gitlab.MergeRequest{},
+			want: false,
+		},
+		"unknown changeset type": {
+			meta: nil,
+			want: false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := &Changeset{Metadata: tc.meta}
+			if have := c.HasMergeConflict(); have != tc.want {
+				t.Errorf("unexpected result: have %t; want %t", have, tc.want)
+			}
+		})
+	}
+}
+
 func TestChangeset_Labels(t *testing.T) {
 	for name, tc := range map[string]struct {
 		meta any
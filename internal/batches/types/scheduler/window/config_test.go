@@ -197,6 +197,60 @@ func TestConfiguration_Schedule(t *testing.T) {
 	}
 }
 
+func TestConfiguration_IsOpen(t *testing.T) {
+	// Wednesday, 10:00 UTC.
+	at := time.Date(2021, 4, 7, 10, 0, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		cfg  *Configuration
+		want bool
+	}{
+		"no rollout windows": {
+			cfg:  &Configuration{windows: []Window{}},
+			want: true,
+		},
+		"open window": {
+			cfg: &Configuration{
+				windows: []Window{
+					{days: newWeekdaySet(time.Wednesday), rate: rate{n: 10, unit: ratePerHour}},
+				},
+			},
+			want: true,
+		},
+		"unlimited window": {
+			cfg: &Configuration{
+				windows: []Window{
+					{days: newWeekdaySet(), start: timeOfDayPtr(8, 0), end: timeOfDayPtr(12, 0), rate: makeUnlimitedRate()},
+				},
+			},
+			want: true,
+		},
+		"zero rate window": {
+			cfg: &Configuration{
+				windows: []Window{
+					{days: newWeekdaySet(), rate: rate{n: 10, unit: ratePerHour}},
+					{days: newWeekdaySet(time.Wednesday), rate: rate{n: 0}},
+				},
+			},
+			want: false,
+		},
+		"no window in effect": {
+			cfg: &Configuration{
+				windows: []Window{
+					{days: newWeekdaySet(time.Saturday, time.Sunday), rate: makeUnlimitedRate()},
+				},
+			},
+			want: false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if have := tc.cfg.IsOpen(at); have != tc.want {
+				t.Errorf("unexpected result: have=%v want=%v", have, tc.want)
+			}
+		})
+	}
+}
+
 func TestConfiguration_currentFor(t *testing.T) {
 	// Let's set up some common windows to simplify defining the test cases.
 
@@ -29,6 +29,7 @@ func GetSupportedExternalServices() map[string]CodehostCapabilities {
 		extsvc.TypeBitbucketCloud:  {},
 		extsvc.TypeAzureDevOps:     {CodehostCapabilityDraftChangesets: true},
 		extsvc.TypeGerrit:          {CodehostCapabilityDraftChangesets: true},
+		extsvc.TypeGitea:           {CodehostCapabilityLabels: true, CodehostCapabilityDraftChangesets: true},
 	}
 	if c := conf.Get(); c.ExperimentalFeatures != nil && c.ExperimentalFeatures.BatchChangesEnablePerforce {
 		supportedExternalServices[extsvc.TypePerforce] = CodehostCapabilities{}
@@ -74,6 +74,7 @@ func NewDependencyIndexSchedulers(
 	depsSvc DependenciesService,
 	autoindexingSvc *Service,
 	repoUpdater RepoUpdaterClient,
+	dataSource string,
 ) []goroutine.BackgroundRoutine {
 	return background.NewDependencyIndexSchedulers(
 		scopedContext("dependencies", observationCtx),
@@ -83,6 +84,7 @@ func NewDependencyIndexSchedulers(
 		autoindexingSvc.store,
 		autoindexingSvc.indexEnqueuer,
 		repoUpdater,
+		dataSource,
 		DependenciesConfigInst,
 	)
 }
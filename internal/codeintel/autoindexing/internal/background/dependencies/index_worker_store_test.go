@@ -51,6 +51,15 @@ func Test_AutoIndexingManualEnqueuedDequeueOrder(t *testing.T) {
 			},
 			nextID: 3,
 		},
+		{
+			// Repositories take turns, even when their indexes were queued later.
+			indexes: []shared.Index{
+				{ID: 1, RepositoryID: 1, State: "processing"},
+				{ID: 2, RepositoryID: 1},
+				{ID: 3, RepositoryID: 2},
+			},
+			nextID: 3,
+		},
 	} {
 		t.Run(fmt.Sprint(i), func(t *testing.T) {
 			if _, err := db.ExecContext(context.Background(), "TRUNCATE lsif_indexes RESTART IDENTITY CASCADE"); err != nil {
@@ -27,20 +27,23 @@ import (
 // records from lsif_dependency_syncing_jobs.
 func NewDependencySyncScheduler(
 	dependencySyncStore dbworkerstore.Store[dependencySyncingJob],
+	dependencyIndexingStore dbworkerstore.Store[dependencyIndexingJob],
 	uploadSvc UploadService,
 	depsSvc DependenciesService,
 	store store.Store,
 	externalServiceStore ExternalServiceStore,
 	metrics workerutil.WorkerObservability,
+	wakeup <-chan struct{},
 	config *Config,
 ) *workerutil.Worker[dependencySyncingJob] {
 	rootContext := actor.WithInternalActor(context.Background())
 	handler := &dependencySyncSchedulerHandler{
-		uploadsSvc:  uploadSvc,
-		depsSvc:     depsSvc,
-		store:       store,
-		workerStore: dependencySyncStore,
-		extsvcStore: externalServiceStore,
+		uploadsSvc:          uploadSvc,
+		depsSvc:             depsSvc,
+		store:               store,
+		workerStore:         dependencySyncStore,
+		indexingWorkerStore: dependencyIndexingStore,
+		extsvcStore:         externalServiceStore,
 	}
 
 	return dbworker.NewWorker[dependencySyncingJob](rootContext, dependencySyncStore, handler, workerutil.WorkerOptions{
@@ -48,17 +51,19 @@ func NewDependencySyncScheduler(
 		Description:       "reads dependency package references from code-intel uploads to be synced to the instance",
 		NumHandlers:       1,
 		Interval:          config.DependencySyncSchedulerPollInterval,
+		Wakeup:            wakeup,
 		HeartbeatInterval: 1 * time.Second,
 		Metrics:           metrics,
 	})
 }
 
 type dependencySyncSchedulerHandler struct {
-	uploadsSvc  UploadService
-	depsSvc     DependenciesService
-	store       store.Store
-	workerStore dbworkerstore.Store[dependencySyncingJob]
-	extsvcStore ExternalServiceStore
+	uploadsSvc          UploadService
+	depsSvc             DependenciesService
+	store               store.Store
+	workerStore         dbworkerstore.Store[dependencySyncingJob]
+	indexingWorkerStore dbworkerstore.Store[dependencyIndexingJob]
+	extsvcStore         ExternalServiceStore
 }
 
 // For mocking in tests
@@ -201,8 +206,8 @@ func (h *dependencySyncSchedulerHandler) Handle(ctx context.Context, logger log.
 	if shouldIndex {
 		// If we saw a kind that's not in schemeToExternalService, then kinds contains an empty string key
 		for kind := range kinds {
-			if _, err := h.store.InsertDependencyIndexingJob(ctx, job.UploadID, kind, nextSync); err != nil {
-				errs = append(errs, errors.Wrap(err, "dbstore.InsertDependencyIndexingJob"))
+			if err := h.insertDependencyIndexingJob(ctx, job, kind, nextSync); err != nil {
+				errs = append(errs, err)
 			}
 		}
 	}
@@ -218,6 +223,26 @@ func (h *dependencySyncSchedulerHandler) Handle(ctx context.Context, logger log.
 	return errors.Append(nil, errs...)
 }
 
+// insertDependencyIndexingJob inserts a dependency indexing job for the upload of the given job,
+// which is not dequeued before the given job has completed, so that the external services it
+// scheduled for syncing are not read too early.
+func (h *dependencySyncSchedulerHandler) insertDependencyIndexingJob(ctx context.Context, job dependencySyncingJob, kind string, nextSync time.Time) error {
+	return h.store.WithTransaction(ctx, func(tx store.Store) error {
+		id, err := tx.InsertDependencyIndexingJob(ctx, job.UploadID, kind, nextSync)
+		if err != nil {
+			return errors.Wrap(err, "dbstore.InsertDependencyIndexingJob")
+		}
+
+		if err := h.indexingWorkerStore.With(tx.Handle()).AddDependencies(ctx, id, []dbworkerstore.RecordReference{
+			{TableName: DependencySyncingJobWorkerStoreOptions.TableName, ID: job.ID},
+		}); err != nil {
+			return errors.Wrap(err, "dbworkerstore.AddDependencies")
+		}
+
+		return nil
+	})
+}
+
 // newPackage constructs a precise.Package from the given shared.Package,
 // applying any normalization or necessary transformations that LSIF/SCIP uploads
 // require for internal consistency.
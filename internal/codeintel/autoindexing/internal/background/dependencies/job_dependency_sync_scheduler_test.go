@@ -8,9 +8,11 @@ import (
 	"github.com/sourcegraph/log/logtest"
 	"github.com/stretchr/testify/require"
 
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/autoindexing/internal/store"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
 )
 
@@ -22,7 +24,11 @@ func TestDependencySyncSchedulerJVM(t *testing.T) {
 	mockWorkerStore := NewMockWorkerStore[dependencySyncingJob]()
 	mockUploadsSvc := NewMockUploadService()
 	mockDepedenciesSvc := NewMockDependenciesService()
+	mockIndexingWorkerStore := NewMockWorkerStore[dependencyIndexingJob]()
+	mockIndexingWorkerStore.WithFunc.SetDefaultReturn(mockIndexingWorkerStore)
 	mockStore := NewMockStore()
+	mockStore.WithTransactionFunc.SetDefaultHook(func(ctx context.Context, f func(tx store.Store) error) error { return f(mockStore) })
+	mockStore.InsertDependencyIndexingJobFunc.SetDefaultReturn(7, nil)
 	mockExtsvcStore := NewMockExternalServiceStore()
 	mockScanner := NewMockPackageReferenceScanner()
 	mockUploadsSvc.ReferencesForUploadFunc.SetDefaultReturn(mockScanner, nil)
@@ -30,15 +36,17 @@ func TestDependencySyncSchedulerJVM(t *testing.T) {
 	mockScanner.NextFunc.PushReturn(shared.PackageReference{Package: shared.Package{DumpID: 42, Scheme: dependencies.JVMPackagesScheme, Name: "name1", Version: "v2.2.0"}}, true, nil)
 
 	handler := dependencySyncSchedulerHandler{
-		uploadsSvc:  mockUploadsSvc,
-		depsSvc:     mockDepedenciesSvc,
-		store:       mockStore,
-		workerStore: mockWorkerStore,
-		extsvcStore: mockExtsvcStore,
+		uploadsSvc:          mockUploadsSvc,
+		depsSvc:             mockDepedenciesSvc,
+		store:               mockStore,
+		workerStore:         mockWorkerStore,
+		indexingWorkerStore: mockIndexingWorkerStore,
+		extsvcStore:         mockExtsvcStore,
 	}
 
 	logger := logtest.Scoped(t)
 	job := dependencySyncingJob{
+		ID:       3,
 		UploadID: 42,
 	}
 	if err := handler.Handle(context.Background(), logger, job); err != nil {
@@ -59,6 +67,19 @@ func TestDependencySyncSchedulerJVM(t *testing.T) {
 		}
 	}
 
+	if calls := mockIndexingWorkerStore.AddDependenciesFunc.History(); len(calls) != 1 {
+		t.Errorf("unexpected number of calls to AddDependencies. want=%d have=%d", 1, len(calls))
+	} else {
+		if calls[0].Arg1 != 7 {
+			t.Errorf("unexpected dependent. want=%d have=%d", 7, calls[0].Arg1)
+		}
+
+		expectedPrerequisites := []dbworkerstore.RecordReference{{TableName: "lsif_dependency_syncing_jobs", ID: 3}}
+		if diff := cmp.Diff(expectedPrerequisites, calls[0].Arg2); diff != "" {
+			t.Errorf("unexpected prerequisites (-want +got):\n%s", diff)
+		}
+	}
+
 	if len(mockExtsvcStore.ListFunc.History()) != 1 {
 		t.Errorf("unexpected number of calls to extsvc.List. want=%d have=%d", 1, len(mockExtsvcStore.ListFunc.History()))
 	}
@@ -73,7 +94,11 @@ func TestDependencySyncSchedulerGomod(t *testing.T) {
 	mockWorkerStore := NewMockWorkerStore[dependencySyncingJob]()
 	mockUploadsSvc := NewMockUploadService()
 	mockDepedenciesSvc := NewMockDependenciesService()
+	mockIndexingWorkerStore := NewMockWorkerStore[dependencyIndexingJob]()
+	mockIndexingWorkerStore.WithFunc.SetDefaultReturn(mockIndexingWorkerStore)
 	mockStore := NewMockStore()
+	mockStore.WithTransactionFunc.SetDefaultHook(func(ctx context.Context, f func(tx store.Store) error) error { return f(mockStore) })
+	mockStore.InsertDependencyIndexingJobFunc.SetDefaultReturn(7, nil)
 	mockExtsvcStore := NewMockExternalServiceStore()
 	mockScanner := NewMockPackageReferenceScanner()
 	mockUploadsSvc.ReferencesForUploadFunc.SetDefaultReturn(mockScanner, nil)
@@ -81,15 +106,17 @@ func TestDependencySyncSchedulerGomod(t *testing.T) {
 	mockScanner.NextFunc.PushReturn(shared.PackageReference{Package: shared.Package{DumpID: 42, Scheme: "gomod", Name: "name1", Version: "v2.2.0"}}, true, nil)
 
 	handler := dependencySyncSchedulerHandler{
-		uploadsSvc:  mockUploadsSvc,
-		depsSvc:     mockDepedenciesSvc,
-		store:       mockStore,
-		workerStore: mockWorkerStore,
-		extsvcStore: mockExtsvcStore,
+		uploadsSvc:          mockUploadsSvc,
+		depsSvc:             mockDepedenciesSvc,
+		store:               mockStore,
+		workerStore:         mockWorkerStore,
+		indexingWorkerStore: mockIndexingWorkerStore,
+		extsvcStore:         mockExtsvcStore,
 	}
 
 	logger := logtest.Scoped(t)
 	job := dependencySyncingJob{
+		ID:       3,
 		UploadID: 42,
 	}
 	if err := handler.Handle(context.Background(), logger, job); err != nil {
@@ -6,6 +6,7 @@ import (
 	"github.com/sourcegraph/log"
 
 	uploadsshared "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
 	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
 )
@@ -39,3 +40,22 @@ func NewDependencyIndexResetter(logger log.Logger, interval time.Duration, store
 		},
 	})
 }
+
+// NewDependencySyncResolver returns a background routine that periodically records the states of
+// dependency sync records that were deleted or moved to a terminal state outside of the worker, so
+// that the dependency index records that depend on them are not blocked forever.
+func NewDependencySyncResolver(logger log.Logger, interval time.Duration, store dbworkerstore.Store[dependencySyncingJob]) goroutine.BackgroundRoutine {
+	return dbworker.NewDependencyResolver(logger, store, dbworker.DependencyResolverOptions{
+		Name:     "precise_code_intel_dependency_sync_dependency_resolver",
+		Interval: interval,
+	})
+}
+
+// NewDependencyIndexResolver returns a background routine that periodically fails dependency index
+// records whose prerequisites, the dependency sync records that created them, can no longer complete.
+func NewDependencyIndexResolver(logger log.Logger, interval time.Duration, store dbworkerstore.Store[dependencyIndexingJob]) goroutine.BackgroundRoutine {
+	return dbworker.NewDependencyResolver(logger, store, dbworker.DependencyResolverOptions{
+		Name:     "precise_code_intel_dependency_index_dependency_resolver",
+		Interval: interval,
+	})
+}
@@ -1935,10 +1935,16 @@ type MockStore struct {
 	// GetQueuedRepoRevFunc is an instance of a mock function object
 	// controlling the behavior of the method GetQueuedRepoRev.
 	GetQueuedRepoRevFunc *StoreGetQueuedRepoRevFunc
+	// GetQueuedRootsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetQueuedRoots.
+	GetQueuedRootsFunc *StoreGetQueuedRootsFunc
 	// GetRepositoriesForIndexScanFunc is an instance of a mock function
 	// object controlling the behavior of the method
 	// GetRepositoriesForIndexScan.
 	GetRepositoriesForIndexScanFunc *StoreGetRepositoriesForIndexScanFunc
+	// HandleFunc is an instance of a mock function object controlling the
+	// behavior of the method Handle.
+	HandleFunc *StoreHandleFunc
 	// InsertDependencyIndexingJobFunc is an instance of a mock function
 	// object controlling the behavior of the method
 	// InsertDependencyIndexingJob.
@@ -2015,11 +2021,21 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		GetQueuedRootsFunc: &StoreGetQueuedRootsFunc{
+			defaultHook: func(context.Context, int, string) (r0 []string, r1 error) {
+				return
+			},
+		},
 		GetRepositoriesForIndexScanFunc: &StoreGetRepositoriesForIndexScanFunc{
 			defaultHook: func(context.Context, time.Duration, bool, *int, int, time.Time) (r0 []int, r1 error) {
 				return
 			},
 		},
+		HandleFunc: &StoreHandleFunc{
+			defaultHook: func() (r0 *basestore.Store) {
+				return
+			},
+		},
 		InsertDependencyIndexingJobFunc: &StoreInsertDependencyIndexingJobFunc{
 			defaultHook: func(context.Context, int, string, time.Time) (r0 int, r1 error) {
 				return
@@ -2122,11 +2138,21 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.GetQueuedRepoRev")
 			},
 		},
+		GetQueuedRootsFunc: &StoreGetQueuedRootsFunc{
+			defaultHook: func(context.Context, int, string) ([]string, error) {
+				panic("unexpected invocation of MockStore.GetQueuedRoots")
+			},
+		},
 		GetRepositoriesForIndexScanFunc: &StoreGetRepositoriesForIndexScanFunc{
 			defaultHook: func(context.Context, time.Duration, bool, *int, int, time.Time) ([]int, error) {
 				panic("unexpected invocation of MockStore.GetRepositoriesForIndexScan")
 			},
 		},
+		HandleFunc: &StoreHandleFunc{
+			defaultHook: func() *basestore.Store {
+				panic("unexpected invocation of MockStore.Handle")
+			},
+		},
 		InsertDependencyIndexingJobFunc: &StoreInsertDependencyIndexingJobFunc{
 			defaultHook: func(context.Context, int, string, time.Time) (int, error) {
 				panic("unexpected invocation of MockStore.InsertDependencyIndexingJob")
@@ -2221,9 +2247,15 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		GetQueuedRepoRevFunc: &StoreGetQueuedRepoRevFunc{
 			defaultHook: i.GetQueuedRepoRev,
 		},
+		GetQueuedRootsFunc: &StoreGetQueuedRootsFunc{
+			defaultHook: i.GetQueuedRoots,
+		},
 		GetRepositoriesForIndexScanFunc: &StoreGetRepositoriesForIndexScanFunc{
 			defaultHook: i.GetRepositoriesForIndexScan,
 		},
+		HandleFunc: &StoreHandleFunc{
+			defaultHook: i.Handle,
+		},
 		InsertDependencyIndexingJobFunc: &StoreInsertDependencyIndexingJobFunc{
 			defaultHook: i.InsertDependencyIndexingJob,
 		},
@@ -2712,6 +2744,117 @@ func (c StoreGetQueuedRepoRevFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreGetQueuedRootsFunc describes the behavior when the GetQueuedRoots
+// method of the parent MockStore instance is invoked.
+type StoreGetQueuedRootsFunc struct {
+	defaultHook func(context.Context, int, string) ([]string, error)
+	hooks       []func(context.Context, int, string) ([]string, error)
+	history     []StoreGetQueuedRootsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetQueuedRoots delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockStore) GetQueuedRoots(v0 context.Context, v1 int, v2 string) ([]string, error) {
+	r0, r1 := m.GetQueuedRootsFunc.nextHook()(v0, v1, v2)
+	m.GetQueuedRootsFunc.appendCall(StoreGetQueuedRootsFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetQueuedRoots
+// method of the parent MockStore instance is invoked and the hook queue is
+// empty.
+func (f *StoreGetQueuedRootsFunc) SetDefaultHook(hook func(context.Context, int, string) ([]string, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetQueuedRoots method of the parent MockStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *StoreGetQueuedRootsFunc) PushHook(hook func(context.Context, int, string) ([]string, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreGetQueuedRootsFunc) SetDefaultReturn(r0 []string, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, string) ([]string, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreGetQueuedRootsFunc) PushReturn(r0 []string, r1 error) {
+	f.PushHook(func(context.Context, int, string) ([]string, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreGetQueuedRootsFunc) nextHook() func(context.Context, int, string) ([]string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetQueuedRootsFunc) appendCall(r0 StoreGetQueuedRootsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreGetQueuedRootsFuncCall objects
+// describing the invocations of this function.
+func (f *StoreGetQueuedRootsFunc) History() []StoreGetQueuedRootsFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetQueuedRootsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetQueuedRootsFuncCall is an object that describes an invocation of
+// method GetQueuedRoots on an instance of MockStore.
+type StoreGetQueuedRootsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetQueuedRootsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetQueuedRootsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // StoreGetRepositoriesForIndexScanFunc describes the behavior when the
 // GetRepositoriesForIndexScan method of the parent MockStore instance is
 // invoked.
@@ -2835,6 +2978,104 @@ func (c StoreGetRepositoriesForIndexScanFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreHandleFunc describes the behavior when the Handle method of the
+// parent MockStore instance is invoked.
+type StoreHandleFunc struct {
+	defaultHook func() *basestore.Store
+	hooks       []func() *basestore.Store
+	history     []StoreHandleFuncCall
+	mutex       sync.Mutex
+}
+
+// Handle delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockStore) Handle() *basestore.Store {
+	r0 := m.HandleFunc.nextHook()()
+	m.HandleFunc.appendCall(StoreHandleFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Handle method of the
+// parent MockStore instance is invoked and the hook queue is empty.
+func (f *StoreHandleFunc) SetDefaultHook(hook func() *basestore.Store) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Handle method of the parent MockStore instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *StoreHandleFunc) PushHook(hook func() *basestore.Store) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreHandleFunc) SetDefaultReturn(r0 *basestore.Store) {
+	f.SetDefaultHook(func() *basestore.Store {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreHandleFunc) PushReturn(r0 *basestore.Store) {
+	f.PushHook(func() *basestore.Store {
+		return r0
+	})
+}
+
+func (f *StoreHandleFunc) nextHook() func() *basestore.Store {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreHandleFunc) appendCall(r0 StoreHandleFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreHandleFuncCall objects describing the
+// invocations of this function.
+func (f *StoreHandleFunc) History() []StoreHandleFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreHandleFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreHandleFuncCall is an object that describes an invocation of method
+// Handle on an instance of MockStore.
+type StoreHandleFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *basestore.Store
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreHandleFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreHandleFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // StoreInsertDependencyIndexingJobFunc describes the behavior when the
 // InsertDependencyIndexingJob method of the parent MockStore instance is
 // invoked.
@@ -4498,9 +4739,15 @@ func (c StoreWithTransactionFuncCall) Results() []interface{} {
 // github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store)
 // used for unit testing.
 type MockWorkerStore[T workerutil.Record] struct {
+	// AddDependenciesFunc is an instance of a mock function object
+	// controlling the behavior of the method AddDependencies.
+	AddDependenciesFunc *WorkerStoreAddDependenciesFunc[T]
 	// AddExecutionLogEntryFunc is an instance of a mock function object
 	// controlling the behavior of the method AddExecutionLogEntry.
 	AddExecutionLogEntryFunc *WorkerStoreAddExecutionLogEntryFunc[T]
+	// DependenciesFunc is an instance of a mock function object controlling
+	// the behavior of the method Dependencies.
+	DependenciesFunc *WorkerStoreDependenciesFunc[T]
 	// DequeueFunc is an instance of a mock function object controlling the
 	// behavior of the method Dequeue.
 	DequeueFunc *WorkerStoreDequeueFunc[T]
@@ -4522,15 +4769,24 @@ type MockWorkerStore[T workerutil.Record] struct {
 	// MaxDurationInQueueFunc is an instance of a mock function object
 	// controlling the behavior of the method MaxDurationInQueue.
 	MaxDurationInQueueFunc *WorkerStoreMaxDurationInQueueFunc[T]
+	// QueueStatsByPartitionFunc is an instance of a mock function object
+	// controlling the behavior of the method QueueStatsByPartition.
+	QueueStatsByPartitionFunc *WorkerStoreQueueStatsByPartitionFunc[T]
 	// QueuedCountFunc is an instance of a mock function object controlling
 	// the behavior of the method QueuedCount.
 	QueuedCountFunc *WorkerStoreQueuedCountFunc[T]
+	// ReleaseFunc is an instance of a mock function object controlling the
+	// behavior of the method Release.
+	ReleaseFunc *WorkerStoreReleaseFunc[T]
 	// RequeueFunc is an instance of a mock function object controlling the
 	// behavior of the method Requeue.
 	RequeueFunc *WorkerStoreRequeueFunc[T]
 	// ResetStalledFunc is an instance of a mock function object controlling
 	// the behavior of the method ResetStalled.
 	ResetStalledFunc *WorkerStoreResetStalledFunc[T]
+	// ResolveDependenciesFunc is an instance of a mock function object
+	// controlling the behavior of the method ResolveDependencies.
+	ResolveDependenciesFunc *WorkerStoreResolveDependenciesFunc[T]
 	// UpdateExecutionLogEntryFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdateExecutionLogEntry.
 	UpdateExecutionLogEntryFunc *WorkerStoreUpdateExecutionLogEntryFunc[T]
@@ -4543,11 +4799,21 @@ type MockWorkerStore[T workerutil.Record] struct {
 // return zero values for all results, unless overwritten.
 func NewMockWorkerStore[T workerutil.Record]() *MockWorkerStore[T] {
 	return &MockWorkerStore[T]{
+		AddDependenciesFunc: &WorkerStoreAddDependenciesFunc[T]{
+			defaultHook: func(context.Context, int, []store1.RecordReference) (r0 error) {
+				return
+			},
+		},
 		AddExecutionLogEntryFunc: &WorkerStoreAddExecutionLogEntryFunc[T]{
 			defaultHook: func(context.Context, int, executor.ExecutionLogEntry, store1.ExecutionLogEntryOptions) (r0 int, r1 error) {
 				return
 			},
 		},
+		DependenciesFunc: &WorkerStoreDependenciesFunc[T]{
+			defaultHook: func(context.Context, int) (r0 []store1.Dependency, r1 error) {
+				return
+			},
+		},
 		DequeueFunc: &WorkerStoreDequeueFunc[T]{
 			defaultHook: func(context.Context, string, []*sqlf.Query) (r0 T, r1 bool, r2 error) {
 				return
@@ -4583,11 +4849,21 @@ func NewMockWorkerStore[T workerutil.Record]() *MockWorkerStore[T] {
 				return
 			},
 		},
+		QueueStatsByPartitionFunc: &WorkerStoreQueueStatsByPartitionFunc[T]{
+			defaultHook: func(context.Context, int) (r0 []store1.PartitionQueueStats, r1 error) {
+				return
+			},
+		},
 		QueuedCountFunc: &WorkerStoreQueuedCountFunc[T]{
 			defaultHook: func(context.Context, bool) (r0 int, r1 error) {
 				return
 			},
 		},
+		ReleaseFunc: &WorkerStoreReleaseFunc[T]{
+			defaultHook: func(context.Context, int, time.Time) (r0 error) {
+				return
+			},
+		},
 		RequeueFunc: &WorkerStoreRequeueFunc[T]{
 			defaultHook: func(context.Context, int, time.Time) (r0 error) {
 				return
@@ -4598,6 +4874,11 @@ func NewMockWorkerStore[T workerutil.Record]() *MockWorkerStore[T] {
 				return
 			},
 		},
+		ResolveDependenciesFunc: &WorkerStoreResolveDependenciesFunc[T]{
+			defaultHook: func(context.Context) (r0 []int, r1 error) {
+				return
+			},
+		},
 		UpdateExecutionLogEntryFunc: &WorkerStoreUpdateExecutionLogEntryFunc[T]{
 			defaultHook: func(context.Context, int, int, executor.ExecutionLogEntry, store1.ExecutionLogEntryOptions) (r0 error) {
 				return
@@ -4615,11 +4896,21 @@ func NewMockWorkerStore[T workerutil.Record]() *MockWorkerStore[T] {
 // methods panic on invocation, unless overwritten.
 func NewStrictMockWorkerStore[T workerutil.Record]() *MockWorkerStore[T] {
 	return &MockWorkerStore[T]{
+		AddDependenciesFunc: &WorkerStoreAddDependenciesFunc[T]{
+			defaultHook: func(context.Context, int, []store1.RecordReference) error {
+				panic("unexpected invocation of MockWorkerStore.AddDependencies")
+			},
+		},
 		AddExecutionLogEntryFunc: &WorkerStoreAddExecutionLogEntryFunc[T]{
 			defaultHook: func(context.Context, int, executor.ExecutionLogEntry, store1.ExecutionLogEntryOptions) (int, error) {
 				panic("unexpected invocation of MockWorkerStore.AddExecutionLogEntry")
 			},
 		},
+		DependenciesFunc: &WorkerStoreDependenciesFunc[T]{
+			defaultHook: func(context.Context, int) ([]store1.Dependency, error) {
+				panic("unexpected invocation of MockWorkerStore.Dependencies")
+			},
+		},
 		DequeueFunc: &WorkerStoreDequeueFunc[T]{
 			defaultHook: func(context.Context, string, []*sqlf.Query) (T, bool, error) {
 				panic("unexpected invocation of MockWorkerStore.Dequeue")
@@ -4655,11 +4946,21 @@ func NewStrictMockWorkerStore[T workerutil.Record]() *MockWorkerStore[T] {
 				panic("unexpected invocation of MockWorkerStore.MaxDurationInQueue")
 			},
 		},
+		QueueStatsByPartitionFunc: &WorkerStoreQueueStatsByPartitionFunc[T]{
+			defaultHook: func(context.Context, int) ([]store1.PartitionQueueStats, error) {
+				panic("unexpected invocation of MockWorkerStore.QueueStatsByPartition")
+			},
+		},
 		QueuedCountFunc: &WorkerStoreQueuedCountFunc[T]{
 			defaultHook: func(context.Context, bool) (int, error) {
 				panic("unexpected invocation of MockWorkerStore.QueuedCount")
 			},
 		},
+		ReleaseFunc: &WorkerStoreReleaseFunc[T]{
+			defaultHook: func(context.Context, int, time.Time) error {
+				panic("unexpected invocation of MockWorkerStore.Release")
+			},
+		},
 		RequeueFunc: &WorkerStoreRequeueFunc[T]{
 			defaultHook: func(context.Context, int, time.Time) error {
 				panic("unexpected invocation of MockWorkerStore.Requeue")
@@ -4670,6 +4971,11 @@ func NewStrictMockWorkerStore[T workerutil.Record]() *MockWorkerStore[T] {
 				panic("unexpected invocation of MockWorkerStore.ResetStalled")
 			},
 		},
+		ResolveDependenciesFunc: &WorkerStoreResolveDependenciesFunc[T]{
+			defaultHook: func(context.Context) ([]int, error) {
+				panic("unexpected invocation of MockWorkerStore.ResolveDependencies")
+			},
+		},
 		UpdateExecutionLogEntryFunc: &WorkerStoreUpdateExecutionLogEntryFunc[T]{
 			defaultHook: func(context.Context, int, int, executor.ExecutionLogEntry, store1.ExecutionLogEntryOptions) error {
 				panic("unexpected invocation of MockWorkerStore.UpdateExecutionLogEntry")
@@ -4688,9 +4994,15 @@ func NewStrictMockWorkerStore[T workerutil.Record]() *MockWorkerStore[T] {
 // overwritten.
 func NewMockWorkerStoreFrom[T workerutil.Record](i store1.Store[T]) *MockWorkerStore[T] {
 	return &MockWorkerStore[T]{
+		AddDependenciesFunc: &WorkerStoreAddDependenciesFunc[T]{
+			defaultHook: i.AddDependencies,
+		},
 		AddExecutionLogEntryFunc: &WorkerStoreAddExecutionLogEntryFunc[T]{
 			defaultHook: i.AddExecutionLogEntry,
 		},
+		DependenciesFunc: &WorkerStoreDependenciesFunc[T]{
+			defaultHook: i.Dependencies,
+		},
 		DequeueFunc: &WorkerStoreDequeueFunc[T]{
 			defaultHook: i.Dequeue,
 		},
@@ -4712,15 +5024,24 @@ func NewMockWorkerStoreFrom[T workerutil.Record](i store1.Store[T]) *MockWorkerS
 		MaxDurationInQueueFunc: &WorkerStoreMaxDurationInQueueFunc[T]{
 			defaultHook: i.MaxDurationInQueue,
 		},
+		QueueStatsByPartitionFunc: &WorkerStoreQueueStatsByPartitionFunc[T]{
+			defaultHook: i.QueueStatsByPartition,
+		},
 		QueuedCountFunc: &WorkerStoreQueuedCountFunc[T]{
 			defaultHook: i.QueuedCount,
 		},
+		ReleaseFunc: &WorkerStoreReleaseFunc[T]{
+			defaultHook: i.Release,
+		},
 		RequeueFunc: &WorkerStoreRequeueFunc[T]{
 			defaultHook: i.Requeue,
 		},
 		ResetStalledFunc: &WorkerStoreResetStalledFunc[T]{
 			defaultHook: i.ResetStalled,
 		},
+		ResolveDependenciesFunc: &WorkerStoreResolveDependenciesFunc[T]{
+			defaultHook: i.ResolveDependencies,
+		},
 		UpdateExecutionLogEntryFunc: &WorkerStoreUpdateExecutionLogEntryFunc[T]{
 			defaultHook: i.UpdateExecutionLogEntry,
 		},
@@ -4730,37 +5051,35 @@ func NewMockWorkerStoreFrom[T workerutil.Record](i store1.Store[T]) *MockWorkerS
 	}
 }
 
-// WorkerStoreAddExecutionLogEntryFunc describes the behavior when the
-// AddExecutionLogEntry method of the parent MockWorkerStore instance is
-// invoked.
-type WorkerStoreAddExecutionLogEntryFunc[T workerutil.Record] struct {
-	defaultHook func(context.Context, int, executor.ExecutionLogEntry, store1.ExecutionLogEntryOptions) (int, error)
-	hooks       []func(context.Context, int, executor.ExecutionLogEntry, store1.ExecutionLogEntryOptions) (int, error)
-	history     []WorkerStoreAddExecutionLogEntryFuncCall[T]
+// WorkerStoreAddDependenciesFunc describes the behavior when the
+// AddDependencies method of the parent MockWorkerStore instance is invoked.
+type WorkerStoreAddDependenciesFunc[T workerutil.Record] struct {
+	defaultHook func(context.Context, int, []store1.RecordReference) error
+	hooks       []func(context.Context, int, []store1.RecordReference) error
+	history     []WorkerStoreAddDependenciesFuncCall[T]
 	mutex       sync.Mutex
 }
 
-// AddExecutionLogEntry delegates to the next hook function in the queue and
+// AddDependencies delegates to the next hook function in the queue and
 // stores the parameter and result values of this invocation.
-func (m *MockWorkerStore[T]) AddExecutionLogEntry(v0 context.Context, v1 int, v2 executor.ExecutionLogEntry, v3 store1.ExecutionLogEntryOptions) (int, error) {
-	r0, r1 := m.AddExecutionLogEntryFunc.nextHook()(v0, v1, v2, v3)
-	m.AddExecutionLogEntryFunc.appendCall(WorkerStoreAddExecutionLogEntryFuncCall[T]{v0, v1, v2, v3, r0, r1})
-	return r0, r1
+func (m *MockWorkerStore[T]) AddDependencies(v0 context.Context, v1 int, v2 []store1.RecordReference) error {
+	r0 := m.AddDependenciesFunc.nextHook()(v0, v1, v2)
+	m.AddDependenciesFunc.appendCall(WorkerStoreAddDependenciesFuncCall[T]{v0, v1, v2, r0})
+	return r0
 }
 
-// SetDefaultHook sets function that is called when the AddExecutionLogEntry
+// SetDefaultHook sets function that is called when the AddDependencies
 // method of the parent MockWorkerStore instance is invoked and the hook
 // queue is empty.
-func (f *WorkerStoreAddExecutionLogEntryFunc[T]) SetDefaultHook(hook func(context.Context, int, executor.ExecutionLogEntry, store1.ExecutionLogEntryOptions) (int, error)) {
+func (f *WorkerStoreAddDependenciesFunc[T]) SetDefaultHook(hook func(context.Context, int, []store1.RecordReference) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// AddExecutionLogEntry method of the parent MockWorkerStore instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *WorkerStoreAddExecutionLogEntryFunc[T]) PushHook(hook func(context.Context, int, executor.ExecutionLogEntry, store1.ExecutionLogEntryOptions) (int, error)) {
+// AddDependencies method of the parent MockWorkerStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *WorkerStoreAddDependenciesFunc[T]) PushHook(hook func(context.Context, int, []store1.RecordReference) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -4768,20 +5087,20 @@ func (f *WorkerStoreAddExecutionLogEntryFunc[T]) PushHook(hook func(context.Cont
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *WorkerStoreAddExecutionLogEntryFunc[T]) SetDefaultReturn(r0 int, r1 error) {
-	f.SetDefaultHook(func(context.Context, int, executor.ExecutionLogEntry, store1.ExecutionLogEntryOptions) (int, error) {
-		return r0, r1
+func (f *WorkerStoreAddDependenciesFunc[T]) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, []store1.RecordReference) error {
+		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *WorkerStoreAddExecutionLogEntryFunc[T]) PushReturn(r0 int, r1 error) {
-	f.PushHook(func(context.Context, int, executor.ExecutionLogEntry, store1.ExecutionLogEntryOptions) (int, error) {
-		return r0, r1
+func (f *WorkerStoreAddDependenciesFunc[T]) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, []store1.RecordReference) error {
+		return r0
 	})
 }
 
-func (f *WorkerStoreAddExecutionLogEntryFunc[T]) nextHook() func(context.Context, int, executor.ExecutionLogEntry, store1.ExecutionLogEntryOptions) (int, error) {
+func (f *WorkerStoreAddDependenciesFunc[T]) nextHook() func(context.Context, int, []store1.RecordReference) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -4794,27 +5113,26 @@ func (f *WorkerStoreAddExecutionLogEntryFunc[T]) nextHook() func(context.Context
 	return hook
 }
 
-func (f *WorkerStoreAddExecutionLogEntryFunc[T]) appendCall(r0 WorkerStoreAddExecutionLogEntryFuncCall[T]) {
+func (f *WorkerStoreAddDependenciesFunc[T]) appendCall(r0 WorkerStoreAddDependenciesFuncCall[T]) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of WorkerStoreAddExecutionLogEntryFuncCall
-// objects describing the invocations of this function.
-func (f *WorkerStoreAddExecutionLogEntryFunc[T]) History() []WorkerStoreAddExecutionLogEntryFuncCall[T] {
+// History returns a sequence of WorkerStoreAddDependenciesFuncCall objects
+// describing the invocations of this function.
+func (f *WorkerStoreAddDependenciesFunc[T]) History() []WorkerStoreAddDependenciesFuncCall[T] {
 	f.mutex.Lock()
-	history := make([]WorkerStoreAddExecutionLogEntryFuncCall[T], len(f.history))
+	history := make([]WorkerStoreAddDependenciesFuncCall[T], len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// WorkerStoreAddExecutionLogEntryFuncCall is an object that describes an
-// invocation of method AddExecutionLogEntry on an instance of
-// MockWorkerStore.
-type WorkerStoreAddExecutionLogEntryFuncCall[T workerutil.Record] struct {
+// WorkerStoreAddDependenciesFuncCall is an object that describes an
+// invocation of method AddDependencies on an instance of MockWorkerStore.
+type WorkerStoreAddDependenciesFuncCall[T workerutil.Record] struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -4823,22 +5141,133 @@ type WorkerStoreAddExecutionLogEntryFuncCall[T workerutil.Record] struct {
 	Arg1 int
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
-	Arg2 executor.ExecutionLogEntry
-	// Arg3 is the value of the 4th argument passed to this method
-	// invocation.
-	Arg3 store1.ExecutionLogEntryOptions
+	Arg2 []store1.RecordReference
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 int
-	// Result1 is the value of the 2nd result returned from this method
-	// invocation.
-	Result1 error
+	Result0 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c WorkerStoreAddExecutionLogEntryFuncCall[T]) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+func (c WorkerStoreAddDependenciesFuncCall[T]) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c WorkerStoreAddDependenciesFuncCall[T]) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// WorkerStoreAddExecutionLogEntryFunc describes the behavior when the
+// AddExecutionLogEntry method of the parent MockWorkerStore instance is
+// invoked.
+type WorkerStoreAddExecutionLogEntryFunc[T workerutil.Record] struct {
+	defaultHook func(context.Context, int, executor.ExecutionLogEntry, store1.ExecutionLogEntryOptions) (int, error)
+	hooks       []func(context.Context, int, executor.ExecutionLogEntry, store1.ExecutionLogEntryOptions) (int, error)
+	history     []WorkerStoreAddExecutionLogEntryFuncCall[T]
+	mutex       sync.Mutex
+}
+
+// AddExecutionLogEntry delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockWorkerStore[T]) AddExecutionLogEntry(v0 context.Context, v1 int, v2 executor.ExecutionLogEntry, v3 store1.ExecutionLogEntryOptions) (int, error) {
+	r0, r1 := m.AddExecutionLogEntryFunc.nextHook()(v0, v1, v2, v3)
+	m.AddExecutionLogEntryFunc.appendCall(WorkerStoreAddExecutionLogEntryFuncCall[T]{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the AddExecutionLogEntry
+// method of the parent MockWorkerStore instance is invoked and the hook
+// queue is empty.
+func (f *WorkerStoreAddExecutionLogEntryFunc[T]) SetDefaultHook(hook func(context.Context, int, executor.ExecutionLogEntry, store1.ExecutionLogEntryOptions) (int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// AddExecutionLogEntry method of the parent MockWorkerStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *WorkerStoreAddExecutionLogEntryFunc[T]) PushHook(hook func(context.Context, int, executor.ExecutionLogEntry, store1.ExecutionLogEntryOptions) (int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *WorkerStoreAddExecutionLogEntryFunc[T]) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, executor.ExecutionLogEntry, store1.ExecutionLogEntryOptions) (int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *WorkerStoreAddExecutionLogEntryFunc[T]) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context, int, executor.ExecutionLogEntry, store1.ExecutionLogEntryOptions) (int, error) {
+		return r0, r1
+	})
+}
+
+func (f *WorkerStoreAddExecutionLogEntryFunc[T]) nextHook() func(context.Context, int, executor.ExecutionLogEntry, store1.ExecutionLogEntryOptions) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *WorkerStoreAddExecutionLogEntryFunc[T]) appendCall(r0 WorkerStoreAddExecutionLogEntryFuncCall[T]) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of WorkerStoreAddExecutionLogEntryFuncCall
+// objects describing the invocations of this function.
+func (f *WorkerStoreAddExecutionLogEntryFunc[T]) History() []WorkerStoreAddExecutionLogEntryFuncCall[T] {
+	f.mutex.Lock()
+	history := make([]WorkerStoreAddExecutionLogEntryFuncCall[T], len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// WorkerStoreAddExecutionLogEntryFuncCall is an object that describes an
+// invocation of method AddExecutionLogEntry on an instance of
+// MockWorkerStore.
+type WorkerStoreAddExecutionLogEntryFuncCall[T workerutil.Record] struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 executor.ExecutionLogEntry
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 store1.ExecutionLogEntryOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c WorkerStoreAddExecutionLogEntryFuncCall[T]) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
 }
 
 // Results returns an interface slice containing the results of this
@@ -4847,6 +5276,114 @@ func (c WorkerStoreAddExecutionLogEntryFuncCall[T]) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// WorkerStoreDependenciesFunc describes the behavior when the Dependencies
+// method of the parent MockWorkerStore instance is invoked.
+type WorkerStoreDependenciesFunc[T workerutil.Record] struct {
+	defaultHook func(context.Context, int) ([]store1.Dependency, error)
+	hooks       []func(context.Context, int) ([]store1.Dependency, error)
+	history     []WorkerStoreDependenciesFuncCall[T]
+	mutex       sync.Mutex
+}
+
+// Dependencies delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockWorkerStore[T]) Dependencies(v0 context.Context, v1 int) ([]store1.Dependency, error) {
+	r0, r1 := m.DependenciesFunc.nextHook()(v0, v1)
+	m.DependenciesFunc.appendCall(WorkerStoreDependenciesFuncCall[T]{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Dependencies method
+// of the parent MockWorkerStore instance is invoked and the hook queue is
+// empty.
+func (f *WorkerStoreDependenciesFunc[T]) SetDefaultHook(hook func(context.Context, int) ([]store1.Dependency, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Dependencies method of the parent MockWorkerStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *WorkerStoreDependenciesFunc[T]) PushHook(hook func(context.Context, int) ([]store1.Dependency, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *WorkerStoreDependenciesFunc[T]) SetDefaultReturn(r0 []store1.Dependency, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) ([]store1.Dependency, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *WorkerStoreDependenciesFunc[T]) PushReturn(r0 []store1.Dependency, r1 error) {
+	f.PushHook(func(context.Context, int) ([]store1.Dependency, error) {
+		return r0, r1
+	})
+}
+
+func (f *WorkerStoreDependenciesFunc[T]) nextHook() func(context.Context, int) ([]store1.Dependency, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *WorkerStoreDependenciesFunc[T]) appendCall(r0 WorkerStoreDependenciesFuncCall[T]) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of WorkerStoreDependenciesFuncCall objects
+// describing the invocations of this function.
+func (f *WorkerStoreDependenciesFunc[T]) History() []WorkerStoreDependenciesFuncCall[T] {
+	f.mutex.Lock()
+	history := make([]WorkerStoreDependenciesFuncCall[T], len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// WorkerStoreDependenciesFuncCall is an object that describes an invocation
+// of method Dependencies on an instance of MockWorkerStore.
+type WorkerStoreDependenciesFuncCall[T workerutil.Record] struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []store1.Dependency
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c WorkerStoreDependenciesFuncCall[T]) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c WorkerStoreDependenciesFuncCall[T]) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // WorkerStoreDequeueFunc describes the behavior when the Dequeue method of
 // the parent MockWorkerStore instance is invoked.
 type WorkerStoreDequeueFunc[T workerutil.Record] struct {
@@ -5619,6 +6156,117 @@ func (c WorkerStoreMaxDurationInQueueFuncCall[T]) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// WorkerStoreQueueStatsByPartitionFunc describes the behavior when the
+// QueueStatsByPartition method of the parent MockWorkerStore instance is
+// invoked.
+type WorkerStoreQueueStatsByPartitionFunc[T workerutil.Record] struct {
+	defaultHook func(context.Context, int) ([]store1.PartitionQueueStats, error)
+	hooks       []func(context.Context, int) ([]store1.PartitionQueueStats, error)
+	history     []WorkerStoreQueueStatsByPartitionFuncCall[T]
+	mutex       sync.Mutex
+}
+
+// QueueStatsByPartition delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockWorkerStore[T]) QueueStatsByPartition(v0 context.Context, v1 int) ([]store1.PartitionQueueStats, error) {
+	r0, r1 := m.QueueStatsByPartitionFunc.nextHook()(v0, v1)
+	m.QueueStatsByPartitionFunc.appendCall(WorkerStoreQueueStatsByPartitionFuncCall[T]{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// QueueStatsByPartition method of the parent MockWorkerStore instance is
+// invoked and the hook queue is empty.
+func (f *WorkerStoreQueueStatsByPartitionFunc[T]) SetDefaultHook(hook func(context.Context, int) ([]store1.PartitionQueueStats, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// QueueStatsByPartition method of the parent MockWorkerStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *WorkerStoreQueueStatsByPartitionFunc[T]) PushHook(hook func(context.Context, int) ([]store1.PartitionQueueStats, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *WorkerStoreQueueStatsByPartitionFunc[T]) SetDefaultReturn(r0 []store1.PartitionQueueStats, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) ([]store1.PartitionQueueStats, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *WorkerStoreQueueStatsByPartitionFunc[T]) PushReturn(r0 []store1.PartitionQueueStats, r1 error) {
+	f.PushHook(func(context.Context, int) ([]store1.PartitionQueueStats, error) {
+		return r0, r1
+	})
+}
+
+func (f *WorkerStoreQueueStatsByPartitionFunc[T]) nextHook() func(context.Context, int) ([]store1.PartitionQueueStats, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *WorkerStoreQueueStatsByPartitionFunc[T]) appendCall(r0 WorkerStoreQueueStatsByPartitionFuncCall[T]) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of WorkerStoreQueueStatsByPartitionFuncCall
+// objects describing the invocations of this function.
+func (f *WorkerStoreQueueStatsByPartitionFunc[T]) History() []WorkerStoreQueueStatsByPartitionFuncCall[T] {
+	f.mutex.Lock()
+	history := make([]WorkerStoreQueueStatsByPartitionFuncCall[T], len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// WorkerStoreQueueStatsByPartitionFuncCall is an object that describes an
+// invocation of method QueueStatsByPartition on an instance of
+// MockWorkerStore.
+type WorkerStoreQueueStatsByPartitionFuncCall[T workerutil.Record] struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []store1.PartitionQueueStats
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c WorkerStoreQueueStatsByPartitionFuncCall[T]) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c WorkerStoreQueueStatsByPartitionFuncCall[T]) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // WorkerStoreQueuedCountFunc describes the behavior when the QueuedCount
 // method of the parent MockWorkerStore instance is invoked.
 type WorkerStoreQueuedCountFunc[T workerutil.Record] struct {
@@ -5727,6 +6375,114 @@ func (c WorkerStoreQueuedCountFuncCall[T]) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// WorkerStoreReleaseFunc describes the behavior when the Release method of
+// the parent MockWorkerStore instance is invoked.
+type WorkerStoreReleaseFunc[T workerutil.Record] struct {
+	defaultHook func(context.Context, int, time.Time) error
+	hooks       []func(context.Context, int, time.Time) error
+	history     []WorkerStoreReleaseFuncCall[T]
+	mutex       sync.Mutex
+}
+
+// Release delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockWorkerStore[T]) Release(v0 context.Context, v1 int, v2 time.Time) error {
+	r0 := m.ReleaseFunc.nextHook()(v0, v1, v2)
+	m.ReleaseFunc.appendCall(WorkerStoreReleaseFuncCall[T]{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Release method of
+// the parent MockWorkerStore instance is invoked and the hook queue is
+// empty.
+func (f *WorkerStoreReleaseFunc[T]) SetDefaultHook(hook func(context.Context, int, time.Time) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Release method of the parent MockWorkerStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *WorkerStoreReleaseFunc[T]) PushHook(hook func(context.Context, int, time.Time) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *WorkerStoreReleaseFunc[T]) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, time.Time) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *WorkerStoreReleaseFunc[T]) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, time.Time) error {
+		return r0
+	})
+}
+
+func (f *WorkerStoreReleaseFunc[T]) nextHook() func(context.Context, int, time.Time) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *WorkerStoreReleaseFunc[T]) appendCall(r0 WorkerStoreReleaseFuncCall[T]) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of WorkerStoreReleaseFuncCall objects
+// describing the invocations of this function.
+func (f *WorkerStoreReleaseFunc[T]) History() []WorkerStoreReleaseFuncCall[T] {
+	f.mutex.Lock()
+	history := make([]WorkerStoreReleaseFuncCall[T], len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// WorkerStoreReleaseFuncCall is an object that describes an invocation of
+// method Release on an instance of MockWorkerStore.
+type WorkerStoreReleaseFuncCall[T workerutil.Record] struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 time.Time
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c WorkerStoreReleaseFuncCall[T]) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c WorkerStoreReleaseFuncCall[T]) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // WorkerStoreRequeueFunc describes the behavior when the Requeue method of
 // the parent MockWorkerStore instance is invoked.
 type WorkerStoreRequeueFunc[T workerutil.Record] struct {
@@ -5943,6 +6699,113 @@ func (c WorkerStoreResetStalledFuncCall[T]) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// WorkerStoreResolveDependenciesFunc describes the behavior when the
+// ResolveDependencies method of the parent MockWorkerStore instance is
+// invoked.
+type WorkerStoreResolveDependenciesFunc[T workerutil.Record] struct {
+	defaultHook func(context.Context) ([]int, error)
+	hooks       []func(context.Context) ([]int, error)
+	history     []WorkerStoreResolveDependenciesFuncCall[T]
+	mutex       sync.Mutex
+}
+
+// ResolveDependencies delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockWorkerStore[T]) ResolveDependencies(v0 context.Context) ([]int, error) {
+	r0, r1 := m.ResolveDependenciesFunc.nextHook()(v0)
+	m.ResolveDependenciesFunc.appendCall(WorkerStoreResolveDependenciesFuncCall[T]{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ResolveDependencies
+// method of the parent MockWorkerStore instance is invoked and the hook
+// queue is empty.
+func (f *WorkerStoreResolveDependenciesFunc[T]) SetDefaultHook(hook func(context.Context) ([]int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ResolveDependencies method of the parent MockWorkerStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *WorkerStoreResolveDependenciesFunc[T]) PushHook(hook func(context.Context) ([]int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *WorkerStoreResolveDependenciesFunc[T]) SetDefaultReturn(r0 []int, r1 error) {
+	f.SetDefaultHook(func(context.Context) ([]int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *WorkerStoreResolveDependenciesFunc[T]) PushReturn(r0 []int, r1 error) {
+	f.PushHook(func(context.Context) ([]int, error) {
+		return r0, r1
+	})
+}
+
+func (f *WorkerStoreResolveDependenciesFunc[T]) nextHook() func(context.Context) ([]int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *WorkerStoreResolveDependenciesFunc[T]) appendCall(r0 WorkerStoreResolveDependenciesFuncCall[T]) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of WorkerStoreResolveDependenciesFuncCall
+// objects describing the invocations of this function.
+func (f *WorkerStoreResolveDependenciesFunc[T]) History() []WorkerStoreResolveDependenciesFuncCall[T] {
+	f.mutex.Lock()
+	history := make([]WorkerStoreResolveDependenciesFuncCall[T], len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// WorkerStoreResolveDependenciesFuncCall is an object that describes an
+// invocation of method ResolveDependencies on an instance of
+// MockWorkerStore.
+type WorkerStoreResolveDependenciesFuncCall[T workerutil.Record] struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c WorkerStoreResolveDependenciesFuncCall[T]) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c WorkerStoreResolveDependenciesFuncCall[T]) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // WorkerStoreUpdateExecutionLogEntryFunc describes the behavior when the
 // UpdateExecutionLogEntry method of the parent MockWorkerStore instance is
 // invoked.
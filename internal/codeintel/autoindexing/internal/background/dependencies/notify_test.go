@@ -0,0 +1,87 @@
+package dependencies
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+)
+
+func TestDependencySyncingJobInsertWakesWorker(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	logger := logtest.Scoped(t)
+	raw := dbtest.NewDB(logger, t)
+	db := database.NewDB(logger, raw)
+
+	// The listener needs its own connection to the database of the test.
+	dataSource, err := dbtest.GetDSN()
+	require.NoError(t, err)
+	var name string
+	require.NoError(t, raw.QueryRowContext(ctx, "SELECT current_database()").Scan(&name))
+	dataSource.Path = "/" + name
+
+	listener := dbworker.NewListener(logger, dataSource.String(), dbworker.ListenerOptions{
+		Name:    "test",
+		Channel: DependencySyncingJobNotifyChannel,
+	})
+	listened := listener.Wakeup()
+	wakeup := listener.Wakeup()
+	go listener.Start()
+	t.Cleanup(listener.Stop)
+
+	// The listener wakes up its workers once it starts listening.
+	waitForWakeup(t, listened)
+
+	handled := make(chan int, 1)
+	handler := workerutil.HandlerFunc[dependencySyncingJob](func(ctx context.Context, logger log.Logger, job dependencySyncingJob) error {
+		handled <- job.ID
+		return nil
+	})
+	// The worker would not poll again within the test.
+	worker := dbworker.NewWorker[dependencySyncingJob](ctx, store.New(&observation.TestContext, db.Handle(), DependencySyncingJobWorkerStoreOptions), handler, workerutil.WorkerOptions{
+		Name:              "test",
+		NumHandlers:       1,
+		Interval:          time.Hour,
+		Wakeup:            wakeup,
+		HeartbeatInterval: time.Second,
+		Metrics:           workerutil.NewMetrics(&observation.TestContext, "test"),
+	})
+	go worker.Start()
+	t.Cleanup(worker.Stop)
+
+	var id int
+	require.NoError(t, raw.QueryRowContext(ctx, "INSERT INTO lsif_dependency_syncing_jobs (upload_id) VALUES (NULL) RETURNING id").Scan(&id))
+
+	// The insert notifies the listener, which wakes up the worker.
+	waitForWakeup(t, listened)
+	select {
+	case handledID := <-handled:
+		require.Equal(t, id, handledID)
+	case <-time.After(10 * time.Second):
+		t.Fatal("timeout waiting for the worker to handle the inserted job")
+	}
+}
+
+func waitForWakeup(t *testing.T, wakeup <-chan struct{}) {
+	t.Helper()
+
+	select {
+	case <-wakeup:
+	case <-time.After(10 * time.Second):
+		t.Fatal("timeout waiting for wakeup")
+	}
+}
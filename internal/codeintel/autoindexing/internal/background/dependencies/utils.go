@@ -30,8 +30,11 @@ var IndexWorkerStoreOptions = dbworkerstore.Options[uploadsshared.Index]{
 	ColumnExpressions: indexColumnsWithNullRank,
 	Scan:              dbworkerstore.BuildWorkerScan(scanIndex),
 	OrderByExpression: sqlf.Sprintf("(u.enqueuer_user_id > 0) DESC, u.queued_at, u.id"),
-	StalledMaxAge:     stalledIndexMaxAge,
-	MaxNumResets:      indexMaxNumResets,
+	// Repositories with many indexes, like monorepos with many projects, must not hold back the
+	// indexes of other repositories.
+	PartitionByExpression: sqlf.Sprintf("u.repository_id"),
+	StalledMaxAge:         stalledIndexMaxAge,
+	MaxNumResets:          indexMaxNumResets,
 }
 
 var indexColumnsWithNullRank = []*sqlf.Query{
@@ -109,6 +112,10 @@ const stalledDependencySyncingJobMaxAge = time.Second * 25
 // moved into "errored" rather than "queued" on its next reset.
 const dependencySyncingJobMaxNumResets = 3
 
+// DependencySyncingJobNotifyChannel is the channel notified when dependency syncing jobs are
+// inserted, by the lsif_dependency_syncing_jobs_notify_queued trigger, or requeued.
+const DependencySyncingJobNotifyChannel = "codeintel_dependency_syncing"
+
 var DependencySyncingJobWorkerStoreOptions = dbworkerstore.Options[dependencySyncingJob]{
 	Name:              "codeintel_dependency_syncing",
 	TableName:         "lsif_dependency_syncing_jobs",
@@ -117,6 +124,8 @@ var DependencySyncingJobWorkerStoreOptions = dbworkerstore.Options[dependencySyn
 	OrderByExpression: sqlf.Sprintf("lsif_dependency_syncing_jobs.queued_at, lsif_dependency_syncing_jobs.upload_id"),
 	StalledMaxAge:     stalledDependencySyncingJobMaxAge,
 	MaxNumResets:      dependencySyncingJobMaxNumResets,
+	TrackDependencies: true,
+	NotifyChannel:     DependencySyncingJobNotifyChannel,
 }
 
 var dependencySyncingJobColumns = []*sqlf.Query{
@@ -165,6 +174,7 @@ var DependencyIndexingJobWorkerStoreOptions = dbworkerstore.Options[dependencyIn
 	OrderByExpression: sqlf.Sprintf("lsif_dependency_indexing_jobs.queued_at, lsif_dependency_indexing_jobs.upload_id"),
 	StalledMaxAge:     stalledDependencyIndexingJobMaxAge,
 	MaxNumResets:      dependencyIndexingJobMaxNumResets,
+	TrackDependencies: true,
 }
 
 var dependencyIndexingJobColumns = []*sqlf.Query{
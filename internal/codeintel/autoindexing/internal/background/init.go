@@ -10,6 +10,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
 	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
 )
 
@@ -56,6 +57,7 @@ func NewDependencyIndexSchedulers(
 	store store.Store,
 	indexEnqueuer dependencies.IndexEnqueuer,
 	repoUpdater dependencies.RepoUpdaterClient,
+	dataSource string,
 	config *dependencies.Config,
 ) []goroutine.BackgroundRoutine {
 	metrics := dependencies.NewResetterMetrics(observationCtx)
@@ -67,14 +69,23 @@ func NewDependencyIndexSchedulers(
 	repoStore := db.Repos()
 	gitserverRepoStore := db.GitserverRepos()
 
+	// Dependency syncing jobs are picked up as soon as uploads insert them.
+	dependencySyncListener := dbworker.NewListener(observationCtx.Logger.Scoped("dependencySyncListener", ""), dataSource, dbworker.ListenerOptions{
+		Name:    "precise_code_intel_dependency_sync_listener",
+		Channel: dependencies.DependencySyncingJobNotifyChannel,
+	})
+
 	return []goroutine.BackgroundRoutine{
+		dependencySyncListener,
 		dependencies.NewDependencySyncScheduler(
 			dependencySyncStore,
+			dependencyIndexingStore,
 			uploadSvc,
 			depsSvc,
 			store,
 			externalServiceStore,
 			workerutil.NewMetrics(observationCtx, "codeintel_dependency_index_processor"),
+			dependencySyncListener.Wakeup(),
 			config,
 		),
 		dependencies.NewDependencyIndexingScheduler(
@@ -91,6 +102,8 @@ func NewDependencyIndexSchedulers(
 
 		dependencies.NewIndexResetter(observationCtx.Logger.Scoped("indexResetter", ""), config.ResetterInterval, indexStore, metrics),
 		dependencies.NewDependencyIndexResetter(observationCtx.Logger.Scoped("dependencyIndexResetter", ""), config.ResetterInterval, dependencyIndexingStore, metrics),
+		dependencies.NewDependencySyncResolver(observationCtx.Logger.Scoped("dependencySyncResolver", ""), config.ResetterInterval, dependencySyncStore),
+		dependencies.NewDependencyIndexResolver(observationCtx.Logger.Scoped("dependencyIndexResolver", ""), config.ResetterInterval, dependencyIndexingStore),
 	}
 }
 
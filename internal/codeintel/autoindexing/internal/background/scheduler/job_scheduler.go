@@ -10,7 +10,9 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/autoindexing/internal/inference"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/autoindexing/internal/jobselector"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/autoindexing/internal/store"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/policies"
 	policiesshared "github.com/sourcegraph/sourcegraph/internal/codeintel/policies/shared"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
@@ -165,7 +167,7 @@ func (b indexSchedulerJob) handleRepository(ctx context.Context, repositoryID, p
 
 	for {
 		// Retrieve the set of configuration policies that affect indexing for this repository.
-		policies, totalCount, err := b.policiesSvc.GetConfigurationPolicies(ctx, policiesshared.GetConfigurationPoliciesOptions{
+		policyBatch, totalCount, err := b.policiesSvc.GetConfigurationPolicies(ctx, policiesshared.GetConfigurationPoliciesOptions{
 			RepositoryID: repositoryID,
 			ForIndexing:  &t,
 			Limit:        policyBatchSize,
@@ -174,10 +176,10 @@ func (b indexSchedulerJob) handleRepository(ctx context.Context, repositoryID, p
 		if err != nil {
 			return errors.Wrap(err, "policySvc.GetConfigurationPolicies")
 		}
-		offset += len(policies)
+		offset += len(policyBatch)
 
 		// Get the set of commits within this repository that match an indexing policy
-		commitMap, err := b.policyMatcher.CommitsDescribedByPolicy(ctx, repositoryID, repoName, policies, now)
+		commitMap, err := b.policyMatcher.CommitsDescribedByPolicy(ctx, repositoryID, repoName, policyBatch, now)
 		if err != nil {
 			return errors.Wrap(err, "policies.CommitsDescribedByPolicy")
 		}
@@ -187,17 +189,19 @@ func (b indexSchedulerJob) handleRepository(ctx context.Context, repositoryID, p
 				continue
 			}
 
-			// Attempt to queue an index if one does not exist for each of the matching commits
-			if _, err := b.indexEnqueuer.QueueIndexes(ctx, repositoryID, commit, "", false, false); err != nil {
+			// Attempt to queue an index if one does not exist for each of the matching commits. If every
+			// matching policy is scoped to a subset of paths, only the roots within those paths are indexed.
+			rootFilter := jobselector.RootFilter(policies.PathFilter(policyMatches))
+			if _, err := b.indexEnqueuer.QueueIndexesForRoots(ctx, repositoryID, commit, rootFilter); err != nil {
 				if errors.HasType(err, &gitdomain.RevisionNotFoundError{}) {
 					continue
 				}
 
-				return errors.Wrap(err, "indexEnqueuer.QueueIndexes")
+				return errors.Wrap(err, "indexEnqueuer.QueueIndexesForRoots")
 			}
 		}
 
-		if len(policies) == 0 || offset >= totalCount {
+		if len(policyBatch) == 0 || offset >= totalCount {
 			return nil
 		}
 	}
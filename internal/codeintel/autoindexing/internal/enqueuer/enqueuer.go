@@ -62,19 +62,52 @@ func (s *IndexEnqueuer) QueueIndexes(ctx context.Context, repositoryID int, rev,
 	}})
 	defer endObservation(1, observation.Args{})
 
-	repo, err := s.repoStore.Get(ctx, api.RepoID(repositoryID))
+	commit, err := s.resolveRevision(ctx, repositoryID, rev)
 	if err != nil {
 		return nil, err
 	}
+	trace.AddEvent("ResolveRevision", attribute.String("commit", commit))
 
-	commitID, err := s.gitserverClient.ResolveRevision(ctx, repo.Name, rev, gitserver.ResolveRevisionOptions{})
+	return s.queueIndexForRepositoryAndCommit(ctx, repositoryID, commit, configuration, force, bypassLimit, nil)
+}
+
+// QueueIndexesForRoots enqueues the set of index jobs for the following repository and commit that have
+// a root passing the given filter. This is used by the auto-indexing scheduler for configuration policies
+// that apply only to a subset of paths within a repository, so that an index job is enqueued for each
+// matching root rather than for the entire repository. A nil filter behaves like QueueIndexes.
+//
+// Existing records are checked per root, so that policies covering different paths of the same commit can
+// each enqueue their own jobs. If a record already exists for a root passing the filter, the jobs of the
+// commit are not inferred again.
+func (s *IndexEnqueuer) QueueIndexesForRoots(ctx context.Context, repositoryID int, rev string, rootFilter jobselector.RootFilter) (_ []uploadsshared.Index, err error) {
+	ctx, trace, endObservation := s.operations.queueIndexesForRoots.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("repositoryID", repositoryID),
+		attribute.String("rev", rev),
+		attribute.Bool("filtered", rootFilter != nil),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	commit, err := s.resolveRevision(ctx, repositoryID, rev)
 	if err != nil {
-		return nil, errors.Wrap(err, "gitserver.ResolveRevision")
+		return nil, err
 	}
-	commit := string(commitID)
 	trace.AddEvent("ResolveRevision", attribute.String("commit", commit))
 
-	return s.queueIndexForRepositoryAndCommit(ctx, repositoryID, commit, configuration, force, bypassLimit)
+	return s.queueIndexForRepositoryAndCommit(ctx, repositoryID, commit, "", false, false, rootFilter)
+}
+
+func (s *IndexEnqueuer) resolveRevision(ctx context.Context, repositoryID int, rev string) (string, error) {
+	repo, err := s.repoStore.Get(ctx, api.RepoID(repositoryID))
+	if err != nil {
+		return "", err
+	}
+
+	commitID, err := s.gitserverClient.ResolveRevision(ctx, repo.Name, rev, gitserver.ResolveRevisionOptions{})
+	if err != nil {
+		return "", errors.Wrap(err, "gitserver.ResolveRevision")
+	}
+
+	return string(commitID), nil
 }
 
 // QueueIndexesForPackage enqueues index jobs for a dependency of a recently-processed precise code
@@ -123,7 +156,7 @@ func (s *IndexEnqueuer) QueueIndexesForPackage(ctx context.Context, pkg dependen
 		return errors.Wrap(err, "gitserverClient.ResolveRevision")
 	}
 
-	_, err = s.queueIndexForRepositoryAndCommit(ctx, repoID, string(commit), "", false, false)
+	_, err = s.queueIndexForRepositoryAndCommit(ctx, repoID, string(commit), "", false, false, nil)
 	return err
 }
 
@@ -131,19 +164,20 @@ func (s *IndexEnqueuer) QueueIndexesForPackage(ctx context.Context, pkg dependen
 //
 // If the force flag is false, then the presence of an upload or index record for this given repository and commit
 // will cause this method to no-op. Note that this is NOT a guarantee that there will never be any duplicate records
-// when the flag is false.
-func (s *IndexEnqueuer) queueIndexForRepositoryAndCommit(ctx context.Context, repositoryID int, commit, configuration string, force, bypassLimit bool) ([]uploadsshared.Index, error) {
+// when the flag is false. When a root filter is supplied, only the presence of records with a root passing the filter
+// is considered.
+func (s *IndexEnqueuer) queueIndexForRepositoryAndCommit(ctx context.Context, repositoryID int, commit, configuration string, force, bypassLimit bool, rootFilter jobselector.RootFilter) ([]uploadsshared.Index, error) {
 	if !force {
-		isQueued, err := s.store.IsQueued(ctx, repositoryID, commit)
+		isQueued, err := s.isQueued(ctx, repositoryID, commit, rootFilter)
 		if err != nil {
-			return nil, errors.Wrap(err, "dbstore.IsQueued")
+			return nil, err
 		}
 		if isQueued {
 			return nil, nil
 		}
 	}
 
-	indexes, err := s.jobSelector.GetIndexRecords(ctx, repositoryID, commit, configuration, bypassLimit)
+	indexes, err := s.jobSelector.GetIndexRecords(ctx, repositoryID, commit, configuration, bypassLimit, rootFilter)
 	if err != nil {
 		return nil, err
 	}
@@ -167,3 +201,29 @@ func (s *IndexEnqueuer) queueIndexForRepositoryAndCommit(ctx context.Context, re
 
 	return s.store.InsertIndexes(ctx, indexesToInsert)
 }
+
+// isQueued determines whether index jobs have already been enqueued for the given repository and commit, so
+// that inferring them again can be skipped. When a root filter is supplied, only records with a root passing
+// the filter are considered, so that a policy covering other paths of the same commit still enqueues its jobs.
+func (s *IndexEnqueuer) isQueued(ctx context.Context, repositoryID int, commit string, rootFilter jobselector.RootFilter) (bool, error) {
+	if rootFilter == nil {
+		isQueued, err := s.store.IsQueued(ctx, repositoryID, commit)
+		if err != nil {
+			return false, errors.Wrap(err, "dbstore.IsQueued")
+		}
+
+		return isQueued, nil
+	}
+
+	roots, err := s.store.GetQueuedRoots(ctx, repositoryID, commit)
+	if err != nil {
+		return false, errors.Wrap(err, "dbstore.GetQueuedRoots")
+	}
+	for _, root := range roots {
+		if rootFilter(root) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -11,6 +11,7 @@ import (
 
 type operations struct {
 	queueIndex           *observation.Operation
+	queueIndexesForRoots *observation.Operation
 	queueIndexForPackage *observation.Operation
 }
 
@@ -42,6 +43,7 @@ func newOperations(observationCtx *observation.Context) *operations {
 
 	return &operations{
 		queueIndex:           op("QueueIndex"),
+		queueIndexesForRoots: op("QueueIndexesForRoots"),
 		queueIndexForPackage: op("QueueIndexForPackage"),
 	}
 }
@@ -0,0 +1,43 @@
+package inference
+
+import (
+	"testing"
+)
+
+func TestCppGenerator(t *testing.T) {
+	testGenerators(t,
+		generatorTestCase{
+			description: "cpp compilation database",
+			repositoryContents: map[string]string{
+				"compile_commands.json": "",
+				"src/main.cc":           "",
+			},
+		},
+		generatorTestCase{
+			description: "cpp cmake project",
+			repositoryContents: map[string]string{
+				"CMakeLists.txt":       "",
+				"lib/CMakeLists.txt":   "",
+				"lib/foo.cpp":          "",
+				"tools/CMakeLists.txt": "",
+			},
+		},
+		generatorTestCase{
+			description: "cpp cmake project with compilation database in build directory",
+			repositoryContents: map[string]string{
+				"CMakeLists.txt":              "",
+				"build/compile_commands.json": "",
+				"src/main.cpp":                "",
+			},
+		},
+		generatorTestCase{
+			description: "cpp multiple cmake projects",
+			repositoryContents: map[string]string{
+				"engine/CMakeLists.txt":      "",
+				"engine/core/CMakeLists.txt": "",
+				"tools/CMakeLists.txt":       "",
+				"bench/CMakeLists.txt":       "",
+			},
+		},
+	)
+}
@@ -0,0 +1,36 @@
+package inference
+
+import (
+	"testing"
+)
+
+func TestDotnetGenerator(t *testing.T) {
+	testGenerators(t,
+		generatorTestCase{
+			description: "dotnet solution",
+			repositoryContents: map[string]string{
+				"Acme.sln":                       "",
+				"src/Acme.Api/Acme.Api.csproj":   "",
+				"src/Acme.Core/Acme.Core.csproj": "",
+			},
+		},
+		generatorTestCase{
+			description: "dotnet projects without solution",
+			repositoryContents: map[string]string{
+				"Tool/Tool.csproj":             "",
+				"Legacy/Legacy.vbproj":         "",
+				"Tool.Tests/Tool.Tests.csproj": "",
+			},
+		},
+		generatorTestCase{
+			description: "dotnet multiple solutions",
+			repositoryContents: map[string]string{
+				"client/Client.sln":           "",
+				"client/Client/Client.csproj": "",
+				"server/Server.sln":           "",
+				"server/Server/Server.csproj": "",
+				"shared/Shared.csproj":        "",
+			},
+		},
+	)
+}
@@ -0,0 +1,27 @@
+package inference
+
+import (
+	"testing"
+)
+
+func TestKotlinGenerator(t *testing.T) {
+	testGenerators(t,
+		generatorTestCase{
+			description: "kotlin project with Gradle Kotlin DSL",
+			repositoryContents: map[string]string{
+				"build.gradle.kts":                   "",
+				"src/main/kotlin/com/example/App.kt": "",
+			},
+		},
+		generatorTestCase{
+			description: "kotlin multi-module project with Gradle settings",
+			repositoryContents: map[string]string{
+				"settings.gradle.kts":                      "",
+				"app/build.gradle.kts":                     "",
+				"app/src/main/kotlin/com/example/App.kt":   "",
+				"core/build.gradle.kts":                    "",
+				"core/src/main/kotlin/com/example/Core.kt": "",
+			},
+		},
+	)
+}
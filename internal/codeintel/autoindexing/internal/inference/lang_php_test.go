@@ -0,0 +1,25 @@
+package inference
+
+import (
+	"testing"
+)
+
+func TestPHPGenerator(t *testing.T) {
+	testGenerators(t,
+		generatorTestCase{
+			description: "php composer project",
+			repositoryContents: map[string]string{
+				"composer.json":      "",
+				"composer.lock":      "",
+				"src/Controller.php": "",
+			},
+		},
+		generatorTestCase{
+			description: "php multiple composer projects",
+			repositoryContents: map[string]string{
+				"packages/api/composer.json": "",
+				"packages/web/composer.json": "",
+			},
+		},
+	)
+}
@@ -16,8 +16,11 @@ var Indexes = indexesAPI{}
 type indexesAPI struct{}
 
 var defaultIndexers = map[string]string{
+	"cpp":        "sourcegraph/scip-clang",
+	"dotnet":     "sourcegraph/scip-dotnet",
 	"go":         "sourcegraph/scip-go",
 	"java":       "sourcegraph/scip-java",
+	"php":        "sourcegraph/scip-php",
 	"python":     "sourcegraph/scip-python",
 	"rust":       "sourcegraph/scip-rust",
 	"typescript": "sourcegraph/scip-typescript",
@@ -35,6 +38,15 @@ var defaultIndexerSHAs = map[string]string{
 	"sourcegraph/scip-ruby":       "sha256:ef53e5f1450330ddb4a3edce963b7e10d900d44ff1e7de4960680289ac25f319",
 }
 
+// Indexers that are not yet pinned to a digest are referenced by tag. To pin one, add it
+// to defaultIndexerSHAs and run update-shas.sh; an entry in defaultIndexerSHAs takes
+// precedence over an entry here.
+var defaultIndexerTags = map[string]string{
+	"sourcegraph/scip-clang":  "latest",
+	"sourcegraph/scip-dotnet": "latest",
+	"sourcegraph/scip-php":    "latest",
+}
+
 func DefaultIndexerForLang(language string) (string, bool) {
 	indexer, ok := defaultIndexers[language]
 	if !ok {
@@ -43,6 +55,10 @@ func DefaultIndexerForLang(language string) (string, bool) {
 
 	sha, ok := defaultIndexerSHAs[indexer]
 	if !ok {
+		if tag, ok := defaultIndexerTags[indexer]; ok {
+			return fmt.Sprintf("%s:%s", indexer, tag), true
+		}
+
 		panic(fmt.Sprintf("no SHA set for indexer %q", indexer))
 	}
 
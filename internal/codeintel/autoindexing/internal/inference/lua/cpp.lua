@@ -0,0 +1,68 @@
+local path = require "path"
+local recognizer = require "sg.autoindex.recognizer"
+local pattern = require "sg.autoindex.patterns"
+
+local shared = require "sg.autoindex.shared"
+
+local indexer = require("sg.autoindex.indexes").get "cpp"
+local outfile = "index.scip"
+
+local cmake_build_dir = "build"
+
+local sorted_keys = function(set)
+  local keys = {}
+  for key in pairs(set) do
+    table.insert(keys, key)
+  end
+  table.sort(keys)
+
+  return keys
+end
+
+return recognizer.new_path_recognizer {
+  patterns = {
+    pattern.new_path_basename "compile_commands.json",
+    pattern.new_path_basename "CMakeLists.txt",
+    pattern.new_path_exclude(shared.exclude_paths),
+  },
+
+  -- Invoked when a compilation database or CMakeLists.txt exists anywhere in the repository
+  generate = function(_, paths)
+    local compdb_roots = {}
+    local cmake_roots = {}
+    for i = 1, #paths do
+      local dir = path.dirname(paths[i])
+      if path.basename(paths[i]) == "compile_commands.json" then
+        compdb_roots[dir] = true
+      else
+        cmake_roots[dir] = true
+      end
+    end
+
+    local jobs = {}
+
+    -- A compilation database is handed to the indexer as-is. When it lives in the default
+    -- build directory of a CMake project, index from the project root so that document
+    -- paths are relative to the sources. CMake projects without a compilation database are
+    -- not configured here: that needs CMake and the project's own toolchain and dependencies,
+    -- which the indexer image does not provide. They need an explicit index configuration.
+    for _, dir in ipairs(sorted_keys(compdb_roots)) do
+      local root = dir
+      local compdb_path = "compile_commands.json"
+      if path.basename(dir) == cmake_build_dir and cmake_roots[path.dirname(dir)] then
+        root = path.dirname(dir)
+        compdb_path = path.join(cmake_build_dir, compdb_path)
+      end
+
+      table.insert(jobs, {
+        steps = {},
+        root = root,
+        indexer = indexer,
+        indexer_args = { "scip-clang", "--compdb-path=" .. compdb_path },
+        outfile = outfile,
+      })
+    end
+
+    return jobs
+  end,
+}
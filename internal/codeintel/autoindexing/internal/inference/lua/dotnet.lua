@@ -0,0 +1,87 @@
+local path = require "path"
+local recognizer = require "sg.autoindex.recognizer"
+local pattern = require "sg.autoindex.patterns"
+
+local shared = require "sg.autoindex.shared"
+
+local indexer = require("sg.autoindex.indexes").get "dotnet"
+local outfile = "index.scip"
+
+local is_solution = function(p)
+  return string.sub(p, -4) == ".sln"
+end
+
+local within_any = function(dir, roots)
+  if roots[dir] then
+    return true
+  end
+
+  local ancestors = path.ancestors(dir)
+  for i = 1, #ancestors do
+    if roots[ancestors[i]] then
+      return true
+    end
+  end
+
+  return false
+end
+
+local new_job = function(root, project_file)
+  return {
+    steps = {
+      {
+        root = root,
+        image = indexer,
+        commands = { "dotnet restore " .. project_file },
+      },
+    },
+    root = root,
+    indexer = indexer,
+    indexer_args = { "scip-dotnet", "index", project_file },
+    outfile = outfile,
+  }
+end
+
+return recognizer.new_path_recognizer {
+  patterns = {
+    pattern.new_path_extension "sln",
+    pattern.new_path_extension "csproj",
+    pattern.new_path_extension "vbproj",
+    pattern.new_path_exclude(shared.exclude_paths),
+  },
+
+  -- Invoked when a solution or project file exists anywhere in the repository
+  generate = function(_, paths)
+    local solutions = {}
+    local projects = {}
+    for i = 1, #paths do
+      if is_solution(paths[i]) then
+        table.insert(solutions, paths[i])
+      else
+        table.insert(projects, paths[i])
+      end
+    end
+    table.sort(solutions)
+    table.sort(projects)
+
+    local jobs = {}
+
+    -- Every solution is indexed on its own; a solution references the projects it builds
+    local solution_roots = {}
+    for i = 1, #solutions do
+      local root = path.dirname(solutions[i])
+      solution_roots[root] = true
+      table.insert(jobs, new_job(root, path.basename(solutions[i])))
+    end
+
+    -- Projects that do not live under any solution are indexed individually
+    for i = 1, #projects do
+      local root = path.dirname(projects[i])
+      if not within_any(root, solution_roots) then
+        table.insert(jobs, new_job(root, path.basename(projects[i])))
+      end
+    end
+
+    return jobs
+  end,
+}
@@ -0,0 +1,50 @@
+local path = require "path"
+local recognizer = require "sg.autoindex.recognizer"
+local pattern = require "sg.autoindex.patterns"
+
+local shared = require "sg.autoindex.shared"
+
+local indexer = require("sg.autoindex.indexes").get "php"
+local outfile = "index.scip"
+
+local exclude_paths = pattern.new_path_combine(shared.exclude_paths, {
+  pattern.new_path_segment "vendor",
+})
+
+return recognizer.new_path_recognizer {
+  patterns = {
+    pattern.new_path_basename "composer.json",
+    pattern.new_path_exclude(exclude_paths),
+  },
+
+  -- Invoked when composer.json exists anywhere in the repository
+  generate = function(_, paths)
+    local roots = {}
+    for i = 1, #paths do
+      table.insert(roots, path.dirname(paths[i]))
+    end
+    table.sort(roots)
+
+    local jobs = {}
+    for i = 1, #roots do
+      table.insert(jobs, {
+        steps = {
+          {
+            root = roots[i],
+            image = indexer,
+            -- Dependencies are installed so the indexer can resolve external symbols
+            -- via the generated autoloader; install scripts and platform checks
+            -- aren't needed (and often fail) in the indexing container.
+            commands = { "composer install --no-interaction --no-progress --no-scripts --ignore-platform-reqs" },
+          },
+        },
+        root = roots[i],
+        indexer = indexer,
+        indexer_args = { "scip-php" },
+        outfile = outfile,
+      })
+    end
+
+    return jobs
+  end,
+}
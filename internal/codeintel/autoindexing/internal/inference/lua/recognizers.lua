@@ -1,8 +1,11 @@
 local config = require("sg.autoindex.config").new {}
 
 for _, name in ipairs {
+  "cpp",
+  "dotnet",
   "go",
   "java",
+  "php",
   "python",
   "ruby",
   "rust",
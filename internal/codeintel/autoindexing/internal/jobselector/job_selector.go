@@ -49,6 +49,13 @@ var (
 
 // InferIndexJobsFromRepositoryStructure collects the result of InferIndexJobs over all registered recognizers.
 func (s *JobSelector) InferIndexJobsFromRepositoryStructure(ctx context.Context, repositoryID int, commit string, localOverrideScript string, bypassLimit bool) (*shared.InferenceResult, error) {
+	return s.inferIndexJobsFromRepositoryStructure(ctx, repositoryID, commit, localOverrideScript, bypassLimit, nil)
+}
+
+// inferIndexJobsFromRepositoryStructure collects the result of InferIndexJobs over all registered recognizers.
+// If a root filter is supplied, inferred jobs with a root that does not pass the filter are discarded before
+// the limit on the number of inferred jobs is applied.
+func (s *JobSelector) inferIndexJobsFromRepositoryStructure(ctx context.Context, repositoryID int, commit string, localOverrideScript string, bypassLimit bool, rootFilter RootFilter) (*shared.InferenceResult, error) {
 	repo, err := s.repoStore.Get(ctx, api.RepoID(repositoryID))
 	if err != nil {
 		return nil, err
@@ -77,6 +84,16 @@ func (s *JobSelector) InferIndexJobsFromRepositoryStructure(ctx context.Context,
 		return nil, err
 	}
 
+	if rootFilter != nil {
+		filtered := result.IndexJobs[:0]
+		for _, indexJob := range result.IndexJobs {
+			if rootFilter(indexJob.Root) {
+				filtered = append(filtered, indexJob)
+			}
+		}
+		result.IndexJobs = filtered
+	}
+
 	if !bypassLimit && len(result.IndexJobs) > MaximumIndexJobsPerInferredConfiguration {
 		s.logger.Info("Too many inferred roots. Scheduling no index jobs for repository.", log.Int("repository_id", repositoryID))
 		result.IndexJobs = nil
@@ -85,7 +102,11 @@ func (s *JobSelector) InferIndexJobsFromRepositoryStructure(ctx context.Context,
 	return result, nil
 }
 
-type configurationFactoryFunc func(ctx context.Context, repositoryID int, commit string, bypassLimit bool) ([]uploadsshared.Index, bool, error)
+// RootFilter determines whether or not an index job rooted at the given directory (relative to the root
+// of the repository) should be scheduled. A nil filter accepts every root.
+type RootFilter func(root string) bool
+
+type configurationFactoryFunc func(ctx context.Context, repositoryID int, commit string, bypassLimit bool, rootFilter RootFilter) ([]uploadsshared.Index, bool, error)
 
 // GetIndexRecords determines the set of index records that should be enqueued for the given commit.
 // For each repository, we look for index configuration in the following order:
@@ -94,7 +115,9 @@ type configurationFactoryFunc func(ctx context.Context, repositoryID int, commit
 //   - in the database
 //   - committed to `sourcegraph.yaml` in the repository
 //   - inferred from the repository structure
-func (s *JobSelector) GetIndexRecords(ctx context.Context, repositoryID int, commit, configuration string, bypassLimit bool) ([]uploadsshared.Index, error) {
+//
+// If a root filter is supplied, only index records with a root passing the filter are returned.
+func (s *JobSelector) GetIndexRecords(ctx context.Context, repositoryID int, commit, configuration string, bypassLimit bool, rootFilter RootFilter) ([]uploadsshared.Index, error) {
 	if canSchedule, _, err := s.store.RepositoryExceptions(ctx, repositoryID); err != nil {
 		return nil, err
 	} else if !canSchedule {
@@ -110,7 +133,7 @@ func (s *JobSelector) GetIndexRecords(ctx context.Context, repositoryID int, com
 	}
 
 	for _, fn := range fns {
-		if indexRecords, ok, err := fn(ctx, repositoryID, commit, bypassLimit); err != nil {
+		if indexRecords, ok, err := fn(ctx, repositoryID, commit, bypassLimit, rootFilter); err != nil {
 			return nil, err
 		} else if ok {
 			return indexRecords, nil
@@ -125,7 +148,7 @@ func (s *JobSelector) GetIndexRecords(ctx context.Context, repositoryID int, com
 // flag is returned.
 func makeExplicitConfigurationFactory(configuration string) configurationFactoryFunc {
 	logger := log.Scoped("explicitConfigurationFactory", "")
-	return func(ctx context.Context, repositoryID int, commit string, _ bool) ([]uploadsshared.Index, bool, error) {
+	return func(ctx context.Context, repositoryID int, commit string, _ bool, rootFilter RootFilter) ([]uploadsshared.Index, bool, error) {
 		if configuration == "" {
 			return nil, false, nil
 		}
@@ -139,13 +162,13 @@ func makeExplicitConfigurationFactory(configuration string) configurationFactory
 			return nil, true, nil
 		}
 
-		return convertIndexConfiguration(repositoryID, commit, indexConfiguration), true, nil
+		return filterIndexRecordsByRoot(convertIndexConfiguration(repositoryID, commit, indexConfiguration), rootFilter), true, nil
 	}
 }
 
 // getIndexRecordsFromConfigurationInDatabase returns a set of index jobs configured via the UI for
 // the given repository. If no jobs are configured via the UI then a false valued flag is returned.
-func (s *JobSelector) getIndexRecordsFromConfigurationInDatabase(ctx context.Context, repositoryID int, commit string, _ bool) ([]uploadsshared.Index, bool, error) {
+func (s *JobSelector) getIndexRecordsFromConfigurationInDatabase(ctx context.Context, repositoryID int, commit string, _ bool, rootFilter RootFilter) ([]uploadsshared.Index, bool, error) {
 	indexConfigurationRecord, ok, err := s.store.GetIndexConfigurationByRepositoryID(ctx, repositoryID)
 	if err != nil {
 		return nil, false, errors.Wrap(err, "dbstore.GetIndexConfigurationByRepositoryID")
@@ -163,13 +186,13 @@ func (s *JobSelector) getIndexRecordsFromConfigurationInDatabase(ctx context.Con
 		return nil, true, nil
 	}
 
-	return convertIndexConfiguration(repositoryID, commit, indexConfiguration), true, nil
+	return filterIndexRecordsByRoot(convertIndexConfiguration(repositoryID, commit, indexConfiguration), rootFilter), true, nil
 }
 
 // getIndexRecordsFromConfigurationInRepository returns a set of index jobs configured via a committed
 // configuration file at the given commit. If no jobs are configured within the repository then a false
 // valued flag is returned.
-func (s *JobSelector) getIndexRecordsFromConfigurationInRepository(ctx context.Context, repositoryID int, commit string, _ bool) ([]uploadsshared.Index, bool, error) {
+func (s *JobSelector) getIndexRecordsFromConfigurationInRepository(ctx context.Context, repositoryID int, commit string, _ bool, rootFilter RootFilter) ([]uploadsshared.Index, bool, error) {
 	repo, err := s.repoStore.Get(ctx, api.RepoID(repositoryID))
 	if err != nil {
 		return nil, false, err
@@ -193,14 +216,14 @@ func (s *JobSelector) getIndexRecordsFromConfigurationInRepository(ctx context.C
 		return nil, true, nil
 	}
 
-	return convertIndexConfiguration(repositoryID, commit, indexConfiguration), true, nil
+	return filterIndexRecordsByRoot(convertIndexConfiguration(repositoryID, commit, indexConfiguration), rootFilter), true, nil
 }
 
 // inferIndexRecordsFromRepositoryStructure looks at the repository contents at the given commit and
 // determines a set of index jobs that are likely to succeed. If no jobs could be inferred then a
 // false valued flag is returned.
-func (s *JobSelector) inferIndexRecordsFromRepositoryStructure(ctx context.Context, repositoryID int, commit string, bypassLimit bool) ([]uploadsshared.Index, bool, error) {
-	result, err := s.InferIndexJobsFromRepositoryStructure(ctx, repositoryID, commit, "", bypassLimit)
+func (s *JobSelector) inferIndexRecordsFromRepositoryStructure(ctx context.Context, repositoryID int, commit string, bypassLimit bool, rootFilter RootFilter) ([]uploadsshared.Index, bool, error) {
+	result, err := s.inferIndexJobsFromRepositoryStructure(ctx, repositoryID, commit, "", bypassLimit, rootFilter)
 	if err != nil || len(result.IndexJobs) == 0 {
 		return nil, false, err
 	}
@@ -208,6 +231,24 @@ func (s *JobSelector) inferIndexRecordsFromRepositoryStructure(ctx context.Conte
 	return convertInferredConfiguration(repositoryID, commit, result.IndexJobs), true, nil
 }
 
+// filterIndexRecordsByRoot returns the subset of the given index records with a root passing the given
+// filter. Explicit configurations are filtered after conversion so that a path-scoped policy schedules
+// only the configured jobs that fall within its paths.
+func filterIndexRecordsByRoot(indexes []uploadsshared.Index, rootFilter RootFilter) []uploadsshared.Index {
+	if rootFilter == nil {
+		return indexes
+	}
+
+	filtered := make([]uploadsshared.Index, 0, len(indexes))
+	for _, index := range indexes {
+		if rootFilter(index.Root) {
+			filtered = append(filtered, index)
+		}
+	}
+
+	return filtered
+}
+
 // convertIndexConfiguration converts an index configuration object into a set of index records to be
 // inserted into the database.
 func convertIndexConfiguration(repositoryID int, commit string, indexConfiguration config.IndexConfiguration) (indexes []uploadsshared.Index) {
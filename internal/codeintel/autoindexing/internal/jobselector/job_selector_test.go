@@ -0,0 +1,58 @@
+package jobselector
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	uploadsshared "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+)
+
+func TestFilterIndexRecordsByRoot(t *testing.T) {
+	indexes := []uploadsshared.Index{
+		{ID: 1, Root: ""},
+		{ID: 2, Root: "client/web"},
+		{ID: 3, Root: "client/shared"},
+		{ID: 4, Root: "cmd/frontend"},
+	}
+
+	testCases := []struct {
+		name       string
+		rootFilter RootFilter
+		expected   []int
+	}{
+		{
+			name:       "nil filter",
+			rootFilter: nil,
+			expected:   []int{1, 2, 3, 4},
+		},
+		{
+			name:       "subset of roots",
+			rootFilter: func(root string) bool { return root == "client/web" || root == "cmd/frontend" },
+			expected:   []int{2, 4},
+		},
+		{
+			name:       "repository root",
+			rootFilter: func(root string) bool { return root == "" },
+			expected:   []int{1},
+		},
+		{
+			name:       "no matching roots",
+			rootFilter: func(root string) bool { return false },
+			expected:   []int{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ids := []int{}
+			for _, index := range filterIndexRecordsByRoot(indexes, testCase.rootFilter) {
+				ids = append(ids, index.ID)
+			}
+
+			if diff := cmp.Diff(testCase.expected, ids); diff != "" {
+				t.Errorf("unexpected indexes (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
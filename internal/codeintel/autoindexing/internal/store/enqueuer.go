@@ -74,6 +74,46 @@ SELECT
 	)
 `
 
+// GetQueuedRoots returns the roots of the records that would cause IsQueued to return true for the given
+// repository and commit: the roots of manual uploads, and the roots of index records whose most recent
+// attempt for the same root and indexer is not marked for reindexing.
+func (s *store) GetQueuedRoots(ctx context.Context, repositoryID int, commit string) (_ []string, err error) {
+	ctx, _, endObservation := s.operations.getQueuedRoots.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("repositoryID", repositoryID),
+		attribute.String("commit", commit),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return basestore.ScanStrings(s.db.Query(ctx, sqlf.Sprintf(
+		getQueuedRootsQuery,
+		repositoryID, commit,
+		repositoryID, commit,
+	)))
+}
+
+const getQueuedRootsQuery = `
+SELECT u.root
+FROM lsif_uploads u
+WHERE
+	u.repository_id = %s AND
+	u.commit = %s AND
+	u.state NOT IN ('deleting', 'deleted') AND
+	u.associated_index_id IS NULL AND
+	NOT u.should_reindex
+
+UNION
+
+SELECT i.root
+FROM (
+	SELECT DISTINCT ON (root, indexer) root, should_reindex
+	FROM lsif_indexes
+	WHERE repository_id = %s AND commit = %s
+	ORDER BY root, indexer, queued_at DESC
+) i
+WHERE NOT i.should_reindex
+ORDER BY root
+`
+
 func (s *store) IsQueuedRootIndexer(ctx context.Context, repositoryID int, commit string, root string, indexer string) (_ bool, err error) {
 	ctx, _, endObservation := s.operations.isQueuedRootIndexer.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
 		attribute.Int("repositoryID", repositoryID),
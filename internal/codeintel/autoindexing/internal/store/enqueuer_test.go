@@ -102,6 +102,30 @@ func TestIsQueuedRootIndexer(t *testing.T) {
 	}
 }
 
+func TestGetQueuedRoots(t *testing.T) {
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := New(&observation.TestContext, db)
+
+	now := time.Now()
+	insertIndexes(t, db, uploadsshared.Index{ID: 1, RepositoryID: 1, Commit: makeCommit(1), Root: "a", Indexer: "i1", QueuedAt: now.Add(-time.Hour * 1)})
+	insertIndexes(t, db, uploadsshared.Index{ID: 2, RepositoryID: 1, Commit: makeCommit(1), Root: "b", Indexer: "i1", QueuedAt: now.Add(-time.Hour * 1), ShouldReindex: true})
+	insertIndexes(t, db, uploadsshared.Index{ID: 3, RepositoryID: 1, Commit: makeCommit(1), Root: "c", Indexer: "i1", QueuedAt: now.Add(-time.Hour * 1)})
+	insertIndexes(t, db, uploadsshared.Index{ID: 4, RepositoryID: 1, Commit: makeCommit(1), Root: "c", Indexer: "i1", QueuedAt: now.Add(-time.Hour * 2), ShouldReindex: true})
+	insertIndexes(t, db, uploadsshared.Index{ID: 5, RepositoryID: 1, Commit: makeCommit(2), Root: "d", Indexer: "i1", QueuedAt: now.Add(-time.Hour * 1)})
+	insertUploads(t, db, upload{ID: 1, RepositoryID: 1, Commit: makeCommit(1), Root: "e"})
+	insertUploads(t, db, upload{ID: 2, RepositoryID: 1, Commit: makeCommit(1), Root: "f", State: "deleted"})
+	insertUploads(t, db, upload{ID: 3, RepositoryID: 1, Commit: makeCommit(1), Root: "g", ShouldReindex: true})
+
+	roots, err := store.GetQueuedRoots(context.Background(), 1, makeCommit(1))
+	if err != nil {
+		t.Fatalf("unexpected error getting queued roots: %s", err)
+	}
+	if diff := cmp.Diff([]string{"a", "c", "e"}, roots); diff != "" {
+		t.Errorf("unexpected roots (-want +got):\n%s", diff)
+	}
+}
+
 func TestInsertIndexes(t *testing.T) {
 	ctx := context.Background()
 	logger := logtest.Scoped(t)
@@ -27,6 +27,7 @@ type operations struct {
 	markRepoRevsAsProcessed                *observation.Operation
 	isQueued                               *observation.Operation
 	isQueuedRootIndexer                    *observation.Operation
+	getQueuedRoots                         *observation.Operation
 	insertIndexes                          *observation.Operation
 	insertDependencyIndexingJob            *observation.Operation
 	queueRepoRev                           *observation.Operation
@@ -83,6 +84,7 @@ func newOperations(observationCtx *observation.Context) *operations {
 		markRepoRevsAsProcessed:                op("MarkRepoRevsAsProcessed"),
 		isQueued:                               op("IsQueued"),
 		isQueuedRootIndexer:                    op("IsQueuedRootIndexer"),
+		getQueuedRoots:                         op("GetQueuedRoots"),
 		insertIndexes:                          op("InsertIndexes"),
 		insertDependencyIndexingJob:            op("InsertDependencyIndexingJob"),
 		queueRepoRev:                           op("QueueRepoRev"),
@@ -15,6 +15,7 @@ import (
 
 type Store interface {
 	WithTransaction(ctx context.Context, f func(tx Store) error) error
+	Handle() *basestore.Store
 
 	// Inference configuration
 	GetInferenceScript(ctx context.Context) (string, error)
@@ -41,6 +42,7 @@ type Store interface {
 	// Enqueuer
 	IsQueued(ctx context.Context, repositoryID int, commit string) (bool, error)
 	IsQueuedRootIndexer(ctx context.Context, repositoryID int, commit string, root string, indexer string) (bool, error)
+	GetQueuedRoots(ctx context.Context, repositoryID int, commit string) ([]string, error)
 	InsertIndexes(ctx context.Context, indexes []uploadsshared.Index) ([]uploadsshared.Index, error)
 
 	// Dependency indexing
@@ -92,3 +94,7 @@ func (s *store) Transact(ctx context.Context) (*store, error) {
 func (s *store) Done(err error) error {
 	return s.db.Done(err)
 }
+
+func (s *store) Handle() *basestore.Store {
+	return s.db
+}
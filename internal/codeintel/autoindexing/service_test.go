@@ -397,6 +397,103 @@ func TestQueueIndexesInferred(t *testing.T) {
 	}
 }
 
+func TestQueueIndexesForRoots(t *testing.T) {
+	mockDBStore := NewMockStore()
+	mockDBStore.InsertIndexesFunc.SetDefaultHook(func(ctx context.Context, indexes []uploadsshared.Index) ([]uploadsshared.Index, error) {
+		return indexes, nil
+	})
+	mockDBStore.RepositoryExceptionsFunc.SetDefaultReturn(true, true, nil)
+	mockDBStore.GetQueuedRootsFunc.SetDefaultHook(func(ctx context.Context, repositoryID int, commit string) ([]string, error) {
+		if repositoryID == 43 {
+			return []string{"a"}, nil
+		}
+		return nil, nil
+	})
+
+	gitserverClient := gitserver.NewMockClient()
+	gitserverClient.ResolveRevisionFunc.SetDefaultHook(func(ctx context.Context, repo api.RepoName, rev string, opts gitserver.ResolveRevisionOptions) (api.CommitID, error) {
+		return api.CommitID(fmt.Sprintf("c%s", repo)), nil
+	})
+	gitserverClient.ReadFileFunc.SetDefaultReturn(nil, os.ErrNotExist)
+
+	inferenceService := NewMockInferenceService()
+	inferenceService.InferIndexJobsFunc.SetDefaultReturn(&shared.InferenceResult{IndexJobs: []config.IndexJob{{Root: "a"}, {Root: "b"}, {Root: "c"}}}, nil)
+
+	service := newService(
+		&observation.TestContext,
+		mockDBStore,
+		inferenceService,
+		nil,                    // repoUpdater
+		defaultMockRepoStore(), // repoStore
+		gitserverClient,
+	)
+
+	rootFilter := func(root string) bool { return root != "c" }
+	for _, id := range []int{42, 43} {
+		if _, err := service.indexEnqueuer.QueueIndexesForRoots(context.Background(), id, "HEAD", rootFilter); err != nil {
+			t.Fatalf("unexpected error queueing indexes: %s", err)
+		}
+	}
+
+	indexRoots := map[int][]string{}
+	for _, call := range mockDBStore.InsertIndexesFunc.History() {
+		for _, index := range call.Result0 {
+			indexRoots[index.RepositoryID] = append(indexRoots[index.RepositoryID], index.Root)
+		}
+	}
+
+	// Repository 43 already has a record for a root passing the filter
+	expectedIndexRoots := map[int][]string{
+		42: {"a", "b"},
+	}
+	if diff := cmp.Diff(expectedIndexRoots, indexRoots); diff != "" {
+		t.Errorf("unexpected indexes (-want +got):\n%s", diff)
+	}
+
+	if len(mockDBStore.IsQueuedFunc.History()) != 0 {
+		t.Errorf("unexpected number of calls to IsQueued. want=%d have=%d", 0, len(mockDBStore.IsQueuedFunc.History()))
+	}
+	if len(mockDBStore.GetQueuedRootsFunc.History()) != 2 {
+		t.Errorf("unexpected number of calls to GetQueuedRoots. want=%d have=%d", 2, len(mockDBStore.GetQueuedRootsFunc.History()))
+	}
+	if len(inferenceService.InferIndexJobsFunc.History()) != 1 {
+		t.Errorf("unexpected number of calls to InferIndexJobs. want=%d have=%d", 1, len(inferenceService.InferIndexJobsFunc.History()))
+	}
+}
+
+func TestQueueIndexesForRootsUnfiltered(t *testing.T) {
+	mockDBStore := NewMockStore()
+	mockDBStore.IsQueuedFunc.SetDefaultReturn(true, nil)
+
+	gitserverClient := gitserver.NewMockClient()
+	gitserverClient.ResolveRevisionFunc.SetDefaultReturn("c42", nil)
+
+	inferenceService := NewMockInferenceService()
+
+	service := newService(
+		&observation.TestContext,
+		mockDBStore,
+		inferenceService,
+		nil,                    // repoUpdater
+		defaultMockRepoStore(), // repoStore
+		gitserverClient,
+	)
+
+	if _, err := service.indexEnqueuer.QueueIndexesForRoots(context.Background(), 42, "HEAD", nil); err != nil {
+		t.Fatalf("unexpected error queueing indexes: %s", err)
+	}
+
+	if len(mockDBStore.IsQueuedFunc.History()) != 1 {
+		t.Errorf("unexpected number of calls to IsQueued. want=%d have=%d", 1, len(mockDBStore.IsQueuedFunc.History()))
+	}
+	if len(mockDBStore.GetQueuedRootsFunc.History()) != 0 {
+		t.Errorf("unexpected number of calls to GetQueuedRoots. want=%d have=%d", 0, len(mockDBStore.GetQueuedRootsFunc.History()))
+	}
+	if len(inferenceService.InferIndexJobsFunc.History()) != 0 {
+		t.Errorf("unexpected number of calls to InferIndexJobs. want=%d have=%d", 0, len(inferenceService.InferIndexJobsFunc.History()))
+	}
+}
+
 func TestQueueIndexesForPackage(t *testing.T) {
 	mockDBStore := NewMockStore()
 	mockDBStore.InsertIndexesFunc.SetDefaultHook(func(ctx context.Context, indexes []uploadsshared.Index) ([]uploadsshared.Index, error) {
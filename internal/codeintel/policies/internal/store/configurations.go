@@ -150,7 +150,8 @@ SELECT
 	p.indexing_enabled,
 	p.index_commit_max_age_hours,
 	p.index_intermediate_commits,
-	p.embeddings_enabled
+	p.embeddings_enabled,
+	p.path_patterns
 FROM lsif_configuration_policies p
 LEFT JOIN repo ON repo.id = p.repository_id
 WHERE %s
@@ -192,7 +193,8 @@ SELECT
 	p.indexing_enabled,
 	p.index_commit_max_age_hours,
 	p.index_intermediate_commits,
-	p.embeddings_enabled
+	p.embeddings_enabled,
+	p.path_patterns
 FROM lsif_configuration_policies p
 LEFT JOIN repo ON repo.id = p.repository_id
 WHERE
@@ -209,6 +211,7 @@ func (s *store) CreateConfigurationPolicy(ctx context.Context, configurationPoli
 	retentionDurationHours := optionalNumHours(configurationPolicy.RetentionDuration)
 	indexingCommitMaxAgeHours := optionalNumHours(configurationPolicy.IndexCommitMaxAge)
 	repositoryPatterns := optionalArray(configurationPolicy.RepositoryPatterns)
+	pathPatterns := optionalArray(configurationPolicy.PathPatterns)
 
 	hydratedConfigurationPolicy, _, err := scanFirstConfigurationPolicy(s.db.Query(ctx, sqlf.Sprintf(
 		createConfigurationPolicyQuery,
@@ -224,6 +227,7 @@ func (s *store) CreateConfigurationPolicy(ctx context.Context, configurationPoli
 		indexingCommitMaxAgeHours,
 		configurationPolicy.IndexIntermediateCommits,
 		configurationPolicy.EmbeddingEnabled,
+		pathPatterns,
 	)))
 	if err != nil {
 		return shared.ConfigurationPolicy{}, err
@@ -245,8 +249,9 @@ INSERT INTO lsif_configuration_policies (
 	indexing_enabled,
 	index_commit_max_age_hours,
 	index_intermediate_commits,
-	embeddings_enabled
-) VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+	embeddings_enabled,
+	path_patterns
+) VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 RETURNING
 	id,
 	repository_id,
@@ -261,7 +266,8 @@ RETURNING
 	indexing_enabled,
 	index_commit_max_age_hours,
 	index_intermediate_commits,
-	embeddings_enabled
+	embeddings_enabled,
+	path_patterns
 `
 
 var (
@@ -279,6 +285,7 @@ func (s *store) UpdateConfigurationPolicy(ctx context.Context, policy shared.Con
 	retentionDuration := optionalNumHours(policy.RetentionDuration)
 	indexCommitMaxAge := optionalNumHours(policy.IndexCommitMaxAge)
 	repositoryPatterns := optionalArray(policy.RepositoryPatterns)
+	pathPatterns := optionalArray(policy.PathPatterns)
 
 	return s.db.WithTransact(ctx, func(tx *basestore.Store) error {
 		// First, pull current policy to see if it's protected, and if so whether or not the
@@ -310,6 +317,7 @@ func (s *store) UpdateConfigurationPolicy(ctx context.Context, policy shared.Con
 			indexCommitMaxAge,
 			policy.IndexIntermediateCommits,
 			policy.EmbeddingEnabled,
+			pathPatterns,
 			policy.ID,
 		))
 	})
@@ -330,7 +338,8 @@ SELECT
 	indexing_enabled,
 	index_commit_max_age_hours,
 	index_intermediate_commits,
-	embeddings_enabled
+	embeddings_enabled,
+	path_patterns
 FROM lsif_configuration_policies
 WHERE id = %s
 FOR UPDATE
@@ -348,7 +357,8 @@ UPDATE lsif_configuration_policies SET
 	indexing_enabled = %s,
 	index_commit_max_age_hours = %s,
 	index_intermediate_commits = %s,
-	embeddings_enabled = %s
+	embeddings_enabled = %s,
+	path_patterns = %s
 WHERE id = %s
 `
 
@@ -400,7 +410,7 @@ SELECT protected FROM candidate
 
 func scanConfigurationPolicy(s dbutil.Scanner) (configurationPolicy shared.ConfigurationPolicy, err error) {
 	var retentionDurationHours, indexCommitMaxAgeHours *int
-	var repositoryPatterns []string
+	var repositoryPatterns, pathPatterns []string
 
 	if err := s.Scan(
 		&configurationPolicy.ID,
@@ -417,6 +427,7 @@ func scanConfigurationPolicy(s dbutil.Scanner) (configurationPolicy shared.Confi
 		&indexCommitMaxAgeHours,
 		&configurationPolicy.IndexIntermediateCommits,
 		&configurationPolicy.EmbeddingEnabled,
+		pq.Array(&pathPatterns),
 	); err != nil {
 		return configurationPolicy, err
 	}
@@ -424,6 +435,7 @@ func scanConfigurationPolicy(s dbutil.Scanner) (configurationPolicy shared.Confi
 	configurationPolicy.RetentionDuration = optionalDuration(retentionDurationHours)
 	configurationPolicy.IndexCommitMaxAge = optionalDuration(indexCommitMaxAgeHours)
 	configurationPolicy.RepositoryPatterns = optionalSlice(repositoryPatterns)
+	configurationPolicy.PathPatterns = optionalSlice(pathPatterns)
 
 	return configurationPolicy, nil
 }
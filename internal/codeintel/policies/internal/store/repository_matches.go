@@ -192,5 +192,6 @@ RETURNING
 	indexing_enabled,
 	index_commit_max_age_hours,
 	index_intermediate_commits,
-	embeddings_enabled
+	embeddings_enabled,
+	path_patterns
 `
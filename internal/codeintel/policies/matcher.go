@@ -24,11 +24,13 @@ type Matcher struct {
 
 // PolicyMatch indicates the name of the matching branch or tag associated with some commit. The policy
 // identifier field is set unless the policy match exists due to a `includeTipOfDefaultBranch` match. The
-// policy duration field is set if the matching policy specifies a duration.
+// policy duration field is set if the matching policy specifies a duration. The path patterns field is
+// set if the matching policy applies only to a subset of directories within the repository.
 type PolicyMatch struct {
 	Name           string
 	PolicyID       *int
 	PolicyDuration *time.Duration
+	PathPatterns   *[]string
 	CommittedAt    *time.Time
 }
 
@@ -135,9 +137,10 @@ type matcherContext struct {
 }
 
 type branchRequestMeta struct {
-	isDefaultBranch     bool
-	commitID            string // commit hash of the tip of the branch
-	policyDurationByIDs map[int]*time.Duration
+	isDefaultBranch         bool
+	commitID                string // commit hash of the tip of the branch
+	policyDurationByIDs     map[int]*time.Duration
+	policyPathPatternsByIDs map[int]*[]string
 }
 
 // matchTaggedCommits determines if the given commit (described by the tag-type ref given description) matches any tag-type
@@ -150,6 +153,7 @@ func (m *Matcher) matchTaggedCommits(context matcherContext, commit string, refD
 			Name:           refDescription.Name,
 			PolicyID:       &policy.ID,
 			PolicyDuration: policyDuration,
+			PathPatterns:   policy.PathPatterns,
 			CommittedAt:    refDescription.CreatedDate,
 		})
 	}
@@ -179,6 +183,7 @@ func (m *Matcher) matchBranchHeads(context matcherContext, commit string, refDes
 			Name:           refDescription.Name,
 			PolicyID:       &policy.ID,
 			PolicyDuration: policyDuration,
+			PathPatterns:   policy.PathPatterns,
 			CommittedAt:    refDescription.CreatedDate,
 		})
 
@@ -187,9 +192,11 @@ func (m *Matcher) matchBranchHeads(context matcherContext, commit string, refDes
 			meta, ok := context.branchRequests[refDescription.Name]
 			if !ok {
 				meta.policyDurationByIDs = map[int]*time.Duration{}
+				meta.policyPathPatternsByIDs = map[int]*[]string{}
 			}
 
 			meta.policyDurationByIDs[policy.ID] = policyDuration
+			meta.policyPathPatternsByIDs[policy.ID] = policy.PathPatterns
 			meta.isDefaultBranch = meta.isDefaultBranch || refDescription.IsDefaultBranch
 			meta.commitID = commit
 			context.branchRequests[refDescription.Name] = meta
@@ -247,6 +254,7 @@ func (m *Matcher) matchCommitsOnBranch(ctx context.Context, context matcherConte
 					Name:           branchName,
 					PolicyID:       &localPolicyID,
 					PolicyDuration: policyDuration,
+					PathPatterns:   branchRequestMeta.policyPathPatternsByIDs[policyID],
 					CommittedAt:    &commitDate,
 				})
 			}
@@ -280,6 +288,7 @@ func (m *Matcher) matchCommitPolicies(ctx context.Context, context matcherContex
 				Name:           commit,
 				PolicyID:       &id,
 				PolicyDuration: policyDuration,
+				PathPatterns:   policy.PathPatterns,
 				CommittedAt:    &commitDate,
 			})
 		}
@@ -288,6 +297,34 @@ func (m *Matcher) matchCommitPolicies(ctx context.Context, context matcherContex
 	return nil
 }
 
+// MatchesPath returns true if the given directory (relative to the root of the repository) is covered
+// by the policy that produced this match. Matches without path patterns (including implicit matches of
+// the tip of the default branch) cover the entire repository.
+func (pm PolicyMatch) MatchesPath(path string) bool {
+	return shared.PathPatternsMatch(pm.PathPatterns, path)
+}
+
+// PathFilter returns a function that determines whether or not a directory (relative to the root of the
+// repository) is covered by at least one of the given policy matches. If any of the given matches cover
+// the entire repository, a nil function is returned to indicate that no filtering is necessary.
+func PathFilter(policyMatches []PolicyMatch) func(path string) bool {
+	for _, policyMatch := range policyMatches {
+		if policyMatch.PathPatterns == nil {
+			return nil
+		}
+	}
+
+	return func(path string) bool {
+		for _, policyMatch := range policyMatches {
+			if policyMatch.MatchesPath(path) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
 func (m *Matcher) forEachMatchingPolicy(context matcherContext, refDescription gitdomain.RefDescription, targetObjectType shared.GitObjectType, f func(policy shared.ConfigurationPolicy), now time.Time) {
 	for _, policy := range context.policies {
 		if policy.Type == targetObjectType && m.policyMatchesRefDescription(context, policy, refDescription, now) {
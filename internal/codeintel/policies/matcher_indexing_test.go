@@ -147,6 +147,25 @@ func TestCommitsDescribedByPolicyForIndexing(t *testing.T) {
 		})
 	})
 
+	t.Run("propagates path patterns to matches", func(t *testing.T) {
+		pathPatterns := []string{"services/payments/**"}
+		policies := []policiesshared.ConfigurationPolicy{
+			{
+				ID:                       policyID,
+				Type:                     "GIT_TREE",
+				Pattern:                  "xy/*",
+				PathPatterns:             &pathPatterns,
+				IndexCommitMaxAge:        &testDuration,
+				IndexIntermediateCommits: true,
+			},
+		}
+
+		runTest(t, mainGitserverClient, policies, map[string][]PolicyMatch{
+			"deadbeef07": {PolicyMatch{Name: "xy/feature-x", PolicyID: &policyID, PolicyDuration: &testDuration, PathPatterns: &pathPatterns}},
+			"deadbeef08": {PolicyMatch{Name: "xy/feature-x", PolicyID: &policyID, PolicyDuration: &testDuration, PathPatterns: &pathPatterns}},
+		})
+	})
+
 	t.Run("matches commit policies", func(t *testing.T) {
 		policies := []policiesshared.ConfigurationPolicy{
 			{
@@ -181,3 +200,36 @@ func TestCommitsDescribedByPolicyForIndexing(t *testing.T) {
 		runTest(t, developGitserverClient, nil, nil)
 	})
 }
+
+func TestPathFilter(t *testing.T) {
+	paymentsPatterns := []string{"services/payments/**"}
+	billingPatterns := []string{"services/billing"}
+
+	t.Run("unrestricted", func(t *testing.T) {
+		if filter := PathFilter([]PolicyMatch{{PathPatterns: &paymentsPatterns}, {}}); filter != nil {
+			t.Fatalf("expected nil filter when a match covers the entire repository")
+		}
+	})
+
+	t.Run("restricted", func(t *testing.T) {
+		filter := PathFilter([]PolicyMatch{{PathPatterns: &paymentsPatterns}, {PathPatterns: &billingPatterns}})
+		if filter == nil {
+			t.Fatalf("expected non-nil filter")
+		}
+
+		for path, expected := range map[string]bool{
+			"":                           false,
+			"services":                   false,
+			"services/payments":          true,
+			"services/payments/api":      true,
+			"services/billing":           true,
+			"services/billing/internal":  true,
+			"services/billingv2":         false,
+			"services/notifications/api": false,
+		} {
+			if matched := filter(path); matched != expected {
+				t.Errorf("unexpected match for %q. want=%v have=%v", path, expected, matched)
+			}
+		}
+	})
+}
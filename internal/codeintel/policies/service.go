@@ -316,6 +316,9 @@ func (s *Service) populateMatchingCommits(
 	// that the upload's commit is not first in the list.
 	if policyMatches, ok := matchingPolicies[upload.Commit]; ok {
 		for _, policyMatch := range policyMatches {
+			if !policyMatch.MatchesPath(upload.Root) {
+				continue
+			}
 			if policyMatch.PolicyDuration == nil || now.Sub(upload.UploadedAt) < *policyMatch.PolicyDuration {
 				policyID := -1
 				if policyMatch.PolicyID != nil {
@@ -336,6 +339,9 @@ func (s *Service) populateMatchingCommits(
 		}
 		if policyMatches, ok := matchingPolicies[commit]; ok {
 			for _, policyMatch := range policyMatches {
+				if !policyMatch.MatchesPath(upload.Root) {
+					continue
+				}
 				if policyMatch.PolicyDuration == nil || now.Sub(upload.UploadedAt) < *policyMatch.PolicyDuration {
 					policyID := -1
 					if policyMatch.PolicyID != nil {
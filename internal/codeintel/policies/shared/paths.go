@@ -0,0 +1,119 @@
+package shared
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/gobwas/glob"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// MatchesPath returns true if the given directory (relative to the root of the repository) falls
+// within the path patterns of this configuration policy. Policies without path patterns apply to
+// the entire repository.
+func (p ConfigurationPolicy) MatchesPath(path string) bool {
+	return PathPatternsMatch(p.PathPatterns, path)
+}
+
+// ValidatePathPatterns returns an error if any of the given path patterns is not a valid glob.
+func ValidatePathPatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		if normalizePath(pattern) == "" {
+			return errors.New("path patterns must not be empty")
+		}
+		if _, err := compilePathPattern(pattern); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("illegal path pattern `%s`", pattern))
+		}
+	}
+
+	return nil
+}
+
+// PathPatternsMatch returns true if the given directory (relative to the root of the repository)
+// matches one of the given path patterns. A nil set of patterns matches every path.
+//
+// A pattern without glob syntax is treated as a path prefix, so that the pattern `services/payments`
+// matches the directories `services/payments` and `services/payments/api`, but not `services/paymentsv2`.
+// Glob patterns are matched against the directory with a trailing slash, so that the pattern
+// `services/payments/**` also matches the directory `services/payments` itself.
+func PathPatternsMatch(patterns *[]string, path string) bool {
+	if patterns == nil {
+		return true
+	}
+
+	path = normalizePath(path)
+	for _, pattern := range *patterns {
+		if matchPathPattern(pattern, path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchPathPattern(pattern, path string) bool {
+	pattern = normalizePath(pattern)
+
+	if !strings.ContainsAny(pattern, "*?[{") {
+		return path == pattern || strings.HasPrefix(path, pattern+"/")
+	}
+
+	compiled, err := compilePathPattern(pattern)
+	if err != nil {
+		// Invalid patterns are rejected on write; never match anything we can't compile
+		return false
+	}
+
+	if path == "" {
+		return compiled.Match("")
+	}
+
+	return compiled.Match(path) || compiled.Match(path+"/")
+}
+
+var (
+	pathPatternCacheMu sync.RWMutex
+	pathPatternCache   = map[string]glob.Glob{}
+)
+
+// compilePathPattern compiles the given glob pattern using `/` as the separator. Compiled patterns
+// are cached as the same small set of patterns is matched against every upload or index root of a
+// repository.
+func compilePathPattern(pattern string) (glob.Glob, error) {
+	pattern = normalizePath(pattern)
+
+	pathPatternCacheMu.RLock()
+	compiled, ok := pathPatternCache[pattern]
+	pathPatternCacheMu.RUnlock()
+	if ok {
+		return compiled, nil
+	}
+
+	compiled, err := glob.Compile(pattern, '/')
+	if err != nil {
+		return nil, err
+	}
+
+	pathPatternCacheMu.Lock()
+	pathPatternCache[pattern] = compiled
+	pathPatternCacheMu.Unlock()
+
+	return compiled, nil
+}
+
+// normalizePath converts an index root or path pattern into a canonical relative form: no leading
+// `./` or `/`, and no trailing slash. The repository root is represented by the empty string.
+func normalizePath(path string) string {
+	path = strings.TrimSpace(path)
+	for strings.HasPrefix(path, "./") {
+		path = path[2:]
+	}
+	path = strings.Trim(path, "/")
+	if path == "." {
+		return ""
+	}
+
+	return path
+}
@@ -0,0 +1,48 @@
+package shared
+
+import "testing"
+
+func TestPathPatternsMatch(t *testing.T) {
+	testCases := []struct {
+		patterns []string
+		path     string
+		expected bool
+	}{
+		{patterns: []string{"services/payments"}, path: "services/payments", expected: true},
+		{patterns: []string{"services/payments"}, path: "services/payments/api", expected: true},
+		{patterns: []string{"services/payments"}, path: "services/paymentsv2", expected: false},
+		{patterns: []string{"services/payments/"}, path: "./services/payments/", expected: true},
+		{patterns: []string{"services/payments/**"}, path: "services/payments", expected: true},
+		{patterns: []string{"services/payments/**"}, path: "services/payments/api/v1", expected: true},
+		{patterns: []string{"services/payments/**"}, path: "services", expected: false},
+		{patterns: []string{"services/*/api"}, path: "services/payments/api", expected: true},
+		{patterns: []string{"services/*/api"}, path: "services/payments/api/v1", expected: false},
+		{patterns: []string{"services/*/api"}, path: "services/payments/internal/api", expected: false},
+		{patterns: []string{"libs", "services/**"}, path: "libs/go", expected: true},
+		{patterns: []string{"services/**"}, path: "", expected: false},
+		{patterns: []string{"**"}, path: "", expected: true},
+	}
+
+	for _, testCase := range testCases {
+		patterns := testCase.patterns
+		if matched := PathPatternsMatch(&patterns, testCase.path); matched != testCase.expected {
+			t.Errorf("unexpected match for %q against %v. want=%v have=%v", testCase.path, testCase.patterns, testCase.expected, matched)
+		}
+	}
+
+	if !PathPatternsMatch(nil, "any/path") {
+		t.Errorf("expected nil patterns to match every path")
+	}
+}
+
+func TestValidatePathPatterns(t *testing.T) {
+	if err := ValidatePathPatterns([]string{"services/payments", "libs/**", "cmd/{api,worker}"}); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if err := ValidatePathPatterns([]string{"services/[payments"}); err == nil {
+		t.Errorf("expected error for malformed glob")
+	}
+	if err := ValidatePathPatterns([]string{"/"}); err == nil {
+		t.Errorf("expected error for empty pattern")
+	}
+}
@@ -54,6 +54,10 @@ func (r *configurationPolicyResolver) RepositoryPatterns() *[]string {
 	return r.configurationPolicy.RepositoryPatterns
 }
 
+func (r *configurationPolicyResolver) PathPatterns() *[]string {
+	return r.configurationPolicy.PathPatterns
+}
+
 func (r *configurationPolicyResolver) Type() (_ resolverstubs.GitObjectType, err error) {
 	defer r.errTracer.Collect(&err,
 		attribute.String("configurationPolicyResolver.field", "type"),
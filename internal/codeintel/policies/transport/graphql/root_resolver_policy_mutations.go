@@ -43,6 +43,7 @@ func (r *rootResolver) CreateCodeIntelligenceConfigurationPolicy(ctx context.Con
 		RepositoryID:              repositoryID,
 		Name:                      args.Name,
 		RepositoryPatterns:        args.RepositoryPatterns,
+		PathPatterns:              args.PathPatterns,
 		Type:                      shared.GitObjectType(args.Type),
 		Pattern:                   args.Pattern,
 		RetentionEnabled:          args.RetentionEnabled,
@@ -85,6 +86,7 @@ func (r *rootResolver) UpdateCodeIntelligenceConfigurationPolicy(ctx context.Con
 		ID:                        id,
 		Name:                      args.Name,
 		RepositoryPatterns:        args.RepositoryPatterns,
+		PathPatterns:              args.PathPatterns,
 		Type:                      shared.GitObjectType(args.Type),
 		Pattern:                   args.Pattern,
 		RetentionEnabled:          args.RetentionEnabled,
@@ -150,6 +152,11 @@ func validateConfigurationPolicy(policy resolverstubs.CodeIntelConfigurationPoli
 	if shared.GitObjectType(policy.Type) == shared.GitObjectTypeCommit && policy.Pattern != "HEAD" {
 		return errors.Errorf("pattern must be HEAD for policy type 'GIT_COMMIT'")
 	}
+	if policy.PathPatterns != nil {
+		if err := shared.ValidatePathPatterns(*policy.PathPatterns); err != nil {
+			return err
+		}
+	}
 
 	if policy.RetentionEnabled && policy.RetentionDurationHours != nil && (*policy.RetentionDurationHours < 0 || *policy.RetentionDurationHours > maxDurationHours) {
 		return errors.Errorf("illegal retention duration '%d'", *policy.RetentionDurationHours)
@@ -166,6 +173,10 @@ func validateConfigurationPolicy(policy resolverstubs.CodeIntelConfigurationPoli
 		if shared.GitObjectType(policy.Type) != shared.GitObjectTypeCommit {
 			return errors.Errorf("embeddings policies must have type 'GIT_COMMIT'")
 		}
+
+		if policy.PathPatterns != nil {
+			return errors.Errorf("embeddings policies cannot be scoped to paths")
+		}
 	}
 
 	return nil
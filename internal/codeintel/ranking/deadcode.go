@@ -0,0 +1,78 @@
+package ranking
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	internalshared "github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/internal/shared"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/shared"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// DeadCodeReport returns the most recently completed dead code report, if any.
+func (s *Service) DeadCodeReport(ctx context.Context) (shared.DeadCodeReport, bool, error) {
+	graphKey, completedAt, ok, err := s.store.DeadCodeReportGraphKey(ctx)
+	return shared.DeadCodeReport{GraphKey: graphKey, CompletedAt: completedAt}, ok, err
+}
+
+const unreferencedDefinitionsPageSize = 1000
+
+// UnreferencedDefinitions invokes f for each definition in the given dead code report, ordered
+// by upload and path. Definitions in repositories that are not visible to the current user, in
+// paths hidden from the current user by sub-repo permissions, or in repositories excluded from
+// the report via site configuration are skipped.
+func (s *Service) UnreferencedDefinitions(ctx context.Context, report shared.DeadCodeReport, f func(definition shared.UnreferencedDefinition) error) (err error) {
+	ctx, _, endObservation := s.operations.unreferencedDefinitions.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	a := actor.FromContext(ctx)
+	visibleRepos := map[int]bool{}
+
+	for afterID := 0; ; {
+		definitions, err := s.store.GetUnreferencedDefinitions(ctx, report.GraphKey, afterID, unreferencedDefinitionsPageSize)
+		if err != nil {
+			return err
+		}
+		if len(definitions) == 0 {
+			return nil
+		}
+		afterID = definitions[len(definitions)-1].ID
+
+		var unseenRepoIDs []api.RepoID
+		for _, definition := range definitions {
+			if _, ok := visibleRepos[definition.RepositoryID]; !ok {
+				visibleRepos[definition.RepositoryID] = false
+				unseenRepoIDs = append(unseenRepoIDs, api.RepoID(definition.RepositoryID))
+			}
+		}
+		if len(unseenRepoIDs) > 0 {
+			// 🚨 SECURITY: The repo store only returns repositories visible to the current user.
+			repos, err := s.repoStore.GetReposSetByIDs(ctx, unseenRepoIDs...)
+			if err != nil {
+				return err
+			}
+			for id := range repos {
+				visibleRepos[int(id)] = !internalshared.ExcludedFromDeadCodeReport(string(repos[id].Name))
+			}
+		}
+
+		for _, definition := range definitions {
+			if !visibleRepos[definition.RepositoryID] {
+				continue
+			}
+
+			// 🚨 SECURITY: Filter out paths hidden from the current user by sub-repo permissions.
+			if ok, err := authz.FilterActorPath(ctx, s.subRepoPermsChecker, a, api.RepoName(definition.Repository), definition.DocumentPath); err != nil {
+				return err
+			} else if !ok {
+				continue
+			}
+
+			if err := f(definition); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package ranking
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/shared"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestUnreferencedDefinitions(t *testing.T) {
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		CodeIntelRankingDeadCodeReportExcludedRepositories: []string{"github.com/test/generated-*"},
+	}})
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	ctx := actor.WithActor(context.Background(), actor.FromUser(1))
+	mockStore := NewMockStore()
+	mockRepoStore := NewMockRepoStore()
+	mockSubRepoPermsChecker := authz.NewMockSubRepoPermissionChecker()
+	svc := newService(&observation.TestContext, mockStore, nil, mockRepoStore, mockSubRepoPermsChecker, conf.DefaultClient())
+
+	definitions := []shared.UnreferencedDefinition{
+		{ID: 1, RepositoryID: 50, Repository: "github.com/test/visible", DocumentPath: "a.go", SymbolName: "a"},
+		{ID: 2, RepositoryID: 51, Repository: "github.com/test/hidden", DocumentPath: "b.go", SymbolName: "b"},
+		{ID: 3, RepositoryID: 50, Repository: "github.com/test/visible", DocumentPath: "secret/c.go", SymbolName: "c"},
+		{ID: 4, RepositoryID: 52, Repository: "github.com/test/generated-client", DocumentPath: "d.go", SymbolName: "d"},
+		{ID: 5, RepositoryID: 50, Repository: "github.com/test/visible", DocumentPath: "e.go", SymbolName: "e"},
+	}
+	mockStore.GetUnreferencedDefinitionsFunc.SetDefaultHook(func(_ context.Context, graphKey string, afterID, limit int) ([]shared.UnreferencedDefinition, error) {
+		if graphKey != "dev.123" {
+			t.Errorf("unexpected graph key %q", graphKey)
+		}
+
+		// Serve pages of three to exercise pagination
+		var page []shared.UnreferencedDefinition
+		for _, definition := range definitions {
+			if definition.ID > afterID && len(page) < 3 {
+				page = append(page, definition)
+			}
+		}
+		return page, nil
+	})
+	mockRepoStore.GetReposSetByIDsFunc.SetDefaultHook(func(_ context.Context, ids ...api.RepoID) (map[api.RepoID]*types.Repo, error) {
+		repos := map[api.RepoID]*types.Repo{}
+		for _, id := range ids {
+			switch id {
+			case 50:
+				repos[id] = &types.Repo{ID: id, Name: "github.com/test/visible"}
+			case 52:
+				repos[id] = &types.Repo{ID: id, Name: "github.com/test/generated-client"}
+			}
+		}
+		return repos, nil
+	})
+	mockSubRepoPermsChecker.EnabledFunc.SetDefaultReturn(true)
+	mockSubRepoPermsChecker.PermissionsFunc.SetDefaultHook(func(_ context.Context, _ int32, content authz.RepoContent) (authz.Perms, error) {
+		if content.Path == "secret/c.go" {
+			return authz.None, nil
+		}
+		return authz.Read, nil
+	})
+
+	var symbols []string
+	if err := svc.UnreferencedDefinitions(ctx, shared.DeadCodeReport{GraphKey: "dev.123"}, func(definition shared.UnreferencedDefinition) error {
+		symbols = append(symbols, definition.SymbolName)
+		return nil
+	}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if diff := cmp.Diff([]string{"a", "e"}, symbols); diff != "" {
+		t.Errorf("unexpected definitions (-want +got):\n%s", diff)
+	}
+
+	// Each repository is checked once
+	var repoIDs []api.RepoID
+	for _, call := range mockRepoStore.GetReposSetByIDsFunc.History() {
+		repoIDs = append(repoIDs, call.Arg1...)
+	}
+	if diff := cmp.Diff([]api.RepoID{50, 51, 52}, repoIDs); diff != "" {
+		t.Errorf("unexpected repository checks (-want +got):\n%s", diff)
+	}
+}
@@ -1,8 +1,10 @@
 package ranking
 
 import (
+	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/internal/background"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/internal/background/coordinator"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/internal/background/deadcode"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/internal/background/exporter"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/internal/background/janitor"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/internal/background/mapper"
@@ -25,6 +27,8 @@ func NewService(
 		scopedContext("service", observationCtx),
 		store.New(scopedContext("store", observationCtx), db),
 		lsifstore.New(scopedContext("lsifstore", observationCtx), codeIntelDB),
+		db.Repos(),
+		authz.DefaultSubRepoPermsChecker,
 		conf.DefaultClient(),
 	)
 }
@@ -35,6 +39,7 @@ var (
 	MapperConfigInst      = &mapper.Config{}
 	ReducerConfigInst     = &reducer.Config{}
 	JanitorConfigInst     = &janitor.Config{}
+	DeadCodeConfigInst    = &deadcode.Config{}
 )
 
 func NewSymbolExporter(observationCtx *observation.Context, rankingService *Service) goroutine.BackgroundRoutine {
@@ -78,6 +83,15 @@ func NewSymbolJanitor(observationCtx *observation.Context, rankingService *Servi
 	)
 }
 
+func NewDeadCodeReporter(observationCtx *observation.Context, rankingService *Service) goroutine.BackgroundRoutine {
+	return background.NewDeadCodeReporter(
+		scopedContext("deadcode", observationCtx),
+		rankingService.store,
+		rankingService.lsifstore,
+		DeadCodeConfigInst,
+	)
+}
+
 func scopedContext(component string, observationCtx *observation.Context) *observation.Context {
 	return observation.ScopedContext("codeintel", "ranking", component, observationCtx)
 }
@@ -0,0 +1,19 @@
+package deadcode
+
+import (
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/env"
+)
+
+type Config struct {
+	env.BaseConfig
+
+	Interval  time.Duration
+	BatchSize int
+}
+
+func (c *Config) Load() {
+	c.Interval = c.GetInterval("CODEINTEL_RANKING_DEAD_CODE_REPORTER_INTERVAL", "1s", "How frequently to run the ranking dead code reporter.")
+	c.BatchSize = c.GetInt("CODEINTEL_RANKING_DEAD_CODE_REPORTER_BATCH_SIZE", "16", "How many exported uploads to scan for unreferenced definitions at once.")
+}
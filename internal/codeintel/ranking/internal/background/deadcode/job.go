@@ -0,0 +1,175 @@
+package deadcode
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/sourcegraph/scip/bindings/go/scip"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/internal/lsifstore"
+	rankingshared "github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/internal/shared"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/internal/store"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/shared"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/shared/background"
+	uploadsshared "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func NewDeadCodeReporter(
+	observationCtx *observation.Context,
+	store store.Store,
+	lsifstore lsifstore.Store,
+	config *Config,
+) goroutine.BackgroundRoutine {
+	name := "codeintel.ranking.dead-code-reporter"
+
+	return background.NewPipelineJob(context.Background(), background.PipelineOptions{
+		Name:        name,
+		Description: "Records definitions that have no references in the most recently reduced ranking graph.",
+		Interval:    config.Interval,
+		Metrics:     background.NewPipelineMetrics(observationCtx, name),
+		ProcessFunc: func(ctx context.Context) (numRecordsProcessed int, numRecordsAltered background.TaggedCounts, err error) {
+			numUploadsScanned, numDefinitionsInserted, err := reportUnreferencedDefinitions(ctx, store, lsifstore, config.BatchSize)
+			if err != nil {
+				return 0, nil, err
+			}
+
+			return numUploadsScanned, background.NewSingleCount(numDefinitionsInserted), nil
+		},
+	})
+}
+
+func reportUnreferencedDefinitions(
+	ctx context.Context,
+	s store.Store,
+	lsifStore lsifstore.Store,
+	batchSize int,
+) (numUploads, numDefinitionsInserted int, _ error) {
+	if enabled := conf.CodeIntelRankingDocumentReferenceCountsEnabled(); !enabled {
+		return 0, 0, nil
+	}
+
+	derivativeGraphKeyPrefix, _, err := store.DerivativeGraphKey(ctx, s)
+	if err != nil {
+		return 0, 0, err
+	}
+	derivativeGraphKey := rankingshared.DerivativeGraphKeyFromPrefix(derivativeGraphKeyPrefix)
+
+	err = s.WithTransaction(ctx, func(tx store.Store) error {
+		// Only returns uploads once the reducer has completed for this graph key,
+		// as until then the set of counted definitions is incomplete.
+		uploads, err := tx.GetUploadsForDeadCodeReport(ctx, derivativeGraphKey, batchSize)
+		if err != nil {
+			return err
+		}
+		// assignment to outer scope
+		numUploads = len(uploads)
+
+		for _, upload := range uploads {
+			if rankingshared.ExcludedFromDeadCodeReport(upload.Repo) {
+				// Still marked as processed, so we won't look at this upload again
+				continue
+			}
+
+			checksumsByPath, err := tx.GetUnreferencedDefinitionChecksums(ctx, derivativeGraphKey, upload.ExportedUploadID)
+			if err != nil {
+				return err
+			}
+			if len(checksumsByPath) == 0 {
+				continue
+			}
+
+			definitions, err := resolveUnreferencedDefinitions(ctx, lsifStore, upload, checksumsByPath)
+			if err != nil {
+				return err
+			}
+
+			if err := tx.InsertUnreferencedDefinitions(ctx, derivativeGraphKey, definitions); err != nil {
+				return err
+			}
+
+			// assignment to outer scope
+			numDefinitionsInserted += len(definitions)
+		}
+
+		return nil
+	})
+
+	return numUploads, numDefinitionsInserted, err
+}
+
+// resolveUnreferencedDefinitions recovers the names of the symbols identified by the given
+// checksums (keyed by repository-relative document path). The ranking tables only store opaque
+// checksums, so we re-read the affected SCIP documents and canonicalize each definition in the
+// same way the exporter did.
+func resolveUnreferencedDefinitions(
+	ctx context.Context,
+	lsifStore lsifstore.Store,
+	upload uploadsshared.ExportedUpload,
+	checksumsByPath map[string][][16]byte,
+) ([]shared.UnreferencedDefinition, error) {
+	// The exporter records definitions at filepath.Join(root, path), but SCIP
+	// documents are stored relative to the root of the upload.
+	documentPathsByPath := make(map[string]string, len(checksumsByPath))
+	paths := make([]string, 0, len(checksumsByPath))
+	for documentPath := range checksumsByPath {
+		path, err := filepath.Rel(upload.Root, documentPath)
+		if err != nil || path == ".." || strings.HasPrefix(path, "../") {
+			continue
+		}
+
+		documentPathsByPath[path] = documentPath
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var definitions []shared.UnreferencedDefinition
+	if err := lsifStore.ScanDocumentsByPath(ctx, upload.UploadID, paths, func(path string, document *scip.Document) error {
+		documentPath := documentPathsByPath[path]
+
+		unreferenced := map[[16]byte]struct{}{}
+		for _, checksum := range checksumsByPath[documentPath] {
+			unreferenced[checksum] = struct{}{}
+		}
+
+		for _, occ := range document.Occurrences {
+			if !scip.SymbolRole_Definition.Matches(occ) {
+				continue
+			}
+			if !rankingshared.IsExportedSymbol(occ.Symbol) {
+				// Only report symbols that other packages could reference. Unused unexported
+				// symbols are better caught by compilers and linters.
+				continue
+			}
+
+			checksum, ok := rankingshared.CanonicalizeSymbol(occ.Symbol)
+			if !ok {
+				continue
+			}
+			if _, ok := unreferenced[checksum]; !ok {
+				continue
+			}
+
+			// Report each symbol only once per document
+			delete(unreferenced, checksum)
+
+			definitions = append(definitions, shared.UnreferencedDefinition{
+				RepositoryID: upload.RepoID,
+				Repository:   upload.Repo,
+				UploadID:     upload.UploadID,
+				DocumentPath: documentPath,
+				SymbolName:   occ.Symbol,
+			})
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return definitions, nil
+}
@@ -0,0 +1,172 @@
+package deadcode
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+
+	rankingshared "github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/internal/shared"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/internal/store"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/shared"
+	uploadsshared "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const (
+	exportedSymbol   = "scip-go gomod github.com/test/a v1.0 `github.com/test/a/sub`/Exported()."
+	unexportedSymbol = "scip-go gomod github.com/test/a v1.0 `github.com/test/a/sub`/unexported()."
+	referencedSymbol = "scip-go gomod github.com/test/a v1.0 `github.com/test/a/sub`/Referenced()."
+)
+
+func TestReportUnreferencedDefinitions(t *testing.T) {
+	enabled := true
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		CodeIntelRankingDocumentReferenceCountsEnabled:     &enabled,
+		CodeIntelRankingDeadCodeReportExcludedRepositories: []string{"github.com/test/generated-*"},
+	}})
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	mockStore := NewMockStore()
+	mockStore.WithTransactionFunc.SetDefaultHook(func(ctx context.Context, f func(tx store.Store) error) error { return f(mockStore) })
+	mockStore.DerivativeGraphKeyFunc.SetDefaultReturn("123", time.Now(), true, nil)
+	mockStore.GetUploadsForDeadCodeReportFunc.SetDefaultReturn([]uploadsshared.ExportedUpload{
+		{UploadID: 1, ExportedUploadID: 11, Repo: "github.com/test/a", RepoID: 50, Root: "sub/"},
+		{UploadID: 2, ExportedUploadID: 12, Repo: "github.com/test/generated-client", RepoID: 51, Root: ""},
+	}, nil)
+	mockStore.GetUnreferencedDefinitionChecksumsFunc.SetDefaultReturn(map[string][][16]byte{
+		"sub/a.go":   {checksum(t, exportedSymbol), checksum(t, unexportedSymbol)},
+		"other/b.go": {checksum(t, exportedSymbol)},
+	}, nil)
+
+	mockLsifStore := NewMockLsifStore()
+	mockLsifStore.ScanDocumentsByPathFunc.SetDefaultHook(func(_ context.Context, uploadID int, paths []string, f func(path string, document *scip.Document) error) error {
+		if uploadID != 1 {
+			t.Errorf("unexpected upload id. want=%d have=%d", 1, uploadID)
+		}
+		if diff := cmp.Diff([]string{"a.go"}, paths); diff != "" {
+			t.Errorf("unexpected paths (-want +got):\n%s", diff)
+		}
+
+		return f("a.go", &scip.Document{
+			RelativePath: "a.go",
+			Occurrences: []*scip.Occurrence{
+				{Symbol: exportedSymbol, SymbolRoles: int32(scip.SymbolRole_Definition)},
+				{Symbol: exportedSymbol, SymbolRoles: int32(scip.SymbolRole_Definition)},
+				{Symbol: unexportedSymbol, SymbolRoles: int32(scip.SymbolRole_Definition)},
+				{Symbol: referencedSymbol, SymbolRoles: int32(scip.SymbolRole_Definition)},
+				{Symbol: exportedSymbol},
+			},
+		})
+	})
+
+	numUploads, numDefinitions, err := reportUnreferencedDefinitions(context.Background(), mockStore, mockLsifStore, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if numUploads != 2 {
+		t.Errorf("unexpected number of uploads. want=%d have=%d", 2, numUploads)
+	}
+	if numDefinitions != 1 {
+		t.Errorf("unexpected number of definitions. want=%d have=%d", 1, numDefinitions)
+	}
+
+	derivativeGraphKey := rankingshared.DerivativeGraphKeyFromPrefix("123")
+
+	// The excluded repository is not scanned
+	if calls := mockStore.GetUnreferencedDefinitionChecksumsFunc.History(); len(calls) != 1 {
+		t.Fatalf("unexpected number of calls to GetUnreferencedDefinitionChecksums. want=%d have=%d", 1, len(calls))
+	} else if calls[0].Arg1 != derivativeGraphKey || calls[0].Arg2 != 11 {
+		t.Errorf("unexpected arguments to GetUnreferencedDefinitionChecksums: %v", calls[0].Args())
+	}
+
+	calls := mockStore.InsertUnreferencedDefinitionsFunc.History()
+	if len(calls) != 1 {
+		t.Fatalf("unexpected number of calls to InsertUnreferencedDefinitions. want=%d have=%d", 1, len(calls))
+	}
+	if calls[0].Arg1 != derivativeGraphKey {
+		t.Errorf("unexpected graph key. want=%q have=%q", derivativeGraphKey, calls[0].Arg1)
+	}
+	expectedDefinitions := []shared.UnreferencedDefinition{
+		{RepositoryID: 50, Repository: "github.com/test/a", UploadID: 1, DocumentPath: "sub/a.go", SymbolName: exportedSymbol},
+	}
+	if diff := cmp.Diff(expectedDefinitions, calls[0].Arg2); diff != "" {
+		t.Errorf("unexpected definitions (-want +got):\n%s", diff)
+	}
+}
+
+func TestReportUnreferencedDefinitionsDisabled(t *testing.T) {
+	enabled := false
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		CodeIntelRankingDocumentReferenceCountsEnabled: &enabled,
+	}})
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	mockStore := NewStrictMockStore()
+	mockLsifStore := NewStrictMockLsifStore()
+
+	numUploads, numDefinitions, err := reportUnreferencedDefinitions(context.Background(), mockStore, mockLsifStore, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if numUploads != 0 || numDefinitions != 0 {
+		t.Errorf("unexpected counts. want=(0, 0) have=(%d, %d)", numUploads, numDefinitions)
+	}
+}
+
+func TestResolveUnreferencedDefinitions(t *testing.T) {
+	upload := uploadsshared.ExportedUpload{UploadID: 1, ExportedUploadID: 11, Repo: "github.com/test/a", RepoID: 50}
+
+	mockLsifStore := NewMockLsifStore()
+	mockLsifStore.ScanDocumentsByPathFunc.SetDefaultHook(func(_ context.Context, _ int, paths []string, f func(path string, document *scip.Document) error) error {
+		for _, path := range paths {
+			if err := f(path, &scip.Document{
+				RelativePath: path,
+				Occurrences: []*scip.Occurrence{
+					{Symbol: exportedSymbol, SymbolRoles: int32(scip.SymbolRole_Definition)},
+					{Symbol: referencedSymbol, SymbolRoles: int32(scip.SymbolRole_Definition)},
+				},
+			}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	definitions, err := resolveUnreferencedDefinitions(context.Background(), mockLsifStore, upload, map[string][][16]byte{
+		"b.go": {checksum(t, exportedSymbol), checksum(t, referencedSymbol)},
+		"a.go": {checksum(t, exportedSymbol)},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var documentSymbols []string
+	for _, definition := range definitions {
+		documentSymbols = append(documentSymbols, definition.DocumentPath+" "+definition.SymbolName)
+	}
+	sort.Strings(documentSymbols)
+
+	expected := []string{
+		"a.go " + exportedSymbol,
+		"b.go " + exportedSymbol,
+		"b.go " + referencedSymbol,
+	}
+	if diff := cmp.Diff(expected, documentSymbols); diff != "" {
+		t.Errorf("unexpected definitions (-want +got):\n%s", diff)
+	}
+}
+
+func checksum(t *testing.T, symbol string) [16]byte {
+	checksum, ok := rankingshared.CanonicalizeSymbol(symbol)
+	if !ok {
+		t.Fatalf("failed to canonicalize symbol %q", symbol)
+	}
+
+	return checksum
+}
@@ -0,0 +1,4415 @@
+// Code generated by go-mockgen 1.3.7; DO NOT EDIT.
+//
+// This file was generated by running `sg generate` (or `go-mockgen`) at the root of
+// this repository. To add additional mocks to this or another package, add a new entry
+// to the mockgen.yaml file in the root of this repository.
+
+package deadcode
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	scip "github.com/sourcegraph/scip/bindings/go/scip"
+	api "github.com/sourcegraph/sourcegraph/internal/api"
+	lsifstore "github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/internal/lsifstore"
+	store "github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/internal/store"
+	shared "github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/shared"
+	shared1 "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+)
+
+// MockStore is a mock implementation of the Store interface (from the
+// package
+// github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/internal/store)
+// used for unit testing.
+type MockStore struct {
+	// BumpDerivativeGraphKeyFunc is an instance of a mock function object
+	// controlling the behavior of the method BumpDerivativeGraphKey.
+	BumpDerivativeGraphKeyFunc *StoreBumpDerivativeGraphKeyFunc
+	// CoordinateFunc is an instance of a mock function object controlling
+	// the behavior of the method Coordinate.
+	CoordinateFunc *StoreCoordinateFunc
+	// CoverageCountsFunc is an instance of a mock function object
+	// controlling the behavior of the method CoverageCounts.
+	CoverageCountsFunc *StoreCoverageCountsFunc
+	// DeadCodeReportGraphKeyFunc is an instance of a mock function object
+	// controlling the behavior of the method DeadCodeReportGraphKey.
+	DeadCodeReportGraphKeyFunc *StoreDeadCodeReportGraphKeyFunc
+	// DeleteRankingProgressFunc is an instance of a mock function object
+	// controlling the behavior of the method DeleteRankingProgress.
+	DeleteRankingProgressFunc *StoreDeleteRankingProgressFunc
+	// DerivativeGraphKeyFunc is an instance of a mock function object
+	// controlling the behavior of the method DerivativeGraphKey.
+	DerivativeGraphKeyFunc *StoreDerivativeGraphKeyFunc
+	// GetDocumentRanksFunc is an instance of a mock function object
+	// controlling the behavior of the method GetDocumentRanks.
+	GetDocumentRanksFunc *StoreGetDocumentRanksFunc
+	// GetReferenceCountStatisticsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// GetReferenceCountStatistics.
+	GetReferenceCountStatisticsFunc *StoreGetReferenceCountStatisticsFunc
+	// GetStarRankFunc is an instance of a mock function object controlling
+	// the behavior of the method GetStarRank.
+	GetStarRankFunc *StoreGetStarRankFunc
+	// GetUnreferencedDefinitionChecksumsFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// GetUnreferencedDefinitionChecksums.
+	GetUnreferencedDefinitionChecksumsFunc *StoreGetUnreferencedDefinitionChecksumsFunc
+	// GetUnreferencedDefinitionsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// GetUnreferencedDefinitions.
+	GetUnreferencedDefinitionsFunc *StoreGetUnreferencedDefinitionsFunc
+	// GetUploadsForDeadCodeReportFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// GetUploadsForDeadCodeReport.
+	GetUploadsForDeadCodeReportFunc *StoreGetUploadsForDeadCodeReportFunc
+	// GetUploadsForRankingFunc is an instance of a mock function object
+	// controlling the behavior of the method GetUploadsForRanking.
+	GetUploadsForRankingFunc *StoreGetUploadsForRankingFunc
+	// InsertDefinitionsForRankingFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// InsertDefinitionsForRanking.
+	InsertDefinitionsForRankingFunc *StoreInsertDefinitionsForRankingFunc
+	// InsertInitialPathCountsFunc is an instance of a mock function object
+	// controlling the behavior of the method InsertInitialPathCounts.
+	InsertInitialPathCountsFunc *StoreInsertInitialPathCountsFunc
+	// InsertInitialPathRanksFunc is an instance of a mock function object
+	// controlling the behavior of the method InsertInitialPathRanks.
+	InsertInitialPathRanksFunc *StoreInsertInitialPathRanksFunc
+	// InsertPathCountInputsFunc is an instance of a mock function object
+	// controlling the behavior of the method InsertPathCountInputs.
+	InsertPathCountInputsFunc *StoreInsertPathCountInputsFunc
+	// InsertPathRanksFunc is an instance of a mock function object
+	// controlling the behavior of the method InsertPathRanks.
+	InsertPathRanksFunc *StoreInsertPathRanksFunc
+	// InsertReferencesForRankingFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// InsertReferencesForRanking.
+	InsertReferencesForRankingFunc *StoreInsertReferencesForRankingFunc
+	// InsertUnreferencedDefinitionsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// InsertUnreferencedDefinitions.
+	InsertUnreferencedDefinitionsFunc *StoreInsertUnreferencedDefinitionsFunc
+	// LastUpdatedAtFunc is an instance of a mock function object
+	// controlling the behavior of the method LastUpdatedAt.
+	LastUpdatedAtFunc *StoreLastUpdatedAtFunc
+	// SoftDeleteStaleExportedUploadsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// SoftDeleteStaleExportedUploads.
+	SoftDeleteStaleExportedUploadsFunc *StoreSoftDeleteStaleExportedUploadsFunc
+	// SummariesFunc is an instance of a mock function object controlling
+	// the behavior of the method Summaries.
+	SummariesFunc *StoreSummariesFunc
+	// VacuumAbandonedExportedUploadsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// VacuumAbandonedExportedUploads.
+	VacuumAbandonedExportedUploadsFunc *StoreVacuumAbandonedExportedUploadsFunc
+	// VacuumDeletedExportedUploadsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// VacuumDeletedExportedUploads.
+	VacuumDeletedExportedUploadsFunc *StoreVacuumDeletedExportedUploadsFunc
+	// VacuumStaleDeadCodeReportsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// VacuumStaleDeadCodeReports.
+	VacuumStaleDeadCodeReportsFunc *StoreVacuumStaleDeadCodeReportsFunc
+	// VacuumStaleGraphsFunc is an instance of a mock function object
+	// controlling the behavior of the method VacuumStaleGraphs.
+	VacuumStaleGraphsFunc *StoreVacuumStaleGraphsFunc
+	// VacuumStaleProcessedPathsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// VacuumStaleProcessedPaths.
+	VacuumStaleProcessedPathsFunc *StoreVacuumStaleProcessedPathsFunc
+	// VacuumStaleProcessedReferencesFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// VacuumStaleProcessedReferences.
+	VacuumStaleProcessedReferencesFunc *StoreVacuumStaleProcessedReferencesFunc
+	// VacuumStaleRanksFunc is an instance of a mock function object
+	// controlling the behavior of the method VacuumStaleRanks.
+	VacuumStaleRanksFunc *StoreVacuumStaleRanksFunc
+	// WithTransactionFunc is an instance of a mock function object
+	// controlling the behavior of the method WithTransaction.
+	WithTransactionFunc *StoreWithTransactionFunc
+}
+
+// NewMockStore creates a new mock of the Store interface. All methods
+// return zero values for all results, unless overwritten.
+func NewMockStore() *MockStore {
+	return &MockStore{
+		BumpDerivativeGraphKeyFunc: &StoreBumpDerivativeGraphKeyFunc{
+			defaultHook: func(context.Context) (r0 error) {
+				return
+			},
+		},
+		CoordinateFunc: &StoreCoordinateFunc{
+			defaultHook: func(context.Context, string) (r0 error) {
+				return
+			},
+		},
+		CoverageCountsFunc: &StoreCoverageCountsFunc{
+			defaultHook: func(context.Context, string) (r0 shared.CoverageCounts, r1 error) {
+				return
+			},
+		},
+		DeadCodeReportGraphKeyFunc: &StoreDeadCodeReportGraphKeyFunc{
+			defaultHook: func(context.Context) (r0 string, r1 time.Time, r2 bool, r3 error) {
+				return
+			},
+		},
+		DeleteRankingProgressFunc: &StoreDeleteRankingProgressFunc{
+			defaultHook: func(context.Context, string) (r0 error) {
+				return
+			},
+		},
+		DerivativeGraphKeyFunc: &StoreDerivativeGraphKeyFunc{
+			defaultHook: func(context.Context) (r0 string, r1 time.Time, r2 bool, r3 error) {
+				return
+			},
+		},
+		GetDocumentRanksFunc: &StoreGetDocumentRanksFunc{
+			defaultHook: func(context.Context, api.RepoName) (r0 map[string]float64, r1 bool, r2 error) {
+				return
+			},
+		},
+		GetReferenceCountStatisticsFunc: &StoreGetReferenceCountStatisticsFunc{
+			defaultHook: func(context.Context) (r0 float64, r1 error) {
+				return
+			},
+		},
+		GetStarRankFunc: &StoreGetStarRankFunc{
+			defaultHook: func(context.Context, api.RepoName) (r0 float64, r1 error) {
+				return
+			},
+		},
+		GetUnreferencedDefinitionChecksumsFunc: &StoreGetUnreferencedDefinitionChecksumsFunc{
+			defaultHook: func(context.Context, string, int) (r0 map[string][][16]byte, r1 error) {
+				return
+			},
+		},
+		GetUnreferencedDefinitionsFunc: &StoreGetUnreferencedDefinitionsFunc{
+			defaultHook: func(context.Context, string, int, int) (r0 []shared.UnreferencedDefinition, r1 error) {
+				return
+			},
+		},
+		GetUploadsForDeadCodeReportFunc: &StoreGetUploadsForDeadCodeReportFunc{
+			defaultHook: func(context.Context, string, int) (r0 []shared1.ExportedUpload, r1 error) {
+				return
+			},
+		},
+		GetUploadsForRankingFunc: &StoreGetUploadsForRankingFunc{
+			defaultHook: func(context.Context, string, string, int) (r0 []shared1.ExportedUpload, r1 error) {
+				return
+			},
+		},
+		InsertDefinitionsForRankingFunc: &StoreInsertDefinitionsForRankingFunc{
+			defaultHook: func(context.Context, string, chan shared.RankingDefinitions) (r0 error) {
+				return
+			},
+		},
+		InsertInitialPathCountsFunc: &StoreInsertInitialPathCountsFunc{
+			defaultHook: func(context.Context, string, int) (r0 int, r1 int, r2 error) {
+				return
+			},
+		},
+		InsertInitialPathRanksFunc: &StoreInsertInitialPathRanksFunc{
+			defaultHook: func(context.Context, int, []string, int, string) (r0 error) {
+				return
+			},
+		},
+		InsertPathCountInputsFunc: &StoreInsertPathCountInputsFunc{
+			defaultHook: func(context.Context, string, int) (r0 int, r1 int, r2 error) {
+				return
+			},
+		},
+		InsertPathRanksFunc: &StoreInsertPathRanksFunc{
+			defaultHook: func(context.Context, string, int) (r0 int, r1 int, r2 error) {
+				return
+			},
+		},
+		InsertReferencesForRankingFunc: &StoreInsertReferencesForRankingFunc{
+			defaultHook: func(context.Context, string, int, int, chan [16]byte) (r0 error) {
+				return
+			},
+		},
+		InsertUnreferencedDefinitionsFunc: &StoreInsertUnreferencedDefinitionsFunc{
+			defaultHook: func(context.Context, string, []shared.UnreferencedDefinition) (r0 error) {
+				return
+			},
+		},
+		LastUpdatedAtFunc: &StoreLastUpdatedAtFunc{
+			defaultHook: func(context.Context, []api.RepoID) (r0 map[api.RepoID]time.Time, r1 error) {
+				return
+			},
+		},
+		SoftDeleteStaleExportedUploadsFunc: &StoreSoftDeleteStaleExportedUploadsFunc{
+			defaultHook: func(context.Context, string) (r0 int, r1 int, r2 error) {
+				return
+			},
+		},
+		SummariesFunc: &StoreSummariesFunc{
+			defaultHook: func(context.Context) (r0 []shared.Summary, r1 error) {
+				return
+			},
+		},
+		VacuumAbandonedExportedUploadsFunc: &StoreVacuumAbandonedExportedUploadsFunc{
+			defaultHook: func(context.Context, string, int) (r0 int, r1 error) {
+				return
+			},
+		},
+		VacuumDeletedExportedUploadsFunc: &StoreVacuumDeletedExportedUploadsFunc{
+			defaultHook: func(context.Context, string) (r0 int, r1 error) {
+				return
+			},
+		},
+		VacuumStaleDeadCodeReportsFunc: &StoreVacuumStaleDeadCodeReportsFunc{
+			defaultHook: func(context.Context, string, int) (r0 int, r1 error) {
+				return
+			},
+		},
+		VacuumStaleGraphsFunc: &StoreVacuumStaleGraphsFunc{
+			defaultHook: func(context.Context, string, int) (r0 int, r1 error) {
+				return
+			},
+		},
+		VacuumStaleProcessedPathsFunc: &StoreVacuumStaleProcessedPathsFunc{
+			defaultHook: func(context.Context, string, int) (r0 int, r1 error) {
+				return
+			},
+		},
+		VacuumStaleProcessedReferencesFunc: &StoreVacuumStaleProcessedReferencesFunc{
+			defaultHook: func(context.Context, string, int) (r0 int, r1 error) {
+				return
+			},
+		},
+		VacuumStaleRanksFunc: &StoreVacuumStaleRanksFunc{
+			defaultHook: func(context.Context, string) (r0 int, r1 int, r2 error) {
+				return
+			},
+		},
+		WithTransactionFunc: &StoreWithTransactionFunc{
+			defaultHook: func(context.Context, func(tx store.Store) error) (r0 error) {
+				return
+			},
+		},
+	}
+}
+
+// NewStrictMockStore creates a new mock of the Store interface. All methods
+// panic on invocation, unless overwritten.
+func NewStrictMockStore() *MockStore {
+	return &MockStore{
+		BumpDerivativeGraphKeyFunc: &StoreBumpDerivativeGraphKeyFunc{
+			defaultHook: func(context.Context) error {
+				panic("unexpected invocation of MockStore.BumpDerivativeGraphKey")
+			},
+		},
+		CoordinateFunc: &StoreCoordinateFunc{
+			defaultHook: func(context.Context, string) error {
+				panic("unexpected invocation of MockStore.Coordinate")
+			},
+		},
+		CoverageCountsFunc: &StoreCoverageCountsFunc{
+			defaultHook: func(context.Context, string) (shared.CoverageCounts, error) {
+				panic("unexpected invocation of MockStore.CoverageCounts")
+			},
+		},
+		DeadCodeReportGraphKeyFunc: &StoreDeadCodeReportGraphKeyFunc{
+			defaultHook: func(context.Context) (string, time.Time, bool, error) {
+				panic("unexpected invocation of MockStore.DeadCodeReportGraphKey")
+			},
+		},
+		DeleteRankingProgressFunc: &StoreDeleteRankingProgressFunc{
+			defaultHook: func(context.Context, string) error {
+				panic("unexpected invocation of MockStore.DeleteRankingProgress")
+			},
+		},
+		DerivativeGraphKeyFunc: &StoreDerivativeGraphKeyFunc{
+			defaultHook: func(context.Context) (string, time.Time, bool, error) {
+				panic("unexpected invocation of MockStore.DerivativeGraphKey")
+			},
+		},
+		GetDocumentRanksFunc: &StoreGetDocumentRanksFunc{
+			defaultHook: func(context.Context, api.RepoName) (map[string]float64, bool, error) {
+				panic("unexpected invocation of MockStore.GetDocumentRanks")
+			},
+		},
+		GetReferenceCountStatisticsFunc: &StoreGetReferenceCountStatisticsFunc{
+			defaultHook: func(context.Context) (float64, error) {
+				panic("unexpected invocation of MockStore.GetReferenceCountStatistics")
+			},
+		},
+		GetStarRankFunc: &StoreGetStarRankFunc{
+			defaultHook: func(context.Context, api.RepoName) (float64, error) {
+				panic("unexpected invocation of MockStore.GetStarRank")
+			},
+		},
+		GetUnreferencedDefinitionChecksumsFunc: &StoreGetUnreferencedDefinitionChecksumsFunc{
+			defaultHook: func(context.Context, string, int) (map[string][][16]byte, error) {
+				panic("unexpected invocation of MockStore.GetUnreferencedDefinitionChecksums")
+			},
+		},
+		GetUnreferencedDefinitionsFunc: &StoreGetUnreferencedDefinitionsFunc{
+			defaultHook: func(context.Context, string, int, int) ([]shared.UnreferencedDefinition, error) {
+				panic("unexpected invocation of MockStore.GetUnreferencedDefinitions")
+			},
+		},
+		GetUploadsForDeadCodeReportFunc: &StoreGetUploadsForDeadCodeReportFunc{
+			defaultHook: func(context.Context, string, int) ([]shared1.ExportedUpload, error) {
+				panic("unexpected invocation of MockStore.GetUploadsForDeadCodeReport")
+			},
+		},
+		GetUploadsForRankingFunc: &StoreGetUploadsForRankingFunc{
+			defaultHook: func(context.Context, string, string, int) ([]shared1.ExportedUpload, error) {
+				panic("unexpected invocation of MockStore.GetUploadsForRanking")
+			},
+		},
+		InsertDefinitionsForRankingFunc: &StoreInsertDefinitionsForRankingFunc{
+			defaultHook: func(context.Context, string, chan shared.RankingDefinitions) error {
+				panic("unexpected invocation of MockStore.InsertDefinitionsForRanking")
+			},
+		},
+		InsertInitialPathCountsFunc: &StoreInsertInitialPathCountsFunc{
+			defaultHook: func(context.Context, string, int) (int, int, error) {
+				panic("unexpected invocation of MockStore.InsertInitialPathCounts")
+			},
+		},
+		InsertInitialPathRanksFunc: &StoreInsertInitialPathRanksFunc{
+			defaultHook: func(context.Context, int, []string, int, string) error {
+				panic("unexpected invocation of MockStore.InsertInitialPathRanks")
+			},
+		},
+		InsertPathCountInputsFunc: &StoreInsertPathCountInputsFunc{
+			defaultHook: func(context.Context, string, int) (int, int, error) {
+				panic("unexpected invocation of MockStore.InsertPathCountInputs")
+			},
+		},
+		InsertPathRanksFunc: &StoreInsertPathRanksFunc{
+			defaultHook: func(context.Context, string, int) (int, int, error) {
+				panic("unexpected invocation of MockStore.InsertPathRanks")
+			},
+		},
+		InsertReferencesForRankingFunc: &StoreInsertReferencesForRankingFunc{
+			defaultHook: func(context.Context, string, int, int, chan [16]byte) error {
+				panic("unexpected invocation of MockStore.InsertReferencesForRanking")
+			},
+		},
+		InsertUnreferencedDefinitionsFunc: &StoreInsertUnreferencedDefinitionsFunc{
+			defaultHook: func(context.Context, string, []shared.UnreferencedDefinition) error {
+				panic("unexpected invocation of MockStore.InsertUnreferencedDefinitions")
+			},
+		},
+		LastUpdatedAtFunc: &StoreLastUpdatedAtFunc{
+			defaultHook: func(context.Context, []api.RepoID) (map[api.RepoID]time.Time, error) {
+				panic("unexpected invocation of MockStore.LastUpdatedAt")
+			},
+		},
+		SoftDeleteStaleExportedUploadsFunc: &StoreSoftDeleteStaleExportedUploadsFunc{
+			defaultHook: func(context.Context, string) (int, int, error) {
+				panic("unexpected invocation of MockStore.SoftDeleteStaleExportedUploads")
+			},
+		},
+		SummariesFunc: &StoreSummariesFunc{
+			defaultHook: func(context.Context) ([]shared.Summary, error) {
+				panic("unexpected invocation of MockStore.Summaries")
+			},
+		},
+		VacuumAbandonedExportedUploadsFunc: &StoreVacuumAbandonedExportedUploadsFunc{
+			defaultHook: func(context.Context, string, int) (int, error) {
+				panic("unexpected invocation of MockStore.VacuumAbandonedExportedUploads")
+			},
+		},
+		VacuumDeletedExportedUploadsFunc: &StoreVacuumDeletedExportedUploadsFunc{
+			defaultHook: func(context.Context, string) (int, error) {
+				panic("unexpected invocation of MockStore.VacuumDeletedExportedUploads")
+			},
+		},
+		VacuumStaleDeadCodeReportsFunc: &StoreVacuumStaleDeadCodeReportsFunc{
+			defaultHook: func(context.Context, string, int) (int, error) {
+				panic("unexpected invocation of MockStore.VacuumStaleDeadCodeReports")
+			},
+		},
+		VacuumStaleGraphsFunc: &StoreVacuumStaleGraphsFunc{
+			defaultHook: func(context.Context, string, int) (int, error) {
+				panic("unexpected invocation of MockStore.VacuumStaleGraphs")
+			},
+		},
+		VacuumStaleProcessedPathsFunc: &StoreVacuumStaleProcessedPathsFunc{
+			defaultHook: func(context.Context, string, int) (int, error) {
+				panic("unexpected invocation of MockStore.VacuumStaleProcessedPaths")
+			},
+		},
+		VacuumStaleProcessedReferencesFunc: &StoreVacuumStaleProcessedReferencesFunc{
+			defaultHook: func(context.Context, string, int) (int, error) {
+				panic("unexpected invocation of MockStore.VacuumStaleProcessedReferences")
+			},
+		},
+		VacuumStaleRanksFunc: &StoreVacuumStaleRanksFunc{
+			defaultHook: func(context.Context, string) (int, int, error) {
+				panic("unexpected invocation of MockStore.VacuumStaleRanks")
+			},
+		},
+		WithTransactionFunc: &StoreWithTransactionFunc{
+			defaultHook: func(context.Context, func(tx store.Store) error) error {
+				panic("unexpected invocation of MockStore.WithTransaction")
+			},
+		},
+	}
+}
+
+// NewMockStoreFrom creates a new mock of the MockStore interface. All
+// methods delegate to the given implementation, unless overwritten.
+func NewMockStoreFrom(i store.Store) *MockStore {
+	return &MockStore{
+		BumpDerivativeGraphKeyFunc: &StoreBumpDerivativeGraphKeyFunc{
+			defaultHook: i.BumpDerivativeGraphKey,
+		},
+		CoordinateFunc: &StoreCoordinateFunc{
+			defaultHook: i.Coordinate,
+		},
+		CoverageCountsFunc: &StoreCoverageCountsFunc{
+			defaultHook: i.CoverageCounts,
+		},
+		DeadCodeReportGraphKeyFunc: &StoreDeadCodeReportGraphKeyFunc{
+			defaultHook: i.DeadCodeReportGraphKey,
+		},
+		DeleteRankingProgressFunc: &StoreDeleteRankingProgressFunc{
+			defaultHook: i.DeleteRankingProgress,
+		},
+		DerivativeGraphKeyFunc: &StoreDerivativeGraphKeyFunc{
+			defaultHook: i.DerivativeGraphKey,
+		},
+		GetDocumentRanksFunc: &StoreGetDocumentRanksFunc{
+			defaultHook: i.GetDocumentRanks,
+		},
+		GetReferenceCountStatisticsFunc: &StoreGetReferenceCountStatisticsFunc{
+			defaultHook: i.GetReferenceCountStatistics,
+		},
+		GetStarRankFunc: &StoreGetStarRankFunc{
+			defaultHook: i.GetStarRank,
+		},
+		GetUnreferencedDefinitionChecksumsFunc: &StoreGetUnreferencedDefinitionChecksumsFunc{
+			defaultHook: i.GetUnreferencedDefinitionChecksums,
+		},
+		GetUnreferencedDefinitionsFunc: &StoreGetUnreferencedDefinitionsFunc{
+			defaultHook: i.GetUnreferencedDefinitions,
+		},
+		GetUploadsForDeadCodeReportFunc: &StoreGetUploadsForDeadCodeReportFunc{
+			defaultHook: i.GetUploadsForDeadCodeReport,
+		},
+		GetUploadsForRankingFunc: &StoreGetUploadsForRankingFunc{
+			defaultHook: i.GetUploadsForRanking,
+		},
+		InsertDefinitionsForRankingFunc: &StoreInsertDefinitionsForRankingFunc{
+			defaultHook: i.InsertDefinitionsForRanking,
+		},
+		InsertInitialPathCountsFunc: &StoreInsertInitialPathCountsFunc{
+			defaultHook: i.InsertInitialPathCounts,
+		},
+		InsertInitialPathRanksFunc: &StoreInsertInitialPathRanksFunc{
+			defaultHook: i.InsertInitialPathRanks,
+		},
+		InsertPathCountInputsFunc: &StoreInsertPathCountInputsFunc{
+			defaultHook: i.InsertPathCountInputs,
+		},
+		InsertPathRanksFunc: &StoreInsertPathRanksFunc{
+			defaultHook: i.InsertPathRanks,
+		},
+		InsertReferencesForRankingFunc: &StoreInsertReferencesForRankingFunc{
+			defaultHook: i.InsertReferencesForRanking,
+		},
+		InsertUnreferencedDefinitionsFunc: &StoreInsertUnreferencedDefinitionsFunc{
+			defaultHook: i.InsertUnreferencedDefinitions,
+		},
+		LastUpdatedAtFunc: &StoreLastUpdatedAtFunc{
+			defaultHook: i.LastUpdatedAt,
+		},
+		SoftDeleteStaleExportedUploadsFunc: &StoreSoftDeleteStaleExportedUploadsFunc{
+			defaultHook: i.SoftDeleteStaleExportedUploads,
+		},
+		SummariesFunc: &StoreSummariesFunc{
+			defaultHook: i.Summaries,
+		},
+		VacuumAbandonedExportedUploadsFunc: &StoreVacuumAbandonedExportedUploadsFunc{
+			defaultHook: i.VacuumAbandonedExportedUploads,
+		},
+		VacuumDeletedExportedUploadsFunc: &StoreVacuumDeletedExportedUploadsFunc{
+			defaultHook: i.VacuumDeletedExportedUploads,
+		},
+		VacuumStaleDeadCodeReportsFunc: &StoreVacuumStaleDeadCodeReportsFunc{
+			defaultHook: i.VacuumStaleDeadCodeReports,
+		},
+		VacuumStaleGraphsFunc: &StoreVacuumStaleGraphsFunc{
+			defaultHook: i.VacuumStaleGraphs,
+		},
+		VacuumStaleProcessedPathsFunc: &StoreVacuumStaleProcessedPathsFunc{
+			defaultHook: i.VacuumStaleProcessedPaths,
+		},
+		VacuumStaleProcessedReferencesFunc: &StoreVacuumStaleProcessedReferencesFunc{
+			defaultHook: i.VacuumStaleProcessedReferences,
+		},
+		VacuumStaleRanksFunc: &StoreVacuumStaleRanksFunc{
+			defaultHook: i.VacuumStaleRanks,
+		},
+		WithTransactionFunc: &StoreWithTransactionFunc{
+			defaultHook: i.WithTransaction,
+		},
+	}
+}
+
+// StoreBumpDerivativeGraphKeyFunc describes the behavior when the
+// BumpDerivativeGraphKey method of the parent MockStore instance is
+// invoked.
+type StoreBumpDerivativeGraphKeyFunc struct {
+	defaultHook func(context.Context) error
+	hooks       []func(context.Context) error
+	history     []StoreBumpDerivativeGraphKeyFuncCall
+	mutex       sync.Mutex
+}
+
+// BumpDerivativeGraphKey delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockStore) BumpDerivativeGraphKey(v0 context.Context) error {
+	r0 := m.BumpDerivativeGraphKeyFunc.nextHook()(v0)
+	m.BumpDerivativeGraphKeyFunc.appendCall(StoreBumpDerivativeGraphKeyFuncCall{v0, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// BumpDerivativeGraphKey method of the parent MockStore instance is invoked
+// and the hook queue is empty.
+func (f *StoreBumpDerivativeGraphKeyFunc) SetDefaultHook(hook func(context.Context) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// BumpDerivativeGraphKey method of the parent MockStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *StoreBumpDerivativeGraphKeyFunc) PushHook(hook func(context.Context) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreBumpDerivativeGraphKeyFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreBumpDerivativeGraphKeyFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context) error {
+		return r0
+	})
+}
+
+func (f *StoreBumpDerivativeGraphKeyFunc) nextHook() func(context.Context) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreBumpDerivativeGraphKeyFunc) appendCall(r0 StoreBumpDerivativeGraphKeyFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreBumpDerivativeGraphKeyFuncCall objects
+// describing the invocations of this function.
+func (f *StoreBumpDerivativeGraphKeyFunc) History() []StoreBumpDerivativeGraphKeyFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreBumpDerivativeGraphKeyFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreBumpDerivativeGraphKeyFuncCall is an object that describes an
+// invocation of method BumpDerivativeGraphKey on an instance of MockStore.
+type StoreBumpDerivativeGraphKeyFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreBumpDerivativeGraphKeyFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreBumpDerivativeGraphKeyFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// StoreCoordinateFunc describes the behavior when the Coordinate method of
+// the parent MockStore instance is invoked.
+type StoreCoordinateFunc struct {
+	defaultHook func(context.Context, string) error
+	hooks       []func(context.Context, string) error
+	history     []StoreCoordinateFuncCall
+	mutex       sync.Mutex
+}
+
+// Coordinate delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockStore) Coordinate(v0 context.Context, v1 string) error {
+	r0 := m.CoordinateFunc.nextHook()(v0, v1)
+	m.CoordinateFunc.appendCall(StoreCoordinateFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Coordinate method of
+// the parent MockStore instance is invoked and the hook queue is empty.
+func (f *StoreCoordinateFunc) SetDefaultHook(hook func(context.Context, string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Coordinate method of the parent MockStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *StoreCoordinateFunc) PushHook(hook func(context.Context, string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreCoordinateFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreCoordinateFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, string) error {
+		return r0
+	})
+}
+
+func (f *StoreCoordinateFunc) nextHook() func(context.Context, string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreCoordinateFunc) appendCall(r0 StoreCoordinateFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreCoordinateFuncCall objects describing
+// the invocations of this function.
+func (f *StoreCoordinateFunc) History() []StoreCoordinateFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreCoordinateFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreCoordinateFuncCall is an object that describes an invocation of
+// method Coordinate on an instance of MockStore.
+type StoreCoordinateFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreCoordinateFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreCoordinateFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// StoreCoverageCountsFunc describes the behavior when the CoverageCounts
+// method of the parent MockStore instance is invoked.
+type StoreCoverageCountsFunc struct {
+	defaultHook func(context.Context, string) (shared.CoverageCounts, error)
+	hooks       []func(context.Context, string) (shared.CoverageCounts, error)
+	history     []StoreCoverageCountsFuncCall
+	mutex       sync.Mutex
+}
+
+// CoverageCounts delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockStore) CoverageCounts(v0 context.Context, v1 string) (shared.CoverageCounts, error) {
+	r0, r1 := m.CoverageCountsFunc.nextHook()(v0, v1)
+	m.CoverageCountsFunc.appendCall(StoreCoverageCountsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the CoverageCounts
+// method of the parent MockStore instance is invoked and the hook queue is
+// empty.
+func (f *StoreCoverageCountsFunc) SetDefaultHook(hook func(context.Context, string) (shared.CoverageCounts, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CoverageCounts method of the parent MockStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *StoreCoverageCountsFunc) PushHook(hook func(context.Context, string) (shared.CoverageCounts, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreCoverageCountsFunc) SetDefaultReturn(r0 shared.CoverageCounts, r1 error) {
+	f.SetDefaultHook(func(context.Context, string) (shared.CoverageCounts, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreCoverageCountsFunc) PushReturn(r0 shared.CoverageCounts, r1 error) {
+	f.PushHook(func(context.Context, string) (shared.CoverageCounts, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreCoverageCountsFunc) nextHook() func(context.Context, string) (shared.CoverageCounts, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreCoverageCountsFunc) appendCall(r0 StoreCoverageCountsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreCoverageCountsFuncCall objects
+// describing the invocations of this function.
+func (f *StoreCoverageCountsFunc) History() []StoreCoverageCountsFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreCoverageCountsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreCoverageCountsFuncCall is an object that describes an invocation of
+// method CoverageCounts on an instance of MockStore.
+type StoreCoverageCountsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 shared.CoverageCounts
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreCoverageCountsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreCoverageCountsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// StoreDeadCodeReportGraphKeyFunc describes the behavior when the
+// DeadCodeReportGraphKey method of the parent MockStore instance is
+// invoked.
+type StoreDeadCodeReportGraphKeyFunc struct {
+	defaultHook func(context.Context) (string, time.Time, bool, error)
+	hooks       []func(context.Context) (string, time.Time, bool, error)
+	history     []StoreDeadCodeReportGraphKeyFuncCall
+	mutex       sync.Mutex
+}
+
+// DeadCodeReportGraphKey delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockStore) DeadCodeReportGraphKey(v0 context.Context) (string, time.Time, bool, error) {
+	r0, r1, r2, r3 := m.DeadCodeReportGraphKeyFunc.nextHook()(v0)
+	m.DeadCodeReportGraphKeyFunc.appendCall(StoreDeadCodeReportGraphKeyFuncCall{v0, r0, r1, r2, r3})
+	return r0, r1, r2, r3
+}
+
+// SetDefaultHook sets function that is called when the
+// DeadCodeReportGraphKey method of the parent MockStore instance is invoked
+// and the hook queue is empty.
+func (f *StoreDeadCodeReportGraphKeyFunc) SetDefaultHook(hook func(context.Context) (string, time.Time, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeadCodeReportGraphKey method of the parent MockStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *StoreDeadCodeReportGraphKeyFunc) PushHook(hook func(context.Context) (string, time.Time, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreDeadCodeReportGraphKeyFunc) SetDefaultReturn(r0 string, r1 time.Time, r2 bool, r3 error) {
+	f.SetDefaultHook(func(context.Context) (string, time.Time, bool, error) {
+		return r0, r1, r2, r3
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreDeadCodeReportGraphKeyFunc) PushReturn(r0 string, r1 time.Time, r2 bool, r3 error) {
+	f.PushHook(func(context.Context) (string, time.Time, bool, error) {
+		return r0, r1, r2, r3
+	})
+}
+
+func (f *StoreDeadCodeReportGraphKeyFunc) nextHook() func(context.Context) (string, time.Time, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreDeadCodeReportGraphKeyFunc) appendCall(r0 StoreDeadCodeReportGraphKeyFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreDeadCodeReportGraphKeyFuncCall objects
+// describing the invocations of this function.
+func (f *StoreDeadCodeReportGraphKeyFunc) History() []StoreDeadCodeReportGraphKeyFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreDeadCodeReportGraphKeyFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreDeadCodeReportGraphKeyFuncCall is an object that describes an
+// invocation of method DeadCodeReportGraphKey on an instance of MockStore.
+type StoreDeadCodeReportGraphKeyFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 time.Time
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 bool
+	// Result3 is the value of the 4th result returned from this method
+	// invocation.
+	Result3 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreDeadCodeReportGraphKeyFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreDeadCodeReportGraphKeyFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2, c.Result3}
+}
+
+// StoreDeleteRankingProgressFunc describes the behavior when the
+// DeleteRankingProgress method of the parent MockStore instance is invoked.
+type StoreDeleteRankingProgressFunc struct {
+	defaultHook func(context.Context, string) error
+	hooks       []func(context.Context, string) error
+	history     []StoreDeleteRankingProgressFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteRankingProgress delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockStore) DeleteRankingProgress(v0 context.Context, v1 string) error {
+	r0 := m.DeleteRankingProgressFunc.nextHook()(v0, v1)
+	m.DeleteRankingProgressFunc.appendCall(StoreDeleteRankingProgressFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// DeleteRankingProgress method of the parent MockStore instance is invoked
+// and the hook queue is empty.
+func (f *StoreDeleteRankingProgressFunc) SetDefaultHook(hook func(context.Context, string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteRankingProgress method of the parent MockStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *StoreDeleteRankingProgressFunc) PushHook(hook func(context.Context, string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreDeleteRankingProgressFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreDeleteRankingProgressFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, string) error {
+		return r0
+	})
+}
+
+func (f *StoreDeleteRankingProgressFunc) nextHook() func(context.Context, string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreDeleteRankingProgressFunc) appendCall(r0 StoreDeleteRankingProgressFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreDeleteRankingProgressFuncCall objects
+// describing the invocations of this function.
+func (f *StoreDeleteRankingProgressFunc) History() []StoreDeleteRankingProgressFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreDeleteRankingProgressFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreDeleteRankingProgressFuncCall is an object that describes an
+// invocation of method DeleteRankingProgress on an instance of MockStore.
+type StoreDeleteRankingProgressFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreDeleteRankingProgressFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreDeleteRankingProgressFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// StoreDerivativeGraphKeyFunc describes the behavior when the
+// DerivativeGraphKey method of the parent MockStore instance is invoked.
+type StoreDerivativeGraphKeyFunc struct {
+	defaultHook func(context.Context) (string, time.Time, bool, error)
+	hooks       []func(context.Context) (string, time.Time, bool, error)
+	history     []StoreDerivativeGraphKeyFuncCall
+	mutex       sync.Mutex
+}
+
+// DerivativeGraphKey delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockStore) DerivativeGraphKey(v0 context.Context) (string, time.Time, bool, error) {
+	r0, r1, r2, r3 := m.DerivativeGraphKeyFunc.nextHook()(v0)
+	m.DerivativeGraphKeyFunc.appendCall(StoreDerivativeGraphKeyFuncCall{v0, r0, r1, r2, r3})
+	return r0, r1, r2, r3
+}
+
+// SetDefaultHook sets function that is called when the DerivativeGraphKey
+// method of the parent MockStore instance is invoked and the hook queue is
+// empty.
+func (f *StoreDerivativeGraphKeyFunc) SetDefaultHook(hook func(context.Context) (string, time.Time, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DerivativeGraphKey method of the parent MockStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *StoreDerivativeGraphKeyFunc) PushHook(hook func(context.Context) (string, time.Time, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreDerivativeGraphKeyFunc) SetDefaultReturn(r0 string, r1 time.Time, r2 bool, r3 error) {
+	f.SetDefaultHook(func(context.Context) (string, time.Time, bool, error) {
+		return r0, r1, r2, r3
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreDerivativeGraphKeyFunc) PushReturn(r0 string, r1 time.Time, r2 bool, r3 error) {
+	f.PushHook(func(context.Context) (string, time.Time, bool, error) {
+		return r0, r1, r2, r3
+	})
+}
+
+func (f *StoreDerivativeGraphKeyFunc) nextHook() func(context.Context) (string, time.Time, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreDerivativeGraphKeyFunc) appendCall(r0 StoreDerivativeGraphKeyFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreDerivativeGraphKeyFuncCall objects
+// describing the invocations of this function.
+func (f *StoreDerivativeGraphKeyFunc) History() []StoreDerivativeGraphKeyFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreDerivativeGraphKeyFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreDerivativeGraphKeyFuncCall is an object that describes an invocation
+// of method DerivativeGraphKey on an instance of MockStore.
+type StoreDerivativeGraphKeyFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 time.Time
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 bool
+	// Result3 is the value of the 4th result returned from this method
+	// invocation.
+	Result3 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreDerivativeGraphKeyFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreDerivativeGraphKeyFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2, c.Result3}
+}
+
+// StoreGetDocumentRanksFunc describes the behavior when the
+// GetDocumentRanks method of the parent MockStore instance is invoked.
+type StoreGetDocumentRanksFunc struct {
+	defaultHook func(context.Context, api.RepoName) (map[string]float64, bool, error)
+	hooks       []func(context.Context, api.RepoName) (map[string]float64, bool, error)
+	history     []StoreGetDocumentRanksFuncCall
+	mutex       sync.Mutex
+}
+
+// GetDocumentRanks delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockStore) GetDocumentRanks(v0 context.Context, v1 api.RepoName) (map[string]float64, bool, error) {
+	r0, r1, r2 := m.GetDocumentRanksFunc.nextHook()(v0, v1)
+	m.GetDocumentRanksFunc.appendCall(StoreGetDocumentRanksFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the GetDocumentRanks
+// method of the parent MockStore instance is invoked and the hook queue is
+// empty.
+func (f *StoreGetDocumentRanksFunc) SetDefaultHook(hook func(context.Context, api.RepoName) (map[string]float64, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetDocumentRanks method of the parent MockStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *StoreGetDocumentRanksFunc) PushHook(hook func(context.Context, api.RepoName) (map[string]float64, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreGetDocumentRanksFunc) SetDefaultReturn(r0 map[string]float64, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoName) (map[string]float64, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreGetDocumentRanksFunc) PushReturn(r0 map[string]float64, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, api.RepoName) (map[string]float64, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *StoreGetDocumentRanksFunc) nextHook() func(context.Context, api.RepoName) (map[string]float64, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetDocumentRanksFunc) appendCall(r0 StoreGetDocumentRanksFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreGetDocumentRanksFuncCall objects
+// describing the invocations of this function.
+func (f *StoreGetDocumentRanksFunc) History() []StoreGetDocumentRanksFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetDocumentRanksFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetDocumentRanksFuncCall is an object that describes an invocation
+// of method GetDocumentRanks on an instance of MockStore.
+type StoreGetDocumentRanksFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoName
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[string]float64
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetDocumentRanksFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetDocumentRanksFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// StoreGetReferenceCountStatisticsFunc describes the behavior when the
+// GetReferenceCountStatistics method of the parent MockStore instance is
+// invoked.
+type StoreGetReferenceCountStatisticsFunc struct {
+	defaultHook func(context.Context) (float64, error)
+	hooks       []func(context.Context) (float64, error)
+	history     []StoreGetReferenceCountStatisticsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetReferenceCountStatistics delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockStore) GetReferenceCountStatistics(v0 context.Context) (float64, error) {
+	r0, r1 := m.GetReferenceCountStatisticsFunc.nextHook()(v0)
+	m.GetReferenceCountStatisticsFunc.appendCall(StoreGetReferenceCountStatisticsFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetReferenceCountStatistics method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreGetReferenceCountStatisticsFunc) SetDefaultHook(hook func(context.Context) (float64, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetReferenceCountStatistics method of the parent MockStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *StoreGetReferenceCountStatisticsFunc) PushHook(hook func(context.Context) (float64, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreGetReferenceCountStatisticsFunc) SetDefaultReturn(r0 float64, r1 error) {
+	f.SetDefaultHook(func(context.Context) (float64, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreGetReferenceCountStatisticsFunc) PushReturn(r0 float64, r1 error) {
+	f.PushHook(func(context.Context) (float64, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreGetReferenceCountStatisticsFunc) nextHook() func(context.Context) (float64, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetReferenceCountStatisticsFunc) appendCall(r0 StoreGetReferenceCountStatisticsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreGetReferenceCountStatisticsFuncCall
+// objects describing the invocations of this function.
+func (f *StoreGetReferenceCountStatisticsFunc) History() []StoreGetReferenceCountStatisticsFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetReferenceCountStatisticsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetReferenceCountStatisticsFuncCall is an object that describes an
+// invocation of method GetReferenceCountStatistics on an instance of
+// MockStore.
+type StoreGetReferenceCountStatisticsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 float64
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetReferenceCountStatisticsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetReferenceCountStatisticsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// StoreGetStarRankFunc describes the behavior when the GetStarRank method
+// of the parent MockStore instance is invoked.
+type StoreGetStarRankFunc struct {
+	defaultHook func(context.Context, api.RepoName) (float64, error)
+	hooks       []func(context.Context, api.RepoName) (float64, error)
+	history     []StoreGetStarRankFuncCall
+	mutex       sync.Mutex
+}
+
+// GetStarRank delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockStore) GetStarRank(v0 context.Context, v1 api.RepoName) (float64, error) {
+	r0, r1 := m.GetStarRankFunc.nextHook()(v0, v1)
+	m.GetStarRankFunc.appendCall(StoreGetStarRankFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetStarRank method
+// of the parent MockStore instance is invoked and the hook queue is empty.
+func (f *StoreGetStarRankFunc) SetDefaultHook(hook func(context.Context, api.RepoName) (float64, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetStarRank method of the parent MockStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *StoreGetStarRankFunc) PushHook(hook func(context.Context, api.RepoName) (float64, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreGetStarRankFunc) SetDefaultReturn(r0 float64, r1 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoName) (float64, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreGetStarRankFunc) PushReturn(r0 float64, r1 error) {
+	f.PushHook(func(context.Context, api.RepoName) (float64, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreGetStarRankFunc) nextHook() func(context.Context, api.RepoName) (float64, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetStarRankFunc) appendCall(r0 StoreGetStarRankFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreGetStarRankFuncCall objects describing
+// the invocations of this function.
+func (f *StoreGetStarRankFunc) History() []StoreGetStarRankFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetStarRankFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetStarRankFuncCall is an object that describes an invocation of
+// method GetStarRank on an instance of MockStore.
+type StoreGetStarRankFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoName
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 float64
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetStarRankFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetStarRankFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// StoreGetUnreferencedDefinitionChecksumsFunc describes the behavior when
+// the GetUnreferencedDefinitionChecksums method of the parent MockStore
+// instance is invoked.
+type StoreGetUnreferencedDefinitionChecksumsFunc struct {
+	defaultHook func(context.Context, string, int) (map[string][][16]byte, error)
+	hooks       []func(context.Context, string, int) (map[string][][16]byte, error)
+	history     []StoreGetUnreferencedDefinitionChecksumsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetUnreferencedDefinitionChecksums delegates to the next hook function in
+// the queue and stores the parameter and result values of this invocation.
+func (m *MockStore) GetUnreferencedDefinitionChecksums(v0 context.Context, v1 string, v2 int) (map[string][][16]byte, error) {
+	r0, r1 := m.GetUnreferencedDefinitionChecksumsFunc.nextHook()(v0, v1, v2)
+	m.GetUnreferencedDefinitionChecksumsFunc.appendCall(StoreGetUnreferencedDefinitionChecksumsFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetUnreferencedDefinitionChecksums method of the parent MockStore
+// instance is invoked and the hook queue is empty.
+func (f *StoreGetUnreferencedDefinitionChecksumsFunc) SetDefaultHook(hook func(context.Context, string, int) (map[string][][16]byte, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetUnreferencedDefinitionChecksums method of the parent MockStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *StoreGetUnreferencedDefinitionChecksumsFunc) PushHook(hook func(context.Context, string, int) (map[string][][16]byte, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreGetUnreferencedDefinitionChecksumsFunc) SetDefaultReturn(r0 map[string][][16]byte, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, int) (map[string][][16]byte, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreGetUnreferencedDefinitionChecksumsFunc) PushReturn(r0 map[string][][16]byte, r1 error) {
+	f.PushHook(func(context.Context, string, int) (map[string][][16]byte, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreGetUnreferencedDefinitionChecksumsFunc) nextHook() func(context.Context, string, int) (map[string][][16]byte, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetUnreferencedDefinitionChecksumsFunc) appendCall(r0 StoreGetUnreferencedDefinitionChecksumsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// StoreGetUnreferencedDefinitionChecksumsFuncCall objects describing the
+// invocations of this function.
+func (f *StoreGetUnreferencedDefinitionChecksumsFunc) History() []StoreGetUnreferencedDefinitionChecksumsFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetUnreferencedDefinitionChecksumsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetUnreferencedDefinitionChecksumsFuncCall is an object that
+// describes an invocation of method GetUnreferencedDefinitionChecksums on
+// an instance of MockStore.
+type StoreGetUnreferencedDefinitionChecksumsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[string][][16]byte
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetUnreferencedDefinitionChecksumsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetUnreferencedDefinitionChecksumsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// StoreGetUnreferencedDefinitionsFunc describes the behavior when the
+// GetUnreferencedDefinitions method of the parent MockStore instance is
+// invoked.
+type StoreGetUnreferencedDefinitionsFunc struct {
+	defaultHook func(context.Context, string, int, int) ([]shared.UnreferencedDefinition, error)
+	hooks       []func(context.Context, string, int, int) ([]shared.UnreferencedDefinition, error)
+	history     []StoreGetUnreferencedDefinitionsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetUnreferencedDefinitions delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockStore) GetUnreferencedDefinitions(v0 context.Context, v1 string, v2 int, v3 int) ([]shared.UnreferencedDefinition, error) {
+	r0, r1 := m.GetUnreferencedDefinitionsFunc.nextHook()(v0, v1, v2, v3)
+	m.GetUnreferencedDefinitionsFunc.appendCall(StoreGetUnreferencedDefinitionsFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetUnreferencedDefinitions method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreGetUnreferencedDefinitionsFunc) SetDefaultHook(hook func(context.Context, string, int, int) ([]shared.UnreferencedDefinition, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetUnreferencedDefinitions method of the parent MockStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *StoreGetUnreferencedDefinitionsFunc) PushHook(hook func(context.Context, string, int, int) ([]shared.UnreferencedDefinition, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreGetUnreferencedDefinitionsFunc) SetDefaultReturn(r0 []shared.UnreferencedDefinition, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, int, int) ([]shared.UnreferencedDefinition, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreGetUnreferencedDefinitionsFunc) PushReturn(r0 []shared.UnreferencedDefinition, r1 error) {
+	f.PushHook(func(context.Context, string, int, int) ([]shared.UnreferencedDefinition, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreGetUnreferencedDefinitionsFunc) nextHook() func(context.Context, string, int, int) ([]shared.UnreferencedDefinition, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetUnreferencedDefinitionsFunc) appendCall(r0 StoreGetUnreferencedDefinitionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreGetUnreferencedDefinitionsFuncCall
+// objects describing the invocations of this function.
+func (f *StoreGetUnreferencedDefinitionsFunc) History() []StoreGetUnreferencedDefinitionsFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetUnreferencedDefinitionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetUnreferencedDefinitionsFuncCall is an object that describes an
+// invocation of method GetUnreferencedDefinitions on an instance of
+// MockStore.
+type StoreGetUnreferencedDefinitionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []shared.UnreferencedDefinition
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetUnreferencedDefinitionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetUnreferencedDefinitionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// StoreGetUploadsForDeadCodeReportFunc describes the behavior when the
+// GetUploadsForDeadCodeReport method of the parent MockStore instance is
+// invoked.
+type StoreGetUploadsForDeadCodeReportFunc struct {
+	defaultHook func(context.Context, string, int) ([]shared1.ExportedUpload, error)
+	hooks       []func(context.Context, string, int) ([]shared1.ExportedUpload, error)
+	history     []StoreGetUploadsForDeadCodeReportFuncCall
+	mutex       sync.Mutex
+}
+
+// GetUploadsForDeadCodeReport delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockStore) GetUploadsForDeadCodeReport(v0 context.Context, v1 string, v2 int) ([]shared1.ExportedUpload, error) {
+	r0, r1 := m.GetUploadsForDeadCodeReportFunc.nextHook()(v0, v1, v2)
+	m.GetUploadsForDeadCodeReportFunc.appendCall(StoreGetUploadsForDeadCodeReportFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetUploadsForDeadCodeReport method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreGetUploadsForDeadCodeReportFunc) SetDefaultHook(hook func(context.Context, string, int) ([]shared1.ExportedUpload, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetUploadsForDeadCodeReport method of the parent MockStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *StoreGetUploadsForDeadCodeReportFunc) PushHook(hook func(context.Context, string, int) ([]shared1.ExportedUpload, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreGetUploadsForDeadCodeReportFunc) SetDefaultReturn(r0 []shared1.ExportedUpload, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, int) ([]shared1.ExportedUpload, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreGetUploadsForDeadCodeReportFunc) PushReturn(r0 []shared1.ExportedUpload, r1 error) {
+	f.PushHook(func(context.Context, string, int) ([]shared1.ExportedUpload, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreGetUploadsForDeadCodeReportFunc) nextHook() func(context.Context, string, int) ([]shared1.ExportedUpload, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetUploadsForDeadCodeReportFunc) appendCall(r0 StoreGetUploadsForDeadCodeReportFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreGetUploadsForDeadCodeReportFuncCall
+// objects describing the invocations of this function.
+func (f *StoreGetUploadsForDeadCodeReportFunc) History() []StoreGetUploadsForDeadCodeReportFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetUploadsForDeadCodeReportFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetUploadsForDeadCodeReportFuncCall is an object that describes an
+// invocation of method GetUploadsForDeadCodeReport on an instance of
+// MockStore.
+type StoreGetUploadsForDeadCodeReportFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []shared1.ExportedUpload
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetUploadsForDeadCodeReportFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetUploadsForDeadCodeReportFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// StoreGetUploadsForRankingFunc describes the behavior when the
+// GetUploadsForRanking method of the parent MockStore instance is invoked.
+type StoreGetUploadsForRankingFunc struct {
+	defaultHook func(context.Context, string, string, int) ([]shared1.ExportedUpload, error)
+	hooks       []func(context.Context, string, string, int) ([]shared1.ExportedUpload, error)
+	history     []StoreGetUploadsForRankingFuncCall
+	mutex       sync.Mutex
+}
+
+// GetUploadsForRanking delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockStore) GetUploadsForRanking(v0 context.Context, v1 string, v2 string, v3 int) ([]shared1.ExportedUpload, error) {
+	r0, r1 := m.GetUploadsForRankingFunc.nextHook()(v0, v1, v2, v3)
+	m.GetUploadsForRankingFunc.appendCall(StoreGetUploadsForRankingFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetUploadsForRanking
+// method of the parent MockStore instance is invoked and the hook queue is
+// empty.
+func (f *StoreGetUploadsForRankingFunc) SetDefaultHook(hook func(context.Context, string, string, int) ([]shared1.ExportedUpload, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetUploadsForRanking method of the parent MockStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *StoreGetUploadsForRankingFunc) PushHook(hook func(context.Context, string, string, int) ([]shared1.ExportedUpload, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreGetUploadsForRankingFunc) SetDefaultReturn(r0 []shared1.ExportedUpload, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, string, int) ([]shared1.ExportedUpload, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreGetUploadsForRankingFunc) PushReturn(r0 []shared1.ExportedUpload, r1 error) {
+	f.PushHook(func(context.Context, string, string, int) ([]shared1.ExportedUpload, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreGetUploadsForRankingFunc) nextHook() func(context.Context, string, string, int) ([]shared1.ExportedUpload, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetUploadsForRankingFunc) appendCall(r0 StoreGetUploadsForRankingFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreGetUploadsForRankingFuncCall objects
+// describing the invocations of this function.
+func (f *StoreGetUploadsForRankingFunc) History() []StoreGetUploadsForRankingFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetUploadsForRankingFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetUploadsForRankingFuncCall is an object that describes an
+// invocation of method GetUploadsForRanking on an instance of MockStore.
+type StoreGetUploadsForRankingFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []shared1.ExportedUpload
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetUploadsForRankingFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetUploadsForRankingFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// StoreInsertDefinitionsForRankingFunc describes the behavior when the
+// InsertDefinitionsForRanking method of the parent MockStore instance is
+// invoked.
+type StoreInsertDefinitionsForRankingFunc struct {
+	defaultHook func(context.Context, string, chan shared.RankingDefinitions) error
+	hooks       []func(context.Context, string, chan shared.RankingDefinitions) error
+	history     []StoreInsertDefinitionsForRankingFuncCall
+	mutex       sync.Mutex
+}
+
+// InsertDefinitionsForRanking delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockStore) InsertDefinitionsForRanking(v0 context.Context, v1 string, v2 chan shared.RankingDefinitions) error {
+	r0 := m.InsertDefinitionsForRankingFunc.nextHook()(v0, v1, v2)
+	m.InsertDefinitionsForRankingFunc.appendCall(StoreInsertDefinitionsForRankingFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// InsertDefinitionsForRanking method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreInsertDefinitionsForRankingFunc) SetDefaultHook(hook func(context.Context, string, chan shared.RankingDefinitions) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// InsertDefinitionsForRanking method of the parent MockStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *StoreInsertDefinitionsForRankingFunc) PushHook(hook func(context.Context, string, chan shared.RankingDefinitions) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreInsertDefinitionsForRankingFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, string, chan shared.RankingDefinitions) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreInsertDefinitionsForRankingFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, string, chan shared.RankingDefinitions) error {
+		return r0
+	})
+}
+
+func (f *StoreInsertDefinitionsForRankingFunc) nextHook() func(context.Context, string, chan shared.RankingDefinitions) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreInsertDefinitionsForRankingFunc) appendCall(r0 StoreInsertDefinitionsForRankingFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreInsertDefinitionsForRankingFuncCall
+// objects describing the invocations of this function.
+func (f *StoreInsertDefinitionsForRankingFunc) History() []StoreInsertDefinitionsForRankingFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreInsertDefinitionsForRankingFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreInsertDefinitionsForRankingFuncCall is an object that describes an
+// invocation of method InsertDefinitionsForRanking on an instance of
+// MockStore.
+type StoreInsertDefinitionsForRankingFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 chan shared.RankingDefinitions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreInsertDefinitionsForRankingFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreInsertDefinitionsForRankingFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// StoreInsertInitialPathCountsFunc describes the behavior when the
+// InsertInitialPathCounts method of the parent MockStore instance is
+// invoked.
+type StoreInsertInitialPathCountsFunc struct {
+	defaultHook func(context.Context, string, int) (int, int, error)
+	hooks       []func(context.Context, string, int) (int, int, error)
+	history     []StoreInsertInitialPathCountsFuncCall
+	mutex       sync.Mutex
+}
+
+// InsertInitialPathCounts delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockStore) InsertInitialPathCounts(v0 context.Context, v1 string, v2 int) (int, int, error) {
+	r0, r1, r2 := m.InsertInitialPathCountsFunc.nextHook()(v0, v1, v2)
+	m.InsertInitialPathCountsFunc.appendCall(StoreInsertInitialPathCountsFuncCall{v0, v1, v2, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the
+// InsertInitialPathCounts method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreInsertInitialPathCountsFunc) SetDefaultHook(hook func(context.Context, string, int) (int, int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// InsertInitialPathCounts method of the parent MockStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *StoreInsertInitialPathCountsFunc) PushHook(hook func(context.Context, string, int) (int, int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreInsertInitialPathCountsFunc) SetDefaultReturn(r0 int, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, string, int) (int, int, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreInsertInitialPathCountsFunc) PushReturn(r0 int, r1 int, r2 error) {
+	f.PushHook(func(context.Context, string, int) (int, int, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *StoreInsertInitialPathCountsFunc) nextHook() func(context.Context, string, int) (int, int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreInsertInitialPathCountsFunc) appendCall(r0 StoreInsertInitialPathCountsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreInsertInitialPathCountsFuncCall
+// objects describing the invocations of this function.
+func (f *StoreInsertInitialPathCountsFunc) History() []StoreInsertInitialPathCountsFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreInsertInitialPathCountsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreInsertInitialPathCountsFuncCall is an object that describes an
+// invocation of method InsertInitialPathCounts on an instance of MockStore.
+type StoreInsertInitialPathCountsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreInsertInitialPathCountsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreInsertInitialPathCountsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// StoreInsertInitialPathRanksFunc describes the behavior when the
+// InsertInitialPathRanks method of the parent MockStore instance is
+// invoked.
+type StoreInsertInitialPathRanksFunc struct {
+	defaultHook func(context.Context, int, []string, int, string) error
+	hooks       []func(context.Context, int, []string, int, string) error
+	history     []StoreInsertInitialPathRanksFuncCall
+	mutex       sync.Mutex
+}
+
+// InsertInitialPathRanks delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockStore) InsertInitialPathRanks(v0 context.Context, v1 int, v2 []string, v3 int, v4 string) error {
+	r0 := m.InsertInitialPathRanksFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.InsertInitialPathRanksFunc.appendCall(StoreInsertInitialPathRanksFuncCall{v0, v1, v2, v3, v4, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// InsertInitialPathRanks method of the parent MockStore instance is invoked
+// and the hook queue is empty.
+func (f *StoreInsertInitialPathRanksFunc) SetDefaultHook(hook func(context.Context, int, []string, int, string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// InsertInitialPathRanks method of the parent MockStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *StoreInsertInitialPathRanksFunc) PushHook(hook func(context.Context, int, []string, int, string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreInsertInitialPathRanksFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, []string, int, string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreInsertInitialPathRanksFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, []string, int, string) error {
+		return r0
+	})
+}
+
+func (f *StoreInsertInitialPathRanksFunc) nextHook() func(context.Context, int, []string, int, string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreInsertInitialPathRanksFunc) appendCall(r0 StoreInsertInitialPathRanksFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreInsertInitialPathRanksFuncCall objects
+// describing the invocations of this function.
+func (f *StoreInsertInitialPathRanksFunc) History() []StoreInsertInitialPathRanksFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreInsertInitialPathRanksFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreInsertInitialPathRanksFuncCall is an object that describes an
+// invocation of method InsertInitialPathRanks on an instance of MockStore.
+type StoreInsertInitialPathRanksFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreInsertInitialPathRanksFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreInsertInitialPathRanksFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// StoreInsertPathCountInputsFunc describes the behavior when the
+// InsertPathCountInputs method of the parent MockStore instance is invoked.
+type StoreInsertPathCountInputsFunc struct {
+	defaultHook func(context.Context, string, int) (int, int, error)
+	hooks       []func(context.Context, string, int) (int, int, error)
+	history     []StoreInsertPathCountInputsFuncCall
+	mutex       sync.Mutex
+}
+
+// InsertPathCountInputs delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockStore) InsertPathCountInputs(v0 context.Context, v1 string, v2 int) (int, int, error) {
+	r0, r1, r2 := m.InsertPathCountInputsFunc.nextHook()(v0, v1, v2)
+	m.InsertPathCountInputsFunc.appendCall(StoreInsertPathCountInputsFuncCall{v0, v1, v2, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the
+// InsertPathCountInputs method of the parent MockStore instance is invoked
+// and the hook queue is empty.
+func (f *StoreInsertPathCountInputsFunc) SetDefaultHook(hook func(context.Context, string, int) (int, int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// InsertPathCountInputs method of the parent MockStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *StoreInsertPathCountInputsFunc) PushHook(hook func(context.Context, string, int) (int, int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreInsertPathCountInputsFunc) SetDefaultReturn(r0 int, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, string, int) (int, int, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreInsertPathCountInputsFunc) PushReturn(r0 int, r1 int, r2 error) {
+	f.PushHook(func(context.Context, string, int) (int, int, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *StoreInsertPathCountInputsFunc) nextHook() func(context.Context, string, int) (int, int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreInsertPathCountInputsFunc) appendCall(r0 StoreInsertPathCountInputsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreInsertPathCountInputsFuncCall objects
+// describing the invocations of this function.
+func (f *StoreInsertPathCountInputsFunc) History() []StoreInsertPathCountInputsFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreInsertPathCountInputsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreInsertPathCountInputsFuncCall is an object that describes an
+// invocation of method InsertPathCountInputs on an instance of MockStore.
+type StoreInsertPathCountInputsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreInsertPathCountInputsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreInsertPathCountInputsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// StoreInsertPathRanksFunc describes the behavior when the InsertPathRanks
+// method of the parent MockStore instance is invoked.
+type StoreInsertPathRanksFunc struct {
+	defaultHook func(context.Context, string, int) (int, int, error)
+	hooks       []func(context.Context, string, int) (int, int, error)
+	history     []StoreInsertPathRanksFuncCall
+	mutex       sync.Mutex
+}
+
+// InsertPathRanks delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockStore) InsertPathRanks(v0 context.Context, v1 string, v2 int) (int, int, error) {
+	r0, r1, r2 := m.InsertPathRanksFunc.nextHook()(v0, v1, v2)
+	m.InsertPathRanksFunc.appendCall(StoreInsertPathRanksFuncCall{v0, v1, v2, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the InsertPathRanks
+// method of the parent MockStore instance is invoked and the hook queue is
+// empty.
+func (f *StoreInsertPathRanksFunc) SetDefaultHook(hook func(context.Context, string, int) (int, int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// InsertPathRanks method of the parent MockStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *StoreInsertPathRanksFunc) PushHook(hook func(context.Context, string, int) (int, int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreInsertPathRanksFunc) SetDefaultReturn(r0 int, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, string, int) (int, int, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreInsertPathRanksFunc) PushReturn(r0 int, r1 int, r2 error) {
+	f.PushHook(func(context.Context, string, int) (int, int, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *StoreInsertPathRanksFunc) nextHook() func(context.Context, string, int) (int, int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreInsertPathRanksFunc) appendCall(r0 StoreInsertPathRanksFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreInsertPathRanksFuncCall objects
+// describing the invocations of this function.
+func (f *StoreInsertPathRanksFunc) History() []StoreInsertPathRanksFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreInsertPathRanksFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreInsertPathRanksFuncCall is an object that describes an invocation of
+// method InsertPathRanks on an instance of MockStore.
+type StoreInsertPathRanksFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreInsertPathRanksFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreInsertPathRanksFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// StoreInsertReferencesForRankingFunc describes the behavior when the
+// InsertReferencesForRanking method of the parent MockStore instance is
+// invoked.
+type StoreInsertReferencesForRankingFunc struct {
+	defaultHook func(context.Context, string, int, int, chan [16]byte) error
+	hooks       []func(context.Context, string, int, int, chan [16]byte) error
+	history     []StoreInsertReferencesForRankingFuncCall
+	mutex       sync.Mutex
+}
+
+// InsertReferencesForRanking delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockStore) InsertReferencesForRanking(v0 context.Context, v1 string, v2 int, v3 int, v4 chan [16]byte) error {
+	r0 := m.InsertReferencesForRankingFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.InsertReferencesForRankingFunc.appendCall(StoreInsertReferencesForRankingFuncCall{v0, v1, v2, v3, v4, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// InsertReferencesForRanking method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreInsertReferencesForRankingFunc) SetDefaultHook(hook func(context.Context, string, int, int, chan [16]byte) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// InsertReferencesForRanking method of the parent MockStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *StoreInsertReferencesForRankingFunc) PushHook(hook func(context.Context, string, int, int, chan [16]byte) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreInsertReferencesForRankingFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, string, int, int, chan [16]byte) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreInsertReferencesForRankingFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, string, int, int, chan [16]byte) error {
+		return r0
+	})
+}
+
+func (f *StoreInsertReferencesForRankingFunc) nextHook() func(context.Context, string, int, int, chan [16]byte) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreInsertReferencesForRankingFunc) appendCall(r0 StoreInsertReferencesForRankingFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreInsertReferencesForRankingFuncCall
+// objects describing the invocations of this function.
+func (f *StoreInsertReferencesForRankingFunc) History() []StoreInsertReferencesForRankingFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreInsertReferencesForRankingFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreInsertReferencesForRankingFuncCall is an object that describes an
+// invocation of method InsertReferencesForRanking on an instance of
+// MockStore.
+type StoreInsertReferencesForRankingFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 chan [16]byte
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreInsertReferencesForRankingFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreInsertReferencesForRankingFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// StoreInsertUnreferencedDefinitionsFunc describes the behavior when the
+// InsertUnreferencedDefinitions method of the parent MockStore instance is
+// invoked.
+type StoreInsertUnreferencedDefinitionsFunc struct {
+	defaultHook func(context.Context, string, []shared.UnreferencedDefinition) error
+	hooks       []func(context.Context, string, []shared.UnreferencedDefinition) error
+	history     []StoreInsertUnreferencedDefinitionsFuncCall
+	mutex       sync.Mutex
+}
+
+// InsertUnreferencedDefinitions delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockStore) InsertUnreferencedDefinitions(v0 context.Context, v1 string, v2 []shared.UnreferencedDefinition) error {
+	r0 := m.InsertUnreferencedDefinitionsFunc.nextHook()(v0, v1, v2)
+	m.InsertUnreferencedDefinitionsFunc.appendCall(StoreInsertUnreferencedDefinitionsFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// InsertUnreferencedDefinitions method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreInsertUnreferencedDefinitionsFunc) SetDefaultHook(hook func(context.Context, string, []shared.UnreferencedDefinition) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// InsertUnreferencedDefinitions method of the parent MockStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *StoreInsertUnreferencedDefinitionsFunc) PushHook(hook func(context.Context, string, []shared.UnreferencedDefinition) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreInsertUnreferencedDefinitionsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, string, []shared.UnreferencedDefinition) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreInsertUnreferencedDefinitionsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, string, []shared.UnreferencedDefinition) error {
+		return r0
+	})
+}
+
+func (f *StoreInsertUnreferencedDefinitionsFunc) nextHook() func(context.Context, string, []shared.UnreferencedDefinition) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreInsertUnreferencedDefinitionsFunc) appendCall(r0 StoreInsertUnreferencedDefinitionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreInsertUnreferencedDefinitionsFuncCall
+// objects describing the invocations of this function.
+func (f *StoreInsertUnreferencedDefinitionsFunc) History() []StoreInsertUnreferencedDefinitionsFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreInsertUnreferencedDefinitionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreInsertUnreferencedDefinitionsFuncCall is an object that describes an
+// invocation of method InsertUnreferencedDefinitions on an instance of
+// MockStore.
+type StoreInsertUnreferencedDefinitionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []shared.UnreferencedDefinition
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreInsertUnreferencedDefinitionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreInsertUnreferencedDefinitionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// StoreLastUpdatedAtFunc describes the behavior when the LastUpdatedAt
+// method of the parent MockStore instance is invoked.
+type StoreLastUpdatedAtFunc struct {
+	defaultHook func(context.Context, []api.RepoID) (map[api.RepoID]time.Time, error)
+	hooks       []func(context.Context, []api.RepoID) (map[api.RepoID]time.Time, error)
+	history     []StoreLastUpdatedAtFuncCall
+	mutex       sync.Mutex
+}
+
+// LastUpdatedAt delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockStore) LastUpdatedAt(v0 context.Context, v1 []api.RepoID) (map[api.RepoID]time.Time, error) {
+	r0, r1 := m.LastUpdatedAtFunc.nextHook()(v0, v1)
+	m.LastUpdatedAtFunc.appendCall(StoreLastUpdatedAtFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the LastUpdatedAt method
+// of the parent MockStore instance is invoked and the hook queue is empty.
+func (f *StoreLastUpdatedAtFunc) SetDefaultHook(hook func(context.Context, []api.RepoID) (map[api.RepoID]time.Time, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// LastUpdatedAt method of the parent MockStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *StoreLastUpdatedAtFunc) PushHook(hook func(context.Context, []api.RepoID) (map[api.RepoID]time.Time, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreLastUpdatedAtFunc) SetDefaultReturn(r0 map[api.RepoID]time.Time, r1 error) {
+	f.SetDefaultHook(func(context.Context, []api.RepoID) (map[api.RepoID]time.Time, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreLastUpdatedAtFunc) PushReturn(r0 map[api.RepoID]time.Time, r1 error) {
+	f.PushHook(func(context.Context, []api.RepoID) (map[api.RepoID]time.Time, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreLastUpdatedAtFunc) nextHook() func(context.Context, []api.RepoID) (map[api.RepoID]time.Time, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreLastUpdatedAtFunc) appendCall(r0 StoreLastUpdatedAtFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreLastUpdatedAtFuncCall objects
+// describing the invocations of this function.
+func (f *StoreLastUpdatedAtFunc) History() []StoreLastUpdatedAtFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreLastUpdatedAtFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreLastUpdatedAtFuncCall is an object that describes an invocation of
+// method LastUpdatedAt on an instance of MockStore.
+type StoreLastUpdatedAtFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 []api.RepoID
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[api.RepoID]time.Time
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreLastUpdatedAtFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreLastUpdatedAtFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// StoreSoftDeleteStaleExportedUploadsFunc describes the behavior when the
+// SoftDeleteStaleExportedUploads method of the parent MockStore instance is
+// invoked.
+type StoreSoftDeleteStaleExportedUploadsFunc struct {
+	defaultHook func(context.Context, string) (int, int, error)
+	hooks       []func(context.Context, string) (int, int, error)
+	history     []StoreSoftDeleteStaleExportedUploadsFuncCall
+	mutex       sync.Mutex
+}
+
+// SoftDeleteStaleExportedUploads delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockStore) SoftDeleteStaleExportedUploads(v0 context.Context, v1 string) (int, int, error) {
+	r0, r1, r2 := m.SoftDeleteStaleExportedUploadsFunc.nextHook()(v0, v1)
+	m.SoftDeleteStaleExportedUploadsFunc.appendCall(StoreSoftDeleteStaleExportedUploadsFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the
+// SoftDeleteStaleExportedUploads method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreSoftDeleteStaleExportedUploadsFunc) SetDefaultHook(hook func(context.Context, string) (int, int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SoftDeleteStaleExportedUploads method of the parent MockStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *StoreSoftDeleteStaleExportedUploadsFunc) PushHook(hook func(context.Context, string) (int, int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreSoftDeleteStaleExportedUploadsFunc) SetDefaultReturn(r0 int, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, string) (int, int, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreSoftDeleteStaleExportedUploadsFunc) PushReturn(r0 int, r1 int, r2 error) {
+	f.PushHook(func(context.Context, string) (int, int, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *StoreSoftDeleteStaleExportedUploadsFunc) nextHook() func(context.Context, string) (int, int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreSoftDeleteStaleExportedUploadsFunc) appendCall(r0 StoreSoftDeleteStaleExportedUploadsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreSoftDeleteStaleExportedUploadsFuncCall
+// objects describing the invocations of this function.
+func (f *StoreSoftDeleteStaleExportedUploadsFunc) History() []StoreSoftDeleteStaleExportedUploadsFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreSoftDeleteStaleExportedUploadsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreSoftDeleteStaleExportedUploadsFuncCall is an object that describes
+// an invocation of method SoftDeleteStaleExportedUploads on an instance of
+// MockStore.
+type StoreSoftDeleteStaleExportedUploadsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreSoftDeleteStaleExportedUploadsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreSoftDeleteStaleExportedUploadsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// StoreSummariesFunc describes the behavior when the Summaries method of
+// the parent MockStore instance is invoked.
+type StoreSummariesFunc struct {
+	defaultHook func(context.Context) ([]shared.Summary, error)
+	hooks       []func(context.Context) ([]shared.Summary, error)
+	history     []StoreSummariesFuncCall
+	mutex       sync.Mutex
+}
+
+// Summaries delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockStore) Summaries(v0 context.Context) ([]shared.Summary, error) {
+	r0, r1 := m.SummariesFunc.nextHook()(v0)
+	m.SummariesFunc.appendCall(StoreSummariesFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Summaries method of
+// the parent MockStore instance is invoked and the hook queue is empty.
+func (f *StoreSummariesFunc) SetDefaultHook(hook func(context.Context) ([]shared.Summary, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Summaries method of the parent MockStore instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *StoreSummariesFunc) PushHook(hook func(context.Context) ([]shared.Summary, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreSummariesFunc) SetDefaultReturn(r0 []shared.Summary, r1 error) {
+	f.SetDefaultHook(func(context.Context) ([]shared.Summary, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreSummariesFunc) PushReturn(r0 []shared.Summary, r1 error) {
+	f.PushHook(func(context.Context) ([]shared.Summary, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreSummariesFunc) nextHook() func(context.Context) ([]shared.Summary, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreSummariesFunc) appendCall(r0 StoreSummariesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreSummariesFuncCall objects describing
+// the invocations of this function.
+func (f *StoreSummariesFunc) History() []StoreSummariesFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreSummariesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreSummariesFuncCall is an object that describes an invocation of
+// method Summaries on an instance of MockStore.
+type StoreSummariesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []shared.Summary
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreSummariesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreSummariesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// StoreVacuumAbandonedExportedUploadsFunc describes the behavior when the
+// VacuumAbandonedExportedUploads method of the parent MockStore instance is
+// invoked.
+type StoreVacuumAbandonedExportedUploadsFunc struct {
+	defaultHook func(context.Context, string, int) (int, error)
+	hooks       []func(context.Context, string, int) (int, error)
+	history     []StoreVacuumAbandonedExportedUploadsFuncCall
+	mutex       sync.Mutex
+}
+
+// VacuumAbandonedExportedUploads delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockStore) VacuumAbandonedExportedUploads(v0 context.Context, v1 string, v2 int) (int, error) {
+	r0, r1 := m.VacuumAbandonedExportedUploadsFunc.nextHook()(v0, v1, v2)
+	m.VacuumAbandonedExportedUploadsFunc.appendCall(StoreVacuumAbandonedExportedUploadsFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// VacuumAbandonedExportedUploads method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreVacuumAbandonedExportedUploadsFunc) SetDefaultHook(hook func(context.Context, string, int) (int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// VacuumAbandonedExportedUploads method of the parent MockStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *StoreVacuumAbandonedExportedUploadsFunc) PushHook(hook func(context.Context, string, int) (int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreVacuumAbandonedExportedUploadsFunc) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, int) (int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreVacuumAbandonedExportedUploadsFunc) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context, string, int) (int, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreVacuumAbandonedExportedUploadsFunc) nextHook() func(context.Context, string, int) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreVacuumAbandonedExportedUploadsFunc) appendCall(r0 StoreVacuumAbandonedExportedUploadsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreVacuumAbandonedExportedUploadsFuncCall
+// objects describing the invocations of this function.
+func (f *StoreVacuumAbandonedExportedUploadsFunc) History() []StoreVacuumAbandonedExportedUploadsFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreVacuumAbandonedExportedUploadsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreVacuumAbandonedExportedUploadsFuncCall is an object that describes
+// an invocation of method VacuumAbandonedExportedUploads on an instance of
+// MockStore.
+type StoreVacuumAbandonedExportedUploadsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreVacuumAbandonedExportedUploadsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreVacuumAbandonedExportedUploadsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// StoreVacuumDeletedExportedUploadsFunc describes the behavior when the
+// VacuumDeletedExportedUploads method of the parent MockStore instance is
+// invoked.
+type StoreVacuumDeletedExportedUploadsFunc struct {
+	defaultHook func(context.Context, string) (int, error)
+	hooks       []func(context.Context, string) (int, error)
+	history     []StoreVacuumDeletedExportedUploadsFuncCall
+	mutex       sync.Mutex
+}
+
+// VacuumDeletedExportedUploads delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockStore) VacuumDeletedExportedUploads(v0 context.Context, v1 string) (int, error) {
+	r0, r1 := m.VacuumDeletedExportedUploadsFunc.nextHook()(v0, v1)
+	m.VacuumDeletedExportedUploadsFunc.appendCall(StoreVacuumDeletedExportedUploadsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// VacuumDeletedExportedUploads method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreVacuumDeletedExportedUploadsFunc) SetDefaultHook(hook func(context.Context, string) (int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// VacuumDeletedExportedUploads method of the parent MockStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *StoreVacuumDeletedExportedUploadsFunc) PushHook(hook func(context.Context, string) (int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreVacuumDeletedExportedUploadsFunc) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context, string) (int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreVacuumDeletedExportedUploadsFunc) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context, string) (int, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreVacuumDeletedExportedUploadsFunc) nextHook() func(context.Context, string) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreVacuumDeletedExportedUploadsFunc) appendCall(r0 StoreVacuumDeletedExportedUploadsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreVacuumDeletedExportedUploadsFuncCall
+// objects describing the invocations of this function.
+func (f *StoreVacuumDeletedExportedUploadsFunc) History() []StoreVacuumDeletedExportedUploadsFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreVacuumDeletedExportedUploadsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreVacuumDeletedExportedUploadsFuncCall is an object that describes an
+// invocation of method VacuumDeletedExportedUploads on an instance of
+// MockStore.
+type StoreVacuumDeletedExportedUploadsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreVacuumDeletedExportedUploadsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreVacuumDeletedExportedUploadsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// StoreVacuumStaleDeadCodeReportsFunc describes the behavior when the
+// VacuumStaleDeadCodeReports method of the parent MockStore instance is
+// invoked.
+type StoreVacuumStaleDeadCodeReportsFunc struct {
+	defaultHook func(context.Context, string, int) (int, error)
+	hooks       []func(context.Context, string, int) (int, error)
+	history     []StoreVacuumStaleDeadCodeReportsFuncCall
+	mutex       sync.Mutex
+}
+
+// VacuumStaleDeadCodeReports delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockStore) VacuumStaleDeadCodeReports(v0 context.Context, v1 string, v2 int) (int, error) {
+	r0, r1 := m.VacuumStaleDeadCodeReportsFunc.nextHook()(v0, v1, v2)
+	m.VacuumStaleDeadCodeReportsFunc.appendCall(StoreVacuumStaleDeadCodeReportsFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// VacuumStaleDeadCodeReports method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreVacuumStaleDeadCodeReportsFunc) SetDefaultHook(hook func(context.Context, string, int) (int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// VacuumStaleDeadCodeReports method of the parent MockStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *StoreVacuumStaleDeadCodeReportsFunc) PushHook(hook func(context.Context, string, int) (int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreVacuumStaleDeadCodeReportsFunc) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, int) (int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreVacuumStaleDeadCodeReportsFunc) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context, string, int) (int, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreVacuumStaleDeadCodeReportsFunc) nextHook() func(context.Context, string, int) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreVacuumStaleDeadCodeReportsFunc) appendCall(r0 StoreVacuumStaleDeadCodeReportsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreVacuumStaleDeadCodeReportsFuncCall
+// objects describing the invocations of this function.
+func (f *StoreVacuumStaleDeadCodeReportsFunc) History() []StoreVacuumStaleDeadCodeReportsFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreVacuumStaleDeadCodeReportsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreVacuumStaleDeadCodeReportsFuncCall is an object that describes an
+// invocation of method VacuumStaleDeadCodeReports on an instance of
+// MockStore.
+type StoreVacuumStaleDeadCodeReportsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreVacuumStaleDeadCodeReportsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreVacuumStaleDeadCodeReportsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// StoreVacuumStaleGraphsFunc describes the behavior when the
+// VacuumStaleGraphs method of the parent MockStore instance is invoked.
+type StoreVacuumStaleGraphsFunc struct {
+	defaultHook func(context.Context, string, int) (int, error)
+	hooks       []func(context.Context, string, int) (int, error)
+	history     []StoreVacuumStaleGraphsFuncCall
+	mutex       sync.Mutex
+}
+
+// VacuumStaleGraphs delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockStore) VacuumStaleGraphs(v0 context.Context, v1 string, v2 int) (int, error) {
+	r0, r1 := m.VacuumStaleGraphsFunc.nextHook()(v0, v1, v2)
+	m.VacuumStaleGraphsFunc.appendCall(StoreVacuumStaleGraphsFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the VacuumStaleGraphs
+// method of the parent MockStore instance is invoked and the hook queue is
+// empty.
+func (f *StoreVacuumStaleGraphsFunc) SetDefaultHook(hook func(context.Context, string, int) (int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// VacuumStaleGraphs method of the parent MockStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *StoreVacuumStaleGraphsFunc) PushHook(hook func(context.Context, string, int) (int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreVacuumStaleGraphsFunc) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, int) (int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreVacuumStaleGraphsFunc) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context, string, int) (int, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreVacuumStaleGraphsFunc) nextHook() func(context.Context, string, int) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreVacuumStaleGraphsFunc) appendCall(r0 StoreVacuumStaleGraphsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreVacuumStaleGraphsFuncCall objects
+// describing the invocations of this function.
+func (f *StoreVacuumStaleGraphsFunc) History() []StoreVacuumStaleGraphsFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreVacuumStaleGraphsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreVacuumStaleGraphsFuncCall is an object that describes an invocation
+// of method VacuumStaleGraphs on an instance of MockStore.
+type StoreVacuumStaleGraphsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreVacuumStaleGraphsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreVacuumStaleGraphsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// StoreVacuumStaleProcessedPathsFunc describes the behavior when the
+// VacuumStaleProcessedPaths method of the parent MockStore instance is
+// invoked.
+type StoreVacuumStaleProcessedPathsFunc struct {
+	defaultHook func(context.Context, string, int) (int, error)
+	hooks       []func(context.Context, string, int) (int, error)
+	history     []StoreVacuumStaleProcessedPathsFuncCall
+	mutex       sync.Mutex
+}
+
+// VacuumStaleProcessedPaths delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockStore) VacuumStaleProcessedPaths(v0 context.Context, v1 string, v2 int) (int, error) {
+	r0, r1 := m.VacuumStaleProcessedPathsFunc.nextHook()(v0, v1, v2)
+	m.VacuumStaleProcessedPathsFunc.appendCall(StoreVacuumStaleProcessedPathsFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// VacuumStaleProcessedPaths method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreVacuumStaleProcessedPathsFunc) SetDefaultHook(hook func(context.Context, string, int) (int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// VacuumStaleProcessedPaths method of the parent MockStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *StoreVacuumStaleProcessedPathsFunc) PushHook(hook func(context.Context, string, int) (int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreVacuumStaleProcessedPathsFunc) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, int) (int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreVacuumStaleProcessedPathsFunc) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context, string, int) (int, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreVacuumStaleProcessedPathsFunc) nextHook() func(context.Context, string, int) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreVacuumStaleProcessedPathsFunc) appendCall(r0 StoreVacuumStaleProcessedPathsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreVacuumStaleProcessedPathsFuncCall
+// objects describing the invocations of this function.
+func (f *StoreVacuumStaleProcessedPathsFunc) History() []StoreVacuumStaleProcessedPathsFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreVacuumStaleProcessedPathsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreVacuumStaleProcessedPathsFuncCall is an object that describes an
+// invocation of method VacuumStaleProcessedPaths on an instance of
+// MockStore.
+type StoreVacuumStaleProcessedPathsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreVacuumStaleProcessedPathsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreVacuumStaleProcessedPathsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// StoreVacuumStaleProcessedReferencesFunc describes the behavior when the
+// VacuumStaleProcessedReferences method of the parent MockStore instance is
+// invoked.
+type StoreVacuumStaleProcessedReferencesFunc struct {
+	defaultHook func(context.Context, string, int) (int, error)
+	hooks       []func(context.Context, string, int) (int, error)
+	history     []StoreVacuumStaleProcessedReferencesFuncCall
+	mutex       sync.Mutex
+}
+
+// VacuumStaleProcessedReferences delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockStore) VacuumStaleProcessedReferences(v0 context.Context, v1 string, v2 int) (int, error) {
+	r0, r1 := m.VacuumStaleProcessedReferencesFunc.nextHook()(v0, v1, v2)
+	m.VacuumStaleProcessedReferencesFunc.appendCall(StoreVacuumStaleProcessedReferencesFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// VacuumStaleProcessedReferences method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreVacuumStaleProcessedReferencesFunc) SetDefaultHook(hook func(context.Context, string, int) (int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// VacuumStaleProcessedReferences method of the parent MockStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *StoreVacuumStaleProcessedReferencesFunc) PushHook(hook func(context.Context, string, int) (int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreVacuumStaleProcessedReferencesFunc) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, int) (int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreVacuumStaleProcessedReferencesFunc) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context, string, int) (int, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreVacuumStaleProcessedReferencesFunc) nextHook() func(context.Context, string, int) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreVacuumStaleProcessedReferencesFunc) appendCall(r0 StoreVacuumStaleProcessedReferencesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreVacuumStaleProcessedReferencesFuncCall
+// objects describing the invocations of this function.
+func (f *StoreVacuumStaleProcessedReferencesFunc) History() []StoreVacuumStaleProcessedReferencesFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreVacuumStaleProcessedReferencesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreVacuumStaleProcessedReferencesFuncCall is an object that describes
+// an invocation of method VacuumStaleProcessedReferences on an instance of
+// MockStore.
+type StoreVacuumStaleProcessedReferencesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreVacuumStaleProcessedReferencesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreVacuumStaleProcessedReferencesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// StoreVacuumStaleRanksFunc describes the behavior when the
+// VacuumStaleRanks method of the parent MockStore instance is invoked.
+type StoreVacuumStaleRanksFunc struct {
+	defaultHook func(context.Context, string) (int, int, error)
+	hooks       []func(context.Context, string) (int, int, error)
+	history     []StoreVacuumStaleRanksFuncCall
+	mutex       sync.Mutex
+}
+
+// VacuumStaleRanks delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockStore) VacuumStaleRanks(v0 context.Context, v1 string) (int, int, error) {
+	r0, r1, r2 := m.VacuumStaleRanksFunc.nextHook()(v0, v1)
+	m.VacuumStaleRanksFunc.appendCall(StoreVacuumStaleRanksFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the VacuumStaleRanks
+// method of the parent MockStore instance is invoked and the hook queue is
+// empty.
+func (f *StoreVacuumStaleRanksFunc) SetDefaultHook(hook func(context.Context, string) (int, int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// VacuumStaleRanks method of the parent MockStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *StoreVacuumStaleRanksFunc) PushHook(hook func(context.Context, string) (int, int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreVacuumStaleRanksFunc) SetDefaultReturn(r0 int, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, string) (int, int, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreVacuumStaleRanksFunc) PushReturn(r0 int, r1 int, r2 error) {
+	f.PushHook(func(context.Context, string) (int, int, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *StoreVacuumStaleRanksFunc) nextHook() func(context.Context, string) (int, int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreVacuumStaleRanksFunc) appendCall(r0 StoreVacuumStaleRanksFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreVacuumStaleRanksFuncCall objects
+// describing the invocations of this function.
+func (f *StoreVacuumStaleRanksFunc) History() []StoreVacuumStaleRanksFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreVacuumStaleRanksFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreVacuumStaleRanksFuncCall is an object that describes an invocation
+// of method VacuumStaleRanks on an instance of MockStore.
+type StoreVacuumStaleRanksFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreVacuumStaleRanksFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreVacuumStaleRanksFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// StoreWithTransactionFunc describes the behavior when the WithTransaction
+// method of the parent MockStore instance is invoked.
+type StoreWithTransactionFunc struct {
+	defaultHook func(context.Context, func(tx store.Store) error) error
+	hooks       []func(context.Context, func(tx store.Store) error) error
+	history     []StoreWithTransactionFuncCall
+	mutex       sync.Mutex
+}
+
+// WithTransaction delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockStore) WithTransaction(v0 context.Context, v1 func(tx store.Store) error) error {
+	r0 := m.WithTransactionFunc.nextHook()(v0, v1)
+	m.WithTransactionFunc.appendCall(StoreWithTransactionFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the WithTransaction
+// method of the parent MockStore instance is invoked and the hook queue is
+// empty.
+func (f *StoreWithTransactionFunc) SetDefaultHook(hook func(context.Context, func(tx store.Store) error) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// WithTransaction method of the parent MockStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *StoreWithTransactionFunc) PushHook(hook func(context.Context, func(tx store.Store) error) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreWithTransactionFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, func(tx store.Store) error) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreWithTransactionFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, func(tx store.Store) error) error {
+		return r0
+	})
+}
+
+func (f *StoreWithTransactionFunc) nextHook() func(context.Context, func(tx store.Store) error) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreWithTransactionFunc) appendCall(r0 StoreWithTransactionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreWithTransactionFuncCall objects
+// describing the invocations of this function.
+func (f *StoreWithTransactionFunc) History() []StoreWithTransactionFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreWithTransactionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreWithTransactionFuncCall is an object that describes an invocation of
+// method WithTransaction on an instance of MockStore.
+type StoreWithTransactionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 func(tx store.Store) error
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreWithTransactionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreWithTransactionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// MockLsifStore is a mock implementation of the Store interface (from the
+// package
+// github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/internal/lsifstore)
+// used for unit testing.
+type MockLsifStore struct {
+	// InsertDefinitionsAndReferencesForDocumentFunc is an instance of a
+	// mock function object controlling the behavior of the method
+	// InsertDefinitionsAndReferencesForDocument.
+	InsertDefinitionsAndReferencesForDocumentFunc *LsifStoreInsertDefinitionsAndReferencesForDocumentFunc
+	// ScanDocumentsByPathFunc is an instance of a mock function object
+	// controlling the behavior of the method ScanDocumentsByPath.
+	ScanDocumentsByPathFunc *LsifStoreScanDocumentsByPathFunc
+	// WithTransactionFunc is an instance of a mock function object
+	// controlling the behavior of the method WithTransaction.
+	WithTransactionFunc *LsifStoreWithTransactionFunc
+}
+
+// NewMockLsifStore creates a new mock of the Store interface. All methods
+// return zero values for all results, unless overwritten.
+func NewMockLsifStore() *MockLsifStore {
+	return &MockLsifStore{
+		InsertDefinitionsAndReferencesForDocumentFunc: &LsifStoreInsertDefinitionsAndReferencesForDocumentFunc{
+			defaultHook: func(context.Context, shared1.ExportedUpload, string, int, func(ctx context.Context, upload shared1.ExportedUpload, rankingBatchSize int, rankingGraphKey string, path string, document *scip.Document) error) (r0 error) {
+				return
+			},
+		},
+		ScanDocumentsByPathFunc: &LsifStoreScanDocumentsByPathFunc{
+			defaultHook: func(context.Context, int, []string, func(path string, document *scip.Document) error) (r0 error) {
+				return
+			},
+		},
+		WithTransactionFunc: &LsifStoreWithTransactionFunc{
+			defaultHook: func(context.Context, func(tx lsifstore.Store) error) (r0 error) {
+				return
+			},
+		},
+	}
+}
+
+// NewStrictMockLsifStore creates a new mock of the Store interface. All
+// methods panic on invocation, unless overwritten.
+func NewStrictMockLsifStore() *MockLsifStore {
+	return &MockLsifStore{
+		InsertDefinitionsAndReferencesForDocumentFunc: &LsifStoreInsertDefinitionsAndReferencesForDocumentFunc{
+			defaultHook: func(context.Context, shared1.ExportedUpload, string, int, func(ctx context.Context, upload shared1.ExportedUpload, rankingBatchSize int, rankingGraphKey string, path string, document *scip.Document) error) error {
+				panic("unexpected invocation of MockLsifStore.InsertDefinitionsAndReferencesForDocument")
+			},
+		},
+		ScanDocumentsByPathFunc: &LsifStoreScanDocumentsByPathFunc{
+			defaultHook: func(context.Context, int, []string, func(path string, document *scip.Document) error) error {
+				panic("unexpected invocation of MockLsifStore.ScanDocumentsByPath")
+			},
+		},
+		WithTransactionFunc: &LsifStoreWithTransactionFunc{
+			defaultHook: func(context.Context, func(tx lsifstore.Store) error) error {
+				panic("unexpected invocation of MockLsifStore.WithTransaction")
+			},
+		},
+	}
+}
+
+// NewMockLsifStoreFrom creates a new mock of the MockLsifStore interface.
+// All methods delegate to the given implementation, unless overwritten.
+func NewMockLsifStoreFrom(i lsifstore.Store) *MockLsifStore {
+	return &MockLsifStore{
+		InsertDefinitionsAndReferencesForDocumentFunc: &LsifStoreInsertDefinitionsAndReferencesForDocumentFunc{
+			defaultHook: i.InsertDefinitionsAndReferencesForDocument,
+		},
+		ScanDocumentsByPathFunc: &LsifStoreScanDocumentsByPathFunc{
+			defaultHook: i.ScanDocumentsByPath,
+		},
+		WithTransactionFunc: &LsifStoreWithTransactionFunc{
+			defaultHook: i.WithTransaction,
+		},
+	}
+}
+
+// LsifStoreInsertDefinitionsAndReferencesForDocumentFunc describes the
+// behavior when the InsertDefinitionsAndReferencesForDocument method of the
+// parent MockLsifStore instance is invoked.
+type LsifStoreInsertDefinitionsAndReferencesForDocumentFunc struct {
+	defaultHook func(context.Context, shared1.ExportedUpload, string, int, func(ctx context.Context, upload shared1.ExportedUpload, rankingBatchSize int, rankingGraphKey string, path string, document *scip.Document) error) error
+	hooks       []func(context.Context, shared1.ExportedUpload, string, int, func(ctx context.Context, upload shared1.ExportedUpload, rankingBatchSize int, rankingGraphKey string, path string, document *scip.Document) error) error
+	history     []LsifStoreInsertDefinitionsAndReferencesForDocumentFuncCall
+	mutex       sync.Mutex
+}
+
+// InsertDefinitionsAndReferencesForDocument delegates to the next hook
+// function in the queue and stores the parameter and result values of this
+// invocation.
+func (m *MockLsifStore) InsertDefinitionsAndReferencesForDocument(v0 context.Context, v1 shared1.ExportedUpload, v2 string, v3 int, v4 func(ctx context.Context, upload shared1.ExportedUpload, rankingBatchSize int, rankingGraphKey string, path string, document *scip.Document) error) error {
+	r0 := m.InsertDefinitionsAndReferencesForDocumentFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.InsertDefinitionsAndReferencesForDocumentFunc.appendCall(LsifStoreInsertDefinitionsAndReferencesForDocumentFuncCall{v0, v1, v2, v3, v4, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// InsertDefinitionsAndReferencesForDocument method of the parent
+// MockLsifStore instance is invoked and the hook queue is empty.
+func (f *LsifStoreInsertDefinitionsAndReferencesForDocumentFunc) SetDefaultHook(hook func(context.Context, shared1.ExportedUpload, string, int, func(ctx context.Context, upload shared1.ExportedUpload, rankingBatchSize int, rankingGraphKey string, path string, document *scip.Document) error) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// InsertDefinitionsAndReferencesForDocument method of the parent
+// MockLsifStore instance invokes the hook at the front of the queue and
+// discards it. After the queue is empty, the default hook function is
+// invoked for any future action.
+func (f *LsifStoreInsertDefinitionsAndReferencesForDocumentFunc) PushHook(hook func(context.Context, shared1.ExportedUpload, string, int, func(ctx context.Context, upload shared1.ExportedUpload, rankingBatchSize int, rankingGraphKey string, path string, document *scip.Document) error) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LsifStoreInsertDefinitionsAndReferencesForDocumentFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, shared1.ExportedUpload, string, int, func(ctx context.Context, upload shared1.ExportedUpload, rankingBatchSize int, rankingGraphKey string, path string, document *scip.Document) error) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LsifStoreInsertDefinitionsAndReferencesForDocumentFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, shared1.ExportedUpload, string, int, func(ctx context.Context, upload shared1.ExportedUpload, rankingBatchSize int, rankingGraphKey string, path string, document *scip.Document) error) error {
+		return r0
+	})
+}
+
+func (f *LsifStoreInsertDefinitionsAndReferencesForDocumentFunc) nextHook() func(context.Context, shared1.ExportedUpload, string, int, func(ctx context.Context, upload shared1.ExportedUpload, rankingBatchSize int, rankingGraphKey string, path string, document *scip.Document) error) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LsifStoreInsertDefinitionsAndReferencesForDocumentFunc) appendCall(r0 LsifStoreInsertDefinitionsAndReferencesForDocumentFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// LsifStoreInsertDefinitionsAndReferencesForDocumentFuncCall objects
+// describing the invocations of this function.
+func (f *LsifStoreInsertDefinitionsAndReferencesForDocumentFunc) History() []LsifStoreInsertDefinitionsAndReferencesForDocumentFuncCall {
+	f.mutex.Lock()
+	history := make([]LsifStoreInsertDefinitionsAndReferencesForDocumentFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LsifStoreInsertDefinitionsAndReferencesForDocumentFuncCall is an object
+// that describes an invocation of method
+// InsertDefinitionsAndReferencesForDocument on an instance of
+// MockLsifStore.
+type LsifStoreInsertDefinitionsAndReferencesForDocumentFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 shared1.ExportedUpload
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 func(ctx context.Context, upload shared1.ExportedUpload, rankingBatchSize int, rankingGraphKey string, path string, document *scip.Document) error
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LsifStoreInsertDefinitionsAndReferencesForDocumentFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LsifStoreInsertDefinitionsAndReferencesForDocumentFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// LsifStoreScanDocumentsByPathFunc describes the behavior when the
+// ScanDocumentsByPath method of the parent MockLsifStore instance is
+// invoked.
+type LsifStoreScanDocumentsByPathFunc struct {
+	defaultHook func(context.Context, int, []string, func(path string, document *scip.Document) error) error
+	hooks       []func(context.Context, int, []string, func(path string, document *scip.Document) error) error
+	history     []LsifStoreScanDocumentsByPathFuncCall
+	mutex       sync.Mutex
+}
+
+// ScanDocumentsByPath delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockLsifStore) ScanDocumentsByPath(v0 context.Context, v1 int, v2 []string, v3 func(path string, document *scip.Document) error) error {
+	r0 := m.ScanDocumentsByPathFunc.nextHook()(v0, v1, v2, v3)
+	m.ScanDocumentsByPathFunc.appendCall(LsifStoreScanDocumentsByPathFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the ScanDocumentsByPath
+// method of the parent MockLsifStore instance is invoked and the hook queue
+// is empty.
+func (f *LsifStoreScanDocumentsByPathFunc) SetDefaultHook(hook func(context.Context, int, []string, func(path string, document *scip.Document) error) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ScanDocumentsByPath method of the parent MockLsifStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *LsifStoreScanDocumentsByPathFunc) PushHook(hook func(context.Context, int, []string, func(path string, document *scip.Document) error) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LsifStoreScanDocumentsByPathFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, []string, func(path string, document *scip.Document) error) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LsifStoreScanDocumentsByPathFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, []string, func(path string, document *scip.Document) error) error {
+		return r0
+	})
+}
+
+func (f *LsifStoreScanDocumentsByPathFunc) nextHook() func(context.Context, int, []string, func(path string, document *scip.Document) error) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LsifStoreScanDocumentsByPathFunc) appendCall(r0 LsifStoreScanDocumentsByPathFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LsifStoreScanDocumentsByPathFuncCall
+// objects describing the invocations of this function.
+func (f *LsifStoreScanDocumentsByPathFunc) History() []LsifStoreScanDocumentsByPathFuncCall {
+	f.mutex.Lock()
+	history := make([]LsifStoreScanDocumentsByPathFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LsifStoreScanDocumentsByPathFuncCall is an object that describes an
+// invocation of method ScanDocumentsByPath on an instance of MockLsifStore.
+type LsifStoreScanDocumentsByPathFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 func(path string, document *scip.Document) error
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LsifStoreScanDocumentsByPathFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LsifStoreScanDocumentsByPathFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// LsifStoreWithTransactionFunc describes the behavior when the
+// WithTransaction method of the parent MockLsifStore instance is invoked.
+type LsifStoreWithTransactionFunc struct {
+	defaultHook func(context.Context, func(tx lsifstore.Store) error) error
+	hooks       []func(context.Context, func(tx lsifstore.Store) error) error
+	history     []LsifStoreWithTransactionFuncCall
+	mutex       sync.Mutex
+}
+
+// WithTransaction delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockLsifStore) WithTransaction(v0 context.Context, v1 func(tx lsifstore.Store) error) error {
+	r0 := m.WithTransactionFunc.nextHook()(v0, v1)
+	m.WithTransactionFunc.appendCall(LsifStoreWithTransactionFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the WithTransaction
+// method of the parent MockLsifStore instance is invoked and the hook queue
+// is empty.
+func (f *LsifStoreWithTransactionFunc) SetDefaultHook(hook func(context.Context, func(tx lsifstore.Store) error) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// WithTransaction method of the parent MockLsifStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *LsifStoreWithTransactionFunc) PushHook(hook func(context.Context, func(tx lsifstore.Store) error) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LsifStoreWithTransactionFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, func(tx lsifstore.Store) error) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LsifStoreWithTransactionFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, func(tx lsifstore.Store) error) error {
+		return r0
+	})
+}
+
+func (f *LsifStoreWithTransactionFunc) nextHook() func(context.Context, func(tx lsifstore.Store) error) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LsifStoreWithTransactionFunc) appendCall(r0 LsifStoreWithTransactionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LsifStoreWithTransactionFuncCall objects
+// describing the invocations of this function.
+func (f *LsifStoreWithTransactionFunc) History() []LsifStoreWithTransactionFuncCall {
+	f.mutex.Lock()
+	history := make([]LsifStoreWithTransactionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LsifStoreWithTransactionFuncCall is an object that describes an
+// invocation of method WithTransaction on an instance of MockLsifStore.
+type LsifStoreWithTransactionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 func(tx lsifstore.Store) error
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LsifStoreWithTransactionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LsifStoreWithTransactionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
@@ -2,6 +2,7 @@ package background
 
 import (
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/internal/background/coordinator"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/internal/background/deadcode"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/internal/background/exporter"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/internal/background/janitor"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/internal/background/mapper"
@@ -40,5 +41,10 @@ func NewSymbolJanitor(observationCtx *observation.Context, store store.Store, co
 		janitor.NewProcessedPathsJanitor(observationCtx, store, config),
 		janitor.NewRankCountsJanitor(observationCtx, store, config),
 		janitor.NewRankJanitor(observationCtx, store, config),
+		janitor.NewDeadCodeReportJanitor(observationCtx, store, config),
 	}
 }
+
+func NewDeadCodeReporter(observationCtx *observation.Context, store store.Store, lsifstore lsifstore.Store, config *deadcode.Config) goroutine.BackgroundRoutine {
+	return deadcode.NewDeadCodeReporter(observationCtx, store, lsifstore, config)
+}
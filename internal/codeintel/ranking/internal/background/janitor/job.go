@@ -142,6 +142,25 @@ func NewRankJanitor(
 	})
 }
 
+func NewDeadCodeReportJanitor(
+	observationCtx *observation.Context,
+	store store.Store,
+	config *Config,
+) goroutine.BackgroundRoutine {
+	name := "codeintel.ranking.dead-code-report-janitor"
+
+	return background.NewJanitorJob(context.Background(), background.JanitorOptions{
+		Name:        name,
+		Description: "Removes unreferenced definition records for superseded dead code reports.",
+		Interval:    config.Interval,
+		Metrics:     background.NewJanitorMetrics(observationCtx, name),
+		CleanupFunc: func(ctx context.Context) (numRecordsScanned int, numRecordsAltered int, err error) {
+			numDeleted, err := vacuumStaleDeadCodeReports(ctx, store)
+			return numDeleted, numDeleted, err
+		},
+	})
+}
+
 func softDeleteStaleExportedUploads(ctx context.Context, store store.Store) (int, int, error) {
 	if enabled := conf.CodeIntelRankingDocumentReferenceCountsEnabled(); !enabled {
 		return 0, 0, nil
@@ -227,3 +246,16 @@ func vacuumStaleRanks(ctx context.Context, s store.Store) (int, int, error) {
 
 	return s.VacuumStaleRanks(ctx, rankingshared.DerivativeGraphKeyFromPrefix(derivativeGraphKeyPrefix))
 }
+
+func vacuumStaleDeadCodeReports(ctx context.Context, s store.Store) (int, error) {
+	if enabled := conf.CodeIntelRankingDocumentReferenceCountsEnabled(); !enabled {
+		return 0, nil
+	}
+
+	derivativeGraphKeyPrefix, _, err := store.DerivativeGraphKey(ctx, s)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.VacuumStaleDeadCodeReports(ctx, rankingshared.DerivativeGraphKeyFromPrefix(derivativeGraphKeyPrefix), vacuumMiscRecordsBatchSize)
+}
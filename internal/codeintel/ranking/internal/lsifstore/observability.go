@@ -9,6 +9,7 @@ import (
 
 type operations struct {
 	insertDefinitionsAndReferencesForDocument *observation.Operation
+	scanDocumentsByPath                       *observation.Operation
 }
 
 var m = new(metrics.SingletonREDMetrics)
@@ -33,5 +34,6 @@ func newOperations(observationCtx *observation.Context) *operations {
 
 	return &operations{
 		insertDefinitionsAndReferencesForDocument: op("InsertDefinitionsAndReferencesForDocument"),
+		scanDocumentsByPath:                       op("ScanDocumentsByPath"),
 	}
 }
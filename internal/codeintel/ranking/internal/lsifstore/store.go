@@ -16,6 +16,7 @@ type Store interface {
 
 	// Stream
 	InsertDefinitionsAndReferencesForDocument(ctx context.Context, upload shared.ExportedUpload, rankingGraphKey string, rankingBatchSize int, f func(ctx context.Context, upload shared.ExportedUpload, rankingBatchSize int, rankingGraphKey, path string, document *scip.Document) error) error
+	ScanDocumentsByPath(ctx context.Context, uploadID int, paths []string, f func(path string, document *scip.Document) error) error
 }
 
 type SCIPWriter interface {
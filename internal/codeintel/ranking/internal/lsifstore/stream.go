@@ -5,6 +5,7 @@ import (
 	"context"
 
 	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
 	"github.com/sourcegraph/scip/bindings/go/scip"
 	"go.opentelemetry.io/otel/attribute"
 	"google.golang.org/protobuf/proto"
@@ -39,30 +40,88 @@ func (s *store) InsertDefinitionsAndReferencesForDocument(
 			return err
 		}
 
-		scipPayload, err := shared.Decompressor.Decompress(bytes.NewReader(compressedSCIPPayload))
+		document, err := decodeDocument(compressedSCIPPayload)
 		if err != nil {
 			return err
 		}
+		err = setDefsAndRefs(ctx, upload, rankingBatchNumber, rankingGraphKey, path, document)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const getDocumentsByUploadIDQuery = `
+SELECT
+	sid.document_path,
+	sd.raw_scip_payload
+FROM codeintel_scip_document_lookup sid
+JOIN codeintel_scip_documents sd ON sd.id = sid.document_id
+WHERE sid.upload_id = %s
+ORDER BY sid.document_path
+`
+
+func (s *store) ScanDocumentsByPath(
+	ctx context.Context,
+	uploadID int,
+	paths []string,
+	f func(path string, document *scip.Document) error,
+) (err error) {
+	ctx, _, endObservation := s.operations.scanDocumentsByPath.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("id", uploadID),
+		attribute.Int("numPaths", len(paths)),
+	}})
+	defer endObservation(1, observation.Args{})
 
-		var document scip.Document
-		if err := proto.Unmarshal(scipPayload, &document); err != nil {
+	rows, err := s.db.Query(ctx, sqlf.Sprintf(getDocumentsByUploadIDAndPathsQuery, uploadID, pq.Array(paths)))
+	if err != nil {
+		return err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	for rows.Next() {
+		var path string
+		var compressedSCIPPayload []byte
+		if err := rows.Scan(&path, &compressedSCIPPayload); err != nil {
 			return err
 		}
-		err = setDefsAndRefs(ctx, upload, rankingBatchNumber, rankingGraphKey, path, &document)
+
+		document, err := decodeDocument(compressedSCIPPayload)
 		if err != nil {
 			return err
 		}
+		if err := f(path, document); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
-const getDocumentsByUploadIDQuery = `
+const getDocumentsByUploadIDAndPathsQuery = `
 SELECT
 	sid.document_path,
 	sd.raw_scip_payload
 FROM codeintel_scip_document_lookup sid
 JOIN codeintel_scip_documents sd ON sd.id = sid.document_id
-WHERE sid.upload_id = %s
+WHERE
+	sid.upload_id = %s AND
+	sid.document_path = ANY(%s)
 ORDER BY sid.document_path
 `
+
+func decodeDocument(compressedSCIPPayload []byte) (*scip.Document, error) {
+	scipPayload, err := shared.Decompressor.Decompress(bytes.NewReader(compressedSCIPPayload))
+	if err != nil {
+		return nil, err
+	}
+
+	var document scip.Document
+	if err := proto.Unmarshal(scipPayload, &document); err != nil {
+		return nil, err
+	}
+
+	return &document, nil
+}
@@ -0,0 +1,21 @@
+package shared
+
+import (
+	"github.com/gobwas/glob"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+)
+
+// ExcludedFromDeadCodeReport returns true if the given repository name matches one of the
+// patterns configured by `codeIntelRanking.deadCodeReportExcludedRepositories`. Patterns use
+// the same glob syntax as the repository patterns of code intelligence configuration policies.
+// Patterns that fail to compile never match.
+func ExcludedFromDeadCodeReport(repoName string) bool {
+	for _, pattern := range conf.CodeIntelRankingDeadCodeReportExcludedRepositories() {
+		if compiled, err := glob.Compile(pattern); err == nil && compiled.Match(repoName) {
+			return true
+		}
+	}
+
+	return false
+}
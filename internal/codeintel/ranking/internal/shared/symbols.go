@@ -0,0 +1,87 @@
+package shared
+
+import (
+	"crypto/md5"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/sourcegraph/scip/bindings/go/scip"
+)
+
+const skipPrefix = "lsif ."
+
+var emptyChecksum = [16]byte{}
+
+// CanonicalizeSymbol transforms a symbol name into an opaque string that
+// can be matched internally by the ranking machinery.
+//
+// Canonicalization of a symbol name for ranking makes two transformations:
+//
+//   - The package version is removed so that we don't need to match SCIP
+//     uploads exactly to get a reference count.
+//   - We then hash the simplified symbol name into a fixed-sized block that
+//     can be matched in constant time against other symbols in Postgres.
+func CanonicalizeSymbol(symbolName string) ([16]byte, bool) {
+	if symbolName == "" || scip.IsLocalSymbol(symbolName) || strings.HasPrefix(symbolName, skipPrefix) {
+		return emptyChecksum, false
+	}
+
+	symbol, err := noVersionFormatter.Format(symbolName)
+	if err != nil {
+		return emptyChecksum, false
+	}
+
+	return md5.Sum([]byte(symbol)), true
+}
+
+var noVersionFormatter = scip.SymbolFormatter{
+	OnError:               func(err error) error { return err },
+	IncludeScheme:         func(_ string) bool { return true },
+	IncludePackageManager: func(_ string) bool { return true },
+	IncludePackageName:    func(_ string) bool { return true },
+	IncludePackageVersion: func(_ string) bool { return false },
+	IncludeDescriptor:     func(_ string) bool { return true },
+	IncludeRawDescriptor:  func(_ *scip.Descriptor) bool { return true },
+	IncludeDisambiguator:  func(_ string) bool { return true },
+}
+
+// IsExportedSymbol returns true if the given symbol name may be referenced from outside of
+// the package that defines it. SCIP does not record the visibility of a symbol, so this is
+// an approximation: local symbols and symbols nested in a parameter, type parameter or local
+// descriptor are never exported, and the naming conventions of languages that encode the
+// visibility of a symbol in its name (Go and Python) are taken into account.
+func IsExportedSymbol(symbolName string) bool {
+	if symbolName == "" || scip.IsLocalSymbol(symbolName) || strings.HasPrefix(symbolName, skipPrefix) {
+		return false
+	}
+
+	symbol, err := scip.ParseSymbol(symbolName)
+	if err != nil || symbol.Package == nil {
+		return false
+	}
+
+	for _, descriptor := range symbol.Descriptors {
+		switch descriptor.Suffix {
+		case scip.Descriptor_Parameter, scip.Descriptor_TypeParameter, scip.Descriptor_Local:
+			return false
+		case scip.Descriptor_Namespace:
+			// Package names do not follow the naming conventions below
+			continue
+		}
+
+		switch symbol.Package.Manager {
+		case "gomod":
+			if r, _ := utf8.DecodeRuneInString(descriptor.Name); !unicode.IsUpper(r) {
+				return false
+			}
+		case "python":
+			// Dunder names such as __init__ are public
+			if strings.HasPrefix(descriptor.Name, "_") && !(strings.HasPrefix(descriptor.Name, "__") && strings.HasSuffix(descriptor.Name, "__")) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
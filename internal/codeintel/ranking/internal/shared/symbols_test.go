@@ -0,0 +1,25 @@
+package shared
+
+import "testing"
+
+func TestIsExportedSymbol(t *testing.T) {
+	for symbol, expected := range map[string]bool{
+		"":                        false,
+		"local 5":                 false,
+		"lsif . pkg 1.0 foo/Bar#": false,
+		"scip-go gomod github.com/foo/bar v1.0 `github.com/foo/bar/pkg`/Exported().":        true,
+		"scip-go gomod github.com/foo/bar v1.0 `github.com/foo/bar/pkg`/unexported().":      false,
+		"scip-go gomod github.com/foo/bar v1.0 `github.com/foo/bar/pkg`/Type#Method().":     true,
+		"scip-go gomod github.com/foo/bar v1.0 `github.com/foo/bar/pkg`/Type#field.":        false,
+		"scip-go gomod github.com/foo/bar v1.0 `github.com/foo/bar/pkg`/Exported().(param)": false,
+		"scip-python python foo 1.0 `foo.bar`/Baz#":                                         true,
+		"scip-python python foo 1.0 `foo.bar`/Baz#_private().":                              false,
+		"scip-python python foo 1.0 `foo.bar`/Baz#__init__().":                              true,
+		"scip-typescript npm foo 1.0 src/`index.ts`/foo().":                                 true,
+		"scip-typescript npm foo 1.0 src/`index.ts`/foo().[T]":                              false,
+	} {
+		if exported := IsExportedSymbol(symbol); exported != expected {
+			t.Errorf("unexpected result for %q. want=%v have=%v", symbol, expected, exported)
+		}
+	}
+}
@@ -0,0 +1,332 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"go.opentelemetry.io/otel/attribute"
+
+	rankingshared "github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/internal/shared"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/shared"
+	uploadsshared "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/batch"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func (s *store) GetUploadsForDeadCodeReport(ctx context.Context, derivativeGraphKey string, batchSize int) (_ []uploadsshared.ExportedUpload, err error) {
+	ctx, _, endObservation := s.operations.getUploadsForDeadCodeReport.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.String("derivativeGraphKey", derivativeGraphKey),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	graphKey, ok := rankingshared.GraphKeyFromDerivativeGraphKey(derivativeGraphKey)
+	if !ok {
+		return nil, errors.Newf("unexpected derivative graph key %q", derivativeGraphKey)
+	}
+
+	return scanUploads(s.db.Query(ctx, sqlf.Sprintf(
+		getUploadsForDeadCodeReportQuery,
+		derivativeGraphKey,
+		graphKey,
+		derivativeGraphKey,
+		batchSize,
+		derivativeGraphKey,
+	)))
+}
+
+const getUploadsForDeadCodeReportQuery = `
+WITH
+progress AS (
+	SELECT
+		crp.id,
+		crp.max_export_id,
+		crp.mappers_started_at AS started_at
+	FROM codeintel_ranking_progress crp
+	WHERE
+		crp.graph_key = %s AND
+		crp.reducer_completed_at IS NOT NULL AND
+		crp.dead_code_report_completed_at IS NULL
+),
+ranked_exported_uploads AS (
+	SELECT
+		cre.id,
+		cre.upload_id,
+		cre.deleted_at,
+
+		-- Group by repository/root/indexer and order by descending ids. The mapper
+		-- only counts references to definitions of the rank = 1 record, so we must
+		-- ignore shadowed records as well (their definitions would all look unused).
+		RANK() OVER (PARTITION BY cre.upload_key ORDER BY cre.upload_id DESC) AS rank
+	FROM codeintel_ranking_exports cre
+	JOIN progress p ON TRUE
+	WHERE
+		cre.graph_key = %s AND
+
+		-- Ensure that the record is within the bounds where it would be visible
+		-- to the "snapshot" defined by the ranking computation state row.
+		cre.id <= p.max_export_id AND
+		(cre.deleted_at IS NULL OR cre.deleted_at > p.started_at)
+),
+candidates AS (
+	SELECT
+		reu.id,
+		reu.upload_id,
+		u.repository_id,
+		r.name AS repository_name,
+		u.root
+	FROM ranked_exported_uploads reu
+	JOIN lsif_uploads u ON u.id = reu.upload_id
+	JOIN repo r ON r.id = u.repository_id
+	WHERE
+		reu.rank = 1 AND
+		reu.deleted_at IS NULL AND
+		r.deleted_at IS NULL AND
+		r.blocked IS NULL AND
+		NOT EXISTS (
+			SELECT 1
+			FROM codeintel_ranking_unreferenced_definitions_processed udp
+			WHERE
+				udp.graph_key = %s AND
+				udp.exported_upload_id = reu.id
+		)
+	ORDER BY reu.id
+	LIMIT %s
+),
+inserted AS (
+	INSERT INTO codeintel_ranking_unreferenced_definitions_processed (graph_key, exported_upload_id)
+	SELECT %s, c.id FROM candidates c
+	ON CONFLICT DO NOTHING
+	RETURNING exported_upload_id
+),
+set_progress AS (
+	UPDATE codeintel_ranking_progress
+	SET dead_code_report_completed_at = NOW()
+	WHERE
+		id IN (SELECT id FROM progress) AND
+		NOT EXISTS (SELECT 1 FROM candidates)
+)
+SELECT
+	c.upload_id,
+	c.id,
+	c.repository_name,
+	c.repository_id,
+	c.root
+FROM inserted i
+JOIN candidates c ON c.id = i.exported_upload_id
+ORDER BY c.upload_id
+`
+
+func (s *store) GetUnreferencedDefinitionChecksums(ctx context.Context, derivativeGraphKey string, exportedUploadID int) (_ map[string][][16]byte, err error) {
+	ctx, _, endObservation := s.operations.getUnreferencedDefinitionChecksums.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.String("derivativeGraphKey", derivativeGraphKey),
+		attribute.Int("exportedUploadID", exportedUploadID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	rows, err := s.db.Query(ctx, sqlf.Sprintf(getUnreferencedDefinitionChecksumsQuery, exportedUploadID, derivativeGraphKey))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	checksumsByPath := map[string][][16]byte{}
+	for rows.Next() {
+		var (
+			path     string
+			checksum []byte
+		)
+		if err := rows.Scan(&path, &checksum); err != nil {
+			return nil, err
+		}
+
+		var c [16]byte
+		copy(c[:], checksum)
+		checksumsByPath[path] = append(checksumsByPath[path], c)
+	}
+
+	return checksumsByPath, nil
+}
+
+const getUnreferencedDefinitionChecksumsQuery = `
+SELECT
+	rd.document_path,
+	rd.symbol_checksum
+FROM codeintel_ranking_definitions rd
+WHERE
+	rd.exported_upload_id = %s AND
+	NOT EXISTS (
+		SELECT 1
+		FROM codeintel_ranking_path_counts_inputs pci
+		WHERE
+			pci.graph_key = %s AND
+			pci.definition_id = rd.id
+	)
+ORDER BY rd.document_path, rd.id
+`
+
+func (s *store) InsertUnreferencedDefinitions(ctx context.Context, derivativeGraphKey string, definitions []shared.UnreferencedDefinition) (err error) {
+	ctx, _, endObservation := s.operations.insertUnreferencedDefinitions.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.String("derivativeGraphKey", derivativeGraphKey),
+		attribute.Int("numDefinitions", len(definitions)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.withTransaction(ctx, func(tx *store) error {
+		inserter := func(inserter *batch.Inserter) error {
+			for _, definition := range definitions {
+				if err := inserter.Insert(
+					ctx,
+					derivativeGraphKey,
+					definition.RepositoryID,
+					definition.UploadID,
+					definition.DocumentPath,
+					definition.SymbolName,
+				); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}
+
+		return batch.WithInserter(
+			ctx,
+			tx.db.Handle(),
+			"codeintel_ranking_unreferenced_definitions",
+			batch.MaxNumPostgresParameters,
+			[]string{
+				"graph_key",
+				"repository_id",
+				"upload_id",
+				"document_path",
+				"symbol_name",
+			},
+			inserter,
+		)
+	})
+}
+
+func (s *store) DeadCodeReportGraphKey(ctx context.Context) (graphKey string, completedAt time.Time, _ bool, err error) {
+	ctx, _, endObservation := s.operations.deadCodeReportGraphKey.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	rows, err := s.db.Query(ctx, sqlf.Sprintf(deadCodeReportGraphKeyQuery))
+	if err != nil {
+		return "", time.Time{}, false, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	if rows.Next() {
+		if err := rows.Scan(&graphKey, &completedAt); err != nil {
+			return "", time.Time{}, false, err
+		}
+
+		return graphKey, completedAt, true, nil
+	}
+
+	return "", time.Time{}, false, nil
+}
+
+const deadCodeReportGraphKeyQuery = `
+SELECT graph_key, dead_code_report_completed_at
+FROM codeintel_ranking_progress
+WHERE dead_code_report_completed_at IS NOT NULL
+ORDER BY dead_code_report_completed_at DESC
+LIMIT 1
+`
+
+func (s *store) GetUnreferencedDefinitions(ctx context.Context, derivativeGraphKey string, afterID, limit int) (_ []shared.UnreferencedDefinition, err error) {
+	ctx, _, endObservation := s.operations.getUnreferencedDefinitions.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.String("derivativeGraphKey", derivativeGraphKey),
+		attribute.Int("afterID", afterID),
+		attribute.Int("limit", limit),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return scanUnreferencedDefinitions(s.db.Query(ctx, sqlf.Sprintf(getUnreferencedDefinitionsQuery, derivativeGraphKey, afterID, limit)))
+}
+
+const getUnreferencedDefinitionsQuery = `
+SELECT
+	ud.id,
+	ud.repository_id,
+	r.name,
+	ud.upload_id,
+	ud.document_path,
+	ud.symbol_name
+FROM codeintel_ranking_unreferenced_definitions ud
+JOIN repo r ON r.id = ud.repository_id
+WHERE
+	ud.graph_key = %s AND
+	ud.id > %s AND
+	r.deleted_at IS NULL AND
+	r.blocked IS NULL
+ORDER BY ud.id
+LIMIT %s
+`
+
+var scanUnreferencedDefinitions = basestore.NewSliceScanner(func(s dbutil.Scanner) (d shared.UnreferencedDefinition, _ error) {
+	err := s.Scan(&d.ID, &d.RepositoryID, &d.Repository, &d.UploadID, &d.DocumentPath, &d.SymbolName)
+	return d, err
+})
+
+func (s *store) VacuumStaleDeadCodeReports(ctx context.Context, derivativeGraphKey string, batchSize int) (_ int, err error) {
+	ctx, _, endObservation := s.operations.vacuumStaleDeadCodeReports.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	count, _, err := basestore.ScanFirstInt(s.db.Query(ctx, sqlf.Sprintf(
+		vacuumStaleDeadCodeReportsQuery,
+		derivativeGraphKey,
+		batchSize,
+		derivativeGraphKey,
+		batchSize,
+	)))
+	return count, err
+}
+
+const vacuumStaleDeadCodeReportsQuery = `
+WITH
+report_graph_key AS (
+	SELECT graph_key
+	FROM codeintel_ranking_progress
+	WHERE dead_code_report_completed_at IS NOT NULL
+	ORDER BY dead_code_report_completed_at DESC
+	LIMIT 1
+),
+locked_definitions AS (
+	SELECT id
+	FROM codeintel_ranking_unreferenced_definitions
+	WHERE
+		-- Keep the report being built as well as the most recently completed report
+		graph_key != %s AND
+		graph_key NOT IN (SELECT graph_key FROM report_graph_key)
+	ORDER BY graph_key, id
+	FOR UPDATE SKIP LOCKED
+	LIMIT %s
+),
+deleted_definitions AS (
+	DELETE FROM codeintel_ranking_unreferenced_definitions
+	WHERE id IN (SELECT id FROM locked_definitions)
+	RETURNING 1
+),
+locked_processed AS (
+	SELECT id
+	FROM codeintel_ranking_unreferenced_definitions_processed
+	WHERE graph_key != %s
+	ORDER BY graph_key, id
+	FOR UPDATE SKIP LOCKED
+	LIMIT %s
+),
+deleted_processed AS (
+	DELETE FROM codeintel_ranking_unreferenced_definitions_processed
+	WHERE id IN (SELECT id FROM locked_processed)
+	RETURNING 1
+)
+SELECT
+	(SELECT COUNT(*) FROM deleted_definitions) +
+	(SELECT COUNT(*) FROM deleted_processed)
+`
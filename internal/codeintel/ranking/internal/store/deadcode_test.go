@@ -0,0 +1,318 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/sourcegraph/log/logtest"
+
+	rankingshared "github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/internal/shared"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/shared"
+	uploadsshared "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestGetUploadsForDeadCodeReport(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := New(&observation.TestContext, db)
+
+	key := rankingshared.NewDerivativeGraphKey(mockRankingGraphKey, "123")
+
+	// Insert and export uploads
+	insertUploads(t, db,
+		uploadsshared.Upload{ID: 42, RepositoryID: 50},
+		uploadsshared.Upload{ID: 43, RepositoryID: 51, Root: "lib/"},
+		uploadsshared.Upload{ID: 44, RepositoryID: 52, RepositoryName: "DELETED-n-52"},
+		uploadsshared.Upload{ID: 45, RepositoryID: 53}, // shadowed by a younger upload
+		uploadsshared.Upload{ID: 46, RepositoryID: 53},
+	)
+	if _, err := db.ExecContext(ctx, `
+		WITH v AS (SELECT unnest('{42, 43, 44, 45, 46}'::integer[]))
+		INSERT INTO codeintel_ranking_exports (id, upload_id, graph_key, upload_key)
+		SELECT id + 100, id, $1, (SELECT md5(u.repository_id::text || ':' || u.root) FROM lsif_uploads u WHERE u.id = v.id) FROM v AS v(id)
+	`,
+		mockRankingGraphKey,
+	); err != nil {
+		t.Fatalf("failed to insert exported uploads: %s", err)
+	}
+
+	// Insert metadata; the reducer has not yet completed
+	if _, err := db.ExecContext(ctx, `
+		INSERT INTO codeintel_ranking_progress(graph_key, max_export_id, mappers_started_at)
+		VALUES ($1, 1000, NOW())
+	`,
+		key,
+	); err != nil {
+		t.Fatalf("failed to insert metadata: %s", err)
+	}
+
+	if uploads, err := store.GetUploadsForDeadCodeReport(ctx, key, 2); err != nil {
+		t.Fatalf("unexpected error getting uploads for dead code report: %s", err)
+	} else if len(uploads) != 0 {
+		t.Fatalf("expected no uploads before the reducer completes, got %v", uploads)
+	}
+
+	if _, err := db.ExecContext(ctx, `UPDATE codeintel_ranking_progress SET reducer_started_at = NOW(), reducer_completed_at = NOW()`); err != nil {
+		t.Fatalf("failed to update metadata: %s", err)
+	}
+
+	// Initial batch of records
+	uploads, err := store.GetUploadsForDeadCodeReport(ctx, key, 2)
+	if err != nil {
+		t.Fatalf("unexpected error getting uploads for dead code report: %s", err)
+	}
+	expectedUploads := []uploadsshared.ExportedUpload{
+		{UploadID: 42, ExportedUploadID: 142, Repo: "n-50", RepoID: 50},
+		{UploadID: 43, ExportedUploadID: 143, Repo: "n-51", RepoID: 51, Root: "lib/"},
+	}
+	if diff := cmp.Diff(expectedUploads, uploads); diff != "" {
+		t.Fatalf("unexpected uploads (-want +got):\n%s", diff)
+	}
+
+	// Remaining records
+	uploads, err = store.GetUploadsForDeadCodeReport(ctx, key, 2)
+	if err != nil {
+		t.Fatalf("unexpected error getting uploads for dead code report: %s", err)
+	}
+	expectedUploads = []uploadsshared.ExportedUpload{
+		{UploadID: 46, ExportedUploadID: 146, Repo: "n-53", RepoID: 53},
+	}
+	if diff := cmp.Diff(expectedUploads, uploads); diff != "" {
+		t.Fatalf("unexpected uploads (-want +got):\n%s", diff)
+	}
+
+	// The report has not been completed until a batch finds no remaining uploads
+	if _, _, ok, err := store.DeadCodeReportGraphKey(ctx); err != nil {
+		t.Fatalf("unexpected error getting dead code report graph key: %s", err)
+	} else if ok {
+		t.Fatalf("expected no completed dead code report")
+	}
+
+	if uploads, err := store.GetUploadsForDeadCodeReport(ctx, key, 2); err != nil {
+		t.Fatalf("unexpected error getting uploads for dead code report: %s", err)
+	} else if len(uploads) != 0 {
+		t.Fatalf("expected no remaining uploads, got %v", uploads)
+	}
+
+	if graphKey, _, ok, err := store.DeadCodeReportGraphKey(ctx); err != nil {
+		t.Fatalf("unexpected error getting dead code report graph key: %s", err)
+	} else if !ok {
+		t.Fatalf("expected a completed dead code report")
+	} else if graphKey != key {
+		t.Errorf("unexpected graph key. want=%q have=%q", key, graphKey)
+	}
+}
+
+func TestGetUnreferencedDefinitionChecksums(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := New(&observation.TestContext, db)
+
+	key := rankingshared.NewDerivativeGraphKey(mockRankingGraphKey, "123")
+
+	// Insert and export upload
+	insertUploads(t, db, uploadsshared.Upload{ID: 42})
+	if _, err := db.ExecContext(ctx, `
+		INSERT INTO codeintel_ranking_exports (id, upload_id, graph_key, upload_key)
+		VALUES (142, 42, $1, md5('key-42'))
+	`,
+		mockRankingGraphKey,
+	); err != nil {
+		t.Fatalf("failed to insert exported upload: %s", err)
+	}
+
+	// Insert definitions
+	foo, bar, baz := hash("foo"), hash("bar"), hash("baz")
+	if _, err := db.ExecContext(ctx, `
+		INSERT INTO codeintel_ranking_definitions (id, symbol_name, symbol_checksum, document_path, graph_key, exported_upload_id)
+		VALUES
+			(1, '', $2, 'a.go', $1, 142),
+			(2, '', $3, 'a.go', $1, 142),
+			(3, '', $4, 'b.go', $1, 142)
+	`,
+		mockRankingGraphKey,
+		foo[:],
+		bar[:],
+		baz[:],
+	); err != nil {
+		t.Fatalf("failed to insert definitions: %s", err)
+	}
+
+	// Only bar is referenced in this graph; baz is only referenced in another graph
+	if _, err := db.ExecContext(ctx, `
+		INSERT INTO codeintel_ranking_path_counts_inputs (graph_key, definition_id, count)
+		VALUES ($1, 2, 1), ($2, 3, 1)
+	`,
+		key,
+		rankingshared.NewDerivativeGraphKey(mockRankingGraphKey, "456"),
+	); err != nil {
+		t.Fatalf("failed to insert path count inputs: %s", err)
+	}
+
+	checksumsByPath, err := store.GetUnreferencedDefinitionChecksums(ctx, key, 142)
+	if err != nil {
+		t.Fatalf("unexpected error getting unreferenced definition checksums: %s", err)
+	}
+	expectedChecksumsByPath := map[string][][16]byte{
+		"a.go": {foo},
+		"b.go": {baz},
+	}
+	if diff := cmp.Diff(expectedChecksumsByPath, checksumsByPath); diff != "" {
+		t.Errorf("unexpected checksums (-want +got):\n%s", diff)
+	}
+}
+
+func TestUnreferencedDefinitions(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := New(&observation.TestContext, db)
+
+	key := rankingshared.NewDerivativeGraphKey(mockRankingGraphKey, "123")
+	otherKey := rankingshared.NewDerivativeGraphKey(mockRankingGraphKey, "456")
+
+	insertRepo(t, db, 50, "")
+	insertRepo(t, db, 51, "DELETED-n-51")
+
+	if err := store.InsertUnreferencedDefinitions(ctx, key, []shared.UnreferencedDefinition{
+		{RepositoryID: 50, UploadID: 42, DocumentPath: "a.go", SymbolName: "a"},
+		{RepositoryID: 51, UploadID: 43, DocumentPath: "b.go", SymbolName: "b"},
+		{RepositoryID: 50, UploadID: 42, DocumentPath: "c.go", SymbolName: "c"},
+	}); err != nil {
+		t.Fatalf("unexpected error inserting unreferenced definitions: %s", err)
+	}
+	if err := store.InsertUnreferencedDefinitions(ctx, otherKey, []shared.UnreferencedDefinition{
+		{RepositoryID: 50, UploadID: 41, DocumentPath: "d.go", SymbolName: "d"},
+	}); err != nil {
+		t.Fatalf("unexpected error inserting unreferenced definitions: %s", err)
+	}
+
+	ignoreID := cmpopts.IgnoreFields(shared.UnreferencedDefinition{}, "ID")
+
+	// Definitions of deleted repositories are skipped
+	definitions, err := store.GetUnreferencedDefinitions(ctx, key, 0, 1)
+	if err != nil {
+		t.Fatalf("unexpected error getting unreferenced definitions: %s", err)
+	}
+	expectedDefinitions := []shared.UnreferencedDefinition{
+		{RepositoryID: 50, Repository: "n-50", UploadID: 42, DocumentPath: "a.go", SymbolName: "a"},
+	}
+	if diff := cmp.Diff(expectedDefinitions, definitions, ignoreID); diff != "" {
+		t.Fatalf("unexpected definitions (-want +got):\n%s", diff)
+	}
+
+	// Next page
+	definitions, err = store.GetUnreferencedDefinitions(ctx, key, definitions[0].ID, 10)
+	if err != nil {
+		t.Fatalf("unexpected error getting unreferenced definitions: %s", err)
+	}
+	expectedDefinitions = []shared.UnreferencedDefinition{
+		{RepositoryID: 50, Repository: "n-50", UploadID: 42, DocumentPath: "c.go", SymbolName: "c"},
+	}
+	if diff := cmp.Diff(expectedDefinitions, definitions, ignoreID); diff != "" {
+		t.Fatalf("unexpected definitions (-want +got):\n%s", diff)
+	}
+
+	// Last page
+	if definitions, err := store.GetUnreferencedDefinitions(ctx, key, definitions[0].ID, 10); err != nil {
+		t.Fatalf("unexpected error getting unreferenced definitions: %s", err)
+	} else if len(definitions) != 0 {
+		t.Fatalf("expected no more definitions, got %v", definitions)
+	}
+}
+
+func TestVacuumStaleDeadCodeReports(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := New(&observation.TestContext, db)
+
+	staleKey := rankingshared.NewDerivativeGraphKey(mockRankingGraphKey, "122")
+	reportKey := rankingshared.NewDerivativeGraphKey(mockRankingGraphKey, "123")
+	currentKey := rankingshared.NewDerivativeGraphKey(mockRankingGraphKey, "124")
+
+	// Insert metadata; the report for reportKey is the most recently completed one
+	if _, err := db.ExecContext(ctx, `
+		INSERT INTO codeintel_ranking_progress(graph_key, max_export_id, mappers_started_at, dead_code_report_completed_at)
+		VALUES
+			($1, 1000, NOW() - '3 hours'::interval, NOW() - '2 hours'::interval),
+			($2, 1000, NOW() - '2 hours'::interval, NOW() - '1 hour'::interval),
+			($3, 1000, NOW(), NULL)
+	`,
+		staleKey,
+		reportKey,
+		currentKey,
+	); err != nil {
+		t.Fatalf("failed to insert metadata: %s", err)
+	}
+
+	// Insert and export upload
+	insertUploads(t, db, uploadsshared.Upload{ID: 42})
+	if _, err := db.ExecContext(ctx, `
+		INSERT INTO codeintel_ranking_exports (id, upload_id, graph_key, upload_key)
+		VALUES (142, 42, $1, md5('key-42'))
+	`,
+		mockRankingGraphKey,
+	); err != nil {
+		t.Fatalf("failed to insert exported upload: %s", err)
+	}
+
+	for _, graphKey := range []string{staleKey, reportKey, currentKey} {
+		if err := store.InsertUnreferencedDefinitions(ctx, graphKey, []shared.UnreferencedDefinition{
+			{RepositoryID: 50, UploadID: 42, DocumentPath: "a.go", SymbolName: "a"},
+			{RepositoryID: 50, UploadID: 42, DocumentPath: "b.go", SymbolName: "b"},
+		}); err != nil {
+			t.Fatalf("unexpected error inserting unreferenced definitions: %s", err)
+		}
+
+		if _, err := db.ExecContext(ctx, `
+			INSERT INTO codeintel_ranking_unreferenced_definitions_processed (graph_key, exported_upload_id)
+			VALUES ($1, 142)
+		`,
+			graphKey,
+		); err != nil {
+			t.Fatalf("failed to insert processed exported upload: %s", err)
+		}
+	}
+
+	// Removes the stale definitions, as well as processed records of all but the
+	// report being built
+	if numDeleted, err := store.VacuumStaleDeadCodeReports(ctx, currentKey, 100); err != nil {
+		t.Fatalf("unexpected error vacuuming stale dead code reports: %s", err)
+	} else if numDeleted != 4 {
+		t.Errorf("unexpected number of records deleted. want=%d have=%d", 4, numDeleted)
+	}
+
+	for graphKey, expectedCount := range map[string]int{staleKey: 0, reportKey: 2, currentKey: 2} {
+		definitions, err := store.GetUnreferencedDefinitions(ctx, graphKey, 0, 10)
+		if err != nil {
+			t.Fatalf("unexpected error getting unreferenced definitions: %s", err)
+		}
+		if len(definitions) != expectedCount {
+			t.Errorf("unexpected number of definitions for %q. want=%d have=%d", graphKey, expectedCount, len(definitions))
+		}
+	}
+}
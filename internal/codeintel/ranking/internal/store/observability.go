@@ -32,6 +32,13 @@ type operations struct {
 	vacuumStaleGraphs              *observation.Operation
 	insertPathRanks                *observation.Operation
 	vacuumStaleRanks               *observation.Operation
+
+	getUploadsForDeadCodeReport        *observation.Operation
+	getUnreferencedDefinitionChecksums *observation.Operation
+	insertUnreferencedDefinitions      *observation.Operation
+	deadCodeReportGraphKey             *observation.Operation
+	getUnreferencedDefinitions         *observation.Operation
+	vacuumStaleDeadCodeReports         *observation.Operation
 }
 
 var m = new(metrics.SingletonREDMetrics)
@@ -79,5 +86,12 @@ func newOperations(observationCtx *observation.Context) *operations {
 		vacuumStaleGraphs:              op("VacuumStaleGraphs"),
 		insertPathRanks:                op("InsertPathRanks"),
 		vacuumStaleRanks:               op("VacuumStaleRanks"),
+
+		getUploadsForDeadCodeReport:        op("GetUploadsForDeadCodeReport"),
+		getUnreferencedDefinitionChecksums: op("GetUnreferencedDefinitionChecksums"),
+		insertUnreferencedDefinitions:      op("InsertUnreferencedDefinitions"),
+		deadCodeReportGraphKey:             op("DeadCodeReportGraphKey"),
+		getUnreferencedDefinitions:         op("GetUnreferencedDefinitions"),
+		vacuumStaleDeadCodeReports:         op("VacuumStaleDeadCodeReports"),
 	}
 }
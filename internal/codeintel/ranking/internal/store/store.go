@@ -56,6 +56,14 @@ type Store interface {
 	// Reducer behavior + cleanup
 	InsertPathRanks(ctx context.Context, graphKey string, batchSize int) (numInputsProcessed int, numPathRanksInserted int, _ error)
 	VacuumStaleRanks(ctx context.Context, derivativeGraphKey string) (rankRecordsScanned int, rankRecordsSDeleted int, _ error)
+
+	// Dead code report behavior + cleanup
+	GetUploadsForDeadCodeReport(ctx context.Context, derivativeGraphKey string, batchSize int) ([]uploadsshared.ExportedUpload, error)
+	GetUnreferencedDefinitionChecksums(ctx context.Context, derivativeGraphKey string, exportedUploadID int) (map[string][][16]byte, error)
+	InsertUnreferencedDefinitions(ctx context.Context, derivativeGraphKey string, definitions []shared.UnreferencedDefinition) error
+	DeadCodeReportGraphKey(ctx context.Context) (string, time.Time, bool, error)
+	GetUnreferencedDefinitions(ctx context.Context, derivativeGraphKey string, afterID, limit int) ([]shared.UnreferencedDefinition, error)
+	VacuumStaleDeadCodeReports(ctx context.Context, derivativeGraphKey string, batchSize int) (int, error)
 }
 
 type store struct {
@@ -16,6 +16,7 @@ import (
 	shared "github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/shared"
 	shared1 "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
 	conftypes "github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
+	types "github.com/sourcegraph/sourcegraph/internal/types"
 	schema "github.com/sourcegraph/sourcegraph/schema"
 )
 
@@ -33,6 +34,9 @@ type MockStore struct {
 	// CoverageCountsFunc is an instance of a mock function object
 	// controlling the behavior of the method CoverageCounts.
 	CoverageCountsFunc *StoreCoverageCountsFunc
+	// DeadCodeReportGraphKeyFunc is an instance of a mock function object
+	// controlling the behavior of the method DeadCodeReportGraphKey.
+	DeadCodeReportGraphKeyFunc *StoreDeadCodeReportGraphKeyFunc
 	// DeleteRankingProgressFunc is an instance of a mock function object
 	// controlling the behavior of the method DeleteRankingProgress.
 	DeleteRankingProgressFunc *StoreDeleteRankingProgressFunc
@@ -49,6 +53,18 @@ type MockStore struct {
 	// GetStarRankFunc is an instance of a mock function object controlling
 	// the behavior of the method GetStarRank.
 	GetStarRankFunc *StoreGetStarRankFunc
+	// GetUnreferencedDefinitionChecksumsFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// GetUnreferencedDefinitionChecksums.
+	GetUnreferencedDefinitionChecksumsFunc *StoreGetUnreferencedDefinitionChecksumsFunc
+	// GetUnreferencedDefinitionsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// GetUnreferencedDefinitions.
+	GetUnreferencedDefinitionsFunc *StoreGetUnreferencedDefinitionsFunc
+	// GetUploadsForDeadCodeReportFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// GetUploadsForDeadCodeReport.
+	GetUploadsForDeadCodeReportFunc *StoreGetUploadsForDeadCodeReportFunc
 	// GetUploadsForRankingFunc is an instance of a mock function object
 	// controlling the behavior of the method GetUploadsForRanking.
 	GetUploadsForRankingFunc *StoreGetUploadsForRankingFunc
@@ -72,6 +88,10 @@ type MockStore struct {
 	// object controlling the behavior of the method
 	// InsertReferencesForRanking.
 	InsertReferencesForRankingFunc *StoreInsertReferencesForRankingFunc
+	// InsertUnreferencedDefinitionsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// InsertUnreferencedDefinitions.
+	InsertUnreferencedDefinitionsFunc *StoreInsertUnreferencedDefinitionsFunc
 	// LastUpdatedAtFunc is an instance of a mock function object
 	// controlling the behavior of the method LastUpdatedAt.
 	LastUpdatedAtFunc *StoreLastUpdatedAtFunc
@@ -90,6 +110,10 @@ type MockStore struct {
 	// object controlling the behavior of the method
 	// VacuumDeletedExportedUploads.
 	VacuumDeletedExportedUploadsFunc *StoreVacuumDeletedExportedUploadsFunc
+	// VacuumStaleDeadCodeReportsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// VacuumStaleDeadCodeReports.
+	VacuumStaleDeadCodeReportsFunc *StoreVacuumStaleDeadCodeReportsFunc
 	// VacuumStaleGraphsFunc is an instance of a mock function object
 	// controlling the behavior of the method VacuumStaleGraphs.
 	VacuumStaleGraphsFunc *StoreVacuumStaleGraphsFunc
@@ -128,6 +152,11 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		DeadCodeReportGraphKeyFunc: &StoreDeadCodeReportGraphKeyFunc{
+			defaultHook: func(context.Context) (r0 string, r1 time.Time, r2 bool, r3 error) {
+				return
+			},
+		},
 		DeleteRankingProgressFunc: &StoreDeleteRankingProgressFunc{
 			defaultHook: func(context.Context, string) (r0 error) {
 				return
@@ -153,6 +182,21 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		GetUnreferencedDefinitionChecksumsFunc: &StoreGetUnreferencedDefinitionChecksumsFunc{
+			defaultHook: func(context.Context, string, int) (r0 map[string][][16]byte, r1 error) {
+				return
+			},
+		},
+		GetUnreferencedDefinitionsFunc: &StoreGetUnreferencedDefinitionsFunc{
+			defaultHook: func(context.Context, string, int, int) (r0 []shared.UnreferencedDefinition, r1 error) {
+				return
+			},
+		},
+		GetUploadsForDeadCodeReportFunc: &StoreGetUploadsForDeadCodeReportFunc{
+			defaultHook: func(context.Context, string, int) (r0 []shared1.ExportedUpload, r1 error) {
+				return
+			},
+		},
 		GetUploadsForRankingFunc: &StoreGetUploadsForRankingFunc{
 			defaultHook: func(context.Context, string, string, int) (r0 []shared1.ExportedUpload, r1 error) {
 				return
@@ -188,6 +232,11 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		InsertUnreferencedDefinitionsFunc: &StoreInsertUnreferencedDefinitionsFunc{
+			defaultHook: func(context.Context, string, []shared.UnreferencedDefinition) (r0 error) {
+				return
+			},
+		},
 		LastUpdatedAtFunc: &StoreLastUpdatedAtFunc{
 			defaultHook: func(context.Context, []api.RepoID) (r0 map[api.RepoID]time.Time, r1 error) {
 				return
@@ -213,6 +262,11 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		VacuumStaleDeadCodeReportsFunc: &StoreVacuumStaleDeadCodeReportsFunc{
+			defaultHook: func(context.Context, string, int) (r0 int, r1 error) {
+				return
+			},
+		},
 		VacuumStaleGraphsFunc: &StoreVacuumStaleGraphsFunc{
 			defaultHook: func(context.Context, string, int) (r0 int, r1 error) {
 				return
@@ -260,6 +314,11 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.CoverageCounts")
 			},
 		},
+		DeadCodeReportGraphKeyFunc: &StoreDeadCodeReportGraphKeyFunc{
+			defaultHook: func(context.Context) (string, time.Time, bool, error) {
+				panic("unexpected invocation of MockStore.DeadCodeReportGraphKey")
+			},
+		},
 		DeleteRankingProgressFunc: &StoreDeleteRankingProgressFunc{
 			defaultHook: func(context.Context, string) error {
 				panic("unexpected invocation of MockStore.DeleteRankingProgress")
@@ -285,6 +344,21 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.GetStarRank")
 			},
 		},
+		GetUnreferencedDefinitionChecksumsFunc: &StoreGetUnreferencedDefinitionChecksumsFunc{
+			defaultHook: func(context.Context, string, int) (map[string][][16]byte, error) {
+				panic("unexpected invocation of MockStore.GetUnreferencedDefinitionChecksums")
+			},
+		},
+		GetUnreferencedDefinitionsFunc: &StoreGetUnreferencedDefinitionsFunc{
+			defaultHook: func(context.Context, string, int, int) ([]shared.UnreferencedDefinition, error) {
+				panic("unexpected invocation of MockStore.GetUnreferencedDefinitions")
+			},
+		},
+		GetUploadsForDeadCodeReportFunc: &StoreGetUploadsForDeadCodeReportFunc{
+			defaultHook: func(context.Context, string, int) ([]shared1.ExportedUpload, error) {
+				panic("unexpected invocation of MockStore.GetUploadsForDeadCodeReport")
+			},
+		},
 		GetUploadsForRankingFunc: &StoreGetUploadsForRankingFunc{
 			defaultHook: func(context.Context, string, string, int) ([]shared1.ExportedUpload, error) {
 				panic("unexpected invocation of MockStore.GetUploadsForRanking")
@@ -320,6 +394,11 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.InsertReferencesForRanking")
 			},
 		},
+		InsertUnreferencedDefinitionsFunc: &StoreInsertUnreferencedDefinitionsFunc{
+			defaultHook: func(context.Context, string, []shared.UnreferencedDefinition) error {
+				panic("unexpected invocation of MockStore.InsertUnreferencedDefinitions")
+			},
+		},
 		LastUpdatedAtFunc: &StoreLastUpdatedAtFunc{
 			defaultHook: func(context.Context, []api.RepoID) (map[api.RepoID]time.Time, error) {
 				panic("unexpected invocation of MockStore.LastUpdatedAt")
@@ -345,6 +424,11 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.VacuumDeletedExportedUploads")
 			},
 		},
+		VacuumStaleDeadCodeReportsFunc: &StoreVacuumStaleDeadCodeReportsFunc{
+			defaultHook: func(context.Context, string, int) (int, error) {
+				panic("unexpected invocation of MockStore.VacuumStaleDeadCodeReports")
+			},
+		},
 		VacuumStaleGraphsFunc: &StoreVacuumStaleGraphsFunc{
 			defaultHook: func(context.Context, string, int) (int, error) {
 				panic("unexpected invocation of MockStore.VacuumStaleGraphs")
@@ -386,6 +470,9 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		CoverageCountsFunc: &StoreCoverageCountsFunc{
 			defaultHook: i.CoverageCounts,
 		},
+		DeadCodeReportGraphKeyFunc: &StoreDeadCodeReportGraphKeyFunc{
+			defaultHook: i.DeadCodeReportGraphKey,
+		},
 		DeleteRankingProgressFunc: &StoreDeleteRankingProgressFunc{
 			defaultHook: i.DeleteRankingProgress,
 		},
@@ -401,6 +488,15 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		GetStarRankFunc: &StoreGetStarRankFunc{
 			defaultHook: i.GetStarRank,
 		},
+		GetUnreferencedDefinitionChecksumsFunc: &StoreGetUnreferencedDefinitionChecksumsFunc{
+			defaultHook: i.GetUnreferencedDefinitionChecksums,
+		},
+		GetUnreferencedDefinitionsFunc: &StoreGetUnreferencedDefinitionsFunc{
+			defaultHook: i.GetUnreferencedDefinitions,
+		},
+		GetUploadsForDeadCodeReportFunc: &StoreGetUploadsForDeadCodeReportFunc{
+			defaultHook: i.GetUploadsForDeadCodeReport,
+		},
 		GetUploadsForRankingFunc: &StoreGetUploadsForRankingFunc{
 			defaultHook: i.GetUploadsForRanking,
 		},
@@ -422,6 +518,9 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		InsertReferencesForRankingFunc: &StoreInsertReferencesForRankingFunc{
 			defaultHook: i.InsertReferencesForRanking,
 		},
+		InsertUnreferencedDefinitionsFunc: &StoreInsertUnreferencedDefinitionsFunc{
+			defaultHook: i.InsertUnreferencedDefinitions,
+		},
 		LastUpdatedAtFunc: &StoreLastUpdatedAtFunc{
 			defaultHook: i.LastUpdatedAt,
 		},
@@ -437,6 +536,9 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		VacuumDeletedExportedUploadsFunc: &StoreVacuumDeletedExportedUploadsFunc{
 			defaultHook: i.VacuumDeletedExportedUploads,
 		},
+		VacuumStaleDeadCodeReportsFunc: &StoreVacuumStaleDeadCodeReportsFunc{
+			defaultHook: i.VacuumStaleDeadCodeReports,
+		},
 		VacuumStaleGraphsFunc: &StoreVacuumStaleGraphsFunc{
 			defaultHook: i.VacuumStaleGraphs,
 		},
@@ -770,6 +872,118 @@ func (c StoreCoverageCountsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreDeadCodeReportGraphKeyFunc describes the behavior when the
+// DeadCodeReportGraphKey method of the parent MockStore instance is
+// invoked.
+type StoreDeadCodeReportGraphKeyFunc struct {
+	defaultHook func(context.Context) (string, time.Time, bool, error)
+	hooks       []func(context.Context) (string, time.Time, bool, error)
+	history     []StoreDeadCodeReportGraphKeyFuncCall
+	mutex       sync.Mutex
+}
+
+// DeadCodeReportGraphKey delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockStore) DeadCodeReportGraphKey(v0 context.Context) (string, time.Time, bool, error) {
+	r0, r1, r2, r3 := m.DeadCodeReportGraphKeyFunc.nextHook()(v0)
+	m.DeadCodeReportGraphKeyFunc.appendCall(StoreDeadCodeReportGraphKeyFuncCall{v0, r0, r1, r2, r3})
+	return r0, r1, r2, r3
+}
+
+// SetDefaultHook sets function that is called when the
+// DeadCodeReportGraphKey method of the parent MockStore instance is invoked
+// and the hook queue is empty.
+func (f *StoreDeadCodeReportGraphKeyFunc) SetDefaultHook(hook func(context.Context) (string, time.Time, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeadCodeReportGraphKey method of the parent MockStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *StoreDeadCodeReportGraphKeyFunc) PushHook(hook func(context.Context) (string, time.Time, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreDeadCodeReportGraphKeyFunc) SetDefaultReturn(r0 string, r1 time.Time, r2 bool, r3 error) {
+	f.SetDefaultHook(func(context.Context) (string, time.Time, bool, error) {
+		return r0, r1, r2, r3
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreDeadCodeReportGraphKeyFunc) PushReturn(r0 string, r1 time.Time, r2 bool, r3 error) {
+	f.PushHook(func(context.Context) (string, time.Time, bool, error) {
+		return r0, r1, r2, r3
+	})
+}
+
+func (f *StoreDeadCodeReportGraphKeyFunc) nextHook() func(context.Context) (string, time.Time, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreDeadCodeReportGraphKeyFunc) appendCall(r0 StoreDeadCodeReportGraphKeyFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreDeadCodeReportGraphKeyFuncCall objects
+// describing the invocations of this function.
+func (f *StoreDeadCodeReportGraphKeyFunc) History() []StoreDeadCodeReportGraphKeyFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreDeadCodeReportGraphKeyFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreDeadCodeReportGraphKeyFuncCall is an object that describes an
+// invocation of method DeadCodeReportGraphKey on an instance of MockStore.
+type StoreDeadCodeReportGraphKeyFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 time.Time
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 bool
+	// Result3 is the value of the 4th result returned from this method
+	// invocation.
+	Result3 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreDeadCodeReportGraphKeyFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreDeadCodeReportGraphKeyFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2, c.Result3}
+}
+
 // StoreDeleteRankingProgressFunc describes the behavior when the
 // DeleteRankingProgress method of the parent MockStore instance is invoked.
 type StoreDeleteRankingProgressFunc struct {
@@ -1312,35 +1526,37 @@ func (c StoreGetStarRankFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// StoreGetUploadsForRankingFunc describes the behavior when the
-// GetUploadsForRanking method of the parent MockStore instance is invoked.
-type StoreGetUploadsForRankingFunc struct {
-	defaultHook func(context.Context, string, string, int) ([]shared1.ExportedUpload, error)
-	hooks       []func(context.Context, string, string, int) ([]shared1.ExportedUpload, error)
-	history     []StoreGetUploadsForRankingFuncCall
+// StoreGetUnreferencedDefinitionChecksumsFunc describes the behavior when
+// the GetUnreferencedDefinitionChecksums method of the parent MockStore
+// instance is invoked.
+type StoreGetUnreferencedDefinitionChecksumsFunc struct {
+	defaultHook func(context.Context, string, int) (map[string][][16]byte, error)
+	hooks       []func(context.Context, string, int) (map[string][][16]byte, error)
+	history     []StoreGetUnreferencedDefinitionChecksumsFuncCall
 	mutex       sync.Mutex
 }
 
-// GetUploadsForRanking delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockStore) GetUploadsForRanking(v0 context.Context, v1 string, v2 string, v3 int) ([]shared1.ExportedUpload, error) {
-	r0, r1 := m.GetUploadsForRankingFunc.nextHook()(v0, v1, v2, v3)
-	m.GetUploadsForRankingFunc.appendCall(StoreGetUploadsForRankingFuncCall{v0, v1, v2, v3, r0, r1})
+// GetUnreferencedDefinitionChecksums delegates to the next hook function in
+// the queue and stores the parameter and result values of this invocation.
+func (m *MockStore) GetUnreferencedDefinitionChecksums(v0 context.Context, v1 string, v2 int) (map[string][][16]byte, error) {
+	r0, r1 := m.GetUnreferencedDefinitionChecksumsFunc.nextHook()(v0, v1, v2)
+	m.GetUnreferencedDefinitionChecksumsFunc.appendCall(StoreGetUnreferencedDefinitionChecksumsFuncCall{v0, v1, v2, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the GetUploadsForRanking
-// method of the parent MockStore instance is invoked and the hook queue is
-// empty.
-func (f *StoreGetUploadsForRankingFunc) SetDefaultHook(hook func(context.Context, string, string, int) ([]shared1.ExportedUpload, error)) {
+// SetDefaultHook sets function that is called when the
+// GetUnreferencedDefinitionChecksums method of the parent MockStore
+// instance is invoked and the hook queue is empty.
+func (f *StoreGetUnreferencedDefinitionChecksumsFunc) SetDefaultHook(hook func(context.Context, string, int) (map[string][][16]byte, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetUploadsForRanking method of the parent MockStore instance invokes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *StoreGetUploadsForRankingFunc) PushHook(hook func(context.Context, string, string, int) ([]shared1.ExportedUpload, error)) {
+// GetUnreferencedDefinitionChecksums method of the parent MockStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *StoreGetUnreferencedDefinitionChecksumsFunc) PushHook(hook func(context.Context, string, int) (map[string][][16]byte, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -1348,20 +1564,20 @@ func (f *StoreGetUploadsForRankingFunc) PushHook(hook func(context.Context, stri
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *StoreGetUploadsForRankingFunc) SetDefaultReturn(r0 []shared1.ExportedUpload, r1 error) {
-	f.SetDefaultHook(func(context.Context, string, string, int) ([]shared1.ExportedUpload, error) {
+func (f *StoreGetUnreferencedDefinitionChecksumsFunc) SetDefaultReturn(r0 map[string][][16]byte, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, int) (map[string][][16]byte, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *StoreGetUploadsForRankingFunc) PushReturn(r0 []shared1.ExportedUpload, r1 error) {
-	f.PushHook(func(context.Context, string, string, int) ([]shared1.ExportedUpload, error) {
+func (f *StoreGetUnreferencedDefinitionChecksumsFunc) PushReturn(r0 map[string][][16]byte, r1 error) {
+	f.PushHook(func(context.Context, string, int) (map[string][][16]byte, error) {
 		return r0, r1
 	})
 }
 
-func (f *StoreGetUploadsForRankingFunc) nextHook() func(context.Context, string, string, int) ([]shared1.ExportedUpload, error) {
+func (f *StoreGetUnreferencedDefinitionChecksumsFunc) nextHook() func(context.Context, string, int) (map[string][][16]byte, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -1374,26 +1590,28 @@ func (f *StoreGetUploadsForRankingFunc) nextHook() func(context.Context, string,
 	return hook
 }
 
-func (f *StoreGetUploadsForRankingFunc) appendCall(r0 StoreGetUploadsForRankingFuncCall) {
+func (f *StoreGetUnreferencedDefinitionChecksumsFunc) appendCall(r0 StoreGetUnreferencedDefinitionChecksumsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of StoreGetUploadsForRankingFuncCall objects
-// describing the invocations of this function.
-func (f *StoreGetUploadsForRankingFunc) History() []StoreGetUploadsForRankingFuncCall {
+// History returns a sequence of
+// StoreGetUnreferencedDefinitionChecksumsFuncCall objects describing the
+// invocations of this function.
+func (f *StoreGetUnreferencedDefinitionChecksumsFunc) History() []StoreGetUnreferencedDefinitionChecksumsFuncCall {
 	f.mutex.Lock()
-	history := make([]StoreGetUploadsForRankingFuncCall, len(f.history))
+	history := make([]StoreGetUnreferencedDefinitionChecksumsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// StoreGetUploadsForRankingFuncCall is an object that describes an
-// invocation of method GetUploadsForRanking on an instance of MockStore.
-type StoreGetUploadsForRankingFuncCall struct {
+// StoreGetUnreferencedDefinitionChecksumsFuncCall is an object that
+// describes an invocation of method GetUnreferencedDefinitionChecksums on
+// an instance of MockStore.
+type StoreGetUnreferencedDefinitionChecksumsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -1402,13 +1620,10 @@ type StoreGetUploadsForRankingFuncCall struct {
 	Arg1 string
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
-	Arg2 string
-	// Arg3 is the value of the 4th argument passed to this method
-	// invocation.
-	Arg3 int
+	Arg2 int
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []shared1.ExportedUpload
+	Result0 map[string][][16]byte
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -1416,47 +1631,47 @@ type StoreGetUploadsForRankingFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c StoreGetUploadsForRankingFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+func (c StoreGetUnreferencedDefinitionChecksumsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c StoreGetUploadsForRankingFuncCall) Results() []interface{} {
+func (c StoreGetUnreferencedDefinitionChecksumsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// StoreInsertDefinitionsForRankingFunc describes the behavior when the
-// InsertDefinitionsForRanking method of the parent MockStore instance is
+// StoreGetUnreferencedDefinitionsFunc describes the behavior when the
+// GetUnreferencedDefinitions method of the parent MockStore instance is
 // invoked.
-type StoreInsertDefinitionsForRankingFunc struct {
-	defaultHook func(context.Context, string, chan shared.RankingDefinitions) error
-	hooks       []func(context.Context, string, chan shared.RankingDefinitions) error
-	history     []StoreInsertDefinitionsForRankingFuncCall
+type StoreGetUnreferencedDefinitionsFunc struct {
+	defaultHook func(context.Context, string, int, int) ([]shared.UnreferencedDefinition, error)
+	hooks       []func(context.Context, string, int, int) ([]shared.UnreferencedDefinition, error)
+	history     []StoreGetUnreferencedDefinitionsFuncCall
 	mutex       sync.Mutex
 }
 
-// InsertDefinitionsForRanking delegates to the next hook function in the
+// GetUnreferencedDefinitions delegates to the next hook function in the
 // queue and stores the parameter and result values of this invocation.
-func (m *MockStore) InsertDefinitionsForRanking(v0 context.Context, v1 string, v2 chan shared.RankingDefinitions) error {
-	r0 := m.InsertDefinitionsForRankingFunc.nextHook()(v0, v1, v2)
-	m.InsertDefinitionsForRankingFunc.appendCall(StoreInsertDefinitionsForRankingFuncCall{v0, v1, v2, r0})
-	return r0
+func (m *MockStore) GetUnreferencedDefinitions(v0 context.Context, v1 string, v2 int, v3 int) ([]shared.UnreferencedDefinition, error) {
+	r0, r1 := m.GetUnreferencedDefinitionsFunc.nextHook()(v0, v1, v2, v3)
+	m.GetUnreferencedDefinitionsFunc.appendCall(StoreGetUnreferencedDefinitionsFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
 }
 
 // SetDefaultHook sets function that is called when the
-// InsertDefinitionsForRanking method of the parent MockStore instance is
+// GetUnreferencedDefinitions method of the parent MockStore instance is
 // invoked and the hook queue is empty.
-func (f *StoreInsertDefinitionsForRankingFunc) SetDefaultHook(hook func(context.Context, string, chan shared.RankingDefinitions) error) {
+func (f *StoreGetUnreferencedDefinitionsFunc) SetDefaultHook(hook func(context.Context, string, int, int) ([]shared.UnreferencedDefinition, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// InsertDefinitionsForRanking method of the parent MockStore instance
+// GetUnreferencedDefinitions method of the parent MockStore instance
 // invokes the hook at the front of the queue and discards it. After the
 // queue is empty, the default hook function is invoked for any future
 // action.
-func (f *StoreInsertDefinitionsForRankingFunc) PushHook(hook func(context.Context, string, chan shared.RankingDefinitions) error) {
+func (f *StoreGetUnreferencedDefinitionsFunc) PushHook(hook func(context.Context, string, int, int) ([]shared.UnreferencedDefinition, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -1464,20 +1679,20 @@ func (f *StoreInsertDefinitionsForRankingFunc) PushHook(hook func(context.Contex
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *StoreInsertDefinitionsForRankingFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, string, chan shared.RankingDefinitions) error {
-		return r0
+func (f *StoreGetUnreferencedDefinitionsFunc) SetDefaultReturn(r0 []shared.UnreferencedDefinition, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, int, int) ([]shared.UnreferencedDefinition, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *StoreInsertDefinitionsForRankingFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, string, chan shared.RankingDefinitions) error {
-		return r0
+func (f *StoreGetUnreferencedDefinitionsFunc) PushReturn(r0 []shared.UnreferencedDefinition, r1 error) {
+	f.PushHook(func(context.Context, string, int, int) ([]shared.UnreferencedDefinition, error) {
+		return r0, r1
 	})
 }
 
-func (f *StoreInsertDefinitionsForRankingFunc) nextHook() func(context.Context, string, chan shared.RankingDefinitions) error {
+func (f *StoreGetUnreferencedDefinitionsFunc) nextHook() func(context.Context, string, int, int) ([]shared.UnreferencedDefinition, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -1490,27 +1705,27 @@ func (f *StoreInsertDefinitionsForRankingFunc) nextHook() func(context.Context,
 	return hook
 }
 
-func (f *StoreInsertDefinitionsForRankingFunc) appendCall(r0 StoreInsertDefinitionsForRankingFuncCall) {
+func (f *StoreGetUnreferencedDefinitionsFunc) appendCall(r0 StoreGetUnreferencedDefinitionsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of StoreInsertDefinitionsForRankingFuncCall
+// History returns a sequence of StoreGetUnreferencedDefinitionsFuncCall
 // objects describing the invocations of this function.
-func (f *StoreInsertDefinitionsForRankingFunc) History() []StoreInsertDefinitionsForRankingFuncCall {
+func (f *StoreGetUnreferencedDefinitionsFunc) History() []StoreGetUnreferencedDefinitionsFuncCall {
 	f.mutex.Lock()
-	history := make([]StoreInsertDefinitionsForRankingFuncCall, len(f.history))
+	history := make([]StoreGetUnreferencedDefinitionsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// StoreInsertDefinitionsForRankingFuncCall is an object that describes an
-// invocation of method InsertDefinitionsForRanking on an instance of
+// StoreGetUnreferencedDefinitionsFuncCall is an object that describes an
+// invocation of method GetUnreferencedDefinitions on an instance of
 // MockStore.
-type StoreInsertDefinitionsForRankingFuncCall struct {
+type StoreGetUnreferencedDefinitionsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -1519,54 +1734,61 @@ type StoreInsertDefinitionsForRankingFuncCall struct {
 	Arg1 string
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
-	Arg2 chan shared.RankingDefinitions
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 error
+	Result0 []shared.UnreferencedDefinition
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c StoreInsertDefinitionsForRankingFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+func (c StoreGetUnreferencedDefinitionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c StoreInsertDefinitionsForRankingFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c StoreGetUnreferencedDefinitionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// StoreInsertInitialPathCountsFunc describes the behavior when the
-// InsertInitialPathCounts method of the parent MockStore instance is
+// StoreGetUploadsForDeadCodeReportFunc describes the behavior when the
+// GetUploadsForDeadCodeReport method of the parent MockStore instance is
 // invoked.
-type StoreInsertInitialPathCountsFunc struct {
-	defaultHook func(context.Context, string, int) (int, int, error)
-	hooks       []func(context.Context, string, int) (int, int, error)
-	history     []StoreInsertInitialPathCountsFuncCall
+type StoreGetUploadsForDeadCodeReportFunc struct {
+	defaultHook func(context.Context, string, int) ([]shared1.ExportedUpload, error)
+	hooks       []func(context.Context, string, int) ([]shared1.ExportedUpload, error)
+	history     []StoreGetUploadsForDeadCodeReportFuncCall
 	mutex       sync.Mutex
 }
 
-// InsertInitialPathCounts delegates to the next hook function in the queue
-// and stores the parameter and result values of this invocation.
-func (m *MockStore) InsertInitialPathCounts(v0 context.Context, v1 string, v2 int) (int, int, error) {
-	r0, r1, r2 := m.InsertInitialPathCountsFunc.nextHook()(v0, v1, v2)
-	m.InsertInitialPathCountsFunc.appendCall(StoreInsertInitialPathCountsFuncCall{v0, v1, v2, r0, r1, r2})
-	return r0, r1, r2
+// GetUploadsForDeadCodeReport delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockStore) GetUploadsForDeadCodeReport(v0 context.Context, v1 string, v2 int) ([]shared1.ExportedUpload, error) {
+	r0, r1 := m.GetUploadsForDeadCodeReportFunc.nextHook()(v0, v1, v2)
+	m.GetUploadsForDeadCodeReportFunc.appendCall(StoreGetUploadsForDeadCodeReportFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
 }
 
 // SetDefaultHook sets function that is called when the
-// InsertInitialPathCounts method of the parent MockStore instance is
+// GetUploadsForDeadCodeReport method of the parent MockStore instance is
 // invoked and the hook queue is empty.
-func (f *StoreInsertInitialPathCountsFunc) SetDefaultHook(hook func(context.Context, string, int) (int, int, error)) {
+func (f *StoreGetUploadsForDeadCodeReportFunc) SetDefaultHook(hook func(context.Context, string, int) ([]shared1.ExportedUpload, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// InsertInitialPathCounts method of the parent MockStore instance invokes
-// the hook at the front of the queue and discards it. After the queue is
-// empty, the default hook function is invoked for any future action.
-func (f *StoreInsertInitialPathCountsFunc) PushHook(hook func(context.Context, string, int) (int, int, error)) {
+// GetUploadsForDeadCodeReport method of the parent MockStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *StoreGetUploadsForDeadCodeReportFunc) PushHook(hook func(context.Context, string, int) ([]shared1.ExportedUpload, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -1574,20 +1796,20 @@ func (f *StoreInsertInitialPathCountsFunc) PushHook(hook func(context.Context, s
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *StoreInsertInitialPathCountsFunc) SetDefaultReturn(r0 int, r1 int, r2 error) {
-	f.SetDefaultHook(func(context.Context, string, int) (int, int, error) {
-		return r0, r1, r2
+func (f *StoreGetUploadsForDeadCodeReportFunc) SetDefaultReturn(r0 []shared1.ExportedUpload, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, int) ([]shared1.ExportedUpload, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *StoreInsertInitialPathCountsFunc) PushReturn(r0 int, r1 int, r2 error) {
-	f.PushHook(func(context.Context, string, int) (int, int, error) {
-		return r0, r1, r2
+func (f *StoreGetUploadsForDeadCodeReportFunc) PushReturn(r0 []shared1.ExportedUpload, r1 error) {
+	f.PushHook(func(context.Context, string, int) ([]shared1.ExportedUpload, error) {
+		return r0, r1
 	})
 }
 
-func (f *StoreInsertInitialPathCountsFunc) nextHook() func(context.Context, string, int) (int, int, error) {
+func (f *StoreGetUploadsForDeadCodeReportFunc) nextHook() func(context.Context, string, int) ([]shared1.ExportedUpload, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -1600,13 +1822,351 @@ func (f *StoreInsertInitialPathCountsFunc) nextHook() func(context.Context, stri
 	return hook
 }
 
-func (f *StoreInsertInitialPathCountsFunc) appendCall(r0 StoreInsertInitialPathCountsFuncCall) {
+func (f *StoreGetUploadsForDeadCodeReportFunc) appendCall(r0 StoreGetUploadsForDeadCodeReportFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of StoreInsertInitialPathCountsFuncCall
+// History returns a sequence of StoreGetUploadsForDeadCodeReportFuncCall
+// objects describing the invocations of this function.
+func (f *StoreGetUploadsForDeadCodeReportFunc) History() []StoreGetUploadsForDeadCodeReportFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetUploadsForDeadCodeReportFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetUploadsForDeadCodeReportFuncCall is an object that describes an
+// invocation of method GetUploadsForDeadCodeReport on an instance of
+// MockStore.
+type StoreGetUploadsForDeadCodeReportFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []shared1.ExportedUpload
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetUploadsForDeadCodeReportFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetUploadsForDeadCodeReportFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// StoreGetUploadsForRankingFunc describes the behavior when the
+// GetUploadsForRanking method of the parent MockStore instance is invoked.
+type StoreGetUploadsForRankingFunc struct {
+	defaultHook func(context.Context, string, string, int) ([]shared1.ExportedUpload, error)
+	hooks       []func(context.Context, string, string, int) ([]shared1.ExportedUpload, error)
+	history     []StoreGetUploadsForRankingFuncCall
+	mutex       sync.Mutex
+}
+
+// GetUploadsForRanking delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockStore) GetUploadsForRanking(v0 context.Context, v1 string, v2 string, v3 int) ([]shared1.ExportedUpload, error) {
+	r0, r1 := m.GetUploadsForRankingFunc.nextHook()(v0, v1, v2, v3)
+	m.GetUploadsForRankingFunc.appendCall(StoreGetUploadsForRankingFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetUploadsForRanking
+// method of the parent MockStore instance is invoked and the hook queue is
+// empty.
+func (f *StoreGetUploadsForRankingFunc) SetDefaultHook(hook func(context.Context, string, string, int) ([]shared1.ExportedUpload, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetUploadsForRanking method of the parent MockStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *StoreGetUploadsForRankingFunc) PushHook(hook func(context.Context, string, string, int) ([]shared1.ExportedUpload, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreGetUploadsForRankingFunc) SetDefaultReturn(r0 []shared1.ExportedUpload, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, string, int) ([]shared1.ExportedUpload, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreGetUploadsForRankingFunc) PushReturn(r0 []shared1.ExportedUpload, r1 error) {
+	f.PushHook(func(context.Context, string, string, int) ([]shared1.ExportedUpload, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreGetUploadsForRankingFunc) nextHook() func(context.Context, string, string, int) ([]shared1.ExportedUpload, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetUploadsForRankingFunc) appendCall(r0 StoreGetUploadsForRankingFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreGetUploadsForRankingFuncCall objects
+// describing the invocations of this function.
+func (f *StoreGetUploadsForRankingFunc) History() []StoreGetUploadsForRankingFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetUploadsForRankingFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetUploadsForRankingFuncCall is an object that describes an
+// invocation of method GetUploadsForRanking on an instance of MockStore.
+type StoreGetUploadsForRankingFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []shared1.ExportedUpload
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetUploadsForRankingFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetUploadsForRankingFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// StoreInsertDefinitionsForRankingFunc describes the behavior when the
+// InsertDefinitionsForRanking method of the parent MockStore instance is
+// invoked.
+type StoreInsertDefinitionsForRankingFunc struct {
+	defaultHook func(context.Context, string, chan shared.RankingDefinitions) error
+	hooks       []func(context.Context, string, chan shared.RankingDefinitions) error
+	history     []StoreInsertDefinitionsForRankingFuncCall
+	mutex       sync.Mutex
+}
+
+// InsertDefinitionsForRanking delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockStore) InsertDefinitionsForRanking(v0 context.Context, v1 string, v2 chan shared.RankingDefinitions) error {
+	r0 := m.InsertDefinitionsForRankingFunc.nextHook()(v0, v1, v2)
+	m.InsertDefinitionsForRankingFunc.appendCall(StoreInsertDefinitionsForRankingFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// InsertDefinitionsForRanking method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreInsertDefinitionsForRankingFunc) SetDefaultHook(hook func(context.Context, string, chan shared.RankingDefinitions) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// InsertDefinitionsForRanking method of the parent MockStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *StoreInsertDefinitionsForRankingFunc) PushHook(hook func(context.Context, string, chan shared.RankingDefinitions) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreInsertDefinitionsForRankingFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, string, chan shared.RankingDefinitions) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreInsertDefinitionsForRankingFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, string, chan shared.RankingDefinitions) error {
+		return r0
+	})
+}
+
+func (f *StoreInsertDefinitionsForRankingFunc) nextHook() func(context.Context, string, chan shared.RankingDefinitions) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreInsertDefinitionsForRankingFunc) appendCall(r0 StoreInsertDefinitionsForRankingFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreInsertDefinitionsForRankingFuncCall
+// objects describing the invocations of this function.
+func (f *StoreInsertDefinitionsForRankingFunc) History() []StoreInsertDefinitionsForRankingFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreInsertDefinitionsForRankingFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreInsertDefinitionsForRankingFuncCall is an object that describes an
+// invocation of method InsertDefinitionsForRanking on an instance of
+// MockStore.
+type StoreInsertDefinitionsForRankingFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 chan shared.RankingDefinitions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreInsertDefinitionsForRankingFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreInsertDefinitionsForRankingFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// StoreInsertInitialPathCountsFunc describes the behavior when the
+// InsertInitialPathCounts method of the parent MockStore instance is
+// invoked.
+type StoreInsertInitialPathCountsFunc struct {
+	defaultHook func(context.Context, string, int) (int, int, error)
+	hooks       []func(context.Context, string, int) (int, int, error)
+	history     []StoreInsertInitialPathCountsFuncCall
+	mutex       sync.Mutex
+}
+
+// InsertInitialPathCounts delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockStore) InsertInitialPathCounts(v0 context.Context, v1 string, v2 int) (int, int, error) {
+	r0, r1, r2 := m.InsertInitialPathCountsFunc.nextHook()(v0, v1, v2)
+	m.InsertInitialPathCountsFunc.appendCall(StoreInsertInitialPathCountsFuncCall{v0, v1, v2, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the
+// InsertInitialPathCounts method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreInsertInitialPathCountsFunc) SetDefaultHook(hook func(context.Context, string, int) (int, int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// InsertInitialPathCounts method of the parent MockStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *StoreInsertInitialPathCountsFunc) PushHook(hook func(context.Context, string, int) (int, int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreInsertInitialPathCountsFunc) SetDefaultReturn(r0 int, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, string, int) (int, int, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreInsertInitialPathCountsFunc) PushReturn(r0 int, r1 int, r2 error) {
+	f.PushHook(func(context.Context, string, int) (int, int, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *StoreInsertInitialPathCountsFunc) nextHook() func(context.Context, string, int) (int, int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreInsertInitialPathCountsFunc) appendCall(r0 StoreInsertInitialPathCountsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreInsertInitialPathCountsFuncCall
 // objects describing the invocations of this function.
 func (f *StoreInsertInitialPathCountsFunc) History() []StoreInsertInitialPathCountsFuncCall {
 	f.mutex.Lock()
@@ -1949,20 +2509,137 @@ func (f *StoreInsertPathRanksFunc) appendCall(r0 StoreInsertPathRanksFuncCall) {
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of StoreInsertPathRanksFuncCall objects
-// describing the invocations of this function.
-func (f *StoreInsertPathRanksFunc) History() []StoreInsertPathRanksFuncCall {
+// History returns a sequence of StoreInsertPathRanksFuncCall objects
+// describing the invocations of this function.
+func (f *StoreInsertPathRanksFunc) History() []StoreInsertPathRanksFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreInsertPathRanksFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreInsertPathRanksFuncCall is an object that describes an invocation of
+// method InsertPathRanks on an instance of MockStore.
+type StoreInsertPathRanksFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreInsertPathRanksFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreInsertPathRanksFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// StoreInsertReferencesForRankingFunc describes the behavior when the
+// InsertReferencesForRanking method of the parent MockStore instance is
+// invoked.
+type StoreInsertReferencesForRankingFunc struct {
+	defaultHook func(context.Context, string, int, int, chan [16]byte) error
+	hooks       []func(context.Context, string, int, int, chan [16]byte) error
+	history     []StoreInsertReferencesForRankingFuncCall
+	mutex       sync.Mutex
+}
+
+// InsertReferencesForRanking delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockStore) InsertReferencesForRanking(v0 context.Context, v1 string, v2 int, v3 int, v4 chan [16]byte) error {
+	r0 := m.InsertReferencesForRankingFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.InsertReferencesForRankingFunc.appendCall(StoreInsertReferencesForRankingFuncCall{v0, v1, v2, v3, v4, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// InsertReferencesForRanking method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreInsertReferencesForRankingFunc) SetDefaultHook(hook func(context.Context, string, int, int, chan [16]byte) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// InsertReferencesForRanking method of the parent MockStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *StoreInsertReferencesForRankingFunc) PushHook(hook func(context.Context, string, int, int, chan [16]byte) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreInsertReferencesForRankingFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, string, int, int, chan [16]byte) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreInsertReferencesForRankingFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, string, int, int, chan [16]byte) error {
+		return r0
+	})
+}
+
+func (f *StoreInsertReferencesForRankingFunc) nextHook() func(context.Context, string, int, int, chan [16]byte) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreInsertReferencesForRankingFunc) appendCall(r0 StoreInsertReferencesForRankingFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreInsertReferencesForRankingFuncCall
+// objects describing the invocations of this function.
+func (f *StoreInsertReferencesForRankingFunc) History() []StoreInsertReferencesForRankingFuncCall {
 	f.mutex.Lock()
-	history := make([]StoreInsertPathRanksFuncCall, len(f.history))
+	history := make([]StoreInsertReferencesForRankingFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// StoreInsertPathRanksFuncCall is an object that describes an invocation of
-// method InsertPathRanks on an instance of MockStore.
-type StoreInsertPathRanksFuncCall struct {
+// StoreInsertReferencesForRankingFuncCall is an object that describes an
+// invocation of method InsertReferencesForRanking on an instance of
+// MockStore.
+type StoreInsertReferencesForRankingFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -1972,60 +2649,60 @@ type StoreInsertPathRanksFuncCall struct {
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
 	Arg2 int
-	// Result0 is the value of the 1st result returned from this method
+	// Arg3 is the value of the 4th argument passed to this method
 	// invocation.
-	Result0 int
-	// Result1 is the value of the 2nd result returned from this method
+	Arg3 int
+	// Arg4 is the value of the 5th argument passed to this method
 	// invocation.
-	Result1 int
-	// Result2 is the value of the 3rd result returned from this method
+	Arg4 chan [16]byte
+	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result2 error
+	Result0 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c StoreInsertPathRanksFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+func (c StoreInsertReferencesForRankingFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c StoreInsertPathRanksFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1, c.Result2}
+func (c StoreInsertReferencesForRankingFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
 }
 
-// StoreInsertReferencesForRankingFunc describes the behavior when the
-// InsertReferencesForRanking method of the parent MockStore instance is
+// StoreInsertUnreferencedDefinitionsFunc describes the behavior when the
+// InsertUnreferencedDefinitions method of the parent MockStore instance is
 // invoked.
-type StoreInsertReferencesForRankingFunc struct {
-	defaultHook func(context.Context, string, int, int, chan [16]byte) error
-	hooks       []func(context.Context, string, int, int, chan [16]byte) error
-	history     []StoreInsertReferencesForRankingFuncCall
+type StoreInsertUnreferencedDefinitionsFunc struct {
+	defaultHook func(context.Context, string, []shared.UnreferencedDefinition) error
+	hooks       []func(context.Context, string, []shared.UnreferencedDefinition) error
+	history     []StoreInsertUnreferencedDefinitionsFuncCall
 	mutex       sync.Mutex
 }
 
-// InsertReferencesForRanking delegates to the next hook function in the
+// InsertUnreferencedDefinitions delegates to the next hook function in the
 // queue and stores the parameter and result values of this invocation.
-func (m *MockStore) InsertReferencesForRanking(v0 context.Context, v1 string, v2 int, v3 int, v4 chan [16]byte) error {
-	r0 := m.InsertReferencesForRankingFunc.nextHook()(v0, v1, v2, v3, v4)
-	m.InsertReferencesForRankingFunc.appendCall(StoreInsertReferencesForRankingFuncCall{v0, v1, v2, v3, v4, r0})
+func (m *MockStore) InsertUnreferencedDefinitions(v0 context.Context, v1 string, v2 []shared.UnreferencedDefinition) error {
+	r0 := m.InsertUnreferencedDefinitionsFunc.nextHook()(v0, v1, v2)
+	m.InsertUnreferencedDefinitionsFunc.appendCall(StoreInsertUnreferencedDefinitionsFuncCall{v0, v1, v2, r0})
 	return r0
 }
 
 // SetDefaultHook sets function that is called when the
-// InsertReferencesForRanking method of the parent MockStore instance is
+// InsertUnreferencedDefinitions method of the parent MockStore instance is
 // invoked and the hook queue is empty.
-func (f *StoreInsertReferencesForRankingFunc) SetDefaultHook(hook func(context.Context, string, int, int, chan [16]byte) error) {
+func (f *StoreInsertUnreferencedDefinitionsFunc) SetDefaultHook(hook func(context.Context, string, []shared.UnreferencedDefinition) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// InsertReferencesForRanking method of the parent MockStore instance
+// InsertUnreferencedDefinitions method of the parent MockStore instance
 // invokes the hook at the front of the queue and discards it. After the
 // queue is empty, the default hook function is invoked for any future
 // action.
-func (f *StoreInsertReferencesForRankingFunc) PushHook(hook func(context.Context, string, int, int, chan [16]byte) error) {
+func (f *StoreInsertUnreferencedDefinitionsFunc) PushHook(hook func(context.Context, string, []shared.UnreferencedDefinition) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -2033,20 +2710,20 @@ func (f *StoreInsertReferencesForRankingFunc) PushHook(hook func(context.Context
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *StoreInsertReferencesForRankingFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, string, int, int, chan [16]byte) error {
+func (f *StoreInsertUnreferencedDefinitionsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, string, []shared.UnreferencedDefinition) error {
 		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *StoreInsertReferencesForRankingFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, string, int, int, chan [16]byte) error {
+func (f *StoreInsertUnreferencedDefinitionsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, string, []shared.UnreferencedDefinition) error {
 		return r0
 	})
 }
 
-func (f *StoreInsertReferencesForRankingFunc) nextHook() func(context.Context, string, int, int, chan [16]byte) error {
+func (f *StoreInsertUnreferencedDefinitionsFunc) nextHook() func(context.Context, string, []shared.UnreferencedDefinition) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -2059,27 +2736,27 @@ func (f *StoreInsertReferencesForRankingFunc) nextHook() func(context.Context, s
 	return hook
 }
 
-func (f *StoreInsertReferencesForRankingFunc) appendCall(r0 StoreInsertReferencesForRankingFuncCall) {
+func (f *StoreInsertUnreferencedDefinitionsFunc) appendCall(r0 StoreInsertUnreferencedDefinitionsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of StoreInsertReferencesForRankingFuncCall
+// History returns a sequence of StoreInsertUnreferencedDefinitionsFuncCall
 // objects describing the invocations of this function.
-func (f *StoreInsertReferencesForRankingFunc) History() []StoreInsertReferencesForRankingFuncCall {
+func (f *StoreInsertUnreferencedDefinitionsFunc) History() []StoreInsertUnreferencedDefinitionsFuncCall {
 	f.mutex.Lock()
-	history := make([]StoreInsertReferencesForRankingFuncCall, len(f.history))
+	history := make([]StoreInsertUnreferencedDefinitionsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// StoreInsertReferencesForRankingFuncCall is an object that describes an
-// invocation of method InsertReferencesForRanking on an instance of
+// StoreInsertUnreferencedDefinitionsFuncCall is an object that describes an
+// invocation of method InsertUnreferencedDefinitions on an instance of
 // MockStore.
-type StoreInsertReferencesForRankingFuncCall struct {
+type StoreInsertUnreferencedDefinitionsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -2088,13 +2765,7 @@ type StoreInsertReferencesForRankingFuncCall struct {
 	Arg1 string
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
-	Arg2 int
-	// Arg3 is the value of the 4th argument passed to this method
-	// invocation.
-	Arg3 int
-	// Arg4 is the value of the 5th argument passed to this method
-	// invocation.
-	Arg4 chan [16]byte
+	Arg2 []shared.UnreferencedDefinition
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 error
@@ -2102,13 +2773,13 @@ type StoreInsertReferencesForRankingFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c StoreInsertReferencesForRankingFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+func (c StoreInsertUnreferencedDefinitionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c StoreInsertReferencesForRankingFuncCall) Results() []interface{} {
+func (c StoreInsertUnreferencedDefinitionsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
@@ -2662,6 +3333,120 @@ func (c StoreVacuumDeletedExportedUploadsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreVacuumStaleDeadCodeReportsFunc describes the behavior when the
+// VacuumStaleDeadCodeReports method of the parent MockStore instance is
+// invoked.
+type StoreVacuumStaleDeadCodeReportsFunc struct {
+	defaultHook func(context.Context, string, int) (int, error)
+	hooks       []func(context.Context, string, int) (int, error)
+	history     []StoreVacuumStaleDeadCodeReportsFuncCall
+	mutex       sync.Mutex
+}
+
+// VacuumStaleDeadCodeReports delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockStore) VacuumStaleDeadCodeReports(v0 context.Context, v1 string, v2 int) (int, error) {
+	r0, r1 := m.VacuumStaleDeadCodeReportsFunc.nextHook()(v0, v1, v2)
+	m.VacuumStaleDeadCodeReportsFunc.appendCall(StoreVacuumStaleDeadCodeReportsFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// VacuumStaleDeadCodeReports method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreVacuumStaleDeadCodeReportsFunc) SetDefaultHook(hook func(context.Context, string, int) (int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// VacuumStaleDeadCodeReports method of the parent MockStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *StoreVacuumStaleDeadCodeReportsFunc) PushHook(hook func(context.Context, string, int) (int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreVacuumStaleDeadCodeReportsFunc) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, int) (int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreVacuumStaleDeadCodeReportsFunc) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context, string, int) (int, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreVacuumStaleDeadCodeReportsFunc) nextHook() func(context.Context, string, int) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreVacuumStaleDeadCodeReportsFunc) appendCall(r0 StoreVacuumStaleDeadCodeReportsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreVacuumStaleDeadCodeReportsFuncCall
+// objects describing the invocations of this function.
+func (f *StoreVacuumStaleDeadCodeReportsFunc) History() []StoreVacuumStaleDeadCodeReportsFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreVacuumStaleDeadCodeReportsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreVacuumStaleDeadCodeReportsFuncCall is an object that describes an
+// invocation of method VacuumStaleDeadCodeReports on an instance of
+// MockStore.
+type StoreVacuumStaleDeadCodeReportsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreVacuumStaleDeadCodeReportsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreVacuumStaleDeadCodeReportsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // StoreVacuumStaleGraphsFunc describes the behavior when the
 // VacuumStaleGraphs method of the parent MockStore instance is invoked.
 type StoreVacuumStaleGraphsFunc struct {
@@ -3362,3 +4147,162 @@ func (c SiteConfigQuerierSiteConfigFuncCall) Args() []interface{} {
 func (c SiteConfigQuerierSiteConfigFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
+
+// MockRepoStore is a mock implementation of the RepoStore interface (from
+// the package
+// github.com/sourcegraph/sourcegraph/internal/codeintel/ranking) used for
+// unit testing.
+type MockRepoStore struct {
+	// GetReposSetByIDsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetReposSetByIDs.
+	GetReposSetByIDsFunc *RepoStoreGetReposSetByIDsFunc
+}
+
+// NewMockRepoStore creates a new mock of the RepoStore interface. All
+// methods return zero values for all results, unless overwritten.
+func NewMockRepoStore() *MockRepoStore {
+	return &MockRepoStore{
+		GetReposSetByIDsFunc: &RepoStoreGetReposSetByIDsFunc{
+			defaultHook: func(context.Context, ...api.RepoID) (r0 map[api.RepoID]*types.Repo, r1 error) {
+				return
+			},
+		},
+	}
+}
+
+// NewStrictMockRepoStore creates a new mock of the RepoStore interface. All
+// methods panic on invocation, unless overwritten.
+func NewStrictMockRepoStore() *MockRepoStore {
+	return &MockRepoStore{
+		GetReposSetByIDsFunc: &RepoStoreGetReposSetByIDsFunc{
+			defaultHook: func(context.Context, ...api.RepoID) (map[api.RepoID]*types.Repo, error) {
+				panic("unexpected invocation of MockRepoStore.GetReposSetByIDs")
+			},
+		},
+	}
+}
+
+// NewMockRepoStoreFrom creates a new mock of the MockRepoStore interface.
+// All methods delegate to the given implementation, unless overwritten.
+func NewMockRepoStoreFrom(i RepoStore) *MockRepoStore {
+	return &MockRepoStore{
+		GetReposSetByIDsFunc: &RepoStoreGetReposSetByIDsFunc{
+			defaultHook: i.GetReposSetByIDs,
+		},
+	}
+}
+
+// RepoStoreGetReposSetByIDsFunc describes the behavior when the
+// GetReposSetByIDs method of the parent MockRepoStore instance is invoked.
+type RepoStoreGetReposSetByIDsFunc struct {
+	defaultHook func(context.Context, ...api.RepoID) (map[api.RepoID]*types.Repo, error)
+	hooks       []func(context.Context, ...api.RepoID) (map[api.RepoID]*types.Repo, error)
+	history     []RepoStoreGetReposSetByIDsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetReposSetByIDs delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockRepoStore) GetReposSetByIDs(v0 context.Context, v1 ...api.RepoID) (map[api.RepoID]*types.Repo, error) {
+	r0, r1 := m.GetReposSetByIDsFunc.nextHook()(v0, v1...)
+	m.GetReposSetByIDsFunc.appendCall(RepoStoreGetReposSetByIDsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetReposSetByIDs
+// method of the parent MockRepoStore instance is invoked and the hook queue
+// is empty.
+func (f *RepoStoreGetReposSetByIDsFunc) SetDefaultHook(hook func(context.Context, ...api.RepoID) (map[api.RepoID]*types.Repo, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetReposSetByIDs method of the parent MockRepoStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *RepoStoreGetReposSetByIDsFunc) PushHook(hook func(context.Context, ...api.RepoID) (map[api.RepoID]*types.Repo, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RepoStoreGetReposSetByIDsFunc) SetDefaultReturn(r0 map[api.RepoID]*types.Repo, r1 error) {
+	f.SetDefaultHook(func(context.Context, ...api.RepoID) (map[api.RepoID]*types.Repo, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RepoStoreGetReposSetByIDsFunc) PushReturn(r0 map[api.RepoID]*types.Repo, r1 error) {
+	f.PushHook(func(context.Context, ...api.RepoID) (map[api.RepoID]*types.Repo, error) {
+		return r0, r1
+	})
+}
+
+func (f *RepoStoreGetReposSetByIDsFunc) nextHook() func(context.Context, ...api.RepoID) (map[api.RepoID]*types.Repo, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RepoStoreGetReposSetByIDsFunc) appendCall(r0 RepoStoreGetReposSetByIDsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of RepoStoreGetReposSetByIDsFuncCall objects
+// describing the invocations of this function.
+func (f *RepoStoreGetReposSetByIDsFunc) History() []RepoStoreGetReposSetByIDsFuncCall {
+	f.mutex.Lock()
+	history := make([]RepoStoreGetReposSetByIDsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RepoStoreGetReposSetByIDsFuncCall is an object that describes an
+// invocation of method GetReposSetByIDs on an instance of MockRepoStore.
+type RepoStoreGetReposSetByIDsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is a slice containing the values of the variadic arguments
+	// passed to this method invocation.
+	Arg1 []api.RepoID
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[api.RepoID]*types.Repo
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation. The variadic slice argument is flattened in this array such
+// that one positional argument and three variadic arguments would result in
+// a slice of four, not two.
+func (c RepoStoreGetReposSetByIDsFuncCall) Args() []interface{} {
+	trailing := []interface{}{}
+	for _, val := range c.Arg1 {
+		trailing = append(trailing, val)
+	}
+
+	return append([]interface{}{c.Arg0}, trailing...)
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RepoStoreGetReposSetByIDsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
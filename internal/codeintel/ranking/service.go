@@ -8,6 +8,7 @@ import (
 	"github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/internal/lsifstore"
 	internalshared "github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/internal/shared"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/internal/store"
@@ -20,25 +21,31 @@ import (
 )
 
 type Service struct {
-	store      store.Store
-	lsifstore  lsifstore.Store
-	getConf    conftypes.SiteConfigQuerier
-	operations *operations
-	logger     log.Logger
+	store               store.Store
+	lsifstore           lsifstore.Store
+	repoStore           RepoStore
+	subRepoPermsChecker authz.SubRepoPermissionChecker
+	getConf             conftypes.SiteConfigQuerier
+	operations          *operations
+	logger              log.Logger
 }
 
 func newService(
 	observationCtx *observation.Context,
 	store store.Store,
 	lsifStore lsifstore.Store,
+	repoStore RepoStore,
+	subRepoPermsChecker authz.SubRepoPermissionChecker,
 	getConf conftypes.SiteConfigQuerier,
 ) *Service {
 	return &Service{
-		store:      store,
-		lsifstore:  lsifStore,
-		getConf:    getConf,
-		operations: newOperations(observationCtx),
-		logger:     observationCtx.Logger,
+		store:               store,
+		lsifstore:           lsifStore,
+		repoStore:           repoStore,
+		subRepoPermsChecker: subRepoPermsChecker,
+		getConf:             getConf,
+		operations:          newOperations(observationCtx),
+		logger:              observationCtx.Logger,
 	}
 }
 
@@ -35,3 +35,20 @@ type RankingReferences struct {
 	ExportedUploadID int
 	SymbolChecksums  [][16]byte
 }
+
+// DeadCodeReport identifies the most recently completed set of unreferenced definitions.
+type DeadCodeReport struct {
+	GraphKey    string
+	CompletedAt time.Time
+}
+
+// UnreferencedDefinition is a definition of an exported symbol for which the ranking
+// graph found no references from any visible upload.
+type UnreferencedDefinition struct {
+	ID           int
+	RepositoryID int
+	Repository   string
+	UploadID     int
+	DocumentPath string
+	SymbolName   string
+}
@@ -0,0 +1,95 @@
+package http
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/shared"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/shared/streaming"
+)
+
+// NewDeadCodeReportHandler returns a handler that serves the most recently completed dead code
+// report as CSV. Each row is a definition of an exported symbol which no visible upload references.
+//
+// Repository visibility and sub-repo permissions are checked with the actor of the request (see
+// DeadCodeReportService.UnreferencedDefinitions).
+func NewDeadCodeReportHandler(svc DeadCodeReportService) http.Handler {
+	logger := log.Scoped("DeadCodeReportHandler", "")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		if !actor.FromContext(ctx).IsAuthenticated() {
+			http.Error(w, "authentication required", http.StatusUnauthorized)
+			return
+		}
+
+		report, ok, err := svc.DeadCodeReport(ctx)
+		if err != nil {
+			logger.Error("Failed to fetch dead code report", log.Error(err))
+			http.Error(w, fmt.Sprintf("failed to fetch dead code report: %s", err), http.StatusInternalServerError)
+			return
+		}
+		if !ok {
+			http.Error(w, "no dead code report has been generated yet", http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"dead-code-report-%s.csv\"", report.CompletedAt.UTC().Format("2006-01-02")))
+		w.Header().Set("Last-Modified", report.CompletedAt.UTC().Format(http.TimeFormat))
+
+		if err := streaming.WriteResponse(w, logger, func(w io.Writer) error {
+			return writeDeadCodeReport(w, func(f func(definition shared.UnreferencedDefinition) error) error {
+				return svc.UnreferencedDefinitions(ctx, report, f)
+			})
+		}); err != nil {
+			logger.Error("Failed to write dead code report", log.Error(err))
+			http.Error(w, fmt.Sprintf("failed to write dead code report: %s", err), http.StatusInternalServerError)
+		}
+	})
+}
+
+var deadCodeReportHeader = []string{"repository", "path", "symbol", "upload_id"}
+
+func writeDeadCodeReport(w io.Writer, definitions func(f func(definition shared.UnreferencedDefinition) error) error) error {
+	cw := csv.NewWriter(w)
+
+	// Delay writing the header until we have a row (or reach the end of the report) so that
+	// we can still send a proper error response if reading the first page fails.
+	wroteHeader := false
+	writeHeader := func() error {
+		if wroteHeader {
+			return nil
+		}
+		wroteHeader = true
+		return cw.Write(deadCodeReportHeader)
+	}
+
+	if err := definitions(func(definition shared.UnreferencedDefinition) error {
+		if err := writeHeader(); err != nil {
+			return err
+		}
+
+		return cw.Write([]string{
+			definition.Repository,
+			definition.DocumentPath,
+			definition.SymbolName,
+			strconv.Itoa(definition.UploadID),
+		})
+	}); err != nil {
+		return err
+	}
+	if err := writeHeader(); err != nil {
+		return err
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,94 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/shared"
+)
+
+func TestDeadCodeReportHandler(t *testing.T) {
+	report := shared.DeadCodeReport{GraphKey: "dev.123", CompletedAt: time.Date(2023, 9, 21, 12, 0, 0, 0, time.UTC)}
+
+	mockService := NewMockDeadCodeReportService()
+	mockService.DeadCodeReportFunc.SetDefaultReturn(report, true, nil)
+	mockService.UnreferencedDefinitionsFunc.SetDefaultHook(func(_ context.Context, r shared.DeadCodeReport, f func(definition shared.UnreferencedDefinition) error) error {
+		if r.GraphKey != report.GraphKey {
+			t.Errorf("unexpected graph key %q", r.GraphKey)
+		}
+
+		for _, definition := range []shared.UnreferencedDefinition{
+			{Repository: "github.com/test/a", DocumentPath: "cmd/main.go", SymbolName: "scip-go gomod a v1 main/unused().", UploadID: 42},
+			{Repository: "github.com/test/b", DocumentPath: "lib/b.go", SymbolName: "scip-go gomod b v1 lib/B#Old().", UploadID: 43},
+		} {
+			if err := f(definition); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	handler := NewDeadCodeReportHandler(mockService)
+
+	r, err := http.NewRequest("GET", "/codeintel/dead-code-report", nil)
+	if err != nil {
+		t.Fatalf("unexpected error constructing request: %s", err)
+	}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r.WithContext(actor.WithActor(context.Background(), actor.FromUser(1))))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected status code. want=%d have=%d body=%q", http.StatusOK, w.Code, w.Body.String())
+	}
+	if contentType := w.Header().Get("Content-Type"); contentType != "text/csv" {
+		t.Errorf("unexpected content type %q", contentType)
+	}
+	if disposition := w.Header().Get("Content-Disposition"); disposition != `attachment; filename="dead-code-report-2023-09-21.csv"` {
+		t.Errorf("unexpected content disposition %q", disposition)
+	}
+
+	expected := "" +
+		"repository,path,symbol,upload_id\n" +
+		"github.com/test/a,cmd/main.go,scip-go gomod a v1 main/unused().,42\n" +
+		"github.com/test/b,lib/b.go,scip-go gomod b v1 lib/B#Old().,43\n"
+	if diff := cmp.Diff(expected, w.Body.String()); diff != "" {
+		t.Errorf("unexpected report (-want +got):\n%s", diff)
+	}
+}
+
+func TestDeadCodeReportHandlerErrors(t *testing.T) {
+	mockService := NewMockDeadCodeReportService()
+	handler := NewDeadCodeReportHandler(mockService)
+
+	serve := func(ctx context.Context) *httptest.ResponseRecorder {
+		r, err := http.NewRequest("GET", "/codeintel/dead-code-report", nil)
+		if err != nil {
+			t.Fatalf("unexpected error constructing request: %s", err)
+		}
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r.WithContext(ctx))
+		return w
+	}
+
+	// Anonymous users
+	if w := serve(context.Background()); w.Code != http.StatusUnauthorized {
+		t.Errorf("unexpected status code. want=%d have=%d", http.StatusUnauthorized, w.Code)
+	}
+	if calls := len(mockService.DeadCodeReportFunc.History()); calls != 0 {
+		t.Errorf("unexpected number of DeadCodeReport calls. want=%d have=%d", 0, calls)
+	}
+
+	// No completed report
+	ctx := actor.WithActor(context.Background(), actor.FromUser(1))
+	if w := serve(ctx); w.Code != http.StatusNotFound {
+		t.Errorf("unexpected status code. want=%d have=%d", http.StatusNotFound, w.Code)
+	}
+}
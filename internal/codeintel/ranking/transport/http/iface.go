@@ -0,0 +1,12 @@
+package http
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/shared"
+)
+
+type DeadCodeReportService interface {
+	DeadCodeReport(ctx context.Context) (shared.DeadCodeReport, bool, error)
+	UnreferencedDefinitions(ctx context.Context, report shared.DeadCodeReport, f func(definition shared.UnreferencedDefinition) error) error
+}
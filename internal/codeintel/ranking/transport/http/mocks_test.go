@@ -0,0 +1,300 @@
+// Code generated by go-mockgen 1.3.7; DO NOT EDIT.
+//
+// This file was generated by running `sg generate` (or `go-mockgen`) at the root of
+// this repository. To add additional mocks to this or another package, add a new entry
+// to the mockgen.yaml file in the root of this repository.
+
+package http
+
+import (
+	"context"
+	"sync"
+
+	shared "github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/shared"
+)
+
+// MockDeadCodeReportService is a mock implementation of the
+// DeadCodeReportService interface (from the package
+// github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/transport/http)
+// used for unit testing.
+type MockDeadCodeReportService struct {
+	// DeadCodeReportFunc is an instance of a mock function object
+	// controlling the behavior of the method DeadCodeReport.
+	DeadCodeReportFunc *DeadCodeReportServiceDeadCodeReportFunc
+	// UnreferencedDefinitionsFunc is an instance of a mock function object
+	// controlling the behavior of the method UnreferencedDefinitions.
+	UnreferencedDefinitionsFunc *DeadCodeReportServiceUnreferencedDefinitionsFunc
+}
+
+// NewMockDeadCodeReportService creates a new mock of the
+// DeadCodeReportService interface. All methods return zero values for all
+// results, unless overwritten.
+func NewMockDeadCodeReportService() *MockDeadCodeReportService {
+	return &MockDeadCodeReportService{
+		DeadCodeReportFunc: &DeadCodeReportServiceDeadCodeReportFunc{
+			defaultHook: func(context.Context) (r0 shared.DeadCodeReport, r1 bool, r2 error) {
+				return
+			},
+		},
+		UnreferencedDefinitionsFunc: &DeadCodeReportServiceUnreferencedDefinitionsFunc{
+			defaultHook: func(context.Context, shared.DeadCodeReport, func(definition shared.UnreferencedDefinition) error) (r0 error) {
+				return
+			},
+		},
+	}
+}
+
+// NewStrictMockDeadCodeReportService creates a new mock of the
+// DeadCodeReportService interface. All methods panic on invocation, unless
+// overwritten.
+func NewStrictMockDeadCodeReportService() *MockDeadCodeReportService {
+	return &MockDeadCodeReportService{
+		DeadCodeReportFunc: &DeadCodeReportServiceDeadCodeReportFunc{
+			defaultHook: func(context.Context) (shared.DeadCodeReport, bool, error) {
+				panic("unexpected invocation of MockDeadCodeReportService.DeadCodeReport")
+			},
+		},
+		UnreferencedDefinitionsFunc: &DeadCodeReportServiceUnreferencedDefinitionsFunc{
+			defaultHook: func(context.Context, shared.DeadCodeReport, func(definition shared.UnreferencedDefinition) error) error {
+				panic("unexpected invocation of MockDeadCodeReportService.UnreferencedDefinitions")
+			},
+		},
+	}
+}
+
+// NewMockDeadCodeReportServiceFrom creates a new mock of the
+// MockDeadCodeReportService interface. All methods delegate to the given
+// implementation, unless overwritten.
+func NewMockDeadCodeReportServiceFrom(i DeadCodeReportService) *MockDeadCodeReportService {
+	return &MockDeadCodeReportService{
+		DeadCodeReportFunc: &DeadCodeReportServiceDeadCodeReportFunc{
+			defaultHook: i.DeadCodeReport,
+		},
+		UnreferencedDefinitionsFunc: &DeadCodeReportServiceUnreferencedDefinitionsFunc{
+			defaultHook: i.UnreferencedDefinitions,
+		},
+	}
+}
+
+// DeadCodeReportServiceDeadCodeReportFunc describes the behavior when the
+// DeadCodeReport method of the parent MockDeadCodeReportService instance is
+// invoked.
+type DeadCodeReportServiceDeadCodeReportFunc struct {
+	defaultHook func(context.Context) (shared.DeadCodeReport, bool, error)
+	hooks       []func(context.Context) (shared.DeadCodeReport, bool, error)
+	history     []DeadCodeReportServiceDeadCodeReportFuncCall
+	mutex       sync.Mutex
+}
+
+// DeadCodeReport delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockDeadCodeReportService) DeadCodeReport(v0 context.Context) (shared.DeadCodeReport, bool, error) {
+	r0, r1, r2 := m.DeadCodeReportFunc.nextHook()(v0)
+	m.DeadCodeReportFunc.appendCall(DeadCodeReportServiceDeadCodeReportFuncCall{v0, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the DeadCodeReport
+// method of the parent MockDeadCodeReportService instance is invoked and
+// the hook queue is empty.
+func (f *DeadCodeReportServiceDeadCodeReportFunc) SetDefaultHook(hook func(context.Context) (shared.DeadCodeReport, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeadCodeReport method of the parent MockDeadCodeReportService instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *DeadCodeReportServiceDeadCodeReportFunc) PushHook(hook func(context.Context) (shared.DeadCodeReport, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *DeadCodeReportServiceDeadCodeReportFunc) SetDefaultReturn(r0 shared.DeadCodeReport, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context) (shared.DeadCodeReport, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *DeadCodeReportServiceDeadCodeReportFunc) PushReturn(r0 shared.DeadCodeReport, r1 bool, r2 error) {
+	f.PushHook(func(context.Context) (shared.DeadCodeReport, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *DeadCodeReportServiceDeadCodeReportFunc) nextHook() func(context.Context) (shared.DeadCodeReport, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DeadCodeReportServiceDeadCodeReportFunc) appendCall(r0 DeadCodeReportServiceDeadCodeReportFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DeadCodeReportServiceDeadCodeReportFuncCall
+// objects describing the invocations of this function.
+func (f *DeadCodeReportServiceDeadCodeReportFunc) History() []DeadCodeReportServiceDeadCodeReportFuncCall {
+	f.mutex.Lock()
+	history := make([]DeadCodeReportServiceDeadCodeReportFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DeadCodeReportServiceDeadCodeReportFuncCall is an object that describes
+// an invocation of method DeadCodeReport on an instance of
+// MockDeadCodeReportService.
+type DeadCodeReportServiceDeadCodeReportFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 shared.DeadCodeReport
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DeadCodeReportServiceDeadCodeReportFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DeadCodeReportServiceDeadCodeReportFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// DeadCodeReportServiceUnreferencedDefinitionsFunc describes the behavior
+// when the UnreferencedDefinitions method of the parent
+// MockDeadCodeReportService instance is invoked.
+type DeadCodeReportServiceUnreferencedDefinitionsFunc struct {
+	defaultHook func(context.Context, shared.DeadCodeReport, func(definition shared.UnreferencedDefinition) error) error
+	hooks       []func(context.Context, shared.DeadCodeReport, func(definition shared.UnreferencedDefinition) error) error
+	history     []DeadCodeReportServiceUnreferencedDefinitionsFuncCall
+	mutex       sync.Mutex
+}
+
+// UnreferencedDefinitions delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockDeadCodeReportService) UnreferencedDefinitions(v0 context.Context, v1 shared.DeadCodeReport, v2 func(definition shared.UnreferencedDefinition) error) error {
+	r0 := m.UnreferencedDefinitionsFunc.nextHook()(v0, v1, v2)
+	m.UnreferencedDefinitionsFunc.appendCall(DeadCodeReportServiceUnreferencedDefinitionsFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// UnreferencedDefinitions method of the parent MockDeadCodeReportService
+// instance is invoked and the hook queue is empty.
+func (f *DeadCodeReportServiceUnreferencedDefinitionsFunc) SetDefaultHook(hook func(context.Context, shared.DeadCodeReport, func(definition shared.UnreferencedDefinition) error) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UnreferencedDefinitions method of the parent MockDeadCodeReportService
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *DeadCodeReportServiceUnreferencedDefinitionsFunc) PushHook(hook func(context.Context, shared.DeadCodeReport, func(definition shared.UnreferencedDefinition) error) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *DeadCodeReportServiceUnreferencedDefinitionsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, shared.DeadCodeReport, func(definition shared.UnreferencedDefinition) error) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *DeadCodeReportServiceUnreferencedDefinitionsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, shared.DeadCodeReport, func(definition shared.UnreferencedDefinition) error) error {
+		return r0
+	})
+}
+
+func (f *DeadCodeReportServiceUnreferencedDefinitionsFunc) nextHook() func(context.Context, shared.DeadCodeReport, func(definition shared.UnreferencedDefinition) error) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DeadCodeReportServiceUnreferencedDefinitionsFunc) appendCall(r0 DeadCodeReportServiceUnreferencedDefinitionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// DeadCodeReportServiceUnreferencedDefinitionsFuncCall objects describing
+// the invocations of this function.
+func (f *DeadCodeReportServiceUnreferencedDefinitionsFunc) History() []DeadCodeReportServiceUnreferencedDefinitionsFuncCall {
+	f.mutex.Lock()
+	history := make([]DeadCodeReportServiceUnreferencedDefinitionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DeadCodeReportServiceUnreferencedDefinitionsFuncCall is an object that
+// describes an invocation of method UnreferencedDefinitions on an instance
+// of MockDeadCodeReportService.
+type DeadCodeReportServiceUnreferencedDefinitionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 shared.DeadCodeReport
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 func(definition shared.UnreferencedDefinition) error
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DeadCodeReportServiceUnreferencedDefinitionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DeadCodeReportServiceUnreferencedDefinitionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
@@ -42,6 +42,7 @@ type CodeIntelConfigurationPolicy struct {
 	Name                      string
 	RepositoryID              *int32
 	RepositoryPatterns        *[]string
+	PathPatterns              *[]string
 	Type                      GitObjectType
 	Pattern                   string
 	RetentionEnabled          bool
@@ -84,6 +85,7 @@ type CodeIntelligenceConfigurationPolicyResolver interface {
 	ID() graphql.ID
 	Repository(ctx context.Context) (RepositoryResolver, error)
 	RepositoryPatterns() *[]string
+	PathPatterns() *[]string
 	Name() string
 	Type() (GitObjectType, error)
 	Pattern() string
@@ -0,0 +1,35 @@
+package streaming
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/sourcegraph/log"
+)
+
+// WriteResponse writes the body of a response with write, which streams it to the client as it is
+// produced. If write fails before anything was sent, its error is returned so that the caller can
+// still send a proper error response. Once part of the body has been sent, the status line can no
+// longer be changed, so the error is logged and the response is aborted instead, which is the only
+// way to signal to the client that the body is truncated.
+func WriteResponse(w http.ResponseWriter, logger log.Logger, write func(w io.Writer) error) error {
+	cw := &countingWriter{w: w}
+	err := write(cw)
+	if err != nil && cw.n > 0 {
+		logger.Error("Failed to write response", log.Error(err), log.Int("bytesWritten", cw.n))
+		panic(http.ErrAbortHandler)
+	}
+
+	return err
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.n += n
+	return n, err
+}
@@ -0,0 +1,49 @@
+package streaming
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestWriteResponse(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		if err := WriteResponse(w, logtest.Scoped(t), func(w io.Writer) error {
+			_, err := io.WriteString(w, "body")
+			return err
+		}); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if body := w.Body.String(); body != "body" {
+			t.Errorf("unexpected body: %q", body)
+		}
+	})
+
+	t.Run("error before writing", func(t *testing.T) {
+		if err := WriteResponse(httptest.NewRecorder(), logtest.Scoped(t), func(w io.Writer) error {
+			return errors.New("oops")
+		}); err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+
+	t.Run("error after writing", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r != http.ErrAbortHandler {
+				t.Fatalf("expected the response to be aborted, got %v", r)
+			}
+		}()
+
+		_ = WriteResponse(httptest.NewRecorder(), logtest.NoOp(t), func(w io.Writer) error {
+			_, _ = io.WriteString(w, "partial body")
+			return errors.New("oops")
+		})
+		t.Fatal("expected the response to be aborted")
+	})
+}
@@ -0,0 +1,114 @@
+package uploads
+
+import (
+	"bufio"
+	"context"
+	"io"
+
+	"github.com/sourcegraph/scip/bindings/go/scip"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ErrNoSCIPData is returned from ExportSCIPIndex when the requested upload does not exist,
+// is not visible to the current user, or has no processed SCIP data.
+var ErrNoSCIPData = errors.New("no SCIP data for upload")
+
+var (
+	indexMetadataField  = fieldNumber("metadata")
+	indexDocumentsField = fieldNumber("documents")
+)
+
+// ExportSCIPIndex writes a SCIP index for the given upload to w. The index is rebuilt from the
+// metadata and documents stored in the codeintel-db, so the original upload file does not need
+// to exist anymore.
+//
+// The index is streamed one document at a time as a sequence of top-level fields of the Index
+// message, which is equivalent to a single serialized Index. Because the original project root
+// is not retained, the exported project root is the upload root within the repository, and
+// each document path is relative to it. Symbol information for external symbols was denormalized
+// into the referencing documents during processing and is exported as part of those documents.
+//
+// ErrNoSCIPData is returned before anything is written if there is nothing to export.
+func (s *Service) ExportSCIPIndex(ctx context.Context, uploadID int, w io.Writer) (err error) {
+	ctx, _, endObservation := s.operations.exportSCIPIndex.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("uploadID", uploadID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	// 🚨 SECURITY: The store enforces that the upload's repository is visible to the current user.
+	upload, ok, err := s.store.GetUploadByID(ctx, uploadID)
+	if err != nil {
+		return err
+	}
+	if !ok || upload.State != "completed" {
+		return ErrNoSCIPData
+	}
+
+	meta, ok, err := s.lsifstore.GetSCIPMetadata(ctx, uploadID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrNoSCIPData
+	}
+
+	bw := bufio.NewWriter(w)
+	var buf []byte
+
+	if buf, err = appendIndexField(buf[:0], indexMetadataField, &scip.Metadata{
+		Version: scip.ProtocolVersion(meta.ProtocolVersion),
+		ToolInfo: &scip.ToolInfo{
+			Name:      meta.ToolName,
+			Version:   meta.ToolVersion,
+			Arguments: meta.ToolArguments,
+		},
+		ProjectRoot:          "file:///" + upload.Root,
+		TextDocumentEncoding: scip.TextEncoding(scip.TextEncoding_value[meta.TextDocumentEncoding]),
+	}); err != nil {
+		return err
+	}
+	if _, err := bw.Write(buf); err != nil {
+		return err
+	}
+
+	if err := s.lsifstore.ScanDocuments(ctx, uploadID, func(path string, document *scip.Document) (err error) {
+		// The relative path is stored outside of the document payload
+		document.RelativePath = path
+
+		if buf, err = appendIndexField(buf[:0], indexDocumentsField, document); err != nil {
+			return err
+		}
+		_, err = bw.Write(buf)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
+
+// appendIndexField appends the given message to buf, encoded as the given field of a SCIP Index.
+func appendIndexField(buf []byte, field protowire.Number, m proto.Message) ([]byte, error) {
+	payload, err := proto.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+
+	buf = protowire.AppendTag(buf, field, protowire.BytesType)
+	buf = protowire.AppendBytes(buf, payload)
+	return buf, nil
+}
+
+func fieldNumber(name string) protowire.Number {
+	field := (&scip.Index{}).ProtoReflect().Descriptor().Fields().ByName(protoreflect.Name(name))
+	if field == nil {
+		panic("unknown SCIP Index field " + name)
+	}
+	return field.Number()
+}
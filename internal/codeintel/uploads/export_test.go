@@ -0,0 +1,119 @@
+package uploads
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/internal/lsifstore"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestExportSCIPIndex(t *testing.T) {
+	mockStore := NewMockStore()
+	mockLSIFStore := NewMockLSIFStore()
+	svc := newService(&observation.TestContext, mockStore, NewMockRepoStore(), mockLSIFStore, gitserver.NewMockClient())
+
+	mockStore.GetUploadByIDFunc.SetDefaultReturn(shared.Upload{ID: 42, State: "completed", Root: "lib/"}, true, nil)
+	mockLSIFStore.GetSCIPMetadataFunc.SetDefaultReturn(lsifstore.ProcessedMetadata{
+		TextDocumentEncoding: "UTF8",
+		ToolName:             "scip-go",
+		ToolVersion:          "0.1.0",
+		ToolArguments:        []string{"--module-name", "example"},
+		ProtocolVersion:      0,
+	}, true, nil)
+	mockLSIFStore.ScanDocumentsFunc.SetDefaultHook(func(_ context.Context, _ int, f func(path string, document *scip.Document) error) error {
+		for _, path := range []string{"a.go", "b/b.go"} {
+			if err := f(path, &scip.Document{
+				Language: "go",
+				Occurrences: []*scip.Occurrence{
+					{Range: []int32{1, 2, 3}, Symbol: "scip-go gomod example v1 `example`/" + path + "."},
+				},
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	var buf bytes.Buffer
+	if err := svc.ExportSCIPIndex(context.Background(), 42, &buf); err != nil {
+		t.Fatalf("unexpected error exporting index: %s", err)
+	}
+
+	var index scip.Index
+	if err := proto.Unmarshal(buf.Bytes(), &index); err != nil {
+		t.Fatalf("exported index is not a valid SCIP index: %s", err)
+	}
+
+	expected := &scip.Index{
+		Metadata: &scip.Metadata{
+			Version: scip.ProtocolVersion_UnspecifiedProtocolVersion,
+			ToolInfo: &scip.ToolInfo{
+				Name:      "scip-go",
+				Version:   "0.1.0",
+				Arguments: []string{"--module-name", "example"},
+			},
+			ProjectRoot:          "file:///lib/",
+			TextDocumentEncoding: scip.TextEncoding_UTF8,
+		},
+		Documents: []*scip.Document{
+			{
+				Language:     "go",
+				RelativePath: "a.go",
+				Occurrences: []*scip.Occurrence{
+					{Range: []int32{1, 2, 3}, Symbol: "scip-go gomod example v1 `example`/a.go."},
+				},
+			},
+			{
+				Language:     "go",
+				RelativePath: "b/b.go",
+				Occurrences: []*scip.Occurrence{
+					{Range: []int32{1, 2, 3}, Symbol: "scip-go gomod example v1 `example`/b/b.go."},
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(expected, &index, protocmp.Transform()); diff != "" {
+		t.Errorf("unexpected index (-want +got):\n%s", diff)
+	}
+}
+
+func TestExportSCIPIndexNoData(t *testing.T) {
+	mockStore := NewMockStore()
+	mockLSIFStore := NewMockLSIFStore()
+	svc := newService(&observation.TestContext, mockStore, NewMockRepoStore(), mockLSIFStore, gitserver.NewMockClient())
+
+	// Upload not found (or not visible)
+	var buf bytes.Buffer
+	if err := svc.ExportSCIPIndex(context.Background(), 42, &buf); !errors.Is(err, ErrNoSCIPData) {
+		t.Fatalf("unexpected error. want=%q have=%q", ErrNoSCIPData, err)
+	}
+
+	// Upload not yet processed
+	mockStore.GetUploadByIDFunc.PushReturn(shared.Upload{ID: 42, State: "queued"}, true, nil)
+	if err := svc.ExportSCIPIndex(context.Background(), 42, &buf); !errors.Is(err, ErrNoSCIPData) {
+		t.Fatalf("unexpected error. want=%q have=%q", ErrNoSCIPData, err)
+	}
+
+	// Processed data deleted
+	mockStore.GetUploadByIDFunc.PushReturn(shared.Upload{ID: 42, State: "completed"}, true, nil)
+	if err := svc.ExportSCIPIndex(context.Background(), 42, &buf); !errors.Is(err, ErrNoSCIPData) {
+		t.Fatalf("unexpected error. want=%q have=%q", ErrNoSCIPData, err)
+	}
+
+	if buf.Len() != 0 {
+		t.Fatalf("expected nothing to be written, got %d bytes", buf.Len())
+	}
+	if calls := len(mockLSIFStore.ScanDocumentsFunc.History()); calls != 0 {
+		t.Fatalf("unexpected number of ScanDocuments calls. want=%d have=%d", 0, calls)
+	}
+}
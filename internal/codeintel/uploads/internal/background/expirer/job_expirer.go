@@ -270,6 +270,11 @@ func (s *expirer) isUploadProtectedByPolicy(
 		for _, commit := range commits {
 			if policyMatches, ok := commitMap[commit]; ok {
 				for _, policyMatch := range policyMatches {
+					if !policyMatch.MatchesPath(upload.Root) {
+						// Policy is scoped to paths that do not contain this upload
+						continue
+					}
+
 					if policyMatch.PolicyDuration == nil || now.Sub(upload.UploadedAt) < *policyMatch.PolicyDuration {
 						return true, nil
 					}
@@ -0,0 +1,88 @@
+package lsifstore
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func (s *store) GetSCIPMetadata(ctx context.Context, uploadID int) (_ ProcessedMetadata, _ bool, err error) {
+	ctx, _, endObservation := s.operations.getSCIPMetadata.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("uploadID", uploadID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	rows, err := s.db.Query(ctx, sqlf.Sprintf(getSCIPMetadataQuery, uploadID))
+	if err != nil {
+		return ProcessedMetadata{}, false, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	if !rows.Next() {
+		return ProcessedMetadata{}, false, nil
+	}
+
+	var meta ProcessedMetadata
+	if err := rows.Scan(
+		&meta.TextDocumentEncoding,
+		&meta.ToolName,
+		&meta.ToolVersion,
+		pq.Array(&meta.ToolArguments),
+		&meta.ProtocolVersion,
+	); err != nil {
+		return ProcessedMetadata{}, false, err
+	}
+
+	return meta, true, nil
+}
+
+const getSCIPMetadataQuery = `
+SELECT
+	m.text_document_encoding,
+	m.tool_name,
+	m.tool_version,
+	m.tool_arguments,
+	m.protocol_version
+FROM codeintel_scip_metadata m
+WHERE m.upload_id = %s
+ORDER BY m.id
+LIMIT 1
+`
+
+func (s *store) ScanDocuments(ctx context.Context, uploadID int, f func(path string, document *scip.Document) error) (err error) {
+	ctx, _, endObservation := s.operations.scanDocuments.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("uploadID", uploadID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	rows, err := s.db.Query(ctx, sqlf.Sprintf(getDocumentsByUploadIDQuery, uploadID))
+	if err != nil {
+		return err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	for rows.Next() {
+		var path string
+		var compressedSCIPPayload []byte
+		if err := rows.Scan(&path, &compressedSCIPPayload); err != nil {
+			return err
+		}
+
+		document, err := decodeSCIPDocument(compressedSCIPPayload)
+		if err != nil {
+			return err
+		}
+
+		if err := f(path, document); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package lsifstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	codeintelshared "github.com/sourcegraph/sourcegraph/internal/codeintel/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestGetSCIPMetadata(t *testing.T) {
+	logger := logtest.Scoped(t)
+	codeIntelDB := codeintelshared.NewCodeIntelDB(logger, dbtest.NewDB(logger, t))
+	store := New(&observation.TestContext, codeIntelDB)
+	ctx := context.Background()
+
+	expected := ProcessedMetadata{
+		TextDocumentEncoding: "UTF8",
+		ToolName:             "scip-test",
+		ToolVersion:          "0.1.0",
+		ToolArguments:        []string{"-p", "src"},
+		ProtocolVersion:      1,
+	}
+	if err := store.InsertMetadata(ctx, 42, expected); err != nil {
+		t.Fatalf("failed to insert metadata: %s", err)
+	}
+
+	if meta, exists, err := store.GetSCIPMetadata(ctx, 42); err != nil {
+		t.Fatalf("failed to get metadata: %s", err)
+	} else if !exists {
+		t.Fatalf("expected metadata to exist")
+	} else if diff := cmp.Diff(expected, meta); diff != "" {
+		t.Errorf("unexpected metadata (-want +got):\n%s", diff)
+	}
+
+	if _, exists, err := store.GetSCIPMetadata(ctx, 43); err != nil {
+		t.Fatalf("failed to get metadata: %s", err)
+	} else if exists {
+		t.Fatalf("expected no metadata for unknown upload")
+	}
+}
+
+func TestScanDocuments(t *testing.T) {
+	logger := logtest.Scoped(t)
+	codeIntelDB := codeintelshared.NewCodeIntelDB(logger, dbtest.NewDB(logger, t))
+	store := New(&observation.TestContext, codeIntelDB)
+	ctx := context.Background()
+
+	documents := map[string]*scip.Document{
+		"cmd/main.go": {
+			Symbols: []*scip.SymbolInformation{
+				{Symbol: "scip-go gomod example v1 main/main()."},
+			},
+			Occurrences: []*scip.Occurrence{
+				{Range: []int32{4, 5, 9}, Symbol: "scip-go gomod example v1 main/main().", SymbolRoles: int32(scip.SymbolRole_Definition)},
+				{Range: []int32{5, 1, 5, 6}, Symbol: "scip-go gomod example v1 util/Util()."},
+			},
+		},
+		"util/util.go": {
+			Symbols: []*scip.SymbolInformation{
+				{Symbol: "scip-go gomod example v1 util/Util()."},
+			},
+			Occurrences: []*scip.Occurrence{
+				{Range: []int32{2, 5, 9}, Symbol: "scip-go gomod example v1 util/Util().", SymbolRoles: int32(scip.SymbolRole_Definition)},
+			},
+		},
+	}
+
+	if err := store.WithTransaction(ctx, func(tx Store) error {
+		scipWriter, err := tx.NewSCIPWriter(ctx, 42)
+		if err != nil {
+			return err
+		}
+		for _, path := range []string{"util/util.go", "cmd/main.go"} {
+			if err := scipWriter.InsertDocument(ctx, path, documents[path]); err != nil {
+				return err
+			}
+		}
+		_, err = scipWriter.Flush(ctx)
+		return err
+	}); err != nil {
+		t.Fatalf("failed to write SCIP documents: %s", err)
+	}
+
+	var paths []string
+	if err := store.ScanDocuments(ctx, 42, func(path string, document *scip.Document) error {
+		paths = append(paths, path)
+
+		if diff := cmp.Diff(documents[path], document, protocmp.Transform()); diff != "" {
+			t.Errorf("unexpected document %q (-want +got):\n%s", path, diff)
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("failed to scan documents: %s", err)
+	}
+
+	if diff := cmp.Diff([]string{"cmd/main.go", "util/util.go"}, paths); diff != "" {
+		t.Errorf("unexpected paths (-want +got):\n%s", diff)
+	}
+}
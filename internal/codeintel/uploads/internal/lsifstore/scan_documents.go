@@ -39,16 +39,11 @@ func (s *store) InsertDefinitionsAndReferencesForDocument(
 			return err
 		}
 
-		scipPayload, err := shared.Decompressor.Decompress(bytes.NewReader(compressedSCIPPayload))
+		document, err := decodeSCIPDocument(compressedSCIPPayload)
 		if err != nil {
 			return err
 		}
-
-		var document scip.Document
-		if err := proto.Unmarshal(scipPayload, &document); err != nil {
-			return err
-		}
-		err = setDefsAndRefs(ctx, upload, rankingBatchNumber, rankingGraphKey, path, &document)
+		err = setDefsAndRefs(ctx, upload, rankingBatchNumber, rankingGraphKey, path, document)
 		if err != nil {
 			return err
 		}
@@ -57,6 +52,22 @@ func (s *store) InsertDefinitionsAndReferencesForDocument(
 	return nil
 }
 
+// decodeSCIPDocument decompresses and unmarshals a document payload as stored in the
+// codeintel_scip_documents table.
+func decodeSCIPDocument(compressedSCIPPayload []byte) (*scip.Document, error) {
+	scipPayload, err := shared.Decompressor.Decompress(bytes.NewReader(compressedSCIPPayload))
+	if err != nil {
+		return nil, err
+	}
+
+	var document scip.Document
+	if err := proto.Unmarshal(scipPayload, &document); err != nil {
+		return nil, err
+	}
+
+	return &document, nil
+}
+
 const getDocumentsByUploadIDQuery = `
 SELECT
 	sid.document_path,
@@ -28,6 +28,8 @@ type Store interface {
 	DeleteUnreferencedDocuments(ctx context.Context, batchSize int, maxAge time.Duration, now time.Time) (numScanned, numDeleted int, err error)
 
 	// Scan/export document data
+	GetSCIPMetadata(ctx context.Context, uploadID int) (ProcessedMetadata, bool, error)
+	ScanDocuments(ctx context.Context, uploadID int, f func(path string, document *scip.Document) error) error
 	InsertDefinitionsAndReferencesForDocument(ctx context.Context, upload shared.ExportedUpload, rankingGraphKey string, rankingBatchSize int, f func(ctx context.Context, upload shared.ExportedUpload, rankingBatchSize int, rankingGraphKey, path string, document *scip.Document) error) (err error)
 }
 
@@ -15,6 +15,7 @@ import (
 
 type operations struct {
 	inferClosestUploads *observation.Operation
+	exportSCIPIndex     *observation.Operation
 }
 
 var m = new(metrics.SingletonREDMetrics)
@@ -39,6 +40,7 @@ func newOperations(observationCtx *observation.Context) *operations {
 
 	return &operations{
 		inferClosestUploads: op("InferClosestUploads"),
+		exportSCIPIndex:     op("ExportSCIPIndex"),
 	}
 }
 
@@ -0,0 +1,158 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/shared/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// newExportHandler returns a handler that streams a SCIP index reconstructed from the processed
+// data of a single upload. The upload is selected either directly by the `upload` query parameter,
+// or by the `repository` and `commit` query parameters (optionally narrowed by `root` and
+// `indexerName`), in which case the upload providing precise code intelligence for that commit
+// is exported. Repository visibility is checked with the actor of the request (see
+// GetExportHandler).
+func newExportHandler(repoStore RepoStore, svc ExportService) http.Handler {
+	logger := log.Scoped("SCIPExportHandler", "")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		uploadID, statusCode, err := uploadIDFromExportRequest(ctx, repoStore, svc, r)
+		if err != nil {
+			http.Error(w, err.Error(), statusCode)
+			return
+		}
+
+		// The export headers are only set once the export has produced output, so that they
+		// are not sent along with an error response.
+		ew := &exportWriter{header: w.Header(), uploadID: uploadID}
+		err = streaming.WriteResponse(w, logger.With(log.Int("uploadID", uploadID)), func(w io.Writer) error {
+			ew.w = w
+			return svc.ExportSCIPIndex(ctx, uploadID, ew)
+		})
+		if err == nil {
+			ew.setHeaders()
+			return
+		}
+		if errors.Is(err, uploads.ErrNoSCIPData) {
+			http.Error(w, fmt.Sprintf("no precise code intelligence data for upload %d", uploadID), http.StatusNotFound)
+			return
+		}
+
+		logger.Error("Failed to export SCIP index", log.Int("uploadID", uploadID), log.Error(err))
+		http.Error(w, fmt.Sprintf("failed to export SCIP index: %s", err), http.StatusInternalServerError)
+	})
+}
+
+// exportWriter writes an exported index to the response, setting the export headers
+// before the first byte of the index is written.
+type exportWriter struct {
+	w          io.Writer
+	header     http.Header
+	uploadID   int
+	headersSet bool
+}
+
+func (w *exportWriter) Write(p []byte) (int, error) {
+	w.setHeaders()
+	return w.w.Write(p)
+}
+
+func (w *exportWriter) setHeaders() {
+	if w.headersSet {
+		return
+	}
+	w.headersSet = true
+
+	w.header.Set("Content-Type", "application/x-protobuf+scip")
+	w.header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"index-%d.scip\"", w.uploadID))
+	w.header.Set("X-Sourcegraph-Upload-ID", strconv.Itoa(w.uploadID))
+}
+
+func uploadIDFromExportRequest(ctx context.Context, repoStore RepoStore, svc ExportService, r *http.Request) (int, int, error) {
+	if rawUploadID := getQuery(r, "upload"); rawUploadID != "" {
+		uploadID, err := strconv.Atoi(rawUploadID)
+		if err != nil {
+			return 0, http.StatusBadRequest, errors.Errorf("illegal upload identifier %q", rawUploadID)
+		}
+
+		return uploadID, 0, nil
+	}
+
+	repoName := getQuery(r, "repository")
+	rev := getQuery(r, "commit")
+	if repoName == "" || rev == "" {
+		return 0, http.StatusBadRequest, errors.New("either upload or both repository and commit must be supplied")
+	}
+
+	// 🚨 SECURITY: Unlike the upload endpoint, we resolve the repository as the requesting user
+	// so that we never export data for a repository the user cannot see.
+	repo, err := repoStore.GetByName(ctx, api.RepoName(repoName))
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return 0, http.StatusNotFound, errors.Errorf("unknown repository %q", repoName)
+		}
+
+		return 0, http.StatusInternalServerError, err
+	}
+
+	commit, err := repoStore.ResolveRev(ctx, repo, rev)
+	if err != nil {
+		if errors.HasType(err, &gitdomain.RevisionNotFoundError{}) {
+			return 0, http.StatusNotFound, errors.Errorf("unknown commit %q", rev)
+		}
+		if gitdomain.IsCloneInProgress(err) {
+			return 0, http.StatusNotFound, errors.Errorf("repository %q is still cloning", repoName)
+		}
+
+		return 0, http.StatusInternalServerError, err
+	}
+
+	dumps, err := svc.InferClosestUploads(ctx, int(repo.ID), string(commit), "", true, getQuery(r, "indexerName"))
+	if err != nil {
+		return 0, http.StatusInternalServerError, err
+	}
+
+	candidates := make([]shared.Dump, 0, len(dumps))
+	for _, dump := range dumps {
+		if root := getQuery(r, "root"); root != "" && dump.Root != sanitizeRoot(root) {
+			continue
+		}
+		candidates = append(candidates, dump)
+	}
+
+	switch len(candidates) {
+	case 0:
+		return 0, http.StatusNotFound, errors.Errorf("no precise code intelligence data for %s@%s", repoName, rev)
+	case 1:
+		return candidates[0].ID, 0, nil
+	}
+
+	descriptions := make([]string, 0, len(candidates))
+	for _, dump := range candidates {
+		descriptions = append(descriptions, fmt.Sprintf("%d (root %q, indexer %q)", dump.ID, dump.Root, dump.Indexer))
+	}
+	sort.Strings(descriptions)
+
+	return 0, http.StatusBadRequest, errors.Errorf(
+		"multiple uploads provide precise code intelligence for %s@%s; select one with the root, indexerName or upload parameters: %s",
+		repoName,
+		rev,
+		strings.Join(descriptions, ", "),
+	)
+}
@@ -0,0 +1,107 @@
+package http
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestExportHandler(t *testing.T) {
+	mockRepoStore := NewMockRepoStore()
+	mockRepoStore.GetByNameFunc.SetDefaultHook(func(_ context.Context, name api.RepoName) (*types.Repo, error) {
+		if name != "github.com/test/test" {
+			return nil, &database.RepoNotFoundErr{Name: name}
+		}
+		return &types.Repo{ID: 50, Name: name}, nil
+	})
+	mockRepoStore.ResolveRevFunc.SetDefaultHook(func(_ context.Context, _ *types.Repo, rev string) (api.CommitID, error) {
+		if rev != "main" && rev != testCommit {
+			return "", &gitdomain.RevisionNotFoundError{Repo: "github.com/test/test", Spec: rev}
+		}
+		return api.CommitID(testCommit), nil
+	})
+
+	mockExportService := NewMockExportService()
+	mockExportService.InferClosestUploadsFunc.SetDefaultReturn([]shared.Dump{
+		{ID: 42, Root: "", Indexer: "scip-go"},
+		{ID: 43, Root: "web/", Indexer: "scip-typescript"},
+	}, nil)
+	mockExportService.ExportSCIPIndexFunc.SetDefaultHook(func(_ context.Context, uploadID int, w io.Writer) error {
+		if uploadID == 404 {
+			return uploads.ErrNoSCIPData
+		}
+		_, err := w.Write([]byte("index"))
+		return err
+	})
+
+	handler := newExportHandler(mockRepoStore, mockExportService)
+
+	testCases := []struct {
+		query          string
+		expectedStatus int
+		expectedUpload string
+	}{
+		{query: "upload=42", expectedStatus: http.StatusOK, expectedUpload: "42"},
+		{query: "upload=404", expectedStatus: http.StatusNotFound},
+		{query: "upload=abc", expectedStatus: http.StatusBadRequest},
+		{query: "repository=github.com/test/test", expectedStatus: http.StatusBadRequest},
+		{query: "repository=github.com/test/missing&commit=main", expectedStatus: http.StatusNotFound},
+		{query: "repository=github.com/test/test&commit=unknown", expectedStatus: http.StatusNotFound},
+		{query: "repository=github.com/test/test&commit=main", expectedStatus: http.StatusBadRequest},
+		{query: "repository=github.com/test/test&commit=main&root=web", expectedStatus: http.StatusOK, expectedUpload: "43"},
+		{query: "repository=github.com/test/test&commit=main&root=/", expectedStatus: http.StatusOK, expectedUpload: "42"},
+		{query: "repository=github.com/test/test&commit=main&root=docs/", expectedStatus: http.StatusNotFound},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.query, func(t *testing.T) {
+			r, err := http.NewRequest("GET", "/scip/export?"+testCase.query, nil)
+			if err != nil {
+				t.Fatalf("unexpected error constructing request: %s", err)
+			}
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if w.Code != testCase.expectedStatus {
+				t.Fatalf("unexpected status code. want=%d have=%d body=%q", testCase.expectedStatus, w.Code, w.Body.String())
+			}
+			if testCase.expectedStatus != http.StatusOK {
+				for _, header := range []string{"Content-Disposition", "X-Sourcegraph-Upload-ID"} {
+					if value := w.Header().Get(header); value != "" {
+						t.Errorf("unexpected %s header on error response: %q", header, value)
+					}
+				}
+				return
+			}
+
+			if uploadID := w.Header().Get("X-Sourcegraph-Upload-ID"); uploadID != testCase.expectedUpload {
+				t.Errorf("unexpected upload. want=%s have=%s", testCase.expectedUpload, uploadID)
+			}
+			if contentType := w.Header().Get("Content-Type"); contentType != "application/x-protobuf+scip" {
+				t.Errorf("unexpected content type %q", contentType)
+			}
+			if body := w.Body.String(); body != "index" {
+				t.Errorf("unexpected body %q", body)
+			}
+		})
+	}
+
+	// Ambiguous requests list the candidate uploads
+	r, _ := http.NewRequest("GET", "/scip/export?repository=github.com/test/test&commit=main", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if body := w.Body.String(); !strings.Contains(body, "42") || !strings.Contains(body, "43") {
+		t.Errorf("expected candidate uploads to be listed, got %q", body)
+	}
+}
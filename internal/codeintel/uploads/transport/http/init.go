@@ -20,6 +20,9 @@ var (
 	handler         http.Handler
 	handlerWithAuth http.Handler
 	handlerOnce     sync.Once
+
+	exportHandler     http.Handler
+	exportHandlerOnce sync.Once
 )
 
 func GetHandler(svc *uploads.Service, db database.DB, gitserverClient gitserver.Client, uploadStore uploadstore.Store, withCodeHostAuthAuth bool) http.Handler {
@@ -56,3 +59,21 @@ func GetHandler(svc *uploads.Service, db database.DB, gitserverClient gitserver.
 	}
 	return handler
 }
+
+// GetExportHandler returns a handler that streams SCIP indexes reconstructed from processed
+// upload data.
+//
+// 🚨 SECURITY: The caller MUST wrap the returned handler in middleware that checks authentication
+// and sets the actor in the request context.
+func GetExportHandler(svc *uploads.Service, db database.DB, gitserverClient gitserver.Client) http.Handler {
+	exportHandlerOnce.Do(func() {
+		logger := log.Scoped(
+			"uploads.exporthandler",
+			"codeintel SCIP index export http handler",
+		)
+
+		exportHandler = newExportHandler(backend.NewRepos(logger, db, gitserverClient), svc)
+	})
+
+	return exportHandler
+}
@@ -8,8 +8,12 @@ package http
 
 import (
 	"context"
+	"io"
 	"sync"
 
+	api "github.com/sourcegraph/sourcegraph/internal/api"
+	shared "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	types "github.com/sourcegraph/sourcegraph/internal/types"
 	uploadhandler "github.com/sourcegraph/sourcegraph/internal/uploadhandler"
 )
 
@@ -781,3 +785,573 @@ func (c DBStoreWithTransactionFuncCall[T]) Args() []interface{} {
 func (c DBStoreWithTransactionFuncCall[T]) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
+
+// MockExportService is a mock implementation of the ExportService interface
+// (from the package
+// github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/transport/http)
+// used for unit testing.
+type MockExportService struct {
+	// ExportSCIPIndexFunc is an instance of a mock function object
+	// controlling the behavior of the method ExportSCIPIndex.
+	ExportSCIPIndexFunc *ExportServiceExportSCIPIndexFunc
+	// InferClosestUploadsFunc is an instance of a mock function object
+	// controlling the behavior of the method InferClosestUploads.
+	InferClosestUploadsFunc *ExportServiceInferClosestUploadsFunc
+}
+
+// NewMockExportService creates a new mock of the ExportService interface.
+// All methods return zero values for all results, unless overwritten.
+func NewMockExportService() *MockExportService {
+	return &MockExportService{
+		ExportSCIPIndexFunc: &ExportServiceExportSCIPIndexFunc{
+			defaultHook: func(context.Context, int, io.Writer) (r0 error) {
+				return
+			},
+		},
+		InferClosestUploadsFunc: &ExportServiceInferClosestUploadsFunc{
+			defaultHook: func(context.Context, int, string, string, bool, string) (r0 []shared.Dump, r1 error) {
+				return
+			},
+		},
+	}
+}
+
+// NewStrictMockExportService creates a new mock of the ExportService
+// interface. All methods panic on invocation, unless overwritten.
+func NewStrictMockExportService() *MockExportService {
+	return &MockExportService{
+		ExportSCIPIndexFunc: &ExportServiceExportSCIPIndexFunc{
+			defaultHook: func(context.Context, int, io.Writer) error {
+				panic("unexpected invocation of MockExportService.ExportSCIPIndex")
+			},
+		},
+		InferClosestUploadsFunc: &ExportServiceInferClosestUploadsFunc{
+			defaultHook: func(context.Context, int, string, string, bool, string) ([]shared.Dump, error) {
+				panic("unexpected invocation of MockExportService.InferClosestUploads")
+			},
+		},
+	}
+}
+
+// NewMockExportServiceFrom creates a new mock of the MockExportService
+// interface. All methods delegate to the given implementation, unless
+// overwritten.
+func NewMockExportServiceFrom(i ExportService) *MockExportService {
+	return &MockExportService{
+		ExportSCIPIndexFunc: &ExportServiceExportSCIPIndexFunc{
+			defaultHook: i.ExportSCIPIndex,
+		},
+		InferClosestUploadsFunc: &ExportServiceInferClosestUploadsFunc{
+			defaultHook: i.InferClosestUploads,
+		},
+	}
+}
+
+// ExportServiceExportSCIPIndexFunc describes the behavior when the
+// ExportSCIPIndex method of the parent MockExportService instance is
+// invoked.
+type ExportServiceExportSCIPIndexFunc struct {
+	defaultHook func(context.Context, int, io.Writer) error
+	hooks       []func(context.Context, int, io.Writer) error
+	history     []ExportServiceExportSCIPIndexFuncCall
+	mutex       sync.Mutex
+}
+
+// ExportSCIPIndex delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockExportService) ExportSCIPIndex(v0 context.Context, v1 int, v2 io.Writer) error {
+	r0 := m.ExportSCIPIndexFunc.nextHook()(v0, v1, v2)
+	m.ExportSCIPIndexFunc.appendCall(ExportServiceExportSCIPIndexFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the ExportSCIPIndex
+// method of the parent MockExportService instance is invoked and the hook
+// queue is empty.
+func (f *ExportServiceExportSCIPIndexFunc) SetDefaultHook(hook func(context.Context, int, io.Writer) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ExportSCIPIndex method of the parent MockExportService instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *ExportServiceExportSCIPIndexFunc) PushHook(hook func(context.Context, int, io.Writer) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ExportServiceExportSCIPIndexFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, io.Writer) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ExportServiceExportSCIPIndexFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, io.Writer) error {
+		return r0
+	})
+}
+
+func (f *ExportServiceExportSCIPIndexFunc) nextHook() func(context.Context, int, io.Writer) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ExportServiceExportSCIPIndexFunc) appendCall(r0 ExportServiceExportSCIPIndexFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ExportServiceExportSCIPIndexFuncCall
+// objects describing the invocations of this function.
+func (f *ExportServiceExportSCIPIndexFunc) History() []ExportServiceExportSCIPIndexFuncCall {
+	f.mutex.Lock()
+	history := make([]ExportServiceExportSCIPIndexFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ExportServiceExportSCIPIndexFuncCall is an object that describes an
+// invocation of method ExportSCIPIndex on an instance of MockExportService.
+type ExportServiceExportSCIPIndexFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 io.Writer
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ExportServiceExportSCIPIndexFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ExportServiceExportSCIPIndexFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ExportServiceInferClosestUploadsFunc describes the behavior when the
+// InferClosestUploads method of the parent MockExportService instance is
+// invoked.
+type ExportServiceInferClosestUploadsFunc struct {
+	defaultHook func(context.Context, int, string, string, bool, string) ([]shared.Dump, error)
+	hooks       []func(context.Context, int, string, string, bool, string) ([]shared.Dump, error)
+	history     []ExportServiceInferClosestUploadsFuncCall
+	mutex       sync.Mutex
+}
+
+// InferClosestUploads delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockExportService) InferClosestUploads(v0 context.Context, v1 int, v2 string, v3 string, v4 bool, v5 string) ([]shared.Dump, error) {
+	r0, r1 := m.InferClosestUploadsFunc.nextHook()(v0, v1, v2, v3, v4, v5)
+	m.InferClosestUploadsFunc.appendCall(ExportServiceInferClosestUploadsFuncCall{v0, v1, v2, v3, v4, v5, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the InferClosestUploads
+// method of the parent MockExportService instance is invoked and the hook
+// queue is empty.
+func (f *ExportServiceInferClosestUploadsFunc) SetDefaultHook(hook func(context.Context, int, string, string, bool, string) ([]shared.Dump, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// InferClosestUploads method of the parent MockExportService instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *ExportServiceInferClosestUploadsFunc) PushHook(hook func(context.Context, int, string, string, bool, string) ([]shared.Dump, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ExportServiceInferClosestUploadsFunc) SetDefaultReturn(r0 []shared.Dump, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, string, string, bool, string) ([]shared.Dump, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ExportServiceInferClosestUploadsFunc) PushReturn(r0 []shared.Dump, r1 error) {
+	f.PushHook(func(context.Context, int, string, string, bool, string) ([]shared.Dump, error) {
+		return r0, r1
+	})
+}
+
+func (f *ExportServiceInferClosestUploadsFunc) nextHook() func(context.Context, int, string, string, bool, string) ([]shared.Dump, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ExportServiceInferClosestUploadsFunc) appendCall(r0 ExportServiceInferClosestUploadsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ExportServiceInferClosestUploadsFuncCall
+// objects describing the invocations of this function.
+func (f *ExportServiceInferClosestUploadsFunc) History() []ExportServiceInferClosestUploadsFuncCall {
+	f.mutex.Lock()
+	history := make([]ExportServiceInferClosestUploadsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ExportServiceInferClosestUploadsFuncCall is an object that describes an
+// invocation of method InferClosestUploads on an instance of
+// MockExportService.
+type ExportServiceInferClosestUploadsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 bool
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []shared.Dump
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ExportServiceInferClosestUploadsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ExportServiceInferClosestUploadsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// MockRepoStore is a mock implementation of the RepoStore interface (from
+// the package
+// github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/transport/http)
+// used for unit testing.
+type MockRepoStore struct {
+	// GetByNameFunc is an instance of a mock function object controlling
+	// the behavior of the method GetByName.
+	GetByNameFunc *RepoStoreGetByNameFunc
+	// ResolveRevFunc is an instance of a mock function object controlling
+	// the behavior of the method ResolveRev.
+	ResolveRevFunc *RepoStoreResolveRevFunc
+}
+
+// NewMockRepoStore creates a new mock of the RepoStore interface. All
+// methods return zero values for all results, unless overwritten.
+func NewMockRepoStore() *MockRepoStore {
+	return &MockRepoStore{
+		GetByNameFunc: &RepoStoreGetByNameFunc{
+			defaultHook: func(context.Context, api.RepoName) (r0 *types.Repo, r1 error) {
+				return
+			},
+		},
+		ResolveRevFunc: &RepoStoreResolveRevFunc{
+			defaultHook: func(context.Context, *types.Repo, string) (r0 api.CommitID, r1 error) {
+				return
+			},
+		},
+	}
+}
+
+// NewStrictMockRepoStore creates a new mock of the RepoStore interface. All
+// methods panic on invocation, unless overwritten.
+func NewStrictMockRepoStore() *MockRepoStore {
+	return &MockRepoStore{
+		GetByNameFunc: &RepoStoreGetByNameFunc{
+			defaultHook: func(context.Context, api.RepoName) (*types.Repo, error) {
+				panic("unexpected invocation of MockRepoStore.GetByName")
+			},
+		},
+		ResolveRevFunc: &RepoStoreResolveRevFunc{
+			defaultHook: func(context.Context, *types.Repo, string) (api.CommitID, error) {
+				panic("unexpected invocation of MockRepoStore.ResolveRev")
+			},
+		},
+	}
+}
+
+// NewMockRepoStoreFrom creates a new mock of the MockRepoStore interface.
+// All methods delegate to the given implementation, unless overwritten.
+func NewMockRepoStoreFrom(i RepoStore) *MockRepoStore {
+	return &MockRepoStore{
+		GetByNameFunc: &RepoStoreGetByNameFunc{
+			defaultHook: i.GetByName,
+		},
+		ResolveRevFunc: &RepoStoreResolveRevFunc{
+			defaultHook: i.ResolveRev,
+		},
+	}
+}
+
+// RepoStoreGetByNameFunc describes the behavior when the GetByName method
+// of the parent MockRepoStore instance is invoked.
+type RepoStoreGetByNameFunc struct {
+	defaultHook func(context.Context, api.RepoName) (*types.Repo, error)
+	hooks       []func(context.Context, api.RepoName) (*types.Repo, error)
+	history     []RepoStoreGetByNameFuncCall
+	mutex       sync.Mutex
+}
+
+// GetByName delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockRepoStore) GetByName(v0 context.Context, v1 api.RepoName) (*types.Repo, error) {
+	r0, r1 := m.GetByNameFunc.nextHook()(v0, v1)
+	m.GetByNameFunc.appendCall(RepoStoreGetByNameFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetByName method of
+// the parent MockRepoStore instance is invoked and the hook queue is empty.
+func (f *RepoStoreGetByNameFunc) SetDefaultHook(hook func(context.Context, api.RepoName) (*types.Repo, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetByName method of the parent MockRepoStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *RepoStoreGetByNameFunc) PushHook(hook func(context.Context, api.RepoName) (*types.Repo, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RepoStoreGetByNameFunc) SetDefaultReturn(r0 *types.Repo, r1 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoName) (*types.Repo, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RepoStoreGetByNameFunc) PushReturn(r0 *types.Repo, r1 error) {
+	f.PushHook(func(context.Context, api.RepoName) (*types.Repo, error) {
+		return r0, r1
+	})
+}
+
+func (f *RepoStoreGetByNameFunc) nextHook() func(context.Context, api.RepoName) (*types.Repo, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RepoStoreGetByNameFunc) appendCall(r0 RepoStoreGetByNameFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of RepoStoreGetByNameFuncCall objects
+// describing the invocations of this function.
+func (f *RepoStoreGetByNameFunc) History() []RepoStoreGetByNameFuncCall {
+	f.mutex.Lock()
+	history := make([]RepoStoreGetByNameFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RepoStoreGetByNameFuncCall is an object that describes an invocation of
+// method GetByName on an instance of MockRepoStore.
+type RepoStoreGetByNameFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoName
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *types.Repo
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c RepoStoreGetByNameFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RepoStoreGetByNameFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// RepoStoreResolveRevFunc describes the behavior when the ResolveRev method
+// of the parent MockRepoStore instance is invoked.
+type RepoStoreResolveRevFunc struct {
+	defaultHook func(context.Context, *types.Repo, string) (api.CommitID, error)
+	hooks       []func(context.Context, *types.Repo, string) (api.CommitID, error)
+	history     []RepoStoreResolveRevFuncCall
+	mutex       sync.Mutex
+}
+
+// ResolveRev delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockRepoStore) ResolveRev(v0 context.Context, v1 *types.Repo, v2 string) (api.CommitID, error) {
+	r0, r1 := m.ResolveRevFunc.nextHook()(v0, v1, v2)
+	m.ResolveRevFunc.appendCall(RepoStoreResolveRevFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ResolveRev method of
+// the parent MockRepoStore instance is invoked and the hook queue is empty.
+func (f *RepoStoreResolveRevFunc) SetDefaultHook(hook func(context.Context, *types.Repo, string) (api.CommitID, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ResolveRev method of the parent MockRepoStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *RepoStoreResolveRevFunc) PushHook(hook func(context.Context, *types.Repo, string) (api.CommitID, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RepoStoreResolveRevFunc) SetDefaultReturn(r0 api.CommitID, r1 error) {
+	f.SetDefaultHook(func(context.Context, *types.Repo, string) (api.CommitID, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RepoStoreResolveRevFunc) PushReturn(r0 api.CommitID, r1 error) {
+	f.PushHook(func(context.Context, *types.Repo, string) (api.CommitID, error) {
+		return r0, r1
+	})
+}
+
+func (f *RepoStoreResolveRevFunc) nextHook() func(context.Context, *types.Repo, string) (api.CommitID, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RepoStoreResolveRevFunc) appendCall(r0 RepoStoreResolveRevFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of RepoStoreResolveRevFuncCall objects
+// describing the invocations of this function.
+func (f *RepoStoreResolveRevFunc) History() []RepoStoreResolveRevFuncCall {
+	f.mutex.Lock()
+	history := make([]RepoStoreResolveRevFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RepoStoreResolveRevFuncCall is an object that describes an invocation of
+// method ResolveRev on an instance of MockRepoStore.
+type RepoStoreResolveRevFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *types.Repo
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 api.CommitID
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c RepoStoreResolveRevFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RepoStoreResolveRevFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
@@ -31,7 +31,7 @@ func SendEmailForNewSearchResult(ctx context.Context, db database.DB, userID int
 	if MockSendEmailForNewSearchResult != nil {
 		return MockSendEmailForNewSearchResult(ctx, db, userID, data)
 	}
-	return sendEmail(ctx, db, userID, newSearchResultsEmailTemplates, data)
+	return SendEmail(ctx, db, userID, "code-monitor", newSearchResultsEmailTemplates, data)
 }
 
 var (
@@ -120,7 +120,9 @@ func NewTestTemplateDataForNewSearchResults(monitorDescription string) *Template
 	}
 }
 
-func sendEmail(ctx context.Context, db database.DB, userID int32, template txtypes.Templates, data any) error {
+// SendEmail sends an email rendered from template to the verified primary email address of the given user. It is
+// also used to deliver code insights alerts, which share the action types of code monitors.
+func SendEmail(ctx context.Context, db database.DB, userID int32, source string, template txtypes.Templates, data any) error {
 	email, verified, err := db.UserEmails().GetPrimaryEmail(ctx, userID)
 	if err != nil {
 		if errcode.IsNotFound(err) {
@@ -132,7 +134,7 @@ func sendEmail(ctx context.Context, db database.DB, userID int32, template txtyp
 		return errors.Newf("unable to send email to user ID %d's unverified primary email address", userID)
 	}
 
-	if err := txemail.Send(ctx, "code-monitor", txtypes.Message{
+	if err := txemail.Send(ctx, source, txtypes.Message{
 		To:       []string{email},
 		Template: template,
 		Data:     data,
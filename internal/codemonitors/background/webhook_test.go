@@ -38,7 +38,7 @@ func TestWebhook(t *testing.T) {
 		defer s.Close()
 
 		client := s.Client()
-		err := postWebhook(context.Background(), client, s.URL, generateWebhookPayload(action))
+		err := PostWebhook(context.Background(), client, s.URL, generateWebhookPayload(action))
 		require.NoError(t, err)
 	})
 
@@ -64,7 +64,7 @@ func TestWebhook(t *testing.T) {
 		defer s.Close()
 
 		client := s.Client()
-		err := postWebhook(context.Background(), client, s.URL, generateWebhookPayload(action))
+		err := PostWebhook(context.Background(), client, s.URL, generateWebhookPayload(action))
 		require.Error(t, err)
 	})
 }
@@ -20,6 +20,7 @@ import (
 	vdb "github.com/sourcegraph/sourcegraph/internal/embeddings/db"
 	"github.com/sourcegraph/sourcegraph/internal/embeddings/embed"
 	"github.com/sourcegraph/sourcegraph/internal/featureflag"
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
 	"github.com/sourcegraph/sourcegraph/internal/metrics"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/search"
@@ -40,7 +41,7 @@ type FileChunkContext struct {
 	EndLine   int
 }
 
-func NewCodyContextClient(obsCtx *observation.Context, db database.DB, embeddingsClient embeddings.Client, searchClient client.SearchClient, getQdrantSearcher func() (vdb.VectorSearcher, error)) *CodyContextClient {
+func NewCodyContextClient(obsCtx *observation.Context, db database.DB, embeddingsClient embeddings.Client, searchClient client.SearchClient, getQdrantSearcher func() (vdb.VectorSearcher, error), fusionOptions FusionOptions) *CodyContextClient {
 	redMetrics := metrics.NewREDMetrics(
 		obsCtx.Registerer,
 		"codycontext_client",
@@ -63,11 +64,14 @@ func NewCodyContextClient(obsCtx *observation.Context, db database.DB, embedding
 		embeddingsClient:  embeddingsClient,
 		searchClient:      searchClient,
 		getQdrantSearcher: getQdrantSearcher,
+		fusionOptions:     fusionOptions,
 
 		obsCtx:                 obsCtx,
 		getCodyContextOp:       op("getCodyContext"),
+		getHybridContextOp:     op("getHybridContext"),
 		getEmbeddingsContextOp: op("getEmbeddingsContext"),
 		getKeywordContextOp:    op("getKeywordContext"),
+		getSymbolContextOp:     op("getSymbolContext"),
 	}
 }
 
@@ -76,11 +80,14 @@ type CodyContextClient struct {
 	embeddingsClient  embeddings.Client
 	searchClient      client.SearchClient
 	getQdrantSearcher func() (vdb.VectorSearcher, error)
+	fusionOptions     FusionOptions
 
 	obsCtx                 *observation.Context
 	getCodyContextOp       *observation.Operation
+	getHybridContextOp     *observation.Operation
 	getEmbeddingsContextOp *observation.Operation
 	getKeywordContextOp    *observation.Operation
+	getSymbolContextOp     *observation.Operation
 }
 
 type GetContextArgs struct {
@@ -116,6 +123,10 @@ func (c *CodyContextClient) GetCodyContext(ctx context.Context, args GetContextA
 		return nil, err
 	}
 
+	if featureflag.FromContext(ctx).GetBoolOr("cody-context-hybrid", false) {
+		return c.getHybridContext(ctx, args, embeddingRepos)
+	}
+
 	// NOTE: We use a pretty simple heuristic for combining results from
 	// embeddings and keyword search. We use the ratio of repos with embeddings
 	// to decide how many results out of our limit should be reserved for
@@ -137,16 +148,55 @@ func (c *CodyContextClient) GetCodyContext(ctx context.Context, args GetContextA
 		TextResultsCount: args.TextResultsCount - embeddingsArgs.TextResultsCount,
 	}
 
-	var embeddingsResults, keywordResults []FileChunkContext
+	var embeddingsCode, embeddingsText, keywordCode, keywordText []FileChunkContext
 
 	// Fetch keyword results and embeddings results concurrently
 	p := pool.New().WithErrors()
 	p.Go(func() (err error) {
-		embeddingsResults, err = c.getEmbeddingsContext(ctx, embeddingsArgs)
+		embeddingsCode, embeddingsText, err = c.getEmbeddingsContext(ctx, embeddingsArgs)
+		return err
+	})
+	p.Go(func() (err error) {
+		keywordCode, keywordText, err = c.getKeywordContext(ctx, keywordArgs)
+		return err
+	})
+
+	err = p.Wait()
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]FileChunkContext, 0, len(embeddingsCode)+len(embeddingsText)+len(keywordCode)+len(keywordText))
+	results = append(results, embeddingsCode...)
+	results = append(results, embeddingsText...)
+	results = append(results, keywordCode...)
+	return append(results, keywordText...), nil
+}
+
+// getHybridContext searches all repos with keyword and symbol search, and the repos with
+// embeddings with embeddings search, then merges the results of each search with reciprocal
+// rank fusion. Unlike the default mode, every search is given the whole result budget, as the
+// fusion decides which results to keep.
+func (c *CodyContextClient) getHybridContext(ctx context.Context, args GetContextArgs, embeddingRepos []types.RepoIDName) (_ []FileChunkContext, err error) {
+	ctx, _, endObservation := c.getHybridContextOp.With(ctx, &err, observation.Args{Attrs: args.Attrs()})
+	defer endObservation(1, observation.Args{})
+
+	embeddingsArgs := args
+	embeddingsArgs.Repos = embeddingRepos
+
+	var embeddingsCode, embeddingsText, keywordCode, keywordText, symbolCode []FileChunkContext
+
+	p := pool.New().WithErrors()
+	p.Go(func() (err error) {
+		embeddingsCode, embeddingsText, err = c.getEmbeddingsContext(ctx, embeddingsArgs)
+		return err
+	})
+	p.Go(func() (err error) {
+		keywordCode, keywordText, err = c.getKeywordContext(ctx, args)
 		return err
 	})
 	p.Go(func() (err error) {
-		keywordResults, err = c.getKeywordContext(ctx, keywordArgs)
+		symbolCode, err = c.getSymbolContext(ctx, args)
 		return err
 	})
 
@@ -155,7 +205,18 @@ func (c *CodyContextClient) GetCodyContext(ctx context.Context, args GetContextA
 		return nil, err
 	}
 
-	return append(embeddingsResults, keywordResults...), nil
+	options := c.fusionOptions
+	code := FuseResults(options.K, int(args.CodeResultsCount),
+		RankedResults{Weight: options.EmbeddingsWeight, Results: embeddingsCode},
+		RankedResults{Weight: options.KeywordWeight, Results: keywordCode},
+		RankedResults{Weight: options.SymbolWeight, Results: symbolCode},
+	)
+	text := FuseResults(options.K, int(args.TextResultsCount),
+		RankedResults{Weight: options.EmbeddingsWeight, Results: embeddingsText},
+		RankedResults{Weight: options.KeywordWeight, Results: keywordText},
+	)
+
+	return append(code, text...), nil
 }
 
 // partitionRepos splits a set of repos into repos with embeddings and repos without embeddings
@@ -175,13 +236,14 @@ func (c *CodyContextClient) partitionRepos(ctx context.Context, input []types.Re
 	return embedded, notEmbedded, nil
 }
 
-func (c *CodyContextClient) getEmbeddingsContext(ctx context.Context, args GetContextArgs) (_ []FileChunkContext, err error) {
+// getEmbeddingsContext uses embeddings search to find relevant bits of code and text for Cody
+func (c *CodyContextClient) getEmbeddingsContext(ctx context.Context, args GetContextArgs) (code, text []FileChunkContext, err error) {
 	ctx, _, endObservation := c.getEmbeddingsContextOp.With(ctx, &err, observation.Args{Attrs: args.Attrs()})
 	defer endObservation(1, observation.Args{})
 
 	if len(args.Repos) == 0 || (args.CodeResultsCount == 0 && args.TextResultsCount == 0) {
 		// Don't bother doing an API request if we can't actually have any results.
-		return nil, nil
+		return nil, nil, nil
 	}
 
 	if featureflag.FromContext(ctx).GetBoolOr("qdrant", false) {
@@ -203,7 +265,7 @@ func (c *CodyContextClient) getEmbeddingsContext(ctx context.Context, args GetCo
 		TextResultsCount: int(args.TextResultsCount),
 	})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	idsByName := make(map[api.RepoName]api.RepoID)
@@ -211,18 +273,21 @@ func (c *CodyContextClient) getEmbeddingsContext(ctx context.Context, args GetCo
 		idsByName[repoName] = repoIDs[i]
 	}
 
-	res := make([]FileChunkContext, 0, len(results.CodeResults)+len(results.TextResults))
-	for _, result := range append(results.CodeResults, results.TextResults...) {
-		res = append(res, FileChunkContext{
-			RepoName:  result.RepoName,
-			RepoID:    idsByName[result.RepoName],
-			CommitID:  result.Revision,
-			Path:      result.FileName,
-			StartLine: result.StartLine,
-			EndLine:   result.EndLine,
-		})
+	convert := func(results []embeddings.EmbeddingSearchResult) []FileChunkContext {
+		res := make([]FileChunkContext, 0, len(results))
+		for _, result := range results {
+			res = append(res, FileChunkContext{
+				RepoName:  result.RepoName,
+				RepoID:    idsByName[result.RepoName],
+				CommitID:  result.Revision,
+				Path:      result.FileName,
+				StartLine: result.StartLine,
+				EndLine:   result.EndLine,
+			})
+		}
+		return res
 	}
-	return res, nil
+	return convert(results.CodeResults), convert(results.TextResults), nil
 }
 
 var textFileFilter = func() string {
@@ -233,8 +298,8 @@ var textFileFilter = func() string {
 	return `file:(` + strings.Join(extensions, "|") + `)$`
 }()
 
-// getKeywordContext uses keyword search to find relevant bits of context for Cody
-func (c *CodyContextClient) getKeywordContext(ctx context.Context, args GetContextArgs) (_ []FileChunkContext, err error) {
+// getKeywordContext uses keyword search to find relevant bits of code and text for Cody
+func (c *CodyContextClient) getKeywordContext(ctx context.Context, args GetContextArgs) (code, text []FileChunkContext, err error) {
 	ctx, _, endObservation := c.getKeywordContextOp.With(ctx, &err, observation.Args{Attrs: args.Attrs()})
 	defer endObservation(1, observation.Args{})
 
@@ -242,107 +307,160 @@ func (c *CodyContextClient) getKeywordContext(ctx context.Context, args GetConte
 		// TODO(camdencheek): for some reason the search query `repo:^$`
 		// returns all repos, not zero repos, causing searches over zero repos
 		// to break in unexpected ways.
+		return nil, nil, nil
+	}
+
+	repoFilter := repoFilter(args.Repos)
+	textQuery := fmt.Sprintf(`%s %s content:%s`, repoFilter, textFileFilter, strconv.Quote(args.Query))
+	codeQuery := fmt.Sprintf(`%s -%s content:%s`, repoFilter, textFileFilter, strconv.Quote(args.Query))
+
+	p := pool.NewWithResults[[]FileChunkContext]().WithContext(ctx)
+	p.Go(func(ctx context.Context) ([]FileChunkContext, error) {
+		return c.search(ctx, "keyword", codeQuery, int(args.CodeResultsCount), fileMatchToContextMatches)
+	})
+	p.Go(func(ctx context.Context) ([]FileChunkContext, error) {
+		return c.search(ctx, "keyword", textQuery, int(args.TextResultsCount), fileMatchToContextMatches)
+	})
+	results, err := p.Wait()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return results[0], results[1], nil
+}
+
+// maxSymbolQueryIdentifiers bounds the number of identifiers of a query that are looked up with
+// symbol search.
+const maxSymbolQueryIdentifiers = 5
+
+// identifierPattern matches words of a query that look like identifiers in code: words that
+// contain an underscore or an uppercase letter after their first letter, such as `InternalDoer`
+// or `repo_id`.
+var identifierPattern = lazyregexp.New(`\b[A-Za-z_][a-z0-9_]*(?:[A-Z_][A-Za-z0-9_]*)+\b`)
+
+// getSymbolContext uses symbol search to find the definitions of identifiers mentioned in the
+// query. Only code is searched, as text files have no symbols.
+func (c *CodyContextClient) getSymbolContext(ctx context.Context, args GetContextArgs) (_ []FileChunkContext, err error) {
+	ctx, _, endObservation := c.getSymbolContextOp.With(ctx, &err, observation.Args{Attrs: args.Attrs()})
+	defer endObservation(1, observation.Args{})
+
+	identifiers := queryIdentifiers(args.Query)
+	if len(args.Repos) == 0 || len(identifiers) == 0 {
 		return nil, nil
 	}
 
-	// mini-HACK: pass in the scope using repo: filters. In an ideal world, we
-	// would not be using query text manipulation for this and would be using
-	// the job structs directly.
-	regexEscapedRepoNames := make([]string, len(args.Repos))
-	for i, repo := range args.Repos {
-		regexEscapedRepoNames[i] = regexp.QuoteMeta(string(repo.Name))
+	escapedIdentifiers := make([]string, len(identifiers))
+	for i, identifier := range identifiers {
+		escapedIdentifiers[i] = regexp.QuoteMeta(identifier)
 	}
 
-	textQuery := fmt.Sprintf(`repo:^%s$ %s content:%s`, query.UnionRegExps(regexEscapedRepoNames), textFileFilter, strconv.Quote(args.Query))
-	codeQuery := fmt.Sprintf(`repo:^%s$ -%s content:%s`, query.UnionRegExps(regexEscapedRepoNames), textFileFilter, strconv.Quote(args.Query))
+	symbolQuery := fmt.Sprintf(`%s -%s type:symbol ^%s$`, repoFilter(args.Repos), textFileFilter, query.UnionRegExps(escapedIdentifiers))
+	return c.search(ctx, "regexp", symbolQuery, int(args.CodeResultsCount), symbolMatchesToContextMatches)
+}
 
-	doSearch := func(ctx context.Context, query string, limit int) ([]FileChunkContext, error) {
-		if limit == 0 {
-			// Skip a search entirely if the limit is zero.
-			return nil, nil
+// queryIdentifiers returns the distinct identifiers of the query, in order of appearance.
+func queryIdentifiers(query string) []string {
+	seen := map[string]struct{}{}
+	var identifiers []string
+	for _, identifier := range identifierPattern.FindAllString(query, -1) {
+		if _, ok := seen[identifier]; ok {
+			continue
 		}
+		seen[identifier] = struct{}{}
 
-		ctx, cancel := context.WithCancel(ctx)
-		defer cancel()
-
-		patternTypeKeyword := "keyword"
-		plan, err := c.searchClient.Plan(
-			ctx,
-			"V3",
-			&patternTypeKeyword,
-			query,
-			search.Precise,
-			search.Streaming,
-		)
-		if err != nil {
-			return nil, err
+		identifiers = append(identifiers, identifier)
+		if len(identifiers) == maxSymbolQueryIdentifiers {
+			break
 		}
+	}
+	return identifiers
+}
 
-		var (
-			mu        sync.Mutex
-			collected []FileChunkContext
-		)
-		stream := streaming.StreamFunc(func(e streaming.SearchEvent) {
-			mu.Lock()
-			defer mu.Unlock()
-
-			for _, res := range e.Results {
-				if fm, ok := res.(*result.FileMatch); ok {
-					collected = append(collected, fileMatchToContextMatches(fm)...)
-					if len(collected) >= limit {
-						cancel()
-						return
-					}
-				}
-			}
-		})
+// repoFilter returns a repo: filter that matches exactly the given repos.
+//
+// mini-HACK: pass in the scope using repo: filters. In an ideal world, we
+// would not be using query text manipulation for this and would be using
+// the job structs directly.
+func repoFilter(repos []types.RepoIDName) string {
+	regexEscapedRepoNames := make([]string, len(repos))
+	for i, repo := range repos {
+		regexEscapedRepoNames[i] = regexp.QuoteMeta(string(repo.Name))
+	}
+	return fmt.Sprintf(`repo:^%s$`, query.UnionRegExps(regexEscapedRepoNames))
+}
 
-		alert, err := c.searchClient.Execute(ctx, stream, plan)
-		if err != nil {
-			return nil, err
-		}
-		if alert != nil {
-			c.obsCtx.Logger.Warn("received alert from keyword search execution",
-				log.String("title", alert.Title),
-				log.String("description", alert.Description),
-			)
-		}
+// search runs a search query and converts its file matches to context until the limit is reached.
+func (c *CodyContextClient) search(ctx context.Context, patternType, query string, limit int, convert func(*result.FileMatch) []FileChunkContext) ([]FileChunkContext, error) {
+	if limit == 0 {
+		// Skip a search entirely if the limit is zero.
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
 
-		return collected, nil
+	plan, err := c.searchClient.Plan(
+		ctx,
+		"V3",
+		&patternType,
+		query,
+		search.Precise,
+		search.Streaming,
+	)
+	if err != nil {
+		return nil, err
 	}
 
-	p := pool.NewWithResults[[]FileChunkContext]().WithContext(ctx)
-	p.Go(func(ctx context.Context) ([]FileChunkContext, error) {
-		return doSearch(ctx, codeQuery, int(args.CodeResultsCount))
-	})
-	p.Go(func(ctx context.Context) ([]FileChunkContext, error) {
-		return doSearch(ctx, textQuery, int(args.TextResultsCount))
+	var (
+		mu        sync.Mutex
+		collected []FileChunkContext
+	)
+	stream := streaming.StreamFunc(func(e streaming.SearchEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		for _, res := range e.Results {
+			if fm, ok := res.(*result.FileMatch); ok {
+				collected = append(collected, convert(fm)...)
+				if len(collected) >= limit {
+					cancel()
+					return
+				}
+			}
+		}
 	})
-	results, err := p.Wait()
+
+	alert, err := c.searchClient.Execute(ctx, stream, plan)
 	if err != nil {
 		return nil, err
 	}
+	if alert != nil {
+		c.obsCtx.Logger.Warn("received alert from search execution",
+			log.String("title", alert.Title),
+			log.String("description", alert.Description),
+		)
+	}
 
-	return append(results[0], results[1]...), nil
+	return collected, nil
 }
 
-func (c *CodyContextClient) getEmbeddingsContextFromQdrant(ctx context.Context, args GetContextArgs) (_ []FileChunkContext, err error) {
+func (c *CodyContextClient) getEmbeddingsContextFromQdrant(ctx context.Context, args GetContextArgs) (code, text []FileChunkContext, err error) {
 	embeddingsConf := conf.GetEmbeddingsConfig(conf.Get().SiteConfig())
 	if c == nil {
-		return nil, errors.New("embeddings not configured or disabled")
+		return nil, nil, errors.New("embeddings not configured or disabled")
 	}
 	client, err := embed.NewEmbeddingsClient(embeddingsConf)
 	if err != nil {
-		return nil, errors.Wrap(err, "getting embeddings client")
+		return nil, nil, errors.Wrap(err, "getting embeddings client")
 	}
 	qdrantSearcher, err := c.getQdrantSearcher()
 	if err != nil {
-		return nil, errors.Wrap(err, "getting qdrant searcher")
+		return nil, nil, errors.Wrap(err, "getting qdrant searcher")
 	}
 
 	resp, err := client.GetQueryEmbedding(ctx, args.Query)
 	if err != nil || len(resp.Failed) > 0 {
-		return nil, errors.Wrap(err, "getting query embedding")
+		return nil, nil, errors.Wrap(err, "getting query embedding")
 	}
 	query := resp.Embeddings
 
@@ -355,21 +473,25 @@ func (c *CodyContextClient) getEmbeddingsContextFromQdrant(ctx context.Context,
 	}
 	chunks, err := qdrantSearcher.Search(ctx, params)
 	if err != nil {
-		return nil, errors.Wrap(err, "searching vector DB")
+		return nil, nil, errors.Wrap(err, "searching vector DB")
 	}
 
-	res := make([]FileChunkContext, 0, len(chunks))
 	for _, chunk := range chunks {
-		res = append(res, FileChunkContext{
+		res := FileChunkContext{
 			RepoName:  chunk.Point.Payload.RepoName,
 			RepoID:    chunk.Point.Payload.RepoID,
 			CommitID:  chunk.Point.Payload.Revision,
 			Path:      chunk.Point.Payload.FilePath,
 			StartLine: int(chunk.Point.Payload.StartLine),
 			EndLine:   int(chunk.Point.Payload.EndLine),
-		})
+		}
+		if chunk.Point.Payload.IsCode {
+			code = append(code, res)
+		} else {
+			text = append(text, res)
+		}
 	}
-	return res, nil
+	return code, text, nil
 }
 
 func fileMatchToContextMatches(fm *result.FileMatch) []FileChunkContext {
@@ -395,6 +517,29 @@ func fileMatchToContextMatches(fm *result.FileMatch) []FileChunkContext {
 	}}
 }
 
+func symbolMatchesToContextMatches(fm *result.FileMatch) []FileChunkContext {
+	if len(fm.Symbols) == 0 {
+		return nil
+	}
+
+	// As with keyword matches, we only use the first symbol from each
+	// file. Symbol lines are 1-based.
+
+	// 2 lines of leading context, clamped to zero
+	startLine := max(0, fm.Symbols[0].Symbol.Line-1-2)
+	// depend on content fetching to trim to the end of the file
+	endLine := startLine + 16
+
+	return []FileChunkContext{{
+		RepoName:  fm.Repo.Name,
+		RepoID:    fm.Repo.ID,
+		CommitID:  fm.CommitID,
+		Path:      fm.Path,
+		StartLine: startLine,
+		EndLine:   endLine,
+	}}
+}
+
 func max(vals ...int) int {
 	res := math.MinInt32
 	for _, val := range vals {
@@ -0,0 +1,111 @@
+package context
+
+import (
+	"sort"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+// FusionOptions configure how the results of different searches are merged in hybrid mode.
+type FusionOptions struct {
+	// K dampens the difference between the scores of top-ranked and lower-ranked results. Larger
+	// values give lower-ranked results more weight relative to top-ranked results.
+	K int
+	// EmbeddingsWeight, KeywordWeight and SymbolWeight scale the scores of the results of each
+	// search. A weight of zero ignores the results of a search.
+	EmbeddingsWeight float64
+	KeywordWeight    float64
+	SymbolWeight     float64
+}
+
+// DefaultFusionOptions weighs all searches equally, with the constant K recommended by the original
+// reciprocal rank fusion paper.
+var DefaultFusionOptions = FusionOptions{
+	K:                60,
+	EmbeddingsWeight: 1,
+	KeywordWeight:    1,
+	SymbolWeight:     1,
+}
+
+// RankedResults are the results of a search, ordered from most to least relevant.
+type RankedResults struct {
+	Weight  float64
+	Results []FileChunkContext
+}
+
+// FuseResults merges ranked results with reciprocal rank fusion and returns up to limit results,
+// ordered from most to least relevant. A result at the 1-based rank r of a ranking with weight w
+// scores w / (k + r), and results score the sum of their scores in every ranking. See
+// https://plg.uwaterloo.ca/~gvcormac/cormacksigir09-rrf.pdf.
+//
+// Results of the same file whose lines overlap are considered to be the same result, so that a
+// chunk found by several searches is ranked higher rather than returned several times. The
+// merged result keeps the lines of the first ranking it appears in, and each ranking only counts
+// once towards its score.
+func FuseResults(k, limit int, rankings ...RankedResults) []FileChunkContext {
+	k, limit = max(0, k), max(0, limit)
+
+	type fusedResult struct {
+		chunk FileChunkContext
+		score float64
+	}
+
+	type fileKey struct {
+		repoName api.RepoName
+		path     string
+	}
+
+	var fused []*fusedResult
+	fusedByFile := map[fileKey][]*fusedResult{}
+
+	for _, ranking := range rankings {
+		if ranking.Weight <= 0 {
+			continue
+		}
+
+		scored := map[*fusedResult]struct{}{}
+		for i, chunk := range ranking.Results {
+			key := fileKey{repoName: chunk.RepoName, path: chunk.Path}
+
+			var match *fusedResult
+			for _, candidate := range fusedByFile[key] {
+				if overlaps(candidate.chunk, chunk) {
+					match = candidate
+					break
+				}
+			}
+			if match == nil {
+				match = &fusedResult{chunk: chunk}
+				fused = append(fused, match)
+				fusedByFile[key] = append(fusedByFile[key], match)
+			}
+
+			if _, ok := scored[match]; ok {
+				// A better ranked result of this ranking was already merged into the same result.
+				continue
+			}
+			scored[match] = struct{}{}
+
+			match.score += ranking.Weight / float64(k+i+1)
+		}
+	}
+
+	// Ties are broken by the order in which results were first seen, which favors the results of
+	// earlier rankings.
+	sort.SliceStable(fused, func(i, j int) bool { return fused[i].score > fused[j].score })
+
+	results := make([]FileChunkContext, 0, min(len(fused), limit))
+	for _, result := range truncate(fused, limit) {
+		results = append(results, result.chunk)
+	}
+	return results
+}
+
+// overlaps returns true if the half-open line ranges of two chunks of the same file overlap.
+// Identical ranges always overlap, even when empty.
+func overlaps(a, b FileChunkContext) bool {
+	if a.StartLine == b.StartLine && a.EndLine == b.EndLine {
+		return true
+	}
+	return a.StartLine < b.EndLine && b.StartLine < a.EndLine
+}
@@ -0,0 +1,118 @@
+package context
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFuseResults(t *testing.T) {
+	chunk := func(path string, startLine, endLine int) FileChunkContext {
+		return FileChunkContext{RepoName: "repo", Path: path, StartLine: startLine, EndLine: endLine}
+	}
+
+	testCases := []struct {
+		name     string
+		k        int
+		limit    int
+		rankings []RankedResults
+		want     []FileChunkContext
+	}{
+		{
+			name:  "interleaves rankings",
+			k:     60,
+			limit: 10,
+			rankings: []RankedResults{
+				{Weight: 1, Results: []FileChunkContext{chunk("a.go", 0, 10), chunk("b.go", 0, 10)}},
+				{Weight: 1, Results: []FileChunkContext{chunk("c.go", 0, 10), chunk("d.go", 0, 10)}},
+			},
+			want: []FileChunkContext{chunk("a.go", 0, 10), chunk("c.go", 0, 10), chunk("b.go", 0, 10), chunk("d.go", 0, 10)},
+		},
+		{
+			name:  "results found by several searches rank higher",
+			k:     60,
+			limit: 10,
+			rankings: []RankedResults{
+				{Weight: 1, Results: []FileChunkContext{chunk("a.go", 0, 10), chunk("b.go", 0, 10)}},
+				{Weight: 1, Results: []FileChunkContext{chunk("c.go", 0, 10), chunk("b.go", 4, 12)}},
+			},
+			// b.go keeps the lines of the first ranking
+			want: []FileChunkContext{chunk("b.go", 0, 10), chunk("a.go", 0, 10), chunk("c.go", 0, 10)},
+		},
+		{
+			name:  "chunks of the same file that do not overlap are distinct",
+			k:     60,
+			limit: 10,
+			rankings: []RankedResults{
+				{Weight: 1, Results: []FileChunkContext{chunk("a.go", 0, 10), chunk("a.go", 10, 20)}},
+				{Weight: 1, Results: []FileChunkContext{chunk("a.go", 20, 30)}},
+			},
+			want: []FileChunkContext{chunk("a.go", 0, 10), chunk("a.go", 20, 30), chunk("a.go", 10, 20)},
+		},
+		{
+			name:  "rankings count once per result",
+			k:     60,
+			limit: 10,
+			rankings: []RankedResults{
+				{Weight: 1, Results: []FileChunkContext{chunk("a.go", 0, 10), chunk("a.go", 5, 15), chunk("a.go", 8, 9)}},
+				{Weight: 1, Results: []FileChunkContext{chunk("b.go", 0, 10), chunk("c.go", 0, 10)}},
+				{Weight: 1, Results: []FileChunkContext{chunk("c.go", 0, 10)}},
+			},
+			want: []FileChunkContext{chunk("c.go", 0, 10), chunk("a.go", 0, 10), chunk("b.go", 0, 10)},
+		},
+		{
+			name:  "weights",
+			k:     60,
+			limit: 10,
+			rankings: []RankedResults{
+				{Weight: 1, Results: []FileChunkContext{chunk("a.go", 0, 10)}},
+				{Weight: 2, Results: []FileChunkContext{chunk("b.go", 0, 10)}},
+				{Weight: 0, Results: []FileChunkContext{chunk("c.go", 0, 10)}},
+			},
+			want: []FileChunkContext{chunk("b.go", 0, 10), chunk("a.go", 0, 10)},
+		},
+		{
+			name:  "limit",
+			k:     60,
+			limit: 2,
+			rankings: []RankedResults{
+				{Weight: 1, Results: []FileChunkContext{chunk("a.go", 0, 10), chunk("b.go", 0, 10), chunk("c.go", 0, 10)}},
+			},
+			want: []FileChunkContext{chunk("a.go", 0, 10), chunk("b.go", 0, 10)},
+		},
+		{
+			name:  "empty ranges of the same file",
+			k:     60,
+			limit: 10,
+			rankings: []RankedResults{
+				{Weight: 1, Results: []FileChunkContext{chunk("a.go", 0, 0)}},
+				{Weight: 1, Results: []FileChunkContext{chunk("b.go", 0, 0), chunk("a.go", 0, 0)}},
+			},
+			want: []FileChunkContext{chunk("a.go", 0, 0), chunk("b.go", 0, 0)},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if diff := cmp.Diff(testCase.want, FuseResults(testCase.k, testCase.limit, testCase.rankings...)); diff != "" {
+				t.Errorf("unexpected results (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestQueryIdentifiers(t *testing.T) {
+	testCases := map[string][]string{
+		"Where in the code do we send Slack notifications?": nil,
+		"In my codebase, what does InternalDoer do?":        {"InternalDoer"},
+		"how is getRepoEmbeddingIndex used with repo_id?":   {"getRepoEmbeddingIndex", "repo_id"},
+		"newClient vs newClient":                            {"newClient"},
+		"a_b c_d e_f g_h i_j k_l":                           {"a_b", "c_d", "e_f", "g_h", "i_j"},
+	}
+
+	for query, want := range testCases {
+		if diff := cmp.Diff(want, queryIdentifiers(query)); diff != "" {
+			t.Errorf("unexpected identifiers for %q (-want +got):\n%s", query, diff)
+		}
+	}
+}
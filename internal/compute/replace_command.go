@@ -3,10 +3,14 @@ package compute
 import (
 	"context"
 	"fmt"
+	"path/filepath"
+
+	"github.com/go-enry/go-enry/v2"
 
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/comby"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -25,28 +29,61 @@ func (c *Replace) String() string {
 }
 
 func replace(ctx context.Context, content []byte, matchPattern MatchPattern, replacePattern string) (*Text, error) {
-	var newContent string
+	newContent, err := replaceWithMatcher(ctx, content, matchPattern, replacePattern, ".generic") // TODO(search): use language or file filter
+	if err != nil {
+		return nil, err
+	}
+	return &Text{Value: newContent, Kind: "replace-in-place"}, nil
+}
+
+// ReplaceContent replaces all matches of matchPattern in content with
+// replacePattern. For structural patterns the comby matcher is selected by
+// language, or inferred from the extension of path if no language is given.
+func ReplaceContent(ctx context.Context, content []byte, matchPattern MatchPattern, replacePattern, language, path string) (string, error) {
+	return replaceWithMatcher(ctx, content, matchPattern, replacePattern, combyMatcher(language, path))
+}
+
+func replaceWithMatcher(ctx context.Context, content []byte, matchPattern MatchPattern, replacePattern, matcher string) (string, error) {
 	switch match := matchPattern.(type) {
 	case *Regexp:
-		newContent = match.Value.ReplaceAllString(string(content), replacePattern)
+		return match.Value.ReplaceAllString(string(content), replacePattern), nil
 	case *Comby:
 		replacements, err := comby.Replacements(ctx, comby.Args{
 			Input:           comby.FileContent(content),
 			MatchTemplate:   match.Value,
 			RewriteTemplate: replacePattern,
-			Matcher:         ".generic", // TODO(search): use language or file filter
+			Matcher:         matcher,
 			ResultKind:      comby.Replacement,
 			NumWorkers:      0, // Just a single file's content.
 		})
 		if err != nil {
-			return nil, err
+			return "", err
 		}
 		// There is only one replacement value since we passed in comby.FileContent.
-		newContent = replacements[0].Content
+		return replacements[0].Content, nil
 	default:
-		return nil, errors.Errorf("unsupported replacement operation for match pattern %T", match)
+		return "", errors.Errorf("unsupported replacement operation for match pattern %T", match)
 	}
-	return &Text{Value: newContent, Kind: "replace-in-place"}, nil
+}
+
+var isValidCombyMatcher = lazyregexp.New(`^\.(s|sh|bib|c|cs|css|dart|clj|elm|erl|ex|f|fsx|go|html|hs|java|js|json|jl|kt|tex|lisp|nim|md|ml|org|pas|php|py|re|rb|rs|rst|scala|sql|swift|tex|txt|ts)$`)
+
+// combyMatcher returns the comby matcher for the given language or, if the
+// language isn't set or known, for the extension of path. Comby accepts a
+// representative file extension to select a language and falls back to a
+// generic matcher.
+func combyMatcher(language, path string) string {
+	if lang, ok := enry.GetLanguageByAlias(language); ok {
+		for _, extension := range enry.GetLanguageExtensions(lang) {
+			if isValidCombyMatcher.MatchString(extension) {
+				return extension
+			}
+		}
+	}
+	if extension := filepath.Ext(path); isValidCombyMatcher.MatchString(extension) {
+		return extension
+	}
+	return ".generic"
 }
 
 func (c *Replace) Run(ctx context.Context, gitserverClient gitserver.Client, r result.Match) (Result, error) {
@@ -37,3 +37,11 @@ func Test_replace(t *testing.T) {
 			ReplacePattern: "foo(:[y], :[x])",
 		}))
 }
+
+func Test_combyMatcher(t *testing.T) {
+	autogold.Expect(".go").Equal(t, combyMatcher("Go", "main.ts"))
+	autogold.Expect(".ts").Equal(t, combyMatcher("typescript", ""))
+	autogold.Expect(".py").Equal(t, combyMatcher("", "lib/main.py"))
+	autogold.Expect(".generic").Equal(t, combyMatcher("", "Makefile"))
+	autogold.Expect(".generic").Equal(t, combyMatcher("not-a-language", "README"))
+}
@@ -176,6 +176,15 @@ func ExecutorsEnabled() bool {
 	return Get().ExecutorsAccessToken != ""
 }
 
+// ExecutorsArtifactRetention returns how long the artifacts uploaded by executor jobs are kept.
+func ExecutorsArtifactRetention() time.Duration {
+	days := Get().ExecutorsArtifactRetentionDays
+	if days <= 0 {
+		days = 7
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
 func ExecutorsFrontendURL() string {
 	current := Get()
 	if current.ExecutorsFrontendURL != "" {
@@ -278,6 +287,10 @@ func CodeIntelRankingDocumentReferenceCountsGraphKey() string {
 	return "dev"
 }
 
+func CodeIntelRankingDeadCodeReportExcludedRepositories() []string {
+	return Get().CodeIntelRankingDeadCodeReportExcludedRepositories
+}
+
 func EmbeddingsEnabled() bool {
 	return GetEmbeddingsConfig(Get().SiteConfiguration) != nil
 }
@@ -250,6 +250,9 @@ func UnredactSecrets(input string, raw conftypes.RawUnified) (string, error) {
 		if ap.AzureDevOps != nil {
 			oldSecrets[ap.AzureDevOps.ClientID] = ap.AzureDevOps.ClientSecret
 		}
+		if ap.Gitea != nil {
+			oldSecrets[ap.Gitea.ClientID] = ap.Gitea.ClientSecret
+		}
 	}
 
 	newCfg, err := ParseConfig(conftypes.RawUnified{
@@ -274,6 +277,9 @@ func UnredactSecrets(input string, raw conftypes.RawUnified) (string, error) {
 		if ap.AzureDevOps != nil && ap.AzureDevOps.ClientSecret == redactedSecret {
 			ap.AzureDevOps.ClientSecret = oldSecrets[ap.AzureDevOps.ClientID]
 		}
+		if ap.Gitea != nil && ap.Gitea.ClientSecret == redactedSecret {
+			ap.Gitea.ClientSecret = oldSecrets[ap.Gitea.ClientID]
+		}
 	}
 	unredactedSite, err := jsonc.Edit(input, newCfg.AuthProviders, "auth.providers")
 	if err != nil {
@@ -380,6 +386,9 @@ func redactConfSecrets(raw conftypes.RawUnified, hashSecrets bool) (empty confty
 		if ap.AzureDevOps != nil {
 			ap.AzureDevOps.ClientSecret = getRedactedSecret(ap.AzureDevOps.ClientSecret)
 		}
+		if ap.Gitea != nil {
+			ap.Gitea.ClientSecret = getRedactedSecret(ap.Gitea.ClientSecret)
+		}
 	}
 	redactedSite := raw.Site
 	if len(cfg.AuthProviders) > 0 {
@@ -144,6 +144,10 @@ SELECT
 	h.git_version,
 	h.ignite_version,
 	h.src_cli_version,
+	h.labels,
+	h.num_cpus,
+	h.memory,
+	h.disk_space,
 	h.first_seen_at,
 	h.last_seen_at
 FROM executor_heartbeats h
@@ -201,6 +205,10 @@ SELECT
 	h.git_version,
 	h.ignite_version,
 	h.src_cli_version,
+	h.labels,
+	h.num_cpus,
+	h.memory,
+	h.disk_space,
 	h.first_seen_at,
 	h.last_seen_at
 FROM
@@ -214,6 +222,11 @@ func (s *executorStore) UpsertHeartbeat(ctx context.Context, executor types.Exec
 }
 
 func (s *executorStore) upsertHeartbeat(ctx context.Context, executor types.Executor, now time.Time) error {
+	labels := executor.Labels
+	if labels == nil {
+		labels = []string{}
+	}
+
 	return s.Exec(ctx, sqlf.Sprintf(
 		executorStoreUpsertHeartbeatQuery,
 
@@ -227,6 +240,10 @@ func (s *executorStore) upsertHeartbeat(ctx context.Context, executor types.Exec
 		executor.GitVersion,
 		executor.IgniteVersion,
 		executor.SrcCliVersion,
+		pq.Array(labels),
+		executor.NumCPUs,
+		executor.Memory,
+		executor.DiskSpace,
 		now,
 		now,
 	))
@@ -244,10 +261,14 @@ INSERT INTO executor_heartbeats (
 	git_version,
 	ignite_version,
 	src_cli_version,
+	labels,
+	num_cpus,
+	memory,
+	disk_space,
 	first_seen_at,
 	last_seen_at
 )
-VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 ON CONFLICT (hostname) DO UPDATE
 SET
 	queue_name = EXCLUDED.queue_name,
@@ -259,6 +280,10 @@ SET
 	git_version = EXCLUDED.git_version,
 	ignite_version = EXCLUDED.ignite_version,
 	src_cli_version = EXCLUDED.src_cli_version,
+	labels = EXCLUDED.labels,
+	num_cpus = EXCLUDED.num_cpus,
+	memory = EXCLUDED.memory,
+	disk_space = EXCLUDED.disk_space,
 	last_seen_at =EXCLUDED.last_seen_at
 `
 
@@ -287,6 +312,7 @@ func scanExecutors(rows *sql.Rows, queryErr error) (_ []types.Executor, err erro
 		var executor types.Executor
 		var sqlQueueName *string
 		var sqlQueueNames pq.StringArray
+		var sqlLabels pq.StringArray
 		if err := rows.Scan(
 			&executor.ID,
 			&executor.Hostname,
@@ -299,6 +325,10 @@ func scanExecutors(rows *sql.Rows, queryErr error) (_ []types.Executor, err erro
 			&executor.GitVersion,
 			&executor.IgniteVersion,
 			&executor.SrcCliVersion,
+			&sqlLabels,
+			&executor.NumCPUs,
+			&executor.Memory,
+			&executor.DiskSpace,
 			&executor.FirstSeenAt,
 			&executor.LastSeenAt,
 		); err != nil {
@@ -315,6 +345,12 @@ func scanExecutors(rows *sql.Rows, queryErr error) (_ []types.Executor, err erro
 		}
 		executor.QueueNames = queueNames
 
+		var labels []string
+		for _, label := range sqlLabels {
+			labels = append(labels, label)
+		}
+		executor.Labels = labels
+
 		executors = append(executors, executor)
 	}
 
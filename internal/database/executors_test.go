@@ -239,6 +239,10 @@ func TestExecutorsGetByHostname(t *testing.T) {
 		GitVersion:      "test-git-version",
 		IgniteVersion:   "test-ignite-version",
 		SrcCliVersion:   "test-src-cli-version",
+		Labels:          []string{"gpu", "network:internal"},
+		NumCPUs:         8,
+		Memory:          "16G",
+		DiskSpace:       "100G",
 		FirstSeenAt:     t1,
 		LastSeenAt:      t2,
 	}
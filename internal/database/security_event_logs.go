@@ -65,6 +65,9 @@ const (
 	SecurityEventAzureDevOpsAuthSucceeded SecurityEventName = "AzureDevOpsAuthSucceeded"
 	SecurityEventAzureDevOpsAuthFailed    SecurityEventName = "AzureDevOpsAuthFailed"
 
+	SecurityEventGiteaAuthSucceeded SecurityEventName = "GiteaAuthSucceeded"
+	SecurityEventGiteaAuthFailed    SecurityEventName = "GiteaAuthFailed"
+
 	SecurityEventOIDCLoginSucceeded SecurityEventName = "SecurityEventOIDCLoginSucceeded"
 	SecurityEventOIDCLoginFailed    SecurityEventName = "SecurityEventOIDCLoginFailed"
 )
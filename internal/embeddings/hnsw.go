@@ -0,0 +1,350 @@
+package embeddings
+
+import (
+	"container/heap"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// HNSWParameters configure the construction of the HNSW graphs of embedding indexes.
+type HNSWParameters struct {
+	// M is the number of neighbors a row is linked to when it is inserted into a layer of the
+	// graph. Rows keep at most M neighbors on the upper layers and 2*M on the bottom layer. Larger
+	// values improve recall at the cost of memory and index time.
+	M int
+	// EfConstruction is the number of candidates considered when linking a row to its neighbors.
+	// Larger values improve the quality of the graph at the cost of index time.
+	EfConstruction int
+	// MinRows is the minimum number of rows of an index for a graph to be built. Smaller indexes
+	// are always searched exhaustively.
+	MinRows int
+}
+
+var DefaultHNSWParameters = HNSWParameters{
+	M:              16,
+	EfConstruction: 100,
+	MinRows:        10_000,
+}
+
+// maxHNSWLayers bounds the number of layers of a graph. Rows are only inserted into upper layers
+// with a probability of 1/M per layer, so this is never reached in practice.
+const maxHNSWLayers = 16
+
+// HNSWGraph is a hierarchical navigable small world graph over the rows of an embedding index,
+// used to find the rows most similar to a query without scanning the whole index. See
+// https://arxiv.org/abs/1603.09320.
+//
+// Every row belongs to the bottom layer and to each layer above it with a decreasing probability.
+// Searches start from the entry point on the top layer and greedily walk towards the query,
+// layer by layer.
+type HNSWGraph struct {
+	// EntryPoint is the row searches start from. It belongs to every layer.
+	EntryPoint int32
+	// Neighbors are the neighbors of each row on each layer the row belongs to, from the bottom
+	// layer up.
+	Neighbors [][][]int32
+}
+
+func (g *HNSWGraph) numLayers() int {
+	return len(g.Neighbors[g.EntryPoint])
+}
+
+func (g *HNSWGraph) estimateSize() uint64 {
+	size := uint64(len(g.Neighbors) * 24)
+	for _, layers := range g.Neighbors {
+		size += uint64(len(layers) * 24)
+		for _, neighbors := range layers {
+			size += uint64(len(neighbors) * 4)
+		}
+	}
+	return size
+}
+
+func (g *HNSWGraph) validate(numRows int) bool {
+	if len(g.Neighbors) != numRows || g.EntryPoint < 0 || int(g.EntryPoint) >= numRows {
+		return false
+	}
+
+	numLayers := g.numLayers()
+	for _, layers := range g.Neighbors {
+		if len(layers) == 0 || len(layers) > numLayers {
+			return false
+		}
+		for _, neighbors := range layers {
+			for _, neighbor := range neighbors {
+				if neighbor < 0 || int(neighbor) >= numRows {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// BuildGraph builds the HNSW graph of the index with the given parameters, replacing any previous
+// graph. Indexes with fewer than MinRows rows are left without a graph.
+func (index *EmbeddingIndex) BuildGraph(params HNSWParameters) {
+	index.Graph = nil
+
+	numRows := len(index.RowMetadata)
+	if params.M <= 0 || numRows == 0 || numRows < params.MinRows {
+		return
+	}
+	if params.M < 2 {
+		params.M = 2
+	}
+	if params.EfConstruction < params.M {
+		params.EfConstruction = params.M
+	}
+
+	b := &hnswBuilder{
+		index:   index,
+		graph:   &HNSWGraph{Neighbors: make([][][]int32, numRows)},
+		params:  params,
+		visited: newVisitedRows(numRows),
+		// Seed with the size of the index so that rebuilding the graph of an index yields the
+		// same graph.
+		rng:              rand.New(rand.NewSource(int64(numRows))),
+		levelNormalizer:  1 / math.Log(float64(params.M)),
+		neighborsScratch: make([]hnswCandidate, 0, 2*params.M+1),
+	}
+	for row := 0; row < numRows; row++ {
+		b.insert(int32(row))
+	}
+
+	index.Graph = b.graph
+}
+
+type hnswBuilder struct {
+	index            *EmbeddingIndex
+	graph            *HNSWGraph
+	params           HNSWParameters
+	visited          *visitedRows
+	rng              *rand.Rand
+	levelNormalizer  float64
+	neighborsScratch []hnswCandidate
+}
+
+func (b *hnswBuilder) insert(row int32) {
+	numLayers := b.randomNumLayers()
+	b.graph.Neighbors[row] = make([][]int32, numLayers)
+	if row == 0 {
+		b.graph.EntryPoint = row
+		return
+	}
+
+	query := b.index.Row(int(row))
+	visit := func(row int32) bool { return b.visited.visit(row) }
+
+	entryPoint := b.graph.EntryPoint
+	topLayer := b.graph.numLayers() - 1
+	for layer := topLayer; layer >= numLayers; layer-- {
+		b.visited.reset()
+		entryPoint = b.index.searchLayer(b.graph, query, entryPoint, 1, layer, visit)[0].row
+	}
+
+	for layer := min(numLayers-1, topLayer); layer >= 0; layer-- {
+		b.visited.reset()
+		candidates := b.index.searchLayer(b.graph, query, entryPoint, b.params.EfConstruction, layer, visit)
+
+		neighbors := b.selectNeighbors(candidates, b.params.M, nil)
+		for _, neighbor := range neighbors {
+			b.link(neighbor, row, layer)
+		}
+		b.graph.Neighbors[row][layer] = neighbors
+
+		entryPoint = candidates[0].row
+	}
+
+	if numLayers > topLayer+1 {
+		b.graph.EntryPoint = row
+	}
+}
+
+// link adds a neighbor to the row on the given layer, keeping only the most similar neighbors
+// when the row has too many.
+func (b *hnswBuilder) link(row, neighbor int32, layer int) {
+	neighbors := append(b.graph.Neighbors[row][layer], neighbor)
+
+	maxNeighbors := b.params.M
+	if layer == 0 {
+		maxNeighbors = 2 * b.params.M
+	}
+	if len(neighbors) > maxNeighbors {
+		rowEmbedding := b.index.Row(int(row))
+
+		candidates := b.neighborsScratch[:0]
+		for _, n := range neighbors {
+			candidates = append(candidates, hnswCandidate{row: n, similarity: Dot(rowEmbedding, b.index.Row(int(n)))})
+		}
+		sort.Slice(candidates, func(i, j int) bool { return candidates[i].similarity > candidates[j].similarity })
+
+		neighbors = b.selectNeighbors(candidates, maxNeighbors, neighbors[:0])
+	}
+
+	b.graph.Neighbors[row][layer] = neighbors
+}
+
+// selectNeighbors appends up to m of the given candidates, which are ordered by decreasing
+// similarity to a row, to the given slice. Following the heuristic of the HNSW paper, candidates
+// that are more similar to an already selected neighbor than to the row are skipped, so that rows
+// keep links to other clusters of rows rather than only to their closest cluster.
+func (b *hnswBuilder) selectNeighbors(candidates []hnswCandidate, m int, neighbors []int32) []int32 {
+	for _, candidate := range candidates {
+		if len(neighbors) >= m {
+			break
+		}
+
+		embedding := b.index.Row(int(candidate.row))
+		diverse := true
+		for _, neighbor := range neighbors {
+			if Dot(embedding, b.index.Row(int(neighbor))) > candidate.similarity {
+				diverse = false
+				break
+			}
+		}
+		if diverse {
+			neighbors = append(neighbors, candidate.row)
+		}
+	}
+
+	return neighbors
+}
+
+// randomNumLayers draws the number of layers of a row, so that the row belongs to each layer
+// above the bottom one with a probability of 1/M.
+func (b *hnswBuilder) randomNumLayers() int {
+	numLayers := 1 + int(math.Floor(-math.Log(1-b.rng.Float64())*b.levelNormalizer))
+	return min(numLayers, maxHNSWLayers)
+}
+
+// approximateSearch returns up to ef rows of the index that are the most similar to the query,
+// ordered by decreasing similarity.
+func (index *EmbeddingIndex) approximateSearch(query []int8, ef int) []hnswCandidate {
+	g := index.Graph
+
+	var visited map[int32]struct{}
+	visit := func(row int32) bool {
+		if _, ok := visited[row]; ok {
+			return false
+		}
+		visited[row] = struct{}{}
+		return true
+	}
+
+	entryPoint := g.EntryPoint
+	for layer := g.numLayers() - 1; layer > 0; layer-- {
+		visited = make(map[int32]struct{})
+		entryPoint = index.searchLayer(g, query, entryPoint, 1, layer, visit)[0].row
+	}
+
+	visited = make(map[int32]struct{}, ef*8)
+	return index.searchLayer(g, query, entryPoint, ef, 0, visit)
+}
+
+// searchLayer returns up to ef rows that are the most similar to the query on the given layer of
+// the graph, ordered by decreasing similarity, starting from the given entry point. The visit
+// function marks rows as visited and returns false if they were already visited.
+func (index *EmbeddingIndex) searchLayer(g *HNSWGraph, query []int8, entryPoint int32, ef int, layer int, visit func(row int32) bool) []hnswCandidate {
+	visit(entryPoint)
+	entry := hnswCandidate{row: entryPoint, similarity: Dot(index.Row(int(entryPoint)), query)}
+
+	// candidates is a max-heap of the rows to expand, and results is a min-heap of the ef most
+	// similar rows found so far.
+	candidates := &hnswHeap{candidates: []hnswCandidate{entry}, mostSimilarFirst: true}
+	results := &hnswHeap{candidates: []hnswCandidate{entry}}
+
+	for candidates.Len() > 0 {
+		candidate := heap.Pop(candidates).(hnswCandidate)
+		if results.Len() >= ef && candidate.similarity < results.candidates[0].similarity {
+			// All remaining candidates are less similar than the results.
+			break
+		}
+
+		for _, neighbor := range g.Neighbors[candidate.row][layer] {
+			if !visit(neighbor) {
+				continue
+			}
+
+			similarity := Dot(index.Row(int(neighbor)), query)
+			if results.Len() < ef || similarity > results.candidates[0].similarity {
+				heap.Push(candidates, hnswCandidate{row: neighbor, similarity: similarity})
+				heap.Push(results, hnswCandidate{row: neighbor, similarity: similarity})
+				if results.Len() > ef {
+					heap.Pop(results)
+				}
+			}
+		}
+	}
+
+	sorted := results.candidates
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].similarity > sorted[j].similarity })
+	return sorted
+}
+
+type hnswCandidate struct {
+	row        int32
+	similarity int32
+}
+
+type hnswHeap struct {
+	candidates       []hnswCandidate
+	mostSimilarFirst bool
+}
+
+func (h *hnswHeap) Len() int { return len(h.candidates) }
+
+func (h *hnswHeap) Less(i, j int) bool {
+	if h.mostSimilarFirst {
+		return h.candidates[i].similarity > h.candidates[j].similarity
+	}
+	return h.candidates[i].similarity < h.candidates[j].similarity
+}
+
+func (h *hnswHeap) Swap(i, j int) {
+	h.candidates[i], h.candidates[j] = h.candidates[j], h.candidates[i]
+}
+
+func (h *hnswHeap) Push(x any) {
+	h.candidates = append(h.candidates, x.(hnswCandidate))
+}
+
+func (h *hnswHeap) Pop() any {
+	old := h.candidates
+	n := len(old)
+	x := old[n-1]
+	h.candidates = old[0 : n-1]
+	return x
+}
+
+// visitedRows is a set of visited rows that can be reset in constant time.
+type visitedRows struct {
+	marks      []uint32
+	generation uint32
+}
+
+func newVisitedRows(numRows int) *visitedRows {
+	return &visitedRows{marks: make([]uint32, numRows), generation: 1}
+}
+
+func (v *visitedRows) reset() {
+	v.generation++
+	if v.generation == 0 {
+		// The generation wrapped around, so marks of old generations could be mistaken for the
+		// current one.
+		for i := range v.marks {
+			v.marks[i] = 0
+		}
+		v.generation = 1
+	}
+}
+
+func (v *visitedRows) visit(row int32) bool {
+	if v.marks[row] == v.generation {
+		return false
+	}
+	v.marks[row] = v.generation
+	return true
+}
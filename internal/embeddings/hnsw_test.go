@@ -0,0 +1,150 @@
+package embeddings
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildGraph(t *testing.T) {
+	prng := rand.New(rand.NewSource(0))
+	index := getRandomIndex(prng, 1000, 32)
+
+	index.BuildGraph(HNSWParameters{M: 8, EfConstruction: 32, MinRows: 1001})
+	require.Nil(t, index.Graph, "expected no graph for a small index")
+
+	index.BuildGraph(HNSWParameters{M: 8, EfConstruction: 32, MinRows: 1000})
+	require.NotNil(t, index.Graph)
+	require.NoError(t, index.Validate())
+
+	for row, layers := range index.Graph.Neighbors {
+		require.NotEmpty(t, layers[0], "row %d has no neighbors", row)
+		for layer, neighbors := range layers {
+			maxNeighbors := 8
+			if layer == 0 {
+				maxNeighbors = 16
+			}
+			require.LessOrEqual(t, len(neighbors), maxNeighbors, "row %d has too many neighbors on layer %d", row, layer)
+		}
+	}
+
+	// The graph is deterministic.
+	graph := index.Graph
+	index.BuildGraph(HNSWParameters{M: 8, EfConstruction: 32, MinRows: 1000})
+	require.Equal(t, graph, index.Graph)
+}
+
+func TestApproximateSimilaritySearch(t *testing.T) {
+	prng := rand.New(rand.NewSource(0))
+	numRows, numQueries, numResults, columnDimension := 5000, 50, 10, 32
+	index := getRandomIndex(prng, numRows, columnDimension)
+	index.BuildGraph(HNSWParameters{M: 16, EfConstruction: 100})
+
+	queries := make([][]int8, 0, numQueries)
+	for q := 0; q < numQueries; q++ {
+		queries = append(queries, getNormalizedEmbeddings(prng, 1, columnDimension))
+	}
+
+	t.Run("exact", func(t *testing.T) {
+		for _, query := range queries {
+			graph := index.Graph
+			index.Graph = nil
+			want := index.SimilaritySearch(query, numResults, WorkerOptions{}, SearchOptions{ApproximateSearchEf: 64}, "", "")
+			index.Graph = graph
+
+			// Indexes with a graph are searched exhaustively unless approximate search is enabled.
+			have := index.SimilaritySearch(query, numResults, WorkerOptions{}, SearchOptions{}, "", "")
+			require.Equal(t, want, have)
+		}
+	})
+
+	// Recall improves as more candidates are considered.
+	previousRecall := 0.0
+	for _, ef := range []int{16, 32, 64} {
+		recall := approximateSearchRecall(index, queries, numResults, ef)
+		require.GreaterOrEqual(t, recall, previousRecall, "ef=%d", ef)
+		previousRecall = recall
+	}
+	require.GreaterOrEqual(t, previousRecall, 0.95)
+}
+
+func BenchmarkApproximateSimilaritySearch(b *testing.B) {
+	prng := rand.New(rand.NewSource(0))
+
+	numRows := 100_000
+	numResults := 100
+	columnDimension := 256
+	index := getRandomIndex(prng, numRows, columnDimension)
+	index.BuildGraph(DefaultHNSWParameters)
+	query := getNormalizedEmbeddings(prng, 1, columnDimension)
+
+	b.ResetTimer()
+
+	for _, ef := range []int{100, 200, 400} {
+		b.Run(fmt.Sprintf("ef=%d", ef), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				_ = index.SimilaritySearch(query, numResults, WorkerOptions{NumWorkers: 1}, SearchOptions{ApproximateSearchEf: ef}, "", "")
+			}
+			b.ReportMetric(approximateSearchRecall(index, [][]int8{query}, numResults, ef), "recall")
+		})
+	}
+}
+
+// approximateSearchRecall returns the share of the exact search results that are found by the
+// approximate search, over all queries.
+func approximateSearchRecall(index *EmbeddingIndex, queries [][]int8, numResults, ef int) float64 {
+	found, total := 0, 0
+	for _, query := range queries {
+		exact := map[string]struct{}{}
+		graph := index.Graph
+		index.Graph = nil
+		for _, result := range index.SimilaritySearch(query, numResults, WorkerOptions{}, SearchOptions{}, "", "") {
+			exact[result.FileName] = struct{}{}
+		}
+		index.Graph = graph
+
+		for _, result := range index.SimilaritySearch(query, numResults, WorkerOptions{}, SearchOptions{ApproximateSearchEf: ef}, "", "") {
+			if _, ok := exact[result.FileName]; ok {
+				found++
+			}
+		}
+		total += len(exact)
+	}
+
+	return float64(found) / float64(total)
+}
+
+func getRandomIndex(prng *rand.Rand, numRows, columnDimension int) *EmbeddingIndex {
+	index := &EmbeddingIndex{
+		Embeddings:      getNormalizedEmbeddings(prng, numRows, columnDimension),
+		ColumnDimension: columnDimension,
+		RowMetadata:     make([]RepoEmbeddingRowMetadata, numRows),
+	}
+	for i := range index.RowMetadata {
+		index.RowMetadata[i] = RepoEmbeddingRowMetadata{FileName: strconv.Itoa(i)}
+	}
+	return index
+}
+
+// getNormalizedEmbeddings returns quantized random vectors of unit length, like the embeddings
+// returned by embeddings providers.
+func getNormalizedEmbeddings(prng *rand.Rand, numRows, columnDimension int) []int8 {
+	embeddings := make([]float32, 0, numRows*columnDimension)
+	for i := 0; i < numRows; i++ {
+		row := make([]float32, columnDimension)
+		norm := 0.0
+		for j := range row {
+			row[j] = float32(prng.NormFloat64())
+			norm += float64(row[j] * row[j])
+		}
+		for j := range row {
+			row[j] /= float32(math.Sqrt(norm))
+		}
+		embeddings = append(embeddings, row...)
+	}
+	return Quantize(embeddings, nil)
+}
@@ -20,10 +20,11 @@ import (
 // way that affects how it's decoded, we add a new format version and update CurrentFormatVersion to the latest.
 type IndexFormatVersion int
 
-const CurrentFormatVersion = EmbeddingModelVersion
+const CurrentFormatVersion = HNSWGraphVersion
 const (
 	InitialVersion        IndexFormatVersion = iota // The initial format, before we started tracking format versions
 	EmbeddingModelVersion                           // Added the model name used to create embeddings
+	HNSWGraphVersion                                // Added the optional HNSW graphs of the code and text indexes
 )
 
 func DownloadIndex[T any](ctx context.Context, uploadStore uploadstore.Store, key string) (_ *T, err error) {
@@ -89,6 +90,7 @@ func UpdateRepoEmbeddingIndex(
 	new *RepoEmbeddingIndex,
 	toRemove []string,
 	ranks types.RepoPathRanks,
+	hnswParameters HNSWParameters,
 ) error {
 	// update revision
 	previous.Revision = new.Revision
@@ -107,6 +109,9 @@ func UpdateRepoEmbeddingIndex(
 	previous.CodeIndex.append(new.CodeIndex)
 	previous.TextIndex.append(new.TextIndex)
 
+	// rebuild the graphs, which do not link the new rows
+	previous.BuildGraphs(hnswParameters)
+
 	// re-upload
 	return UploadRepoEmbeddingIndex(ctx, uploadStore, key, previous)
 }
@@ -239,6 +244,19 @@ func (d *decoder) decode() (*RepoEmbeddingIndex, error) {
 			ei.Embeddings = append(ei.Embeddings, Quantize(embeddingsBuf, quantizeBuf)...)
 		}
 
+		if d.formatVersion >= HNSWGraphVersion {
+			var hasGraph bool
+			if err := d.dec.Decode(&hasGraph); err != nil {
+				return nil, err
+			}
+			if hasGraph {
+				ei.Graph = &HNSWGraph{}
+				if err := d.dec.Decode(ei.Graph); err != nil {
+					return nil, err
+				}
+			}
+		}
+
 		if err := ei.Validate(); err != nil {
 			return nil, err
 		}
@@ -318,6 +336,18 @@ func (e *encoder) encode(rei *RepoEmbeddingIndex) error {
 				return err
 			}
 		}
+
+		if e.formatVersion >= HNSWGraphVersion {
+			// gob cannot encode nil pointers, so we encode whether the index has a graph first.
+			if err := e.enc.Encode(ei.Graph != nil); err != nil {
+				return err
+			}
+			if ei.Graph != nil {
+				if err := e.enc.Encode(ei.Graph); err != nil {
+					return err
+				}
+			}
+		}
 	}
 
 	return nil
@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/types"
 	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 	"github.com/sourcegraph/sourcegraph/lib/iterator"
@@ -146,6 +147,60 @@ func TestRepoEmbeddingIndexStorage(t *testing.T) {
 	require.Equal(t, index, downloadedIndex)
 }
 
+func TestRepoEmbeddingIndexStorageGraph(t *testing.T) {
+	prng := rand.New(rand.NewSource(0))
+	index := &RepoEmbeddingIndex{
+		RepoName:  api.RepoName("repo"),
+		Revision:  api.CommitID("commit"),
+		CodeIndex: *getRandomIndex(prng, 200, 16),
+		TextIndex: *getRandomIndex(prng, 10, 16),
+	}
+	index.BuildGraphs(HNSWParameters{M: 4, EfConstruction: 16, MinRows: 100})
+	require.NotNil(t, index.CodeIndex.Graph)
+	require.Nil(t, index.TextIndex.Graph)
+
+	ctx := context.Background()
+	uploadStore := newMockUploadStore()
+
+	err := UploadRepoEmbeddingIndex(ctx, uploadStore, "0.embeddingindex", index)
+	require.NoError(t, err)
+
+	downloadedIndex, err := DownloadRepoEmbeddingIndex(ctx, uploadStore, 0, "")
+	require.NoError(t, err)
+
+	require.Equal(t, index, downloadedIndex)
+}
+
+func TestUpdateRepoEmbeddingIndexGraph(t *testing.T) {
+	prng := rand.New(rand.NewSource(0))
+	params := HNSWParameters{M: 4, EfConstruction: 16, MinRows: 100}
+	previous := &RepoEmbeddingIndex{
+		RepoName:  api.RepoName("repo"),
+		Revision:  api.CommitID("previous"),
+		CodeIndex: *getRandomIndex(prng, 100, 16),
+	}
+	previous.BuildGraphs(params)
+	new := &RepoEmbeddingIndex{
+		RepoName:  api.RepoName("repo"),
+		Revision:  api.CommitID("new"),
+		CodeIndex: *getRandomIndex(prng, 20, 16),
+	}
+
+	ctx := context.Background()
+	uploadStore := newMockUploadStore()
+
+	err := UpdateRepoEmbeddingIndex(ctx, uploadStore, "0.embeddingindex", previous, new, []string{"0"}, types.RepoPathRanks{}, params)
+	require.NoError(t, err)
+
+	downloadedIndex, err := DownloadRepoEmbeddingIndex(ctx, uploadStore, 0, "")
+	require.NoError(t, err)
+
+	// The graph links the remaining and the new rows.
+	require.Len(t, downloadedIndex.CodeIndex.RowMetadata, 119)
+	require.NotNil(t, downloadedIndex.CodeIndex.Graph)
+	require.Len(t, downloadedIndex.CodeIndex.Graph.Neighbors, 119)
+}
+
 func TestIndexFormatVersion(t *testing.T) {
 	index := &RepoEmbeddingIndex{
 		RepoName: api.RepoName("repo"),
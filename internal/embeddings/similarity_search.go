@@ -97,6 +97,11 @@ func (index *EmbeddingIndex) SimilaritySearch(
 	numRows := len(index.RowMetadata)
 	// Cannot request more results than there are rows.
 	numResults = min(numRows, numResults)
+
+	if index.Graph != nil && opts.ApproximateSearchEf > 0 {
+		return index.approximateSimilaritySearch(query, numResults, opts, repoName, revision)
+	}
+
 	// We need at least 1 worker.
 	numWorkers := max(1, workerOptions.NumWorkers)
 
@@ -129,10 +134,35 @@ func (index *EmbeddingIndex) SimilaritySearch(
 	// And re-sort it according to the score (descending).
 	sort.Slice(neighbors, func(i, j int) bool { return neighbors[i].scoreDetails.Score > neighbors[j].scoreDetails.Score })
 
-	// Take top neighbors and return them as results.
-	results := make([]EmbeddingSearchResult, numResults)
+	return index.searchResults(neighbors, numResults, repoName, revision)
+}
+
+// approximateSimilaritySearch finds the `nResults` most similar rows to a query vector by searching
+// the graph of the index. The rows most similar to the query are found by searching the graph
+// with `opts.ApproximateSearchEf` candidates and then re-scored to take document ranks into account.
+func (index *EmbeddingIndex) approximateSimilaritySearch(
+	query []int8,
+	numResults int,
+	opts SearchOptions,
+	repoName api.RepoName,
+	revision api.CommitID,
+) []EmbeddingSearchResult {
+	candidates := index.approximateSearch(query, max(opts.ApproximateSearchEf, numResults))
+
+	neighbors := make([]nearestNeighbor, 0, len(candidates))
+	for _, candidate := range candidates {
+		neighbors = append(neighbors, nearestNeighbor{index: int(candidate.row), scoreDetails: index.score(query, int(candidate.row), opts)})
+	}
+	sort.Slice(neighbors, func(i, j int) bool { return neighbors[i].scoreDetails.Score > neighbors[j].scoreDetails.Score })
+
+	return index.searchResults(neighbors, numResults, repoName, revision)
+}
+
+// searchResults returns the top neighbors, sorted by descending score, as results.
+func (index *EmbeddingIndex) searchResults(neighbors []nearestNeighbor, numResults int, repoName api.RepoName, revision api.CommitID) []EmbeddingSearchResult {
+	results := make([]EmbeddingSearchResult, min(numResults, len(neighbors)))
 
-	for idx := 0; idx < min(numResults, len(neighbors)); idx++ {
+	for idx := range results {
 		metadata := index.RowMetadata[neighbors[idx].index]
 		results[idx] = EmbeddingSearchResult{
 			RepoName:     repoName,
@@ -218,4 +248,8 @@ func max(a, b int) int {
 
 type SearchOptions struct {
 	UseDocumentRanks bool
+	// ApproximateSearchEf is the number of candidates considered when searching indexes that have
+	// a graph. Larger values trade latency for recall. When zero, all indexes are searched
+	// exhaustively.
+	ApproximateSearchEf int
 }
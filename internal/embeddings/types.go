@@ -14,6 +14,9 @@ type EmbeddingIndex struct {
 	ColumnDimension int
 	RowMetadata     []RepoEmbeddingRowMetadata
 	Ranks           []float32
+	// Graph is the optional HNSW graph of the rows, used for approximate similarity searches.
+	// It is nil for indexes that are searched exhaustively.
+	Graph *HNSWGraph
 }
 
 // Row returns the embeddings for the nth row in the index
@@ -22,7 +25,11 @@ func (index *EmbeddingIndex) Row(n int) []int8 {
 }
 
 func (index *EmbeddingIndex) EstimateSize() uint64 {
-	return uint64(len(index.Embeddings) + len(index.RowMetadata)*(16+8+8) + len(index.Ranks)*4)
+	size := uint64(len(index.Embeddings) + len(index.RowMetadata)*(16+8+8) + len(index.Ranks)*4)
+	if index.Graph != nil {
+		size += index.Graph.estimateSize()
+	}
+	return size
 }
 
 // Validate will return a non-nil error if the fields on index break an
@@ -33,11 +40,18 @@ func (index *EmbeddingIndex) Validate() error {
 		return errors.Errorf("embedding index has an unexpected number of cells: cells=%d != columns=%d * rows=%d", len(index.Embeddings), index.ColumnDimension, len(index.RowMetadata))
 	}
 
+	if index.Graph != nil && !index.Graph.validate(len(index.RowMetadata)) {
+		return errors.Errorf("embedding index has an invalid graph for %d rows", len(index.RowMetadata))
+	}
+
 	return nil
 }
 
-// Filter removes all files from the index that are in the set and updates the ranks
+// Filter removes all files from the index that are in the set and updates the ranks. The graph of
+// the index is dropped, as it refers to the removed rows.
 func (index *EmbeddingIndex) filter(set map[string]struct{}, ranks types.RepoPathRanks) {
+	index.Graph = nil
+
 	// We can reset Ranks here because we are anyway going to update them based on
 	// "ranks".
 	index.Ranks = make([]float32, 0, len(index.RowMetadata))
@@ -63,7 +77,10 @@ func (index *EmbeddingIndex) filter(set map[string]struct{}, ranks types.RepoPat
 	index.Embeddings = index.Embeddings[:cursor*index.ColumnDimension]
 }
 
+// append adds the rows of the other index to the index. The graph of the index is dropped, as it
+// does not link the new rows.
 func (index *EmbeddingIndex) append(other EmbeddingIndex) {
+	index.Graph = nil
 	index.RowMetadata = append(index.RowMetadata, other.RowMetadata...)
 	index.Ranks = append(index.Ranks, other.Ranks...)
 	index.Embeddings = append(index.Embeddings, other.Embeddings...)
@@ -87,6 +104,12 @@ func (i *RepoEmbeddingIndex) EstimateSize() uint64 {
 	return i.CodeIndex.EstimateSize() + i.TextIndex.EstimateSize()
 }
 
+// BuildGraphs builds the HNSW graphs of the code and text indexes with the given parameters.
+func (i *RepoEmbeddingIndex) BuildGraphs(params HNSWParameters) {
+	i.CodeIndex.BuildGraph(params)
+	i.TextIndex.BuildGraph(params)
+}
+
 func (i *RepoEmbeddingIndex) IsModelCompatible(model string) bool {
 	return i.EmbeddingsModel == "" || i.EmbeddingsModel == model
 }
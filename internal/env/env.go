@@ -166,6 +166,16 @@ func MustGetInt(name string, defaultValue int, description string) int {
 	return i
 }
 
+// MustGetFloat is similar to Get but ensures that the value is a valid float.
+func MustGetFloat(name string, defaultValue float64, description string) float64 {
+	s := Get(name, strconv.FormatFloat(defaultValue, 'g', -1, 64), description)
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		panic(fmt.Sprintf("parsing environment variable %q. Expected valid float, got %q", name, s))
+	}
+	return f
+}
+
 // MustGetBool is similar to Get but ensures that the value is a valid bool.
 func MustGetBool(name string, defaultValue bool, description string) bool {
 	s := Get(name, strconv.FormatBool(defaultValue), description)
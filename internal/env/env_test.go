@@ -53,6 +53,19 @@ func TestGet(t *testing.T) {
 		}
 	})
 
+	t.Run("float", func(t *testing.T) {
+		reset(map[string]string{"B": "0.25"})
+
+		a := MustGetFloat("A", 1.5, "foo")
+		b := MustGetFloat("B", 1, "bar")
+		if want := 1.5; a != want {
+			t.Errorf("got A == %v, want %v", a, want)
+		}
+		if want := 0.25; b != want {
+			t.Errorf("got B == %v, want %v", b, want)
+		}
+	})
+
 	t.Run("conflicting registrations", func(t *testing.T) {
 		reset(nil)
 
@@ -0,0 +1,27 @@
+package artifacts
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+)
+
+// NewExpirer returns a background routine that periodically deletes expired artifacts.
+func NewExpirer(ctx context.Context, logger log.Logger, svc *Service, interval time.Duration) goroutine.BackgroundRoutine {
+	return goroutine.NewPeriodicGoroutine(
+		ctx,
+		goroutine.HandlerFunc(func(ctx context.Context) error {
+			count, err := svc.DeleteExpired(ctx, time.Now())
+			if count > 0 {
+				logger.Info("Deleted expired executor job artifacts", log.Int("count", count))
+			}
+			return err
+		}),
+		goroutine.WithName("executor.artifacts-expirer"),
+		goroutine.WithDescription("deletes executor job artifacts that exceeded their retention"),
+		goroutine.WithInterval(interval),
+	)
+}
@@ -0,0 +1,139 @@
+package artifacts
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/executor/store"
+	"github.com/sourcegraph/sourcegraph/internal/executor/types"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ErrTooLarge is returned when the content of an uploaded artifact exceeds types.MaxArtifactSize.
+var ErrTooLarge = errors.Newf("artifact exceeds the maximum size of %d bytes", types.MaxArtifactSize)
+
+// Service stores the artifacts uploaded by executors for the jobs they ran. The metadata
+// of each artifact is stored in the database, and its content in the upload store.
+type Service struct {
+	store       store.ArtifactStore
+	uploadStore uploadstore.Store
+}
+
+// NewService creates a new Service.
+func NewService(store store.ArtifactStore, uploadStore uploadstore.Store) *Service {
+	return &Service{
+		store:       store,
+		uploadStore: uploadStore,
+	}
+}
+
+// Upload stores the content of the artifact with the given name of the given job,
+// replacing any previous artifact with the same name. The artifact expires after the
+// artifact retention of the site configuration.
+func (s *Service) Upload(ctx context.Context, queue string, jobID int, name string, content io.Reader) (store.Artifact, error) {
+	if err := types.ValidateArtifactName(name); err != nil {
+		return store.Artifact{}, err
+	}
+
+	key := objectKey(queue, jobID, name)
+	r := &limitedReader{r: content, n: types.MaxArtifactSize}
+	size, err := s.uploadStore.Upload(ctx, key, r)
+	if err != nil {
+		// Do not keep a partially written object around.
+		_ = s.uploadStore.Delete(ctx, key)
+		if r.exceeded {
+			return store.Artifact{}, ErrTooLarge
+		}
+		return store.Artifact{}, err
+	}
+
+	artifact := store.Artifact{
+		Queue:     queue,
+		JobID:     jobID,
+		Name:      name,
+		ObjectKey: key,
+		Size:      size,
+		ExpiresAt: time.Now().Add(conf.ExecutorsArtifactRetention()),
+	}
+	if err := s.store.Upsert(ctx, artifact); err != nil {
+		return store.Artifact{}, err
+	}
+	return artifact, nil
+}
+
+// List returns the unexpired artifacts of the given job.
+func (s *Service) List(ctx context.Context, queue string, jobID int) ([]store.Artifact, error) {
+	return s.store.List(ctx, queue, jobID)
+}
+
+// Open returns the unexpired artifact with the given name of the given job, along with a
+// reader of its content. The reader must be closed by the caller.
+func (s *Service) Open(ctx context.Context, queue string, jobID int, name string) (store.Artifact, io.ReadCloser, bool, error) {
+	artifact, ok, err := s.store.Get(ctx, queue, jobID, name)
+	if err != nil || !ok {
+		return store.Artifact{}, nil, false, err
+	}
+
+	rc, err := s.uploadStore.Get(ctx, artifact.ObjectKey)
+	if err != nil {
+		return store.Artifact{}, nil, false, err
+	}
+	return artifact, rc, true, nil
+}
+
+// expireBatchSize is the maximum number of artifacts deleted by a single call to DeleteExpired.
+const expireBatchSize = 500
+
+// DeleteExpired deletes the content and the metadata of artifacts that expired before the
+// given time. Metadata is only deleted once the content was deleted, so that content is
+// never orphaned in the upload store. It returns the number of deleted artifacts.
+func (s *Service) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	artifacts, err := s.store.ListExpired(ctx, now, expireBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		ids  = make([]int, 0, len(artifacts))
+		errs error
+	)
+	for _, artifact := range artifacts {
+		if err := s.uploadStore.Delete(ctx, artifact.ObjectKey); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "deleting artifact %q", artifact.ObjectKey))
+			continue
+		}
+		ids = append(ids, artifact.ID)
+	}
+
+	if err := s.store.Delete(ctx, ids); err != nil {
+		errs = errors.Append(errs, err)
+		return 0, errs
+	}
+	return len(ids), errs
+}
+
+// objectKey returns the key of the content of the given artifact in the upload store.
+func objectKey(queue string, jobID int, name string) string {
+	return fmt.Sprintf("%s/%d/%s", queue, jobID, name)
+}
+
+// limitedReader reads from r, and fails with ErrTooLarge once more than n bytes were read.
+type limitedReader struct {
+	r        io.Reader
+	n        int64
+	exceeded bool
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+	if l.n < 0 {
+		l.exceeded = true
+		return n, ErrTooLarge
+	}
+	return n, err
+}
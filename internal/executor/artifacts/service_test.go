@@ -0,0 +1,78 @@
+package artifacts
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/executor/store"
+	"github.com/sourcegraph/sourcegraph/internal/executor/types"
+	uploadstoremocks "github.com/sourcegraph/sourcegraph/internal/uploadstore/mocks"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestService_Upload(t *testing.T) {
+	artifactStore := store.NewMockArtifactStore()
+	uploadStore := uploadstoremocks.NewMockStore()
+	uploadStore.UploadFunc.SetDefaultHook(func(ctx context.Context, key string, r io.Reader) (int64, error) {
+		return io.Copy(io.Discard, r)
+	})
+	svc := NewService(artifactStore, uploadStore)
+
+	artifact, err := svc.Upload(context.Background(), "batches", 42, "reports/unit.xml", strings.NewReader("<testsuites/>"))
+	require.NoError(t, err)
+	assert.Equal(t, "batches/42/reports/unit.xml", artifact.ObjectKey)
+	assert.Equal(t, int64(13), artifact.Size)
+	assert.WithinDuration(t, time.Now().Add(7*24*time.Hour), artifact.ExpiresAt, time.Minute)
+
+	require.Len(t, uploadStore.UploadFunc.History(), 1)
+	assert.Equal(t, "batches/42/reports/unit.xml", uploadStore.UploadFunc.History()[0].Arg1)
+	require.Len(t, artifactStore.UpsertFunc.History(), 1)
+	assert.Equal(t, artifact, artifactStore.UpsertFunc.History()[0].Arg1)
+
+	t.Run("invalid name", func(t *testing.T) {
+		_, err := svc.Upload(context.Background(), "batches", 42, "../secret", strings.NewReader(""))
+		require.Error(t, err)
+		assert.Len(t, uploadStore.UploadFunc.History(), 1)
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		content := bytes.NewReader(make([]byte, types.MaxArtifactSize+1))
+		_, err := svc.Upload(context.Background(), "batches", 42, "large.bin", content)
+		assert.ErrorIs(t, err, ErrTooLarge)
+		require.Len(t, uploadStore.DeleteFunc.History(), 1)
+		assert.Equal(t, "batches/42/large.bin", uploadStore.DeleteFunc.History()[0].Arg1)
+		assert.Len(t, artifactStore.UpsertFunc.History(), 1)
+	})
+}
+
+func TestService_DeleteExpired(t *testing.T) {
+	artifactStore := store.NewMockArtifactStore()
+	artifactStore.ListExpiredFunc.SetDefaultReturn([]store.Artifact{
+		{ID: 1, ObjectKey: "batches/1/a.log"},
+		{ID: 2, ObjectKey: "batches/1/b.log"},
+		{ID: 3, ObjectKey: "batches/2/c.log"},
+	}, nil)
+	uploadStore := uploadstoremocks.NewMockStore()
+	uploadStore.DeleteFunc.SetDefaultHook(func(ctx context.Context, key string) error {
+		if key == "batches/1/b.log" {
+			return errors.New("failed")
+		}
+		return nil
+	})
+	svc := NewService(artifactStore, uploadStore)
+
+	count, err := svc.DeleteExpired(context.Background(), time.Now())
+	require.Error(t, err)
+	assert.Equal(t, 2, count)
+
+	// Metadata of artifacts whose content could not be deleted is kept for the next run.
+	require.Len(t, artifactStore.DeleteFunc.History(), 1)
+	assert.Equal(t, []int{1, 3}, artifactStore.DeleteFunc.History()[0].Arg1)
+}
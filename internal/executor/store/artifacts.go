@@ -0,0 +1,131 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+)
+
+// ArtifactStore is the store for interacting with the executor_job_artifacts table.
+type ArtifactStore interface {
+	// Upsert creates the Artifact, or replaces the one with the same queue, job ID and name.
+	Upsert(ctx context.Context, artifact Artifact) error
+	// List retrieves the unexpired Artifacts of the specified job, ordered by name.
+	List(ctx context.Context, queue string, jobID int) ([]Artifact, error)
+	// Get retrieves the unexpired Artifact matching the specified values.
+	Get(ctx context.Context, queue string, jobID int, name string) (Artifact, bool, error)
+	// ListExpired retrieves at most limit Artifacts that expired before the given time.
+	ListExpired(ctx context.Context, now time.Time, limit int) ([]Artifact, error)
+	// Delete deletes the Artifacts with the given IDs.
+	Delete(ctx context.Context, ids []int) error
+}
+
+// Artifact is a file uploaded by an executor for a specific Job.
+type Artifact struct {
+	ID        int
+	Queue     string
+	JobID     int
+	Name      string
+	ObjectKey string
+	Size      int64
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+type artifactStore struct {
+	*basestore.Store
+}
+
+// NewArtifactStore creates a new ArtifactStore.
+func NewArtifactStore(db database.DB) ArtifactStore {
+	return &artifactStore{Store: basestore.NewWithHandle(db.Handle())}
+}
+
+func (s *artifactStore) Upsert(ctx context.Context, artifact Artifact) error {
+	return s.Exec(
+		ctx,
+		sqlf.Sprintf(
+			upsertExecutorJobArtifactFmtstr,
+			artifact.Queue, artifact.JobID, artifact.Name, artifact.ObjectKey, artifact.Size, artifact.ExpiresAt,
+		),
+	)
+}
+
+const upsertExecutorJobArtifactFmtstr = `
+INSERT INTO executor_job_artifacts (queue, job_id, name, object_key, size, expires_at)
+VALUES (%s, %s, %s, %s, %s, %s)
+ON CONFLICT (queue, job_id, name) DO UPDATE SET
+	object_key = EXCLUDED.object_key,
+	size = EXCLUDED.size,
+	created_at = NOW(),
+	expires_at = EXCLUDED.expires_at
+`
+
+func (s *artifactStore) List(ctx context.Context, queue string, jobID int) ([]Artifact, error) {
+	return scanArtifacts(s.Query(ctx, sqlf.Sprintf(listExecutorJobArtifactsFmtstr, queue, jobID)))
+}
+
+const listExecutorJobArtifactsFmtstr = `
+SELECT id, queue, job_id, name, object_key, size, created_at, expires_at
+FROM executor_job_artifacts
+WHERE queue = %s AND job_id = %s AND expires_at > NOW()
+ORDER BY name
+`
+
+func (s *artifactStore) Get(ctx context.Context, queue string, jobID int, name string) (Artifact, bool, error) {
+	return scanFirstArtifact(s.Query(ctx, sqlf.Sprintf(getExecutorJobArtifactFmtstr, queue, jobID, name)))
+}
+
+const getExecutorJobArtifactFmtstr = `
+SELECT id, queue, job_id, name, object_key, size, created_at, expires_at
+FROM executor_job_artifacts
+WHERE queue = %s AND job_id = %s AND name = %s AND expires_at > NOW()
+`
+
+func (s *artifactStore) ListExpired(ctx context.Context, now time.Time, limit int) ([]Artifact, error) {
+	return scanArtifacts(s.Query(ctx, sqlf.Sprintf(listExpiredExecutorJobArtifactsFmtstr, now, limit)))
+}
+
+const listExpiredExecutorJobArtifactsFmtstr = `
+SELECT id, queue, job_id, name, object_key, size, created_at, expires_at
+FROM executor_job_artifacts
+WHERE expires_at <= %s
+ORDER BY expires_at, id
+LIMIT %s
+`
+
+func (s *artifactStore) Delete(ctx context.Context, ids []int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return s.Exec(ctx, sqlf.Sprintf(deleteExecutorJobArtifactsFmtstr, pq.Array(ids)))
+}
+
+const deleteExecutorJobArtifactsFmtstr = `
+DELETE FROM executor_job_artifacts WHERE id = ANY(%s)
+`
+
+func scanArtifact(s dbutil.Scanner) (artifact Artifact, _ error) {
+	err := s.Scan(
+		&artifact.ID,
+		&artifact.Queue,
+		&artifact.JobID,
+		&artifact.Name,
+		&artifact.ObjectKey,
+		&artifact.Size,
+		&artifact.CreatedAt,
+		&artifact.ExpiresAt,
+	)
+	return artifact, err
+}
+
+var (
+	scanArtifacts     = basestore.NewSliceScanner(scanArtifact)
+	scanFirstArtifact = basestore.NewFirstScanner(scanArtifact)
+)
@@ -0,0 +1,79 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/executor/store"
+)
+
+func TestArtifactStore(t *testing.T) {
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	artifactStore := store.NewArtifactStore(db)
+
+	ctx := context.Background()
+	now := time.Now()
+
+	for _, artifact := range []store.Artifact{
+		{Queue: "batches", JobID: 10, Name: "reports/unit.xml", ObjectKey: "batches/10/reports/unit.xml", Size: 10, ExpiresAt: now.Add(time.Hour)},
+		{Queue: "batches", JobID: 10, Name: "coverage.out", ObjectKey: "batches/10/coverage.out", Size: 20, ExpiresAt: now.Add(time.Hour)},
+		{Queue: "batches", JobID: 10, Name: "old.log", ObjectKey: "batches/10/old.log", Size: 30, ExpiresAt: now.Add(-time.Hour)},
+		{Queue: "codeintel", JobID: 10, Name: "index.scip", ObjectKey: "codeintel/10/index.scip", Size: 40, ExpiresAt: now.Add(time.Hour)},
+	} {
+		require.NoError(t, artifactStore.Upsert(ctx, artifact))
+	}
+
+	t.Run("List", func(t *testing.T) {
+		artifacts, err := artifactStore.List(ctx, "batches", 10)
+		require.NoError(t, err)
+		require.Len(t, artifacts, 2)
+		assert.Equal(t, "coverage.out", artifacts[0].Name)
+		assert.Equal(t, "reports/unit.xml", artifacts[1].Name)
+
+		artifacts, err = artifactStore.List(ctx, "batches", 11)
+		require.NoError(t, err)
+		assert.Empty(t, artifacts)
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		artifact, ok, err := artifactStore.Get(ctx, "codeintel", 10, "index.scip")
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, "codeintel/10/index.scip", artifact.ObjectKey)
+		assert.Equal(t, int64(40), artifact.Size)
+
+		_, ok, err = artifactStore.Get(ctx, "batches", 10, "old.log")
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("Upsert replaces", func(t *testing.T) {
+		require.NoError(t, artifactStore.Upsert(ctx, store.Artifact{Queue: "batches", JobID: 10, Name: "coverage.out", ObjectKey: "batches/10/coverage.out", Size: 25, ExpiresAt: now.Add(time.Hour)}))
+
+		artifact, ok, err := artifactStore.Get(ctx, "batches", 10, "coverage.out")
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, int64(25), artifact.Size)
+	})
+
+	t.Run("ListExpired and Delete", func(t *testing.T) {
+		expired, err := artifactStore.ListExpired(ctx, now, 10)
+		require.NoError(t, err)
+		require.Len(t, expired, 1)
+		assert.Equal(t, "old.log", expired[0].Name)
+
+		require.NoError(t, artifactStore.Delete(ctx, []int{expired[0].ID}))
+
+		expired, err = artifactStore.ListExpired(ctx, now.Add(2*time.Hour), 10)
+		require.NoError(t, err)
+		assert.Len(t, expired, 3)
+	})
+}
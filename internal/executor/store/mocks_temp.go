@@ -9,8 +9,663 @@ package store
 import (
 	"context"
 	"sync"
+	"time"
 )
 
+// MockArtifactStore is a mock implementation of the ArtifactStore interface
+// (from the package
+// github.com/sourcegraph/sourcegraph/internal/executor/store) used for unit
+// testing.
+type MockArtifactStore struct {
+	// DeleteFunc is an instance of a mock function object controlling the
+	// behavior of the method Delete.
+	DeleteFunc *ArtifactStoreDeleteFunc
+	// GetFunc is an instance of a mock function object controlling the
+	// behavior of the method Get.
+	GetFunc *ArtifactStoreGetFunc
+	// ListFunc is an instance of a mock function object controlling the
+	// behavior of the method List.
+	ListFunc *ArtifactStoreListFunc
+	// ListExpiredFunc is an instance of a mock function object controlling
+	// the behavior of the method ListExpired.
+	ListExpiredFunc *ArtifactStoreListExpiredFunc
+	// UpsertFunc is an instance of a mock function object controlling the
+	// behavior of the method Upsert.
+	UpsertFunc *ArtifactStoreUpsertFunc
+}
+
+// NewMockArtifactStore creates a new mock of the ArtifactStore interface.
+// All methods return zero values for all results, unless overwritten.
+func NewMockArtifactStore() *MockArtifactStore {
+	return &MockArtifactStore{
+		DeleteFunc: &ArtifactStoreDeleteFunc{
+			defaultHook: func(context.Context, []int) (r0 error) {
+				return
+			},
+		},
+		GetFunc: &ArtifactStoreGetFunc{
+			defaultHook: func(context.Context, string, int, string) (r0 Artifact, r1 bool, r2 error) {
+				return
+			},
+		},
+		ListFunc: &ArtifactStoreListFunc{
+			defaultHook: func(context.Context, string, int) (r0 []Artifact, r1 error) {
+				return
+			},
+		},
+		ListExpiredFunc: &ArtifactStoreListExpiredFunc{
+			defaultHook: func(context.Context, time.Time, int) (r0 []Artifact, r1 error) {
+				return
+			},
+		},
+		UpsertFunc: &ArtifactStoreUpsertFunc{
+			defaultHook: func(context.Context, Artifact) (r0 error) {
+				return
+			},
+		},
+	}
+}
+
+// NewStrictMockArtifactStore creates a new mock of the ArtifactStore
+// interface. All methods panic on invocation, unless overwritten.
+func NewStrictMockArtifactStore() *MockArtifactStore {
+	return &MockArtifactStore{
+		DeleteFunc: &ArtifactStoreDeleteFunc{
+			defaultHook: func(context.Context, []int) error {
+				panic("unexpected invocation of MockArtifactStore.Delete")
+			},
+		},
+		GetFunc: &ArtifactStoreGetFunc{
+			defaultHook: func(context.Context, string, int, string) (Artifact, bool, error) {
+				panic("unexpected invocation of MockArtifactStore.Get")
+			},
+		},
+		ListFunc: &ArtifactStoreListFunc{
+			defaultHook: func(context.Context, string, int) ([]Artifact, error) {
+				panic("unexpected invocation of MockArtifactStore.List")
+			},
+		},
+		ListExpiredFunc: &ArtifactStoreListExpiredFunc{
+			defaultHook: func(context.Context, time.Time, int) ([]Artifact, error) {
+				panic("unexpected invocation of MockArtifactStore.ListExpired")
+			},
+		},
+		UpsertFunc: &ArtifactStoreUpsertFunc{
+			defaultHook: func(context.Context, Artifact) error {
+				panic("unexpected invocation of MockArtifactStore.Upsert")
+			},
+		},
+	}
+}
+
+// NewMockArtifactStoreFrom creates a new mock of the MockArtifactStore
+// interface. All methods delegate to the given implementation, unless
+// overwritten.
+func NewMockArtifactStoreFrom(i ArtifactStore) *MockArtifactStore {
+	return &MockArtifactStore{
+		DeleteFunc: &ArtifactStoreDeleteFunc{
+			defaultHook: i.Delete,
+		},
+		GetFunc: &ArtifactStoreGetFunc{
+			defaultHook: i.Get,
+		},
+		ListFunc: &ArtifactStoreListFunc{
+			defaultHook: i.List,
+		},
+		ListExpiredFunc: &ArtifactStoreListExpiredFunc{
+			defaultHook: i.ListExpired,
+		},
+		UpsertFunc: &ArtifactStoreUpsertFunc{
+			defaultHook: i.Upsert,
+		},
+	}
+}
+
+// ArtifactStoreDeleteFunc describes the behavior when the Delete method of
+// the parent MockArtifactStore instance is invoked.
+type ArtifactStoreDeleteFunc struct {
+	defaultHook func(context.Context, []int) error
+	hooks       []func(context.Context, []int) error
+	history     []ArtifactStoreDeleteFuncCall
+	mutex       sync.Mutex
+}
+
+// Delete delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockArtifactStore) Delete(v0 context.Context, v1 []int) error {
+	r0 := m.DeleteFunc.nextHook()(v0, v1)
+	m.DeleteFunc.appendCall(ArtifactStoreDeleteFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Delete method of the
+// parent MockArtifactStore instance is invoked and the hook queue is empty.
+func (f *ArtifactStoreDeleteFunc) SetDefaultHook(hook func(context.Context, []int) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Delete method of the parent MockArtifactStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ArtifactStoreDeleteFunc) PushHook(hook func(context.Context, []int) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ArtifactStoreDeleteFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, []int) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ArtifactStoreDeleteFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, []int) error {
+		return r0
+	})
+}
+
+func (f *ArtifactStoreDeleteFunc) nextHook() func(context.Context, []int) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ArtifactStoreDeleteFunc) appendCall(r0 ArtifactStoreDeleteFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ArtifactStoreDeleteFuncCall objects
+// describing the invocations of this function.
+func (f *ArtifactStoreDeleteFunc) History() []ArtifactStoreDeleteFuncCall {
+	f.mutex.Lock()
+	history := make([]ArtifactStoreDeleteFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ArtifactStoreDeleteFuncCall is an object that describes an invocation of
+// method Delete on an instance of MockArtifactStore.
+type ArtifactStoreDeleteFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 []int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ArtifactStoreDeleteFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ArtifactStoreDeleteFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ArtifactStoreGetFunc describes the behavior when the Get method of the
+// parent MockArtifactStore instance is invoked.
+type ArtifactStoreGetFunc struct {
+	defaultHook func(context.Context, string, int, string) (Artifact, bool, error)
+	hooks       []func(context.Context, string, int, string) (Artifact, bool, error)
+	history     []ArtifactStoreGetFuncCall
+	mutex       sync.Mutex
+}
+
+// Get delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockArtifactStore) Get(v0 context.Context, v1 string, v2 int, v3 string) (Artifact, bool, error) {
+	r0, r1, r2 := m.GetFunc.nextHook()(v0, v1, v2, v3)
+	m.GetFunc.appendCall(ArtifactStoreGetFuncCall{v0, v1, v2, v3, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the Get method of the
+// parent MockArtifactStore instance is invoked and the hook queue is empty.
+func (f *ArtifactStoreGetFunc) SetDefaultHook(hook func(context.Context, string, int, string) (Artifact, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Get method of the parent MockArtifactStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ArtifactStoreGetFunc) PushHook(hook func(context.Context, string, int, string) (Artifact, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ArtifactStoreGetFunc) SetDefaultReturn(r0 Artifact, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, string, int, string) (Artifact, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ArtifactStoreGetFunc) PushReturn(r0 Artifact, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, string, int, string) (Artifact, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *ArtifactStoreGetFunc) nextHook() func(context.Context, string, int, string) (Artifact, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ArtifactStoreGetFunc) appendCall(r0 ArtifactStoreGetFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ArtifactStoreGetFuncCall objects describing
+// the invocations of this function.
+func (f *ArtifactStoreGetFunc) History() []ArtifactStoreGetFuncCall {
+	f.mutex.Lock()
+	history := make([]ArtifactStoreGetFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ArtifactStoreGetFuncCall is an object that describes an invocation of
+// method Get on an instance of MockArtifactStore.
+type ArtifactStoreGetFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 Artifact
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ArtifactStoreGetFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ArtifactStoreGetFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// ArtifactStoreListFunc describes the behavior when the List method of the
+// parent MockArtifactStore instance is invoked.
+type ArtifactStoreListFunc struct {
+	defaultHook func(context.Context, string, int) ([]Artifact, error)
+	hooks       []func(context.Context, string, int) ([]Artifact, error)
+	history     []ArtifactStoreListFuncCall
+	mutex       sync.Mutex
+}
+
+// List delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockArtifactStore) List(v0 context.Context, v1 string, v2 int) ([]Artifact, error) {
+	r0, r1 := m.ListFunc.nextHook()(v0, v1, v2)
+	m.ListFunc.appendCall(ArtifactStoreListFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the List method of the
+// parent MockArtifactStore instance is invoked and the hook queue is empty.
+func (f *ArtifactStoreListFunc) SetDefaultHook(hook func(context.Context, string, int) ([]Artifact, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// List method of the parent MockArtifactStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ArtifactStoreListFunc) PushHook(hook func(context.Context, string, int) ([]Artifact, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ArtifactStoreListFunc) SetDefaultReturn(r0 []Artifact, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, int) ([]Artifact, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ArtifactStoreListFunc) PushReturn(r0 []Artifact, r1 error) {
+	f.PushHook(func(context.Context, string, int) ([]Artifact, error) {
+		return r0, r1
+	})
+}
+
+func (f *ArtifactStoreListFunc) nextHook() func(context.Context, string, int) ([]Artifact, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ArtifactStoreListFunc) appendCall(r0 ArtifactStoreListFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ArtifactStoreListFuncCall objects
+// describing the invocations of this function.
+func (f *ArtifactStoreListFunc) History() []ArtifactStoreListFuncCall {
+	f.mutex.Lock()
+	history := make([]ArtifactStoreListFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ArtifactStoreListFuncCall is an object that describes an invocation of
+// method List on an instance of MockArtifactStore.
+type ArtifactStoreListFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []Artifact
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ArtifactStoreListFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ArtifactStoreListFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ArtifactStoreListExpiredFunc describes the behavior when the ListExpired
+// method of the parent MockArtifactStore instance is invoked.
+type ArtifactStoreListExpiredFunc struct {
+	defaultHook func(context.Context, time.Time, int) ([]Artifact, error)
+	hooks       []func(context.Context, time.Time, int) ([]Artifact, error)
+	history     []ArtifactStoreListExpiredFuncCall
+	mutex       sync.Mutex
+}
+
+// ListExpired delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockArtifactStore) ListExpired(v0 context.Context, v1 time.Time, v2 int) ([]Artifact, error) {
+	r0, r1 := m.ListExpiredFunc.nextHook()(v0, v1, v2)
+	m.ListExpiredFunc.appendCall(ArtifactStoreListExpiredFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListExpired method
+// of the parent MockArtifactStore instance is invoked and the hook queue is
+// empty.
+func (f *ArtifactStoreListExpiredFunc) SetDefaultHook(hook func(context.Context, time.Time, int) ([]Artifact, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListExpired method of the parent MockArtifactStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *ArtifactStoreListExpiredFunc) PushHook(hook func(context.Context, time.Time, int) ([]Artifact, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ArtifactStoreListExpiredFunc) SetDefaultReturn(r0 []Artifact, r1 error) {
+	f.SetDefaultHook(func(context.Context, time.Time, int) ([]Artifact, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ArtifactStoreListExpiredFunc) PushReturn(r0 []Artifact, r1 error) {
+	f.PushHook(func(context.Context, time.Time, int) ([]Artifact, error) {
+		return r0, r1
+	})
+}
+
+func (f *ArtifactStoreListExpiredFunc) nextHook() func(context.Context, time.Time, int) ([]Artifact, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ArtifactStoreListExpiredFunc) appendCall(r0 ArtifactStoreListExpiredFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ArtifactStoreListExpiredFuncCall objects
+// describing the invocations of this function.
+func (f *ArtifactStoreListExpiredFunc) History() []ArtifactStoreListExpiredFuncCall {
+	f.mutex.Lock()
+	history := make([]ArtifactStoreListExpiredFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ArtifactStoreListExpiredFuncCall is an object that describes an
+// invocation of method ListExpired on an instance of MockArtifactStore.
+type ArtifactStoreListExpiredFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 time.Time
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []Artifact
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ArtifactStoreListExpiredFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ArtifactStoreListExpiredFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// ArtifactStoreUpsertFunc describes the behavior when the Upsert method of
+// the parent MockArtifactStore instance is invoked.
+type ArtifactStoreUpsertFunc struct {
+	defaultHook func(context.Context, Artifact) error
+	hooks       []func(context.Context, Artifact) error
+	history     []ArtifactStoreUpsertFuncCall
+	mutex       sync.Mutex
+}
+
+// Upsert delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockArtifactStore) Upsert(v0 context.Context, v1 Artifact) error {
+	r0 := m.UpsertFunc.nextHook()(v0, v1)
+	m.UpsertFunc.appendCall(ArtifactStoreUpsertFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Upsert method of the
+// parent MockArtifactStore instance is invoked and the hook queue is empty.
+func (f *ArtifactStoreUpsertFunc) SetDefaultHook(hook func(context.Context, Artifact) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Upsert method of the parent MockArtifactStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ArtifactStoreUpsertFunc) PushHook(hook func(context.Context, Artifact) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ArtifactStoreUpsertFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, Artifact) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ArtifactStoreUpsertFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, Artifact) error {
+		return r0
+	})
+}
+
+func (f *ArtifactStoreUpsertFunc) nextHook() func(context.Context, Artifact) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ArtifactStoreUpsertFunc) appendCall(r0 ArtifactStoreUpsertFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ArtifactStoreUpsertFuncCall objects
+// describing the invocations of this function.
+func (f *ArtifactStoreUpsertFunc) History() []ArtifactStoreUpsertFuncCall {
+	f.mutex.Lock()
+	history := make([]ArtifactStoreUpsertFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ArtifactStoreUpsertFuncCall is an object that describes an invocation of
+// method Upsert on an instance of MockArtifactStore.
+type ArtifactStoreUpsertFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 Artifact
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ArtifactStoreUpsertFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ArtifactStoreUpsertFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // MockJobTokenStore is a mock implementation of the JobTokenStore interface
 // (from the package
 // github.com/sourcegraph/sourcegraph/internal/executor/store) used for unit
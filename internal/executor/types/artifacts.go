@@ -0,0 +1,39 @@
+package types
+
+import (
+	"path"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	// MaxArtifactSize is the maximum size in bytes of a single artifact of a job.
+	// Larger files are skipped by executors and rejected by Sourcegraph.
+	MaxArtifactSize = 100 * 1024 * 1024
+
+	// MaxArtifactsPerJob is the maximum number of artifacts a single job uploads.
+	MaxArtifactsPerJob = 100
+
+	// maxArtifactNameLength is the maximum length of the name of an artifact.
+	maxArtifactNameLength = 1024
+)
+
+// ValidateArtifactName returns an error if the given name is not a valid artifact
+// name. Artifact names are slash-separated paths relative to the repository
+// directory of a job, and must not escape it.
+func ValidateArtifactName(name string) error {
+	if name == "" {
+		return errors.New("artifact name must not be empty")
+	}
+	if len(name) > maxArtifactNameLength {
+		return errors.Newf("artifact name must not be longer than %d characters", maxArtifactNameLength)
+	}
+	if path.IsAbs(name) || strings.Contains(name, "\\") {
+		return errors.Newf("artifact name %q must be a relative, slash-separated path", name)
+	}
+	if path.Clean(name) != name || name == "." || name == ".." || strings.HasPrefix(name, "../") {
+		return errors.Newf("artifact name %q must be a clean path within the repository directory", name)
+	}
+	return nil
+}
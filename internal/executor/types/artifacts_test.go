@@ -0,0 +1,31 @@
+package types_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/internal/executor/types"
+)
+
+func TestValidateArtifactName(t *testing.T) {
+	for _, name := range []string{"report.xml", "build/logs/test.log", "index.scip", ".hidden/file"} {
+		assert.NoError(t, types.ValidateArtifactName(name), name)
+	}
+
+	for _, name := range []string{
+		"",
+		".",
+		"..",
+		"../secret",
+		"/etc/passwd",
+		"build/../../secret",
+		"build//test.log",
+		"build/./test.log",
+		`build\test.log`,
+		strings.Repeat("a", 1025),
+	} {
+		assert.Error(t, types.ValidateArtifactName(name), name)
+	}
+}
@@ -0,0 +1,159 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/c2h5oh/datasize"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ExecutorCapabilities describes what an executor can run. Executors advertise their capabilities when dequeueing
+// jobs and on every heartbeat.
+type ExecutorCapabilities struct {
+	// Labels are arbitrary capabilities of the executor, for example "gpu" or "network:internal".
+	Labels []string
+	// NumCPUs is the number of CPUs available to a job. Zero means unknown.
+	NumCPUs int
+	// Memory is the amount of memory available to a job, such as "12G". Empty means unknown.
+	Memory string
+	// DiskSpace is the amount of disk space available to a job, such as "20G". Empty means unknown.
+	DiskSpace string
+}
+
+// JobRequirements describes the capabilities an executor needs to run a job. Requirements are resolved by
+// Sourcegraph when a job is dequeued and are never sent to executors.
+type JobRequirements struct {
+	// Labels must all be advertised by the executor.
+	Labels []string
+	// NumCPUs is the minimum number of CPUs the executor must provide.
+	NumCPUs int
+	// Memory is the minimum amount of memory the executor must provide.
+	Memory string
+	// DiskSpace is the minimum amount of disk space the executor must provide.
+	DiskSpace string
+}
+
+// IsZero returns true if the job can be run by any executor.
+func (r JobRequirements) IsZero() bool {
+	return len(r.Labels) == 0 && r.NumCPUs == 0 && r.Memory == "" && r.DiskSpace == ""
+}
+
+// Merge returns the requirements that satisfy both r and other: the union of the labels and the larger of each
+// resource request.
+func (r JobRequirements) Merge(other JobRequirements) JobRequirements {
+	merged := JobRequirements{
+		Labels:    mergeLabels(r.Labels, other.Labels),
+		NumCPUs:   r.NumCPUs,
+		Memory:    r.Memory,
+		DiskSpace: r.DiskSpace,
+	}
+	if other.NumCPUs > merged.NumCPUs {
+		merged.NumCPUs = other.NumCPUs
+	}
+	if largerSize(other.Memory, merged.Memory) {
+		merged.Memory = other.Memory
+	}
+	if largerSize(other.DiskSpace, merged.DiskSpace) {
+		merged.DiskSpace = other.DiskSpace
+	}
+	return merged
+}
+
+// Validate returns an error if the resource requests of the requirements cannot be parsed.
+func (r JobRequirements) Validate() error {
+	var errs error
+	if r.NumCPUs < 0 {
+		errs = errors.Append(errs, errors.Newf("invalid number of CPUs %d", r.NumCPUs))
+	}
+	if r.Memory != "" {
+		if _, err := datasize.ParseString(r.Memory); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "invalid memory %q", r.Memory))
+		}
+	}
+	if r.DiskSpace != "" {
+		if _, err := datasize.ParseString(r.DiskSpace); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "invalid disk space %q", r.DiskSpace))
+		}
+	}
+	return errs
+}
+
+// Unsatisfied returns a description of each requirement that an executor with the given capabilities does not
+// satisfy. An executor that does not advertise a resource does not satisfy any request for it.
+func (r JobRequirements) Unsatisfied(capabilities ExecutorCapabilities) []string {
+	var unsatisfied []string
+
+	advertised := make(map[string]struct{}, len(capabilities.Labels))
+	for _, label := range capabilities.Labels {
+		advertised[label] = struct{}{}
+	}
+	for _, label := range r.Labels {
+		if _, ok := advertised[label]; !ok {
+			unsatisfied = append(unsatisfied, fmt.Sprintf("label %q", label))
+		}
+	}
+
+	if r.NumCPUs > 0 && capabilities.NumCPUs < r.NumCPUs {
+		unsatisfied = append(unsatisfied, fmt.Sprintf("%d CPUs", r.NumCPUs))
+	}
+	if r.Memory != "" && !sizeAtLeast(capabilities.Memory, r.Memory) {
+		unsatisfied = append(unsatisfied, fmt.Sprintf("%s memory", r.Memory))
+	}
+	if r.DiskSpace != "" && !sizeAtLeast(capabilities.DiskSpace, r.DiskSpace) {
+		unsatisfied = append(unsatisfied, fmt.Sprintf("%s disk space", r.DiskSpace))
+	}
+
+	return unsatisfied
+}
+
+// SatisfiedBy returns true if an executor with the given capabilities can run the job.
+func (r JobRequirements) SatisfiedBy(capabilities ExecutorCapabilities) bool {
+	return len(r.Unsatisfied(capabilities)) == 0
+}
+
+// sizeAtLeast returns true if the size available is at least the size requested. Sizes that cannot be parsed
+// are never satisfied.
+func sizeAtLeast(available, requested string) bool {
+	a, err := datasize.ParseString(available)
+	if err != nil {
+		return false
+	}
+	b, err := datasize.ParseString(requested)
+	if err != nil {
+		return false
+	}
+	return a >= b
+}
+
+// largerSize returns true if size a is larger than size b. If either size cannot be parsed, a is considered
+// larger, so that invalid requests are never silently dropped.
+func largerSize(a, b string) bool {
+	if a == "" {
+		return false
+	}
+	if b == "" {
+		return true
+	}
+	return !sizeAtLeast(b, a)
+}
+
+func mergeLabels(a, b []string) []string {
+	if len(a) == 0 && len(b) == 0 {
+		return nil
+	}
+	set := make(map[string]struct{}, len(a)+len(b))
+	for _, label := range a {
+		set[label] = struct{}{}
+	}
+	for _, label := range b {
+		set[label] = struct{}{}
+	}
+	labels := make([]string, 0, len(set))
+	for label := range set {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	return labels
+}
@@ -0,0 +1,102 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/internal/executor/types"
+)
+
+func TestJobRequirements_Unsatisfied(t *testing.T) {
+	capabilities := types.ExecutorCapabilities{
+		Labels:    []string{"gpu", "network:internal"},
+		NumCPUs:   8,
+		Memory:    "16G",
+		DiskSpace: "100G",
+	}
+
+	tests := []struct {
+		name         string
+		requirements types.JobRequirements
+		expected     []string
+	}{
+		{
+			name:         "no requirements",
+			requirements: types.JobRequirements{},
+		},
+		{
+			name: "satisfied",
+			requirements: types.JobRequirements{
+				Labels:    []string{"gpu"},
+				NumCPUs:   8,
+				Memory:    "16384M",
+				DiskSpace: "20GB",
+			},
+		},
+		{
+			name: "missing label",
+			requirements: types.JobRequirements{
+				Labels: []string{"gpu", "arm64"},
+			},
+			expected: []string{`label "arm64"`},
+		},
+		{
+			name: "insufficient resources",
+			requirements: types.JobRequirements{
+				NumCPUs:   16,
+				Memory:    "32G",
+				DiskSpace: "1T",
+			},
+			expected: []string{"16 CPUs", "32G memory", "1T disk space"},
+		},
+		{
+			name: "invalid request",
+			requirements: types.JobRequirements{
+				Memory: "lots",
+			},
+			expected: []string{"lots memory"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			unsatisfied := test.requirements.Unsatisfied(capabilities)
+			if diff := cmp.Diff(test.expected, unsatisfied); diff != "" {
+				t.Errorf("unexpected unsatisfied requirements (-want +got):\n%s", diff)
+			}
+			assert.Equal(t, len(test.expected) == 0, test.requirements.SatisfiedBy(capabilities))
+		})
+	}
+
+	t.Run("unknown resources", func(t *testing.T) {
+		requirements := types.JobRequirements{NumCPUs: 1, Memory: "1G"}
+		assert.Equal(t, []string{"1 CPUs", "1G memory"}, requirements.Unsatisfied(types.ExecutorCapabilities{}))
+	})
+}
+
+func TestJobRequirements_Merge(t *testing.T) {
+	a := types.JobRequirements{Labels: []string{"gpu"}, NumCPUs: 4, Memory: "8G"}
+	b := types.JobRequirements{Labels: []string{"arm64", "gpu"}, NumCPUs: 2, Memory: "10240M", DiskSpace: "50G"}
+
+	expected := types.JobRequirements{
+		Labels:    []string{"arm64", "gpu"},
+		NumCPUs:   4,
+		Memory:    "10240M",
+		DiskSpace: "50G",
+	}
+	if diff := cmp.Diff(expected, a.Merge(b)); diff != "" {
+		t.Errorf("unexpected merged requirements (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expected, b.Merge(a)); diff != "" {
+		t.Errorf("unexpected merged requirements (-want +got):\n%s", diff)
+	}
+	assert.True(t, types.JobRequirements{}.Merge(types.JobRequirements{}).IsZero())
+}
+
+func TestJobRequirements_Validate(t *testing.T) {
+	assert.NoError(t, types.JobRequirements{NumCPUs: 2, Memory: "2G", DiskSpace: "20 GB"}.Validate())
+	assert.Error(t, types.JobRequirements{Memory: "2 gigs"}.Validate())
+	assert.Error(t, types.JobRequirements{NumCPUs: -1}.Validate())
+}
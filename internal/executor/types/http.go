@@ -15,6 +15,7 @@ type DequeueRequest struct {
 	NumCPUs      int      `json:"numCPUs,omitempty"`
 	Memory       string   `json:"memory,omitempty"`
 	DiskSpace    string   `json:"diskSpace,omitempty"`
+	Labels       []string `json:"labels,omitempty"`
 }
 
 type JobOperationRequest struct {
@@ -59,6 +60,12 @@ type HeartbeatRequest struct {
 	JobIDsByQueue []QueueJobIDs `json:"jobIdsByQueue,omitempty"`
 	QueueNames    []string      `json:"queueNames,omitempty"`
 
+	// Capabilities of the executor, used to route jobs to executors that can run them.
+	Labels    []string `json:"labels,omitempty"`
+	NumCPUs   int      `json:"numCPUs,omitempty"`
+	Memory    string   `json:"memory,omitempty"`
+	DiskSpace string   `json:"diskSpace,omitempty"`
+
 	// Telemetry data.
 	OS              string `json:"os"`
 	Architecture    string `json:"architecture"`
@@ -81,6 +88,12 @@ type HeartbeatRequestV1 struct {
 	ExecutorName string `json:"executorName"`
 	JobIDs       []int  `json:"jobIds"`
 
+	// Capabilities of the executor, used to route jobs to executors that can run them.
+	Labels    []string `json:"labels,omitempty"`
+	NumCPUs   int      `json:"numCPUs,omitempty"`
+	Memory    string   `json:"memory,omitempty"`
+	DiskSpace string   `json:"diskSpace,omitempty"`
+
 	// Telemetry data.
 	OS              string `json:"os"`
 	Architecture    string `json:"architecture"`
@@ -102,6 +115,11 @@ type heartbeatRequestUnmarshaller struct {
 	JobIDsByQueue []QueueJobIDs `json:"jobIdsByQueue"`
 	QueueNames    []string      `json:"queueNames"`
 
+	Labels    []string `json:"labels"`
+	NumCPUs   int      `json:"numCPUs"`
+	Memory    string   `json:"memory"`
+	DiskSpace string   `json:"diskSpace"`
+
 	// Telemetry data.
 	OS              string `json:"os"`
 	Architecture    string `json:"architecture"`
@@ -132,6 +150,10 @@ func (h *HeartbeatRequest) UnmarshalJSON(b []byte) error {
 	h.Version = req.Version
 	h.JobIDsByQueue = req.JobIDsByQueue
 	h.QueueNames = req.QueueNames
+	h.Labels = req.Labels
+	h.NumCPUs = req.NumCPUs
+	h.Memory = req.Memory
+	h.DiskSpace = req.DiskSpace
 	h.ExecutorName = req.ExecutorName
 	h.OS = req.OS
 	h.Architecture = req.Architecture
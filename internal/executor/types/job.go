@@ -71,6 +71,16 @@ type Job struct {
 	// takes precedence over a potentially configured EXECUTOR_DOCKER_AUTH_CONFIG environment
 	// variable.
 	DockerAuthConfig DockerAuthConfig `json:"dockerAuthConfig,omitempty"`
+
+	// Artifacts are glob patterns of files relative to the repository directory that
+	// are uploaded to Sourcegraph after the steps of the job have run, whether or not
+	// they succeeded. Their content is redacted like the command output.
+	Artifacts []string `json:"artifacts,omitempty"`
+
+	// Requirements describe the capabilities an executor needs to run this job. They
+	// are only used by Sourcegraph to decide which executor a job is handed out to and
+	// are not sent to executors.
+	Requirements JobRequirements `json:"-"`
 }
 
 func (j Job) MarshalJSON() ([]byte, error) {
@@ -90,6 +100,7 @@ func (j Job) MarshalJSON() ([]byte, error) {
 			CliSteps:            j.CliSteps,
 			RedactedValues:      j.RedactedValues,
 			DockerAuthConfig:    j.DockerAuthConfig,
+			Artifacts:           j.Artifacts,
 		}
 		v2.VirtualMachineFiles = make(map[string]v2VirtualMachineFile, len(j.VirtualMachineFiles))
 		for k, v := range j.VirtualMachineFiles {
@@ -151,6 +162,7 @@ func (j *Job) UnmarshalJSON(data []byte) error {
 		j.CliSteps = v2.CliSteps
 		j.RedactedValues = v2.RedactedValues
 		j.DockerAuthConfig = v2.DockerAuthConfig
+		j.Artifacts = v2.Artifacts
 		return nil
 	}
 	var v1 v1Job
@@ -201,6 +213,7 @@ type v2Job struct {
 	CliSteps            []CliStep                       `json:"cliSteps"`
 	RedactedValues      map[string]string               `json:"redactedValues"`
 	DockerAuthConfig    DockerAuthConfig                `json:"dockerAuthConfig,omitempty"`
+	Artifacts           []string                        `json:"artifacts,omitempty"`
 }
 
 type v1Job struct {
@@ -57,6 +57,7 @@ func TestJob_MarshalJSON(t *testing.T) {
 				RedactedValues: map[string]string{
 					"password": "foo",
 				},
+				Artifacts: []string{"reports/*.xml"},
 			},
 			expected: `{
 		"version": 2,
@@ -93,7 +94,8 @@ func TestJob_MarshalJSON(t *testing.T) {
 		}],
 		"redactedValues": {
 			"password": "foo"
-		}
+		},
+		"artifacts": ["reports/*.xml"]
 	}`,
 		},
 		{
@@ -223,7 +225,8 @@ func TestJob_UnmarshalJSON(t *testing.T) {
 	}],
 	"redactedValues": {
 		"password": "foo"
-	}
+	},
+	"artifacts": ["reports/*.xml"]
 }`,
 			expected: Job{
 				Version:             2,
@@ -262,6 +265,7 @@ func TestJob_UnmarshalJSON(t *testing.T) {
 				RedactedValues: map[string]string{
 					"password": "foo",
 				},
+				Artifacts: []string{"reports/*.xml"},
 			},
 		},
 		{
@@ -0,0 +1,105 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"golang.org/x/oauth2"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/oauthutil"
+)
+
+// GetExternalAccountData returns the deserialized user and token from the external account data
+// JSON blob in a typesafe way.
+func GetExternalAccountData(ctx context.Context, data *extsvc.AccountData) (usr *User, tok *oauth2.Token, err error) {
+	if data.Data != nil {
+		usr, err = encryption.DecryptJSON[User](ctx, data.Data)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if data.AuthData != nil {
+		tok, err = encryption.DecryptJSON[oauth2.Token](ctx, data.AuthData)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return usr, tok, nil
+}
+
+func GetPublicExternalAccountData(ctx context.Context, accountData *extsvc.AccountData) (*extsvc.PublicAccountData, error) {
+	data, _, err := GetExternalAccountData(ctx, accountData)
+	if err != nil {
+		return nil, err
+	}
+
+	return &extsvc.PublicAccountData{
+		DisplayName: data.FullName,
+		Login:       data.Login,
+	}, nil
+}
+
+// SetExternalAccountData sets the user and token into the external account data blob.
+func SetExternalAccountData(data *extsvc.AccountData, user *User, token *oauth2.Token) error {
+	serializedUser, err := json.Marshal(user)
+	if err != nil {
+		return err
+	}
+	serializedToken, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+
+	data.Data = extsvc.NewUnencryptedData(serializedUser)
+	data.AuthData = extsvc.NewUnencryptedData(serializedToken)
+	return nil
+}
+
+var MockGetOAuthContext func() *oauthutil.OAuthContext
+
+// GetOAuthContext matches the corresponding auth provider using the given
+// baseURL and returns the oauthutil.OAuthContext of it. It returns nil if no
+// matching auth provider is configured.
+func GetOAuthContext(baseURL string) *oauthutil.OAuthContext {
+	if MockGetOAuthContext != nil {
+		return MockGetOAuthContext()
+	}
+
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	for _, authProvider := range conf.SiteConfig().AuthProviders {
+		p := authProvider.Gitea
+		if p == nil {
+			continue
+		}
+
+		rawURL := strings.TrimSuffix(p.Url, "/")
+		if rawURL != baseURL {
+			continue
+		}
+		authURL, err := url.JoinPath(rawURL, "/login/oauth/authorize")
+		if err != nil {
+			continue
+		}
+		tokenURL, err := url.JoinPath(rawURL, "/login/oauth/access_token")
+		if err != nil {
+			continue
+		}
+
+		return &oauthutil.OAuthContext{
+			ClientID:     p.ClientID,
+			ClientSecret: p.ClientSecret,
+			Endpoint: oauth2.Endpoint{
+				AuthURL:  authURL,
+				TokenURL: tokenURL,
+			},
+		}
+	}
+	return nil
+}
@@ -0,0 +1,236 @@
+//nolint:bodyclose // Body is closed in client.do, but the response is still returned to provide access to the headers
+package gitea
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// DefaultPageSize is the number of items requested per page. Gitea and
+// Forgejo cap page sizes at 50 by default.
+const DefaultPageSize = 50
+
+// Client accesses a Gitea or Forgejo instance via the REST API. Forgejo is a
+// fork of Gitea and keeps its API compatible, so the same client is used for
+// both.
+type Client interface {
+	Authenticator() auth.Authenticator
+	WithAuthenticator(a auth.Authenticator) (Client, error)
+
+	CurrentUser(ctx context.Context) (*User, error)
+	ListCurrentUserEmails(ctx context.Context) ([]*Email, error)
+	ListCurrentUserOrgs(ctx context.Context, page int) (orgs []*Organization, hasNextPage bool, err error)
+
+	GetRepo(ctx context.Context, owner, name string) (*Repo, error)
+	ListOrgRepos(ctx context.Context, org string, page int) (repos []*Repo, hasNextPage bool, err error)
+	ListCurrentUserRepos(ctx context.Context, page int) (repos []*Repo, hasNextPage bool, err error)
+	ForkRepo(ctx context.Context, owner, name string, input ForkRepoInput) (*Repo, error)
+
+	GetPullRequest(ctx context.Context, owner, name string, index int64) (*PullRequest, error)
+	ListOpenPullRequests(ctx context.Context, owner, name string, page int) (prs []*PullRequest, hasNextPage bool, err error)
+	CreatePullRequest(ctx context.Context, owner, name string, input CreatePullRequestInput) (*PullRequest, error)
+	EditPullRequest(ctx context.Context, owner, name string, index int64, input EditPullRequestInput) (*PullRequest, error)
+	MergePullRequest(ctx context.Context, owner, name string, index int64, input MergePullRequestInput) error
+	ListPullRequestReviews(ctx context.Context, owner, name string, index int64) ([]*PullRequestReview, error)
+	CreatePullRequestComment(ctx context.Context, owner, name string, index int64, body string) (*Comment, error)
+	GetCombinedStatus(ctx context.Context, owner, name, ref string) (*CombinedStatus, error)
+}
+
+type client struct {
+	// HTTP Client used to communicate with the API.
+	httpClient httpcli.Doer
+
+	// URL is the base URL of the instance, without the API path.
+	URL *url.URL
+
+	// auther is used to authenticate HTTP requests.
+	auther auth.Authenticator
+
+	// rateLimit is the self-imposed rate limiter (since Gitea does not have a
+	// concept of rate limiting in HTTP response headers).
+	rateLimit *ratelimit.InstrumentedLimiter
+}
+
+// NewClient returns an authenticated Gitea API client with the provided
+// configuration. If a nil httpClient is provided, httpcli.ExternalDoer will be
+// used.
+func NewClient(urn string, config *schema.GiteaConnection, httpClient httpcli.Doer) (Client, error) {
+	return newClient(urn, config, httpClient)
+}
+
+func newClient(urn string, config *schema.GiteaConnection, httpClient httpcli.Doer) (*client, error) {
+	u, err := url.Parse(config.Url)
+	if err != nil {
+		return nil, err
+	}
+
+	if httpClient == nil {
+		httpClient = httpcli.ExternalDoer
+	}
+
+	return &client{
+		httpClient: httpClient,
+		URL:        extsvc.NormalizeBaseURL(u),
+		auther:     &auth.OAuthBearerToken{Token: config.Token},
+		// Default limits are defined in extsvc.GetLimitFromConfig
+		rateLimit: ratelimit.NewInstrumentedLimiter(urn, ratelimit.NewGlobalRateLimiter(log.Scoped("GiteaClient", ""), urn)),
+	}, nil
+}
+
+func (c *client) Authenticator() auth.Authenticator {
+	return c.auther
+}
+
+// WithAuthenticator returns a new Client that uses the same configuration,
+// HTTP client, and rate limiter as the current Client, except authenticated
+// with the given authenticator instance. Gitea accepts access tokens and OAuth
+// tokens as bearer tokens, as well as basic authentication.
+func (c *client) WithAuthenticator(a auth.Authenticator) (Client, error) {
+	switch a.(type) {
+	case *auth.OAuthBearerToken, *auth.OAuthBearerTokenWithSSH, *auth.BasicAuth, *auth.BasicAuthWithSSH:
+		break
+	default:
+		return nil, errors.Errorf("authenticator type unsupported for Gitea clients: %T", a)
+	}
+
+	return &client{
+		httpClient: c.httpClient,
+		URL:        c.URL,
+		auther:     a,
+		rateLimit:  c.rateLimit,
+	}, nil
+}
+
+// page adds the pagination parameters for the given page to the query.
+func page(qs url.Values, page int) url.Values {
+	if qs == nil {
+		qs = make(url.Values)
+	}
+	if page < 1 {
+		page = 1
+	}
+	qs.Set("page", strconv.Itoa(page))
+	qs.Set("limit", strconv.Itoa(DefaultPageSize))
+	return qs
+}
+
+// hasNextPage reports whether there are more results after the given page.
+// Gitea returns the total number of results in the X-Total-Count header; if
+// it's missing, we assume there are more results if the page was full.
+func hasNextPage(resp *http.Response, page, results int) bool {
+	if total, err := strconv.Atoi(resp.Header.Get("X-Total-Count")); err == nil {
+		return page*DefaultPageSize < total
+	}
+	return results == DefaultPageSize
+}
+
+func (c *client) get(ctx context.Context, path string, qs url.Values, result any) (*http.Response, error) {
+	u := url.URL{Path: path, RawQuery: qs.Encode()}
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(ctx, req, result)
+}
+
+func (c *client) send(ctx context.Context, method, path string, payload, result any) (*http.Response, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshalling request")
+	}
+
+	req, err := http.NewRequest(method, path, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return c.do(ctx, req, result)
+}
+
+func (c *client) do(ctx context.Context, req *http.Request, result any) (*http.Response, error) {
+	req = req.WithContext(ctx)
+	req.URL = c.URL.ResolveReference(&url.URL{Path: "api/v1/" + req.URL.Path, RawQuery: req.URL.RawQuery})
+	req.Header.Set("Accept", "application/json")
+
+	if c.auther != nil {
+		if err := c.auther.Authenticate(req); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := c.rateLimit.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bs, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return nil, errors.WithStack(&httpError{
+			URL:        req.URL,
+			StatusCode: resp.StatusCode,
+			Body:       bs,
+		})
+	}
+
+	if result == nil || len(bs) == 0 {
+		return resp, nil
+	}
+	return resp, json.Unmarshal(bs, result)
+}
+
+type httpError struct {
+	StatusCode int
+	URL        *url.URL
+	Body       []byte
+}
+
+func (e *httpError) Error() string {
+	return fmt.Sprintf("Gitea API HTTP error: code=%d url=%q body=%q", e.StatusCode, e.URL, e.Body)
+}
+
+func (e *httpError) Unauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized
+}
+
+func (e *httpError) NotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
+
+// IsConflict reports whether err is an HTTP 409 Conflict response, which
+// Gitea returns, for example, when a pull request for the same branches
+// already exists or a pull request can't be merged because of conflicts.
+func IsConflict(err error) bool {
+	var e *httpError
+	return errors.As(err, &e) && e.StatusCode == http.StatusConflict
+}
+
+// IsNotMergeable reports whether err is the response Gitea returns when a pull
+// request can't be merged.
+func IsNotMergeable(err error) bool {
+	var e *httpError
+	return errors.As(err, &e) && (e.StatusCode == http.StatusMethodNotAllowed || e.StatusCode == http.StatusConflict)
+}
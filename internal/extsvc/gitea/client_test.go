@@ -0,0 +1,117 @@
+package gitea
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+)
+
+func TestClient_do(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/unauthorized":
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte("Unauthorized"))
+		case "/api/v1/conflict":
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte("Conflict"))
+		default:
+			w.Write([]byte(fmt.Sprintf(`{"key":%q}`, r.Header.Get("Authorization"))))
+		}
+	}))
+	defer srv.Close()
+	srvURL, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	c := &client{
+		httpClient: httpcli.ExternalDoer,
+		URL:        srvURL,
+		auther:     &auth.OAuthBearerToken{Token: "secret"},
+		rateLimit:  &ratelimit.InstrumentedLimiter{Limiter: rate.NewLimiter(10, 10)},
+	}
+
+	t.Run("error", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "unauthorized", nil)
+		require.NoError(t, err)
+
+		resp, err := c.do(context.Background(), req, nil)
+		assert.Nil(t, resp)
+		assert.Equal(t, fmt.Sprintf("Gitea API HTTP error: code=401 url=\"%s/api/v1/unauthorized\" body=\"Unauthorized\"", srvURL), err.Error())
+		assert.False(t, IsConflict(err))
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "conflict", nil)
+		require.NoError(t, err)
+
+		_, err = c.do(context.Background(), req, nil)
+		assert.True(t, IsConflict(err))
+		assert.True(t, IsNotMergeable(err))
+	})
+
+	t.Run("authenticated", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "anything", nil)
+		require.NoError(t, err)
+
+		var respStruct struct {
+			Key string `json:"key"`
+		}
+		resp, err := c.do(context.Background(), req, &respStruct)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, "Bearer secret", respStruct.Key)
+	})
+}
+
+func TestClient_WithAuthenticator(t *testing.T) {
+	c := &client{URL: &url.URL{Scheme: "https", Host: "gitea.sgdev.org"}}
+
+	for name, a := range map[string]auth.Authenticator{
+		"bearer": &auth.OAuthBearerToken{Token: "abc"},
+		"basic":  &auth.BasicAuth{Username: "user", Password: "pass"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, err := c.WithAuthenticator(a)
+			require.NoError(t, err)
+			assert.Same(t, a, got.Authenticator())
+		})
+	}
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := c.WithAuthenticator(&auth.OAuthClient{})
+		assert.Error(t, err)
+	})
+}
+
+func TestHasNextPage(t *testing.T) {
+	for name, tc := range map[string]struct {
+		total   string
+		page    int
+		results int
+		want    bool
+	}{
+		"total count with more pages": {total: "120", page: 2, results: 50, want: true},
+		"total count on last page":    {total: "100", page: 2, results: 50, want: false},
+		"no total count, full page":   {page: 1, results: DefaultPageSize, want: true},
+		"no total count, short page":  {page: 1, results: 3, want: false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resp := &http.Response{Header: make(http.Header)}
+			if tc.total != "" {
+				resp.Header.Set("X-Total-Count", tc.total)
+			}
+			assert.Equal(t, tc.want, hasNextPage(resp, tc.page, tc.results))
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package gitea
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+)
+
+// Gitea sends the event type and payload signature in X-Gitea-* headers.
+// Forgejo additionally sends the same values in X-Forgejo-* headers, but
+// keeps sending the Gitea ones for compatibility.
+const (
+	EventHeader     = "X-Gitea-Event"
+	SignatureHeader = "X-Gitea-Signature"
+)
+
+// Webhook event types, as sent in the EventHeader.
+const (
+	EventPush                      = "push"
+	EventPullRequest               = "pull_request"
+	EventPullRequestReviewApproved = "pull_request_review_approved"
+	EventPullRequestReviewRejected = "pull_request_review_rejected"
+	EventPullRequestReviewComment  = "pull_request_review_comment"
+	EventPullRequestComment        = "pull_request_comment"
+)
+
+// WebhookEventType returns the type of the webhook event sent with the
+// request, or an empty string if it isn't a Gitea or Forgejo webhook.
+func WebhookEventType(r *http.Request) string {
+	if e := r.Header.Get(EventHeader); e != "" {
+		return e
+	}
+	return r.Header.Get("X-Forgejo-Event")
+}
+
+// ValidateSignature reports whether the payload was signed with the given
+// secret. Gitea signs payloads with HMAC-SHA256 and sends the hex encoded
+// digest in the SignatureHeader.
+func ValidateSignature(r *http.Request, payload []byte, secret string) bool {
+	sig := r.Header.Get(SignatureHeader)
+	if sig == "" {
+		sig = r.Header.Get("X-Forgejo-Signature")
+	}
+	expected, err := hex.DecodeString(sig)
+	if err != nil || len(expected) == 0 {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+func ParseWebhookEvent(eventType string, payload []byte) (any, error) {
+	var target any
+	switch eventType {
+	case EventPush:
+		target = &PushEvent{}
+	case EventPullRequest:
+		target = &PullRequestEvent{}
+	case EventPullRequestReviewApproved, EventPullRequestReviewRejected, EventPullRequestReviewComment:
+		target = &PullRequestReviewEvent{}
+	case EventPullRequestComment:
+		target = &PullRequestCommentEvent{}
+	default:
+		return nil, UnknownWebhookEventType(eventType)
+	}
+
+	if err := json.Unmarshal(payload, target); err != nil {
+		return nil, err
+	}
+	return target, nil
+}
+
+// PushEvent is sent when commits are pushed to a repository.
+type PushEvent struct {
+	Ref        string `json:"ref"`
+	Before     string `json:"before"`
+	After      string `json:"after"`
+	CompareURL string `json:"compare_url"`
+	Repository *Repo  `json:"repository"`
+	Pusher     *User  `json:"pusher"`
+	Sender     *User  `json:"sender"`
+}
+
+// PullRequestEvent is sent when a pull request is opened, edited, closed,
+// reopened, synchronized, or otherwise changed.
+type PullRequestEvent struct {
+	Action      string       `json:"action"`
+	Index       int64        `json:"number"`
+	PullRequest *PullRequest `json:"pull_request"`
+	Repository  *Repo        `json:"repository"`
+	Sender      *User        `json:"sender"`
+}
+
+// PullRequestReviewEvent is sent when a review is submitted on a pull
+// request.
+type PullRequestReviewEvent struct {
+	PullRequestEvent
+	Review *WebhookReview `json:"review"`
+}
+
+// WebhookReview is the abbreviated review included in review webhook
+// payloads. Type is the event type, such as "pull_request_review_approved".
+type WebhookReview struct {
+	Type    string `json:"type"`
+	Content string `json:"content"`
+}
+
+// PullRequestCommentEvent is sent when a comment is created on a pull
+// request.
+type PullRequestCommentEvent struct {
+	Action     string   `json:"action"`
+	Issue      *Issue   `json:"issue"`
+	Comment    *Comment `json:"comment"`
+	IsPull     bool     `json:"is_pull"`
+	Repository *Repo    `json:"repository"`
+	Sender     *User    `json:"sender"`
+}
+
+// Issue is the abbreviated issue included in comment webhook payloads.
+type Issue struct {
+	ID      int64  `json:"id"`
+	Index   int64  `json:"number"`
+	Title   string `json:"title"`
+	State   string `json:"state"`
+	HTMLURL string `json:"html_url"`
+}
+
+type UnknownWebhookEventType string
+
+var _ error = UnknownWebhookEventType("")
+
+func (e UnknownWebhookEventType) Error() string {
+	return "unknown webhook event type: " + string(e)
+}
@@ -0,0 +1,89 @@
+package gitea
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWebhookEvent(t *testing.T) {
+	t.Run("push", func(t *testing.T) {
+		payload := []byte(`{"ref":"refs/heads/main","before":"f3bc4e3b","after":"6b1f2a5d","repository":{"id":11,"full_name":"sgtest/go-jsonschema"},"pusher":{"id":1,"login":"sourcegraph-bot"}}`)
+
+		event, err := ParseWebhookEvent(EventPush, payload)
+		require.NoError(t, err)
+		push, ok := event.(*PushEvent)
+		require.True(t, ok)
+		assert.Equal(t, "refs/heads/main", push.Ref)
+		assert.Equal(t, "sgtest/go-jsonschema", push.Repository.FullName)
+		assert.Equal(t, "sourcegraph-bot", push.Pusher.Login)
+	})
+
+	t.Run("pull request", func(t *testing.T) {
+		payload := []byte(`{"action":"closed","number":1,"pull_request":{"id":101,"number":1,"state":"closed","merged":true},"repository":{"id":11,"full_name":"sgtest/go-jsonschema"}}`)
+
+		event, err := ParseWebhookEvent(EventPullRequest, payload)
+		require.NoError(t, err)
+		pr, ok := event.(*PullRequestEvent)
+		require.True(t, ok)
+		assert.Equal(t, "closed", pr.Action)
+		assert.Equal(t, int64(1), pr.PullRequest.Index)
+		assert.True(t, pr.PullRequest.HasMerged)
+	})
+
+	t.Run("review", func(t *testing.T) {
+		payload := []byte(`{"action":"reviewed","number":1,"pull_request":{"id":101,"number":1},"review":{"type":"pull_request_review_approved","content":"LGTM"}}`)
+
+		event, err := ParseWebhookEvent(EventPullRequestReviewApproved, payload)
+		require.NoError(t, err)
+		review, ok := event.(*PullRequestReviewEvent)
+		require.True(t, ok)
+		assert.Equal(t, int64(1), review.PullRequest.Index)
+		assert.Equal(t, "LGTM", review.Review.Content)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		_, err := ParseWebhookEvent("wiki", []byte(`{}`))
+		assert.Equal(t, UnknownWebhookEventType("wiki"), err)
+	})
+}
+
+func TestWebhookEventType(t *testing.T) {
+	r, err := http.NewRequest(http.MethodPost, "/.api/webhooks", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "", WebhookEventType(r))
+
+	r.Header.Set("X-Forgejo-Event", EventPush)
+	assert.Equal(t, EventPush, WebhookEventType(r))
+
+	r.Header.Set(EventHeader, EventPullRequest)
+	assert.Equal(t, EventPullRequest, WebhookEventType(r))
+}
+
+func TestValidateSignature(t *testing.T) {
+	payload := []byte(`{"ref":"refs/heads/main"}`)
+	// HMAC-SHA256 of payload with the key "secret".
+	const sig = "d8f89f0618acd61fe621aa4e64078c0e2bca15d0b578b7f3eb734f55883c5320"
+
+	for name, tc := range map[string]struct {
+		header, sig, secret string
+		want                bool
+	}{
+		"gitea header":    {header: SignatureHeader, sig: sig, secret: "secret", want: true},
+		"forgejo header":  {header: "X-Forgejo-Signature", sig: sig, secret: "secret", want: true},
+		"wrong secret":    {header: SignatureHeader, sig: sig, secret: "other", want: false},
+		"missing":         {secret: "secret", want: false},
+		"not hex encoded": {header: SignatureHeader, sig: "nope", secret: "secret", want: false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			r, err := http.NewRequest(http.MethodPost, "/.api/webhooks", nil)
+			require.NoError(t, err)
+			if tc.header != "" {
+				r.Header.Set(tc.header, tc.sig)
+			}
+			assert.Equal(t, tc.want, ValidateSignature(r, payload, tc.secret))
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package gitea
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// GetPullRequest returns a single pull request.
+func (c *client) GetPullRequest(ctx context.Context, owner, name string, index int64) (*PullRequest, error) {
+	var pr PullRequest
+	if _, err := c.get(ctx, pullRequestPath(owner, name, index), nil, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// ListOpenPullRequests returns one page of the open pull requests in the
+// given repository.
+func (c *client) ListOpenPullRequests(ctx context.Context, owner, name string, p int) ([]*PullRequest, bool, error) {
+	qs := make(url.Values)
+	qs.Set("state", string(PullRequestStateOpen))
+
+	var prs []*PullRequest
+	resp, err := c.get(ctx, repoPath(owner, name)+"/pulls", page(qs, p), &prs)
+	if err != nil {
+		return nil, false, err
+	}
+	return prs, hasNextPage(resp, p, len(prs)), nil
+}
+
+// CreatePullRequest opens a new pull request in the given repository. If a
+// pull request for the same head and base already exists, Gitea responds with
+// 409 Conflict, which can be detected with IsConflict.
+func (c *client) CreatePullRequest(ctx context.Context, owner, name string, input CreatePullRequestInput) (*PullRequest, error) {
+	var pr PullRequest
+	if _, err := c.send(ctx, http.MethodPost, repoPath(owner, name)+"/pulls", &input, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// EditPullRequest updates a pull request. This is also used to close and
+// reopen pull requests.
+func (c *client) EditPullRequest(ctx context.Context, owner, name string, index int64, input EditPullRequestInput) (*PullRequest, error) {
+	var pr PullRequest
+	if _, err := c.send(ctx, http.MethodPatch, pullRequestPath(owner, name, index), &input, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// MergePullRequest merges a pull request. If the pull request can't be merged,
+// the returned error satisfies IsNotMergeable.
+func (c *client) MergePullRequest(ctx context.Context, owner, name string, index int64, input MergePullRequestInput) error {
+	_, err := c.send(ctx, http.MethodPost, pullRequestPath(owner, name, index)+"/merge", &input, nil)
+	return err
+}
+
+// ListPullRequestReviews returns the reviews left on a pull request. Gitea
+// doesn't paginate this endpoint by default, so we request the maximum page
+// size and fetch until we've seen every review.
+func (c *client) ListPullRequestReviews(ctx context.Context, owner, name string, index int64) ([]*PullRequestReview, error) {
+	var all []*PullRequestReview
+	for p := 1; ; p++ {
+		var reviews []*PullRequestReview
+		resp, err := c.get(ctx, pullRequestPath(owner, name, index)+"/reviews", page(nil, p), &reviews)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, reviews...)
+		if !hasNextPage(resp, p, len(reviews)) {
+			return all, nil
+		}
+	}
+}
+
+// CreatePullRequestComment adds a comment to a pull request. Pull requests
+// are issues in Gitea, so this uses the issue comments endpoint.
+func (c *client) CreatePullRequestComment(ctx context.Context, owner, name string, index int64, body string) (*Comment, error) {
+	payload := struct {
+		Body string `json:"body"`
+	}{Body: body}
+
+	var comment Comment
+	path := fmt.Sprintf("%s/issues/%d/comments", repoPath(owner, name), index)
+	if _, err := c.send(ctx, http.MethodPost, path, &payload, &comment); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// GetCombinedStatus returns the combined commit status for the given ref.
+// Since branch names may contain slashes, which Gitea can't route, callers
+// should prefer passing a commit SHA.
+func (c *client) GetCombinedStatus(ctx context.Context, owner, name, ref string) (*CombinedStatus, error) {
+	var status CombinedStatus
+	path := fmt.Sprintf("%s/commits/%s/status", repoPath(owner, name), ref)
+	if _, err := c.get(ctx, path, nil, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+func pullRequestPath(owner, name string, index int64) string {
+	return fmt.Sprintf("%s/pulls/%d", repoPath(owner, name), index)
+}
+
+// wipPrefixes are the title prefixes Gitea uses by default to mark pull
+// requests as work in progress.
+var wipPrefixes = []string{"WIP:", "[WIP]"}
+
+func hasWIPPrefix(title string) bool {
+	upper := strings.ToUpper(strings.TrimSpace(title))
+	for _, prefix := range wipPrefixes {
+		if strings.HasPrefix(upper, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetWIPPrefix returns the title with a work in progress prefix, unless it
+// already has one.
+func SetWIPPrefix(title string) string {
+	if hasWIPPrefix(title) {
+		return title
+	}
+	return wipPrefixes[0] + " " + title
+}
+
+// TrimWIPPrefix returns the title without its work in progress prefix, if any.
+func TrimWIPPrefix(title string) string {
+	trimmed := strings.TrimSpace(title)
+	upper := strings.ToUpper(trimmed)
+	for _, prefix := range wipPrefixes {
+		if strings.HasPrefix(upper, prefix) {
+			return strings.TrimSpace(trimmed[len(prefix):])
+		}
+	}
+	return title
+}
@@ -0,0 +1,145 @@
+package gitea
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/testutil"
+)
+
+func TestClient_GetPullRequest(t *testing.T) {
+	cli, save := NewTestClient(t, "GetPullRequest", *update)
+	defer save()
+
+	pr, err := cli.GetPullRequest(context.Background(), "sgtest", "go-jsonschema", 1)
+	require.NoError(t, err)
+	testutil.AssertGolden(t, "testdata/golden/GetPullRequest.json", *update, pr)
+}
+
+func TestClient_ListOpenPullRequests(t *testing.T) {
+	cli, save := NewTestClient(t, "ListOpenPullRequests", *update)
+	defer save()
+
+	prs, hasNextPage, err := cli.ListOpenPullRequests(context.Background(), "sgtest", "go-jsonschema", 1)
+	require.NoError(t, err)
+	assert.False(t, hasNextPage)
+	require.Len(t, prs, 2)
+	assert.False(t, prs[0].Draft())
+	assert.True(t, prs[1].Draft())
+	testutil.AssertGolden(t, "testdata/golden/ListOpenPullRequests.json", *update, prs)
+}
+
+func TestClient_CreatePullRequest(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		cli, save := NewTestClient(t, "CreatePullRequest", *update)
+		defer save()
+
+		pr, err := cli.CreatePullRequest(ctx, "sgtest", "go-jsonschema", CreatePullRequestInput{
+			Head:  "sourcegraph-bot:sourcegraph/batch-change",
+			Base:  "main",
+			Title: "Sourcegraph test",
+			Body:  "Created by Sourcegraph batch change `sourcegraph-bot/test`.",
+		})
+		require.NoError(t, err)
+		testutil.AssertGolden(t, "testdata/golden/CreatePullRequest.json", *update, pr)
+	})
+
+	t.Run("already exists", func(t *testing.T) {
+		cli, save := NewTestClient(t, "CreatePullRequest-already-exists", *update)
+		defer save()
+
+		pr, err := cli.CreatePullRequest(ctx, "sgtest", "go-jsonschema", CreatePullRequestInput{
+			Head:  "sourcegraph/batch-change",
+			Base:  "main",
+			Title: "Add README",
+		})
+		assert.Nil(t, pr)
+		assert.True(t, IsConflict(err))
+	})
+}
+
+func TestClient_EditPullRequest(t *testing.T) {
+	cli, save := NewTestClient(t, "EditPullRequest", *update)
+	defer save()
+
+	state := PullRequestStateClosed
+	pr, err := cli.EditPullRequest(context.Background(), "sgtest", "go-jsonschema", 1, EditPullRequestInput{State: &state})
+	require.NoError(t, err)
+	assert.Equal(t, PullRequestStateClosed, pr.State)
+}
+
+func TestClient_MergePullRequest(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		cli, save := NewTestClient(t, "MergePullRequest", *update)
+		defer save()
+
+		err := cli.MergePullRequest(ctx, "sgtest", "go-jsonschema", 1, MergePullRequestInput{
+			Do:                     MergeStyleSquash,
+			DeleteBranchAfterMerge: true,
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("not mergeable", func(t *testing.T) {
+		cli, save := NewTestClient(t, "MergePullRequest-not-mergeable", *update)
+		defer save()
+
+		err := cli.MergePullRequest(ctx, "sgtest", "go-jsonschema", 2, MergePullRequestInput{Do: MergeStyleMerge})
+		assert.True(t, IsNotMergeable(err))
+	})
+}
+
+func TestClient_ListPullRequestReviews(t *testing.T) {
+	cli, save := NewTestClient(t, "ListPullRequestReviews", *update)
+	defer save()
+
+	reviews, err := cli.ListPullRequestReviews(context.Background(), "sgtest", "go-jsonschema", 1)
+	require.NoError(t, err)
+	testutil.AssertGolden(t, "testdata/golden/ListPullRequestReviews.json", *update, reviews)
+}
+
+func TestClient_CreatePullRequestComment(t *testing.T) {
+	cli, save := NewTestClient(t, "CreatePullRequestComment", *update)
+	defer save()
+
+	comment, err := cli.CreatePullRequestComment(context.Background(), "sgtest", "go-jsonschema", 1, "Hello from Sourcegraph")
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), comment.ID)
+	assert.Equal(t, "Hello from Sourcegraph", comment.Body)
+}
+
+func TestClient_GetCombinedStatus(t *testing.T) {
+	cli, save := NewTestClient(t, "GetCombinedStatus", *update)
+	defer save()
+
+	status, err := cli.GetCombinedStatus(context.Background(), "sgtest", "go-jsonschema", "6b1f2a5d0c9e8f7a6b5c4d3e2f1a0b9c8d7e6f5a")
+	require.NoError(t, err)
+	testutil.AssertGolden(t, "testdata/golden/GetCombinedStatus.json", *update, status)
+}
+
+func TestWIPPrefix(t *testing.T) {
+	for title, want := range map[string]bool{
+		"Add README":        false,
+		"WIP: Add README":   true,
+		"wip: Add README":   true,
+		"[WIP] Add README":  true,
+		"Add WIP: handling": false,
+	} {
+		t.Run(title, func(t *testing.T) {
+			assert.Equal(t, want, hasWIPPrefix(title))
+		})
+	}
+
+	assert.Equal(t, "WIP: Add README", SetWIPPrefix("Add README"))
+	assert.Equal(t, "[WIP] Add README", SetWIPPrefix("[WIP] Add README"))
+	assert.Equal(t, "Add README", TrimWIPPrefix("WIP: Add README"))
+	assert.Equal(t, "Add README", TrimWIPPrefix("[wip] Add README"))
+	assert.Equal(t, "Add README", TrimWIPPrefix("Add README"))
+}
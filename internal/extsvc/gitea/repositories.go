@@ -0,0 +1,54 @@
+package gitea
+
+import (
+	"context"
+	"net/http"
+)
+
+// GetRepo returns a single repository.
+func (c *client) GetRepo(ctx context.Context, owner, name string) (*Repo, error) {
+	var repo Repo
+	if _, err := c.get(ctx, repoPath(owner, name), nil, &repo); err != nil {
+		return nil, err
+	}
+	return &repo, nil
+}
+
+// ListOrgRepos returns one page of the repositories in the given
+// organization that are visible to the current user.
+func (c *client) ListOrgRepos(ctx context.Context, org string, p int) ([]*Repo, bool, error) {
+	var repos []*Repo
+	resp, err := c.get(ctx, "orgs/"+org+"/repos", page(nil, p), &repos)
+	if err != nil {
+		return nil, false, err
+	}
+	return repos, hasNextPage(resp, p, len(repos)), nil
+}
+
+// ListCurrentUserRepos returns one page of the repositories the current user
+// has access to, including repositories owned by organizations the user is a
+// member of.
+func (c *client) ListCurrentUserRepos(ctx context.Context, p int) ([]*Repo, bool, error) {
+	var repos []*Repo
+	resp, err := c.get(ctx, "user/repos", page(nil, p), &repos)
+	if err != nil {
+		return nil, false, err
+	}
+	return repos, hasNextPage(resp, p, len(repos)), nil
+}
+
+// ForkRepo forks the given repository. Gitea creates forks synchronously, but
+// responds with 202 Accepted; the returned repository is ready to be pushed
+// to. If a fork with the same name already exists in the target namespace,
+// Gitea responds with 409 Conflict.
+func (c *client) ForkRepo(ctx context.Context, owner, name string, input ForkRepoInput) (*Repo, error) {
+	var fork Repo
+	if _, err := c.send(ctx, http.MethodPost, repoPath(owner, name)+"/forks", &input, &fork); err != nil {
+		return nil, err
+	}
+	return &fork, nil
+}
+
+func repoPath(owner, name string) string {
+	return "repos/" + owner + "/" + name
+}
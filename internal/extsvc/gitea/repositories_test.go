@@ -0,0 +1,66 @@
+package gitea
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/testutil"
+)
+
+func TestClient_GetRepo(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("found", func(t *testing.T) {
+		cli, save := NewTestClient(t, "GetRepo", *update)
+		defer save()
+
+		repo, err := cli.GetRepo(ctx, "sgtest", "go-jsonschema")
+		require.NoError(t, err)
+		testutil.AssertGolden(t, "testdata/golden/GetRepo.json", *update, repo)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		cli, save := NewTestClient(t, "GetRepo-not-found", *update)
+		defer save()
+
+		repo, err := cli.GetRepo(ctx, "sgtest", "does-not-exist")
+		assert.Nil(t, repo)
+		assert.True(t, errcode.IsNotFound(err))
+	})
+}
+
+func TestClient_ListOrgRepos(t *testing.T) {
+	cli, save := NewTestClient(t, "ListOrgRepos", *update)
+	defer save()
+
+	repos, hasNextPage, err := cli.ListOrgRepos(context.Background(), "sgtest", 1)
+	require.NoError(t, err)
+	assert.False(t, hasNextPage)
+	testutil.AssertGolden(t, "testdata/golden/ListOrgRepos.json", *update, repos)
+}
+
+func TestClient_ListCurrentUserRepos(t *testing.T) {
+	cli, save := NewTestClient(t, "ListCurrentUserRepos", *update)
+	defer save()
+
+	repos, hasNextPage, err := cli.ListCurrentUserRepos(context.Background(), 1)
+	require.NoError(t, err)
+	assert.False(t, hasNextPage)
+	testutil.AssertGolden(t, "testdata/golden/ListCurrentUserRepos.json", *update, repos)
+}
+
+func TestClient_ForkRepo(t *testing.T) {
+	cli, save := NewTestClient(t, "ForkRepo", *update)
+	defer save()
+
+	name := "sgtest-go-jsonschema"
+	fork, err := cli.ForkRepo(context.Background(), "sgtest", "go-jsonschema", ForkRepoInput{Name: &name})
+	require.NoError(t, err)
+	assert.True(t, fork.Fork)
+	assert.Equal(t, "sgtest/go-jsonschema", fork.Parent.FullName)
+	testutil.AssertGolden(t, "testdata/golden/ForkRepo.json", *update, fork)
+}
@@ -0,0 +1,245 @@
+package gitea
+
+import (
+	"strconv"
+	"time"
+)
+
+// User is a Gitea user account.
+type User struct {
+	ID        int64  `json:"id"`
+	Login     string `json:"login"`
+	FullName  string `json:"full_name"`
+	Email     string `json:"email"`
+	AvatarURL string `json:"avatar_url"`
+	IsAdmin   bool   `json:"is_admin"`
+}
+
+// Email is an email address of a Gitea user.
+type Email struct {
+	Email    string `json:"email"`
+	Verified bool   `json:"verified"`
+	Primary  bool   `json:"primary"`
+}
+
+// Organization is a Gitea organization.
+type Organization struct {
+	ID        int64  `json:"id"`
+	Name      string `json:"name"`
+	FullName  string `json:"full_name"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+// Permission describes the permissions the authenticated user has on a
+// repository.
+type Permission struct {
+	Admin bool `json:"admin"`
+	Push  bool `json:"push"`
+	Pull  bool `json:"pull"`
+}
+
+// Repo is a Gitea repository.
+type Repo struct {
+	ID            int64       `json:"id"`
+	Owner         *User       `json:"owner"`
+	Name          string      `json:"name"`
+	FullName      string      `json:"full_name"`
+	Description   string      `json:"description"`
+	Empty         bool        `json:"empty"`
+	Private       bool        `json:"private"`
+	Fork          bool        `json:"fork"`
+	Parent        *Repo       `json:"parent,omitempty"`
+	Mirror        bool        `json:"mirror"`
+	Archived      bool        `json:"archived"`
+	HTMLURL       string      `json:"html_url"`
+	SSHURL        string      `json:"ssh_url"`
+	CloneURL      string      `json:"clone_url"`
+	DefaultBranch string      `json:"default_branch"`
+	Stars         int         `json:"stars_count"`
+	Internal      bool        `json:"internal"`
+	Permissions   *Permission `json:"permissions,omitempty"`
+	CreatedAt     time.Time   `json:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+}
+
+// ForkRepoInput is the payload used to fork a repository. Both fields are
+// optional: by default, the fork is created in the authenticated user's
+// namespace with the same name as the upstream repository.
+type ForkRepoInput struct {
+	Organization *string `json:"organization,omitempty"`
+	Name         *string `json:"name,omitempty"`
+}
+
+// PullRequestState is the state of a pull request.
+type PullRequestState string
+
+const (
+	PullRequestStateOpen   PullRequestState = "open"
+	PullRequestStateClosed PullRequestState = "closed"
+)
+
+// Label is a label attached to an issue or pull request.
+type Label struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Color       string `json:"color"`
+	Description string `json:"description"`
+}
+
+// PRBranchInfo describes one side of a pull request.
+type PRBranchInfo struct {
+	Label  string `json:"label"`
+	Ref    string `json:"ref"`
+	Sha    string `json:"sha"`
+	RepoID int64  `json:"repo_id"`
+	Repo   *Repo  `json:"repo"`
+}
+
+// PullRequest is a Gitea pull request. Pull requests share their number
+// ("index") with issues in the same repository.
+type PullRequest struct {
+	ID        int64            `json:"id"`
+	URL       string           `json:"url"`
+	Index     int64            `json:"number"`
+	User      *User            `json:"user"`
+	Title     string           `json:"title"`
+	Body      string           `json:"body"`
+	Labels    []*Label         `json:"labels"`
+	State     PullRequestState `json:"state"`
+	IsLocked  bool             `json:"is_locked"`
+	Comments  int              `json:"comments"`
+	HTMLURL   string           `json:"html_url"`
+	DiffURL   string           `json:"diff_url"`
+	Mergeable bool             `json:"mergeable"`
+	HasMerged bool             `json:"merged"`
+	Merged    *time.Time       `json:"merged_at"`
+	MergedBy  *User            `json:"merged_by"`
+	Base      *PRBranchInfo    `json:"base"`
+	Head      *PRBranchInfo    `json:"head"`
+	MergeBase string           `json:"merge_base"`
+	Created   *time.Time       `json:"created_at"`
+	Updated   *time.Time       `json:"updated_at"`
+	Closed    *time.Time       `json:"closed_at"`
+}
+
+// Draft reports whether the pull request is a work in progress. Gitea has no
+// native draft state; instead, pull requests whose title starts with one of
+// the configured prefixes (by default "WIP:" and "[WIP]") are treated as
+// drafts.
+func (pr *PullRequest) Draft() bool {
+	return hasWIPPrefix(pr.Title)
+}
+
+// CreatePullRequestInput is the payload used to create a pull request. For
+// pull requests from forks, Head must be of the form "owner:branch".
+type CreatePullRequestInput struct {
+	Head  string `json:"head"`
+	Base  string `json:"base"`
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// EditPullRequestInput is the payload used to update a pull request. Fields
+// that are nil are left unchanged.
+type EditPullRequestInput struct {
+	Title *string           `json:"title,omitempty"`
+	Body  *string           `json:"body,omitempty"`
+	Base  *string           `json:"base,omitempty"`
+	State *PullRequestState `json:"state,omitempty"`
+}
+
+// MergeStyle is the strategy used to merge a pull request.
+type MergeStyle string
+
+const (
+	MergeStyleMerge  MergeStyle = "merge"
+	MergeStyleRebase MergeStyle = "rebase"
+	MergeStyleSquash MergeStyle = "squash"
+)
+
+// MergePullRequestInput is the payload used to merge a pull request.
+type MergePullRequestInput struct {
+	Do                     MergeStyle `json:"Do"`
+	DeleteBranchAfterMerge bool       `json:"delete_branch_after_merge,omitempty"`
+}
+
+// ReviewState is the state of a pull request review.
+type ReviewState string
+
+const (
+	ReviewStateApproved       ReviewState = "APPROVED"
+	ReviewStatePending        ReviewState = "PENDING"
+	ReviewStateComment        ReviewState = "COMMENT"
+	ReviewStateRequestChanges ReviewState = "REQUEST_CHANGES"
+	ReviewStateRequestReview  ReviewState = "REQUEST_REVIEW"
+)
+
+// PullRequestReview is a review left on a pull request.
+type PullRequestReview struct {
+	ID          int64       `json:"id"`
+	Reviewer    *User       `json:"user"`
+	State       ReviewState `json:"state"`
+	Body        string      `json:"body"`
+	CommitID    string      `json:"commit_id"`
+	Stale       bool        `json:"stale"`
+	Official    bool        `json:"official"`
+	Dismissed   bool        `json:"dismissed"`
+	HTMLURL     string      `json:"html_url"`
+	SubmittedAt time.Time   `json:"submitted_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+}
+
+func (r *PullRequestReview) Key() string {
+	return strconv.FormatInt(r.ID, 10)
+}
+
+// Comment is a comment on an issue or pull request.
+type Comment struct {
+	ID        int64     `json:"id"`
+	HTMLURL   string    `json:"html_url"`
+	User      *User     `json:"user"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// StatusState is the state of a commit status.
+type StatusState string
+
+const (
+	StatusStatePending StatusState = "pending"
+	StatusStateSuccess StatusState = "success"
+	StatusStateError   StatusState = "error"
+	StatusStateFailure StatusState = "failure"
+	StatusStateWarning StatusState = "warning"
+)
+
+// CommitStatus is a single status reported for a commit, usually by a CI
+// system such as Gitea Actions or Woodpecker.
+type CommitStatus struct {
+	ID          int64       `json:"id"`
+	State       StatusState `json:"status"`
+	TargetURL   string      `json:"target_url"`
+	Description string      `json:"description"`
+	Context     string      `json:"context"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+}
+
+func (s *CommitStatus) Key() string {
+	// Gitea only reports the latest status for each context, so the context
+	// identifies the check across updates.
+	if s.Context != "" {
+		return s.Context
+	}
+	return strconv.FormatInt(s.ID, 10)
+}
+
+// CombinedStatus is the combined status of all the statuses reported for a
+// commit.
+type CombinedStatus struct {
+	State      StatusState     `json:"state"`
+	SHA        string          `json:"sha"`
+	TotalCount int             `json:"total_count"`
+	Statuses   []*CommitStatus `json:"statuses"`
+}
@@ -0,0 +1,34 @@
+package gitea
+
+import (
+	"context"
+)
+
+// CurrentUser returns the user associated with the authenticator in use.
+func (c *client) CurrentUser(ctx context.Context) (*User, error) {
+	var user User
+	if _, err := c.get(ctx, "user", nil, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// ListCurrentUserEmails returns the email addresses of the current user.
+func (c *client) ListCurrentUserEmails(ctx context.Context) ([]*Email, error) {
+	var emails []*Email
+	if _, err := c.get(ctx, "user/emails", nil, &emails); err != nil {
+		return nil, err
+	}
+	return emails, nil
+}
+
+// ListCurrentUserOrgs returns one page of the organizations the current user
+// is a member of.
+func (c *client) ListCurrentUserOrgs(ctx context.Context, p int) ([]*Organization, bool, error) {
+	var orgs []*Organization
+	resp, err := c.get(ctx, "user/orgs", page(nil, p), &orgs)
+	if err != nil {
+		return nil, false, err
+	}
+	return orgs, hasNextPage(resp, p, len(orgs)), nil
+}
@@ -0,0 +1,51 @@
+package gitea
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_CurrentUser(t *testing.T) {
+	cli, save := NewTestClient(t, "CurrentUser", *update)
+	defer save()
+
+	user, err := cli.CurrentUser(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &User{
+		ID:        1,
+		Login:     "sourcegraph-bot",
+		FullName:  "Sourcegraph Bot",
+		Email:     "sourcegraph-bot@sourcegraph.com",
+		AvatarURL: "https://gitea.sgdev.org/avatars/1",
+	}, user)
+}
+
+func TestClient_ListCurrentUserEmails(t *testing.T) {
+	cli, save := NewTestClient(t, "ListCurrentUserEmails", *update)
+	defer save()
+
+	emails, err := cli.ListCurrentUserEmails(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []*Email{
+		{Email: "sourcegraph-bot@sourcegraph.com", Verified: true, Primary: true},
+		{Email: "bot@example.com"},
+	}, emails)
+}
+
+func TestClient_ListCurrentUserOrgs(t *testing.T) {
+	cli, save := NewTestClient(t, "ListCurrentUserOrgs", *update)
+	defer save()
+
+	orgs, hasNextPage, err := cli.ListCurrentUserOrgs(context.Background(), 1)
+	require.NoError(t, err)
+	assert.False(t, hasNextPage)
+	assert.Equal(t, []*Organization{{
+		ID:        3,
+		Name:      "sgtest",
+		FullName:  "Sourcegraph Test",
+		AvatarURL: "https://gitea.sgdev.org/avatars/3",
+	}}, orgs)
+}
@@ -241,6 +241,8 @@ type PullRequest struct {
 	BaseRefName    string
 	Number         int64
 	ReviewDecision string
+	// Mergeable is one of MERGEABLE, CONFLICTING or UNKNOWN.
+	Mergeable      string
 	Author         Actor
 	BaseRepository PullRequestRepo
 	HeadRepository PullRequestRepo
@@ -1464,6 +1466,7 @@ fragment pr on PullRequest {
   headRefName
   baseRefName
   reviewDecision
+  mergeable
   %s
   author {
     ...actor
@@ -48,6 +48,7 @@ type MergeRequest struct {
 	WorkInProgress          bool              `json:"work_in_progress"`
 	Draft                   bool              `json:"draft"`
 	ForceRemoveSourceBranch bool              `json:"force_remove_source_branch"`
+	HasConflicts            bool              `json:"has_conflicts"`
 	// We only get a partial User object back from the REST API. For example, it lacks
 	// `Email` and `Identities`. If we need more, we need to issue an additional API
 	// request. Otherwise, we should use a different type here.
@@ -212,6 +212,10 @@ const (
 
 	// VariantLocalGit is the (api.ExternalRepoSpec).ServiceType for local git repositories
 	VariantLocalGit
+
+	// VariantGitea is the (api.ExternalRepoSpec).ServiceType value for Gitea and Forgejo repositories. The
+	// ServiceID value is the base URL to the Gitea or Forgejo instance.
+	VariantGitea
 )
 
 type variantValues struct {
@@ -228,6 +232,7 @@ var variantValuesMap = map[Variant]variantValues{
 	VariantBitbucketCloud:  {AsKind: "BITBUCKETCLOUD", AsType: "bitbucketCloud", ConfigPrototype: func() any { return &schema.BitbucketCloudConnection{} }, WebhookURLPath: "bitbucket-cloud-webhooks", SupportsRepoExclusion: true},
 	VariantBitbucketServer: {AsKind: "BITBUCKETSERVER", AsType: "bitbucketServer", ConfigPrototype: func() any { return &schema.BitbucketServerConnection{} }, WebhookURLPath: "bitbucket-server-webhooks", SupportsRepoExclusion: true},
 	VariantGerrit:          {AsKind: "GERRIT", AsType: "gerrit", ConfigPrototype: func() any { return &schema.GerritConnection{} }},
+	VariantGitea:           {AsKind: "GITEA", AsType: "gitea", ConfigPrototype: func() any { return &schema.GiteaConnection{} }, SupportsRepoExclusion: true},
 	VariantGitHub:          {AsKind: "GITHUB", AsType: "github", ConfigPrototype: func() any { return &schema.GitHubConnection{} }, WebhookURLPath: "github-webhooks", SupportsRepoExclusion: true},
 	VariantGitLab:          {AsKind: "GITLAB", AsType: "gitlab", ConfigPrototype: func() any { return &schema.GitLabConnection{} }, WebhookURLPath: "gitlab-webhooks", SupportsRepoExclusion: true},
 	VariantGitolite:        {AsKind: "GITOLITE", AsType: "gitolite", ConfigPrototype: func() any { return &schema.GitoliteConnection{} }, SupportsRepoExclusion: true},
@@ -295,6 +300,7 @@ var (
 	KindBitbucketServer = VariantBitbucketServer.AsKind()
 	KindBitbucketCloud  = VariantBitbucketCloud.AsKind()
 	KindGerrit          = VariantGerrit.AsKind()
+	KindGitea           = VariantGitea.AsKind()
 	KindGitHub          = VariantGitHub.AsKind()
 	KindGitLab          = VariantGitLab.AsKind()
 	KindGitolite        = VariantGitolite.AsKind()
@@ -332,6 +338,10 @@ var (
 	// TypeGerrit is the (api.ExternalRepoSpec).ServiceType value for Gerrit projects.
 	TypeGerrit = VariantGerrit.AsType()
 
+	// TypeGitea is the (api.ExternalRepoSpec).ServiceType value for Gitea and Forgejo repositories. The
+	// ServiceID value is the base URL to the Gitea or Forgejo instance.
+	TypeGitea = VariantGitea.AsType()
+
 	// TypeGitHub is the (api.ExternalRepoSpec).ServiceType value for GitHub repositories. The ServiceID value
 	// is the base URL to the GitHub instance (https://github.com or the GitHub Enterprise URL).
 	TypeGitHub = VariantGitHub.AsType()
@@ -562,6 +572,8 @@ func extractToken(parsed any, kind string) (string, error) {
 		return c.Token, nil
 	case *schema.PagureConnection:
 		return c.Token, nil
+	case *schema.GiteaConnection:
+		return c.Token, nil
 	default:
 		return "", errors.Errorf("unable to extract token for service kind %q", kind)
 	}
@@ -630,6 +642,12 @@ func GetLimitFromConfig(config any, kind string) (limit rate.Limit, isDefault bo
 			isDefault = false
 			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
 		}
+	case *schema.GiteaConnection:
+		limit = GetDefaultRateLimit(KindGitea)
+		if c != nil && c.RateLimit != nil {
+			isDefault = false
+			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
+		}
 	case *schema.PerforceConnection:
 		limit = GetDefaultRateLimit(KindPerforce)
 		if c != nil && c.RateLimit != nil {
@@ -698,6 +716,10 @@ func GetDefaultRateLimit(kind string) rate.Limit {
 		return rate.Limit(8)
 	case KindBitbucketCloud:
 		return rate.Limit(2)
+	case KindGitea:
+		// Gitea and Forgejo don't rate limit API requests by default, so we
+		// apply our own limit of 10/s to not overload the instance.
+		return rate.Limit(10)
 	case KindPerforce:
 		return rate.Limit(5000.0 / 3600.0)
 	case KindJVMPackages:
@@ -831,6 +853,8 @@ func uniqueCodeHostIdentifier(kind string, cfg any) (string, error) {
 		rawURL = c.Url
 	case *schema.GerritConnection:
 		rawURL = c.Url
+	case *schema.GiteaConnection:
+		rawURL = c.Url
 	case *schema.PhabricatorConnection:
 		rawURL = c.Url
 	case *schema.OtherExternalServiceConnection:
@@ -37,6 +37,9 @@ func TestVariantConfigPrototypePointers(t *testing.T) {
 	if y, ok := VariantGerrit.ConfigPrototype().(*schema.GerritConnection); !ok {
 		t.Errorf("wrong type for Gerrit configuration prototype: %T", y)
 	}
+	if y, ok := VariantGitea.ConfigPrototype().(*schema.GiteaConnection); !ok {
+		t.Errorf("wrong type for Gitea configuration prototype: %T", y)
+	}
 	if y, ok := VariantGitHub.ConfigPrototype().(*schema.GitHubConnection); !ok {
 		t.Errorf("wrong type for GitHub configuration prototype: %T", y)
 	}
@@ -109,6 +112,11 @@ func TestExtractToken(t *testing.T) {
 			kind:   KindPhabricator,
 			want:   "deadbeef",
 		},
+		{
+			config: `{"token": "deadbeef"}`,
+			kind:   KindGitea,
+			want:   "deadbeef",
+		},
 	} {
 		t.Run(tc.kind, func(t *testing.T) {
 			have, err := ExtractToken(tc.config, tc.kind)
@@ -165,6 +173,13 @@ func TestExtractRateLimitConfig(t *testing.T) {
 			want:          2.0,
 			expectDefault: true,
 		},
+		{
+			name:          "Gitea default",
+			config:        `{"url": "https://example.com/"}`,
+			kind:          KindGitea,
+			want:          10.0,
+			expectDefault: true,
+		},
 		{
 			name:          "GitLab non-default",
 			config:        `{"url": "https://example.com/", "rateLimit": {"enabled": true, "requestsPerHour": 3600}}`,
@@ -378,6 +393,11 @@ func TestUniqueCodeHostIdentifier(t *testing.T) {
 			config: `{"url": "https://example.com"}`,
 			want:   "https://example.com/",
 		},
+		{
+			kind:   KindGitea,
+			config: `{"url": "https://codeberg.org"}`,
+			want:   "https://codeberg.org/",
+		},
 		{
 			kind:   KindBitbucketServer,
 			config: `{"url": "https://bitbucket.sgdev.org/"}`,
@@ -0,0 +1,50 @@
+package alerts
+
+import (
+	"context"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/metrics"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// NewCleaner returns a routine that deletes completed alert delivery jobs older than a week. The alert history
+// itself is kept in insight_series_alert_events.
+func NewCleaner(ctx context.Context, observationCtx *observation.Context, workerBaseStore *basestore.Store) goroutine.BackgroundRoutine {
+	operation := observationCtx.Operation(observation.Op{
+		Name: "AlertDelivery.Cleaner.Run",
+		Metrics: metrics.NewREDMetrics(
+			observationCtx.Registerer,
+			"insights_alert_delivery_job_cleaner",
+			metrics.WithCountHelp("Total number of insights alert delivery cleaner executions"),
+		),
+	})
+
+	return goroutine.NewPeriodicGoroutine(
+		ctx,
+		goroutine.HandlerFunc(
+			func(ctx context.Context) error {
+				return cleanJobs(ctx, workerBaseStore)
+			},
+		),
+		goroutine.WithName("insights.alert_delivery_job_cleaner"),
+		goroutine.WithDescription("removes completed insights alert delivery jobs"),
+		goroutine.WithInterval(1*time.Hour),
+		goroutine.WithOperation(operation),
+	)
+}
+
+func cleanJobs(ctx context.Context, workerBaseStore *basestore.Store) error {
+	return workerBaseStore.Exec(
+		ctx,
+		sqlf.Sprintf(cleanJobsFmtStr, time.Now().Add(-168*time.Hour)),
+	)
+}
+
+const cleanJobsFmtStr = `
+DELETE FROM insight_series_alert_jobs WHERE state='completed' AND started_at <= %s
+`
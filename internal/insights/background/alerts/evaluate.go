@@ -0,0 +1,153 @@
+package alerts
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// minAnomalySamples is the smallest number of previous values an anomaly rule needs to establish a baseline.
+const minAnomalySamples = 3
+
+// Result describes why an alert rule fired.
+type Result struct {
+	Value    float64
+	Baseline *float64
+	Message  string
+}
+
+// ValidateRule returns an error if the rule can never be evaluated.
+func ValidateRule(rule types.SeriesAlertRule) error {
+	if rule.Lookback < 1 {
+		return errors.New("lookback must be at least 1")
+	}
+	switch rule.Condition {
+	case types.AlertAboveThreshold, types.AlertBelowThreshold:
+	case types.AlertPercentChange:
+		if rule.Threshold == 0 {
+			return errors.New("percent change threshold must not be zero")
+		}
+	case types.AlertAnomaly:
+		if rule.Threshold <= 0 {
+			return errors.New("anomaly threshold must be a positive number of standard deviations")
+		}
+		if rule.Lookback < minAnomalySamples {
+			return errors.Newf("anomaly lookback must be at least %d", minAnomalySamples)
+		}
+	default:
+		return errors.Newf("unknown alert condition %q", rule.Condition)
+	}
+	return nil
+}
+
+// Evaluate checks the latest of the given values, sorted oldest first, against the rule. It returns false if the
+// rule does not fire or there is not enough data to evaluate it yet.
+func Evaluate(rule types.SeriesAlertRule, values []float64) (Result, bool) {
+	if len(values) == 0 {
+		return Result{}, false
+	}
+	latest := values[len(values)-1]
+	previous := values[:len(values)-1]
+
+	switch rule.Condition {
+	case types.AlertAboveThreshold:
+		// Only fire when the value crosses the threshold, not on every recording it stays above it.
+		if latest <= rule.Threshold {
+			return Result{}, false
+		}
+		result := Result{Value: latest, Message: fmt.Sprintf("value %s is above the threshold of %s", formatValue(latest), formatValue(rule.Threshold))}
+		if len(previous) > 0 {
+			baseline := previous[len(previous)-1]
+			if baseline > rule.Threshold {
+				return Result{}, false
+			}
+			result.Baseline = &baseline
+		}
+		return result, true
+
+	case types.AlertBelowThreshold:
+		if latest >= rule.Threshold {
+			return Result{}, false
+		}
+		result := Result{Value: latest, Message: fmt.Sprintf("value %s is below the threshold of %s", formatValue(latest), formatValue(rule.Threshold))}
+		if len(previous) > 0 {
+			baseline := previous[len(previous)-1]
+			if baseline < rule.Threshold {
+				return Result{}, false
+			}
+			result.Baseline = &baseline
+		}
+		return result, true
+
+	case types.AlertPercentChange:
+		if len(previous) < rule.Lookback {
+			return Result{}, false
+		}
+		baseline := previous[len(previous)-rule.Lookback]
+		if baseline == 0 {
+			// A change from zero has no meaningful percentage.
+			return Result{}, false
+		}
+		change := (latest - baseline) / math.Abs(baseline) * 100
+		if (rule.Threshold > 0 && change < rule.Threshold) || (rule.Threshold < 0 && change > rule.Threshold) {
+			return Result{}, false
+		}
+		direction := "rose"
+		if change < 0 {
+			direction = "fell"
+		}
+		return Result{
+			Value:    latest,
+			Baseline: &baseline,
+			Message: fmt.Sprintf("value %s by %.1f%% from %s to %s over the last %s",
+				direction, math.Abs(change), formatValue(baseline), formatValue(latest), pluralize(rule.Lookback, "recording")),
+		}, true
+
+	case types.AlertAnomaly:
+		if len(previous) < rule.Lookback || rule.Lookback < minAnomalySamples {
+			return Result{}, false
+		}
+		window := previous[len(previous)-rule.Lookback:]
+		mean, stddev := meanAndStddev(window)
+		if stddev == 0 {
+			// A perfectly flat history makes every change an anomaly.
+			if latest == mean {
+				return Result{}, false
+			}
+		} else if math.Abs(latest-mean)/stddev < rule.Threshold {
+			return Result{}, false
+		}
+		return Result{
+			Value:    latest,
+			Baseline: &mean,
+			Message: fmt.Sprintf("value %s deviates from the mean of %s over the previous %s",
+				formatValue(latest), formatValue(mean), pluralize(rule.Lookback, "recording")),
+		}, true
+	}
+
+	return Result{}, false
+}
+
+func meanAndStddev(values []float64) (mean, stddev float64) {
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+	for _, v := range values {
+		stddev += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(stddev / float64(len(values)))
+}
+
+func formatValue(v float64) string {
+	return fmt.Sprintf("%g", v)
+}
+
+func pluralize(n int, word string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, word)
+	}
+	return fmt.Sprintf("%d %ss", n, word)
+}
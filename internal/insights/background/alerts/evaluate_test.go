@@ -0,0 +1,154 @@
+package alerts
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+)
+
+func TestEvaluate(t *testing.T) {
+	testCases := []struct {
+		name      string
+		rule      types.SeriesAlertRule
+		values    []float64
+		wantFired bool
+		wantMsg   string
+	}{
+		{
+			name:      "no values",
+			rule:      types.SeriesAlertRule{Condition: types.AlertAboveThreshold, Threshold: 10},
+			wantFired: false,
+		},
+		{
+			name:      "above threshold on first recording",
+			rule:      types.SeriesAlertRule{Condition: types.AlertAboveThreshold, Threshold: 10},
+			values:    []float64{11},
+			wantFired: true,
+			wantMsg:   "value 11 is above the threshold of 10",
+		},
+		{
+			name:      "crosses above threshold",
+			rule:      types.SeriesAlertRule{Condition: types.AlertAboveThreshold, Threshold: 10},
+			values:    []float64{5, 10, 12},
+			wantFired: true,
+			wantMsg:   "value 12 is above the threshold of 10",
+		},
+		{
+			name:      "stays above threshold",
+			rule:      types.SeriesAlertRule{Condition: types.AlertAboveThreshold, Threshold: 10},
+			values:    []float64{5, 11, 12},
+			wantFired: false,
+		},
+		{
+			name:      "crosses below threshold",
+			rule:      types.SeriesAlertRule{Condition: types.AlertBelowThreshold, Threshold: 10},
+			values:    []float64{12, 9},
+			wantFired: true,
+			wantMsg:   "value 9 is below the threshold of 10",
+		},
+		{
+			name:      "stays below threshold",
+			rule:      types.SeriesAlertRule{Condition: types.AlertBelowThreshold, Threshold: 10},
+			values:    []float64{9, 8},
+			wantFired: false,
+		},
+		{
+			name:      "rose by percent",
+			rule:      types.SeriesAlertRule{Condition: types.AlertPercentChange, Threshold: 20, Lookback: 1},
+			values:    []float64{100, 120},
+			wantFired: true,
+			wantMsg:   "value rose by 20.0% from 100 to 120 over the last 1 recording",
+		},
+		{
+			name:      "rose by less than percent",
+			rule:      types.SeriesAlertRule{Condition: types.AlertPercentChange, Threshold: 20, Lookback: 1},
+			values:    []float64{100, 119},
+			wantFired: false,
+		},
+		{
+			name:      "fell by percent over lookback",
+			rule:      types.SeriesAlertRule{Condition: types.AlertPercentChange, Threshold: -50, Lookback: 2},
+			values:    []float64{100, 80, 40},
+			wantFired: true,
+			wantMsg:   "value fell by 60.0% from 100 to 40 over the last 2 recordings",
+		},
+		{
+			name:      "negative threshold ignores increases",
+			rule:      types.SeriesAlertRule{Condition: types.AlertPercentChange, Threshold: -50, Lookback: 1},
+			values:    []float64{100, 300},
+			wantFired: false,
+		},
+		{
+			name:      "percent change from zero",
+			rule:      types.SeriesAlertRule{Condition: types.AlertPercentChange, Threshold: 20, Lookback: 1},
+			values:    []float64{0, 10},
+			wantFired: false,
+		},
+		{
+			name:      "percent change without enough history",
+			rule:      types.SeriesAlertRule{Condition: types.AlertPercentChange, Threshold: 20, Lookback: 3},
+			values:    []float64{100, 200},
+			wantFired: false,
+		},
+		{
+			name:      "anomaly",
+			rule:      types.SeriesAlertRule{Condition: types.AlertAnomaly, Threshold: 3, Lookback: 4},
+			values:    []float64{10, 12, 10, 12, 30},
+			wantFired: true,
+			wantMsg:   "value 30 deviates from the mean of 11 over the previous 4 recordings",
+		},
+		{
+			name:      "within normal variation",
+			rule:      types.SeriesAlertRule{Condition: types.AlertAnomaly, Threshold: 3, Lookback: 4},
+			values:    []float64{10, 12, 10, 12, 13},
+			wantFired: false,
+		},
+		{
+			name:      "change after flat history",
+			rule:      types.SeriesAlertRule{Condition: types.AlertAnomaly, Threshold: 3, Lookback: 3},
+			values:    []float64{5, 5, 5, 6},
+			wantFired: true,
+			wantMsg:   "value 6 deviates from the mean of 5 over the previous 3 recordings",
+		},
+		{
+			name:      "anomaly without enough history",
+			rule:      types.SeriesAlertRule{Condition: types.AlertAnomaly, Threshold: 3, Lookback: 4},
+			values:    []float64{10, 10, 100},
+			wantFired: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, fired := Evaluate(tc.rule, tc.values)
+			if fired != tc.wantFired {
+				t.Fatalf("unexpected fired: want %v, got %v", tc.wantFired, fired)
+			}
+			if result.Message != tc.wantMsg {
+				t.Fatalf("unexpected message:\nwant %q\ngot  %q", tc.wantMsg, result.Message)
+			}
+		})
+	}
+}
+
+func TestValidateRule(t *testing.T) {
+	testCases := []struct {
+		name    string
+		rule    types.SeriesAlertRule
+		wantErr bool
+	}{
+		{name: "threshold", rule: types.SeriesAlertRule{Condition: types.AlertAboveThreshold, Threshold: -1, Lookback: 1}},
+		{name: "zero percent change", rule: types.SeriesAlertRule{Condition: types.AlertPercentChange, Threshold: 0, Lookback: 1}, wantErr: true},
+		{name: "anomaly lookback too short", rule: types.SeriesAlertRule{Condition: types.AlertAnomaly, Threshold: 2, Lookback: 2}, wantErr: true},
+		{name: "negative anomaly threshold", rule: types.SeriesAlertRule{Condition: types.AlertAnomaly, Threshold: -2, Lookback: 5}, wantErr: true},
+		{name: "unknown condition", rule: types.SeriesAlertRule{Condition: "SOMETIMES", Lookback: 1}, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := ValidateRule(tc.rule); (err != nil) != tc.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
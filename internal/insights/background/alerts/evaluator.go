@@ -0,0 +1,137 @@
+package alerts
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// EvaluateSeries evaluates the enabled alert rules of a series against the points recorded at recordTime. Every
+// rule that fires is added to the alert history of the series, and enqueues a delivery job for each of its actions.
+func EvaluateSeries(ctx context.Context, logger log.Logger, timeseriesStore store.Interface, alertStore *store.AlertStore, series *types.InsightSeries, recordTime time.Time) error {
+	rules, err := alertStore.ListAlertRules(ctx, store.AlertRuleQueryArgs{SeriesID: series.ID, OnlyEnabled: true})
+	if err != nil {
+		return errors.Wrap(err, "ListAlertRules")
+	}
+
+	var errs error
+	for _, rule := range rules {
+		fired, err := evaluateRule(ctx, timeseriesStore, alertStore, series, rule, recordTime)
+		if err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "evaluating alert rule %d", rule.ID))
+			continue
+		}
+		if fired > 0 {
+			logger.Debug("insights alert fired", log.Int("seriesID", series.ID), log.Int("ruleID", rule.ID), log.Int("count", fired))
+		}
+	}
+	return errs
+}
+
+func evaluateRule(ctx context.Context, timeseriesStore store.Interface, alertStore *store.AlertStore, series *types.InsightSeries, rule *types.SeriesAlertRule, recordTime time.Time) (fired int, err error) {
+	// The latest recording plus the history the rule compares it against.
+	samples := rule.Lookback + 1
+	// Recording times are stored with a lower precision than recordTime, so allow for rounding.
+	recordingTimes, err := alertStore.ListRecordingTimes(ctx, series.ID, recordTime.Add(time.Second), samples)
+	if err != nil {
+		return 0, errors.Wrap(err, "ListRecordingTimes")
+	}
+	if len(recordingTimes) == 0 {
+		return 0, nil
+	}
+	latest := recordingTimes[len(recordingTimes)-1]
+	if d := recordTime.Sub(latest); d < 0 || d >= time.Second {
+		// The recording at recordTime is not the latest one, so there is nothing new to evaluate.
+		return 0, nil
+	}
+
+	// 🚨 SECURITY: Points are read as the creator of the rule, so that alerts never include data from repositories
+	// the creator cannot see.
+	ctx = actor.WithActor(ctx, actor.FromUser(rule.CreatedBy))
+	from, to := recordingTimes[0], latest.Add(time.Second)
+	points, err := timeseriesStore.SeriesPoints(ctx, store.SeriesPointsOpts{
+		SeriesID: &series.SeriesID,
+		ID:       &series.ID,
+		From:     &from,
+		To:       &to,
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "SeriesPoints")
+	}
+
+	values := valuesByCapture(points, recordingTimes)
+	if len(values) == 0 && !series.GeneratedFromCaptureGroups {
+		// Nothing matched in any of the recordings.
+		values = []captureValues{{values: make([]float64, len(recordingTimes))}}
+	}
+
+	for _, s := range values {
+		result, ok := Evaluate(*rule, s.values)
+		if !ok {
+			continue
+		}
+		created, err := alertStore.CreateAlertEvent(ctx, types.SeriesAlertEvent{
+			RuleID:    &rule.ID,
+			SeriesID:  series.ID,
+			PointTime: latest,
+			Capture:   s.capture,
+			Value:     result.Value,
+			Baseline:  result.Baseline,
+			Message:   result.Message,
+		})
+		if err != nil {
+			return fired, errors.Wrap(err, "CreateAlertEvent")
+		}
+		if created {
+			fired++
+		}
+	}
+	return fired, nil
+}
+
+type captureValues struct {
+	capture *string
+	values  []float64
+}
+
+// valuesByCapture aligns the points of each capture value to the given recording times. Recordings without a point
+// count as zero, which matches how series with augmentation are displayed.
+func valuesByCapture(points []store.SeriesPoint, recordingTimes []time.Time) []captureValues {
+	index := make(map[int64]int, len(recordingTimes))
+	for i, t := range recordingTimes {
+		index[t.Unix()] = i
+	}
+
+	var order []string
+	byCapture := map[string]*captureValues{}
+	for _, p := range points {
+		i, ok := index[p.Time.Unix()]
+		if !ok {
+			// Snapshot points are not part of the recorded history.
+			continue
+		}
+		key := ""
+		if p.Capture != nil {
+			key = *p.Capture
+		}
+		cv, ok := byCapture[key]
+		if !ok {
+			cv = &captureValues{capture: p.Capture, values: make([]float64, len(recordingTimes))}
+			byCapture[key] = cv
+			order = append(order, key)
+		}
+		cv.values[i] += p.Value
+	}
+
+	results := make([]captureValues, 0, len(order))
+	for _, key := range order {
+		results = append(results, *byCapture[key])
+	}
+	return results
+}
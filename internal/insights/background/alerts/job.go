@@ -0,0 +1,78 @@
+package alerts
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/executor"
+)
+
+// AlertDeliveryJob delivers a fired alert through one of the actions of its rule.
+type AlertDeliveryJob struct {
+	ID              int
+	State           string
+	FailureMessage  *string
+	QueuedAt        time.Time
+	StartedAt       *time.Time
+	FinishedAt      *time.Time
+	ProcessAfter    *time.Time
+	NumResets       int
+	NumFailures     int
+	LastHeartbeatAt time.Time
+	ExecutionLogs   []executor.ExecutionLogEntry
+	WorkerHostname  string
+	Cancel          bool
+
+	EventID  int
+	ActionID int
+}
+
+var alertDeliveryJobColumns = []*sqlf.Query{
+	sqlf.Sprintf("insight_series_alert_jobs.event_id"),
+	sqlf.Sprintf("insight_series_alert_jobs.action_id"),
+
+	sqlf.Sprintf("id"),
+	sqlf.Sprintf("state"),
+	sqlf.Sprintf("failure_message"),
+	sqlf.Sprintf("started_at"),
+	sqlf.Sprintf("finished_at"),
+	sqlf.Sprintf("process_after"),
+	sqlf.Sprintf("num_resets"),
+	sqlf.Sprintf("num_failures"),
+	sqlf.Sprintf("execution_logs"),
+}
+
+func (j *AlertDeliveryJob) RecordID() int {
+	return j.ID
+}
+
+func (j *AlertDeliveryJob) RecordUID() string {
+	return strconv.Itoa(j.ID)
+}
+
+func scanAlertDeliveryJob(s dbutil.Scanner) (*AlertDeliveryJob, error) {
+	var job AlertDeliveryJob
+
+	if err := s.Scan(
+		&job.EventID,
+		&job.ActionID,
+
+		&job.ID,
+		&job.State,
+		&job.FailureMessage,
+		&job.StartedAt,
+		&job.FinishedAt,
+		&job.ProcessAfter,
+		&job.NumResets,
+		&job.NumFailures,
+		pq.Array(&job.ExecutionLogs),
+	); err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
@@ -0,0 +1,124 @@
+package alerts
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/slack-go/slack"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/txemail"
+	"github.com/sourcegraph/sourcegraph/internal/txemail/txtypes"
+)
+
+var alertEmailTemplates = txemail.MustValidate(txtypes.Templates{
+	Subject: `Sourcegraph code insights alert: {{.Description}}`,
+	Text: `
+{{.Description}}
+
+The code insights series for the query
+
+  {{.Query}}
+
+fired an alert{{if .Capture}} for the capture value "{{.Capture}}"{{end}}: {{.Message}}.
+
+View your code insights: {{.InsightsURL}}
+`,
+	HTML: `
+<p><strong>{{.Description}}</strong></p>
+
+<p>The code insights series for the query <code>{{.Query}}</code> fired an alert{{if .Capture}} for the
+capture value <code>{{.Capture}}</code>{{end}}: {{.Message}}.</p>
+
+<p><a href="{{.InsightsURL}}">View your code insights</a></p>
+`,
+})
+
+// notification is the data sent for a fired alert, regardless of how it is delivered.
+type notification struct {
+	Description string
+	Condition   types.AlertCondition
+	SeriesID    string
+	Query       string
+	Capture     string
+	Time        time.Time
+	Value       float64
+	Baseline    *float64
+	Message     string
+	InsightsURL string
+}
+
+func newNotification(rule *types.SeriesAlertRule, series *types.InsightSeries, event *types.SeriesAlertEvent) notification {
+	description := rule.Description
+	if description == "" {
+		description = fmt.Sprintf("%s alert for %q", rule.Condition, series.Query)
+	}
+	var capture string
+	if event.Capture != nil {
+		capture = *event.Capture
+	}
+	return notification{
+		Description: description,
+		Condition:   rule.Condition,
+		SeriesID:    series.SeriesID,
+		Query:       series.Query,
+		Capture:     capture,
+		Time:        event.PointTime,
+		Value:       event.Value,
+		Baseline:    event.Baseline,
+		Message:     event.Message,
+		InsightsURL: insightsURL(),
+	}
+}
+
+func insightsURL() string {
+	u, err := url.Parse(conf.ExternalURL())
+	if err != nil {
+		return ""
+	}
+	return u.ResolveReference(&url.URL{Path: "/insights"}).String()
+}
+
+type webhookPayload struct {
+	Description string   `json:"description"`
+	Condition   string   `json:"condition"`
+	SeriesID    string   `json:"seriesID"`
+	Query       string   `json:"query"`
+	Capture     string   `json:"capture,omitempty"`
+	Time        string   `json:"time"`
+	Value       float64  `json:"value"`
+	Baseline    *float64 `json:"baseline,omitempty"`
+	Message     string   `json:"message"`
+	URL         string   `json:"url"`
+}
+
+func (n notification) webhookPayload() webhookPayload {
+	return webhookPayload{
+		Description: n.Description,
+		Condition:   string(n.Condition),
+		SeriesID:    n.SeriesID,
+		Query:       n.Query,
+		Capture:     n.Capture,
+		Time:        n.Time.UTC().Format(time.RFC3339),
+		Value:       n.Value,
+		Baseline:    n.Baseline,
+		Message:     n.Message,
+		URL:         n.InsightsURL,
+	}
+}
+
+func (n notification) slackMessage() *slack.WebhookMessage {
+	text := fmt.Sprintf("*%s*\nThe code insights series for `%s` fired an alert", n.Description, n.Query)
+	if n.Capture != "" {
+		text += fmt.Sprintf(" for the capture value `%s`", n.Capture)
+	}
+	text += ": " + n.Message + "."
+	if n.InsightsURL != "" {
+		text += fmt.Sprintf("\n<%s|View your code insights>", n.InsightsURL)
+	}
+	return &slack.WebhookMessage{Blocks: &slack.Blocks{BlockSet: []slack.Block{
+		slack.NewSectionBlock(slack.NewTextBlockObject("mrkdwn", text, false, false), nil, nil),
+	}}}
+}
@@ -0,0 +1,113 @@
+package alerts
+
+import (
+	"context"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/log"
+
+	cmbackground "github.com/sourcegraph/sourcegraph/internal/codemonitors/background"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
+	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var _ workerutil.Handler[*AlertDeliveryJob] = &alertDeliveryHandler{}
+
+type alertDeliveryHandler struct {
+	alertStore   *store.AlertStore
+	insightStore *store.InsightStore
+	db           database.DB
+	doer         httpcli.Doer
+}
+
+func (h *alertDeliveryHandler) Handle(ctx context.Context, logger log.Logger, record *AlertDeliveryJob) error {
+	event, err := h.alertStore.GetAlertEvent(ctx, record.EventID)
+	if err != nil {
+		return errors.Wrap(err, "GetAlertEvent")
+	}
+	action, err := h.alertStore.GetAlertAction(ctx, record.ActionID)
+	if err != nil {
+		return errors.Wrap(err, "GetAlertAction")
+	}
+	if event == nil || action == nil || event.RuleID == nil {
+		// The rule or series was deleted after the alert fired.
+		return nil
+	}
+	rule, err := h.alertStore.GetAlertRule(ctx, *event.RuleID)
+	if err != nil {
+		return errors.Wrap(err, "GetAlertRule")
+	}
+	if rule == nil {
+		return nil
+	}
+	series, err := h.insightStore.GetDataSeriesByID(ctx, event.SeriesID)
+	if err != nil {
+		return errors.Wrap(err, "GetDataSeriesByID")
+	}
+
+	n := newNotification(rule, series, event)
+	switch action.Type {
+	case types.AlertActionEmail:
+		return cmbackground.SendEmail(ctx, h.db, action.RecipientUserID, "code-insights-alert", alertEmailTemplates, n)
+	case types.AlertActionWebhook:
+		return cmbackground.PostWebhook(ctx, h.doer, action.URL, n.webhookPayload())
+	case types.AlertActionSlackWebhook:
+		return cmbackground.PostSlackWebhook(ctx, h.doer, action.URL, n.slackMessage())
+	default:
+		return errors.Newf("unknown alert action type %q", action.Type)
+	}
+}
+
+// NewWorker returns a worker that delivers fired code insights alerts.
+func NewWorker(ctx context.Context, logger log.Logger, workerStore dbworkerstore.Store[*AlertDeliveryJob], alertStore *store.AlertStore, insightStore *store.InsightStore, db database.DB, metrics workerutil.WorkerObservability) *workerutil.Worker[*AlertDeliveryJob] {
+	options := workerutil.WorkerOptions{
+		Name:              "insights_alert_delivery_worker",
+		Description:       "delivers code insights alerts by email, webhook and Slack",
+		NumHandlers:       5,
+		Interval:          10 * time.Second,
+		HeartbeatInterval: 15 * time.Second,
+		Metrics:           metrics,
+	}
+
+	return dbworker.NewWorker[*AlertDeliveryJob](ctx, workerStore, &alertDeliveryHandler{
+		alertStore:   alertStore,
+		insightStore: insightStore,
+		db:           db,
+		doer:         httpcli.ExternalDoer,
+	}, options)
+}
+
+// NewResetter returns a resetter that will reset pending alert delivery jobs if they take too long
+// to complete.
+func NewResetter(ctx context.Context, logger log.Logger, workerStore dbworkerstore.Store[*AlertDeliveryJob], metrics dbworker.ResetterMetrics) *dbworker.Resetter[*AlertDeliveryJob] {
+	options := dbworker.ResetterOptions{
+		Name:     "insights_alert_delivery_worker_resetter",
+		Interval: 1 * time.Minute,
+		Metrics:  metrics,
+	}
+	return dbworker.NewResetter(logger, workerStore, options)
+}
+
+func CreateDBWorkerStore(observationCtx *observation.Context, store *basestore.Store) dbworkerstore.Store[*AlertDeliveryJob] {
+	return dbworkerstore.New(observationCtx, store.Handle(), dbworkerstore.Options[*AlertDeliveryJob]{
+		Name:              "insights_alert_delivery_worker_store",
+		TableName:         "insight_series_alert_jobs",
+		ColumnExpressions: alertDeliveryJobColumns,
+		Scan:              dbworkerstore.BuildWorkerScan(scanAlertDeliveryJob),
+		OrderByExpression: sqlf.Sprintf("queued_at, id"),
+		RetryAfter:        5 * time.Minute,
+		MaxNumRetries:     3,
+		MaxNumResets:      5,
+		StalledMaxAge:     time.Second * 60,
+	})
+}
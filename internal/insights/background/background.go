@@ -17,6 +17,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	internalGitserver "github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/insights/background/alerts"
 	"github.com/sourcegraph/sourcegraph/internal/insights/background/limiter"
 	"github.com/sourcegraph/sourcegraph/internal/insights/background/pings"
 	"github.com/sourcegraph/sourcegraph/internal/insights/background/queryrunner"
@@ -80,7 +81,7 @@ func GetBackgroundJobs(ctx context.Context, logger log.Logger, mainAppDB databas
 		historicRateLimiter := limiter.HistoricalWorkRate()
 		backfillConfig := pipeline.BackfillerConfig{
 			CompressionPlan:         compression.NewGitserverFilter(logger, gitserverClient),
-			SearchHandlers:          queryrunner.GetSearchHandlers(),
+			SearchHandlers:          queryrunner.GetSearchHandlers(mainAppDB.Repos(), gitserverClient),
 			InsightStore:            insightsStore,
 			CommitClient:            gitserver.NewGitCommitClient(gitserverClient),
 			SearchPlanWorkerLimit:   1,
@@ -159,6 +160,21 @@ func GetBackgroundDataRetentionJob(ctx context.Context, observationCtx *observat
 	}
 }
 
+// GetBackgroundAlertDeliveryJob returns the routines that deliver code insights alerts once they fired. Alerts are
+// evaluated by the query runner after every new recording.
+func GetBackgroundAlertDeliveryJob(ctx context.Context, observationCtx *observation.Context, mainAppDB database.DB, insightsDB edb.InsightsDB) []goroutine.BackgroundRoutine {
+	workerMetrics, resetterMetrics := newWorkerMetrics(observationCtx, "insights_alert_delivery")
+
+	workerBaseStore := basestore.NewWithHandle(insightsDB.Handle())
+	dbWorkerStore := alerts.CreateDBWorkerStore(observationCtx, workerBaseStore)
+
+	return []goroutine.BackgroundRoutine{
+		alerts.NewWorker(ctx, observationCtx.Logger.Scoped("Worker", ""), dbWorkerStore, store.NewAlertStore(insightsDB), store.NewInsightStore(insightsDB), mainAppDB, workerMetrics),
+		alerts.NewResetter(ctx, observationCtx.Logger.Scoped("Resetter", ""), dbWorkerStore, resetterMetrics),
+		alerts.NewCleaner(ctx, observationCtx, workerBaseStore),
+	}
+}
+
 // newWorkerMetrics returns a basic set of metrics to be used for a worker and its resetter:
 //
 //   - WorkerMetrics records worker operations & number of jobs.
@@ -148,7 +148,10 @@ func (ie *InsightEnqueuer) EnqueueSingle(
 	var modifiedQuery querybuilder.BasicQuery
 	var finalQuery string
 
-	if series.RepositoryCriteria != nil {
+	if series.GenerationMethod == types.Script {
+		// Scripts don't run a search, the query only scopes the job to the repositories of the series.
+		modifiedQuery = querybuilder.ScriptScopeQuery(series.Repositories, "")
+	} else if series.RepositoryCriteria != nil {
 		modifiedQuery, err = querybuilder.MakeQueryWithRepoFilters(*series.RepositoryCriteria, basicQuery, true, querybuilder.CodeInsightsQueryDefaults(true)...)
 	} else if len(series.Repositories) > 0 {
 		modifiedQuery, err = querybuilder.MultiRepoQuery(basicQuery, series.Repositories, defaultQueryParams)
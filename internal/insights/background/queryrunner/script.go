@@ -0,0 +1,109 @@
+package queryrunner
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/insights/discovery"
+	"github.com/sourcegraph/sourcegraph/internal/insights/query/querybuilder"
+	"github.com/sourcegraph/sourcegraph/internal/insights/script"
+	"github.com/sourcegraph/sourcegraph/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	searchquery "github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// scriptRunner runs the Lua script of a series against a repository at a commit.
+type scriptRunner interface {
+	Run(ctx context.Context, source string, repo api.RepoName, commit api.CommitID, limits script.Limits) ([]script.Datapoint, error)
+}
+
+// generateScriptRecordings runs the script of the series against every repository the job is scoped to, and
+// records each labelled value returned by the script as a capture group value of the series.
+func generateScriptRecordings(ctx context.Context, job *SearchJob, series *types.InsightSeries, recordTime time.Time, runner scriptRunner, repoStore discovery.RepoStore, gitserverClient gitserver.Client, logger log.Logger) ([]store.RecordSeriesPointArgs, error) {
+	if len(series.Repositories) == 0 {
+		return nil, errors.Newf("script series must be scoped to repositories, series_id: %s", series.SeriesID)
+	}
+	repoFilters, err := querybuilder.ScriptScopeRepositories(querybuilder.BasicQuery(job.SearchQuery))
+	if err != nil {
+		return nil, errors.Wrap(err, "ScriptScopeRepositories")
+	}
+	repos, err := repoStore.List(ctx, database.ReposListOptions{Names: series.Repositories})
+	if err != nil {
+		return nil, errors.Wrap(err, "repoStore.List")
+	}
+
+	limits := script.LimitsFromConfig()
+	checker := authz.DefaultSubRepoPermsChecker
+	var recordings []store.RecordSeriesPointArgs
+
+	for _, repo := range repos {
+		revision, ok := scriptRevision(repoFilters, string(repo.Name))
+		if !ok {
+			continue
+		}
+
+		// sub-repo permissions filtering. Scripts read whole repositories, so repositories with sub-repo
+		// permissions are excluded just like they are from search results.
+		subRepoEnabled, subRepoErr := authz.SubRepoEnabledForRepoID(ctx, checker, repo.ID)
+		if subRepoErr != nil {
+			logger.Error("sub-repo permissions check errored", log.String("seriesID", job.SeriesID), log.String("repo", string(repo.Name)), log.Error(subRepoErr))
+			continue
+		}
+		if subRepoEnabled {
+			continue
+		}
+
+		commit, err := gitserverClient.ResolveRevision(ctx, repo.Name, revision, gitserver.ResolveRevisionOptions{NoEnsureRevision: true})
+		if err != nil {
+			if errors.HasType(err, &gitdomain.RevisionNotFoundError{}) || gitdomain.IsRepoNotExist(err) {
+				// no error - repo may not be cloned yet (or not even pushed to code host yet)
+				continue
+			}
+			return nil, errors.Wrapf(err, "ResolveRevision repo:%s", repo.Name)
+		}
+
+		datapoints, err := runner.Run(ctx, series.Query, repo.Name, commit, limits)
+		if err != nil {
+			return nil, errors.Wrapf(err, "script repo:%s", repo.Name)
+		}
+		for _, datapoint := range datapoints {
+			capture := datapoint.Label
+			recordings = append(recordings, toRecording(job, datapoint.Value, recordTime, string(repo.Name), repo.ID, &capture)...)
+		}
+	}
+
+	return recordings, nil
+}
+
+// scriptRevision returns the revision a job scoped by the given repository filters runs against in the given
+// repository, and whether the job applies to the repository at all.
+func scriptRevision(repoFilters []searchquery.ParsedRepoFilter, repoName string) (string, bool) {
+	for _, filter := range repoFilters {
+		if !filter.RepoRegex.MatchString(repoName) {
+			continue
+		}
+		if len(filter.Revs) > 0 && filter.Revs[0].RevSpec != "" {
+			return filter.Revs[0].RevSpec, true
+		}
+		return "HEAD", true
+	}
+	return "", false
+}
+
+func makeScriptHandler(runner scriptRunner, repoStore discovery.RepoStore, gitserverClient gitserver.Client) InsightsHandler {
+	return func(ctx context.Context, job *SearchJob, series *types.InsightSeries, recordTime time.Time) ([]store.RecordSeriesPointArgs, error) {
+		recordings, err := generateScriptRecordings(ctx, job, series, recordTime, runner, repoStore, gitserverClient, log.Scoped("ScriptRecordingsGenerator", ""))
+		if err != nil {
+			return nil, errors.Wrapf(err, "scriptHandler")
+		}
+		return recordings, nil
+	}
+}
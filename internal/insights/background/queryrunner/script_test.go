@@ -0,0 +1,102 @@
+package queryrunner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hexops/autogold/v2"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/insights/query/querybuilder"
+	"github.com/sourcegraph/sourcegraph/internal/insights/script"
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	dbtypes "github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+type fakeScriptRunner struct {
+	runs []string
+}
+
+func (r *fakeScriptRunner) Run(_ context.Context, _ string, repo api.RepoName, commit api.CommitID, _ script.Limits) ([]script.Datapoint, error) {
+	r.runs = append(r.runs, string(repo)+"@"+string(commit))
+	return []script.Datapoint{{Label: "go", Value: 3}, {Label: "lua", Value: 1}}, nil
+}
+
+func TestGenerateScriptRecordings(t *testing.T) {
+	date := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+	repos := []*dbtypes.Repo{
+		{ID: 1, Name: "github.com/sourcegraph/sourcegraph"},
+		{ID: 2, Name: "github.com/sourcegraph/zoekt"},
+		{ID: 3, Name: "github.com/sourcegraph/uncloned"},
+	}
+	series := &types.InsightSeries{
+		SeriesID:         "testseries1",
+		Query:            `return { go = 3, lua = 1 }`,
+		Repositories:     []string{"github.com/sourcegraph/sourcegraph", "github.com/sourcegraph/zoekt", "github.com/sourcegraph/uncloned"},
+		GenerationMethod: types.Script,
+	}
+
+	repoStore := dbmocks.NewMockRepoStore()
+	repoStore.ListFunc.SetDefaultReturn(repos, nil)
+	gitserverClient := gitserver.NewMockClient()
+	gitserverClient.ResolveRevisionFunc.SetDefaultHook(func(_ context.Context, repo api.RepoName, spec string, _ gitserver.ResolveRevisionOptions) (api.CommitID, error) {
+		if repo == "github.com/sourcegraph/uncloned" {
+			return "", &gitdomain.RepoNotExistError{Repo: repo}
+		}
+		return api.CommitID(string(repo) + "-" + spec), nil
+	})
+
+	t.Run("global job", func(t *testing.T) {
+		runner := &fakeScriptRunner{}
+		job := SearchJob{
+			SeriesID:    series.SeriesID,
+			SearchQuery: querybuilder.ScriptScopeQuery(series.Repositories, "").String(),
+			PersistMode: "record",
+		}
+		recordings, err := generateScriptRecordings(context.Background(), &job, series, date, runner, repoStore, gitserverClient, logtest.Scoped(t))
+		if err != nil {
+			t.Fatal(err)
+		}
+		autogold.Expect([]string{
+			"github.com/sourcegraph/sourcegraph@github.com/sourcegraph/sourcegraph-HEAD",
+			"github.com/sourcegraph/zoekt@github.com/sourcegraph/zoekt-HEAD",
+		}).Equal(t, runner.runs)
+		autogold.Expect([]string{
+			"github.com/sourcegraph/sourcegraph 1 2021-12-01 00:00:00 +0000 UTC go 3.000000",
+			"github.com/sourcegraph/sourcegraph 1 2021-12-01 00:00:00 +0000 UTC lua 1.000000",
+			"github.com/sourcegraph/zoekt 2 2021-12-01 00:00:00 +0000 UTC go 3.000000",
+			"github.com/sourcegraph/zoekt 2 2021-12-01 00:00:00 +0000 UTC lua 1.000000",
+		}).Equal(t, stringify(recordings))
+	})
+
+	t.Run("historical job", func(t *testing.T) {
+		runner := &fakeScriptRunner{}
+		job := SearchJob{
+			SeriesID:    series.SeriesID,
+			SearchQuery: querybuilder.ScriptScopeQuery([]string{"github.com/sourcegraph/zoekt"}, "abc123").String(),
+			RecordTime:  &date,
+			PersistMode: "record",
+		}
+		recordings, err := generateScriptRecordings(context.Background(), &job, series, date, runner, repoStore, gitserverClient, logtest.Scoped(t))
+		if err != nil {
+			t.Fatal(err)
+		}
+		autogold.Expect([]string{"github.com/sourcegraph/zoekt@github.com/sourcegraph/zoekt-abc123"}).Equal(t, runner.runs)
+		autogold.Expect([]string{
+			"github.com/sourcegraph/zoekt 2 2021-12-01 00:00:00 +0000 UTC go 3.000000",
+			"github.com/sourcegraph/zoekt 2 2021-12-01 00:00:00 +0000 UTC lua 1.000000",
+		}).Equal(t, stringify(recordings))
+	})
+
+	t.Run("series without repositories", func(t *testing.T) {
+		job := SearchJob{SeriesID: series.SeriesID, SearchQuery: "repo:^(a)$"}
+		if _, err := generateScriptRecordings(context.Background(), &job, &types.InsightSeries{GenerationMethod: types.Script}, date, &fakeScriptRunner{}, repoStore, gitserverClient, logtest.Scoped(t)); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
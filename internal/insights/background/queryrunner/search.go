@@ -11,8 +11,10 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/insights/discovery"
 	"github.com/sourcegraph/sourcegraph/internal/insights/query/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/insights/script"
 	"github.com/sourcegraph/sourcegraph/internal/insights/store"
 	"github.com/sourcegraph/sourcegraph/internal/insights/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -20,7 +22,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 )
 
-func GetSearchHandlers() map[types.GenerationMethod]InsightsHandler {
+func GetSearchHandlers(repoStore discovery.RepoStore, gitserverClient gitserver.Client) map[types.GenerationMethod]InsightsHandler {
 	searchStream := func(ctx context.Context, query string) (*streaming.TabulationResult, error) {
 		tr, ctx := trace.New(ctx, "CodeInsightsSearch.searchStream")
 		defer tr.End()
@@ -56,10 +58,25 @@ func GetSearchHandlers() map[types.GenerationMethod]InsightsHandler {
 		return streamResults, nil
 	}
 
+	scriptSearch := func(ctx context.Context, query string) (int, error) {
+		tr, err := searchStream(ctx, query)
+		if err != nil {
+			return 0, err
+		}
+		if len(tr.Errors) > 0 {
+			return 0, classifiedError(tr.Errors, types.Script)
+		}
+		if tr.DidTimeout {
+			return 0, SearchTimeoutError
+		}
+		return tr.TotalCount, nil
+	}
+
 	return map[types.GenerationMethod]InsightsHandler{
 		types.MappingCompute: makeMappingComputeHandler(computeTextExtraSearch),
 		types.SearchCompute:  makeComputeHandler(computeSearchStream),
 		types.Search:         makeSearchHandler(searchStream),
+		types.Script:         makeScriptHandler(script.NewRunner(gitserverClient, scriptSearch), repoStore, gitserverClient),
 	}
 
 }
@@ -11,6 +11,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/insights/background/alerts"
 	"github.com/sourcegraph/sourcegraph/internal/insights/discovery"
 	"github.com/sourcegraph/sourcegraph/internal/insights/store"
 	"github.com/sourcegraph/sourcegraph/internal/insights/types"
@@ -29,6 +30,7 @@ type workHandler struct {
 	insightsStore   *store.Store
 	repoStore       discovery.RepoStore
 	metadadataStore *store.InsightStore
+	alertStore      *store.AlertStore
 	limiter         *ratelimit.InstrumentedLimiter
 	logger          log.Logger
 
@@ -142,7 +144,18 @@ func (r *workHandler) Handle(ctx context.Context, logger log.Logger, record *Job
 		return err
 	}
 
-	return r.persistRecordings(ctx, &job.SearchJob, series, recordings, recordTime)
+	if err := r.persistRecordings(ctx, &job.SearchJob, series, recordings, recordTime); err != nil {
+		return err
+	}
+
+	// Alert rules are only evaluated for new recordings, not for snapshots or points recorded at a time in the past.
+	if isGlobal && job.PersistMode == string(store.RecordMode) {
+		// Alerts are best effort, failing to evaluate them must not cause the recording to be retried.
+		if err := alerts.EvaluateSeries(ctx, logger, r.insightsStore, r.alertStore, series, recordTime); err != nil {
+			logger.Error("insights alert evaluation failed", log.Int("seriesId", series.ID), log.Error(err))
+		}
+	}
+	return nil
 }
 
 func TranslateIncompleteReasons(err error) store.IncompleteReason {
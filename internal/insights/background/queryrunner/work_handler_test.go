@@ -143,6 +143,7 @@ func Test_HandleWithTerminalError(t *testing.T) {
 		insightsStore:   tss,
 		baseWorkerStore: workerStore,
 		metadadataStore: metadataStore,
+		alertStore:      store.NewAlertStoreWith(metadataStore),
 		limiter:         ratelimit.NewInstrumentedLimiter("asdf", rate.NewLimiter(10, 5)),
 		logger:          logger,
 		mu:              sync.RWMutex{},
@@ -16,6 +16,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/executor"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/insights/compression"
 	"github.com/sourcegraph/sourcegraph/internal/insights/discovery"
 	"github.com/sourcegraph/sourcegraph/internal/insights/priority"
@@ -75,8 +76,9 @@ func NewWorker(ctx context.Context, logger log.Logger, workerStore *workerStoreE
 		repoStore:       repoStore,
 		limiter:         limiter,
 		metadadataStore: store.NewInsightStoreWith(insightsStore),
+		alertStore:      store.NewAlertStoreWith(insightsStore),
 		seriesCache:     sharedCache,
-		searchHandlers:  GetSearchHandlers(),
+		searchHandlers:  GetSearchHandlers(repoStore, gitserver.NewClient()),
 		logger:          log.Scoped("insights.queryRunner.Handler", ""),
 	}, options)
 }
@@ -262,6 +262,29 @@ func MultiRepoQuery(query BasicQuery, repos []string, defaultParams searchquery.
 	return modified, nil
 }
 
+// ScriptScopeQuery generates the query that scopes a job of a Lua-scripted series to the provided repositories. Scripted
+// series don't run this query as a search, it only identifies the repositories (and, for a single repository, the
+// revision) the script runs against. Repositories should be provided in plain text.
+func ScriptScopeQuery(repos []string, revision string) BasicQuery {
+	if len(repos) == 1 && revision != "" {
+		return BasicQuery(strings.TrimSpace(string(forRepoRevision("", repos[0], revision))))
+	}
+	return BasicQuery(strings.TrimSpace(string(forRepos("", repos))))
+}
+
+// ScriptScopeRepositories returns the repository filters of a query generated by ScriptScopeQuery.
+func ScriptScopeRepositories(query BasicQuery) ([]searchquery.ParsedRepoFilter, error) {
+	planSteps, err := searchquery.Pipeline(searchquery.Init(string(query), searchquery.SearchTypeLiteral))
+	if err != nil {
+		return nil, err
+	}
+	if len(planSteps) != 1 {
+		return nil, QueryNotSupported
+	}
+	repoFilters, _ := planSteps[0].Repositories()
+	return repoFilters, nil
+}
+
 type MapType string
 
 const (
@@ -229,6 +229,68 @@ func TestMultiRepoQuery(t *testing.T) {
 	}
 }
 
+func TestScriptScopeQuery(t *testing.T) {
+	tests := []struct {
+		name      string
+		repos     []string
+		revision  string
+		want      string
+		wantRevs  []string
+		matches   []string
+		unmatched []string
+	}{
+		{
+			name:      "single repo at revision",
+			repos:     []string{"github.com/myrepos/repo1"},
+			revision:  "abc123",
+			want:      `repo:^github\.com/myrepos/repo1$@abc123`,
+			wantRevs:  []string{"abc123"},
+			matches:   []string{"github.com/myrepos/repo1"},
+			unmatched: []string{"github.com/myrepos/repo10"},
+		},
+		{
+			name:      "multiple repos",
+			repos:     []string{"github.com/myrepos/repo1", "github.com/myrepos/repo2"},
+			want:      `repo:^(github\.com/myrepos/repo1|github\.com/myrepos/repo2)$`,
+			matches:   []string{"github.com/myrepos/repo1", "github.com/myrepos/repo2"},
+			unmatched: []string{"github.com/myrepos/repo3"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := ScriptScopeQuery(test.repos, test.revision)
+			if diff := cmp.Diff(test.want, string(got)); diff != "" {
+				t.Fatalf("unexpected query (want/got): %s", diff)
+			}
+
+			filters, err := ScriptScopeRepositories(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(filters) != 1 {
+				t.Fatalf("expected a single repo filter, got %d", len(filters))
+			}
+			var revs []string
+			for _, rev := range filters[0].Revs {
+				revs = append(revs, rev.RevSpec)
+			}
+			if diff := cmp.Diff(test.wantRevs, revs); diff != "" {
+				t.Errorf("unexpected revisions (want/got): %s", diff)
+			}
+			for _, repo := range test.matches {
+				if !filters[0].RepoRegex.MatchString(repo) {
+					t.Errorf("expected %q to match", repo)
+				}
+			}
+			for _, repo := range test.unmatched {
+				if filters[0].RepoRegex.MatchString(repo) {
+					t.Errorf("expected %q not to match", repo)
+				}
+			}
+		})
+	}
+}
+
 func TestDefaults(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -174,6 +174,14 @@ func (h *newBackfillHandler) Handle(ctx context.Context, logger log.Logger, job
 }
 
 func parseQuery(series types.InsightSeries) (query.Plan, error) {
+	if series.GenerationMethod == types.Script {
+		// Scripts don't run a search, so their cost is estimated from the repositories they are scoped to.
+		plan, err := querybuilder.ParseQuery(querybuilder.ScriptScopeQuery(series.Repositories, "").String(), "literal")
+		if err != nil {
+			return nil, errors.Wrap(err, "ParseQuery")
+		}
+		return plan, nil
+	}
 	if series.GeneratedFromCaptureGroups {
 		seriesQuery, err := compute.Parse(series.Query)
 		if err != nil {
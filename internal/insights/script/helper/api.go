@@ -0,0 +1,86 @@
+package helper
+
+import (
+	lua "github.com/yuin/gopher-lua"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/luasandbox/util"
+)
+
+// invocation holds the state of a single script run. Its Lua API only grants read access to the
+// repository and commit the script runs against.
+type invocation struct {
+	source *remoteSource
+	repo   api.RepoName
+	commit api.CommitID
+
+	// remainingBytes is the number of bytes of file contents the script may still read.
+	remainingBytes int
+}
+
+func (i *invocation) LuaAPI() map[string]lua.LGFunction {
+	return map[string]lua.LGFunction{
+		// repository() returns the name of the repository the script runs against.
+		"repository": util.WrapLuaFunction(func(state *lua.LState) error {
+			state.Push(lua.LString(i.repo))
+			return nil
+		}),
+		// commit() returns the commit the script runs against.
+		"commit": util.WrapLuaFunction(func(state *lua.LState) error {
+			state.Push(lua.LString(i.commit))
+			return nil
+		}),
+		// files(glob?) returns the paths of the files in the repository, optionally filtered by
+		// a glob pattern such as "**/*.go".
+		"files": util.WrapLuaFunction(func(state *lua.LState) error {
+			var pathspecs []gitdomain.Pathspec
+			if pattern := state.OptString(1, ""); pattern != "" {
+				pathspecs = append(pathspecs, gitdomain.Pathspec(":(glob)"+pattern))
+			}
+
+			paths, err := i.source.Files(state.Context(), pathspecs)
+			if err != nil {
+				return err
+			}
+
+			table := state.CreateTable(len(paths), 0)
+			for _, path := range paths {
+				table.Append(lua.LString(path))
+			}
+			state.Push(table)
+			return nil
+		}),
+		// read_file(path) returns the contents of the file at the given path, or nil and an error
+		// message if the file cannot be read.
+		"read_file": util.WrapSoftFailingLuaFunction(func(state *lua.LState) error {
+			path := state.CheckString(1)
+
+			contents, err := i.source.ReadFile(state.Context(), path, i.remainingBytes+1)
+			if err != nil {
+				return err
+			}
+			if len(contents) > i.remainingBytes {
+				state.RaiseError("insight script exceeded the limit on the size of file contents it may read")
+				return nil
+			}
+			i.remainingBytes -= len(contents)
+
+			state.Push(lua.LString(contents))
+			return nil
+		}),
+		// search(query) returns the number of results of the given search query in the repository
+		// at the commit the script runs against.
+		"search": util.WrapLuaFunction(func(state *lua.LState) error {
+			query := state.CheckString(1)
+
+			count, err := i.source.Search(state.Context(), query)
+			if err != nil {
+				return err
+			}
+
+			state.Push(lua.LNumber(count))
+			return nil
+		}),
+	}
+}
@@ -0,0 +1,212 @@
+// Package helper runs a single Lua script of a scripted code insight series in the insights-script
+// helper process. The process receives a Request on its stdin, and then sends Calls on its stdout to
+// access the repository, each answered by a Reply, until it reports the result of the run with a "done"
+// call. It only depends on the Lua sandbox, so that starting it is cheap.
+package helper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	lua "github.com/yuin/gopher-lua"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/luasandbox"
+	"github.com/sourcegraph/sourcegraph/internal/luasandbox/util"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	// ModuleName is the name scripts require to access the repository they run against.
+	ModuleName = "sg.insights"
+
+	// callStackSize and registryMaxSize bound the depth of function calls and the number of slots of
+	// the data stack of the Lua VM. They do not bound the memory held by strings and tables, which is
+	// bounded by Limits.MaxMemoryBytes instead.
+	callStackSize   = 200
+	registryMaxSize = 256 * 1024
+
+	// maxDatapoints is the maximum number of labelled values a single script run may return.
+	maxDatapoints = 1000
+
+	// ExitCodeMemoryLimit is the exit code of a helper process that exceeded its memory limit.
+	ExitCodeMemoryLimit = 3
+
+	// ExitCodeCPULimit is the exit code of a helper process that exceeded its CPU limit.
+	ExitCodeCPULimit = 4
+)
+
+// Datapoint is a labelled value returned by a script.
+type Datapoint struct {
+	Label string
+	Value float64
+}
+
+// Limits bounds the resources a single script run may use.
+type Limits struct {
+	// Timeout bounds the time a script may run, including the time spent waiting on gitserver and search.
+	Timeout time.Duration
+	// MaxReadBytes bounds the total size of file contents a script may read.
+	MaxReadBytes int
+	// MaxCPUTime bounds the CPU time of the process a script runs in, rounded up to whole seconds.
+	// It is only enforced on Linux.
+	MaxCPUTime time.Duration
+	// MaxMemoryBytes bounds the heap of the process a script runs in.
+	MaxMemoryBytes int
+}
+
+// Request is the script run requested from the helper process.
+type Request struct {
+	Script string
+	Repo   api.RepoName
+	Commit api.CommitID
+	Limits Limits
+}
+
+// Call is a call of the helper process to the source of the run, or the result of the run if Method
+// is "done".
+type Call struct {
+	Method    string
+	Pathspecs []gitdomain.Pathspec `json:",omitempty"`
+	Path      string               `json:",omitempty"`
+	MaxBytes  int                  `json:",omitempty"`
+	Query     string               `json:",omitempty"`
+
+	Datapoints []Datapoint `json:",omitempty"`
+	Error      string      `json:",omitempty"`
+}
+
+// Reply is the reply of the process that started the helper process to a Call.
+type Reply struct {
+	Files    []string `json:",omitempty"`
+	Contents []byte   `json:",omitempty"`
+	Count    int      `json:",omitempty"`
+	Error    string   `json:",omitempty"`
+}
+
+// Run runs the script requested on stdin and returns the exit code of the helper process.
+func Run(stdin io.Reader, stdout, stderr io.Writer) int {
+	enc := json.NewEncoder(stdout)
+	dec := json.NewDecoder(stdin)
+
+	var req Request
+	if err := dec.Decode(&req); err != nil {
+		fmt.Fprintf(stderr, "reading request: %s\n", err)
+		return 1
+	}
+	limitCPU(req.Limits.MaxCPUTime, stderr)
+	limitMemory(req.Limits.MaxMemoryBytes, stderr)
+
+	src := &remoteSource{enc: enc, dec: dec}
+	datapoints, err := run(context.Background(), luasandbox.NewService(), src, req)
+
+	done := Call{Method: "done", Datapoints: datapoints}
+	if err != nil {
+		done.Error = err.Error()
+	}
+	if err := enc.Encode(done); err != nil {
+		fmt.Fprintf(stderr, "sending result: %s\n", err)
+		return 1
+	}
+	return 0
+}
+
+// run runs the requested script in a fresh Lua sandbox of the given service.
+func run(ctx context.Context, sandboxService *luasandbox.Service, src *remoteSource, req Request) ([]Datapoint, error) {
+	inv := &invocation{
+		source:         src,
+		repo:           req.Repo,
+		commit:         req.Commit,
+		remainingBytes: req.Limits.MaxReadBytes,
+	}
+
+	sandbox, err := sandboxService.CreateSandbox(ctx, luasandbox.CreateOptions{
+		GoModules:       map[string]lua.LGFunction{ModuleName: util.CreateModule(inv.LuaAPI())},
+		CallStackSize:   callStackSize,
+		RegistryMaxSize: registryMaxSize,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "CreateSandbox")
+	}
+	defer sandbox.Close()
+
+	value, err := sandbox.RunScript(ctx, luasandbox.RunOptions{Timeout: req.Limits.Timeout}, req.Script)
+	if err != nil {
+		return nil, errors.Wrap(err, "running insight script")
+	}
+	return scanDatapoints(value)
+}
+
+// scanDatapoints converts the value returned by a script into data points. Scripts must return a table
+// mapping labels to numbers.
+func scanDatapoints(value lua.LValue) ([]Datapoint, error) {
+	table, ok := value.(*lua.LTable)
+	if !ok {
+		return nil, errors.Newf("insight script must return a table of labelled numbers, got %s", value.Type())
+	}
+
+	var datapoints []Datapoint
+	err := util.ForEach(table, func(key, value lua.LValue) error {
+		label, ok := key.(lua.LString)
+		if !ok || label == "" {
+			return errors.Newf("insight script returned a value with an invalid label %s", key)
+		}
+		number, ok := value.(lua.LNumber)
+		if !ok {
+			return errors.Newf("insight script returned a non-numeric value for label %q", label)
+		}
+		if len(datapoints) == maxDatapoints {
+			return errors.Newf("insight script returned more than %d values", maxDatapoints)
+		}
+		datapoints = append(datapoints, Datapoint{Label: string(label), Value: float64(number)})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(datapoints, func(i, j int) bool { return datapoints[i].Label < datapoints[j].Label })
+	return datapoints, nil
+}
+
+// remoteSource is the read access to the repository of a script run, which forwards its calls to the
+// process that started the helper process.
+type remoteSource struct {
+	enc *json.Encoder
+	dec *json.Decoder
+}
+
+func (s *remoteSource) Files(_ context.Context, pathspecs []gitdomain.Pathspec) ([]string, error) {
+	reply, err := s.call(Call{Method: "files", Pathspecs: pathspecs})
+	return reply.Files, err
+}
+
+func (s *remoteSource) ReadFile(_ context.Context, path string, maxBytes int) ([]byte, error) {
+	reply, err := s.call(Call{Method: "read_file", Path: path, MaxBytes: maxBytes})
+	return reply.Contents, err
+}
+
+// Search returns the number of results of the given search query, which the process that started
+// the helper process scopes to the repository and commit of the run.
+func (s *remoteSource) Search(_ context.Context, query string) (int, error) {
+	reply, err := s.call(Call{Method: "search", Query: query})
+	return reply.Count, err
+}
+
+func (s *remoteSource) call(call Call) (reply Reply, err error) {
+	if err := s.enc.Encode(call); err != nil {
+		return reply, err
+	}
+	if err := s.dec.Decode(&reply); err != nil {
+		return reply, err
+	}
+	if reply.Error != "" {
+		return reply, errors.New(reply.Error)
+	}
+	return reply, nil
+}
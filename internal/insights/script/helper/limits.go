@@ -0,0 +1,59 @@
+package helper
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"runtime/metrics"
+	"time"
+)
+
+const (
+	// memoryCheckInterval is the interval at which the helper process checks the size of its heap.
+	memoryCheckInterval = 10 * time.Millisecond
+
+	// dataLimitHeadroom is added to the memory limit of the helper process when limiting the size of its
+	// data segment, to account for the binary and the Go runtime. It only stops allocations that grow
+	// faster than the heap is checked.
+	dataLimitHeadroom = 1024 * 1024 * 1024
+)
+
+// limitCPU bounds the CPU time of the helper process to maxTime, rounded up to whole seconds. The
+// helper process exits with ExitCodeCPULimit once it exceeds the limit.
+func limitCPU(maxTime time.Duration, stderr io.Writer) {
+	if maxTime <= 0 {
+		return
+	}
+	setCPULimit(uint64((maxTime+time.Second-1)/time.Second), stderr)
+}
+
+// limitMemory bounds the heap of the helper process to maxBytes. The helper process exits with
+// ExitCodeMemoryLimit once its live heap exceeds the limit.
+func limitMemory(maxBytes int, stderr io.Writer) {
+	debug.SetMemoryLimit(int64(maxBytes))
+	setDataLimit(uint64(maxBytes) + dataLimitHeadroom)
+
+	go func() {
+		samples := []metrics.Sample{{Name: "/memory/classes/heap/objects:bytes"}}
+		exceeded := func() bool {
+			metrics.Read(samples)
+			return samples[0].Value.Uint64() > uint64(maxBytes)
+		}
+
+		for range time.Tick(memoryCheckInterval) {
+			if !exceeded() {
+				continue
+			}
+
+			// The heap also holds unreachable objects until the next collection, so only exit
+			// if the heap still exceeds the limit after one.
+			runtime.GC()
+			if exceeded() {
+				fmt.Fprintf(stderr, "insight script exceeded the memory limit of %d bytes\n", maxBytes)
+				os.Exit(ExitCodeMemoryLimit)
+			}
+		}
+	}()
+}
@@ -0,0 +1,30 @@
+package helper
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// setDataLimit limits the size of the data segment of the process, which includes the heap. The Go
+// runtime crashes with an out of memory error once it can't grow the heap any further.
+func setDataLimit(maxBytes uint64) {
+	_ = syscall.Setrlimit(syscall.RLIMIT_DATA, &syscall.Rlimit{Cur: maxBytes, Max: maxBytes})
+}
+
+// setCPULimit limits the CPU time of the process. The kernel sends SIGXCPU once the process used
+// maxSeconds of CPU time, upon which it exits with ExitCodeCPULimit, and kills it a second later if
+// it did not.
+func setCPULimit(maxSeconds uint64, stderr io.Writer) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGXCPU)
+	go func() {
+		<-signals
+		fmt.Fprintf(stderr, "insight script exceeded the CPU limit of %ds\n", maxSeconds)
+		os.Exit(ExitCodeCPULimit)
+	}()
+
+	_ = syscall.Setrlimit(syscall.RLIMIT_CPU, &syscall.Rlimit{Cur: maxSeconds, Max: maxSeconds + 1})
+}
@@ -0,0 +1,13 @@
+//go:build !linux
+
+package helper
+
+import "io"
+
+// setDataLimit is a no-op on platforms other than Linux, where the memory of the helper process is only
+// bounded by checking the size of its heap.
+func setDataLimit(maxBytes uint64) {}
+
+// setCPULimit is a no-op on platforms other than Linux, where the helper process is only bounded by the
+// timeout of the script.
+func setCPULimit(maxSeconds uint64, stderr io.Writer) {}
@@ -0,0 +1,103 @@
+// Package script runs Lua scripts that generate data points for code insights series with the script
+// generation method.
+package script
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	luaParse "github.com/yuin/gopher-lua/parse"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/insights/script/helper"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	defaultTimeout        = 10 * time.Second
+	defaultMaxReadBytes   = 64 * 1024 * 1024
+	defaultMaxCPUTime     = 5 * time.Second
+	defaultMaxMemoryBytes = 256 * 1024 * 1024
+)
+
+// Datapoint is a labelled value returned by a script.
+type Datapoint = helper.Datapoint
+
+// Limits bounds the resources a single script run may use. MaxCPUTime defaults to 5 seconds and
+// MaxMemoryBytes to 256 MiB.
+type Limits = helper.Limits
+
+// LimitsFromConfig returns the limits configured in the site configuration.
+func LimitsFromConfig() Limits {
+	limits := Limits{Timeout: defaultTimeout, MaxReadBytes: defaultMaxReadBytes, MaxCPUTime: defaultMaxCPUTime, MaxMemoryBytes: defaultMaxMemoryBytes}
+	siteConfig := conf.Get()
+	if siteConfig.InsightsScriptTimeoutSeconds > 0 {
+		limits.Timeout = time.Duration(siteConfig.InsightsScriptTimeoutSeconds) * time.Second
+	}
+	if siteConfig.InsightsScriptMaxReadBytes > 0 {
+		limits.MaxReadBytes = siteConfig.InsightsScriptMaxReadBytes
+	}
+	if siteConfig.InsightsScriptMaxCPUSeconds > 0 {
+		limits.MaxCPUTime = time.Duration(siteConfig.InsightsScriptMaxCPUSeconds) * time.Second
+	}
+	if siteConfig.InsightsScriptMaxMemoryBytes > 0 {
+		limits.MaxMemoryBytes = siteConfig.InsightsScriptMaxMemoryBytes
+	}
+	return limits
+}
+
+// SearchFunc returns the number of results of the given search query.
+type SearchFunc func(ctx context.Context, query string) (int, error)
+
+// Runner runs scripts against a repository at a commit.
+type Runner struct {
+	gitserverClient gitserver.Client
+	search          SearchFunc
+}
+
+func NewRunner(gitserverClient gitserver.Client, search SearchFunc) *Runner {
+	return &Runner{
+		gitserverClient: gitserverClient,
+		search:          search,
+	}
+}
+
+// Validate returns an error if the given script is not valid Lua.
+func Validate(script string) error {
+	if strings.TrimSpace(script) == "" {
+		return errors.New("script is empty")
+	}
+	if _, err := luaParse.Parse(strings.NewReader(script), "(insight script)"); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Run runs the given script in a fresh Lua sandbox with read access to the given repository at the given
+// commit, and returns the labelled values the script returned sorted by label. The sandbox runs in the
+// insights-script helper process so that the CPU time and memory the script uses can be bounded without
+// affecting the caller.
+func (r *Runner) Run(ctx context.Context, script string, repo api.RepoName, commit api.CommitID, limits Limits) ([]Datapoint, error) {
+	if limits.MaxCPUTime <= 0 {
+		limits.MaxCPUTime = defaultMaxCPUTime
+	}
+	if limits.MaxMemoryBytes <= 0 {
+		limits.MaxMemoryBytes = defaultMaxMemoryBytes
+	}
+
+	src := &gitserverSource{
+		gitserverClient: r.gitserverClient,
+		search:          r.search,
+		repo:            repo,
+		commit:          commit,
+	}
+	return runSubprocess(ctx, src, helper.Request{
+		Script: script,
+		Repo:   repo,
+		Commit: commit,
+		Limits: limits,
+	})
+}
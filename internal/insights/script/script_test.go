@@ -0,0 +1,173 @@
+package script
+
+import (
+	"context"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/insights/script/helper"
+)
+
+// testHelperEnvVar makes the test binary act as the insights-script helper, so that scripts run in a
+// subprocess of the test binary.
+const testHelperEnvVar = "INSIGHTS_SCRIPT_TEST_HELPER"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(testHelperEnvVar) != "" {
+		os.Exit(helper.Run(os.Stdin, os.Stdout, os.Stderr))
+	}
+
+	os.Setenv(testHelperEnvVar, "1")
+	lookupHelper = os.Executable
+	os.Exit(m.Run())
+}
+
+func TestRun(t *testing.T) {
+	ctx := context.Background()
+	files := map[string]string{
+		"README.md":    "# hello",
+		"cmd/main.go":  "package main\n// TODO: remove\n",
+		"lib/a.go":     "package lib\n// TODO: one\n// TODO: two\n",
+		"lib/large.go": strings.Repeat("x", 1024),
+	}
+
+	gitserverClient := gitserver.NewMockClient()
+	gitserverClient.LsFilesFunc.SetDefaultHook(func(_ context.Context, _ authz.SubRepoPermissionChecker, _ api.RepoName, _ api.CommitID, pathspecs ...gitdomain.Pathspec) ([]string, error) {
+		var paths []string
+		for path := range files {
+			if len(pathspecs) == 0 || (pathspecs[0] == ":(glob)**/*.go" && strings.HasSuffix(path, ".go")) {
+				paths = append(paths, path)
+			}
+		}
+		return paths, nil
+	})
+	gitserverClient.NewFileReaderFunc.SetDefaultHook(func(_ context.Context, _ authz.SubRepoPermissionChecker, _ api.RepoName, _ api.CommitID, name string) (io.ReadCloser, error) {
+		contents, ok := files[name]
+		if !ok {
+			return nil, os.ErrNotExist
+		}
+		return io.NopCloser(strings.NewReader(contents)), nil
+	})
+
+	var searchQueries []string
+	search := func(_ context.Context, query string) (int, error) {
+		searchQueries = append(searchQueries, query)
+		return 7, nil
+	}
+
+	runner := NewRunner(gitserverClient, search)
+	limits := Limits{Timeout: time.Second, MaxReadBytes: 512}
+
+	t.Run("labelled values", func(t *testing.T) {
+		script := `
+			local insights = require("sg.insights")
+			local todos = 0
+			for _, path in ipairs(insights.files("**/*.go")) do
+				if path ~= "lib/large.go" then
+					local contents = insights.read_file(path)
+					for _ in string.gmatch(contents, "TODO") do
+						todos = todos + 1
+					end
+				end
+			end
+			local _, err = insights.read_file("missing.go")
+			return {
+				todos = todos,
+				files = #insights.files(),
+				matches = insights.search("lang:go TODO"),
+				missing = err and 1 or 0,
+			}
+		`
+		datapoints, err := runner.Run(ctx, script, "github.com/sourcegraph/sourcegraph", "deadbeef", limits)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		want := []Datapoint{
+			{Label: "files", Value: 4},
+			{Label: "matches", Value: 7},
+			{Label: "missing", Value: 1},
+			{Label: "todos", Value: 3},
+		}
+		if diff := cmp.Diff(want, datapoints); diff != "" {
+			t.Errorf("unexpected datapoints (-want +got):\n%s", diff)
+		}
+		if len(searchQueries) != 1 || !strings.Contains(searchQueries[0], `repo:^github\.com/sourcegraph/sourcegraph$@deadbeef`) {
+			t.Errorf("expected search to be scoped to the repository and commit, got %v", searchQueries)
+		}
+	})
+
+	t.Run("read limit", func(t *testing.T) {
+		script := `
+			local insights = require("sg.insights")
+			return { size = #insights.read_file("lib/large.go") }
+		`
+		if _, err := runner.Run(ctx, script, "github.com/sourcegraph/sourcegraph", "deadbeef", limits); err == nil || !strings.Contains(err.Error(), "exceeded the limit") {
+			t.Fatalf("expected read limit error, got %v", err)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		script := `
+			while true do end
+		`
+		if _, err := runner.Run(ctx, script, "github.com/sourcegraph/sourcegraph", "deadbeef", Limits{Timeout: time.Millisecond}); err == nil || !strings.Contains(err.Error(), context.DeadlineExceeded.Error()) {
+			t.Fatalf("expected timeout error, got %v", err)
+		}
+	})
+
+	t.Run("cpu limit", func(t *testing.T) {
+		if runtime.GOOS != "linux" {
+			t.Skip("the CPU limit is only enforced on Linux")
+		}
+
+		script := `
+			while true do end
+		`
+		cpuLimits := Limits{Timeout: 10 * time.Second, MaxCPUTime: time.Second}
+		if _, err := runner.Run(ctx, script, "github.com/sourcegraph/sourcegraph", "deadbeef", cpuLimits); err == nil || !strings.Contains(err.Error(), "exceeded the CPU limit") {
+			t.Fatalf("expected CPU limit error, got %v", err)
+		}
+	})
+
+	t.Run("memory limit", func(t *testing.T) {
+		script := `
+			local chunks = {}
+			while true do
+				table.insert(chunks, string.rep("x", 1024 * 1024) .. #chunks)
+			end
+		`
+		memoryLimits := Limits{Timeout: 10 * time.Second, MaxMemoryBytes: 64 * 1024 * 1024}
+		if _, err := runner.Run(ctx, script, "github.com/sourcegraph/sourcegraph", "deadbeef", memoryLimits); err == nil || !strings.Contains(err.Error(), "exceeded the memory limit") {
+			t.Fatalf("expected memory limit error, got %v", err)
+		}
+	})
+
+	t.Run("invalid return value", func(t *testing.T) {
+		for _, script := range []string{`return 42`, `return { 1, 2 }`, `return { total = "many" }`} {
+			if _, err := runner.Run(ctx, script, "github.com/sourcegraph/sourcegraph", "deadbeef", limits); err == nil {
+				t.Errorf("expected error for script %q", script)
+			}
+		}
+	})
+}
+
+func TestValidate(t *testing.T) {
+	if err := Validate(`return { total = 1 }`); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	for _, script := range []string{"", "return {", "local = 1"} {
+		if err := Validate(script); err == nil {
+			t.Errorf("expected error for script %q", script)
+		}
+	}
+}
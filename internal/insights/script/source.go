@@ -0,0 +1,53 @@
+package script
+
+import (
+	"context"
+	"io"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/insights/query/querybuilder"
+)
+
+// source is the read access to a repository at a commit that a script run is granted. Scripts run in a
+// helper process, which reaches the repository through the process that started it.
+type source interface {
+	// Files returns the paths of the files matching the given pathspecs.
+	Files(ctx context.Context, pathspecs []gitdomain.Pathspec) ([]string, error)
+	// ReadFile returns at most maxBytes bytes of the contents of the file at the given path.
+	ReadFile(ctx context.Context, path string, maxBytes int) ([]byte, error)
+	// Search returns the number of results of the given search query in the repository at the commit.
+	Search(ctx context.Context, query string) (int, error)
+}
+
+// gitserverSource is the source of a script run backed by gitserver and search.
+type gitserverSource struct {
+	gitserverClient gitserver.Client
+	search          SearchFunc
+	repo            api.RepoName
+	commit          api.CommitID
+}
+
+func (s *gitserverSource) Files(ctx context.Context, pathspecs []gitdomain.Pathspec) ([]string, error) {
+	return s.gitserverClient.LsFiles(ctx, authz.DefaultSubRepoPermsChecker, s.repo, s.commit, pathspecs...)
+}
+
+func (s *gitserverSource) ReadFile(ctx context.Context, path string, maxBytes int) ([]byte, error) {
+	rc, err := s.gitserverClient.NewFileReader(ctx, authz.DefaultSubRepoPermsChecker, s.repo, s.commit, path)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	return io.ReadAll(io.LimitReader(rc, int64(maxBytes)))
+}
+
+func (s *gitserverSource) Search(ctx context.Context, query string) (int, error) {
+	scopedQuery, err := querybuilder.SingleRepoQuery(querybuilder.BasicQuery(query), string(s.repo), string(s.commit), querybuilder.CodeInsightsQueryDefaults(false))
+	if err != nil {
+		return 0, err
+	}
+	return s.search(ctx, scopedQuery.String())
+}
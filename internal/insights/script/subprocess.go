@@ -0,0 +1,148 @@
+package script
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/insights/script/helper"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Scripts run in a subprocess of the helperName binary, which runs them with helper.Run. Encoding errors
+// and crashes of the subprocess, including exceeding its CPU or memory limit, fail the run without
+// affecting the process that started it.
+const helperName = "insights-script"
+
+const (
+	// subprocessGracePeriod is the time the subprocess is given on top of the timeout of the script
+	// to start and report its result before it is killed.
+	subprocessGracePeriod = 5 * time.Second
+
+	// maxStderrBytes bounds the output of the subprocess included in errors.
+	maxStderrBytes = 4096
+)
+
+// lookupHelper returns the path of the helperName binary, which is shipped next to the binary of the
+// worker, or is otherwise found in the PATH.
+var lookupHelper = func() (string, error) {
+	if executable, err := os.Executable(); err == nil {
+		path := filepath.Join(filepath.Dir(executable), helperName)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return exec.LookPath(helperName)
+}
+
+// runSubprocess runs the requested script in a subprocess, serving its calls to the given source.
+func runSubprocess(ctx context.Context, src source, req helper.Request) ([]Datapoint, error) {
+	path, err := lookupHelper()
+	if err != nil {
+		return nil, errors.Wrap(err, "finding insight script helper")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, req.Limits.Timeout+subprocessGracePeriod)
+	defer cancel()
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, path)
+	cmd.Stderr = &limitedWriter{w: &stderr, remaining: maxStderrBytes}
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, errors.Wrap(err, "starting insight script subprocess")
+	}
+
+	datapoints, serveErr := serveChild(ctx, src, req, json.NewEncoder(stdin), json.NewDecoder(stdout))
+	stdin.Close()
+	waitErr := cmd.Wait()
+
+	var exitErr *exec.ExitError
+	if errors.As(waitErr, &exitErr) && (exitErr.ExitCode() == helper.ExitCodeMemoryLimit || bytes.Contains(stderr.Bytes(), []byte("out of memory"))) {
+		return nil, errors.Newf("insight script exceeded the memory limit of %d bytes", req.Limits.MaxMemoryBytes)
+	}
+	// The subprocess is killed once it reaches the hard CPU limit, if it did not exit on the soft one.
+	if errors.As(waitErr, &exitErr) && (exitErr.ExitCode() == helper.ExitCodeCPULimit || exitErr.UserTime()+exitErr.SystemTime() >= req.Limits.MaxCPUTime) {
+		return nil, errors.Newf("insight script exceeded the CPU limit of %s", req.Limits.MaxCPUTime)
+	}
+	if ctx.Err() != nil {
+		return nil, errors.Wrap(ctx.Err(), "running insight script")
+	}
+	if serveErr != nil {
+		return nil, serveErr
+	}
+	if waitErr != nil {
+		return nil, errors.Wrapf(waitErr, "insight script subprocess failed: %s", bytes.TrimSpace(stderr.Bytes()))
+	}
+	return datapoints, nil
+}
+
+// serveChild sends the request to the subprocess and answers its calls until it reports the result of
+// the run.
+func serveChild(ctx context.Context, src source, req helper.Request, enc *json.Encoder, dec *json.Decoder) ([]Datapoint, error) {
+	if err := enc.Encode(req); err != nil {
+		return nil, errors.Wrap(err, "sending request to insight script subprocess")
+	}
+
+	for {
+		var call helper.Call
+		if err := dec.Decode(&call); err != nil {
+			return nil, errors.Wrap(err, "reading from insight script subprocess")
+		}
+
+		var reply helper.Reply
+		var err error
+		switch call.Method {
+		case "files":
+			reply.Files, err = src.Files(ctx, call.Pathspecs)
+		case "read_file":
+			reply.Contents, err = src.ReadFile(ctx, call.Path, call.MaxBytes)
+		case "search":
+			reply.Count, err = src.Search(ctx, call.Query)
+		case "done":
+			if call.Error != "" {
+				return nil, errors.New(call.Error)
+			}
+			return call.Datapoints, nil
+		default:
+			return nil, errors.Newf("unknown call %q from insight script subprocess", call.Method)
+		}
+		if err != nil {
+			reply.Error = err.Error()
+		}
+
+		if err := enc.Encode(reply); err != nil {
+			return nil, errors.Wrap(err, "replying to insight script subprocess")
+		}
+	}
+}
+
+// limitedWriter writes at most remaining bytes to w, and discards the rest.
+type limitedWriter struct {
+	w         io.Writer
+	remaining int
+}
+
+func (w *limitedWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	if len(p) > w.remaining {
+		p = p[:w.remaining]
+	}
+	w.remaining -= len(p)
+	if _, err := w.w.Write(p); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
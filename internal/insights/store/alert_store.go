@@ -0,0 +1,366 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	edb "github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// AlertStore persists the alert rules attached to insight series, and the history of alerts fired for them.
+type AlertStore struct {
+	*basestore.Store
+	Now func() time.Time
+}
+
+// NewAlertStore returns a new AlertStore backed by the given Postgres db.
+func NewAlertStore(db edb.InsightsDB) *AlertStore {
+	return &AlertStore{Store: basestore.NewWithHandle(db.Handle()), Now: time.Now}
+}
+
+// NewAlertStoreWith returns a new AlertStore backed by the given Postgres db.
+func NewAlertStoreWith(other basestore.ShareableStore) *AlertStore {
+	return &AlertStore{Store: basestore.NewWithHandle(other.Handle()), Now: time.Now}
+}
+
+// With creates a new AlertStore with the given basestore.Shareable store as the underlying basestore.Store.
+// Needed to implement the basestore.Store interface
+func (s *AlertStore) With(other basestore.ShareableStore) *AlertStore {
+	return &AlertStore{Store: s.Store.With(other), Now: s.Now}
+}
+
+func (s *AlertStore) Transact(ctx context.Context) (*AlertStore, error) {
+	txBase, err := s.Store.Transact(ctx)
+	return &AlertStore{Store: txBase, Now: s.Now}, err
+}
+
+// CreateAlertRule stores an alert rule together with its actions.
+func (s *AlertStore) CreateAlertRule(ctx context.Context, rule types.SeriesAlertRule) (_ *types.SeriesAlertRule, err error) {
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	id, _, err := basestore.ScanFirstInt(tx.Query(ctx, sqlf.Sprintf(insertAlertRuleSql,
+		rule.SeriesID,
+		rule.Condition,
+		rule.Threshold,
+		rule.Lookback,
+		rule.Description,
+		rule.Enabled,
+		rule.CreatedBy,
+		s.Now(),
+	)))
+	if err != nil {
+		return nil, errors.Wrap(err, "CreateAlertRule")
+	}
+
+	for _, action := range rule.Actions {
+		if err := tx.Exec(ctx, sqlf.Sprintf(insertAlertActionSql,
+			id,
+			action.Type,
+			dbutil.NewNullString(action.URL),
+			dbutil.NewNullInt32(action.RecipientUserID),
+		)); err != nil {
+			return nil, errors.Wrap(err, "CreateAlertAction")
+		}
+	}
+
+	return tx.GetAlertRule(ctx, id)
+}
+
+// DeleteAlertRule deletes an alert rule and its actions. The alert history of the series is kept.
+func (s *AlertStore) DeleteAlertRule(ctx context.Context, id int) error {
+	return s.Exec(ctx, sqlf.Sprintf(deleteAlertRuleSql, id))
+}
+
+// GetAlertRule returns the alert rule with the given ID, or nil if it does not exist.
+func (s *AlertStore) GetAlertRule(ctx context.Context, id int) (*types.SeriesAlertRule, error) {
+	rules, err := s.ListAlertRules(ctx, AlertRuleQueryArgs{ID: id})
+	if err != nil || len(rules) == 0 {
+		return nil, err
+	}
+	return rules[0], nil
+}
+
+// AlertRuleQueryArgs contains query predicates for fetching alert rules.
+type AlertRuleQueryArgs struct {
+	ID          int
+	SeriesID    int
+	OnlyEnabled bool
+}
+
+// ListAlertRules returns all alert rules matching the given arguments, including their actions.
+func (s *AlertStore) ListAlertRules(ctx context.Context, args AlertRuleQueryArgs) ([]*types.SeriesAlertRule, error) {
+	preds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if args.ID != 0 {
+		preds = append(preds, sqlf.Sprintf("id = %s", args.ID))
+	}
+	if args.SeriesID != 0 {
+		preds = append(preds, sqlf.Sprintf("series_id = %s", args.SeriesID))
+	}
+	if args.OnlyEnabled {
+		preds = append(preds, sqlf.Sprintf("enabled"))
+	}
+
+	rules, err := scanAlertRules(s.Query(ctx, sqlf.Sprintf(listAlertRulesSql, sqlf.Join(preds, "AND"))))
+	if err != nil || len(rules) == 0 {
+		return rules, err
+	}
+
+	ids := make([]int, 0, len(rules))
+	byID := make(map[int]*types.SeriesAlertRule, len(rules))
+	for _, rule := range rules {
+		ids = append(ids, rule.ID)
+		byID[rule.ID] = rule
+	}
+	actions, err := scanAlertActions(s.Query(ctx, sqlf.Sprintf(listAlertActionsSql, sqlf.Sprintf("rule_id = ANY(%s)", pq.Array(ids)))))
+	if err != nil {
+		return nil, err
+	}
+	for _, action := range actions {
+		byID[action.RuleID].Actions = append(byID[action.RuleID].Actions, *action)
+	}
+	return rules, nil
+}
+
+// GetAlertAction returns the alert action with the given ID, or nil if it does not exist.
+func (s *AlertStore) GetAlertAction(ctx context.Context, id int) (*types.SeriesAlertAction, error) {
+	actions, err := scanAlertActions(s.Query(ctx, sqlf.Sprintf(listAlertActionsSql, sqlf.Sprintf("id = %s", id))))
+	if err != nil || len(actions) == 0 {
+		return nil, err
+	}
+	return actions[0], nil
+}
+
+// CreateAlertEvent records that an alert rule fired for a point of a series, and enqueues one delivery job per
+// action of the rule. An alert fires at most once per rule, point in time and capture value, so created is false if
+// the event has already been recorded.
+func (s *AlertStore) CreateAlertEvent(ctx context.Context, event types.SeriesAlertEvent) (created bool, err error) {
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	id, ok, err := basestore.ScanFirstInt(tx.Query(ctx, sqlf.Sprintf(insertAlertEventSql,
+		event.RuleID,
+		event.SeriesID,
+		event.PointTime,
+		event.Capture,
+		event.Value,
+		event.Baseline,
+		event.Message,
+		s.Now(),
+	)))
+	if err != nil {
+		return false, errors.Wrap(err, "CreateAlertEvent")
+	}
+	if !ok {
+		return false, nil
+	}
+
+	if event.RuleID != nil {
+		if err := tx.Exec(ctx, sqlf.Sprintf(enqueueAlertJobsSql, id, *event.RuleID)); err != nil {
+			return false, errors.Wrap(err, "EnqueueAlertJobs")
+		}
+	}
+	return true, nil
+}
+
+// GetAlertEvent returns the alert event with the given ID, or nil if it does not exist.
+func (s *AlertStore) GetAlertEvent(ctx context.Context, id int) (*types.SeriesAlertEvent, error) {
+	events, err := s.ListAlertEvents(ctx, AlertEventQueryArgs{ID: id})
+	if err != nil || len(events) == 0 {
+		return nil, err
+	}
+	return events[0], nil
+}
+
+// AlertEventQueryArgs contains query predicates for fetching the alert history of a series.
+type AlertEventQueryArgs struct {
+	ID       int
+	SeriesID int
+	RuleID   int
+	Limit    int
+}
+
+// ListAlertEvents returns the alert events matching the given arguments, most recent first.
+func (s *AlertStore) ListAlertEvents(ctx context.Context, args AlertEventQueryArgs) ([]*types.SeriesAlertEvent, error) {
+	preds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if args.ID != 0 {
+		preds = append(preds, sqlf.Sprintf("id = %s", args.ID))
+	}
+	if args.SeriesID != 0 {
+		preds = append(preds, sqlf.Sprintf("series_id = %s", args.SeriesID))
+	}
+	if args.RuleID != 0 {
+		preds = append(preds, sqlf.Sprintf("rule_id = %s", args.RuleID))
+	}
+	limitClause := sqlf.Sprintf("")
+	if args.Limit > 0 {
+		limitClause = sqlf.Sprintf("LIMIT %s", args.Limit)
+	}
+	return scanAlertEvents(s.Query(ctx, sqlf.Sprintf(listAlertEventsSql, sqlf.Join(preds, "AND"), limitClause)))
+}
+
+// ListRecordingTimes returns up to limit of the most recent times at which the series was recorded, excluding
+// snapshots, that are not after to. Times are truncated to seconds to match series points, and sorted oldest first.
+func (s *AlertStore) ListRecordingTimes(ctx context.Context, seriesID int, to time.Time, limit int) (_ []time.Time, err error) {
+	rows, err := s.Query(ctx, sqlf.Sprintf(listRecordingTimesSql, seriesID, to.UTC(), limit))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var times []time.Time
+	for rows.Next() {
+		var t time.Time
+		if err := rows.Scan(&t); err != nil {
+			return nil, err
+		}
+		times = append([]time.Time{t}, times...)
+	}
+	return times, nil
+}
+
+func scanAlertRules(rows *sql.Rows, queryErr error) (_ []*types.SeriesAlertRule, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var results []*types.SeriesAlertRule
+	for rows.Next() {
+		var temp types.SeriesAlertRule
+		if err := rows.Scan(
+			&temp.ID,
+			&temp.SeriesID,
+			&temp.Condition,
+			&temp.Threshold,
+			&temp.Lookback,
+			&temp.Description,
+			&temp.Enabled,
+			&temp.CreatedBy,
+			&temp.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		results = append(results, &temp)
+	}
+	return results, nil
+}
+
+func scanAlertActions(rows *sql.Rows, queryErr error) (_ []*types.SeriesAlertAction, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var results []*types.SeriesAlertAction
+	for rows.Next() {
+		var temp types.SeriesAlertAction
+		if err := rows.Scan(
+			&temp.ID,
+			&temp.RuleID,
+			&temp.Type,
+			&dbutil.NullString{S: &temp.URL},
+			&dbutil.NullInt32{N: &temp.RecipientUserID},
+		); err != nil {
+			return nil, err
+		}
+		results = append(results, &temp)
+	}
+	return results, nil
+}
+
+func scanAlertEvents(rows *sql.Rows, queryErr error) (_ []*types.SeriesAlertEvent, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var results []*types.SeriesAlertEvent
+	for rows.Next() {
+		var temp types.SeriesAlertEvent
+		if err := rows.Scan(
+			&temp.ID,
+			&temp.RuleID,
+			&temp.SeriesID,
+			&temp.PointTime,
+			&temp.Capture,
+			&temp.Value,
+			&temp.Baseline,
+			&temp.Message,
+			&temp.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		results = append(results, &temp)
+	}
+	return results, nil
+}
+
+const insertAlertRuleSql = `
+INSERT INTO insight_series_alert_rules (series_id, condition, threshold, lookback, description, enabled, created_by, created_at)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s)
+RETURNING id;
+`
+
+const insertAlertActionSql = `
+INSERT INTO insight_series_alert_actions (rule_id, type, url, recipient_user_id) VALUES (%s, %s, %s, %s);
+`
+
+const deleteAlertRuleSql = `
+DELETE FROM insight_series_alert_rules WHERE id = %s;
+`
+
+const listAlertRulesSql = `
+SELECT id, series_id, condition, threshold, lookback, description, enabled, created_by, created_at
+FROM insight_series_alert_rules
+WHERE %s
+ORDER BY id;
+`
+
+const listAlertActionsSql = `
+SELECT id, rule_id, type, url, recipient_user_id
+FROM insight_series_alert_actions
+WHERE %s
+ORDER BY id;
+`
+
+const insertAlertEventSql = `
+INSERT INTO insight_series_alert_events (rule_id, series_id, point_time, capture, value, baseline, message, created_at)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s)
+ON CONFLICT (rule_id, point_time, COALESCE(capture, '')) DO NOTHING
+RETURNING id;
+`
+
+const enqueueAlertJobsSql = `
+INSERT INTO insight_series_alert_jobs (event_id, action_id)
+SELECT %s, id FROM insight_series_alert_actions WHERE rule_id = %s;
+`
+
+const listRecordingTimesSql = `
+SELECT date_trunc('seconds', recording_time) FROM insight_series_recording_times
+WHERE insight_series_id = %s AND snapshot IS FALSE AND recording_time <= %s
+ORDER BY recording_time DESC
+LIMIT %s;
+`
+
+const listAlertEventsSql = `
+SELECT id, rule_id, series_id, point_time, capture, value, baseline, message, created_at
+FROM insight_series_alert_events
+WHERE %s
+ORDER BY point_time DESC, id DESC
+%s;
+`
@@ -0,0 +1,140 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/log/logtest"
+
+	edb "github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+)
+
+func TestAlertStore(t *testing.T) {
+	logger := logtest.Scoped(t)
+	insightsDB := edb.NewInsightsDB(dbtest.NewInsightsDB(logger, t), logger)
+	now := time.Date(2021, 5, 1, 1, 0, 0, 0, time.UTC).Truncate(time.Microsecond).Round(0)
+	ctx := context.Background()
+	equateTimes := cmpopts.EquateApproxTime(0)
+
+	insightStore := NewInsightStore(insightsDB)
+	insightStore.Now = func() time.Time { return now }
+	series, err := insightStore.CreateSeries(ctx, types.InsightSeries{
+		SeriesID:           "series-1",
+		Query:              "TODO",
+		SampleIntervalUnit: string(types.Week),
+		GenerationMethod:   types.Search,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := NewAlertStoreWith(insightStore)
+	store.Now = func() time.Time { return now }
+
+	rule, err := store.CreateAlertRule(ctx, types.SeriesAlertRule{
+		SeriesID:    series.ID,
+		Condition:   types.AlertPercentChange,
+		Threshold:   20,
+		Lookback:    1,
+		Description: "TODOs rose",
+		Enabled:     true,
+		CreatedBy:   1,
+		Actions: []types.SeriesAlertAction{
+			{Type: types.AlertActionEmail, RecipientUserID: 1},
+			{Type: types.AlertActionWebhook, URL: "https://example.com/hook"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &types.SeriesAlertRule{
+		ID:          1,
+		SeriesID:    series.ID,
+		Condition:   types.AlertPercentChange,
+		Threshold:   20,
+		Lookback:    1,
+		Description: "TODOs rose",
+		Enabled:     true,
+		CreatedBy:   1,
+		CreatedAt:   now,
+		Actions: []types.SeriesAlertAction{
+			{ID: 1, RuleID: 1, Type: types.AlertActionEmail, RecipientUserID: 1},
+			{ID: 2, RuleID: 1, Type: types.AlertActionWebhook, URL: "https://example.com/hook"},
+		},
+	}
+	if diff := cmp.Diff(want, rule, equateTimes); diff != "" {
+		t.Fatalf("unexpected rule (-want +got):\n%s", diff)
+	}
+
+	rules, err := store.ListAlertRules(ctx, AlertRuleQueryArgs{SeriesID: series.ID, OnlyEnabled: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]*types.SeriesAlertRule{want}, rules, equateTimes); diff != "" {
+		t.Fatalf("unexpected rules (-want +got):\n%s", diff)
+	}
+
+	baseline := 10.0
+	event := types.SeriesAlertEvent{
+		RuleID:    &rule.ID,
+		SeriesID:  series.ID,
+		PointTime: now,
+		Value:     12,
+		Baseline:  &baseline,
+		Message:   "rose by 20%",
+	}
+	created, err := store.CreateAlertEvent(ctx, event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !created {
+		t.Fatal("expected event to be created")
+	}
+
+	// The same rule fires at most once per point.
+	created, err = store.CreateAlertEvent(ctx, event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created {
+		t.Fatal("expected duplicate event to be ignored")
+	}
+
+	jobs, _, err := basestore.ScanFirstInt(insightStore.Query(ctx, sqlf.Sprintf("SELECT COUNT(*) FROM insight_series_alert_jobs WHERE event_id = 1")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if jobs != 2 {
+		t.Fatalf("expected one job per action, got %d", jobs)
+	}
+
+	// Deleting the rule keeps the alert history.
+	if err := store.DeleteAlertRule(ctx, rule.ID); err != nil {
+		t.Fatal(err)
+	}
+	events, err := store.ListAlertEvents(ctx, AlertEventQueryArgs{SeriesID: series.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantEvents := []*types.SeriesAlertEvent{{
+		ID:        1,
+		SeriesID:  series.ID,
+		PointTime: now,
+		Value:     12,
+		Baseline:  &baseline,
+		Message:   "rose by 20%",
+		CreatedAt: now,
+	}}
+	if diff := cmp.Diff(wantEvents, events, equateTimes); diff != "" {
+		t.Fatalf("unexpected events (-want +got):\n%s", diff)
+	}
+}
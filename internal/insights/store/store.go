@@ -900,6 +900,56 @@ func (s *Store) GetAllDataForInsightViewID(ctx context.Context, opts ExportOpts)
 	// 🚨 SECURITY: this function will only be called if the insight with the given insightViewId is visible given
 	// this user context. This is similar to how `SeriesPoints` works.
 	// We enforce repo permissions here as we store repository data at this level.
+	preds, err := s.exportRepoPredicates(ctx, opts.IncludeRepoRegex, opts.ExcludeRepoRegex)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	var results []SeriesPointForExport
+	exportScanner := func(sc scanner) error {
+		var tmp SeriesPointForExport
+		if err = sc.Scan(
+			&tmp.InsightViewTitle,
+			&tmp.SeriesLabel,
+			&tmp.SeriesQuery,
+			&tmp.RecordingTime,
+			&tmp.RepoName,
+			&tmp.Value,
+			&tmp.Capture,
+		); err != nil {
+			return err
+		}
+		// if this is a capture group insight the label will be the capture
+		if tmp.Capture != nil {
+			tmp.SeriesLabel = *tmp.Capture
+		}
+		results = append(results, tmp)
+		return nil
+	}
+
+	formattedPreds := sqlf.Join(preds, "AND")
+	// start with the oldest archived points and add them to the results
+	if err := tx.query(ctx, sqlf.Sprintf(exportCodeInsightsDataSql, quote(recordingTimesTableArchive), quote(recordingTableArchive), opts.InsightViewUniqueID, formattedPreds), exportScanner); err != nil {
+		return nil, errors.Wrap(err, "fetching archived code insights data")
+	}
+	// then add live points
+	// we join both series points tables
+	if err := tx.query(ctx, sqlf.Sprintf(exportCodeInsightsDataSql, quote(recordingTimesTable), quote("(select * from series_points union all select * from series_points_snapshots)"), opts.InsightViewUniqueID, formattedPreds), exportScanner); err != nil {
+		return nil, errors.Wrap(err, "fetching code insights data")
+	}
+
+	return results, nil
+}
+
+// exportRepoPredicates returns the predicates on the points of exported series that exclude repositories the
+// user cannot access and apply the given repository filters.
+func (s *Store) exportRepoPredicates(ctx context.Context, includeRepoRegex, excludeRepoRegex []string) ([]*sqlf.Query, error) {
 	denylist, err := s.permStore.GetUnauthorizedRepoIDs(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "GetUnauthorizedRepoIDs")
@@ -912,9 +962,9 @@ func (s *Store) GetAllDataForInsightViewID(ctx context.Context, opts ExportOpts)
 	if len(excludedRepoIDs) > 0 {
 		preds = append(preds, sqlf.Sprintf("sp.repo_id not in (%s)", sqlf.Join(excludedRepoIDs, ",")))
 	}
-	if len(opts.IncludeRepoRegex) > 0 {
+	if len(includeRepoRegex) > 0 {
 		includePreds := []*sqlf.Query{}
-		for _, regex := range opts.IncludeRepoRegex {
+		for _, regex := range includeRepoRegex {
 			if len(regex) == 0 {
 				continue
 			}
@@ -925,8 +975,8 @@ func (s *Store) GetAllDataForInsightViewID(ctx context.Context, opts ExportOpts)
 			preds = append(preds, includes)
 		}
 	}
-	if len(opts.ExcludeRepoRegex) > 0 {
-		for _, regex := range opts.ExcludeRepoRegex {
+	if len(excludeRepoRegex) > 0 {
+		for _, regex := range excludeRepoRegex {
 			if len(regex) == 0 {
 				continue
 			}
@@ -936,6 +986,65 @@ func (s *Store) GetAllDataForInsightViewID(ctx context.Context, opts ExportOpts)
 	if len(preds) == 0 {
 		preds = append(preds, sqlf.Sprintf("true"))
 	}
+	return preds, nil
+}
+
+const exportCodeInsightsDataSql = `
+select iv.title, ivs.label, i.query, isrt.recording_time, rn.name, coalesce(sp.value, 0) as value, sp.capture
+from %s isrt
+    join insight_series i on i.id = isrt.insight_series_id
+    join insight_view_series ivs ON i.id = ivs.insight_series_id
+    join insight_view iv ON ivs.insight_view_id = iv.id
+    left outer join %s sp on sp.series_id = i.series_id and sp.time = isrt.recording_time
+    left outer join repo_names rn on sp.repo_name_id = rn.id
+	where iv.unique_id = %s and %s
+    order by iv.title, isrt.recording_time, ivs.label, sp.capture;
+`
+
+// SeriesPointForMetrics is a recorded series point of an insight view along with the labels it is exposed with
+// in the metrics export. It should only be used for code insight metrics exporting.
+type SeriesPointForMetrics struct {
+	InsightViewUniqueID string
+	InsightViewTitle    string
+	SeriesID            string
+	SeriesLabel         string
+	RecordingTime       time.Time
+	RepoName            *string
+	Capture             *string
+	Value               float64
+}
+
+type MetricsOpts struct {
+	InsightViewUniqueID string
+	IncludeRepoRegex    []string
+	ExcludeRepoRegex    []string
+
+	// LatestOnly restricts the results to the points of the latest recording of each series.
+	LatestOnly bool
+	// From and To restrict the results to points recorded within the range (inclusive), if non-nil.
+	From *time.Time
+	To   *time.Time
+}
+
+// GetMetricsDataForInsightViewID returns the recorded points of the series of the given insight view. Unlike
+// GetAllDataForInsightViewID, recording times without any points are omitted.
+func (s *Store) GetMetricsDataForInsightViewID(ctx context.Context, opts MetricsOpts) (_ []SeriesPointForMetrics, err error) {
+	// 🚨 SECURITY: this function will only be called if the insight with the given insightViewId is visible given
+	// this user context. We enforce repo permissions here as we store repository data at this level.
+	preds, err := s.exportRepoPredicates(ctx, opts.IncludeRepoRegex, opts.ExcludeRepoRegex)
+	if err != nil {
+		return nil, err
+	}
+	if opts.From != nil {
+		preds = append(preds, sqlf.Sprintf("isrt.recording_time >= %s", *opts.From))
+	}
+	if opts.To != nil {
+		preds = append(preds, sqlf.Sprintf("isrt.recording_time <= %s", *opts.To))
+	}
+	latestPred := sqlf.Sprintf("true")
+	if opts.LatestOnly {
+		latestPred = sqlf.Sprintf("recording_time = latest_recording_time")
+	}
 
 	tx, err := s.Transact(ctx)
 	if err != nil {
@@ -943,13 +1052,14 @@ func (s *Store) GetAllDataForInsightViewID(ctx context.Context, opts ExportOpts)
 	}
 	defer func() { err = tx.Done(err) }()
 
-	var results []SeriesPointForExport
-	exportScanner := func(sc scanner) error {
-		var tmp SeriesPointForExport
+	var results []SeriesPointForMetrics
+	metricsScanner := func(sc scanner) error {
+		var tmp SeriesPointForMetrics
 		if err = sc.Scan(
+			&tmp.InsightViewUniqueID,
 			&tmp.InsightViewTitle,
+			&tmp.SeriesID,
 			&tmp.SeriesLabel,
-			&tmp.SeriesQuery,
 			&tmp.RecordingTime,
 			&tmp.RepoName,
 			&tmp.Value,
@@ -957,36 +1067,37 @@ func (s *Store) GetAllDataForInsightViewID(ctx context.Context, opts ExportOpts)
 		); err != nil {
 			return err
 		}
-		// if this is a capture group insight the label will be the capture
-		if tmp.Capture != nil {
-			tmp.SeriesLabel = *tmp.Capture
-		}
 		results = append(results, tmp)
 		return nil
 	}
 
 	formattedPreds := sqlf.Join(preds, "AND")
-	// start with the oldest archived points and add them to the results
-	if err := tx.query(ctx, sqlf.Sprintf(exportCodeInsightsDataSql, quote(recordingTimesTableArchive), quote(recordingTableArchive), opts.InsightViewUniqueID, formattedPreds), exportScanner); err != nil {
-		return nil, errors.Wrap(err, "fetching archived code insights data")
+	// archived points are never the latest points of a series
+	if !opts.LatestOnly {
+		if err := tx.query(ctx, sqlf.Sprintf(metricsCodeInsightsDataSql, quote(recordingTimesTableArchive), quote(recordingTableArchive), opts.InsightViewUniqueID, formattedPreds, latestPred), metricsScanner); err != nil {
+			return nil, errors.Wrap(err, "fetching archived code insights data")
+		}
 	}
-	// then add live points
-	// we join both series points tables
-	if err := tx.query(ctx, sqlf.Sprintf(exportCodeInsightsDataSql, quote(recordingTimesTable), quote("(select * from series_points union all select * from series_points_snapshots)"), opts.InsightViewUniqueID, formattedPreds), exportScanner); err != nil {
+	if err := tx.query(ctx, sqlf.Sprintf(metricsCodeInsightsDataSql, quote(recordingTimesTable), quote("(select * from series_points union all select * from series_points_snapshots)"), opts.InsightViewUniqueID, formattedPreds, latestPred), metricsScanner); err != nil {
 		return nil, errors.Wrap(err, "fetching code insights data")
 	}
 
 	return results, nil
 }
 
-const exportCodeInsightsDataSql = `
-select iv.title, ivs.label, i.query, isrt.recording_time, rn.name, coalesce(sp.value, 0) as value, sp.capture
-from %s isrt
-    join insight_series i on i.id = isrt.insight_series_id
-    join insight_view_series ivs ON i.id = ivs.insight_series_id
-    join insight_view iv ON ivs.insight_view_id = iv.id
-    left outer join %s sp on sp.series_id = i.series_id and sp.time = isrt.recording_time
-    left outer join repo_names rn on sp.repo_name_id = rn.id
-	where iv.unique_id = %s and %s
-    order by iv.title, isrt.recording_time, ivs.label, sp.capture;
+const metricsCodeInsightsDataSql = `
+select unique_id, title, series_id, label, recording_time, name, value, capture
+from (
+    select iv.unique_id, iv.title, i.series_id, ivs.label, isrt.recording_time, rn.name, sp.value, sp.capture,
+        max(isrt.recording_time) over (partition by i.series_id) as latest_recording_time
+    from %s isrt
+        join insight_series i on i.id = isrt.insight_series_id
+        join insight_view_series ivs ON i.id = ivs.insight_series_id
+        join insight_view iv ON ivs.insight_view_id = iv.id
+        join %s sp on sp.series_id = i.series_id and sp.time = isrt.recording_time
+        left outer join repo_names rn on sp.repo_name_id = rn.id
+    where iv.unique_id = %s and i.deleted_at is null and %s
+) points
+where %s
+order by series_id, recording_time, name, capture;
 `
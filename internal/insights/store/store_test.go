@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"fmt"
 	"sort"
 	"strings"
 	"testing"
@@ -1178,6 +1179,103 @@ SELECT recording_time,
 	})
 }
 
+func TestGetMetricsDataForInsightViewId(t *testing.T) {
+	ctx := context.Background()
+	logger := logtest.Scoped(t)
+	insightsDB := edb.NewInsightsDB(dbtest.NewInsightsDB(logger, t), logger)
+
+	permissionStore := NewMockInsightPermissionStore()
+	permissionStore.GetUnauthorizedRepoIDsFunc.SetDefaultReturn(nil, nil)
+
+	insightStore := NewInsightStore(insightsDB)
+	seriesStore := New(insightsDB, permissionStore)
+
+	view, err := insightStore.CreateView(ctx, types.InsightView{
+		Title:            "my view",
+		UniqueID:         "1",
+		PresentationType: types.Line,
+	}, []InsightViewGrant{GlobalGrant()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	series := setupSeries(ctx, insightStore, t)
+	if err := insightStore.AttachSeriesToView(ctx, series, view, types.InsightViewSeriesMetadata{Label: "label"}); err != nil {
+		t.Fatal(err)
+	}
+
+	first := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	second := first.Add(time.Hour)
+	empty := second.Add(time.Hour)
+	recordingTimes := types.InsightSeriesRecordingTimes{InsightSeriesID: series.ID, RecordingTimes: []types.RecordingTime{
+		{Timestamp: first}, {Timestamp: second},
+	}}
+	if err := seriesStore.SetInsightSeriesRecordingTimes(ctx, []types.InsightSeriesRecordingTimes{recordingTimes}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = insightsDB.ExecContext(ctx, `
+INSERT INTO repo_names(name) VALUES ('github.com/gorilla/mux-original'), ('github.com/sourcegraph/sourcegraph');
+INSERT INTO series_points(time, series_id, value, repo_id, repo_name_id, original_repo_name_id)
+SELECT recording_time, 'series1', 11, 1111, rn.id, rn.id
+	FROM insight_series_recording_times, repo_names rn WHERE insight_series_id = 1 AND rn.name = 'github.com/gorilla/mux-original';
+INSERT INTO series_points(time, series_id, value, repo_id, repo_name_id, original_repo_name_id, capture)
+SELECT $1::timestamptz, 'series1', 22, 2222, rn.id, rn.id, 'v1'
+	FROM repo_names rn WHERE rn.name = 'github.com/sourcegraph/sourcegraph';
+`, second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a recording time without points is not exposed
+	if err := seriesStore.SetInsightSeriesRecordingTimes(ctx, []types.InsightSeriesRecordingTimes{{InsightSeriesID: series.ID, RecordingTimes: []types.RecordingTime{{Timestamp: empty}}}}); err != nil {
+		t.Fatal(err)
+	}
+
+	stringify := func(points []SeriesPointForMetrics) []string {
+		var s []string
+		for _, p := range points {
+			s = append(s, fmt.Sprintf("%s %s %s %s %s %s %.0f", p.InsightViewUniqueID, p.SeriesID, p.SeriesLabel, p.RecordingTime.UTC().Format(time.RFC3339), pointers.Deref(p.RepoName, ""), pointers.Deref(p.Capture, ""), p.Value))
+		}
+		return s
+	}
+
+	t.Run("all points", func(t *testing.T) {
+		got, err := seriesStore.GetMetricsDataForInsightViewID(ctx, MetricsOpts{InsightViewUniqueID: view.UniqueID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		autogold.Expect([]string{
+			"1 series1 label 2023-01-01T00:00:00Z github.com/gorilla/mux-original  11",
+			"1 series1 label 2023-01-01T01:00:00Z github.com/gorilla/mux-original  11",
+			"1 series1 label 2023-01-01T01:00:00Z github.com/sourcegraph/sourcegraph v1 22",
+		}).Equal(t, stringify(got))
+	})
+	t.Run("latest points", func(t *testing.T) {
+		got, err := seriesStore.GetMetricsDataForInsightViewID(ctx, MetricsOpts{InsightViewUniqueID: view.UniqueID, LatestOnly: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		autogold.Expect([]string{
+			"1 series1 label 2023-01-01T01:00:00Z github.com/gorilla/mux-original  11",
+			"1 series1 label 2023-01-01T01:00:00Z github.com/sourcegraph/sourcegraph v1 22",
+		}).Equal(t, stringify(got))
+	})
+	t.Run("time range", func(t *testing.T) {
+		got, err := seriesStore.GetMetricsDataForInsightViewID(ctx, MetricsOpts{InsightViewUniqueID: view.UniqueID, From: &first, To: &first})
+		if err != nil {
+			t.Fatal(err)
+		}
+		autogold.Expect([]string{"1 series1 label 2023-01-01T00:00:00Z github.com/gorilla/mux-original  11"}).Equal(t, stringify(got))
+	})
+	t.Run("respects repo permissions", func(t *testing.T) {
+		permissionStore.GetUnauthorizedRepoIDsFunc.SetDefaultReturn([]api.RepoID{1111}, nil)
+		defer permissionStore.GetUnauthorizedRepoIDsFunc.SetDefaultReturn(nil, nil)
+		got, err := seriesStore.GetMetricsDataForInsightViewID(ctx, MetricsOpts{InsightViewUniqueID: view.UniqueID, LatestOnly: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		autogold.Expect([]string{"1 series1 label 2023-01-01T01:00:00Z github.com/sourcegraph/sourcegraph v1 22"}).Equal(t, stringify(got))
+	})
+}
+
 func setupSeries(ctx context.Context, tx *InsightStore, t *testing.T) types.InsightSeries {
 	now := time.Now()
 	series := types.InsightSeries{
@@ -0,0 +1,194 @@
+package timeseries
+
+import (
+	"math"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ForecastMethod is the model fitted to the recorded points of a series to project future values.
+type ForecastMethod string
+
+const (
+	// LinearForecast fits a straight line through the recorded values.
+	LinearForecast ForecastMethod = "linear"
+	// ExponentialForecast fits a straight line through the logarithm of the recorded values, so it can only be
+	// used for series whose recorded values are all positive.
+	ExponentialForecast ForecastMethod = "exponential"
+)
+
+const (
+	// minForecastObservations is the smallest number of recorded points a forecast is fitted to. Fewer points
+	// leave no degrees of freedom to estimate the confidence band.
+	minForecastObservations = 3
+
+	// confidenceZ is the two-sided z-score of the 95% confidence band around projected values.
+	confidenceZ = 1.96
+
+	// maxTargetHorizon bounds how far in the future an estimated target date may be. Crossings further out
+	// are not meaningful extrapolations of the recorded data.
+	maxTargetHorizon = 100 * 365 * 24 * time.Hour
+)
+
+// ErrInsufficientData is returned when a series does not have enough recorded points to fit a forecast.
+var ErrInsufficientData = errors.New("not enough recorded points to forecast")
+
+// Observation is a recorded value of a series at a point in time.
+type Observation struct {
+	Time  time.Time
+	Value float64
+}
+
+// ForecastPoint is a projected value of a series along with its confidence band. Forecast points are never
+// recorded and must not be persisted alongside the recorded points of a series.
+type ForecastPoint struct {
+	Time  time.Time
+	Value float64
+	Lower float64
+	Upper float64
+}
+
+type ForecastOptions struct {
+	Method ForecastMethod
+	// Interval is the step between projected points, usually the sample interval of the series.
+	Interval TimeInterval
+	// Intervals is the number of points to project after the last recorded point.
+	Intervals int
+	// Target is an optional value to estimate the date of.
+	Target *float64
+}
+
+type Forecast struct {
+	Method ForecastMethod
+	Points []ForecastPoint
+	// TargetTime is the estimated time the projected value reaches the target, if a target was given and the
+	// fitted trend reaches it after the last recorded point.
+	TargetTime *time.Time
+}
+
+// MakeForecast fits the given recorded points with a least-squares regression of the given method and projects
+// it forward.
+func MakeForecast(observations []Observation, opts ForecastOptions) (*Forecast, error) {
+	if opts.Intervals < 0 {
+		return nil, errors.New("number of forecast intervals must not be negative")
+	}
+	if !opts.Interval.IsValid() || opts.Interval.Value == 0 {
+		return nil, errors.Newf("invalid forecast interval %d %s", opts.Interval.Value, opts.Interval.Unit)
+	}
+	if len(observations) < minForecastObservations {
+		return nil, ErrInsufficientData
+	}
+
+	var transform, inverse func(float64) float64
+	switch opts.Method {
+	case LinearForecast:
+		transform = func(v float64) float64 { return v }
+		inverse = transform
+	case ExponentialForecast:
+		for _, o := range observations {
+			if o.Value <= 0 {
+				return nil, errors.New("exponential forecasts require all recorded values to be positive")
+			}
+		}
+		transform = math.Log
+		inverse = math.Exp
+	default:
+		return nil, errors.Newf("unsupported forecast method %q", opts.Method)
+	}
+
+	origin := observations[0].Time
+	xs := make([]float64, 0, len(observations))
+	ys := make([]float64, 0, len(observations))
+	for _, o := range observations {
+		xs = append(xs, daysSince(origin, o.Time))
+		ys = append(ys, transform(o.Value))
+	}
+	fit, err := fitLine(xs, ys)
+	if err != nil {
+		return nil, err
+	}
+
+	forecast := &Forecast{Method: opts.Method, Points: make([]ForecastPoint, 0, opts.Intervals)}
+	last := observations[len(observations)-1].Time
+	current := last
+	for i := 0; i < opts.Intervals; i++ {
+		current = opts.Interval.StepForwards(current)
+		x := daysSince(origin, current)
+		y := fit.predict(x)
+		margin := confidenceZ * fit.predictionError(x)
+		forecast.Points = append(forecast.Points, ForecastPoint{
+			Time:  current,
+			Value: inverse(y),
+			Lower: inverse(y - margin),
+			Upper: inverse(y + margin),
+		})
+	}
+
+	// An exponential fit never reaches a target that is not positive.
+	if opts.Target != nil && fit.slope != 0 && (opts.Method != ExponentialForecast || *opts.Target > 0) {
+		x := (transform(*opts.Target) - fit.intercept) / fit.slope
+		days := x - daysSince(origin, last)
+		if days > 0 && days <= maxTargetHorizon.Hours()/24 {
+			targetTime := last.Add(time.Duration(days * float64(24*time.Hour)))
+			forecast.TargetTime = &targetTime
+		}
+	}
+
+	return forecast, nil
+}
+
+func daysSince(origin, t time.Time) float64 {
+	return t.Sub(origin).Hours() / 24
+}
+
+// linearFit is an ordinary least-squares fit of y = intercept + slope*x.
+type linearFit struct {
+	intercept, slope float64
+	n                int
+	meanX, sxx       float64
+	// stdErr is the standard error of the residuals.
+	stdErr float64
+}
+
+func fitLine(xs, ys []float64) (linearFit, error) {
+	n := float64(len(xs))
+	var sumX, sumY float64
+	for i := range xs {
+		sumX += xs[i]
+		sumY += ys[i]
+	}
+	meanX, meanY := sumX/n, sumY/n
+
+	var sxx, sxy float64
+	for i := range xs {
+		dx := xs[i] - meanX
+		sxx += dx * dx
+		sxy += dx * (ys[i] - meanY)
+	}
+	if sxx == 0 {
+		return linearFit{}, errors.New("recorded points must span more than a single point in time")
+	}
+
+	fit := linearFit{slope: sxy / sxx, n: len(xs), meanX: meanX, sxx: sxx}
+	fit.intercept = meanY - fit.slope*meanX
+
+	var sse float64
+	for i := range xs {
+		residual := ys[i] - fit.predict(xs[i])
+		sse += residual * residual
+	}
+	fit.stdErr = math.Sqrt(sse / (n - 2))
+	return fit, nil
+}
+
+func (f linearFit) predict(x float64) float64 {
+	return f.intercept + f.slope*x
+}
+
+// predictionError is the standard error of a new observation at x, which widens the further x is from the
+// recorded points.
+func (f linearFit) predictionError(x float64) float64 {
+	dx := x - f.meanX
+	return f.stdErr * math.Sqrt(1+1/float64(f.n)+dx*dx/f.sxx)
+}
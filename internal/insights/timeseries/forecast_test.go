@@ -0,0 +1,147 @@
+package timeseries
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/insights/types"
+)
+
+func TestMakeForecast(t *testing.T) {
+	start := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+	weekly := TimeInterval{Unit: types.Week, Value: 1}
+
+	observe := func(values ...float64) []Observation {
+		observations := make([]Observation, 0, len(values))
+		current := start
+		for _, value := range values {
+			observations = append(observations, Observation{Time: current, Value: value})
+			current = weekly.StepForwards(current)
+		}
+		return observations
+	}
+	stringify := func(forecast *Forecast) []string {
+		var s []string
+		for _, p := range forecast.Points {
+			s = append(s, fmt.Sprintf("%s %.2f [%.2f, %.2f]", p.Time.Format(time.DateOnly), p.Value, p.Lower, p.Upper))
+		}
+		if forecast.TargetTime != nil {
+			s = append(s, "target "+forecast.TargetTime.Format(time.DateOnly))
+		}
+		return s
+	}
+	float := func(f float64) *float64 { return &f }
+
+	t.Run("linear", func(t *testing.T) {
+		forecast, err := MakeForecast(observe(10, 20, 30, 40), ForecastOptions{
+			Method:    LinearForecast,
+			Interval:  weekly,
+			Intervals: 2,
+			Target:    float(100),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []string{
+			"2021-12-29 50.00 [50.00, 50.00]",
+			"2022-01-05 60.00 [60.00, 60.00]",
+			"target 2022-02-02",
+		}
+		if diff := cmp.Diff(want, stringify(forecast)); diff != "" {
+			t.Errorf("unexpected forecast (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("linear with noise widens the band over time", func(t *testing.T) {
+		forecast, err := MakeForecast(observe(10, 22, 28, 41, 50), ForecastOptions{
+			Method:    LinearForecast,
+			Interval:  weekly,
+			Intervals: 3,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var previousWidth float64
+		for _, p := range forecast.Points {
+			if p.Lower >= p.Value || p.Upper <= p.Value {
+				t.Errorf("expected value %f to be inside its band [%f, %f]", p.Value, p.Lower, p.Upper)
+			}
+			if width := p.Upper - p.Lower; width <= previousWidth {
+				t.Errorf("expected band to widen, got width %f after %f", width, previousWidth)
+			} else {
+				previousWidth = width
+			}
+		}
+	})
+
+	t.Run("exponential", func(t *testing.T) {
+		forecast, err := MakeForecast(observe(1, 2, 4, 8), ForecastOptions{
+			Method:    ExponentialForecast,
+			Interval:  weekly,
+			Intervals: 2,
+			Target:    float(64),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []string{
+			"2021-12-29 16.00 [16.00, 16.00]",
+			"2022-01-05 32.00 [32.00, 32.00]",
+			"target 2022-01-12",
+		}
+		if diff := cmp.Diff(want, stringify(forecast)); diff != "" {
+			t.Errorf("unexpected forecast (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("target already reached", func(t *testing.T) {
+		forecast, err := MakeForecast(observe(10, 20, 30), ForecastOptions{
+			Method:    LinearForecast,
+			Interval:  weekly,
+			Intervals: 1,
+			Target:    float(15),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if forecast.TargetTime != nil {
+			t.Errorf("expected no target time, got %s", forecast.TargetTime)
+		}
+	})
+
+	t.Run("flat series never reaches target", func(t *testing.T) {
+		forecast, err := MakeForecast(observe(5, 5, 5), ForecastOptions{
+			Method:    LinearForecast,
+			Interval:  weekly,
+			Intervals: 1,
+			Target:    float(10),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if forecast.TargetTime != nil || math.Abs(forecast.Points[0].Value-5) > 1e-9 {
+			t.Errorf("unexpected forecast %v", stringify(forecast))
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for name, test := range map[string]struct {
+			observations []Observation
+			opts         ForecastOptions
+		}{
+			"insufficient data":    {observe(1, 2), ForecastOptions{Method: LinearForecast, Interval: weekly}},
+			"non-positive values":  {observe(0, 1, 2), ForecastOptions{Method: ExponentialForecast, Interval: weekly}},
+			"unsupported method":   {observe(1, 2, 3), ForecastOptions{Method: "quadratic", Interval: weekly}},
+			"invalid interval":     {observe(1, 2, 3), ForecastOptions{Method: LinearForecast}},
+			"single point in time": {[]Observation{{start, 1}, {start, 2}, {start, 3}}, ForecastOptions{Method: LinearForecast, Interval: weekly}},
+		} {
+			if _, err := MakeForecast(test.observations, test.opts); err == nil {
+				t.Errorf("%s: expected error", name)
+			}
+		}
+	})
+}
@@ -0,0 +1,65 @@
+package types
+
+import "time"
+
+// AlertCondition is the kind of check an alert rule performs against the latest value of an insight series.
+type AlertCondition string
+
+const (
+	// AlertAboveThreshold fires when the value crosses above the threshold.
+	AlertAboveThreshold AlertCondition = "ABOVE_THRESHOLD"
+	// AlertBelowThreshold fires when the value crosses below the threshold.
+	AlertBelowThreshold AlertCondition = "BELOW_THRESHOLD"
+	// AlertPercentChange fires when the value changed by at least threshold percent compared to the value lookback
+	// recordings ago. A negative threshold matches decreases.
+	AlertPercentChange AlertCondition = "PERCENT_CHANGE"
+	// AlertAnomaly fires when the value is at least threshold standard deviations away from the mean of the previous
+	// lookback recordings.
+	AlertAnomaly AlertCondition = "ANOMALY"
+)
+
+// AlertActionType is the way a fired alert is delivered. The types match the actions available to code monitors.
+type AlertActionType string
+
+const (
+	AlertActionEmail        AlertActionType = "EMAIL"
+	AlertActionWebhook      AlertActionType = "WEBHOOK"
+	AlertActionSlackWebhook AlertActionType = "SLACK_WEBHOOK"
+)
+
+// SeriesAlertRule is an alert rule attached to an insight series. Rules are evaluated every time a new point is
+// recorded for the series.
+type SeriesAlertRule struct {
+	ID          int
+	SeriesID    int // references insight_series(id)
+	Condition   AlertCondition
+	Threshold   float64
+	Lookback    int
+	Description string
+	Enabled     bool
+	CreatedBy   int32
+	CreatedAt   time.Time
+	Actions     []SeriesAlertAction
+}
+
+// SeriesAlertAction is a notification sent when an alert rule fires.
+type SeriesAlertAction struct {
+	ID              int
+	RuleID          int
+	Type            AlertActionType
+	URL             string
+	RecipientUserID int32
+}
+
+// SeriesAlertEvent is an entry in the alert history of an insight series.
+type SeriesAlertEvent struct {
+	ID        int
+	RuleID    *int
+	SeriesID  int
+	PointTime time.Time
+	Capture   *string
+	Value     float64
+	Baseline  *float64
+	Message   string
+	CreatedAt time.Time
+}
@@ -131,6 +131,7 @@ const (
 	SearchCompute  GenerationMethod = "search-compute"
 	LanguageStats  GenerationMethod = "language-stats"
 	MappingCompute GenerationMethod = "mapping-compute"
+	Script         GenerationMethod = "script"
 )
 
 type Dashboard struct {
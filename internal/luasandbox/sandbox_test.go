@@ -87,6 +87,28 @@ func TestSandboxMaxTimeout(t *testing.T) {
 	}
 }
 
+func TestSandboxMaxCallStackSize(t *testing.T) {
+	ctx := context.Background()
+
+	sandbox, err := newService(&observation.TestContext).CreateSandbox(ctx, CreateOptions{CallStackSize: 64})
+	if err != nil {
+		t.Fatalf("unexpected error creating sandbox: %s", err)
+	}
+	defer sandbox.Close()
+
+	script := `
+		local function f(n)
+			return 1 + f(n + 1)
+		end
+		return f(0)
+	`
+	if _, err := sandbox.RunScript(ctx, RunOptions{}, script); err == nil {
+		t.Fatalf("expected error running script")
+	} else if !strings.Contains(err.Error(), "stack overflow") {
+		t.Fatalf("unexpected error running script: %s", err)
+	}
+}
+
 func TestRunScript(t *testing.T) {
 	ctx := context.Background()
 
@@ -28,6 +28,17 @@ type CreateOptions struct {
 	// in the lua sandbox state. This prevents subsequent executions from
 	// modifying (or peeking into) the state of any other recognizer.
 	LuaModules map[string]string
+
+	// CallStackSize bounds the depth of nested function calls a script in the
+	// sandbox can make. Defaults to the call stack size of the Lua VM.
+	CallStackSize int
+
+	// RegistryMaxSize bounds the number of slots of the data stack of the Lua
+	// VM, which holds the arguments and locals of the active function calls.
+	// Exceeding either limit raises a stack overflow error in the script. It
+	// does not bound memory: strings and tables a script builds are allocated
+	// outside of the data stack. Defaults to a fixed-size data stack.
+	RegistryMaxSize int
 }
 
 func (s *Service) CreateSandbox(ctx context.Context, opts CreateOptions) (_ *Sandbox, err error) {
@@ -49,7 +60,9 @@ func (s *Service) CreateSandbox(ctx context.Context, opts CreateOptions) (_ *San
 
 	state := lua.NewState(lua.Options{
 		// Do not open libraries implicitly
-		SkipOpenLibs: true,
+		SkipOpenLibs:    true,
+		CallStackSize:   opts.CallStackSize,
+		RegistryMaxSize: opts.RegistryMaxSize,
 	})
 
 	for _, lib := range builtinLibs {
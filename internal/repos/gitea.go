@@ -0,0 +1,242 @@
+package repos
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// A GiteaSource yields repositories from a single Gitea or Forgejo connection
+// configured in Sourcegraph via the external services configuration.
+type GiteaSource struct {
+	svc     *types.ExternalService
+	config  *schema.GiteaConnection
+	baseURL *url.URL
+	exclude excludeFunc
+	client  gitea.Client
+	logger  log.Logger
+}
+
+var _ UserSource = &GiteaSource{}
+
+// NewGiteaSource returns a new GiteaSource from the given external service.
+func NewGiteaSource(ctx context.Context, logger log.Logger, svc *types.ExternalService, cf *httpcli.Factory) (*GiteaSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.GiteaConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	return newGiteaSource(logger, svc, &c, cf)
+}
+
+func newGiteaSource(logger log.Logger, svc *types.ExternalService, c *schema.GiteaConnection, cf *httpcli.Factory) (*GiteaSource, error) {
+	baseURL, err := url.Parse(c.Url)
+	if err != nil {
+		return nil, err
+	}
+	baseURL = extsvc.NormalizeBaseURL(baseURL)
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	var opts []httpcli.Opt
+	if c.Certificate != "" {
+		opts = append(opts, httpcli.NewCertPoolOpt(c.Certificate))
+	}
+
+	cli, err := cf.Doer(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var eb excludeBuilder
+	for _, r := range c.Exclude {
+		eb.Exact(r.Name)
+		if r.Id != 0 {
+			eb.Exact(strconv.Itoa(r.Id))
+		}
+		eb.Pattern(r.Pattern)
+	}
+	exclude, err := eb.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := gitea.NewClient(svc.URN(), c, cli)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GiteaSource{
+		svc:     svc,
+		config:  c,
+		baseURL: baseURL,
+		exclude: exclude,
+		client:  client,
+		logger:  logger,
+	}, nil
+}
+
+// CheckConnection checks that the instance is reachable and that the
+// configured token is valid.
+func (s *GiteaSource) CheckConnection(ctx context.Context) error {
+	if _, err := s.client.CurrentUser(ctx); err != nil {
+		return errors.Wrap(err, "connection check failed. could not fetch authenticated user")
+	}
+	return nil
+}
+
+// ListRepos returns all Gitea repositories configured with this GiteaSource's
+// config.
+func (s *GiteaSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	seen := make(map[int64]bool)
+	emit := func(r *gitea.Repo) {
+		if seen[r.ID] || s.excludes(r) {
+			return
+		}
+		seen[r.ID] = true
+		results <- SourceResult{Source: s, Repo: s.makeRepo(r)}
+	}
+
+	for _, nameWithOwner := range s.config.Repos {
+		owner, name, ok := strings.Cut(nameWithOwner, "/")
+		if !ok {
+			results <- SourceResult{Source: s, Err: errors.Errorf("gitea.repos: invalid repository name %q", nameWithOwner)}
+			continue
+		}
+
+		r, err := s.client.GetRepo(ctx, owner, name)
+		if err != nil {
+			// A repository that was deleted or renamed shouldn't fail the
+			// whole sync.
+			if errcode.IsNotFound(err) {
+				s.logger.Warn("skipping missing gitea.repos entry", log.String("name", nameWithOwner), log.Error(err))
+				continue
+			}
+			results <- SourceResult{Source: s, Err: errors.Wrapf(err, "gitea.repos: item=%q", nameWithOwner)}
+			continue
+		}
+		emit(r)
+	}
+
+	for _, org := range s.config.Orgs {
+		for page, hasNextPage := 1, true; hasNextPage; page++ {
+			var repos []*gitea.Repo
+			var err error
+			repos, hasNextPage, err = s.client.ListOrgRepos(ctx, org, page)
+			if err != nil {
+				results <- SourceResult{Source: s, Err: errors.Wrapf(err, "gitea.orgs: item=%q, page=%d", org, page)}
+				break
+			}
+
+			for _, r := range repos {
+				emit(r)
+			}
+		}
+	}
+}
+
+// ExternalServices returns a singleton slice containing the external service.
+func (s *GiteaSource) ExternalServices() types.ExternalServices {
+	return types.ExternalServices{s.svc}
+}
+
+func (s *GiteaSource) makeRepo(r *gitea.Repo) *types.Repo {
+	urn := s.svc.URN()
+	return &types.Repo{
+		Name: reposource.GiteaRepoName(
+			s.config.RepositoryPathPattern,
+			s.baseURL.Hostname(),
+			r.FullName,
+		),
+		URI: string(reposource.GiteaRepoName(
+			"",
+			s.baseURL.Hostname(),
+			r.FullName,
+		)),
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          strconv.FormatInt(r.ID, 10),
+			ServiceType: extsvc.TypeGitea,
+			ServiceID:   s.baseURL.String(),
+		},
+		Description: r.Description,
+		Fork:        r.Fork,
+		Archived:    r.Archived,
+		Stars:       r.Stars,
+		Private:     r.Private || r.Internal,
+		Sources: map[string]*types.SourceInfo{
+			urn: {
+				ID:       urn,
+				CloneURL: s.remoteURL(r),
+			},
+		},
+		Metadata: r,
+	}
+}
+
+// remoteURL returns the repository's Git remote URL
+//
+// note: this used to contain credentials but that is no longer the case
+// if you need to get an authenticated clone url use repos.CloneURL
+func (s *GiteaSource) remoteURL(r *gitea.Repo) string {
+	if s.config.GitURLType == "ssh" {
+		return r.SSHURL
+	}
+	return r.CloneURL
+}
+
+func (s *GiteaSource) excludes(r *gitea.Repo) bool {
+	return s.exclude(r.FullName) || s.exclude(strconv.FormatInt(r.ID, 10))
+}
+
+// WithAuthenticator returns a copy of the original Source configured to use
+// the given authenticator, provided that authenticator type is supported by
+// the code host.
+func (s *GiteaSource) WithAuthenticator(a auth.Authenticator) (Source, error) {
+	switch a.(type) {
+	case
+		*auth.OAuthBearerToken,
+		*auth.OAuthBearerTokenWithSSH,
+		*auth.BasicAuth,
+		*auth.BasicAuthWithSSH:
+		break
+
+	default:
+		return nil, newUnsupportedAuthenticatorError("GiteaSource", a)
+	}
+
+	sc := *s
+	cli, err := sc.client.WithAuthenticator(a)
+	if err != nil {
+		return nil, err
+	}
+	sc.client = cli
+
+	return &sc, nil
+}
+
+// ValidateAuthenticator validates the currently set authenticator is usable.
+// Returns an error, when validating the Authenticator yielded an error.
+func (s *GiteaSource) ValidateAuthenticator(ctx context.Context) error {
+	_, err := s.client.CurrentUser(ctx)
+	return err
+}
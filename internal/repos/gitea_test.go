@@ -0,0 +1,75 @@
+package repos
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestGiteaSource_ListRepos(t *testing.T) {
+	ratelimit.SetupForTest(t)
+
+	conf := &schema.GiteaConnection{
+		Url:   "https://gitea.sgdev.org",
+		Token: os.Getenv("GITEA_TOKEN"),
+		Orgs:  []string{"sgtest"},
+		Repos: []string{
+			"sourcegraph-bot/sgtest-go-jsonschema",
+			"sgtest/does-not-exist",
+		},
+		Exclude: []*schema.ExcludedGiteaRepo{
+			{Name: "sgtest/old-stuff"},
+			{Id: 12},
+			{Pattern: "^sgtest/repo-4"},
+		},
+	}
+	cf, save := NewClientFactory(t, t.Name())
+	defer save(t)
+
+	svc := &types.ExternalService{
+		Kind:   extsvc.KindGitea,
+		Config: extsvc.NewUnencryptedConfig(MarshalJSON(t, conf)),
+	}
+
+	ctx := context.Background()
+	src, err := NewGiteaSource(ctx, logtest.Scoped(t), svc, cf)
+	require.NoError(t, err)
+
+	repos, err := ListAll(ctx, src)
+	require.NoError(t, err)
+
+	// 1 repo from "repos", plus 54 org repos minus 12 excluded ones.
+	require.Len(t, repos, 43)
+	byName := make(map[string]*types.Repo, len(repos))
+	for _, r := range repos {
+		byName[string(r.Name)] = r
+	}
+	for _, excluded := range []string{"sgtest/old-stuff", "sgtest/src-cli", "sgtest/repo-40", "sgtest/repo-49"} {
+		assert.NotContains(t, byName, "gitea.sgdev.org/"+excluded)
+	}
+
+	fork := byName["gitea.sgdev.org/sourcegraph-bot/sgtest-go-jsonschema"]
+	require.NotNil(t, fork)
+	assert.True(t, fork.Fork)
+	assert.Equal(t, api.ExternalRepoSpec{
+		ID:          "21",
+		ServiceType: extsvc.TypeGitea,
+		ServiceID:   "https://gitea.sgdev.org/",
+	}, fork.ExternalRepo)
+	assert.Equal(t, "https://gitea.sgdev.org/sourcegraph-bot/sgtest-go-jsonschema.git", fork.Sources[svc.URN()].CloneURL)
+
+	private := byName["gitea.sgdev.org/sgtest/private-repo"]
+	require.NotNil(t, private)
+	assert.True(t, private.Private)
+}
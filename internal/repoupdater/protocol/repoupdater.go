@@ -12,6 +12,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	proto "github.com/sourcegraph/sourcegraph/internal/repoupdater/v1"
@@ -280,6 +281,19 @@ func NewRepoInfo(r *types.Repo) *RepoInfo {
 			Blob:   pathAppend(href, "/src/{rev}/{path}"),
 			Commit: pathAppend(href, "/commits/{commit}"),
 		}
+	case extsvc.TypeGitea:
+		repo := r.Metadata.(*gitea.Repo)
+		if repo.HTMLURL == "" {
+			break
+		}
+
+		href := repo.HTMLURL
+		info.Links = &RepoLinks{
+			Root:   href,
+			Tree:   pathAppend(href, "/src/{rev}/{path}"),
+			Blob:   pathAppend(href, "/src/{rev}/{path}"),
+			Commit: pathAppend(href, "/commit/{commit}"),
+		}
 	case extsvc.TypeAWSCodeCommit:
 		repo := r.Metadata.(*awscodecommit.Repository)
 		if repo.ARN == "" {
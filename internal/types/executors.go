@@ -15,6 +15,10 @@ type Executor struct {
 	GitVersion      string
 	IgniteVersion   string
 	SrcCliVersion   string
+	Labels          []string
+	NumCPUs         int
+	Memory          string
+	DiskSpace       string
 	FirstSeenAt     time.Time
 	LastSeenAt      time.Time
 }
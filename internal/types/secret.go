@@ -61,6 +61,8 @@ func (e *ExternalService) RedactedConfig(ctx context.Context) (string, error) {
 		es.redactString(c.Token, "token")
 	case *schema.GerritConnection:
 		es.redactString(c.Password, "password")
+	case *schema.GiteaConnection:
+		es.redactString(c.Token, "token")
 	case *schema.BitbucketServerConnection:
 		es.redactString(c.Password, "password")
 		es.redactString(c.Token, "token")
@@ -210,6 +212,12 @@ func (e *ExternalService) UnredactConfig(ctx context.Context, old *ExternalServi
 		if c.Url != o.Url {
 			return errCodeHostIdentityChanged{"url", "password"}
 		}
+	case *schema.GiteaConnection:
+		o := oldCfg.(*schema.GiteaConnection)
+		if c.Token == RedactedSecret && c.Url != o.Url {
+			return errCodeHostIdentityChanged{"url", "token"}
+		}
+		es.unredactString(c.Token, o.Token, "token")
 	case *schema.GitoliteConnection:
 		// Nothing to redact
 	case *schema.GoModulesConnection:
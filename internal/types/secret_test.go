@@ -85,6 +85,11 @@ func TestExternalService_RedactedConfig(t *testing.T) {
 			in:   schema.PagureConnection{Url: "https://src.fedoraproject.org", Token: "bar"},
 			out:  schema.PagureConnection{Url: "https://src.fedoraproject.org", Token: RedactedSecret},
 		},
+		{
+			kind: extsvc.KindGitea,
+			in:   schema.GiteaConnection{Url: "https://codeberg.org", Token: "bar"},
+			out:  schema.GiteaConnection{Url: "https://codeberg.org", Token: RedactedSecret},
+		},
 		{
 			kind: extsvc.KindJVMPackages,
 			in:   schema.JVMPackagesConnection{Maven: schema.Maven{Credentials: "foobar", Dependencies: []string{"baz"}}},
@@ -294,6 +299,19 @@ func TestExternalService_UnredactConfig(t *testing.T) {
 			in:   schema.PagureConnection{Url: "https://src.fedoraproject.org", Token: RedactedSecret},
 			out:  schema.PagureConnection{Url: "https://src.fedoraproject.org", Token: "bar"},
 		},
+		{
+			kind: extsvc.KindGitea,
+			old:  schema.GiteaConnection{Url: "https://codeberg.org", Token: "bar"},
+			in:   schema.GiteaConnection{Url: "https://codeberg.org", Token: RedactedSecret},
+			out:  schema.GiteaConnection{Url: "https://codeberg.org", Token: "bar"},
+		},
+		{
+			kind:    extsvc.KindGitea,
+			old:     schema.GiteaConnection{Url: "https://codeberg.org", Token: "bar"},
+			in:      schema.GiteaConnection{Url: "https://gitea.example.com", Token: RedactedSecret},
+			out:     schema.GiteaConnection{Url: "https://gitea.example.com", Token: "bar"},
+			wantErr: errCodeHostIdentityChanged{"url", "token"},
+		},
 		{
 			kind: extsvc.KindJVMPackages,
 			old:  schema.JVMPackagesConnection{Maven: schema.Maven{Credentials: "foobar", Dependencies: []string{"baz"}}},
@@ -0,0 +1,42 @@
+package dbworker
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+)
+
+// NewDependencyGraphHandler returns a handler that serves the dependency graph of the record
+// identified by the table and id query parameters as JSON, for debugging records that are not
+// dequeued because of their prerequisites.
+func NewDependencyGraphHandler(handle basestore.TransactableHandle) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		table := r.URL.Query().Get("table")
+		id, err := strconv.Atoi(r.URL.Query().Get("id"))
+		if table == "" || err != nil {
+			http.Error(w, "the table and id query parameters are required, e.g. ?table=lsif_indexes&id=42", http.StatusBadRequest)
+			return
+		}
+
+		dependencies, err := store.DependencyGraph(r.Context(), handle, store.RecordReference{TableName: table, ID: id})
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to read dependency graph: %q", err.Error()), http.StatusInternalServerError)
+			return
+		}
+		if dependencies == nil {
+			dependencies = []store.Dependency{}
+		}
+
+		resp, err := json.MarshalIndent(dependencies, "", "  ")
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to marshal dependency graph: %q", err.Error()), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(resp)
+	})
+}
@@ -0,0 +1,19 @@
+package dbworker
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDependencyGraphHandlerBadRequest(t *testing.T) {
+	handler := NewDependencyGraphHandler(nil)
+
+	for _, query := range []string{"", "?table=lsif_indexes", "?id=42", "?table=lsif_indexes&id=x"} {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest("GET", "/job-dependencies"+query, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("unexpected status for %q. want=%d have=%d", query, http.StatusBadRequest, w.Code)
+		}
+	}
+}
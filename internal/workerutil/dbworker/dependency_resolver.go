@@ -0,0 +1,40 @@
+package dbworker
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+)
+
+type DependencyResolverOptions struct {
+	Name     string
+	Interval time.Duration
+}
+
+// NewDependencyResolver returns a background routine that periodically calls ResolveDependencies on
+// the given store, which must track dependencies, so that records whose prerequisites failed, were
+// canceled or deleted do not stay queued forever.
+func NewDependencyResolver[T workerutil.Record](logger log.Logger, store store.Store[T], options DependencyResolverOptions) *goroutine.PeriodicGoroutine {
+	if options.Name == "" {
+		panic("no name supplied to github.com/sourcegraph/sourcegraph/internal/dbworker/NewDependencyResolver")
+	}
+
+	return goroutine.NewPeriodicGoroutine(
+		context.Background(),
+		goroutine.HandlerFunc(func(ctx context.Context) error {
+			ids, err := store.ResolveDependencies(ctx)
+			for _, id := range ids {
+				logger.Warn("Moved record whose prerequisite can no longer complete out of the queue", log.String("name", options.Name), log.Int("id", id))
+			}
+			return err
+		}),
+		goroutine.WithName(options.Name),
+		goroutine.WithDescription("fails records whose prerequisites failed, were canceled or deleted"),
+		goroutine.WithInterval(options.Interval),
+	)
+}
@@ -0,0 +1,193 @@
+package dbworker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/derision-test/glock"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Listener wakes up workers when records become dequeueable in stores that notify on a channel
+// (see the `NotifyChannel` store option). It LISTENs on the channel over a dedicated connection,
+// which is re-established with an exponential backoff when it is lost.
+//
+// Notifications sent while the listener is not connected are lost. To make up for them, the
+// listener wakes up all workers every time it starts listening. Workers still poll their store
+// on their configured interval, so a wakeup that never arrives only delays a record.
+type Listener struct {
+	options  ListenerOptions
+	connect  func(ctx context.Context, channel string) (notificationConn, error)
+	clock    glock.Clock
+	ctx      context.Context // root context passed to the database
+	cancel   func()          // cancels the root context
+	finished chan struct{}   // signals that Start has finished
+	logger   log.Logger
+
+	mu          sync.Mutex
+	subscribers []chan struct{}
+}
+
+type ListenerOptions struct {
+	Name string
+
+	// Channel is the notification channel to LISTEN on.
+	Channel string
+
+	// MinReconnectDelay and MaxReconnectDelay bound the delay before reconnecting after the
+	// connection is lost. The delay doubles for every consecutive failed attempt.
+	MinReconnectDelay time.Duration
+	MaxReconnectDelay time.Duration
+}
+
+// notificationConn is a connection that has started listening on a channel.
+type notificationConn interface {
+	WaitForNotification(ctx context.Context) (*pgconn.Notification, error)
+	Close(ctx context.Context) error
+}
+
+const (
+	defaultMinReconnectDelay = time.Second
+	defaultMaxReconnectDelay = time.Minute
+)
+
+// NewListener creates a listener that connects to the database with the given data source name.
+// The connection is not shared with other users of the database, as it is held for the lifetime
+// of the listener.
+func NewListener(logger log.Logger, dataSource string, options ListenerOptions) *Listener {
+	connect := func(ctx context.Context, channel string) (notificationConn, error) {
+		conn, err := pgx.Connect(ctx, dataSource)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+			_ = conn.Close(ctx)
+			return nil, err
+		}
+
+		return conn, nil
+	}
+
+	return newListener(logger, connect, options, glock.NewRealClock())
+}
+
+func newListener(logger log.Logger, connect func(ctx context.Context, channel string) (notificationConn, error), options ListenerOptions, clock glock.Clock) *Listener {
+	if options.Name == "" {
+		panic("no name supplied to github.com/sourcegraph/sourcegraph/internal/dbworker/newListener")
+	}
+	if options.Channel == "" {
+		panic("no channel supplied to github.com/sourcegraph/sourcegraph/internal/dbworker/newListener")
+	}
+	if options.MinReconnectDelay <= 0 {
+		options.MinReconnectDelay = defaultMinReconnectDelay
+	}
+	if options.MaxReconnectDelay <= 0 {
+		options.MaxReconnectDelay = defaultMaxReconnectDelay
+	}
+	if options.MaxReconnectDelay < options.MinReconnectDelay {
+		options.MaxReconnectDelay = options.MinReconnectDelay
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Listener{
+		options:  options,
+		connect:  connect,
+		clock:    clock,
+		ctx:      ctx,
+		cancel:   cancel,
+		finished: make(chan struct{}),
+		logger:   logger,
+	}
+}
+
+// Wakeup returns a channel that receives a value whenever a notification arrives, to be
+// supplied as the `Wakeup` option of a single worker. Notifications that arrive before the
+// previous one was received are coalesced.
+func (l *Listener) Wakeup() <-chan struct{} {
+	ch := make(chan struct{}, 1)
+
+	l.mu.Lock()
+	l.subscribers = append(l.subscribers, ch)
+	l.mu.Unlock()
+
+	return ch
+}
+
+// Start begins listening on the channel until Stop is called.
+func (l *Listener) Start() {
+	defer close(l.finished)
+
+	delay := l.options.MinReconnectDelay
+	for {
+		listened, err := l.listen()
+		if l.ctx.Err() != nil {
+			return
+		}
+		if listened {
+			delay = l.options.MinReconnectDelay
+		}
+
+		l.logger.Warn("Lost connection listening for notifications",
+			log.String("name", l.options.Name),
+			log.String("channel", l.options.Channel),
+			log.Duration("reconnectDelay", delay),
+			log.Error(err))
+
+		select {
+		case <-l.clock.After(delay):
+		case <-l.ctx.Done():
+			return
+		}
+
+		if delay *= 2; delay > l.options.MaxReconnectDelay {
+			delay = l.options.MaxReconnectDelay
+		}
+	}
+}
+
+// Stop will cause the listener to close its connection and exit.
+func (l *Listener) Stop() {
+	l.cancel()
+	<-l.finished
+}
+
+// listen connects and broadcasts notifications until the connection fails. This method returns
+// true if the listener started listening before the connection failed.
+func (l *Listener) listen() (bool, error) {
+	conn, err := l.connect(l.ctx, l.options.Channel)
+	if err != nil {
+		return false, errors.Wrap(err, "connect")
+	}
+	defer func() { _ = conn.Close(context.Background()) }()
+
+	// Records may have been enqueued while we were not listening.
+	l.broadcast()
+
+	for {
+		if _, err := conn.WaitForNotification(l.ctx); err != nil {
+			return true, errors.Wrap(err, "WaitForNotification")
+		}
+
+		l.broadcast()
+	}
+}
+
+// broadcast wakes up all workers without blocking on those that have yet to wake up.
+func (l *Listener) broadcast() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, ch := range l.subscribers {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package dbworker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/derision-test/glock"
+	"github.com/jackc/pgconn"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type testNotificationConn struct {
+	notifications chan error
+}
+
+func (c *testNotificationConn) WaitForNotification(ctx context.Context) (*pgconn.Notification, error) {
+	select {
+	case err := <-c.notifications:
+		if err != nil {
+			return nil, err
+		}
+		return &pgconn.Notification{Channel: "test", Payload: "workerutil_test"}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (c *testNotificationConn) Close(ctx context.Context) error {
+	return nil
+}
+
+func TestListener(t *testing.T) {
+	logger := logtest.Scoped(t)
+	clock := glock.NewMockClock()
+	options := ListenerOptions{
+		Name:              "test",
+		Channel:           "test",
+		MinReconnectDelay: time.Second,
+		MaxReconnectDelay: time.Minute,
+	}
+
+	conn := &testNotificationConn{notifications: make(chan error)}
+	connectErrors := []error{errors.New("connection refused"), nil, nil}
+	connects := make(chan string, len(connectErrors))
+	connect := func(ctx context.Context, channel string) (notificationConn, error) {
+		err := connectErrors[0]
+		connectErrors = connectErrors[1:]
+		connects <- channel
+		if err != nil {
+			return nil, err
+		}
+		return conn, nil
+	}
+
+	listener := newListener(logger, connect, options, clock)
+	wakeup1 := listener.Wakeup()
+	wakeup2 := listener.Wakeup()
+	go func() { listener.Start() }()
+
+	// The first connection attempt fails
+	<-connects
+	assertNoWakeup(t, wakeup1)
+
+	// Workers are woken up once connected, as notifications may have been missed
+	clock.BlockingAdvance(time.Second)
+	if channel := <-connects; channel != "test" {
+		t.Fatalf("unexpected channel. want=%q have=%q", "test", channel)
+	}
+	<-wakeup1
+	<-wakeup2
+
+	// Notifications wake up all workers
+	conn.notifications <- nil
+	<-wakeup1
+	<-wakeup2
+
+	// Notifications do not block on workers that have yet to wake up
+	for i := 0; i < 3; i++ {
+		conn.notifications <- nil
+	}
+	<-wakeup1
+	<-wakeup2
+
+	// The delay was reset by the successful connection
+	conn.notifications <- errors.New("connection reset by peer")
+	clock.BlockingAdvance(time.Second)
+	<-connects
+	<-wakeup1
+	<-wakeup2
+
+	listener.Stop()
+}
+
+func assertNoWakeup(t *testing.T, wakeup <-chan struct{}) {
+	t.Helper()
+
+	select {
+	case <-wakeup:
+		t.Fatal("unexpected wakeup")
+	default:
+	}
+}
@@ -47,4 +47,51 @@ func InitPrometheusMetric[T workerutil.Record](observationCtx *observation.Conte
 
 		return float64(age) / float64(time.Second)
 	}))
+
+	observationCtx.Registerer.MustRegister(&partitionQueueCollector[T]{
+		workerStore: workerStore,
+		logger:      logger,
+		queued: prometheus.NewDesc(
+			fmt.Sprintf("src_%s_partition_total", teamAndResource),
+			fmt.Sprintf("Total number of %s records in the queued state by partition, for the partitions with the most queued records.", resource),
+			[]string{"partition"},
+			constLabels,
+		),
+		queuedDuration: prometheus.NewDesc(
+			fmt.Sprintf("src_%s_partition_queued_duration_seconds_total", teamAndResource),
+			fmt.Sprintf("The maximum amount of time a %s record has been sitting in the queue by partition, for the partitions with the most queued records.", resource),
+			[]string{"partition"},
+			constLabels,
+		),
+	})
+}
+
+// maxPartitionsReported bounds the cardinality of the partition label of the metrics of partitioned stores.
+const maxPartitionsReported = 100
+
+// partitionQueueCollector reports the queue depth and wait time of the partitions of a store. It reports
+// nothing for stores that are not partitioned.
+type partitionQueueCollector[T workerutil.Record] struct {
+	workerStore    store.Store[T]
+	logger         log.Logger
+	queued         *prometheus.Desc
+	queuedDuration *prometheus.Desc
+}
+
+func (c *partitionQueueCollector[T]) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.queued
+	ch <- c.queuedDuration
+}
+
+func (c *partitionQueueCollector[T]) Collect(ch chan<- prometheus.Metric) {
+	stats, err := c.workerStore.QueueStatsByPartition(context.Background(), maxPartitionsReported)
+	if err != nil {
+		c.logger.Error("Failed to determine queue size by partition", log.Error(err))
+		return
+	}
+
+	for _, partition := range stats {
+		ch <- prometheus.MustNewConstMetric(c.queued, prometheus.GaugeValue, float64(partition.Count), partition.Partition)
+		ch <- prometheus.MustNewConstMetric(c.queuedDuration, prometheus.GaugeValue, float64(partition.MaxDurationInQueue)/float64(time.Second), partition.Partition)
+	}
 }
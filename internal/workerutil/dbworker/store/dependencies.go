@@ -0,0 +1,362 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ErrDependencyCycle is returned by AddDependencies when a record would transitively depend on itself.
+var ErrDependencyCycle = errors.New("dependency cycle")
+
+// RecordReference identifies a record of a store that tracks dependencies.
+type RecordReference struct {
+	// TableName is the TableName of the store of the record.
+	TableName string
+	ID        int
+}
+
+// Dependency is an edge of the dependency graph of records.
+type Dependency struct {
+	Dependent    RecordReference
+	Prerequisite RecordReference
+	// PrerequisiteState is the state of the prerequisite as recorded by its store. It is queued
+	// until the prerequisite is completed, failed or canceled, or deleted if the prerequisite no
+	// longer exists.
+	PrerequisiteState string
+	UpdatedAt         time.Time
+}
+
+// AddDependencies declares that the record with the given identifier must not be dequeued before
+// the given prerequisite records have completed. Prerequisites may belong to other stores, which
+// must also track dependencies. If the dependencies would create a cycle, ErrDependencyCycle is
+// returned and no dependency is added.
+//
+// Prerequisites must exist when dependencies are added, as prerequisites that do not exist are
+// treated as deleted by ResolveDependencies. Dependencies are best added in the transaction that
+// enqueues the record, so that it cannot be dequeued before.
+func (s *store[T]) AddDependencies(ctx context.Context, id int, prerequisites []RecordReference) (err error) {
+	ctx, _, endObservation := s.operations.addDependencies.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("id", id),
+		attribute.Int("numPrerequisites", len(prerequisites)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if !s.options.TrackDependencies {
+		return errors.Newf("store %q does not track dependencies", s.options.Name)
+	}
+	if len(prerequisites) == 0 {
+		return nil
+	}
+
+	tableNames := make([]string, 0, len(prerequisites))
+	ids := make([]int, 0, len(prerequisites))
+	for _, prerequisite := range prerequisites {
+		tableNames = append(tableNames, prerequisite.TableName)
+		ids = append(ids, prerequisite.ID)
+	}
+
+	tx, err := s.Store.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	// Concurrent calls could each add half of a cycle without seeing the other half, so the graph
+	// is locked until the transaction ends.
+	if err := tx.Exec(ctx, sqlf.Sprintf(lockDependencyGraphQuery)); err != nil {
+		return err
+	}
+
+	cycle, _, err := basestore.ScanFirstBool(tx.Query(ctx, sqlf.Sprintf(
+		addDependenciesQuery,
+		pq.Array(tableNames),
+		pq.Array(ids),
+		s.options.TableName,
+		id,
+		s.options.TableName,
+		id,
+		pq.Array(tableNames),
+		pq.Array(ids),
+	)))
+	if err != nil {
+		return err
+	}
+	if cycle {
+		return ErrDependencyCycle
+	}
+
+	return nil
+}
+
+const lockDependencyGraphQuery = `
+SELECT pg_advisory_xact_lock(hashtext('workerutil_job_dependencies'))
+`
+
+const addDependenciesQuery = `
+WITH RECURSIVE
+prerequisites(prerequisite_table, prerequisite_id) AS (
+	SELECT * FROM unnest(%s::text[], %s::integer[])
+	UNION
+	SELECT d.prerequisite_table, d.prerequisite_id
+	FROM workerutil_job_dependencies d
+	JOIN prerequisites p ON p.prerequisite_table = d.dependent_table AND p.prerequisite_id = d.dependent_id
+),
+cycle AS (
+	SELECT EXISTS (
+		SELECT 1 FROM prerequisites WHERE prerequisite_table = %s AND prerequisite_id = %s
+	) AS found
+),
+inserted AS (
+	INSERT INTO workerutil_job_dependencies (dependent_table, dependent_id, prerequisite_table, prerequisite_id)
+	SELECT %s::text, %s::integer, p.prerequisite_table, p.prerequisite_id
+	FROM unnest(%s::text[], %s::integer[]) AS p(prerequisite_table, prerequisite_id)
+	WHERE NOT (SELECT found FROM cycle)
+	ON CONFLICT DO NOTHING
+)
+SELECT found FROM cycle
+`
+
+// Dependencies returns the edges of the dependency graph of the record with the given identifier:
+// its prerequisites and dependents, and theirs, transitively.
+func (s *store[T]) Dependencies(ctx context.Context, id int) (_ []Dependency, err error) {
+	ctx, _, endObservation := s.operations.dependencies.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("id", id),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return dependencyGraph(ctx, s.Store, RecordReference{TableName: s.options.TableName, ID: id})
+}
+
+// DependencyGraph returns the edges of the dependency graph of the given record, like the
+// Dependencies method of its store, for callers that do not know the type of the records of the
+// store, like debug endpoints.
+func DependencyGraph(ctx context.Context, handle basestore.TransactableHandle, record RecordReference) ([]Dependency, error) {
+	return dependencyGraph(ctx, basestore.NewWithHandle(handle), record)
+}
+
+func dependencyGraph(ctx context.Context, db *basestore.Store, record RecordReference) ([]Dependency, error) {
+	return scanDependencies(db.Query(ctx, sqlf.Sprintf(
+		dependenciesQuery,
+		record.TableName,
+		record.ID,
+		record.TableName,
+		record.ID,
+	)))
+}
+
+const dependenciesQuery = `
+WITH RECURSIVE
+upstream AS (
+	SELECT d.* FROM workerutil_job_dependencies d
+	WHERE d.dependent_table = %s AND d.dependent_id = %s
+	UNION
+	SELECT d.* FROM workerutil_job_dependencies d
+	JOIN upstream u ON d.dependent_table = u.prerequisite_table AND d.dependent_id = u.prerequisite_id
+),
+downstream AS (
+	SELECT d.* FROM workerutil_job_dependencies d
+	WHERE d.prerequisite_table = %s AND d.prerequisite_id = %s
+	UNION
+	SELECT d.* FROM workerutil_job_dependencies d
+	JOIN downstream w ON d.prerequisite_table = w.dependent_table AND d.prerequisite_id = w.dependent_id
+)
+SELECT
+	e.dependent_table,
+	e.dependent_id,
+	e.prerequisite_table,
+	e.prerequisite_id,
+	e.prerequisite_state,
+	e.updated_at
+FROM (
+	SELECT * FROM upstream
+	UNION
+	SELECT * FROM downstream
+) e
+ORDER BY e.dependent_table, e.dependent_id, e.prerequisite_table, e.prerequisite_id
+`
+
+var scanDependencies = basestore.NewSliceScanner(func(s dbutil.Scanner) (d Dependency, err error) {
+	err = s.Scan(
+		&d.Dependent.TableName,
+		&d.Dependent.ID,
+		&d.Prerequisite.TableName,
+		&d.Prerequisite.ID,
+		&d.PrerequisiteState,
+		&d.UpdatedAt,
+	)
+	return d, err
+})
+
+// updatedColumns returns the RETURNING list of a query that moves records of the store to a new
+// state: the given columns, along with the new state of the records if the store tracks
+// dependencies, which recordPrerequisiteStates reads.
+func (s *store[T]) updatedColumns(columns ...string) *sqlf.Query {
+	if s.options.TrackDependencies {
+		columns = append(columns, "state")
+	}
+
+	expressions := make([]string, 0, len(columns))
+	for _, column := range columns {
+		expressions = append(expressions, fmt.Sprintf("{%s} AS %s", column, column))
+	}
+	return s.formatQuery(strings.Join(expressions, ", "))
+}
+
+// recordPrerequisiteStates wraps the given query, which moves records of the store to a new state
+// and returns the columns listed by updatedColumns, so that the new states of records that are
+// prerequisites of other records are recorded. The wrapped query returns the given columns of the
+// given query. The query is returned unchanged if the store does not track dependencies.
+func (s *store[T]) recordPrerequisiteStates(q *sqlf.Query, columns string) *sqlf.Query {
+	if !s.options.TrackDependencies {
+		return q
+	}
+
+	return sqlf.Sprintf(recordPrerequisiteStatesQuery, q, s.options.TableName, sqlf.Sprintf(columns))
+}
+
+const recordPrerequisiteStatesQuery = `
+WITH updated AS (
+	%s
+),
+resolved AS (
+	UPDATE workerutil_job_dependencies d
+	SET prerequisite_state = u.state, updated_at = NOW()
+	FROM updated u
+	WHERE
+		d.prerequisite_table = %s AND
+		d.prerequisite_id = u.id AND
+		u.state IN ('completed', 'failed', 'canceled')
+)
+SELECT %s FROM updated
+`
+
+// prerequisitesCompletedCondition matches the records of the store that have no prerequisite that
+// has yet to complete.
+const prerequisitesCompletedCondition = `
+NOT EXISTS (
+	SELECT 1 FROM workerutil_job_dependencies d
+	WHERE
+		d.dependent_table = %s AND
+		d.dependent_id = {id} AND
+		d.prerequisite_state != 'completed'
+)
+`
+
+// ResolveDependencies catches up with the prerequisites of the store that were moved to a terminal
+// state or deleted without the store, for example by a direct UPDATE or DELETE, and records their
+// live state, or deleted for deleted records. It then moves the queued and errored records of the
+// store whose prerequisites failed, were canceled or deleted to the failed or canceled state, along
+// with the records of the store that transitively depend on them. It returns the identifiers of
+// the records that were moved, and does nothing if the store does not track dependencies.
+//
+// Records of other stores that depend on the moved records are moved by ResolveDependencies of
+// their own store.
+func (s *store[T]) ResolveDependencies(ctx context.Context) (ids []int, err error) {
+	ctx, _, endObservation := s.operations.resolveDependencies.With(ctx, &err, observation.Args{})
+	defer func() {
+		endObservation(1, observation.Args{Attrs: []attribute.KeyValue{
+			attribute.Int("numFailed", len(ids)),
+		}})
+	}()
+
+	if !s.options.TrackDependencies {
+		return nil, nil
+	}
+
+	if err := s.Exec(ctx, s.formatQuery(
+		syncPrerequisiteStatesQuery,
+		quote(s.options.TableName),
+		s.options.TableName,
+		s.options.TableName,
+	)); err != nil {
+		return nil, err
+	}
+
+	// Every iteration moves the records of the store that depend on the records moved by the
+	// previous one, until the failures have cascaded through the whole graph of the store.
+	for {
+		failed, err := basestore.ScanInts(s.Query(ctx, s.recordPrerequisiteStates(s.formatQuery(
+			failBlockedQuery,
+			quote(s.options.TableName),
+			s.options.TableName,
+			quote(s.options.TableName),
+			quote(s.options.TableName),
+			quote(s.options.TableName),
+		), "id")))
+		if err != nil {
+			return ids, err
+		}
+		if len(failed) == 0 {
+			return ids, nil
+		}
+		ids = append(ids, failed...)
+	}
+}
+
+// syncPrerequisiteStatesQuery records the live state of the records of the store that are
+// prerequisites of other records, if they were moved to a terminal state or deleted without the
+// store recording it.
+const syncPrerequisiteStatesQuery = `
+WITH live AS (
+	SELECT d.dependent_table, d.dependent_id, d.prerequisite_id, COALESCE(r.{state}, 'deleted') AS state
+	FROM workerutil_job_dependencies d
+	LEFT JOIN %s r ON r.{id} = d.prerequisite_id
+	WHERE
+		d.prerequisite_table = %s AND
+		d.prerequisite_state = 'queued' AND
+		(r.{id} IS NULL OR r.{state} IN ('completed', 'failed', 'canceled'))
+)
+UPDATE workerutil_job_dependencies d
+SET prerequisite_state = l.state, updated_at = NOW()
+FROM live l
+WHERE
+	d.dependent_table = l.dependent_table AND
+	d.dependent_id = l.dependent_id AND
+	d.prerequisite_table = %s AND
+	d.prerequisite_id = l.prerequisite_id
+`
+
+// failBlockedQuery moves the queued and errored records of the store that have a failed, canceled or
+// deleted prerequisite to the failed state, or to the canceled state if the prerequisite was
+// canceled. Cancellations take precedence over failures.
+const failBlockedQuery = `
+WITH blocked AS (
+	SELECT
+		{id} AS blocked_id,
+		d.prerequisite_table,
+		d.prerequisite_id,
+		d.prerequisite_state
+	FROM %s
+	JOIN LATERAL (
+		SELECT prerequisite_table, prerequisite_id, prerequisite_state
+		FROM workerutil_job_dependencies
+		WHERE
+			dependent_table = %s AND
+			dependent_id = {id} AND
+			prerequisite_state IN ('failed', 'canceled', 'deleted')
+		ORDER BY prerequisite_state = 'canceled' DESC, prerequisite_table, prerequisite_id
+		LIMIT 1
+	) d ON true
+	WHERE {state} IN ('queued', 'errored')
+	FOR UPDATE OF %s SKIP LOCKED
+)
+UPDATE %s
+SET
+	{state} = CASE WHEN b.prerequisite_state = 'canceled' THEN 'canceled' ELSE 'failed' END,
+	{finished_at} = clock_timestamp(),
+	{failure_message} = 'prerequisite ' || b.prerequisite_table || ' ' || b.prerequisite_id || ' ' || b.prerequisite_state
+FROM blocked b
+WHERE %s.{id} = b.blocked_id
+RETURNING {id} AS id, {state} AS state
+`
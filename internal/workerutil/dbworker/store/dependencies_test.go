@@ -0,0 +1,296 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/keegancsmith/sqlf"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreDequeueDependencies(t *testing.T) {
+	db := setupStoreTest(t)
+
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO workerutil_test (id, state, created_at)
+		VALUES
+			(1, 'queued', NOW() - '2 minute'::interval),
+			(2, 'queued', NOW() - '1 minute'::interval)
+	`); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+
+	store := testStore(db, dependencyTestStoreOptions())
+	ctx := context.Background()
+	require.NoError(t, store.AddDependencies(ctx, 1, []RecordReference{{TableName: "workerutil_test", ID: 2}}))
+
+	// The oldest record waits for its prerequisite.
+	record, ok, err := store.Dequeue(ctx, "test", nil)
+	assertDequeueRecordResult(t, 2, record, ok, err)
+
+	_, ok, err = store.Dequeue(ctx, "test", nil)
+	require.NoError(t, err)
+	require.False(t, ok, "expected no dequeueable record")
+
+	marked, err := store.MarkComplete(ctx, 2, MarkFinalOptions{})
+	require.NoError(t, err)
+	require.True(t, marked)
+
+	record, ok, err = store.Dequeue(ctx, "test", nil)
+	assertDequeueRecordResult(t, 1, record, ok, err)
+}
+
+func TestStoreDequeueDependenciesFailed(t *testing.T) {
+	db := setupStoreTest(t)
+
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO workerutil_test (id, state, cancel)
+		VALUES
+			(1, 'processing', false),
+			(2, 'queued', false),
+			(3, 'errored', false),
+			(4, 'processing', true),
+			(5, 'queued', false)
+	`); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+
+	store := testStore(db, dependencyTestStoreOptions())
+	ctx := context.Background()
+	// 3 -> 2 -> 1 and 5 -> 4
+	require.NoError(t, store.AddDependencies(ctx, 2, []RecordReference{{TableName: "workerutil_test", ID: 1}}))
+	require.NoError(t, store.AddDependencies(ctx, 3, []RecordReference{{TableName: "workerutil_test", ID: 2}}))
+	require.NoError(t, store.AddDependencies(ctx, 5, []RecordReference{{TableName: "workerutil_test", ID: 4}}))
+
+	marked, err := store.MarkFailed(ctx, 1, "oops", MarkFinalOptions{})
+	require.NoError(t, err)
+	require.True(t, marked)
+	marked, err = store.MarkErrored(ctx, 4, "canceled", MarkFinalOptions{})
+	require.NoError(t, err)
+	require.True(t, marked)
+
+	// Records with failed prerequisites are not dequeued, but stay queued until they are resolved.
+	_, ok, err := store.Dequeue(ctx, "test", nil)
+	require.NoError(t, err)
+	require.False(t, ok, "expected no dequeueable record")
+	require.Equal(t, "queued", testRecordStates(t, db)[2].State)
+
+	// Failures cascade through the whole graph.
+	ids, err := store.ResolveDependencies(ctx)
+	require.NoError(t, err)
+	sort.Ints(ids)
+	require.Equal(t, []int{2, 3, 5}, ids)
+
+	expected := map[int]testRecordState{
+		1: {State: "failed", FailureMessage: "oops"},
+		2: {State: "failed", FailureMessage: "prerequisite workerutil_test 1 failed"},
+		3: {State: "failed", FailureMessage: "prerequisite workerutil_test 2 failed"},
+		4: {State: "canceled", FailureMessage: "canceled"},
+		5: {State: "canceled", FailureMessage: "prerequisite workerutil_test 4 canceled"},
+	}
+	if diff := cmp.Diff(expected, testRecordStates(t, db)); diff != "" {
+		t.Errorf("unexpected records (-want +got):\n%s", diff)
+	}
+}
+
+func TestStoreResolveDependenciesOutsideOfStore(t *testing.T) {
+	db := setupStoreTest(t)
+
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO workerutil_test (id, state)
+		VALUES
+			(1, 'queued'),
+			(2, 'queued'),
+			(3, 'queued'),
+			(4, 'queued'),
+			(5, 'queued'),
+			(6, 'queued')
+	`); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+
+	store := testStore(db, dependencyTestStoreOptions())
+	ctx := context.Background()
+	// 3 -> 1, 4 -> 2 and 5 -> 6
+	require.NoError(t, store.AddDependencies(ctx, 3, []RecordReference{{TableName: "workerutil_test", ID: 1}}))
+	require.NoError(t, store.AddDependencies(ctx, 4, []RecordReference{{TableName: "workerutil_test", ID: 2}}))
+	require.NoError(t, store.AddDependencies(ctx, 5, []RecordReference{{TableName: "workerutil_test", ID: 6}}))
+
+	// The prerequisites are canceled, deleted and completed without the store.
+	if _, err := db.ExecContext(ctx, `
+		UPDATE workerutil_test SET state = CASE id WHEN 1 THEN 'canceled' ELSE 'completed' END WHERE id IN (1, 6);
+		DELETE FROM workerutil_test WHERE id = 2;
+	`); err != nil {
+		t.Fatalf("unexpected error updating records: %s", err)
+	}
+
+	ids, err := store.ResolveDependencies(ctx)
+	require.NoError(t, err)
+	sort.Ints(ids)
+	require.Equal(t, []int{3, 4}, ids)
+
+	expected := map[int]testRecordState{
+		1: {State: "canceled"},
+		3: {State: "canceled", FailureMessage: "prerequisite workerutil_test 1 canceled"},
+		4: {State: "failed", FailureMessage: "prerequisite workerutil_test 2 deleted"},
+		5: {State: "queued"},
+		6: {State: "completed"},
+	}
+	if diff := cmp.Diff(expected, testRecordStates(t, db)); diff != "" {
+		t.Errorf("unexpected records (-want +got):\n%s", diff)
+	}
+
+	record, ok, err := store.Dequeue(ctx, "test", nil)
+	assertDequeueRecordResult(t, 5, record, ok, err)
+}
+
+func TestStoreResolveDependenciesNotTracked(t *testing.T) {
+	db := setupStoreTest(t)
+	store := testStore(db, defaultTestStoreOptions(nil, testScanRecord))
+
+	ids, err := store.ResolveDependencies(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, ids)
+}
+
+func TestStoreResetStalledDependencies(t *testing.T) {
+	db := setupStoreTest(t)
+
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO workerutil_test (id, state, last_heartbeat_at, num_resets)
+		VALUES
+			(1, 'processing', NOW() - '1 hour'::interval, 5),
+			(2, 'queued', NULL, 0)
+	`); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+
+	store := testStore(db, dependencyTestStoreOptions())
+	ctx := context.Background()
+	require.NoError(t, store.AddDependencies(ctx, 2, []RecordReference{{TableName: "workerutil_test", ID: 1}}))
+
+	_, failed, err := store.ResetStalled(ctx)
+	require.NoError(t, err)
+	require.Len(t, failed, 1)
+
+	dependencies, err := store.Dependencies(ctx, 2)
+	require.NoError(t, err)
+	require.Len(t, dependencies, 1)
+	require.Equal(t, "failed", dependencies[0].PrerequisiteState)
+}
+
+func TestStoreAddDependenciesCycle(t *testing.T) {
+	db := setupStoreTest(t)
+	store := testStore(db, dependencyTestStoreOptions())
+	ctx := context.Background()
+
+	require.NoError(t, store.AddDependencies(ctx, 1, []RecordReference{{TableName: "workerutil_test", ID: 2}}))
+	require.NoError(t, store.AddDependencies(ctx, 2, []RecordReference{{TableName: "workerutil_test", ID: 3}}))
+	// Adding the same dependency twice is a no-op.
+	require.NoError(t, store.AddDependencies(ctx, 2, []RecordReference{{TableName: "workerutil_test", ID: 3}}))
+
+	err := store.AddDependencies(ctx, 3, []RecordReference{{TableName: "other_table", ID: 4}, {TableName: "workerutil_test", ID: 1}})
+	require.ErrorIs(t, err, ErrDependencyCycle)
+	err = store.AddDependencies(ctx, 4, []RecordReference{{TableName: "workerutil_test", ID: 4}})
+	require.ErrorIs(t, err, ErrDependencyCycle)
+
+	// Nothing was added by the failed calls.
+	dependencies, err := store.Dependencies(ctx, 3)
+	require.NoError(t, err)
+	require.Len(t, dependencies, 2)
+}
+
+func TestStoreDependencies(t *testing.T) {
+	db := setupStoreTest(t)
+	store := testStore(db, dependencyTestStoreOptions())
+	ctx := context.Background()
+
+	// 1 -> 2 -> other_table:3, 4 -> 1 and 5 -> 6
+	require.NoError(t, store.AddDependencies(ctx, 1, []RecordReference{{TableName: "workerutil_test", ID: 2}}))
+	require.NoError(t, store.AddDependencies(ctx, 2, []RecordReference{{TableName: "other_table", ID: 3}}))
+	require.NoError(t, store.AddDependencies(ctx, 4, []RecordReference{{TableName: "workerutil_test", ID: 1}}))
+	require.NoError(t, store.AddDependencies(ctx, 5, []RecordReference{{TableName: "workerutil_test", ID: 6}}))
+
+	dependencies, err := store.Dependencies(ctx, 2)
+	require.NoError(t, err)
+
+	expected := []Dependency{
+		{
+			Dependent:         RecordReference{TableName: "workerutil_test", ID: 1},
+			Prerequisite:      RecordReference{TableName: "workerutil_test", ID: 2},
+			PrerequisiteState: "queued",
+		},
+		{
+			Dependent:         RecordReference{TableName: "workerutil_test", ID: 2},
+			Prerequisite:      RecordReference{TableName: "other_table", ID: 3},
+			PrerequisiteState: "queued",
+		},
+		{
+			Dependent:         RecordReference{TableName: "workerutil_test", ID: 4},
+			Prerequisite:      RecordReference{TableName: "workerutil_test", ID: 1},
+			PrerequisiteState: "queued",
+		},
+	}
+	if diff := cmp.Diff(expected, dependencies, cmpopts.IgnoreFields(Dependency{}, "UpdatedAt")); diff != "" {
+		t.Errorf("unexpected dependencies (-want +got):\n%s", diff)
+	}
+}
+
+func TestStoreAddDependenciesNotTracked(t *testing.T) {
+	db := setupStoreTest(t)
+	store := testStore(db, defaultTestStoreOptions(nil, testScanRecord))
+
+	err := store.AddDependencies(context.Background(), 1, []RecordReference{{TableName: "workerutil_test", ID: 2}})
+	require.EqualError(t, err, `store "test" does not track dependencies`)
+}
+
+func TestStoreRecordPrerequisiteStatesNotTracked(t *testing.T) {
+	markComplete := func(store *store[*TestRecord]) string {
+		q := store.formatQuery(markCompleteQuery, quote(store.options.TableName), sqlf.Sprintf("true"), store.updatedColumns("id"))
+		return store.recordPrerequisiteStates(q, "id").Query(sqlf.PostgresBindVar)
+	}
+
+	untracked := markComplete(testStore(nil, defaultTestStoreOptions(nil, testScanRecord)))
+	require.NotContains(t, untracked, "workerutil_job_dependencies")
+	require.NotContains(t, untracked, "WITH updated")
+	require.True(t, strings.HasSuffix(strings.TrimSpace(untracked), "RETURNING id AS id"), untracked)
+
+	tracked := markComplete(testStore(nil, dependencyTestStoreOptions()))
+	require.Contains(t, tracked, "workerutil_job_dependencies")
+	require.Contains(t, tracked, "RETURNING id AS id, state AS state")
+}
+
+func dependencyTestStoreOptions() Options[*TestRecord] {
+	options := defaultTestStoreOptions(nil, testScanRecord)
+	options.TrackDependencies = true
+	return options
+}
+
+type testRecordState struct {
+	State          string
+	FailureMessage string
+}
+
+func testRecordStates(t *testing.T, db *sql.DB) map[int]testRecordState {
+	t.Helper()
+
+	rows, err := db.QueryContext(context.Background(), `SELECT id, state, COALESCE(failure_message, '') FROM workerutil_test`)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	states := map[int]testRecordState{}
+	for rows.Next() {
+		var id int
+		var state testRecordState
+		require.NoError(t, rows.Scan(&id, &state.State, &state.FailureMessage))
+		states[id] = state
+	}
+	require.NoError(t, rows.Err())
+
+	return states
+}
@@ -23,9 +23,15 @@ import (
 // github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store)
 // used for unit testing.
 type MockStore[T workerutil.Record] struct {
+	// AddDependenciesFunc is an instance of a mock function object
+	// controlling the behavior of the method AddDependencies.
+	AddDependenciesFunc *StoreAddDependenciesFunc[T]
 	// AddExecutionLogEntryFunc is an instance of a mock function object
 	// controlling the behavior of the method AddExecutionLogEntry.
 	AddExecutionLogEntryFunc *StoreAddExecutionLogEntryFunc[T]
+	// DependenciesFunc is an instance of a mock function object controlling
+	// the behavior of the method Dependencies.
+	DependenciesFunc *StoreDependenciesFunc[T]
 	// DequeueFunc is an instance of a mock function object controlling the
 	// behavior of the method Dequeue.
 	DequeueFunc *StoreDequeueFunc[T]
@@ -47,15 +53,24 @@ type MockStore[T workerutil.Record] struct {
 	// MaxDurationInQueueFunc is an instance of a mock function object
 	// controlling the behavior of the method MaxDurationInQueue.
 	MaxDurationInQueueFunc *StoreMaxDurationInQueueFunc[T]
+	// QueueStatsByPartitionFunc is an instance of a mock function object
+	// controlling the behavior of the method QueueStatsByPartition.
+	QueueStatsByPartitionFunc *StoreQueueStatsByPartitionFunc[T]
 	// QueuedCountFunc is an instance of a mock function object controlling
 	// the behavior of the method QueuedCount.
 	QueuedCountFunc *StoreQueuedCountFunc[T]
+	// ReleaseFunc is an instance of a mock function object controlling the
+	// behavior of the method Release.
+	ReleaseFunc *StoreReleaseFunc[T]
 	// RequeueFunc is an instance of a mock function object controlling the
 	// behavior of the method Requeue.
 	RequeueFunc *StoreRequeueFunc[T]
 	// ResetStalledFunc is an instance of a mock function object controlling
 	// the behavior of the method ResetStalled.
 	ResetStalledFunc *StoreResetStalledFunc[T]
+	// ResolveDependenciesFunc is an instance of a mock function object
+	// controlling the behavior of the method ResolveDependencies.
+	ResolveDependenciesFunc *StoreResolveDependenciesFunc[T]
 	// UpdateExecutionLogEntryFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdateExecutionLogEntry.
 	UpdateExecutionLogEntryFunc *StoreUpdateExecutionLogEntryFunc[T]
@@ -68,11 +83,21 @@ type MockStore[T workerutil.Record] struct {
 // return zero values for all results, unless overwritten.
 func NewMockStore[T workerutil.Record]() *MockStore[T] {
 	return &MockStore[T]{
+		AddDependenciesFunc: &StoreAddDependenciesFunc[T]{
+			defaultHook: func(context.Context, int, []store.RecordReference) (r0 error) {
+				return
+			},
+		},
 		AddExecutionLogEntryFunc: &StoreAddExecutionLogEntryFunc[T]{
 			defaultHook: func(context.Context, int, executor.ExecutionLogEntry, store.ExecutionLogEntryOptions) (r0 int, r1 error) {
 				return
 			},
 		},
+		DependenciesFunc: &StoreDependenciesFunc[T]{
+			defaultHook: func(context.Context, int) (r0 []store.Dependency, r1 error) {
+				return
+			},
+		},
 		DequeueFunc: &StoreDequeueFunc[T]{
 			defaultHook: func(context.Context, string, []*sqlf.Query) (r0 T, r1 bool, r2 error) {
 				return
@@ -108,11 +133,21 @@ func NewMockStore[T workerutil.Record]() *MockStore[T] {
 				return
 			},
 		},
+		QueueStatsByPartitionFunc: &StoreQueueStatsByPartitionFunc[T]{
+			defaultHook: func(context.Context, int) (r0 []store.PartitionQueueStats, r1 error) {
+				return
+			},
+		},
 		QueuedCountFunc: &StoreQueuedCountFunc[T]{
 			defaultHook: func(context.Context, bool) (r0 int, r1 error) {
 				return
 			},
 		},
+		ReleaseFunc: &StoreReleaseFunc[T]{
+			defaultHook: func(context.Context, int, time.Time) (r0 error) {
+				return
+			},
+		},
 		RequeueFunc: &StoreRequeueFunc[T]{
 			defaultHook: func(context.Context, int, time.Time) (r0 error) {
 				return
@@ -123,6 +158,11 @@ func NewMockStore[T workerutil.Record]() *MockStore[T] {
 				return
 			},
 		},
+		ResolveDependenciesFunc: &StoreResolveDependenciesFunc[T]{
+			defaultHook: func(context.Context) (r0 []int, r1 error) {
+				return
+			},
+		},
 		UpdateExecutionLogEntryFunc: &StoreUpdateExecutionLogEntryFunc[T]{
 			defaultHook: func(context.Context, int, int, executor.ExecutionLogEntry, store.ExecutionLogEntryOptions) (r0 error) {
 				return
@@ -140,11 +180,21 @@ func NewMockStore[T workerutil.Record]() *MockStore[T] {
 // panic on invocation, unless overwritten.
 func NewStrictMockStore[T workerutil.Record]() *MockStore[T] {
 	return &MockStore[T]{
+		AddDependenciesFunc: &StoreAddDependenciesFunc[T]{
+			defaultHook: func(context.Context, int, []store.RecordReference) error {
+				panic("unexpected invocation of MockStore.AddDependencies")
+			},
+		},
 		AddExecutionLogEntryFunc: &StoreAddExecutionLogEntryFunc[T]{
 			defaultHook: func(context.Context, int, executor.ExecutionLogEntry, store.ExecutionLogEntryOptions) (int, error) {
 				panic("unexpected invocation of MockStore.AddExecutionLogEntry")
 			},
 		},
+		DependenciesFunc: &StoreDependenciesFunc[T]{
+			defaultHook: func(context.Context, int) ([]store.Dependency, error) {
+				panic("unexpected invocation of MockStore.Dependencies")
+			},
+		},
 		DequeueFunc: &StoreDequeueFunc[T]{
 			defaultHook: func(context.Context, string, []*sqlf.Query) (T, bool, error) {
 				panic("unexpected invocation of MockStore.Dequeue")
@@ -180,11 +230,21 @@ func NewStrictMockStore[T workerutil.Record]() *MockStore[T] {
 				panic("unexpected invocation of MockStore.MaxDurationInQueue")
 			},
 		},
+		QueueStatsByPartitionFunc: &StoreQueueStatsByPartitionFunc[T]{
+			defaultHook: func(context.Context, int) ([]store.PartitionQueueStats, error) {
+				panic("unexpected invocation of MockStore.QueueStatsByPartition")
+			},
+		},
 		QueuedCountFunc: &StoreQueuedCountFunc[T]{
 			defaultHook: func(context.Context, bool) (int, error) {
 				panic("unexpected invocation of MockStore.QueuedCount")
 			},
 		},
+		ReleaseFunc: &StoreReleaseFunc[T]{
+			defaultHook: func(context.Context, int, time.Time) error {
+				panic("unexpected invocation of MockStore.Release")
+			},
+		},
 		RequeueFunc: &StoreRequeueFunc[T]{
 			defaultHook: func(context.Context, int, time.Time) error {
 				panic("unexpected invocation of MockStore.Requeue")
@@ -195,6 +255,11 @@ func NewStrictMockStore[T workerutil.Record]() *MockStore[T] {
 				panic("unexpected invocation of MockStore.ResetStalled")
 			},
 		},
+		ResolveDependenciesFunc: &StoreResolveDependenciesFunc[T]{
+			defaultHook: func(context.Context) ([]int, error) {
+				panic("unexpected invocation of MockStore.ResolveDependencies")
+			},
+		},
 		UpdateExecutionLogEntryFunc: &StoreUpdateExecutionLogEntryFunc[T]{
 			defaultHook: func(context.Context, int, int, executor.ExecutionLogEntry, store.ExecutionLogEntryOptions) error {
 				panic("unexpected invocation of MockStore.UpdateExecutionLogEntry")
@@ -212,9 +277,15 @@ func NewStrictMockStore[T workerutil.Record]() *MockStore[T] {
 // methods delegate to the given implementation, unless overwritten.
 func NewMockStoreFrom[T workerutil.Record](i store.Store[T]) *MockStore[T] {
 	return &MockStore[T]{
+		AddDependenciesFunc: &StoreAddDependenciesFunc[T]{
+			defaultHook: i.AddDependencies,
+		},
 		AddExecutionLogEntryFunc: &StoreAddExecutionLogEntryFunc[T]{
 			defaultHook: i.AddExecutionLogEntry,
 		},
+		DependenciesFunc: &StoreDependenciesFunc[T]{
+			defaultHook: i.Dependencies,
+		},
 		DequeueFunc: &StoreDequeueFunc[T]{
 			defaultHook: i.Dequeue,
 		},
@@ -236,15 +307,24 @@ func NewMockStoreFrom[T workerutil.Record](i store.Store[T]) *MockStore[T] {
 		MaxDurationInQueueFunc: &StoreMaxDurationInQueueFunc[T]{
 			defaultHook: i.MaxDurationInQueue,
 		},
+		QueueStatsByPartitionFunc: &StoreQueueStatsByPartitionFunc[T]{
+			defaultHook: i.QueueStatsByPartition,
+		},
 		QueuedCountFunc: &StoreQueuedCountFunc[T]{
 			defaultHook: i.QueuedCount,
 		},
+		ReleaseFunc: &StoreReleaseFunc[T]{
+			defaultHook: i.Release,
+		},
 		RequeueFunc: &StoreRequeueFunc[T]{
 			defaultHook: i.Requeue,
 		},
 		ResetStalledFunc: &StoreResetStalledFunc[T]{
 			defaultHook: i.ResetStalled,
 		},
+		ResolveDependenciesFunc: &StoreResolveDependenciesFunc[T]{
+			defaultHook: i.ResolveDependencies,
+		},
 		UpdateExecutionLogEntryFunc: &StoreUpdateExecutionLogEntryFunc[T]{
 			defaultHook: i.UpdateExecutionLogEntry,
 		},
@@ -254,6 +334,114 @@ func NewMockStoreFrom[T workerutil.Record](i store.Store[T]) *MockStore[T] {
 	}
 }
 
+// StoreAddDependenciesFunc describes the behavior when the AddDependencies
+// method of the parent MockStore instance is invoked.
+type StoreAddDependenciesFunc[T workerutil.Record] struct {
+	defaultHook func(context.Context, int, []store.RecordReference) error
+	hooks       []func(context.Context, int, []store.RecordReference) error
+	history     []StoreAddDependenciesFuncCall[T]
+	mutex       sync.Mutex
+}
+
+// AddDependencies delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockStore[T]) AddDependencies(v0 context.Context, v1 int, v2 []store.RecordReference) error {
+	r0 := m.AddDependenciesFunc.nextHook()(v0, v1, v2)
+	m.AddDependenciesFunc.appendCall(StoreAddDependenciesFuncCall[T]{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the AddDependencies
+// method of the parent MockStore instance is invoked and the hook queue is
+// empty.
+func (f *StoreAddDependenciesFunc[T]) SetDefaultHook(hook func(context.Context, int, []store.RecordReference) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// AddDependencies method of the parent MockStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *StoreAddDependenciesFunc[T]) PushHook(hook func(context.Context, int, []store.RecordReference) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreAddDependenciesFunc[T]) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, []store.RecordReference) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreAddDependenciesFunc[T]) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, []store.RecordReference) error {
+		return r0
+	})
+}
+
+func (f *StoreAddDependenciesFunc[T]) nextHook() func(context.Context, int, []store.RecordReference) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreAddDependenciesFunc[T]) appendCall(r0 StoreAddDependenciesFuncCall[T]) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreAddDependenciesFuncCall objects
+// describing the invocations of this function.
+func (f *StoreAddDependenciesFunc[T]) History() []StoreAddDependenciesFuncCall[T] {
+	f.mutex.Lock()
+	history := make([]StoreAddDependenciesFuncCall[T], len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreAddDependenciesFuncCall is an object that describes an invocation of
+// method AddDependencies on an instance of MockStore.
+type StoreAddDependenciesFuncCall[T workerutil.Record] struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []store.RecordReference
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreAddDependenciesFuncCall[T]) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreAddDependenciesFuncCall[T]) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // StoreAddExecutionLogEntryFunc describes the behavior when the
 // AddExecutionLogEntry method of the parent MockStore instance is invoked.
 type StoreAddExecutionLogEntryFunc[T workerutil.Record] struct {
@@ -368,6 +556,113 @@ func (c StoreAddExecutionLogEntryFuncCall[T]) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreDependenciesFunc describes the behavior when the Dependencies method
+// of the parent MockStore instance is invoked.
+type StoreDependenciesFunc[T workerutil.Record] struct {
+	defaultHook func(context.Context, int) ([]store.Dependency, error)
+	hooks       []func(context.Context, int) ([]store.Dependency, error)
+	history     []StoreDependenciesFuncCall[T]
+	mutex       sync.Mutex
+}
+
+// Dependencies delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockStore[T]) Dependencies(v0 context.Context, v1 int) ([]store.Dependency, error) {
+	r0, r1 := m.DependenciesFunc.nextHook()(v0, v1)
+	m.DependenciesFunc.appendCall(StoreDependenciesFuncCall[T]{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Dependencies method
+// of the parent MockStore instance is invoked and the hook queue is empty.
+func (f *StoreDependenciesFunc[T]) SetDefaultHook(hook func(context.Context, int) ([]store.Dependency, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Dependencies method of the parent MockStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *StoreDependenciesFunc[T]) PushHook(hook func(context.Context, int) ([]store.Dependency, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreDependenciesFunc[T]) SetDefaultReturn(r0 []store.Dependency, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) ([]store.Dependency, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreDependenciesFunc[T]) PushReturn(r0 []store.Dependency, r1 error) {
+	f.PushHook(func(context.Context, int) ([]store.Dependency, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreDependenciesFunc[T]) nextHook() func(context.Context, int) ([]store.Dependency, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreDependenciesFunc[T]) appendCall(r0 StoreDependenciesFuncCall[T]) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreDependenciesFuncCall objects
+// describing the invocations of this function.
+func (f *StoreDependenciesFunc[T]) History() []StoreDependenciesFuncCall[T] {
+	f.mutex.Lock()
+	history := make([]StoreDependenciesFuncCall[T], len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreDependenciesFuncCall is an object that describes an invocation of
+// method Dependencies on an instance of MockStore.
+type StoreDependenciesFuncCall[T workerutil.Record] struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []store.Dependency
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreDependenciesFuncCall[T]) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreDependenciesFuncCall[T]) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // StoreDequeueFunc describes the behavior when the Dequeue method of the
 // parent MockStore instance is invoked.
 type StoreDequeueFunc[T workerutil.Record] struct {
@@ -1133,6 +1428,114 @@ func (c StoreMaxDurationInQueueFuncCall[T]) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreQueueStatsByPartitionFunc describes the behavior when the
+// QueueStatsByPartition method of the parent MockStore instance is invoked.
+type StoreQueueStatsByPartitionFunc[T workerutil.Record] struct {
+	defaultHook func(context.Context, int) ([]store.PartitionQueueStats, error)
+	hooks       []func(context.Context, int) ([]store.PartitionQueueStats, error)
+	history     []StoreQueueStatsByPartitionFuncCall[T]
+	mutex       sync.Mutex
+}
+
+// QueueStatsByPartition delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockStore[T]) QueueStatsByPartition(v0 context.Context, v1 int) ([]store.PartitionQueueStats, error) {
+	r0, r1 := m.QueueStatsByPartitionFunc.nextHook()(v0, v1)
+	m.QueueStatsByPartitionFunc.appendCall(StoreQueueStatsByPartitionFuncCall[T]{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// QueueStatsByPartition method of the parent MockStore instance is invoked
+// and the hook queue is empty.
+func (f *StoreQueueStatsByPartitionFunc[T]) SetDefaultHook(hook func(context.Context, int) ([]store.PartitionQueueStats, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// QueueStatsByPartition method of the parent MockStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *StoreQueueStatsByPartitionFunc[T]) PushHook(hook func(context.Context, int) ([]store.PartitionQueueStats, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreQueueStatsByPartitionFunc[T]) SetDefaultReturn(r0 []store.PartitionQueueStats, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) ([]store.PartitionQueueStats, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreQueueStatsByPartitionFunc[T]) PushReturn(r0 []store.PartitionQueueStats, r1 error) {
+	f.PushHook(func(context.Context, int) ([]store.PartitionQueueStats, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreQueueStatsByPartitionFunc[T]) nextHook() func(context.Context, int) ([]store.PartitionQueueStats, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreQueueStatsByPartitionFunc[T]) appendCall(r0 StoreQueueStatsByPartitionFuncCall[T]) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreQueueStatsByPartitionFuncCall objects
+// describing the invocations of this function.
+func (f *StoreQueueStatsByPartitionFunc[T]) History() []StoreQueueStatsByPartitionFuncCall[T] {
+	f.mutex.Lock()
+	history := make([]StoreQueueStatsByPartitionFuncCall[T], len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreQueueStatsByPartitionFuncCall is an object that describes an
+// invocation of method QueueStatsByPartition on an instance of MockStore.
+type StoreQueueStatsByPartitionFuncCall[T workerutil.Record] struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []store.PartitionQueueStats
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreQueueStatsByPartitionFuncCall[T]) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreQueueStatsByPartitionFuncCall[T]) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // StoreQueuedCountFunc describes the behavior when the QueuedCount method
 // of the parent MockStore instance is invoked.
 type StoreQueuedCountFunc[T workerutil.Record] struct {
@@ -1240,6 +1643,113 @@ func (c StoreQueuedCountFuncCall[T]) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreReleaseFunc describes the behavior when the Release method of the
+// parent MockStore instance is invoked.
+type StoreReleaseFunc[T workerutil.Record] struct {
+	defaultHook func(context.Context, int, time.Time) error
+	hooks       []func(context.Context, int, time.Time) error
+	history     []StoreReleaseFuncCall[T]
+	mutex       sync.Mutex
+}
+
+// Release delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockStore[T]) Release(v0 context.Context, v1 int, v2 time.Time) error {
+	r0 := m.ReleaseFunc.nextHook()(v0, v1, v2)
+	m.ReleaseFunc.appendCall(StoreReleaseFuncCall[T]{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Release method of
+// the parent MockStore instance is invoked and the hook queue is empty.
+func (f *StoreReleaseFunc[T]) SetDefaultHook(hook func(context.Context, int, time.Time) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Release method of the parent MockStore instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *StoreReleaseFunc[T]) PushHook(hook func(context.Context, int, time.Time) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreReleaseFunc[T]) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, time.Time) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreReleaseFunc[T]) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, time.Time) error {
+		return r0
+	})
+}
+
+func (f *StoreReleaseFunc[T]) nextHook() func(context.Context, int, time.Time) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreReleaseFunc[T]) appendCall(r0 StoreReleaseFuncCall[T]) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreReleaseFuncCall objects describing the
+// invocations of this function.
+func (f *StoreReleaseFunc[T]) History() []StoreReleaseFuncCall[T] {
+	f.mutex.Lock()
+	history := make([]StoreReleaseFuncCall[T], len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreReleaseFuncCall is an object that describes an invocation of method
+// Release on an instance of MockStore.
+type StoreReleaseFuncCall[T workerutil.Record] struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 time.Time
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreReleaseFuncCall[T]) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreReleaseFuncCall[T]) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // StoreRequeueFunc describes the behavior when the Requeue method of the
 // parent MockStore instance is invoked.
 type StoreRequeueFunc[T workerutil.Record] struct {
@@ -1454,6 +1964,111 @@ func (c StoreResetStalledFuncCall[T]) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// StoreResolveDependenciesFunc describes the behavior when the
+// ResolveDependencies method of the parent MockStore instance is invoked.
+type StoreResolveDependenciesFunc[T workerutil.Record] struct {
+	defaultHook func(context.Context) ([]int, error)
+	hooks       []func(context.Context) ([]int, error)
+	history     []StoreResolveDependenciesFuncCall[T]
+	mutex       sync.Mutex
+}
+
+// ResolveDependencies delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockStore[T]) ResolveDependencies(v0 context.Context) ([]int, error) {
+	r0, r1 := m.ResolveDependenciesFunc.nextHook()(v0)
+	m.ResolveDependenciesFunc.appendCall(StoreResolveDependenciesFuncCall[T]{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ResolveDependencies
+// method of the parent MockStore instance is invoked and the hook queue is
+// empty.
+func (f *StoreResolveDependenciesFunc[T]) SetDefaultHook(hook func(context.Context) ([]int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ResolveDependencies method of the parent MockStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *StoreResolveDependenciesFunc[T]) PushHook(hook func(context.Context) ([]int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreResolveDependenciesFunc[T]) SetDefaultReturn(r0 []int, r1 error) {
+	f.SetDefaultHook(func(context.Context) ([]int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreResolveDependenciesFunc[T]) PushReturn(r0 []int, r1 error) {
+	f.PushHook(func(context.Context) ([]int, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreResolveDependenciesFunc[T]) nextHook() func(context.Context) ([]int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreResolveDependenciesFunc[T]) appendCall(r0 StoreResolveDependenciesFuncCall[T]) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreResolveDependenciesFuncCall objects
+// describing the invocations of this function.
+func (f *StoreResolveDependenciesFunc[T]) History() []StoreResolveDependenciesFuncCall[T] {
+	f.mutex.Lock()
+	history := make([]StoreResolveDependenciesFuncCall[T], len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreResolveDependenciesFuncCall is an object that describes an
+// invocation of method ResolveDependencies on an instance of MockStore.
+type StoreResolveDependenciesFuncCall[T workerutil.Record] struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreResolveDependenciesFuncCall[T]) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreResolveDependenciesFuncCall[T]) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // StoreUpdateExecutionLogEntryFunc describes the behavior when the
 // UpdateExecutionLogEntry method of the parent MockStore instance is
 // invoked.
@@ -9,7 +9,9 @@ import (
 )
 
 type operations struct {
+	addDependencies         *observation.Operation
 	addExecutionLogEntry    *observation.Operation
+	dependencies            *observation.Operation
 	dequeue                 *observation.Operation
 	heartbeat               *observation.Operation
 	markComplete            *observation.Operation
@@ -17,8 +19,11 @@ type operations struct {
 	markFailed              *observation.Operation
 	maxDurationInQueue      *observation.Operation
 	queuedCount             *observation.Operation
+	queueStatsByPartition   *observation.Operation
 	requeue                 *observation.Operation
+	release                 *observation.Operation
 	resetStalled            *observation.Operation
+	resolveDependencies     *observation.Operation
 	updateExecutionLogEntry *observation.Operation
 	canceledJobs            *observation.Operation
 }
@@ -58,7 +63,9 @@ func newOperations(observationCtx *observation.Context, storeName string) *opera
 	}
 
 	return &operations{
+		addDependencies:         op("AddDependencies"),
 		addExecutionLogEntry:    op("AddExecutionLogEntry"),
+		dependencies:            op("Dependencies"),
 		dequeue:                 op("Dequeue"),
 		heartbeat:               op("Heartbeat"),
 		markComplete:            op("MarkComplete"),
@@ -66,8 +73,11 @@ func newOperations(observationCtx *observation.Context, storeName string) *opera
 		markFailed:              op("MarkFailed"),
 		maxDurationInQueue:      op("MaxDurationInQueue"),
 		queuedCount:             op("QueuedCount"),
+		queueStatsByPartition:   op("QueueStatsByPartition"),
 		requeue:                 op("Requeue"),
+		release:                 op("Release"),
 		resetStalled:            op("ResetStalled"),
+		resolveDependencies:     op("ResolveDependencies"),
 		updateExecutionLogEntry: op("UpdateExecutionLogEntry"),
 		canceledJobs:            op("CanceledJobs"),
 	}
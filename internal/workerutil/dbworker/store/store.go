@@ -3,6 +3,7 @@ package store
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
@@ -88,6 +89,11 @@ type Store[T workerutil.Record] interface {
 	// MaxDurationInQueue returns the maximum age of queued records in this store. Returns 0 if there are no queued records.
 	MaxDurationInQueue(ctx context.Context) (time.Duration, error)
 
+	// QueueStatsByPartition returns the number of queued and errored records and the maximum age of queued records
+	// of the partitions with the most queued records, at most limit of them. Returns nil if the store is not
+	// partitioned.
+	QueueStatsByPartition(ctx context.Context, limit int) ([]PartitionQueueStats, error)
+
 	// Dequeue selects the first queued record matching the given conditions and updates the state to processing. If there
 	// is such a record, it is returned. If there is no such unclaimed record, a nil record and a nil cancel function
 	// will be returned along with a false-valued flag. This method must not be called from within a transaction.
@@ -103,6 +109,11 @@ type Store[T workerutil.Record] interface {
 	// the next dequeue of this record can be performed.
 	Requeue(ctx context.Context, id int, after time.Time) error
 
+	// Release moves a record dequeued by the caller back to the queued state, so that another worker can
+	// dequeue it. Unlike Requeue, the record keeps its position in the queue and its cancellation flag. If
+	// after is non-zero, the record cannot be dequeued again before that time.
+	Release(ctx context.Context, id int, after time.Time) error
+
 	// AddExecutionLogEntry adds an executor log entry to the record and returns the ID of the new entry (which can be
 	// used with UpdateExecutionLogEntry) and a possible error. When the record is not found (due to options not matching
 	// or the record being deleted), ErrExecutionLogEntryNotUpdated is returned.
@@ -133,6 +144,23 @@ type Store[T workerutil.Record] interface {
 	// identifiers the age of the record's last heartbeat timestamp for each record reset to queued and failed states,
 	// respectively.
 	ResetStalled(ctx context.Context) (resetLastHeartbeatsByIDs, failedLastHeartbeatsByIDs map[int]time.Duration, err error)
+
+	// AddDependencies declares that the record with the given identifier must not be dequeued before
+	// the given prerequisite records have completed. Prerequisites may belong to other stores, which
+	// must also track dependencies. If the dependencies would create a cycle, ErrDependencyCycle is
+	// returned and no dependency is added.
+	AddDependencies(ctx context.Context, id int, prerequisites []RecordReference) error
+
+	// Dependencies returns the edges of the dependency graph of the record with the given identifier:
+	// its prerequisites and dependents, and theirs, transitively.
+	Dependencies(ctx context.Context, id int) ([]Dependency, error)
+
+	// ResolveDependencies records the live state of the prerequisites of this store that were moved to
+	// a terminal state or deleted without the store, and moves the queued and errored records of this
+	// store whose prerequisites failed, were canceled or deleted to the failed or canceled state. It
+	// returns the identifiers of the moved records. Stores that track dependencies must call it
+	// periodically, see dbworker.NewDependencyResolver.
+	ResolveDependencies(ctx context.Context) ([]int, error)
 }
 
 type store[T workerutil.Record] struct {
@@ -205,6 +233,19 @@ type Options[T workerutil.Record] struct {
 	// supplied.
 	OrderByExpression *sqlf.Query
 
+	// PartitionByExpression is an optional SQL expression that partitions records, by user or repository
+	// for example. When supplied, records are dequeued fairly across partitions instead of strictly by
+	// `OrderByExpression`: the next record is taken from the partition with the fewest records that are
+	// processing or were picked before it, relative to the weight of the partition. Within a partition,
+	// records are dequeued by `OrderByExpression`. This expression may use the alias provided in
+	// `ViewName`, if one was supplied.
+	PartitionByExpression *sqlf.Query
+
+	// PartitionWeights are the weights of partitions, keyed by the value of `PartitionByExpression`
+	// cast to text. Partitions get a share of the records being processed proportional to their
+	// weight. Partitions without a positive weight have a weight of 1.
+	PartitionWeights map[string]float64
+
 	// ColumnExpressions are the target columns provided to the query when selecting a job record. These
 	// expressions may use the alias provided in `ViewName`, if one was supplied.
 	ColumnExpressions []*sqlf.Query
@@ -238,6 +279,26 @@ type Options[T workerutil.Record] struct {
 	// Setting this value to zero will disable retries entirely.
 	MaxNumRetries int
 
+	// TrackDependencies enables dependencies between records, declared with AddDependencies. Records
+	// are only dequeued once all of their prerequisites have completed. Records whose prerequisites
+	// failed, were canceled or deleted are moved to the failed or canceled state by
+	// ResolveDependencies, which must be called periodically.
+	//
+	// The store keeps the dependency graph in the workerutil_job_dependencies table, which must exist
+	// in the database of the store. Records are identified by their table name. The stores of all
+	// records of a dependency graph must track dependencies and share a database.
+	TrackDependencies bool
+
+	// NotifyChannel is an optional notification channel on which the store emits a NOTIFY, with the
+	// table name as payload, when it moves records back to the queued state (via `Requeue` or
+	// `ResetStalled`). Workers can LISTEN on this channel to wake up as soon as work is available
+	// instead of waiting for their next poll. Notifications are delivered once the surrounding
+	// transaction, if any, commits.
+	//
+	// The store does not insert records. To notify on insert, add a statement-level trigger executing
+	// the workerutil_notify_queued function with the channel as argument to the table.
+	NotifyChannel string
+
 	// clock is used to mock out the wall clock used for heartbeat updates.
 	clock glock.Clock
 }
@@ -442,6 +503,75 @@ oldest_record AS (
 SELECT EXTRACT(EPOCH FROM NOW() - last_queued_at)::integer AS age FROM oldest_record
 `
 
+// PartitionQueueStats are the statistics of the queued records of a partition of a store.
+type PartitionQueueStats struct {
+	// Partition is the value of the partition expression of the store, cast to text.
+	Partition string
+	// Count is the number of queued and errored records of the partition.
+	Count int
+	// MaxDurationInQueue is the maximum age of the queued records of the partition that are ready
+	// for processing.
+	MaxDurationInQueue time.Duration
+}
+
+// QueueStatsByPartition returns the number of queued and errored records and the maximum age of queued records
+// of the partitions with the most queued records, at most limit of them. Returns nil if the store is not
+// partitioned.
+func (s *store[T]) QueueStatsByPartition(ctx context.Context, limit int) (_ []PartitionQueueStats, err error) {
+	if s.options.PartitionByExpression == nil {
+		return nil, nil
+	}
+
+	ctx, _, endObservation := s.operations.queueStatsByPartition.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	now := s.now()
+	retryAfter := int(s.options.RetryAfter / time.Second)
+
+	return scanPartitionQueueStats(s.Query(ctx, s.formatQuery(
+		queueStatsByPartitionQuery,
+		now,
+		s.options.PartitionByExpression,
+		now,
+		retryAfter,
+		now,
+		retryAfter,
+		retryAfter,
+		quote(s.options.ViewName),
+		limit,
+	)))
+}
+
+const queueStatsByPartitionQuery = `
+SELECT
+	partition_key,
+	COUNT(*),
+	COALESCE(EXTRACT(EPOCH FROM %s - MIN(last_queued_at))::integer, 0)
+FROM (
+	SELECT
+		COALESCE((%s)::text, '') AS partition_key,
+		CASE
+			-- Select when the record was most recently dequeueable
+			WHEN {state} = 'queued' AND ({process_after} IS NULL OR {process_after} <= %s)
+				THEN GREATEST({queued_at}, {process_after})
+			WHEN %s > 0 AND {state} = 'errored' AND %s - {finished_at} > (%s * '1 second'::interval)
+				THEN {finished_at} + (%s * '1 second'::interval)
+		END AS last_queued_at
+	FROM %s
+	WHERE {state} IN ('queued', 'errored')
+) queued
+GROUP BY partition_key
+ORDER BY COUNT(*) DESC, partition_key
+LIMIT %s
+`
+
+var scanPartitionQueueStats = basestore.NewSliceScanner(func(s dbutil.Scanner) (stats PartitionQueueStats, err error) {
+	var ageInSeconds int
+	err = s.Scan(&stats.Partition, &stats.Count, &ageInSeconds)
+	stats.MaxDurationInQueue = time.Duration(ageInSeconds) * time.Second
+	return stats, err
+})
+
 // columnsUpdatedByDequeue are the unmapped column names modified by the dequeue method.
 var columnsUpdatedByDequeue = []string{
 	"state",
@@ -492,16 +622,13 @@ func (s *store[T]) Dequeue(ctx context.Context, workerHostname string, condition
 		s.columnReplacer.Replace("{worker_hostname}"):   workerHostnameExpr,
 	}
 
+	if s.options.TrackDependencies {
+		conditions = append(conditions, s.formatQuery(prerequisitesCompletedCondition, s.options.TableName))
+	}
+
 	records, err := s.options.Scan(s.Query(ctx, s.formatQuery(
 		dequeueQuery,
-		s.options.OrderByExpression,
-		quote(s.options.ViewName),
-		now,
-		retryAfter,
-		now,
-		retryAfter,
-		makeConditionSuffix(conditions),
-		s.options.OrderByExpression,
+		s.makePotentialCandidatesQuery(now, retryAfter, conditions),
 		quote(s.options.TableName),
 		quote(s.options.TableName),
 		quote(s.options.TableName),
@@ -524,26 +651,7 @@ func (s *store[T]) Dequeue(ctx context.Context, workerHostname string, condition
 }
 
 const dequeueQuery = `
-WITH potential_candidates AS (
-	SELECT
-		{id} AS candidate_id,
-		ROW_NUMBER() OVER (ORDER BY %s) AS order
-	FROM %s
-	WHERE
-		(
-			(
-				{state} = 'queued' AND
-				({process_after} IS NULL OR {process_after} <= %s)
-			) OR (
-				%s > 0 AND
-				{state} = 'errored' AND
-				%s - {finished_at} > (%s * '1 second'::interval)
-			)
-		)
-		%s
-	ORDER BY %s
-	LIMIT 50
-),
+WITH %s,
 candidate AS (
 	SELECT
 		{id} FROM %s
@@ -571,6 +679,159 @@ WHERE
 	{id} IN (SELECT {id} FROM candidate)
 `
 
+// makePotentialCandidatesQuery constructs the potential_candidates CTE of the dequeue query, which
+// selects the first dequeueable records matching the given conditions in the order in which they
+// should be dequeued.
+func (s *store[T]) makePotentialCandidatesQuery(now time.Time, retryAfter int, conditions []*sqlf.Query) *sqlf.Query {
+	if s.options.PartitionByExpression == nil {
+		return s.formatQuery(
+			potentialCandidatesQuery,
+			s.options.OrderByExpression,
+			quote(s.options.ViewName),
+			now,
+			retryAfter,
+			now,
+			retryAfter,
+			makeConditionSuffix(conditions),
+			s.options.OrderByExpression,
+		)
+	}
+
+	dequeueable := s.formatQuery(dequeueableCondition, now, retryAfter, now, retryAfter)
+	conditionSuffix := makeConditionSuffix(conditions)
+
+	return s.formatQuery(
+		fairPotentialCandidatesQuery,
+		s.options.PartitionByExpression,
+		quote(s.options.ViewName),
+		dequeueable,
+		conditionSuffix,
+		s.options.OrderByExpression,
+		s.options.OrderByExpression,
+		quote(s.options.ViewName),
+		dequeueable,
+		s.options.PartitionByExpression,
+		s.options.PartitionByExpression,
+		conditionSuffix,
+		s.options.OrderByExpression,
+		quote(s.options.ViewName),
+		s.options.PartitionByExpression,
+		quote(s.options.ViewName),
+		s.partitionWeights(),
+	)
+}
+
+// partitionWeights returns the positive partition weights as a JSON object.
+func (s *store[T]) partitionWeights() string {
+	weights := make(map[string]float64, len(s.options.PartitionWeights))
+	for partition, weight := range s.options.PartitionWeights {
+		if weight > 0 {
+			weights[partition] = weight
+		}
+	}
+
+	serialized, _ := json.Marshal(weights)
+	return string(serialized)
+}
+
+const potentialCandidatesQuery = `
+potential_candidates AS (
+	SELECT
+		{id} AS candidate_id,
+		ROW_NUMBER() OVER (ORDER BY %s) AS order
+	FROM %s
+	WHERE
+		(
+			(
+				{state} = 'queued' AND
+				({process_after} IS NULL OR {process_after} <= %s)
+			) OR (
+				%s > 0 AND
+				{state} = 'errored' AND
+				%s - {finished_at} > (%s * '1 second'::interval)
+			)
+		)
+		%s
+	ORDER BY %s
+	LIMIT 50
+)
+`
+
+// dequeueableCondition matches the records that are queued, or errored and ready to be retried.
+const dequeueableCondition = `
+(
+	(
+		{state} = 'queued' AND
+		({process_after} IS NULL OR {process_after} <= %s)
+	) OR (
+		%s > 0 AND
+		{state} = 'errored' AND
+		%s - {finished_at} > (%s * '1 second'::interval)
+	)
+)
+`
+
+// fairPotentialCandidatesQuery interleaves the dequeueable records of all partitions. The share of a
+// record is the number of records of its partition that are processing or ranked before it, divided
+// by the weight of the partition. Records with the smallest share are dequeued first, which results
+// in a weighted round-robin across partitions that accounts for the records already processing.
+//
+// As shares only grow within a partition, the first 50 records by share are among the first 50
+// records of each partition, so only those are ranked instead of all dequeueable records.
+const fairPotentialCandidatesQuery = `
+partition_keys AS (
+	SELECT DISTINCT (%s) AS partition_value
+	FROM %s
+	WHERE %s %s
+),
+ranked_candidates AS (
+	SELECT
+		c.candidate_id,
+		c.partition_key,
+		c.partition_rank,
+		ROW_NUMBER() OVER (ORDER BY %s) AS global_rank
+	FROM partition_keys pk
+	JOIN LATERAL (
+		SELECT
+			{id} AS candidate_id,
+			pk.partition_value::text AS partition_key,
+			ROW_NUMBER() OVER (ORDER BY %s) AS partition_rank
+		FROM %s
+		WHERE
+			%s AND
+			((%s) = pk.partition_value OR (pk.partition_value IS NULL AND (%s) IS NULL))
+			%s
+		ORDER BY %s
+		LIMIT 50
+	) c ON true
+	JOIN %s ON {id} = c.candidate_id
+),
+partition_load AS (
+	SELECT
+		(%s)::text AS partition_key,
+		COUNT(*) AS num_processing
+	FROM %s
+	WHERE {state} = 'processing'
+	GROUP BY 1
+),
+fair_candidates AS (
+	SELECT
+		rc.candidate_id,
+		rc.global_rank,
+		(COALESCE(pl.num_processing, 0) + rc.partition_rank) / COALESCE((%s::jsonb ->> rc.partition_key)::float, 1) AS share
+	FROM ranked_candidates rc
+	LEFT JOIN partition_load pl ON pl.partition_key IS NOT DISTINCT FROM rc.partition_key
+),
+potential_candidates AS (
+	SELECT
+		candidate_id,
+		ROW_NUMBER() OVER (ORDER BY share, global_rank) AS order
+	FROM fair_candidates
+	ORDER BY share, global_rank
+	LIMIT 50
+)
+`
+
 // makeDequeueSelectExpressions constructs the ordered set of SQL expressions that are returned
 // from the dequeue query. This method returns a copy of the configured column expressions slice
 // where expressions referencing one of the column updated by dequeue are replaced by the updated
@@ -702,12 +963,21 @@ func (s *store[T]) Requeue(ctx context.Context, id int, after time.Time) (err er
 	}})
 	defer endObservation(1, observation.Args{})
 
-	return s.Exec(ctx, s.formatQuery(
+	if err := s.Exec(ctx, s.formatQuery(
 		requeueQuery,
 		quote(s.options.TableName),
 		after,
 		id,
-	))
+	)); err != nil {
+		return err
+	}
+
+	if after.After(s.now()) {
+		// Waking workers up would be of no use before the record can be dequeued.
+		return nil
+	}
+
+	return s.notify(ctx)
 }
 
 const requeueQuery = `
@@ -721,6 +991,42 @@ SET
 WHERE {id} = %s
 `
 
+// Release moves a record dequeued by the caller back to the queued state, so that another worker can
+// dequeue it. Unlike Requeue, the record keeps its position in the queue and its cancellation flag. If
+// after is non-zero, the record cannot be dequeued again before that time.
+func (s *store[T]) Release(ctx context.Context, id int, after time.Time) (err error) {
+	ctx, _, endObservation := s.operations.release.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("id", id),
+		attribute.Stringer("after", after),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if err := s.Exec(ctx, s.formatQuery(
+		releaseQuery,
+		quote(s.options.TableName),
+		dbutil.NullTimeColumn(after),
+		id,
+	)); err != nil {
+		return err
+	}
+
+	if after.After(s.now()) {
+		// Waking workers up would be of no use before the record can be dequeued.
+		return nil
+	}
+
+	return s.notify(ctx)
+}
+
+const releaseQuery = `
+UPDATE %s
+SET
+	{state} = 'queued',
+	{started_at} = null,
+	{process_after} = COALESCE(%s, {process_after})
+WHERE {id} = %s AND {state} = 'processing'
+`
+
 // AddExecutionLogEntry adds an executor log entry to the record and returns the ID of the new entry (which can be
 // used with UpdateExecutionLogEntry) and a possible error. When the record is not found (due to options not matching
 // or the record being deleted), ErrExecutionLogEntryNotUpdated is returned.
@@ -843,7 +1149,8 @@ func (s *store[T]) MarkComplete(ctx context.Context, id int, options MarkFinalOp
 	}
 	conds = append(conds, options.ToSQLConds(s.formatQuery)...)
 
-	_, ok, err := basestore.ScanFirstInt(s.Query(ctx, s.formatQuery(markCompleteQuery, quote(s.options.TableName), sqlf.Join(conds, "AND"))))
+	q := s.formatQuery(markCompleteQuery, quote(s.options.TableName), sqlf.Join(conds, "AND"), s.updatedColumns("id"))
+	_, ok, err := basestore.ScanFirstInt(s.Query(ctx, s.recordPrerequisiteStates(q, "id")))
 	return ok, err
 }
 
@@ -851,7 +1158,7 @@ const markCompleteQuery = `
 UPDATE %s
 SET {state} = 'completed', {finished_at} = clock_timestamp()
 WHERE %s
-RETURNING {id}
+RETURNING %s
 `
 
 // MarkErrored attempts to update the state of the record to errored. This method will only have an effect
@@ -869,8 +1176,8 @@ func (s *store[T]) MarkErrored(ctx context.Context, id int, failureMessage strin
 	}
 	conds = append(conds, options.ToSQLConds(s.formatQuery)...)
 
-	q := s.formatQuery(markErroredQuery, quote(s.options.TableName), s.options.MaxNumRetries, failureMessage, sqlf.Join(conds, "AND"))
-	_, ok, err := basestore.ScanFirstInt(s.Query(ctx, q))
+	q := s.formatQuery(markErroredQuery, quote(s.options.TableName), s.options.MaxNumRetries, failureMessage, sqlf.Join(conds, "AND"), s.updatedColumns("id"))
+	_, ok, err := basestore.ScanFirstInt(s.Query(ctx, s.recordPrerequisiteStates(q, "id")))
 	return ok, err
 }
 
@@ -881,7 +1188,7 @@ SET {state} = CASE WHEN {cancel} THEN 'canceled' WHEN {num_failures} + 1 >= %d T
 	{failure_message} = %s,
 	{num_failures} = CASE WHEN {cancel} THEN {num_failures} ELSE {num_failures} + 1 END
 WHERE %s
-RETURNING {id}
+RETURNING %s
 `
 
 // MarkFailed attempts to update the state of the record to failed. This method will only have an effect
@@ -899,8 +1206,8 @@ func (s *store[T]) MarkFailed(ctx context.Context, id int, failureMessage string
 	}
 	conds = append(conds, options.ToSQLConds(s.formatQuery)...)
 
-	q := s.formatQuery(markFailedQuery, quote(s.options.TableName), failureMessage, sqlf.Join(conds, "AND"))
-	_, ok, err := basestore.ScanFirstInt(s.Query(ctx, q))
+	q := s.formatQuery(markFailedQuery, quote(s.options.TableName), failureMessage, sqlf.Join(conds, "AND"), s.updatedColumns("id"))
+	_, ok, err := basestore.ScanFirstInt(s.Query(ctx, s.recordPrerequisiteStates(q, "id")))
 	return ok, err
 }
 
@@ -911,7 +1218,7 @@ SET {state} = CASE WHEN {cancel} THEN 'canceled' ELSE 'failed' END,
 	{failure_message} = %s,
 	{num_failures} = CASE WHEN {cancel} THEN {num_failures} ELSE {num_failures} + 1 END
 WHERE %s
-RETURNING {id}
+RETURNING %s
 `
 
 const defaultResetFailureMessage = "job processor died while handling this message too many times"
@@ -944,6 +1251,12 @@ func (s *store[T]) ResetStalled(ctx context.Context) (resetLastHeartbeatsByIDs,
 	}
 	trace.AddEvent("TODO Domain Owner", attribute.Int("numResetIDs", len(resetLastHeartbeatsByIDs)))
 
+	if len(resetLastHeartbeatsByIDs) > 0 {
+		if err := s.notify(ctx); err != nil {
+			return resetLastHeartbeatsByIDs, failedLastHeartbeatsByIDs, err
+		}
+	}
+
 	resetFailureMessage := s.options.ResetFailureMessage
 	if resetFailureMessage == "" {
 		resetFailureMessage = defaultResetFailureMessage
@@ -951,7 +1264,7 @@ func (s *store[T]) ResetStalled(ctx context.Context) (resetLastHeartbeatsByIDs,
 
 	failedLastHeartbeatsByIDs, err = scan(s.Query(
 		ctx,
-		s.formatQuery(
+		s.recordPrerequisiteStates(s.formatQuery(
 			resetStalledMaxResetsQuery,
 			quote(s.options.TableName),
 			now,
@@ -959,7 +1272,8 @@ func (s *store[T]) ResetStalled(ctx context.Context) (resetLastHeartbeatsByIDs,
 			s.options.MaxNumResets,
 			quote(s.options.TableName),
 			resetFailureMessage,
-		),
+			s.updatedColumns("id", "last_heartbeat_at"),
+		), "id, last_heartbeat_at"),
 	))
 	if err != nil {
 		return resetLastHeartbeatsByIDs, failedLastHeartbeatsByIDs, err
@@ -1025,9 +1339,20 @@ SET
 	{finished_at} = clock_timestamp(),
 	{failure_message} = %s
 WHERE {id} IN (SELECT {id} FROM stalled)
-RETURNING {id}, {last_heartbeat_at}
+RETURNING %s
 `
 
+// notify emits a NOTIFY on the notification channel of the store, if it has one.
+func (s *store[T]) notify(ctx context.Context) error {
+	if s.options.NotifyChannel == "" {
+		return nil
+	}
+
+	return s.Exec(ctx, sqlf.Sprintf(notifyQuery, s.options.NotifyChannel, s.options.TableName))
+}
+
+const notifyQuery = `SELECT pg_notify(%s, %s)`
+
 func (s *store[T]) formatQuery(query string, args ...any) *sqlf.Query {
 	return sqlf.Sprintf(s.columnReplacer.Replace(query), args...)
 }
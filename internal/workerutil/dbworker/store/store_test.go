@@ -422,6 +422,94 @@ func TestStoreDequeueRetryAfterDisabled(t *testing.T) {
 	}
 }
 
+func TestStoreDequeuePartitioned(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		weights  map[string]float64
+		expected []int
+	}{
+		{name: "unweighted", expected: []int{1, 10, 2, 11, 3}},
+		{name: "weighted", weights: map[string]float64{"0": 2, "1": -1}, expected: []int{1, 2, 10, 3, 11}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			db := setupStoreTest(t)
+
+			if _, err := db.ExecContext(context.Background(), `
+				INSERT INTO workerutil_test (id, state, created_at)
+				VALUES
+					(1, 'queued', NOW() - '5 minute'::interval),
+					(2, 'queued', NOW() - '4 minute'::interval),
+					(3, 'queued', NOW() - '3 minute'::interval),
+					(10, 'queued', NOW() - '2 minute'::interval),
+					(11, 'queued', NOW() - '1 minute'::interval)
+			`); err != nil {
+				t.Fatalf("unexpected error inserting records: %s", err)
+			}
+
+			options := defaultTestStoreOptions(nil, testScanRecord)
+			options.PartitionByExpression = sqlf.Sprintf("workerutil_test.id / 10")
+			options.PartitionWeights = test.weights
+			store := testStore(db, options)
+
+			// Records stay processing, so that they count towards the load of their partition.
+			var ids []int
+			for range test.expected {
+				record, ok, err := store.Dequeue(context.Background(), "test", nil)
+				require.NoError(t, err)
+				require.True(t, ok, "expected a dequeueable record")
+				ids = append(ids, record.ID)
+			}
+			if diff := cmp.Diff(test.expected, ids); diff != "" {
+				t.Errorf("unexpected dequeue order (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestStoreQueueStatsByPartition(t *testing.T) {
+	db := setupStoreTest(t)
+
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO workerutil_test (id, state, created_at)
+		VALUES
+			(1, 'queued', NOW() - '30 minute'::interval),
+			(2, 'queued', NOW() - '20 minute'::interval),
+			(3, 'errored', NOW() - '50 minute'::interval),
+			(10, 'queued', NOW() - '10 minute'::interval),
+			(11, 'processing', NOW() - '40 minute'::interval)
+	`); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+
+	options := defaultTestStoreOptions(nil, testScanRecord)
+	store := testStore(db, options)
+	stats, err := store.QueueStatsByPartition(context.Background(), 10)
+	require.NoError(t, err)
+	require.Nil(t, stats, "expected no stats for a store that is not partitioned")
+
+	options.PartitionByExpression = sqlf.Sprintf("workerutil_test.id / 10")
+	store = testStore(db, options)
+	stats, err = store.QueueStatsByPartition(context.Background(), 10)
+	require.NoError(t, err)
+	for i := range stats {
+		stats[i].MaxDurationInQueue = stats[i].MaxDurationInQueue.Round(time.Minute)
+	}
+
+	// Errored records are counted, but only count towards the age of the queue once they can be retried.
+	expected := []PartitionQueueStats{
+		{Partition: "0", Count: 3, MaxDurationInQueue: 30 * time.Minute},
+		{Partition: "1", Count: 1, MaxDurationInQueue: 10 * time.Minute},
+	}
+	if diff := cmp.Diff(expected, stats); diff != "" {
+		t.Errorf("unexpected stats (-want +got):\n%s", diff)
+	}
+
+	stats, err = store.QueueStatsByPartition(context.Background(), 1)
+	require.NoError(t, err)
+	require.Len(t, stats, 1)
+	require.Equal(t, "0", stats[0].Partition)
+}
+
 func TestStoreRequeue(t *testing.T) {
 	db := setupStoreTest(t)
 
@@ -463,6 +551,60 @@ func TestStoreRequeue(t *testing.T) {
 	}
 }
 
+func TestStoreRelease(t *testing.T) {
+	db := setupStoreTest(t)
+
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO workerutil_test (id, state, started_at, cancel)
+		VALUES
+			(1, 'processing', NOW(), true),
+			(2, 'processing', NOW(), false),
+			(3, 'completed', NOW(), false)
+	`); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+
+	after := testNow().Add(time.Hour)
+	store := testStore(db, defaultTestStoreOptions(nil, testScanRecord))
+	for id, releaseAfter := range map[int]time.Time{1: {}, 2: after, 3: after} {
+		if err := store.Release(context.Background(), id, releaseAfter); err != nil {
+			t.Fatalf("unexpected error releasing record: %s", err)
+		}
+	}
+
+	type result struct {
+		State        string
+		Started      bool
+		ProcessAfter *time.Time
+		Cancel       bool
+	}
+	rows, err := db.QueryContext(context.Background(), `SELECT state, started_at IS NOT NULL, process_after, cancel FROM workerutil_test ORDER BY id`)
+	if err != nil {
+		t.Fatalf("unexpected error querying records: %s", err)
+	}
+	defer func() { _ = basestore.CloseRows(rows, nil) }()
+
+	var results []result
+	for rows.Next() {
+		var r result
+		if err := rows.Scan(&r.State, &r.Started, &r.ProcessAfter, &r.Cancel); err != nil {
+			t.Fatalf("unexpected error scanning record: %s", err)
+		}
+		results = append(results, r)
+	}
+
+	expected := []result{
+		// Released records keep their cancellation flag.
+		{State: "queued", Cancel: true},
+		{State: "queued", ProcessAfter: &after},
+		// Records that are no longer processing are not released.
+		{State: "completed", Started: true},
+	}
+	if diff := cmp.Diff(expected, results, cmp.Comparer(func(a, b time.Time) bool { return a.Equal(b) })); diff != "" {
+		t.Errorf("unexpected records (-want +got):\n%s", diff)
+	}
+}
+
 func TestStoreAddExecutionLogEntry(t *testing.T) {
 	db := setupStoreTest(t)
 
@@ -88,6 +88,12 @@ type WorkerOptions struct {
 	// Interval is the frequency to poll the underlying store for new work.
 	Interval time.Duration
 
+	// Wakeup is an optional channel that signals that new work may be available. The
+	// worker polls the underlying store as soon as it receives a value instead of
+	// waiting for the rest of the poll interval. Polling on Interval continues as a
+	// fallback for missed signals.
+	Wakeup <-chan struct{}
+
 	// HeartbeatInterval is the interval between heartbeat updates to a job's last_heartbeat_at field. This
 	// field is periodically updated while being actively processed to signal to other workers that the
 	// record is neither pending nor abandoned.
@@ -241,6 +247,7 @@ loop:
 
 		select {
 		case <-w.dequeueClock.After(delay):
+		case <-w.options.Wakeup:
 		case <-w.dequeueCtx.Done():
 			break loop
 		case <-shutdownChan:
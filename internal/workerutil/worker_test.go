@@ -427,6 +427,42 @@ func TestWorkerNumTotalJobs(t *testing.T) {
 	}
 }
 
+func TestWorkerWakeup(t *testing.T) {
+	store := NewMockStore[*TestRecord]()
+	handler := NewMockHandler[*TestRecord]()
+	dequeueClock := glock.NewMockClock()
+	heartbeatClock := glock.NewMockClock()
+	shutdownClock := glock.NewMockClock()
+	wakeup := make(chan struct{})
+	options := WorkerOptions{
+		Name:           "test",
+		WorkerHostname: "test",
+		NumHandlers:    1,
+		Interval:       time.Hour,
+		Wakeup:         wakeup,
+		Metrics:        NewMetrics(&observation.TestContext, ""),
+	}
+
+	dequeued := make(chan struct{}, 2)
+	store.DequeueFunc.SetDefaultHook(func(ctx context.Context, s string, i any) (*TestRecord, bool, error) {
+		dequeued <- struct{}{}
+		return nil, false, nil
+	})
+
+	worker := newWorker(context.Background(), Store[*TestRecord](store), Handler[*TestRecord](handler), options, dequeueClock, heartbeatClock, shutdownClock)
+	go func() { worker.Start() }()
+	<-dequeued
+
+	// Should dequeue again without waiting for the poll interval
+	wakeup <- struct{}{}
+	<-dequeued
+	worker.Stop()
+
+	if callCount := len(store.DequeueFunc.History()); callCount != 2 {
+		t.Errorf("unexpected call count. want=%d have=%d", 2, callCount)
+	}
+}
+
 func TestWorkerMaxActiveTime(t *testing.T) {
 	store := NewMockStore[*TestRecord]()
 	handler := NewMockHandler[*TestRecord]()
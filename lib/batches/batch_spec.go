@@ -2,6 +2,7 @@ package batches
 
 import (
 	"fmt"
+	"path"
 	"strings"
 
 	"github.com/sourcegraph/sourcegraph/lib/batches/env"
@@ -37,6 +38,17 @@ type BatchSpec struct {
 	TransformChanges  *TransformChanges        `json:"transformChanges,omitempty" yaml:"transformChanges,omitempty"`
 	ImportChangesets  []ImportChangeset        `json:"importChangesets,omitempty" yaml:"importChangesets"`
 	ChangesetTemplate *ChangesetTemplate       `json:"changesetTemplate,omitempty" yaml:"changesetTemplate"`
+	Executor          *ExecutorRequirements    `json:"executor,omitempty" yaml:"executor,omitempty"`
+	Artifacts         []string                 `json:"artifacts,omitempty" yaml:"artifacts,omitempty"`
+}
+
+// ExecutorRequirements describes the capabilities an executor must have to
+// run the workspaces of a batch spec that is executed server-side.
+type ExecutorRequirements struct {
+	Labels    []string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	CPUs      int      `json:"cpus,omitempty" yaml:"cpus,omitempty"`
+	Memory    string   `json:"memory,omitempty" yaml:"memory,omitempty"`
+	DiskSpace string   `json:"diskSpace,omitempty" yaml:"diskSpace,omitempty"`
 }
 
 type ChangesetTemplate struct {
@@ -46,6 +58,76 @@ type ChangesetTemplate struct {
 	Fork      *bool                        `json:"fork,omitempty" yaml:"fork"`
 	Commit    ExpandedGitCommitDescription `json:"commit,omitempty" yaml:"commit"`
 	Published *overridable.BoolOrString    `json:"published" yaml:"published"`
+	AutoMerge *AutoMergePolicy             `json:"autoMerge,omitempty" yaml:"autoMerge,omitempty"`
+	Rollout   *RolloutPolicy               `json:"rollout,omitempty" yaml:"rollout,omitempty"`
+}
+
+// AutoMergePolicy describes when changesets created by a batch change should
+// be merged automatically.
+type AutoMergePolicy struct {
+	RequirePassingChecks *bool `json:"requirePassingChecks,omitempty" yaml:"requirePassingChecks,omitempty"`
+	RequiredApprovals    int   `json:"requiredApprovals,omitempty" yaml:"requiredApprovals,omitempty"`
+	Squash               bool  `json:"squash,omitempty" yaml:"squash,omitempty"`
+	OnlyInRolloutWindows bool  `json:"onlyInRolloutWindows,omitempty" yaml:"onlyInRolloutWindows,omitempty"`
+}
+
+// RequiresPassingChecks returns true if all checks on a changeset must pass
+// before it is merged. Checks are required unless explicitly disabled.
+func (p *AutoMergePolicy) RequiresPassingChecks() bool {
+	return p.RequirePassingChecks == nil || *p.RequirePassingChecks
+}
+
+// RolloutPolicy describes the waves in which the changesets created by a
+// batch change are published.
+type RolloutPolicy struct {
+	Waves          []RolloutWave `json:"waves,omitempty" yaml:"waves"`
+	PauseOnFailure bool          `json:"pauseOnFailure,omitempty" yaml:"pauseOnFailure,omitempty"`
+}
+
+// RolloutWave is a set of changesets that are published together. It is
+// either defined by repository name patterns or by the percentage of all
+// changesets that are published once the wave is reached.
+type RolloutWave struct {
+	Repositories    []string                `json:"repositories,omitempty" yaml:"repositories,omitempty"`
+	Percentage      int                     `json:"percentage,omitempty" yaml:"percentage,omitempty"`
+	SuccessCriteria *RolloutSuccessCriteria `json:"successCriteria,omitempty" yaml:"successCriteria,omitempty"`
+}
+
+// RolloutSuccessCriteria are the criteria the changesets of a wave have to
+// meet before the next wave is published.
+type RolloutSuccessCriteria struct {
+	MergedPercentage int  `json:"mergedPercentage,omitempty" yaml:"mergedPercentage,omitempty"`
+	NoFailingChecks  bool `json:"noFailingChecks,omitempty" yaml:"noFailingChecks,omitempty"`
+}
+
+// MatchesRepository returns true if the name of the repository matches one of
+// the repository patterns of the wave.
+func (w *RolloutWave) MatchesRepository(name string) bool {
+	for _, pattern := range w.Repositories {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *RolloutPolicy) validate() error {
+	var errs error
+	lastPercentage := 0
+	for i, wave := range p.Waves {
+		for _, pattern := range wave.Repositories {
+			if _, err := path.Match(pattern, ""); err != nil {
+				errs = errors.Append(errs, NewValidationError(errors.Newf("rollout wave %d has an invalid repository pattern %q", i+1, pattern)))
+			}
+		}
+		if wave.Percentage != 0 {
+			if wave.Percentage <= lastPercentage {
+				errs = errors.Append(errs, NewValidationError(errors.Newf("rollout wave %d must have a higher percentage than the previous waves", i+1)))
+			}
+			lastPercentage = wave.Percentage
+		}
+	}
+	return errs
 }
 
 type GitCommitAuthor struct {
@@ -96,6 +178,47 @@ type Step struct {
 	Outputs   Outputs           `json:"outputs,omitempty" yaml:"outputs,omitempty"`
 	Mount     []Mount           `json:"mount,omitempty" yaml:"mount,omitempty"`
 	If        any               `json:"if,omitempty" yaml:"if,omitempty"`
+	Rewrite   *RewriteStep      `json:"rewrite,omitempty" yaml:"rewrite,omitempty"`
+}
+
+// RewriteStep is a search and replace that is applied to the files in a
+// workspace without running a container.
+type RewriteStep struct {
+	Match       string `json:"match,omitempty" yaml:"match"`
+	Replace     string `json:"replace" yaml:"replace"`
+	Language    string `json:"language,omitempty" yaml:"language,omitempty"`
+	PatternType string `json:"patternType,omitempty" yaml:"patternType,omitempty"`
+}
+
+const (
+	RewritePatternTypeStructural = "structural"
+	RewritePatternTypeRegexp     = "regexp"
+)
+
+// IsRegexp returns true if Match is a regular expression rather than a
+// structural pattern.
+func (r *RewriteStep) IsRegexp() bool {
+	return r.PatternType == RewritePatternTypeRegexp
+}
+
+// IsRewrite returns true if the step is a rewrite step that doesn't need a
+// container to run.
+func (s *Step) IsRewrite() bool {
+	return s.Rewrite != nil
+}
+
+// HasOnlyRewriteSteps returns true if the batch spec has steps and all of them
+// are rewrite steps, which means it can be executed without an executor.
+func (b *BatchSpec) HasOnlyRewriteSteps() bool {
+	if len(b.Steps) == 0 {
+		return false
+	}
+	for _, step := range b.Steps {
+		if !step.IsRewrite() {
+			return false
+		}
+	}
+	return true
 }
 
 func (s *Step) IfCondition() string {
@@ -166,7 +289,14 @@ func parseBatchSpec(schema string, data []byte) (*BatchSpec, error) {
 		errs = errors.Append(errs, NewValidationError(errors.New("batch spec includes steps but no changesetTemplate")))
 	}
 
+	hasRewriteSteps := false
 	for i, step := range spec.Steps {
+		if step.IsRewrite() {
+			hasRewriteSteps = true
+			if step.Run != "" || step.Container != "" || len(step.Files) > 0 || len(step.Mount) > 0 {
+				errs = errors.Append(errs, NewValidationError(errors.Newf("step %d is a rewrite step and cannot set run, container, files or mount", i+1)))
+			}
+		}
 		for _, mount := range step.Mount {
 			if strings.Contains(mount.Path, invalidMountCharacters) {
 				errs = errors.Append(errs, NewValidationError(errors.Newf("step %d mount path contains invalid characters", i+1)))
@@ -177,6 +307,16 @@ func parseBatchSpec(schema string, data []byte) (*BatchSpec, error) {
 		}
 	}
 
+	if spec.ChangesetTemplate != nil && spec.ChangesetTemplate.Rollout != nil {
+		if err := spec.ChangesetTemplate.Rollout.validate(); err != nil {
+			errs = errors.Append(errs, err)
+		}
+	}
+
+	if hasRewriteSteps && !spec.HasOnlyRewriteSteps() {
+		errs = errors.Append(errs, NewValidationError(errors.New("rewrite steps cannot be combined with container steps in the same batch spec")))
+	}
+
 	return &spec, errs
 }
 
@@ -0,0 +1,231 @@
+package batches
+
+import (
+	"bytes"
+	"strings"
+
+	yamlv3 "gopkg.in/yaml.v3"
+
+	"github.com/sourcegraph/sourcegraph/lib/batches/schema"
+	"github.com/sourcegraph/sourcegraph/lib/batches/template"
+	"github.com/sourcegraph/sourcegraph/lib/batches/yaml"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// BatchSpecTemplate is a batch spec with typed input parameters that can be
+// shared and instantiated with different inputs. The spec references inputs
+// with ${{ inputs.<name> }}.
+type BatchSpecTemplate struct {
+	Name        string              `json:"name,omitempty" yaml:"name"`
+	Description string              `json:"description,omitempty" yaml:"description"`
+	Parameters  []TemplateParameter `json:"parameters,omitempty" yaml:"parameters"`
+	Spec        string              `json:"spec,omitempty" yaml:"spec"`
+}
+
+type TemplateParameterType string
+
+const (
+	TemplateParameterTypeString          TemplateParameterType = "string"
+	TemplateParameterTypeRepositoryQuery TemplateParameterType = "repositoryQuery"
+	TemplateParameterTypeEnum            TemplateParameterType = "enum"
+)
+
+// TemplateParameter is an input parameter of a BatchSpecTemplate.
+type TemplateParameter struct {
+	Name        string                `json:"name,omitempty" yaml:"name"`
+	Type        TemplateParameterType `json:"type,omitempty" yaml:"type"`
+	Description string                `json:"description,omitempty" yaml:"description"`
+	Required    bool                  `json:"required,omitempty" yaml:"required"`
+	Default     *string               `json:"default,omitempty" yaml:"default"`
+	Values      []string              `json:"values,omitempty" yaml:"values"`
+}
+
+// IsRequired returns true if a value has to be provided for the parameter
+// when the template is instantiated.
+func (p *TemplateParameter) IsRequired() bool {
+	return p.Required && p.Default == nil
+}
+
+func (p *TemplateParameter) validateValue(value string) error {
+	if strings.ContainsAny(value, "\r\n") {
+		return errors.Newf("the value of input %q must not contain line breaks", p.Name)
+	}
+
+	switch p.Type {
+	case TemplateParameterTypeRepositoryQuery:
+		if strings.TrimSpace(value) == "" && p.IsRequired() {
+			return errors.Newf("input %q must be a non-empty repository query", p.Name)
+		}
+
+	case TemplateParameterTypeEnum:
+		if value == "" && !p.IsRequired() {
+			return nil
+		}
+		for _, v := range p.Values {
+			if v == value {
+				return nil
+			}
+		}
+		return errors.Newf("input %q must be one of %s, got %q", p.Name, strings.Join(p.Values, ", "), value)
+	}
+
+	return nil
+}
+
+// ParseBatchSpecTemplate parses and validates the given batch spec template.
+func ParseBatchSpecTemplate(data []byte) (*BatchSpecTemplate, error) {
+	var tmpl BatchSpecTemplate
+	if err := yaml.UnmarshalValidate(schema.BatchSpecTemplateJSON, data, &tmpl); err != nil {
+		var multiErr errors.MultiError
+		if errors.As(err, &multiErr) {
+			var newMultiError error
+			for _, e := range multiErr.Errors() {
+				newMultiError = errors.Append(newMultiError, NewValidationError(e))
+			}
+			return nil, newMultiError
+		}
+		return nil, err
+	}
+
+	var errs error
+
+	seen := make(map[string]struct{}, len(tmpl.Parameters))
+	placeholders := make(map[string]string, len(tmpl.Parameters))
+	for i := range tmpl.Parameters {
+		p := &tmpl.Parameters[i]
+		if _, ok := seen[p.Name]; ok {
+			errs = errors.Append(errs, NewValidationError(errors.Newf("parameter %q is declared more than once", p.Name)))
+		}
+		seen[p.Name] = struct{}{}
+		placeholders[p.Name] = ""
+
+		if p.Type == TemplateParameterTypeEnum && len(p.Values) == 0 {
+			errs = errors.Append(errs, NewValidationError(errors.Newf("enum parameter %q must list its allowed values", p.Name)))
+		}
+		if p.Type != TemplateParameterTypeEnum && len(p.Values) > 0 {
+			errs = errors.Append(errs, NewValidationError(errors.Newf("only enum parameters can list allowed values, but parameter %q is of type %s", p.Name, p.Type)))
+		}
+		if p.Default != nil {
+			if err := p.validateValue(*p.Default); err != nil {
+				errs = errors.Append(errs, NewValidationError(errors.Wrap(err, "invalid default")))
+			}
+		}
+	}
+
+	// A dry run with empty inputs catches references to undeclared inputs
+	// and invalid YAML before the template is ever instantiated.
+	if _, err := renderSpecInputs(tmpl.Spec, placeholders); err != nil {
+		errs = errors.Append(errs, NewValidationError(errors.Wrap(err, "spec")))
+	}
+
+	if errs != nil {
+		return nil, errs
+	}
+
+	return &tmpl, nil
+}
+
+// ResolveInputs validates the given inputs against the parameters of the
+// template and returns the value of every parameter, falling back to the
+// parameter defaults for inputs that aren't given.
+func (t *BatchSpecTemplate) ResolveInputs(inputs map[string]string) (map[string]string, error) {
+	var errs error
+
+	params := make(map[string]*TemplateParameter, len(t.Parameters))
+	for i := range t.Parameters {
+		params[t.Parameters[i].Name] = &t.Parameters[i]
+	}
+	for name := range inputs {
+		if _, ok := params[name]; !ok {
+			errs = errors.Append(errs, NewValidationError(errors.Newf("template has no parameter %q", name)))
+		}
+	}
+
+	values := make(map[string]string, len(t.Parameters))
+	for _, p := range t.Parameters {
+		value, ok := inputs[p.Name]
+		if !ok {
+			if p.IsRequired() {
+				errs = errors.Append(errs, NewValidationError(errors.Newf("input %q is required", p.Name)))
+				continue
+			}
+			if p.Default != nil {
+				value = *p.Default
+			}
+		}
+		if err := p.validateValue(value); err != nil {
+			errs = errors.Append(errs, NewValidationError(err))
+			continue
+		}
+		values[p.Name] = value
+	}
+
+	if errs != nil {
+		return nil, errs
+	}
+	return values, nil
+}
+
+// Instantiate validates the given inputs and returns the raw batch spec the
+// template evaluates to with them. The returned spec still has to be parsed
+// with ParseBatchSpec.
+func (t *BatchSpecTemplate) Instantiate(inputs map[string]string) (string, error) {
+	values, err := t.ResolveInputs(inputs)
+	if err != nil {
+		return "", err
+	}
+
+	return renderSpecInputs(t.Spec, values)
+}
+
+// renderSpecInputs replaces the references to inputs in the scalars of the
+// given YAML document. Substituting in the parsed document rather than the
+// raw text guarantees that an input can never change the structure of the
+// batch spec: the value of an input always ends up in a single string.
+func renderSpecInputs(spec string, values map[string]string) (string, error) {
+	var doc yamlv3.Node
+	if err := yamlv3.Unmarshal([]byte(spec), &doc); err != nil {
+		return "", errors.Wrap(err, "parsing spec")
+	}
+
+	var errs error
+	var walk func(n *yamlv3.Node)
+	walk = func(n *yamlv3.Node) {
+		if n.Kind == yamlv3.ScalarNode {
+			rendered, err := template.RenderInputs(n.Value, values)
+			if err != nil {
+				errs = errors.Append(errs, errors.Wrapf(err, "line %d", n.Line))
+				return
+			}
+			// Let the encoder resolve the tag of a plain scalar again, so
+			// that an input such as "true" can be used as a boolean.
+			if rendered != n.Value && n.Style == 0 {
+				n.Tag = ""
+			}
+			n.Value = rendered
+		}
+		for _, c := range n.Content {
+			walk(c)
+		}
+	}
+	walk(&doc)
+	if errs != nil {
+		return "", errs
+	}
+
+	// An empty document can't be encoded.
+	if doc.Kind == 0 {
+		return "", nil
+	}
+
+	var out bytes.Buffer
+	enc := yamlv3.NewEncoder(&out)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return "", errors.Wrap(err, "encoding spec")
+	}
+	if err := enc.Close(); err != nil {
+		return "", errors.Wrap(err, "encoding spec")
+	}
+	return out.String(), nil
+}
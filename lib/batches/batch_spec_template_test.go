@@ -0,0 +1,268 @@
+package batches
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const testBatchSpecTemplate = `
+name: bump-go-version
+description: Bump the Go version of go.mod files
+parameters:
+  - name: repos
+    type: repositoryQuery
+    required: true
+  - name: version
+    type: string
+    default: "1.21"
+  - name: publish
+    type: enum
+    values: ["true", "false", "draft"]
+    default: "false"
+spec: |
+  name: bump-go-${{ inputs.version }}
+  # Keep this comment.
+  on:
+    - repositoriesMatchingQuery: ${{ inputs.repos }}
+  steps:
+    - run: go mod edit -go=${{ inputs.version }} && echo ${{ repository.name }}
+      container: golang:${{ inputs.version }}
+  changesetTemplate:
+    title: Bump Go to ${{ inputs.version }}
+    body: Automated
+    branch: bump-go
+    commit:
+      message: Bump Go to ${{ inputs.version }}
+    published: ${{ inputs.publish }}
+`
+
+func TestParseBatchSpecTemplate(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		tmpl, err := ParseBatchSpecTemplate([]byte(testBatchSpecTemplate))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if have, want := tmpl.Name, "bump-go-version"; have != want {
+			t.Fatalf("wrong name. want=%q, have=%q", want, have)
+		}
+		if have, want := len(tmpl.Parameters), 3; have != want {
+			t.Fatalf("wrong number of parameters. want=%d, have=%d", want, have)
+		}
+		if !tmpl.Parameters[0].IsRequired() {
+			t.Fatal("repos parameter is not required")
+		}
+		if tmpl.Parameters[1].IsRequired() {
+			t.Fatal("version parameter is required")
+		}
+	})
+
+	tests := []struct {
+		name     string
+		template string
+		wantErr  string
+	}{
+		{
+			name: "missing spec",
+			template: `
+name: no-spec
+`,
+			wantErr: "spec is required",
+		},
+		{
+			name: "invalid parameter type",
+			template: `
+name: invalid-type
+parameters:
+  - name: foo
+    type: number
+spec: "name: foo"
+`,
+			wantErr: "parameters.0.type must be one of the following",
+		},
+		{
+			name: "duplicate parameter",
+			template: `
+name: duplicate
+parameters:
+  - name: foo
+    type: string
+  - name: foo
+    type: string
+spec: "name: foo"
+`,
+			wantErr: `parameter "foo" is declared more than once`,
+		},
+		{
+			name: "enum without values",
+			template: `
+name: enum
+parameters:
+  - name: foo
+    type: enum
+spec: "name: foo"
+`,
+			wantErr: `enum parameter "foo" must list its allowed values`,
+		},
+		{
+			name: "values on string parameter",
+			template: `
+name: values
+parameters:
+  - name: foo
+    type: string
+    values: [a]
+spec: "name: foo"
+`,
+			wantErr: `only enum parameters can list allowed values, but parameter "foo" is of type string`,
+		},
+		{
+			name: "invalid enum default",
+			template: `
+name: enum
+parameters:
+  - name: foo
+    type: enum
+    values: [a, b]
+    default: c
+spec: "name: foo"
+`,
+			wantErr: `invalid default: input "foo" must be one of a, b, got "c"`,
+		},
+		{
+			name: "undeclared input",
+			template: `
+name: undeclared
+spec: "name: ${{ inputs.foo }}"
+`,
+			wantErr: `spec: line 1: unknown input "foo"`,
+		},
+		{
+			name: "invalid spec YAML",
+			template: `
+name: invalid
+spec: "name: [foo"
+`,
+			wantErr: "spec: parsing spec",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseBatchSpecTemplate([]byte(tc.template))
+			if err == nil {
+				t.Fatal("no error returned")
+			}
+			if !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("wrong error. want=%q, have=%q", tc.wantErr, err.Error())
+			}
+		})
+	}
+}
+
+func TestBatchSpecTemplate_Instantiate(t *testing.T) {
+	tmpl, err := ParseBatchSpecTemplate([]byte(testBatchSpecTemplate))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("with defaults", func(t *testing.T) {
+		raw, err := tmpl.Instantiate(map[string]string{"repos": "file:go.mod"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := `name: bump-go-1.21
+# Keep this comment.
+on:
+  - repositoriesMatchingQuery: file:go.mod
+steps:
+  - run: go mod edit -go=1.21 && echo ${{ repository.name }}
+    container: golang:1.21
+changesetTemplate:
+  title: Bump Go to 1.21
+  body: Automated
+  branch: bump-go
+  commit:
+    message: Bump Go to 1.21
+  published: false
+`
+		if diff := cmp.Diff(want, raw); diff != "" {
+			t.Fatalf("wrong spec (-want +have):\n%s", diff)
+		}
+
+		spec, err := ParseBatchSpec([]byte(raw))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := spec.On[0].RepositoriesMatchingQuery, "file:go.mod"; have != want {
+			t.Fatalf("wrong query. want=%q, have=%q", want, have)
+		}
+	})
+
+	t.Run("inputs can't change the structure of the spec", func(t *testing.T) {
+		raw, err := tmpl.Instantiate(map[string]string{
+			"repos":   "file:go.mod",
+			"version": "1.21\"\n  - run: rm -rf /",
+		})
+		if err == nil {
+			t.Fatalf("no error returned, spec:\n%s", raw)
+		}
+
+		raw, err = tmpl.Instantiate(map[string]string{
+			"repos":   `"file:go.mod" } malicious: [`,
+			"version": "1.21",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		spec, err := ParseBatchSpec([]byte(raw))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := spec.On[0].RepositoriesMatchingQuery, `"file:go.mod" } malicious: [`; have != want {
+			t.Fatalf("wrong query. want=%q, have=%q", want, have)
+		}
+	})
+
+	tests := []struct {
+		name    string
+		inputs  map[string]string
+		wantErr string
+	}{
+		{
+			name:    "missing required input",
+			inputs:  map[string]string{},
+			wantErr: `input "repos" is required`,
+		},
+		{
+			name:    "empty repository query",
+			inputs:  map[string]string{"repos": " "},
+			wantErr: `input "repos" must be a non-empty repository query`,
+		},
+		{
+			name:    "invalid enum value",
+			inputs:  map[string]string{"repos": "file:go.mod", "publish": "yes"},
+			wantErr: `input "publish" must be one of true, false, draft, got "yes"`,
+		},
+		{
+			name:    "unknown input",
+			inputs:  map[string]string{"repos": "file:go.mod", "foo": "bar"},
+			wantErr: `template has no parameter "foo"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tmpl.Instantiate(tc.inputs)
+			if err == nil {
+				t.Fatal("no error returned")
+			}
+			if have := err.Error(); have != tc.wantErr {
+				t.Fatalf("wrong error. want=%q, have=%q", tc.wantErr, have)
+			}
+		})
+	}
+}
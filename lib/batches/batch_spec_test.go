@@ -211,6 +211,225 @@ changesetTemplate:
 		_, err := ParseBatchSpec([]byte(spec))
 		assert.Equal(t, "step 1 mount mountpoint contains invalid characters", err.Error())
 	})
+
+	t.Run("rewrite steps", func(t *testing.T) {
+		const spec = `
+name: test-spec
+description: A test spec
+steps:
+  - rewrite:
+      match: fmt.Sprintf("%s", :[arg])
+      replace: :[arg]
+      language: Go
+  - rewrite:
+      match: foo\((\w+)\)
+      replace: bar($1)
+      patternType: regexp
+changesetTemplate:
+  title: Test Rewrite
+  body: Test a rewrite step
+  branch: test
+  commit:
+    message: Test
+`
+		batchSpec, err := ParseBatchSpec([]byte(spec))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.True(t, batchSpec.HasOnlyRewriteSteps())
+		assert.Equal(t, &RewriteStep{Match: `fmt.Sprintf("%s", :[arg])`, Replace: ":[arg]", Language: "Go"}, batchSpec.Steps[0].Rewrite)
+		assert.False(t, batchSpec.Steps[0].Rewrite.IsRegexp())
+		assert.True(t, batchSpec.Steps[1].Rewrite.IsRegexp())
+	})
+
+	t.Run("rewrite step with container", func(t *testing.T) {
+		const spec = `
+name: test-spec
+description: A test spec
+steps:
+  - rewrite:
+      match: foo
+      replace: bar
+    container: alpine:3
+changesetTemplate:
+  title: Test Rewrite
+  body: Test a rewrite step
+  branch: test
+  commit:
+    message: Test
+`
+		_, err := ParseBatchSpec([]byte(spec))
+		assert.Equal(t, "step 1 is a rewrite step and cannot set run, container, files or mount", err.Error())
+	})
+
+	t.Run("rewrite steps mixed with container steps", func(t *testing.T) {
+		const spec = `
+name: test-spec
+description: A test spec
+steps:
+  - rewrite:
+      match: foo
+      replace: bar
+  - run: echo foo
+    container: alpine:3
+changesetTemplate:
+  title: Test Rewrite
+  body: Test a rewrite step
+  branch: test
+  commit:
+    message: Test
+`
+		_, err := ParseBatchSpec([]byte(spec))
+		assert.Equal(t, "rewrite steps cannot be combined with container steps in the same batch spec", err.Error())
+	})
+
+	t.Run("rollout", func(t *testing.T) {
+		const spec = `
+name: test-spec
+description: A test spec
+steps:
+  - run: echo foo
+    container: alpine:3
+changesetTemplate:
+  title: Test Rollout
+  body: Test a rollout
+  branch: test
+  commit:
+    message: Test
+  published: true
+  rollout:
+    pauseOnFailure: true
+    waves:
+      - repositories: [github.com/sourcegraph/canary-*]
+        successCriteria:
+          mergedPercentage: 50
+      - percentage: 25
+        successCriteria:
+          noFailingChecks: true
+      - percentage: 100
+`
+		batchSpec, err := ParseBatchSpec([]byte(spec))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := &RolloutPolicy{
+			PauseOnFailure: true,
+			Waves: []RolloutWave{
+				{Repositories: []string{"github.com/sourcegraph/canary-*"}, SuccessCriteria: &RolloutSuccessCriteria{MergedPercentage: 50}},
+				{Percentage: 25, SuccessCriteria: &RolloutSuccessCriteria{NoFailingChecks: true}},
+				{Percentage: 100},
+			},
+		}
+		if diff := cmp.Diff(want, batchSpec.ChangesetTemplate.Rollout); diff != "" {
+			t.Fatalf("wrong rollout policy (-want +got):\n%s", diff)
+		}
+		assert.True(t, want.Waves[0].MatchesRepository("github.com/sourcegraph/canary-web"))
+		assert.False(t, want.Waves[0].MatchesRepository("github.com/sourcegraph/sourcegraph"))
+	})
+
+	t.Run("rollout with decreasing percentages", func(t *testing.T) {
+		const spec = `
+name: test-spec
+description: A test spec
+steps:
+  - run: echo foo
+    container: alpine:3
+changesetTemplate:
+  title: Test Rollout
+  body: Test a rollout
+  branch: test
+  commit:
+    message: Test
+  rollout:
+    waves:
+      - percentage: 50
+      - percentage: 10
+`
+		_, err := ParseBatchSpec([]byte(spec))
+		assert.Equal(t, "rollout wave 2 must have a higher percentage than the previous waves", err.Error())
+	})
+
+	t.Run("executor requirements", func(t *testing.T) {
+		const spec = `
+name: test-spec
+description: A test spec
+executor:
+  labels: [gpu]
+  cpus: 8
+  memory: 32G
+steps:
+  - run: echo foo
+    container: alpine:3
+changesetTemplate:
+  title: Test Executor
+  body: Test executor requirements
+  branch: test
+  commit:
+    message: Test
+`
+		batchSpec, err := ParseBatchSpec([]byte(spec))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := &ExecutorRequirements{Labels: []string{"gpu"}, CPUs: 8, Memory: "32G"}
+		if diff := cmp.Diff(want, batchSpec.Executor); diff != "" {
+			t.Fatalf("wrong executor requirements (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("executor requirements with invalid memory", func(t *testing.T) {
+		const spec = `
+name: test-spec
+description: A test spec
+executor:
+  memory: lots
+`
+		_, err := ParseBatchSpec([]byte(spec))
+		assert.Error(t, err)
+	})
+
+	t.Run("artifacts", func(t *testing.T) {
+		const spec = `
+name: test-spec
+description: A test spec
+artifacts:
+  - reports/*.xml
+  - coverage.out
+steps:
+  - run: make test
+    container: golang:1.21
+changesetTemplate:
+  title: Test Artifacts
+  body: Test artifacts
+  branch: test
+  commit:
+    message: Test
+`
+		batchSpec, err := ParseBatchSpec([]byte(spec))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]string{"reports/*.xml", "coverage.out"}, batchSpec.Artifacts); diff != "" {
+			t.Fatalf("wrong artifacts (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("step without container or rewrite", func(t *testing.T) {
+		const spec = `
+name: test-spec
+description: A test spec
+steps:
+  - run: echo foo
+changesetTemplate:
+  title: Test Rewrite
+  body: Test a rewrite step
+  branch: test
+  commit:
+    message: Test
+`
+		_, err := ParseBatchSpec([]byte(spec))
+		assert.Error(t, err)
+	})
 }
 
 func TestOnQueryOrRepository_Branches(t *testing.T) {
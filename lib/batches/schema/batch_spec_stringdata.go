@@ -123,7 +123,7 @@ const BatchSpecJSON = `{
         "type": "object",
         "description": "A command to run (as part of a sequence) in a repository branch to produce the required changes.",
         "additionalProperties": false,
-        "required": ["run", "container"],
+        "anyOf": [{ "required": ["run", "container"] }, { "required": ["rewrite"] }],
         "properties": {
           "run": {
             "type": "string",
@@ -237,6 +237,35 @@ const BatchSpecJSON = `{
                 }
               }
             }
+          },
+          "rewrite": {
+            "type": "object",
+            "description": "A search and replace that is applied to the files in the workspace without running a container. Steps using rewrite cannot set run, container, files or mount, and cannot be combined with container steps.",
+            "additionalProperties": false,
+            "required": ["match"],
+            "properties": {
+              "match": {
+                "type": "string",
+                "description": "The pattern to match. A structural search pattern by default, or a regular expression if patternType is regexp.",
+                "examples": ["fmt.Sprintf(\"%s\", :[arg])", "foo\\((\\w+)\\)"]
+              },
+              "replace": {
+                "type": "string",
+                "description": "The replacement for every match. Holes like :[arg] in structural patterns and groups like $1 in regular expressions can be referenced.",
+                "examples": [":[arg]", "bar($1)"]
+              },
+              "language": {
+                "type": "string",
+                "description": "The language of the files to rewrite. Only files of this language are rewritten, and it selects the structural matcher. If not set, all files are rewritten and the matcher is inferred from the file extension.",
+                "examples": ["Go", "TypeScript"]
+              },
+              "patternType": {
+                "type": "string",
+                "description": "The type of the match pattern.",
+                "enum": ["structural", "regexp"],
+                "default": "structural"
+              }
+            }
           }
         }
       }
@@ -306,6 +335,49 @@ const BatchSpecJSON = `{
         }
       }
     },
+    "executor": {
+      "title": "ExecutorRequirements",
+      "type": "object",
+      "description": "The capabilities an executor must have to run the workspaces of this batch spec when it is executed server-side. Workspaces are only handed out to executors that advertise all of the labels and allocate at least the requested resources to each job. Ignored when the batch spec is executed with src-cli.",
+      "additionalProperties": false,
+      "properties": {
+        "labels": {
+          "type": "array",
+          "description": "Labels that an executor must advertise to run the workspaces.",
+          "items": {
+            "type": "string"
+          },
+          "examples": [["gpu"], ["network:internal"]]
+        },
+        "cpus": {
+          "type": "integer",
+          "description": "The minimum number of CPUs an executor must allocate to each workspace.",
+          "minimum": 1
+        },
+        "memory": {
+          "type": "string",
+          "description": "The minimum amount of memory an executor must allocate to each workspace.",
+          "pattern": "^[0-9]+\\s*([kKmMgGtT][bB]?)?$",
+          "examples": ["16G"]
+        },
+        "diskSpace": {
+          "type": "string",
+          "description": "The minimum amount of disk space an executor must allocate to each workspace.",
+          "pattern": "^[0-9]+\\s*([kKmMgGtT][bB]?)?$",
+          "examples": ["100G"]
+        }
+      }
+    },
+    "artifacts": {
+      "type": "array",
+      "description": "Glob patterns of files, relative to the root of the repository, that are uploaded as artifacts of each workspace after its steps have run. Secrets are redacted from the uploaded files. Ignored when the batch spec is executed with src-cli.",
+      "items": {
+        "type": "string",
+        "minLength": 1
+      },
+      "maxItems": 20,
+      "examples": [["reports/*.xml", "coverage.out"]]
+    },
     "changesetTemplate": {
       "type": "object",
       "description": "A template describing how to create (and update) changesets with the file changes produced by the command steps.",
@@ -399,6 +471,94 @@ const BatchSpecJSON = `{
               }
             }
           ]
+        },
+        "autoMerge": {
+          "title": "AutoMergePolicy",
+          "type": "object",
+          "description": "A policy describing when changesets created by this batch change should be merged automatically. Changesets are only merged once all conditions in the policy are met. If omitted, changesets are never merged automatically.",
+          "additionalProperties": false,
+          "properties": {
+            "requirePassingChecks": {
+              "type": "boolean",
+              "description": "Whether all checks on the changeset must have passed before it is merged. Defaults to true.",
+              "default": true
+            },
+            "requiredApprovals": {
+              "type": "integer",
+              "description": "The minimum number of distinct reviewers whose most recent review approves the changeset.",
+              "minimum": 0,
+              "default": 0
+            },
+            "squash": {
+              "type": "boolean",
+              "description": "Whether to squash the commits of the changeset when merging it. Not all code hosts support squash merges.",
+              "default": false
+            },
+            "onlyInRolloutWindows": {
+              "type": "boolean",
+              "description": "Whether changesets may only be merged while a rollout window configured in the ` + "`" + `batchChanges.rolloutWindows` + "`" + ` site setting is open.",
+              "default": false
+            }
+          }
+        },
+        "rollout": {
+          "title": "RolloutPolicy",
+          "type": "object",
+          "description": "A staged rollout of the changesets created by this batch change. Changesets are published in waves: the changesets of a wave are only published once the success criteria of the previous wave are met. Changesets that are not part of any wave are published after the last wave. If omitted, all changesets are published at once.",
+          "additionalProperties": false,
+          "required": ["waves"],
+          "properties": {
+            "waves": {
+              "type": "array",
+              "description": "The waves in which changesets are published, in order.",
+              "minItems": 1,
+              "items": {
+                "title": "RolloutWave",
+                "type": "object",
+                "additionalProperties": false,
+                "oneOf": [{ "required": ["repositories"] }, { "required": ["percentage"] }],
+                "properties": {
+                  "repositories": {
+                    "type": "array",
+                    "description": "Glob patterns of the names of the repositories whose changesets are part of this wave.",
+                    "items": { "type": "string" },
+                    "minItems": 1,
+                    "examples": [["github.com/my-org/canary-*"]]
+                  },
+                  "percentage": {
+                    "type": "integer",
+                    "description": "The percentage of all changesets of the batch change that are published once this wave is reached, including those of previous waves.",
+                    "minimum": 1,
+                    "maximum": 100
+                  },
+                  "successCriteria": {
+                    "title": "RolloutSuccessCriteria",
+                    "type": "object",
+                    "description": "The criteria the changesets of this wave have to meet before the next wave is published. If omitted, the next wave is published as soon as all changesets of this wave are published.",
+                    "additionalProperties": false,
+                    "properties": {
+                      "mergedPercentage": {
+                        "type": "integer",
+                        "description": "The minimum percentage of published changesets of this wave that have to be merged.",
+                        "minimum": 0,
+                        "maximum": 100
+                      },
+                      "noFailingChecks": {
+                        "type": "boolean",
+                        "description": "Whether the checks of all open changesets of this wave have to pass.",
+                        "default": false
+                      }
+                    }
+                  }
+                }
+              }
+            },
+            "pauseOnFailure": {
+              "type": "boolean",
+              "description": "Whether the rollout is paused when a changeset of the current wave fails to publish, has failing checks or is closed. A paused rollout has to be resumed manually.",
+              "default": false
+            }
+          }
         }
       }
     }
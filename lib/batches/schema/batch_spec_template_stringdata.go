@@ -0,0 +1,72 @@
+// Code generated by stringdata. DO NOT EDIT.
+
+package schema
+
+// BatchSpecTemplateJSON is the content of the file "schema/batch_spec_template.schema.json".
+const BatchSpecTemplateJSON = `{
+  "$id": "batch_spec_template.schema.json#",
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "title": "BatchSpecTemplate",
+  "description": "A batch spec template, which can be shared in a namespace or globally and instantiated with inputs to create a batch spec.",
+  "type": "object",
+  "additionalProperties": false,
+  "required": ["name", "spec"],
+  "properties": {
+    "name": {
+      "type": "string",
+      "description": "The name of the template, which is unique among all templates in the namespace.",
+      "pattern": "^[\\w.-]+$"
+    },
+    "description": {
+      "type": "string",
+      "description": "The description of the template."
+    },
+    "parameters": {
+      "type": "array",
+      "description": "The input parameters of the template. The value of a parameter is referenced in the spec with ${{ inputs.<name> }}.",
+      "items": {
+        "title": "BatchSpecTemplateParameter",
+        "type": "object",
+        "additionalProperties": false,
+        "required": ["name", "type"],
+        "properties": {
+          "name": {
+            "type": "string",
+            "description": "The name of the parameter.",
+            "pattern": "^[A-Za-z_][A-Za-z0-9_]*$"
+          },
+          "type": {
+            "type": "string",
+            "description": "The type of the parameter. A repositoryQuery is a Sourcegraph search query that selects the repositories the batch change runs in. An enum only allows the listed values.",
+            "enum": ["string", "repositoryQuery", "enum"]
+          },
+          "description": {
+            "type": "string",
+            "description": "The description of the parameter, shown to users instantiating the template."
+          },
+          "required": {
+            "type": "boolean",
+            "description": "Whether a value has to be provided for the parameter when the template is instantiated. Parameters with a default are never required."
+          },
+          "default": {
+            "type": "string",
+            "description": "The value of the parameter if none is provided."
+          },
+          "values": {
+            "type": "array",
+            "description": "The allowed values of an enum parameter.",
+            "items": {
+              "type": "string"
+            },
+            "minItems": 1
+          }
+        }
+      }
+    },
+    "spec": {
+      "type": "string",
+      "description": "The batch spec YAML the template is instantiated to. References to inputs are replaced with the values of the parameters."
+    }
+  }
+}
+`
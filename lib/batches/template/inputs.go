@@ -0,0 +1,43 @@
+package template
+
+import (
+	"github.com/grafana/regexp"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// inputRe matches a reference to an input of a batch spec template, such as
+// "${{ inputs.go_version }}".
+var inputRe = regexp.MustCompile(`\$\{\{\s*inputs\.([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// unsupportedInputRe matches any other use of inputs in a template
+// expression, such as "${{ inputs.name | replace "a" "b" }}".
+var unsupportedInputRe = regexp.MustCompile(`\$\{\{[^}]*\binputs\b[^}]*\}\}`)
+
+// RenderInputs replaces all references to inputs in the given string with
+// their values. Every other template expression, such as
+// "${{ repository.name }}", is left as is, since it's only evaluated when the
+// batch spec is executed.
+func RenderInputs(s string, inputs map[string]string) (string, error) {
+	// Inputs can only be referenced on their own. Anything else would
+	// otherwise fail in confusing ways once the batch spec is executed.
+	if m := unsupportedInputRe.FindString(inputRe.ReplaceAllString(s, "")); m != "" {
+		return "", errors.Newf("unsupported use of inputs in %q: inputs can only be referenced as ${{ inputs.<name> }}", m)
+	}
+
+	var errs error
+	rendered := inputRe.ReplaceAllStringFunc(s, func(ref string) string {
+		name := inputRe.FindStringSubmatch(ref)[1]
+		value, ok := inputs[name]
+		if !ok {
+			errs = errors.Append(errs, errors.Newf("unknown input %q", name))
+			return ref
+		}
+		return value
+	})
+	if errs != nil {
+		return "", errs
+	}
+
+	return rendered, nil
+}
@@ -0,0 +1,66 @@
+package template
+
+import (
+	"testing"
+)
+
+func TestRenderInputs(t *testing.T) {
+	inputs := map[string]string{
+		"version": "1.21",
+		"query":   "lang:go repo:^github.com/sourcegraph/",
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr string
+	}{
+		{
+			name:  "single reference",
+			input: "go mod edit -go=${{ inputs.version }}",
+			want:  "go mod edit -go=1.21",
+		},
+		{
+			name:  "multiple references without spaces",
+			input: "${{inputs.query}} ${{inputs.version}}",
+			want:  "lang:go repo:^github.com/sourcegraph/ 1.21",
+		},
+		{
+			name:  "other expressions are left alone",
+			input: "${{ repository.name }}@${{ inputs.version }} ${{ join steps.modified_files \" \" }}",
+			want:  "${{ repository.name }}@1.21 ${{ join steps.modified_files \" \" }}",
+		},
+		{
+			name:    "unknown input",
+			input:   "${{ inputs.unknown }}",
+			wantErr: `unknown input "unknown"`,
+		},
+		{
+			name:    "input in pipeline",
+			input:   `${{ inputs.version | replace "." "-" }}`,
+			wantErr: `unsupported use of inputs in "${{ inputs.version | replace \".\" \"-\" }}": inputs can only be referenced as ${{ inputs.<name> }}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			have, err := RenderInputs(tc.input, inputs)
+			if tc.wantErr != "" {
+				if err == nil {
+					t.Fatalf("expected error %q, got none", tc.wantErr)
+				}
+				if err.Error() != tc.wantErr {
+					t.Fatalf("wrong error. want=%q, have=%q", tc.wantErr, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have != tc.want {
+				t.Fatalf("wrong output. want=%q, have=%q", tc.want, have)
+			}
+		})
+	}
+}
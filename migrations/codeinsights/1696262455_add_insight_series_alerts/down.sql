@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS insight_series_alert_jobs;
+DROP TABLE IF EXISTS insight_series_alert_events;
+DROP TABLE IF EXISTS insight_series_alert_actions;
+DROP TABLE IF EXISTS insight_series_alert_rules;
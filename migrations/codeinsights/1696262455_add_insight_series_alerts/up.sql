@@ -0,0 +1,66 @@
+CREATE TABLE IF NOT EXISTS insight_series_alert_rules (
+    id SERIAL PRIMARY KEY,
+    series_id INTEGER NOT NULL REFERENCES insight_series(id) ON DELETE CASCADE,
+    condition TEXT NOT NULL,
+    threshold DOUBLE PRECISION NOT NULL,
+    lookback INTEGER NOT NULL DEFAULT 1,
+    description TEXT NOT NULL DEFAULT '',
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT insight_series_alert_rules_lookback_positive CHECK (lookback > 0)
+);
+
+CREATE INDEX IF NOT EXISTS insight_series_alert_rules_series_id_idx ON insight_series_alert_rules(series_id);
+
+CREATE TABLE IF NOT EXISTS insight_series_alert_actions (
+    id SERIAL PRIMARY KEY,
+    rule_id INTEGER NOT NULL REFERENCES insight_series_alert_rules(id) ON DELETE CASCADE,
+    type TEXT NOT NULL,
+    url TEXT,
+    recipient_user_id INTEGER,
+    CONSTRAINT insight_series_alert_actions_target CHECK ((type = 'EMAIL' AND recipient_user_id IS NOT NULL AND url IS NULL) OR (type <> 'EMAIL' AND url IS NOT NULL))
+);
+
+CREATE INDEX IF NOT EXISTS insight_series_alert_actions_rule_id_idx ON insight_series_alert_actions(rule_id);
+
+CREATE TABLE IF NOT EXISTS insight_series_alert_events (
+    id SERIAL PRIMARY KEY,
+    rule_id INTEGER REFERENCES insight_series_alert_rules(id) ON DELETE SET NULL,
+    series_id INTEGER NOT NULL REFERENCES insight_series(id) ON DELETE CASCADE,
+    point_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    capture TEXT,
+    value DOUBLE PRECISION NOT NULL,
+    baseline DOUBLE PRECISION,
+    message TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS insight_series_alert_events_rule_point_unique ON insight_series_alert_events(rule_id, point_time, COALESCE(capture, ''));
+CREATE INDEX IF NOT EXISTS insight_series_alert_events_series_id_point_time_idx ON insight_series_alert_events(series_id, point_time DESC);
+
+CREATE TABLE IF NOT EXISTS insight_series_alert_jobs (
+    id SERIAL PRIMARY KEY,
+    state TEXT DEFAULT 'queued',
+    failure_message TEXT,
+    queued_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    started_at TIMESTAMP WITH TIME ZONE,
+    finished_at TIMESTAMP WITH TIME ZONE,
+    process_after TIMESTAMP WITH TIME ZONE,
+    num_resets INTEGER NOT NULL DEFAULT 0,
+    num_failures INTEGER NOT NULL DEFAULT 0,
+    last_heartbeat_at TIMESTAMP WITH TIME ZONE,
+    execution_logs JSON[],
+    worker_hostname TEXT NOT NULL DEFAULT '',
+    cancel BOOLEAN NOT NULL DEFAULT FALSE,
+    event_id INTEGER NOT NULL REFERENCES insight_series_alert_events(id) ON DELETE CASCADE,
+    action_id INTEGER NOT NULL REFERENCES insight_series_alert_actions(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS insight_series_alert_jobs_state_idx ON insight_series_alert_jobs(state);
+
+COMMENT ON TABLE insight_series_alert_rules IS 'Alert rules evaluated against an insight series every time a new point is recorded.';
+COMMENT ON COLUMN insight_series_alert_rules.lookback IS 'The number of previous recordings the rule compares the latest value against.';
+COMMENT ON TABLE insight_series_alert_actions IS 'The notifications sent when an alert rule fires.';
+COMMENT ON TABLE insight_series_alert_events IS 'The history of alerts fired for an insight series.';
+COMMENT ON TABLE insight_series_alert_jobs IS 'Delivery jobs for fired alerts, one per alert action.';
@@ -0,0 +1,3 @@
+ALTER TABLE lsif_configuration_policies ADD COLUMN IF NOT EXISTS path_patterns TEXT[];
+
+COMMENT ON COLUMN lsif_configuration_policies.path_patterns IS 'The set of path prefixes or glob patterns of directories within matching repositories to which this configuration policy applies. If null, the policy applies to the entire repository.';
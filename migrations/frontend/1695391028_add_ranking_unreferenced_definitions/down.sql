@@ -0,0 +1,7 @@
+ALTER TABLE codeintel_ranking_progress DROP COLUMN IF EXISTS dead_code_report_completed_at;
+
+DROP INDEX IF EXISTS codeintel_ranking_path_counts_inputs_graph_key_definition_id;
+
+DROP TABLE IF EXISTS codeintel_ranking_unreferenced_definitions_processed;
+
+DROP TABLE IF EXISTS codeintel_ranking_unreferenced_definitions;
@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS codeintel_ranking_unreferenced_definitions (
+    id BIGSERIAL PRIMARY KEY,
+    graph_key TEXT NOT NULL,
+    repository_id INTEGER NOT NULL,
+    upload_id INTEGER NOT NULL,
+    document_path TEXT NOT NULL,
+    symbol_name TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS codeintel_ranking_unreferenced_definitions_graph_key_id ON codeintel_ranking_unreferenced_definitions(graph_key, id);
+
+COMMENT ON TABLE codeintel_ranking_unreferenced_definitions IS 'Definitions of exported symbols that have no references from any visible upload, as determined by a completed ranking graph.';
+
+CREATE TABLE IF NOT EXISTS codeintel_ranking_unreferenced_definitions_processed (
+    id BIGSERIAL PRIMARY KEY,
+    graph_key TEXT NOT NULL,
+    exported_upload_id INTEGER NOT NULL,
+    CONSTRAINT codeintel_ranking_unreferenced_definitions_processed_fk FOREIGN KEY (exported_upload_id) REFERENCES codeintel_ranking_exports(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS codeintel_ranking_unreferenced_definitions_processed_graph_key ON codeintel_ranking_unreferenced_definitions_processed(graph_key, exported_upload_id);
+
+CREATE INDEX IF NOT EXISTS codeintel_ranking_path_counts_inputs_graph_key_definition_id ON codeintel_ranking_path_counts_inputs(graph_key, definition_id);
+
+ALTER TABLE codeintel_ranking_progress ADD COLUMN IF NOT EXISTS dead_code_report_completed_at TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1,21 @@
+ALTER TABLE batch_changes ADD COLUMN IF NOT EXISTS auto_rebase BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS changeset_rebases (
+    id BIGSERIAL PRIMARY KEY,
+    changeset_id INTEGER NOT NULL REFERENCES changesets(id) ON DELETE CASCADE DEFERRABLE,
+    batch_change_id INTEGER NOT NULL REFERENCES batch_changes(id) ON DELETE CASCADE DEFERRABLE,
+    previous_changeset_spec_id INTEGER NOT NULL,
+    batch_spec_workspace_id INTEGER REFERENCES batch_spec_workspaces(id) ON DELETE SET NULL DEFERRABLE,
+    changeset_spec_id INTEGER REFERENCES changeset_specs(id) ON DELETE SET NULL DEFERRABLE,
+    base_rev TEXT NOT NULL DEFAULT '',
+    state TEXT NOT NULL DEFAULT 'queued',
+    failure_message TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS changeset_rebases_changeset_id_previous_changeset_spec_id ON changeset_rebases(changeset_id, previous_changeset_spec_id);
+CREATE INDEX IF NOT EXISTS changeset_rebases_state ON changeset_rebases(state);
+
+COMMENT ON COLUMN batch_changes.auto_rebase IS 'Whether changesets that conflict with their base branch are automatically re-executed against the new base commit and force-pushed.';
+COMMENT ON TABLE changeset_rebases IS 'History of automatic re-executions of changesets whose base branch moved and caused a merge conflict.';
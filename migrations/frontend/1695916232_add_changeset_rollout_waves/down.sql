@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS changeset_rollout_waves;
+
+ALTER TABLE batch_changes DROP COLUMN IF EXISTS rollout_pause_reason;
+ALTER TABLE batch_changes DROP COLUMN IF EXISTS rollout_paused;
+ALTER TABLE batch_changes DROP COLUMN IF EXISTS rollout_wave;
@@ -0,0 +1,19 @@
+ALTER TABLE batch_changes ADD COLUMN IF NOT EXISTS rollout_wave INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE batch_changes ADD COLUMN IF NOT EXISTS rollout_paused BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE batch_changes ADD COLUMN IF NOT EXISTS rollout_pause_reason TEXT;
+
+CREATE TABLE IF NOT EXISTS changeset_rollout_waves (
+    changeset_id INTEGER PRIMARY KEY REFERENCES changesets(id) ON DELETE CASCADE DEFERRABLE,
+    batch_change_id INTEGER NOT NULL REFERENCES batch_changes(id) ON DELETE CASCADE DEFERRABLE,
+    wave INTEGER NOT NULL,
+    held BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS changeset_rollout_waves_batch_change_id_wave ON changeset_rollout_waves(batch_change_id, wave);
+
+COMMENT ON COLUMN batch_changes.rollout_wave IS 'The index of the rollout wave whose changesets are currently being published.';
+COMMENT ON COLUMN batch_changes.rollout_paused IS 'Whether the rollout of the batch change is paused, in which case no further changesets are published.';
+COMMENT ON TABLE changeset_rollout_waves IS 'The rollout wave each changeset of a batch change with a rollout policy is assigned to.';
+COMMENT ON COLUMN changeset_rollout_waves.held IS 'Whether the reconciler held back publishing the changeset because its wave has not been reached yet.';
@@ -0,0 +1,4 @@
+ALTER TABLE batch_specs DROP COLUMN IF EXISTS batch_spec_template_version_id;
+
+DROP TABLE IF EXISTS batch_spec_template_versions;
+DROP TABLE IF EXISTS batch_spec_templates;
@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS batch_spec_templates (
+    id BIGSERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    namespace_user_id INTEGER REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+    namespace_org_id INTEGER REFERENCES orgs(id) ON DELETE CASCADE DEFERRABLE,
+    creator_id INTEGER REFERENCES users(id) ON DELETE SET NULL DEFERRABLE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT batch_spec_templates_has_at_most_one_namespace CHECK (namespace_user_id IS NULL OR namespace_org_id IS NULL)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS batch_spec_templates_unique_user_id ON batch_spec_templates(name, namespace_user_id) WHERE namespace_user_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS batch_spec_templates_unique_org_id ON batch_spec_templates(name, namespace_org_id) WHERE namespace_org_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS batch_spec_templates_unique_global ON batch_spec_templates(name) WHERE namespace_user_id IS NULL AND namespace_org_id IS NULL;
+
+CREATE TABLE IF NOT EXISTS batch_spec_template_versions (
+    id BIGSERIAL PRIMARY KEY,
+    template_id BIGINT NOT NULL REFERENCES batch_spec_templates(id) ON DELETE CASCADE DEFERRABLE,
+    version INTEGER NOT NULL,
+    raw_template TEXT NOT NULL,
+    creator_id INTEGER REFERENCES users(id) ON DELETE SET NULL DEFERRABLE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS batch_spec_template_versions_template_id_version ON batch_spec_template_versions(template_id, version);
+
+ALTER TABLE batch_specs ADD COLUMN IF NOT EXISTS batch_spec_template_version_id BIGINT REFERENCES batch_spec_template_versions(id) ON DELETE SET NULL DEFERRABLE;
+
+COMMENT ON TABLE batch_spec_templates IS 'Batch spec templates that can be instantiated with inputs to create batch specs. Templates without a namespace are global.';
+COMMENT ON TABLE batch_spec_template_versions IS 'The immutable versions of a batch spec template.';
+COMMENT ON COLUMN batch_specs.batch_spec_template_version_id IS 'The version of the batch spec template the batch spec was instantiated from, if any.';
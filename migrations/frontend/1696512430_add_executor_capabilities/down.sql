@@ -0,0 +1,4 @@
+ALTER TABLE executor_heartbeats DROP COLUMN IF EXISTS labels;
+ALTER TABLE executor_heartbeats DROP COLUMN IF EXISTS num_cpus;
+ALTER TABLE executor_heartbeats DROP COLUMN IF EXISTS memory;
+ALTER TABLE executor_heartbeats DROP COLUMN IF EXISTS disk_space;
@@ -0,0 +1,9 @@
+ALTER TABLE executor_heartbeats ADD COLUMN IF NOT EXISTS labels TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE executor_heartbeats ADD COLUMN IF NOT EXISTS num_cpus INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE executor_heartbeats ADD COLUMN IF NOT EXISTS memory TEXT NOT NULL DEFAULT '';
+ALTER TABLE executor_heartbeats ADD COLUMN IF NOT EXISTS disk_space TEXT NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN executor_heartbeats.labels IS 'The labels advertised by the executor. Jobs requiring labels are only handed out to executors advertising all of them.';
+COMMENT ON COLUMN executor_heartbeats.num_cpus IS 'The number of CPUs available to each job run by the executor.';
+COMMENT ON COLUMN executor_heartbeats.memory IS 'The amount of memory available to each job run by the executor.';
+COMMENT ON COLUMN executor_heartbeats.disk_space IS 'The amount of disk space available to each job run by the executor.';
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS executor_job_artifacts (
+    id SERIAL PRIMARY KEY,
+    queue TEXT NOT NULL,
+    job_id INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    object_key TEXT NOT NULL,
+    size BIGINT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS executor_job_artifacts_queue_job_id_name ON executor_job_artifacts(queue, job_id, name);
+CREATE INDEX IF NOT EXISTS executor_job_artifacts_expires_at ON executor_job_artifacts(expires_at);
+
+COMMENT ON TABLE executor_job_artifacts IS 'The artifacts uploaded by executors for the jobs they ran. The content of each artifact is stored in the upload store under object_key.';
+COMMENT ON COLUMN executor_job_artifacts.name IS 'The path of the artifact relative to the repository directory of the job.';
+COMMENT ON COLUMN executor_job_artifacts.expires_at IS 'The time after which the artifact is deleted, derived from the artifact retention of the site configuration.';
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS workerutil_job_dependencies (
+    dependent_table TEXT NOT NULL,
+    dependent_id INTEGER NOT NULL,
+    prerequisite_table TEXT NOT NULL,
+    prerequisite_id INTEGER NOT NULL,
+    prerequisite_state TEXT NOT NULL DEFAULT 'queued',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (dependent_table, dependent_id, prerequisite_table, prerequisite_id)
+);
+
+CREATE INDEX IF NOT EXISTS workerutil_job_dependencies_prerequisite ON workerutil_job_dependencies(prerequisite_table, prerequisite_id);
+
+COMMENT ON TABLE workerutil_job_dependencies IS 'Dependencies between the records of dbworker stores that track dependencies. A record is only dequeued once all of its prerequisites have completed.';
+COMMENT ON COLUMN workerutil_job_dependencies.prerequisite_state IS 'The state of the prerequisite, recorded by its store when the prerequisite is completed, failed or canceled, or deleted if the prerequisite no longer exists.';
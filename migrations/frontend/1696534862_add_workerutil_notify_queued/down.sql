@@ -0,0 +1 @@
+DROP FUNCTION IF EXISTS workerutil_notify_queued();
@@ -0,0 +1,8 @@
+CREATE OR REPLACE FUNCTION workerutil_notify_queued() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$ BEGIN
+    PERFORM pg_notify(TG_ARGV[0], TG_TABLE_NAME);
+    RETURN NULL;
+END $$;
+
+COMMENT ON FUNCTION workerutil_notify_queued() IS 'Notifies the channel given as trigger argument, with the table name as payload, so that workers listening on the channel dequeue newly inserted records.';
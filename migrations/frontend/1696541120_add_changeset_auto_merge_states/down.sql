@@ -0,0 +1 @@
+DROP TABLE IF EXISTS changeset_auto_merge_states;
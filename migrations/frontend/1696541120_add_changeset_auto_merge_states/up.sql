@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS changeset_auto_merge_states (
+    changeset_id INTEGER PRIMARY KEY REFERENCES changesets(id) ON DELETE CASCADE DEFERRABLE,
+    reason TEXT NOT NULL DEFAULT '',
+    backoff_seconds INTEGER NOT NULL DEFAULT 0,
+    retry_after TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE changeset_auto_merge_states IS 'Why the auto-merger has not merged an open changeset yet.';
+COMMENT ON COLUMN changeset_auto_merge_states.reason IS 'Why the changeset has not been merged yet, in the most recent evaluation of the auto-merge policy of its batch change.';
+COMMENT ON COLUMN changeset_auto_merge_states.backoff_seconds IS 'How long the auto-merger backs off after the code host last reported the changeset as not mergeable. Doubles with each consecutive failure.';
+COMMENT ON COLUMN changeset_auto_merge_states.retry_after IS 'The auto-merger makes no further merge attempts before this time.';
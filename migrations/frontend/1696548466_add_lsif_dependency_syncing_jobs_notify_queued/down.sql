@@ -0,0 +1 @@
+DROP TRIGGER IF EXISTS lsif_dependency_syncing_jobs_notify_queued ON lsif_dependency_syncing_jobs;
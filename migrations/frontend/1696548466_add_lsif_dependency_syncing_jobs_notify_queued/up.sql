@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS lsif_dependency_syncing_jobs_notify_queued ON lsif_dependency_syncing_jobs;
+
+CREATE TRIGGER lsif_dependency_syncing_jobs_notify_queued AFTER INSERT ON lsif_dependency_syncing_jobs
+FOR EACH STATEMENT EXECUTE FUNCTION workerutil_notify_queued('codeintel_dependency_syncing');
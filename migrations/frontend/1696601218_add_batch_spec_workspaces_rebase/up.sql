@@ -0,0 +1,3 @@
+ALTER TABLE batch_spec_workspaces ADD COLUMN IF NOT EXISTS rebase BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN batch_spec_workspaces.rebase IS 'Whether the workspace re-executes a changeset against a new base commit. Such workspaces are not part of the execution of their batch spec.';
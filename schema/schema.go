@@ -132,6 +132,7 @@ type AuthProviders struct {
 	Bitbucketcloud *BitbucketCloudAuthProvider
 	Builtin        *BuiltinAuthProvider
 	Gerrit         *GerritAuthProvider
+	Gitea          *GiteaAuthProvider
 	Github         *GitHubAuthProvider
 	Gitlab         *GitLabAuthProvider
 	HttpHeader     *HTTPHeaderAuthProvider
@@ -152,6 +153,9 @@ func (v AuthProviders) MarshalJSON() ([]byte, error) {
 	if v.Gerrit != nil {
 		return json.Marshal(v.Gerrit)
 	}
+	if v.Gitea != nil {
+		return json.Marshal(v.Gitea)
+	}
 	if v.Github != nil {
 		return json.Marshal(v.Github)
 	}
@@ -185,6 +189,8 @@ func (v *AuthProviders) UnmarshalJSON(data []byte) error {
 		return json.Unmarshal(data, &v.Builtin)
 	case "gerrit":
 		return json.Unmarshal(data, &v.Gerrit)
+	case "gitea":
+		return json.Unmarshal(data, &v.Gitea)
 	case "github":
 		return json.Unmarshal(data, &v.Github)
 	case "gitlab":
@@ -196,7 +202,19 @@ func (v *AuthProviders) UnmarshalJSON(data []byte) error {
 	case "saml":
 		return json.Unmarshal(data, &v.Saml)
 	}
-	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"azureDevOps", "bitbucketcloud", "builtin", "gerrit", "github", "gitlab", "http-header", "openidconnect", "saml"})
+	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"azureDevOps", "bitbucketcloud", "builtin", "gerrit", "gitea", "github", "gitlab", "http-header", "openidconnect", "saml"})
+}
+
+// AutoMergePolicy description: A policy describing when changesets created by this batch change should be merged automatically. Changesets are only merged once all conditions in the policy are met. If omitted, changesets are never merged automatically.
+type AutoMergePolicy struct {
+	// OnlyInRolloutWindows description: Whether changesets may only be merged while a rollout window configured in the `batchChanges.rolloutWindows` site setting is open.
+	OnlyInRolloutWindows bool `json:"onlyInRolloutWindows,omitempty"`
+	// RequirePassingChecks description: Whether all checks on the changeset must have passed before it is merged. Defaults to true.
+	RequirePassingChecks *bool `json:"requirePassingChecks,omitempty"`
+	// RequiredApprovals description: The minimum number of distinct reviewers whose most recent review approves the changeset.
+	RequiredApprovals int `json:"requiredApprovals,omitempty"`
+	// Squash description: Whether to squash the commits of the changeset when merging it. Not all code hosts support squash merges.
+	Squash bool `json:"squash,omitempty"`
 }
 
 // AzureDevOpsAuthProvider description: Azure auth provider for dev.azure.com
@@ -252,6 +270,8 @@ type BatchSpec struct {
 	ChangesetTemplate *ChangesetTemplate `json:"changesetTemplate,omitempty"`
 	// Description description: The description of the batch change.
 	Description string `json:"description,omitempty"`
+	// Executor description: The capabilities an executor must have to run the workspaces of this batch spec when it is executed server-side. Workspaces are only handed out to executors that advertise all of the labels and allocate at least the requested resources to each job. Ignored when the batch spec is executed with src-cli.
+	Executor *ExecutorRequirements `json:"executor,omitempty"`
 	// ImportChangesets description: Import existing changesets on code hosts.
 	ImportChangesets []*ImportChangesets `json:"importChangesets,omitempty"`
 	// Name description: The name of the batch change, which is unique among all batch changes in the namespace. A batch change's name is case-preserving.
@@ -266,6 +286,32 @@ type BatchSpec struct {
 	Workspaces []*WorkspaceConfiguration `json:"workspaces,omitempty"`
 }
 
+// BatchSpecTemplate description: A batch spec template, which can be shared in a namespace or globally and instantiated with inputs to create a batch spec.
+type BatchSpecTemplate struct {
+	// Description description: The description of the template.
+	Description string `json:"description,omitempty"`
+	// Name description: The name of the template, which is unique among all templates in the namespace.
+	Name string `json:"name"`
+	// Parameters description: The input parameters of the template. The value of a parameter is referenced in the spec with ${{ inputs.<name> }}.
+	Parameters []*BatchSpecTemplateParameter `json:"parameters,omitempty"`
+	// Spec description: The batch spec YAML the template is instantiated to. References to inputs are replaced with the values of the parameters.
+	Spec string `json:"spec"`
+}
+type BatchSpecTemplateParameter struct {
+	// Default description: The value of the parameter if none is provided.
+	Default string `json:"default,omitempty"`
+	// Description description: The description of the parameter, shown to users instantiating the template.
+	Description string `json:"description,omitempty"`
+	// Name description: The name of the parameter.
+	Name string `json:"name"`
+	// Required description: Whether a value has to be provided for the parameter when the template is instantiated. Parameters with a default are never required.
+	Required bool `json:"required,omitempty"`
+	// Type description: The type of the parameter. A repositoryQuery is a Sourcegraph search query that selects the repositories the batch change runs in. An enum only allows the listed values.
+	Type string `json:"type"`
+	// Values description: The allowed values of an enum parameter.
+	Values []string `json:"values,omitempty"`
+}
+
 // Batches description: The configuration for the batches queue.
 type Batches struct {
 	// Limit description: The maximum number of dequeues allowed within the expiration window.
@@ -521,6 +567,8 @@ type BuiltinAuthProvider struct {
 
 // ChangesetTemplate description: A template describing how to create (and update) changesets with the file changes produced by the command steps.
 type ChangesetTemplate struct {
+	// AutoMerge description: A policy describing when changesets created by this batch change should be merged automatically. Changesets are only merged once all conditions in the policy are met. If omitted, changesets are never merged automatically.
+	AutoMerge *AutoMergePolicy `json:"autoMerge,omitempty"`
 	// Body description: The body (description) of the changeset.
 	Body string `json:"body,omitempty"`
 	// Branch description: The name of the Git branch to create or update on each repository with the changes.
@@ -531,6 +579,8 @@ type ChangesetTemplate struct {
 	Fork bool `json:"fork,omitempty"`
 	// Published description: Whether to publish the changeset. An unpublished changeset can be previewed on Sourcegraph by any person who can view the batch change, but its commit, branch, and pull request aren't created on the code host. A published changeset results in a commit, branch, and pull request being created on the code host. If omitted, the publication state is controlled from the Batch Changes UI.
 	Published any `json:"published,omitempty"`
+	// Rollout description: A staged rollout of the changesets created by this batch change. Changesets are published in waves: the changesets of a wave are only published once the success criteria of the previous wave are met. Changesets that are not part of any wave are published after the last wave. If omitted, all changesets are published at once.
+	Rollout *RolloutPolicy `json:"rollout,omitempty"`
 	// Title description: The title of the changeset.
 	Title string `json:"title"`
 }
@@ -794,6 +844,14 @@ type ExcludedGitLabProject struct {
 	// Pattern description: Regular expression which matches against the name of a GitLab project ("group/name").
 	Pattern string `json:"pattern,omitempty"`
 }
+type ExcludedGiteaRepo struct {
+	// Id description: The ID of a repository (as returned by the API) to exclude from mirroring.
+	Id int `json:"id,omitempty"`
+	// Name description: The name of a repository ("owner/name") to exclude from mirroring.
+	Name string `json:"name,omitempty"`
+	// Pattern description: Regular expression which matches against the name of a repository.
+	Pattern string `json:"pattern,omitempty"`
+}
 type ExcludedGitoliteRepo struct {
 	// Name description: The name of a Gitolite repo ("my-repo") to exclude from mirroring.
 	Name string `json:"name,omitempty"`
@@ -807,6 +865,33 @@ type ExcludedOtherRepo struct {
 	Pattern string `json:"pattern,omitempty"`
 }
 
+type ExecutorJobRequirements struct {
+	// Cpus description: The minimum number of CPUs an executor must allocate to each job.
+	Cpus int `json:"cpus,omitempty"`
+	// DiskSpace description: The minimum amount of disk space an executor must allocate to each job.
+	DiskSpace string `json:"diskSpace,omitempty"`
+	// Labels description: Labels that an executor must advertise in EXECUTOR_LABELS to run the jobs.
+	Labels []string `json:"labels,omitempty"`
+	// Memory description: The minimum amount of memory an executor must allocate to each job.
+	Memory string `json:"memory,omitempty"`
+	// Queue description: The queue whose jobs the requirements apply to.
+	Queue string `json:"queue"`
+	// Repositories description: Glob patterns of the names of the repositories whose jobs the requirements apply to. A `*` does not match across a `/`, so `github.com/sourcegraph/*` matches the repositories of an organization. If omitted, the requirements apply to all jobs of the queue.
+	Repositories []string `json:"repositories,omitempty"`
+}
+
+// ExecutorRequirements description: The capabilities an executor must have to run the workspaces of this batch spec when it is executed server-side. Workspaces are only handed out to executors that advertise all of the labels and allocate at least the requested resources to each job. Ignored when the batch spec is executed with src-cli.
+type ExecutorRequirements struct {
+	// Cpus description: The minimum number of CPUs an executor must allocate to each workspace.
+	Cpus int `json:"cpus,omitempty"`
+	// DiskSpace description: The minimum amount of disk space an executor must allocate to each workspace.
+	DiskSpace string `json:"diskSpace,omitempty"`
+	// Labels description: Labels that an executor must advertise to run the workspaces.
+	Labels []string `json:"labels,omitempty"`
+	// Memory description: The minimum amount of memory an executor must allocate to each workspace.
+	Memory string `json:"memory,omitempty"`
+}
+
 // ExecutorsMultiqueue description: The configuration for multiqueue executors.
 type ExecutorsMultiqueue struct {
 	// DequeueCacheConfig description: The configuration for the dequeue cache of multiqueue executors. Each queue defines a limit of dequeues in the expiration window as well as a weight, indicating how frequently a queue is picked at random. For example, a weight of 4 for batches and 1 for codeintel means out of 5 dequeues, statistically batches will be picked 4 times and codeintel 1 time (unless one of those queues is at its limit).
@@ -1326,6 +1411,73 @@ type GitRecorder struct {
 	Size int `json:"size,omitempty"`
 }
 
+// GiteaAuthProvider description: Configures the Gitea or Forgejo OAuth authentication provider for SSO. In addition to specifying this configuration object, you must also create an OAuth2 application on your instance (under Settings > Applications) with the redirect URI set to the concatenation of your Sourcegraph instance URL and "/.auth/gitea/callback".
+type GiteaAuthProvider struct {
+	// AllowOrgs description: Restricts new logins and signups (if allowSignup is true) to members of these organizations only. Existing sessions won't be invalidated. Leave empty or unset for no organization restrictions.
+	AllowOrgs []string `json:"allowOrgs,omitempty"`
+	// AllowSignup description: Allows new visitors to sign up for accounts via Gitea or Forgejo authentication. If false, users signing in via Gitea or Forgejo must have an existing Sourcegraph account, which will be linked to their Gitea or Forgejo identity after sign-in.
+	AllowSignup *bool `json:"allowSignup,omitempty"`
+	// ClientID description: The Client ID of the OAuth2 application.
+	ClientID string `json:"clientID"`
+	// ClientSecret description: The Client Secret of the OAuth2 application.
+	ClientSecret  string  `json:"clientSecret"`
+	DisplayName   string  `json:"displayName,omitempty"`
+	DisplayPrefix *string `json:"displayPrefix,omitempty"`
+	Hidden        bool    `json:"hidden,omitempty"`
+	Order         int     `json:"order,omitempty"`
+	Type          string  `json:"type"`
+	// Url description: URL of the Gitea or Forgejo instance, such as https://gitea.example.com or https://codeberg.org.
+	Url string `json:"url"`
+}
+
+// GiteaAuthorization description: If non-null, enforces Gitea or Forgejo repository permissions. This requires that there is an item in the [site configuration json](https://docs.sourcegraph.com/admin/config/site_config#auth-providers) `auth.providers` field, of type "gitea" with the same `url` field as specified in this `GiteaConnection`.
+type GiteaAuthorization struct {
+}
+
+// GiteaConnection description: Configuration for a connection to Gitea or Forgejo.
+type GiteaConnection struct {
+	// Authorization description: If non-null, enforces Gitea or Forgejo repository permissions. This requires that there is an item in the [site configuration json](https://docs.sourcegraph.com/admin/config/site_config#auth-providers) `auth.providers` field, of type "gitea" with the same `url` field as specified in this `GiteaConnection`.
+	Authorization *GiteaAuthorization `json:"authorization,omitempty"`
+	// Certificate description: TLS certificate of the Gitea or Forgejo instance. This is only necessary if the certificate is self-signed or signed by an internal CA. To get the certificate run `openssl s_client -connect HOST:443 -showcerts < /dev/null 2> /dev/null | openssl x509 -outform PEM`. To escape the value into a JSON string, you may want to use a tool like https://json-escape-text.now.sh.
+	Certificate string `json:"certificate,omitempty"`
+	// Exclude description: A list of repositories to never mirror from this Gitea or Forgejo instance. Takes precedence over "orgs" and "repos".
+	//
+	// Supports excluding by name ({"name": "myorg/myrepo"}), by ID ({"id": 42}), or by regular expression ({"pattern": "^myorg/.*"}).
+	Exclude []*ExcludedGiteaRepo `json:"exclude,omitempty"`
+	// GitURLType description: The type of Git URLs to use for cloning and fetching Git repositories on this Gitea or Forgejo instance.
+	//
+	// If "http", Sourcegraph will access repositories using Git URLs of the form https://gitea.example.com/myorg/myrepo.git.
+	//
+	// If "ssh", Sourcegraph will access repositories using Git URLs of the form git@gitea.example.com:myorg/myrepo.git. See the documentation for how to provide SSH private keys and known_hosts: https://docs.sourcegraph.com/admin/repo/auth#repositories-that-need-http-s-or-ssh-authentication.
+	GitURLType string `json:"gitURLType,omitempty"`
+	// Orgs description: An array of organization names identifying organizations whose repositories should be mirrored on Sourcegraph.
+	Orgs []string `json:"orgs,omitempty"`
+	// RateLimit description: Rate limit applied when making API requests to Gitea or Forgejo.
+	RateLimit *GiteaRateLimit `json:"rateLimit,omitempty"`
+	// Repos description: An array of repository "owner/name" strings specifying which repositories to mirror on Sourcegraph.
+	Repos []string `json:"repos,omitempty"`
+	// RepositoryPathPattern description: The pattern used to generate the corresponding Sourcegraph repository name for a Gitea or Forgejo repository.
+	//
+	//  - "{host}" is replaced with the instance URL's host (such as gitea.example.com), and "{nameWithOwner}" is replaced with the repository's "owner/name" (such as "myorg/myrepo").
+	//
+	// For example, if your instance is https://gitea.example.com and your Sourcegraph is https://src.example.com, then a repositoryPathPattern of "{host}/{nameWithOwner}" would mean that a repository at https://gitea.example.com/myorg/myrepo is available on Sourcegraph at https://src.example.com/gitea.example.com/myorg/myrepo.
+	//
+	// It is important that the Sourcegraph repository name generated with this pattern be unique to this code host. If different code hosts generate repository names that collide, Sourcegraph's behavior is undefined.
+	RepositoryPathPattern string `json:"repositoryPathPattern,omitempty"`
+	// Token description: An access token for the Gitea or Forgejo instance. It needs the `read:repository` and `read:organization` scopes to sync repositories, the `read:user` scope to sync permissions, and the `write:repository` scope to create pull requests with batch changes.
+	Token string `json:"token"`
+	// Url description: URL of a Gitea or Forgejo instance, such as https://gitea.example.com or https://codeberg.org.
+	Url string `json:"url"`
+}
+
+// GiteaRateLimit description: Rate limit applied when making API requests to Gitea or Forgejo.
+type GiteaRateLimit struct {
+	// Enabled description: true if rate limiting is enabled.
+	Enabled bool `json:"enabled"`
+	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally, the burst limit is set to 500, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 500 requests immediately, provided that the complexity cost of each request is 1.
+	RequestsPerHour float64 `json:"requestsPerHour"`
+}
+
 // Github description: GitHub configuration, both for queries and receiving release webhooks.
 type Github struct {
 	// Repository description: The repository to get the latest version of.
@@ -1978,6 +2130,42 @@ type RestartStep struct {
 	Value string `json:"value"`
 }
 
+// Rewrite description: A search and replace that is applied to the files in the workspace without running a container. Steps using rewrite cannot set run, container, files or mount, and cannot be combined with container steps.
+type Rewrite struct {
+	// Language description: The language of the files to rewrite. Only files of this language are rewritten, and it selects the structural matcher. If not set, all files are rewritten and the matcher is inferred from the file extension.
+	Language string `json:"language,omitempty"`
+	// Match description: The pattern to match. A structural search pattern by default, or a regular expression if patternType is regexp.
+	Match string `json:"match"`
+	// PatternType description: The type of the match pattern.
+	PatternType string `json:"patternType,omitempty"`
+	// Replace description: The replacement for every match. Holes like :[arg] in structural patterns and groups like $1 in regular expressions can be referenced.
+	Replace string `json:"replace,omitempty"`
+}
+
+// RolloutPolicy description: A staged rollout of the changesets created by this batch change. Changesets are published in waves: the changesets of a wave are only published once the success criteria of the previous wave are met. Changesets that are not part of any wave are published after the last wave. If omitted, all changesets are published at once.
+type RolloutPolicy struct {
+	// PauseOnFailure description: Whether the rollout is paused when a changeset of the current wave fails to publish, has failing checks or is closed. A paused rollout has to be resumed manually.
+	PauseOnFailure bool `json:"pauseOnFailure,omitempty"`
+	// Waves description: The waves in which changesets are published, in order.
+	Waves []*RolloutWave `json:"waves"`
+}
+
+// RolloutSuccessCriteria description: The criteria the changesets of this wave have to meet before the next wave is published. If omitted, the next wave is published as soon as all changesets of this wave are published.
+type RolloutSuccessCriteria struct {
+	// MergedPercentage description: The minimum percentage of published changesets of this wave that have to be merged.
+	MergedPercentage int `json:"mergedPercentage,omitempty"`
+	// NoFailingChecks description: Whether the checks of all open changesets of this wave have to pass.
+	NoFailingChecks bool `json:"noFailingChecks,omitempty"`
+}
+type RolloutWave struct {
+	// Percentage description: The percentage of all changesets of the batch change that are published once this wave is reached, including those of previous waves.
+	Percentage int `json:"percentage,omitempty"`
+	// Repositories description: Glob patterns of the names of the repositories whose changesets are part of this wave.
+	Repositories []string `json:"repositories,omitempty"`
+	// SuccessCriteria description: The criteria the changesets of this wave have to meet before the next wave is published. If omitted, the next wave is published as soon as all changesets of this wave are published.
+	SuccessCriteria *RolloutSuccessCriteria `json:"successCriteria,omitempty"`
+}
+
 // RubyPackagesConnection description: Configuration for a connection to Ruby packages
 type RubyPackagesConnection struct {
 	// Dependencies description: An array of strings specifying Ruby packages to mirror in Sourcegraph.
@@ -2519,6 +2707,8 @@ type SiteConfiguration struct {
 	CodeIntelAutoIndexingIndexerMap map[string]string `json:"codeIntelAutoIndexing.indexerMap,omitempty"`
 	// CodeIntelAutoIndexingPolicyRepositoryMatchLimit description: The maximum number of repositories to which a single auto-indexing policy can apply. Default is -1, which is unlimited.
 	CodeIntelAutoIndexingPolicyRepositoryMatchLimit *int `json:"codeIntelAutoIndexing.policyRepositoryMatchLimit,omitempty"`
+	// CodeIntelRankingDeadCodeReportExcludedRepositories description: Glob patterns of repository names whose definitions are left out of the unreferenced definitions (dead code) report, for example `github.com/myorg/generated-*`.
+	CodeIntelRankingDeadCodeReportExcludedRepositories []string `json:"codeIntelRanking.deadCodeReportExcludedRepositories,omitempty"`
 	// CodeIntelRankingDocumentReferenceCountsCronExpression description: A cron expression indicating when to run the document reference counts graph reduction job.
 	CodeIntelRankingDocumentReferenceCountsCronExpression *string `json:"codeIntelRanking.documentReferenceCountsCronExpression,omitempty"`
 	// CodeIntelRankingDocumentReferenceCountsDerivativeGraphKeyPrefix description: An arbitrary identifier used to group calculated rankings from SCIP data (excluding the SCIP export).
@@ -2569,12 +2759,16 @@ type SiteConfiguration struct {
 	EncryptionKeys *EncryptionKeys `json:"encryption.keys,omitempty"`
 	// ExecutorsAccessToken description: The shared secret between Sourcegraph and executors. The value must contain at least 20 characters.
 	ExecutorsAccessToken string `json:"executors.accessToken,omitempty"`
+	// ExecutorsArtifactRetentionDays description: The number of days the artifacts uploaded by executor jobs are kept before they are deleted.
+	ExecutorsArtifactRetentionDays int `json:"executors.artifactRetentionDays,omitempty"`
 	// ExecutorsBatcheshelperImage description: The image to use for batch changes in executors. Use this value to pull from a custom image registry.
 	ExecutorsBatcheshelperImage string `json:"executors.batcheshelperImage,omitempty"`
 	// ExecutorsBatcheshelperImageTag description: The tag to use for the batcheshelper image in executors. Use this value to use a custom tag. Sourcegraph by default uses the best match, so use this setting only if you really need to overwrite it and make sure to keep it updated.
 	ExecutorsBatcheshelperImageTag string `json:"executors.batcheshelperImageTag,omitempty"`
 	// ExecutorsFrontendURL description: The URL where Sourcegraph executors can reach the Sourcegraph instance. If not set, defaults to externalURL. URLs with a path (other than `/`) are not allowed. For Docker executors, the special hostname `host.docker.internal` can be used to refer to the Docker container's host.
 	ExecutorsFrontendURL string `json:"executors.frontendURL,omitempty"`
+	// ExecutorsJobRequirements description: Requirements that an executor must satisfy to be handed out matching jobs. Each rule applies to the jobs of its queue whose repository matches one of its repository patterns. The requirements of all matching rules are combined with those declared by the job itself, for example in the `executor` property of a batch spec.
+	ExecutorsJobRequirements []*ExecutorJobRequirements `json:"executors.jobRequirements,omitempty"`
 	// ExecutorsLsifGoImage description: The tag to use for the lsif-go image in executors. Use this value to use a custom tag. Sourcegraph by default uses the best match, so use this setting only if you really need to overwrite it and make sure to keep it updated.
 	ExecutorsLsifGoImage string `json:"executors.lsifGoImage,omitempty"`
 	// ExecutorsMultiqueue description: The configuration for multiqueue executors.
@@ -2636,6 +2830,14 @@ type SiteConfiguration struct {
 	InsightsQueryWorkerRateLimit *float64 `json:"insights.query.worker.rateLimit,omitempty"`
 	// InsightsQueryWorkerRateLimitBurst description: The allowed burst rate for the Code Insights queries per second rate limiter.
 	InsightsQueryWorkerRateLimitBurst int `json:"insights.query.worker.rateLimitBurst,omitempty"`
+	// InsightsScriptMaxCPUSeconds description: The maximum number of seconds of CPU time a Lua-scripted code insight series may use for a single repository and commit. Scripts run in a separate process that is stopped once it exceeds the limit.
+	InsightsScriptMaxCPUSeconds int `json:"insights.script.maxCPUSeconds,omitempty"`
+	// InsightsScriptMaxMemoryBytes description: The maximum number of bytes of memory a Lua-scripted code insight series may use for a single repository and commit. Scripts run in a separate process that is stopped once it exceeds the limit.
+	InsightsScriptMaxMemoryBytes int `json:"insights.script.maxMemoryBytes,omitempty"`
+	// InsightsScriptMaxReadBytes description: The maximum number of bytes of file contents a Lua-scripted code insight series may read for a single repository and commit.
+	InsightsScriptMaxReadBytes int `json:"insights.script.maxReadBytes,omitempty"`
+	// InsightsScriptTimeoutSeconds description: The maximum number of seconds a Lua-scripted code insight series may run for a single repository and commit, including the time spent reading files and running searches.
+	InsightsScriptTimeoutSeconds int `json:"insights.script.timeoutSeconds,omitempty"`
 	// LicenseKey description: The license key associated with a Sourcegraph product subscription, which is necessary to activate Sourcegraph Enterprise functionality. To obtain this value, contact Sourcegraph to purchase a subscription. To escape the value into a JSON string, you may want to use a tool like https://json-escape-text.now.sh.
 	LicenseKey string `json:"licenseKey,omitempty"`
 	// Log description: Configuration for logging and alerting, including to external services.
@@ -2903,7 +3105,7 @@ type SrcCliVersionCache struct {
 // Step description: A command to run (as part of a sequence) in a repository branch to produce the required changes.
 type Step struct {
 	// Container description: The Docker image used to launch the Docker container in which the shell command is run.
-	Container string `json:"container"`
+	Container string `json:"container,omitempty"`
 	// Env description: Environment variables to set in the step environment.
 	Env any `json:"env,omitempty"`
 	// Files description: Files that should be mounted into or be created inside the Docker container.
@@ -2914,8 +3116,10 @@ type Step struct {
 	Mount []*Mount `json:"mount,omitempty"`
 	// Outputs description: Output variables of this step that can be referenced in the changesetTemplate or other steps via outputs.<name-of-output>
 	Outputs map[string]OutputVariable `json:"outputs,omitempty"`
+	// Rewrite description: A search and replace that is applied to the files in the workspace without running a container. Steps using rewrite cannot set run, container, files or mount, and cannot be combined with container steps.
+	Rewrite *Rewrite `json:"rewrite,omitempty"`
 	// Run description: The shell command to run in the container. It can also be a multi-line shell script. The working directory is the root directory of the repository checkout.
-	Run string `json:"run"`
+	Run string `json:"run,omitempty"`
 }
 type SubRepoPermissions struct {
 	// Enabled description: Enables sub-repo permission checking
@@ -17,6 +17,11 @@ var AzureDevOpsSchemaJSON string
 //go:embed batch_spec.schema.json
 var BatchSpecSchemaJSON string
 
+// BatchSpecTemplateSchemaJSON is the content of the file "batch_spec_template.schema.json".
+//
+//go:embed batch_spec_template.schema.json
+var BatchSpecTemplateSchemaJSON string
+
 // BitbucketCloudSchemaJSON is the content of the file "bitbucket_cloud.schema.json".
 //
 //go:embed bitbucket_cloud.schema.json
@@ -37,6 +42,11 @@ var ChangesetSpecSchemaJSON string
 //go:embed gerrit.schema.json
 var GerritSchemaJSON string
 
+// GiteaSchemaJSON is the content of the file "gitea.schema.json".
+//
+//go:embed gitea.schema.json
+var GiteaSchemaJSON string
+
 // GitHubSchemaJSON is the content of the file "github.schema.json".
 //
 //go:embed github.schema.json